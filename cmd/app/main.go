@@ -12,10 +12,13 @@ import (
 
 	"room-reservation-api/internal/config"
 	"room-reservation-api/internal/database"
+	"room-reservation-api/internal/logging"
 	"room-reservation-api/internal/server"
+
+	"gorm.io/gorm"
 )
 
-func gracefulShutdown(apiServer *http.Server, done chan bool) {
+func gracefulShutdown(apiServer *server.Server, timeout time.Duration, done chan bool) {
 	// Create context that listens for the interrupt signal from the OS
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -26,8 +29,8 @@ func gracefulShutdown(apiServer *http.Server, done chan bool) {
 	log.Println("🛑 Shutting down gracefully, press Ctrl+C again to force")
 	stop() // Allow Ctrl+C to force shutdown
 
-	// Give the server 10 seconds to finish current requests
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// Requests, WebSocket clients and running jobs share one deadline
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	if err := apiServer.Shutdown(ctx); err != nil {
@@ -46,9 +49,10 @@ func main() {
 		logLevel = slog.LevelDebug
 	}
 
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	// Records logged with a request context carry its request ID
+	logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: logLevel,
-	}))
+	})))
 
 	// Set as default logger
 	slog.SetDefault(logger)
@@ -68,7 +72,13 @@ func main() {
 	)
 
 	// Initialize database connection
-	db, err := database.Connect(cfg.DatabaseURL)
+	pool := database.PoolConfig{
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		ConnMaxIdleTime: cfg.DBConnMaxIdleTime,
+	}
+	db, err := database.Connect(cfg.DatabaseURL, pool)
 	if err != nil {
 		logger.Error("❌ Failed to connect to database", "error", err)
 		os.Exit(1)
@@ -76,6 +86,16 @@ func main() {
 
 	logger.Info("✅ Database connected successfully")
 
+	// Lists and counts move to the read replica when one is configured
+	var replica *gorm.DB
+	if cfg.DatabaseReplicaURL != "" {
+		replica, err = database.ConnectReplica(cfg.DatabaseReplicaURL, pool)
+		if err != nil {
+			logger.Error("❌ Failed to connect to read replica", "error", err)
+			os.Exit(1)
+		}
+	}
+
 	// Seed database with initial data (admin user + sample spaces)
 	if err := database.SeedDatabase(db); err != nil {
 		logger.Warn("⚠️  Failed to seed database", "error", err)
@@ -89,7 +109,7 @@ func main() {
 	}
 
 	// Initialize server with all dependencies
-	serverInstance := server.New(cfg, logger, db)
+	serverInstance := server.New(cfg, logger, db, replica)
 
 	logger.Info("🎯 Server initialized successfully")
 	logger.Info("📊 PFE Demo Features Available:",
@@ -120,7 +140,7 @@ func main() {
 	done := make(chan bool, 1)
 
 	// Run graceful shutdown in a separate goroutine
-	go gracefulShutdown(serverInstance.GetHTTPServer(), done)
+	go gracefulShutdown(serverInstance, cfg.ShutdownTimeout, done)
 
 	// Start the server
 	if err := serverInstance.Start(); err != nil && err != http.ErrServerClosed {
@@ -143,6 +163,11 @@ func main() {
 	} else {
 		logger.Info("✅ Database connection closed")
 	}
+	if replica != nil {
+		if err := database.CloseConnection(replica); err != nil {
+			logger.Error("❌ Failed to close read replica connection", "error", err)
+		}
+	}
 
 	logger.Info("🎯 Room Reservation API shutdown complete - PFE Demo End")
 }
@@ -0,0 +1,111 @@
+// roomctl is the operations tool for the Room Reservation API
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"room-reservation-api/internal/database"
+)
+
+const usage = `Usage: roomctl <command> [flags]
+
+Commands:
+  clone-to-staging   Copy production data into a staging database with personal data scrambled
+
+Run "roomctl <command> -h" for the flags of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	switch os.Args[1] {
+	case "clone-to-staging":
+		err = cloneToStaging(ctx, os.Args[2:], logger)
+	case "-h", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		logger.Error("❌ "+os.Args[1]+" failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+// cloneToStaging copies the source database into the target with personal data scrambled
+func cloneToStaging(ctx context.Context, args []string, logger *slog.Logger) error {
+	flags := flag.NewFlagSet("clone-to-staging", flag.ExitOnError)
+	sourceURL := flags.String("source", os.Getenv("DATABASE_URL"), "Production database URL (read only)")
+	targetURL := flags.String("target", os.Getenv("STAGING_DATABASE_URL"), "Staging database URL, its data is replaced")
+	password := flags.String("password", "staging-password", "Password every cloned user can sign in with")
+	salt := flags.String("salt", time.Now().UTC().Format("2006-01-02"), "Seed for the fake identities, reuse it to get the same ones")
+	batchSize := flags.Int("batch-size", 500, "Rows per insert")
+	skipMigrate := flags.Bool("skip-migrate", false, "Use the target schema as it is instead of migrating it first")
+	confirm := flags.Bool("yes", false, "Confirm that all data in the target database may be replaced")
+	flags.Parse(args)
+
+	switch {
+	case *sourceURL == "" || *targetURL == "":
+		return errors.New("both -source and -target database URLs are required")
+	case *sourceURL == *targetURL:
+		return errors.New("source and target are the same database")
+	case !*confirm:
+		return errors.New("this replaces all data in the target database, rerun with -yes to continue")
+	}
+
+	source, err := database.ConnectWithoutMigrations(*sourceURL)
+	if err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	defer database.CloseConnection(source)
+
+	target, err := database.ConnectWithoutMigrations(*targetURL)
+	if err != nil {
+		return fmt.Errorf("target: %w", err)
+	}
+	defer database.CloseConnection(target)
+
+	if !*skipMigrate {
+		logger.Info("Migrating staging schema")
+		if err := database.Migrate(target); err != nil {
+			return fmt.Errorf("failed to migrate target: %w", err)
+		}
+	}
+
+	started := time.Now()
+	results, err := database.CloneToStaging(ctx, source, target, database.CloneOptions{
+		BatchSize:       *batchSize,
+		StagingPassword: *password,
+		Salt:            *salt,
+	}, logger)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, result := range results {
+		total += result.Rows
+	}
+	logger.Info("✅ Staging clone complete", "tables", len(results), "rows", total, "duration", time.Since(started).Round(time.Second))
+	return nil
+}
@@ -0,0 +1,241 @@
+// internal/breaker/breaker.go
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned without calling the integration while its breaker is open
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the state of a circuit breaker
+type State string
+
+const (
+	// StateClosed lets calls through and counts consecutive failures
+	StateClosed State = "closed"
+	// StateOpen rejects calls until the open timeout has passed
+	StateOpen State = "open"
+	// StateHalfOpen lets one trial call through to decide whether to close again
+	StateHalfOpen State = "half_open"
+)
+
+// Settings configures a circuit breaker
+type Settings struct {
+	FailureThreshold int           // Consecutive failures that open the breaker
+	OpenTimeout      time.Duration // Time the breaker stays open before a trial call
+	CallTimeout      time.Duration // Longest a single call may take, 0 leaves it to the call
+}
+
+// Status reports the state of a breaker
+type Status struct {
+	Name      string     `json:"name"`
+	State     State      `json:"state"`
+	Failures  int        `json:"failures"`
+	OpenedAt  *time.Time `json:"opened_at,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+// Breaker stops calling an integration that keeps failing or timing out, so callers
+// fail fast instead of waiting on it
+type Breaker struct {
+	name     string
+	settings Settings
+	logger   *slog.Logger
+
+	mu        sync.Mutex
+	state     State
+	failures  int
+	openedAt  time.Time
+	trial     bool // A half-open trial call is in flight
+	lastError string
+}
+
+// Execute runs fn unless the breaker is open. fn receives a context bounded by the call
+// timeout; a call that overruns it is abandoned and counted as a failure.
+func (b *Breaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	_, err := Do(ctx, b, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// Do is Execute for calls that return a value. The value is returned along with the
+// error of a failed call, and is the zero value when the call was rejected or abandoned.
+func Do[T any](ctx context.Context, b *Breaker, fn func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	if err := b.allow(); err != nil {
+		return zero, err
+	}
+
+	if b.settings.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.settings.CallTimeout)
+		defer cancel()
+	}
+
+	type outcome struct {
+		value T
+		err   error
+	}
+
+	// Run in the background so calls that ignore the context cannot block the caller
+	done := make(chan outcome, 1)
+	go func() {
+		value, err := fn(ctx)
+		done <- outcome{value: value, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		b.record(result.err)
+		return result.value, result.err
+	case <-ctx.Done():
+		err := fmt.Errorf("%s did not respond in time: %w", b.name, ctx.Err())
+		b.record(err)
+		return zero, err
+	}
+}
+
+// Status reports the current state of the breaker
+func (b *Breaker) Status() Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := Status{
+		Name:      b.name,
+		State:     b.currentState(time.Now()),
+		Failures:  b.failures,
+		LastError: b.lastError,
+	}
+	if status.State != StateClosed {
+		openedAt := b.openedAt
+		status.OpenedAt = &openedAt
+	}
+	return status
+}
+
+// allow decides whether a call may go through
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState(time.Now()) {
+	case StateOpen:
+		return fmt.Errorf("%s: %w", b.name, ErrOpen)
+	case StateHalfOpen:
+		if b.trial {
+			return fmt.Errorf("%s: %w", b.name, ErrOpen)
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a call
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasTrial := b.trial
+	b.trial = false
+
+	if err == nil {
+		if b.state != StateClosed {
+			b.logger.Info("Circuit breaker closed", "breaker", b.name)
+		}
+		b.state = StateClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	b.lastError = err.Error()
+	if wasTrial || b.failures >= b.settings.FailureThreshold {
+		if b.state == StateClosed || wasTrial {
+			b.logger.Warn("Circuit breaker opened", "breaker", b.name, "failures", b.failures, "error", err)
+		}
+		b.state = StateOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// currentState moves an open breaker to half-open once its timeout has passed
+func (b *Breaker) currentState(now time.Time) State {
+	if b.state == StateOpen && now.Sub(b.openedAt) >= b.settings.OpenTimeout {
+		b.state = StateHalfOpen
+	}
+	return b.state
+}
+
+// Registry keeps the breakers of all integrations so their state can be reported
+type Registry struct {
+	defaults Settings
+	logger   *slog.Logger
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+// NewRegistry creates a registry whose breakers use the default settings unless told otherwise
+func NewRegistry(defaults Settings, logger *slog.Logger) *Registry {
+	if defaults.FailureThreshold <= 0 {
+		defaults.FailureThreshold = 5
+	}
+	if defaults.OpenTimeout <= 0 {
+		defaults.OpenTimeout = 30 * time.Second
+	}
+
+	return &Registry{
+		defaults: defaults,
+		logger:   logger,
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+// Get returns the breaker with the given name, creating it with the default settings
+// and the given call timeout on first use
+func (r *Registry) Get(name string, callTimeout time.Duration) *Breaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if b, ok := r.breakers[name]; ok {
+		return b
+	}
+
+	settings := r.defaults
+	settings.CallTimeout = callTimeout
+	b := &Breaker{
+		name:     name,
+		settings: settings,
+		logger:   r.logger,
+		state:    StateClosed,
+	}
+	r.breakers[name] = b
+	return b
+}
+
+// Statuses reports every breaker, sorted by name
+func (r *Registry) Statuses() []Status {
+	r.mu.Lock()
+	breakers := make([]*Breaker, 0, len(r.breakers))
+	for _, b := range r.breakers {
+		breakers = append(breakers, b)
+	}
+	r.mu.Unlock()
+
+	statuses := make([]Status, len(breakers))
+	for i, b := range breakers {
+		statuses[i] = b.Status()
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
@@ -0,0 +1,189 @@
+// internal/database/anonymize.go
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	fakeFirstNames = []string{
+		"Alex", "Sam", "Jordan", "Taylor", "Morgan", "Casey", "Riley", "Jamie", "Avery", "Quinn",
+		"Charlie", "Dakota", "Emerson", "Finley", "Harper", "Kai", "Logan", "Parker", "Reese", "Skyler",
+	}
+	fakeLastNames = []string{
+		"Anderson", "Bennett", "Carter", "Dawson", "Ellis", "Fischer", "Garcia", "Hughes", "Ibrahim", "Jensen",
+		"Keller", "Laurent", "Martin", "Nakamura", "Owens", "Petit", "Rossi", "Silva", "Tanaka", "Weber",
+	}
+	fillerWords = strings.Fields(`lorem ipsum dolor sit amet consectetur adipiscing elit sed do eiusmod
+		tempor incididunt ut labore et dolore magna aliqua enim ad minim veniam quis nostrud exercitation
+		ullamco laboris nisi aliquip ex ea commodo consequat`)
+)
+
+// anonymizer replaces personal data in cloned rows with fake values. Values are derived
+// from row IDs, so the same user gets the same fake identity everywhere it appears.
+type anonymizer struct {
+	salt         string
+	passwordHash string
+	userNames    map[string]string // User ID -> fake full name, filled while users are copied
+}
+
+func newAnonymizer(salt, passwordHash string) *anonymizer {
+	return &anonymizer{
+		salt:         salt,
+		passwordHash: passwordHash,
+		userNames:    make(map[string]string),
+	}
+}
+
+// scrambleRow rewrites the personal columns of a row from the given table in place
+func (a *anonymizer) scrambleRow(table string, row map[string]interface{}) {
+	id := stringValue(row["id"])
+
+	switch table {
+	case "users":
+		first := pick(fakeFirstNames, a.hash("first_name", id))
+		last := pick(fakeLastNames, a.hash("last_name", id))
+		a.userNames[id] = first + " " + last
+
+		row["first_name"] = first
+		row["last_name"] = last
+		row["email"] = fmt.Sprintf("%s.%s.%s@staging.invalid", strings.ToLower(first), strings.ToLower(last), id[:8])
+		row["password_hash"] = a.passwordHash
+		row["profile_picture"] = ""
+		if stringValue(row["phone"]) != "" {
+			row["phone"] = fmt.Sprintf("+1555%07d", a.hash("phone", id)%10000000)
+		}
+
+	case "reservation_guests":
+		row["name"] = pick(fakeFirstNames, a.hash("first_name", id)) + " " + pick(fakeLastNames, a.hash("last_name", id))
+		row["email"] = "guest." + id[:8] + "@staging.invalid"
+		if stringValue(row["company"]) != "" {
+			row["company"] = "Company " + id[:4]
+		}
+
+	case "room_displays":
+		// Production display keys must not unlock staging
+		row["api_key_hash"] = fmt.Sprintf("%016x%048d", a.hash("api_key", id), 0)
+
+	case "occupancy_sensors":
+		// Production sensor keys must not unlock staging
+		row["api_key_hash"] = fmt.Sprintf("%016x%048d", a.hash("api_key", id), 0)
+
+	case "integration_keys":
+		// Production reception keys must not unlock staging
+		row["key_hash"] = fmt.Sprintf("%016x%048d", a.hash("integration_key", id), 0)
+
+	case "visitor_events":
+		// Snapshots hold guest and host names, emails and the meeting title
+		row["visitor"] = "{}"
+
+	case "widget_tokens":
+		// Production widget tokens must not unlock staging
+		row["token_hash"] = fmt.Sprintf("%016x%048d", a.hash("widget_token", id), 0)
+
+	case "reservations":
+		// Bookings made through the widget name the person who asked
+		for _, column := range []string{"requester_name", "requester_email"} {
+			if stringValue(row[column]) != "" {
+				row[column] = ""
+			}
+		}
+
+	case "webhook_subscriptions":
+		// Staging must not push events to production integrations
+		row["url"] = "https://webhooks.staging.invalid/" + id[:8]
+		row["secret"] = fmt.Sprintf("%016x", a.hash("webhook_secret", id))
+
+	case "webhook_deliveries":
+		row["payload"] = "{}"
+
+	case "bulk_cancellations":
+		// Reports list user emails and meeting titles
+		row["results"] = "[]"
+		a.fill(row, "reason", id)
+
+	case "questionnaire_submissions":
+		// Free-text answers can hold health details
+		row["answers"] = "{}"
+
+	case "messages":
+		if name, ok := a.userNames[stringValue(row["sender_id"])]; ok {
+			row["sender_name"] = name
+		}
+		a.fill(row, "content", id)
+
+	case "conversations":
+		if row["title"] != nil {
+			row["title"] = "Conversation " + id[:8]
+		}
+
+	case "message_attachments":
+		row["file_name"] = "attachment-" + id[:8] + filepath.Ext(stringValue(row["file_name"]))
+
+	case "message_reports":
+		a.fill(row, "message_content", id)
+		a.fill(row, "details", id)
+		a.fill(row, "moderator_note", id)
+
+	case "chat_bans":
+		a.fill(row, "reason", id)
+
+	case "notifications":
+		// Notification texts quote names and message excerpts
+		a.fill(row, "message", id)
+	}
+}
+
+// fill replaces a non-empty text column with filler text of the same length
+func (a *anonymizer) fill(row map[string]interface{}, column, id string) {
+	text := stringValue(row[column])
+	if text == "" {
+		return
+	}
+
+	length := len([]rune(text))
+	var b strings.Builder
+	for i := int(a.hash(column, id) % uint64(len(fillerWords))); b.Len() < length; i++ {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(fillerWords[i%len(fillerWords)])
+	}
+	row[column] = b.String()[:length]
+}
+
+func (a *anonymizer) hash(field, id string) uint64 {
+	sum := sha256.Sum256([]byte(a.salt + ":" + field + ":" + id))
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+func pick(values []string, hash uint64) string {
+	return values[hash%uint64(len(values))]
+}
+
+// stringValue normalizes a scanned column value to a string
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		if len(v) == 16 {
+			if id, err := uuid.FromBytes(v); err == nil {
+				return id.String()
+			}
+		}
+		return string(v)
+	case [16]byte:
+		return uuid.UUID(v).String()
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
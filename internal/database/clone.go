@@ -0,0 +1,176 @@
+// internal/database/clone.go
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// Postgres accepts at most 65535 bind parameters per statement
+const maxInsertParameters = 60000
+
+// CloneOptions configures a clone into a staging database
+type CloneOptions struct {
+	BatchSize       int    // Rows per insert
+	StagingPassword string // Every cloned user can sign in with this password
+	Salt            string // Changes the generated fake identities between clones
+}
+
+// CloneTableResult reports how many rows were copied from a table
+type CloneTableResult struct {
+	Table string
+	Rows  int64
+}
+
+// CloneToStaging replaces the contents of target with the rows of source, scrambling names,
+// emails, phone numbers and chat content on the way. IDs are kept as they are, so every
+// reference between tables still resolves. The target schema must already exist.
+func CloneToStaging(ctx context.Context, source, target *gorm.DB, opts CloneOptions, logger *slog.Logger) ([]CloneTableResult, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+	if opts.StagingPassword == "" {
+		return nil, errors.New("a staging password is required")
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(opts.StagingPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash staging password: %w", err)
+	}
+	anon := newAnonymizer(opts.Salt, string(passwordHash))
+
+	tables, err := schemaTables(target)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CloneTableResult, 0, len(tables))
+	err = target.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Skip foreign key triggers so self-referencing rows can arrive in any order
+		if err := tx.Exec("SET LOCAL session_replication_role = replica").Error; err != nil {
+			return fmt.Errorf("failed to disable foreign key checks on target (superuser required): %w", err)
+		}
+
+		if err := tx.Exec("TRUNCATE TABLE " + strings.Join(tables, ", ") + " CASCADE").Error; err != nil {
+			return fmt.Errorf("failed to clear target tables: %w", err)
+		}
+
+		for _, table := range tables {
+			copied, err := cloneTable(ctx, source, tx, table, opts.BatchSize, anon)
+			if err != nil {
+				return fmt.Errorf("failed to clone %s: %w", table, err)
+			}
+			results = append(results, CloneTableResult{Table: table, Rows: copied})
+			logger.Info("Cloned table", "table", table, "rows", copied)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// cloneTable streams one table from source into target in batches
+func cloneTable(ctx context.Context, source, target *gorm.DB, table string, batchSize int, anon *anonymizer) (int64, error) {
+	columns, err := sharedColumns(source, target, table)
+	if err != nil {
+		return 0, err
+	}
+	if len(columns) == 0 {
+		return 0, nil // Table not in source yet
+	}
+
+	if limit := maxInsertParameters / len(columns); batchSize > limit {
+		batchSize = limit
+	}
+
+	rows, err := source.WithContext(ctx).Table(table).Select(columns).Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var copied int64
+	batch := make([]map[string]interface{}, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := target.Table(table).Create(&batch).Error; err != nil {
+			return err
+		}
+		copied += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+
+	for rows.Next() {
+		row := make(map[string]interface{}, len(columns))
+		if err := source.ScanRows(rows, &row); err != nil {
+			return copied, err
+		}
+		anon.scrambleRow(table, row)
+
+		batch = append(batch, row)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return copied, err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return copied, err
+	}
+
+	return copied, flush()
+}
+
+// schemaTables returns the table names of all models, parents first
+func schemaTables(db *gorm.DB) ([]string, error) {
+	tables := make([]string, 0)
+	for _, model := range schemaModels() {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse %T: %w", model, err)
+		}
+		tables = append(tables, stmt.Schema.Table)
+	}
+	return tables, nil
+}
+
+// sharedColumns lists the writable target columns that also exist in source.
+// Generated columns such as search vectors are recomputed by the target.
+func sharedColumns(source, target *gorm.DB, table string) ([]string, error) {
+	const query = `SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = ? AND is_generated = 'NEVER'
+		ORDER BY ordinal_position`
+
+	var sourceColumns, targetColumns []string
+	if err := source.Raw(query, table).Scan(&sourceColumns).Error; err != nil {
+		return nil, fmt.Errorf("failed to read source columns: %w", err)
+	}
+	if err := target.Raw(query, table).Scan(&targetColumns).Error; err != nil {
+		return nil, fmt.Errorf("failed to read target columns: %w", err)
+	}
+
+	inSource := make(map[string]bool, len(sourceColumns))
+	for _, column := range sourceColumns {
+		inSource[column] = true
+	}
+
+	columns := make([]string, 0, len(targetColumns))
+	for _, column := range targetColumns {
+		if inSource[column] {
+			columns = append(columns, column)
+		}
+	}
+	return columns, nil
+}
@@ -12,10 +12,63 @@ import (
 	"room-reservation-api/internal/models"
 )
 
+// PoolConfig sizes a connection pool
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration // 0 keeps idle connections until their lifetime ends
+}
+
+// DefaultPool is the pool of connections opened without configuration, such as by tools
+var DefaultPool = PoolConfig{
+	MaxOpenConns:    100,
+	MaxIdleConns:    10,
+	ConnMaxLifetime: time.Hour,
+}
+
 // Connect establishes a connection to the database
-func Connect(databaseURL string) (*gorm.DB, error) {
+func Connect(databaseURL string, pool PoolConfig) (*gorm.DB, error) {
+	db, err := open(databaseURL, logger.Info, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	// Auto-migrate models
+	if err := autoMigrate(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	slog.Info("Database connected and migrated successfully")
+	return db, nil
+}
+
+// ConnectWithoutMigrations opens a quiet connection that leaves the schema untouched,
+// for tools that must not alter the database they read from
+func ConnectWithoutMigrations(databaseURL string) (*gorm.DB, error) {
+	return open(databaseURL, logger.Warn, DefaultPool)
+}
+
+// ConnectReplica opens a read replica. Its schema follows the primary, so it is not migrated.
+func ConnectReplica(replicaURL string, pool PoolConfig) (*gorm.DB, error) {
+	db, err := open(replicaURL, logger.Info, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Read replica connected successfully")
+	return db, nil
+}
+
+// Migrate brings the schema up to date with the models
+func Migrate(db *gorm.DB) error {
+	return autoMigrate(db)
+}
+
+// open connects to the database and configures the connection pool
+func open(databaseURL string, logLevel logger.LogLevel, pool PoolConfig) (*gorm.DB, error) {
 	// Configure GORM logger
-	gormLogger := logger.Default.LogMode(logger.Info)
+	gormLogger := logger.Default.LogMode(logLevel)
 
 	// Open database connection
 	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
@@ -35,48 +88,168 @@ func Connect(databaseURL string) (*gorm.DB, error) {
 	}
 
 	// Configure connection pool
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
 
 	// Test the connection
 	if err := sqlDB.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// Auto-migrate models
-	if err := autoMigrate(db); err != nil {
-		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	return db, nil
+}
+
+// PoolStatus reports how busy a connection pool is
+type PoolStatus struct {
+	MaxOpen      int     `json:"max_open"` // 0 when unlimited
+	Open         int     `json:"open"`
+	InUse        int     `json:"in_use"`
+	Idle         int     `json:"idle"`
+	WaitCount    int64   `json:"wait_count"` // Queries that waited for a free connection
+	WaitDuration string  `json:"wait_duration"`
+	Saturation   float64 `json:"saturation"` // Share of MaxOpen in use
+	Saturated    bool    `json:"saturated"`  // Every connection is in use; new queries wait
+}
+
+// Pool reads the connection pool statistics of a database
+func Pool(db *gorm.DB) (PoolStatus, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return PoolStatus{}, fmt.Errorf("failed to get underlying database: %w", err)
 	}
 
-	slog.Info("Database connected and migrated successfully")
-	return db, nil
+	stats := sqlDB.Stats()
+	status := PoolStatus{
+		MaxOpen:      stats.MaxOpenConnections,
+		Open:         stats.OpenConnections,
+		InUse:        stats.InUse,
+		Idle:         stats.Idle,
+		WaitCount:    stats.WaitCount,
+		WaitDuration: stats.WaitDuration.String(),
+	}
+	if stats.MaxOpenConnections > 0 {
+		status.Saturation = float64(stats.InUse) / float64(stats.MaxOpenConnections)
+		status.Saturated = stats.InUse >= stats.MaxOpenConnections
+	}
+	return status, nil
 }
 
-// autoMigrate runs automatic migrations for all models
-func autoMigrate(db *gorm.DB) error {
-	models := []interface{}{
+// schemaModels lists every persisted model, parents before the models referencing them
+func schemaModels() []interface{} {
+	return []interface{}{
 		// Core models
 		&models.User{},
 		&models.Space{},
 		&models.Reservation{},
-		// &models.Notification{},
+		&models.ReservationResource{},
+		&models.ReservationTask{},
+		&models.ChecklistTemplate{},
+		&models.ReservationCoOrganizer{},
+		&models.ApprovalRule{},
+		&models.VIPBlock{},
+		&models.VIPBlockViolation{},
+		&models.BookingConflict{},
+		&models.Notification{},
+		&models.RoomSwapSuggestion{},
+		&models.ReservationGuest{},
+		&models.RoomDisplay{},
+		&models.WidgetToken{},
+		&models.OutboxEvent{},
+		&models.ReportRun{},
+		&models.FavoriteSpace{},
+		&models.BookingTemplate{},
+		&models.SavedSearch{},
+		&models.CheckInQuestionnaire{},
+		&models.QuestionnaireSubmission{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.DeadLetter{},
+		&models.ActivityEvent{},
+		&models.UserPreference{},
+		&models.BulkCancellation{},
+		&models.OccupancySensor{},
+		&models.OccupancySample{},
+		&models.FloorConsolidation{},
+		&models.LowUsageAlert{},
+		&models.PlacementPolicy{},
+		&models.CostCenter{},
+		&models.SpacePhoto{},
+		&models.ReservationFeedback{},
+		&models.ReservationSession{},
+		&models.ReservationPreemption{},
+		&models.BuildingClosure{},
+		&models.EmergencyBroadcast{},
+		&models.EmergencyRecipient{},
+		&models.IntegrationKey{},
+		&models.VisitorEvent{},
+		&models.ServiceItem{},
+		&models.ServiceRequest{},
+		&models.CheckInPolicy{},
+		&models.TeamBlock{},
+		&models.TeamBlockException{},
+		&models.CalendarRegion{},
+		&models.CalendarDay{},
+		&models.ApprovalActionToken{},
+		&models.BroadcastChannel{},
+		&models.BroadcastPost{},
+		&models.BroadcastRecipient{},
+		&models.FloorPlan{},
+		&models.Neighborhood{},
+		&models.FloorPlanSeat{},
 
 		// Chat models - order matters due to foreign key relationships
 		&models.Conversation{},
 		&models.ConversationParticipant{},
 		&models.Message{},
 		&models.MessageAttachment{},
+		&models.ChatUpload{},
 		&models.MessageReadReceipt{},
+		&models.MessageDeliveryReceipt{},
+		&models.DeviceKeyBundle{},
+		&models.UserBlock{},
 		&models.SupportAgent{},
+		&models.MessageReport{},
+		&models.ChatBan{},
+		&models.CannedResponse{},
+		&models.AgentAssignment{},
+		&models.ComplianceExport{},
+		&models.QuotaOverride{},
+		&models.BookingStrike{},
 	}
+}
 
-	for _, model := range models {
+// SchemaStatus reports how many model tables the schema should have and which are missing,
+// which shows whether the running build's migrations were applied
+func SchemaStatus(db *gorm.DB) (expected int, missing []string) {
+	schema := schemaModels()
+	for _, model := range schema {
+		if !db.Migrator().HasTable(model) {
+			stmt := &gorm.Statement{DB: db}
+			if err := stmt.Parse(model); err == nil {
+				missing = append(missing, stmt.Schema.Table)
+			} else {
+				missing = append(missing, fmt.Sprintf("%T", model))
+			}
+		}
+	}
+	return len(schema), missing
+}
+
+// autoMigrate runs automatic migrations for all models
+func autoMigrate(db *gorm.DB) error {
+	for _, model := range schemaModels() {
 		if err := db.AutoMigrate(model); err != nil {
 			return fmt.Errorf("failed to migrate %T: %w", model, err)
 		}
 	}
 
+	// Create full-text search columns
+	if err := createSearchColumns(db); err != nil {
+		return fmt.Errorf("failed to create search columns: %w", err)
+	}
+
 	// Create indexes
 	if err := createIndexes(db); err != nil {
 		return fmt.Errorf("failed to create indexes: %w", err)
@@ -90,6 +263,29 @@ func autoMigrate(db *gorm.DB) error {
 	return nil
 }
 
+// createSearchColumns adds generated tsvector columns used by full-text search.
+// They are kept out of the models so GORM never tries to write them.
+func createSearchColumns(db *gorm.DB) error {
+	columns := []string{
+		// Title matches rank above description matches
+		`ALTER TABLE reservations ADD COLUMN IF NOT EXISTS search_vector tsvector
+			GENERATED ALWAYS AS (
+				setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+				setweight(to_tsvector('english', coalesce(description, '')), 'B')
+			) STORED`,
+		`ALTER TABLE messages ADD COLUMN IF NOT EXISTS search_vector tsvector
+			GENERATED ALWAYS AS (to_tsvector('english', coalesce(content, ''))) STORED`,
+	}
+
+	for _, column := range columns {
+		if err := db.Exec(column).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // createIndexes creates additional database indexes for better performance
 func createIndexes(db *gorm.DB) error {
 	indexes := []string{
@@ -113,6 +309,11 @@ func createIndexes(db *gorm.DB) error {
 		"CREATE INDEX IF NOT EXISTS idx_reservations_start_time ON reservations(start_time)",
 		"CREATE INDEX IF NOT EXISTS idx_reservations_end_time ON reservations(end_time)",
 		"CREATE INDEX IF NOT EXISTS idx_reservations_parent ON reservations(recurrence_parent_id)",
+		"CREATE INDEX IF NOT EXISTS idx_reservations_search ON reservations USING GIN(search_vector)",
+
+		// VIP block indexes
+		"CREATE INDEX IF NOT EXISTS idx_vip_blocks_space_active ON vip_blocks(space_id, is_active)",
+		"CREATE INDEX IF NOT EXISTS idx_vip_block_violations_time ON vip_block_violations(start_time, end_time)",
 
 		// Notification indexes
 		"CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications(user_id)",
@@ -121,13 +322,26 @@ func createIndexes(db *gorm.DB) error {
 		"CREATE INDEX IF NOT EXISTS idx_notifications_scheduled ON notifications(scheduled_at)",
 		"CREATE INDEX IF NOT EXISTS idx_notifications_created ON notifications(created_at)",
 
+		// Occupancy indexes
+		"CREATE INDEX IF NOT EXISTS idx_occupancy_samples_space_time ON occupancy_samples(space_id, recorded_at)",
+		"CREATE INDEX IF NOT EXISTS idx_occupancy_samples_recorded ON occupancy_samples(recorded_at)",
+
+		// Floor consolidation indexes
+		"CREATE INDEX IF NOT EXISTS idx_floor_consolidations_dates ON floor_consolidations(building, start_date, end_date)",
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_low_usage_alerts_building_date ON low_usage_alerts(building, date)",
+
+		// Room swap suggestion indexes
+		"CREATE INDEX IF NOT EXISTS idx_room_swap_suggestions_expiry ON room_swap_suggestions(status, expires_at)",
+
 		// Chat indexes - Conversations
 		"CREATE INDEX IF NOT EXISTS idx_conversations_status ON conversations(status)",
 		"CREATE INDEX IF NOT EXISTS idx_conversations_priority ON conversations(priority)",
 		"CREATE INDEX IF NOT EXISTS idx_conversations_assigned_agent ON conversations(assigned_agent_id)",
 		"CREATE INDEX IF NOT EXISTS idx_conversations_last_message_at ON conversations(last_message_at DESC)",
 		"CREATE INDEX IF NOT EXISTS idx_conversations_is_archived ON conversations(is_archived)",
+		"CREATE INDEX IF NOT EXISTS idx_conversations_archived_last_message ON conversations(is_archived, last_message_at DESC)",
 		"CREATE INDEX IF NOT EXISTS idx_conversations_created_at ON conversations(created_at DESC)",
+		"CREATE INDEX IF NOT EXISTS idx_conversations_status_last_message ON conversations(status, last_message_at)",
 
 		// Chat indexes - Conversation Participants
 		"CREATE INDEX IF NOT EXISTS idx_conversation_participants_user_id ON conversation_participants(user_id)",
@@ -142,10 +356,12 @@ func createIndexes(db *gorm.DB) error {
 		"CREATE INDEX IF NOT EXISTS idx_messages_message_type ON messages(message_type)",
 		"CREATE INDEX IF NOT EXISTS idx_messages_sender_type ON messages(sender_type)",
 		"CREATE INDEX IF NOT EXISTS idx_messages_conversation_created ON messages(conversation_id, created_at DESC)",
+		"CREATE INDEX IF NOT EXISTS idx_messages_search ON messages USING GIN(search_vector)",
 
 		// Chat indexes - Message Attachments
 		"CREATE INDEX IF NOT EXISTS idx_message_attachments_message_id ON message_attachments(message_id)",
 		"CREATE INDEX IF NOT EXISTS idx_message_attachments_file_type ON message_attachments(file_type)",
+		"CREATE INDEX IF NOT EXISTS idx_message_attachments_scan_status ON message_attachments(scan_status)",
 
 		// Chat indexes - Message Read Receipts
 		"CREATE INDEX IF NOT EXISTS idx_message_read_receipts_message_id ON message_read_receipts(message_id)",
@@ -157,6 +373,11 @@ func createIndexes(db *gorm.DB) error {
 		"CREATE INDEX IF NOT EXISTS idx_support_agents_department ON support_agents(department)",
 		"CREATE INDEX IF NOT EXISTS idx_support_agents_rating ON support_agents(rating DESC)",
 		"CREATE INDEX IF NOT EXISTS idx_support_agents_last_seen ON support_agents(last_seen_at DESC)",
+
+		// Chat indexes - Moderation
+		"CREATE INDEX IF NOT EXISTS idx_message_reports_status_created ON message_reports(status, created_at)",
+		"CREATE INDEX IF NOT EXISTS idx_message_reports_message_reporter ON message_reports(message_id, reporter_id)",
+		"CREATE INDEX IF NOT EXISTS idx_chat_bans_user_expires ON chat_bans(user_id, expires_at)",
 	}
 
 	for _, index := range indexes {
@@ -175,11 +396,18 @@ func createConstraints(db *gorm.DB) error {
 		"ALTER TABLE spaces ADD CONSTRAINT IF NOT EXISTS chk_space_capacity CHECK (capacity > 0 AND capacity <= 1000)",
 		"ALTER TABLE spaces ADD CONSTRAINT IF NOT EXISTS chk_space_surface CHECK (surface IS NULL OR surface >= 0)",
 		"ALTER TABLE spaces ADD CONSTRAINT IF NOT EXISTS chk_space_prices CHECK (price_per_hour >= 0 AND price_per_day >= 0 AND price_per_month >= 0)",
+		"ALTER TABLE spaces ADD CONSTRAINT IF NOT EXISTS chk_space_cost CHECK (cost_per_hour >= 0)",
 		"ALTER TABLE spaces ADD CONSTRAINT IF NOT EXISTS chk_space_booking_times CHECK (booking_advance_time >= 0 AND max_booking_duration > 0)",
+		"ALTER TABLE spaces ADD CONSTRAINT IF NOT EXISTS chk_space_cancellation_window CHECK (cancellation_window >= 0)",
 
 		// Reservation constraints
 		"ALTER TABLE reservations ADD CONSTRAINT IF NOT EXISTS chk_reservation_time CHECK (end_time > start_time)",
 		"ALTER TABLE reservations ADD CONSTRAINT IF NOT EXISTS chk_reservation_participants CHECK (participant_count > 0)",
+		"ALTER TABLE reservation_guests ADD CONSTRAINT IF NOT EXISTS chk_guest_rsvp_status CHECK (rsvp_status IN ('pending', 'accepted', 'declined'))",
+
+		// VIP block constraints
+		"ALTER TABLE vip_blocks ADD CONSTRAINT IF NOT EXISTS chk_vip_block_day CHECK (day_of_week BETWEEN 0 AND 6)",
+		"ALTER TABLE vip_blocks ADD CONSTRAINT IF NOT EXISTS chk_vip_block_time CHECK (end_time > start_time)",
 
 		// Notification constraints
 		"ALTER TABLE notifications ADD CONSTRAINT IF NOT EXISTS chk_notification_retry CHECK (retry_count >= 0 AND retry_count <= max_retries)",
@@ -200,10 +428,20 @@ func createConstraints(db *gorm.DB) error {
 		// Chat constraints - Message Attachments
 		"ALTER TABLE message_attachments ADD CONSTRAINT IF NOT EXISTS chk_attachment_file_size CHECK (file_size > 0)",
 		"ALTER TABLE message_attachments ADD CONSTRAINT IF NOT EXISTS chk_attachment_file_name CHECK (LENGTH(file_name) > 0)",
+		"ALTER TABLE message_attachments ADD CONSTRAINT IF NOT EXISTS chk_attachment_scan_status CHECK (scan_status IN ('pending', 'clean', 'infected', 'skipped'))",
 
 		// Chat constraints - Support Agents
 		"ALTER TABLE support_agents ADD CONSTRAINT IF NOT EXISTS chk_agent_status CHECK (status IN ('online', 'away', 'offline'))",
 		"ALTER TABLE support_agents ADD CONSTRAINT IF NOT EXISTS chk_agent_rating CHECK (rating >= 0.0 AND rating <= 5.0)",
+
+		// Chat constraints - Moderation
+		"ALTER TABLE message_reports ADD CONSTRAINT IF NOT EXISTS chk_report_reason CHECK (reason IN ('spam', 'harassment', 'inappropriate', 'other'))",
+		"ALTER TABLE message_reports ADD CONSTRAINT IF NOT EXISTS chk_report_status CHECK (status IN ('pending', 'actioned', 'dismissed'))",
+		"ALTER TABLE chat_bans ADD CONSTRAINT IF NOT EXISTS chk_chat_ban_expiry CHECK (expires_at > created_at)",
+
+		// Dead letter constraints
+		"ALTER TABLE dead_letters ADD CONSTRAINT IF NOT EXISTS chk_dead_letter_source CHECK (source IN ('notification', 'webhook', 'job'))",
+		"ALTER TABLE dead_letters ADD CONSTRAINT IF NOT EXISTS chk_dead_letter_status CHECK (status IN ('open', 'replayed', 'discarded'))",
 	}
 
 	for _, constraint := range constraints {
@@ -420,6 +658,49 @@ func SeedDatabase(db *gorm.DB) error {
 		slog.Info("Sample spaces created", "count", len(sampleSpaces))
 	}
 
+	// Seed support bot answers for the most common questions
+	var cannedCount int64
+	db.Model(&models.CannedResponse{}).Count(&cannedCount)
+
+	var admin models.User
+	if cannedCount == 0 && db.Where("role = ?", models.RoleAdmin).First(&admin).Error == nil {
+		cannedResponses := []models.CannedResponse{
+			{
+				Title:    "How to book a space",
+				Shortcut: "/book",
+				Content: "To book a space, open Spaces, pick a room and choose an available time slot, then confirm your reservation. " +
+					"Rooms marked \"requires approval\" are confirmed once an admin approves the request.",
+				Category:  "booking",
+				Keywords:  []string{"book", "reserve", "reservation", "how do i get a room"},
+				AutoReply: true,
+			},
+			{
+				Title:    "Cancellation policy",
+				Shortcut: "/cancel-policy",
+				Content: "You can cancel a pending or confirmed reservation from My Reservations. " +
+					"Changes to the time or room are possible up to 30 minutes before the start.",
+				Category:  "booking",
+				Keywords:  []string{"cancel", "cancellation", "refund", "no-show"},
+				AutoReply: true,
+			},
+			{
+				Title:    "Greeting",
+				Shortcut: "/hello",
+				Content:  "Hi {{customer_name}}, this is {{agent_name}} from support. How can I help you today?",
+				Category: "general",
+			},
+		}
+
+		for _, response := range cannedResponses {
+			response.CreatedByID = admin.ID
+			if err := db.Create(&response).Error; err != nil {
+				slog.Warn("Failed to create canned response", "shortcut", response.Shortcut, "error", err)
+			}
+		}
+
+		slog.Info("Canned responses created", "count", len(cannedResponses))
+	}
+
 	return nil
 }
 
@@ -0,0 +1,84 @@
+// internal/deeplink/deeplink.go
+package deeplink
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Scheme of the links mobile apps register to open themselves
+const Scheme = "app"
+
+// Kind is the screen a link opens
+type Kind string
+
+const (
+	KindReservation  Kind = "reservation"
+	KindConversation Kind = "conversation"
+)
+
+// webPaths are the routes of each screen in the web client
+var webPaths = map[Kind]string{
+	KindReservation:  "/reservations/%s",
+	KindConversation: "/chat/%s",
+}
+
+// Link points at one reservation or conversation, e.g. app://reservation/{id}
+type Link struct {
+	Kind Kind      `json:"kind"`
+	ID   uuid.UUID `json:"id"`
+}
+
+// Reservation links to a reservation
+func Reservation(id uuid.UUID) Link {
+	return Link{Kind: KindReservation, ID: id}
+}
+
+// Conversation links to a chat conversation
+func Conversation(id uuid.UUID) Link {
+	return Link{Kind: KindConversation, ID: id}
+}
+
+// New builds a link from its parts, checking the kind is known
+func New(kind string, id uuid.UUID) (Link, error) {
+	if _, ok := webPaths[Kind(kind)]; !ok {
+		return Link{}, fmt.Errorf("unknown link kind: %s", kind)
+	}
+	return Link{Kind: Kind(kind), ID: id}, nil
+}
+
+// Parse reads an app:// link
+func Parse(value string) (Link, error) {
+	rest, ok := strings.CutPrefix(value, Scheme+"://")
+	if !ok {
+		return Link{}, fmt.Errorf("link must start with %s://", Scheme)
+	}
+
+	kind, rawID, ok := strings.Cut(rest, "/")
+	if !ok {
+		return Link{}, fmt.Errorf("link must look like %s://<kind>/<id>", Scheme)
+	}
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return Link{}, fmt.Errorf("invalid link ID: %v", err)
+	}
+	return New(kind, id)
+}
+
+// String formats the link for apps
+func (l Link) String() string {
+	return fmt.Sprintf("%s://%s/%s", Scheme, l.Kind, l.ID)
+}
+
+// WebURL is the page of the web client showing the linked screen
+func (l Link) WebURL(webAppURL string) string {
+	return strings.TrimRight(webAppURL, "/") + fmt.Sprintf(webPaths[l.Kind], l.ID)
+}
+
+// RedirectURL is the HTTPS form of the link, for channels where app:// links cannot be
+// tapped; the API redirects it to the web client
+func (l Link) RedirectURL(apiBaseURL string) string {
+	return fmt.Sprintf("%s/api/v1/links/%s/%s", strings.TrimRight(apiBaseURL, "/"), l.Kind, l.ID)
+}
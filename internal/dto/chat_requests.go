@@ -2,6 +2,8 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -12,13 +14,25 @@ type CreateConversationRequest struct {
 	Priority       string                 `json:"priority" binding:"omitempty,oneof=low normal high urgent" validate:"omitempty,oneof=low normal high urgent"`
 	InitialMessage *SendMessageRequest    `json:"initial_message,omitempty"`
 	Tags           []string               `json:"tags,omitempty" validate:"omitempty,dive,max=50"`
+	Department     string                 `json:"department,omitempty" binding:"omitempty,max=100" validate:"omitempty,max=100"` // Routes support requests to agents of this department
+	ReservationID  *uuid.UUID             `json:"reservation_id,omitempty"`                                                      // Booking the conversation is about; implies its space
+	SpaceID        *uuid.UUID             `json:"space_id,omitempty"`                                                            // Space the conversation is about
+	Encrypted      bool                   `json:"encrypted,omitempty"`                                                           // End-to-end encrypted direct conversation; both users need registered device keys
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// ReportReservationProblemRequest represents a problem reported from a reservation
+type ReportReservationProblemRequest struct {
+	Description string `json:"description" binding:"required,min=1,max=5000" validate:"required,min=1,max=5000"`
+	Priority    string `json:"priority,omitempty" binding:"omitempty,oneof=low normal high urgent" validate:"omitempty,oneof=low normal high urgent"`
+}
+
 // SendMessageRequest represents the request to send a message
 type SendMessageRequest struct {
 	ConversationID uuid.UUID              `json:"conversation_id" binding:"required" validate:"required"`
-	Content        string                 `json:"content" binding:"required,min=1,max=5000" validate:"required,min=1,max=5000"`
+	Content        string                 `json:"content" binding:"required_without=Ciphertext,max=5000" validate:"required_without=Ciphertext,max=5000"`
+	Ciphertext     string                 `json:"ciphertext,omitempty" binding:"omitempty,max=65536" validate:"omitempty,max=65536"`                                 // Client-encrypted payload, required in encrypted conversations
+	SenderDeviceID string                 `json:"sender_device_id,omitempty" binding:"required_with=Ciphertext,max=100" validate:"required_with=Ciphertext,max=100"` // Device whose keys encrypted the payload
 	Type           string                 `json:"type" binding:"required,oneof=text image file video audio booking_confirmation membership_renewal cancellation payment_reminder system_notification" validate:"required"`
 	Attachments    []AttachmentRequest    `json:"attachments,omitempty" validate:"omitempty,dive"`
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
@@ -42,6 +56,7 @@ type UpdateConversationRequest struct {
 	Tags            *[]string  `json:"tags,omitempty" validate:"omitempty,dive,max=50"`
 	IsArchived      *bool      `json:"is_archived,omitempty"`
 	Title           *string    `json:"title,omitempty" validate:"omitempty,max=255"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"` // updated_at as last read; the update fails with 409 if it changed since
 }
 
 // MarkMessagesReadRequest represents the request to mark messages as read
@@ -49,17 +64,23 @@ type MarkMessagesReadRequest struct {
 	MessageIDs []uuid.UUID `json:"message_ids" binding:"required,min=1" validate:"required,min=1,dive,required"`
 }
 
+// MarkMessagesDeliveredRequest represents the request to mark messages as delivered
+type MarkMessagesDeliveredRequest struct {
+	MessageIDs []uuid.UUID `json:"message_ids" binding:"required,min=1" validate:"required,min=1,dive,required"`
+}
+
 // GetConversationsRequest represents the request to get conversations with filters
 type GetConversationsRequest struct {
-	Status     string `form:"status" validate:"omitempty,oneof=active resolved pending"`
-	Priority   string `form:"priority" validate:"omitempty,oneof=low normal high urgent"`
-	IsArchived *bool  `form:"is_archived"`
-	Tag        string `form:"tag" validate:"omitempty,max=50"`
-	Search     string `form:"search" validate:"omitempty,max=100"`
-	Limit      int    `form:"limit" validate:"omitempty,min=1,max=100"`
-	Offset     int    `form:"offset" validate:"omitempty,min=0"`
-	SortBy     string `form:"sort_by" validate:"omitempty,oneof=created_at updated_at last_message_at"`
-	SortOrder  string `form:"sort_order" validate:"omitempty,oneof=asc desc"`
+	Status     string  `form:"status" validate:"omitempty,oneof=active resolved pending"`
+	Priority   string  `form:"priority" validate:"omitempty,oneof=low normal high urgent"`
+	IsArchived *bool   `form:"is_archived"`
+	Tag        string  `form:"tag" validate:"omitempty,max=50"`
+	Search     string  `form:"search" validate:"omitempty,max=100"`
+	Limit      int     `form:"limit" validate:"omitempty,min=1,max=100"`
+	Offset     int     `form:"offset" validate:"omitempty,min=0"`
+	SortBy     string  `form:"sort_by" validate:"omitempty,oneof=created_at updated_at last_message_at"`
+	SortOrder  string  `form:"sort_order" validate:"omitempty,oneof=asc desc"`
+	Cursor     *string `form:"cursor"` // Cursor-based pagination - next_cursor of the previous page, replaces offset
 }
 
 // GetMessagesRequest represents the request to get messages with pagination
@@ -69,9 +90,34 @@ type GetMessagesRequest struct {
 	Offset         int       `form:"offset" validate:"omitempty,min=0"`
 	Before         *string   `form:"before"` // Cursor-based pagination - timestamp
 	After          *string   `form:"after"`  // Cursor-based pagination - timestamp
+	Cursor         *string   `form:"cursor"` // Cursor-based pagination - next_cursor of the previous page, replaces offset
 	MessageType    string    `form:"message_type" validate:"omitempty,oneof=text image file video audio booking_confirmation membership_renewal cancellation payment_reminder system_notification"`
 }
 
+// MuteConversationRequest represents the request to mute a conversation's notifications
+type MuteConversationRequest struct {
+	DurationMinutes int `json:"duration_minutes,omitempty" binding:"omitempty,min=1,max=525600" validate:"omitempty,min=1,max=525600"` // Omit to mute until unmuted
+}
+
+// BlockUserRequest represents the request to block a user from direct messages
+type BlockUserRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required" validate:"required"`
+}
+
+// RegisterDeviceKeysRequest publishes the public key bundle of one of the caller's devices
+type RegisterDeviceKeysRequest struct {
+	IdentityKey           string   `json:"identity_key" binding:"required,max=1024" validate:"required,max=1024"`
+	SignedPreKey          string   `json:"signed_pre_key" binding:"required,max=1024" validate:"required,max=1024"`
+	SignedPreKeySignature string   `json:"signed_pre_key_signature" binding:"required,max=1024" validate:"required,max=1024"`
+	OneTimePreKeys        []string `json:"one_time_pre_keys,omitempty" binding:"omitempty,max=200,dive,required,max=1024" validate:"omitempty,max=200,dive,required,max=1024"` // Replaces the remaining one-time keys
+}
+
+// ChatSyncRequest represents a catch-up request from a client resuming from the background
+type ChatSyncRequest struct {
+	Cursor string `form:"cursor"` // next_cursor of the previous sync; omit on the first sync
+	Limit  int    `form:"limit" validate:"omitempty,min=1,max=500"`
+}
+
 // UpdateMessageRequest represents the request to update/edit a message
 type UpdateMessageRequest struct {
 	Content string `json:"content" binding:"required,min=1,max=5000" validate:"required,min=1,max=5000"`
@@ -118,6 +164,11 @@ type SearchMessagesRequest struct {
 	Offset         int        `form:"offset" validate:"omitempty,min=0"`
 }
 
+// ExportConversationRequest represents the request to export a conversation transcript
+type ExportConversationRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=json pdf" validate:"omitempty,oneof=json pdf"` // Defaults to json
+}
+
 // FileUploadRequest represents the request for file upload (for attachments)
 type FileUploadRequest struct {
 	ConversationID uuid.UUID `form:"conversation_id" binding:"required" validate:"required"`
@@ -138,3 +189,72 @@ type ConversationStatsRequest struct {
 	Department string     `form:"department" validate:"omitempty,max=100"`
 	GroupBy    string     `form:"group_by" validate:"omitempty,oneof=day week month agent department"`
 }
+
+// ReportMessageRequest represents the request to flag a message for moderation
+type ReportMessageRequest struct {
+	Reason  string `json:"reason" binding:"required,oneof=spam harassment inappropriate other" validate:"required,oneof=spam harassment inappropriate other"`
+	Details string `json:"details,omitempty" binding:"omitempty,max=1000" validate:"omitempty,max=1000"`
+}
+
+// GetReportsRequest represents the request to list the moderation queue
+type GetReportsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending actioned dismissed" validate:"omitempty,oneof=pending actioned dismissed"`
+	Limit  int    `form:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" validate:"omitempty,min=0"`
+}
+
+// ModerationActionRequest represents a moderator decision on a report
+type ModerationActionRequest struct {
+	Action           string `json:"action" binding:"required,oneof=dismiss delete_message warn ban" validate:"required,oneof=dismiss delete_message warn ban"`
+	Note             string `json:"note,omitempty" binding:"omitempty,max=1000" validate:"omitempty,max=1000"`
+	BanDurationHours int    `json:"ban_duration_hours,omitempty" binding:"omitempty,min=1,max=720" validate:"omitempty,min=1,max=720"` // Defaults to 24 hours
+}
+
+// CreateCannedResponseRequest represents the request to add a canned response
+type CreateCannedResponseRequest struct {
+	Title     string   `json:"title" binding:"required,max=100" validate:"required,max=100"`
+	Shortcut  string   `json:"shortcut" binding:"required,max=50" validate:"required,max=50"`
+	Content   string   `json:"content" binding:"required,min=1,max=5000" validate:"required,min=1,max=5000"`
+	Category  string   `json:"category,omitempty" binding:"omitempty,max=50" validate:"omitempty,max=50"`
+	Keywords  []string `json:"keywords,omitempty" validate:"omitempty,dive,max=100"`
+	AutoReply bool     `json:"auto_reply"` // Let the support bot send it when keywords match
+}
+
+// UpdateCannedResponseRequest represents the request to update a canned response
+type UpdateCannedResponseRequest struct {
+	Title     *string   `json:"title,omitempty" binding:"omitempty,max=100" validate:"omitempty,max=100"`
+	Shortcut  *string   `json:"shortcut,omitempty" binding:"omitempty,max=50" validate:"omitempty,max=50"`
+	Content   *string   `json:"content,omitempty" binding:"omitempty,min=1,max=5000" validate:"omitempty,min=1,max=5000"`
+	Category  *string   `json:"category,omitempty" binding:"omitempty,max=50" validate:"omitempty,max=50"`
+	Keywords  *[]string `json:"keywords,omitempty" validate:"omitempty,dive,max=100"`
+	AutoReply *bool     `json:"auto_reply,omitempty"`
+	IsActive  *bool     `json:"is_active,omitempty"`
+}
+
+// GetCannedResponsesRequest represents the request to list canned responses
+type GetCannedResponsesRequest struct {
+	Category string `form:"category" validate:"omitempty,max=50"`
+	Search   string `form:"search" validate:"omitempty,max=100"`
+	Limit    int    `form:"limit" validate:"omitempty,min=1,max=100"`
+	Offset   int    `form:"offset" validate:"omitempty,min=0"`
+}
+
+// InsertCannedResponseRequest represents an agent sending a canned response, by ID or shortcut
+type InsertCannedResponseRequest struct {
+	CannedResponseID *uuid.UUID `json:"canned_response_id,omitempty"`
+	Shortcut         string     `json:"shortcut,omitempty" validate:"omitempty,max=50"`
+	ReplyToID        *uuid.UUID `json:"reply_to_id,omitempty"`
+}
+
+// BatchUnarchiveRequest represents an admin restoring archived conversations
+type BatchUnarchiveRequest struct {
+	ConversationIDs []uuid.UUID `json:"conversation_ids" binding:"required,min=1,max=500"`
+}
+
+// ComplianceExportRequest represents an auditor exporting messages for a legal request
+type ComplianceExportRequest struct {
+	CaseReference string     `json:"case_reference" binding:"required,max=100" validate:"required,max=100"` // Legal case or request the export is for
+	Reason        string     `json:"reason,omitempty" binding:"omitempty,max=1000" validate:"omitempty,max=1000"`
+	From          *time.Time `json:"from,omitempty"` // Messages sent at or after, inclusive
+	To            *time.Time `json:"to,omitempty"`   // Messages sent before, exclusive
+}
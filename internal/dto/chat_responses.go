@@ -17,16 +17,39 @@ type ChatMessageResponse struct {
 	Content        string                 `json:"content"`
 	Type           string                 `json:"type"`
 	IsRead         bool                   `json:"is_read"`
+	DeliveryStatus string                 `json:"delivery_status,omitempty"` // sent, delivered or read; only on the caller's own messages
+	Encrypted      bool                   `json:"encrypted,omitempty"`
+	Ciphertext     string                 `json:"ciphertext,omitempty"` // Decrypted by the client; Content is empty
+	SenderDeviceID string                 `json:"sender_device_id,omitempty"`
 	IsEdited       bool                   `json:"is_edited"`
 	EditedAt       *time.Time             `json:"edited_at,omitempty"`
 	Attachments    []AttachmentResponse   `json:"attachments,omitempty"`
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
 	ReplyToID      *uuid.UUID             `json:"reply_to_id,omitempty"`
+	ReplyTo        *ReplyPreview          `json:"reply_to,omitempty"`
+	ReplyCount     int                    `json:"reply_count"`
 	Timestamp      time.Time              `json:"timestamp"`
 	CreatedAt      time.Time              `json:"created_at"`
 	UpdatedAt      time.Time              `json:"updated_at"`
 }
 
+// ReplyPreview is a short excerpt of the message being replied to
+type ReplyPreview struct {
+	ID         uuid.UUID `json:"id"`
+	SenderID   uuid.UUID `json:"sender_id"`
+	SenderName string    `json:"sender_name"`
+	Content    string    `json:"content"`
+	Type       string    `json:"type"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ThreadResponse represents a message and its reply chain
+type ThreadResponse struct {
+	RootMessage  ChatMessageResponse   `json:"root_message"`
+	Replies      []ChatMessageResponse `json:"replies"`
+	TotalReplies int                   `json:"total_replies"`
+}
+
 // AttachmentResponse represents a file attachment in API responses
 type AttachmentResponse struct {
 	ID           uuid.UUID `json:"id"`
@@ -36,6 +59,7 @@ type AttachmentResponse struct {
 	FileType     string    `json:"file_type"`
 	FileURL      string    `json:"file_url"`
 	ThumbnailURL *string   `json:"thumbnail_url,omitempty"`
+	ScanStatus   string    `json:"scan_status"` // pending, clean, infected, skipped
 	CreatedAt    time.Time `json:"created_at"`
 }
 
@@ -72,15 +96,53 @@ type ConversationResponse struct {
 	LastMessageAt   time.Time                         `json:"last_message_at"`
 	UnreadCount     int                               `json:"unread_count"`
 	IsArchived      bool                              `json:"is_archived"`
+	ArchivedAt      *time.Time                        `json:"archived_at,omitempty"`
+	AutoArchived    bool                              `json:"auto_archived"`
 	Tags            []string                          `json:"tags,omitempty"`
 	Priority        string                            `json:"priority"`
 	Status          string                            `json:"status"`
 	AssignedAgentID *uuid.UUID                        `json:"assigned_agent_id,omitempty"`
 	AssignedAgent   *SupportAgentInfo                 `json:"assigned_agent,omitempty"`
+	Department      string                            `json:"department,omitempty"`
+	EscalatedAt     *time.Time                        `json:"escalated_at,omitempty"`
+	ResolvedAt      *time.Time                        `json:"resolved_at,omitempty"`
+	AutoResolved    bool                              `json:"auto_resolved"`
+	ReopenCount     int                               `json:"reopen_count"`
+	ReopenUntil     *time.Time                        `json:"reopen_until,omitempty"` // Deadline for reopening a resolved conversation
+	ReservationID   *uuid.UUID                        `json:"reservation_id,omitempty"`
+	AttendeeChat    bool                              `json:"attendee_chat"` // Group chat of the reservation's attendees
+	Encrypted       bool                              `json:"encrypted"`     // End-to-end encrypted; messages carry ciphertext only
+	IsMuted         bool                              `json:"is_muted"`      // Notifications suppressed for the current user
+	MutedUntil      *time.Time                        `json:"muted_until,omitempty"`
+	SpaceID         *uuid.UUID                        `json:"space_id,omitempty"`
+	Reservation     *LinkedReservationInfo            `json:"reservation,omitempty"` // Booking context for agents
+	Space           *LinkedSpaceInfo                  `json:"space,omitempty"`
 	CreatedAt       time.Time                         `json:"created_at"`
 	UpdatedAt       time.Time                         `json:"updated_at"`
 }
 
+// LinkedReservationInfo represents the booking a conversation is about
+type LinkedReservationInfo struct {
+	ID               uuid.UUID  `json:"id"`
+	Title            string     `json:"title"`
+	Status           string     `json:"status"`
+	StartTime        time.Time  `json:"start_time"`
+	EndTime          time.Time  `json:"end_time"`
+	ParticipantCount int        `json:"participant_count"`
+	CheckInTime      *time.Time `json:"check_in_time,omitempty"`
+	Organizer        UserInfo   `json:"organizer"`
+}
+
+// LinkedSpaceInfo represents the space a conversation is about
+type LinkedSpaceInfo struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Type     string    `json:"type"`
+	Building string    `json:"building"`
+	Floor    int       `json:"floor"`
+	Status   string    `json:"status"`
+}
+
 // SupportAgentInfo represents basic support agent information
 type SupportAgentInfo struct {
 	ID         uuid.UUID `json:"id"`
@@ -98,6 +160,7 @@ type ConversationListResponse struct {
 	UnreadCount   int64                  `json:"unread_count"`
 	HasMore       bool                   `json:"has_more"`
 	NextOffset    *int                   `json:"next_offset,omitempty"`
+	NextCursor    *string                `json:"next_cursor,omitempty"`
 }
 
 // MessageListResponse represents a paginated list of messages
@@ -109,6 +172,49 @@ type MessageListResponse struct {
 	PrevCursor *string               `json:"prev_cursor,omitempty"`
 }
 
+// ChatSyncResponse carries everything a client needs to catch up in one call
+type ChatSyncResponse struct {
+	Conversations []ConversationUnreadCount `json:"conversations"`
+	TotalUnread   int                       `json:"total_unread"`
+	Messages      []ChatMessageResponse     `json:"messages"` // Oldest first
+	NextCursor    string                    `json:"next_cursor"`
+	HasMore       bool                      `json:"has_more"`
+	SyncedAt      time.Time                 `json:"synced_at"`
+}
+
+// ConversationUnreadCount is the unread badge of one conversation
+type ConversationUnreadCount struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	UnreadCount    int       `json:"unread_count"`
+}
+
+// UserBlockResponse represents a user the caller blocked
+type UserBlockResponse struct {
+	UserID    uuid.UUID `json:"user_id"`
+	User      UserInfo  `json:"user"`
+	BlockedAt time.Time `json:"blocked_at"`
+}
+
+// DeviceKeysResponse describes a key bundle the caller registered for one of their devices
+type DeviceKeysResponse struct {
+	DeviceID              string    `json:"device_id"`
+	IdentityKey           string    `json:"identity_key"`
+	SignedPreKey          string    `json:"signed_pre_key"`
+	SignedPreKeySignature string    `json:"signed_pre_key_signature"`
+	OneTimePreKeyCount    int       `json:"one_time_pre_key_count"` // Replenish when low
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// ClaimedKeyBundleResponse is the key material needed to start an encrypted session with a device
+type ClaimedKeyBundleResponse struct {
+	UserID                uuid.UUID `json:"user_id"`
+	DeviceID              string    `json:"device_id"`
+	IdentityKey           string    `json:"identity_key"`
+	SignedPreKey          string    `json:"signed_pre_key"`
+	SignedPreKeySignature string    `json:"signed_pre_key_signature"`
+	OneTimePreKey         string    `json:"one_time_pre_key,omitempty"` // Empty once the device ran out of one-time keys
+}
+
 // ReadReceiptResponse represents a message read receipt
 type ReadReceiptResponse struct {
 	ID        uuid.UUID `json:"id"`
@@ -118,6 +224,25 @@ type ReadReceiptResponse struct {
 	ReadAt    time.Time `json:"read_at"`
 }
 
+// MessageDeliveryResponse summarizes how far a message has progressed for each recipient
+type MessageDeliveryResponse struct {
+	MessageID      uuid.UUID                   `json:"message_id"`
+	Status         string                      `json:"status"`
+	RecipientCount int                         `json:"recipient_count"`
+	DeliveredCount int                         `json:"delivered_count"`
+	ReadCount      int                         `json:"read_count"`
+	Recipients     []RecipientDeliveryResponse `json:"recipients"`
+}
+
+// RecipientDeliveryResponse represents one participant's delivery state for a message
+type RecipientDeliveryResponse struct {
+	UserID      uuid.UUID  `json:"user_id"`
+	User        UserInfo   `json:"user"`
+	Status      string     `json:"status"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+}
+
 // TypingStatusResponse represents typing status information
 type TypingStatusResponse struct {
 	ConversationID uuid.UUID `json:"conversation_id"`
@@ -152,6 +277,9 @@ type ConversationStatsResponse struct {
 	ActiveConversations   int64                    `json:"active_conversations"`
 	ResolvedConversations int64                    `json:"resolved_conversations"`
 	PendingConversations  int64                    `json:"pending_conversations"`
+	AutoResolved          int64                    `json:"auto_resolved_conversations"`
+	ReopenedConversations int64                    `json:"reopened_conversations"`
+	TotalReopens          int64                    `json:"total_reopens"`
 	TotalMessages         int64                    `json:"total_messages"`
 	AverageResponseTime   float64                  `json:"average_response_time_minutes"`
 	ConversationsByDay    []DailyConversationStats `json:"conversations_by_day,omitempty"`
@@ -193,6 +321,47 @@ type MessageSearchResult struct {
 	Score        float64              `json:"score,omitempty"`
 }
 
+// ConversationTranscript represents a full conversation export for compliance requests
+type ConversationTranscript struct {
+	ConversationID uuid.UUID               `json:"conversation_id"`
+	Title          string                  `json:"title,omitempty"`
+	Status         string                  `json:"status"`
+	Priority       string                  `json:"priority"`
+	Tags           []string                `json:"tags,omitempty"`
+	CreatedAt      time.Time               `json:"created_at"`
+	ResolvedAt     *time.Time              `json:"resolved_at,omitempty"`
+	Participants   []TranscriptParticipant `json:"participants"`
+	Messages       []TranscriptMessage     `json:"messages"`
+	MessageCount   int                     `json:"message_count"`
+	Truncated      bool                    `json:"truncated"` // More messages exist than the export limit
+	ExportedAt     time.Time               `json:"exported_at"`
+	ExportedBy     uuid.UUID               `json:"exported_by"`
+}
+
+// TranscriptParticipant represents a conversation participant in a transcript
+type TranscriptParticipant struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email,omitempty"`
+	UserType string    `json:"user_type"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// TranscriptMessage represents a single message in a transcript
+type TranscriptMessage struct {
+	ID          uuid.UUID  `json:"id"`
+	SenderID    uuid.UUID  `json:"sender_id"`
+	SenderName  string     `json:"sender_name"`
+	SenderType  string     `json:"sender_type"`
+	MessageType string     `json:"message_type"`
+	Content     string     `json:"content"`
+	ReplyToID   *uuid.UUID `json:"reply_to_id,omitempty"`
+	Attachments []string   `json:"attachments,omitempty"` // File names
+	IsEdited    bool       `json:"is_edited"`
+	EditedAt    *time.Time `json:"edited_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
 // FileUploadResponse represents the response after file upload
 type FileUploadResponse struct {
 	FileURL      string `json:"file_url"`
@@ -200,6 +369,7 @@ type FileUploadResponse struct {
 	FileName     string `json:"file_name"`
 	FileSize     int64  `json:"file_size"`
 	FileType     string `json:"file_type"`
+	ScanStatus   string `json:"scan_status"` // pending (quarantined), clean, skipped
 }
 
 // OnlineUsersResponse represents currently online users
@@ -233,6 +403,7 @@ type WebSocketMessage struct {
 const (
 	WSEventMessageSent         = "message_sent"
 	WSEventMessageRead         = "message_read"
+	WSEventMessageDelivered    = "message_delivered"
 	WSEventMessageUpdated      = "message_updated"
 	WSEventUserTyping          = "user_typing"
 	WSEventUserStoppedTyping   = "user_stopped_typing"
@@ -246,3 +417,149 @@ const (
 	WSEventConnected           = "connected"
 	WSEventDisconnected        = "disconnected"
 )
+
+// MessageReportResponse represents a reported message in the moderation queue
+type MessageReportResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	MessageID      uuid.UUID  `json:"message_id"`
+	ConversationID uuid.UUID  `json:"conversation_id"`
+	MessageContent string     `json:"message_content"`
+	Reason         string     `json:"reason"`
+	Details        string     `json:"details,omitempty"`
+	Status         string     `json:"status"`
+	Action         string     `json:"action,omitempty"`
+	ModeratorNote  string     `json:"moderator_note,omitempty"`
+	Reporter       UserInfo   `json:"reporter"`
+	ReportedUser   UserInfo   `json:"reported_user"`
+	ResolvedBy     *UserInfo  `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// MessageReportListResponse represents a paginated moderation queue
+type MessageReportListResponse struct {
+	Reports    []MessageReportResponse `json:"reports"`
+	TotalCount int64                   `json:"total_count"`
+	HasMore    bool                    `json:"has_more"`
+}
+
+// ChatBanResponse represents a temporary chat ban
+type ChatBanResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	User       UserInfo   `json:"user"`
+	ReportID   *uuid.UUID `json:"report_id,omitempty"`
+	Reason     string     `json:"reason"`
+	BannedByID uuid.UUID  `json:"banned_by_id"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ChatBanListResponse represents a paginated list of active chat bans
+type ChatBanListResponse struct {
+	Bans       []ChatBanResponse `json:"bans"`
+	TotalCount int64             `json:"total_count"`
+	HasMore    bool              `json:"has_more"`
+}
+
+// CannedResponseResponse represents a canned response
+type CannedResponseResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title"`
+	Shortcut   string    `json:"shortcut"`
+	Content    string    `json:"content"`
+	Category   string    `json:"category,omitempty"`
+	Keywords   []string  `json:"keywords"`
+	AutoReply  bool      `json:"auto_reply"`
+	IsActive   bool      `json:"is_active"`
+	UsageCount int       `json:"usage_count"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// CannedResponseListResponse represents a paginated list of canned responses
+type CannedResponseListResponse struct {
+	CannedResponses []CannedResponseResponse `json:"canned_responses"`
+	TotalCount      int64                    `json:"total_count"`
+	HasMore         bool                     `json:"has_more"`
+}
+
+// BatchUnarchiveResponse represents the outcome of a batch unarchive
+type BatchUnarchiveResponse struct {
+	Requested  int   `json:"requested"`
+	Unarchived int64 `json:"unarchived"` // Conversations that were archived and are now restored
+}
+
+// ComplianceExportBundle is a tamper-evident message export for legal requests. Each entry's
+// hash covers the previous hash and the entry, so removing, reordering or editing a message
+// breaks the chain; the manifest, which carries the head of the chain, is signed by the server.
+type ComplianceExportBundle struct {
+	Manifest  ComplianceManifest `json:"manifest"`
+	Entries   []ComplianceEntry  `json:"entries"`
+	Signature string             `json:"signature"` // Base64 Ed25519 signature of the manifest's JSON encoding
+}
+
+// ComplianceManifest describes a compliance export and pins the head of its hash chain
+type ComplianceManifest struct {
+	ExportID           uuid.UUID  `json:"export_id"`
+	Scope              string     `json:"scope"`
+	ConversationID     *uuid.UUID `json:"conversation_id,omitempty"`
+	SubjectUserID      *uuid.UUID `json:"subject_user_id,omitempty"`
+	CaseReference      string     `json:"case_reference"`
+	Reason             string     `json:"reason,omitempty"`
+	From               *time.Time `json:"from,omitempty"`
+	To                 *time.Time `json:"to,omitempty"`
+	GeneratedAt        time.Time  `json:"generated_at"`
+	GeneratedBy        uuid.UUID  `json:"generated_by"`
+	MessageCount       int        `json:"message_count"`
+	FirstMessageAt     *time.Time `json:"first_message_at,omitempty"`
+	LastMessageAt      *time.Time `json:"last_message_at,omitempty"`
+	HashAlgorithm      string     `json:"hash_algorithm"`
+	GenesisHash        string     `json:"genesis_hash"` // Previous hash of the first entry
+	HeadHash           string     `json:"head_hash"`    // Hash of the last entry, the genesis hash when empty
+	SignatureAlgorithm string     `json:"signature_algorithm"`
+	KeyID              string     `json:"key_id"`
+}
+
+// ComplianceEntry is one message in a compliance export, linked to the previous entry by hash
+type ComplianceEntry struct {
+	Sequence       int                    `json:"sequence"`
+	MessageID      uuid.UUID              `json:"message_id"`
+	ConversationID uuid.UUID              `json:"conversation_id"`
+	SenderID       uuid.UUID              `json:"sender_id"`
+	SenderName     string                 `json:"sender_name"`
+	SenderType     string                 `json:"sender_type"`
+	MessageType    string                 `json:"message_type"`
+	Content        string                 `json:"content"`
+	ReplyToID      *uuid.UUID             `json:"reply_to_id,omitempty"`
+	Attachments    []ComplianceAttachment `json:"attachments,omitempty"`
+	IsEdited       bool                   `json:"is_edited"`
+	EditedAt       *time.Time             `json:"edited_at,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+	PrevHash       string                 `json:"prev_hash"`
+	Hash           string                 `json:"hash"` // SHA-256 of prev_hash and the entry encoded with an empty hash
+}
+
+// ComplianceAttachment describes a file attached to an exported message
+type ComplianceAttachment struct {
+	FileName   string `json:"file_name"`
+	FileType   string `json:"file_type"`
+	FileSize   int64  `json:"file_size"`
+	ScanStatus string `json:"scan_status"`
+}
+
+// ComplianceVerification is the outcome of checking a compliance export bundle
+type ComplianceVerification struct {
+	Valid          bool     `json:"valid"`
+	ChainIntact    bool     `json:"chain_intact"`
+	SignatureValid bool     `json:"signature_valid"`
+	IssuedByServer bool     `json:"issued_by_server"` // The export is on record with the same head hash
+	Problems       []string `json:"problems,omitempty"`
+}
+
+// ComplianceSigningKey is the public key verifying compliance manifests
+type ComplianceSigningKey struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"`
+	PublicKey string `json:"public_key"` // Base64 raw Ed25519 public key
+}
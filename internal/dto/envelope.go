@@ -0,0 +1,128 @@
+// internal/dto/envelope.go
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Media types clients send in Accept to pick a response shape whatever the API version of the path
+const (
+	MediaTypeV1 = "application/vnd.roomreservation.v1+json" // Shape of each handler, as served by /api/v1
+	MediaTypeV2 = "application/vnd.roomreservation.v2+json" // Envelope, as served by /api/v2
+)
+
+// Envelope is the one response shape of API v2: what was asked for in data, everything about
+// it (messages, pagination, request ID) in meta, and what went wrong in errors
+type Envelope struct {
+	Data   interface{}            `json:"data"`
+	Meta   map[string]interface{} `json:"meta,omitempty"`
+	Errors []EnvelopeError        `json:"errors,omitempty"`
+}
+
+// EnvelopeError is one problem with a request; validation failures give one per field
+type EnvelopeError struct {
+	Code    string                 `json:"code"`
+	Title   string                 `json:"title,omitempty"`
+	Message string                 `json:"message,omitempty"`
+	Field   string                 `json:"field,omitempty"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+}
+
+// Keys of the v1 shapes moved from the body to the meta of the envelope
+var envelopeMetaKeys = map[string]bool{
+	"message":    true,
+	"pagination": true,
+	"warnings":   true,
+	"count":      true,
+	"total":      true,
+}
+
+// WrapEnvelope converts a v1 JSON body to the envelope. Error responses become errors;
+// SuccessResponse and paginated bodies give their data and keep the rest as meta; any other
+// body is the data itself.
+func WrapEnvelope(status int, body []byte, requestID string) ([]byte, error) {
+	// Numbers stay as written, so large IDs and timestamps keep their precision
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	envelope := Envelope{Meta: map[string]interface{}{}}
+	object, isObject := decoded.(map[string]interface{})
+	_, hasError := object["error"]
+
+	switch {
+	case status >= 400 && isObject && hasError:
+		envelope.Errors = envelopeErrors(object)
+		for _, key := range []string{"request_id", "details"} {
+			if value, ok := object[key]; ok {
+				envelope.Meta[key] = value
+			}
+		}
+	case isObject && isDataWrapper(object):
+		for key, value := range object {
+			switch key {
+			case "data":
+				envelope.Data = value
+			case "success":
+			default:
+				envelope.Meta[key] = value
+			}
+		}
+	default:
+		envelope.Data = decoded
+	}
+
+	if requestID != "" {
+		envelope.Meta["request_id"] = requestID
+	}
+	if len(envelope.Meta) == 0 {
+		envelope.Meta = nil
+	}
+	return json.Marshal(envelope)
+}
+
+// isDataWrapper tells SuccessResponse and paginated bodies, whose data is under "data", from
+// DTOs that happen to have a data field
+func isDataWrapper(object map[string]interface{}) bool {
+	if _, ok := object["success"]; ok {
+		return true
+	}
+	if _, ok := object["data"]; !ok {
+		return false
+	}
+	for key := range object {
+		if key != "data" && !envelopeMetaKeys[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// envelopeErrors lists the errors of an ErrorResponse body, one per invalid field when there are some
+func envelopeErrors(object map[string]interface{}) []EnvelopeError {
+	title, _ := object["error"].(string)
+	code, _ := object["code"].(string)
+	message, _ := object["message"].(string)
+
+	fields, _ := object["fields"].([]interface{})
+	if len(fields) == 0 {
+		return []EnvelopeError{{Code: code, Title: title, Message: message}}
+	}
+
+	errors := make([]EnvelopeError, 0, len(fields))
+	for _, raw := range fields {
+		field, _ := raw.(map[string]interface{})
+		fieldError := EnvelopeError{Title: title}
+		fieldError.Code, _ = field["code"].(string)
+		fieldError.Field, _ = field["field"].(string)
+		fieldError.Message, _ = field["message"].(string)
+		fieldError.Params, _ = field["params"].(map[string]interface{})
+		errors = append(errors, fieldError)
+	}
+	return errors
+}
@@ -0,0 +1,512 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrorCode is a stable machine-readable identifier of an error. Clients map codes to
+// their own copy instead of parsing messages; codes never change once published.
+type ErrorCode string
+
+// Generic codes, used when an error has no more specific code
+const (
+	ErrCodeValidation         ErrorCode = "VALIDATION_ERROR"
+	ErrCodeUnauthorized       ErrorCode = "UNAUTHORIZED"
+	ErrCodeForbidden          ErrorCode = "FORBIDDEN"
+	ErrCodeAccessDenied       ErrorCode = "ACCESS_DENIED"
+	ErrCodeNotFound           ErrorCode = "NOT_FOUND"
+	ErrCodeConflict           ErrorCode = "CONFLICT"
+	ErrCodeRateLimited        ErrorCode = "RATE_LIMITED"
+	ErrCodeInternal           ErrorCode = "INTERNAL_SERVER_ERROR"
+	ErrCodeServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE"
+)
+
+// Reservation codes
+const (
+	ErrCodeResTimeConflict      ErrorCode = "RES_TIME_CONFLICT"
+	ErrCodeResVIPReserved       ErrorCode = "RES_VIP_RESERVED"
+	ErrCodeResUserOverlap       ErrorCode = "RES_USER_OVERLAP"
+	ErrCodeResInPast            ErrorCode = "RES_IN_PAST"
+	ErrCodeResInvalidTimeRange  ErrorCode = "RES_INVALID_TIME_RANGE"
+	ErrCodeResAdvanceNotice     ErrorCode = "RES_ADVANCE_NOTICE_REQUIRED"
+	ErrCodeResDurationExceeded  ErrorCode = "RES_DURATION_EXCEEDED"
+	ErrCodeResNotModifiable     ErrorCode = "RES_NOT_MODIFIABLE"
+	ErrCodeResNotCancellable    ErrorCode = "RES_NOT_CANCELLABLE"
+	ErrCodeResNotPending        ErrorCode = "RES_NOT_PENDING_APPROVAL"
+	ErrCodeResNotConfirmed      ErrorCode = "RES_NOT_CONFIRMED"
+	ErrCodeResAlreadyCheckedIn  ErrorCode = "RES_ALREADY_CHECKED_IN"
+	ErrCodeResCheckInWindow     ErrorCode = "RES_CHECK_IN_WINDOW"
+	ErrCodeResNotCheckedIn      ErrorCode = "RES_NOT_CHECKED_IN"
+	ErrCodeResAlreadyCheckedOut ErrorCode = "RES_ALREADY_CHECKED_OUT"
+	ErrCodeResLicensePlate      ErrorCode = "RES_LICENSE_PLATE_REQUIRED"
+	ErrCodeResPublicHoliday     ErrorCode = "RES_PUBLIC_HOLIDAY"
+	ErrCodeResNonWorkingDay     ErrorCode = "RES_NON_WORKING_DAY"
+	ErrCodeResUserQuota         ErrorCode = "RES_USER_QUOTA_EXCEEDED"
+	ErrCodeResTeamQuota         ErrorCode = "RES_TEAM_QUOTA_EXCEEDED"
+	ErrCodeResStrikeRestricted  ErrorCode = "RES_STRIKE_RESTRICTED"
+	ErrCodeResCloneSlotTaken    ErrorCode = "RES_CLONE_SLOT_TAKEN"
+	ErrCodeResPreemptionDenied  ErrorCode = "RES_PREEMPTION_DENIED"
+)
+
+// Space codes
+const (
+	ErrCodeSpaceCapacityExceeded ErrorCode = "SPACE_CAPACITY_EXCEEDED"
+	ErrCodeSpaceUnavailable      ErrorCode = "SPACE_UNAVAILABLE"
+	ErrCodeSpaceRequiresApproval ErrorCode = "SPACE_REQUIRES_APPROVAL"
+	ErrCodeSpaceNotFree          ErrorCode = "SPACE_NOT_FREE"
+	ErrCodeSpaceNameTaken        ErrorCode = "SPACE_NAME_TAKEN"
+	ErrCodeSpaceInvalidCapacity  ErrorCode = "SPACE_INVALID_CAPACITY"
+	ErrCodeSpaceFloorClosed      ErrorCode = "SPACE_FLOOR_CLOSED"
+	ErrCodeSpaceClosed           ErrorCode = "SPACE_CLOSED"
+	ErrCodeSpaceNeighborhood     ErrorCode = "SPACE_NEIGHBORHOOD_RESTRICTED"
+	ErrCodeSpacePilot            ErrorCode = "SPACE_PILOT_RESTRICTED"
+)
+
+// Resource codes, for desks, parking spots and equipment booked like spaces
+const (
+	ErrCodeResourceBooked   ErrorCode = "RESOURCE_BOOKED"
+	ErrCodeResourceNotAddOn ErrorCode = "RESOURCE_NOT_ADD_ON"
+)
+
+// DefaultLanguage is used when the client asks for no supported language.
+// Its messages are also the text returned by Error(), which existing code matches on.
+const DefaultLanguage = "en"
+
+// errorMessages holds the message of each code per language; {name} is replaced by the param of that name
+var errorMessages = map[ErrorCode]map[string]string{
+	ErrCodeValidation: {
+		"en": "the request is invalid",
+		"fr": "la requête est invalide",
+	},
+	ErrCodeUnauthorized: {
+		"en": "authentication required",
+		"fr": "authentification requise",
+	},
+	ErrCodeForbidden: {
+		"en": "access forbidden",
+		"fr": "accès interdit",
+	},
+	ErrCodeAccessDenied: {
+		"en": "access denied",
+		"fr": "accès refusé",
+	},
+	ErrCodeNotFound: {
+		"en": "record not found",
+		"fr": "élément introuvable",
+	},
+	ErrCodeConflict: {
+		"en": "the resource was changed by another request",
+		"fr": "la ressource a été modifiée par une autre requête",
+	},
+	ErrCodeRateLimited: {
+		"en": "too many requests",
+		"fr": "trop de requêtes",
+	},
+	ErrCodeInternal: {
+		"en": "internal server error",
+		"fr": "erreur interne du serveur",
+	},
+	ErrCodeServiceUnavailable: {
+		"en": "service temporarily unavailable",
+		"fr": "service temporairement indisponible",
+	},
+	ErrCodeResTimeConflict: {
+		"en": "time slot is not available",
+		"fr": "ce créneau n'est pas disponible",
+	},
+	ErrCodeResVIPReserved: {
+		"en": "time slot is reserved for VIP use",
+		"fr": "ce créneau est réservé aux VIP",
+	},
+	ErrCodeResUserOverlap: {
+		"en": "overlapping reservation exists for this user",
+		"fr": "vous avez déjà une réservation sur ce créneau",
+	},
+	ErrCodeResInPast: {
+		"en": "cannot book in the past",
+		"fr": "impossible de réserver dans le passé",
+	},
+	ErrCodeResInvalidTimeRange: {
+		"en": "end time must be after start time",
+		"fr": "l'heure de fin doit être postérieure à l'heure de début",
+	},
+	ErrCodeResAdvanceNotice: {
+		"en": "reservations must be made at least {minutes} minutes in advance",
+		"fr": "les réservations doivent être faites au moins {minutes} minutes à l'avance",
+	},
+	ErrCodeResDurationExceeded: {
+		"en": "booking duration cannot exceed {minutes} minutes",
+		"fr": "la durée de réservation ne peut pas dépasser {minutes} minutes",
+	},
+	ErrCodeResNotModifiable: {
+		"en": "reservation cannot be modified",
+		"fr": "la réservation ne peut pas être modifiée",
+	},
+	ErrCodeResNotCancellable: {
+		"en": "reservation cannot be cancelled",
+		"fr": "la réservation ne peut pas être annulée",
+	},
+	ErrCodeResNotPending: {
+		"en": "reservation is not pending approval",
+		"fr": "la réservation n'est pas en attente d'approbation",
+	},
+	ErrCodeResNotConfirmed: {
+		"en": "reservation must be confirmed to check in",
+		"fr": "la réservation doit être confirmée pour s'enregistrer",
+	},
+	ErrCodeResAlreadyCheckedIn: {
+		"en": "already checked in",
+		"fr": "déjà enregistré",
+	},
+	ErrCodeResCheckInWindow: {
+		"en": "can only check in within 15 minutes of start time",
+		"fr": "l'enregistrement n'est possible que 15 minutes avant le début",
+	},
+	ErrCodeResNotCheckedIn: {
+		"en": "must check in before checking out",
+		"fr": "vous devez vous enregistrer avant de partir",
+	},
+	ErrCodeResAlreadyCheckedOut: {
+		"en": "already checked out",
+		"fr": "départ déjà enregistré",
+	},
+	ErrCodeSpaceCapacityExceeded: {
+		"en": "participant count ({participants}) exceeds space capacity ({capacity})",
+		"fr": "le nombre de participants ({participants}) dépasse la capacité de l'espace ({capacity})",
+	},
+	ErrCodeSpaceUnavailable: {
+		"en": "space is not available for booking",
+		"fr": "l'espace n'est pas disponible à la réservation",
+	},
+	ErrCodeSpaceRequiresApproval: {
+		"en": "space requires approval and cannot be booked instantly",
+		"fr": "l'espace nécessite une approbation et ne peut pas être réservé immédiatement",
+	},
+	ErrCodeSpaceNotFree: {
+		"en": "space is not free right now",
+		"fr": "l'espace n'est pas libre en ce moment",
+	},
+	ErrCodeSpaceNameTaken: {
+		"en": "space with this name already exists in the building",
+		"fr": "un espace portant ce nom existe déjà dans le bâtiment",
+	},
+	ErrCodeSpaceInvalidCapacity: {
+		"en": "capacity must be greater than 0",
+		"fr": "la capacité doit être supérieure à 0",
+	},
+	ErrCodeSpaceFloorClosed: {
+		"en": "floor {floor} of {building} is closed that day, book a space on floor(s) {open_floors}",
+		"fr": "l'étage {floor} de {building} est fermé ce jour-là, réservez un espace aux étages {open_floors}",
+	},
+	ErrCodeSpaceClosed: {
+		"en": "{space} is closed from {start_date} to {end_date}",
+		"fr": "{space} est fermé du {start_date} au {end_date}",
+	},
+	ErrCodeSpaceNeighborhood: {
+		"en": "{name} is in the {neighborhood} neighborhood, reserved for {departments}",
+		"fr": "{name} fait partie du quartier {neighborhood}, réservé à {departments}",
+	},
+	ErrCodeSpacePilot: {
+		"en": "{name} is in soft launch until {date} and can only be booked by its pilot group",
+		"fr": "{name} est en lancement pilote jusqu'au {date} et ne peut être réservé que par son groupe pilote",
+	},
+	ErrCodeResLicensePlate: {
+		"en": "a license plate is required to book this parking spot",
+		"fr": "une plaque d'immatriculation est requise pour réserver cette place de parking",
+	},
+	ErrCodeResPublicHoliday: {
+		"en": "{date} is a public holiday ({name}), bookings are closed that day",
+		"fr": "le {date} est un jour férié ({name}), les réservations sont fermées ce jour-là",
+	},
+	ErrCodeResNonWorkingDay: {
+		"en": "{date} is a non-working day in {building} ({name}), bookings are closed that day",
+		"fr": "le {date} est un jour non travaillé à {building} ({name}), les réservations sont fermées ce jour-là",
+	},
+	ErrCodeResUserQuota: {
+		"en": "this booking exceeds your quota of {limit} hours per week ({remaining} hours left)",
+		"fr": "cette réservation dépasse votre quota de {limit} heures par semaine (il reste {remaining} heures)",
+	},
+	ErrCodeResTeamQuota: {
+		"en": "this booking exceeds the {department} quota of {limit} premium-room hours per month ({remaining} hours left)",
+		"fr": "cette réservation dépasse le quota de {department} de {limit} heures de salles premium par mois (il reste {remaining} heures)",
+	},
+	ErrCodeResStrikeRestricted: {
+		"en": "you have {strikes} strikes for late cancellations and no-shows and cannot book premium spaces until {date}",
+		"fr": "vous avez {strikes} pénalités pour annulations tardives et absences et ne pouvez pas réserver d'espaces premium avant le {date}",
+	},
+	ErrCodeResCloneSlotTaken: {
+		"en": "the copy conflicts with another booking, the nearest free slot is {suggested_start} to {suggested_end}",
+		"fr": "la copie est en conflit avec une autre réservation, le créneau libre le plus proche va de {suggested_start} à {suggested_end}",
+	},
+	ErrCodeResPreemptionDenied: {
+		"en": "your booking priority ({priority}) does not allow preempting bookings of this space (tier {tier})",
+		"fr": "votre priorité de réservation ({priority}) ne permet pas de prendre la place des réservations de cet espace (niveau {tier})",
+	},
+	ErrCodeResourceBooked: {
+		"en": "{name} is already booked for this time",
+		"fr": "{name} est déjà réservé sur ce créneau",
+	},
+	ErrCodeResourceNotAddOn: {
+		"en": "{name} is not equipment in {building} and cannot be added to this booking",
+		"fr": "{name} n'est pas un équipement de {building} et ne peut pas être ajouté à cette réservation",
+	},
+}
+
+// CodedError is a business or validation error with a stable code and the params its message needs
+type CodedError struct {
+	Code   ErrorCode
+	Params map[string]interface{}
+}
+
+// NewCodedError creates an error for the code; params fill the placeholders of its message
+func NewCodedError(code ErrorCode, params map[string]interface{}) *CodedError {
+	return &CodedError{Code: code, Params: params}
+}
+
+// Error returns the message in the default language
+func (e *CodedError) Error() string {
+	return e.Localize(DefaultLanguage)
+}
+
+// Localize returns the message in the language, falling back to the default language
+func (e *CodedError) Localize(lang string) string {
+	messages := errorMessages[e.Code]
+	message, ok := messages[lang]
+	if !ok {
+		message, ok = messages[DefaultLanguage]
+	}
+	if !ok {
+		return strings.ToLower(string(e.Code))
+	}
+
+	for name, value := range e.Params {
+		message = strings.ReplaceAll(message, "{"+name+"}", fmt.Sprint(value))
+	}
+	return message
+}
+
+// Coded errors without params, shared by the services
+var (
+	ErrAccessDenied              = NewCodedError(ErrCodeAccessDenied, nil)
+	ErrTimeSlotUnavailable       = NewCodedError(ErrCodeResTimeConflict, nil)
+	ErrTimeSlotVIPReserved       = NewCodedError(ErrCodeResVIPReserved, nil)
+	ErrUserOverlap               = NewCodedError(ErrCodeResUserOverlap, nil)
+	ErrBookingInPast             = NewCodedError(ErrCodeResInPast, nil)
+	ErrInvalidTimeRange          = NewCodedError(ErrCodeResInvalidTimeRange, nil)
+	ErrReservationNotModifiable  = NewCodedError(ErrCodeResNotModifiable, nil)
+	ErrReservationNotCancellable = NewCodedError(ErrCodeResNotCancellable, nil)
+	ErrReservationNotPending     = NewCodedError(ErrCodeResNotPending, nil)
+	ErrReservationNotConfirmed   = NewCodedError(ErrCodeResNotConfirmed, nil)
+	ErrAlreadyCheckedIn          = NewCodedError(ErrCodeResAlreadyCheckedIn, nil)
+	ErrCheckInWindow             = NewCodedError(ErrCodeResCheckInWindow, nil)
+	ErrNotCheckedIn              = NewCodedError(ErrCodeResNotCheckedIn, nil)
+	ErrAlreadyCheckedOut         = NewCodedError(ErrCodeResAlreadyCheckedOut, nil)
+	ErrSpaceUnavailable          = NewCodedError(ErrCodeSpaceUnavailable, nil)
+	ErrSpaceRequiresApproval     = NewCodedError(ErrCodeSpaceRequiresApproval, nil)
+	ErrSpaceNotFree              = NewCodedError(ErrCodeSpaceNotFree, nil)
+	ErrSpaceNameTaken            = NewCodedError(ErrCodeSpaceNameTaken, nil)
+	ErrSpaceInvalidCapacity      = NewCodedError(ErrCodeSpaceInvalidCapacity, nil)
+	ErrLicensePlateRequired      = NewCodedError(ErrCodeResLicensePlate, nil)
+	ErrStaleUpdate               = NewCodedError(ErrCodeConflict, nil)
+)
+
+// NewCapacityExceededError reports more participants than the space seats
+func NewCapacityExceededError(participants, capacity int) *CodedError {
+	return NewCodedError(ErrCodeSpaceCapacityExceeded, map[string]interface{}{
+		"participants": participants,
+		"capacity":     capacity,
+	})
+}
+
+// NewAdvanceNoticeError reports a booking made later than the space allows
+func NewAdvanceNoticeError(minutes int) *CodedError {
+	return NewCodedError(ErrCodeResAdvanceNotice, map[string]interface{}{"minutes": minutes})
+}
+
+// NewDurationExceededError reports a booking longer than the space allows
+func NewDurationExceededError(minutes int) *CodedError {
+	return NewCodedError(ErrCodeResDurationExceeded, map[string]interface{}{"minutes": minutes})
+}
+
+// NewPublicHolidayError reports a booking on a public holiday
+func NewPublicHolidayError(date, name string) *CodedError {
+	return NewCodedError(ErrCodeResPublicHoliday, map[string]interface{}{
+		"date": date,
+		"name": name,
+	})
+}
+
+// NewNonWorkingDayError reports a booking on a blocking holiday or closure day of the building's working calendar
+func NewNonWorkingDayError(building, date, name string) *CodedError {
+	return NewCodedError(ErrCodeResNonWorkingDay, map[string]interface{}{
+		"date":     date,
+		"building": building,
+		"name":     name,
+	})
+}
+
+// NewUserQuotaExceededError reports a booking that would take the user past their weekly hours
+func NewUserQuotaExceededError(limit int, remaining float64) *CodedError {
+	return NewCodedError(ErrCodeResUserQuota, map[string]interface{}{
+		"limit":     limit,
+		"remaining": remaining,
+	})
+}
+
+// NewTeamQuotaExceededError reports a booking that would take the department past its monthly premium-room hours
+func NewTeamQuotaExceededError(department string, limit int, remaining float64) *CodedError {
+	return NewCodedError(ErrCodeResTeamQuota, map[string]interface{}{
+		"department": department,
+		"limit":      limit,
+		"remaining":  remaining,
+	})
+}
+
+// NewStrikeRestrictedError reports a premium booking by a user restricted for too many strikes
+func NewStrikeRestrictedError(strikes int, until string) *CodedError {
+	return NewCodedError(ErrCodeResStrikeRestricted, map[string]interface{}{
+		"strikes": strikes,
+		"date":    until,
+	})
+}
+
+// NewPreemptionDeniedError reports a preemption by a user whose priority is below the tier of the space,
+// or in a space without preemption (tier 0)
+func NewPreemptionDeniedError(priority, tier int) *CodedError {
+	return NewCodedError(ErrCodeResPreemptionDenied, map[string]interface{}{
+		"priority": priority,
+		"tier":     tier,
+	})
+}
+
+// NewCloneSlotTakenError reports a copied reservation that conflicts, with the nearest free slot
+func NewCloneSlotTakenError(start, end time.Time) *CodedError {
+	return NewCodedError(ErrCodeResCloneSlotTaken, map[string]interface{}{
+		"suggested_start": start.Format(time.RFC3339),
+		"suggested_end":   end.Format(time.RFC3339),
+	})
+}
+
+// NewFloorClosedError reports a space on a floor closed by a floor consolidation
+func NewFloorClosedError(building string, floor int, openFloors []int64) *CodedError {
+	floors := make([]string, len(openFloors))
+	for i, open := range openFloors {
+		floors[i] = fmt.Sprint(open)
+	}
+	return NewCodedError(ErrCodeSpaceFloorClosed, map[string]interface{}{
+		"building":    building,
+		"floor":       floor,
+		"open_floors": strings.Join(floors, ", "),
+	})
+}
+
+// NewSpaceClosedError reports a space in a building or on a floor closed for the days
+func NewSpaceClosedError(space string, startDate, endDate time.Time) *CodedError {
+	return NewCodedError(ErrCodeSpaceClosed, map[string]interface{}{
+		"space":      space,
+		"start_date": startDate.Format("2006-01-02"),
+		"end_date":   endDate.Format("2006-01-02"),
+	})
+}
+
+// NewNeighborhoodRestrictedError reports a desk in a neighborhood the user's team may not book
+func NewNeighborhoodRestrictedError(name, neighborhood string, departments []string) *CodedError {
+	return NewCodedError(ErrCodeSpaceNeighborhood, map[string]interface{}{
+		"name":         name,
+		"neighborhood": neighborhood,
+		"departments":  strings.Join(departments, ", "),
+	})
+}
+
+// NewPilotRestrictedError reports a space in soft launch booked by a user outside its pilot group
+func NewPilotRestrictedError(name, until string) *CodedError {
+	return NewCodedError(ErrCodeSpacePilot, map[string]interface{}{
+		"name": name,
+		"date": until,
+	})
+}
+
+// NewResourceBookedError reports an add-on resource already booked in the slot
+func NewResourceBookedError(name string) *CodedError {
+	return NewCodedError(ErrCodeResourceBooked, map[string]interface{}{"name": name})
+}
+
+// NewResourceNotAddOnError reports a resource that cannot be added to a booking of a space in the building
+func NewResourceNotAddOnError(name, building string) *CodedError {
+	return NewCodedError(ErrCodeResourceNotAddOn, map[string]interface{}{
+		"name":     name,
+		"building": building,
+	})
+}
+
+// CodeForStatus is the generic code of an HTTP status, for errors without a code of their own
+func CodeForStatus(status int) ErrorCode {
+	switch {
+	case status == http.StatusUnauthorized:
+		return ErrCodeUnauthorized
+	case status == http.StatusForbidden:
+		return ErrCodeForbidden
+	case status == http.StatusNotFound:
+		return ErrCodeNotFound
+	case status == http.StatusConflict || status == http.StatusPreconditionFailed:
+		return ErrCodeConflict
+	case status == http.StatusTooManyRequests:
+		return ErrCodeRateLimited
+	case status == http.StatusServiceUnavailable:
+		return ErrCodeServiceUnavailable
+	case status >= http.StatusInternalServerError:
+		return ErrCodeInternal
+	default:
+		return ErrCodeValidation
+	}
+}
+
+// ResolveLanguage picks the first supported language of an Accept-Language header
+func ResolveLanguage(acceptLanguage string) string {
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		lang := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if _, ok := errorMessages[ErrCodeValidation][lang]; ok {
+			return lang
+		}
+	}
+	return DefaultLanguage
+}
+
+// NewLocalizedErrorResponse builds the error response for err in the language. Validation
+// errors list every invalid field with its code and translated message; coded errors keep
+// their code, params and translated message; other errors get the generic code of the
+// status and their own message.
+func NewLocalizedErrorResponse(title string, err error, status int, lang string) *ErrorResponse {
+	if fields, ok := ValidationErrorsFrom(err); ok {
+		return &ErrorResponse{
+			Error:   title,
+			Message: NewCodedError(ErrCodeValidation, nil).Localize(lang),
+			Code:    string(ErrCodeValidation),
+			Fields:  fields.Localize(lang),
+		}
+	}
+
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		response := &ErrorResponse{
+			Error:   title,
+			Message: coded.Localize(lang),
+			Code:    string(coded.Code),
+		}
+		for name, value := range coded.Params {
+			response.AddDetail(name, value)
+		}
+		return response
+	}
+
+	return &ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+		Code:    string(CodeForStatus(status)),
+	}
+}
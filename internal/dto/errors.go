@@ -75,13 +75,16 @@ type ErrorResponse struct {
 	Code       string                 `json:"code,omitempty"`
 	StatusCode int                    `json:"status_code,omitempty"`
 	Details    map[string]interface{} `json:"details,omitempty"`
+	RequestID  string                 `json:"request_id,omitempty"` // Correlation ID, echoed in X-Request-ID
+	Fields     ValidationErrors       `json:"fields,omitempty"`     // Every invalid field, on validation errors
 }
 
 // ValidationError represents field validation errors
 type ValidationError struct {
-	Field   string `json:"field"`
-	Message string `json:"message"`
-	Code    string `json:"code"`
+	Field   string                 `json:"field"`
+	Code    string                 `json:"code"` // Localization key of the message, see the Validation* codes
+	Message string                 `json:"message"`
+	Params  map[string]interface{} `json:"params,omitempty"`
 }
 
 // ValidationErrorResponse represents validation error response
@@ -1,8 +1,11 @@
 package dto
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"room-reservation-api/internal/models"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -48,6 +51,11 @@ type ChangePasswordRequest struct {
 	NewPassword     string `json:"new_password" binding:"required,min=8"`
 }
 
+// DeleteAccountRequest confirms a user's request to delete their own account
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
 // User Profile Requests
 type UpdateProfileRequest struct {
 	FirstName      string `json:"first_name" binding:"omitempty,min=2,max=100"`
@@ -60,7 +68,12 @@ type UpdateProfileRequest struct {
 
 // Admin Requests
 type UpdateUserRoleRequest struct {
-	Role models.UserRole `json:"role" binding:"required,oneof=admin manager user"`
+	Role models.UserRole `json:"role" binding:"required,oneof=admin manager user auditor facilities"`
+}
+
+// UpdateUserPriorityRequest sets the booking priority tier of a user, 0 leaves the role's
+type UpdateUserPriorityRequest struct {
+	PriorityTier *int `json:"priority_tier" binding:"required,min=0,max=10"`
 }
 
 // Query Parameters
@@ -68,7 +81,7 @@ type GetUsersQuery struct {
 	Page     int    `form:"page,default=1" binding:"min=1"`
 	Limit    int    `form:"limit,default=10" binding:"min=1,max=100"`
 	Search   string `form:"search"`
-	Role     string `form:"role" binding:"omitempty,oneof=admin manager user"`
+	Role     string `form:"role" binding:"omitempty,oneof=admin manager user auditor facilities"`
 	IsActive *bool  `form:"is_active"`
 }
 
@@ -80,54 +93,200 @@ SPACE REQUESTS
 
 // CreateSpaceRequest represents the request body for creating a new space
 type CreateSpaceRequest struct {
-	Name               string      `json:"name" binding:"required,min=2,max=100"`
-	Type               string      `json:"type" binding:"required,oneof=meeting_room office auditorium open_space hot_desk conference_room"`
-	Capacity           int         `json:"capacity" binding:"required,min=1,max=1000"`
-	Building           string      `json:"building" binding:"required,min=1,max=50"`
-	Floor              int         `json:"floor" binding:"required"`
-	RoomNumber         string      `json:"room_number" binding:"required,min=1,max=20"`
-	Equipment          []Equipment `json:"equipment,omitempty"`
-	Description        string      `json:"description,omitempty"`
-	Surface            float64     `json:"surface,omitempty" binding:"omitempty,min=0"`
-	Photos             []string    `json:"photos,omitempty"`
-	PricePerHour       float64     `json:"price_per_hour,omitempty" binding:"omitempty,min=0"`
-	PricePerDay        float64     `json:"price_per_day,omitempty" binding:"omitempty,min=0"`
-	PricePerMonth      float64     `json:"price_per_month,omitempty" binding:"omitempty,min=0"`
-	ManagerID          *uuid.UUID  `json:"manager_id,omitempty"`
-	RequiresApproval   bool        `json:"requires_approval"`
-	BookingAdvanceTime int         `json:"booking_advance_time,omitempty" binding:"omitempty,min=0"`
-	MaxBookingDuration int         `json:"max_booking_duration,omitempty" binding:"omitempty,min=30"`
+	Name                string              `json:"name" binding:"required,min=2,max=100"`
+	Type                string              `json:"type" binding:"required,oneof=meeting_room office auditorium open_space hot_desk conference_room parking_spot equipment"`
+	Capacity            int                 `json:"capacity" binding:"required,min=1,max=1000"`
+	Building            string              `json:"building" binding:"required,min=1,max=50"`
+	Floor               int                 `json:"floor" binding:"required"`
+	RoomNumber          string              `json:"room_number" binding:"required,min=1,max=20"`
+	Equipment           []Equipment         `json:"equipment,omitempty"`
+	Description         string              `json:"description,omitempty"`
+	Surface             float64             `json:"surface,omitempty" binding:"omitempty,min=0"`
+	Photos              []string            `json:"photos,omitempty"`
+	PricePerHour        float64             `json:"price_per_hour,omitempty" binding:"omitempty,min=0"`
+	PricePerDay         float64             `json:"price_per_day,omitempty" binding:"omitempty,min=0"`
+	PricePerMonth       float64             `json:"price_per_month,omitempty" binding:"omitempty,min=0"`
+	CostPerHour         float64             `json:"cost_per_hour,omitempty" binding:"omitempty,min=0"`
+	ManagerID           *uuid.UUID          `json:"manager_id,omitempty"`
+	RequiresApproval    bool                `json:"requires_approval"`
+	BookingAdvanceTime  int                 `json:"booking_advance_time,omitempty" binding:"omitempty,min=0"`
+	MaxBookingDuration  int                 `json:"max_booking_duration,omitempty" binding:"omitempty,min=30"`
+	BufferBefore        int                 `json:"buffer_before,omitempty" binding:"omitempty,min=0,max=240"`          // minutes kept free before each booking
+	BufferAfter         int                 `json:"buffer_after,omitempty" binding:"omitempty,min=0,max=240"`           // minutes kept free after each booking
+	CancellationWindow  int                 `json:"cancellation_window,omitempty" binding:"omitempty,min=0,max=10080"`  // minutes before the start under which cancelling earns a strike
+	EarlyReleaseMinutes int                 `json:"early_release_minutes,omitempty" binding:"omitempty,min=0,max=1440"` // booked minutes left at check-out from which the rest is released
+	PreemptionTier      int                 `json:"preemption_tier,omitempty" binding:"omitempty,min=0,max=10"`         // lowest user priority that may preempt pending bookings
+	IsVIP               bool                `json:"is_vip"`
+	IsPremium           bool                `json:"is_premium"`
+	PilotUntil          *time.Time          `json:"pilot_until,omitempty"` // Soft launch: only the pilot group can see and book the space until then
+	PilotUserIDs        []uuid.UUID         `json:"pilot_user_ids,omitempty" binding:"omitempty,max=500"`
+	PilotDepartments    []string            `json:"pilot_departments,omitempty" binding:"omitempty,max=50,dive,min=1,max=100"`
+	CombinationOf       []uuid.UUID         `json:"combination_of,omitempty" binding:"omitempty,min=2,max=10,unique"` // Spaces of the building joined into this one, e.g. by opening a partition wall
+	EnergyRating        string              `json:"energy_rating,omitempty" binding:"omitempty,oneof=A B C D E F G"`  // A is the most efficient
+	CheckInPresence     string              `json:"check_in_presence,omitempty" binding:"omitempty,oneof=none beacon geofence beacon_or_geofence"`
+	BeaconIDs           []string            `json:"beacon_ids,omitempty" binding:"omitempty,max=20,dive,min=1,max=100"`
+	Latitude            *float64            `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude           *float64            `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
+	GeofenceRadius      int                 `json:"geofence_radius,omitempty" binding:"omitempty,min=10,max=5000"` // meters, default 150
+	Attributes          *ResourceAttributes `json:"attributes,omitempty"`                                          // Settings of the type's resource class, e.g. the spot number of a parking spot
 }
 
 // UpdateSpaceRequest represents the request body for updating a space
 type UpdateSpaceRequest struct {
-	Name               *string     `json:"name,omitempty" binding:"omitempty,min=2,max=100"`
-	Type               *string     `json:"type,omitempty" binding:"omitempty,oneof=meeting_room office auditorium open_space hot_desk conference_room"`
-	Capacity           *int        `json:"capacity,omitempty" binding:"omitempty,min=1,max=1000"`
-	Building           *string     `json:"building,omitempty" binding:"omitempty,min=1,max=50"`
-	Floor              *int        `json:"floor,omitempty"`
-	RoomNumber         *string     `json:"room_number,omitempty" binding:"omitempty,min=1,max=20"`
-	Equipment          []Equipment `json:"equipment,omitempty"`
-	Status             *string     `json:"status,omitempty" binding:"omitempty,oneof=available maintenance out_of_service reserved"`
-	Description        *string     `json:"description,omitempty"`
-	Surface            *float64    `json:"surface,omitempty" binding:"omitempty,min=0"`
-	Photos             []string    `json:"photos,omitempty"`
-	PricePerHour       *float64    `json:"price_per_hour,omitempty" binding:"omitempty,min=0"`
-	PricePerDay        *float64    `json:"price_per_day,omitempty" binding:"omitempty,min=0"`
-	PricePerMonth      *float64    `json:"price_per_month,omitempty" binding:"omitempty,min=0"`
-	ManagerID          *uuid.UUID  `json:"manager_id,omitempty"`
-	RequiresApproval   *bool       `json:"requires_approval,omitempty"`
-	BookingAdvanceTime *int        `json:"booking_advance_time,omitempty" binding:"omitempty,min=0"`
-	MaxBookingDuration *int        `json:"max_booking_duration,omitempty" binding:"omitempty,min=30"`
+	Name                *string             `json:"name,omitempty" binding:"omitempty,min=2,max=100"`
+	Type                *string             `json:"type,omitempty" binding:"omitempty,oneof=meeting_room office auditorium open_space hot_desk conference_room parking_spot equipment"`
+	Capacity            *int                `json:"capacity,omitempty" binding:"omitempty,min=1,max=1000"`
+	Building            *string             `json:"building,omitempty" binding:"omitempty,min=1,max=50"`
+	Floor               *int                `json:"floor,omitempty"`
+	RoomNumber          *string             `json:"room_number,omitempty" binding:"omitempty,min=1,max=20"`
+	Equipment           []Equipment         `json:"equipment,omitempty"`
+	Status              *string             `json:"status,omitempty" binding:"omitempty,oneof=available maintenance out_of_service reserved"`
+	Description         *string             `json:"description,omitempty"`
+	Surface             *float64            `json:"surface,omitempty" binding:"omitempty,min=0"`
+	Photos              []string            `json:"photos,omitempty"`
+	PricePerHour        *float64            `json:"price_per_hour,omitempty" binding:"omitempty,min=0"`
+	PricePerDay         *float64            `json:"price_per_day,omitempty" binding:"omitempty,min=0"`
+	PricePerMonth       *float64            `json:"price_per_month,omitempty" binding:"omitempty,min=0"`
+	CostPerHour         *float64            `json:"cost_per_hour,omitempty" binding:"omitempty,min=0"`
+	ManagerID           *uuid.UUID          `json:"manager_id,omitempty"`
+	RequiresApproval    *bool               `json:"requires_approval,omitempty"`
+	BookingAdvanceTime  *int                `json:"booking_advance_time,omitempty" binding:"omitempty,min=0"`
+	MaxBookingDuration  *int                `json:"max_booking_duration,omitempty" binding:"omitempty,min=30"`
+	BufferBefore        *int                `json:"buffer_before,omitempty" binding:"omitempty,min=0,max=240"`
+	BufferAfter         *int                `json:"buffer_after,omitempty" binding:"omitempty,min=0,max=240"`
+	CancellationWindow  *int                `json:"cancellation_window,omitempty" binding:"omitempty,min=0,max=10080"`
+	EarlyReleaseMinutes *int                `json:"early_release_minutes,omitempty" binding:"omitempty,min=0,max=1440"` // 0 stops releasing
+	PreemptionTier      *int                `json:"preemption_tier,omitempty" binding:"omitempty,min=0,max=10"`         // 0 disables preemption
+	IsVIP               *bool               `json:"is_vip,omitempty"`
+	IsPremium           *bool               `json:"is_premium,omitempty"`
+	PilotUntil          *time.Time          `json:"pilot_until,omitempty"`                                                     // A past date opens the space to everyone now
+	PilotUserIDs        []uuid.UUID         `json:"pilot_user_ids,omitempty" binding:"omitempty,max=500"`                      // Replaces the list
+	PilotDepartments    []string            `json:"pilot_departments,omitempty" binding:"omitempty,max=50,dive,min=1,max=100"` // Replaces the list
+	CombinationOf       []uuid.UUID         `json:"combination_of,omitempty" binding:"omitempty,max=10,unique"`                // Replaces the parts; empty makes it a plain space
+	EnergyRating        *string             `json:"energy_rating,omitempty" binding:"omitempty,oneof=A B C D E F G"`
+	CheckInPresence     *string             `json:"check_in_presence,omitempty" binding:"omitempty,oneof=none beacon geofence beacon_or_geofence"`
+	BeaconIDs           []string            `json:"beacon_ids,omitempty" binding:"omitempty,max=20,dive,min=1,max=100"` // Replaces the list; empty removes all
+	Latitude            *float64            `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`
+	Longitude           *float64            `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"`
+	GeofenceRadius      *int                `json:"geofence_radius,omitempty" binding:"omitempty,min=10,max=5000"`
+	Attributes          *ResourceAttributes `json:"attributes,omitempty"` // Replaces all attributes
+	UpdatedAt           *time.Time          `json:"updated_at,omitempty"` // updated_at as last read; the update fails with 409 if it changed since
+}
+
+// SpaceCatalog is the portable form of every space, exported to replicate an environment
+// or bulk edit spaces, and imported back with upsert semantics by building and name
+type SpaceCatalog struct {
+	ExportedAt *time.Time          `json:"exported_at,omitempty"`
+	Spaces     []SpaceCatalogEntry `json:"spaces" binding:"required,min=1"`
+}
+
+// SpaceCatalogEntry represents one space of the catalog with its policies and schedules.
+// Imports replace every field of an existing space; managers are matched by email across
+// environments. Beacon IDs and pilot users stay out since they only make sense in one environment.
+type SpaceCatalogEntry struct {
+	Building            string                  `json:"building" validate:"required,min=1,max=50"`
+	Name                string                  `json:"name" validate:"required,min=2,max=100"`
+	Type                string                  `json:"type" validate:"required,oneof=meeting_room office auditorium open_space hot_desk conference_room parking_spot equipment"`
+	Capacity            int                     `json:"capacity" validate:"required,min=1,max=1000"`
+	Floor               int                     `json:"floor"`
+	RoomNumber          string                  `json:"room_number" validate:"required,min=1,max=20"`
+	Status              string                  `json:"status,omitempty" validate:"omitempty,oneof=available maintenance out_of_service reserved"` // Defaults to available
+	Description         string                  `json:"description,omitempty"`
+	Surface             float64                 `json:"surface,omitempty" validate:"omitempty,min=0"`
+	Photos              []string                `json:"photos,omitempty"`
+	Equipment           []Equipment             `json:"equipment,omitempty" validate:"omitempty,dive"`
+	Attributes          *ResourceAttributes     `json:"attributes,omitempty"`
+	PricePerHour        float64                 `json:"price_per_hour,omitempty" validate:"omitempty,min=0"`
+	PricePerDay         float64                 `json:"price_per_day,omitempty" validate:"omitempty,min=0"`
+	PricePerMonth       float64                 `json:"price_per_month,omitempty" validate:"omitempty,min=0"`
+	CostPerHour         float64                 `json:"cost_per_hour,omitempty" validate:"omitempty,min=0"`
+	ManagerEmail        string                  `json:"manager_email,omitempty" validate:"omitempty,email"`
+	RequiresApproval    bool                    `json:"requires_approval"`
+	BookingAdvanceTime  int                     `json:"booking_advance_time" validate:"min=0"`
+	MaxBookingDuration  int                     `json:"max_booking_duration,omitempty" validate:"omitempty,min=30"` // Defaults to 480
+	BufferBefore        int                     `json:"buffer_before,omitempty" validate:"omitempty,min=0,max=240"`
+	BufferAfter         int                     `json:"buffer_after,omitempty" validate:"omitempty,min=0,max=240"`
+	CancellationWindow  int                     `json:"cancellation_window,omitempty" validate:"omitempty,min=0,max=10080"`
+	EarlyReleaseMinutes int                     `json:"early_release_minutes,omitempty" validate:"omitempty,min=0,max=1440"`
+	PreemptionTier      int                     `json:"preemption_tier,omitempty" validate:"omitempty,min=0,max=10"`
+	IsVIP               bool                    `json:"is_vip"`
+	IsPremium           bool                    `json:"is_premium"`
+	EnergyRating        string                  `json:"energy_rating,omitempty" validate:"omitempty,oneof=A B C D E F G"`
+	CheckInPresence     string                  `json:"check_in_presence,omitempty" validate:"omitempty,oneof=none beacon geofence beacon_or_geofence"`
+	Latitude            *float64                `json:"latitude,omitempty" validate:"omitempty,min=-90,max=90"`
+	Longitude           *float64                `json:"longitude,omitempty" validate:"omitempty,min=-180,max=180"`
+	GeofenceRadius      int                     `json:"geofence_radius,omitempty" validate:"omitempty,min=10,max=5000"` // Defaults to 150
+	PilotUntil          *time.Time              `json:"pilot_until,omitempty"`
+	PilotDepartments    []string                `json:"pilot_departments,omitempty" validate:"omitempty,max=50,dive,min=1,max=100"`
+	ApprovalRule        *SetApprovalRuleRequest `json:"approval_rule,omitempty"`                     // Left unchanged when omitted
+	VIPBlocks           []CreateVIPBlockRequest `json:"vip_blocks" validate:"omitempty,max=50,dive"` // Replaces the active blocks; left unchanged when null
+}
+
+// Validate checks the entry's approval rule and VIP blocks can apply to the space
+func (e *SpaceCatalogEntry) Validate() error {
+	var fields ValidationErrors
+	if e.Latitude != nil && e.Longitude == nil {
+		fields.Add("longitude", ValidationRequiredWith, map[string]interface{}{"other": "latitude"})
+	}
+	if e.Longitude != nil && e.Latitude == nil {
+		fields.Add("latitude", ValidationRequiredWith, map[string]interface{}{"other": "longitude"})
+	}
+	if e.ApprovalRule != nil && !e.RequiresApproval {
+		fields.Add("requires_approval", ValidationRequiredWith, map[string]interface{}{"other": "approval_rule"})
+	}
+	if len(e.VIPBlocks) > 0 && !e.IsVIP {
+		fields.Add("is_vip", ValidationRequiredWith, map[string]interface{}{"other": "vip_blocks"})
+	}
+	for i := range e.VIPBlocks {
+		if err := e.VIPBlocks[i].Validate(); err != nil {
+			if blockFields, ok := ValidationErrorsFrom(err); ok {
+				fields = append(fields, blockFields.Prefixed(fmt.Sprintf("vip_blocks[%d]", i))...)
+			}
+		}
+	}
+	return fields.Err()
 }
 
 // Equipment represents equipment in a space
 type Equipment struct {
-	Name        string `json:"name" binding:"required"`
-	Quantity    int    `json:"quantity" binding:"required,min=1"`
+	Name        string `json:"name" binding:"required" validate:"required"`
+	Quantity    int    `json:"quantity" binding:"required,min=1" validate:"required,min=1"`
 	Description string `json:"description,omitempty"`
 }
 
+// ResourceAttributes represents the settings of one resource class; only the
+// fields of the space type's class may be set
+type ResourceAttributes struct {
+	// Parking
+	SpotNumber           string `json:"spot_number,omitempty" binding:"omitempty,max=20" validate:"omitempty,max=20"` // Required for parking spots
+	RequiresLicensePlate bool   `json:"requires_license_plate,omitempty"`
+	EVCharging           bool   `json:"ev_charging,omitempty"`
+
+	// Desk
+	MonitorCount   int  `json:"monitor_count,omitempty" binding:"omitempty,min=0,max=6" validate:"omitempty,min=0,max=6"`
+	StandingDesk   bool `json:"standing_desk,omitempty"`
+	DockingStation bool `json:"docking_station,omitempty"`
+
+	// Equipment
+	AssetTag string `json:"asset_tag,omitempty" binding:"omitempty,max=50" validate:"omitempty,max=50"`
+	Category string `json:"category,omitempty" binding:"omitempty,max=50" validate:"omitempty,max=50"`
+}
+
+// ReorderSpacePhotosRequest represents the new order of the photo gallery of a space
+type ReorderSpacePhotosRequest struct {
+	PhotoIDs []uuid.UUID `json:"photo_ids" binding:"required,min=1"` // Every photo of the gallery, first shown first
+}
+
+// CreateVIPBlockRequest represents a guaranteed-availability window for a VIP space
+type CreateVIPBlockRequest struct {
+	DayOfWeek    int      `json:"day_of_week" binding:"min=0,max=6" validate:"min=0,max=6"`
+	StartTime    string   `json:"start_time" binding:"required" validate:"required"` // HH:MM
+	EndTime      string   `json:"end_time" binding:"required" validate:"required"`   // HH:MM
+	Timezone     string   `json:"timezone,omitempty"`
+	AllowedRoles []string `json:"allowed_roles" binding:"required,min=1,dive,oneof=admin manager user" validate:"required,min=1,dive,oneof=admin manager user"`
+	Reason       string   `json:"reason,omitempty"`
+}
+
 // SpaceSearchRequest represents the request for searching spaces
 type SpaceSearchRequest struct {
 	Query       string   `json:"query,omitempty" form:"query"`
@@ -146,6 +305,25 @@ type SpaceSearchRequest struct {
 	SortOrder   string   `json:"sort_order,omitempty" form:"sort_order"`
 }
 
+// ResourceSearchRequest represents the query of a search within one resource class
+type ResourceSearchRequest struct {
+	Building       string     `form:"building"`
+	Floor          *int       `form:"floor"`
+	MinCapacity    *int       `form:"min_capacity" binding:"omitempty,min=1"`
+	AvailableStart *time.Time `form:"available_start"` // With available_end, only resources free the whole time
+	AvailableEnd   *time.Time `form:"available_end"`
+
+	// Attributes, ignored by classes without them
+	MinMonitors    *int   `form:"min_monitors" binding:"omitempty,min=1"`
+	StandingDesk   *bool  `form:"standing_desk"`
+	DockingStation *bool  `form:"docking_station"`
+	EVCharging     *bool  `form:"ev_charging"`
+	Category       string `form:"category" binding:"omitempty,max=50"`
+
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
 // SpaceAvailabilityRequest represents the request for checking space availability
 type SpaceAvailabilityRequest struct {
 	SpaceID   uuid.UUID `json:"space_id" binding:"required"`
@@ -218,37 +396,40 @@ func (r *SpaceFiltersRequest) GetOffset() int {
 
 // Validate validates the availability request
 func (r *SpaceAvailabilityRequest) Validate() error {
+	var fields ValidationErrors
 	if r.StartTime.After(r.EndTime) {
-		return errors.New("start time must be before end time")
+		fields.Add("start_time", ValidationBefore, map[string]interface{}{"other": "end_time"})
 	}
 
 	if r.StartTime.Before(time.Now()) {
-		return errors.New("start time must be in the future")
+		fields.Add("start_time", ValidationFuture, nil)
 	}
 
-	return nil
+	return fields.Err()
 }
 
 // Validate validates the search request
 func (r *SpaceSearchRequest) Validate() error {
+	var fields ValidationErrors
 	if r.MinCapacity != nil && r.MaxCapacity != nil && *r.MinCapacity > *r.MaxCapacity {
-		return errors.New("min capacity must be less than or equal to max capacity")
+		fields.Add("min_capacity", ValidationNotAbove, map[string]interface{}{"other": "max_capacity"})
 	}
 
-	return nil
+	return fields.Err()
 }
 
 // Validate validates the filters request
 func (r *SpaceFiltersRequest) Validate() error {
+	var fields ValidationErrors
 	if r.MinCapacity != nil && r.MaxCapacity != nil && *r.MinCapacity > *r.MaxCapacity {
-		return errors.New("min capacity must be less than or equal to max capacity")
+		fields.Add("min_capacity", ValidationNotAbove, map[string]interface{}{"other": "max_capacity"})
 	}
 
 	if r.AvailableStartTime != nil && r.AvailableEndTime != nil && r.AvailableStartTime.After(*r.AvailableEndTime) {
-		return errors.New("available start time must be before end time")
+		fields.Add("available_start_time", ValidationBefore, map[string]interface{}{"other": "available_end_time"})
 	}
 
-	return nil
+	return fields.Err()
 }
 
 /*
@@ -259,14 +440,206 @@ RESERVATION REQUESTS
 
 // CreateReservationRequest represents the request body for creating a new reservation
 type CreateReservationRequest struct {
-	SpaceID           uuid.UUID          `json:"space_id" binding:"required"`
+	// Omitted fields are filled from the user's booking preferences
+	SpaceID           uuid.UUID          `json:"space_id,omitempty"`                            // Defaults to any suitable room in the building, placed by the building's strategy
+	Building          string             `json:"building,omitempty" binding:"omitempty,max=50"` // Building of "any suitable room", defaults to the preferred building
 	StartTime         time.Time          `json:"start_time" binding:"required"`
-	EndTime           time.Time          `json:"end_time" binding:"required"`
-	ParticipantCount  int                `json:"participant_count" binding:"required,min=1"`
+	EndTime           time.Time          `json:"end_time,omitempty"`                                    // Defaults to start time plus the preferred duration
+	ParticipantCount  int                `json:"participant_count,omitempty" binding:"omitempty,min=1"` // Defaults to the preferred participants plus the user
 	Title             string             `json:"title" binding:"required,min=2,max=200"`
 	Description       string             `json:"description,omitempty"`
 	IsRecurring       bool               `json:"is_recurring"`
 	RecurrencePattern *RecurrencePattern `json:"recurrence_pattern,omitempty"`
+	IsPrivate         *bool              `json:"is_private,omitempty"` // Only show as busy on department calendars
+
+	// Admin override for VIP blocks; the justification is recorded as a violation
+	OverrideVIPBlock      bool   `json:"override_vip_block,omitempty"`
+	OverrideJustification string `json:"override_justification,omitempty" binding:"omitempty,max=1000"`
+
+	// Book anyway when the user already has an overlapping reservation elsewhere
+	OverrideDuplicate      bool   `json:"override_duplicate,omitempty"`
+	DuplicateJustification string `json:"duplicate_justification,omitempty" binding:"omitempty,max=1000"`
+
+	// Take the slot from pending bookings of lower-priority users, in spaces with a preemption tier;
+	// the justification is recorded with each displaced booking
+	Preempt              bool   `json:"preempt,omitempty"`
+	PreemptJustification string `json:"preempt_justification,omitempty" binding:"omitempty,max=1000"`
+
+	// Equipment in the same building booked for the whole reservation
+	ResourceIDs []uuid.UUID `json:"resource_ids,omitempty" binding:"omitempty,max=10,unique"`
+	// Car to let in, required by parking spots that check plates
+	LicensePlate string `json:"license_plate,omitempty" binding:"omitempty,max=20"`
+	// Start the organizer's checklist from the template of the space type
+	WithChecklist bool `json:"with_checklist,omitempty"`
+	// Free-form labels, stored in lower case
+	Tags []string `json:"tags,omitempty" binding:"omitempty,max=10,dive,required,max=30"`
+	// Code from the cost center list the reservation is charged to
+	CostCenter string `json:"cost_center,omitempty" binding:"omitempty,max=50"`
+}
+
+// BookNowRequest represents the request body for booking a space immediately
+type BookNowRequest struct {
+	DurationMinutes  int    `json:"duration_minutes" binding:"required,min=15,max=480"`
+	Title            string `json:"title,omitempty" binding:"omitempty,min=2,max=200"` // Defaults to "Ad-hoc booking"
+	ParticipantCount int    `json:"participant_count,omitempty" binding:"omitempty,min=1"`
+	LicensePlate     string `json:"license_plate,omitempty" binding:"omitempty,max=20"` // Required by parking spots that check plates
+}
+
+// FindTimeRequest represents the request body for finding a slot that suits several participants
+type FindTimeRequest struct {
+	ParticipantIDs  []uuid.UUID `json:"participant_ids" binding:"required,min=1,max=50"` // The requester is always included
+	DurationMinutes int         `json:"duration_minutes" binding:"required,min=15,max=480"`
+	WindowStart     time.Time   `json:"window_start" binding:"required"`
+	WindowEnd       time.Time   `json:"window_end" binding:"required"`
+	SpaceID         *uuid.UUID  `json:"space_id,omitempty"`                                     // Only propose this space
+	WorkdayStart    string      `json:"workday_start,omitempty" binding:"omitempty,len=5"`      // HH:MM, default 09:00
+	WorkdayEnd      string      `json:"workday_end,omitempty" binding:"omitempty,len=5"`        // HH:MM, default 18:00
+	Timezone        string      `json:"timezone,omitempty" binding:"omitempty,max=50"`          // IANA name for working hours, default UTC
+	IncludeWeekends bool        `json:"include_weekends,omitempty"`                             // Propose Saturdays and Sundays too
+	IncludeHolidays bool        `json:"include_holidays,omitempty"`                             // Propose public holidays too
+	MaxResults      int         `json:"max_results,omitempty" binding:"omitempty,min=1,max=50"` // Default 10
+}
+
+// CloneReservationRequest represents the request body for copying a reservation to another time.
+// Participants, equipment, co-organizers and guests are carried over.
+type CloneReservationRequest struct {
+	StartTime     time.Time  `json:"start_time" binding:"required"`
+	EndTime       *time.Time `json:"end_time,omitempty"`                                // Defaults to the original duration
+	SpaceID       *uuid.UUID `json:"space_id,omitempty"`                                // Defaults to the original space
+	Title         string     `json:"title,omitempty" binding:"omitempty,min=2,max=200"` // Defaults to the original title
+	AcceptNearest bool       `json:"accept_nearest,omitempty"`                          // Book the nearest free slot when the exact copy conflicts
+}
+
+// BulkCancelRequest represents the request body for cancelling every reservation matching the filters (admin only)
+type BulkCancelRequest struct {
+	SpaceID       *uuid.UUID `json:"space_id,omitempty"`
+	UserID        *uuid.UUID `json:"user_id,omitempty"`
+	Status        string     `json:"status,omitempty" binding:"omitempty,oneof=pending confirmed"` // Default both
+	StartDate     *time.Time `json:"start_date,omitempty"`                                         // Reservations starting at or after
+	EndDate       *time.Time `json:"end_date,omitempty"`                                           // Reservations ending at or before
+	Reason        string     `json:"reason" binding:"required,min=3,max=500"`
+	DryRun        bool       `json:"dry_run,omitempty"`        // Only count the matches
+	ExpectedCount *int       `json:"expected_count,omitempty"` // Required to cancel; the count from the dry run
+}
+
+// CreateFloorConsolidationRequest represents the request body for keeping only some floors of a building open (admin only)
+type CreateFloorConsolidationRequest struct {
+	Building   string `json:"building" binding:"required,max=100"`
+	OpenFloors []int  `json:"open_floors" binding:"required,min=1,max=50"`
+	StartDate  string `json:"start_date" binding:"required"` // YYYY-MM-DD
+	EndDate    string `json:"end_date" binding:"required"`   // YYYY-MM-DD, inclusive
+	Reason     string `json:"reason,omitempty" binding:"max=500"`
+}
+
+// CreateBuildingClosureRequest represents the request body for closing a building or one of its floors (admin only)
+type CreateBuildingClosureRequest struct {
+	Building  string `json:"building" binding:"required,max=100"`
+	Floor     *int   `json:"floor,omitempty"`               // Omit to close every floor
+	StartDate string `json:"start_date" binding:"required"` // YYYY-MM-DD
+	EndDate   string `json:"end_date" binding:"required"`   // YYYY-MM-DD, inclusive
+	Reason    string `json:"reason,omitempty" binding:"max=500"`
+}
+
+// CreateEmergencyBroadcastRequest represents the request body for announcing an emergency in a building (admin only)
+type CreateEmergencyBroadcastRequest struct {
+	Building string `json:"building" binding:"required,max=100"`
+	Title    string `json:"title" binding:"required,min=2,max=200"`
+	Message  string `json:"message" binding:"required,max=2000"`
+	Action   string `json:"action" binding:"required,oneof=check_out suspend"` // What happens to meetings checked in
+}
+
+// SetPlacementPolicyRequest represents the request body for choosing where "any suitable room" bookings go in a building (admin only)
+type SetPlacementPolicyRequest struct {
+	Strategy string `json:"strategy" binding:"required,oneof=smallest_fit fill_floors balance_wear low_energy"`
+}
+
+// SetCostCenterRequest represents the request body for adding or changing a cost center (admin only)
+type SetCostCenterRequest struct {
+	Name     string `json:"name" binding:"required,min=2,max=200"`
+	IsActive *bool  `json:"is_active,omitempty"` // Defaults to true
+}
+
+// SetApprovalRuleRequest represents the request body for the approval rule of a space (admin only).
+// Bookings within every threshold given are confirmed without approval; omitted thresholds are not checked.
+type SetApprovalRuleRequest struct {
+	AutoApproveMaxMinutes      *int     `json:"auto_approve_max_minutes,omitempty" binding:"omitempty,min=1,max=10080" validate:"omitempty,min=1,max=10080"`
+	AutoApproveMaxParticipants *int     `json:"auto_approve_max_participants,omitempty" binding:"omitempty,min=1" validate:"omitempty,min=1"`
+	AutoApproveMinTenureDays   *int     `json:"auto_approve_min_tenure_days,omitempty" binding:"omitempty,min=0,max=3650" validate:"omitempty,min=0,max=3650"`
+	AutoApproveRoles           []string `json:"auto_approve_roles,omitempty" binding:"omitempty,dive,oneof=user manager admin" validate:"omitempty,dive,oneof=user manager admin"`
+	TwoStep                    *bool    `json:"two_step,omitempty"` // Defaults to true for auditoriums
+}
+
+// SaveFloorPlanRequest represents the request body for uploading the plan of a floor (admin only).
+// Exactly one of SVG and ImageURL is given; seat coordinates use the width and height as units.
+type SaveFloorPlanRequest struct {
+	Building string `json:"building" binding:"required,max=50"`
+	Floor    int    `json:"floor"`
+	SVG      string `json:"svg,omitempty" binding:"max=2000000"`
+	ImageURL string `json:"image_url,omitempty" binding:"omitempty,url,max=500"`
+	Width    int    `json:"width" binding:"required,min=1,max=100000"`
+	Height   int    `json:"height" binding:"required,min=1,max=100000"`
+}
+
+// SetFloorPlanSeatsRequest represents the request body for placing the desks of a floor plan (admin only)
+type SetFloorPlanSeatsRequest struct {
+	Seats []FloorPlanSeatRequest `json:"seats" binding:"max=1000,dive"`
+}
+
+// FloorPlanSeatRequest represents one desk placed on a floor plan
+type FloorPlanSeatRequest struct {
+	SpaceID        uuid.UUID  `json:"space_id" binding:"required"`
+	X              float64    `json:"x" binding:"min=0"`
+	Y              float64    `json:"y" binding:"min=0"`
+	NeighborhoodID *uuid.UUID `json:"neighborhood_id,omitempty"`
+}
+
+// CreateNeighborhoodRequest represents the request body for creating a team zone on a floor plan (admin only)
+type CreateNeighborhoodRequest struct {
+	Name        string   `json:"name" binding:"required,min=2,max=100"`
+	Color       string   `json:"color,omitempty" binding:"omitempty,hexcolor,len=7"`
+	Departments []string `json:"departments,omitempty" binding:"max=50,dive,required,max=100"` // Empty lets every team book its desks
+}
+
+// PickSeatRequest represents the request body for booking the desk clicked on a floor plan
+type PickSeatRequest struct {
+	X         float64   `json:"x" binding:"min=0"`
+	Y         float64   `json:"y" binding:"min=0"`
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required"`
+	Title     string    `json:"title,omitempty" binding:"omitempty,min=2,max=200"`
+}
+
+// SetCoOrganizersRequest represents the request body for replacing the co-organizers of a reservation
+type SetCoOrganizersRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids" binding:"max=10"` // Empty removes every co-organizer
+}
+
+// CreateReservationTaskRequest represents the request body for adding a task to a reservation's checklist
+type CreateReservationTaskRequest struct {
+	Title           string     `json:"title" binding:"required,min=2,max=200"`
+	DueAt           *time.Time `json:"due_at,omitempty"`
+	ReminderMinutes *int       `json:"reminder_minutes,omitempty" binding:"omitempty,min=0,max=10080"` // Remind this long before due_at
+}
+
+// UpdateReservationTaskRequest represents the request body for editing or ticking off a checklist task
+type UpdateReservationTaskRequest struct {
+	Title           *string    `json:"title,omitempty" binding:"omitempty,min=2,max=200"`
+	DueAt           *time.Time `json:"due_at,omitempty"`
+	ReminderMinutes *int       `json:"reminder_minutes,omitempty" binding:"omitempty,min=0,max=10080"`
+	Position        *int       `json:"position,omitempty" binding:"omitempty,min=0"`
+	Completed       *bool      `json:"completed,omitempty"`
+}
+
+// SetChecklistTemplateRequest represents the request body for the checklist of a space type (admin only)
+type SetChecklistTemplateRequest struct {
+	Items []ChecklistTemplateItemRequest `json:"items" binding:"required,min=1,max=30,dive"`
+}
+
+// ChecklistTemplateItemRequest represents a task of a checklist template, due relative to the reservation start
+type ChecklistTemplateItemRequest struct {
+	Title            string `json:"title" binding:"required,min=2,max=200"`
+	DueBeforeMinutes *int   `json:"due_before_minutes,omitempty" binding:"omitempty,min=0,max=43200"`
+	ReminderMinutes  *int   `json:"reminder_minutes,omitempty" binding:"omitempty,min=0,max=10080"`
 }
 
 // UpdateReservationRequest represents the request body for updating a reservation
@@ -276,6 +649,10 @@ type UpdateReservationRequest struct {
 	ParticipantCount *int       `json:"participant_count,omitempty" binding:"omitempty,min=1"`
 	Title            *string    `json:"title,omitempty" binding:"omitempty,min=2,max=200"`
 	Description      *string    `json:"description,omitempty"`
+	IsPrivate        *bool      `json:"is_private,omitempty"`
+	Tags             []string   `json:"tags,omitempty" binding:"omitempty,max=10,dive,required,max=30"` // Replaces the tags; [] removes them
+	CostCenter       *string    `json:"cost_center,omitempty" binding:"omitempty,max=50"`               // Empty removes it
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`                                           // updated_at as last read; the update fails with 409 if it changed since
 }
 
 // RecurrencePattern represents recurrence configuration
@@ -285,6 +662,149 @@ type RecurrencePattern struct {
 	DaysOfWeek     []int      `json:"days_of_week,omitempty" binding:"omitempty,dive,min=0,max=6"`
 	EndDate        *time.Time `json:"end_date,omitempty"`
 	MaxOccurrences *int       `json:"max_occurrences,omitempty" binding:"omitempty,min=1,max=100"`
+	OnClosedDay    string     `json:"on_closed_day,omitempty" binding:"omitempty,oneof=skip shift"` // Occurrences on holidays and closure days: skip (default), or shift to the next open weekday
+}
+
+// AddGuestsRequest represents the request body for inviting external guests to a reservation
+type AddGuestsRequest struct {
+	Guests []GuestRequest `json:"guests" binding:"required,min=1,max=20,dive"`
+}
+
+// GuestRequest represents one external guest
+type GuestRequest struct {
+	Name    string `json:"name" binding:"required,min=2,max=200"`
+	Email   string `json:"email" binding:"required,email,max=255"`
+	Company string `json:"company,omitempty" binding:"omitempty,max=200"`
+}
+
+// GuestRSVPRequest represents a guest's answer to an invitation
+type GuestRSVPRequest struct {
+	Response string `json:"response" binding:"required,oneof=accepted declined"`
+}
+
+// CreateRoomDisplayRequest represents the request body for registering a door display
+type CreateRoomDisplayRequest struct {
+	Name string `json:"name" binding:"required,min=2,max=100"`
+}
+
+// CreateIntegrationKeyRequest represents the request body for creating an API key for a
+// third-party system, such as a reception's visitor management system
+type CreateIntegrationKeyRequest struct {
+	Name      string   `json:"name" binding:"required,min=2,max=100"`
+	Scopes    []string `json:"scopes" binding:"required,min=1,unique,dive,oneof=visitors:read visitors:check_in badges:read events:read"`
+	Buildings []string `json:"buildings,omitempty" binding:"omitempty,max=50,unique,dive,min=1,max=100"` // Omit to cover every building
+}
+
+// VisitorCheckInRequest represents a visitor checked in by a reception system, identified by
+// guest ID or by the pass token read from their guest pass
+type VisitorCheckInRequest struct {
+	GuestID   *uuid.UUID `json:"guest_id,omitempty"`
+	PassToken string     `json:"pass_token,omitempty" binding:"omitempty,max=64"`
+}
+
+// Validate checks that exactly one way of identifying the visitor is given
+func (r *VisitorCheckInRequest) Validate() error {
+	var fields ValidationErrors
+	switch {
+	case r.GuestID != nil && r.PassToken != "":
+		fields.Add("guest_id", ValidationExclusive, map[string]interface{}{"other": "pass_token"})
+	case r.GuestID == nil && r.PassToken == "":
+		fields.Add("guest_id", ValidationRequiredWith, map[string]interface{}{"other": "no pass_token"})
+	}
+	return fields.Err()
+}
+
+// CreateWidgetTokenRequest represents the request body for creating a booking widget token,
+// scoped to either one space or one building
+type CreateWidgetTokenRequest struct {
+	Name           string     `json:"name" binding:"required,min=2,max=100"`
+	SpaceID        *uuid.UUID `json:"space_id,omitempty"`
+	Building       string     `json:"building,omitempty" binding:"omitempty,max=50"`
+	AllowedOrigins []string   `json:"allowed_origins,omitempty" binding:"omitempty,max=20,dive,url"` // e.g. https://www.example.com
+}
+
+// Validate validates the scope of the widget token
+func (r *CreateWidgetTokenRequest) Validate() error {
+	var fields ValidationErrors
+	switch {
+	case r.SpaceID != nil && r.Building != "":
+		fields.Add("space_id", ValidationExclusive, map[string]interface{}{"other": "building"})
+	case r.SpaceID == nil && r.Building == "":
+		fields.Add("space_id", ValidationRequiredWith, map[string]interface{}{"other": "no building"})
+	}
+	return fields.Err()
+}
+
+// WidgetBookingRequest represents a booking request sent by an embedded booking widget.
+// The space is required for widgets covering a whole building.
+type WidgetBookingRequest struct {
+	SpaceID          *uuid.UUID `json:"space_id,omitempty"`
+	StartTime        time.Time  `json:"start_time" binding:"required"`
+	EndTime          time.Time  `json:"end_time" binding:"required"`
+	Title            string     `json:"title" binding:"required,min=2,max=200"`
+	ParticipantCount int        `json:"participant_count" binding:"required,min=1,max=1000"`
+	Name             string     `json:"name" binding:"required,min=2,max=200"` // Requester, who has no account
+	Email            string     `json:"email" binding:"required,email,max=255"`
+	Notes            string     `json:"notes,omitempty" binding:"omitempty,max=2000"`
+}
+
+// Validate validates the time slot of the booking request
+func (r *WidgetBookingRequest) Validate() error {
+	var fields ValidationErrors
+	if !r.StartTime.Before(r.EndTime) {
+		fields.Add("start_time", ValidationBefore, map[string]interface{}{"other": "end_time"})
+	}
+	return fields.Err()
+}
+
+// CreateOccupancySensorRequest represents the request body for registering an occupancy sensor
+type CreateOccupancySensorRequest struct {
+	Name string `json:"name" binding:"required,min=2,max=100"`
+}
+
+// OccupancySampleRequest represents a headcount reported by an occupancy sensor
+type OccupancySampleRequest struct {
+	Headcount  *int       `json:"headcount" binding:"required,min=0,max=10000"`
+	RecordedAt *time.Time `json:"recorded_at,omitempty"` // Defaults to when the sample is received
+}
+
+// SetQuestionnaireRequest represents the request body for configuring the check-in questionnaire of a space
+type SetQuestionnaireRequest struct {
+	Title     string                  `json:"title" binding:"required,min=2,max=200"`
+	Questions []QuestionnaireQuestion `json:"questions" binding:"required,min=1,max=30,dive"`
+	IsActive  *bool                   `json:"is_active,omitempty"`
+}
+
+// QuestionnaireQuestion represents one question of a check-in questionnaire
+type QuestionnaireQuestion struct {
+	ID             string              `json:"id" binding:"required,max=50"`
+	Text           string              `json:"text" binding:"required,max=500"`
+	Type           models.QuestionType `json:"type" binding:"required,oneof=yes_no choice text"`
+	Required       bool                `json:"required"`
+	Options        []string            `json:"options,omitempty" binding:"omitempty,max=20"`
+	ExpectedAnswer string              `json:"expected_answer,omitempty" binding:"omitempty,max=200"`
+}
+
+// SubmitQuestionnaireRequest represents a user's answers keyed by question ID
+type SubmitQuestionnaireRequest struct {
+	Answers map[string]string `json:"answers" binding:"required"`
+}
+
+// CreateWebhookRequest represents the request body for subscribing an endpoint to events
+type CreateWebhookRequest struct {
+	Name          string   `json:"name" binding:"required,min=2,max=100"`
+	URL           string   `json:"url" binding:"required,url,max=500"`
+	Events        []string `json:"events" binding:"required,min=1,dive,oneof=reservation_created reservation_updated reservation_cancelled"`
+	SchemaVersion int      `json:"schema_version,omitempty" binding:"omitempty,min=1"` // Defaults to the current version
+}
+
+// UpdateWebhookRequest represents the request body for changing a webhook subscription
+type UpdateWebhookRequest struct {
+	Name          *string  `json:"name,omitempty" binding:"omitempty,min=2,max=100"`
+	URL           *string  `json:"url,omitempty" binding:"omitempty,url,max=500"`
+	Events        []string `json:"events,omitempty" binding:"omitempty,min=1,dive,oneof=reservation_created reservation_updated reservation_cancelled"`
+	SchemaVersion *int     `json:"schema_version,omitempty" binding:"omitempty,min=1"`
+	IsActive      *bool    `json:"is_active,omitempty"`
 }
 
 // ReservationSearchRequest represents the request for searching reservations
@@ -301,12 +821,47 @@ type ReservationSearchRequest struct {
 	RequiresApproval *bool      `json:"requires_approval,omitempty" form:"requires_approval"`
 	IncludeCheckedIn *bool      `json:"include_checked_in,omitempty" form:"include_checked_in"`
 	IncludeNoShows   *bool      `json:"include_no_shows,omitempty" form:"include_no_shows"`
+	Tags             []string   `json:"tags,omitempty" form:"tags" binding:"omitempty,max=10"` // Reservations carrying all of them
+	CostCenter       string     `json:"cost_center,omitempty" form:"cost_center" binding:"omitempty,max=50"`
 	SortBy           string     `json:"sort_by,omitempty" form:"sort_by" binding:"omitempty,oneof=start_time end_time created_at title participant_count"`
 	SortOrder        string     `json:"sort_order,omitempty" form:"sort_order" binding:"omitempty,oneof=asc desc"`
 	Page             int        `json:"page,omitempty" form:"page" binding:"omitempty,min=1"`
 	Limit            int        `json:"limit,omitempty" form:"limit" binding:"omitempty,min=1,max=100"`
 }
 
+// Relations of a reservation list, selected with ?include=
+const (
+	IncludeUser         = "user"
+	IncludeSpace        = "space"
+	IncludeApprover     = "approver"
+	IncludeCoOrganizers = "co_organizers"
+)
+
+// ReservationIncludes selects the relations loaded with a reservation list; nil loads all of them
+type ReservationIncludes map[string]bool
+
+// ParseReservationIncludes reads a comma-separated ?include= list, e.g. "space,user". An
+// empty list loads no relation.
+func ParseReservationIncludes(value string) (ReservationIncludes, error) {
+	include := ReservationIncludes{}
+	for _, relation := range strings.Split(value, ",") {
+		relation = strings.TrimSpace(relation)
+		switch relation {
+		case "":
+		case IncludeUser, IncludeSpace, IncludeApprover, IncludeCoOrganizers:
+			include[relation] = true
+		default:
+			return nil, fmt.Errorf("unknown include %q, expected user, space, approver or co_organizers", relation)
+		}
+	}
+	return include, nil
+}
+
+// Has tells whether the list loads a relation
+func (i ReservationIncludes) Has(relation string) bool {
+	return i == nil || i[relation]
+}
+
 // ApprovalRequest represents a request to approve or reject a reservation
 type ApprovalRequest struct {
 	Action   string `json:"action" binding:"required,oneof=approve reject"`
@@ -316,10 +871,10 @@ type ApprovalRequest struct {
 
 // BulkApprovalRequest represents a bulk approval/rejection request
 type BulkApprovalRequest struct {
-	ReservationIDs []uuid.UUID `json:"reservation_ids" binding:"required,min=1"`
+	ReservationIDs []uuid.UUID `json:"reservation_ids" binding:"required,min=1,max=100"`
 	Action         string      `json:"action" binding:"required,oneof=approve reject"`
 	Comments       string      `json:"comments,omitempty"`
-	Reason         string      `json:"reason,omitempty"`
+	Reason         string      `json:"reason,omitempty"` // Required to reject, sent to every requester
 }
 
 // CancelReservationRequest represents a cancellation request
@@ -333,6 +888,12 @@ type CancelReservationRequest struct {
 type CheckInRequest struct {
 	CheckInTime *time.Time `json:"check_in_time,omitempty"`
 	Notes       string     `json:"notes,omitempty"`
+
+	// Proof of presence, required by spaces with a presence check
+	BeaconID  string   `json:"beacon_id,omitempty" binding:"omitempty,max=100"`          // BLE beacon seen by the device
+	Latitude  *float64 `json:"latitude,omitempty" binding:"omitempty,min=-90,max=90"`    // Device GPS position
+	Longitude *float64 `json:"longitude,omitempty" binding:"omitempty,min=-180,max=180"` // Device GPS position
+	Accuracy  float64  `json:"accuracy,omitempty" binding:"omitempty,min=0"`             // Reported GPS accuracy in meters
 }
 
 // CheckOutRequest represents a check-out request
@@ -340,7 +901,7 @@ type CheckOutRequest struct {
 	CheckOutTime *time.Time `json:"check_out_time,omitempty"`
 	Notes        string     `json:"notes,omitempty"`
 	Rating       *int       `json:"rating,omitempty" binding:"omitempty,min=1,max=5"`
-	Feedback     string     `json:"feedback,omitempty"`
+	Feedback     string     `json:"feedback,omitempty" binding:"max=2000"`
 }
 
 // NoShowRequest represents a no-show report request
@@ -390,6 +951,88 @@ type ReservationStatsRequest struct {
 	IncludeNoShows bool        `json:"include_no_shows,omitempty" form:"include_no_shows"`
 }
 
+// SheetImportRequest represents a request to import reservations from a shared Google Sheet
+type SheetImportRequest struct {
+	SheetURL      string             `json:"sheet_url" binding:"required,url"`
+	ColumnMapping SheetColumnMapping `json:"column_mapping"`
+	Timezone      string             `json:"timezone,omitempty"`
+	ApprovedRows  []int              `json:"approved_rows,omitempty" binding:"omitempty,dive,min=2"` // Sheet row numbers to commit (header is row 1)
+}
+
+// SheetColumnMapping maps reservation fields to sheet header names
+type SheetColumnMapping struct {
+	Space            string `json:"space,omitempty"` // Space ID or space name
+	StartTime        string `json:"start_time,omitempty"`
+	EndTime          string `json:"end_time,omitempty"`
+	Title            string `json:"title,omitempty"`
+	ParticipantCount string `json:"participant_count,omitempty"`
+	Description      string `json:"description,omitempty"`
+}
+
+// SetDefaults sets default header names for unmapped columns
+func (m *SheetColumnMapping) SetDefaults() {
+	if m.Space == "" {
+		m.Space = "space"
+	}
+	if m.StartTime == "" {
+		m.StartTime = "start_time"
+	}
+	if m.EndTime == "" {
+		m.EndTime = "end_time"
+	}
+	if m.Title == "" {
+		m.Title = "title"
+	}
+	if m.ParticipantCount == "" {
+		m.ParticipantCount = "participant_count"
+	}
+	if m.Description == "" {
+		m.Description = "description"
+	}
+}
+
+// Validate validates the sheet import request
+func (r *SheetImportRequest) Validate() error {
+	var fields ValidationErrors
+	if !strings.Contains(r.SheetURL, "docs.google.com/spreadsheets/d/") {
+		fields.Add("sheet_url", ValidationInvalid, map[string]interface{}{"rule": "google_sheets_url"})
+	}
+
+	if r.Timezone != "" {
+		if _, err := time.LoadLocation(r.Timezone); err != nil {
+			fields.Add("timezone", ValidationTimezone, nil)
+		}
+	}
+
+	return fields.Err()
+}
+
+// Conflict strategies of a CSV reservation import
+const (
+	ImportConflictSkip          = "skip"           // Leave out rows clashing with a booking
+	ImportConflictForce         = "force"          // Book them anyway, as the legacy system had them
+	ImportConflictQueueApproval = "queue_approval" // Book them as pending for a manager to settle
+)
+
+// CSVImportRequest represents the options of a reservation import from a CSV file, sent as
+// multipart form fields along with the file (admin only)
+type CSVImportRequest struct {
+	DryRun     bool   `form:"dry_run"`                                                         // Only validate and report what would be booked
+	OnConflict string `form:"on_conflict" binding:"omitempty,oneof=skip force queue_approval"` // Defaults to skip
+	Timezone   string `form:"timezone"`                                                        // Of dates without an offset, defaults to the server's
+}
+
+// Validate validates the CSV import options
+func (r *CSVImportRequest) Validate() error {
+	var fields ValidationErrors
+	if r.Timezone != "" {
+		if _, err := time.LoadLocation(r.Timezone); err != nil {
+			fields.Add("timezone", ValidationTimezone, nil)
+		}
+	}
+	return fields.Err()
+}
+
 // SetDefaults sets default values for search request
 func (r *ReservationSearchRequest) SetDefaults() {
 	if r.Page == 0 {
@@ -413,113 +1056,511 @@ func (r *ReservationSearchRequest) GetOffset() int {
 
 // Validate validates the create reservation request
 func (r *CreateReservationRequest) Validate() error {
+	var fields ValidationErrors
 	if r.StartTime.After(r.EndTime) {
-		return errors.New("start time must be before end time")
+		fields.Add("start_time", ValidationBefore, map[string]interface{}{"other": "end_time"})
+	} else {
+		validateReservationDuration(&fields, r.EndTime.Sub(r.StartTime))
 	}
 
 	if r.StartTime.Before(time.Now()) {
-		return errors.New("start time must be in the future")
+		fields.Add("start_time", ValidationFuture, nil)
+	}
+
+	if r.OverrideVIPBlock && strings.TrimSpace(r.OverrideJustification) == "" {
+		fields.Add("override_justification", ValidationRequiredWith, map[string]interface{}{"other": "override_vip_block"})
 	}
 
-	// Check minimum duration (15 minutes)
-	if r.EndTime.Sub(r.StartTime) < 15*time.Minute {
-		return errors.New("reservation must be at least 15 minutes long")
+	if r.OverrideDuplicate && strings.TrimSpace(r.DuplicateJustification) == "" {
+		fields.Add("duplicate_justification", ValidationRequiredWith, map[string]interface{}{"other": "override_duplicate"})
 	}
 
-	// Check maximum duration (12 hours)
-	if r.EndTime.Sub(r.StartTime) > 12*time.Hour {
-		return errors.New("reservation cannot exceed 12 hours")
+	if r.Preempt && strings.TrimSpace(r.PreemptJustification) == "" {
+		fields.Add("preempt_justification", ValidationRequiredWith, map[string]interface{}{"other": "preempt"})
+	}
+	if r.Preempt && r.IsRecurring {
+		fields.Add("preempt", ValidationExclusive, map[string]interface{}{"other": "is_recurring"})
 	}
 
 	// Validate recurrence pattern if recurring
 	if r.IsRecurring {
 		if r.RecurrencePattern == nil {
-			return errors.New("recurrence pattern is required for recurring reservations")
-		}
-
-		if err := r.RecurrencePattern.Validate(); err != nil {
-			return err
+			fields.Add("recurrence_pattern", ValidationRequiredWith, map[string]interface{}{"other": "is_recurring"})
+		} else if err := r.RecurrencePattern.Validate(); err != nil {
+			var patternFields ValidationErrors
+			if !errors.As(err, &patternFields) {
+				return err
+			}
+			fields = append(fields, patternFields.Prefixed("recurrence_pattern")...)
 		}
 	}
 
-	return nil
+	return fields.Err()
+}
+
+// validateReservationDuration checks a booking lasts between 15 minutes and 12 hours
+func validateReservationDuration(fields *ValidationErrors, duration time.Duration) {
+	if duration < 15*time.Minute {
+		fields.Add("end_time", ValidationMinDuration, map[string]interface{}{"minutes": 15})
+	}
+	if duration > 12*time.Hour {
+		fields.Add("end_time", ValidationMaxDuration, map[string]interface{}{"hours": 12})
+	}
 }
 
 // Validate validates the update reservation request
 func (r *UpdateReservationRequest) Validate() error {
+	var fields ValidationErrors
 	if r.StartTime != nil && r.EndTime != nil {
 		if r.StartTime.After(*r.EndTime) {
-			return errors.New("start time must be before end time")
+			fields.Add("start_time", ValidationBefore, map[string]interface{}{"other": "end_time"})
+		} else {
+			validateReservationDuration(&fields, r.EndTime.Sub(*r.StartTime))
 		}
+	}
 
-		// Check minimum duration
-		if r.EndTime.Sub(*r.StartTime) < 15*time.Minute {
-			return errors.New("reservation must be at least 15 minutes long")
-		}
+	return fields.Err()
+}
+
+// Validate validates the VIP block request
+func (r *CreateVIPBlockRequest) Validate() error {
+	var fields ValidationErrors
+	start, startErr := time.Parse("15:04", r.StartTime)
+	if startErr != nil {
+		fields.Add("start_time", ValidationTimeFormat, map[string]interface{}{"format": "HH:MM"})
+	}
+
+	end, endErr := time.Parse("15:04", r.EndTime)
+	if endErr != nil {
+		fields.Add("end_time", ValidationTimeFormat, map[string]interface{}{"format": "HH:MM"})
+	}
 
-		// Check maximum duration
-		if r.EndTime.Sub(*r.StartTime) > 12*time.Hour {
-			return errors.New("reservation cannot exceed 12 hours")
+	if startErr == nil && endErr == nil && !end.After(start) {
+		fields.Add("start_time", ValidationBefore, map[string]interface{}{"other": "end_time"})
+	}
+
+	if r.Timezone != "" {
+		if _, err := time.LoadLocation(r.Timezone); err != nil {
+			fields.Add("timezone", ValidationTimezone, nil)
 		}
 	}
 
-	return nil
+	return fields.Err()
 }
 
 // Validate validates the recurrence pattern
 func (r *RecurrencePattern) Validate() error {
+	var fields ValidationErrors
 	if r.Type == "weekly" && len(r.DaysOfWeek) == 0 {
-		return errors.New("days of week must be specified for weekly recurrence")
+		fields.Add("days_of_week", ValidationRequiredWith, map[string]interface{}{"other": "type=weekly"})
 	}
 
 	if r.EndDate != nil && r.MaxOccurrences != nil {
-		return errors.New("cannot specify both end date and max occurrences")
+		fields.Add("end_date", ValidationExclusive, map[string]interface{}{"other": "max_occurrences"})
 	}
 
 	if r.EndDate == nil && r.MaxOccurrences == nil {
-		return errors.New("must specify either end date or max occurrences for recurrence")
+		fields.Add("end_date", ValidationRequired, nil)
 	}
 
 	if r.EndDate != nil && r.EndDate.Before(time.Now()) {
-		return errors.New("recurrence end date must be in the future")
+		fields.Add("end_date", ValidationFuture, nil)
 	}
 
-	// Validate days of week
-	for _, day := range r.DaysOfWeek {
-		if day < 0 || day > 6 {
-			return errors.New("days of week must be between 0 (Sunday) and 6 (Saturday)")
+	// Days run from 0 (Sunday) to 6 (Saturday)
+	for i, day := range r.DaysOfWeek {
+		if day < 0 {
+			fields.Add(fmt.Sprintf("days_of_week[%d]", i), ValidationMin, map[string]interface{}{"min": 0})
+		} else if day > 6 {
+			fields.Add(fmt.Sprintf("days_of_week[%d]", i), ValidationMax, map[string]interface{}{"max": 6})
 		}
 	}
 
-	return nil
+	return fields.Err()
 }
 
 // Validate validates the search request
 func (r *ReservationSearchRequest) Validate() error {
+	var fields ValidationErrors
 	if r.MinParticipants != nil && r.MaxParticipants != nil && *r.MinParticipants > *r.MaxParticipants {
-		return errors.New("min participants must be less than or equal to max participants")
+		fields.Add("min_participants", ValidationNotAbove, map[string]interface{}{"other": "max_participants"})
 	}
 
 	if r.StartDate != nil && r.EndDate != nil && r.StartDate.After(*r.EndDate) {
-		return errors.New("start date must be before end date")
+		fields.Add("start_date", ValidationBefore, map[string]interface{}{"other": "end_date"})
 	}
 
-	return nil
+	return fields.Err()
 }
 
 // Validate validates the availability check request
 func (r *AvailabilityCheckRequest) Validate() error {
+	var fields ValidationErrors
 	if r.StartTime.After(r.EndTime) {
-		return errors.New("start time must be before end time")
+		fields.Add("start_time", ValidationBefore, map[string]interface{}{"other": "end_time"})
+	} else if r.EndTime.Sub(r.StartTime) < 15*time.Minute {
+		fields.Add("end_time", ValidationMinDuration, map[string]interface{}{"minutes": 15})
+	}
+
+	if r.StartTime.Before(time.Now()) {
+		fields.Add("start_time", ValidationFuture, nil)
+	}
+
+	return fields.Err()
+}
+
+// UpdateUserPreferencesRequest replaces the defaults applied to the user's new reservations
+type UpdateUserPreferencesRequest struct {
+	PreferredBuilding      string      `json:"preferred_building,omitempty" binding:"omitempty,max=50"`
+	PreferredFloor         *int        `json:"preferred_floor,omitempty"`
+	DefaultDurationMinutes int         `json:"default_duration_minutes,omitempty" binding:"omitempty,min=15,max=720"`
+	DefaultParticipantIDs  []uuid.UUID `json:"default_participant_ids,omitempty" binding:"omitempty,max=50"`
+	DefaultPrivate         bool        `json:"default_private"`
+}
+
+// SetQuotaOverrideRequest represents the request body replacing the quota of a user or team (admin only)
+type SetQuotaOverrideRequest struct {
+	LimitHours int        `json:"limit_hours" binding:"min=0,max=10000"` // 0 lifts the quota
+	Reason     string     `json:"reason,omitempty" binding:"omitempty,max=500"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"` // Back to the configured quota afterwards
+}
+
+// UpdateDeadLetterRequest replaces the payload of a dead letter before it is replayed (admin only)
+type UpdateDeadLetterRequest struct {
+	Payload json.RawMessage `json:"payload" binding:"required"`
+}
+
+// ReplayDeadLettersRequest selects open dead letters to replay at once (admin only). Without
+// IDs every open dead letter of the source is replayed, up to the batch limit.
+type ReplayDeadLettersRequest struct {
+	IDs    []uuid.UUID `json:"ids,omitempty" binding:"omitempty,max=500"`
+	Source string      `json:"source,omitempty" binding:"omitempty,oneof=notification webhook job"`
+}
+
+// FixDataQualityRequest applies the fix-up action of one kind of data quality issue (admin
+// only). Without IDs every issue of the kind currently found is fixed.
+type FixDataQualityRequest struct {
+	Type string      `json:"type" binding:"required,oneof=check_in_without_confirmation overlapping_confirmed orphaned_participant deleted_space"`
+	IDs  []uuid.UUID `json:"ids,omitempty" binding:"omitempty,max=500"` // Record IDs of the issues to fix
+}
+
+// PublicAvailabilityRequest represents the query of the anonymous free/busy view
+type PublicAvailabilityRequest struct {
+	Date     string `form:"date"` // YYYY-MM-DD, today when empty
+	Building string `form:"building" binding:"omitempty,max=50"`
+	Type     string `form:"type" binding:"omitempty,max=50"`
+	Timezone string `form:"timezone" binding:"omitempty,max=64"` // IANA name the day is read in, UTC when empty
+}
+
+// Validate validates the public availability query
+func (r *PublicAvailabilityRequest) Validate() error {
+	var fields ValidationErrors
+	if r.Date != "" {
+		if _, err := time.Parse("2006-01-02", r.Date); err != nil {
+			fields.Add("date", ValidationTimeFormat, map[string]interface{}{"format": "YYYY-MM-DD"})
+		}
+	}
+	if r.Timezone != "" {
+		if _, err := time.LoadLocation(r.Timezone); err != nil {
+			fields.Add("timezone", ValidationTimezone, nil)
+		}
+	}
+	return fields.Err()
+}
+
+// ReportFilter keeps the rows of a custom report whose field compares to the value
+type ReportFilter struct {
+	Field    string      `json:"field" binding:"required,max=50"`
+	Operator string      `json:"operator" binding:"required,oneof=eq ne in not_in gt gte lt lte contains"`
+	Value    interface{} `json:"value"` // A list for in and not_in
+}
+
+// ReportDefinition describes a custom report (admin only): the rows of one source grouped by
+// dimensions and, with a date grain, by period, and the measures aggregated for each group.
+// GET /admin/reports/catalog lists what each source offers.
+type ReportDefinition struct {
+	Source     string         `json:"source" binding:"required,oneof=reservations spaces users"`
+	Dimensions []string       `json:"dimensions,omitempty" binding:"omitempty,max=5"`
+	Measures   []string       `json:"measures" binding:"required,min=1,max=10"`
+	Filters    []ReportFilter `json:"filters,omitempty" binding:"omitempty,max=20,dive"`
+	DateGrain  string         `json:"date_grain,omitempty" binding:"omitempty,oneof=hour day week month quarter year"`
+	From       *time.Time     `json:"from,omitempty"`                                // Included, on the date field of the source
+	To         *time.Time     `json:"to,omitempty"`                                  // Excluded
+	Timezone   string         `json:"timezone,omitempty" binding:"omitempty,max=64"` // IANA name periods are cut in, UTC when empty
+	SortBy     string         `json:"sort_by,omitempty" binding:"omitempty,max=50"`  // A column of the result; groups in order by default
+	SortOrder  string         `json:"sort_order,omitempty" binding:"omitempty,oneof=asc desc"`
+	Limit      int            `json:"limit,omitempty" binding:"omitempty,min=1,max=10000"` // Rows returned, 1000 by default
+	Async      bool           `json:"async,omitempty"`                                     // Queue the report even when it could run right away
+}
+
+// Validate validates the dates, timezone and filter values of the report definition
+func (r *ReportDefinition) Validate() error {
+	var fields ValidationErrors
+	if r.From != nil && r.To != nil && !r.From.Before(*r.To) {
+		fields.Add("from", ValidationBefore, map[string]interface{}{"other": "to"})
+	}
+	if r.Timezone != "" {
+		if _, err := time.LoadLocation(r.Timezone); err != nil {
+			fields.Add("timezone", ValidationTimezone, nil)
+		}
+	}
+	for i, filter := range r.Filters {
+		field := fmt.Sprintf("filters[%d].value", i)
+		list, isList := filter.Value.([]interface{})
+		switch {
+		case filter.Value == nil:
+			fields.Add(field, ValidationRequired, nil)
+		case filter.Operator == "in" || filter.Operator == "not_in":
+			if !isList {
+				fields.Add(field, ValidationType, map[string]interface{}{"type": "array"})
+			} else if len(list) == 0 {
+				fields.Add(field, ValidationMinItems, map[string]interface{}{"min": 1})
+			} else if len(list) > 100 {
+				fields.Add(field, ValidationMaxItems, map[string]interface{}{"max": 100})
+			}
+		case isList:
+			fields.Add(field, ValidationType, map[string]interface{}{"type": "string, number or boolean"})
+		}
+	}
+	return fields.Err()
+}
+
+// CapacityForecastRequest represents the query of a capacity planning forecast
+type CapacityForecastRequest struct {
+	Days             int    `form:"days" binding:"omitempty,min=1,max=90"`          // Days forecast from tomorrow, 28 by default
+	HistoryWeeks     int    `form:"history_weeks" binding:"omitempty,min=4,max=52"` // Weeks of past bookings the model learns from, 12 by default
+	Building         string `form:"building" binding:"omitempty,max=50"`
+	Type             string `form:"type" binding:"omitempty,max=50"`
+	ThresholdPercent int    `form:"threshold" binding:"omitempty,min=1,max=200"` // Usage from which a day is at risk, 85 by default
+}
+
+// FeedbackReportRequest represents the query of the facilities feedback report
+type FeedbackReportRequest struct {
+	Days     int    `form:"days" binding:"omitempty,min=7,max=365"` // Past days covered, 90 by default
+	Building string `form:"building" binding:"omitempty,max=50"`
+	SpaceID  string `form:"space_id" binding:"omitempty,uuid"`
+	Interval string `form:"interval" binding:"omitempty,oneof=week month"` // Period of the rating trend, week by default
+}
+
+// BookingInsightsRequest represents the query of a user's booking insights
+type BookingInsightsRequest struct {
+	Days     int    `form:"days" binding:"omitempty,min=7,max=365"` // Past days analyzed, 90 by default
+	Timezone string `form:"timezone" binding:"omitempty,max=64"`    // IANA name weekdays and hours are read in, UTC when empty
+}
+
+// Validate validates the timezone of the booking insights query
+func (r *BookingInsightsRequest) Validate() error {
+	var fields ValidationErrors
+	if r.Timezone != "" {
+		if _, err := time.LoadLocation(r.Timezone); err != nil {
+			fields.Add("timezone", ValidationTimezone, nil)
+		}
 	}
+	return fields.Err()
+}
+
+// BookingTemplateRequest represents a booking template to create or replace
+type BookingTemplateRequest struct {
+	Name             string     `json:"name" binding:"required,max=100"`
+	Title            string     `json:"title" binding:"required,min=2,max=200"`
+	Description      string     `json:"description,omitempty" binding:"omitempty,max=2000"`
+	DurationMinutes  int        `json:"duration_minutes" binding:"required,min=15,max=720"`
+	ParticipantCount int        `json:"participant_count,omitempty" binding:"omitempty,min=1"` // 1 by default
+	SpaceID          *uuid.UUID `json:"space_id,omitempty"`                                    // Preferred space
+	Building         string     `json:"building,omitempty" binding:"omitempty,max=50"`         // Any suitable room in it when no space is preferred
+	IsPrivate        bool       `json:"is_private,omitempty"`
+}
 
+// BookFromTemplateRequest represents a reservation made from a booking template; set fields
+// replace those of the template for this reservation only
+type BookFromTemplateRequest struct {
+	StartTime        time.Time  `json:"start_time" binding:"required"`
+	SpaceID          *uuid.UUID `json:"space_id,omitempty"`
+	Title            string     `json:"title,omitempty" binding:"omitempty,min=2,max=200"`
+	ParticipantCount int        `json:"participant_count,omitempty" binding:"omitempty,min=1"`
+}
+
+// Validate validates the start time of the reservation made from a template
+func (r *BookFromTemplateRequest) Validate() error {
+	var fields ValidationErrors
 	if r.StartTime.Before(time.Now()) {
-		return errors.New("start time must be in the future")
+		fields.Add("start_time", ValidationFuture, nil)
 	}
+	return fields.Err()
+}
+
+// SavedSearchRequest represents space search criteria to save or replace
+type SavedSearchRequest struct {
+	Name               string     `json:"name" binding:"required,max=100"`
+	MinCapacity        int        `json:"min_capacity,omitempty" binding:"omitempty,min=1"`
+	Types              []string   `json:"types,omitempty" binding:"omitempty,max=8,dive,oneof=meeting_room office auditorium open_space hot_desk conference_room parking_spot equipment"`
+	Building           string     `json:"building,omitempty" binding:"omitempty,max=50"`
+	Amenities          []string   `json:"amenities,omitempty" binding:"omitempty,max=10,dive,required,max=50"` // Equipment names, e.g. projector
+	WindowStart        *time.Time `json:"window_start,omitempty"`                                              // With window_end, the time wanted; any future time otherwise
+	WindowEnd          *time.Time `json:"window_end,omitempty"`
+	MinDurationMinutes int        `json:"min_duration_minutes,omitempty" binding:"omitempty,min=15,max=720"` // 30 by default
+	AlertsEnabled      *bool      `json:"alerts_enabled,omitempty"`                                          // Alert when a matching slot frees up; on by default
+}
 
-	if r.EndTime.Sub(r.StartTime) < 15*time.Minute {
-		return errors.New("time slot must be at least 15 minutes long")
+// Validate validates the time window of the saved search
+func (r *SavedSearchRequest) Validate() error {
+	var fields ValidationErrors
+	switch {
+	case r.WindowStart != nil && r.WindowEnd == nil:
+		fields.Add("window_end", ValidationRequiredWith, map[string]interface{}{"other": "window_start"})
+	case r.WindowEnd != nil && r.WindowStart == nil:
+		fields.Add("window_start", ValidationRequiredWith, map[string]interface{}{"other": "window_end"})
+	case r.WindowStart != nil:
+		if !r.WindowStart.Before(*r.WindowEnd) {
+			fields.Add("window_start", ValidationBefore, map[string]interface{}{"other": "window_end"})
+		}
+		if !r.WindowEnd.After(time.Now()) {
+			fields.Add("window_end", ValidationFuture, nil)
+		}
 	}
+	return fields.Err()
+}
+
+// CreateServiceItemRequest represents the request body for adding a service to the catalog (admin only)
+type CreateServiceItemRequest struct {
+	Name            string  `json:"name" binding:"required,min=2,max=100"`
+	Category        string  `json:"category" binding:"required,oneof=catering av_support furniture other"`
+	Description     string  `json:"description,omitempty" binding:"omitempty,max=1000"`
+	UnitPrice       float64 `json:"unit_price" binding:"min=0"`
+	LeadTimeMinutes int     `json:"lead_time_minutes" binding:"min=0,max=20160"` // Up to two weeks
+	MaxQuantity     int     `json:"max_quantity" binding:"required,min=1,max=1000"`
+}
+
+// UpdateServiceItemRequest represents the request body for editing a catalog service (admin only)
+type UpdateServiceItemRequest struct {
+	Name            *string  `json:"name,omitempty" binding:"omitempty,min=2,max=100"`
+	Description     *string  `json:"description,omitempty" binding:"omitempty,max=1000"`
+	UnitPrice       *float64 `json:"unit_price,omitempty" binding:"omitempty,min=0"` // Requests already placed keep their price
+	LeadTimeMinutes *int     `json:"lead_time_minutes,omitempty" binding:"omitempty,min=0,max=20160"`
+	MaxQuantity     *int     `json:"max_quantity,omitempty" binding:"omitempty,min=1,max=1000"`
+	IsActive        *bool    `json:"is_active,omitempty"`
+}
+
+// CreateServiceRequestRequest represents the request body for ordering a service with a reservation
+type CreateServiceRequestRequest struct {
+	ServiceItemID uuid.UUID `json:"service_item_id" binding:"required"`
+	Quantity      int       `json:"quantity" binding:"required,min=1"`
+	Notes         string    `json:"notes,omitempty" binding:"omitempty,max=1000"` // Dietary needs, setup instructions
+}
+
+// UpdateServiceRequestStatusRequest represents the request body for facility staff moving a service request along
+type UpdateServiceRequestStatusRequest struct {
+	Status     string `json:"status" binding:"required,oneof=accepted fulfilled rejected"`
+	StaffNotes string `json:"staff_notes,omitempty" binding:"omitempty,max=1000"` // Shown to the organizer, e.g. why it was rejected
+}
+
+// AttachEquipmentRequest represents the request body for attaching portable equipment to a reservation
+type AttachEquipmentRequest struct {
+	ResourceID uuid.UUID `json:"resource_id" binding:"required"`
+}
+
+// CreateTeamBlockRequest represents the request body for block-booking desks of a neighborhood for a team (team leads)
+type CreateTeamBlockRequest struct {
+	Department     string      `json:"department,omitempty" binding:"max=100"` // Defaults to the lead's own
+	NeighborhoodID uuid.UUID   `json:"neighborhood_id" binding:"required"`
+	DeskIDs        []uuid.UUID `json:"desk_ids,omitempty" binding:"max=200"`                     // Every desk of the neighborhood when empty
+	Weekdays       []int       `json:"weekdays" binding:"required,min=1,max=7,dive,min=0,max=6"` // 0=Sunday, 1=Monday, etc.
+	StartDate      string      `json:"start_date" binding:"required"`                            // YYYY-MM-DD
+	EndDate        string      `json:"end_date" binding:"required"`                              // YYYY-MM-DD, inclusive
+	DayStart       string      `json:"day_start" binding:"required"`                             // HH:MM
+	DayEnd         string      `json:"day_end" binding:"required"`                               // HH:MM
+	Timezone       string      `json:"timezone,omitempty"`
+}
+
+// Validate validates the team block request
+func (r *CreateTeamBlockRequest) Validate() error {
+	var fields ValidationErrors
+	start, startErr := time.Parse("15:04", r.DayStart)
+	if startErr != nil {
+		fields.Add("day_start", ValidationTimeFormat, map[string]interface{}{"format": "HH:MM"})
+	}
+
+	end, endErr := time.Parse("15:04", r.DayEnd)
+	if endErr != nil {
+		fields.Add("day_end", ValidationTimeFormat, map[string]interface{}{"format": "HH:MM"})
+	}
+
+	if startErr == nil && endErr == nil && !end.After(start) {
+		fields.Add("day_start", ValidationBefore, map[string]interface{}{"other": "day_end"})
+	}
+
+	if r.Timezone != "" {
+		if _, err := time.LoadLocation(r.Timezone); err != nil {
+			fields.Add("timezone", ValidationTimezone, nil)
+		}
+	}
+
+	return fields.Err()
+}
+
+// SetTeamRemoteRequest represents the request body for a team member working remotely on a day of a team block
+type SetTeamRemoteRequest struct {
+	Date   string     `json:"date" binding:"required"` // YYYY-MM-DD
+	UserID *uuid.UUID `json:"user_id,omitempty"`       // Team leads only; the caller when omitted
+}
+
+// SetCalendarRegionRequest represents the request body for the buildings sharing a working calendar (admin only)
+type SetCalendarRegionRequest struct {
+	Name      string   `json:"name" binding:"required,min=2,max=100"`
+	Buildings []string `json:"buildings" binding:"required,min=1,max=100,dive,required,max=50"`
+	Timezone  string   `json:"timezone,omitempty"` // Days are matched in this timezone, UTC by default
+}
+
+// CreateCalendarDayRequest represents the request body for a public holiday or company closure day (admin only)
+type CreateCalendarDayRequest struct {
+	Region   string `json:"region,omitempty" binding:"max=20"` // Region code; every region when empty
+	Date     string `json:"date" binding:"required"`           // YYYY-MM-DD
+	Name     string `json:"name" binding:"required,min=2,max=200"`
+	Kind     string `json:"kind" binding:"required,oneof=public_holiday company_closure"`
+	Blocking *bool  `json:"blocking,omitempty"` // Refuse bookings on the day (default), or only warn
+}
+
+// ApprovalActionRequest represents the request body for deciding a reservation from an approval email link
+type ApprovalActionRequest struct {
+	Comments string `json:"comments,omitempty" binding:"max=1000"` // Required to reject: the reason given to the organizer
+}
+
+// SetCheckInPolicyRequest represents the request body for the check-in enforcement of a space type (admin only)
+type SetCheckInPolicyRequest struct {
+	WindowMinutes int   `json:"window_minutes" binding:"required,min=5,max=240"` // Released when not checked in this long after the start
+	Enabled       *bool `json:"enabled,omitempty"`                               // On by default
+}
+
+// CreateBroadcastChannelRequest represents the request body for an announcement channel (admin only)
+type CreateBroadcastChannelRequest struct {
+	Name        string `json:"name" binding:"required,min=2,max=100"`
+	Description string `json:"description,omitempty" binding:"max=1000"`
+	Building    string `json:"building,omitempty" binding:"max=100"` // Posts go to the people using the building; to every user when empty
+}
+
+// UpdateBroadcastChannelRequest represents the request body for changing an announcement channel (admin only)
+type UpdateBroadcastChannelRequest struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,min=2,max=100"`
+	Description *string `json:"description,omitempty" binding:"omitempty,max=1000"`
+	Archived    *bool   `json:"archived,omitempty"` // Archived channels take no more posts
+}
+
+// CreateBroadcastPostRequest represents the request body for posting to an announcement channel (admin or manager)
+type CreateBroadcastPostRequest struct {
+	Kind  string `json:"kind,omitempty" binding:"omitempty,oneof=announcement maintenance"` // announcement by default
+	Title string `json:"title" binding:"required,min=2,max=200"`
+	Body  string `json:"body" binding:"required,max=5000"`
+}
 
-	return nil
+// ActivityEventsRequest represents the filters of the admin activity feed (admin only)
+type ActivityEventsRequest struct {
+	Categories []string   `form:"category" binding:"omitempty,dive,oneof=booking approval chat job"` // Repeat for several
+	ActorID    string     `form:"actor_id" binding:"omitempty,uuid"`
+	EntityType string     `form:"entity_type" binding:"omitempty,oneof=reservation conversation job"`
+	EntityID   string     `form:"entity_id" binding:"omitempty,uuid"`
+	From       *time.Time `form:"from"` // RFC 3339
+	To         *time.Time `form:"to"`   // RFC 3339, exclusive
+	Cursor     string     `form:"cursor"`
+	Limit      int        `form:"limit" binding:"omitempty,min=1,max=200"`
+	Stream     bool       `form:"stream"` // Server-sent events: replay from the cursor, then follow new events
 }
@@ -1,11 +1,17 @@
 package dto
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"room-reservation-api/internal/breaker"
+	"room-reservation-api/internal/jobs"
 	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/version"
+	"room-reservation-api/internal/websocket"
 
 	"github.com/google/uuid"
 )
@@ -41,6 +47,8 @@ type UserResponse struct {
 	ProfilePicture string          `json:"profile_picture"`
 	Department     string          `json:"department"`
 	Position       string          `json:"position"`
+	PriorityTier   int             `json:"priority_tier"`
+	Priority       int             `json:"priority"` // Effective booking priority, including the role's
 	CreatedAt      time.Time       `json:"created_at"`
 	UpdatedAt      time.Time       `json:"updated_at"`
 }
@@ -78,6 +86,8 @@ func ToUserResponse(user *models.User) UserResponse {
 		ProfilePicture: user.ProfilePicture,
 		Department:     user.Department,
 		Position:       user.Position,
+		PriorityTier:   user.PriorityTier,
+		Priority:       user.Priority(),
 		CreatedAt:      user.CreatedAt,
 		UpdatedAt:      user.UpdatedAt,
 	}
@@ -124,34 +134,475 @@ func NewPaginatedResponse(data interface{}, total int64, page, limit int) Pagina
 	}
 }
 
+// ErrInvalidCursor is returned for cursors not issued by a previous page
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks the last item of a page in keyset pagination: the value of the column the
+// list is sorted by and the ID breaking ties. Clients pass it back as ?cursor= unchanged.
+type Cursor struct {
+	Key string    `json:"k"`
+	ID  uuid.UUID `json:"id"`
+}
+
+// NewTimeCursor creates a cursor for lists sorted by a timestamp
+func NewTimeCursor(t time.Time, id uuid.UUID) Cursor {
+	return Cursor{Key: t.UTC().Format(time.RFC3339Nano), ID: id}
+}
+
+// Time reads the key of a cursor created by NewTimeCursor
+func (c Cursor) Time() (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, c.Key)
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	return t, nil
+}
+
+// Encode formats the cursor as an opaque URL-safe string
+func (c Cursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor reads a cursor from a previous page, nil for the first page
+func DecodeCursor(value string) (*Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// CursorPaginatedResponse is a page of a list paginated by cursor
+type CursorPaginatedResponse struct {
+	Data       interface{}          `json:"data"`
+	Pagination CursorPaginationMeta `json:"pagination"`
+}
+
+// CursorPaginationMeta tells how to fetch the next page
+type CursorPaginationMeta struct {
+	ItemsPerPage int    `json:"items_per_page"`
+	NextCursor   string `json:"next_cursor,omitempty"` // Empty on the last page
+	HasNextPage  bool   `json:"has_next_page"`
+}
+
+// NewCursorPaginatedResponse creates a page, with the cursor of its last item when more follow
+func NewCursorPaginatedResponse(data interface{}, limit int, next *Cursor) CursorPaginatedResponse {
+	meta := CursorPaginationMeta{ItemsPerPage: limit}
+	if next != nil {
+		meta.NextCursor = next.Encode()
+		meta.HasNextPage = true
+	}
+	return CursorPaginatedResponse{Data: data, Pagination: meta}
+}
+
 /*
 SPACE RESPONSES
 */
 type SpaceResponse struct {
-	ID                 uuid.UUID     `json:"id"`
-	Name               string        `json:"name"`
-	Type               string        `json:"type"`
-	Capacity           int           `json:"capacity"`
-	Building           string        `json:"building"`
-	Floor              int           `json:"floor"`
-	RoomNumber         string        `json:"room_number"`
-	Equipment          []Equipment   `json:"equipment"`
-	Status             string        `json:"status"`
-	Description        string        `json:"description"`
-	Surface            float64       `json:"surface"`
-	Photos             []string      `json:"photos"`
-	PricePerHour       float64       `json:"price_per_hour"`
-	PricePerDay        float64       `json:"price_per_day"`
-	PricePerMonth      float64       `json:"price_per_month"`
-	ManagerID          *uuid.UUID    `json:"manager_id"`
-	Manager            *UserResponse `json:"manager,omitempty"`
-	RequiresApproval   bool          `json:"requires_approval"`
-	BookingAdvanceTime int           `json:"booking_advance_time"`
-	MaxBookingDuration int           `json:"max_booking_duration"`
-	FullLocation       string        `json:"full_location"`
-	IsAvailable        bool          `json:"is_available"`
-	CreatedAt          time.Time     `json:"created_at"`
-	UpdatedAt          time.Time     `json:"updated_at"`
+	ID                  uuid.UUID           `json:"id"`
+	Name                string              `json:"name"`
+	Type                string              `json:"type"`
+	ResourceClass       string              `json:"resource_class"` // room, desk, parking or equipment
+	Capacity            int                 `json:"capacity"`
+	Building            string              `json:"building"`
+	Floor               int                 `json:"floor"`
+	RoomNumber          string              `json:"room_number"`
+	Equipment           []Equipment         `json:"equipment"`
+	Attributes          *ResourceAttributes `json:"attributes,omitempty"`
+	Status              string              `json:"status"`
+	Description         string              `json:"description"`
+	Surface             float64             `json:"surface"`
+	Photos              []string            `json:"photos"`
+	PrimaryPhotoURL     string              `json:"primary_photo_url,omitempty"` // Thumbnail for search results
+	PricePerHour        float64             `json:"price_per_hour"`
+	PricePerDay         float64             `json:"price_per_day"`
+	PricePerMonth       float64             `json:"price_per_month"`
+	CostPerHour         float64             `json:"cost_per_hour"`
+	ManagerID           *uuid.UUID          `json:"manager_id"`
+	Manager             *UserResponse       `json:"manager,omitempty"`
+	RequiresApproval    bool                `json:"requires_approval"`
+	BookingAdvanceTime  int                 `json:"booking_advance_time"`
+	MaxBookingDuration  int                 `json:"max_booking_duration"`
+	BufferBefore        int                 `json:"buffer_before"`
+	BufferAfter         int                 `json:"buffer_after"`
+	CancellationWindow  int                 `json:"cancellation_window"`
+	EarlyReleaseMinutes int                 `json:"early_release_minutes"`
+	PreemptionTier      int                 `json:"preemption_tier"`
+	IsVIP               bool                `json:"is_vip"`
+	IsPremium           bool                `json:"is_premium"`
+	PilotUntil          *time.Time          `json:"pilot_until,omitempty"`    // Set while the space is in soft launch
+	CombinationOf       []string            `json:"combination_of,omitempty"` // Parts of a combined space, booked with it
+	EnergyRating        string              `json:"energy_rating,omitempty"`
+	FullLocation        string              `json:"full_location"`
+	IsAvailable         bool                `json:"is_available"`
+	CurrentOccupancy    *int                `json:"current_occupancy,omitempty"` // Live headcount, set when the space has a reporting sensor
+	OccupancyUpdatedAt  *time.Time          `json:"occupancy_updated_at,omitempty"`
+	CreatedAt           time.Time           `json:"created_at"`
+	UpdatedAt           time.Time           `json:"updated_at"`
+}
+
+// FindTimeResponse represents slots when every participant and at least one space are free
+type FindTimeResponse struct {
+	DurationMinutes int              `json:"duration_minutes"`
+	Participants    []uuid.UUID      `json:"participants"`
+	Suggestions     []TimeSuggestion `json:"suggestions"`
+}
+
+// TimeSuggestion represents one proposed slot with the spaces free for it
+type TimeSuggestion struct {
+	StartTime time.Time           `json:"start_time"`
+	EndTime   time.Time           `json:"end_time"`
+	Spaces    []SuggestedSpaceRef `json:"spaces"` // Smallest fitting spaces first
+}
+
+// SuggestedSpaceRef represents a space free for a suggested slot
+type SuggestedSpaceRef struct {
+	ID               uuid.UUID `json:"id"`
+	Name             string    `json:"name"`
+	Building         string    `json:"building"`
+	Floor            int       `json:"floor"`
+	Capacity         int       `json:"capacity"`
+	RequiresApproval bool      `json:"requires_approval"`
+}
+
+// BulkCancelResponse represents the outcome of a bulk cancellation or its dry run
+type BulkCancelResponse struct {
+	ID        *uuid.UUID              `json:"id,omitempty"` // Not set for a dry run
+	DryRun    bool                    `json:"dry_run"`
+	Matched   int                     `json:"matched"`
+	Cancelled int                     `json:"cancelled"`
+	Failed    int                     `json:"failed"`
+	Sample    []BulkCancelReservation `json:"sample,omitempty"` // First matches of a dry run
+	ReportURL string                  `json:"report_url,omitempty"`
+}
+
+// BulkCancelReservation represents a reservation matched by a bulk cancellation
+type BulkCancelReservation struct {
+	ID        uuid.UUID `json:"id"`
+	Title     string    `json:"title"`
+	SpaceName string    `json:"space_name"`
+	UserEmail string    `json:"user_email"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Status    string    `json:"status"`
+}
+
+// QuotaUsageResponse represents how much of their booking quotas a user has used
+type QuotaUsageResponse struct {
+	UserID      uuid.UUID   `json:"user_id"`
+	Weekly      *QuotaUsage `json:"weekly,omitempty"`       // Hours the user can book per week
+	TeamPremium *QuotaUsage `json:"team_premium,omitempty"` // Premium-room hours the user's department can book per month
+}
+
+// QuotaUsage represents one quota over its current period
+type QuotaUsage struct {
+	Department        string     `json:"department,omitempty"`
+	PeriodStart       time.Time  `json:"period_start"`
+	PeriodEnd         time.Time  `json:"period_end"`
+	LimitHours        *int       `json:"limit_hours"` // Null when the quota does not apply
+	UsedHours         float64    `json:"used_hours"`
+	RemainingHours    *float64   `json:"remaining_hours"`
+	Overridden        bool       `json:"overridden"` // Limit set by an admin instead of the configured one
+	OverrideExpiresAt *time.Time `json:"override_expires_at,omitempty"`
+}
+
+// StrikeSummaryResponse represents a user's strikes for late cancellations and no-shows
+type StrikeSummaryResponse struct {
+	UserID          uuid.UUID               `json:"user_id"`
+	ActiveStrikes   int                     `json:"active_strikes"` // Uncleared strikes within the window
+	Limit           int                     `json:"limit"`          // Strikes that restrict premium bookings, 0 when restrictions are off
+	WindowDays      int                     `json:"window_days"`    // How long strikes count
+	Restricted      bool                    `json:"restricted"`     // Kept from booking premium spaces
+	RestrictedUntil *time.Time              `json:"restricted_until,omitempty"`
+	Strikes         []*models.BookingStrike `json:"strikes"`
+}
+
+// ChargebackReportResponse represents the cost of reservations charged back to each department per month
+type ChargebackReportResponse struct {
+	From              string          `json:"from"` // YYYY-MM
+	To                string          `json:"to"`   // YYYY-MM, included
+	Rows              []ChargebackRow `json:"rows"`
+	TotalReservations int64           `json:"total_reservations"`
+	TotalHours        float64         `json:"total_hours"`
+	TotalServicesCost float64         `json:"total_services_cost"`
+	TotalCost         float64         `json:"total_cost"`
+}
+
+// ChargebackRow represents what one department was charged in one month for one cost center
+type ChargebackRow struct {
+	Month        string  `json:"month"`
+	Department   string  `json:"department"`
+	CostCenter   string  `json:"cost_center"` // Empty for reservations without one
+	Reservations int64   `json:"reservations"`
+	Hours        float64 `json:"hours"`
+	ServicesCost float64 `json:"services_cost"` // Catering and other services delivered with the reservations
+	Cost         float64 `json:"cost"`          // Spaces and services
+}
+
+// LowUsageForecastResponse represents the booked usage of every building and floor over the coming days
+type LowUsageForecastResponse struct {
+	From             string             `json:"from"`
+	To               string             `json:"to"`
+	ThresholdPercent int                `json:"threshold_percent"`
+	Days             []DailyUsageReport `json:"days"`
+}
+
+// DailyUsageReport represents the booked usage of every building on one day
+type DailyUsageReport struct {
+	Date      string                `json:"date"`
+	Buildings []BuildingUsageReport `json:"buildings"`
+}
+
+// BuildingUsageReport represents the booked usage of a building's floors on one day
+type BuildingUsageReport struct {
+	Building            string                      `json:"building"`
+	UsagePercent        int                         `json:"usage_percent"`
+	LowUsage            bool                        `json:"low_usage"`
+	Floors              []FloorUsageReport          `json:"floors"`
+	SuggestedOpenFloors []int                       `json:"suggested_open_floors,omitempty"` // Floors able to host all bookings of a low-usage day
+	Consolidation       *FloorConsolidationResponse `json:"consolidation,omitempty"`         // Consolidation already planned for the day
+}
+
+// FloorUsageReport represents the booked usage of one floor on one day
+type FloorUsageReport struct {
+	Floor        int     `json:"floor"`
+	Capacity     int     `json:"capacity"`
+	BookedHours  float64 `json:"booked_seat_hours"`
+	UsagePercent int     `json:"usage_percent"`
+	LowUsage     bool    `json:"low_usage"`
+}
+
+// FloorConsolidationResponse represents floors of a building kept open while the others are closed
+type FloorConsolidationResponse struct {
+	ID                   uuid.UUID  `json:"id"`
+	Building             string     `json:"building"`
+	OpenFloors           []int      `json:"open_floors"`
+	StartDate            string     `json:"start_date"`
+	EndDate              string     `json:"end_date"`
+	Reason               string     `json:"reason,omitempty"`
+	CreatedByID          uuid.UUID  `json:"created_by_id"`
+	EndedAt              *time.Time `json:"ended_at,omitempty"`
+	AffectedReservations *int64     `json:"affected_reservations,omitempty"` // Existing bookings on closed floors, set on creation
+}
+
+// BuildingClosureResponse represents a building, or one of its floors, closed for a range of days
+type BuildingClosureResponse struct {
+	ID                   uuid.UUID  `json:"id"`
+	Building             string     `json:"building"`
+	Floor                *int       `json:"floor,omitempty"` // Omitted when every floor is closed
+	StartDate            string     `json:"start_date"`
+	EndDate              string     `json:"end_date"`
+	Reason               string     `json:"reason,omitempty"`
+	CreatedByID          uuid.UUID  `json:"created_by_id"`
+	EndedAt              *time.Time `json:"ended_at,omitempty"`
+	AffectedReservations *int64     `json:"affected_reservations,omitempty"` // Existing bookings flagged, set on creation
+}
+
+// TeamBlockResponse represents desks of a neighborhood block-booked for a team
+type TeamBlockResponse struct {
+	ID             uuid.UUID              `json:"id"`
+	Department     string                 `json:"department"`
+	NeighborhoodID uuid.UUID              `json:"neighborhood_id"`
+	DeskIDs        []string               `json:"desk_ids"`
+	Weekdays       []int64                `json:"weekdays"`
+	StartDate      string                 `json:"start_date"`
+	EndDate        string                 `json:"end_date"`
+	DayStart       string                 `json:"day_start"`
+	DayEnd         string                 `json:"day_end"`
+	Timezone       string                 `json:"timezone"`
+	CreatedByID    uuid.UUID              `json:"created_by_id"`
+	CancelledAt    *time.Time             `json:"cancelled_at,omitempty"`
+	Days           []TeamBlockDayResponse `json:"days,omitempty"` // Upcoming days, set when a single block is returned
+}
+
+// TeamBlockDayResponse represents who sits where on a day of a team block
+type TeamBlockDayResponse struct {
+	Date        string               `json:"date"`
+	Assignments []TeamDeskAssignment `json:"assignments"`
+	Remote      []uuid.UUID          `json:"remote"`     // Members working remotely
+	Unassigned  []uuid.UUID          `json:"unassigned"` // Members left without a desk: not enough desks, or booked elsewhere
+}
+
+// TeamDeskAssignment represents the desk booked for a team member on a day
+type TeamDeskAssignment struct {
+	UserID        uuid.UUID `json:"user_id"`
+	SpaceID       uuid.UUID `json:"space_id"`
+	ReservationID uuid.UUID `json:"reservation_id"`
+}
+
+// EmergencyBroadcastResponse represents an emergency announced in a building
+type EmergencyBroadcastResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	Building         string     `json:"building"`
+	Title            string     `json:"title"`
+	Message          string     `json:"message"`
+	Action           string     `json:"action"`
+	CheckInsAffected int        `json:"check_ins_affected"`
+	CreatedByID      uuid.UUID  `json:"created_by_id"`
+	CreatedAt        time.Time  `json:"created_at"`
+	EndedAt          *time.Time `json:"ended_at,omitempty"`
+	// Acknowledgments, set on creation and when a broadcast is retrieved on its own
+	RecipientCount    *int                         `json:"recipient_count,omitempty"`
+	AcknowledgedCount *int                         `json:"acknowledged_count,omitempty"`
+	Recipients        []EmergencyRecipientResponse `json:"recipients,omitempty"` // Not yet acknowledged first
+}
+
+// EmergencyRecipientResponse represents a user told about an emergency
+type EmergencyRecipientResponse struct {
+	UserID         uuid.UUID  `json:"user_id"`
+	Name           string     `json:"name"`
+	Email          string     `json:"email"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+}
+
+// PlacementPoliciesResponse represents the placement strategy of every building
+type PlacementPoliciesResponse struct {
+	DefaultStrategy string                    `json:"default_strategy"` // Used by buildings without a policy
+	Buildings       []BuildingPlacementPolicy `json:"buildings"`
+}
+
+// BuildingPlacementPolicy represents the placement strategy applied in one building
+type BuildingPlacementPolicy struct {
+	Building    string     `json:"building"`
+	Strategy    string     `json:"strategy"`
+	IsDefault   bool       `json:"is_default"` // The building has no policy of its own
+	UpdatedByID *uuid.UUID `json:"updated_by_id,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// FloorMapResponse represents a floor plan with the availability of its desks for a time range
+type FloorMapResponse struct {
+	ID            uuid.UUID              `json:"id"`
+	Building      string                 `json:"building"`
+	Floor         int                    `json:"floor"`
+	SVG           string                 `json:"svg,omitempty"`
+	ImageURL      string                 `json:"image_url,omitempty"`
+	Width         int                    `json:"width"`
+	Height        int                    `json:"height"`
+	StartTime     time.Time              `json:"start_time"`
+	EndTime       time.Time              `json:"end_time"`
+	Neighborhoods []NeighborhoodResponse `json:"neighborhoods"`
+	Seats         []FloorMapSeat         `json:"seats"`
+	FreeSeats     int                    `json:"free_seats"` // Seats the user can book for the whole range
+}
+
+// NeighborhoodResponse represents a team zone of a floor plan
+type NeighborhoodResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color,omitempty"`
+	Departments []string  `json:"departments"`
+}
+
+// FloorMapSeat represents a desk on a floor map.
+// Status is free, booked, unavailable (out of service or floor closed) or restricted (another team's neighborhood).
+type FloorMapSeat struct {
+	SpaceID        uuid.UUID  `json:"space_id"`
+	Name           string     `json:"name"`
+	X              float64    `json:"x"`
+	Y              float64    `json:"y"`
+	NeighborhoodID *uuid.UUID `json:"neighborhood_id,omitempty"`
+	Status         string     `json:"status"`
+}
+
+// HolidayCalendarResponse represents the public holidays of a year in the organization's country and region
+type HolidayCalendarResponse struct {
+	Country  string    `json:"country"`          // Empty when no holiday calendar is configured
+	Region   string    `json:"region,omitempty"` // Regional holidays are only included for this region
+	Timezone string    `json:"timezone"`
+	Year     int       `json:"year"`
+	Source   string    `json:"source,omitempty"` // api or embedded
+	Holidays []Holiday `json:"holidays"`
+}
+
+// ApprovalActionResponse represents the decision an approval email link makes on a pending reservation
+type ApprovalActionResponse struct {
+	Action        string     `json:"action"` // approve or reject
+	ReservationID uuid.UUID  `json:"reservation_id"`
+	Title         string     `json:"title"`
+	SpaceName     string     `json:"space_name"`
+	RequestedBy   string     `json:"requested_by"`
+	StartTime     time.Time  `json:"start_time"`
+	EndTime       time.Time  `json:"end_time"`
+	Status        string     `json:"status"`     // Of the reservation
+	ExpiresAt     time.Time  `json:"expires_at"` // Of the link
+	UsedAt        *time.Time `json:"used_at,omitempty"`
+}
+
+// RoomSwapSuggestionResponse represents a room swap suggestion shown from its link
+type RoomSwapSuggestionResponse struct {
+	ReservationID  uuid.UUID             `json:"reservation_id"`
+	Title          string                `json:"title"`
+	StartTime      time.Time             `json:"start_time"`
+	EndTime        time.Time             `json:"end_time"`
+	Participants   int                   `json:"participants"`
+	Reason         string                `json:"reason"` // oversized or undersized
+	CurrentSpace   RoomSwapSpaceResponse `json:"current_space"`
+	SuggestedSpace RoomSwapSpaceResponse `json:"suggested_space"`
+	Status         string                `json:"status"` // pending, accepted, declined or expired
+	ExpiresAt      time.Time             `json:"expires_at"`
+	RespondedAt    *time.Time            `json:"responded_at,omitempty"`
+}
+
+// RoomSwapSpaceResponse represents a room of a swap suggestion
+type RoomSwapSpaceResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Building string    `json:"building"`
+	Capacity int       `json:"capacity"`
+}
+
+// WorkingCalendarResponse represents the holidays and closure days of a year in a building's region
+type WorkingCalendarResponse struct {
+	Building string               `json:"building"`
+	Region   string               `json:"region,omitempty"` // Empty when the building is in no region: only days of every region apply
+	Timezone string               `json:"timezone"`
+	Year     int                  `json:"year"`
+	Days     []models.CalendarDay `json:"days"`
+}
+
+// Holiday represents a public holiday, when bookings are closed
+type Holiday struct {
+	Date      string `json:"date"` // YYYY-MM-DD
+	Name      string `json:"name"`
+	LocalName string `json:"local_name,omitempty"`
+}
+
+// UserPreferencesResponse represents the defaults applied to a user's new reservations
+type UserPreferencesResponse struct {
+	PreferredBuilding      string      `json:"preferred_building"`
+	PreferredFloor         *int        `json:"preferred_floor"`
+	DefaultDurationMinutes int         `json:"default_duration_minutes"`
+	DefaultParticipantIDs  []uuid.UUID `json:"default_participant_ids"`
+	DefaultPrivate         bool        `json:"default_private"`
+	UpdatedAt              *time.Time  `json:"updated_at,omitempty"` // Unset until the user saves preferences
+}
+
+// SpaceRecommendationsResponse represents spaces ranked for a requested time
+type SpaceRecommendationsResponse struct {
+	StartTime       time.Time             `json:"start_time"`
+	EndTime         time.Time             `json:"end_time"`
+	Participants    int                   `json:"participants"`
+	Amenities       []string              `json:"amenities,omitempty"`
+	HomeLocation    string                `json:"home_location,omitempty"` // Where the user's department usually books
+	Recommendations []SpaceRecommendation `json:"recommendations"`
+}
+
+// SpaceRecommendation represents one suggested space with the reasons it ranks where it does
+type SpaceRecommendation struct {
+	Space            *SpaceResponse `json:"space"`
+	Score            int            `json:"score"` // 0-100
+	Reasons          []string       `json:"reasons"`
+	MissingAmenities []string       `json:"missing_amenities,omitempty"`
 }
 
 // BatchAvailabilityRequest represents a batch availability check request
@@ -497,6 +948,7 @@ type AvailabilityResponse struct {
 	NextAvailable *TimeSlot             `json:"next_available,omitempty"`
 	Suggestions   []AvailabilitySlot    `json:"suggestions,omitempty"`
 	CapacityCheck *CapacityCheckResult  `json:"capacity_check,omitempty"`
+	VIPBlocked    bool                  `json:"vip_blocked,omitempty"` // Slot falls in a VIP guaranteed-availability block
 }
 
 // CapacityCheckResult represents capacity validation result
@@ -519,18 +971,496 @@ type ApprovalResponse struct {
 
 // BulkApprovalResponse represents bulk approval/rejection response
 type BulkApprovalResponse struct {
-	Success      []ApprovalResult     `json:"success"`
-	Failed       []BulkOperationError `json:"failed"`
-	TotalCount   int                  `json:"total_count"`
-	SuccessCount int                  `json:"success_count"`
-	FailedCount  int                  `json:"failed_count"`
+	Results      []ApprovalResult `json:"results"` // One per reservation, in request order
+	TotalCount   int              `json:"total_count"`
+	SuccessCount int              `json:"success_count"`
+	FailedCount  int              `json:"failed_count"`
 }
 
 // ApprovalResult represents a single approval result
 type ApprovalResult struct {
 	ReservationID uuid.UUID `json:"reservation_id"`
-	Action        string    `json:"action"`
-	Status        string    `json:"new_status"`
+	Success       bool      `json:"success"`
+	Action        string    `json:"action,omitempty"` // approved, first_approval or rejected
+	Status        string    `json:"new_status,omitempty"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// SheetImportResponse represents the preview or commit result of a sheet import
+type SheetImportResponse struct {
+	SheetID       string                 `json:"sheet_id"`
+	TotalRows     int                    `json:"total_rows"`
+	ReadyCount    int                    `json:"ready_count"`
+	ConflictCount int                    `json:"conflict_count"`
+	InvalidCount  int                    `json:"invalid_count"`
+	CreatedCount  int                    `json:"created_count"`
+	Rows          []SheetImportRowResult `json:"rows"`
+}
+
+// SheetImportRowResult represents the outcome for a single sheet row
+type SheetImportRowResult struct {
+	RowNumber        int                   `json:"row_number"`
+	Status           string                `json:"status"` // "ready", "conflict", "invalid", "created", "queued", "failed", "skipped"
+	SpaceID          *uuid.UUID            `json:"space_id,omitempty"`
+	SpaceName        string                `json:"space_name,omitempty"`
+	StartTime        *time.Time            `json:"start_time,omitempty"`
+	EndTime          *time.Time            `json:"end_time,omitempty"`
+	Title            string                `json:"title,omitempty"`
+	ParticipantCount int                   `json:"participant_count,omitempty"`
+	UserEmail        string                `json:"user_email,omitempty"` // Owner of a CSV row, when not the importer
+	Errors           []string              `json:"errors,omitempty"`
+	Conflicts        []ReservationConflict `json:"conflicts,omitempty"`
+	ReservationID    *uuid.UUID            `json:"reservation_id,omitempty"`
+}
+
+// CSVImportResponse represents the dry run or outcome of a CSV reservation import
+type CSVImportResponse struct {
+	DryRun        bool                   `json:"dry_run"`
+	OnConflict    string                 `json:"on_conflict"`
+	TotalRows     int                    `json:"total_rows"`
+	ReadyCount    int                    `json:"ready_count"`
+	ConflictCount int                    `json:"conflict_count"`
+	InvalidCount  int                    `json:"invalid_count"`
+	CreatedCount  int                    `json:"created_count"`
+	QueuedCount   int                    `json:"queued_count"` // Created pending approval
+	SkippedCount  int                    `json:"skipped_count"`
+	FailedCount   int                    `json:"failed_count"`
+	Rows          []SheetImportRowResult `json:"rows"`
+}
+
+// SpaceCatalogImportResponse represents the dry run or outcome of a space catalog import
+type SpaceCatalogImportResponse struct {
+	DryRun       bool                    `json:"dry_run"`
+	TotalRows    int                     `json:"total_rows"`
+	CreateCount  int                     `json:"create_count"` // Dry run: spaces that would be added
+	UpdateCount  int                     `json:"update_count"` // Dry run: spaces that would be replaced
+	InvalidCount int                     `json:"invalid_count"`
+	CreatedCount int                     `json:"created_count"`
+	UpdatedCount int                     `json:"updated_count"`
+	FailedCount  int                     `json:"failed_count"`
+	Rows         []SpaceCatalogRowResult `json:"rows"`
+}
+
+// SpaceCatalogRowResult represents the outcome for a single space of a catalog import
+type SpaceCatalogRowResult struct {
+	RowNumber int        `json:"row_number"` // Position in the spaces list, or CSV row with the header as row 1
+	Status    string     `json:"status"`     // "create", "update", "invalid", "created", "updated", "failed"
+	Building  string     `json:"building"`
+	Name      string     `json:"name"`
+	SpaceID   *uuid.UUID `json:"space_id,omitempty"`
+	Errors    []string   `json:"errors,omitempty"`
+}
+
+// PreemptionReport lists the reservations displaced by higher-priority bookings
+type PreemptionReport struct {
+	StartDate        time.Time                       `json:"start_date"`
+	EndDate          time.Time                       `json:"end_date"`
+	TotalPreemptions int64                           `json:"total_preemptions"`
+	Preemptions      []*models.ReservationPreemption `json:"preemptions"`
+	Page             int                             `json:"page"`
+	Limit            int                             `json:"limit"`
+}
+
+// VIPViolationReport represents VIP block overrides for a period
+type VIPViolationReport struct {
+	StartDate       time.Time                   `json:"start_date"`
+	EndDate         time.Time                   `json:"end_date"`
+	TotalViolations int64                       `json:"total_violations"`
+	Violations      []*models.VIPBlockViolation `json:"violations"`
+	Page            int                         `json:"page"`
+	Limit           int                         `json:"limit"`
+}
+
+// BookingConflictReport represents per-space booking conflicts for a period
+type BookingConflictReport struct {
+	StartDate         time.Time                     `json:"start_date"`
+	EndDate           time.Time                     `json:"end_date"`
+	ChronicThreshold  int64                         `json:"chronic_threshold"`
+	TotalConflicts    int64                         `json:"total_conflicts"`
+	ChronicSpaceCount int                           `json:"chronic_space_count"`
+	Spaces            []BookingConflictSpaceSummary `json:"spaces"`
+}
+
+// BookingConflictSpaceSummary represents the conflicts of one space, flagged when contention is chronic
+type BookingConflictSpaceSummary struct {
+	*models.BookingConflictSummary
+	ConflictsPerDay float64 `json:"conflicts_per_day"`
+	Chronic         bool    `json:"chronic"`
+}
+
+// SessionUtilizationReport represents how much of the booked time spaces were actually used
+type SessionUtilizationReport struct {
+	StartDate          time.Time                   `json:"start_date"`
+	EndDate            time.Time                   `json:"end_date"`
+	Sessions           int64                       `json:"sessions"`
+	ScheduledHours     float64                     `json:"scheduled_hours"`
+	UsedHours          float64                     `json:"used_hours"`
+	UtilizationPercent float64                     `json:"utilization_percent"`
+	EarlyCheckouts     int64                       `json:"early_checkouts"`
+	ReleasedHours      float64                     `json:"released_hours"` // Booked time freed by early check-outs
+	Spaces             []SessionUtilizationSummary `json:"spaces"`         // Least used first
+}
+
+// SessionUtilizationSummary represents the check-in sessions of one space
+type SessionUtilizationSummary struct {
+	SpaceID            uuid.UUID `json:"space_id"`
+	SpaceName          string    `json:"space_name"`
+	Sessions           int64     `json:"sessions"`
+	ScheduledHours     float64   `json:"scheduled_hours"`
+	UsedHours          float64   `json:"used_hours"`
+	UtilizationPercent float64   `json:"utilization_percent"`
+	EarlyCheckouts     int64     `json:"early_checkouts"`
+	EarlyCheckoutRate  float64   `json:"early_checkout_rate"` // Percent of sessions
+	ReleasedHours      float64   `json:"released_hours"`
+}
+
+// DepartmentCalendarResponse represents the reservations of a department's members
+type DepartmentCalendarResponse struct {
+	Department  string                    `json:"department"`
+	StartDate   time.Time                 `json:"start_date"`
+	EndDate     time.Time                 `json:"end_date"`
+	MemberCount int                       `json:"member_count"`
+	Entries     []DepartmentCalendarEntry `json:"entries"`
+}
+
+// DepartmentCalendarEntry represents one reservation on a department calendar.
+// Private reservations of other members only show the owner and time.
+type DepartmentCalendarEntry struct {
+	ReservationID    uuid.UUID  `json:"reservation_id"`
+	UserID           uuid.UUID  `json:"user_id"`
+	UserName         string     `json:"user_name"`
+	SpaceID          *uuid.UUID `json:"space_id,omitempty"`
+	SpaceName        string     `json:"space_name,omitempty"`
+	Title            string     `json:"title"`
+	StartTime        time.Time  `json:"start_time"`
+	EndTime          time.Time  `json:"end_time"`
+	Status           string     `json:"status"`
+	ParticipantCount int        `json:"participant_count,omitempty"`
+	IsPrivate        bool       `json:"is_private"`
+}
+
+// SpaceTimelineResponse represents a space's day split into free, busy and buffer segments
+type SpaceTimelineResponse struct {
+	SpaceID     uuid.UUID         `json:"space_id"`
+	SpaceName   string            `json:"space_name"`
+	Date        string            `json:"date"` // YYYY-MM-DD
+	Timezone    string            `json:"timezone"`
+	DayStart    time.Time         `json:"day_start"`
+	DayEnd      time.Time         `json:"day_end"`
+	Available   bool              `json:"available"`         // The space is in service
+	Holiday     *Holiday          `json:"holiday,omitempty"` // Bookings are closed that day
+	FreeMinutes int               `json:"free_minutes"`
+	BusyMinutes int               `json:"busy_minutes"`
+	Segments    []TimelineSegment `json:"segments"`
+}
+
+// TimelineSegment represents a stretch of the day; status is free, busy or buffer (kept free around bookings)
+type TimelineSegment struct {
+	Status       string                `json:"status"`
+	StartTime    time.Time             `json:"start_time"`
+	EndTime      time.Time             `json:"end_time"`
+	Reservations []TimelineReservation `json:"reservations,omitempty"` // Bookings making a busy segment busy
+}
+
+// TimelineReservation summarizes a booking on a timeline. Private bookings the caller
+// may not see only show their time.
+type TimelineReservation struct {
+	ReservationID    *uuid.UUID `json:"reservation_id,omitempty"`
+	Title            string     `json:"title"`
+	OrganizerName    string     `json:"organizer_name,omitempty"`
+	StartTime        time.Time  `json:"start_time"`
+	EndTime          time.Time  `json:"end_time"`
+	Status           string     `json:"status"`
+	ParticipantCount int        `json:"participant_count,omitempty"`
+	IsPrivate        bool       `json:"is_private"`
+}
+
+// BookNowResponse represents an ad-hoc booking and the time actually granted
+type BookNowResponse struct {
+	Reservation      *ReservationResponse `json:"reservation"`
+	RequestedEndTime time.Time            `json:"requested_end_time"`
+	GrantedEndTime   time.Time            `json:"granted_end_time"`
+	GrantedMinutes   int                  `json:"granted_minutes"`
+	Shortened        bool                 `json:"shortened"` // The room is booked, or the space limit applies, before the requested end
+	Warnings         []string             `json:"warnings,omitempty"`
+}
+
+// CloneReservationResponse represents the copy of a reservation and whether it was moved
+type CloneReservationResponse struct {
+	SourceID           uuid.UUID            `json:"source_id"`
+	Reservation        *ReservationResponse `json:"reservation"`
+	RequestedStartTime time.Time            `json:"requested_start_time"`
+	RequestedEndTime   time.Time            `json:"requested_end_time"`
+	Adjusted           bool                 `json:"adjusted"` // Booked at the nearest free slot instead of the requested one
+	Warnings           []string             `json:"warnings,omitempty"`
+}
+
+// ReservationGuestResponse represents an invited guest as seen by the host
+type ReservationGuestResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	ReservationID uuid.UUID  `json:"reservation_id"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	Company       string     `json:"company,omitempty"`
+	PassURL       string     `json:"pass_url"`
+	QRCodeURL     string     `json:"qr_code_url"`
+	InviteSentAt  *time.Time `json:"invite_sent_at,omitempty"`
+	RSVPStatus    string     `json:"rsvp_status"`
+	RespondedAt   *time.Time `json:"responded_at,omitempty"`
+	ArrivedAt     *time.Time `json:"arrived_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// GuestPassResponse represents the pass a guest shows at reception
+type GuestPassResponse struct {
+	GuestName  string     `json:"guest_name"`
+	Company    string     `json:"company,omitempty"`
+	HostName   string     `json:"host_name"`
+	Title      string     `json:"title"`
+	SpaceName  string     `json:"space_name"`
+	Building   string     `json:"building"`
+	Floor      int        `json:"floor"`
+	RoomNumber string     `json:"room_number"`
+	StartTime  time.Time  `json:"start_time"`
+	EndTime    time.Time  `json:"end_time"`
+	Status     string     `json:"status"` // Reservation status; "cancelled" passes are no longer valid
+	QRCodeURL  string     `json:"qr_code_url"`
+	RSVPStatus string     `json:"rsvp_status"`
+	ArrivedAt  *time.Time `json:"arrived_at,omitempty"`
+}
+
+// ExpectedVisitorsResponse represents the visitors expected in a building on a day
+type ExpectedVisitorsResponse struct {
+	Building      string            `json:"building"`
+	Date          string            `json:"date"`
+	TotalVisitors int               `json:"total_visitors"`
+	ArrivedCount  int               `json:"arrived_count"`
+	Visitors      []ExpectedVisitor `json:"visitors"`
+}
+
+// ExpectedVisitor represents one guest on the reception list
+type ExpectedVisitor struct {
+	GuestID          uuid.UUID  `json:"guest_id"`
+	Name             string     `json:"name"`
+	Company          string     `json:"company,omitempty"`
+	HostName         string     `json:"host_name"`
+	HostEmail        string     `json:"host_email"`
+	ReservationTitle string     `json:"reservation_title"`
+	SpaceName        string     `json:"space_name"`
+	Floor            int        `json:"floor"`
+	RoomNumber       string     `json:"room_number"`
+	StartTime        time.Time  `json:"start_time"`
+	EndTime          time.Time  `json:"end_time"`
+	ArrivedAt        *time.Time `json:"arrived_at,omitempty"`
+}
+
+// RoomDisplayResponse represents a registered door display
+type RoomDisplayResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	SpaceID    uuid.UUID  `json:"space_id"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"key_prefix"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// RoomDisplayKeyResponse represents a newly registered display with its API key.
+// The key is only returned once.
+type RoomDisplayKeyResponse struct {
+	RoomDisplayResponse
+	APIKey string `json:"api_key"`
+}
+
+// WidgetTokenResponse represents a booking widget token
+type WidgetTokenResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	Name           string     `json:"name"`
+	SpaceID        *uuid.UUID `json:"space_id,omitempty"`
+	SpaceName      string     `json:"space_name,omitempty"`
+	Building       string     `json:"building,omitempty"`
+	AllowedOrigins []string   `json:"allowed_origins"`
+	TokenPrefix    string     `json:"token_prefix"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// WidgetTokenKeyResponse represents a newly created widget token with the token itself.
+// The token is only returned once.
+type WidgetTokenKeyResponse struct {
+	WidgetTokenResponse
+	Token string `json:"token"`
+}
+
+// IntegrationKeyResponse represents an API key of a third-party system
+type IntegrationKeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	Buildings  []string   `json:"buildings"` // Empty covers every building
+	KeyPrefix  string     `json:"key_prefix"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// IntegrationKeyCreatedResponse represents a newly created integration key with the key itself.
+// The key is only returned once.
+type IntegrationKeyCreatedResponse struct {
+	IntegrationKeyResponse
+	Key string `json:"key"`
+}
+
+// VisitorBadgeResponse represents what a reception system prints on a visitor badge
+type VisitorBadgeResponse struct {
+	GuestID       uuid.UUID  `json:"guest_id"`
+	ReservationID uuid.UUID  `json:"reservation_id"`
+	Name          string     `json:"name"`
+	Company       string     `json:"company,omitempty"`
+	HostName      string     `json:"host_name"`
+	Building      string     `json:"building"`
+	Floor         int        `json:"floor"`
+	SpaceName     string     `json:"space_name"`
+	RoomNumber    string     `json:"room_number"`
+	ValidFrom     time.Time  `json:"valid_from"`
+	ValidUntil    time.Time  `json:"valid_until"`
+	QRCodeData    string     `json:"qr_code_data"` // Guest pass link, for a QR code on the badge
+	ArrivedAt     *time.Time `json:"arrived_at,omitempty"`
+}
+
+// ReservationServicesResponse represents the services ordered with a reservation and what they cost
+type ReservationServicesResponse struct {
+	ReservationID uuid.UUID                `json:"reservation_id"`
+	Requests      []*models.ServiceRequest `json:"requests"`
+	TotalCost     float64                  `json:"total_cost"` // Requests not rejected or cancelled
+}
+
+// EquipmentStatusResponse represents an item of the portable equipment inventory and where it is
+type EquipmentStatusResponse struct {
+	Equipment     *SpaceResponse `json:"equipment"`
+	CheckedOut    bool           `json:"checked_out"`
+	Overdue       bool           `json:"overdue"`                  // Still out after its reservation ended
+	ReservationID *uuid.UUID     `json:"reservation_id,omitempty"` // Reservation it is checked out for
+	DueBackAt     *time.Time     `json:"due_back_at,omitempty"`
+	Free          *bool          `json:"free,omitempty"` // Whether it can be booked in the requested window
+}
+
+// WidgetBookingResponse represents a booking request sent through a widget, waiting for approval
+type WidgetBookingResponse struct {
+	ID        uuid.UUID `json:"id"`
+	SpaceID   uuid.UUID `json:"space_id"`
+	SpaceName string    `json:"space_name"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Status    string    `json:"status"`
+}
+
+// OccupancySensorResponse represents a registered occupancy sensor
+type OccupancySensorResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	SpaceID    uuid.UUID  `json:"space_id"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"key_prefix"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// OccupancySensorKeyResponse represents a newly registered sensor with its API key.
+// The key is only returned once.
+type OccupancySensorKeyResponse struct {
+	OccupancySensorResponse
+	APIKey string `json:"api_key"`
+}
+
+// SpaceOccupancyResponse represents the live headcount of a space and its recent samples
+type SpaceOccupancyResponse struct {
+	SpaceID            uuid.UUID        `json:"space_id"`
+	Capacity           int              `json:"capacity"`
+	CurrentOccupancy   *int             `json:"current_occupancy"` // Not set when no sensor reports
+	OccupancyUpdatedAt *time.Time       `json:"occupancy_updated_at,omitempty"`
+	EmptySince         *time.Time       `json:"empty_since,omitempty"`
+	Samples            []OccupancyPoint `json:"samples"`
+}
+
+// OccupancyPoint represents one headcount sample
+type OccupancyPoint struct {
+	Headcount  int       `json:"headcount"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// RoomDisplayStatusResponse represents what a door display shows for its space
+type RoomDisplayStatusResponse struct {
+	SpaceID     uuid.UUID           `json:"space_id"`
+	SpaceName   string              `json:"space_name"`
+	Building    string              `json:"building"`
+	Floor       int                 `json:"floor"`
+	RoomNumber  string              `json:"room_number"`
+	Capacity    int                 `json:"capacity"`
+	State       string              `json:"state"` // "available", "occupied" or "out_of_service"
+	Current     *RoomDisplayMeeting `json:"current,omitempty"`
+	Next        *RoomDisplayMeeting `json:"next,omitempty"`
+	FreeUntil   *time.Time          `json:"free_until,omitempty"` // Start of the next meeting when the room is free
+	CanExtend   bool                `json:"can_extend"`
+	GeneratedAt time.Time           `json:"generated_at"`
+}
+
+// RoomDisplayMeeting represents a meeting on a door display. Private meetings
+// hide their title and organizer.
+type RoomDisplayMeeting struct {
+	ReservationID uuid.UUID `json:"reservation_id"`
+	Title         string    `json:"title"`
+	OrganizerName string    `json:"organizer_name,omitempty"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	CheckedIn     bool      `json:"checked_in"`
+	IsPrivate     bool      `json:"is_private"`
+}
+
+// ReservationQuestionnaireResponse represents the check-in questionnaire of a reservation and whether it was answered
+type ReservationQuestionnaireResponse struct {
+	ReservationID   uuid.UUID                      `json:"reservation_id"`
+	QuestionnaireID uuid.UUID                      `json:"questionnaire_id"`
+	Title           string                         `json:"title"`
+	Questions       []models.QuestionnaireQuestion `json:"questions"`
+	Submitted       bool                           `json:"submitted"`
+	SubmittedAt     *time.Time                     `json:"submitted_at,omitempty"`
+	Answers         map[string]string              `json:"answers,omitempty"`
+}
+
+// QuestionnaireSubmissionResponse represents stored answers in a compliance report
+type QuestionnaireSubmissionResponse struct {
+	ID               uuid.UUID                      `json:"id"`
+	ReservationID    uuid.UUID                      `json:"reservation_id"`
+	ReservationTitle string                         `json:"reservation_title"`
+	StartTime        time.Time                      `json:"start_time"`
+	UserID           uuid.UUID                      `json:"user_id"`
+	UserName         string                         `json:"user_name"`
+	SubmittedAt      time.Time                      `json:"submitted_at"`
+	Questions        []models.QuestionnaireQuestion `json:"questions"`
+	Answers          map[string]string              `json:"answers"`
+}
+
+// WebhookSecretResponse represents a new webhook subscription with its signing secret.
+// The secret is only returned once.
+type WebhookSecretResponse struct {
+	*models.WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+// WebhookSchemaChangelogResponse lists the webhook payload schema versions
+type WebhookSchemaChangelogResponse struct {
+	CurrentVersion int                    `json:"current_version"`
+	Versions       []WebhookSchemaVersion `json:"versions"`
+}
+
+// WebhookSchemaVersion describes one webhook payload schema version
+type WebhookSchemaVersion struct {
+	Version int         `json:"version"`
+	Summary string      `json:"summary"`
+	Changes []string    `json:"changes"`
+	Example interface{} `json:"example"` // reservation_created payload in this version
 }
 
 // ReservationStatsResponse represents reservation statistics
@@ -654,12 +1584,19 @@ type ReservationResponse struct {
 	RejectedAt         *time.Time `json:"rejected_at,omitempty"`
 	RejectionReason    string     `json:"rejection_reason,omitempty"`
 	CancellationReason string     `json:"cancellation_reason,omitempty"`
+	IsPrivate          bool       `json:"is_private"`
+	LicensePlate       string     `json:"license_plate,omitempty"`
+	Tags               []string   `json:"tags,omitempty"`
+	CostCenter         string     `json:"cost_center,omitempty"`
 	CreatedAt          time.Time  `json:"created_at"`
 	UpdatedAt          time.Time  `json:"updated_at"`
 
 	// Related entities (optional, include based on needs)
-	User  *UserResponse  `json:"user,omitempty"`
-	Space *SpaceResponse `json:"space,omitempty"`
+	User         *UserResponse            `json:"user,omitempty"`
+	CoOrganizers []UserResponse           `json:"co_organizers,omitempty"` // Users who can edit, cancel and check in with the owner
+	Space        *SpaceResponse           `json:"space,omitempty"`
+	Resources    []*SpaceResponse         `json:"resources,omitempty"` // Equipment booked with the space
+	Tasks        []models.ReservationTask `json:"tasks,omitempty"`     // Organizer's checklist
 
 	// Computed fields
 	Duration    string `json:"duration"` // e.g., "2h 30m"
@@ -667,6 +1604,10 @@ type ReservationResponse struct {
 	CanCancel   bool   `json:"can_cancel"`
 	CanCheckIn  bool   `json:"can_check_in"`
 	CanCheckOut bool   `json:"can_check_out"`
+
+	// Full-text search relevance, set only on search results
+	SearchScore float64 `json:"search_score,omitempty"`
+	Highlight   string  `json:"highlight,omitempty"`
 }
 
 // ToReservationResponse converts a reservation model to response DTO
@@ -684,6 +1625,7 @@ func ToReservationResponse(reservation *models.Reservation) *ReservationResponse
 		CheckInTime:        reservation.CheckInTime,
 		CheckOutTime:       reservation.CheckOutTime,
 		CancellationReason: reservation.CancellationReason,
+		IsPrivate:          reservation.IsPrivate,
 		CreatedAt:          reservation.CreatedAt,
 		UpdatedAt:          reservation.UpdatedAt,
 		Duration:           formatDuration(reservation.EndTime.Sub(reservation.StartTime)),
@@ -691,8 +1633,29 @@ func ToReservationResponse(reservation *models.Reservation) *ReservationResponse
 		CanCancel:          reservation.CanBeCancelled(),
 		CanCheckIn:         canCheckInNow(reservation),
 		CanCheckOut:        canCheckOutNow(reservation),
+		SearchScore:        reservation.SearchRank,
+		Highlight:          reservation.SearchSnippet,
+		LicensePlate:       reservation.LicensePlate,
+		Tags:               []string(reservation.Tags),
+		CostCenter:         reservation.CostCenter,
 	}
 
+	for _, booked := range reservation.Resources {
+		if booked.Resource != nil {
+			response.Resources = append(response.Resources, ToSpaceResponse(booked.Resource))
+		}
+	}
+	if reservation.User.ID != uuid.Nil {
+		owner := ToUserResponse(&reservation.User)
+		response.User = &owner
+	}
+	for _, coOrganizer := range reservation.CoOrganizers {
+		if coOrganizer.User != nil {
+			response.CoOrganizers = append(response.CoOrganizers, ToUserResponse(coOrganizer.User))
+		}
+	}
+	response.Tasks = reservation.Tasks
+
 	return response
 }
 
@@ -727,17 +1690,36 @@ func canCheckOutNow(reservation *models.Reservation) bool {
 // ToSpaceResponse converts a space model to response DTO
 func ToSpaceResponse(space *models.Space) *SpaceResponse {
 	response := &SpaceResponse{
-		ID:          space.ID,
-		Name:        space.Name,
-		Type:        string(space.Type),
-		Capacity:    space.Capacity,
-		Building:    space.Building,
-		Floor:       space.Floor,
-		RoomNumber:  space.RoomNumber,
-		Status:      string(space.Status),
-		Description: space.Description,
-		CreatedAt:   space.CreatedAt,
-		UpdatedAt:   space.UpdatedAt,
+		ID:            space.ID,
+		Name:          space.Name,
+		Type:          string(space.Type),
+		ResourceClass: string(models.ResourceClassOf(space.Type)),
+		Capacity:      space.Capacity,
+		Building:      space.Building,
+		Floor:         space.Floor,
+		RoomNumber:    space.RoomNumber,
+		Status:        string(space.Status),
+		Description:   space.Description,
+		IsVIP:         space.IsVIP,
+		IsPremium:     space.IsPremium,
+		EnergyRating:  space.EnergyRating,
+		CreatedAt:     space.CreatedAt,
+
+		PrimaryPhotoURL:     space.PrimaryPhotoURL,
+		BufferBefore:        space.BufferBefore,
+		BufferAfter:         space.BufferAfter,
+		CancellationWindow:  space.CancellationWindow,
+		EarlyReleaseMinutes: space.EarlyReleaseMinutes,
+		PreemptionTier:      space.PreemptionTier,
+		CombinationOf:       space.CombinationOf,
+		UpdatedAt:           space.UpdatedAt,
+
+		CurrentOccupancy:   space.CurrentOccupancy,
+		OccupancyUpdatedAt: space.OccupancyUpdatedAt,
+	}
+
+	if space.InPilot(time.Now()) {
+		response.PilotUntil = space.PilotUntil
 	}
 
 	// Parse photos JSON if present
@@ -748,6 +1730,13 @@ func ToSpaceResponse(space *models.Space) *SpaceResponse {
 		}
 	}
 
+	if space.Attributes != nil {
+		var attributes ResourceAttributes
+		if err := json.Unmarshal(space.Attributes, &attributes); err == nil {
+			response.Attributes = &attributes
+		}
+	}
+
 	return response
 }
 
@@ -862,6 +1851,15 @@ type ReservationCalendarResponse struct {
 	Summary      *DayReservationSummary `json:"summary"`
 }
 
+// CalendarBuffer represents time kept free around a reservation, e.g. for cleaning
+type CalendarBuffer struct {
+	ReservationID uuid.UUID `json:"reservation_id"`
+	SpaceID       uuid.UUID `json:"space_id"`
+	Position      string    `json:"position"` // "before" or "after" the reservation
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+}
+
 // DayReservationSummary represents daily reservation summary
 type DayReservationSummary struct {
 	Date              time.Time      `json:"date"`
@@ -880,6 +1878,13 @@ type SpaceSummary struct {
 	Hours        float64   `json:"hours"`
 }
 
+// EventPollResponse represents a batch of realtime events for long-poll clients
+type EventPollResponse struct {
+	Events []websocket.BusEvent `json:"events"`
+	Cursor uint64               `json:"cursor"` // Pass back as ?since= on the next poll
+	Reset  bool                 `json:"reset"`  // Events were missed, reload state before polling again
+}
+
 // NewReservationResponse creates a new reservation response
 func NewReservationResponse(reservation interface{}) *ReservationResponse {
 	// Implementation would convert from models.Reservation
@@ -905,3 +1910,517 @@ func NewApprovalResponse(reservationID, approverID uuid.UUID, action, approverNa
 		Timestamp:     time.Now(),
 	}
 }
+
+// DiagnosticsResponse gathers what on-call engineers check first during an incident
+type DiagnosticsResponse struct {
+	GeneratedAt time.Time           `json:"generated_at"`
+	Build       version.Info        `json:"build"`
+	Runtime     RuntimeDiagnostics  `json:"runtime"`
+	Database    DatabaseDiagnostics `json:"database"`
+	Queues      QueueDiagnostics    `json:"queues"`
+	Realtime    RealtimeDiagnostics `json:"realtime"`
+	Caches      []CacheStats        `json:"caches"`
+	Jobs        []jobs.JobStatus    `json:"jobs"`
+	Breakers    []breaker.Status    `json:"breakers"`
+	Errors      []string            `json:"errors,omitempty"` // Sections that could not be gathered
+}
+
+// RuntimeDiagnostics describes the process
+type RuntimeDiagnostics struct {
+	StartedAt      time.Time `json:"started_at"`
+	Uptime         string    `json:"uptime"`
+	Goroutines     int       `json:"goroutines"`
+	HeapAllocMB    float64   `json:"heap_alloc_mb"`
+	GCPauseTotalMs float64   `json:"gc_pause_total_ms"`
+}
+
+// DatabaseDiagnostics reports the schema and connection pool
+type DatabaseDiagnostics struct {
+	Reachable       bool     `json:"reachable"`
+	SchemaTables    int      `json:"schema_tables"`            // Tables the build's migrations create
+	MissingTables   []string `json:"missing_tables,omitempty"` // Set when migrations were not applied
+	OpenConnections int      `json:"open_connections"`
+	InUse           int      `json:"in_use"`
+	WaitCount       int64    `json:"wait_count"` // Queries that had to wait for a free connection
+	WaitDuration    string   `json:"wait_duration"`
+}
+
+// QueueDiagnostics reports work waiting to be processed
+type QueueDiagnostics struct {
+	NotificationsDue int64           `json:"notifications_due"` // Due notifications not delivered yet
+	Outbox           *OutboxBacklog  `json:"outbox,omitempty"`
+	Webhooks         *WebhookBacklog `json:"webhooks,omitempty"`
+}
+
+// OutboxBacklog reports the committed changes not yet pushed to realtime clients, webhooks and email
+type OutboxBacklog struct {
+	PendingEvents    int64      `json:"pending_events"`
+	OldestPendingAt  *time.Time `json:"oldest_pending_at,omitempty"`
+	OldestPendingAge string     `json:"oldest_pending_age,omitempty"`
+}
+
+// WebhookBacklog reports the webhook deliveries not sent yet
+type WebhookBacklog struct {
+	PendingDeliveries int64      `json:"pending_deliveries"`
+	OldestPendingAt   *time.Time `json:"oldest_pending_at,omitempty"`
+	OldestPendingAge  string     `json:"oldest_pending_age,omitempty"`
+}
+
+// RealtimeDiagnostics reports WebSocket clients and the event bus
+type RealtimeDiagnostics struct {
+	Connections      websocket.ClientStats `json:"connections"`
+	EventBusSequence uint64                `json:"event_bus_sequence"` // Events published since start
+}
+
+// CacheStats reports the effectiveness of an in-memory cache
+type CacheStats struct {
+	Name    string  `json:"name"`
+	Entries int     `json:"entries"`
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	HitRate float64 `json:"hit_rate"` // Between 0 and 1
+}
+
+// Statuses of a dependency in a health report
+const (
+	HealthUp      = "up"
+	HealthDown    = "down"
+	HealthSkipped = "skipped" // Not configured
+)
+
+// HealthReport answers the liveness and readiness probes of orchestrators such as Kubernetes
+type HealthReport struct {
+	Status    string                     `json:"status"` // alive; or ready, degraded or not_ready
+	Timestamp time.Time                  `json:"timestamp"`
+	Version   string                     `json:"version"`
+	Uptime    string                     `json:"uptime"`
+	Checks    map[string]DependencyCheck `json:"checks,omitempty"`
+	Breakers  []breaker.Status           `json:"breakers,omitempty"`
+}
+
+// DependencyCheck is the state of one dependency
+type DependencyCheck struct {
+	Status   string      `json:"status"`
+	Critical bool        `json:"critical"` // The instance is not ready while it is down
+	Latency  string      `json:"latency,omitempty"`
+	Error    string      `json:"error,omitempty"`
+	Details  interface{} `json:"details,omitempty"`
+}
+
+// VersionResponse describes the running API and the client versions it supports
+type VersionResponse struct {
+	version.Info
+	APIVersion        string               `json:"api_version"`
+	MinClientVersions map[string]string    `json:"min_client_versions"` // By platform
+	Compatibility     *ClientCompatibility `json:"compatibility,omitempty"`
+}
+
+// ClientCompatibility tells a client whether it must upgrade before using the API
+type ClientCompatibility struct {
+	Platform        string `json:"platform"`
+	ClientVersion   string `json:"client_version"`
+	MinimumVersion  string `json:"minimum_version,omitempty"` // Empty when the platform has no minimum
+	UpgradeRequired bool   `json:"upgrade_required"`
+}
+
+// NotificationDeadLetterPayload is the payload of a notification that ran out of retries.
+// Title, message and data can be edited before replaying; the recipient cannot.
+type NotificationDeadLetterPayload struct {
+	NotificationID uuid.UUID       `json:"notification_id"`
+	UserID         uuid.UUID       `json:"user_id"`
+	Email          string          `json:"email"`
+	Title          string          `json:"title"`
+	Message        string          `json:"message"`
+	Data           json.RawMessage `json:"data,omitempty"`
+}
+
+// ReplayDeadLettersResponse represents the outcome of replaying several dead letters
+type ReplayDeadLettersResponse struct {
+	Matched  int                     `json:"matched"`
+	Replayed int                     `json:"replayed"`
+	Failed   int                     `json:"failed"`
+	Errors   []DeadLetterReplayError `json:"errors,omitempty"`
+}
+
+// DeadLetterReplayError represents a dead letter that could not be replayed
+type DeadLetterReplayError struct {
+	ID    uuid.UUID `json:"id"`
+	Error string    `json:"error"`
+}
+
+// AccountExportManifest describes the files of a user's data export archive
+type AccountExportManifest struct {
+	UserID        uuid.UUID      `json:"user_id"`
+	GeneratedAt   time.Time      `json:"generated_at"`
+	FormatVersion int            `json:"format_version"`
+	Files         map[string]int `json:"files"` // File name -> number of records
+}
+
+// RetentionReport previews what the retention jobs would change if they ran now
+type RetentionReport struct {
+	GeneratedAt time.Time                 `json:"generated_at"`
+	Categories  []RetentionCategoryReport `json:"categories"`
+}
+
+// RetentionCategoryReport represents the effect of the retention policy on one kind of data
+type RetentionCategoryReport struct {
+	Category      string     `json:"category"` // reservations, chat_messages or audit_logs
+	Action        string     `json:"action"`   // anonymize or purge
+	Job           string     `json:"job"`
+	RetentionDays int        `json:"retention_days"` // 0 when the category is kept forever
+	Cutoff        *time.Time `json:"cutoff,omitempty"`
+	Affected      int64      `json:"affected"`
+}
+
+// Kinds of data quality issues and the fix-up action each one offers
+const (
+	DataQualityCheckInWithoutConfirmation = "check_in_without_confirmation" // Fix: clear_check_in
+	DataQualityOverlappingConfirmed       = "overlapping_confirmed"         // Fix: cancel the later booking
+	DataQualityOrphanedParticipant        = "orphaned_participant"          // Fix: delete the participant
+	DataQualityDeletedSpace               = "deleted_space"                 // Fix: cancel the reservation
+
+	DataQualityFixClearCheckIn = "clear_check_in"
+	DataQualityFixCancel       = "cancel"
+	DataQualityFixDelete       = "delete"
+)
+
+// DataQualityReport lists inconsistencies found in reservation data
+type DataQualityReport struct {
+	GeneratedAt time.Time          `json:"generated_at"`
+	Total       int                `json:"total"`
+	Counts      map[string]int     `json:"counts"` // Issue type -> issues found
+	Issues      []DataQualityIssue `json:"issues"`
+}
+
+// DataQualityIssue represents one inconsistency and how it can be fixed
+type DataQualityIssue struct {
+	Type          string     `json:"type"`
+	RecordID      uuid.UUID  `json:"record_id"` // Reservation, or co-organizer or guest for orphaned participants
+	ReservationID *uuid.UUID `json:"reservation_id,omitempty"`
+	RelatedID     *uuid.UUID `json:"related_id,omitempty"` // Earlier booking overlapped by the reservation
+	Description   string     `json:"description"`
+	FixAction     string     `json:"fix_action"`
+}
+
+// FixDataQualityResponse represents the outcome of fixing data quality issues
+type FixDataQualityResponse struct {
+	Type    string                `json:"type"`
+	Matched int                   `json:"matched"`
+	Fixed   int                   `json:"fixed"`
+	Failed  int                   `json:"failed"`
+	Errors  []DataQualityFixError `json:"errors,omitempty"`
+}
+
+// DataQualityFixError represents an issue that could not be fixed
+type DataQualityFixError struct {
+	RecordID uuid.UUID `json:"record_id"`
+	Error    string    `json:"error"`
+}
+
+// PublicAvailabilityResponse is the free/busy view of one day for lobby screens and public
+// booking portals. It never says who booked a space or what for.
+type PublicAvailabilityResponse struct {
+	Date        string                    `json:"date"`
+	Timezone    string                    `json:"timezone"`
+	GeneratedAt time.Time                 `json:"generated_at"`
+	Spaces      []PublicSpaceAvailability `json:"spaces"`
+}
+
+// PublicSpaceAvailability is when one space is busy during the day, buffer times included
+type PublicSpaceAvailability struct {
+	SpaceID    uuid.UUID  `json:"space_id"`
+	Name       string     `json:"name"`
+	Type       string     `json:"type"`
+	Building   string     `json:"building"`
+	Floor      int        `json:"floor"`
+	RoomNumber string     `json:"room_number"`
+	Capacity   int        `json:"capacity"`
+	FreeNow    bool       `json:"free_now"`
+	Busy       []TimeSlot `json:"busy"`
+}
+
+// ReportColumn describes a column of a custom report
+type ReportColumn struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"` // period, dimension or measure
+}
+
+// ReportResult is the table a custom report produced, one row per group
+type ReportResult struct {
+	Columns     []ReportColumn  `json:"columns"`
+	Rows        [][]interface{} `json:"rows"` // Values in column order; periods and dimensions as text, measures as numbers
+	RowCount    int             `json:"row_count"`
+	Truncated   bool            `json:"truncated"` // More groups than the limit matched
+	GeneratedAt time.Time       `json:"generated_at"`
+}
+
+// ReportRunResponse represents a custom report queued to run in the background
+type ReportRunResponse struct {
+	ID            uuid.UUID         `json:"id"`
+	Status        string            `json:"status"`
+	Definition    *ReportDefinition `json:"definition"`
+	Error         string            `json:"error,omitempty"`
+	RequestedByID uuid.UUID         `json:"requested_by_id"`
+	StartedAt     *time.Time        `json:"started_at,omitempty"`
+	CompletedAt   *time.Time        `json:"completed_at,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	Result        *ReportResult     `json:"result,omitempty"` // Set once completed
+}
+
+// ReportCatalog lists what custom reports can be built from
+type ReportCatalog struct {
+	Sources    []ReportSourceCatalog `json:"sources"`
+	DateGrains []string              `json:"date_grains"`
+	Operators  []string              `json:"operators"`
+}
+
+// ReportSourceCatalog lists the fields of one report source
+type ReportSourceCatalog struct {
+	Name       string              `json:"name"`
+	DateField  string              `json:"date_field"` // What from, to and the date grain apply to
+	Dimensions []string            `json:"dimensions"`
+	Measures   []string            `json:"measures"`
+	Filters    []ReportFilterField `json:"filters"`
+}
+
+// ReportFilterField is a field reports can filter on, with the type its values take
+type ReportFilterField struct {
+	Name string `json:"name"`
+	Type string `json:"type"` // text, number, uuid or boolean
+}
+
+// CapacityForecastResponse represents the demand expected per building and space type over the coming days
+type CapacityForecastResponse struct {
+	From             string                  `json:"from"`
+	To               string                  `json:"to"`
+	HistoryFrom      string                  `json:"history_from"`
+	HistoryTo        string                  `json:"history_to"`
+	ThresholdPercent int                     `json:"threshold_percent"`
+	Groups           []CapacityForecastGroup `json:"groups"`
+	AtRiskDays       []CapacityRiskDay       `json:"at_risk_days"` // Every day and group at risk, busiest first
+	GeneratedAt      time.Time               `json:"generated_at"`
+}
+
+// CapacityForecastGroup represents the forecast of the spaces of one building and type
+type CapacityForecastGroup struct {
+	Building  string                `json:"building"`
+	SpaceType string                `json:"space_type"`
+	Spaces    int                   `json:"spaces"`
+	Capacity  int                   `json:"capacity"`
+	Trend     float64               `json:"trend"` // Recent demand relative to the whole history
+	Days      []CapacityForecastDay `json:"days"`
+}
+
+// CapacityForecastDay represents the demand expected in a group of spaces on one day
+type CapacityForecastDay struct {
+	Date                 string  `json:"date"`
+	Weekday              string  `json:"weekday"`
+	BookedSeatHours      float64 `json:"booked_seat_hours"`    // Already booked
+	ForecastSeatHours    float64 `json:"forecast_seat_hours"`  // Expected once booking is over, at least what is booked
+	AvailableSeatHours   float64 `json:"available_seat_hours"` // Seat time the spaces offer in a workday
+	ForecastUsagePercent int     `json:"forecast_usage_percent"`
+	Holiday              bool    `json:"holiday,omitempty"`
+	AtRisk               bool    `json:"at_risk"`
+}
+
+// CapacityRiskDay represents a day a group of spaces is likely to run out of room
+type CapacityRiskDay struct {
+	Date                 string `json:"date"`
+	Building             string `json:"building"`
+	SpaceType            string `json:"space_type"`
+	ForecastUsagePercent int    `json:"forecast_usage_percent"`
+}
+
+// BookingInsightsResponse represents how a user booked spaces over a past period
+type BookingInsightsResponse struct {
+	From                   time.Time           `json:"from"`
+	To                     time.Time           `json:"to"`
+	Timezone               string              `json:"timezone"`
+	Reservations           int                 `json:"reservations"` // Not cancelled nor rejected
+	Cancelled              int                 `json:"cancelled"`
+	CancellationPercent    int                 `json:"cancellation_percent"`
+	AverageDurationMinutes int                 `json:"average_duration_minutes"`
+	AverageParticipants    float64             `json:"average_participants"`
+	FavoriteSpaces         []FavoriteSpace     `json:"favorite_spaces"`
+	Punctuality            PunctualityInsight  `json:"punctuality"`
+	Weekdays               []WeekdayBookings   `json:"weekdays"` // Busiest first
+	BusiestHour            *int                `json:"busiest_hour,omitempty"`
+	Suggestions            []BookingSuggestion `json:"suggestions"`
+}
+
+// FavoriteSpace represents a space a user books often
+type FavoriteSpace struct {
+	SpaceID      uuid.UUID `json:"space_id"`
+	Name         string    `json:"name"`
+	Building     string    `json:"building"`
+	Reservations int       `json:"reservations"`
+	SharePercent int       `json:"share_percent"`
+}
+
+// PunctualityInsight represents how early or late a user checks in
+type PunctualityInsight struct {
+	CheckedIn                  int     `json:"checked_in"`
+	OnTimePercent              int     `json:"on_time_percent"` // Checked in at most 5 minutes after the start
+	AverageCheckInDelayMinutes float64 `json:"average_check_in_delay_minutes"`
+	NoShows                    int     `json:"no_shows"`
+}
+
+// WeekdayBookings represents the reservations a user starts on one weekday
+type WeekdayBookings struct {
+	Weekday      string `json:"weekday"`
+	Reservations int    `json:"reservations"`
+}
+
+// BookingSuggestion represents advice drawn from a user's booking habits
+type BookingSuggestion struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// FavoriteSpaceResponse represents a space the user marked as a favorite
+type FavoriteSpaceResponse struct {
+	SpaceID     uuid.UUID `json:"space_id"`
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	Building    string    `json:"building"`
+	Floor       int       `json:"floor"`
+	Capacity    int       `json:"capacity"`
+	Status      string    `json:"status"`
+	FavoritedAt time.Time `json:"favorited_at"`
+}
+
+// BookingTemplateResponse represents a booking template of the user
+type BookingTemplateResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	Name             string     `json:"name"`
+	Title            string     `json:"title"`
+	Description      string     `json:"description,omitempty"`
+	DurationMinutes  int        `json:"duration_minutes"`
+	ParticipantCount int        `json:"participant_count"`
+	SpaceID          *uuid.UUID `json:"space_id,omitempty"`
+	SpaceName        string     `json:"space_name,omitempty"`
+	Building         string     `json:"building,omitempty"`
+	IsPrivate        bool       `json:"is_private"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// SavedSearchResponse represents saved space search criteria of the user
+type SavedSearchResponse struct {
+	ID                 uuid.UUID  `json:"id"`
+	Name               string     `json:"name"`
+	MinCapacity        int        `json:"min_capacity,omitempty"`
+	Types              []string   `json:"types"`
+	Building           string     `json:"building,omitempty"`
+	Amenities          []string   `json:"amenities"`
+	WindowStart        *time.Time `json:"window_start,omitempty"`
+	WindowEnd          *time.Time `json:"window_end,omitempty"`
+	MinDurationMinutes int        `json:"min_duration_minutes"`
+	AlertsEnabled      bool       `json:"alerts_enabled"`
+	Expired            bool       `json:"expired"` // The window is over, so no alert is sent anymore
+	LastAlertedAt      *time.Time `json:"last_alerted_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// FeedbackReportResponse represents the quality of spaces as rated by organizers when checking out
+type FeedbackReportResponse struct {
+	From          string                    `json:"from"`
+	To            string                    `json:"to"`
+	Interval      string                    `json:"interval"`
+	FeedbackCount int                       `json:"feedback_count"`
+	RatedCount    int                       `json:"rated_count"`
+	AverageRating float64                   `json:"average_rating"` // 0 when nothing was rated
+	LowRatings    int                       `json:"low_ratings"`    // Ratings of 3 or less
+	Trend         []FeedbackTrendPoint      `json:"trend"`
+	TopComplaints []FeedbackKeyword         `json:"top_complaints"`
+	Buildings     []FeedbackBuildingSummary `json:"buildings"` // Lowest average rating first
+	GeneratedAt   time.Time                 `json:"generated_at"`
+}
+
+// FeedbackBuildingSummary represents the feedback left on the spaces of one building
+type FeedbackBuildingSummary struct {
+	Building      string                 `json:"building"`
+	FeedbackCount int                    `json:"feedback_count"`
+	RatedCount    int                    `json:"rated_count"`
+	AverageRating float64                `json:"average_rating"`
+	LowRatings    int                    `json:"low_ratings"`
+	Trend         []FeedbackTrendPoint   `json:"trend"`
+	TopComplaints []FeedbackKeyword      `json:"top_complaints"`
+	Spaces        []FeedbackSpaceSummary `json:"spaces"` // Lowest average rating first
+}
+
+// FeedbackSpaceSummary represents the feedback left on one space
+type FeedbackSpaceSummary struct {
+	SpaceID       uuid.UUID         `json:"space_id"`
+	SpaceName     string            `json:"space_name"`
+	FeedbackCount int               `json:"feedback_count"`
+	RatedCount    int               `json:"rated_count"`
+	AverageRating float64           `json:"average_rating"`
+	LowRatings    int               `json:"low_ratings"`
+	TopComplaints []FeedbackKeyword `json:"top_complaints"`
+}
+
+// FeedbackTrendPoint represents the ratings of one week or month
+type FeedbackTrendPoint struct {
+	PeriodStart   string  `json:"period_start"`
+	FeedbackCount int     `json:"feedback_count"`
+	RatedCount    int     `json:"rated_count"`
+	AverageRating float64 `json:"average_rating"`
+}
+
+// FeedbackKeyword represents a word recurring in the comments of low ratings
+type FeedbackKeyword struct {
+	Keyword  string `json:"keyword"`
+	Comments int    `json:"comments"` // Comments mentioning it
+}
+
+// BroadcastChannelResponse represents an announcement channel
+type BroadcastChannelResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Building    string     `json:"building,omitempty"` // Empty for a channel to every user
+	CreatedByID uuid.UUID  `json:"created_by_id"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// BroadcastPostResponse represents an announcement posted to a channel
+type BroadcastPostResponse struct {
+	ID          uuid.UUID           `json:"id"`
+	ChannelID   uuid.UUID           `json:"channel_id"`
+	ChannelName string              `json:"channel_name"`
+	Building    string              `json:"building,omitempty"`
+	Kind        string              `json:"kind"`
+	Title       string              `json:"title"`
+	Body        string              `json:"body"`
+	AuthorID    uuid.UUID           `json:"author_id"`
+	AuthorName  string              `json:"author_name"`
+	CreatedAt   time.Time           `json:"created_at"`
+	ReadAt      *time.Time          `json:"read_at,omitempty"` // In the recipient's inbox
+	Stats       *BroadcastPostStats `json:"stats,omitempty"`   // For publishers
+}
+
+// BroadcastPostStats represents how many recipients read an announcement
+type BroadcastPostStats struct {
+	RecipientCount int        `json:"recipient_count"`
+	ReadCount      int64      `json:"read_count"`
+	ReadRate       float64    `json:"read_rate"` // Percentage of recipients who read it
+	LastReadAt     *time.Time `json:"last_read_at,omitempty"`
+}
+
+// ActivityEventResponse represents an entry of the admin activity feed
+type ActivityEventResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	Category   string          `json:"category"` // booking, approval, chat or job
+	Action     string          `json:"action"`
+	ActorID    *uuid.UUID      `json:"actor_id,omitempty"` // Empty for the system
+	ActorName  string          `json:"actor_name,omitempty"`
+	EntityType string          `json:"entity_type"`
+	EntityID   *uuid.UUID      `json:"entity_id,omitempty"`
+	EntityName string          `json:"entity_name,omitempty"`
+	Details    json.RawMessage `json:"details,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Cursor     string          `json:"cursor"` // Resumes the feed after this event
+}
@@ -0,0 +1,347 @@
+// internal/dto/validation.go
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Validation codes of field errors; each is also the key of its localized message
+const (
+	ValidationRequired     = "required"
+	ValidationRequiredWith = "required_with"
+	ValidationMinLength    = "min_length"
+	ValidationMaxLength    = "max_length"
+	ValidationMinItems     = "min_items"
+	ValidationMaxItems     = "max_items"
+	ValidationMin          = "min"
+	ValidationMax          = "max"
+	ValidationOneOf        = "oneof"
+	ValidationEmail        = "email"
+	ValidationURL          = "url"
+	ValidationUUID         = "uuid"
+	ValidationPhone        = "phone"
+	ValidationType         = "type"
+	ValidationInvalid      = "invalid"
+	ValidationBefore       = "before"
+	ValidationNotAbove     = "not_above"
+	ValidationFuture       = "future"
+	ValidationMinDuration  = "min_duration"
+	ValidationMaxDuration  = "max_duration"
+	ValidationTimeFormat   = "time_format"
+	ValidationTimezone     = "timezone"
+	ValidationExclusive    = "exclusive"
+	ValidationNotListed    = "not_listed"
+)
+
+// validationMessages holds the message of each validation code per language; {field} is the
+// field name and other placeholders are filled from the params of the error
+var validationMessages = map[string]map[string]string{
+	ValidationRequired: {
+		"en": "{field} is required",
+		"fr": "{field} est obligatoire",
+	},
+	ValidationRequiredWith: {
+		"en": "{field} is required when {other} is set",
+		"fr": "{field} est obligatoire lorsque {other} est défini",
+	},
+	ValidationMinLength: {
+		"en": "{field} must be at least {min} characters long",
+		"fr": "{field} doit contenir au moins {min} caractères",
+	},
+	ValidationMaxLength: {
+		"en": "{field} must be at most {max} characters long",
+		"fr": "{field} doit contenir au plus {max} caractères",
+	},
+	ValidationMinItems: {
+		"en": "{field} must have at least {min} items",
+		"fr": "{field} doit contenir au moins {min} éléments",
+	},
+	ValidationMaxItems: {
+		"en": "{field} must have at most {max} items",
+		"fr": "{field} doit contenir au plus {max} éléments",
+	},
+	ValidationMin: {
+		"en": "{field} must be at least {min}",
+		"fr": "{field} doit être supérieur ou égal à {min}",
+	},
+	ValidationMax: {
+		"en": "{field} must be at most {max}",
+		"fr": "{field} doit être inférieur ou égal à {max}",
+	},
+	ValidationOneOf: {
+		"en": "{field} must be one of: {values}",
+		"fr": "{field} doit valoir l'une des valeurs : {values}",
+	},
+	ValidationEmail: {
+		"en": "{field} must be a valid email address",
+		"fr": "{field} doit être une adresse e-mail valide",
+	},
+	ValidationURL: {
+		"en": "{field} must be a valid URL",
+		"fr": "{field} doit être une URL valide",
+	},
+	ValidationUUID: {
+		"en": "{field} must be a valid UUID",
+		"fr": "{field} doit être un UUID valide",
+	},
+	ValidationPhone: {
+		"en": "{field} must be a phone number in international format",
+		"fr": "{field} doit être un numéro de téléphone au format international",
+	},
+	ValidationType: {
+		"en": "{field} must be of type {type}",
+		"fr": "{field} doit être de type {type}",
+	},
+	ValidationInvalid: {
+		"en": "{field} is invalid",
+		"fr": "{field} est invalide",
+	},
+	ValidationBefore: {
+		"en": "{field} must be before {other}",
+		"fr": "{field} doit être antérieur à {other}",
+	},
+	ValidationNotAbove: {
+		"en": "{field} must be less than or equal to {other}",
+		"fr": "{field} doit être inférieur ou égal à {other}",
+	},
+	ValidationFuture: {
+		"en": "{field} must be in the future",
+		"fr": "{field} doit être dans le futur",
+	},
+	ValidationMinDuration: {
+		"en": "the time slot must be at least {minutes} minutes long",
+		"fr": "le créneau doit durer au moins {minutes} minutes",
+	},
+	ValidationMaxDuration: {
+		"en": "the time slot cannot exceed {hours} hours",
+		"fr": "le créneau ne peut pas dépasser {hours} heures",
+	},
+	ValidationTimeFormat: {
+		"en": "{field} must use the {format} format",
+		"fr": "{field} doit utiliser le format {format}",
+	},
+	ValidationTimezone: {
+		"en": "{field} must be a valid IANA timezone",
+		"fr": "{field} doit être un fuseau horaire IANA valide",
+	},
+	ValidationExclusive: {
+		"en": "{field} cannot be set together with {other}",
+		"fr": "{field} ne peut pas être défini avec {other}",
+	},
+	ValidationNotListed: {
+		"en": "{field} must be an active code of the list kept by administrators",
+		"fr": "{field} doit être un code actif de la liste tenue par les administrateurs",
+	},
+}
+
+// NewFieldError creates the error of one field; params fill the placeholders of its message
+func NewFieldError(field, code string, params map[string]interface{}) ValidationError {
+	fieldError := ValidationError{Field: field, Code: code, Params: params}
+	fieldError.Message = fieldError.Localize(DefaultLanguage)
+	return fieldError
+}
+
+// Localize returns the message of the field error in the language, falling back to the default language
+func (e *ValidationError) Localize(lang string) string {
+	messages, ok := validationMessages[e.Code]
+	if !ok {
+		return e.Message
+	}
+	message, ok := messages[lang]
+	if !ok {
+		message = messages[DefaultLanguage]
+	}
+
+	message = strings.ReplaceAll(message, "{field}", e.Field)
+	for name, value := range e.Params {
+		message = strings.ReplaceAll(message, "{"+name+"}", fmt.Sprint(value))
+	}
+	return message
+}
+
+// ValidationErrors lists every invalid field of a request
+type ValidationErrors []ValidationError
+
+// Error joins the messages of the fields in the default language
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldError := range e {
+		messages[i] = fieldError.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Localize returns the field errors with their messages in the language
+func (e ValidationErrors) Localize(lang string) ValidationErrors {
+	localized := make(ValidationErrors, len(e))
+	for i, fieldError := range e {
+		fieldError.Message = fieldError.Localize(lang)
+		localized[i] = fieldError
+	}
+	return localized
+}
+
+// Add appends the error of one field
+func (e *ValidationErrors) Add(field, code string, params map[string]interface{}) {
+	*e = append(*e, NewFieldError(field, code, params))
+}
+
+// Err returns the errors as an error, nil when there are none
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Prefixed returns the errors with their fields nested under a parent field
+func (e ValidationErrors) Prefixed(parent string) ValidationErrors {
+	prefixed := make(ValidationErrors, 0, len(e))
+	for _, fieldError := range e {
+		prefixed = append(prefixed, NewFieldError(parent+"."+fieldError.Field, fieldError.Code, fieldError.Params))
+	}
+	return prefixed
+}
+
+// ========================================
+// STRUCT VALIDATION
+// ========================================
+
+// structValidator checks the validate tags of request DTOs; binding tags are checked by gin
+var structValidator = newStructValidator()
+
+func newStructValidator() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("validate")
+	UseJSONFieldNames(v)
+	return v
+}
+
+// UseJSONFieldNames makes a validator report fields by their JSON, or query, names
+func UseJSONFieldNames(v *validator.Validate) {
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form", "uri"} {
+			name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+}
+
+// Validate checks the validate tags of a request, then its own cross-field rules when it has
+// a Validate method. Failures are returned as ValidationErrors.
+func Validate(req interface{}) error {
+	if err := structValidator.Struct(req); err != nil {
+		if fields, ok := ValidationErrorsFrom(err); ok {
+			return fields
+		}
+		return err
+	}
+
+	if validatable, ok := req.(interface{ Validate() error }); ok {
+		return validatable.Validate()
+	}
+	return nil
+}
+
+// ValidationErrorsFrom converts the errors of request binding and validation to field errors.
+// It reports false for errors about something other than the fields of the request.
+func ValidationErrorsFrom(err error) (ValidationErrors, bool) {
+	var fields ValidationErrors
+	if errors.As(err, &fields) {
+		return fields, true
+	}
+
+	var tagErrors validator.ValidationErrors
+	if errors.As(err, &tagErrors) {
+		fields = make(ValidationErrors, 0, len(tagErrors))
+		for _, tagError := range tagErrors {
+			fields = append(fields, fieldErrorFromTag(tagError))
+		}
+		return fields, true
+	}
+
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) && typeError.Field != "" {
+		return ValidationErrors{
+			NewFieldError(typeError.Field, ValidationType, map[string]interface{}{"type": jsonTypeName(typeError.Type)}),
+		}, true
+	}
+
+	return nil, false
+}
+
+// fieldErrorFromTag maps a failed validation tag to its code
+func fieldErrorFromTag(tagError validator.FieldError) ValidationError {
+	// The namespace starts with the struct name, which clients never see
+	field := tagError.Namespace()
+	if _, nested, ok := strings.Cut(field, "."); ok {
+		field = nested
+	}
+
+	sized := tagError.Kind() == reflect.String || tagError.Kind() == reflect.Slice || tagError.Kind() == reflect.Map
+	switch tagError.Tag() {
+	case "required":
+		return NewFieldError(field, ValidationRequired, nil)
+	case "min", "gte":
+		switch {
+		case tagError.Kind() == reflect.String:
+			return NewFieldError(field, ValidationMinLength, map[string]interface{}{"min": tagError.Param()})
+		case sized:
+			return NewFieldError(field, ValidationMinItems, map[string]interface{}{"min": tagError.Param()})
+		default:
+			return NewFieldError(field, ValidationMin, map[string]interface{}{"min": tagError.Param()})
+		}
+	case "max", "lte":
+		switch {
+		case tagError.Kind() == reflect.String:
+			return NewFieldError(field, ValidationMaxLength, map[string]interface{}{"max": tagError.Param()})
+		case sized:
+			return NewFieldError(field, ValidationMaxItems, map[string]interface{}{"max": tagError.Param()})
+		default:
+			return NewFieldError(field, ValidationMax, map[string]interface{}{"max": tagError.Param()})
+		}
+	case "oneof":
+		return NewFieldError(field, ValidationOneOf, map[string]interface{}{"values": strings.Join(strings.Fields(tagError.Param()), ", ")})
+	case "email":
+		return NewFieldError(field, ValidationEmail, nil)
+	case "url", "http_url":
+		return NewFieldError(field, ValidationURL, nil)
+	case "uuid", "uuid4":
+		return NewFieldError(field, ValidationUUID, nil)
+	case "e164":
+		return NewFieldError(field, ValidationPhone, nil)
+	default:
+		return NewFieldError(field, ValidationInvalid, map[string]interface{}{"rule": tagError.Tag()})
+	}
+}
+
+// jsonTypeName names a Go type the way JSON clients know it
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return "string"
+	}
+}
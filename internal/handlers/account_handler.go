@@ -0,0 +1,129 @@
+// internal/handlers/account_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AccountHandler handles self-service data export and account deletion
+type AccountHandler struct {
+	accountService *services.AccountService
+}
+
+// NewAccountHandler creates a new account handler
+func NewAccountHandler(accountService *services.AccountService) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+	}
+}
+
+// ExportData downloads the current user's data
+// @Summary Export my data
+// @Description ZIP archive of JSON files with the user's profile, preferences, reservations, notifications and chat transcripts
+// @Tags users
+// @Produce application/zip
+// @Success 200 {file} file
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /users/me/export [get]
+func (h *AccountHandler) ExportData(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	archive, err := h.accountService.ExportData(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, h.determineAccountErrorStatus(err), "Failed to export data", err)
+		return
+	}
+
+	filename := fmt.Sprintf("account-export-%s.zip", time.Now().Format("2006-01-02"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/zip", archive)
+}
+
+// DeleteAccount deletes the current user's account
+// @Summary Delete my account
+// @Description Confirm with the password to cancel upcoming bookings and anonymize the account. Past bookings stay in statistics without personal details.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.DeleteAccountRequest true "Password confirmation"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /users/me [delete]
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.accountService.DeleteAccount(userID, &req); err != nil {
+		respondError(c, h.determineAccountErrorStatus(err), "Failed to delete account", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Account deleted successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *AccountHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineAccountErrorStatus determines HTTP status code based on error message
+func (h *AccountHandler) determineAccountErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "last admin"):
+		return http.StatusForbidden
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,145 @@
+// internal/handlers/activity_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// How often a caught-up activity stream looks for new events
+const activityStreamPoll = 2 * time.Second
+
+// ActivityHandler serves the admin activity feed
+type ActivityHandler struct {
+	activityService *services.ActivityService
+}
+
+// NewActivityHandler creates a new activity handler
+func NewActivityHandler(activityService *services.ActivityService) *ActivityHandler {
+	return &ActivityHandler{
+		activityService: activityService,
+	}
+}
+
+// GetEvents pages or streams the activity feed (admin only)
+// @Summary Admin activity feed
+// @Description Bookings, approval decisions, chat escalations and scheduled job runs in the order they occurred. Page with the cursor, or pass stream=true to replay from the cursor as server-sent events and keep following new events; every event carries its cursor as id, so reconnecting clients resume from Last-Event-ID. Bookings are attributed to their organizer.
+// @Tags admin
+// @Produce json
+// @Produce text/event-stream
+// @Param category query []string false "booking, approval, chat or job; repeat for several" collectionFormat(multi)
+// @Param actor_id query string false "User who acted" format(uuid)
+// @Param entity_type query string false "reservation, conversation or job"
+// @Param entity_id query string false "Reservation or conversation" format(uuid)
+// @Param from query string false "Occurred at or after (RFC 3339)"
+// @Param to query string false "Occurred before (RFC 3339)"
+// @Param cursor query string false "Cursor of the last event read"
+// @Param limit query int false "Events per page" default(50)
+// @Param stream query bool false "Stream as server-sent events"
+// @Param Last-Event-ID header string false "ID of the last event received, for streams"
+// @Success 200 {object} dto.CursorPaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/events [get]
+func (h *ActivityHandler) GetEvents(c *gin.Context) {
+	var req dto.ActivityEventsRequest
+	if err := bindQuery(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	if !req.Stream {
+		response, err := h.activityService.GetEvents(c.Request.Context(), &req)
+		if err != nil {
+			respondError(c, h.determineActivityErrorStatus(err), "Failed to get activity", err)
+			return
+		}
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	// EventSource sends Last-Event-ID when it reconnects
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		req.Cursor = lastEventID
+	}
+
+	// The first batch is read before the stream opens, so bad filters still get a JSON error
+	ctx, cancel := streamContext(c)
+	defer cancel()
+	batch, more, err := h.activityService.NextEvents(ctx, &req)
+	if err != nil {
+		respondError(c, h.determineActivityErrorStatus(err), "Failed to get activity", err)
+		return
+	}
+
+	// The stream stays open past the server write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", eventStreamRetry.Milliseconds())
+	c.Writer.Flush()
+
+	lastSent := time.Now()
+	for {
+		for _, event := range batch {
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(c.Writer, "id: %s\nevent: activity\ndata: %s\n\n", event.Cursor, data)
+			req.Cursor = event.Cursor
+		}
+		if len(batch) > 0 {
+			lastSent = time.Now()
+		} else if time.Since(lastSent) >= eventStreamHeartbeat {
+			fmt.Fprint(c.Writer, ": keep-alive\n\n")
+			lastSent = time.Now()
+		}
+		c.Writer.Flush()
+
+		// The replay goes on without waiting until it caught up
+		if !more {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(activityStreamPoll):
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		batch, more, err = h.activityService.NextEvents(ctx, &req)
+		if err != nil {
+			if ctx.Err() == nil {
+				fmt.Fprintf(c.Writer, "event: error\ndata: %q\n\n", err.Error())
+				c.Writer.Flush()
+			}
+			return
+		}
+	}
+}
+
+func (h *ActivityHandler) determineActivityErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dto.ErrInvalidCursor):
+		return http.StatusBadRequest
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
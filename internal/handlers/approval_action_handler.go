@@ -0,0 +1,101 @@
+// internal/handlers/approval_action_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ApprovalActionHandler handles the approve and reject links of approval emails
+type ApprovalActionHandler struct {
+	approvalLinkService *services.ApprovalLinkService
+}
+
+// NewApprovalActionHandler creates a new approval action handler
+func NewApprovalActionHandler(approvalLinkService *services.ApprovalLinkService) *ApprovalActionHandler {
+	return &ApprovalActionHandler{
+		approvalLinkService: approvalLinkService,
+	}
+}
+
+// GetApprovalAction shows what an approval link decides (the token authorizes access)
+// @Summary Get approval action
+// @Description Public link from an approval email showing the reservation and the decision it makes. Opening the link does not decide; the decision is confirmed with a POST to it.
+// @Tags approvals
+// @Produce json
+// @Param token path string true "Approval link token"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ApprovalActionResponse}
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /approval-actions/{token} [get]
+func (h *ApprovalActionHandler) GetApprovalAction(c *gin.Context) {
+	action, err := h.approvalLinkService.GetAction(c.Param("token"))
+	if err != nil {
+		respondError(c, h.determineApprovalActionErrorStatus(err), "Failed to get approval action", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Approval action retrieved successfully",
+		Data:    action,
+	})
+}
+
+// ConfirmApprovalAction approves or rejects a reservation from an approval link (the token authorizes the action)
+// @Summary Confirm approval action
+// @Description Make the decision of an approval email link as the approver it was sent to. Each link works once; rejecting requires comments, which become the rejection reason.
+// @Tags approvals
+// @Accept json
+// @Produce json
+// @Param token path string true "Approval link token"
+// @Param request body dto.ApprovalActionRequest false "Comments"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ApprovalActionResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /approval-actions/{token} [post]
+func (h *ApprovalActionHandler) ConfirmApprovalAction(c *gin.Context) {
+	var req dto.ApprovalActionRequest
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid request data", err)
+			return
+		}
+	}
+
+	action, err := h.approvalLinkService.Act(c.Param("token"), &req, c.ClientIP())
+	if err != nil {
+		respondError(c, h.determineApprovalActionErrorStatus(err), "Failed to confirm approval action", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Approval decision recorded successfully",
+		Data:    action,
+	})
+}
+
+// determineApprovalActionErrorStatus determines HTTP status code based on error
+func (h *ApprovalActionHandler) determineApprovalActionErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"),
+		err.Error() == "invalid or expired approval link":
+		return http.StatusNotFound
+	case err.Error() == "access denied",
+		err.Error() == "second approval must come from another admin":
+		return http.StatusForbidden
+	case err.Error() == "reservation is not pending approval":
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
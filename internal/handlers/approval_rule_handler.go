@@ -0,0 +1,192 @@
+// internal/handlers/approval_rule_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ApprovalRuleHandler handles the per-space approval rules
+type ApprovalRuleHandler struct {
+	ruleService *services.ApprovalRuleService
+}
+
+// NewApprovalRuleHandler creates a new approval rule handler
+func NewApprovalRuleHandler(ruleService *services.ApprovalRuleService) *ApprovalRuleHandler {
+	return &ApprovalRuleHandler{
+		ruleService: ruleService,
+	}
+}
+
+// GetRules lists the approval rules of all spaces (admin only)
+// @Summary List approval rules
+// @Description Auto-approval thresholds and two-step setting of every space that has a rule
+// @Tags approval-rules
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /admin/approval-rules [get]
+func (h *ApprovalRuleHandler) GetRules(c *gin.Context) {
+	rules, err := h.ruleService.GetRules()
+	if err != nil {
+		respondError(c, h.determineRuleErrorStatus(err), "Failed to get approval rules", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Approval rules retrieved successfully",
+		Data:    rules,
+	})
+}
+
+// GetRule returns the approval rule of a space (admin only)
+// @Summary Get approval rule
+// @Description Approval rule of a space; spaces without one need a single approval for every booking
+// @Tags approval-rules
+// @Produce json
+// @Param spaceId path string true "Space ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/approval-rules/{spaceId} [get]
+func (h *ApprovalRuleHandler) GetRule(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("spaceId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	rule, err := h.ruleService.GetRule(spaceID)
+	if err != nil {
+		respondError(c, h.determineRuleErrorStatus(err), "Failed to get approval rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Approval rule retrieved successfully",
+		Data:    rule,
+	})
+}
+
+// SetRule creates or replaces the approval rule of a space (admin only)
+// @Summary Set approval rule
+// @Description Make approval of a space conditional. Bookings within every threshold given (duration, participants, and tenure or role for seniority) are confirmed straight away; the others wait for approval. With two_step, the space manager's approval is followed by a second one from another admin; auditoriums default to two-step.
+// @Tags approval-rules
+// @Accept json
+// @Produce json
+// @Param spaceId path string true "Space ID" format(uuid)
+// @Param request body dto.SetApprovalRuleRequest true "Rule"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/approval-rules/{spaceId} [put]
+func (h *ApprovalRuleHandler) SetRule(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("spaceId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SetApprovalRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	rule, err := h.ruleService.SetRule(spaceID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineRuleErrorStatus(err), "Failed to set approval rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Approval rule saved successfully",
+		Data:    rule,
+	})
+}
+
+// DeleteRule removes the approval rule of a space (admin only)
+// @Summary Delete approval rule
+// @Description Every booking of the space needs a single approval again
+// @Tags approval-rules
+// @Produce json
+// @Param spaceId path string true "Space ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/approval-rules/{spaceId} [delete]
+func (h *ApprovalRuleHandler) DeleteRule(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("spaceId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.ruleService.DeleteRule(spaceID); err != nil {
+		respondError(c, h.determineRuleErrorStatus(err), "Failed to delete approval rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Approval rule deleted successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *ApprovalRuleHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineRuleErrorStatus determines HTTP status code based on error message
+func (h *ApprovalRuleHandler) determineRuleErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
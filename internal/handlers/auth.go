@@ -32,8 +32,8 @@ func NewAuthHandler(db *gorm.DB, cfg *config.Config) *AuthHandler {
 
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req dto.RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -77,8 +77,8 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -146,8 +146,8 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 	}
 
 	var req dto.UpdateProfileRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -200,8 +200,8 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	}
 
 	var req dto.ChangePasswordRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -242,8 +242,8 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req dto.RefreshTokenRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -269,8 +269,8 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 	var req dto.ForgotPasswordRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -289,8 +289,8 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 
 func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	var req dto.ResetPasswordRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -307,8 +307,8 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 // Admin-only endpoints
 func (h *AuthHandler) GetAllUsers(c *gin.Context) {
 	var query dto.GetUsersQuery
-	if err := c.ShouldBindQuery(&query); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindQuery(c, &query); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -336,8 +336,8 @@ func (h *AuthHandler) GetAllUsers(c *gin.Context) {
 
 func (h *AuthHandler) SearchUsers(c *gin.Context) {
 	var query dto.GetUsersQuery
-	if err := c.ShouldBindQuery(&query); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindQuery(c, &query); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -368,8 +368,8 @@ func (h *AuthHandler) UpdateUserRole(c *gin.Context) {
 	}
 
 	var req dto.UpdateUserRoleRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -383,6 +383,30 @@ func (h *AuthHandler) UpdateUserRole(c *gin.Context) {
 	})
 }
 
+// UpdateUserPriority sets the booking priority tier of a user (admin only)
+func (h *AuthHandler) UpdateUserPriority(c *gin.Context) {
+	userUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req dto.UpdateUserPriorityRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	if err := h.authService.UpdateUserPriority(userUUID, *req.PriorityTier); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user priority"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "User priority updated successfully",
+	})
+}
+
 func (h *AuthHandler) ActivateUser(c *gin.Context) {
 	userIDParam := c.Param("id")
 	userUUID, err := uuid.Parse(userIDParam)
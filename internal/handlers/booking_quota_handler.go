@@ -0,0 +1,269 @@
+// internal/handlers/booking_quota_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BookingQuotaHandler handles booking quota usage and the overrides set by admins
+type BookingQuotaHandler struct {
+	quotaService *services.BookingQuotaService
+}
+
+// NewBookingQuotaHandler creates a new booking quota handler
+func NewBookingQuotaHandler(quotaService *services.BookingQuotaService) *BookingQuotaHandler {
+	return &BookingQuotaHandler{
+		quotaService: quotaService,
+	}
+}
+
+// GetMyUsage reports the current user's quota usage
+// @Summary My booking quotas
+// @Description Hours booked this week against the weekly quota, and premium-room hours booked by the user's department this month. A null limit means the quota does not apply.
+// @Tags quotas
+// @Produce json
+// @Param date query string false "Day whose week and month are reported (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /users/me/quota [get]
+func (h *BookingQuotaHandler) GetMyUsage(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	h.respondUsage(c, userID)
+}
+
+// GetUserUsage reports a user's quota usage (admin only)
+// @Summary User booking quotas
+// @Description Quota usage of any user, as they see it
+// @Tags quotas
+// @Produce json
+// @Param id path string true "User ID" format(uuid)
+// @Param date query string false "Day whose week and month are reported (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/quotas/users/{id}/usage [get]
+func (h *BookingQuotaHandler) GetUserUsage(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid user ID",
+			Message: "User ID must be a valid UUID",
+		})
+		return
+	}
+
+	h.respondUsage(c, userID)
+}
+
+// GetOverrides lists the quota overrides (admin only)
+// @Summary List quota overrides
+// @Description Users and teams whose quota was replaced by an admin, expired overrides included
+// @Tags quotas
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /admin/quotas/overrides [get]
+func (h *BookingQuotaHandler) GetOverrides(c *gin.Context) {
+	overrides, err := h.quotaService.GetOverrides()
+	if err != nil {
+		respondError(c, h.determineQuotaErrorStatus(err), "Failed to get quota overrides", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Quota overrides retrieved successfully",
+		Data:    overrides,
+	})
+}
+
+// SetUserOverride replaces a user's weekly quota (admin only)
+// @Summary Override user quota
+// @Description Hours the user can book per week instead of the configured quota; 0 lifts it
+// @Tags quotas
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID" format(uuid)
+// @Param request body dto.SetQuotaOverrideRequest true "Override"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/quotas/users/{id} [put]
+func (h *BookingQuotaHandler) SetUserOverride(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid user ID",
+			Message: "User ID must be a valid UUID",
+		})
+		return
+	}
+
+	adminID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SetQuotaOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	override, err := h.quotaService.SetUserOverride(userID, &req, adminID)
+	if err != nil {
+		respondError(c, h.determineQuotaErrorStatus(err), "Failed to override user quota", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "User quota overridden successfully",
+		Data:    override,
+	})
+}
+
+// SetTeamOverride replaces a department's premium-room quota (admin only)
+// @Summary Override team quota
+// @Description Premium-room hours the department can book per month instead of the configured quota; 0 lifts it
+// @Tags quotas
+// @Accept json
+// @Produce json
+// @Param department path string true "Department"
+// @Param request body dto.SetQuotaOverrideRequest true "Override"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/quotas/teams/{department} [put]
+func (h *BookingQuotaHandler) SetTeamOverride(c *gin.Context) {
+	adminID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SetQuotaOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	override, err := h.quotaService.SetTeamOverride(c.Param("department"), &req, adminID)
+	if err != nil {
+		respondError(c, h.determineQuotaErrorStatus(err), "Failed to override team quota", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Team quota overridden successfully",
+		Data:    override,
+	})
+}
+
+// DeleteOverride removes a quota override (admin only)
+// @Summary Remove quota override
+// @Description Put the configured quota back for the user or team
+// @Tags quotas
+// @Produce json
+// @Param id path string true "Override ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/quotas/overrides/{id} [delete]
+func (h *BookingQuotaHandler) DeleteOverride(c *gin.Context) {
+	overrideID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid override ID",
+			Message: "Override ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.quotaService.DeleteOverride(overrideID); err != nil {
+		respondError(c, h.determineQuotaErrorStatus(err), "Failed to delete quota override", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Quota override deleted successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// respondUsage reports the quota usage of a user for the week and month of the date queried
+func (h *BookingQuotaHandler) respondUsage(c *gin.Context, userID uuid.UUID) {
+	at := time.Now()
+	if raw := c.Query("date"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid date",
+				Message: "date must use the YYYY-MM-DD format",
+			})
+			return
+		}
+		at = parsed
+	}
+
+	usage, err := h.quotaService.GetUsage(userID, at)
+	if err != nil {
+		respondError(c, h.determineQuotaErrorStatus(err), "Failed to get quota usage", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Quota usage retrieved successfully",
+		Data:    usage,
+	})
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *BookingQuotaHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineQuotaErrorStatus determines HTTP status code based on error message
+func (h *BookingQuotaHandler) determineQuotaErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,220 @@
+// internal/handlers/booking_strike_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BookingStrikeHandler handles strikes for late cancellations and no-shows
+type BookingStrikeHandler struct {
+	strikeService *services.BookingStrikeService
+}
+
+// NewBookingStrikeHandler creates a new booking strike handler
+func NewBookingStrikeHandler(strikeService *services.BookingStrikeService) *BookingStrikeHandler {
+	return &BookingStrikeHandler{
+		strikeService: strikeService,
+	}
+}
+
+// GetMyStrikes reports the current user's strikes
+// @Summary My strikes
+// @Description Strikes earned by late cancellations and no-shows that still count, and whether they keep the user from booking premium spaces
+// @Tags strikes
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /users/me/strikes [get]
+func (h *BookingStrikeHandler) GetMyStrikes(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	h.respondSummary(c, userID)
+}
+
+// GetUserStrikes reports a user's strikes (admin only)
+// @Summary User strikes
+// @Description Strikes of any user, as they see them
+// @Tags strikes
+// @Produce json
+// @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/strikes/users/{id} [get]
+func (h *BookingStrikeHandler) GetUserStrikes(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid user ID",
+			Message: "User ID must be a valid UUID",
+		})
+		return
+	}
+
+	h.respondSummary(c, userID)
+}
+
+// GetActiveStrikes lists the strikes still counting (admin only)
+// @Summary List strikes
+// @Description Uncleared strikes of every user within the strike window, newest first
+// @Tags strikes
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.PaginatedResponse
+// @Router /admin/strikes [get]
+func (h *BookingStrikeHandler) GetActiveStrikes(c *gin.Context) {
+	page := utils.GetIntQuery(c, "page", 1)
+	limit := utils.GetIntQuery(c, "limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	strikes, total, err := h.strikeService.GetActiveStrikes((page-1)*limit, limit)
+	if err != nil {
+		respondError(c, h.determineStrikeErrorStatus(err), "Failed to get strikes", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(strikes, total, page, limit))
+}
+
+// ClearStrike clears one strike (admin only)
+// @Summary Clear strike
+// @Description Stop a strike from counting against its user; it is kept for history
+// @Tags strikes
+// @Produce json
+// @Param id path string true "Strike ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/strikes/{id} [delete]
+func (h *BookingStrikeHandler) ClearStrike(c *gin.Context) {
+	strikeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid strike ID",
+			Message: "Strike ID must be a valid UUID",
+		})
+		return
+	}
+
+	adminID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	if err := h.strikeService.ClearStrike(strikeID, adminID); err != nil {
+		respondError(c, h.determineStrikeErrorStatus(err), "Failed to clear strike", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Strike cleared successfully",
+	})
+}
+
+// ClearUserStrikes clears every strike of a user (admin only)
+// @Summary Clear user strikes
+// @Description Stop all of a user's strikes from counting, lifting any booking restriction
+// @Tags strikes
+// @Produce json
+// @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/strikes/users/{id} [delete]
+func (h *BookingStrikeHandler) ClearUserStrikes(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid user ID",
+			Message: "User ID must be a valid UUID",
+		})
+		return
+	}
+
+	adminID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	cleared, err := h.strikeService.ClearUserStrikes(userID, adminID)
+	if err != nil {
+		respondError(c, h.determineStrikeErrorStatus(err), "Failed to clear strikes", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: fmt.Sprintf("%d strikes cleared", cleared),
+		Data:    map[string]interface{}{"cleared": cleared},
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// respondSummary reports the strikes of a user
+func (h *BookingStrikeHandler) respondSummary(c *gin.Context, userID uuid.UUID) {
+	summary, err := h.strikeService.GetSummary(userID)
+	if err != nil {
+		respondError(c, h.determineStrikeErrorStatus(err), "Failed to get strikes", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Strikes retrieved successfully",
+		Data:    summary,
+	})
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *BookingStrikeHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineStrikeErrorStatus determines HTTP status code based on error message
+func (h *BookingStrikeHandler) determineStrikeErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,396 @@
+// internal/handlers/broadcast_channel_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BroadcastChannelHandler handles announcement channels, their posts and the announcement inbox
+type BroadcastChannelHandler struct {
+	channelService *services.BroadcastChannelService
+}
+
+// NewBroadcastChannelHandler creates a new broadcast channel handler
+func NewBroadcastChannelHandler(channelService *services.BroadcastChannelService) *BroadcastChannelHandler {
+	return &BroadcastChannelHandler{
+		channelService: channelService,
+	}
+}
+
+// ========================================
+// CHANNEL ENDPOINTS
+// ========================================
+
+// CreateChannel creates an announcement channel (admin only)
+// @Summary Create broadcast channel
+// @Description Create a read-only announcement channel. Posts to a building channel go to the people who booked in the building in the last 90 days or later and its space managers; posts to a channel without building go to every user.
+// @Tags broadcasts
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateBroadcastChannelRequest true "Channel"
+// @Success 201 {object} dto.SuccessResponse{data=dto.BroadcastChannelResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/broadcast-channels [post]
+func (h *BroadcastChannelHandler) CreateChannel(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateBroadcastChannelRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	channel, err := h.channelService.CreateChannel(&req, userID)
+	if err != nil {
+		respondError(c, h.determineChannelErrorStatus(err), "Failed to create broadcast channel", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Broadcast channel created successfully",
+		Data:    channel,
+	})
+}
+
+// UpdateChannel changes an announcement channel (admin only)
+// @Summary Update broadcast channel
+// @Description Rename or describe a channel, or archive it to stop posts; archived channels keep their posts
+// @Tags broadcasts
+// @Accept json
+// @Produce json
+// @Param id path string true "Channel ID" format(uuid)
+// @Param request body dto.UpdateBroadcastChannelRequest true "Changes"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BroadcastChannelResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/broadcast-channels/{id} [put]
+func (h *BroadcastChannelHandler) UpdateChannel(c *gin.Context) {
+	channelID, ok := h.parseID(c, "id", "channel")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateBroadcastChannelRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	channel, err := h.channelService.UpdateChannel(channelID, &req)
+	if err != nil {
+		respondError(c, h.determineChannelErrorStatus(err), "Failed to update broadcast channel", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Broadcast channel updated successfully",
+		Data:    channel,
+	})
+}
+
+// GetChannels lists the channels the current user can post to (manager or admin)
+// @Summary List broadcast channels
+// @Description Channels the caller can post to: every channel for admins, the channels of buildings where they manage a space for managers
+// @Tags broadcasts
+// @Produce json
+// @Param include_archived query bool false "Include archived channels" default(false)
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.BroadcastChannelResponse}
+// @Router /manager/broadcast-channels [get]
+func (h *BroadcastChannelHandler) GetChannels(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	channels, err := h.channelService.GetChannels(userID, utils.GetBoolQuery(c, "include_archived", false))
+	if err != nil {
+		respondError(c, h.determineChannelErrorStatus(err), "Failed to get broadcast channels", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Broadcast channels retrieved successfully",
+		Data:    channels,
+	})
+}
+
+// ========================================
+// POST ENDPOINTS
+// ========================================
+
+// CreatePost posts an announcement to a channel (manager or admin)
+// @Summary Post announcement
+// @Description Deliver an announcement or maintenance notice to the channel's audience as a notification, kept in their announcement inbox. Managers can only post to the channels of buildings where they manage a space.
+// @Tags broadcasts
+// @Accept json
+// @Produce json
+// @Param id path string true "Channel ID" format(uuid)
+// @Param request body dto.CreateBroadcastPostRequest true "Announcement"
+// @Success 201 {object} dto.SuccessResponse{data=dto.BroadcastPostResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /manager/broadcast-channels/{id}/posts [post]
+func (h *BroadcastChannelHandler) CreatePost(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+	channelID, ok := h.parseID(c, "id", "channel")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateBroadcastPostRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	post, err := h.channelService.Post(channelID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineChannelErrorStatus(err), "Failed to post announcement", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Announcement posted successfully",
+		Data:    post,
+	})
+}
+
+// GetPosts lists the posts of a channel with their read statistics (manager or admin)
+// @Summary List channel posts
+// @Description Posts of a channel, most recent first, with how many recipients read each
+// @Tags broadcasts
+// @Produce json
+// @Param id path string true "Channel ID" format(uuid)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /manager/broadcast-channels/{id}/posts [get]
+func (h *BroadcastChannelHandler) GetPosts(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+	channelID, ok := h.parseID(c, "id", "channel")
+	if !ok {
+		return
+	}
+	page, limit := h.pagination(c)
+
+	posts, total, err := h.channelService.GetPosts(channelID, userID, (page-1)*limit, limit)
+	if err != nil {
+		respondError(c, h.determineChannelErrorStatus(err), "Failed to get announcements", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(posts, total, page, limit))
+}
+
+// GetPost shows a post of a channel with its read statistics (manager or admin)
+// @Summary Get channel post
+// @Description Announcement with how many recipients read it and when it was last read
+// @Tags broadcasts
+// @Produce json
+// @Param id path string true "Channel ID" format(uuid)
+// @Param postId path string true "Post ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse{data=dto.BroadcastPostResponse}
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /manager/broadcast-channels/{id}/posts/{postId} [get]
+func (h *BroadcastChannelHandler) GetPost(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+	channelID, ok := h.parseID(c, "id", "channel")
+	if !ok {
+		return
+	}
+	postID, ok := h.parseID(c, "postId", "post")
+	if !ok {
+		return
+	}
+
+	post, err := h.channelService.GetPost(channelID, postID, userID)
+	if err != nil {
+		respondError(c, h.determineChannelErrorStatus(err), "Failed to get announcement", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Announcement retrieved successfully",
+		Data:    post,
+	})
+}
+
+// ========================================
+// INBOX ENDPOINTS
+// ========================================
+
+// GetInbox lists the announcements delivered to the current user
+// @Summary My announcements
+// @Description Announcements from the broadcast channels, most recent first, with when you read them
+// @Tags broadcasts
+// @Produce json
+// @Param channel_id query string false "Only this channel" format(uuid)
+// @Param unread query bool false "Only unread announcements" default(false)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /announcements [get]
+func (h *BroadcastChannelHandler) GetInbox(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var channelID *uuid.UUID
+	if raw := c.Query("channel_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid channel ID", err)
+			return
+		}
+		channelID = &id
+	}
+	page, limit := h.pagination(c)
+
+	posts, total, err := h.channelService.GetInbox(userID, channelID, utils.GetBoolQuery(c, "unread", false), (page-1)*limit, limit)
+	if err != nil {
+		respondError(c, h.determineChannelErrorStatus(err), "Failed to get announcements", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(posts, total, page, limit))
+}
+
+// MarkRead records that the current user read an announcement
+// @Summary Mark announcement read
+// @Description Record that you read an announcement delivered to you; repeating it is harmless
+// @Tags broadcasts
+// @Produce json
+// @Param id path string true "Post ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /announcements/{id}/read [post]
+func (h *BroadcastChannelHandler) MarkRead(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+	postID, ok := h.parseID(c, "id", "post")
+	if !ok {
+		return
+	}
+
+	if err := h.channelService.MarkRead(postID, userID); err != nil {
+		respondError(c, h.determineChannelErrorStatus(err), "Failed to mark announcement read", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Announcement marked as read",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// parseID parses a UUID path parameter, answering 400 when invalid
+func (h *BroadcastChannelHandler) parseID(c *gin.Context, param, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   fmt.Sprintf("Invalid %s ID", name),
+			Message: fmt.Sprintf("%s ID must be a valid UUID", strings.ToUpper(name[:1])+name[1:]),
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// pagination reads the page and limit query parameters
+func (h *BroadcastChannelHandler) pagination(c *gin.Context) (int, int) {
+	page := utils.GetIntQuery(c, "page", 1)
+	limit := utils.GetIntQuery(c, "limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return page, limit
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *BroadcastChannelHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineChannelErrorStatus determines HTTP status code based on error message
+func (h *BroadcastChannelHandler) determineChannelErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	case err.Error() == "access denied",
+		strings.Contains(err.Error(), "not sent to you"):
+		return http.StatusForbidden
+	case strings.Contains(err.Error(), "already"),
+		err.Error() == "broadcast channel is archived":
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
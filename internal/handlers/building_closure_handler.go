@@ -0,0 +1,156 @@
+// internal/handlers/building_closure_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BuildingClosureHandler handles building and floor closures
+type BuildingClosureHandler struct {
+	closureService *services.BuildingClosureService
+}
+
+// NewBuildingClosureHandler creates a new building closure handler
+func NewBuildingClosureHandler(closureService *services.BuildingClosureService) *BuildingClosureHandler {
+	return &BuildingClosureHandler{
+		closureService: closureService,
+	}
+}
+
+// CreateClosure closes a building or one of its floors for a range of days (admin only)
+// @Summary Close building or floor
+// @Description Take every space of a building, or of one of its floors, out of use for the given days, e.g. for a holiday or renovation. New bookings there are rejected with SPACE_CLOSED and the spaces are left out of availability searches; existing bookings are flagged with the closure and their organizers notified with spaces they could rebook.
+// @Tags closures
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateBuildingClosureRequest true "Closure"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/closures [post]
+func (h *BuildingClosureHandler) CreateClosure(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateBuildingClosureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	closure, err := h.closureService.CreateClosure(&req, userID)
+	if err != nil {
+		respondError(c, h.determineClosureErrorStatus(err), "Failed to create building closure", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Building closure created successfully",
+		Data:    closure,
+	})
+}
+
+// GetClosures lists running and planned building closures (admin only)
+// @Summary List building closures
+// @Description Building and floor closures that are running or planned
+// @Tags closures
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /admin/closures [get]
+func (h *BuildingClosureHandler) GetClosures(c *gin.Context) {
+	closures, err := h.closureService.GetClosures()
+	if err != nil {
+		respondError(c, h.determineClosureErrorStatus(err), "Failed to get building closures", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Building closures retrieved successfully",
+		Data:    closures,
+	})
+}
+
+// EndClosure reopens the closed spaces (admin only)
+// @Summary End building closure
+// @Description End a building or floor closure now, before its end date. Bookings from now on lose their closure flag.
+// @Tags closures
+// @Produce json
+// @Param id path string true "Closure ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/closures/{id}/end [post]
+func (h *BuildingClosureHandler) EndClosure(c *gin.Context) {
+	closureID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid closure ID",
+			Message: "Closure ID must be a valid UUID",
+		})
+		return
+	}
+
+	closure, err := h.closureService.EndClosure(closureID)
+	if err != nil {
+		respondError(c, h.determineClosureErrorStatus(err), "Failed to end building closure", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Building closure ended successfully",
+		Data:    closure,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *BuildingClosureHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineClosureErrorStatus determines HTTP status code based on error message
+func (h *BuildingClosureHandler) determineClosureErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	case strings.Contains(err.Error(), "already"):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,66 @@
+// internal/handlers/capacity_forecast_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CapacityForecastHandler handles capacity planning forecasts
+type CapacityForecastHandler struct {
+	forecastService *services.CapacityForecastService
+}
+
+// NewCapacityForecastHandler creates a new capacity forecast handler
+func NewCapacityForecastHandler(forecastService *services.CapacityForecastService) *CapacityForecastHandler {
+	return &CapacityForecastHandler{
+		forecastService: forecastService,
+	}
+}
+
+// GetForecast forecasts demand per building and space type (manager/admin only)
+// @Summary Capacity planning forecast
+// @Description Seat time expected each coming day per building and space type, from the bookings of the same weekday over past weeks scaled by the recent trend, and never below what is already booked. Days whose forecast usage of the workday seat time reaches the threshold are flagged at risk of exceeding capacity.
+// @Tags reports
+// @Produce json
+// @Param days query int false "Days forecast from tomorrow" default(28)
+// @Param history_weeks query int false "Weeks of past bookings the forecast learns from" default(12)
+// @Param building query string false "Only spaces of this building"
+// @Param type query string false "Only spaces of this type"
+// @Param threshold query int false "Forecast usage percent from which a day is at risk" default(85)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /manager/analytics/capacity-forecast [get]
+func (h *CapacityForecastHandler) GetForecast(c *gin.Context) {
+	var req dto.CapacityForecastRequest
+	if err := bindQuery(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	forecast, err := h.forecastService.GetForecast(&req)
+	if err != nil {
+		respondError(c, h.determineForecastErrorStatus(err), "Failed to generate capacity forecast", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Capacity forecast generated successfully",
+		Data:    forecast,
+	})
+}
+
+// determineForecastErrorStatus determines HTTP status code based on error message
+func (h *CapacityForecastHandler) determineForecastErrorStatus(err error) int {
+	if strings.HasPrefix(err.Error(), "failed to") {
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,115 @@
+// internal/handlers/chargeback_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChargebackHandler handles reservation cost reports for finance
+type ChargebackHandler struct {
+	chargebackService *services.ChargebackService
+}
+
+// NewChargebackHandler creates a new chargeback handler
+func NewChargebackHandler(chargebackService *services.ChargebackService) *ChargebackHandler {
+	return &ChargebackHandler{
+		chargebackService: chargebackService,
+	}
+}
+
+// GetChargebackReport reports what reservations cost each department per month (admin only)
+// @Summary Chargeback report
+// @Description Confirmed and completed reservations per month, booker's department and cost center, with hours and cost at the space's hourly cost rate when booked plus the services delivered with them. Use format=csv to download it for finance.
+// @Tags reports
+// @Produce json
+// @Produce text/csv
+// @Param from query string false "First month (YYYY-MM), defaults to the current month"
+// @Param to query string false "Last month (YYYY-MM), defaults to from"
+// @Param format query string false "json or csv" default(json)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/reports/chargeback [get]
+func (h *ChargebackHandler) GetChargebackReport(c *gin.Context) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse("2006-01", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid from month",
+				Message: "from must use the YYYY-MM format",
+			})
+			return
+		}
+		from = parsed
+	}
+
+	to := from
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse("2006-01", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid to month",
+				Message: "to must use the YYYY-MM format",
+			})
+			return
+		}
+		to = parsed
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid format",
+			Message: "format must be json or csv",
+		})
+		return
+	}
+
+	report, err := h.chargebackService.GetChargebackReport(from, to)
+	if err != nil {
+		respondError(c, h.determineChargebackErrorStatus(err), "Failed to generate chargeback report", err)
+		return
+	}
+
+	if format == "csv" {
+		data, err := h.chargebackService.RenderChargebackCSV(report)
+		if err != nil {
+			respondError(c, h.determineChargebackErrorStatus(err), "Failed to generate chargeback report", err)
+			return
+		}
+		filename := fmt.Sprintf("chargeback-%s-to-%s.csv", report.From, report.To)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Data(http.StatusOK, "text/csv", data)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Chargeback report generated successfully",
+		Data:    report,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// determineChargebackErrorStatus determines HTTP status code based on error message
+func (h *ChargebackHandler) determineChargebackErrorStatus(err error) int {
+	switch {
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
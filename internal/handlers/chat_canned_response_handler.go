@@ -0,0 +1,357 @@
+// internal/handlers/chat_canned_response_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Canned response endpoints
+
+// GetCannedResponses godoc
+// @Summary List canned responses
+// @Description List active canned responses for quick insertion, most used first (agents and admins)
+// @Tags canned-responses
+// @Produce json
+// @Param category query string false "Filter by category"
+// @Param search query string false "Search title, shortcut and content"
+// @Param limit query int false "Number of responses to return" default(20)
+// @Param offset query int false "Number of responses to skip" default(0)
+// @Success 200 {object} dto.CannedResponseListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/canned-responses [get]
+func (h *ChatHandler) GetCannedResponses(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.cannedResponseService.EnsureCanUseCannedResponses(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:      "Access denied",
+			Message:    err.Error(),
+			StatusCode: http.StatusForbidden,
+		})
+		return
+	}
+
+	h.listCannedResponses(c, false)
+}
+
+// InsertCannedResponse godoc
+// @Summary Send a canned response
+// @Description Send a canned response, chosen by ID or shortcut, as the current agent
+// @Tags canned-responses
+// @Accept json
+// @Produce json
+// @Param id path string true "Conversation ID"
+// @Param request body dto.InsertCannedResponseRequest true "Canned response to send"
+// @Success 201 {object} dto.ChatMessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/conversations/{id}/canned-responses [post]
+func (h *ChatHandler) InsertCannedResponse(c *gin.Context) {
+	conversationID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid conversation ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	var req dto.InsertCannedResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid request",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	message, err := h.cannedResponseService.InsertCannedResponse(c.Request.Context(), userID, conversationID, &req)
+	if err != nil {
+		switch err.Error() {
+		case "only support agents can use canned responses", "access denied":
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:      "Access denied",
+				Message:    err.Error(),
+				StatusCode: http.StatusForbidden,
+			})
+			return
+		case "canned response not found":
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:      "Canned response not found",
+				Message:    err.Error(),
+				StatusCode: http.StatusNotFound,
+			})
+			return
+		case "canned_response_id or shortcut is required":
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:      "Invalid request",
+				Message:    err.Error(),
+				StatusCode: http.StatusBadRequest,
+			})
+			return
+		}
+
+		if strings.HasPrefix(err.Error(), "you are banned from chat") {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:      "Chat ban",
+				Message:    err.Error(),
+				StatusCode: http.StatusForbidden,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to send canned response", "conversationID", conversationID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to send canned response",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+// Canned response management (admin only)
+
+// GetAllCannedResponses godoc
+// @Summary List all canned responses
+// @Description List canned responses including inactive ones
+// @Tags canned-responses
+// @Produce json
+// @Param category query string false "Filter by category"
+// @Param search query string false "Search title, shortcut and content"
+// @Param limit query int false "Number of responses to return" default(20)
+// @Param offset query int false "Number of responses to skip" default(0)
+// @Success 200 {object} dto.CannedResponseListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/canned-responses [get]
+func (h *ChatHandler) GetAllCannedResponses(c *gin.Context) {
+	h.listCannedResponses(c, true)
+}
+
+// CreateCannedResponse godoc
+// @Summary Create a canned response
+// @Description Add a canned response. Auto replies are sent by the support bot when their keywords match.
+// @Tags canned-responses
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateCannedResponseRequest true "Canned response"
+// @Success 201 {object} dto.CannedResponseResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/canned-responses [post]
+func (h *ChatHandler) CreateCannedResponse(c *gin.Context) {
+	var req dto.CreateCannedResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid request",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	adminUserID := h.getUserIDFromContext(c)
+	if adminUserID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	response, err := h.cannedResponseService.CreateCannedResponse(c.Request.Context(), adminUserID, &req)
+	if err != nil {
+		h.writeCannedResponseError(c, err, "Failed to create canned response")
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// UpdateCannedResponse godoc
+// @Summary Update a canned response
+// @Tags canned-responses
+// @Accept json
+// @Produce json
+// @Param id path string true "Canned response ID"
+// @Param request body dto.UpdateCannedResponseRequest true "Fields to update"
+// @Success 200 {object} dto.CannedResponseResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/canned-responses/{id} [put]
+func (h *ChatHandler) UpdateCannedResponse(c *gin.Context) {
+	id, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid canned response ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	var req dto.UpdateCannedResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid request",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	response, err := h.cannedResponseService.UpdateCannedResponse(c.Request.Context(), id, &req)
+	if err != nil {
+		h.writeCannedResponseError(c, err, "Failed to update canned response")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteCannedResponse godoc
+// @Summary Delete a canned response
+// @Tags canned-responses
+// @Produce json
+// @Param id path string true "Canned response ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/canned-responses/{id} [delete]
+func (h *ChatHandler) DeleteCannedResponse(c *gin.Context) {
+	id, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid canned response ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	if err := h.cannedResponseService.DeleteCannedResponse(c.Request.Context(), id); err != nil {
+		h.writeCannedResponseError(c, err, "Failed to delete canned response")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// listCannedResponses binds the list query and writes the page of canned responses
+func (h *ChatHandler) listCannedResponses(c *gin.Context, includeInactive bool) {
+	var req dto.GetCannedResponsesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", "error", err)
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid query parameters",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	if req.Limit < 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	responses, err := h.cannedResponseService.GetCannedResponses(c.Request.Context(), &req, includeInactive)
+	if err != nil {
+		h.logger.Error("Failed to get canned responses", "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to get canned responses",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// writeCannedResponseError maps canned response management errors to status codes
+func (h *ChatHandler) writeCannedResponseError(c *gin.Context, err error, message string) {
+	switch err.Error() {
+	case "canned response not found":
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:      "Canned response not found",
+			Message:    err.Error(),
+			StatusCode: http.StatusNotFound,
+		})
+		return
+	case "shortcut already in use":
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:      "Shortcut already in use",
+			Message:    err.Error(),
+			StatusCode: http.StatusConflict,
+		})
+		return
+	case "auto replies need at least one keyword":
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid request",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	h.logger.Error(message, "error", err)
+	c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+		Error:      message,
+		Message:    err.Error(),
+		StatusCode: http.StatusInternalServerError,
+	})
+}
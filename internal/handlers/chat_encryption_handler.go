@@ -0,0 +1,227 @@
+// internal/handlers/chat_encryption_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// End-to-end encryption key endpoints
+
+// RegisterDeviceKeys godoc
+// @Summary Register device encryption keys
+// @Description Publish the public key bundle of one of the caller's devices for end-to-end encrypted conversations. Registering the same device again replaces its keys, including the remaining one-time pre-keys.
+// @Tags encryption
+// @Accept json
+// @Produce json
+// @Param deviceId path string true "Client-chosen device ID"
+// @Param request body dto.RegisterDeviceKeysRequest true "Public key bundle"
+// @Success 200 {object} dto.DeviceKeysResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/keys/devices/{deviceId} [put]
+func (h *ChatHandler) RegisterDeviceKeys(c *gin.Context) {
+	deviceID, ok := h.getDeviceIDFromParam(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RegisterDeviceKeysRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	keys, err := h.chatService.RegisterDeviceKeys(c.Request.Context(), userID, deviceID, &req)
+	if err != nil {
+		h.logger.Error("Failed to register device keys", "userID", userID, "deviceID", deviceID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to register device keys",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// GetDeviceKeys godoc
+// @Summary List my device encryption keys
+// @Description List the key bundles registered for the caller's devices, with how many one-time pre-keys each has left
+// @Tags encryption
+// @Produce json
+// @Success 200 {array} dto.DeviceKeysResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/keys/devices [get]
+func (h *ChatHandler) GetDeviceKeys(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	keys, err := h.chatService.GetDeviceKeys(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to get device keys", "userID", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to get device keys",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// DeleteDeviceKeys godoc
+// @Summary Remove device encryption keys
+// @Description Remove the key bundle of a lost or signed-out device so nobody encrypts to it anymore
+// @Tags encryption
+// @Produce json
+// @Param deviceId path string true "Device ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/keys/devices/{deviceId} [delete]
+func (h *ChatHandler) DeleteDeviceKeys(c *gin.Context) {
+	deviceID, ok := h.getDeviceIDFromParam(c)
+	if !ok {
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.chatService.DeleteDeviceKeys(c.Request.Context(), userID, deviceID); err != nil {
+		if err.Error() == "device keys not found" {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:      "Device keys not found",
+				Message:    err.Error(),
+				StatusCode: http.StatusNotFound,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to delete device keys", "userID", userID, "deviceID", deviceID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to delete device keys",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Device keys removed successfully",
+	})
+}
+
+// ClaimKeyBundles godoc
+// @Summary Claim a user's encryption key bundles
+// @Description Get the key bundles of every device of a user to start encrypted sessions with them. Each call hands out and removes one one-time pre-key per device; devices that ran out return only their signed pre-key.
+// @Tags encryption
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {array} dto.ClaimedKeyBundleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/keys/users/{userId}/claim [post]
+func (h *ChatHandler) ClaimKeyBundles(c *gin.Context) {
+	targetUserID, err := h.getUUIDFromParam(c, "userId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid user ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	bundles, err := h.chatService.ClaimKeyBundles(c.Request.Context(), userID, targetUserID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:      "User not found",
+				Message:    err.Error(),
+				StatusCode: http.StatusNotFound,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to claim key bundles", "userID", userID, "targetUserID", targetUserID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to claim key bundles",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, bundles)
+}
+
+// getDeviceIDFromParam reads the deviceId path parameter, responding with 400 when it is unusable
+func (h *ChatHandler) getDeviceIDFromParam(c *gin.Context) (string, bool) {
+	deviceID := strings.TrimSpace(c.Param("deviceId"))
+	if deviceID == "" || len(deviceID) > 100 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid device ID",
+			Message:    "device ID must be between 1 and 100 characters",
+			StatusCode: http.StatusBadRequest,
+		})
+		return "", false
+	}
+	return deviceID, true
+}
+
+// isEncryptionError reports errors caused by breaking the rules of encrypted conversations
+func isEncryptionError(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "encrypt") || strings.Contains(message, "ciphertext")
+}
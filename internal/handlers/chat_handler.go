@@ -2,9 +2,11 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"log/slog"
 	"room-reservation-api/internal/dto"
@@ -15,15 +17,19 @@ import (
 )
 
 type ChatHandler struct {
-	chatService *services.ChatService
-	logger      *slog.Logger
+	chatService           *services.ChatService
+	moderationService     *services.ModerationService
+	cannedResponseService *services.CannedResponseService
+	logger                *slog.Logger
 }
 
 // NewChatHandler creates a new chat handler instance
-func NewChatHandler(chatService *services.ChatService, logger *slog.Logger) *ChatHandler {
+func NewChatHandler(chatService *services.ChatService, moderationService *services.ModerationService, cannedResponseService *services.CannedResponseService, logger *slog.Logger) *ChatHandler {
 	return &ChatHandler{
-		chatService: chatService,
-		logger:      logger,
+		chatService:           chatService,
+		moderationService:     moderationService,
+		cannedResponseService: cannedResponseService,
+		logger:                logger,
 	}
 }
 
@@ -39,20 +45,69 @@ func NewChatHandler(chatService *services.ChatService, logger *slog.Logger) *Cha
 // @Success 201 {object} dto.ConversationResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /chat/conversations [post]
 func (h *ChatHandler) CreateConversation(c *gin.Context) {
 	var req dto.CreateConversationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	conversation, err := h.chatService.CreateConversation(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.respondLinkError(c, "Failed to create conversation", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, conversation)
+}
+
+// ReportReservationProblem godoc
+// @Summary Report a problem with a reservation
+// @Description Open a support conversation about a reservation with the problem as its first message. The conversation is linked to the reservation and its space so agents see the booking. While the previous report on the reservation is open, the problem is added to it instead.
+// @Tags conversations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Param request body dto.ReportReservationProblemRequest true "Problem"
+// @Success 201 {object} dto.ConversationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /reservations/{id}/report-problem [post]
+func (h *ChatHandler) ReportReservationProblem(c *gin.Context) {
+	reservationID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid request",
+			Error:      "Invalid reservation ID",
 			Message:    err.Error(),
 			StatusCode: http.StatusBadRequest,
 		})
 		return
 	}
 
+	var req dto.ReportReservationProblemRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
 	userID := h.getUserIDFromContext(c)
 	if userID == uuid.Nil {
 		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
@@ -63,14 +118,9 @@ func (h *ChatHandler) CreateConversation(c *gin.Context) {
 		return
 	}
 
-	conversation, err := h.chatService.CreateConversation(c.Request.Context(), userID, &req)
+	conversation, err := h.chatService.ReportReservationProblem(c.Request.Context(), userID, reservationID, &req)
 	if err != nil {
-		h.logger.Error("Failed to create conversation", "userID", userID, "error", err)
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:      "Failed to create conversation",
-			Message:    err.Error(),
-			StatusCode: http.StatusInternalServerError,
-		})
+		h.respondLinkError(c, "Failed to report problem", err)
 		return
 	}
 
@@ -85,13 +135,14 @@ func (h *ChatHandler) CreateConversation(c *gin.Context) {
 // @Produce json
 // @Param status query string false "Filter by status (active, resolved, pending)"
 // @Param priority query string false "Filter by priority (low, normal, high, urgent)"
-// @Param is_archived query boolean false "Filter by archived status"
+// @Param is_archived query boolean false "Filter by archived status; archived conversations are left out unless set or searching"
 // @Param tag query string false "Filter by tag"
 // @Param search query string false "Search in conversation titles and messages"
 // @Param limit query int false "Limit number of results" default(20)
 // @Param offset query int false "Offset for pagination" default(0)
 // @Param sort_by query string false "Sort field (created_at, updated_at, last_message_at)" default(last_message_at)
 // @Param sort_order query string false "Sort order (asc, desc)" default(desc)
+// @Param cursor query string false "Cursor pagination: empty for the first page, then next_cursor of the previous page; replaces offset"
 // @Success 200 {object} dto.ConversationListResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -109,13 +160,9 @@ func (h *ChatHandler) GetConversations(c *gin.Context) {
 	}
 
 	var req dto.GetConversationsRequest
-	if err := c.ShouldBindQuery(&req); err != nil {
+	if err := bindQuery(c, &req); err != nil {
 		h.logger.Warn("Invalid query parameters", "error", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid query parameters",
-			Message:    err.Error(),
-			StatusCode: http.StatusBadRequest,
-		})
+		respondError(c, http.StatusBadRequest, "Invalid query parameters", err)
 		return
 	}
 
@@ -132,6 +179,15 @@ func (h *ChatHandler) GetConversations(c *gin.Context) {
 
 	conversations, err := h.chatService.GetConversations(c.Request.Context(), userID, &req)
 	if err != nil {
+		if errors.Is(err, dto.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:      "Invalid cursor",
+				Message:    err.Error(),
+				StatusCode: http.StatusBadRequest,
+			})
+			return
+		}
+
 		h.logger.Error("Failed to get conversations", "userID", userID, "error", err)
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:      "Failed to get conversations",
@@ -207,22 +263,25 @@ func (h *ChatHandler) GetConversation(c *gin.Context) {
 		return
 	}
 
+	setETag(c, conversation.UpdatedAt)
 	c.JSON(http.StatusOK, conversation)
 }
 
 // UpdateConversation godoc
 // @Summary Update a conversation
-// @Description Update conversation details (status, priority, etc.)
+// @Description Update conversation details (status, priority, etc.). Send the ETag of the conversation in If-Match, or its updated_at in the body, to get a 409 instead of overwriting a change made since it was read.
 // @Tags conversations
 // @Accept json
 // @Produce json
 // @Param id path string true "Conversation ID"
+// @Param If-Match header string false "ETag of the conversation as last read"
 // @Param conversation body dto.UpdateConversationRequest true "Update data"
 // @Success 200 {object} dto.ConversationResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /chat/conversations/{id} [put]
 func (h *ChatHandler) UpdateConversation(c *gin.Context) {
@@ -237,14 +296,20 @@ func (h *ChatHandler) UpdateConversation(c *gin.Context) {
 	}
 
 	var req dto.UpdateConversationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+	if expected, err := ifMatch(c); err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
 			Error:      "Invalid request",
 			Message:    err.Error(),
 			StatusCode: http.StatusBadRequest,
 		})
 		return
+	} else if expected != nil {
+		req.UpdatedAt = expected
 	}
 
 	userID := h.getUserIDFromContext(c)
@@ -268,6 +333,20 @@ func (h *ChatHandler) UpdateConversation(c *gin.Context) {
 			return
 		}
 
+		if err.Error() == "reopen window has expired" {
+			c.JSON(http.StatusConflict, dto.ErrorResponse{
+				Error:      "Cannot reopen conversation",
+				Message:    err.Error(),
+				StatusCode: http.StatusConflict,
+			})
+			return
+		}
+
+		if errors.Is(err, dto.ErrStaleUpdate) {
+			respondError(c, http.StatusConflict, "Conversation was changed", err)
+			return
+		}
+
 		h.logger.Error("Failed to update conversation", "userID", userID, "conversationID", conversationID, "error", err)
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:      "Failed to update conversation",
@@ -277,6 +356,7 @@ func (h *ChatHandler) UpdateConversation(c *gin.Context) {
 		return
 	}
 
+	setETag(c, conversation.UpdatedAt)
 	c.JSON(http.StatusOK, conversation)
 }
 
@@ -339,6 +419,133 @@ func (h *ChatHandler) ArchiveConversation(c *gin.Context) {
 	})
 }
 
+// BatchUnarchiveConversations godoc
+// @Summary Unarchive conversations in bulk
+// @Description Restore archived conversations, including those archived for inactivity, to their members' default lists (admin only)
+// @Tags conversations
+// @Accept json
+// @Produce json
+// @Param request body dto.BatchUnarchiveRequest true "Conversations to unarchive"
+// @Success 200 {object} dto.BatchUnarchiveResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/chat/conversations/unarchive [post]
+func (h *ChatHandler) BatchUnarchiveConversations(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req dto.BatchUnarchiveRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	result, err := h.chatService.BatchUnarchiveConversations(c.Request.Context(), userID, &req)
+	if err != nil {
+		if err.Error() == "access denied" {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:      "Access denied",
+				Message:    "Only admins can unarchive conversations in bulk",
+				StatusCode: http.StatusForbidden,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to unarchive conversations", "userID", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to unarchive conversations",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ReopenConversation godoc
+// @Summary Reopen a resolved conversation
+// @Description Reopen a resolved conversation instead of starting a new one. Members can reopen within the reopen window
+// @Tags conversations
+// @Accept json
+// @Produce json
+// @Param id path string true "Conversation ID"
+// @Success 200 {object} dto.ConversationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/conversations/{id}/reopen [post]
+func (h *ChatHandler) ReopenConversation(c *gin.Context) {
+	conversationID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid conversation ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	conversation, err := h.chatService.ReopenConversation(c.Request.Context(), userID, conversationID)
+	if err != nil {
+		switch err.Error() {
+		case "access denied":
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:      "Access denied",
+				Message:    "You don't have permission to reopen this conversation",
+				StatusCode: http.StatusForbidden,
+			})
+			return
+		case "conversation not found":
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:      "Conversation not found",
+				Message:    err.Error(),
+				StatusCode: http.StatusNotFound,
+			})
+			return
+		case "conversation is not resolved", "reopen window has expired":
+			c.JSON(http.StatusConflict, dto.ErrorResponse{
+				Error:      "Cannot reopen conversation",
+				Message:    err.Error(),
+				StatusCode: http.StatusConflict,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to reopen conversation", "userID", userID, "conversationID", conversationID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to reopen conversation",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, conversation)
+}
+
 // DeleteConversation godoc
 // @Summary Delete a conversation
 // @Description Permanently delete a conversation (admin only)
@@ -412,6 +619,7 @@ func (h *ChatHandler) DeleteConversation(c *gin.Context) {
 // @Param before query string false "Get messages before this timestamp (RFC3339)"
 // @Param after query string false "Get messages after this timestamp (RFC3339)"
 // @Param message_type query string false "Filter by message type"
+// @Param cursor query string false "Cursor pagination: empty for the first page, then next_cursor of the previous page; replaces offset"
 // @Success 200 {object} dto.MessageListResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -431,13 +639,9 @@ func (h *ChatHandler) GetMessages(c *gin.Context) {
 
 	var req dto.GetMessagesRequest
 	req.ConversationID = conversationID
-	if err := c.ShouldBindQuery(&req); err != nil {
+	if err := bindQuery(c, &req); err != nil {
 		h.logger.Warn("Invalid query parameters", "error", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid query parameters",
-			Message:    err.Error(),
-			StatusCode: http.StatusBadRequest,
-		})
+		respondError(c, http.StatusBadRequest, "Invalid query parameters", err)
 		return
 	}
 
@@ -466,6 +670,14 @@ func (h *ChatHandler) GetMessages(c *gin.Context) {
 			})
 			return
 		}
+		if errors.Is(err, dto.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:      "Invalid cursor",
+				Message:    err.Error(),
+				StatusCode: http.StatusBadRequest,
+			})
+			return
+		}
 
 		h.logger.Error("Failed to get messages", "userID", userID, "conversationID", conversationID, "error", err)
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
@@ -505,13 +717,9 @@ func (h *ChatHandler) SendMessage(c *gin.Context) {
 	}
 
 	var req dto.SendMessageRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid request",
-			Message:    err.Error(),
-			StatusCode: http.StatusBadRequest,
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -539,6 +747,33 @@ func (h *ChatHandler) SendMessage(c *gin.Context) {
 			return
 		}
 
+		if strings.HasPrefix(err.Error(), "you are banned from chat") {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:      "Chat ban",
+				Message:    err.Error(),
+				StatusCode: http.StatusForbidden,
+			})
+			return
+		}
+
+		if isBlockedError(err) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:      "User blocked",
+				Message:    err.Error(),
+				StatusCode: http.StatusForbidden,
+			})
+			return
+		}
+
+		if isEncryptionError(err) || strings.HasPrefix(err.Error(), "attachment ") {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:      "Failed to send message",
+				Message:    err.Error(),
+				StatusCode: http.StatusBadRequest,
+			})
+			return
+		}
+
 		h.logger.Error("Failed to send message", "userID", userID, "conversationID", conversationID, "error", err)
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:      "Failed to send message",
@@ -577,13 +812,9 @@ func (h *ChatHandler) UpdateMessage(c *gin.Context) {
 	}
 
 	var req dto.UpdateMessageRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid request",
-			Message:    err.Error(),
-			StatusCode: http.StatusBadRequest,
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -608,6 +839,15 @@ func (h *ChatHandler) UpdateMessage(c *gin.Context) {
 			return
 		}
 
+		if isEncryptionError(err) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:      "Failed to update message",
+				Message:    err.Error(),
+				StatusCode: http.StatusBadRequest,
+			})
+			return
+		}
+
 		h.logger.Error("Failed to update message", "userID", userID, "messageID", messageID, "error", err)
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:      "Failed to update message",
@@ -696,13 +936,9 @@ func (h *ChatHandler) DeleteMessage(c *gin.Context) {
 // @Router /chat/messages/read [put]
 func (h *ChatHandler) MarkMessagesAsRead(c *gin.Context) {
 	var req dto.MarkMessagesReadRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid request",
-			Message:    err.Error(),
-			StatusCode: http.StatusBadRequest,
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -786,16 +1022,338 @@ func (h *ChatHandler) GetReadReceipts(c *gin.Context) {
 			return
 		}
 
-		h.logger.Error("Failed to get read receipts", "userID", userID, "messageID", messageID, "error", err)
+		h.logger.Error("Failed to get read receipts", "userID", userID, "messageID", messageID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to get read receipts",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, receipts)
+}
+
+// SyncChat godoc
+// @Summary Catch up on chat after being offline
+// @Description Get unread counts for every conversation plus all messages posted after the cursor, oldest first. Omit the cursor on the first sync to receive a starting cursor; keep calling with next_cursor while has_more is true.
+// @Tags messages
+// @Produce json
+// @Param cursor query string false "next_cursor of the previous sync"
+// @Param limit query int false "Maximum messages to return (default 100, max 500)"
+// @Success 200 {object} dto.ChatSyncResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/sync [get]
+func (h *ChatHandler) SyncChat(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req dto.ChatSyncRequest
+	if err := bindQuery(c, &req); err != nil {
+		h.logger.Warn("Invalid query parameters", "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	sync, err := h.chatService.SyncChat(c.Request.Context(), userID, &req)
+	if err != nil {
+		if errors.Is(err, dto.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:      "Invalid cursor",
+				Message:    err.Error(),
+				StatusCode: http.StatusBadRequest,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to sync chat", "userID", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to sync chat",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, sync)
+}
+
+// MarkMessagesAsDelivered godoc
+// @Summary Mark messages as delivered
+// @Description Acknowledge that messages reached the client, for clients not connected over WebSocket
+// @Tags messages
+// @Accept json
+// @Produce json
+// @Param request body dto.MarkMessagesDeliveredRequest true "Message IDs to mark as delivered"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/messages/delivered [put]
+func (h *ChatHandler) MarkMessagesAsDelivered(c *gin.Context) {
+	var req dto.MarkMessagesDeliveredRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.chatService.MarkMessagesAsDelivered(c.Request.Context(), userID, &req); err != nil {
+		if strings.HasPrefix(err.Error(), "access denied") {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:      "Access denied",
+				Message:    "You don't have permission to mark these messages as delivered",
+				StatusCode: http.StatusForbidden,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to mark messages as delivered", "userID", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to mark messages as delivered",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Messages marked as delivered successfully",
+	})
+}
+
+// GetMessageDelivery godoc
+// @Summary Get delivery status for a message
+// @Description Get the aggregate sent/delivered/read state of a message and each recipient's state
+// @Tags messages
+// @Accept json
+// @Produce json
+// @Param id path string true "Message ID"
+// @Success 200 {object} dto.MessageDeliveryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/messages/{id}/delivery [get]
+func (h *ChatHandler) GetMessageDelivery(c *gin.Context) {
+	messageID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid message ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	delivery, err := h.chatService.GetMessageDelivery(c.Request.Context(), userID, messageID)
+	if err != nil {
+		if errors.Is(err, dto.ErrAccessDenied) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:      "Access denied",
+				Message:    "You don't have permission to view delivery status for this message",
+				StatusCode: http.StatusForbidden,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to get message delivery", "userID", userID, "messageID", messageID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to get message delivery",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// GetThread godoc
+// @Summary Get message thread
+// @Description Get the message that started a thread and the full chain of replies under it
+// @Tags messages
+// @Accept json
+// @Produce json
+// @Param id path string true "Message ID"
+// @Success 200 {object} dto.ThreadResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/messages/{id}/thread [get]
+func (h *ChatHandler) GetThread(c *gin.Context) {
+	messageID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid message ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	thread, err := h.chatService.GetThread(c.Request.Context(), userID, messageID)
+	if err != nil {
+		if err.Error() == "access denied" {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:      "Access denied",
+				Message:    "You don't have permission to view this thread",
+				StatusCode: http.StatusForbidden,
+			})
+			return
+		}
+
+		if strings.HasPrefix(err.Error(), "message not found") {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:      "Message not found",
+				Message:    err.Error(),
+				StatusCode: http.StatusNotFound,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to get thread", "userID", userID, "messageID", messageID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to get thread",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, thread)
+}
+
+// ReportMessage godoc
+// @Summary Report a message
+// @Description Flag an abusive message for review by the moderation team
+// @Tags messages
+// @Accept json
+// @Produce json
+// @Param id path string true "Message ID"
+// @Param report body dto.ReportMessageRequest true "Report details"
+// @Success 201 {object} dto.MessageReportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/messages/{id}/report [post]
+func (h *ChatHandler) ReportMessage(c *gin.Context) {
+	messageID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid message ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	var req dto.ReportMessageRequest
+	if err := bindJSON(c, &req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	report, err := h.moderationService.ReportMessage(c.Request.Context(), userID, messageID, &req)
+	if err != nil {
+		switch err.Error() {
+		case "access denied":
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:      "Access denied",
+				Message:    "You can only report messages in your conversations",
+				StatusCode: http.StatusForbidden,
+			})
+			return
+		case "message not found":
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:      "Message not found",
+				Message:    err.Error(),
+				StatusCode: http.StatusNotFound,
+			})
+			return
+		case "cannot report your own message":
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:      "Invalid report",
+				Message:    err.Error(),
+				StatusCode: http.StatusBadRequest,
+			})
+			return
+		case "message already reported":
+			c.JSON(http.StatusConflict, dto.ErrorResponse{
+				Error:      "Already reported",
+				Message:    err.Error(),
+				StatusCode: http.StatusConflict,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to report message", "userID", userID, "messageID", messageID, "error", err)
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:      "Failed to get read receipts",
+			Error:      "Failed to report message",
 			Message:    err.Error(),
 			StatusCode: http.StatusInternalServerError,
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, receipts)
+	c.JSON(http.StatusCreated, report)
 }
 
 // SearchMessages godoc
@@ -819,13 +1377,9 @@ func (h *ChatHandler) GetReadReceipts(c *gin.Context) {
 // @Router /chat/messages/search [get]
 func (h *ChatHandler) SearchMessages(c *gin.Context) {
 	var req dto.SearchMessagesRequest
-	if err := c.ShouldBindQuery(&req); err != nil {
+	if err := bindQuery(c, &req); err != nil {
 		h.logger.Warn("Invalid query parameters", "error", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid query parameters",
-			Message:    err.Error(),
-			StatusCode: http.StatusBadRequest,
-		})
+		respondError(c, http.StatusBadRequest, "Invalid query parameters", err)
 		return
 	}
 
@@ -886,13 +1440,9 @@ func (h *ChatHandler) AddParticipant(c *gin.Context) {
 	}
 
 	var req dto.AddParticipantRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid request",
-			Message:    err.Error(),
-			StatusCode: http.StatusBadRequest,
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -916,6 +1466,15 @@ func (h *ChatHandler) AddParticipant(c *gin.Context) {
 			return
 		}
 
+		if isEncryptionError(err) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:      "Failed to add participant",
+				Message:    err.Error(),
+				StatusCode: http.StatusBadRequest,
+			})
+			return
+		}
+
 		h.logger.Error("Failed to add participant", "userID", userID, "conversationID", conversationID, "error", err)
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:      "Failed to add participant",
@@ -957,13 +1516,9 @@ func (h *ChatHandler) RemoveParticipant(c *gin.Context) {
 	}
 
 	var req dto.RemoveParticipantRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid request",
-			Message:    err.Error(),
-			StatusCode: http.StatusBadRequest,
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -1065,7 +1620,7 @@ func (h *ChatHandler) LeaveConversation(c *gin.Context) {
 
 // UploadFile godoc
 // @Summary Upload file for chat
-// @Description Upload a file to be used as attachment in messages
+// @Description Upload a file to be used as attachment in messages; files are quarantined until scanned for malware
 // @Tags files
 // @Accept multipart/form-data
 // @Produce json
@@ -1076,6 +1631,7 @@ func (h *ChatHandler) LeaveConversation(c *gin.Context) {
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 413 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /chat/upload [post]
 func (h *ChatHandler) UploadFile(c *gin.Context) {
@@ -1143,6 +1699,14 @@ func (h *ChatHandler) UploadFile(c *gin.Context) {
 			})
 			return
 		}
+		if strings.HasPrefix(err.Error(), "you are banned from chat") {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:      "Chat ban",
+				Message:    err.Error(),
+				StatusCode: http.StatusForbidden,
+			})
+			return
+		}
 		if err.Error() == "file size exceeds maximum allowed size" {
 			c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse{
 				Error:      "File too large",
@@ -1159,6 +1723,14 @@ func (h *ChatHandler) UploadFile(c *gin.Context) {
 			})
 			return
 		}
+		if err.Error() == "file rejected: malware detected" {
+			c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse{
+				Error:      "File rejected",
+				Message:    err.Error(),
+				StatusCode: http.StatusUnprocessableEntity,
+			})
+			return
+		}
 
 		h.logger.Error("Failed to upload file", "userID", userID, "conversationID", conversationID, "error", err)
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
@@ -1235,13 +1807,9 @@ func (h *ChatHandler) GetSupportAgents(c *gin.Context) {
 // @Router /chat/agents [post]
 func (h *ChatHandler) CreateSupportAgent(c *gin.Context) {
 	var req dto.CreateSupportAgentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid request",
-			Message:    err.Error(),
-			StatusCode: http.StatusBadRequest,
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -1304,13 +1872,9 @@ func (h *ChatHandler) AssignAgent(c *gin.Context) {
 	}
 
 	var req dto.AssignAgentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid request",
-			Message:    err.Error(),
-			StatusCode: http.StatusBadRequest,
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -1334,6 +1898,15 @@ func (h *ChatHandler) AssignAgent(c *gin.Context) {
 			return
 		}
 
+		if isEncryptionError(err) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:      "Failed to assign agent",
+				Message:    err.Error(),
+				StatusCode: http.StatusBadRequest,
+			})
+			return
+		}
+
 		h.logger.Error("Failed to assign agent", "userID", userID, "conversationID", conversationID, "error", err)
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:      "Failed to assign agent",
@@ -1349,6 +1922,122 @@ func (h *ChatHandler) AssignAgent(c *gin.Context) {
 	})
 }
 
+// AcceptAssignment godoc
+// @Summary Accept a conversation assignment
+// @Description Confirm a conversation offered to the current agent by auto-assignment
+// @Tags agents
+// @Produce json
+// @Param id path string true "Conversation ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/conversations/{id}/assignment/accept [post]
+func (h *ChatHandler) AcceptAssignment(c *gin.Context) {
+	conversationID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid conversation ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.chatService.AcceptAssignment(c.Request.Context(), userID, conversationID); err != nil {
+		if err.Error() == "no pending assignment for this agent" {
+			c.JSON(http.StatusConflict, dto.ErrorResponse{
+				Error:      "No pending assignment",
+				Message:    err.Error(),
+				StatusCode: http.StatusConflict,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to accept assignment", "userID", userID, "conversationID", conversationID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to accept assignment",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Assignment accepted",
+	})
+}
+
+// DeclineAssignment godoc
+// @Summary Decline a conversation assignment
+// @Description Decline a conversation offered to the current agent; it is offered to the next available agent
+// @Tags agents
+// @Produce json
+// @Param id path string true "Conversation ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/conversations/{id}/assignment/decline [post]
+func (h *ChatHandler) DeclineAssignment(c *gin.Context) {
+	conversationID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid conversation ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.chatService.DeclineAssignment(c.Request.Context(), userID, conversationID); err != nil {
+		if err.Error() == "no pending assignment for this agent" {
+			c.JSON(http.StatusConflict, dto.ErrorResponse{
+				Error:      "No pending assignment",
+				Message:    err.Error(),
+				StatusCode: http.StatusConflict,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to decline assignment", "userID", userID, "conversationID", conversationID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to decline assignment",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Assignment declined",
+	})
+}
+
 // Analytics endpoints
 
 // GetConversationStats godoc
@@ -1370,13 +2059,9 @@ func (h *ChatHandler) AssignAgent(c *gin.Context) {
 // @Router /chat/stats [get]
 func (h *ChatHandler) GetConversationStats(c *gin.Context) {
 	var req dto.ConversationStatsRequest
-	if err := c.ShouldBindQuery(&req); err != nil {
+	if err := bindQuery(c, &req); err != nil {
 		h.logger.Warn("Invalid query parameters", "error", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid query parameters",
-			Message:    err.Error(),
-			StatusCode: http.StatusBadRequest,
-		})
+		respondError(c, http.StatusBadRequest, "Invalid query parameters", err)
 		return
 	}
 
@@ -1413,6 +2098,87 @@ func (h *ChatHandler) GetConversationStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// ExportConversation godoc
+// @Summary Export a conversation transcript
+// @Description Download every message of a conversation as JSON or PDF, for compliance requests. Available to participants and admins.
+// @Tags conversations
+// @Produce json
+// @Produce application/pdf
+// @Param id path string true "Conversation ID"
+// @Param format query string false "Transcript format" Enums(json, pdf) default(json)
+// @Success 200 {object} dto.ConversationTranscript
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/conversations/{id}/export [get]
+func (h *ChatHandler) ExportConversation(c *gin.Context) {
+	conversationID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid conversation ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	var req dto.ExportConversationRequest
+	if err := bindQuery(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	transcript, err := h.chatService.ExportConversation(c.Request.Context(), userID, conversationID)
+	if err != nil {
+		switch err.Error() {
+		case "access denied":
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:      "Access denied",
+				Message:    "You don't have permission to export this conversation",
+				StatusCode: http.StatusForbidden,
+			})
+			return
+		case "conversation not found":
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:      "Conversation not found",
+				Message:    err.Error(),
+				StatusCode: http.StatusNotFound,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to export conversation", "conversationID", conversationID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to export conversation",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("conversation-%s-%s", conversationID, transcript.ExportedAt.UTC().Format("20060102"))
+	if req.Format == "pdf" {
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".pdf"))
+		c.Data(http.StatusOK, "application/pdf", h.chatService.RenderTranscriptPDF(transcript))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".json"))
+	c.JSON(http.StatusOK, transcript)
+}
+
 // GetConversationSummary godoc
 // @Summary Get conversation summary
 // @Description Get summary information about a specific conversation
@@ -1506,13 +2272,9 @@ func (h *ChatHandler) UpdateSupportAgent(c *gin.Context) {
 	}
 
 	var req dto.UpdateSupportAgentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid request",
-			Message:    err.Error(),
-			StatusCode: http.StatusBadRequest,
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -1688,13 +2450,9 @@ func (h *ChatHandler) UpdateAgentStatus(c *gin.Context) {
 // @Router /chat/typing [post]
 func (h *ChatHandler) SetTypingStatus(c *gin.Context) {
 	var req dto.SetTypingStatusRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.Warn("Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:      "Invalid request",
-			Message:    err.Error(),
-			StatusCode: http.StatusBadRequest,
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
 		return
 	}
 
@@ -1804,6 +2562,72 @@ func (h *ChatHandler) GetOnlineUsers(c *gin.Context) {
 // Helper functions
 
 // getUserIDFromContext extracts user ID from Gin context (assumes auth middleware sets it)
+// GetAttendeeChat godoc
+// @Summary Get a reservation's attendee chat
+// @Description Get the group chat opened for a confirmed reservation. Organizers are added automatically and can add the other attendees as participants; the chat is archived a day after the reservation ends or when it is cancelled.
+// @Tags conversations
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} dto.ConversationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /reservations/{id}/chat [get]
+func (h *ChatHandler) GetAttendeeChat(c *gin.Context) {
+	reservationID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid reservation ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	conversation, err := h.chatService.GetAttendeeChat(c.Request.Context(), userID, reservationID)
+	if err != nil {
+		switch err.Error() {
+		case "access denied":
+			respondError(c, http.StatusForbidden, "Access denied", err)
+		case "attendee chat not found", "conversation not found":
+			respondError(c, http.StatusNotFound, "Attendee chat not found", err)
+		default:
+			h.logger.Error("Failed to get attendee chat", "reservationID", reservationID, "error", err)
+			respondError(c, http.StatusInternalServerError, "Failed to get attendee chat", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, conversation)
+}
+
+// respondLinkError responds to a failure to open a conversation about a reservation or space
+func (h *ChatHandler) respondLinkError(c *gin.Context, title string, err error) {
+	switch {
+	case err.Error() == "access denied", isBlockedError(err):
+		respondError(c, http.StatusForbidden, title, err)
+	case err.Error() == "reservation not found", err.Error() == "space not found":
+		respondError(c, http.StatusNotFound, title, err)
+	case err.Error() == "space does not match the reservation", isEncryptionError(err):
+		respondError(c, http.StatusBadRequest, title, err)
+	default:
+		h.logger.Error(title, "error", err)
+		respondError(c, http.StatusInternalServerError, title, err)
+	}
+}
+
 func (h *ChatHandler) getUserIDFromContext(c *gin.Context) uuid.UUID {
 	userIDInterface, exists := c.Get("user_id")
 	if !exists {
@@ -0,0 +1,231 @@
+// internal/handlers/chat_moderation_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"room-reservation-api/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Moderation endpoints (admin only)
+
+// GetReports godoc
+// @Summary Get moderation queue
+// @Description List reported chat messages, oldest first
+// @Tags moderation
+// @Produce json
+// @Param status query string false "Filter by status" Enums(pending, actioned, dismissed)
+// @Param limit query int false "Number of reports to return" default(20)
+// @Param offset query int false "Number of reports to skip" default(0)
+// @Success 200 {object} dto.MessageReportListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/moderation/reports [get]
+func (h *ChatHandler) GetReports(c *gin.Context) {
+	var req dto.GetReportsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid query parameters", "error", err)
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid query parameters",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	if req.Limit < 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	reports, err := h.moderationService.GetReports(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to get reports", "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to get reports",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+// TakeModerationAction godoc
+// @Summary Act on a report
+// @Description Dismiss a report, delete the reported message, warn or temporarily ban its author
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path string true "Report ID"
+// @Param action body dto.ModerationActionRequest true "Moderation decision"
+// @Success 200 {object} dto.MessageReportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/moderation/reports/{id}/action [post]
+func (h *ChatHandler) TakeModerationAction(c *gin.Context) {
+	reportID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid report ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	var req dto.ModerationActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body", "error", err)
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid request",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	moderatorID := h.getUserIDFromContext(c)
+	if moderatorID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	report, err := h.moderationService.TakeAction(c.Request.Context(), moderatorID, reportID, &req)
+	if err != nil {
+		switch err.Error() {
+		case "report not found":
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:      "Report not found",
+				Message:    err.Error(),
+				StatusCode: http.StatusNotFound,
+			})
+			return
+		case "report has already been resolved":
+			c.JSON(http.StatusConflict, dto.ErrorResponse{
+				Error:      "Report already resolved",
+				Message:    err.Error(),
+				StatusCode: http.StatusConflict,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to take moderation action", "reportID", reportID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to take moderation action",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetActiveBans godoc
+// @Summary Get active chat bans
+// @Description List users currently banned from chat
+// @Tags moderation
+// @Produce json
+// @Param limit query int false "Number of bans to return" default(20)
+// @Param offset query int false "Number of bans to skip" default(0)
+// @Success 200 {object} dto.ChatBanListResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/moderation/bans [get]
+func (h *ChatHandler) GetActiveBans(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	bans, err := h.moderationService.GetActiveBans(c.Request.Context(), limit, offset)
+	if err != nil {
+		h.logger.Error("Failed to get bans", "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to get bans",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, bans)
+}
+
+// LiftBan godoc
+// @Summary Lift a chat ban
+// @Description End a chat ban before it expires
+// @Tags moderation
+// @Produce json
+// @Param id path string true "Ban ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/moderation/bans/{id} [delete]
+func (h *ChatHandler) LiftBan(c *gin.Context) {
+	banID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid ban ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	if err := h.moderationService.LiftBan(c.Request.Context(), banID); err != nil {
+		switch err.Error() {
+		case "ban not found":
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:      "Ban not found",
+				Message:    err.Error(),
+				StatusCode: http.StatusNotFound,
+			})
+			return
+		case "ban is no longer active":
+			c.JSON(http.StatusConflict, dto.ErrorResponse{
+				Error:      "Ban not active",
+				Message:    err.Error(),
+				StatusCode: http.StatusConflict,
+			})
+			return
+		}
+
+		h.logger.Error("Failed to lift ban", "banID", banID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to lift ban",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
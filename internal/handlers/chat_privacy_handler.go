@@ -0,0 +1,269 @@
+// internal/handlers/chat_privacy_handler.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"room-reservation-api/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Mute and block endpoints
+
+// MuteConversation godoc
+// @Summary Mute a conversation
+// @Description Stop notifications about a conversation for the current user, for a number of minutes or until unmuted. Messages keep arriving in realtime.
+// @Tags conversations
+// @Accept json
+// @Produce json
+// @Param id path string true "Conversation ID"
+// @Param request body dto.MuteConversationRequest false "Mute duration"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/conversations/{id}/mute [put]
+func (h *ChatHandler) MuteConversation(c *gin.Context) {
+	conversationID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid conversation ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	// The body is optional; without one the conversation stays muted until unmuted
+	var req dto.MuteConversationRequest
+	if c.Request.ContentLength > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid request", err)
+			return
+		}
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.chatService.MuteConversation(c.Request.Context(), userID, conversationID, &req); err != nil {
+		h.respondMuteError(c, "Failed to mute conversation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Conversation muted successfully",
+	})
+}
+
+// UnmuteConversation godoc
+// @Summary Unmute a conversation
+// @Description Restore notifications about a conversation for the current user
+// @Tags conversations
+// @Produce json
+// @Param id path string true "Conversation ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/conversations/{id}/mute [delete]
+func (h *ChatHandler) UnmuteConversation(c *gin.Context) {
+	conversationID, err := h.getUUIDFromParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid conversation ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.chatService.UnmuteConversation(c.Request.Context(), userID, conversationID); err != nil {
+		h.respondMuteError(c, "Failed to unmute conversation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Conversation unmuted successfully",
+	})
+}
+
+// GetBlockedUsers godoc
+// @Summary List blocked users
+// @Description List the users the current user blocked from direct messages
+// @Tags participants
+// @Produce json
+// @Success 200 {array} dto.UserBlockResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/blocks [get]
+func (h *ChatHandler) GetBlockedUsers(c *gin.Context) {
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	blocks, err := h.chatService.GetBlockedUsers(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to get blocked users", "userID", userID, "error", err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:      "Failed to get blocked users",
+			Message:    err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, blocks)
+}
+
+// BlockUser godoc
+// @Summary Block a user
+// @Description Stop a user from starting direct conversations with the current user or messaging them in existing ones. Shared group conversations are not affected.
+// @Tags participants
+// @Accept json
+// @Produce json
+// @Param request body dto.BlockUserRequest true "User to block"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/blocks [post]
+func (h *ChatHandler) BlockUser(c *gin.Context) {
+	var req dto.BlockUserRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.chatService.BlockUser(c.Request.Context(), userID, &req); err != nil {
+		switch err.Error() {
+		case "you cannot block yourself":
+			respondError(c, http.StatusBadRequest, "Failed to block user", err)
+		case "user not found":
+			respondError(c, http.StatusNotFound, "Failed to block user", err)
+		default:
+			h.logger.Error("Failed to block user", "userID", userID, "blockedUserID", req.UserID, "error", err)
+			respondError(c, http.StatusInternalServerError, "Failed to block user", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "User blocked successfully",
+	})
+}
+
+// UnblockUser godoc
+// @Summary Unblock a user
+// @Description Lift a block the current user placed
+// @Tags participants
+// @Produce json
+// @Param userId path string true "Blocked user ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /chat/blocks/{userId} [delete]
+func (h *ChatHandler) UnblockUser(c *gin.Context) {
+	blockedUserID, err := h.getUUIDFromParam(c, "userId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:      "Invalid user ID",
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID == uuid.Nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:      "Unauthorized",
+			Message:    "User ID not found in context",
+			StatusCode: http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.chatService.UnblockUser(c.Request.Context(), userID, blockedUserID); err != nil {
+		if err.Error() == "user is not blocked" {
+			respondError(c, http.StatusNotFound, "Failed to unblock user", err)
+			return
+		}
+
+		h.logger.Error("Failed to unblock user", "userID", userID, "blockedUserID", blockedUserID, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to unblock user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "User unblocked successfully",
+	})
+}
+
+func (h *ChatHandler) respondMuteError(c *gin.Context, title string, err error) {
+	if errors.Is(err, dto.ErrAccessDenied) {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:      "Access denied",
+			Message:    "You are not a participant of this conversation",
+			StatusCode: http.StatusForbidden,
+		})
+		return
+	}
+
+	h.logger.Error(title, "error", err)
+	c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+		Error:      title,
+		Message:    err.Error(),
+		StatusCode: http.StatusInternalServerError,
+	})
+}
+
+// isBlockedError reports a direct message refused because of a block
+func isBlockedError(err error) bool {
+	return err.Error() == "you cannot exchange direct messages with this user"
+}
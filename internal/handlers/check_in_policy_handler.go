@@ -0,0 +1,141 @@
+// internal/handlers/check_in_policy_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CheckInPolicyHandler handles the check-in enforcement of each space type (admin only)
+type CheckInPolicyHandler struct {
+	policyService *services.CheckInPolicyService
+}
+
+// NewCheckInPolicyHandler creates a new check-in policy handler
+func NewCheckInPolicyHandler(policyService *services.CheckInPolicyService) *CheckInPolicyHandler {
+	return &CheckInPolicyHandler{
+		policyService: policyService,
+	}
+}
+
+// GetPolicies lists the check-in policies (admin only)
+// @Summary List check-in policies
+// @Description Check-in enforcement of each space type that has one
+// @Tags check-in-policies
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /admin/check-in-policies [get]
+func (h *CheckInPolicyHandler) GetPolicies(c *gin.Context) {
+	policies, err := h.policyService.GetPolicies()
+	if err != nil {
+		respondError(c, h.determinePolicyErrorStatus(err), "Failed to get check-in policies", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Check-in policies retrieved successfully",
+		Data:    policies,
+	})
+}
+
+// SetPolicy creates or replaces the check-in policy of a space type (admin only)
+// @Summary Set check-in policy
+// @Description Release confirmed reservations of the space type, e.g. hot desks, not checked in within window_minutes of their start. Released reservations are cancelled and reported as no-shows; saved searches matching the space are alerted.
+// @Tags check-in-policies
+// @Accept json
+// @Produce json
+// @Param spaceType path string true "Space type"
+// @Param request body dto.SetCheckInPolicyRequest true "Policy"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/check-in-policies/{spaceType} [put]
+func (h *CheckInPolicyHandler) SetPolicy(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SetCheckInPolicyRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	policy, err := h.policyService.SetPolicy(c.Param("spaceType"), &req, userID)
+	if err != nil {
+		respondError(c, h.determinePolicyErrorStatus(err), "Failed to save check-in policy", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Check-in policy saved successfully",
+		Data:    policy,
+	})
+}
+
+// DeletePolicy stops enforcing check-in for a space type (admin only)
+// @Summary Delete check-in policy
+// @Description Stop releasing reservations of the space type that are not checked in
+// @Tags check-in-policies
+// @Produce json
+// @Param spaceType path string true "Space type"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/check-in-policies/{spaceType} [delete]
+func (h *CheckInPolicyHandler) DeletePolicy(c *gin.Context) {
+	if err := h.policyService.DeletePolicy(c.Param("spaceType")); err != nil {
+		respondError(c, h.determinePolicyErrorStatus(err), "Failed to delete check-in policy", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Check-in policy deleted successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *CheckInPolicyHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determinePolicyErrorStatus determines HTTP status code based on error message
+func (h *CheckInPolicyHandler) determinePolicyErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
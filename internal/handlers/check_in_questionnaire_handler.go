@@ -0,0 +1,346 @@
+// internal/handlers/check_in_questionnaire_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Period covered by the compliance report when no dates are given
+const defaultSubmissionReportDays = 30
+
+// CheckInQuestionnaireHandler handles pre-check-in questionnaire endpoints
+type CheckInQuestionnaireHandler struct {
+	questionnaireService *services.CheckInQuestionnaireService
+}
+
+// NewCheckInQuestionnaireHandler creates a new check-in questionnaire handler
+func NewCheckInQuestionnaireHandler(questionnaireService *services.CheckInQuestionnaireService) *CheckInQuestionnaireHandler {
+	return &CheckInQuestionnaireHandler{
+		questionnaireService: questionnaireService,
+	}
+}
+
+// ========================================
+// USER ENDPOINTS
+// ========================================
+
+// GetReservationQuestionnaire returns the questionnaire to answer before checking in
+// @Summary Get check-in questionnaire
+// @Description Questions the space requires before check-in, or the stored answers once submitted
+// @Tags questionnaires
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/{id}/questionnaire [get]
+func (h *CheckInQuestionnaireHandler) GetReservationQuestionnaire(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid reservation ID",
+			Message: "Reservation ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	questionnaire, err := h.questionnaireService.GetReservationQuestionnaire(reservationID, userID)
+	if err != nil {
+		respondError(c, h.determineQuestionnaireErrorStatus(err), "Failed to get questionnaire", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Questionnaire retrieved successfully",
+		Data:    questionnaire,
+	})
+}
+
+// SubmitAnswers stores the user's answers so they can check in
+// @Summary Submit check-in questionnaire
+// @Description Answer the space's safety questions; check-in is blocked until this is done. Answers are kept for compliance.
+// @Tags questionnaires
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param request body dto.SubmitQuestionnaireRequest true "Answers by question ID"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /reservations/{id}/questionnaire [post]
+func (h *CheckInQuestionnaireHandler) SubmitAnswers(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid reservation ID",
+			Message: "Reservation ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SubmitQuestionnaireRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	result, err := h.questionnaireService.SubmitAnswers(reservationID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineQuestionnaireErrorStatus(err), "Failed to submit questionnaire", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Questionnaire submitted, you can now check in",
+		Data:    result,
+	})
+}
+
+// ========================================
+// MANAGER ENDPOINTS
+// ========================================
+
+// GetSpaceSubmissions lists stored answers for a space (manager/admin)
+// @Summary Questionnaire compliance report
+// @Description Answers submitted for reservations of the space starting in the period (default: last 30 days)
+// @Tags questionnaires
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /manager/spaces/{id}/questionnaire/submissions [get]
+func (h *CheckInQuestionnaireHandler) GetSpaceSubmissions(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	from := today.AddDate(0, 0, -defaultSubmissionReportDays)
+	to := today
+	for param, target := range map[string]*time.Time{"from": &from, "to": &to} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.ParseInLocation("2006-01-02", raw, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid date",
+				Message: param + " must use the YYYY-MM-DD format",
+			})
+			return
+		}
+		*target = parsed
+	}
+
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid date range",
+			Message: "to must not be before from",
+		})
+		return
+	}
+
+	submissions, err := h.questionnaireService.GetSpaceSubmissions(spaceID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		respondError(c, h.determineQuestionnaireErrorStatus(err), "Failed to get submissions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Submissions retrieved successfully",
+		Data:    submissions,
+	})
+}
+
+// ========================================
+// ADMIN ENDPOINTS
+// ========================================
+
+// SetSpaceQuestionnaire creates or replaces the questionnaire of a space (admin only)
+// @Summary Configure check-in questionnaire
+// @Description Set the questions users must answer before checking in. Questions with an expected answer block entry when answered otherwise.
+// @Tags questionnaires
+// @Accept json
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Param request body dto.SetQuestionnaireRequest true "Questionnaire"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/questionnaire [put]
+func (h *CheckInQuestionnaireHandler) SetSpaceQuestionnaire(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SetQuestionnaireRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	questionnaire, err := h.questionnaireService.SetSpaceQuestionnaire(spaceID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineQuestionnaireErrorStatus(err), "Failed to save questionnaire", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Questionnaire saved successfully",
+		Data:    questionnaire,
+	})
+}
+
+// GetSpaceQuestionnaire returns the questionnaire of a space (admin only)
+// @Summary Get space questionnaire
+// @Description Get the check-in questionnaire configured for a space
+// @Tags questionnaires
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/questionnaire [get]
+func (h *CheckInQuestionnaireHandler) GetSpaceQuestionnaire(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	questionnaire, err := h.questionnaireService.GetSpaceQuestionnaire(spaceID)
+	if err != nil {
+		respondError(c, h.determineQuestionnaireErrorStatus(err), "Failed to get questionnaire", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Questionnaire retrieved successfully",
+		Data:    questionnaire,
+	})
+}
+
+// DeleteSpaceQuestionnaire removes the questionnaire of a space (admin only)
+// @Summary Remove space questionnaire
+// @Description Stop requiring a questionnaire before check-in; stored answers are kept
+// @Tags questionnaires
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/questionnaire [delete]
+func (h *CheckInQuestionnaireHandler) DeleteSpaceQuestionnaire(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.questionnaireService.DeleteSpaceQuestionnaire(spaceID); err != nil {
+		respondError(c, h.determineQuestionnaireErrorStatus(err), "Failed to remove questionnaire", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Questionnaire removed successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *CheckInQuestionnaireHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineQuestionnaireErrorStatus determines HTTP status code based on error message
+func (h *CheckInQuestionnaireHandler) determineQuestionnaireErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"),
+		err.Error() == "space has no check-in questionnaire":
+		return http.StatusNotFound
+	case err.Error() == "access denied":
+		return http.StatusForbidden
+	case err.Error() == "questionnaire already submitted",
+		err.Error() == "reservation does not accept answers":
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
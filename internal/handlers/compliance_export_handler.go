@@ -0,0 +1,230 @@
+// internal/handlers/compliance_export_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ComplianceExportHandler handles tamper-evident message exports for legal requests (auditors only)
+type ComplianceExportHandler struct {
+	exportService *services.ComplianceExportService
+}
+
+// NewComplianceExportHandler creates a new compliance export handler
+func NewComplianceExportHandler(exportService *services.ComplianceExportService) *ComplianceExportHandler {
+	return &ComplianceExportHandler{
+		exportService: exportService,
+	}
+}
+
+// ExportConversation exports a conversation for a legal request
+// @Summary Compliance export of a conversation
+// @Description Every message of the conversation within the dates, hash-chained, with a manifest signed by the server. Never truncated; the export is recorded with its case reference.
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param id path string true "Conversation ID" format(uuid)
+// @Param request body dto.ComplianceExportRequest true "Case and dates"
+// @Success 200 {object} dto.ComplianceExportBundle
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /audit/compliance-exports/conversations/{id} [post]
+func (h *ComplianceExportHandler) ExportConversation(c *gin.Context) {
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid conversation ID",
+			Message: "Conversation ID must be a valid UUID",
+		})
+		return
+	}
+
+	auditorID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.ComplianceExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	bundle, err := h.exportService.ExportConversation(c.Request.Context(), conversationID, &req, auditorID)
+	if err != nil {
+		respondError(c, h.determineExportErrorStatus(err), "Failed to export conversation", err)
+		return
+	}
+
+	h.sendBundle(c, bundle, "conversation-"+conversationID.String())
+}
+
+// ExportUserMessages exports a user's messages for a legal request
+// @Summary Compliance export of a user's messages
+// @Description Every message the user sent within the dates across all conversations, hash-chained, with a manifest signed by the server
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID" format(uuid)
+// @Param request body dto.ComplianceExportRequest true "Case and dates"
+// @Success 200 {object} dto.ComplianceExportBundle
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /audit/compliance-exports/users/{id} [post]
+func (h *ComplianceExportHandler) ExportUserMessages(c *gin.Context) {
+	subjectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid user ID",
+			Message: "User ID must be a valid UUID",
+		})
+		return
+	}
+
+	auditorID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.ComplianceExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	bundle, err := h.exportService.ExportUserMessages(c.Request.Context(), subjectID, &req, auditorID)
+	if err != nil {
+		respondError(c, h.determineExportErrorStatus(err), "Failed to export user messages", err)
+		return
+	}
+
+	h.sendBundle(c, bundle, "user-"+subjectID.String())
+}
+
+// GetExports lists the compliance exports issued
+// @Summary Compliance export history
+// @Description Who exported what, when and for which case, newest first
+// @Tags compliance
+// @Produce json
+// @Param case_reference query string false "Only exports for this case"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.PaginatedResponse
+// @Router /audit/compliance-exports [get]
+func (h *ComplianceExportHandler) GetExports(c *gin.Context) {
+	page, limit := utils.ValidatePagination(utils.GetIntQuery(c, "page", 1), utils.GetIntQuery(c, "limit", 20))
+
+	exports, total, err := h.exportService.GetExports(c.Query("case_reference"), utils.CalculateOffset(page, limit), limit)
+	if err != nil {
+		respondError(c, h.determineExportErrorStatus(err), "Failed to get compliance exports", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(exports, total, page, limit))
+}
+
+// GetPublicKey returns the key that verifies export manifests
+// @Summary Compliance signing key
+// @Description Ed25519 public key to check manifest signatures outside the application
+// @Tags compliance
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /audit/compliance-exports/public-key [get]
+func (h *ComplianceExportHandler) GetPublicKey(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Signing key retrieved successfully",
+		Data:    h.exportService.PublicKey(),
+	})
+}
+
+// VerifyExport checks a compliance export bundle
+// @Summary Verify compliance export
+// @Description Check that the hash chain is intact, the manifest signature is valid and the export was issued by this server
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param request body dto.ComplianceExportBundle true "Export bundle"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /audit/compliance-exports/verify [post]
+func (h *ComplianceExportHandler) VerifyExport(c *gin.Context) {
+	var bundle dto.ComplianceExportBundle
+	if err := c.ShouldBindJSON(&bundle); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	verification, err := h.exportService.Verify(&bundle)
+	if err != nil {
+		respondError(c, h.determineExportErrorStatus(err), "Failed to verify compliance export", err)
+		return
+	}
+
+	message := "Compliance export is authentic"
+	if !verification.Valid {
+		message = "Compliance export failed verification"
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: message,
+		Data:    verification,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// sendBundle writes the bundle as a JSON download named after the subject and export
+func (h *ComplianceExportHandler) sendBundle(c *gin.Context, bundle *dto.ComplianceExportBundle, subject string) {
+	filename := fmt.Sprintf("compliance-%s-%s.json", subject, bundle.Manifest.ExportID)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.JSON(http.StatusOK, bundle)
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *ComplianceExportHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineExportErrorStatus determines HTTP status code based on error message
+func (h *ComplianceExportHandler) determineExportErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case err.Error() == "access denied":
+		return http.StatusForbidden
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
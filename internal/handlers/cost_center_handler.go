@@ -0,0 +1,159 @@
+// internal/handlers/cost_center_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CostCenterHandler handles the cost centers reservations are charged to
+type CostCenterHandler struct {
+	costCenterService *services.CostCenterService
+}
+
+// NewCostCenterHandler creates a new cost center handler
+func NewCostCenterHandler(costCenterService *services.CostCenterService) *CostCenterHandler {
+	return &CostCenterHandler{
+		costCenterService: costCenterService,
+	}
+}
+
+// GetActiveCostCenters lists the cost centers new reservations can be charged to
+// @Summary List cost centers
+// @Description Active cost centers by code, to pick the cost_center of a reservation
+// @Tags cost-centers
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /cost-centers [get]
+func (h *CostCenterHandler) GetActiveCostCenters(c *gin.Context) {
+	h.getCostCenters(c, true)
+}
+
+// GetCostCenters lists every cost center (admin only)
+// @Summary List all cost centers
+// @Description Cost centers by code, inactive ones included unless include_inactive is false
+// @Tags cost-centers
+// @Produce json
+// @Param include_inactive query bool false "Also list inactive cost centers" default(true)
+// @Success 200 {object} dto.SuccessResponse
+// @Router /admin/cost-centers [get]
+func (h *CostCenterHandler) GetCostCenters(c *gin.Context) {
+	h.getCostCenters(c, !utils.GetBoolQuery(c, "include_inactive", true))
+}
+
+// SetCostCenter adds or changes a cost center (admin only)
+// @Summary Set cost center
+// @Description Add a cost center under its code, stored in upper case, or change its name and status. Inactive cost centers stay on past reservations but cannot be given to new ones.
+// @Tags cost-centers
+// @Accept json
+// @Produce json
+// @Param code path string true "Cost center code"
+// @Param request body dto.SetCostCenterRequest true "Cost center"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/cost-centers/{code} [put]
+func (h *CostCenterHandler) SetCostCenter(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SetCostCenterRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	costCenter, err := h.costCenterService.SetCostCenter(c.Param("code"), &req, userID)
+	if err != nil {
+		respondError(c, h.determineCostCenterErrorStatus(err), "Failed to set cost center", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Cost center saved successfully",
+		Data:    costCenter,
+	})
+}
+
+// DeleteCostCenter removes a cost center from the list (admin only)
+// @Summary Delete cost center
+// @Description Remove a cost center; reservations already charged to it keep its code
+// @Tags cost-centers
+// @Produce json
+// @Param code path string true "Cost center code"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/cost-centers/{code} [delete]
+func (h *CostCenterHandler) DeleteCostCenter(c *gin.Context) {
+	if err := h.costCenterService.DeleteCostCenter(c.Param("code")); err != nil {
+		respondError(c, h.determineCostCenterErrorStatus(err), "Failed to delete cost center", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Cost center deleted successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// getCostCenters responds with the cost centers
+func (h *CostCenterHandler) getCostCenters(c *gin.Context, activeOnly bool) {
+	costCenters, err := h.costCenterService.GetCostCenters(activeOnly)
+	if err != nil {
+		respondError(c, h.determineCostCenterErrorStatus(err), "Failed to get cost centers", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Cost centers retrieved successfully",
+		Data:    costCenters,
+	})
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *CostCenterHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineCostCenterErrorStatus determines HTTP status code based on error message
+func (h *CostCenterHandler) determineCostCenterErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,121 @@
+// internal/handlers/data_quality_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DataQualityHandler handles the reservation data consistency report and its fix-ups
+type DataQualityHandler struct {
+	dataQualityService *services.DataQualityService
+}
+
+// NewDataQualityHandler creates a new data quality handler
+func NewDataQualityHandler(dataQualityService *services.DataQualityService) *DataQualityHandler {
+	return &DataQualityHandler{
+		dataQualityService: dataQualityService,
+	}
+}
+
+// GetReport scans reservation data for inconsistencies (admin only)
+// @Summary Data quality report
+// @Description Check-ins on unconfirmed reservations, confirmed bookings overlapping in the same space, co-organizers and guests left on deleted reservations, and upcoming reservations on deleted spaces, each with its fix-up action
+// @Tags data-quality
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/data-quality [get]
+func (h *DataQualityHandler) GetReport(c *gin.Context) {
+	report, err := h.dataQualityService.GetReport()
+	if err != nil {
+		respondError(c, h.determineDataQualityErrorStatus(err), "Failed to check data quality", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Data quality report generated successfully",
+		Data:    report,
+	})
+}
+
+// FixIssues applies the fix-up action of one issue type (admin only)
+// @Summary Fix data quality issues
+// @Description Clear check-ins, cancel overlapping or orphaned reservations, or delete orphaned participants. Issues are checked again first; without IDs every issue of the type is fixed.
+// @Tags data-quality
+// @Accept json
+// @Produce json
+// @Param request body dto.FixDataQualityRequest true "Issue type and record IDs"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/data-quality/fix [post]
+func (h *DataQualityHandler) FixIssues(c *gin.Context) {
+	adminID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.FixDataQualityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.dataQualityService.Fix(&req, adminID)
+	if err != nil {
+		respondError(c, h.determineDataQualityErrorStatus(err), "Failed to fix data quality issues", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: fmt.Sprintf("%d of %d issues fixed", result.Fixed, result.Matched),
+		Data:    result,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *DataQualityHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineDataQualityErrorStatus determines HTTP status code based on error message
+func (h *DataQualityHandler) determineDataQualityErrorStatus(err error) int {
+	switch {
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
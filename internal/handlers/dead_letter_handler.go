@@ -0,0 +1,281 @@
+// internal/handlers/dead_letter_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DeadLetterHandler handles the dead letter queue of failed async work
+type DeadLetterHandler struct {
+	deadLetterService *services.DeadLetterService
+}
+
+// NewDeadLetterHandler creates a new dead letter handler
+func NewDeadLetterHandler(deadLetterService *services.DeadLetterService) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		deadLetterService: deadLetterService,
+	}
+}
+
+// GetDeadLetters lists dead letters (admin only)
+// @Summary List dead letters
+// @Description Emails out of retries, webhook deliveries out of attempts and failed job runs, most recently failed first
+// @Tags dead-letters
+// @Produce json
+// @Param source query string false "notification, webhook or job"
+// @Param status query string false "open, replayed or discarded"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/dead-letters [get]
+func (h *DeadLetterHandler) GetDeadLetters(c *gin.Context) {
+	page := utils.GetIntQuery(c, "page", 1)
+	limit := utils.GetIntQuery(c, "limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	letters, total, err := h.deadLetterService.GetDeadLetters(c.Query("source"), c.Query("status"), (page-1)*limit, limit)
+	if err != nil {
+		respondError(c, h.determineDeadLetterErrorStatus(err), "Failed to get dead letters", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(letters, total, page, limit))
+}
+
+// GetDeadLetter shows a dead letter with its payload (admin only)
+// @Summary Get dead letter
+// @Tags dead-letters
+// @Produce json
+// @Param id path string true "Dead letter ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/dead-letters/{id} [get]
+func (h *DeadLetterHandler) GetDeadLetter(c *gin.Context) {
+	letterID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	letter, err := h.deadLetterService.GetDeadLetter(letterID)
+	if err != nil {
+		respondError(c, h.determineDeadLetterErrorStatus(err), "Failed to get dead letter", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Dead letter retrieved successfully",
+		Data:    letter,
+	})
+}
+
+// UpdateDeadLetter edits the payload of an open dead letter (admin only)
+// @Summary Edit dead letter payload
+// @Description Replace the payload used on replay. Notifications take a new title, message and data but keep their recipient; webhook payloads are re-signed when sent. Job runs have no payload.
+// @Tags dead-letters
+// @Accept json
+// @Produce json
+// @Param id path string true "Dead letter ID" format(uuid)
+// @Param request body dto.UpdateDeadLetterRequest true "Payload"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/dead-letters/{id} [put]
+func (h *DeadLetterHandler) UpdateDeadLetter(c *gin.Context) {
+	letterID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateDeadLetterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	letter, err := h.deadLetterService.UpdatePayload(letterID, &req)
+	if err != nil {
+		respondError(c, h.determineDeadLetterErrorStatus(err), "Failed to update dead letter", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Dead letter updated successfully",
+		Data:    letter,
+	})
+}
+
+// ReplayDeadLetter replays one dead letter (admin only)
+// @Summary Replay dead letter
+// @Description Notifications and webhook deliveries go back to their queue with fresh retries; jobs run again right away
+// @Tags dead-letters
+// @Produce json
+// @Param id path string true "Dead letter ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/dead-letters/{id}/replay [post]
+func (h *DeadLetterHandler) ReplayDeadLetter(c *gin.Context) {
+	letterID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	adminID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	letter, err := h.deadLetterService.Replay(c.Request.Context(), letterID, adminID)
+	if err != nil {
+		respondError(c, h.determineDeadLetterErrorStatus(err), "Failed to replay dead letter", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Dead letter replayed successfully",
+		Data:    letter,
+	})
+}
+
+// ReplayDeadLetters replays several dead letters (admin only)
+// @Summary Replay dead letters
+// @Description Replay the open dead letters with the given IDs, or every open one of a source (up to 500). Failures do not stop the others.
+// @Tags dead-letters
+// @Accept json
+// @Produce json
+// @Param request body dto.ReplayDeadLettersRequest true "Selection"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/dead-letters/replay [post]
+func (h *DeadLetterHandler) ReplayDeadLetters(c *gin.Context) {
+	adminID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.ReplayDeadLettersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	result, err := h.deadLetterService.ReplayBulk(c.Request.Context(), &req, adminID)
+	if err != nil {
+		respondError(c, h.determineDeadLetterErrorStatus(err), "Failed to replay dead letters", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: fmt.Sprintf("%d dead letter(s) replayed, %d failed", result.Replayed, result.Failed),
+		Data:    result,
+	})
+}
+
+// DiscardDeadLetter closes a dead letter without replaying it (admin only)
+// @Summary Discard dead letter
+// @Tags dead-letters
+// @Produce json
+// @Param id path string true "Dead letter ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/dead-letters/{id} [delete]
+func (h *DeadLetterHandler) DiscardDeadLetter(c *gin.Context) {
+	letterID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	adminID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	if err := h.deadLetterService.Discard(letterID, adminID); err != nil {
+		respondError(c, h.determineDeadLetterErrorStatus(err), "Failed to discard dead letter", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Dead letter discarded successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// parseID reads the dead letter ID from the path, responding when it is invalid
+func (h *DeadLetterHandler) parseID(c *gin.Context) (uuid.UUID, bool) {
+	letterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid dead letter ID",
+			Message: "Dead letter ID must be a valid UUID",
+		})
+		return uuid.Nil, false
+	}
+	return letterID, true
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *DeadLetterHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineDeadLetterErrorStatus determines HTTP status code based on error message
+func (h *DeadLetterHandler) determineDeadLetterErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "dead letter is already"),
+		err.Error() == "webhook delivery no longer exists":
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "job failed again"):
+		return http.StatusBadGateway
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,76 @@
+// internal/handlers/deep_link_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"room-reservation-api/internal/deeplink"
+	"room-reservation-api/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DeepLinkHandler resolves notification deep links for web clients
+type DeepLinkHandler struct {
+	webAppURL string
+}
+
+// NewDeepLinkHandler creates a new deep link handler
+func NewDeepLinkHandler(webAppURL string) *DeepLinkHandler {
+	return &DeepLinkHandler{
+		webAppURL: webAppURL,
+	}
+}
+
+// ResolveLink redirects an app:// link to the web client
+// @Summary Resolve deep link
+// @Description Redirect an app://reservation/{id} or app://conversation/{id} link to the matching page of the web client
+// @Tags links
+// @Param to query string true "Deep link" example(app://reservation/6f1c2a9e-4b7d-4c1a-9a57-2f0e8c3d5b11)
+// @Success 302
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /links [get]
+func (h *DeepLinkHandler) ResolveLink(c *gin.Context) {
+	link, err := deeplink.Parse(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid link",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.Redirect(http.StatusFound, link.WebURL(h.webAppURL))
+}
+
+// RedirectLink redirects the HTTPS form of a deep link, as sent in emails, to the web client
+// @Summary Open deep link
+// @Description Redirect /links/{kind}/{id} to the matching page of the web client
+// @Tags links
+// @Param kind path string true "Screen" Enums(reservation, conversation)
+// @Param id path string true "Reservation or conversation ID" format(uuid)
+// @Success 302
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /links/{kind}/{id} [get]
+func (h *DeepLinkHandler) RedirectLink(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid link",
+			Message: "Link ID must be a valid UUID",
+		})
+		return
+	}
+
+	link, err := deeplink.New(c.Param("kind"), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid link",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.Redirect(http.StatusFound, link.WebURL(h.webAppURL))
+}
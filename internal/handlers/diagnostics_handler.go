@@ -0,0 +1,38 @@
+// internal/handlers/diagnostics_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DiagnosticsHandler exposes the deep diagnostics report to administrators
+type DiagnosticsHandler struct {
+	diagnosticsService *services.DiagnosticsService
+}
+
+// NewDiagnosticsHandler creates a new diagnostics handler
+func NewDiagnosticsHandler(diagnosticsService *services.DiagnosticsService) *DiagnosticsHandler {
+	return &DiagnosticsHandler{
+		diagnosticsService: diagnosticsService,
+	}
+}
+
+// GetDiagnostics returns the state of the running instance
+// @Summary Deep diagnostics
+// @Description Build, schema, connection pool, queue depths, webhook outbox, WebSocket clients, cache hit rates, job runs and integration breakers in one report. Sections that fail are listed under errors.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=dto.DiagnosticsResponse}
+// @Router /admin/diagnostics [get]
+func (h *DiagnosticsHandler) GetDiagnostics(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Diagnostics retrieved successfully",
+		Data:    h.diagnosticsService.GetDiagnostics(c.Request.Context()),
+	})
+}
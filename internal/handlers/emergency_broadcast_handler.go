@@ -0,0 +1,232 @@
+// internal/handlers/emergency_broadcast_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// EmergencyBroadcastHandler handles emergency broadcasts and their acknowledgments
+type EmergencyBroadcastHandler struct {
+	broadcastService *services.EmergencyBroadcastService
+}
+
+// NewEmergencyBroadcastHandler creates a new emergency broadcast handler
+func NewEmergencyBroadcastHandler(broadcastService *services.EmergencyBroadcastService) *EmergencyBroadcastHandler {
+	return &EmergencyBroadcastHandler{
+		broadcastService: broadcastService,
+	}
+}
+
+// Broadcast announces an emergency in a building (admin only)
+// @Summary Broadcast emergency
+// @Description Notify everyone with a booking in the building today, in-app, over WebSocket and by email, and check out (check_out) or pause until the all-clear (suspend) every meeting checked in there. Recipients acknowledge through POST /emergencies/{id}/acknowledge.
+// @Tags emergencies
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateEmergencyBroadcastRequest true "Emergency"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/emergencies [post]
+func (h *EmergencyBroadcastHandler) Broadcast(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateEmergencyBroadcastRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	broadcast, err := h.broadcastService.Broadcast(&req, userID)
+	if err != nil {
+		respondError(c, h.determineBroadcastErrorStatus(err), "Failed to broadcast emergency", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Emergency broadcast sent successfully",
+		Data:    broadcast,
+	})
+}
+
+// GetBroadcasts lists emergency broadcasts (admin only)
+// @Summary List emergency broadcasts
+// @Description Emergency broadcasts, most recent first
+// @Tags emergencies
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.PaginatedResponse
+// @Router /admin/emergencies [get]
+func (h *EmergencyBroadcastHandler) GetBroadcasts(c *gin.Context) {
+	page := utils.GetIntQuery(c, "page", 1)
+	limit := utils.GetIntQuery(c, "limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	broadcasts, total, err := h.broadcastService.GetBroadcasts((page-1)*limit, limit)
+	if err != nil {
+		respondError(c, h.determineBroadcastErrorStatus(err), "Failed to get emergency broadcasts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(broadcasts, total, page, limit))
+}
+
+// GetBroadcast shows an emergency broadcast with who acknowledged it (admin only)
+// @Summary Get emergency broadcast
+// @Description Emergency broadcast with its recipients, those yet to acknowledge first
+// @Tags emergencies
+// @Produce json
+// @Param id path string true "Emergency ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/emergencies/{id} [get]
+func (h *EmergencyBroadcastHandler) GetBroadcast(c *gin.Context) {
+	broadcastID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	broadcast, err := h.broadcastService.GetBroadcast(broadcastID)
+	if err != nil {
+		respondError(c, h.determineBroadcastErrorStatus(err), "Failed to get emergency broadcast", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Emergency broadcast retrieved successfully",
+		Data:    broadcast,
+	})
+}
+
+// EndBroadcast gives the all-clear (admin only)
+// @Summary End emergency
+// @Description Give the all-clear: suspended check-ins resume and recipients are notified
+// @Tags emergencies
+// @Produce json
+// @Param id path string true "Emergency ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/emergencies/{id}/end [post]
+func (h *EmergencyBroadcastHandler) EndBroadcast(c *gin.Context) {
+	broadcastID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	broadcast, err := h.broadcastService.EndBroadcast(broadcastID)
+	if err != nil {
+		respondError(c, h.determineBroadcastErrorStatus(err), "Failed to end emergency broadcast", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Emergency broadcast ended successfully",
+		Data:    broadcast,
+	})
+}
+
+// Acknowledge records that the current user read an emergency broadcast
+// @Summary Acknowledge emergency
+// @Description Confirm an emergency broadcast sent to you was received; repeating it is harmless
+// @Tags emergencies
+// @Produce json
+// @Param id path string true "Emergency ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /emergencies/{id}/acknowledge [post]
+func (h *EmergencyBroadcastHandler) Acknowledge(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+	broadcastID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.broadcastService.Acknowledge(broadcastID, userID); err != nil {
+		respondError(c, h.determineBroadcastErrorStatus(err), "Failed to acknowledge emergency broadcast", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Emergency broadcast acknowledged",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// parseID parses the emergency broadcast ID path parameter, answering 400 when invalid
+func (h *EmergencyBroadcastHandler) parseID(c *gin.Context) (uuid.UUID, bool) {
+	broadcastID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid emergency ID",
+			Message: "Emergency ID must be a valid UUID",
+		})
+		return uuid.Nil, false
+	}
+	return broadcastID, true
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *EmergencyBroadcastHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineBroadcastErrorStatus determines HTTP status code based on error message
+func (h *EmergencyBroadcastHandler) determineBroadcastErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	case strings.Contains(err.Error(), "not sent to you"):
+		return http.StatusForbidden
+	case strings.Contains(err.Error(), "already"):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
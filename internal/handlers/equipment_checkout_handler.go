@@ -0,0 +1,353 @@
+// internal/handlers/equipment_checkout_handler.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// EquipmentCheckoutHandler handles portable equipment attached to reservations and its checkouts
+type EquipmentCheckoutHandler struct {
+	checkoutService *services.EquipmentCheckoutService
+}
+
+// NewEquipmentCheckoutHandler creates a new equipment checkout handler
+func NewEquipmentCheckoutHandler(checkoutService *services.EquipmentCheckoutService) *EquipmentCheckoutHandler {
+	return &EquipmentCheckoutHandler{
+		checkoutService: checkoutService,
+	}
+}
+
+// ========================================
+// INVENTORY AND ORGANIZER ENDPOINTS
+// ========================================
+
+// GetInventory lists the portable equipment and where it is
+// @Summary Equipment inventory
+// @Description Portable equipment such as projectors and conference phones, with whether each item is checked out or overdue. With start_time and end_time, also whether each can be booked then.
+// @Tags equipment
+// @Produce json
+// @Param building query string false "Building"
+// @Param start_time query string false "Window start (RFC3339)"
+// @Param end_time query string false "Window end (RFC3339)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /equipment [get]
+func (h *EquipmentCheckoutHandler) GetInventory(c *gin.Context) {
+	var startTime, endTime *time.Time
+	for _, param := range []string{"start_time", "end_time"} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid " + param,
+				Message: param + " must be in RFC3339 format (e.g., 2023-12-25T10:00:00Z)",
+			})
+			return
+		}
+		if param == "start_time" {
+			startTime = &parsed
+		} else {
+			endTime = &parsed
+		}
+	}
+	if (startTime == nil) != (endTime == nil) || (startTime != nil && !startTime.Before(*endTime)) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid time window",
+			Message: "start_time and end_time must be given together, start_time first",
+		})
+		return
+	}
+
+	inventory, err := h.checkoutService.GetInventory(c.Query("building"), startTime, endTime)
+	if err != nil {
+		respondError(c, h.determineEquipmentErrorStatus(err), "Failed to get equipment inventory", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Equipment inventory retrieved successfully",
+		Data:    inventory,
+	})
+}
+
+// GetReservationEquipment lists the equipment attached to a reservation
+// @Summary Reservation equipment
+// @Description Equipment attached to the reservation with its pickup and return
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/{id}/equipment [get]
+func (h *EquipmentCheckoutHandler) GetReservationEquipment(c *gin.Context) {
+	reservationID, ok := h.parseID(c, "id", "reservation")
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	equipment, err := h.checkoutService.GetReservationEquipment(reservationID, userID)
+	if err != nil {
+		respondError(c, h.determineEquipmentErrorStatus(err), "Failed to get reservation equipment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Reservation equipment retrieved successfully",
+		Data:    equipment,
+	})
+}
+
+// AttachEquipment attaches portable equipment to a reservation
+// @Summary Attach equipment
+// @Description Book portable equipment of the reservation's building for the whole reservation
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param request body dto.AttachEquipmentRequest true "Equipment"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /reservations/{id}/equipment [post]
+func (h *EquipmentCheckoutHandler) AttachEquipment(c *gin.Context) {
+	reservationID, ok := h.parseID(c, "id", "reservation")
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.AttachEquipmentRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	link, err := h.checkoutService.AttachEquipment(reservationID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineEquipmentErrorStatus(err), "Failed to attach equipment", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Equipment attached successfully",
+		Data:    link,
+	})
+}
+
+// DetachEquipment removes equipment from a reservation
+// @Summary Detach equipment
+// @Description Give back equipment attached to the reservation that was not picked up
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param checkoutId path string true "Equipment checkout ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /reservations/{id}/equipment/{checkoutId} [delete]
+func (h *EquipmentCheckoutHandler) DetachEquipment(c *gin.Context) {
+	reservationID, ok := h.parseID(c, "id", "reservation")
+	if !ok {
+		return
+	}
+	checkoutID, ok := h.parseID(c, "checkoutId", "equipment checkout")
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	if err := h.checkoutService.DetachEquipment(reservationID, checkoutID, userID); err != nil {
+		respondError(c, h.determineEquipmentErrorStatus(err), "Failed to detach equipment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Equipment detached successfully",
+	})
+}
+
+// ========================================
+// FACILITIES ENDPOINTS
+// ========================================
+
+// GetCheckedOut lists the equipment out now
+// @Summary Checked out equipment
+// @Description Equipment picked up and not returned, longest out first; overdue=true keeps the items still out after their reservation ended
+// @Tags equipment
+// @Produce json
+// @Param overdue query bool false "Only overdue equipment"
+// @Success 200 {object} dto.SuccessResponse
+// @Router /facilities/equipment/checkouts [get]
+func (h *EquipmentCheckoutHandler) GetCheckedOut(c *gin.Context) {
+	checkouts, err := h.checkoutService.GetCheckedOut(c.Query("overdue") == "true")
+	if err != nil {
+		respondError(c, h.determineEquipmentErrorStatus(err), "Failed to get checked out equipment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Checked out equipment retrieved successfully",
+		Data:    checkouts,
+	})
+}
+
+// ConfirmPickup records equipment being handed out
+// @Summary Confirm equipment pickup
+// @Description Record the equipment leaving with the organizer, from 30 minutes before the reservation starts until it ends
+// @Tags equipment
+// @Produce json
+// @Param id path string true "Equipment checkout ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /facilities/equipment/checkouts/{id}/pickup [post]
+func (h *EquipmentCheckoutHandler) ConfirmPickup(c *gin.Context) {
+	h.confirm(c, h.checkoutService.ConfirmPickup, "Failed to record pickup", "Pickup recorded successfully")
+}
+
+// ConfirmReturn records equipment being brought back
+// @Summary Confirm equipment return
+// @Description Record the equipment being back with facilities
+// @Tags equipment
+// @Produce json
+// @Param id path string true "Equipment checkout ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /facilities/equipment/checkouts/{id}/return [post]
+func (h *EquipmentCheckoutHandler) ConfirmReturn(c *gin.Context) {
+	h.confirm(c, h.checkoutService.ConfirmReturn, "Failed to record return", "Return recorded successfully")
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// confirm runs a pickup or return confirmation by the signed-in staff member
+func (h *EquipmentCheckoutHandler) confirm(c *gin.Context, action func(checkoutID, staffID uuid.UUID) (*models.ReservationResource, error), failure, success string) {
+	checkoutID, ok := h.parseID(c, "id", "equipment checkout")
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	link, err := action(checkoutID, userID)
+	if err != nil {
+		respondError(c, h.determineEquipmentErrorStatus(err), failure, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: success,
+		Data:    link,
+	})
+}
+
+// parseID parses a UUID path parameter, writing a 400 response when it is invalid
+func (h *EquipmentCheckoutHandler) parseID(c *gin.Context, param, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		label := strings.ToUpper(name[:1]) + name[1:]
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   fmt.Sprintf("Invalid %s ID", name),
+			Message: fmt.Sprintf("%s ID must be a valid UUID", label),
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *EquipmentCheckoutHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineEquipmentErrorStatus determines HTTP status code based on error message
+func (h *EquipmentCheckoutHandler) determineEquipmentErrorStatus(err error) int {
+	var coded *dto.CodedError
+	if errors.As(err, &coded) {
+		switch coded.Code {
+		case dto.ErrCodeAccessDenied:
+			return http.StatusForbidden
+		case dto.ErrCodeResourceBooked:
+			return http.StatusConflict
+		default:
+			return http.StatusBadRequest
+		}
+	}
+
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "already"),
+		strings.Contains(err.Error(), "not been returned"),
+		strings.Contains(err.Error(), "is overdue"):
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,16 @@
+// internal/handlers/error_response.go
+package handlers
+
+import (
+	"room-reservation-api/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondError writes an error response with the error's code and its message in the
+// language asked for by Accept-Language
+func respondError(c *gin.Context, status int, title string, err error) {
+	lang := dto.ResolveLanguage(c.GetHeader("Accept-Language"))
+	c.Header("Content-Language", lang)
+	c.JSON(status, dto.NewLocalizedErrorResponse(title, err, status, lang))
+}
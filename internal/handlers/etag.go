@@ -0,0 +1,33 @@
+// internal/handlers/etag.go
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag sets the ETag of a record from its updated_at, for clients to send back in If-Match
+func setETag(c *gin.Context, updatedAt time.Time) {
+	c.Header("ETag", `"`+strconv.FormatInt(updatedAt.UnixMicro(), 10)+`"`)
+}
+
+// ifMatch reads the If-Match header as the updated_at the client last read. It returns nil
+// when the header is absent or "*", which place no precondition.
+func ifMatch(c *gin.Context) (*time.Time, error) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+	micros, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("If-Match must be an ETag returned by the API")
+	}
+	updatedAt := time.UnixMicro(micros)
+	return &updatedAt, nil
+}
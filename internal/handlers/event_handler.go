@@ -0,0 +1,238 @@
+// internal/handlers/event_handler.go
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// How long a poll waits for new events before returning an empty batch
+	eventPollHold = 25 * time.Second
+	// How often an idle event stream sends a keep-alive comment
+	eventStreamHeartbeat = 15 * time.Second
+	// Delay the browser waits before reconnecting a dropped stream
+	eventStreamRetry = 3 * time.Second
+)
+
+type shutdownContextKey struct{}
+
+// WithShutdown returns a copy of ctx carrying the server's shutdown context. The server uses it
+// as the base of every request, so long-lived requests end when it shuts down instead of
+// holding up the drain until its deadline.
+func WithShutdown(ctx, shutdown context.Context) context.Context {
+	return context.WithValue(ctx, shutdownContextKey{}, shutdown)
+}
+
+// streamContext returns the context of a long-lived request: cancelled when the client goes
+// away or when the server shuts down
+func streamContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	if shutdown, ok := ctx.Value(shutdownContextKey{}).(context.Context); ok {
+		stop := context.AfterFunc(shutdown, cancel)
+		return ctx, func() {
+			stop()
+			cancel()
+		}
+	}
+	return ctx, cancel
+}
+
+// EventHandler serves realtime events to clients without a WebSocket connection
+type EventHandler struct {
+	eventPollService *services.EventPollService
+}
+
+// NewEventHandler creates a new event handler
+func NewEventHandler(eventPollService *services.EventPollService) *EventHandler {
+	return &EventHandler{
+		eventPollService: eventPollService,
+	}
+}
+
+// Poll waits for realtime events
+// @Summary Long-poll realtime events
+// @Description Returns reservation, chat and notification events after the given cursor, holding the request up to 25s when there are none. When reset is true events were missed and the client should reload its state.
+// @Tags events
+// @Produce json
+// @Param since query int false "Cursor returned by the previous poll"
+// @Success 200 {object} dto.EventPollResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /events/poll [get]
+func (h *EventHandler) Poll(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var since *uint64
+	if raw := c.Query("since"); raw != "" {
+		value, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid since",
+				Message: "since must be a cursor returned by a previous poll",
+			})
+			return
+		}
+		since = &value
+	}
+
+	// The hold outlasts the server write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(eventPollHold + 10*time.Second))
+
+	ctx, cancel := streamContext(c)
+	defer cancel()
+
+	response, err := h.eventPollService.Poll(ctx, userID, since, eventPollHold, services.EventFilter{})
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to poll events", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Stream pushes realtime events as server-sent events
+// @Summary Stream realtime events (SSE)
+// @Description Server-sent event stream for read-only dashboards. Each event carries its sequence number as id, so reconnecting clients resume from Last-Event-ID. A "reset" event means events were missed and the client should reload its state. Admins can pass scope=all for a system-wide activity feed.
+// @Tags events
+// @Produce text/event-stream
+// @Param topics query string false "Comma-separated topics to include" example(reservations,notifications)
+// @Param scope query string false "mine (default) or all (admin only)" Enums(mine, all)
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /events/stream [get]
+func (h *EventHandler) Stream(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var filter services.EventFilter
+	if raw := c.Query("topics"); raw != "" {
+		for _, topic := range strings.Split(raw, ",") {
+			topic = strings.TrimSpace(topic)
+			switch topic {
+			case websocket.TopicReservations, websocket.TopicChat, websocket.TopicNotifications:
+				filter.Topics = append(filter.Topics, topic)
+			default:
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+					Error:   "Invalid topics",
+					Message: fmt.Sprintf("unknown topic %q, expected reservations, chat or notifications", topic),
+				})
+				return
+			}
+		}
+	}
+
+	switch c.DefaultQuery("scope", "mine") {
+	case "mine":
+	case "all":
+		// The auth middleware stores the role as models.UserRole
+		if role, _ := c.Get("user_role"); fmt.Sprint(role) != string(models.RoleAdmin) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "Access denied",
+				Message: "only admins can stream events for all users",
+			})
+			return
+		}
+		filter.AllUsers = true
+	default:
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid scope",
+			Message: "scope must be mine or all",
+		})
+		return
+	}
+
+	// EventSource sends Last-Event-ID when it reconnects
+	var cursor *uint64
+	if raw := c.GetHeader("Last-Event-ID"); raw != "" {
+		if value, err := strconv.ParseUint(raw, 10, 64); err == nil {
+			cursor = &value
+		}
+	}
+
+	// The stream stays open past the server write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", eventStreamRetry.Milliseconds())
+	c.Writer.Flush()
+
+	ctx, cancel := streamContext(c)
+	defer cancel()
+	for {
+		batch, err := h.eventPollService.Poll(ctx, userID, cursor, eventStreamHeartbeat, filter)
+		if err != nil {
+			fmt.Fprintf(c.Writer, "event: error\ndata: %q\n\n", err.Error())
+			c.Writer.Flush()
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		if batch.Reset {
+			fmt.Fprintf(c.Writer, "id: %d\nevent: reset\ndata: {}\n\n", batch.Cursor)
+		}
+		for _, event := range batch.Events {
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.Sequence, event.Event, data)
+		}
+		if !batch.Reset && len(batch.Events) == 0 {
+			fmt.Fprint(c.Writer, ": keep-alive\n\n")
+		}
+		c.Writer.Flush()
+
+		cursor = &batch.Cursor
+	}
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *EventHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
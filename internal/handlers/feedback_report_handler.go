@@ -0,0 +1,65 @@
+// internal/handlers/feedback_report_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeedbackReportHandler handles the facilities report of check-out feedback
+type FeedbackReportHandler struct {
+	reportService *services.FeedbackReportService
+}
+
+// NewFeedbackReportHandler creates a new feedback report handler
+func NewFeedbackReportHandler(reportService *services.FeedbackReportService) *FeedbackReportHandler {
+	return &FeedbackReportHandler{
+		reportService: reportService,
+	}
+}
+
+// GetReport reports the ratings and comments left when checking out (admin only)
+// @Summary Facilities feedback report
+// @Description Ratings and comments organizers left when checking out, per building and space, lowest rated first. Includes the average rating per week or month and the words most often found in the comments of ratings of 3 or less, counted once per comment.
+// @Tags reports
+// @Produce json
+// @Param days query int false "Past days covered" default(90)
+// @Param building query string false "Only spaces of this building"
+// @Param space_id query string false "Only this space" format(uuid)
+// @Param interval query string false "week or month" default(week)
+// @Success 200 {object} dto.SuccessResponse{data=dto.FeedbackReportResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/analytics/feedback [get]
+func (h *FeedbackReportHandler) GetReport(c *gin.Context) {
+	var req dto.FeedbackReportRequest
+	if err := bindQuery(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	report, err := h.reportService.GetReport(&req)
+	if err != nil {
+		respondError(c, h.determineReportErrorStatus(err), "Failed to generate feedback report", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Feedback report generated successfully",
+		Data:    report,
+	})
+}
+
+// determineReportErrorStatus determines HTTP status code based on error message
+func (h *FeedbackReportHandler) determineReportErrorStatus(err error) int {
+	if strings.HasPrefix(err.Error(), "failed to") {
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,209 @@
+// internal/handlers/floor_consolidation_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// FloorConsolidationHandler handles low-usage forecasts and floor consolidations
+type FloorConsolidationHandler struct {
+	consolidationService *services.FloorConsolidationService
+}
+
+// NewFloorConsolidationHandler creates a new floor consolidation handler
+func NewFloorConsolidationHandler(consolidationService *services.FloorConsolidationService) *FloorConsolidationHandler {
+	return &FloorConsolidationHandler{
+		consolidationService: consolidationService,
+	}
+}
+
+// GetLowUsageForecast reports booked usage per building and floor for the coming days (admin only)
+// @Summary Low-usage forecast
+// @Description Share of each floor's seat time already booked per day, flagging low-usage days and the floors that could host every booking so the others can be closed
+// @Tags reports
+// @Produce json
+// @Param from query string false "First day (YYYY-MM-DD), defaults to today"
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to 13 days after from"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/reports/low-usage [get]
+func (h *FloorConsolidationHandler) GetLowUsageForecast(c *gin.Context) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid from date",
+				Message: "from must use the YYYY-MM-DD format",
+			})
+			return
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(0, 0, 13)
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid to date",
+				Message: "to must use the YYYY-MM-DD format",
+			})
+			return
+		}
+		to = parsed
+	}
+
+	forecast, err := h.consolidationService.GetLowUsageForecast(from, to)
+	if err != nil {
+		respondError(c, h.determineConsolidationErrorStatus(err), "Failed to generate low-usage forecast", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Low-usage forecast generated successfully",
+		Data:    forecast,
+	})
+}
+
+// CreateConsolidation keeps only some floors of a building open for a range of days (admin only)
+// @Summary Start floor consolidation
+// @Description Steer new bookings in a building onto the open floors for the given days. Bookings on other floors are rejected with SPACE_FLOOR_CLOSED and left out of recommendations and find-time suggestions; existing bookings are kept and counted in affected_reservations.
+// @Tags floor-consolidations
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateFloorConsolidationRequest true "Consolidation"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/floor-consolidations [post]
+func (h *FloorConsolidationHandler) CreateConsolidation(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateFloorConsolidationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	consolidation, err := h.consolidationService.CreateConsolidation(&req, userID)
+	if err != nil {
+		respondError(c, h.determineConsolidationErrorStatus(err), "Failed to create floor consolidation", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Floor consolidation created successfully",
+		Data:    consolidation,
+	})
+}
+
+// GetConsolidations lists running and planned floor consolidations (admin only)
+// @Summary List floor consolidations
+// @Description Floor consolidations that are running or planned
+// @Tags floor-consolidations
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /admin/floor-consolidations [get]
+func (h *FloorConsolidationHandler) GetConsolidations(c *gin.Context) {
+	consolidations, err := h.consolidationService.GetConsolidations()
+	if err != nil {
+		respondError(c, h.determineConsolidationErrorStatus(err), "Failed to get floor consolidations", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Floor consolidations retrieved successfully",
+		Data:    consolidations,
+	})
+}
+
+// EndConsolidation reopens every floor of the building (admin only)
+// @Summary End floor consolidation
+// @Description End a floor consolidation now, before its end date
+// @Tags floor-consolidations
+// @Produce json
+// @Param id path string true "Consolidation ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/floor-consolidations/{id}/end [post]
+func (h *FloorConsolidationHandler) EndConsolidation(c *gin.Context) {
+	consolidationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid consolidation ID",
+			Message: "Consolidation ID must be a valid UUID",
+		})
+		return
+	}
+
+	consolidation, err := h.consolidationService.EndConsolidation(consolidationID)
+	if err != nil {
+		respondError(c, h.determineConsolidationErrorStatus(err), "Failed to end floor consolidation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Floor consolidation ended successfully",
+		Data:    consolidation,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *FloorConsolidationHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineConsolidationErrorStatus determines HTTP status code based on error message
+func (h *FloorConsolidationHandler) determineConsolidationErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	case strings.Contains(err.Error(), "already"):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
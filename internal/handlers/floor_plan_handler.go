@@ -0,0 +1,422 @@
+// internal/handlers/floor_plan_handler.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// FloorPlanHandler handles floor plans, the desk seat picker and team neighborhoods
+type FloorPlanHandler struct {
+	floorPlanService *services.FloorPlanService
+}
+
+// NewFloorPlanHandler creates a new floor plan handler
+func NewFloorPlanHandler(floorPlanService *services.FloorPlanService) *FloorPlanHandler {
+	return &FloorPlanHandler{
+		floorPlanService: floorPlanService,
+	}
+}
+
+// ========================================
+// USER ENDPOINTS
+// ========================================
+
+// GetPlans lists the floor plans
+// @Summary List floor plans
+// @Description Floor plans without their drawings, optionally of one building
+// @Tags floor-plans
+// @Produce json
+// @Param building query string false "Building"
+// @Success 200 {object} dto.SuccessResponse
+// @Router /floor-plans [get]
+func (h *FloorPlanHandler) GetPlans(c *gin.Context) {
+	plans, err := h.floorPlanService.GetPlans(strings.TrimSpace(c.Query("building")))
+	if err != nil {
+		respondError(c, h.determineFloorPlanErrorStatus(err), "Failed to get floor plans", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Floor plans retrieved successfully",
+		Data:    plans,
+	})
+}
+
+// GetFloorMap returns a floor plan with the live availability of its desks
+// @Summary Get floor map
+// @Description Floor plan drawing with each desk's position and status for the range: free, booked, unavailable (out of service or floor closed) or restricted (in another team's neighborhood)
+// @Tags floor-plans
+// @Produce json
+// @Param id path string true "Floor plan ID" format(uuid)
+// @Param start_time query string false "Start of the range (RFC3339), defaults to now"
+// @Param end_time query string false "End of the range (RFC3339), defaults to one hour after start_time"
+// @Success 200 {object} dto.SuccessResponse{data=dto.FloorMapResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /floor-plans/{id}/map [get]
+func (h *FloorPlanHandler) GetFloorMap(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	planID, ok := h.parsePlanID(c)
+	if !ok {
+		return
+	}
+
+	startTime := time.Now()
+	if value := c.Query("start_time"); value != "" {
+		if startTime, err = time.Parse(time.RFC3339, value); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid start_time",
+				Message: "start_time must be in RFC3339 format (e.g., 2023-12-25T10:00:00Z)",
+			})
+			return
+		}
+	}
+	endTime := startTime.Add(time.Hour)
+	if value := c.Query("end_time"); value != "" {
+		if endTime, err = time.Parse(time.RFC3339, value); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid end_time",
+				Message: "end_time must be in RFC3339 format (e.g., 2023-12-25T12:00:00Z)",
+			})
+			return
+		}
+	}
+
+	floorMap, err := h.floorPlanService.GetFloorMap(planID, startTime, endTime, userID)
+	if err != nil {
+		respondError(c, h.determineFloorPlanErrorStatus(err), "Failed to get floor map", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Floor map retrieved successfully",
+		Data:    floorMap,
+	})
+}
+
+// PickSeat books the desk at the clicked position of a floor plan
+// @Summary Pick a desk on the floor plan
+// @Description Book the desk nearest to the clicked coordinates (in the plan's units) for the time range. Desks in another team's neighborhood are rejected with SPACE_NEIGHBORHOOD_RESTRICTED.
+// @Tags floor-plans
+// @Accept json
+// @Produce json
+// @Param id path string true "Floor plan ID" format(uuid)
+// @Param request body dto.PickSeatRequest true "Clicked position and time range"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /floor-plans/{id}/pick [post]
+func (h *FloorPlanHandler) PickSeat(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	planID, ok := h.parsePlanID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.PickSeatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	reservation, err := h.floorPlanService.PickSeat(planID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineFloorPlanErrorStatus(err), "Failed to book desk", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Desk booked successfully",
+		Data:    reservation,
+	})
+}
+
+// ========================================
+// ADMIN ENDPOINTS
+// ========================================
+
+// SavePlan uploads the plan of a floor (admin only)
+// @Summary Save floor plan
+// @Description Create the plan of a building floor from inline SVG or an image URL, or replace the drawing of the existing plan while keeping its seats
+// @Tags floor-plans
+// @Accept json
+// @Produce json
+// @Param request body dto.SaveFloorPlanRequest true "Floor plan"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/floor-plans [post]
+func (h *FloorPlanHandler) SavePlan(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SaveFloorPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	plan, err := h.floorPlanService.SavePlan(&req, userID)
+	if err != nil {
+		respondError(c, h.determineFloorPlanErrorStatus(err), "Failed to save floor plan", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Floor plan saved successfully",
+		Data:    plan,
+	})
+}
+
+// GetPlan retrieves a floor plan with its seats and neighborhoods (admin only)
+// @Summary Get floor plan
+// @Description Floor plan with its drawing, seats and neighborhoods
+// @Tags floor-plans
+// @Produce json
+// @Param id path string true "Floor plan ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/floor-plans/{id} [get]
+func (h *FloorPlanHandler) GetPlan(c *gin.Context) {
+	planID, ok := h.parsePlanID(c)
+	if !ok {
+		return
+	}
+
+	plan, err := h.floorPlanService.GetPlan(planID)
+	if err != nil {
+		respondError(c, h.determineFloorPlanErrorStatus(err), "Failed to get floor plan", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Floor plan retrieved successfully",
+		Data:    plan,
+	})
+}
+
+// DeletePlan removes a floor plan (admin only)
+// @Summary Delete floor plan
+// @Description Remove a floor plan with its seats and neighborhoods; the desks stay bookable
+// @Tags floor-plans
+// @Produce json
+// @Param id path string true "Floor plan ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/floor-plans/{id} [delete]
+func (h *FloorPlanHandler) DeletePlan(c *gin.Context) {
+	planID, ok := h.parsePlanID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.floorPlanService.DeletePlan(planID); err != nil {
+		respondError(c, h.determineFloorPlanErrorStatus(err), "Failed to delete floor plan", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Floor plan deleted successfully",
+	})
+}
+
+// SetSeats places the desks of a floor plan (admin only)
+// @Summary Set floor plan seats
+// @Description Replace the desks placed on the plan with their coordinates and neighborhood
+// @Tags floor-plans
+// @Accept json
+// @Produce json
+// @Param id path string true "Floor plan ID" format(uuid)
+// @Param request body dto.SetFloorPlanSeatsRequest true "Seats"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/floor-plans/{id}/seats [put]
+func (h *FloorPlanHandler) SetSeats(c *gin.Context) {
+	planID, ok := h.parsePlanID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SetFloorPlanSeatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	plan, err := h.floorPlanService.SetSeats(planID, &req)
+	if err != nil {
+		respondError(c, h.determineFloorPlanErrorStatus(err), "Failed to set seats", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Seats saved successfully",
+		Data:    plan,
+	})
+}
+
+// CreateNeighborhood adds a team zone to a floor plan (admin only)
+// @Summary Create neighborhood
+// @Description Add a zone whose desks only the listed departments may book; admins may book any desk
+// @Tags floor-plans
+// @Accept json
+// @Produce json
+// @Param id path string true "Floor plan ID" format(uuid)
+// @Param request body dto.CreateNeighborhoodRequest true "Neighborhood"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/floor-plans/{id}/neighborhoods [post]
+func (h *FloorPlanHandler) CreateNeighborhood(c *gin.Context) {
+	planID, ok := h.parsePlanID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateNeighborhoodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	neighborhood, err := h.floorPlanService.CreateNeighborhood(planID, &req)
+	if err != nil {
+		respondError(c, h.determineFloorPlanErrorStatus(err), "Failed to create neighborhood", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Neighborhood created successfully",
+		Data:    neighborhood,
+	})
+}
+
+// DeleteNeighborhood removes a team zone from a floor plan (admin only)
+// @Summary Delete neighborhood
+// @Description Remove a neighborhood; its desks stay on the plan, open to everyone
+// @Tags floor-plans
+// @Produce json
+// @Param id path string true "Floor plan ID" format(uuid)
+// @Param neighborhoodId path string true "Neighborhood ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/floor-plans/{id}/neighborhoods/{neighborhoodId} [delete]
+func (h *FloorPlanHandler) DeleteNeighborhood(c *gin.Context) {
+	planID, ok := h.parsePlanID(c)
+	if !ok {
+		return
+	}
+
+	neighborhoodID, err := uuid.Parse(c.Param("neighborhoodId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid neighborhood ID",
+			Message: "Neighborhood ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.floorPlanService.DeleteNeighborhood(planID, neighborhoodID); err != nil {
+		respondError(c, h.determineFloorPlanErrorStatus(err), "Failed to delete neighborhood", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Neighborhood deleted successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// parsePlanID parses the floor plan ID path parameter, responding with an error when invalid
+func (h *FloorPlanHandler) parsePlanID(c *gin.Context) (uuid.UUID, bool) {
+	planID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid floor plan ID",
+			Message: "Floor plan ID must be a valid UUID",
+		})
+		return uuid.Nil, false
+	}
+	return planID, true
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *FloorPlanHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineFloorPlanErrorStatus determines HTTP status code based on error code or message
+func (h *FloorPlanHandler) determineFloorPlanErrorStatus(err error) int {
+	var coded *dto.CodedError
+	if errors.As(err, &coded) {
+		switch coded.Code {
+		case dto.ErrCodeSpaceNeighborhood:
+			return http.StatusForbidden
+		case dto.ErrCodeResTimeConflict, dto.ErrCodeResVIPReserved, dto.ErrCodeResUserOverlap,
+			dto.ErrCodeSpaceUnavailable, dto.ErrCodeSpaceFloorClosed, dto.ErrCodeSpaceClosed, dto.ErrCodeResPublicHoliday, dto.ErrCodeResNonWorkingDay:
+			return http.StatusConflict
+		}
+	}
+
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	case strings.Contains(err.Error(), "duplicate key"):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,50 @@
+// internal/handlers/health_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler serves the liveness and readiness probes
+type HealthHandler struct {
+	healthService *services.HealthService
+}
+
+// NewHealthHandler creates a new health handler
+func NewHealthHandler(healthService *services.HealthService) *HealthHandler {
+	return &HealthHandler{
+		healthService: healthService,
+	}
+}
+
+// Live reports the process is up
+// @Summary Liveness probe
+// @Description Answers as long as the process serves HTTP; no dependency is checked, so an outage does not get the instance restarted
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.HealthReport
+// @Router /health/live [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, h.healthService.Live())
+}
+
+// Ready reports whether the instance can take traffic
+// @Summary Readiness probe
+// @Description Checks the database, read replica, Redis, upload storage and WebSocket manager, with the state of integration breakers. Only the database and replica make the instance not ready; the others report it degraded.
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.HealthReport
+// @Failure 503 {object} dto.HealthReport
+// @Router /health/ready [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report, ready := h.healthService.Ready(c.Request.Context())
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
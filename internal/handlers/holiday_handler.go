@@ -0,0 +1,55 @@
+// internal/handlers/holiday_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HolidayHandler handles the public holiday calendar
+type HolidayHandler struct {
+	holidayCalendar *services.HolidayCalendar
+}
+
+// NewHolidayHandler creates a new holiday handler
+func NewHolidayHandler(holidayCalendar *services.HolidayCalendar) *HolidayHandler {
+	return &HolidayHandler{
+		holidayCalendar: holidayCalendar,
+	}
+}
+
+// GetHolidays lists the public holidays of a year
+// @Summary List public holidays
+// @Description Public holidays of the organization's country and region, from the holiday API or the embedded dataset. Bookings are closed on these days for everyone but admins, and recurring bookings and find-time skip them.
+// @Tags holidays
+// @Produce json
+// @Param year query int false "Year, defaults to the current year"
+// @Success 200 {object} dto.SuccessResponse{data=dto.HolidayCalendarResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /holidays [get]
+func (h *HolidayHandler) GetHolidays(c *gin.Context) {
+	year := time.Now().Year()
+	if value := c.Query("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1900 || parsed > 2200 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid year",
+				Message: "year must be a number between 1900 and 2200",
+			})
+			return
+		}
+		year = parsed
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Holidays retrieved successfully",
+		Data:    h.holidayCalendar.Calendar(year),
+	})
+}
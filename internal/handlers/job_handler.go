@@ -0,0 +1,68 @@
+// internal/handlers/job_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/jobs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobHandler exposes background job status to administrators
+type JobHandler struct {
+	scheduler *jobs.Scheduler
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(scheduler *jobs.Scheduler) *JobHandler {
+	return &JobHandler{
+		scheduler: scheduler,
+	}
+}
+
+// GetJobs lists scheduled jobs and their last run
+// @Summary List background jobs
+// @Tags admin
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /admin/jobs [get]
+func (h *JobHandler) GetJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Jobs retrieved successfully",
+		Data:    h.scheduler.Status(),
+	})
+}
+
+// RunJob triggers a job immediately
+// @Summary Run background job now
+// @Tags admin
+// @Produce json
+// @Param name path string true "Job name"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/jobs/{name}/run [post]
+func (h *JobHandler) RunJob(c *gin.Context) {
+	name := c.Param("name")
+
+	found, err := h.scheduler.RunNow(c.Request.Context(), name)
+	if !found {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Job not found",
+			Message: "No job registered with name " + name,
+		})
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Job failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Job " + name + " completed",
+	})
+}
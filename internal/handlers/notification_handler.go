@@ -0,0 +1,153 @@
+// internal/handlers/notification_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// NotificationHandler handles the in-app notification inbox
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetMyNotifications lists the current user's notifications
+// @Summary Get my notifications
+// @Description List notifications of the authenticated user, newest first
+// @Tags notifications
+// @Produce json
+// @Param unread query bool false "Only unread notifications"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /notifications [get]
+func (h *NotificationHandler) GetMyNotifications(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	page := utils.GetIntQuery(c, "page", 1)
+	limit := utils.GetIntQuery(c, "limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	notifications, total, err := h.notificationService.GetUserNotifications(userID, utils.GetBoolQuery(c, "unread", false), (page-1)*limit, limit)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get notifications", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(notifications, total, page, limit))
+}
+
+// GetUnreadCount returns the number of unread notifications
+// @Summary Get unread notification count
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /notifications/unread-count [get]
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	count, err := h.notificationService.CountUnread(userID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to count notifications", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Unread count retrieved successfully",
+		Data:    gin.H{"unread_count": count},
+	})
+}
+
+// MarkAsRead marks a notification as read
+// @Summary Mark notification as read
+// @Tags notifications
+// @Produce json
+// @Param id path string true "Notification ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
+	notificationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid notification ID",
+			Message: "Notification ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	if err := h.notificationService.MarkAsRead(notificationID, userID); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case err.Error() == "access denied":
+			status = http.StatusForbidden
+		case err.Error() == "failed to get notification: record not found":
+			status = http.StatusNotFound
+		}
+		respondError(c, status, "Failed to mark notification as read", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Notification marked as read",
+	})
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *NotificationHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
@@ -0,0 +1,275 @@
+// internal/handlers/occupancy_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// OccupancyHandler handles occupancy sensor ingestion, live occupancy and sensor registration
+type OccupancyHandler struct {
+	occupancyService *services.OccupancyService
+}
+
+// NewOccupancyHandler creates a new occupancy handler
+func NewOccupancyHandler(occupancyService *services.OccupancyService) *OccupancyHandler {
+	return &OccupancyHandler{
+		occupancyService: occupancyService,
+	}
+}
+
+// ========================================
+// SENSOR ENDPOINTS (API key)
+// ========================================
+
+// RecordOccupancy stores a headcount reported by a sensor
+// @Summary Report occupancy
+// @Description Ingest the headcount counted by an IoT sensor in its space. Meetings in rooms that stay empty past the configured threshold are released automatically.
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param X-Sensor-Key header string true "Sensor API key"
+// @Param request body dto.OccupancySampleRequest true "Headcount"
+// @Success 202 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /integrations/occupancy [post]
+func (h *OccupancyHandler) RecordOccupancy(c *gin.Context) {
+	sensor := c.MustGet("occupancy_sensor").(*models.OccupancySensor)
+
+	var req dto.OccupancySampleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	if err := h.occupancyService.RecordSample(sensor, &req); err != nil {
+		respondError(c, h.determineOccupancyErrorStatus(err), "Failed to record occupancy", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.SuccessResponse{
+		Success: true,
+		Message: "Occupancy recorded",
+	})
+}
+
+// ========================================
+// OCCUPANCY ENDPOINTS
+// ========================================
+
+// GetSpaceOccupancy returns the live headcount of a space and its samples
+// @Summary Space occupancy
+// @Description Live headcount reported by the space's sensors and the samples in a time range (default the last 24 hours, at most 7 days)
+// @Tags spaces
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Param from query string false "Range start (RFC3339)"
+// @Param to query string false "Range end (RFC3339)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /spaces/{id}/occupancy [get]
+func (h *OccupancyHandler) GetSpaceOccupancy(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	to := time.Now()
+	if value := c.Query("to"); value != "" {
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid to",
+				Message: "to must be in RFC3339 format (e.g., 2023-12-25T12:00:00Z)",
+			})
+			return
+		}
+	}
+	from := to.Add(-24 * time.Hour)
+	if value := c.Query("from"); value != "" {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid from",
+				Message: "from must be in RFC3339 format (e.g., 2023-12-25T10:00:00Z)",
+			})
+			return
+		}
+	}
+
+	occupancy, err := h.occupancyService.GetSpaceOccupancy(spaceID, from, to)
+	if err != nil {
+		respondError(c, h.determineOccupancyErrorStatus(err), "Failed to get occupancy", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Occupancy retrieved successfully",
+		Data:    occupancy,
+	})
+}
+
+// ========================================
+// REGISTRATION ENDPOINTS (admin)
+// ========================================
+
+// CreateSensor registers an occupancy sensor for a space (admin only)
+// @Summary Register occupancy sensor
+// @Description Create an API key for a headcount sensor installed in the space; the key is only shown once
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Param request body dto.CreateOccupancySensorRequest true "Sensor"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/sensors [post]
+func (h *OccupancyHandler) CreateSensor(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateOccupancySensorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	sensor, err := h.occupancyService.CreateSensor(spaceID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineOccupancyErrorStatus(err), "Failed to register sensor", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Sensor registered successfully, store the API key now",
+		Data:    sensor,
+	})
+}
+
+// GetSpaceSensors lists the occupancy sensors of a space (admin only)
+// @Summary List occupancy sensors
+// @Description Get the sensors registered for a space, including revoked ones
+// @Tags integrations
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/sensors [get]
+func (h *OccupancyHandler) GetSpaceSensors(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	sensors, err := h.occupancyService.GetSpaceSensors(spaceID)
+	if err != nil {
+		respondError(c, h.determineOccupancyErrorStatus(err), "Failed to get sensors", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Sensors retrieved successfully",
+		Data:    sensors,
+	})
+}
+
+// RevokeSensor disables an occupancy sensor key (admin only)
+// @Summary Revoke occupancy sensor
+// @Description Revoke a sensor API key, e.g. when a device is removed or replaced
+// @Tags integrations
+// @Produce json
+// @Param id path string true "Sensor ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/sensors/{id} [delete]
+func (h *OccupancyHandler) RevokeSensor(c *gin.Context) {
+	sensorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid sensor ID",
+			Message: "Sensor ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.occupancyService.RevokeSensor(sensorID); err != nil {
+		respondError(c, h.determineOccupancyErrorStatus(err), "Failed to revoke sensor", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Sensor revoked successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *OccupancyHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineOccupancyErrorStatus determines HTTP status code based on error message
+func (h *OccupancyHandler) determineOccupancyErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,20 @@
+// internal/handlers/pagination.go
+package handlers
+
+import (
+	"room-reservation-api/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cursorQuery reads ?cursor=. A list switches from page and limit to cursor pagination when
+// the parameter is given, left empty for the first page.
+func cursorQuery(c *gin.Context) (*dto.Cursor, bool, error) {
+	value, ok := c.GetQuery("cursor")
+	if !ok {
+		return nil, false, nil
+	}
+
+	cursor, err := dto.DecodeCursor(value)
+	return cursor, true, err
+}
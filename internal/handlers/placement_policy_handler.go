@@ -0,0 +1,141 @@
+// internal/handlers/placement_policy_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PlacementPolicyHandler handles the per-building placement strategies
+type PlacementPolicyHandler struct {
+	placementService *services.PlacementPolicyService
+}
+
+// NewPlacementPolicyHandler creates a new placement policy handler
+func NewPlacementPolicyHandler(placementService *services.PlacementPolicyService) *PlacementPolicyHandler {
+	return &PlacementPolicyHandler{
+		placementService: placementService,
+	}
+}
+
+// GetPolicies lists the placement strategy of every building (admin only)
+// @Summary List placement policies
+// @Description Strategy each building uses to place bookings made without a space_id, and the default for buildings without a policy
+// @Tags placement-policies
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /admin/placement-policies [get]
+func (h *PlacementPolicyHandler) GetPolicies(c *gin.Context) {
+	policies, err := h.placementService.GetPolicies()
+	if err != nil {
+		respondError(c, h.determinePlacementErrorStatus(err), "Failed to get placement policies", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Placement policies retrieved successfully",
+		Data:    policies,
+	})
+}
+
+// SetPolicy sets the placement strategy of a building (admin only)
+// @Summary Set placement policy
+// @Description Choose how bookings for "any suitable room" are placed among equivalent free spaces: smallest_fit (smallest space, preferred floor first), fill_floors (lowest floor first), balance_wear (least used over 30 days first) or low_energy (best energy rating first)
+// @Tags placement-policies
+// @Accept json
+// @Produce json
+// @Param building path string true "Building"
+// @Param request body dto.SetPlacementPolicyRequest true "Strategy"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/placement-policies/{building} [put]
+func (h *PlacementPolicyHandler) SetPolicy(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SetPlacementPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	policy, err := h.placementService.SetPolicy(strings.TrimSpace(c.Param("building")), &req, userID)
+	if err != nil {
+		respondError(c, h.determinePlacementErrorStatus(err), "Failed to set placement policy", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Placement policy saved successfully",
+		Data:    policy,
+	})
+}
+
+// DeletePolicy makes a building use the default placement strategy again (admin only)
+// @Summary Delete placement policy
+// @Description Remove the building's own strategy so it uses the default one
+// @Tags placement-policies
+// @Produce json
+// @Param building path string true "Building"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/placement-policies/{building} [delete]
+func (h *PlacementPolicyHandler) DeletePolicy(c *gin.Context) {
+	if err := h.placementService.DeletePolicy(strings.TrimSpace(c.Param("building"))); err != nil {
+		respondError(c, h.determinePlacementErrorStatus(err), "Failed to delete placement policy", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Placement policy deleted successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *PlacementPolicyHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determinePlacementErrorStatus determines HTTP status code based on error message
+func (h *PlacementPolicyHandler) determinePlacementErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,66 @@
+// internal/handlers/public_availability_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PublicAvailabilityHandler serves the anonymous free/busy view of spaces
+type PublicAvailabilityHandler struct {
+	publicAvailabilityService *services.PublicAvailabilityService
+}
+
+// NewPublicAvailabilityHandler creates a new public availability handler
+func NewPublicAvailabilityHandler(publicAvailabilityService *services.PublicAvailabilityService) *PublicAvailabilityHandler {
+	return &PublicAvailabilityHandler{
+		publicAvailabilityService: publicAvailabilityService,
+	}
+}
+
+// GetAvailability returns when public spaces are busy during a day
+// @Summary Public free/busy view
+// @Description Busy windows of the spaces open to everyone for one day, buffer times included, for lobby screens and public booking portals. No sign-in is needed and nothing about who booked a space or why is shown. Responses are cached.
+// @Tags public
+// @Produce json
+// @Param date query string false "Day as YYYY-MM-DD, up to 14 days ahead; today by default"
+// @Param building query string false "Only spaces in this building"
+// @Param type query string false "Only spaces of this type"
+// @Param timezone query string false "IANA timezone the day is read in; UTC by default"
+// @Success 200 {object} dto.PublicAvailabilityResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /public/spaces/availability [get]
+func (h *PublicAvailabilityHandler) GetAvailability(c *gin.Context) {
+	var req dto.PublicAvailabilityRequest
+	if err := bindQuery(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	view, err := h.publicAvailabilityService.GetAvailability(&req)
+	if err != nil {
+		respondError(c, h.determinePublicAvailabilityErrorStatus(err), "Failed to get availability", err)
+		return
+	}
+
+	if ttl := h.publicAvailabilityService.CacheTTL(); ttl > 0 {
+		c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(ttl.Seconds())))
+	}
+	c.JSON(http.StatusOK, view)
+}
+
+// determinePublicAvailabilityErrorStatus determines HTTP status code based on error message
+func (h *PublicAvailabilityHandler) determinePublicAvailabilityErrorStatus(err error) int {
+	switch {
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,397 @@
+// internal/handlers/quick_book_handler.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// QuickBookHandler handles favorite spaces and booking templates
+type QuickBookHandler struct {
+	quickBookService *services.QuickBookService
+}
+
+// NewQuickBookHandler creates a new favorite space and booking template handler
+func NewQuickBookHandler(quickBookService *services.QuickBookService) *QuickBookHandler {
+	return &QuickBookHandler{
+		quickBookService: quickBookService,
+	}
+}
+
+// ========================================
+// FAVORITE SPACES
+// ========================================
+
+// GetFavorites lists the favorite spaces of the current user
+// @Summary List favorite spaces
+// @Description Spaces the user marked as favorites, most recently added first
+// @Tags spaces
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /spaces/favorites [get]
+func (h *QuickBookHandler) GetFavorites(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	favorites, err := h.quickBookService.GetFavorites(userID)
+	if err != nil {
+		respondError(c, h.determineQuickBookErrorStatus(err), "Failed to get favorite spaces", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Favorite spaces retrieved successfully",
+		Data:    favorites,
+	})
+}
+
+// AddFavorite marks a space as a favorite of the current user
+// @Summary Add favorite space
+// @Description Mark a space as a favorite; marking it again changes nothing
+// @Tags spaces
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /spaces/favorites/{id} [put]
+func (h *QuickBookHandler) AddFavorite(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.quickBookService.AddFavorite(userID, spaceID); err != nil {
+		respondError(c, h.determineQuickBookErrorStatus(err), "Failed to add favorite space", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Space added to favorites",
+	})
+}
+
+// RemoveFavorite unmarks a favorite space of the current user
+// @Summary Remove favorite space
+// @Description Remove a space from the user's favorites
+// @Tags spaces
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /spaces/favorites/{id} [delete]
+func (h *QuickBookHandler) RemoveFavorite(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.quickBookService.RemoveFavorite(userID, spaceID); err != nil {
+		respondError(c, h.determineQuickBookErrorStatus(err), "Failed to remove favorite space", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Space removed from favorites",
+	})
+}
+
+// ========================================
+// BOOKING TEMPLATES
+// ========================================
+
+// GetTemplates lists the booking templates of the current user
+// @Summary List booking templates
+// @Description Booking templates of the user by name
+// @Tags reservations
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /reservations/templates [get]
+func (h *QuickBookHandler) GetTemplates(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	templates, err := h.quickBookService.GetTemplates(userID)
+	if err != nil {
+		respondError(c, h.determineQuickBookErrorStatus(err), "Failed to get booking templates", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Booking templates retrieved successfully",
+		Data:    templates,
+	})
+}
+
+// CreateTemplate saves a booking template for the current user
+// @Summary Create booking template
+// @Description Save the title, duration, participants and preferred space of a reservation made often, to book it again with only a start time
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param request body dto.BookingTemplateRequest true "Booking template"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/templates [post]
+func (h *QuickBookHandler) CreateTemplate(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.BookingTemplateRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	template, err := h.quickBookService.CreateTemplate(&req, userID)
+	if err != nil {
+		respondError(c, h.determineQuickBookErrorStatus(err), "Failed to create booking template", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Booking template created successfully",
+		Data:    template,
+	})
+}
+
+// UpdateTemplate replaces a booking template of the current user
+// @Summary Update booking template
+// @Description Replace every field of a booking template
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID" format(uuid)
+// @Param request body dto.BookingTemplateRequest true "Booking template"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/templates/{id} [put]
+func (h *QuickBookHandler) UpdateTemplate(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid template ID",
+			Message: "Template ID must be a valid UUID",
+		})
+		return
+	}
+
+	var req dto.BookingTemplateRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	template, err := h.quickBookService.UpdateTemplate(templateID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineQuickBookErrorStatus(err), "Failed to update booking template", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Booking template updated successfully",
+		Data:    template,
+	})
+}
+
+// DeleteTemplate removes a booking template of the current user
+// @Summary Delete booking template
+// @Description Delete a booking template; reservations made from it are kept
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Template ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/templates/{id} [delete]
+func (h *QuickBookHandler) DeleteTemplate(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid template ID",
+			Message: "Template ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.quickBookService.DeleteTemplate(templateID, userID); err != nil {
+		respondError(c, h.determineQuickBookErrorStatus(err), "Failed to delete booking template", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Booking template deleted successfully",
+	})
+}
+
+// BookFromTemplate books a booking template at a start time
+// @Summary Book from template
+// @Description Create a reservation from a booking template with one call: only the start time is needed, and the space, title and participants can be changed for this reservation. The reservation goes through the same checks and approvals as any other.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID" format(uuid)
+// @Param request body dto.BookFromTemplateRequest true "Start time and changes"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /reservations/templates/{id}/book [post]
+func (h *QuickBookHandler) BookFromTemplate(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid template ID",
+			Message: "Template ID must be a valid UUID",
+		})
+		return
+	}
+
+	var req dto.BookFromTemplateRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	reservation, err := h.quickBookService.BookFromTemplate(templateID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineQuickBookErrorStatus(err), "Failed to book from template", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Reservation created from template",
+		Data:    reservation,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *QuickBookHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineQuickBookErrorStatus determines HTTP status code based on error message
+func (h *QuickBookHandler) determineQuickBookErrorStatus(err error) int {
+	var fields dto.ValidationErrors
+	if errors.As(err, &fields) {
+		return http.StatusBadRequest
+	}
+
+	var coded *dto.CodedError
+	if errors.As(err, &coded) {
+		switch coded.Code {
+		case dto.ErrCodeSpaceNeighborhood, dto.ErrCodeSpacePilot, dto.ErrCodeResStrikeRestricted:
+			return http.StatusForbidden
+		case dto.ErrCodeResInPast, dto.ErrCodeResAdvanceNotice, dto.ErrCodeResDurationExceeded, dto.ErrCodeSpaceCapacityExceeded:
+			return http.StatusBadRequest
+		default:
+			return http.StatusConflict
+		}
+	}
+
+	switch {
+	case err.Error() == "access denied":
+		return http.StatusForbidden
+	case strings.Contains(err.Error(), "record not found"),
+		err.Error() == "space is not a favorite":
+		return http.StatusNotFound
+	case err.Error() == "time slot is not available",
+		err.Error() == "time slot is reserved for VIP use",
+		err.Error() == "overlapping reservation exists for this user",
+		err.Error() == "space is not available for booking",
+		err.Error() == "no suitable space is free in the building for this time":
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
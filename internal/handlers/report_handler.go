@@ -0,0 +1,157 @@
+// internal/handlers/report_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReportHandler handles the custom report builder
+type ReportHandler struct {
+	reportService *services.ReportService
+}
+
+// NewReportHandler creates a new custom report handler
+func NewReportHandler(reportService *services.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+// GetCatalog lists what custom reports can be built from (admin only)
+// @Summary Report catalog
+// @Description Sources custom reports read, with the dimensions, measures and filter fields of each, and the date grains and filter operators
+// @Tags reports
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /admin/reports/catalog [get]
+func (h *ReportHandler) GetCatalog(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Report catalog retrieved successfully",
+		Data:    h.reportService.GetCatalog(),
+	})
+}
+
+// CreateReport runs a custom report (admin only)
+// @Summary Run custom report
+// @Description Aggregate the measures of a source per dimension and, with a date grain, per period. Reports over a long or open-ended range of reservations, hourly reports over more than a month and reports with async set are queued: the response is then 202 with a run to poll.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param request body dto.ReportDefinition true "Report definition"
+// @Success 200 {object} dto.SuccessResponse
+// @Success 202 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/reports [post]
+func (h *ReportHandler) CreateReport(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.ReportDefinition
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid report definition", err)
+		return
+	}
+
+	result, run, err := h.reportService.CreateReport(c.Request.Context(), &req, userID)
+	if err != nil {
+		respondError(c, h.determineReportErrorStatus(err), "Failed to run report", err)
+		return
+	}
+
+	if run != nil {
+		c.JSON(http.StatusAccepted, dto.SuccessResponse{
+			Success: true,
+			Message: "Report queued, poll its run for the result",
+			Data:    run,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Report generated successfully",
+		Data:    result,
+	})
+}
+
+// GetRun returns a queued custom report (admin only)
+// @Summary Get report run
+// @Description Status of a queued report, with its result once completed
+// @Tags reports
+// @Produce json
+// @Param id path string true "Report run ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/reports/runs/{id} [get]
+func (h *ReportHandler) GetRun(c *gin.Context) {
+	runID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid report run ID",
+			Message: "Report run ID must be a valid UUID",
+		})
+		return
+	}
+
+	run, err := h.reportService.GetRun(runID)
+	if err != nil {
+		respondError(c, h.determineReportErrorStatus(err), "Failed to get report run", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Report run retrieved successfully",
+		Data:    run,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *ReportHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineReportErrorStatus determines HTTP status code based on error message
+func (h *ReportHandler) determineReportErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
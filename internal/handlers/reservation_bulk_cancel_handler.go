@@ -0,0 +1,135 @@
+// internal/handlers/reservation_bulk_cancel_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReservationBulkCancelHandler handles bulk cancellation endpoints
+type ReservationBulkCancelHandler struct {
+	bulkCancelService *services.ReservationBulkCancelService
+}
+
+// NewReservationBulkCancelHandler creates a new bulk cancellation handler
+func NewReservationBulkCancelHandler(bulkCancelService *services.ReservationBulkCancelService) *ReservationBulkCancelHandler {
+	return &ReservationBulkCancelHandler{
+		bulkCancelService: bulkCancelService,
+	}
+}
+
+// BulkCancel cancels every reservation matching the filters (admin only)
+// @Summary Bulk-cancel reservations
+// @Description Cancel pending and confirmed reservations by space, user, date range and status. Send dry_run first to get the match count, then send the same filters with expected_count set to it. Owners are notified with the reason and a CSV report is kept.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param request body dto.BulkCancelRequest true "Filters and reason"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/reservations/bulk-cancel [post]
+func (h *ReservationBulkCancelHandler) BulkCancel(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.BulkCancelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	result, err := h.bulkCancelService.BulkCancel(&req, userID)
+	if err != nil {
+		respondError(c, h.determineBulkCancelErrorStatus(err), "Failed to cancel reservations", err)
+		return
+	}
+
+	message := fmt.Sprintf("%d reservation(s) cancelled, %d failed", result.Cancelled, result.Failed)
+	if result.DryRun {
+		message = fmt.Sprintf("%d reservation(s) would be cancelled", result.Matched)
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: message,
+		Data:    result,
+	})
+}
+
+// GetReport downloads the outcome of a bulk cancellation (admin only)
+// @Summary Bulk cancellation report
+// @Description CSV listing each matched reservation with its owner and whether it was cancelled
+// @Tags reservations
+// @Produce text/csv
+// @Param id path string true "Bulk cancellation ID" format(uuid)
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/reservations/bulk-cancel/{id}/report [get]
+func (h *ReservationBulkCancelHandler) GetReport(c *gin.Context) {
+	bulkCancelID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid bulk cancellation ID",
+			Message: "Bulk cancellation ID must be a valid UUID",
+		})
+		return
+	}
+
+	report, err := h.bulkCancelService.GetReport(bulkCancelID)
+	if err != nil {
+		respondError(c, h.determineBulkCancelErrorStatus(err), "Failed to get report", err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "bulk-cancel-"+bulkCancelID.String()+".csv"))
+	c.Data(http.StatusOK, "text/csv", report)
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *ReservationBulkCancelHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineBulkCancelErrorStatus determines HTTP status code based on error message
+func (h *ReservationBulkCancelHandler) determineBulkCancelErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "changed since the dry run"):
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
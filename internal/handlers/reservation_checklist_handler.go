@@ -0,0 +1,367 @@
+// internal/handlers/reservation_checklist_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReservationChecklistHandler handles the organizer's checklist of a reservation and the checklist templates
+type ReservationChecklistHandler struct {
+	checklistService *services.ReservationChecklistService
+}
+
+// NewReservationChecklistHandler creates a new reservation checklist handler
+func NewReservationChecklistHandler(checklistService *services.ReservationChecklistService) *ReservationChecklistHandler {
+	return &ReservationChecklistHandler{
+		checklistService: checklistService,
+	}
+}
+
+// ========================================
+// ORGANIZER ENDPOINTS
+// ========================================
+
+// GetTasks lists the checklist of a reservation
+// @Summary Reservation checklist
+// @Description Tasks the organizer keeps for a reservation, in order, with due times and completion
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/{id}/tasks [get]
+func (h *ReservationChecklistHandler) GetTasks(c *gin.Context) {
+	reservationID, ok := h.parseID(c, "id", "reservation")
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	tasks, err := h.checklistService.GetTasks(reservationID, userID)
+	if err != nil {
+		respondError(c, h.determineChecklistErrorStatus(err), "Failed to get checklist", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Checklist retrieved successfully",
+		Data:    tasks,
+	})
+}
+
+// AddTask adds a task to the checklist of a reservation
+// @Summary Add checklist task
+// @Description Add a task such as "book catering"; with a due time and reminder_minutes the organizer is notified that long before it is due
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param request body dto.CreateReservationTaskRequest true "Task"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/{id}/tasks [post]
+func (h *ReservationChecklistHandler) AddTask(c *gin.Context) {
+	reservationID, ok := h.parseID(c, "id", "reservation")
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateReservationTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	task, err := h.checklistService.AddTask(reservationID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineChecklistErrorStatus(err), "Failed to add task", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Task added successfully",
+		Data:    task,
+	})
+}
+
+// ApplyTemplate adds the tasks of the space type's template to the checklist
+// @Summary Apply checklist template
+// @Description Append the checklist template of the reservation's space type, with due times relative to the start
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/{id}/tasks/apply-template [post]
+func (h *ReservationChecklistHandler) ApplyTemplate(c *gin.Context) {
+	reservationID, ok := h.parseID(c, "id", "reservation")
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	tasks, err := h.checklistService.ApplyTemplate(reservationID, userID)
+	if err != nil {
+		respondError(c, h.determineChecklistErrorStatus(err), "Failed to apply checklist template", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Checklist template applied successfully",
+		Data:    tasks,
+	})
+}
+
+// UpdateTask edits a checklist task or ticks it off
+// @Summary Update checklist task
+// @Description Change a task's title, order, due time or reminder, or mark it done with completed
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param taskId path string true "Task ID" format(uuid)
+// @Param request body dto.UpdateReservationTaskRequest true "Changes"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/{id}/tasks/{taskId} [put]
+func (h *ReservationChecklistHandler) UpdateTask(c *gin.Context) {
+	reservationID, ok := h.parseID(c, "id", "reservation")
+	if !ok {
+		return
+	}
+	taskID, ok := h.parseID(c, "taskId", "task")
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.UpdateReservationTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	task, err := h.checklistService.UpdateTask(reservationID, taskID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineChecklistErrorStatus(err), "Failed to update task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Task updated successfully",
+		Data:    task,
+	})
+}
+
+// DeleteTask removes a task from the checklist
+// @Summary Delete checklist task
+// @Description Remove a task from the reservation's checklist
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param taskId path string true "Task ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/{id}/tasks/{taskId} [delete]
+func (h *ReservationChecklistHandler) DeleteTask(c *gin.Context) {
+	reservationID, ok := h.parseID(c, "id", "reservation")
+	if !ok {
+		return
+	}
+	taskID, ok := h.parseID(c, "taskId", "task")
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	if err := h.checklistService.DeleteTask(reservationID, taskID, userID); err != nil {
+		respondError(c, h.determineChecklistErrorStatus(err), "Failed to delete task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Task deleted successfully",
+	})
+}
+
+// ========================================
+// ADMIN ENDPOINTS
+// ========================================
+
+// GetTemplates lists the checklist templates (admin only)
+// @Summary List checklist templates
+// @Description Checklist template of each space type that has one
+// @Tags checklist-templates
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /admin/checklist-templates [get]
+func (h *ReservationChecklistHandler) GetTemplates(c *gin.Context) {
+	templates, err := h.checklistService.GetTemplates()
+	if err != nil {
+		respondError(c, h.determineChecklistErrorStatus(err), "Failed to get checklist templates", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Checklist templates retrieved successfully",
+		Data:    templates,
+	})
+}
+
+// SetTemplate creates or replaces the checklist template of a space type (admin only)
+// @Summary Set checklist template
+// @Description Tasks added to reservations of the space type when booked with with_checklist, due the given minutes before the start
+// @Tags checklist-templates
+// @Accept json
+// @Produce json
+// @Param spaceType path string true "Space type"
+// @Param request body dto.SetChecklistTemplateRequest true "Template"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/checklist-templates/{spaceType} [put]
+func (h *ReservationChecklistHandler) SetTemplate(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SetChecklistTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	template, err := h.checklistService.SetTemplate(c.Param("spaceType"), &req, userID)
+	if err != nil {
+		respondError(c, h.determineChecklistErrorStatus(err), "Failed to save checklist template", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Checklist template saved successfully",
+		Data:    template,
+	})
+}
+
+// DeleteTemplate removes the checklist template of a space type (admin only)
+// @Summary Delete checklist template
+// @Description Stop offering a checklist for the space type; existing checklists are kept
+// @Tags checklist-templates
+// @Produce json
+// @Param spaceType path string true "Space type"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/checklist-templates/{spaceType} [delete]
+func (h *ReservationChecklistHandler) DeleteTemplate(c *gin.Context) {
+	if err := h.checklistService.DeleteTemplate(c.Param("spaceType")); err != nil {
+		respondError(c, h.determineChecklistErrorStatus(err), "Failed to delete checklist template", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Checklist template deleted successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// parseID parses a UUID path parameter, writing a 400 response when it is invalid
+func (h *ReservationChecklistHandler) parseID(c *gin.Context, param, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		label := strings.ToUpper(name[:1]) + name[1:]
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   fmt.Sprintf("Invalid %s ID", name),
+			Message: fmt.Sprintf("%s ID must be a valid UUID", label),
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *ReservationChecklistHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineChecklistErrorStatus determines HTTP status code based on error message
+func (h *ReservationChecklistHandler) determineChecklistErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case err.Error() == "access denied":
+		return http.StatusForbidden
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
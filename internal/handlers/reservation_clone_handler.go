@@ -0,0 +1,130 @@
+// internal/handlers/reservation_clone_handler.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReservationCloneHandler handles reservation cloning endpoints
+type ReservationCloneHandler struct {
+	cloneService *services.ReservationCloneService
+}
+
+// NewReservationCloneHandler creates a new reservation clone handler
+func NewReservationCloneHandler(cloneService *services.ReservationCloneService) *ReservationCloneHandler {
+	return &ReservationCloneHandler{
+		cloneService: cloneService,
+	}
+}
+
+// CloneReservation books a copy of a reservation at another time
+// @Summary Clone reservation
+// @Description Copy a reservation to a new start time, optionally in another space, keeping its participants, equipment, co-organizers and guests. The copy lasts as long as the original unless end_time is given. When the slot is taken, the nearest free one is booked if accept_nearest is set, otherwise it is returned as suggested_start and suggested_end in a 409.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param request body dto.CloneReservationRequest true "New time"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /reservations/{id}/clone [post]
+func (h *ReservationCloneHandler) CloneReservation(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid reservation ID",
+			Message: "Reservation ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CloneReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	result, err := h.cloneService.CloneReservation(reservationID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineCloneErrorStatus(err), "Failed to clone reservation", err)
+		return
+	}
+
+	message := "Reservation cloned successfully"
+	if result.Adjusted {
+		message = "Reservation cloned to the nearest free slot"
+	}
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: message,
+		Data:    result,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *ReservationCloneHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineCloneErrorStatus determines HTTP status code based on error message
+func (h *ReservationCloneHandler) determineCloneErrorStatus(err error) int {
+	var coded *dto.CodedError
+	if errors.As(err, &coded) {
+		switch coded.Code {
+		case dto.ErrCodeResCloneSlotTaken, dto.ErrCodeResTimeConflict, dto.ErrCodeResVIPReserved, dto.ErrCodeResUserOverlap,
+			dto.ErrCodeResourceBooked, dto.ErrCodeSpaceFloorClosed, dto.ErrCodeSpaceClosed, dto.ErrCodeResPublicHoliday, dto.ErrCodeResNonWorkingDay,
+			dto.ErrCodeResUserQuota, dto.ErrCodeResTeamQuota:
+			return http.StatusConflict
+		case dto.ErrCodeSpaceNeighborhood, dto.ErrCodeSpacePilot, dto.ErrCodeResStrikeRestricted:
+			return http.StatusForbidden
+		}
+	}
+
+	switch {
+	case err.Error() == "access denied":
+		return http.StatusForbidden
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,151 @@
+// internal/handlers/reservation_co_organizer_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReservationCoOrganizerHandler handles the co-organizers of reservations
+type ReservationCoOrganizerHandler struct {
+	coOrganizerService *services.ReservationCoOrganizerService
+}
+
+// NewReservationCoOrganizerHandler creates a new reservation co-organizer handler
+func NewReservationCoOrganizerHandler(coOrganizerService *services.ReservationCoOrganizerService) *ReservationCoOrganizerHandler {
+	return &ReservationCoOrganizerHandler{
+		coOrganizerService: coOrganizerService,
+	}
+}
+
+// GetCoOrganizers lists the co-organizers of a reservation
+// @Summary List co-organizers
+// @Description Users the owner designated to edit, cancel and check in to the reservation
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/{id}/co-organizers [get]
+func (h *ReservationCoOrganizerHandler) GetCoOrganizers(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid reservation ID",
+			Message: "Reservation ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	coOrganizers, err := h.coOrganizerService.GetCoOrganizers(reservationID, userID)
+	if err != nil {
+		respondError(c, h.determineCoOrganizerErrorStatus(err), "Failed to get co-organizers", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Co-organizers retrieved successfully",
+		Data:    coOrganizers,
+	})
+}
+
+// SetCoOrganizers replaces the co-organizers of a reservation
+// @Summary Set co-organizers
+// @Description Designate up to 10 users who can edit, cancel and check in to the reservation. Only the owner or an admin can change them; users added or removed are notified.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param request body dto.SetCoOrganizersRequest true "Co-organizers"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/{id}/co-organizers [put]
+func (h *ReservationCoOrganizerHandler) SetCoOrganizers(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid reservation ID",
+			Message: "Reservation ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SetCoOrganizersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	reservation, err := h.coOrganizerService.SetCoOrganizers(reservationID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineCoOrganizerErrorStatus(err), "Failed to set co-organizers", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Co-organizers updated successfully",
+		Data:    dto.ToReservationResponse(reservation),
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *ReservationCoOrganizerHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineCoOrganizerErrorStatus determines HTTP status code based on error message
+func (h *ReservationCoOrganizerHandler) determineCoOrganizerErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case err.Error() == "access denied":
+		return http.StatusForbidden
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
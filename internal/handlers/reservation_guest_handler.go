@@ -0,0 +1,429 @@
+// internal/handlers/reservation_guest_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReservationGuestHandler handles external guest invitations, guest passes and reception lookups
+type ReservationGuestHandler struct {
+	guestService *services.ReservationGuestService
+}
+
+// NewReservationGuestHandler creates a new reservation guest handler
+func NewReservationGuestHandler(guestService *services.ReservationGuestService) *ReservationGuestHandler {
+	return &ReservationGuestHandler{
+		guestService: guestService,
+	}
+}
+
+// ========================================
+// HOST ENDPOINTS
+// ========================================
+
+// AddGuests invites external guests to a reservation
+// @Summary Invite guests
+// @Description Add external visitors to a reservation; each receives a guest pass and a calendar invite by email
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param request body dto.AddGuestsRequest true "Guests"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /reservations/{id}/guests [post]
+func (h *ReservationGuestHandler) AddGuests(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid reservation ID",
+			Message: "Reservation ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.AddGuestsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	guests, err := h.guestService.AddGuests(reservationID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineGuestErrorStatus(err), "Failed to invite guests", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Guests invited successfully",
+		Data:    guests,
+	})
+}
+
+// GetGuests lists the guests of a reservation
+// @Summary List guests
+// @Description Get the external visitors invited to a reservation
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/{id}/guests [get]
+func (h *ReservationGuestHandler) GetGuests(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid reservation ID",
+			Message: "Reservation ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	guests, err := h.guestService.GetGuests(reservationID, userID)
+	if err != nil {
+		respondError(c, h.determineGuestErrorStatus(err), "Failed to get guests", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Guests retrieved successfully",
+		Data:    guests,
+	})
+}
+
+// ResendInvite emails a guest's calendar invite again
+// @Summary Resend guest invite
+// @Description Send the calendar invite and guest pass to a guest again
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param guestId path string true "Guest ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 502 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /reservations/{id}/guests/{guestId}/resend [post]
+func (h *ReservationGuestHandler) ResendInvite(c *gin.Context) {
+	reservationID, guestID, ok := h.parseGuestPath(c)
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	guest, err := h.guestService.ResendInvite(reservationID, guestID, userID)
+	if err != nil {
+		respondError(c, h.determineGuestErrorStatus(err), "Failed to resend invite", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Invite sent successfully",
+		Data:    guest,
+	})
+}
+
+// RemoveGuest uninvites a guest from a reservation
+// @Summary Remove guest
+// @Description Remove an external visitor; a calendar cancellation is emailed if an invite was sent
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param guestId path string true "Guest ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/{id}/guests/{guestId} [delete]
+func (h *ReservationGuestHandler) RemoveGuest(c *gin.Context) {
+	reservationID, guestID, ok := h.parseGuestPath(c)
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	if err := h.guestService.RemoveGuest(reservationID, guestID, userID); err != nil {
+		respondError(c, h.determineGuestErrorStatus(err), "Failed to remove guest", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Guest removed successfully",
+	})
+}
+
+// ========================================
+// GUEST PASS ENDPOINTS
+// ========================================
+
+// GetGuestPass shows a guest pass (the token authorizes access)
+// @Summary Get guest pass
+// @Description Public link from the invite email showing the visit details
+// @Tags guests
+// @Produce json
+// @Param token path string true "Guest pass token"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /guest-passes/{token} [get]
+func (h *ReservationGuestHandler) GetGuestPass(c *gin.Context) {
+	pass, err := h.guestService.GetGuestPass(c.Param("token"))
+	if err != nil {
+		respondError(c, h.determineGuestErrorStatus(err), "Failed to get guest pass", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Guest pass retrieved successfully",
+		Data:    pass,
+	})
+}
+
+// RespondToInvite records whether a guest will come (the token authorizes access)
+// @Summary Answer guest invitation
+// @Description Accept or decline an invitation from the guest pass page. Declining takes the guest off the meeting's participant count, which may move the meeting to a smaller room.
+// @Tags guests
+// @Accept json
+// @Produce json
+// @Param token path string true "Guest pass token"
+// @Param request body dto.GuestRSVPRequest true "Response"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /guest-passes/{token}/rsvp [post]
+func (h *ReservationGuestHandler) RespondToInvite(c *gin.Context) {
+	var req dto.GuestRSVPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	pass, err := h.guestService.RespondToInvite(c.Param("token"), &req)
+	if err != nil {
+		respondError(c, h.determineGuestErrorStatus(err), "Failed to answer invitation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Response recorded successfully",
+		Data:    pass,
+	})
+}
+
+// GetGuestPassQRCode renders a guest pass as a QR code image
+// @Summary Get guest pass QR code
+// @Description PNG QR code encoding the guest pass link, scanned at reception
+// @Tags guests
+// @Produce png
+// @Param token path string true "Guest pass token"
+// @Success 200 {file} binary
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /guest-passes/{token}/qr [get]
+func (h *ReservationGuestHandler) GetGuestPassQRCode(c *gin.Context) {
+	png, err := h.guestService.GetGuestPassQRCode(c.Param("token"))
+	if err != nil {
+		respondError(c, h.determineGuestErrorStatus(err), "Failed to get guest pass", err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+// ========================================
+// RECEPTION ENDPOINTS
+// ========================================
+
+// GetExpectedVisitors lists the visitors expected in a building on a day (manager/admin)
+// @Summary Expected visitors
+// @Description Reception list of guests with active reservations in a building, ordered by start time
+// @Tags guests
+// @Produce json
+// @Param building query string true "Building"
+// @Param date query string false "Day (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /manager/reception/visitors [get]
+func (h *ReservationGuestHandler) GetExpectedVisitors(c *gin.Context) {
+	building := strings.TrimSpace(c.Query("building"))
+	if building == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Missing building",
+			Message: "building query parameter is required",
+		})
+		return
+	}
+
+	date := time.Now()
+	if raw := c.Query("date"); raw != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", raw, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid date",
+				Message: "date must use the YYYY-MM-DD format",
+			})
+			return
+		}
+		date = parsed
+	}
+
+	visitors, err := h.guestService.GetExpectedVisitors(building, date)
+	if err != nil {
+		respondError(c, h.determineGuestErrorStatus(err), "Failed to get expected visitors", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Expected visitors retrieved successfully",
+		Data:    visitors,
+	})
+}
+
+// MarkGuestArrived checks a visitor in at reception (manager/admin)
+// @Summary Check visitor in
+// @Description Record that a guest has arrived at reception
+// @Tags guests
+// @Produce json
+// @Param id path string true "Guest ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /manager/reception/visitors/{id}/arrive [post]
+func (h *ReservationGuestHandler) MarkGuestArrived(c *gin.Context) {
+	guestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid guest ID",
+			Message: "Guest ID must be a valid UUID",
+		})
+		return
+	}
+
+	guest, err := h.guestService.MarkGuestArrived(guestID)
+	if err != nil {
+		respondError(c, h.determineGuestErrorStatus(err), "Failed to check visitor in", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Visitor checked in successfully",
+		Data:    guest,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// parseGuestPath parses the reservation and guest IDs, writing the error response on failure
+func (h *ReservationGuestHandler) parseGuestPath(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid reservation ID",
+			Message: "Reservation ID must be a valid UUID",
+		})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	guestID, err := uuid.Parse(c.Param("guestId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid guest ID",
+			Message: "Guest ID must be a valid UUID",
+		})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return reservationID, guestID, true
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *ReservationGuestHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineGuestErrorStatus determines HTTP status code based on error message
+func (h *ReservationGuestHandler) determineGuestErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"),
+		err.Error() == "guest not found",
+		err.Error() == "invalid guest pass":
+		return http.StatusNotFound
+	case err.Error() == "access denied":
+		return http.StatusForbidden
+	case strings.HasPrefix(err.Error(), "guest already invited"),
+		err.Error() == "guest has already arrived",
+		err.Error() == "invitation can no longer be answered":
+		return http.StatusConflict
+	case err.Error() == "email delivery is not configured":
+		return http.StatusServiceUnavailable
+	case err.Error() == "failed to send invite":
+		return http.StatusBadGateway
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
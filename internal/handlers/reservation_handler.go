@@ -4,6 +4,7 @@ package handlers
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
@@ -37,7 +38,7 @@ func NewReservationHandler(reservationService *services.ReservationService) *Res
 
 // CreateReservation creates a new reservation
 // @Summary Create a new reservation
-// @Description Create a new space reservation with validation and conflict checking
+// @Description Create a new space reservation with validation and conflict checking. Omitted space, end time, participant count and visibility come from the user's booking preferences. Equipment in resource_ids is booked for the whole reservation (409 RESOURCE_BOOKED when taken); parking spots may require a license_plate.
 // @Tags reservations
 // @Accept json
 // @Produce json
@@ -67,10 +68,7 @@ func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 	var req dto.CreateReservationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Printf("❌ JSON binding failed: %v", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
 
@@ -80,22 +78,16 @@ func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
 		log.Printf("❌ extractUserID failed: %v", err)
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
 	log.Printf("✅ User ID extracted successfully: %s", userID.String())
 
 	// Validate the request (using built-in validation)
-	if err := req.Validate(); err != nil {
+	if err := dto.Validate(&req); err != nil {
 		log.Printf("❌ Request validation failed: %v", err)
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Validation failed",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Validation failed", err)
 		return
 	}
 
@@ -106,22 +98,120 @@ func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 	if err != nil {
 		log.Printf("❌ Service error: %v", err)
 		status := h.determineErrorStatus(err)
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to create reservation",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to create reservation", err)
 		return
 	}
 
 	log.Printf("✅ Reservation created successfully: %+v", reservation)
 
+	message := "Reservation created successfully"
+	if len(reservation.Warnings) > 0 {
+		message = "Reservation created with warnings"
+	}
+
 	c.JSON(http.StatusCreated, dto.SuccessResponse{
 		Success: true,
-		Message: "Reservation created successfully",
+		Message: message,
 		Data:    reservation,
 	})
 }
 
+// BookNow books a space starting immediately for walk-up use
+// @Summary Book a space now
+// @Description Book the space from now for the requested duration, shortened to the next reservation if needed. The booking is confirmed and checked in right away.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Param request body dto.BookNowRequest true "Book now request"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /spaces/{id}/book-now [post]
+func (h *ReservationHandler) BookNow(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.BookNowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	result, err := h.reservationService.BookNow(spaceID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineErrorStatus(err), "Failed to book space", err)
+		return
+	}
+
+	message := "Space booked successfully"
+	if result.Shortened {
+		message = "Space booked until its next reservation"
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: message,
+		Data:    result,
+	})
+}
+
+// FindTime proposes slots when all participants and a suitable space are free
+// @Summary Find a time
+// @Description Intersect the participants' reservations with space availability to propose meeting slots within working hours. The requester is always included. Only free/busy is used; other reservations are not disclosed.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param request body dto.FindTimeRequest true "Find time request"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/find-time [post]
+func (h *ReservationHandler) FindTime(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.FindTimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	result, err := h.reservationService.FindTime(&req, userID)
+	if err != nil {
+		respondError(c, h.determineErrorStatus(err), "Failed to find a time", err)
+		return
+	}
+
+	message := "Suggestions retrieved successfully"
+	if len(result.Suggestions) == 0 {
+		message = "No slot suits every participant in this window"
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: message,
+		Data:    result,
+	})
+}
+
 // GetReservation retrieves a reservation by ID
 // @Summary Get reservation by ID
 // @Description Retrieve detailed information about a specific reservation
@@ -145,10 +235,7 @@ func (h *ReservationHandler) GetReservation(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -158,13 +245,11 @@ func (h *ReservationHandler) GetReservation(c *gin.Context) {
 		if err.Error() == "access denied" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to get reservation",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to get reservation", err)
 		return
 	}
 
+	setETag(c, reservation.UpdatedAt)
 	c.JSON(http.StatusOK, dto.SuccessResponse{
 		Success: true,
 		Data:    reservation,
@@ -173,11 +258,12 @@ func (h *ReservationHandler) GetReservation(c *gin.Context) {
 
 // UpdateReservation updates an existing reservation
 // @Summary Update reservation
-// @Description Update reservation details (title, time, participants, etc.)
+// @Description Update reservation details (title, time, participants, etc.). Send the ETag of the reservation in If-Match, or its updated_at in the body, to get a 409 instead of overwriting a change made since it was read.
 // @Tags reservations
 // @Accept json
 // @Produce json
 // @Param id path string true "Reservation ID" format(uuid)
+// @Param If-Match header string false "ETag of the reservation as last read"
 // @Param request body dto.UpdateReservationRequest true "Update reservation request"
 // @Success 200 {object} dto.SuccessResponse
 // @Failure 400 {object} dto.ErrorResponse
@@ -197,41 +283,36 @@ func (h *ReservationHandler) UpdateReservation(c *gin.Context) {
 
 	var req dto.UpdateReservationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
+	if expected, err := ifMatch(c); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	} else if expected != nil {
+		req.UpdatedAt = expected
+	}
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
 	// Validate the request (using built-in validation)
-	if err := req.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Validation failed",
-			Message: err.Error(),
-		})
+	if err := dto.Validate(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Validation failed", err)
 		return
 	}
 
 	reservation, err := h.reservationService.UpdateReservation(reservationID, &req, userID)
 	if err != nil {
 		status := h.determineErrorStatus(err)
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to update reservation",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to update reservation", err)
 		return
 	}
 
+	setETag(c, reservation.UpdatedAt)
 	c.JSON(http.StatusOK, dto.SuccessResponse{
 		Success: true,
 		Message: "Reservation updated successfully",
@@ -264,29 +345,20 @@ func (h *ReservationHandler) CancelReservation(c *gin.Context) {
 
 	var req dto.CancelReservationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
 	err = h.reservationService.CancelReservation(reservationID, req.Reason, userID)
 	if err != nil {
 		status := h.determineErrorStatus(err)
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to cancel reservation",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to cancel reservation", err)
 		return
 	}
 
@@ -318,10 +390,7 @@ func (h *ReservationHandler) DeleteReservation(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -331,10 +400,7 @@ func (h *ReservationHandler) DeleteReservation(c *gin.Context) {
 		if err.Error() == "only administrators can delete reservations" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to delete reservation",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to delete reservation", err)
 		return
 	}
 
@@ -354,6 +420,8 @@ func (h *ReservationHandler) DeleteReservation(c *gin.Context) {
 // @Param status query string false "Filter by status" Enums(pending, confirmed, cancelled, completed, rejected)
 // @Param space_id query string false "Filter by space ID" format(uuid)
 // @Param user_id query string false "Filter by user ID" format(uuid)
+// @Param cursor query string false "Cursor pagination: empty for the first page, then next_cursor of the previous page; replaces page"
+// @Param include query string false "Relations to load, comma separated: user, space, approver, co_organizers; all when omitted, none when empty"
 // @Success 200 {object} dto.PaginatedResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
@@ -361,10 +429,7 @@ func (h *ReservationHandler) DeleteReservation(c *gin.Context) {
 func (h *ReservationHandler) GetAllReservations(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -387,21 +452,42 @@ func (h *ReservationHandler) GetAllReservations(c *gin.Context) {
 		}
 	}
 
+	include, err := h.includeQuery(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid include", err)
+		return
+	}
+	if include != nil {
+		filters["include"] = include
+	}
+
 	page := utils.GetIntQuery(c, "page", 1)
 	limit := utils.GetIntQuery(c, "limit", 20)
 	page, limit = h.validatePaginationParams(page, limit)
 	offset := (page - 1) * limit
 
+	cursor, useCursor, err := cursorQuery(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid cursor", err)
+		return
+	}
+	if useCursor {
+		reservations, next, err := h.reservationService.SearchReservationsPage(filters, cursor, limit, userID)
+		if err != nil {
+			respondError(c, h.determinePageErrorStatus(err), "Failed to get reservations", err)
+			return
+		}
+		c.JSON(http.StatusOK, dto.NewCursorPaginatedResponse(reservations, limit, next))
+		return
+	}
+
 	reservations, total, err := h.reservationService.SearchReservations(filters, offset, limit, userID)
 	if err != nil {
 		status := http.StatusInternalServerError
 		if err.Error() == "access denied" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to get reservations",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to get reservations", err)
 		return
 	}
 
@@ -456,13 +542,40 @@ func (h *ReservationHandler) validatePaginationParams(page, limit int) (int, int
 
 // determineErrorStatus determines HTTP status code based on error message
 func (h *ReservationHandler) determineErrorStatus(err error) int {
+	var fields dto.ValidationErrors
+	if errors.As(err, &fields) {
+		return http.StatusBadRequest
+	}
+
+	var coded *dto.CodedError
+	if errors.As(err, &coded) && (coded.Code == dto.ErrCodeSpaceFloorClosed || coded.Code == dto.ErrCodeSpaceClosed || coded.Code == dto.ErrCodeResourceBooked || coded.Code == dto.ErrCodeResPublicHoliday ||
+		coded.Code == dto.ErrCodeResNonWorkingDay || coded.Code == dto.ErrCodeResUserQuota || coded.Code == dto.ErrCodeResTeamQuota) {
+		return http.StatusConflict
+	}
+	if errors.As(err, &coded) && (coded.Code == dto.ErrCodeSpaceNeighborhood || coded.Code == dto.ErrCodeSpacePilot || coded.Code == dto.ErrCodeResStrikeRestricted ||
+		coded.Code == dto.ErrCodeResPreemptionDenied) {
+		return http.StatusForbidden
+	}
+
 	switch err.Error() {
 	case "access denied":
 		return http.StatusForbidden
+	case "the resource was changed by another request":
+		return http.StatusConflict
 	case "time slot is not available":
 		return http.StatusConflict
+	case "time slot is reserved for VIP use":
+		return http.StatusConflict
+	case "overlapping reservation exists for this user":
+		return http.StatusConflict
 	case "space is not available for booking":
 		return http.StatusConflict
+	case "space is not free right now":
+		return http.StatusConflict
+	case "space requires approval and cannot be booked instantly":
+		return http.StatusConflict
+	case "no suitable space is free in the building for this time":
+		return http.StatusConflict
 	case "reservation cannot be modified":
 		return http.StatusConflict
 	case "reservation cannot be cancelled":
@@ -475,6 +588,9 @@ func (h *ReservationHandler) determineErrorStatus(err error) int {
 		if strings.Contains(err.Error(), "exceeds") {
 			return http.StatusConflict
 		}
+		if strings.HasPrefix(err.Error(), "participant ") && strings.HasSuffix(err.Error(), " not found") {
+			return http.StatusNotFound
+		}
 		if strings.Contains(err.Error(), "invalid") {
 			return http.StatusBadRequest
 		}
@@ -482,6 +598,27 @@ func (h *ReservationHandler) determineErrorStatus(err error) int {
 	}
 }
 
+// includeQuery reads ?include=, nil when it is not given so that lists load every relation
+func (h *ReservationHandler) includeQuery(c *gin.Context) (dto.ReservationIncludes, error) {
+	value, ok := c.GetQuery("include")
+	if !ok {
+		return nil, nil
+	}
+	return dto.ParseReservationIncludes(value)
+}
+
+// determinePageErrorStatus determines HTTP status code for errors listing reservations by cursor
+func (h *ReservationHandler) determinePageErrorStatus(err error) int {
+	switch {
+	case err.Error() == "access denied":
+		return http.StatusForbidden
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
+
 // buildFiltersFromQuery builds search filters from query parameters
 func (h *ReservationHandler) buildFiltersFromQuery(c *gin.Context) map[string]interface{} {
 	filters := make(map[string]interface{})
@@ -548,6 +685,8 @@ func (h *ReservationHandler) validateReservationStatus(status string) bool {
 // @Param space_id query string false "Filter by space ID" format(uuid)
 // @Param start_date query string false "Filter from start date" format(date-time)
 // @Param end_date query string false "Filter until end date" format(date-time)
+// @Param cursor query string false "Cursor pagination: empty for the first page, then next_cursor of the previous page; replaces page"
+// @Param include query string false "Relations to load, comma separated: user, space, approver, co_organizers; all when omitted, none when empty"
 // @Success 200 {object} dto.PaginatedResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
@@ -592,10 +731,7 @@ func (h *ReservationHandler) GetUserReservations(c *gin.Context) {
 	userID, err := h.extractUserIDWithDetailedLogging(c)
 	if err != nil {
 		log.Printf("❌ GetUserReservations: Failed to extract user ID: %v", err)
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -609,22 +745,49 @@ func (h *ReservationHandler) GetUserReservations(c *gin.Context) {
 
 	filters := h.buildUserFilters(c)
 
+	include, err := h.includeQuery(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid include", err)
+		return
+	}
+
+	cursor, useCursor, err := cursorQuery(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid cursor", err)
+		return
+	}
+	if useCursor {
+		var results []*models.Reservation
+		var next *dto.Cursor
+		if len(filters) > 0 {
+			filters["user_id"] = userID
+			filters["include"] = include
+			results, next, err = h.reservationService.SearchReservationsPage(filters, cursor, limit, userID)
+		} else {
+			results, next, err = h.reservationService.GetUserReservationsPage(userID, cursor, limit, include)
+		}
+		if err != nil {
+			respondError(c, h.determinePageErrorStatus(err), "Failed to get reservations", err)
+			return
+		}
+		c.JSON(http.StatusOK, dto.NewCursorPaginatedResponse(results, limit, next))
+		return
+	}
+
 	var reservations interface{}
 	var total int64
 
 	if len(filters) > 0 {
 		filters["user_id"] = userID
+		filters["include"] = include
 		reservations, total, err = h.reservationService.SearchReservations(filters, offset, limit, userID)
 	} else {
-		reservations, total, err = h.reservationService.GetUserReservations(userID, offset, limit)
+		reservations, total, err = h.reservationService.GetUserReservations(userID, offset, limit, include)
 	}
 
 	if err != nil {
 		log.Printf("❌ Service error: %v", err)
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get reservations",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get reservations", err)
 		return
 	}
 
@@ -721,10 +884,7 @@ func (h *ReservationHandler) extractUserIDWithDetailedLogging(c *gin.Context) (u
 func (h *ReservationHandler) GetUserUpcomingReservations(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -732,10 +892,7 @@ func (h *ReservationHandler) GetUserUpcomingReservations(c *gin.Context) {
 
 	reservations, err := h.reservationService.GetUserUpcomingReservations(userID, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get upcoming reservations",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get upcoming reservations", err)
 		return
 	}
 
@@ -753,6 +910,44 @@ func (h *ReservationHandler) GetUserUpcomingReservations(c *gin.Context) {
 	})
 }
 
+// GetUserBookingInsights describes how the current user books spaces
+// @Summary Get my booking insights
+// @Description Personal statistics over the past days: favorite spaces, average meeting length and size, check-in punctuality, busiest weekdays and hour, and suggestions such as booking smaller rooms
+// @Tags reservations
+// @Produce json
+// @Param days query int false "Past days analyzed" default(90) minimum(7) maximum(365)
+// @Param timezone query string false "IANA timezone weekdays and hours are read in, default UTC"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /reservations/my/insights [get]
+func (h *ReservationHandler) GetUserBookingInsights(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.BookingInsightsRequest
+	if err := bindQuery(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	insights, err := h.reservationService.GetBookingInsights(userID, &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get booking insights", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Booking insights retrieved successfully",
+		Data:    insights,
+	})
+}
+
 // GetUserPastReservations retrieves past reservations for the current user
 // @Summary Get past reservations
 // @Description Retrieve completed and past reservations for the authenticated user
@@ -768,10 +963,7 @@ func (h *ReservationHandler) GetUserUpcomingReservations(c *gin.Context) {
 func (h *ReservationHandler) GetUserPastReservations(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -795,10 +987,7 @@ func (h *ReservationHandler) GetUserPastReservations(c *gin.Context) {
 
 	reservations, total, err := h.reservationService.SearchReservations(filters, offset, limit, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get past reservations",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get past reservations", err)
 		return
 	}
 
@@ -818,19 +1007,13 @@ func (h *ReservationHandler) GetUserPastReservations(c *gin.Context) {
 func (h *ReservationHandler) GetUserActiveReservation(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
 	reservation, err := h.reservationService.GetUserActiveReservation(userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get active reservation",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get active reservation", err)
 		return
 	}
 
@@ -872,10 +1055,7 @@ func (h *ReservationHandler) GetUserActiveReservation(c *gin.Context) {
 func (h *ReservationHandler) GetUserReservationCount(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -884,10 +1064,7 @@ func (h *ReservationHandler) GetUserReservationCount(c *gin.Context) {
 
 	count, err := h.reservationService.GetUserReservationCount(userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get reservation count",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get reservation count", err)
 		return
 	}
 
@@ -924,10 +1101,7 @@ func (h *ReservationHandler) GetUserReservationCount(c *gin.Context) {
 func (h *ReservationHandler) GetUserReservationSummary(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -937,10 +1111,14 @@ func (h *ReservationHandler) GetUserReservationSummary(c *gin.Context) {
 	// Get basic count
 	totalCount, err := h.reservationService.GetUserReservationCount(userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get reservation summary",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get reservation summary", err)
+		return
+	}
+
+	// Count completed and cancelled reservations in one query
+	statusCounts, err := h.reservationService.GetUserStatusCounts(userID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get reservation summary", err)
 		return
 	}
 
@@ -956,8 +1134,8 @@ func (h *ReservationHandler) GetUserReservationSummary(c *gin.Context) {
 		"statistics": map[string]interface{}{
 			"total_reservations": totalCount,
 			"upcoming_count":     upcomingCount,
-			"completed_count":    h.getCompletedCount(userID),
-			"cancelled_count":    h.getCancelledCount(userID),
+			"completed_count":    statusCounts[models.StatusCompleted],
+			"cancelled_count":    statusCounts[models.StatusCancelled],
 		},
 		"next_reservation": h.getNextReservationInfo(upcomingReservations),
 		"generated_at":     time.Now(),
@@ -1050,18 +1228,14 @@ func (h *ReservationHandler) canCheckOut(reservation interface{}) bool {
 
 // getUserStatusBreakdown gets count of reservations by status for the user
 func (h *ReservationHandler) getUserStatusBreakdown(userID uuid.UUID) (map[string]int, error) {
-	breakdown := make(map[string]int)
-	statuses := []string{"pending", "confirmed", "cancelled", "completed", "rejected"}
+	counts, err := h.reservationService.GetUserStatusCounts(userID)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, status := range statuses {
-		filters := map[string]interface{}{
-			"user_id": userID,
-			"status":  status,
-		}
-		_, total, err := h.reservationService.SearchReservations(filters, 0, 1, userID)
-		if err == nil {
-			breakdown[status] = int(total)
-		}
+	breakdown := make(map[string]int)
+	for _, status := range []models.ReservationStatus{models.StatusPending, models.StatusConfirmed, models.StatusCancelled, models.StatusCompleted, models.StatusRejected} {
+		breakdown[string(status)] = int(counts[status])
 	}
 
 	return breakdown, nil
@@ -1090,44 +1264,18 @@ func (h *ReservationHandler) calculatePeriodRange(period string) (time.Time, tim
 	return startDate, endDate
 }
 
-// getCompletedCount gets count of completed reservations for user
-func (h *ReservationHandler) getCompletedCount(userID uuid.UUID) int {
-	filters := map[string]interface{}{
-		"user_id": userID,
-		"status":  "completed",
-	}
-	_, total, err := h.reservationService.SearchReservations(filters, 0, 1, userID)
-	if err != nil {
-		return 0
-	}
-	return int(total)
-}
-
-// getCancelledCount gets count of cancelled reservations for user
-func (h *ReservationHandler) getCancelledCount(userID uuid.UUID) int {
-	filters := map[string]interface{}{
-		"user_id": userID,
-		"status":  "cancelled",
-	}
-	_, total, err := h.reservationService.SearchReservations(filters, 0, 1, userID)
-	if err != nil {
-		return 0
-	}
-	return int(total)
-}
-
 // ========================================
 // CHECK-IN/CHECK-OUT OPERATIONS
 // ========================================
 
 // CheckIn checks into a reservation
 // @Summary Check into reservation
-// @Description Check into a confirmed reservation (must be within check-in window)
+// @Description Check into a confirmed reservation (must be within check-in window). Spaces with a presence check need beacon_id or latitude/longitude proving the user is there.
 // @Tags reservations
 // @Accept json
 // @Produce json
 // @Param id path string true "Reservation ID" format(uuid)
-// @Param request body dto.CheckInRequest false "Check-in request (optional)"
+// @Param request body dto.CheckInRequest false "Notes and proof of presence"
 // @Success 200 {object} dto.SuccessResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
@@ -1146,25 +1294,24 @@ func (h *ReservationHandler) CheckIn(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
-	// Optional check-in request body for notes
+	// Optional body with notes and, for spaces that require it, proof of presence
 	var req dto.CheckInRequest
-	c.ShouldBindJSON(&req) // Optional binding, ignore errors
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid request data", err)
+			return
+		}
+	}
 
 	// Perform check-in
-	err = h.reservationService.CheckIn(reservationID, userID)
+	err = h.reservationService.CheckIn(reservationID, &req, userID)
 	if err != nil {
 		status := h.determineCheckInErrorStatus(err)
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to check in",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to check in", err)
 		return
 	}
 
@@ -1210,7 +1357,7 @@ func (h *ReservationHandler) CheckIn(c *gin.Context) {
 
 // CheckOut checks out of a reservation
 // @Summary Check out of reservation
-// @Description Check out of a reservation and optionally provide feedback
+// @Description Check out of a reservation and optionally provide feedback. When the space releases early check-outs and at least its early_release_minutes are left, the reservation ends now and the freed time is offered to saved searches.
 // @Tags reservations
 // @Accept json
 // @Produce json
@@ -1234,57 +1381,44 @@ func (h *ReservationHandler) CheckOut(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
 	// Optional check-out request body for feedback
 	var req dto.CheckOutRequest
-	c.ShouldBindJSON(&req) // Optional binding, ignore errors
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
 
-	// Get reservation before check-out for duration calculation
-	reservation, err := h.reservationService.GetReservationByID(reservationID, userID)
-	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{
-			Error:   "Reservation not found",
-			Message: err.Error(),
-		})
+	if _, err := h.reservationService.GetReservationByID(reservationID, userID); err != nil {
+		respondError(c, http.StatusNotFound, "Reservation not found", err)
 		return
 	}
 
-	// Perform check-out
-	err = h.reservationService.CheckOut(reservationID, userID)
+	// Perform check-out, which records the session
+	session, err := h.reservationService.CheckOut(reservationID, userID, &req)
 	if err != nil {
 		status := h.determineCheckOutErrorStatus(err)
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to check out",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to check out", err)
 		return
 	}
 
-	// Calculate session duration
-	var sessionDuration string
-	var actualDuration time.Duration
-	if reservation.CheckInTime != nil {
-		actualDuration = time.Now().Sub(*reservation.CheckInTime)
-		sessionDuration = h.formatDuration(actualDuration)
-	}
-
 	// Build comprehensive check-out response
 	responseData := map[string]interface{}{
 		"reservation_id": reservationID,
-		"checked_out_at": time.Now(),
+		"checked_out_at": session.ActualEnd,
 		"status":         "completed",
 		"session_summary": map[string]interface{}{
-			"check_in_time":      reservation.CheckInTime,
-			"check_out_time":     time.Now(),
-			"session_duration":   sessionDuration,
-			"scheduled_duration": h.formatDuration(reservation.EndTime.Sub(reservation.StartTime)),
-			"early_checkout":     time.Now().Before(reservation.EndTime),
+			"check_in_time":       session.ActualStart,
+			"check_out_time":      session.ActualEnd,
+			"session_duration":    h.formatDuration(session.ActualEnd.Sub(session.ActualStart)),
+			"scheduled_duration":  h.formatDuration(session.ScheduledEnd.Sub(session.ScheduledStart)),
+			"utilization_percent": session.UtilizationPercent,
+			"early_checkout":      session.EarlyCheckout,
+			"released_minutes":    session.ReleasedMinutes,
+			"released":            session.Released, // The rest of the booking was given back
 		},
 	}
 
@@ -1306,6 +1440,55 @@ func (h *ReservationHandler) CheckOut(c *gin.Context) {
 	})
 }
 
+// ExtendMeeting pushes back the end of an ongoing meeting
+// @Summary Extend ongoing meeting
+// @Description Move the end of a meeting in progress to new_end_time, in place, when the room is not booked in between and the space's maximum booking duration allows it. The room's display is refreshed right away.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param request body dto.ExtendReservationRequest true "New end time"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReservationResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /reservations/{id}/extend [post]
+func (h *ReservationHandler) ExtendMeeting(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid reservation ID",
+			Message: "Reservation ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.ExtendReservationRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	reservation, err := h.reservationService.ExtendMeeting(reservationID, userID, &req)
+	if err != nil {
+		respondError(c, h.determineExtendErrorStatus(err), "Failed to extend meeting", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Meeting extended successfully",
+		Data:    dto.ToReservationResponse(reservation),
+	})
+}
+
 // GetCheckInStatus gets the check-in status of a reservation
 // @Summary Get check-in status
 // @Description Get detailed check-in/check-out status for a reservation
@@ -1329,10 +1512,7 @@ func (h *ReservationHandler) GetCheckInStatus(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -1342,10 +1522,7 @@ func (h *ReservationHandler) GetCheckInStatus(c *gin.Context) {
 		if err.Error() == "access denied" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to get reservation",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to get reservation", err)
 		return
 	}
 
@@ -1374,10 +1551,7 @@ func (h *ReservationHandler) GetCheckInStatus(c *gin.Context) {
 func (h *ReservationHandler) GetCheckedInReservations(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -1405,10 +1579,7 @@ func (h *ReservationHandler) GetCheckedInReservations(c *gin.Context) {
 		if err.Error() == "access denied" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to get checked-in reservations",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to get checked-in reservations", err)
 		return
 	}
 
@@ -1421,7 +1592,7 @@ func (h *ReservationHandler) GetCheckedInReservations(c *gin.Context) {
 
 // MarkNoShow marks a reservation as no-show (managers/admins only)
 // @Summary Mark reservation as no-show
-// @Description Mark a reservation as no-show when user fails to check in (managers and admins only)
+// @Description Mark a reservation as no-show when user fails to check in (managers and admins only). The booker earns a strike.
 // @Tags reservations
 // @Accept json
 // @Produce json
@@ -1444,19 +1615,13 @@ func (h *ReservationHandler) MarkNoShow(c *gin.Context) {
 
 	var req dto.NoShowRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -1469,10 +1634,11 @@ func (h *ReservationHandler) MarkNoShow(c *gin.Context) {
 		return
 	}
 
-	// This would need to be implemented in the service
-	// err = h.reservationService.MarkNoShow(reservationID, userID, req.Reason)
+	if err := h.reservationService.MarkNoShow(reservationID, req.Reason, userID); err != nil {
+		respondError(c, h.determineErrorStatus(err), "Failed to mark reservation as no-show", err)
+		return
+	}
 
-	// For now, simulate the operation
 	responseData := map[string]interface{}{
 		"reservation_id": reservationID,
 		"action":         "marked_no_show",
@@ -1495,7 +1661,7 @@ func (h *ReservationHandler) MarkNoShow(c *gin.Context) {
 // determineCheckInErrorStatus determines HTTP status for check-in errors
 func (h *ReservationHandler) determineCheckInErrorStatus(err error) int {
 	switch err.Error() {
-	case "can only check in to your own reservation":
+	case "can only check in to reservations you organize":
 		return http.StatusForbidden
 	case "reservation must be confirmed to check in":
 		return http.StatusConflict
@@ -1503,6 +1669,10 @@ func (h *ReservationHandler) determineCheckInErrorStatus(err error) int {
 		return http.StatusConflict
 	case "can only check in within 15 minutes of start time":
 		return http.StatusConflict
+	case "check-in questionnaire must be submitted first":
+		return http.StatusConflict
+	case "beacon does not belong to this space", "location is outside the space's check-in area":
+		return http.StatusForbidden
 	default:
 		return http.StatusBadRequest
 	}
@@ -1511,7 +1681,7 @@ func (h *ReservationHandler) determineCheckInErrorStatus(err error) int {
 // determineCheckOutErrorStatus determines HTTP status for check-out errors
 func (h *ReservationHandler) determineCheckOutErrorStatus(err error) int {
 	switch err.Error() {
-	case "can only check out of your own reservation":
+	case "can only check out of reservations you organize":
 		return http.StatusForbidden
 	case "must check in before checking out":
 		return http.StatusConflict
@@ -1522,6 +1692,22 @@ func (h *ReservationHandler) determineCheckOutErrorStatus(err error) int {
 	}
 }
 
+// determineExtendErrorStatus determines HTTP status for meeting extension errors
+func (h *ReservationHandler) determineExtendErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case err.Error() == "can only extend reservations you organize":
+		return http.StatusForbidden
+	case err.Error() == "space is booked right after this reservation":
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
+
 // buildCheckInStatusResponse builds comprehensive check-in status response
 func (h *ReservationHandler) buildCheckInStatusResponse(reservation interface{}) map[string]interface{} {
 	// This would access actual reservation fields - using placeholder logic
@@ -1606,17 +1792,6 @@ func (h *ReservationHandler) validateCheckOutTiming(reservation interface{}) err
 	return nil
 }
 
-// generateSessionSummary generates a summary of the reservation session
-func (h *ReservationHandler) generateSessionSummary(reservation interface{}, checkOutTime time.Time) map[string]interface{} {
-	return map[string]interface{}{
-		"total_duration":    "calculated duration",
-		"early_checkout":    false, // Was checkout before scheduled end?
-		"utilization_rate":  100,   // Percentage of scheduled time used
-		"was_no_show":       false,
-		"feedback_provided": false,
-	}
-}
-
 // ========================================
 // SEARCH AND FILTER OPERATIONS
 // ========================================
@@ -1626,7 +1801,7 @@ func (h *ReservationHandler) generateSessionSummary(reservation interface{}, che
 // @Description Search reservations using multiple filters and sorting options
 // @Tags reservations
 // @Produce json
-// @Param query query string false "Search query (searches in title and description)"
+// @Param query query string false "Full-text search in title and description, results ranked by relevance"
 // @Param status query []string false "Filter by status" Enums(pending, confirmed, cancelled, completed, rejected)
 // @Param space_ids query []string false "Filter by space IDs" format(uuid)
 // @Param user_ids query []string false "Filter by user IDs" format(uuid)
@@ -1636,10 +1811,13 @@ func (h *ReservationHandler) generateSessionSummary(reservation interface{}, che
 // @Param max_participants query int false "Maximum participant count" minimum(1)
 // @Param is_recurring query bool false "Filter recurring reservations"
 // @Param include_checked_in query bool false "Include checked-in reservations" default(true)
+// @Param tags query []string false "Reservations carrying all of these tags"
+// @Param cost_center query string false "Filter by cost center code"
 // @Param sort_by query string false "Sort by field" Enums(start_time, end_time, created_at, title, participant_count) default(start_time)
 // @Param sort_order query string false "Sort order" Enums(asc, desc) default(asc)
 // @Param page query int false "Page number" default(1) minimum(1)
 // @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param include query string false "Relations to load, comma separated: user, space, approver, co_organizers; all when omitted, none when empty"
 // @Success 200 {object} dto.ReservationSearchResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -1648,26 +1826,29 @@ func (h *ReservationHandler) generateSessionSummary(reservation interface{}, che
 func (h *ReservationHandler) SearchReservations(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
 	// Parse and validate search request
 	searchReq, err := h.parseSearchRequest(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid search parameters",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid search parameters", err)
 		return
 	}
 
 	// Build filters from search request
 	filters := h.buildAdvancedFilters(searchReq)
 
+	include, err := h.includeQuery(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid include", err)
+		return
+	}
+	if include != nil {
+		filters["include"] = include
+	}
+
 	// Set pagination
 	page, limit := h.validatePaginationParams(searchReq.Page, searchReq.Limit)
 	offset := (page - 1) * limit
@@ -1675,10 +1856,7 @@ func (h *ReservationHandler) SearchReservations(c *gin.Context) {
 	// Perform search
 	reservations, total, err := h.reservationService.SearchReservations(filters, offset, limit, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Search failed",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Search failed", err)
 		return
 	}
 
@@ -1714,29 +1892,20 @@ func (h *ReservationHandler) SearchReservations(c *gin.Context) {
 func (h *ReservationHandler) GetReservationsByDateRange(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
 	// Parse and validate date range
 	startDate, endDate, err := h.parseDateRange(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid date range",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid date range", err)
 		return
 	}
 
 	// Validate date range constraints
 	if err := h.validateDateRange(startDate, endDate); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid date range",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid date range", err)
 		return
 	}
 
@@ -1747,10 +1916,7 @@ func (h *ReservationHandler) GetReservationsByDateRange(c *gin.Context) {
 
 	reservations, total, err := h.reservationService.GetReservationsByDateRange(startDate, endDate, offset, limit, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get reservations",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get reservations", err)
 		return
 	}
 
@@ -1787,10 +1953,7 @@ func (h *ReservationHandler) GetReservationsByDateRange(c *gin.Context) {
 func (h *ReservationHandler) GetReservationsByStatus(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -1814,10 +1977,7 @@ func (h *ReservationHandler) GetReservationsByStatus(c *gin.Context) {
 		if err.Error() == "access denied" {
 			httpStatus = http.StatusForbidden
 		}
-		c.JSON(httpStatus, dto.ErrorResponse{
-			Error:   "Failed to get reservations",
-			Message: err.Error(),
-		})
+		respondError(c, httpStatus, "Failed to get reservations", err)
 		return
 	}
 
@@ -1849,29 +2009,20 @@ func (h *ReservationHandler) GetReservationsByStatus(c *gin.Context) {
 func (h *ReservationHandler) AdvancedSearch(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
 	var req dto.ReservationSearchRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
 
 	// Set defaults and validate
 	req.SetDefaults()
-	if err := req.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid search parameters",
-			Message: err.Error(),
-		})
+	if err := dto.Validate(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid search parameters", err)
 		return
 	}
 
@@ -1885,10 +2036,7 @@ func (h *ReservationHandler) AdvancedSearch(c *gin.Context) {
 	// Perform search
 	reservations, total, err := h.reservationService.SearchReservations(filters, offset, limit, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Advanced search failed",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Advanced search failed", err)
 		return
 	}
 
@@ -1905,7 +2053,7 @@ func (h *ReservationHandler) AdvancedSearch(c *gin.Context) {
 
 // GetReservationCalendar gets calendar view of reservations
 // @Summary Get reservation calendar
-// @Description Get calendar view of reservations for a specific date range
+// @Description Get calendar view of reservations for a specific date range, with the buffer time each space keeps free around its bookings
 // @Tags reservations
 // @Produce json
 // @Param start_date query string true "Calendar start date" format(date)
@@ -1919,20 +2067,14 @@ func (h *ReservationHandler) AdvancedSearch(c *gin.Context) {
 func (h *ReservationHandler) GetReservationCalendar(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
 	// Parse calendar parameters
 	startDate, endDate, err := h.parseCalendarDateRange(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid calendar parameters",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid calendar parameters", err)
 		return
 	}
 
@@ -1960,10 +2102,7 @@ func (h *ReservationHandler) GetReservationCalendar(c *gin.Context) {
 	// Get reservations for calendar period
 	reservations, _, err := h.reservationService.SearchReservations(filters, 0, 1000, userID) // High limit for calendar
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get calendar data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get calendar data", err)
 		return
 	}
 
@@ -1977,6 +2116,64 @@ func (h *ReservationHandler) GetReservationCalendar(c *gin.Context) {
 	})
 }
 
+// GetDepartmentCalendar gets the reservations of a department's members
+// @Summary Get department calendar
+// @Description Get the reservations of all members of a department. Private reservations of other members only show as busy time.
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Department name"
+// @Param start_date query string true "Calendar start date" format(date)
+// @Param end_date query string true "Calendar end date" format(date)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /departments/{id}/calendar [get]
+func (h *ReservationHandler) GetDepartmentCalendar(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	department := strings.TrimSpace(c.Param("id"))
+	if department == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid department",
+			Message: "Department is required",
+		})
+		return
+	}
+
+	startDate, endDate, err := h.parseCalendarDateRange(c)
+	if err == nil {
+		err = h.validateDateRange(startDate, endDate)
+	}
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid calendar parameters", err)
+		return
+	}
+
+	calendar, err := h.reservationService.GetDepartmentCalendar(department, startDate, endDate, userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err.Error() {
+		case "access denied":
+			status = http.StatusForbidden
+		case "department not found":
+			status = http.StatusNotFound
+		}
+		respondError(c, status, "Failed to get department calendar", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Department calendar retrieved successfully",
+		Data:    calendar,
+	})
+}
+
 // ========================================
 // HELPER METHODS FOR PART 4
 // ========================================
@@ -1989,6 +2186,8 @@ func (h *ReservationHandler) parseSearchRequest(c *gin.Context) (*dto.Reservatio
 		Statuses:         c.QueryArray("status"),
 		SpaceIDs:         c.QueryArray("space_ids"),
 		UserIDs:          c.QueryArray("user_ids"),
+		Tags:             c.QueryArray("tags"),
+		CostCenter:       c.Query("cost_center"),
 		SortBy:           c.DefaultQuery("sort_by", "start_time"),
 		SortOrder:        c.DefaultQuery("sort_order", "asc"),
 		Page:             utils.GetIntQuery(c, "page", 1),
@@ -2026,7 +2225,7 @@ func (h *ReservationHandler) parseSearchRequest(c *gin.Context) (*dto.Reservatio
 	}
 
 	req.SetDefaults()
-	return req, req.Validate()
+	return req, dto.Validate(req)
 }
 
 // buildAdvancedFilters builds filters for advanced search
@@ -2034,7 +2233,7 @@ func (h *ReservationHandler) buildAdvancedFilters(req *dto.ReservationSearchRequ
 	filters := make(map[string]interface{})
 
 	if req.Query != "" {
-		filters["title"] = req.Query
+		filters["query"] = req.Query
 	}
 
 	if len(req.Statuses) > 0 {
@@ -2089,6 +2288,14 @@ func (h *ReservationHandler) buildAdvancedFilters(req *dto.ReservationSearchRequ
 		filters["include_checked_in"] = *req.IncludeCheckedIn
 	}
 
+	if tags := models.NormalizeTags(req.Tags); len(tags) > 0 {
+		filters["tags"] = tags
+	}
+
+	if req.CostCenter != "" {
+		filters["cost_center"] = models.NormalizeCostCenterCode(req.CostCenter)
+	}
+
 	return filters
 }
 
@@ -2143,9 +2350,16 @@ func (h *ReservationHandler) buildSearchSummary(reservations interface{}, total
 
 // convertToReservationResponses converts reservation models to response DTOs
 func (h *ReservationHandler) convertToReservationResponses(reservations interface{}) []dto.ReservationResponse {
-	// This would convert actual reservation models to DTOs
-	// For now, returning empty slice
-	return []dto.ReservationResponse{}
+	list, ok := reservations.([]*models.Reservation)
+	if !ok {
+		return []dto.ReservationResponse{}
+	}
+
+	responses := make([]dto.ReservationResponse, len(list))
+	for i, reservation := range list {
+		responses[i] = *dto.ToReservationResponse(reservation)
+	}
+	return responses
 }
 
 // convertToSearchFilters converts search request to filter DTO
@@ -2182,10 +2396,7 @@ func (h *ReservationHandler) GetSpaceReservations(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -2213,10 +2424,7 @@ func (h *ReservationHandler) GetSpaceReservations(c *gin.Context) {
 		if err.Error() == "access denied" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to get space reservations",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to get space reservations", err)
 		return
 	}
 
@@ -2252,6 +2460,69 @@ func (h *ReservationHandler) GetSpaceReservations(c *gin.Context) {
 	})
 }
 
+// GetSpaceTimeline returns a space's day as free, busy and buffer segments
+// @Summary Get space timeline
+// @Description Split the day into free, busy and buffer segments for a Gantt-style view. Busy segments list their bookings; private bookings of others only show their time unless the caller manages the space or is an admin.
+// @Tags spaces
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Param date query string false "Day (YYYY-MM-DD), defaults to today"
+// @Param timezone query string false "IANA timezone the day is taken in, default UTC"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SpaceTimelineResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /spaces/{id}/timeline [get]
+func (h *ReservationHandler) GetSpaceTimeline(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	location := time.UTC
+	if tz := c.Query("timezone"); tz != "" {
+		if location, err = time.LoadLocation(tz); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid timezone",
+				Message: "timezone must be an IANA name such as Europe/Paris",
+			})
+			return
+		}
+	}
+
+	day := time.Now().In(location)
+	if value := c.Query("date"); value != "" {
+		if day, err = time.ParseInLocation("2006-01-02", value, location); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid date",
+				Message: "date must use the YYYY-MM-DD format",
+			})
+			return
+		}
+	}
+
+	timeline, err := h.reservationService.GetSpaceTimeline(spaceID, day, userID)
+	if err != nil {
+		respondError(c, h.determineErrorStatus(err), "Failed to get space timeline", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Space timeline retrieved successfully",
+		Data:    timeline,
+	})
+}
+
 // GetPendingApprovals gets reservations needing approval for manager
 // @Summary Get pending approvals
 // @Description Get all reservations pending approval for spaces managed by the current user
@@ -2268,10 +2539,7 @@ func (h *ReservationHandler) GetSpaceReservations(c *gin.Context) {
 func (h *ReservationHandler) GetPendingApprovals(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -2287,10 +2555,7 @@ func (h *ReservationHandler) GetPendingApprovals(c *gin.Context) {
 		if err.Error() == "only managers and admins can view pending approvals" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to get pending approvals",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to get pending approvals", err)
 		return
 	}
 
@@ -2347,19 +2612,13 @@ func (h *ReservationHandler) ApproveReservation(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
 	var req dto.ApprovalRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
 
@@ -2367,10 +2626,7 @@ func (h *ReservationHandler) ApproveReservation(c *gin.Context) {
 	err = h.reservationService.ApproveReservation(reservationID, userID, req.Comments)
 	if err != nil {
 		status := h.determineApprovalErrorStatus(err)
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to approve reservation",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to approve reservation", err)
 		return
 	}
 
@@ -2391,6 +2647,21 @@ func (h *ReservationHandler) ApproveReservation(c *gin.Context) {
 		return
 	}
 
+	// Under a two-step rule the first approval leaves the reservation pending
+	if reservation.AwaitsSecondApproval() {
+		c.JSON(http.StatusOK, dto.SuccessResponse{
+			Success: true,
+			Message: "First approval recorded, awaiting approval by another admin",
+			Data: map[string]interface{}{
+				"reservation": reservation,
+				"action":      "first_approval",
+				"approved_by": userID,
+				"comments":    req.Comments,
+			},
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, dto.SuccessResponse{
 		Success: true,
 		Message: "Reservation approved successfully",
@@ -2403,6 +2674,42 @@ func (h *ReservationHandler) ApproveReservation(c *gin.Context) {
 	})
 }
 
+// BulkApproveReservations approves or rejects several pending reservations at once
+// @Summary Bulk approve or reject reservations
+// @Description Apply one decision to up to 100 reservations; each is processed on its own and the result lists the outcome of every one (managers and admins only)
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param request body dto.BulkApprovalRequest true "Reservations and decision"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BulkApprovalResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /manager/approvals/bulk [post]
+func (h *ReservationHandler) BulkApproveReservations(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.BulkApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	result, err := h.reservationService.BulkApproveReservations(&req, userID)
+	if err != nil {
+		respondError(c, h.determineApprovalErrorStatus(err), "Failed to process approvals", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: fmt.Sprintf("%d of %d reservations processed", result.SuccessCount, result.TotalCount),
+		Data:    result,
+	})
+}
+
 // RejectReservation rejects a pending reservation
 // @Summary Reject reservation
 // @Description Reject a pending reservation with reason (managers and admins only)
@@ -2428,19 +2735,13 @@ func (h *ReservationHandler) RejectReservation(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
 	var req dto.RejectionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
 
@@ -2457,10 +2758,7 @@ func (h *ReservationHandler) RejectReservation(c *gin.Context) {
 	err = h.reservationService.RejectReservation(reservationID, userID, req.Reason)
 	if err != nil {
 		status := h.determineApprovalErrorStatus(err)
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to reject reservation",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to reject reservation", err)
 		return
 	}
 
@@ -2494,6 +2792,134 @@ func (h *ReservationHandler) RejectReservation(c *gin.Context) {
 	})
 }
 
+// GetConflictAnalytics reports how often users tried to book already-taken slots, per space
+// @Summary Booking conflict analytics
+// @Description Count booking attempts rejected because the slot was taken or VIP-blocked, per space. Managers see the spaces they manage, admins see all spaces.
+// @Tags reports
+// @Produce json
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to 30 days ago"
+// @Param end_date query string false "End date (YYYY-MM-DD), defaults to today"
+// @Param space_id query string false "Filter by space ID" format(uuid)
+// @Param threshold query int false "Conflicts in the period from which a space is flagged as chronically contested" default(5)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /manager/analytics/conflicts [get]
+func (h *ReservationHandler) GetConflictAnalytics(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	startDate, endDate, spaceID, ok := h.parseAnalyticsFilters(c)
+	if !ok {
+		return
+	}
+
+	threshold := utils.GetIntQuery(c, "threshold", 5)
+	if threshold < 1 {
+		threshold = 5
+	}
+
+	report, err := h.reservationService.GetConflictReport(userID, startDate, endDate, spaceID, int64(threshold))
+	if err != nil {
+		respondError(c, h.determineApprovalErrorStatus(err), "Failed to generate conflict analytics", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Booking conflict analytics generated successfully",
+		Data:    report,
+	})
+}
+
+// GetUtilizationAnalytics reports how much of the booked time was actually used, per space
+// @Summary Session utilization analytics
+// @Description Compare the booked time of checked-out reservations with the time between check-in and check-out, per space, least used first. Early check-outs and the booked time they freed are counted. Managers see the spaces they manage, admins see all spaces.
+// @Tags reports
+// @Produce json
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to 30 days ago"
+// @Param end_date query string false "End date (YYYY-MM-DD), defaults to today"
+// @Param space_id query string false "Filter by space ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse{data=dto.SessionUtilizationReport}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /manager/analytics/utilization [get]
+func (h *ReservationHandler) GetUtilizationAnalytics(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	startDate, endDate, spaceID, ok := h.parseAnalyticsFilters(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reservationService.GetUtilizationReport(userID, startDate, endDate, spaceID)
+	if err != nil {
+		respondError(c, h.determineApprovalErrorStatus(err), "Failed to generate utilization analytics", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Utilization analytics generated successfully",
+		Data:    report,
+	})
+}
+
+// parseAnalyticsFilters parses the start_date, end_date and space_id query parameters of the
+// analytics endpoints, responding with 400 when one is invalid. The period defaults to the
+// last 30 days and includes the whole end day.
+func (h *ReservationHandler) parseAnalyticsFilters(c *gin.Context) (time.Time, time.Time, *uuid.UUID, bool) {
+	now := time.Now().UTC()
+	startDate := now.AddDate(0, 0, -30)
+	endDate := now
+
+	if raw := c.Query("start_date"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid start date",
+				Message: "start_date must use the YYYY-MM-DD format",
+			})
+			return time.Time{}, time.Time{}, nil, false
+		}
+		startDate = parsed
+	}
+
+	if raw := c.Query("end_date"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid end date",
+				Message: "end_date must use the YYYY-MM-DD format",
+			})
+			return time.Time{}, time.Time{}, nil, false
+		}
+		endDate = parsed.AddDate(0, 0, 1) // Include the whole end day
+	}
+
+	var spaceID *uuid.UUID
+	if raw := c.Query("space_id"); raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid space ID",
+				Message: "Space ID must be a valid UUID",
+			})
+			return time.Time{}, time.Time{}, nil, false
+		}
+		spaceID = &parsed
+	}
+
+	return startDate, endDate, spaceID, true
+}
+
 func (h *ReservationHandler) determineApprovalErrorStatus(err error) int {
 	switch err.Error() {
 	case "access denied":
@@ -2502,6 +2928,12 @@ func (h *ReservationHandler) determineApprovalErrorStatus(err error) int {
 		return http.StatusConflict
 	case "only managers and admins can view pending approvals":
 		return http.StatusForbidden
+	case "second approval must come from another admin":
+		return http.StatusForbidden
+	case "only managers and admins can view conflict analytics":
+		return http.StatusForbidden
+	case "only managers and admins can view utilization analytics":
+		return http.StatusForbidden
 	case "rejection reason is required":
 		return http.StatusBadRequest
 	default:
@@ -2579,17 +3011,45 @@ func (h *ReservationHandler) isValidCalendarView(view string) bool {
 }
 
 // buildCalendarResponse builds calendar-specific response
-func (h *ReservationHandler) buildCalendarResponse(reservations interface{}, startDate, endDate time.Time, view string) map[string]interface{} {
+func (h *ReservationHandler) buildCalendarResponse(reservations []*models.Reservation, startDate, endDate time.Time, view string) map[string]interface{} {
 	return map[string]interface{}{
 		"view":          view,
 		"start_date":    startDate,
 		"end_date":      endDate,
 		"reservations":  reservations,
+		"buffers":       h.buildCalendarBuffers(reservations),
 		"calendar_data": h.organizeByCalendarView(reservations, view),
 		"summary":       h.buildCalendarSummary(reservations, startDate, endDate),
 	}
 }
 
+// buildCalendarBuffers lists the buffer time blocked around each reservation so the
+// calendar can show the space as unavailable there
+func (h *ReservationHandler) buildCalendarBuffers(reservations []*models.Reservation) []dto.CalendarBuffer {
+	buffers := []dto.CalendarBuffer{}
+	for _, reservation := range reservations {
+		if reservation.Space.BufferBefore > 0 {
+			buffers = append(buffers, dto.CalendarBuffer{
+				ReservationID: reservation.ID,
+				SpaceID:       reservation.SpaceID,
+				Position:      "before",
+				StartTime:     reservation.StartTime.Add(-time.Duration(reservation.Space.BufferBefore) * time.Minute),
+				EndTime:       reservation.StartTime,
+			})
+		}
+		if reservation.Space.BufferAfter > 0 {
+			buffers = append(buffers, dto.CalendarBuffer{
+				ReservationID: reservation.ID,
+				SpaceID:       reservation.SpaceID,
+				Position:      "after",
+				StartTime:     reservation.EndTime,
+				EndTime:       reservation.EndTime.Add(time.Duration(reservation.Space.BufferAfter) * time.Minute),
+			})
+		}
+	}
+	return buffers
+}
+
 // organizeByCalendarView organizes reservations by calendar view
 func (h *ReservationHandler) organizeByCalendarView(reservations interface{}, view string) interface{} {
 	// This would organize reservations by day/week/month based on view
@@ -0,0 +1,201 @@
+// internal/handlers/reservation_import_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReservationImportHandler handles bulk reservation import requests
+type ReservationImportHandler struct {
+	importService *services.ReservationImportService
+}
+
+// NewReservationImportHandler creates a new reservation import handler
+func NewReservationImportHandler(importService *services.ReservationImportService) *ReservationImportHandler {
+	return &ReservationImportHandler{
+		importService: importService,
+	}
+}
+
+// ========================================
+// GOOGLE SHEETS IMPORT
+// ========================================
+
+// PreviewSheetImport previews reservations from a shared Google Sheet
+// @Summary Preview Google Sheets import
+// @Description Read a shared Google Sheet (read-only), map its columns to bookings and report conflicts without creating anything
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param request body dto.SheetImportRequest true "Sheet import request"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 502 {object} dto.ErrorResponse
+// @Router /reservations/import/sheets/preview [post]
+func (h *ReservationImportHandler) PreviewSheetImport(c *gin.Context) {
+	if _, err := h.extractUserID(c); err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SheetImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	preview, err := h.importService.PreviewSheetImport(&req)
+	if err != nil {
+		respondError(c, h.determineImportErrorStatus(err), "Failed to preview sheet import", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Sheet import preview generated successfully",
+		Data:    preview,
+	})
+}
+
+// CommitSheetImport creates reservations for approved sheet rows
+// @Summary Commit Google Sheets import
+// @Description Create reservations for the approved rows of a shared Google Sheet; rows are re-validated before booking
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param request body dto.SheetImportRequest true "Sheet import request with approved_rows"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 502 {object} dto.ErrorResponse
+// @Router /reservations/import/sheets/commit [post]
+func (h *ReservationImportHandler) CommitSheetImport(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SheetImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	result, err := h.importService.CommitSheetImport(&req, userID)
+	if err != nil {
+		respondError(c, h.determineImportErrorStatus(err), "Failed to import reservations", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: fmt.Sprintf("%d reservation(s) imported successfully", result.CreatedCount),
+		Data:    result,
+	})
+}
+
+// ========================================
+// CSV IMPORT
+// ========================================
+
+// ImportCSV books reservations from a CSV file (admin only)
+// @Summary Import reservations from CSV
+// @Description Migrate bookings from a legacy system. The file has a header row with space (ID or name), start_time, end_time and title columns, and optionally participant_count, description and user_email for the booker (the importer otherwise). Every row is validated and reported; dry_run books nothing. Rows clashing with a booking or an earlier row are skipped, booked anyway with on_conflict=force, or booked pending approval with on_conflict=queue_approval.
+// @Tags reservations
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file"
+// @Param dry_run formData bool false "Only validate the rows" default(false)
+// @Param on_conflict formData string false "Conflict strategy" Enums(skip, force, queue_approval) default(skip)
+// @Param timezone formData string false "IANA timezone of dates without an offset"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /admin/reservations/import [post]
+func (h *ReservationImportHandler) ImportCSV(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CSVImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+	if err := dto.Validate(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "No file provided", err)
+		return
+	}
+	defer file.Close()
+
+	result, err := h.importService.ImportCSV(file, &req, userID)
+	if err != nil {
+		respondError(c, h.determineImportErrorStatus(err), "Failed to import reservations", err)
+		return
+	}
+
+	message := fmt.Sprintf("%d reservation(s) imported, %d pending approval", result.CreatedCount, result.QueuedCount)
+	if result.DryRun {
+		message = fmt.Sprintf("Dry run: %d row(s) ready, %d in conflict, %d invalid", result.ReadyCount, result.ConflictCount, result.InvalidCount)
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: message,
+		Data:    result,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *ReservationImportHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineImportErrorStatus determines HTTP status code based on error message
+func (h *ReservationImportHandler) determineImportErrorStatus(err error) int {
+	switch {
+	case strings.HasPrefix(err.Error(), "failed to fetch sheet"):
+		return http.StatusBadGateway
+	case strings.HasPrefix(err.Error(), "failed to get spaces"),
+		strings.HasPrefix(err.Error(), "failed to get user"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
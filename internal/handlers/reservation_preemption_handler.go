@@ -0,0 +1,108 @@
+// internal/handlers/reservation_preemption_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReservationPreemptionHandler reports the reservations displaced by higher-priority bookings
+type ReservationPreemptionHandler struct {
+	preemptionService *services.ReservationPreemptionService
+}
+
+// NewReservationPreemptionHandler creates a new reservation preemption handler
+func NewReservationPreemptionHandler(preemptionService *services.ReservationPreemptionService) *ReservationPreemptionHandler {
+	return &ReservationPreemptionHandler{
+		preemptionService: preemptionService,
+	}
+}
+
+// GetPreemptionReport reports the pending reservations preempted in a period (admin only)
+// @Summary Preemption audit report
+// @Description List pending reservations cancelled for a higher-priority booking, with both users' priorities, the justification and the spaces suggested for rebooking
+// @Tags reports
+// @Produce json
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to 30 days ago"
+// @Param end_date query string false "End date (YYYY-MM-DD), defaults to today"
+// @Param space_id query string false "Filter by space ID" format(uuid)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/reports/preemptions [get]
+func (h *ReservationPreemptionHandler) GetPreemptionReport(c *gin.Context) {
+	now := time.Now().UTC()
+	startDate := now.AddDate(0, 0, -30)
+	endDate := now
+
+	if raw := c.Query("start_date"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid start date",
+				Message: "start_date must use the YYYY-MM-DD format",
+			})
+			return
+		}
+		startDate = parsed
+	}
+
+	if raw := c.Query("end_date"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid end date",
+				Message: "end_date must use the YYYY-MM-DD format",
+			})
+			return
+		}
+		endDate = parsed.AddDate(0, 0, 1) // Include the whole end day
+	}
+
+	var spaceID *uuid.UUID
+	if raw := c.Query("space_id"); raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid space ID",
+				Message: "Space ID must be a valid UUID",
+			})
+			return
+		}
+		spaceID = &parsed
+	}
+
+	page := utils.GetIntQuery(c, "page", 1)
+	limit := utils.GetIntQuery(c, "limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	report, err := h.preemptionService.GetReport(startDate, endDate, spaceID, page, limit)
+	if err != nil {
+		status := http.StatusBadRequest
+		if strings.HasPrefix(err.Error(), "failed to") {
+			status = http.StatusInternalServerError
+		}
+		respondError(c, status, "Failed to generate preemption report", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Preemption report generated successfully",
+		Data:    report,
+	})
+}
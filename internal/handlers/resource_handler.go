@@ -0,0 +1,157 @@
+// internal/handlers/resource_handler.go
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ResourceHandler handles searches within one class of bookable resources
+type ResourceHandler struct {
+	spaceService *services.SpaceService
+}
+
+// NewResourceHandler creates a new resource handler
+func NewResourceHandler(spaceService *services.SpaceService) *ResourceHandler {
+	return &ResourceHandler{
+		spaceService: spaceService,
+	}
+}
+
+// SearchRooms searches bookable rooms
+// @Summary Search rooms
+// @Description Meeting rooms, offices, auditoriums, open spaces and conference rooms that can be booked
+// @Tags resources
+// @Produce json
+// @Param building query string false "Building"
+// @Param floor query int false "Floor"
+// @Param min_capacity query int false "Minimum capacity" minimum(1)
+// @Param available_start query string false "Only rooms free from (RFC3339)"
+// @Param available_end query string false "Only rooms free until (RFC3339)"
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /resources/rooms [get]
+func (h *ResourceHandler) SearchRooms(c *gin.Context) {
+	h.search(c, models.ResourceClassRoom)
+}
+
+// SearchDesks searches bookable desks
+// @Summary Search desks
+// @Description Hot desks that can be booked, filtered by their equipment
+// @Tags resources
+// @Produce json
+// @Param building query string false "Building"
+// @Param floor query int false "Floor"
+// @Param min_monitors query int false "Minimum number of monitors" minimum(1)
+// @Param standing_desk query bool false "Standing desk"
+// @Param docking_station query bool false "Docking station"
+// @Param available_start query string false "Only desks free from (RFC3339)"
+// @Param available_end query string false "Only desks free until (RFC3339)"
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /resources/desks [get]
+func (h *ResourceHandler) SearchDesks(c *gin.Context) {
+	h.search(c, models.ResourceClassDesk)
+}
+
+// SearchParking searches bookable parking spots
+// @Summary Search parking spots
+// @Description Parking spots that can be booked. Spots with requires_license_plate need a license_plate when booked.
+// @Tags resources
+// @Produce json
+// @Param building query string false "Building"
+// @Param floor query int false "Floor (negative for underground levels)"
+// @Param ev_charging query bool false "EV charging"
+// @Param available_start query string false "Only spots free from (RFC3339)"
+// @Param available_end query string false "Only spots free until (RFC3339)"
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /resources/parking [get]
+func (h *ResourceHandler) SearchParking(c *gin.Context) {
+	h.search(c, models.ResourceClassParking)
+}
+
+// SearchEquipment searches bookable equipment
+// @Summary Search equipment
+// @Description Equipment that can be booked on its own or added to a room booking through resource_ids
+// @Tags resources
+// @Produce json
+// @Param building query string false "Building"
+// @Param category query string false "Category, e.g. projector"
+// @Param available_start query string false "Only equipment free from (RFC3339)"
+// @Param available_end query string false "Only equipment free until (RFC3339)"
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /resources/equipment [get]
+func (h *ResourceHandler) SearchEquipment(c *gin.Context) {
+	h.search(c, models.ResourceClassEquipment)
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// search runs a resource search within the class and writes the paginated result
+func (h *ResourceHandler) search(c *gin.Context, class models.ResourceClass) {
+	var req dto.ResourceSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	resources, total, err := h.spaceService.SearchResources(class, &req, h.viewerID(c))
+	if err != nil {
+		respondError(c, h.determineResourceErrorStatus(err), "Failed to search resources", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(resources, total, req.Page, req.Limit))
+}
+
+// viewerID returns the signed-in user searching resources, or nil for anonymous visitors
+func (h *ResourceHandler) viewerID(c *gin.Context) *uuid.UUID {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return nil
+	}
+
+	userIDStr, ok := userID.(string)
+	if !ok {
+		return nil
+	}
+
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil
+	}
+	return &uid
+}
+
+// determineResourceErrorStatus determines HTTP status code based on error message
+func (h *ResourceHandler) determineResourceErrorStatus(err error) int {
+	if strings.HasPrefix(err.Error(), "failed to") {
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,45 @@
+// internal/handlers/retention_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RetentionHandler handles the data retention report
+type RetentionHandler struct {
+	retentionService *services.RetentionService
+}
+
+// NewRetentionHandler creates a new retention handler
+func NewRetentionHandler(retentionService *services.RetentionService) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+	}
+}
+
+// GetReport previews the retention jobs (admin only)
+// @Summary Retention dry run
+// @Description Per kind of data, the retention period, the cutoff and how many records the retention job would anonymize or purge if it ran now. Nothing is changed.
+// @Tags retention
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/retention/report [get]
+func (h *RetentionHandler) GetReport(c *gin.Context) {
+	report, err := h.retentionService.GetReport()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to build retention report", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Retention report generated successfully",
+		Data:    report,
+	})
+}
@@ -0,0 +1,342 @@
+// internal/handlers/room_display_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// How often an idle display stream re-sends the room status
+const displayStreamRefresh = 30 * time.Second
+
+// RoomDisplayHandler handles door display endpoints and their registration
+type RoomDisplayHandler struct {
+	displayService *services.RoomDisplayService
+}
+
+// NewRoomDisplayHandler creates a new room display handler
+func NewRoomDisplayHandler(displayService *services.RoomDisplayService) *RoomDisplayHandler {
+	return &RoomDisplayHandler{
+		displayService: displayService,
+	}
+}
+
+// ========================================
+// DISPLAY ENDPOINTS (API key)
+// ========================================
+
+// GetStatus returns the current and next meeting of the display's space
+// @Summary Door display status
+// @Description Current and next meeting for the space the display is registered to
+// @Tags displays
+// @Produce json
+// @Param X-Display-Key header string true "Display API key"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /display/status [get]
+func (h *RoomDisplayHandler) GetStatus(c *gin.Context) {
+	display := h.currentDisplay(c)
+
+	status, err := h.displayService.GetStatus(display.SpaceID)
+	if err != nil {
+		respondError(c, h.determineDisplayErrorStatus(err), "Failed to get room status", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Room status retrieved successfully",
+		Data:    status,
+	})
+}
+
+// ExtendMeeting adds fifteen minutes to the meeting in progress
+// @Summary Extend current meeting
+// @Description One-tap action on the door display; fails when the room is booked right after
+// @Tags displays
+// @Produce json
+// @Param X-Display-Key header string true "Display API key"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /display/extend [post]
+func (h *RoomDisplayHandler) ExtendMeeting(c *gin.Context) {
+	display := h.currentDisplay(c)
+
+	status, err := h.displayService.ExtendCurrentMeeting(display.SpaceID)
+	if err != nil {
+		respondError(c, h.determineDisplayErrorStatus(err), "Failed to extend meeting", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Meeting extended by 15 minutes",
+		Data:    status,
+	})
+}
+
+// EndMeeting ends the meeting in progress and frees the room
+// @Summary End current meeting early
+// @Description One-tap action on the door display releasing the room now
+// @Tags displays
+// @Produce json
+// @Param X-Display-Key header string true "Display API key"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /display/end [post]
+func (h *RoomDisplayHandler) EndMeeting(c *gin.Context) {
+	display := h.currentDisplay(c)
+
+	status, err := h.displayService.EndCurrentMeeting(display.SpaceID)
+	if err != nil {
+		respondError(c, h.determineDisplayErrorStatus(err), "Failed to end meeting", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Meeting ended, room released",
+		Data:    status,
+	})
+}
+
+// Stream pushes the room status as server-sent events
+// @Summary Door display stream (SSE)
+// @Description Sends a "status" event on connect, whenever a reservation of the space changes, when a meeting starts or ends, and every 30s. The key may be passed as ?key= for EventSource clients.
+// @Tags displays
+// @Produce text/event-stream
+// @Param X-Display-Key header string false "Display API key"
+// @Param key query string false "Display API key, for clients that cannot set headers"
+// @Success 200 {string} string "Event stream"
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /display/stream [get]
+func (h *RoomDisplayHandler) Stream(c *gin.Context) {
+	display := h.currentDisplay(c)
+
+	// The stream stays open past the server write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", eventStreamRetry.Milliseconds())
+
+	ctx, cancel := streamContext(c)
+	defer cancel()
+	cursor := h.displayService.LatestEvent()
+	for {
+		status, err := h.displayService.GetStatus(display.SpaceID)
+		if err != nil {
+			fmt.Fprintf(c.Writer, "event: error\ndata: %q\n\n", err.Error())
+			c.Writer.Flush()
+			return
+		}
+
+		data, err := json.Marshal(status)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(c.Writer, "event: status\ndata: %s\n\n", data)
+		c.Writer.Flush()
+
+		// Wake up for the next meeting boundary even without any reservation change
+		wait := displayStreamRefresh
+		if boundary := h.nextBoundary(status); boundary != nil {
+			if untilBoundary := time.Until(*boundary) + time.Second; untilBoundary < wait {
+				wait = untilBoundary
+			}
+		}
+
+		cursor, _ = h.displayService.WaitForChange(ctx, display.SpaceID, cursor, wait)
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// ========================================
+// REGISTRATION ENDPOINTS (admin)
+// ========================================
+
+// CreateDisplay registers a door display for a space (admin only)
+// @Summary Register door display
+// @Description Create an API key for a display mounted outside the space; the key is only shown once
+// @Tags displays
+// @Accept json
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Param request body dto.CreateRoomDisplayRequest true "Display"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/displays [post]
+func (h *RoomDisplayHandler) CreateDisplay(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateRoomDisplayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	display, err := h.displayService.CreateDisplay(spaceID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineDisplayErrorStatus(err), "Failed to register display", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Display registered successfully, store the API key now",
+		Data:    display,
+	})
+}
+
+// GetSpaceDisplays lists the door displays of a space (admin only)
+// @Summary List door displays
+// @Description Get the displays registered for a space, including revoked ones
+// @Tags displays
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/displays [get]
+func (h *RoomDisplayHandler) GetSpaceDisplays(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	displays, err := h.displayService.GetSpaceDisplays(spaceID)
+	if err != nil {
+		respondError(c, h.determineDisplayErrorStatus(err), "Failed to get displays", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Displays retrieved successfully",
+		Data:    displays,
+	})
+}
+
+// RevokeDisplay disables a door display key (admin only)
+// @Summary Revoke door display
+// @Description Revoke a display API key, e.g. when a device is lost or replaced
+// @Tags displays
+// @Produce json
+// @Param id path string true "Display ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/displays/{id} [delete]
+func (h *RoomDisplayHandler) RevokeDisplay(c *gin.Context) {
+	displayID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid display ID",
+			Message: "Display ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.displayService.RevokeDisplay(displayID); err != nil {
+		respondError(c, h.determineDisplayErrorStatus(err), "Failed to revoke display", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Display revoked successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// currentDisplay returns the display set by the display key middleware
+func (h *RoomDisplayHandler) currentDisplay(c *gin.Context) *models.RoomDisplay {
+	return c.MustGet("room_display").(*models.RoomDisplay)
+}
+
+// nextBoundary returns when the displayed meeting ends or the next one starts
+func (h *RoomDisplayHandler) nextBoundary(status *dto.RoomDisplayStatusResponse) *time.Time {
+	if status.Current != nil {
+		return &status.Current.EndTime
+	}
+	if status.Next != nil {
+		return &status.Next.StartTime
+	}
+	return nil
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *RoomDisplayHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineDisplayErrorStatus determines HTTP status code based on error message
+func (h *RoomDisplayHandler) determineDisplayErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"),
+		err.Error() == "no meeting in progress":
+		return http.StatusNotFound
+	case err.Error() == "space is booked right after this reservation",
+		err.Error() == "display is already revoked":
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,103 @@
+// internal/handlers/room_swap_handler.go
+package handlers
+
+import (
+	"net/http"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoomSwapHandler handles the links of room swap suggestions
+type RoomSwapHandler struct {
+	roomSwapService *services.RoomSwapService
+}
+
+// NewRoomSwapHandler creates a new room swap handler
+func NewRoomSwapHandler(roomSwapService *services.RoomSwapService) *RoomSwapHandler {
+	return &RoomSwapHandler{
+		roomSwapService: roomSwapService,
+	}
+}
+
+// GetSuggestion shows a room swap suggestion (the token authorizes access)
+// @Summary Get room swap suggestion
+// @Description Link from the notification showing the meeting, its room and the suggested one. Opening the link does not respond; accept or decline with a POST.
+// @Tags reservations
+// @Produce json
+// @Param token path string true "Suggestion token"
+// @Success 200 {object} dto.SuccessResponse{data=dto.RoomSwapSuggestionResponse}
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /room-swaps/{token} [get]
+func (h *RoomSwapHandler) GetSuggestion(c *gin.Context) {
+	suggestion, err := h.roomSwapService.GetSuggestion(c.Param("token"))
+	if err != nil {
+		respondError(c, h.determineSwapErrorStatus(err), "Failed to get room swap", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Room swap suggestion retrieved successfully",
+		Data:    suggestion,
+	})
+}
+
+// AcceptSuggestion moves a reservation into the suggested room
+// @Summary Accept room swap suggestion
+// @Description Confirm the suggestion from the notification link; the token authorizes the swap
+// @Tags reservations
+// @Produce json
+// @Param token path string true "Suggestion token"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /room-swaps/{token}/accept [post]
+func (h *RoomSwapHandler) AcceptSuggestion(c *gin.Context) {
+	reservation, err := h.roomSwapService.AcceptSuggestion(c.Param("token"))
+	if err != nil {
+		respondError(c, h.determineSwapErrorStatus(err), "Failed to accept room swap", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Reservation moved to the suggested room",
+		Data:    dto.ToReservationResponse(reservation),
+	})
+}
+
+// DeclineSuggestion keeps a reservation in its current room
+// @Summary Decline room swap suggestion
+// @Description Decline the suggestion from the notification link; the reservation stays unchanged
+// @Tags reservations
+// @Produce json
+// @Param token path string true "Suggestion token"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /room-swaps/{token}/decline [post]
+func (h *RoomSwapHandler) DeclineSuggestion(c *gin.Context) {
+	if err := h.roomSwapService.DeclineSuggestion(c.Param("token")); err != nil {
+		respondError(c, h.determineSwapErrorStatus(err), "Failed to decline room swap", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Room swap suggestion declined",
+	})
+}
+
+// determineSwapErrorStatus determines HTTP status code based on error message
+func (h *RoomSwapHandler) determineSwapErrorStatus(err error) int {
+	switch err.Error() {
+	case "invalid or expired suggestion link":
+		return http.StatusNotFound
+	case "suggested room is no longer available", "reservation has changed since the suggestion was made":
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
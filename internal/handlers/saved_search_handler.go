@@ -0,0 +1,216 @@
+// internal/handlers/saved_search_handler.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SavedSearchHandler handles saved space searches
+type SavedSearchHandler struct {
+	savedSearchService *services.SavedSearchService
+}
+
+// NewSavedSearchHandler creates a new saved search handler
+func NewSavedSearchHandler(savedSearchService *services.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		savedSearchService: savedSearchService,
+	}
+}
+
+// GetSearches lists the saved searches of the current user
+// @Summary List saved searches
+// @Description Space searches the user saved, by name
+// @Tags spaces
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /spaces/saved-searches [get]
+func (h *SavedSearchHandler) GetSearches(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	searches, err := h.savedSearchService.GetSearches(userID)
+	if err != nil {
+		respondError(c, h.determineSavedSearchErrorStatus(err), "Failed to get saved searches", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Saved searches retrieved successfully",
+		Data:    searches,
+	})
+}
+
+// CreateSearch saves a space search for the current user
+// @Summary Create saved search
+// @Description Save space criteria and an optional time window. With alerts enabled, the user is notified when a cancellation frees a matching space for at least the minimum duration.
+// @Tags spaces
+// @Accept json
+// @Produce json
+// @Param request body dto.SavedSearchRequest true "Saved search"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /spaces/saved-searches [post]
+func (h *SavedSearchHandler) CreateSearch(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SavedSearchRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	search, err := h.savedSearchService.CreateSearch(&req, userID)
+	if err != nil {
+		respondError(c, h.determineSavedSearchErrorStatus(err), "Failed to create saved search", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Saved search created successfully",
+		Data:    search,
+	})
+}
+
+// UpdateSearch replaces a saved search of the current user
+// @Summary Update saved search
+// @Description Replace every field of a saved search
+// @Tags spaces
+// @Accept json
+// @Produce json
+// @Param id path string true "Saved search ID" format(uuid)
+// @Param request body dto.SavedSearchRequest true "Saved search"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /spaces/saved-searches/{id} [put]
+func (h *SavedSearchHandler) UpdateSearch(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	searchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid saved search ID",
+			Message: "Saved search ID must be a valid UUID",
+		})
+		return
+	}
+
+	var req dto.SavedSearchRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	search, err := h.savedSearchService.UpdateSearch(searchID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineSavedSearchErrorStatus(err), "Failed to update saved search", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Saved search updated successfully",
+		Data:    search,
+	})
+}
+
+// DeleteSearch removes a saved search of the current user
+// @Summary Delete saved search
+// @Description Delete a saved search and stop its alerts
+// @Tags spaces
+// @Produce json
+// @Param id path string true "Saved search ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /spaces/saved-searches/{id} [delete]
+func (h *SavedSearchHandler) DeleteSearch(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	searchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid saved search ID",
+			Message: "Saved search ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.savedSearchService.DeleteSearch(searchID, userID); err != nil {
+		respondError(c, h.determineSavedSearchErrorStatus(err), "Failed to delete saved search", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Saved search deleted successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *SavedSearchHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineSavedSearchErrorStatus determines HTTP status code based on error message
+func (h *SavedSearchHandler) determineSavedSearchErrorStatus(err error) int {
+	var fields dto.ValidationErrors
+	if errors.As(err, &fields) {
+		return http.StatusBadRequest
+	}
+
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
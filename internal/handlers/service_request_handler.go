@@ -0,0 +1,437 @@
+// internal/handlers/service_request_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ServiceRequestHandler handles the services catalog, the services ordered with reservations
+// and the fulfillment queue of facility staff
+type ServiceRequestHandler struct {
+	serviceRequestService *services.ServiceRequestService
+}
+
+// NewServiceRequestHandler creates a new service request handler
+func NewServiceRequestHandler(serviceRequestService *services.ServiceRequestService) *ServiceRequestHandler {
+	return &ServiceRequestHandler{
+		serviceRequestService: serviceRequestService,
+	}
+}
+
+// ========================================
+// ORGANIZER ENDPOINTS
+// ========================================
+
+// GetCatalog lists the services that can be ordered with a reservation
+// @Summary Services catalog
+// @Description Catering, AV support, furniture and other services on offer, with their price and how long in advance they must be ordered
+// @Tags services
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /services [get]
+func (h *ServiceRequestHandler) GetCatalog(c *gin.Context) {
+	items, err := h.serviceRequestService.GetCatalog(true)
+	if err != nil {
+		respondError(c, h.determineServiceErrorStatus(err), "Failed to get services catalog", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Services catalog retrieved successfully",
+		Data:    items,
+	})
+}
+
+// GetReservationServices lists the services ordered with a reservation
+// @Summary Reservation services
+// @Description Services ordered with the reservation, their status and total cost
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/{id}/services [get]
+func (h *ServiceRequestHandler) GetReservationServices(c *gin.Context) {
+	reservationID, ok := h.parseID(c, "id", "reservation")
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	response, err := h.serviceRequestService.GetReservationServices(reservationID, userID)
+	if err != nil {
+		respondError(c, h.determineServiceErrorStatus(err), "Failed to get reservation services", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Reservation services retrieved successfully",
+		Data:    response,
+	})
+}
+
+// OrderService orders a service with a reservation
+// @Summary Order service
+// @Description Order a catalog service such as catering or extra chairs for the reservation. It must be ordered at least the service's lead time before the reservation starts; its cost is charged back with the reservation once delivered.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param request body dto.CreateServiceRequestRequest true "Service"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reservations/{id}/services [post]
+func (h *ServiceRequestHandler) OrderService(c *gin.Context) {
+	reservationID, ok := h.parseID(c, "id", "reservation")
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateServiceRequestRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	request, err := h.serviceRequestService.OrderService(reservationID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineServiceErrorStatus(err), "Failed to order service", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Service ordered successfully",
+		Data:    request,
+	})
+}
+
+// CancelServiceRequest withdraws a service ordered with a reservation
+// @Summary Cancel service request
+// @Description Withdraw a service that was not delivered yet
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param requestId path string true "Service request ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /reservations/{id}/services/{requestId} [delete]
+func (h *ServiceRequestHandler) CancelServiceRequest(c *gin.Context) {
+	reservationID, ok := h.parseID(c, "id", "reservation")
+	if !ok {
+		return
+	}
+	requestID, ok := h.parseID(c, "requestId", "service request")
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	if err := h.serviceRequestService.CancelServiceRequest(reservationID, requestID, userID); err != nil {
+		respondError(c, h.determineServiceErrorStatus(err), "Failed to cancel service request", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Service request cancelled successfully",
+	})
+}
+
+// ========================================
+// FACILITIES ENDPOINTS
+// ========================================
+
+// GetQueue lists the service requests facility staff work on
+// @Summary Service fulfillment queue
+// @Description Service requests soonest due first; open ones (requested and accepted) unless statuses are given
+// @Tags services
+// @Produce json
+// @Param status query string false "Comma-separated statuses (requested, accepted, fulfilled, rejected, cancelled)"
+// @Param category query string false "Service category" Enums(catering, av_support, furniture, other)
+// @Param building query string false "Building of the reservation's space"
+// @Param from query string false "Due at or after (RFC3339)"
+// @Param to query string false "Due before (RFC3339)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(50)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /facilities/service-requests [get]
+func (h *ServiceRequestHandler) GetQueue(c *gin.Context) {
+	var filters interfaces.ServiceQueueFilters
+	if value := c.Query("status"); value != "" {
+		for _, status := range strings.Split(value, ",") {
+			filters.Statuses = append(filters.Statuses, models.ServiceRequestStatus(strings.TrimSpace(status)))
+		}
+	}
+	if value := c.Query("category"); value != "" {
+		category := models.ServiceCategory(value)
+		filters.Category = &category
+	}
+	if value := c.Query("building"); value != "" {
+		filters.Building = &value
+	}
+	for _, param := range []string{"from", "to"} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid " + param,
+				Message: param + " must be in RFC3339 format (e.g., 2023-12-25T10:00:00Z)",
+			})
+			return
+		}
+		if param == "from" {
+			filters.From = &parsed
+		} else {
+			filters.To = &parsed
+		}
+	}
+
+	page := utils.GetIntQuery(c, "page", 1)
+	limit := utils.GetIntQuery(c, "limit", 50)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	requests, total, err := h.serviceRequestService.GetQueue(filters, (page-1)*limit, limit)
+	if err != nil {
+		respondError(c, h.determineServiceErrorStatus(err), "Failed to get service queue", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(requests, total, page, limit))
+}
+
+// UpdateStatus moves a service request along the fulfillment queue
+// @Summary Update service request status
+// @Description Accept, fulfill or reject a service request. Requested ones can be accepted or rejected, accepted ones fulfilled or rejected; organizers are notified of rejections.
+// @Tags services
+// @Accept json
+// @Produce json
+// @Param id path string true "Service request ID" format(uuid)
+// @Param request body dto.UpdateServiceRequestStatusRequest true "Status"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /facilities/service-requests/{id}/status [put]
+func (h *ServiceRequestHandler) UpdateStatus(c *gin.Context) {
+	requestID, ok := h.parseID(c, "id", "service request")
+	if !ok {
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.UpdateServiceRequestStatusRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	request, err := h.serviceRequestService.UpdateStatus(requestID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineServiceErrorStatus(err), "Failed to update service request", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Service request updated successfully",
+		Data:    request,
+	})
+}
+
+// ========================================
+// ADMIN ENDPOINTS
+// ========================================
+
+// GetAllItems lists the whole services catalog (admin only)
+// @Summary List catalog services
+// @Description Every service of the catalog, including the ones no longer offered
+// @Tags services
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /admin/services [get]
+func (h *ServiceRequestHandler) GetAllItems(c *gin.Context) {
+	items, err := h.serviceRequestService.GetCatalog(false)
+	if err != nil {
+		respondError(c, h.determineServiceErrorStatus(err), "Failed to get services catalog", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Services catalog retrieved successfully",
+		Data:    items,
+	})
+}
+
+// CreateItem adds a service to the catalog (admin only)
+// @Summary Create catalog service
+// @Description Offer a service that can be ordered with reservations, with its unit price and lead time
+// @Tags services
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateServiceItemRequest true "Service"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/services [post]
+func (h *ServiceRequestHandler) CreateItem(c *gin.Context) {
+	var req dto.CreateServiceItemRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	item, err := h.serviceRequestService.CreateItem(&req)
+	if err != nil {
+		respondError(c, h.determineServiceErrorStatus(err), "Failed to create service", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Service created successfully",
+		Data:    item,
+	})
+}
+
+// UpdateItem edits a catalog service (admin only)
+// @Summary Update catalog service
+// @Description Change a service or stop offering it; requests already placed keep their price
+// @Tags services
+// @Accept json
+// @Produce json
+// @Param id path string true "Service ID" format(uuid)
+// @Param request body dto.UpdateServiceItemRequest true "Changes"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/services/{id} [put]
+func (h *ServiceRequestHandler) UpdateItem(c *gin.Context) {
+	itemID, ok := h.parseID(c, "id", "service")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateServiceItemRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	item, err := h.serviceRequestService.UpdateItem(itemID, &req)
+	if err != nil {
+		respondError(c, h.determineServiceErrorStatus(err), "Failed to update service", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Service updated successfully",
+		Data:    item,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// parseID parses a UUID path parameter, writing a 400 response when it is invalid
+func (h *ServiceRequestHandler) parseID(c *gin.Context, param, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		label := strings.ToUpper(name[:1]) + name[1:]
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   fmt.Sprintf("Invalid %s ID", name),
+			Message: fmt.Sprintf("%s ID must be a valid UUID", label),
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *ServiceRequestHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineServiceErrorStatus determines HTTP status code based on error message
+func (h *ServiceRequestHandler) determineServiceErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"),
+		err.Error() == "service not found":
+		return http.StatusNotFound
+	case err.Error() == "access denied":
+		return http.StatusForbidden
+	case strings.HasPrefix(err.Error(), "service request is already"),
+		strings.Contains(err.Error(), "service request cannot be"):
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
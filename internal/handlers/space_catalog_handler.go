@@ -0,0 +1,163 @@
+// internal/handlers/space_catalog_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SpaceCatalogHandler handles the export and import of the whole space catalog
+type SpaceCatalogHandler struct {
+	catalogService *services.SpaceCatalogService
+}
+
+// NewSpaceCatalogHandler creates a new space catalog handler
+func NewSpaceCatalogHandler(catalogService *services.SpaceCatalogService) *SpaceCatalogHandler {
+	return &SpaceCatalogHandler{
+		catalogService: catalogService,
+	}
+}
+
+// ExportCatalog downloads every space with its policies and schedules (admin only)
+// @Summary Export space catalog
+// @Description Download every space by building and name with its booking policies, approval rule and VIP blocks, managers given by email. The JSON file can be imported back as is; the CSV has one line per space, with lists and nested settings as JSON cells, for bulk edits in a spreadsheet.
+// @Tags spaces
+// @Produce json
+// @Produce text/csv
+// @Param format query string false "json or csv" default(json)
+// @Success 200 {object} dto.SpaceCatalog
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/spaces/catalog [get]
+func (h *SpaceCatalogHandler) ExportCatalog(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid format",
+			Message: "format must be json or csv",
+		})
+		return
+	}
+
+	catalog, err := h.catalogService.ExportCatalog()
+	if err != nil {
+		respondError(c, h.determineCatalogErrorStatus(err), "Failed to export space catalog", err)
+		return
+	}
+
+	filename := fmt.Sprintf("space-catalog-%s.%s", catalog.ExportedAt.Format("2006-01-02"), format)
+	if format == "csv" {
+		data, err := h.catalogService.RenderCatalogCSV(catalog)
+		if err != nil {
+			respondError(c, h.determineCatalogErrorStatus(err), "Failed to export space catalog", err)
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Data(http.StatusOK, "text/csv", data)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.JSON(http.StatusOK, catalog)
+}
+
+// ImportCatalog adds or replaces spaces from a catalog file (admin only)
+// @Summary Import space catalog
+// @Description Add or replace spaces matched by building and name, from an exported JSON catalog in the body or a CSV file in the "file" form field. Every field of a matched space is replaced; its approval rule and VIP blocks only when the entry has them. Invalid spaces are reported and skipped. With dry_run the spaces are only validated.
+// @Tags spaces
+// @Accept json
+// @Accept multipart/form-data
+// @Produce json
+// @Param dry_run query bool false "Only validate and report what would change"
+// @Param request body dto.SpaceCatalog false "Catalog, when not uploading a CSV file"
+// @Param file formData file false "Catalog CSV"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SpaceCatalogImportResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/spaces/catalog/import [post]
+func (h *SpaceCatalogHandler) ImportCatalog(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+	dryRun := utils.GetBoolQuery(c, "dry_run", false)
+
+	var result *dto.SpaceCatalogImportResponse
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		file, _, err := c.Request.FormFile("file")
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "No file provided", err)
+			return
+		}
+		defer file.Close()
+
+		result, err = h.catalogService.ImportCatalogCSV(file, dryRun, userID)
+		if err != nil {
+			respondError(c, h.determineCatalogErrorStatus(err), "Failed to import space catalog", err)
+			return
+		}
+	} else {
+		var catalog dto.SpaceCatalog
+		if err := bindJSON(c, &catalog); err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid request data", err)
+			return
+		}
+
+		result, err = h.catalogService.ImportCatalog(&catalog, dryRun, userID)
+		if err != nil {
+			respondError(c, h.determineCatalogErrorStatus(err), "Failed to import space catalog", err)
+			return
+		}
+	}
+
+	message := fmt.Sprintf("%d space(s) created, %d updated, %d invalid, %d failed", result.CreatedCount, result.UpdatedCount, result.InvalidCount, result.FailedCount)
+	if result.DryRun {
+		message = fmt.Sprintf("Dry run: %d space(s) to create, %d to update, %d invalid", result.CreateCount, result.UpdateCount, result.InvalidCount)
+	}
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: message,
+		Data:    result,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *SpaceCatalogHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineCatalogErrorStatus determines HTTP status code based on error message
+func (h *SpaceCatalogHandler) determineCatalogErrorStatus(err error) int {
+	switch {
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
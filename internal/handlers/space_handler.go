@@ -48,10 +48,7 @@ func NewSpaceHandler(spaceService *services.SpaceService) *SpaceHandler {
 func (h *SpaceHandler) CreateSpace(c *gin.Context) {
 	var req dto.CreateSpaceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
 
@@ -71,10 +68,7 @@ func (h *SpaceHandler) CreateSpace(c *gin.Context) {
 		if err.Error() == "only admins and managers can create spaces" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to create space",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to create space", err)
 		return
 	}
 
@@ -105,15 +99,13 @@ func (h *SpaceHandler) GetSpace(c *gin.Context) {
 		return
 	}
 
-	space, err := h.spaceService.GetSpaceByID(spaceID)
+	space, err := h.spaceService.GetSpaceByID(spaceID, h.viewerID(c))
 	if err != nil {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse{
-			Error:   "Space not found",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusNotFound, "Space not found", err)
 		return
 	}
 
+	setETag(c, space.UpdatedAt)
 	c.JSON(http.StatusOK, dto.SuccessResponse{
 		Success: true,
 		Data:    space,
@@ -122,16 +114,18 @@ func (h *SpaceHandler) GetSpace(c *gin.Context) {
 
 // UpdateSpace updates an existing space
 // @Summary Update space
-// @Description Update space details (only managers and admins)
+// @Description Update space details (only managers and admins). Send the ETag of the space in If-Match, or its updated_at in the body, to get a 409 instead of overwriting a change made since it was read.
 // @Tags spaces
 // @Accept json
 // @Produce json
 // @Param id path string true "Space ID" format(uuid)
+// @Param If-Match header string false "ETag of the space as last read"
 // @Param request body dto.UpdateSpaceRequest true "Update space request"
 // @Success 200 {object} dto.SuccessResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Router /spaces/{id} [put]
 func (h *SpaceHandler) UpdateSpace(c *gin.Context) {
 	spaceID, err := uuid.Parse(c.Param("id"))
@@ -145,12 +139,15 @@ func (h *SpaceHandler) UpdateSpace(c *gin.Context) {
 
 	var req dto.UpdateSpaceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
+	if expected, err := ifMatch(c); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	} else if expected != nil {
+		req.UpdatedAt = expected
+	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -166,14 +163,14 @@ func (h *SpaceHandler) UpdateSpace(c *gin.Context) {
 		status := http.StatusBadRequest
 		if err.Error() == "access denied" {
 			status = http.StatusForbidden
+		} else if errors.Is(err, dto.ErrStaleUpdate) {
+			status = http.StatusConflict
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to update space",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to update space", err)
 		return
 	}
 
+	setETag(c, space.UpdatedAt)
 	c.JSON(http.StatusOK, dto.SuccessResponse{
 		Success: true,
 		Message: "Space updated successfully",
@@ -219,10 +216,7 @@ func (h *SpaceHandler) DeleteSpace(c *gin.Context) {
 		if err.Error() == "cannot delete space with active reservations" {
 			status = http.StatusConflict
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to delete space",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to delete space", err)
 		return
 	}
 
@@ -239,6 +233,7 @@ func (h *SpaceHandler) DeleteSpace(c *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number" default(1) minimum(1)
 // @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param cursor query string false "Cursor pagination: empty for the first page, then next_cursor of the previous page; replaces page"
 // @Success 200 {object} dto.PaginatedResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
@@ -257,12 +252,24 @@ func (h *SpaceHandler) GetSpaces(c *gin.Context) {
 
 	offset := (page - 1) * limit
 
-	spaces, total, err := h.spaceService.GetAllSpaces(offset, limit)
+	cursor, useCursor, err := cursorQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get spaces",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid cursor", err)
+		return
+	}
+	if useCursor {
+		spaces, next, err := h.spaceService.GetSpacesPage(cursor, limit, h.viewerID(c))
+		if err != nil {
+			respondError(c, http.StatusInternalServerError, "Failed to get spaces", err)
+			return
+		}
+		c.JSON(http.StatusOK, dto.NewCursorPaginatedResponse(spaces, limit, next))
+		return
+	}
+
+	spaces, total, err := h.spaceService.GetAllSpaces(offset, limit, h.viewerID(c))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get spaces", err)
 		return
 	}
 
@@ -342,6 +349,25 @@ func (h *SpaceHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
 	return uid, nil
 }
 
+// viewerID returns the signed-in user browsing spaces, or nil for anonymous visitors
+func (h *SpaceHandler) viewerID(c *gin.Context) *uuid.UUID {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return nil
+	}
+
+	userIDStr, ok := userID.(string)
+	if !ok {
+		return nil
+	}
+
+	uid, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil
+	}
+	return &uid
+}
+
 // ========================================
 // SEARCH AND FILTER OPERATIONS
 // ========================================
@@ -352,7 +378,7 @@ func (h *SpaceHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
 // @Tags spaces
 // @Produce json
 // @Param query query string false "Search query (searches in name and description)"
-// @Param types query []string false "Space types" Enums(meeting_room, office, auditorium, open_space, hot_desk, conference_room)
+// @Param types query []string false "Space types" Enums(meeting_room, office, auditorium, open_space, hot_desk, conference_room, parking_spot, equipment)
 // @Param buildings query []string false "Buildings to filter by"
 // @Param floors query []int false "Floor numbers to filter by"
 // @Param min_capacity query int false "Minimum capacity" minimum(1)
@@ -369,20 +395,14 @@ func (h *SpaceHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
 func (h *SpaceHandler) SearchSpaces(c *gin.Context) {
 	var req dto.SpaceSearchRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid query parameters",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid query parameters", err)
 		return
 	}
 
 	// Set defaults and validate
 	req.SetDefaults()
-	if err := req.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid search parameters",
-			Message: err.Error(),
-		})
+	if err := dto.Validate(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid search parameters", err)
 		return
 	}
 
@@ -393,12 +413,9 @@ func (h *SpaceHandler) SearchSpaces(c *gin.Context) {
 	// Convert to repository filters
 	filters := h.convertToSpaceFilters(&req)
 
-	spaces, total, err := h.spaceService.SearchSpaces(filters, offset, req.Limit)
+	spaces, total, err := h.spaceService.SearchSpaces(filters, offset, req.Limit, h.viewerID(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to search spaces",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to search spaces", err)
 		return
 	}
 
@@ -435,12 +452,9 @@ func (h *SpaceHandler) GetSpacesByBuilding(c *gin.Context) {
 	page, limit = h.validatePaginationParams(page, limit)
 	offset := (page - 1) * limit
 
-	spaces, total, err := h.spaceService.GetSpacesByBuilding(building, offset, limit)
+	spaces, total, err := h.spaceService.GetSpacesByBuilding(building, offset, limit, h.viewerID(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get spaces",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get spaces", err)
 		return
 	}
 
@@ -453,7 +467,7 @@ func (h *SpaceHandler) GetSpacesByBuilding(c *gin.Context) {
 // @Description Retrieve all spaces of a specific type with pagination
 // @Tags spaces
 // @Produce json
-// @Param type path string true "Space type" Enums(meeting_room, office, auditorium, open_space, hot_desk, conference_room)
+// @Param type path string true "Space type" Enums(meeting_room, office, auditorium, open_space, hot_desk, conference_room, parking_spot, equipment)
 // @Param page query int false "Page number" default(1) minimum(1)
 // @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
 // @Success 200 {object} dto.PaginatedResponse
@@ -484,12 +498,9 @@ func (h *SpaceHandler) GetSpacesByType(c *gin.Context) {
 	page, limit = h.validatePaginationParams(page, limit)
 	offset := (page - 1) * limit
 
-	spaces, total, err := h.spaceService.GetSpacesByType(spaceType, offset, limit)
+	spaces, total, err := h.spaceService.GetSpacesByType(spaceType, offset, limit, h.viewerID(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get spaces",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get spaces", err)
 		return
 	}
 
@@ -531,10 +542,7 @@ func (h *SpaceHandler) GetSpacesByStatus(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -549,10 +557,7 @@ func (h *SpaceHandler) GetSpacesByStatus(c *gin.Context) {
 		if err.Error() == "access denied" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to get spaces",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to get spaces", err)
 		return
 	}
 
@@ -601,10 +606,7 @@ func (h *SpaceHandler) GetSpacesByCapacityRange(c *gin.Context) {
 
 	spaces, total, err := h.spaceService.GetSpacesByCapacityRange(minCapacity, maxCapacity, offset, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get spaces",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get spaces", err)
 		return
 	}
 
@@ -626,20 +628,14 @@ func (h *SpaceHandler) GetSpacesByCapacityRange(c *gin.Context) {
 func (h *SpaceHandler) AdvancedSpaceSearch(c *gin.Context) {
 	var req dto.SpaceFiltersRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
 
 	// Set defaults and validate
 	req.SetDefaults()
-	if err := req.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid search filters",
-			Message: err.Error(),
-		})
+	if err := dto.Validate(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid search filters", err)
 		return
 	}
 
@@ -650,12 +646,9 @@ func (h *SpaceHandler) AdvancedSpaceSearch(c *gin.Context) {
 	// Convert to repository filters
 	filters := h.convertAdvancedFilters(&req)
 
-	spaces, total, err := h.spaceService.SearchSpaces(filters, offset, req.Limit)
+	spaces, total, err := h.spaceService.SearchSpaces(filters, offset, req.Limit, h.viewerID(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to search spaces",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to search spaces", err)
 		return
 	}
 
@@ -725,6 +718,8 @@ func (h *SpaceHandler) isValidSpaceType(spaceType string) bool {
 		"open_space",
 		"hot_desk",
 		"conference_room",
+		"parking_spot",
+		"equipment",
 	}
 
 	for _, validType := range validTypes {
@@ -812,19 +807,13 @@ func (h *SpaceHandler) CheckSpaceAvailability(c *gin.Context) {
 
 	var req dto.SpaceAvailabilityRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
 
 	// Validate the request
-	if err := req.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid availability request",
-			Message: err.Error(),
-		})
+	if err := dto.Validate(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid availability request", err)
 		return
 	}
 
@@ -854,10 +843,7 @@ func (h *SpaceHandler) CheckSpaceAvailability(c *gin.Context) {
 			})
 			return
 		}
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Failed to check availability",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Failed to check availability", err)
 		return
 	}
 
@@ -877,7 +863,7 @@ func (h *SpaceHandler) CheckSpaceAvailability(c *gin.Context) {
 // @Param end_time query string true "End time (RFC3339 format)" format(date-time)
 // @Param min_capacity query int false "Minimum capacity required" minimum(1)
 // @Param max_capacity query int false "Maximum capacity limit" minimum(1)
-// @Param types query []string false "Space types filter" Enums(meeting_room, office, auditorium, open_space, hot_desk, conference_room)
+// @Param types query []string false "Space types filter" Enums(meeting_room, office, auditorium, open_space, hot_desk, conference_room, parking_spot, equipment)
 // @Param buildings query []string false "Buildings filter"
 // @Param page query int false "Page number" default(1) minimum(1)
 // @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
@@ -955,12 +941,9 @@ func (h *SpaceHandler) GetAvailableSpaces(c *gin.Context) {
 	page, limit = h.validatePaginationParams(page, limit)
 	offset := (page - 1) * limit
 
-	spaces, total, err := h.spaceService.GetAvailableSpaces(startTime, endTime, offset, limit)
+	spaces, total, err := h.spaceService.GetAvailableSpaces(startTime, endTime, offset, limit, h.viewerID(c))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Failed to get available spaces",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Failed to get available spaces", err)
 		return
 	}
 
@@ -995,19 +978,13 @@ func (h *SpaceHandler) UpdateSpaceStatus(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
 	var req dto.UpdateSpaceStatusRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
 
@@ -1032,10 +1009,7 @@ func (h *SpaceHandler) UpdateSpaceStatus(c *gin.Context) {
 		if err.Error() == "access denied" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to update space status",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to update space status", err)
 		return
 	}
 
@@ -1066,10 +1040,7 @@ func (h *SpaceHandler) UpdateSpaceStatus(c *gin.Context) {
 func (h *SpaceHandler) BatchCheckAvailability(c *gin.Context) {
 	var req dto.BatchAvailabilityRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
 
@@ -1155,10 +1126,7 @@ func (h *SpaceHandler) BatchCheckAvailability(c *gin.Context) {
 func (h *SpaceHandler) GetSpaceOptions(c *gin.Context) {
 	options, err := h.spaceService.GetSpaceOptions()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get space options",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get space options", err)
 		return
 	}
 
@@ -1180,10 +1148,7 @@ func (h *SpaceHandler) GetSpaceOptions(c *gin.Context) {
 func (h *SpaceHandler) GetBuildings(c *gin.Context) {
 	buildings, err := h.spaceService.GetDistinctBuildings()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get buildings",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get buildings", err)
 		return
 	}
 
@@ -1208,10 +1173,7 @@ func (h *SpaceHandler) GetBuildings(c *gin.Context) {
 func (h *SpaceHandler) GetFloors(c *gin.Context) {
 	floors, err := h.spaceService.GetDistinctFloors()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get floors",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get floors", err)
 		return
 	}
 
@@ -1236,10 +1198,7 @@ func (h *SpaceHandler) GetFloors(c *gin.Context) {
 func (h *SpaceHandler) GetSpaceStatistics(c *gin.Context) {
 	totalSpaces, err := h.spaceService.GetSpaceCount()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get space statistics",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get space statistics", err)
 		return
 	}
 
@@ -1359,10 +1318,7 @@ func (h *SpaceHandler) GetSpacesByManager(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -1377,10 +1333,7 @@ func (h *SpaceHandler) GetSpacesByManager(c *gin.Context) {
 		if err.Error() == "access denied" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to get managed spaces",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to get managed spaces", err)
 		return
 	}
 
@@ -1403,10 +1356,7 @@ func (h *SpaceHandler) GetSpacesByManager(c *gin.Context) {
 func (h *SpaceHandler) GetMyManagedSpaces(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -1419,10 +1369,7 @@ func (h *SpaceHandler) GetMyManagedSpaces(c *gin.Context) {
 
 	spaces, total, err := h.spaceService.GetSpacesByManager(userID, offset, limit, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get managed spaces",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get managed spaces", err)
 		return
 	}
 
@@ -1474,19 +1421,13 @@ func (h *SpaceHandler) AssignManager(c *gin.Context) {
 
 	var req dto.AssignManagerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "Invalid request data",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
 		return
 	}
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -1496,10 +1437,7 @@ func (h *SpaceHandler) AssignManager(c *gin.Context) {
 		if err.Error() == "only administrators can assign managers" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to assign manager",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to assign manager", err)
 		return
 	}
 
@@ -1531,10 +1469,7 @@ func (h *SpaceHandler) UnassignManager(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -1544,10 +1479,7 @@ func (h *SpaceHandler) UnassignManager(c *gin.Context) {
 		if err.Error() == "only administrators can unassign managers" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to unassign manager",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to unassign manager", err)
 		return
 	}
 
@@ -1585,10 +1517,7 @@ func (h *SpaceHandler) GetSpaceReservationCount(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -1598,10 +1527,7 @@ func (h *SpaceHandler) GetSpaceReservationCount(c *gin.Context) {
 		if err.Error() == "access denied" {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, dto.ErrorResponse{
-			Error:   "Failed to get reservation count",
-			Message: err.Error(),
-		})
+		respondError(c, status, "Failed to get reservation count", err)
 		return
 	}
 
@@ -1639,10 +1565,7 @@ func (h *SpaceHandler) GetSpaceCountByStatus(c *gin.Context) {
 
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -1652,10 +1575,7 @@ func (h *SpaceHandler) GetSpaceCountByStatus(c *gin.Context) {
 		if err.Error() == "access denied" {
 			httpStatus = http.StatusForbidden
 		}
-		c.JSON(httpStatus, dto.ErrorResponse{
-			Error:   "Failed to get space count",
-			Message: err.Error(),
-		})
+		respondError(c, httpStatus, "Failed to get space count", err)
 		return
 	}
 
@@ -1690,10 +1610,7 @@ func (h *SpaceHandler) GetSpaceCountByBuilding(c *gin.Context) {
 
 	count, err := h.spaceService.GetSpaceCountByBuilding(building)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get space count",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get space count", err)
 		return
 	}
 
@@ -1720,10 +1637,7 @@ func (h *SpaceHandler) GetSpaceCountByBuilding(c *gin.Context) {
 func (h *SpaceHandler) GetDashboardStatistics(c *gin.Context) {
 	userID, err := h.extractUserID(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
 		return
 	}
 
@@ -1739,10 +1653,7 @@ func (h *SpaceHandler) GetDashboardStatistics(c *gin.Context) {
 	// Get basic space statistics
 	totalSpaces, err := h.spaceService.GetSpaceCount()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Failed to get statistics",
-			Message: err.Error(),
-		})
+		respondError(c, http.StatusInternalServerError, "Failed to get statistics", err)
 		return
 	}
 
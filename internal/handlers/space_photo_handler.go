@@ -0,0 +1,262 @@
+// internal/handlers/space_photo_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SpacePhotoHandler handles the photo galleries of spaces
+type SpacePhotoHandler struct {
+	photoService *services.SpacePhotoService
+}
+
+// NewSpacePhotoHandler creates a new space photo handler
+func NewSpacePhotoHandler(photoService *services.SpacePhotoService) *SpacePhotoHandler {
+	return &SpacePhotoHandler{
+		photoService: photoService,
+	}
+}
+
+// GetPhotos lists the photo gallery of a space (admin only)
+// @Summary List space photos
+// @Description Photos of the space in gallery order, with their thumbnails, dimensions and primary flag
+// @Tags spaces
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/photos [get]
+func (h *SpacePhotoHandler) GetPhotos(c *gin.Context) {
+	spaceID, ok := h.parseSpaceID(c)
+	if !ok {
+		return
+	}
+
+	photos, err := h.photoService.GetPhotos(spaceID)
+	if err != nil {
+		respondError(c, h.determinePhotoErrorStatus(err), "Failed to get photos", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Photos retrieved successfully",
+		Data:    photos,
+	})
+}
+
+// UploadPhotos adds photos to the gallery of a space (admin only)
+// @Summary Upload space photos
+// @Description Upload one or more JPEG, PNG or GIF images in the "photos" form field, 10MB each and 20 per space. Images are stored as JPEG scaled down to fit 1600x1200, with a 400x300 thumbnail. The first photo of an empty gallery becomes the primary photo shown in search results.
+// @Tags spaces
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Param photos formData file true "Photos"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/photos [post]
+func (h *SpacePhotoHandler) UploadPhotos(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	spaceID, ok := h.parseSpaceID(c)
+	if !ok {
+		return
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "No photos provided", err)
+		return
+	}
+	files := form.File["photos"]
+	if len(files) > models.MaxSpacePhotos {
+		respondError(c, http.StatusBadRequest, "Too many photos", fmt.Errorf("a space can have at most %d photos", models.MaxSpacePhotos))
+		return
+	}
+
+	photos, err := h.photoService.UploadPhotos(spaceID, files, userID)
+	if err != nil {
+		respondError(c, h.determinePhotoErrorStatus(err), "Failed to upload photos", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: fmt.Sprintf("%d photo(s) uploaded successfully", len(photos)),
+		Data:    photos,
+	})
+}
+
+// ReorderPhotos changes the order of the gallery of a space (admin only)
+// @Summary Reorder space photos
+// @Description Put the gallery in the given order; photo_ids must list every photo of the space once
+// @Tags spaces
+// @Accept json
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Param request body dto.ReorderSpacePhotosRequest true "New order"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/photos/order [put]
+func (h *SpacePhotoHandler) ReorderPhotos(c *gin.Context) {
+	spaceID, ok := h.parseSpaceID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReorderSpacePhotosRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	photos, err := h.photoService.ReorderPhotos(spaceID, &req)
+	if err != nil {
+		respondError(c, h.determinePhotoErrorStatus(err), "Failed to reorder photos", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Photos reordered successfully",
+		Data:    photos,
+	})
+}
+
+// SetPrimaryPhoto picks the photo shown for a space in search results (admin only)
+// @Summary Set primary space photo
+// @Description Make the photo the primary one of the gallery; its thumbnail becomes the space's primary_photo_url
+// @Tags spaces
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Param photoId path string true "Photo ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/photos/{photoId}/primary [put]
+func (h *SpacePhotoHandler) SetPrimaryPhoto(c *gin.Context) {
+	spaceID, photoID, ok := h.parsePhotoIDs(c)
+	if !ok {
+		return
+	}
+
+	photos, err := h.photoService.SetPrimaryPhoto(spaceID, photoID)
+	if err != nil {
+		respondError(c, h.determinePhotoErrorStatus(err), "Failed to set primary photo", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Primary photo set successfully",
+		Data:    photos,
+	})
+}
+
+// DeletePhoto removes a photo from the gallery of a space (admin only)
+// @Summary Delete space photo
+// @Description Remove the photo and its files; when it was the primary photo, the first remaining one takes its place
+// @Tags spaces
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Param photoId path string true "Photo ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/photos/{photoId} [delete]
+func (h *SpacePhotoHandler) DeletePhoto(c *gin.Context) {
+	spaceID, photoID, ok := h.parsePhotoIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.photoService.DeletePhoto(spaceID, photoID); err != nil {
+		respondError(c, h.determinePhotoErrorStatus(err), "Failed to delete photo", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Photo deleted successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// parseSpaceID parses the space ID path parameter, responding with 400 when it is invalid
+func (h *SpacePhotoHandler) parseSpaceID(c *gin.Context) (uuid.UUID, bool) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return uuid.Nil, false
+	}
+	return spaceID, true
+}
+
+// parsePhotoIDs parses the space and photo ID path parameters, responding with 400 when invalid
+func (h *SpacePhotoHandler) parsePhotoIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	spaceID, ok := h.parseSpaceID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	photoID, err := uuid.Parse(c.Param("photoId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid photo ID",
+			Message: "Photo ID must be a valid UUID",
+		})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return spaceID, photoID, true
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *SpacePhotoHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determinePhotoErrorStatus determines HTTP status code based on error message
+func (h *SpacePhotoHandler) determinePhotoErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
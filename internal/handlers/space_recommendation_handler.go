@@ -0,0 +1,143 @@
+// internal/handlers/space_recommendation_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SpaceRecommendationHandler handles space recommendation endpoints
+type SpaceRecommendationHandler struct {
+	recommendationService *services.SpaceRecommendationService
+}
+
+// NewSpaceRecommendationHandler creates a new space recommendation handler
+func NewSpaceRecommendationHandler(recommendationService *services.SpaceRecommendationService) *SpaceRecommendationHandler {
+	return &SpaceRecommendationHandler{
+		recommendationService: recommendationService,
+	}
+}
+
+// GetRecommendations ranks the spaces free at the requested time for the current user
+// @Summary Recommend spaces
+// @Description Rank available spaces by capacity fit, requested amenities, the user's past bookings and proximity to where the user's department usually books. Each suggestion explains its score.
+// @Tags spaces
+// @Produce json
+// @Param start_time query string true "Start time (RFC3339)"
+// @Param end_time query string true "End time (RFC3339)"
+// @Param participants query int false "Number of participants" default(1)
+// @Param amenities query string false "Comma-separated equipment names, e.g. projector,whiteboard"
+// @Param type query string false "Space type"
+// @Param limit query int false "Maximum suggestions (1-20)" default(5)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /spaces/recommendations [get]
+func (h *SpaceRecommendationHandler) GetRecommendations(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	startTime, err := time.Parse(time.RFC3339, c.Query("start_time"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid start_time",
+			Message: "start_time is required in RFC3339 format (e.g., 2023-12-25T10:00:00Z)",
+		})
+		return
+	}
+
+	endTime, err := time.Parse(time.RFC3339, c.Query("end_time"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid end_time",
+			Message: "end_time is required in RFC3339 format (e.g., 2023-12-25T12:00:00Z)",
+		})
+		return
+	}
+
+	participants, err := strconv.Atoi(c.DefaultQuery("participants", "1"))
+	if err != nil || participants < 1 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid participants",
+			Message: "participants must be a positive number",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	if err != nil || limit < 1 || limit > 20 {
+		limit = 5
+	}
+
+	var amenities []string
+	for _, amenity := range strings.Split(c.Query("amenities"), ",") {
+		if amenity = strings.TrimSpace(amenity); amenity != "" {
+			amenities = append(amenities, amenity)
+		}
+	}
+
+	recommendations, err := h.recommendationService.RecommendSpaces(services.RecommendationQuery{
+		StartTime:    startTime,
+		EndTime:      endTime,
+		Participants: participants,
+		Amenities:    amenities,
+		SpaceType:    c.Query("type"),
+		Limit:        limit,
+	}, userID)
+	if err != nil {
+		respondError(c, h.determineRecommendationErrorStatus(err), "Failed to recommend spaces", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Recommendations retrieved successfully",
+		Data:    recommendations,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *SpaceRecommendationHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineRecommendationErrorStatus determines HTTP status code based on error message
+func (h *SpaceRecommendationHandler) determineRecommendationErrorStatus(err error) int {
+	switch {
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,310 @@
+// internal/handlers/team_block_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TeamBlockHandler handles desks block-booked for teams and their members' remote days
+type TeamBlockHandler struct {
+	teamBlockService *services.TeamBlockService
+}
+
+// NewTeamBlockHandler creates a new team block handler
+func NewTeamBlockHandler(teamBlockService *services.TeamBlockService) *TeamBlockHandler {
+	return &TeamBlockHandler{
+		teamBlockService: teamBlockService,
+	}
+}
+
+// CreateBlock block-books desks for a team (team leads)
+// @Summary Block-book desks for a team
+// @Description Book desks of a neighborhood for the team on the chosen weekdays of a date range (at most 92 days). Members are assigned a desk each day, in the order of desk_ids; members already booked elsewhere or without a free desk are listed as unassigned. Managers book for their own department, admins for any.
+// @Tags team-blocks
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateTeamBlockRequest true "Team block"
+// @Success 201 {object} dto.SuccessResponse{data=dto.TeamBlockResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /team-blocks [post]
+func (h *TeamBlockHandler) CreateBlock(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateTeamBlockRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	block, err := h.teamBlockService.CreateBlock(&req, userID)
+	if err != nil {
+		respondError(c, h.determineTeamBlockErrorStatus(err), "Failed to create team block", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Team block created successfully",
+		Data:    block,
+	})
+}
+
+// GetBlocks lists the team blocks of the user's department
+// @Summary List team blocks
+// @Description Desks block-booked for the user's department, or for every department for admins
+// @Tags team-blocks
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.TeamBlockResponse}
+// @Router /team-blocks [get]
+func (h *TeamBlockHandler) GetBlocks(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	blocks, err := h.teamBlockService.GetBlocks(userID)
+	if err != nil {
+		respondError(c, h.determineTeamBlockErrorStatus(err), "Failed to get team blocks", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Team blocks retrieved successfully",
+		Data:    blocks,
+	})
+}
+
+// GetBlock retrieves a team block with its upcoming days
+// @Summary Get team block
+// @Description A team block with who sits where, who works remotely and who has no desk on its next days
+// @Tags team-blocks
+// @Produce json
+// @Param id path string true "Team block ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.TeamBlockResponse}
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /team-blocks/{id} [get]
+func (h *TeamBlockHandler) GetBlock(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	blockID, ok := h.parseID(c, "id", "team block")
+	if !ok {
+		return
+	}
+
+	block, err := h.teamBlockService.GetBlock(blockID, userID)
+	if err != nil {
+		respondError(c, h.determineTeamBlockErrorStatus(err), "Failed to get team block", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Team block retrieved successfully",
+		Data:    block,
+	})
+}
+
+// CancelBlock ends a team block (team leads)
+// @Summary Cancel team block
+// @Description End the block and cancel its upcoming desk bookings
+// @Tags team-blocks
+// @Produce json
+// @Param id path string true "Team block ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /team-blocks/{id} [delete]
+func (h *TeamBlockHandler) CancelBlock(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	blockID, ok := h.parseID(c, "id", "team block")
+	if !ok {
+		return
+	}
+
+	cancelled, err := h.teamBlockService.CancelBlock(blockID, userID)
+	if err != nil {
+		respondError(c, h.determineTeamBlockErrorStatus(err), "Failed to cancel team block", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Team block cancelled successfully",
+		Data:    gin.H{"cancelled_reservations": cancelled},
+	})
+}
+
+// MarkRemote records a member working remotely on a day of a team block
+// @Summary Work remotely on a team day
+// @Description Cancel the member's desk on the day; the desk goes to a member left without one. Team leads may set user_id to mark another member.
+// @Tags team-blocks
+// @Accept json
+// @Produce json
+// @Param id path string true "Team block ID"
+// @Param request body dto.SetTeamRemoteRequest true "Remote day"
+// @Success 200 {object} dto.SuccessResponse{data=dto.TeamBlockDayResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /team-blocks/{id}/remote [post]
+func (h *TeamBlockHandler) MarkRemote(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	blockID, ok := h.parseID(c, "id", "team block")
+	if !ok {
+		return
+	}
+
+	var req dto.SetTeamRemoteRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	memberID := userID
+	if req.UserID != nil {
+		memberID = *req.UserID
+	}
+
+	day, err := h.teamBlockService.SetRemote(blockID, userID, memberID, req.Date, true)
+	if err != nil {
+		respondError(c, h.determineTeamBlockErrorStatus(err), "Failed to record remote day", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Remote day recorded successfully",
+		Data:    day,
+	})
+}
+
+// UnmarkRemote brings a member back to the office on a day of a team block
+// @Summary Come back on a team day
+// @Description Remove a remote day and assign the member a free desk of the block, if any. Team leads may set user_id to change another member's day.
+// @Tags team-blocks
+// @Produce json
+// @Param id path string true "Team block ID"
+// @Param date query string true "Day (YYYY-MM-DD)"
+// @Param user_id query string false "Member ID (team leads only)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.TeamBlockDayResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /team-blocks/{id}/remote [delete]
+func (h *TeamBlockHandler) UnmarkRemote(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	blockID, ok := h.parseID(c, "id", "team block")
+	if !ok {
+		return
+	}
+
+	memberID := userID
+	if raw := c.Query("user_id"); raw != "" {
+		memberID, err = uuid.Parse(raw)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid user ID", fmt.Errorf("user_id must be a valid UUID"))
+			return
+		}
+	}
+
+	day, err := h.teamBlockService.SetRemote(blockID, userID, memberID, c.Query("date"), false)
+	if err != nil {
+		respondError(c, h.determineTeamBlockErrorStatus(err), "Failed to remove remote day", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Remote day removed successfully",
+		Data:    day,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// parseID parses a UUID path parameter, writing a 400 response when it is invalid
+func (h *TeamBlockHandler) parseID(c *gin.Context, param, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		label := strings.ToUpper(name[:1]) + name[1:]
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   fmt.Sprintf("Invalid %s ID", name),
+			Message: fmt.Sprintf("%s ID must be a valid UUID", label),
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *TeamBlockHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineTeamBlockErrorStatus determines HTTP status code based on error message
+func (h *TeamBlockHandler) determineTeamBlockErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case err.Error() == "access denied":
+		return http.StatusForbidden
+	case strings.HasPrefix(err.Error(), "team block is"):
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,128 @@
+// internal/handlers/user_preference_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// UserPreferenceHandler handles the current user's booking preference endpoints
+type UserPreferenceHandler struct {
+	preferenceService *services.UserPreferenceService
+}
+
+// NewUserPreferenceHandler creates a new user preference handler
+func NewUserPreferenceHandler(preferenceService *services.UserPreferenceService) *UserPreferenceHandler {
+	return &UserPreferenceHandler{
+		preferenceService: preferenceService,
+	}
+}
+
+// GetPreferences returns the current user's booking defaults
+// @Summary Get booking preferences
+// @Description Defaults applied to new reservations when the request leaves out the space, end time, participant count or visibility
+// @Tags users
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /users/me/preferences [get]
+func (h *UserPreferenceHandler) GetPreferences(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	preferences, err := h.preferenceService.GetPreferences(userID)
+	if err != nil {
+		respondError(c, h.determinePreferenceErrorStatus(err), "Failed to get preferences", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Preferences retrieved successfully",
+		Data:    preferences,
+	})
+}
+
+// UpdatePreferences replaces the current user's booking defaults
+// @Summary Update booking preferences
+// @Description Set the preferred building and floor, typical meeting duration, usual participants and default visibility
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.UpdateUserPreferencesRequest true "Preferences"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /users/me/preferences [put]
+func (h *UserPreferenceHandler) UpdatePreferences(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.UpdateUserPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	preferences, err := h.preferenceService.UpdatePreferences(userID, &req)
+	if err != nil {
+		respondError(c, h.determinePreferenceErrorStatus(err), "Failed to update preferences", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Preferences updated successfully",
+		Data:    preferences,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *UserPreferenceHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determinePreferenceErrorStatus determines HTTP status code based on error message
+func (h *UserPreferenceHandler) determinePreferenceErrorStatus(err error) int {
+	switch {
+	case strings.HasSuffix(err.Error(), " not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,25 @@
+// internal/handlers/validation.go
+package handlers
+
+import (
+	"room-reservation-api/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bindJSON binds the JSON body into req, checking its binding tags, then its validate tags
+// and own rules. Failures come back as dto.ValidationErrors for respondError to list per field.
+func bindJSON(c *gin.Context, req interface{}) error {
+	if err := c.ShouldBindJSON(req); err != nil {
+		return err
+	}
+	return dto.Validate(req)
+}
+
+// bindQuery binds the query string into req and validates it like bindJSON
+func bindQuery(c *gin.Context, req interface{}) error {
+	if err := c.ShouldBindQuery(req); err != nil {
+		return err
+	}
+	return dto.Validate(req)
+}
@@ -0,0 +1,79 @@
+// internal/handlers/version_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/version"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Current major version of the REST API, served under /api/v1
+const apiVersion = "v1"
+
+// VersionHandler reports the build and tells clients whether they are still supported
+type VersionHandler struct {
+	minClientVersions map[string]string
+}
+
+// NewVersionHandler creates a new version handler with the oldest supported client version per platform
+func NewVersionHandler(minClientVersions map[string]string) *VersionHandler {
+	return &VersionHandler{
+		minClientVersions: minClientVersions,
+	}
+}
+
+// GetVersion returns the build details and the minimum client versions
+// @Summary API version
+// @Description Semantic version, git SHA and build time of the API, with the oldest supported version of each client platform. A client that sends its platform and version (query or X-Client-Platform and X-Client-Version headers) is told whether it must upgrade.
+// @Tags system
+// @Produce json
+// @Param platform query string false "Client platform, e.g. ios, android, web"
+// @Param client_version query string false "Client semantic version"
+// @Success 200 {object} dto.SuccessResponse{data=dto.VersionResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /version [get]
+func (h *VersionHandler) GetVersion(c *gin.Context) {
+	response := dto.VersionResponse{
+		Info:              version.Get(),
+		APIVersion:        apiVersion,
+		MinClientVersions: h.minClientVersions,
+	}
+
+	platform := strings.ToLower(strings.TrimSpace(c.DefaultQuery("platform", c.GetHeader("X-Client-Platform"))))
+	clientVersion := strings.TrimSpace(c.DefaultQuery("client_version", c.GetHeader("X-Client-Version")))
+	if platform != "" || clientVersion != "" {
+		if platform == "" || clientVersion == "" {
+			respondError(c, http.StatusBadRequest, "Invalid client", fmt.Errorf("platform and client_version must be sent together"))
+			return
+		}
+
+		compatibility := &dto.ClientCompatibility{
+			Platform:       platform,
+			ClientVersion:  clientVersion,
+			MinimumVersion: h.minClientVersions[platform],
+		}
+		if compatibility.MinimumVersion != "" {
+			comparison, err := version.Compare(clientVersion, compatibility.MinimumVersion)
+			if err != nil {
+				respondError(c, http.StatusBadRequest, "Invalid client version", err)
+				return
+			}
+			compatibility.UpgradeRequired = comparison < 0
+		} else if !version.Valid(clientVersion) {
+			respondError(c, http.StatusBadRequest, "Invalid client version", fmt.Errorf("invalid version %q", clientVersion))
+			return
+		}
+		response.Compatibility = compatibility
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Version retrieved successfully",
+		Data:    response,
+	})
+}
@@ -0,0 +1,263 @@
+// internal/handlers/vip_space_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// VIPSpaceHandler handles VIP block management and violation reporting
+type VIPSpaceHandler struct {
+	vipSpaceService *services.VIPSpaceService
+}
+
+// NewVIPSpaceHandler creates a new VIP space handler
+func NewVIPSpaceHandler(vipSpaceService *services.VIPSpaceService) *VIPSpaceHandler {
+	return &VIPSpaceHandler{
+		vipSpaceService: vipSpaceService,
+	}
+}
+
+// ========================================
+// BLOCK MANAGEMENT
+// ========================================
+
+// CreateBlock adds a guaranteed-availability block to a VIP space (admin only)
+// @Summary Create VIP block
+// @Description Reserve a weekly window on a VIP space for specific roles; other users cannot book it
+// @Tags spaces
+// @Accept json
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Param request body dto.CreateVIPBlockRequest true "VIP block request"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/vip-blocks [post]
+func (h *VIPSpaceHandler) CreateBlock(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateVIPBlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	block, err := h.vipSpaceService.CreateBlock(spaceID, &req, userID)
+	if err != nil {
+		respondError(c, h.determineVIPErrorStatus(err), "Failed to create VIP block", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "VIP block created successfully",
+		Data:    block,
+	})
+}
+
+// GetSpaceBlocks lists the VIP blocks of a space (admin only)
+// @Summary List VIP blocks
+// @Description Get the active guaranteed-availability blocks of a space
+// @Tags spaces
+// @Produce json
+// @Param id path string true "Space ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/spaces/{id}/vip-blocks [get]
+func (h *VIPSpaceHandler) GetSpaceBlocks(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid space ID",
+			Message: "Space ID must be a valid UUID",
+		})
+		return
+	}
+
+	blocks, err := h.vipSpaceService.GetSpaceBlocks(spaceID)
+	if err != nil {
+		respondError(c, h.determineVIPErrorStatus(err), "Failed to get VIP blocks", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "VIP blocks retrieved successfully",
+		Data:    blocks,
+	})
+}
+
+// DeleteBlock removes a VIP block (admin only)
+// @Summary Delete VIP block
+// @Description Remove a guaranteed-availability block
+// @Tags spaces
+// @Produce json
+// @Param id path string true "VIP block ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/vip-blocks/{id} [delete]
+func (h *VIPSpaceHandler) DeleteBlock(c *gin.Context) {
+	blockID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid VIP block ID",
+			Message: "VIP block ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.vipSpaceService.DeleteBlock(blockID); err != nil {
+		respondError(c, h.determineVIPErrorStatus(err), "Failed to delete VIP block", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "VIP block deleted successfully",
+	})
+}
+
+// ========================================
+// VIOLATION REPORTING
+// ========================================
+
+// GetViolationReport reports bookings that overrode VIP blocks (admin only)
+// @Summary VIP block violation report
+// @Description List reservations that were booked into VIP blocks by override, with their justification
+// @Tags reports
+// @Produce json
+// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to 30 days ago"
+// @Param end_date query string false "End date (YYYY-MM-DD), defaults to today"
+// @Param space_id query string false "Filter by space ID" format(uuid)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/reports/vip-violations [get]
+func (h *VIPSpaceHandler) GetViolationReport(c *gin.Context) {
+	now := time.Now().UTC()
+	startDate := now.AddDate(0, 0, -30)
+	endDate := now
+
+	if raw := c.Query("start_date"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid start date",
+				Message: "start_date must use the YYYY-MM-DD format",
+			})
+			return
+		}
+		startDate = parsed
+	}
+
+	if raw := c.Query("end_date"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid end date",
+				Message: "end_date must use the YYYY-MM-DD format",
+			})
+			return
+		}
+		endDate = parsed.AddDate(0, 0, 1) // Include the whole end day
+	}
+
+	var spaceID *uuid.UUID
+	if raw := c.Query("space_id"); raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid space ID",
+				Message: "Space ID must be a valid UUID",
+			})
+			return
+		}
+		spaceID = &parsed
+	}
+
+	page := utils.GetIntQuery(c, "page", 1)
+	limit := utils.GetIntQuery(c, "limit", 20)
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	report, err := h.vipSpaceService.GetViolationReport(startDate, endDate, spaceID, page, limit)
+	if err != nil {
+		respondError(c, h.determineVIPErrorStatus(err), "Failed to generate VIP violation report", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "VIP violation report generated successfully",
+		Data:    report,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *VIPSpaceHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineVIPErrorStatus determines HTTP status code based on error message
+func (h *VIPSpaceHandler) determineVIPErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to get VIP"),
+		strings.HasPrefix(err.Error(), "failed to create VIP"),
+		strings.HasPrefix(err.Error(), "failed to delete VIP"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,341 @@
+// internal/handlers/visitor_integration_handler.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// VisitorIntegrationHandler handles the endpoints reception systems use and their integration keys
+type VisitorIntegrationHandler struct {
+	integrationService *services.VisitorIntegrationService
+}
+
+// NewVisitorIntegrationHandler creates a new visitor integration handler
+func NewVisitorIntegrationHandler(integrationService *services.VisitorIntegrationService) *VisitorIntegrationHandler {
+	return &VisitorIntegrationHandler{
+		integrationService: integrationService,
+	}
+}
+
+// ========================================
+// RECEPTION ENDPOINTS (integration key)
+// ========================================
+
+// GetExpectedVisitors lists the external participants expected in a building on a day
+// @Summary Expected visitors
+// @Description External participants of the meetings held in a building on a day, for reception desks. Requires the visitors:read scope.
+// @Tags integrations
+// @Produce json
+// @Param X-Integration-Key header string true "Integration key"
+// @Param building query string true "Building"
+// @Param date query string false "Day (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /integrations/reception/visitors [get]
+func (h *VisitorIntegrationHandler) GetExpectedVisitors(c *gin.Context) {
+	key := h.currentKey(c)
+
+	building := c.Query("building")
+	if building == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Missing building",
+			Message: "building query parameter is required",
+		})
+		return
+	}
+
+	now := time.Now().UTC()
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if raw := c.Query("date"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid date",
+				Message: "date must use the YYYY-MM-DD format",
+			})
+			return
+		}
+		date = parsed
+	}
+
+	visitors, err := h.integrationService.GetExpectedVisitors(key, building, date)
+	if err != nil {
+		respondError(c, h.determineIntegrationErrorStatus(err), "Failed to get expected visitors", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Expected visitors retrieved successfully",
+		Data:    visitors,
+	})
+}
+
+// CheckIn checks a visitor in at reception
+// @Summary Check visitor in
+// @Description Record the arrival of a visitor expected today, identified by guest ID or by the token of their visitor pass, and return the badge to print. Requires the visitors:check_in scope.
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param X-Integration-Key header string true "Integration key"
+// @Param request body dto.VisitorCheckInRequest true "Visitor"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /integrations/reception/visitors/check-in [post]
+func (h *VisitorIntegrationHandler) CheckIn(c *gin.Context) {
+	key := h.currentKey(c)
+
+	var req dto.VisitorCheckInRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	badge, err := h.integrationService.CheckIn(key, &req)
+	if err != nil {
+		respondError(c, h.determineIntegrationErrorStatus(err), "Failed to check visitor in", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Visitor checked in successfully",
+		Data:    badge,
+	})
+}
+
+// GetBadge returns the badge-printing payload of a visitor
+// @Summary Visitor badge
+// @Description Name, company, host and meeting room of a visitor, for printing their badge. Requires the badges:read scope.
+// @Tags integrations
+// @Produce json
+// @Param X-Integration-Key header string true "Integration key"
+// @Param id path string true "Guest ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /integrations/reception/visitors/{id}/badge [get]
+func (h *VisitorIntegrationHandler) GetBadge(c *gin.Context) {
+	key := h.currentKey(c)
+
+	guestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid guest ID",
+			Message: "Guest ID must be a valid UUID",
+		})
+		return
+	}
+
+	badge, err := h.integrationService.GetBadge(key, guestID)
+	if err != nil {
+		respondError(c, h.determineIntegrationErrorStatus(err), "Failed to get visitor badge", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Visitor badge retrieved successfully",
+		Data:    badge,
+	})
+}
+
+// GetEvents returns the visitor changes since the given cursor
+// @Summary Visitor events
+// @Description Visitors expected, updated, cancelled and arrived in the buildings the key covers, oldest first. Poll again with the returned next cursor. Events are kept for 30 days. Requires the events:read scope.
+// @Tags integrations
+// @Produce json
+// @Param X-Integration-Key header string true "Integration key"
+// @Param cursor query string false "Cursor returned by the previous call"
+// @Param limit query int false "Events per page" default(100)
+// @Success 200 {object} dto.CursorPaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /integrations/reception/events [get]
+func (h *VisitorIntegrationHandler) GetEvents(c *gin.Context) {
+	key := h.currentKey(c)
+
+	limit := utils.GetIntQuery(c, "limit", 100)
+	if limit < 1 || limit > 500 {
+		limit = 100
+	}
+
+	cursor, _, err := cursorQuery(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid cursor", err)
+		return
+	}
+
+	events, next, err := h.integrationService.GetEvents(key, cursor, limit)
+	if err != nil {
+		respondError(c, h.determineIntegrationErrorStatus(err), "Failed to get visitor events", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewCursorPaginatedResponse(events, limit, next))
+}
+
+// ========================================
+// KEY ENDPOINTS (admin)
+// ========================================
+
+// CreateKey creates an integration key (admin only)
+// @Summary Create integration key
+// @Description Create an API key for a reception system, limited to the given scopes and buildings (every building when none are given); the key is only shown once
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateIntegrationKeyRequest true "Integration key"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/integration-keys [post]
+func (h *VisitorIntegrationHandler) CreateKey(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateIntegrationKeyRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	key, err := h.integrationService.CreateKey(&req, userID)
+	if err != nil {
+		respondError(c, h.determineIntegrationErrorStatus(err), "Failed to create integration key", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Integration key created successfully, store it now",
+		Data:    key,
+	})
+}
+
+// GetKeys lists the integration keys (admin only)
+// @Summary List integration keys
+// @Description Get every integration key, including revoked ones
+// @Tags integrations
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/integration-keys [get]
+func (h *VisitorIntegrationHandler) GetKeys(c *gin.Context) {
+	keys, err := h.integrationService.GetKeys()
+	if err != nil {
+		respondError(c, h.determineIntegrationErrorStatus(err), "Failed to get integration keys", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Integration keys retrieved successfully",
+		Data:    keys,
+	})
+}
+
+// RevokeKey disables an integration key (admin only)
+// @Summary Revoke integration key
+// @Description Revoke an integration key, e.g. when a reception system is replaced
+// @Tags integrations
+// @Produce json
+// @Param id path string true "Integration key ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/integration-keys/{id} [delete]
+func (h *VisitorIntegrationHandler) RevokeKey(c *gin.Context) {
+	keyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid integration key ID",
+			Message: "Integration key ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.integrationService.RevokeKey(keyID); err != nil {
+		respondError(c, h.determineIntegrationErrorStatus(err), "Failed to revoke integration key", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Integration key revoked successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// currentKey returns the integration key set by the integration key middleware
+func (h *VisitorIntegrationHandler) currentKey(c *gin.Context) *models.IntegrationKey {
+	return c.MustGet("integration_key").(*models.IntegrationKey)
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *VisitorIntegrationHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineIntegrationErrorStatus determines HTTP status code based on error message
+func (h *VisitorIntegrationHandler) determineIntegrationErrorStatus(err error) int {
+	if errors.Is(err, dto.ErrInvalidCursor) {
+		return http.StatusBadRequest
+	}
+
+	switch {
+	case strings.Contains(err.Error(), "record not found"),
+		strings.Contains(err.Error(), "not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "not covered"):
+		return http.StatusForbidden
+	case strings.Contains(err.Error(), "already"):
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
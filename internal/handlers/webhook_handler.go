@@ -0,0 +1,245 @@
+// internal/handlers/webhook_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// WebhookHandler handles webhook subscription endpoints
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// GetSchemaChangelog lists the webhook payload schema versions
+// @Summary Webhook schema changelog
+// @Description Payload schema versions with their changes and an example reservation_created payload. Subscriptions receive payloads converted to the version they selected.
+// @Tags webhooks
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /webhooks/schema-changelog [get]
+func (h *WebhookHandler) GetSchemaChangelog(c *gin.Context) {
+	changelog, err := h.webhookService.GetSchemaChangelog()
+	if err != nil {
+		respondError(c, h.determineWebhookErrorStatus(err), "Failed to get schema changelog", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Schema changelog retrieved successfully",
+		Data:    changelog,
+	})
+}
+
+// CreateWebhook subscribes an endpoint to events (admin only)
+// @Summary Create webhook
+// @Description Subscribe an endpoint to reservation events. The signing secret is only shown once; payloads are signed with HMAC-SHA256 in X-Webhook-Signature.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateWebhookRequest true "Webhook"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	webhook, err := h.webhookService.CreateSubscription(&req, userID)
+	if err != nil {
+		respondError(c, h.determineWebhookErrorStatus(err), "Failed to create webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Webhook created successfully, store the secret now",
+		Data:    webhook,
+	})
+}
+
+// GetWebhooks lists webhook subscriptions (admin only)
+// @Summary List webhooks
+// @Description Get all webhook subscriptions with their schema versions
+// @Tags webhooks
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /admin/webhooks [get]
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	webhooks, err := h.webhookService.GetSubscriptions()
+	if err != nil {
+		respondError(c, h.determineWebhookErrorStatus(err), "Failed to get webhooks", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Webhooks retrieved successfully",
+		Data:    webhooks,
+	})
+}
+
+// UpdateWebhook changes a webhook subscription (admin only)
+// @Summary Update webhook
+// @Description Change the endpoint, events, schema version or pause a subscription
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID" format(uuid)
+// @Param request body dto.UpdateWebhookRequest true "Changes"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	webhookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid webhook ID",
+			Message: "Webhook ID must be a valid UUID",
+		})
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	webhook, err := h.webhookService.UpdateSubscription(webhookID, &req)
+	if err != nil {
+		respondError(c, h.determineWebhookErrorStatus(err), "Failed to update webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Webhook updated successfully",
+		Data:    webhook,
+	})
+}
+
+// DeleteWebhook removes a webhook subscription (admin only)
+// @Summary Delete webhook
+// @Description Remove a subscription; undelivered events are dropped
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	webhookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid webhook ID",
+			Message: "Webhook ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(webhookID); err != nil {
+		respondError(c, h.determineWebhookErrorStatus(err), "Failed to delete webhook", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Webhook deleted successfully",
+	})
+}
+
+// GetDeliveries lists recent deliveries of a webhook (admin only)
+// @Summary Webhook deliveries
+// @Description The last 50 deliveries with payload, attempts and endpoint response
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	webhookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid webhook ID",
+			Message: "Webhook ID must be a valid UUID",
+		})
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(webhookID)
+	if err != nil {
+		respondError(c, h.determineWebhookErrorStatus(err), "Failed to get deliveries", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Deliveries retrieved successfully",
+		Data:    deliveries,
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// extractUserID extracts and validates user ID from context
+func (h *WebhookHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineWebhookErrorStatus determines HTTP status code based on error message
+func (h *WebhookHandler) determineWebhookErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,256 @@
+// internal/handlers/widget_handler.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// WidgetHandler handles embeddable booking widget endpoints and their tokens
+type WidgetHandler struct {
+	widgetService *services.WidgetService
+}
+
+// NewWidgetHandler creates a new booking widget handler
+func NewWidgetHandler(widgetService *services.WidgetService) *WidgetHandler {
+	return &WidgetHandler{
+		widgetService: widgetService,
+	}
+}
+
+// ========================================
+// WIDGET ENDPOINTS (widget token)
+// ========================================
+
+// GetAvailability returns when the spaces the widget covers are busy during a day
+// @Summary Widget availability
+// @Description Busy windows of the space, or the spaces of the building, the widget token covers; nothing about who booked them is shown
+// @Tags widget
+// @Produce json
+// @Param X-Widget-Token header string true "Widget token"
+// @Param date query string false "Day as YYYY-MM-DD, up to 14 days ahead; today by default"
+// @Param type query string false "Only spaces of this type, for building widgets"
+// @Param timezone query string false "IANA timezone the day is read in; UTC by default"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /widget/availability [get]
+func (h *WidgetHandler) GetAvailability(c *gin.Context) {
+	token := h.currentToken(c)
+
+	var req dto.PublicAvailabilityRequest
+	if err := bindQuery(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	view, err := h.widgetService.GetAvailability(token, &req)
+	if err != nil {
+		respondError(c, h.determineWidgetErrorStatus(err), "Failed to get availability", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Availability retrieved successfully",
+		Data:    view,
+	})
+}
+
+// SubmitBookingRequest sends a booking request from the widget to the approval queue
+// @Summary Widget booking request
+// @Description Request a space the widget token covers for a visitor without an account. The reservation is pending until a manager of the space approves it.
+// @Tags widget
+// @Accept json
+// @Produce json
+// @Param X-Widget-Token header string true "Widget token"
+// @Param request body dto.WidgetBookingRequest true "Booking request"
+// @Success 202 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /widget/booking-requests [post]
+func (h *WidgetHandler) SubmitBookingRequest(c *gin.Context) {
+	token := h.currentToken(c)
+
+	var req dto.WidgetBookingRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request", err)
+		return
+	}
+
+	booking, err := h.widgetService.SubmitBookingRequest(token, &req)
+	if err != nil {
+		respondError(c, h.determineWidgetErrorStatus(err), "Failed to request booking", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.SuccessResponse{
+		Success: true,
+		Message: "Booking request received, it will be confirmed once approved",
+		Data:    booking,
+	})
+}
+
+// ========================================
+// TOKEN ENDPOINTS (admin)
+// ========================================
+
+// CreateToken creates a booking widget token (admin only)
+// @Summary Create widget token
+// @Description Create a token for a booking widget embedded in another site, scoped to one space or one building; the token is only shown once. Booking requests sent through the widget belong to the admin creating the token.
+// @Tags widget
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateWidgetTokenRequest true "Widget token"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/widget-tokens [post]
+func (h *WidgetHandler) CreateToken(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateWidgetTokenRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	token, err := h.widgetService.CreateToken(&req, userID)
+	if err != nil {
+		respondError(c, h.determineWidgetErrorStatus(err), "Failed to create widget token", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Widget token created successfully, store it now",
+		Data:    token,
+	})
+}
+
+// GetTokens lists the booking widget tokens (admin only)
+// @Summary List widget tokens
+// @Description Get every widget token, including revoked ones
+// @Tags widget
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /admin/widget-tokens [get]
+func (h *WidgetHandler) GetTokens(c *gin.Context) {
+	tokens, err := h.widgetService.GetTokens()
+	if err != nil {
+		respondError(c, h.determineWidgetErrorStatus(err), "Failed to get widget tokens", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Widget tokens retrieved successfully",
+		Data:    tokens,
+	})
+}
+
+// RevokeToken disables a booking widget token (admin only)
+// @Summary Revoke widget token
+// @Description Revoke a widget token, e.g. when the site embedding it is taken down
+// @Tags widget
+// @Produce json
+// @Param id path string true "Widget token ID" format(uuid)
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/widget-tokens/{id} [delete]
+func (h *WidgetHandler) RevokeToken(c *gin.Context) {
+	tokenID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid widget token ID",
+			Message: "Widget token ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.widgetService.RevokeToken(tokenID); err != nil {
+		respondError(c, h.determineWidgetErrorStatus(err), "Failed to revoke widget token", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Widget token revoked successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// currentToken returns the widget token set by the widget token middleware
+func (h *WidgetHandler) currentToken(c *gin.Context) *models.WidgetToken {
+	return c.MustGet("widget_token").(*models.WidgetToken)
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *WidgetHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineWidgetErrorStatus determines HTTP status code based on error message
+func (h *WidgetHandler) determineWidgetErrorStatus(err error) int {
+	var coded *dto.CodedError
+	if errors.As(err, &coded) {
+		switch coded.Code {
+		case dto.ErrCodeSpacePilot:
+			return http.StatusForbidden
+		case dto.ErrCodeResInPast, dto.ErrCodeResAdvanceNotice, dto.ErrCodeResDurationExceeded, dto.ErrCodeSpaceCapacityExceeded:
+			return http.StatusBadRequest
+		default:
+			return http.StatusConflict
+		}
+	}
+
+	switch {
+	case strings.Contains(err.Error(), "record not found"),
+		strings.HasPrefix(err.Error(), "unknown building"):
+		return http.StatusNotFound
+	case err.Error() == "space is not covered by this widget":
+		return http.StatusForbidden
+	case err.Error() == "widget token is already revoked":
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
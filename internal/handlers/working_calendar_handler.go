@@ -0,0 +1,307 @@
+// internal/handlers/working_calendar_handler.go
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// WorkingCalendarHandler handles the regions' public holidays and company closure days
+type WorkingCalendarHandler struct {
+	calendarService *services.WorkingCalendarService
+}
+
+// NewWorkingCalendarHandler creates a new working calendar handler
+func NewWorkingCalendarHandler(calendarService *services.WorkingCalendarService) *WorkingCalendarHandler {
+	return &WorkingCalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// GetCalendar lists the non-working days of a building
+// @Summary Working calendar of a building
+// @Description Public holidays and company closure days of the building's region and of every region for a year. Blocking days refuse bookings for everyone but admins; the others add a warning to bookings.
+// @Tags working-calendar
+// @Produce json
+// @Param building query string true "Building"
+// @Param year query int false "Year, defaults to the current year"
+// @Success 200 {object} dto.SuccessResponse{data=dto.WorkingCalendarResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /calendar [get]
+func (h *WorkingCalendarHandler) GetCalendar(c *gin.Context) {
+	building := strings.TrimSpace(c.Query("building"))
+	if building == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid building",
+			Message: "building is required",
+		})
+		return
+	}
+
+	year, ok := h.parseYear(c)
+	if !ok {
+		return
+	}
+
+	calendar, err := h.calendarService.Calendar(building, year)
+	if err != nil {
+		respondError(c, h.determineCalendarErrorStatus(err), "Failed to get working calendar", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Working calendar retrieved successfully",
+		Data:    calendar,
+	})
+}
+
+// ========================================
+// ADMIN ENDPOINTS
+// ========================================
+
+// GetRegions lists the calendar regions (admin only)
+// @Summary List calendar regions
+// @Description Regions grouping the buildings that share holidays and closure days
+// @Tags working-calendar
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse
+// @Router /admin/calendar/regions [get]
+func (h *WorkingCalendarHandler) GetRegions(c *gin.Context) {
+	regions, err := h.calendarService.GetRegions()
+	if err != nil {
+		respondError(c, h.determineCalendarErrorStatus(err), "Failed to get calendar regions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Calendar regions retrieved successfully",
+		Data:    regions,
+	})
+}
+
+// SetRegion creates or replaces a calendar region (admin only)
+// @Summary Set calendar region
+// @Description Group buildings into a region, e.g. DE-BY; a building is in one region at most
+// @Tags working-calendar
+// @Accept json
+// @Produce json
+// @Param code path string true "Region code"
+// @Param request body dto.SetCalendarRegionRequest true "Region"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/calendar/regions/{code} [put]
+func (h *WorkingCalendarHandler) SetRegion(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.SetCalendarRegionRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	region, err := h.calendarService.SetRegion(c.Param("code"), &req, userID)
+	if err != nil {
+		respondError(c, h.determineCalendarErrorStatus(err), "Failed to save calendar region", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Calendar region saved successfully",
+		Data:    region,
+	})
+}
+
+// DeleteRegion removes a calendar region and its days (admin only)
+// @Summary Delete calendar region
+// @Description Remove the region and its holidays and closure days; its buildings keep the days of every region
+// @Tags working-calendar
+// @Produce json
+// @Param code path string true "Region code"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/calendar/regions/{code} [delete]
+func (h *WorkingCalendarHandler) DeleteRegion(c *gin.Context) {
+	if err := h.calendarService.DeleteRegion(c.Param("code")); err != nil {
+		respondError(c, h.determineCalendarErrorStatus(err), "Failed to delete calendar region", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Calendar region deleted successfully",
+	})
+}
+
+// GetDays lists the holidays and closure days of a year (admin only)
+// @Summary List calendar days
+// @Description Holidays and closure days of every region, or of one region; region= (empty) lists the days of every region
+// @Tags working-calendar
+// @Produce json
+// @Param year query int false "Year, defaults to the current year"
+// @Param region query string false "Region code"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /admin/calendar/days [get]
+func (h *WorkingCalendarHandler) GetDays(c *gin.Context) {
+	year, ok := h.parseYear(c)
+	if !ok {
+		return
+	}
+
+	var region *string
+	if value, set := c.GetQuery("region"); set {
+		region = &value
+	}
+
+	days, err := h.calendarService.GetDays(region, year)
+	if err != nil {
+		respondError(c, h.determineCalendarErrorStatus(err), "Failed to get calendar days", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Calendar days retrieved successfully",
+		Data:    days,
+	})
+}
+
+// CreateDay adds a holiday or closure day (admin only)
+// @Summary Add calendar day
+// @Description Add a public holiday or company closure day to a region, or to every region. Blocking days (the default) refuse bookings for everyone but admins and recurring series skip or shift their occurrences; other days only add a warning.
+// @Tags working-calendar
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateCalendarDayRequest true "Day"
+// @Success 201 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /admin/calendar/days [post]
+func (h *WorkingCalendarHandler) CreateDay(c *gin.Context) {
+	userID, err := h.extractUserID(c)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "Unauthorized", err)
+		return
+	}
+
+	var req dto.CreateCalendarDayRequest
+	if err := bindJSON(c, &req); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	day, err := h.calendarService.CreateDay(&req, userID)
+	if err != nil {
+		respondError(c, h.determineCalendarErrorStatus(err), "Failed to add calendar day", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Success: true,
+		Message: "Calendar day added successfully",
+		Data:    day,
+	})
+}
+
+// DeleteDay removes a holiday or closure day (admin only)
+// @Summary Delete calendar day
+// @Description Open the day for bookings again
+// @Tags working-calendar
+// @Produce json
+// @Param id path string true "Calendar day ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /admin/calendar/days/{id} [delete]
+func (h *WorkingCalendarHandler) DeleteDay(c *gin.Context) {
+	dayID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Invalid calendar day ID",
+			Message: "Calendar day ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.calendarService.DeleteDay(dayID); err != nil {
+		respondError(c, h.determineCalendarErrorStatus(err), "Failed to delete calendar day", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Calendar day deleted successfully",
+	})
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// parseYear reads the year query parameter, the current year when omitted, writing a 400
+// response when it is invalid
+func (h *WorkingCalendarHandler) parseYear(c *gin.Context) (int, bool) {
+	year := time.Now().Year()
+	if value := c.Query("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1900 || parsed > 2200 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Invalid year",
+				Message: "year must be a number between 1900 and 2200",
+			})
+			return 0, false
+		}
+		year = parsed
+	}
+	return year, true
+}
+
+// extractUserID extracts and validates user ID from context
+func (h *WorkingCalendarHandler) extractUserID(c *gin.Context) (uuid.UUID, error) {
+	userIDInterface, exists := c.Get("user_id")
+	if !exists {
+		return uuid.Nil, fmt.Errorf("user not authenticated")
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid user context type")
+	}
+
+	userUUID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID format: %v", err)
+	}
+
+	return userUUID, nil
+}
+
+// determineCalendarErrorStatus determines HTTP status code based on error message
+func (h *WorkingCalendarHandler) determineCalendarErrorStatus(err error) int {
+	switch {
+	case strings.Contains(err.Error(), "record not found"):
+		return http.StatusNotFound
+	case strings.Contains(err.Error(), "already"):
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,279 @@
+// internal/jobs/scheduler.go
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// JobFunc is the work performed by a scheduled job
+type JobFunc func(ctx context.Context) error
+
+// JobStatus reports the last run of a job
+type JobStatus struct {
+	Name      string     `json:"name"`
+	Schedule  string     `json:"schedule"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	Running   bool       `json:"running"`
+}
+
+// JobRun describes a finished run of a job
+type JobRun struct {
+	Name       string
+	Interval   time.Duration // Zero for daily jobs
+	StartedAt  time.Time
+	Duration   time.Duration
+	Err        error
+	PrevFailed bool // The run before this one failed
+}
+
+type job struct {
+	name     string
+	schedule string
+	interval time.Duration
+	next     func(from time.Time) time.Time
+	run      JobFunc
+
+	mu     sync.Mutex
+	status JobStatus
+}
+
+// Scheduler runs background jobs on fixed intervals or at a daily time
+type Scheduler struct {
+	logger *slog.Logger
+	jobs   []*job
+
+	mu         sync.Mutex
+	cancel     context.CancelFunc // Stops scheduling new runs
+	cancelRuns context.CancelFunc // Interrupts runs in progress
+	wg         sync.WaitGroup
+	running    bool
+	onFailure  func(name string, err error)
+	onRun      func(run JobRun)
+}
+
+// NewScheduler creates a new scheduler
+func NewScheduler(logger *slog.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+	}
+}
+
+// Every registers a job that runs at a fixed interval
+func (s *Scheduler) Every(name string, interval time.Duration, fn JobFunc) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	s.register(name, "every "+interval.String(), interval, func(from time.Time) time.Time {
+		return from.Add(interval)
+	}, fn)
+}
+
+// Daily registers a job that runs once a day at the given local time
+func (s *Scheduler) Daily(name string, hour, minute int, fn JobFunc) {
+	s.register(name, fmt.Sprintf("daily at %02d:%02d", hour, minute), 0, func(from time.Time) time.Time {
+		next := time.Date(from.Year(), from.Month(), from.Day(), hour, minute, 0, 0, from.Location())
+		if !next.After(from) {
+			next = next.AddDate(0, 0, 1)
+		}
+		return next
+	}, fn)
+}
+
+func (s *Scheduler) register(name, schedule string, interval time.Duration, next func(time.Time) time.Time, fn JobFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, &job{
+		name:     name,
+		schedule: schedule,
+		interval: interval,
+		next:     next,
+		run:      fn,
+		status:   JobStatus{Name: name, Schedule: schedule},
+	})
+}
+
+// OnFailure sets a function called with the name and error of every failed run,
+// including panics but not runs cut short by Stop
+func (s *Scheduler) OnFailure(fn func(name string, err error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onFailure = fn
+}
+
+// OnRun sets a function called after every run, successful or not, except runs cut short by Stop
+func (s *Scheduler) OnRun(fn func(run JobRun)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRun = fn
+}
+
+// Start launches every registered job in its own goroutine
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runCtx, cancelRuns := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.cancelRuns = cancelRuns
+	s.running = true
+
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, runCtx, j)
+	}
+
+	s.logger.Info("Scheduler started", "jobs", len(s.jobs))
+}
+
+// Stop stops scheduling runs and waits for the ones in progress to finish. Runs still going
+// when ctx expires are cancelled.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.cancel()
+	cancelRuns := s.cancelRuns
+	s.mu.Unlock()
+	defer cancelRuns()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("Scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("Scheduler stopped before running jobs finished", "running", s.runningJobs())
+		return ctx.Err()
+	}
+}
+
+// runningJobs lists the jobs with a run in progress
+func (s *Scheduler) runningJobs() []string {
+	var names []string
+	for _, j := range s.jobs {
+		j.mu.Lock()
+		if j.status.Running {
+			names = append(names, j.name)
+		}
+		j.mu.Unlock()
+	}
+	return names
+}
+
+// RunNow runs a registered job immediately, outside its schedule
+func (s *Scheduler) RunNow(ctx context.Context, name string) (bool, error) {
+	for _, j := range s.jobs {
+		if j.name == name {
+			return true, s.execute(ctx, j)
+		}
+	}
+	return false, nil
+}
+
+// Status returns the status of every registered job
+func (s *Scheduler) Status() []JobStatus {
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		j.mu.Lock()
+		statuses = append(statuses, j.status)
+		j.mu.Unlock()
+	}
+	return statuses
+}
+
+func (s *Scheduler) loop(ctx, runCtx context.Context, j *job) {
+	defer s.wg.Done()
+
+	for {
+		nextRun := j.next(time.Now())
+		j.mu.Lock()
+		j.status.NextRunAt = &nextRun
+		j.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(nextRun))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.execute(runCtx, j)
+		}
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, j *job) (err error) {
+	j.mu.Lock()
+	if j.status.Running {
+		j.mu.Unlock()
+		return nil
+	}
+	j.status.Running = true
+	j.mu.Unlock()
+
+	startedAt := time.Now()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+			s.logger.Error("Scheduled job panicked", "job", j.name, "error", recovered)
+		}
+
+		j.mu.Lock()
+		prevFailed := j.status.LastError != ""
+		j.status.Running = false
+		j.status.LastRunAt = &startedAt
+		j.status.LastError = ""
+		if err != nil {
+			j.status.LastError = err.Error()
+		}
+		j.mu.Unlock()
+
+		s.mu.Lock()
+		onFailure, onRun := s.onFailure, s.onRun
+		s.mu.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && onFailure != nil {
+			onFailure(j.name, err)
+		}
+		if onRun != nil {
+			onRun(JobRun{
+				Name:       j.name,
+				Interval:   j.interval,
+				StartedAt:  startedAt,
+				Duration:   time.Since(startedAt),
+				Err:        err,
+				PrevFailed: prevFailed,
+			})
+		}
+	}()
+
+	if err = j.run(ctx); err != nil {
+		s.logger.Error("Scheduled job failed", "job", j.name, "error", err)
+		return err
+	}
+
+	s.logger.Info("Scheduled job completed", "job", j.name, "duration", time.Since(startedAt))
+	return nil
+}
@@ -0,0 +1,49 @@
+// internal/logging/context.go
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+type contextKey struct{}
+
+// WithRequestID returns a context carrying the ID of the request being served
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, requestID)
+}
+
+// RequestID returns the request ID carried by ctx, or "" outside a request
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(contextKey{}).(string)
+	return requestID
+}
+
+// ContextHandler adds the request ID of the context to records logged with the *Context
+// methods of slog.Logger, so service logs can be matched to the request that caused them
+type ContextHandler struct {
+	slog.Handler
+}
+
+// NewContextHandler wraps a handler so records carry the request ID
+func NewContextHandler(handler slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: handler}
+}
+
+// Handle adds the request_id attribute when ctx carries one
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs keeps the wrapper on derived handlers
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup keeps the wrapper on derived handlers
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name)}
+}
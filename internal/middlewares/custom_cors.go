@@ -17,9 +17,9 @@ func CustomCORS() gin.HandlerFunc {
 		// Set CORS headers
 		c.Header("Access-Control-Allow-Origin", origin)
 		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type, Accept, Origin, X-Requested-With, Cookie, client-type")
+		c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type, Accept, Origin, X-Requested-With, Cookie, client-type, X-Display-Key, X-Widget-Token, X-Request-ID, If-Match")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Header("Access-Control-Expose-Headers", "Content-Length, Content-Type, Set-Cookie")
+		c.Header("Access-Control-Expose-Headers", "Content-Length, Content-Type, Set-Cookie, X-Request-ID, ETag")
 		c.Header("Access-Control-Max-Age", "86400")
 
 		// Handle preflight with proper status code
@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DisplayKeyHeader carries the API key of a door display
+const DisplayKeyHeader = "X-Display-Key"
+
+// DisplayAuthenticator resolves a display API key to its registered display
+type DisplayAuthenticator interface {
+	Authenticate(apiKey string) (*models.RoomDisplay, error)
+}
+
+// DisplayKeyMiddleware authenticates door displays and sets the display in context
+func DisplayKeyMiddleware(authenticator DisplayAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey := c.GetHeader(DisplayKeyHeader)
+		if apiKey == "" {
+			// Browser EventSource cannot set headers, so streams may pass the key in the query
+			apiKey = c.Query("key")
+		}
+		if apiKey == "" {
+			c.JSON(http.StatusUnauthorized, dto.NewUnauthorizedError(DisplayKeyHeader+" header required"))
+			c.Abort()
+			return
+		}
+
+		display, err := authenticator.Authenticate(apiKey)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, dto.NewUnauthorizedError("Invalid or revoked display key"))
+			c.Abort()
+			return
+		}
+
+		c.Set("room_display", display)
+		c.Next()
+	}
+}
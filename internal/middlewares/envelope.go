@@ -0,0 +1,69 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Context key set by APIVersion when the response is to be wrapped in the envelope
+const envelopeKey = "response_envelope"
+
+// APIVersion marks the requests of an API version route group. The version decides the
+// response shape, handler DTOs for v1 and the envelope for v2, unless the client asks for
+// the other one in Accept with dto.MediaTypeV1 or dto.MediaTypeV2.
+func APIVersion(version string, envelopeByDefault bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		envelope := envelopeByDefault
+		accept := c.GetHeader("Accept")
+		switch {
+		case strings.Contains(accept, dto.MediaTypeV1):
+			envelope = false
+		case strings.Contains(accept, dto.MediaTypeV2):
+			envelope = true
+		}
+
+		c.Set("api_version", version)
+		c.Set(envelopeKey, envelope)
+		c.Header("Vary", "Accept")
+		c.Next()
+	}
+}
+
+// ResponseEnvelope wraps JSON responses in dto.Envelope for requests APIVersion marked.
+// It must run before ErrorCodes so that error bodies are complete when they are wrapped.
+func ResponseEnvelope() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer = &envelopeWriter{ResponseWriter: c.Writer, ctx: c}
+		c.Next()
+	}
+}
+
+// envelopeWriter wraps bodies as they are written; c.JSON writes the body in one call
+type envelopeWriter struct {
+	gin.ResponseWriter
+	ctx *gin.Context
+}
+
+func (w *envelopeWriter) Write(data []byte) (int, error) {
+	if !w.ctx.GetBool(envelopeKey) || w.Status() == http.StatusNoContent ||
+		!strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		return w.ResponseWriter.Write(data)
+	}
+
+	wrapped, err := dto.WrapEnvelope(w.Status(), data, w.ctx.GetString("request_id"))
+	if err != nil {
+		return w.ResponseWriter.Write(data)
+	}
+	if _, err := w.ResponseWriter.Write(wrapped); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (w *envelopeWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
@@ -0,0 +1,62 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorCodes adds the generic code of the status to JSON error responses written
+// without one, so clients can rely on a code being present on every error. The request ID
+// set by RequestID is added too, for clients to quote when reporting a failure.
+func ErrorCodes() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer = &errorCodeWriter{ResponseWriter: c.Writer, requestID: c.GetString("request_id")}
+		c.Next()
+	}
+}
+
+// errorCodeWriter rewrites error bodies as they are written; c.JSON writes the body in one call
+type errorCodeWriter struct {
+	gin.ResponseWriter
+	requestID string
+}
+
+func (w *errorCodeWriter) Write(data []byte) (int, error) {
+	if w.Status() < http.StatusBadRequest || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		return w.ResponseWriter.Write(data)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return w.ResponseWriter.Write(data)
+	}
+	code, hasCode := body["code"].(string)
+	_, hasRequestID := body["request_id"]
+	if hasCode && code != "" && (hasRequestID || w.requestID == "") {
+		return w.ResponseWriter.Write(data)
+	}
+
+	if !hasCode || code == "" {
+		body["code"] = dto.CodeForStatus(w.Status())
+	}
+	if !hasRequestID && w.requestID != "" {
+		body["request_id"] = w.requestID
+	}
+	coded, err := json.Marshal(body)
+	if err != nil {
+		return w.ResponseWriter.Write(data)
+	}
+	if _, err := w.ResponseWriter.Write(coded); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (w *errorCodeWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
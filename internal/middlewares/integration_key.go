@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IntegrationKeyHeader carries the API key of a third-party system
+const IntegrationKeyHeader = "X-Integration-Key"
+
+// IntegrationAuthenticator resolves an integration key to its scopes
+type IntegrationAuthenticator interface {
+	Authenticate(rawKey string) (*models.IntegrationKey, error)
+}
+
+// IntegrationKeyMiddleware authenticates third-party systems and sets the integration key in context
+func IntegrationKeyMiddleware(authenticator IntegrationAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := c.GetHeader(IntegrationKeyHeader)
+		if rawKey == "" {
+			c.JSON(http.StatusUnauthorized, dto.NewUnauthorizedError(IntegrationKeyHeader+" header required"))
+			c.Abort()
+			return
+		}
+
+		key, err := authenticator.Authenticate(rawKey)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, dto.NewUnauthorizedError("Invalid or revoked integration key"))
+			c.Abort()
+			return
+		}
+
+		c.Set("integration_key", key)
+		c.Next()
+	}
+}
+
+// RequireIntegrationScope refuses integration keys that were not granted the scope
+func RequireIntegrationScope(scope models.IntegrationScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, _ := c.MustGet("integration_key").(*models.IntegrationKey)
+		if key == nil || !key.HasScope(scope) {
+			c.JSON(http.StatusForbidden, dto.NewForbiddenError("This integration key lacks the "+string(scope)+" scope"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
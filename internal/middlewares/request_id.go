@@ -0,0 +1,102 @@
+package middlewares
+
+import (
+	"log/slog"
+	"time"
+
+	"room-reservation-api/internal/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the correlation ID of a request, both ways
+const RequestIDHeader = "X-Request-ID"
+
+// Longest client-supplied request ID kept; longer ones are replaced
+const maxRequestIDLength = 128
+
+// RequestID keeps the X-Request-ID sent by the client, or a gateway in front of the API,
+// and assigns one otherwise. The ID is echoed in the response, set in context as
+// "request_id" and carried by the request context for logging.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), requestID))
+
+		c.Next()
+	}
+}
+
+// RequestLogger logs one line per request with its route, status, latency, user and body
+// sizes. Server errors log at error level and client errors at warn; successful requests to
+// the skipped paths, such as health probes, are not logged.
+func RequestLogger(logger *slog.Logger, skipPaths ...string) gin.HandlerFunc {
+	skip := make(map[string]bool, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = true
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if status < 400 && skip[c.Request.URL.Path] {
+			return
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		// Unmatched requests have no route template, so the raw path is logged instead
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("ip", c.ClientIP()),
+			slog.Int64("request_bytes", c.Request.ContentLength),
+			slog.Int("response_bytes", c.Writer.Size()),
+		}
+		if userID := c.GetString("user_id"); userID != "" {
+			attrs = append(attrs, slog.String("user_id", userID))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+
+		logger.LogAttrs(c.Request.Context(), level, "HTTP Request", attrs...)
+	}
+}
+
+// validRequestID accepts short IDs of printable ASCII, so client values cannot break log lines
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < 0x21 || requestID[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -44,6 +44,16 @@ func RequireManager() gin.HandlerFunc {
 	return RequireRole(models.RoleManager, models.RoleAdmin)
 }
 
+// RequireAuditor middleware checks if user is an auditor; admins are deliberately not included
+func RequireAuditor() gin.HandlerFunc {
+	return RequireRole(models.RoleAuditor)
+}
+
+// RequireFacilities middleware checks if user is facility staff or admin
+func RequireFacilities() gin.HandlerFunc {
+	return RequireRole(models.RoleFacilities, models.RoleAdmin)
+}
+
 // RequireOwnerOrAdmin middleware checks if user is the resource owner or admin
 func RequireOwnerOrAdmin(getUserIDFromParam func(*gin.Context) (string, error)) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SensorKeyHeader carries the API key of an occupancy sensor
+const SensorKeyHeader = "X-Sensor-Key"
+
+// SensorAuthenticator resolves a sensor API key to its registered sensor
+type SensorAuthenticator interface {
+	Authenticate(apiKey string) (*models.OccupancySensor, error)
+}
+
+// SensorKeyMiddleware authenticates occupancy sensors and sets the sensor in context
+func SensorKeyMiddleware(authenticator SensorAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey := c.GetHeader(SensorKeyHeader)
+		if apiKey == "" {
+			c.JSON(http.StatusUnauthorized, dto.NewUnauthorizedError(SensorKeyHeader+" header required"))
+			c.Abort()
+			return
+		}
+
+		sensor, err := authenticator.Authenticate(apiKey)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, dto.NewUnauthorizedError("Invalid or revoked sensor key"))
+			c.Abort()
+			return
+		}
+
+		c.Set("occupancy_sensor", sensor)
+		c.Next()
+	}
+}
@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WidgetTokenHeader carries the token of an embedded booking widget
+const WidgetTokenHeader = "X-Widget-Token"
+
+// WidgetAuthenticator resolves a widget token to its scope
+type WidgetAuthenticator interface {
+	Authenticate(rawToken string) (*models.WidgetToken, error)
+}
+
+// WidgetTokenMiddleware authenticates booking widgets and sets the widget token in context.
+// Browsers running the widget on a site the token does not allow are refused.
+func WidgetTokenMiddleware(authenticator WidgetAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawToken := c.GetHeader(WidgetTokenHeader)
+		if rawToken == "" {
+			c.JSON(http.StatusUnauthorized, dto.NewUnauthorizedError(WidgetTokenHeader+" header required"))
+			c.Abort()
+			return
+		}
+
+		token, err := authenticator.Authenticate(rawToken)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, dto.NewUnauthorizedError("Invalid or revoked widget token"))
+			c.Abort()
+			return
+		}
+
+		if origin := c.GetHeader("Origin"); origin != "" && !token.AllowsOrigin(origin) {
+			c.JSON(http.StatusForbidden, dto.NewForbiddenError("This widget token cannot be used from "+origin))
+			c.Abort()
+			return
+		}
+
+		c.Set("widget_token", token)
+		c.Next()
+	}
+}
@@ -0,0 +1,69 @@
+// internal/models/activity_event.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type ActivityCategory string
+
+const (
+	ActivityCategoryBooking  ActivityCategory = "booking"
+	ActivityCategoryApproval ActivityCategory = "approval"
+	ActivityCategoryChat     ActivityCategory = "chat"
+	ActivityCategoryJob      ActivityCategory = "job"
+
+	// Actions besides the outbox event types used for bookings
+	ActivityFirstApproval = "first_approval" // First of two approvals, the booking stays pending
+	ActivityApproved      = "approved"
+	ActivityRejected      = "rejected"
+	ActivityChatEscalated = "chat_escalated"
+	ActivityJobCompleted  = "job_completed"
+	ActivityJobFailed     = "job_failed"
+	ActivityJobRecovered  = "job_recovered" // A frequent job succeeded again after failing
+
+	// Entities activity is about
+	ActivityEntityReservation  = "reservation"
+	ActivityEntityConversation = "conversation"
+	ActivityEntityJob          = "job"
+)
+
+// ActivityEvent is an entry of the admin activity feed. Entries are only appended, in the
+// transaction of the change when there is one, dated when they are written, and kept until
+// the audit log retention; anonymizing a booking or an account scrubs its names and details.
+type ActivityEvent struct {
+	ID         uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Category   ActivityCategory `json:"category" gorm:"type:varchar(20);not null;index"`
+	Action     string           `json:"action" gorm:"size:50;not null"`
+	ActorID    *uuid.UUID       `json:"actor_id,omitempty" gorm:"type:uuid;index"` // Nil for the system
+	EntityType string           `json:"entity_type" gorm:"size:30;not null;index:idx_activity_entity,priority:1"`
+	EntityID   *uuid.UUID       `json:"entity_id,omitempty" gorm:"type:uuid;index:idx_activity_entity,priority:2"` // Nil for jobs
+	EntityName string           `json:"entity_name,omitempty" gorm:"size:255"`                                     // Booking title or job name
+	Details    datatypes.JSON   `json:"details,omitempty" gorm:"type:jsonb"`
+	SourceID   *uuid.UUID       `json:"-" gorm:"type:uuid;uniqueIndex"` // Outbox event recorded, so retries add nothing
+	OccurredAt time.Time        `json:"occurred_at" gorm:"not null;index:idx_activity_occurred,priority:1"`
+	CreatedAt  time.Time        `json:"created_at"`
+
+	// Relationships
+	Actor *User `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+}
+
+// TableName returns the table name for ActivityEvent model
+func (ActivityEvent) TableName() string {
+	return "activity_events"
+}
+
+// BeforeCreate hook to set ID and time if not provided
+func (e *ActivityEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now()
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AssignmentStatus string
+
+const (
+	AssignmentStatusPending   AssignmentStatus = "pending"
+	AssignmentStatusAccepted  AssignmentStatus = "accepted"
+	AssignmentStatusDeclined  AssignmentStatus = "declined"
+	AssignmentStatusExpired   AssignmentStatus = "expired"
+	AssignmentStatusCancelled AssignmentStatus = "cancelled"
+)
+
+// AgentAssignment is an offer of a support conversation to an agent.
+// Declined and expired offers keep the conversation from being offered to the same agent again.
+type AgentAssignment struct {
+	ID             uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ConversationID uuid.UUID        `json:"conversation_id" gorm:"type:uuid;not null;index"`
+	AgentID        uuid.UUID        `json:"agent_id" gorm:"type:uuid;not null;index"`
+	Status         AssignmentStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	OfferedAt      time.Time        `json:"offered_at" gorm:"not null"`
+	RespondedAt    *time.Time       `json:"responded_at,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+
+	// Relationships
+	Agent *User `json:"agent,omitempty" gorm:"foreignKey:AgentID"`
+}
+
+// TableName returns the table name for AgentAssignment model
+func (AgentAssignment) TableName() string {
+	return "agent_assignments"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (aa *AgentAssignment) BeforeCreate(tx *gorm.DB) error {
+	if aa.ID == uuid.Nil {
+		aa.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsPending checks if the agent has not answered the offer yet
+func (aa *AgentAssignment) IsPending() bool {
+	return aa.Status == AssignmentStatusPending
+}
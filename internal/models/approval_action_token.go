@@ -0,0 +1,49 @@
+// internal/models/approval_action_token.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ApprovalAction string
+
+const (
+	ApprovalActionApprove ApprovalAction = "approve"
+	ApprovalActionReject  ApprovalAction = "reject"
+)
+
+// ApprovalActionToken lets an approver decide a pending reservation from the links of the
+// approval email, without logging in. Links carry the token's ID signed with the action, and a
+// token is used once; using one also spends the other links sent to the approver for it.
+type ApprovalActionToken struct {
+	ID            uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ReservationID uuid.UUID      `json:"reservation_id" gorm:"type:uuid;not null;index"`
+	ApproverID    uuid.UUID      `json:"approver_id" gorm:"type:uuid;not null;index"`
+	Action        ApprovalAction `json:"action" gorm:"type:varchar(10);not null"`
+	ExpiresAt     time.Time      `json:"expires_at" gorm:"not null;index"`
+	UsedAt        *time.Time     `json:"used_at,omitempty"`
+	UsedFromIP    string         `json:"used_from_ip,omitempty" gorm:"size:45"`
+	Comments      string         `json:"comments,omitempty" gorm:"type:text"` // Given with the decision
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+// TableName returns the table name for ApprovalActionToken model
+func (ApprovalActionToken) TableName() string {
+	return "approval_action_tokens"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (t *ApprovalActionToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsUsable checks if the link can still be acted on
+func (t *ApprovalActionToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
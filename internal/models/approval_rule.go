@@ -0,0 +1,76 @@
+// internal/models/approval_rule.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// ApprovalRule makes the approval of a space conditional: bookings within every
+// threshold set are confirmed straight away, the others wait for approval.
+// Two-step rules need a second, different approver after the space manager.
+type ApprovalRule struct {
+	ID                         uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SpaceID                    uuid.UUID      `json:"space_id" gorm:"type:uuid;not null;uniqueIndex"`
+	AutoApproveMaxMinutes      *int           `json:"auto_approve_max_minutes,omitempty"`              // Bookings at most this long qualify
+	AutoApproveMaxParticipants *int           `json:"auto_approve_max_participants,omitempty"`         // Bookings for at most this many people qualify
+	AutoApproveMinTenureDays   *int           `json:"auto_approve_min_tenure_days,omitempty"`          // Users with an account at least this old qualify
+	AutoApproveRoles           pq.StringArray `json:"auto_approve_roles,omitempty" gorm:"type:text[]"` // Users with these roles qualify regardless of tenure
+	TwoStep                    bool           `json:"two_step"`
+	UpdatedByID                uuid.UUID      `json:"updated_by_id" gorm:"type:uuid;not null"`
+	CreatedAt                  time.Time      `json:"created_at"`
+	UpdatedAt                  time.Time      `json:"updated_at"`
+
+	// Relationships
+	Space *Space `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
+}
+
+// TableName returns the table name for ApprovalRule model
+func (ApprovalRule) TableName() string {
+	return "approval_rules"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (r *ApprovalRule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// HasThresholds checks if the rule can auto-approve anything
+func (r *ApprovalRule) HasThresholds() bool {
+	return r.AutoApproveMaxMinutes != nil || r.AutoApproveMaxParticipants != nil ||
+		r.AutoApproveMinTenureDays != nil || len(r.AutoApproveRoles) > 0
+}
+
+// AutoApproves checks if a booking stays within every threshold of the rule.
+// A rule without thresholds never auto-approves.
+func (r *ApprovalRule) AutoApproves(duration time.Duration, participants int, user *User, now time.Time) bool {
+	if !r.HasThresholds() {
+		return false
+	}
+	if r.AutoApproveMaxMinutes != nil && duration > time.Duration(*r.AutoApproveMaxMinutes)*time.Minute {
+		return false
+	}
+	if r.AutoApproveMaxParticipants != nil && participants > *r.AutoApproveMaxParticipants {
+		return false
+	}
+
+	// Seniority is met by tenure or by role, whichever the rule sets
+	if r.AutoApproveMinTenureDays == nil && len(r.AutoApproveRoles) == 0 {
+		return true
+	}
+	if r.AutoApproveMinTenureDays != nil && !user.CreatedAt.After(now.AddDate(0, 0, -*r.AutoApproveMinTenureDays)) {
+		return true
+	}
+	for _, role := range r.AutoApproveRoles {
+		if UserRole(role) == user.Role {
+			return true
+		}
+	}
+	return false
+}
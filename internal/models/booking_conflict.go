@@ -0,0 +1,57 @@
+// internal/models/booking_conflict.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BookingConflictReason explains why a booking attempt was turned away
+type BookingConflictReason string
+
+const (
+	ConflictReasonSlotTaken BookingConflictReason = "slot_taken" // Another reservation holds the slot
+	ConflictReasonVIPBlock  BookingConflictReason = "vip_block"  // The slot is inside a VIP block
+)
+
+// BookingConflict records a booking attempt rejected because the slot was already taken.
+// Managers use these to spot spaces with chronic contention.
+type BookingConflict struct {
+	ID        uuid.UUID             `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SpaceID   uuid.UUID             `json:"space_id" gorm:"type:uuid;not null;index"`
+	UserID    uuid.UUID             `json:"user_id" gorm:"type:uuid;not null;index"`
+	Reason    BookingConflictReason `json:"reason" gorm:"size:20;not null"`
+	StartTime time.Time             `json:"start_time" gorm:"not null"` // Requested slot
+	EndTime   time.Time             `json:"end_time" gorm:"not null"`
+	CreatedAt time.Time             `json:"created_at" gorm:"index"` // When the attempt was made
+
+	// Relationships
+	Space *Space `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
+	User  *User  `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// BookingConflictSummary aggregates the conflicts recorded for one space
+type BookingConflictSummary struct {
+	SpaceID        uuid.UUID `json:"space_id"`
+	SpaceName      string    `json:"space_name"`
+	TotalConflicts int64     `json:"total_conflicts"`
+	SlotTaken      int64     `json:"slot_taken"`
+	VIPBlocked     int64     `json:"vip_blocked"`
+	DistinctUsers  int64     `json:"distinct_users"`
+	LastConflictAt time.Time `json:"last_conflict_at"`
+}
+
+// TableName returns the table name for BookingConflict model
+func (BookingConflict) TableName() string {
+	return "booking_conflicts"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (bc *BookingConflict) BeforeCreate(tx *gorm.DB) error {
+	if bc.ID == uuid.Nil {
+		bc.ID = uuid.New()
+	}
+	return nil
+}
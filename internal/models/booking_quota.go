@@ -0,0 +1,53 @@
+// internal/models/booking_quota.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QuotaScope is who a booking quota applies to
+type QuotaScope string
+
+const (
+	QuotaScopeUser QuotaScope = "user" // Hours a user can book per week
+	QuotaScopeTeam QuotaScope = "team" // Premium-room hours a department can book per month
+)
+
+// QuotaOverride replaces the configured quota of one user or one department (team).
+// A limit of 0 lifts the quota; the override stops applying once it expires.
+type QuotaOverride struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Scope       QuotaScope `json:"scope" gorm:"type:varchar(10);not null"`
+	UserID      *uuid.UUID `json:"user_id,omitempty" gorm:"type:uuid;uniqueIndex"`   // Set for user quotas
+	Department  *string    `json:"department,omitempty" gorm:"size:100;uniqueIndex"` // Set for team quotas
+	LimitHours  int        `json:"limit_hours" gorm:"not null"`
+	Reason      string     `json:"reason,omitempty" gorm:"type:text"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	UpdatedByID uuid.UUID  `json:"updated_by_id" gorm:"type:uuid;not null"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Relationships
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for QuotaOverride model
+func (QuotaOverride) TableName() string {
+	return "quota_overrides"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (o *QuotaOverride) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
+
+// AppliesAt checks if the override has not expired at the given time
+func (o *QuotaOverride) AppliesAt(now time.Time) bool {
+	return o.ExpiresAt == nil || now.Before(*o.ExpiresAt)
+}
@@ -0,0 +1,48 @@
+// internal/models/booking_strike.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StrikeReason is why a user earned a strike
+type StrikeReason string
+
+const (
+	StrikeReasonLateCancellation StrikeReason = "late_cancellation" // Cancelled within the space's cancellation window
+	StrikeReasonNoShow           StrikeReason = "no_show"           // Never checked in
+)
+
+// BookingStrike records a late cancellation or no-show against the user who booked.
+// Users with too many recent strikes are temporarily kept from booking premium spaces;
+// admins can clear strikes, which keeps them for history but stops them counting.
+type BookingStrike struct {
+	ID            uuid.UUID    `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID        uuid.UUID    `json:"user_id" gorm:"type:uuid;not null;index"`
+	ReservationID uuid.UUID    `json:"reservation_id" gorm:"type:uuid;not null;uniqueIndex"` // One strike per reservation
+	Reason        StrikeReason `json:"reason" gorm:"type:varchar(30);not null"`
+	Note          string       `json:"note,omitempty" gorm:"type:text"`
+	ClearedAt     *time.Time   `json:"cleared_at,omitempty"`
+	ClearedByID   *uuid.UUID   `json:"cleared_by_id,omitempty" gorm:"type:uuid"`
+	CreatedAt     time.Time    `json:"created_at" gorm:"index"`
+
+	// Relationships
+	User        *User        `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Reservation *Reservation `json:"reservation,omitempty" gorm:"foreignKey:ReservationID"`
+}
+
+// TableName returns the table name for BookingStrike model
+func (BookingStrike) TableName() string {
+	return "booking_strikes"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (s *BookingStrike) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
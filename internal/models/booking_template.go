@@ -0,0 +1,65 @@
+// internal/models/booking_template.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FavoriteSpace is a space a user marked to find it quickly
+type FavoriteSpace struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_favorite_space_user_space"`
+	SpaceID   uuid.UUID `json:"space_id" gorm:"type:uuid;not null;uniqueIndex:idx_favorite_space_user_space"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	Space Space `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
+}
+
+// TableName returns the table name for FavoriteSpace model
+func (FavoriteSpace) TableName() string {
+	return "favorite_spaces"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (f *FavoriteSpace) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
+
+// BookingTemplate is a reservation a user makes often, booked again with one call by giving a start time
+type BookingTemplate struct {
+	ID               uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID           uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	Name             string     `json:"name" gorm:"size:100;not null"`
+	Title            string     `json:"title" gorm:"size:200;not null"`
+	Description      string     `json:"description" gorm:"type:text"`
+	DurationMinutes  int        `json:"duration_minutes" gorm:"not null"`
+	ParticipantCount int        `json:"participant_count" gorm:"not null;default:1"`
+	SpaceID          *uuid.UUID `json:"space_id,omitempty" gorm:"type:uuid;index"` // Preferred space; any suitable room in Building when empty
+	Building         string     `json:"building,omitempty" gorm:"size:50"`
+	IsPrivate        bool       `json:"is_private" gorm:"default:false"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	// Relationships
+	Space *Space `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
+}
+
+// TableName returns the table name for BookingTemplate model
+func (BookingTemplate) TableName() string {
+	return "booking_templates"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (t *BookingTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// internal/models/broadcast_channel.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BroadcastKind is what a broadcast post is about
+type BroadcastKind string
+
+const (
+	BroadcastKindAnnouncement BroadcastKind = "announcement"
+	BroadcastKindMaintenance  BroadcastKind = "maintenance"
+)
+
+// BroadcastChannel is a read-only channel admins and managers post announcements to. Posts go
+// to every user, or for a building channel to the people who use the building.
+type BroadcastChannel struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name        string     `json:"name" gorm:"size:100;not null;uniqueIndex"`
+	Description string     `json:"description" gorm:"type:text"`
+	Building    string     `json:"building" gorm:"size:100;index"` // Empty for a channel to every user
+	CreatedByID uuid.UUID  `json:"created_by_id" gorm:"type:uuid;not null"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"` // No more posts once archived
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// BroadcastPost is an announcement posted to a broadcast channel
+type BroadcastPost struct {
+	ID             uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ChannelID      uuid.UUID     `json:"channel_id" gorm:"type:uuid;not null;index"`
+	AuthorID       uuid.UUID     `json:"author_id" gorm:"type:uuid;not null"`
+	Kind           BroadcastKind `json:"kind" gorm:"type:varchar(20);not null;default:'announcement'"`
+	Title          string        `json:"title" gorm:"size:200;not null"`
+	Body           string        `json:"body" gorm:"type:text;not null"`
+	RecipientCount int           `json:"recipient_count" gorm:"not null;default:0"` // Audience when posted
+	CreatedAt      time.Time     `json:"created_at"`
+
+	// Relationships
+	Channel *BroadcastChannel `json:"channel,omitempty" gorm:"foreignKey:ChannelID"`
+	Author  *User             `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+}
+
+// BroadcastRecipient is a user a broadcast post was delivered to, and when they read it
+type BroadcastRecipient struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PostID    uuid.UUID  `json:"post_id" gorm:"type:uuid;not null;uniqueIndex:idx_broadcast_recipient_user"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_broadcast_recipient_user;index"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	// Relationships
+	Post *BroadcastPost `json:"post,omitempty" gorm:"foreignKey:PostID"`
+}
+
+// TableName returns the table name for BroadcastChannel model
+func (BroadcastChannel) TableName() string {
+	return "broadcast_channels"
+}
+
+// TableName returns the table name for BroadcastPost model
+func (BroadcastPost) TableName() string {
+	return "broadcast_posts"
+}
+
+// TableName returns the table name for BroadcastRecipient model
+func (BroadcastRecipient) TableName() string {
+	return "broadcast_recipients"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (c *BroadcastChannel) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to set ID if not provided
+func (p *BroadcastPost) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to set ID if not provided
+func (r *BroadcastRecipient) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsArchived checks if the channel no longer takes posts
+func (c *BroadcastChannel) IsArchived() bool {
+	return c.ArchivedAt != nil
+}
+
+// IsValid checks if the broadcast kind is valid
+func (k BroadcastKind) IsValid() bool {
+	return k == BroadcastKindAnnouncement || k == BroadcastKindMaintenance
+}
+
+// BroadcastReadStats counts the reads of a broadcast post
+type BroadcastReadStats struct {
+	PostID     uuid.UUID  `json:"post_id"`
+	ReadCount  int64      `json:"read_count"`
+	LastReadAt *time.Time `json:"last_read_at,omitempty"`
+}
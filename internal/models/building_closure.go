@@ -0,0 +1,61 @@
+// internal/models/building_closure.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BuildingClosure closes a whole building, or one of its floors, for a range of days, e.g. for a
+// holiday or renovation. Its spaces cannot be booked meanwhile, and the bookings already made
+// are flagged with the closure until moved or the closure ends.
+type BuildingClosure struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Building    string     `json:"building" gorm:"size:100;not null;index"`
+	Floor       *int       `json:"floor,omitempty"` // nil closes every floor
+	StartDate   time.Time  `json:"start_date" gorm:"type:date;not null"`
+	EndDate     time.Time  `json:"end_date" gorm:"type:date;not null"` // Inclusive
+	Reason      string     `json:"reason" gorm:"type:text"`
+	CreatedByID uuid.UUID  `json:"created_by_id" gorm:"type:uuid;not null"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"` // Set when reopened before the end date
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Relationships
+	CreatedBy *User `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID"`
+}
+
+// TableName returns the table name for BuildingClosure model
+func (BuildingClosure) TableName() string {
+	return "building_closures"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (c *BuildingClosure) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// Window returns the time the closure runs, from midnight (UTC) of its first day until
+// midnight after its last day, or until it was ended
+func (c *BuildingClosure) Window() (time.Time, time.Time) {
+	start := time.Date(c.StartDate.Year(), c.StartDate.Month(), c.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(c.EndDate.Year(), c.EndDate.Month(), c.EndDate.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	if c.EndedAt != nil && c.EndedAt.Before(end) {
+		end = *c.EndedAt
+	}
+	return start, end
+}
+
+// Closes checks if the closure takes the space out of use during part of the time range
+func (c *BuildingClosure) Closes(space *Space, start, end time.Time) bool {
+	if space.Building != c.Building || (c.Floor != nil && *c.Floor != space.Floor) {
+		return false
+	}
+	closedFrom, closedUntil := c.Window()
+	return start.Before(closedUntil) && closedFrom.Before(end)
+}
@@ -0,0 +1,70 @@
+// internal/models/bulk_cancellation.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Outcomes of a reservation in a bulk cancellation
+const (
+	BulkCancelOutcomeCancelled = "cancelled"
+	BulkCancelOutcomeFailed    = "failed"
+)
+
+// BulkCancellationResult is the outcome for one reservation of a bulk cancellation
+type BulkCancellationResult struct {
+	ReservationID uuid.UUID `json:"reservation_id"`
+	Title         string    `json:"title"`
+	SpaceName     string    `json:"space_name"`
+	UserEmail     string    `json:"user_email"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	Outcome       string    `json:"outcome"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// BulkCancellation records an admin cancelling every reservation matching a set of filters
+type BulkCancellation struct {
+	ID            uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	RequestedByID uuid.UUID      `json:"requested_by_id" gorm:"type:uuid;not null;index"`
+	Reason        string         `json:"reason" gorm:"type:text;not null"`
+	Filters       datatypes.JSON `json:"filters" gorm:"type:jsonb"`
+	Matched       int            `json:"matched" gorm:"not null;default:0"`
+	Cancelled     int            `json:"cancelled" gorm:"not null;default:0"`
+	Failed        int            `json:"failed" gorm:"not null;default:0"`
+	Results       datatypes.JSON `json:"-" gorm:"type:jsonb"` // []BulkCancellationResult, served as the report
+	CompletedAt   *time.Time     `json:"completed_at"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+
+	// Relationships
+	RequestedBy *User `json:"requested_by,omitempty" gorm:"foreignKey:RequestedByID"`
+}
+
+// TableName returns the table name for BulkCancellation model
+func (BulkCancellation) TableName() string {
+	return "bulk_cancellations"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (b *BulkCancellation) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// GetResults decodes the per-reservation outcomes
+func (b *BulkCancellation) GetResults() ([]BulkCancellationResult, error) {
+	var results []BulkCancellationResult
+	if len(b.Results) == 0 {
+		return results, nil
+	}
+	err := json.Unmarshal(b.Results, &results)
+	return results, err
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// CannedResponse is a reusable support answer. Agents insert it by shortcut,
+// and responses flagged AutoReply let the support bot answer matching questions.
+type CannedResponse struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Title       string         `json:"title" gorm:"size:100;not null"`
+	Shortcut    string         `json:"shortcut" gorm:"size:50;not null;uniqueIndex"`
+	Content     string         `json:"content" gorm:"type:text;not null"`
+	Category    string         `json:"category" gorm:"size:50;index"`
+	Keywords    pq.StringArray `json:"keywords" gorm:"type:text[]"` // Phrases the bot matches in customer messages
+	AutoReply   bool           `json:"auto_reply" gorm:"not null;default:false"`
+	IsActive    bool           `json:"is_active" gorm:"not null;default:true"`
+	UsageCount  int            `json:"usage_count" gorm:"not null;default:0"`
+	CreatedByID uuid.UUID      `json:"created_by_id" gorm:"type:uuid;not null"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+
+	// Relationships
+	CreatedBy *User `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID"`
+}
+
+// TableName returns the table name for CannedResponse model
+func (CannedResponse) TableName() string {
+	return "canned_responses"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (cr *CannedResponse) BeforeCreate(tx *gorm.DB) error {
+	if cr.ID == uuid.Nil {
+		cr.ID = uuid.New()
+	}
+	return nil
+}
+
+// MatchScore counts the keywords found in a customer message, ignoring case
+func (cr *CannedResponse) MatchScore(text string) int {
+	text = strings.ToLower(text)
+
+	score := 0
+	for _, keyword := range cr.Keywords {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		if keyword != "" && strings.Contains(text, keyword) {
+			score++
+		}
+	}
+	return score
+}
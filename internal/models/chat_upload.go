@@ -0,0 +1,58 @@
+// internal/models/chat_upload.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChatUploadQuarantineDir holds uploads, under the upload directory, that have not passed
+// antivirus scanning yet
+const ChatUploadQuarantineDir = "quarantine"
+
+// ChatUpload records a file stored for a conversation under the name the server gave it.
+// Attachments and the rescan job find files through this record, never through the URL a
+// client sends back.
+type ChatUpload struct {
+	ID             uuid.UUID            `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ConversationID uuid.UUID            `json:"conversation_id" gorm:"type:uuid;not null;uniqueIndex:idx_chat_upload_name"`
+	UploaderID     uuid.UUID            `json:"uploader_id" gorm:"type:uuid;not null;index"`
+	StoredName     string               `json:"stored_name" gorm:"size:100;not null;uniqueIndex:idx_chat_upload_name"` // Random ID and extension
+	ScanStatus     AttachmentScanStatus `json:"scan_status" gorm:"size:20;not null;index"`
+	ScanSignature  *string              `json:"scan_signature,omitempty" gorm:"size:255"`
+	ScannedAt      *time.Time           `json:"scanned_at,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+}
+
+// TableName returns the table name for ChatUpload model
+func (ChatUpload) TableName() string {
+	return "chat_uploads"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (u *ChatUpload) BeforeCreate(tx *gorm.DB) error {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	return nil
+}
+
+// RelativePath returns where the file is stored under the upload directory, with slashes:
+// in quarantine until it passed scanning
+func (u *ChatUpload) RelativePath() string {
+	path := u.ConversationID.String() + "/" + u.StoredName
+	if u.ScanStatus == ScanStatusPending {
+		return ChatUploadQuarantineDir + "/" + path
+	}
+	return path
+}
+
+// FileURL returns the URL the file is served under, empty once it was found infected
+func (u *ChatUpload) FileURL() string {
+	if u.ScanStatus == ScanStatusInfected {
+		return ""
+	}
+	return "/uploads/" + u.RelativePath()
+}
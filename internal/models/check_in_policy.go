@@ -0,0 +1,45 @@
+// internal/models/check_in_policy.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CheckInPolicy enforces check-in for reservations of a space type, e.g. hot desks: a
+// confirmed reservation not checked in within the window after its start is released
+// and reported as a no-show, so the space can be booked by someone else
+type CheckInPolicy struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SpaceType     SpaceType `json:"space_type" gorm:"type:varchar(50);not null;uniqueIndex"`
+	WindowMinutes int       `json:"window_minutes" gorm:"not null"`
+	Enabled       bool      `json:"enabled" gorm:"not null"`
+	UpdatedByID   uuid.UUID `json:"updated_by_id" gorm:"type:uuid;not null"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for CheckInPolicy model
+func (CheckInPolicy) TableName() string {
+	return "check_in_policies"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (p *CheckInPolicy) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// Window returns how long after the start a reservation must be checked in
+func (p *CheckInPolicy) Window() time.Duration {
+	return time.Duration(p.WindowMinutes) * time.Minute
+}
+
+// DeadlineFor returns when the reservation is released if not checked in
+func (p *CheckInPolicy) DeadlineFor(reservation *Reservation) time.Time {
+	return reservation.StartTime.Add(p.Window())
+}
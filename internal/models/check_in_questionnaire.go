@@ -0,0 +1,98 @@
+// internal/models/check_in_questionnaire.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// QuestionType defines how a questionnaire question is answered
+type QuestionType string
+
+const (
+	QuestionTypeYesNo  QuestionType = "yes_no"
+	QuestionTypeChoice QuestionType = "choice"
+	QuestionTypeText   QuestionType = "text"
+)
+
+// QuestionnaireQuestion is a single question of a check-in questionnaire
+type QuestionnaireQuestion struct {
+	ID             string       `json:"id"`
+	Text           string       `json:"text"`
+	Type           QuestionType `json:"type"`
+	Required       bool         `json:"required"`
+	Options        []string     `json:"options,omitempty"`         // Allowed answers for choice questions
+	ExpectedAnswer string       `json:"expected_answer,omitempty"` // Answer needed to enter, e.g. "yes" for attestations
+}
+
+// CheckInQuestionnaire is the questionnaire users of a space answer before they can check in
+type CheckInQuestionnaire struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SpaceID     uuid.UUID      `json:"space_id" gorm:"type:uuid;not null;uniqueIndex"`
+	Title       string         `json:"title" gorm:"size:200;not null"`
+	Questions   datatypes.JSON `json:"questions" gorm:"type:jsonb;not null"` // []QuestionnaireQuestion
+	IsActive    bool           `json:"is_active" gorm:"not null"`
+	UpdatedByID uuid.UUID      `json:"updated_by_id" gorm:"type:uuid;not null"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+
+	// Relationships
+	Space *Space `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
+}
+
+// TableName returns the table name for CheckInQuestionnaire model
+func (CheckInQuestionnaire) TableName() string {
+	return "check_in_questionnaires"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (q *CheckInQuestionnaire) BeforeCreate(tx *gorm.DB) error {
+	if q.ID == uuid.Nil {
+		q.ID = uuid.New()
+	}
+	return nil
+}
+
+// GetQuestions decodes the questions of the questionnaire
+func (q *CheckInQuestionnaire) GetQuestions() ([]QuestionnaireQuestion, error) {
+	var questions []QuestionnaireQuestion
+	if len(q.Questions) == 0 {
+		return questions, nil
+	}
+	err := json.Unmarshal(q.Questions, &questions)
+	return questions, err
+}
+
+// QuestionnaireSubmission stores a user's answers for a reservation. The questions are
+// copied as answered, so later edits to the questionnaire do not alter the compliance record.
+type QuestionnaireSubmission struct {
+	ID              uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ReservationID   uuid.UUID      `json:"reservation_id" gorm:"type:uuid;not null;uniqueIndex"`
+	QuestionnaireID uuid.UUID      `json:"questionnaire_id" gorm:"type:uuid;not null;index"`
+	UserID          uuid.UUID      `json:"user_id" gorm:"type:uuid;not null;index"`
+	Questions       datatypes.JSON `json:"questions" gorm:"type:jsonb;not null"` // []QuestionnaireQuestion at submission time
+	Answers         datatypes.JSON `json:"answers" gorm:"type:jsonb;not null"`   // Question ID -> answer
+	SubmittedAt     time.Time      `json:"submitted_at" gorm:"not null"`
+	CreatedAt       time.Time      `json:"created_at"`
+
+	// Relationships
+	Reservation *Reservation `json:"reservation,omitempty" gorm:"foreignKey:ReservationID"`
+	User        *User        `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for QuestionnaireSubmission model
+func (QuestionnaireSubmission) TableName() string {
+	return "questionnaire_submissions"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (s *QuestionnaireSubmission) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// internal/models/compliance_export.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ComplianceExportScope is what a compliance export covers
+type ComplianceExportScope string
+
+const (
+	ComplianceScopeConversation ComplianceExportScope = "conversation" // Every message of one conversation
+	ComplianceScopeUser         ComplianceExportScope = "user"         // Every message a user sent, across conversations
+)
+
+// ComplianceExport records a tamper-evident export produced for a legal request.
+// The head hash and signature are kept so a bundle handed over later can be
+// matched against what the server actually issued.
+type ComplianceExport struct {
+	ID             uuid.UUID             `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Scope          ComplianceExportScope `json:"scope" gorm:"type:varchar(20);not null"`
+	ConversationID *uuid.UUID            `json:"conversation_id,omitempty" gorm:"type:uuid;index"`
+	SubjectUserID  *uuid.UUID            `json:"subject_user_id,omitempty" gorm:"type:uuid;index"`
+	CaseReference  string                `json:"case_reference" gorm:"size:100;not null;index"`
+	Reason         string                `json:"reason,omitempty" gorm:"type:text"`
+	From           *time.Time            `json:"from,omitempty"`
+	To             *time.Time            `json:"to,omitempty"`
+	MessageCount   int                   `json:"message_count" gorm:"not null"`
+	HeadHash       string                `json:"head_hash" gorm:"size:64;not null"` // Hash of the last entry of the chain
+	Signature      string                `json:"signature" gorm:"type:text;not null"`
+	KeyID          string                `json:"key_id" gorm:"size:16;not null"`
+	RequestedByID  uuid.UUID             `json:"requested_by_id" gorm:"type:uuid;not null;index"`
+	CreatedAt      time.Time             `json:"created_at"`
+
+	// Relationships
+	RequestedBy *User `json:"requested_by,omitempty" gorm:"foreignKey:RequestedByID"`
+}
+
+// TableName returns the table name for ComplianceExport model
+func (ComplianceExport) TableName() string {
+	return "compliance_exports"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (e *ComplianceExport) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
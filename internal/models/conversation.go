@@ -30,16 +30,30 @@ type Conversation struct {
 	Priority        ConversationPriority `json:"priority" gorm:"type:varchar(20);not null;default:'normal'"`
 	Status          ConversationStatus   `json:"status" gorm:"type:varchar(20);not null;default:'active'"`
 	IsArchived      bool                 `json:"is_archived" gorm:"not null;default:false"`
+	ArchivedAt      *time.Time           `json:"archived_at"`
+	AutoArchived    bool                 `json:"auto_archived" gorm:"not null;default:false"` // Archived by the inactivity job, undone by the next message
 	AssignedAgentID *uuid.UUID           `json:"assigned_agent_id" gorm:"type:uuid"`
+	Department      string               `json:"department" gorm:"size:100"` // Support department used to route the conversation
 	Tags            pq.StringArray       `json:"tags" gorm:"type:text[]"`
 	CreatedAt       time.Time            `json:"created_at"`
 	UpdatedAt       time.Time            `json:"updated_at"`
 	LastMessageAt   time.Time            `json:"last_message_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	ResolvedAt      *time.Time           `json:"resolved_at"`
+	AutoResolved    bool                 `json:"auto_resolved" gorm:"not null;default:false"`
+	ReopenCount     int                  `json:"reopen_count" gorm:"not null;default:0"`
+	LastReopenedAt  *time.Time           `json:"last_reopened_at"`
+	EscalatedAt     *time.Time           `json:"escalated_at"`                                                                                                   // Set when no agent accepted the conversation
+	ReservationID   *uuid.UUID           `json:"reservation_id,omitempty" gorm:"type:uuid;index;uniqueIndex:idx_conversation_attendee_chat,where:attendee_chat"` // Booking the conversation is about
+	SpaceID         *uuid.UUID           `json:"space_id,omitempty" gorm:"type:uuid;index"`                                                                      // Space the conversation is about
+	AttendeeChat    bool                 `json:"attendee_chat" gorm:"not null;default:false"`                                                                    // Group chat of the reservation's attendees, archived once it is over
+	Encrypted       bool                 `json:"encrypted" gorm:"not null;default:false"`                                                                        // Messages carry client-side ciphertext the server cannot read
 
 	// Relationships
 	Participants  []ConversationParticipant `json:"participants,omitempty" gorm:"foreignKey:ConversationID"`
 	Messages      []Message                 `json:"messages,omitempty" gorm:"foreignKey:ConversationID"`
 	AssignedAgent *User                     `json:"assigned_agent,omitempty" gorm:"foreignKey:AssignedAgentID"`
+	Reservation   *Reservation              `json:"reservation,omitempty" gorm:"foreignKey:ReservationID;constraint:OnDelete:SET NULL"`
+	Space         *Space                    `json:"space,omitempty" gorm:"foreignKey:SpaceID;constraint:OnDelete:SET NULL"`
 }
 
 // TableName returns the table name for Conversation model
@@ -60,6 +74,11 @@ func (c *Conversation) IsActive() bool {
 	return c.Status == ConversationStatusActive
 }
 
+// IsResolved checks if conversation has been resolved
+func (c *Conversation) IsResolved() bool {
+	return c.Status == ConversationStatusResolved
+}
+
 // IsHighPriority checks if conversation has high or urgent priority
 func (c *Conversation) IsHighPriority() bool {
 	return c.Priority == ConversationPriorityHigh || c.Priority == ConversationPriorityUrgent
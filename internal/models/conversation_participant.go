@@ -22,6 +22,8 @@ type ConversationParticipant struct {
 	UserType       ParticipantType `json:"user_type" gorm:"type:varchar(20);not null"`
 	JoinedAt       time.Time       `json:"joined_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
 	UnreadCount    int             `json:"unread_count" gorm:"not null;default:0"`
+	Muted          bool            `json:"muted" gorm:"not null;default:false"` // Notifications about the conversation are suppressed
+	MutedUntil     *time.Time      `json:"muted_until,omitempty"`               // Nil mutes until unmuted
 
 	// Relationships
 	Conversation *Conversation `json:"conversation,omitempty" gorm:"foreignKey:ConversationID"`
@@ -51,6 +53,11 @@ func (cp *ConversationParticipant) IsAgent() bool {
 	return cp.UserType == ParticipantTypeAgent
 }
 
+// IsMuted checks if notifications about the conversation are suppressed for the participant
+func (cp *ConversationParticipant) IsMuted(now time.Time) bool {
+	return cp.Muted && (cp.MutedUntil == nil || cp.MutedUntil.After(now))
+}
+
 // HasUnreadMessages checks if participant has unread messages
 func (cp *ConversationParticipant) HasUnreadMessages() bool {
 	return cp.UnreadCount > 0
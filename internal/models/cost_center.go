@@ -0,0 +1,61 @@
+// internal/models/cost_center.go
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Bounds of the free-form tags of a reservation
+const (
+	MaxReservationTags   = 10
+	MaxReservationTagLen = 30
+)
+
+// CostCenter is an accounting code reservations can be charged to. Admins keep the list;
+// reservations store the code, so removing or deactivating one leaves past bookings as they are.
+type CostCenter struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Code        string    `json:"code" gorm:"size:50;not null;uniqueIndex"` // Upper-case
+	Name        string    `json:"name" gorm:"size:200;not null"`
+	IsActive    bool      `json:"is_active" gorm:"not null"` // Inactive codes cannot be given to new bookings
+	UpdatedByID uuid.UUID `json:"updated_by_id" gorm:"type:uuid;not null"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for CostCenter model
+func (CostCenter) TableName() string {
+	return "cost_centers"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (c *CostCenter) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// NormalizeCostCenterCode trims a cost center code and puts it in upper case
+func NormalizeCostCenterCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// NormalizeTags trims and lower-cases tags, dropping empty and repeated ones
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
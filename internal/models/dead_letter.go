@@ -0,0 +1,62 @@
+// internal/models/dead_letter.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type DeadLetterSource string
+type DeadLetterStatus string
+
+const (
+	DeadLetterSourceNotification DeadLetterSource = "notification" // Email delivery out of retries
+	DeadLetterSourceWebhook      DeadLetterSource = "webhook"      // Webhook delivery out of attempts
+	DeadLetterSourceJob          DeadLetterSource = "job"          // Failed scheduled job run
+
+	DeadLetterStatusOpen      DeadLetterStatus = "open"
+	DeadLetterStatusReplayed  DeadLetterStatus = "replayed"
+	DeadLetterStatusDiscarded DeadLetterStatus = "discarded"
+)
+
+// DeadLetter keeps async work that gave up so admins can inspect it, fix the payload and
+// replay it. Repeated failures of the same work update its open entry instead of adding one.
+type DeadLetter struct {
+	ID           uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Source       DeadLetterSource `json:"source" gorm:"type:varchar(20);not null;index"`
+	ReferenceID  *uuid.UUID       `json:"reference_id,omitempty" gorm:"type:uuid;index"` // Notification or webhook delivery, nil for jobs
+	Name         string           `json:"name" gorm:"size:100;not null"`                 // Notification type, webhook event or job name
+	Payload      datatypes.JSON   `json:"payload" gorm:"type:jsonb"`
+	LastError    string           `json:"last_error" gorm:"type:text"`
+	Failures     int              `json:"failures" gorm:"not null;default:1"`
+	Status       DeadLetterStatus `json:"status" gorm:"type:varchar(20);default:'open';index"`
+	EditedAt     *time.Time       `json:"edited_at,omitempty"`
+	ResolvedAt   *time.Time       `json:"resolved_at,omitempty"`
+	ResolvedByID *uuid.UUID       `json:"resolved_by_id,omitempty" gorm:"type:uuid"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+
+	// Relationships
+	ResolvedBy *User `json:"resolved_by,omitempty" gorm:"foreignKey:ResolvedByID"`
+}
+
+// TableName returns the table name for DeadLetter model
+func (DeadLetter) TableName() string {
+	return "dead_letters"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (d *DeadLetter) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsOpen checks if the dead letter still waits for a replay or discard
+func (d *DeadLetter) IsOpen() bool {
+	return d.Status == DeadLetterStatusOpen
+}
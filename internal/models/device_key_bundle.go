@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// DeviceKeyBundle holds the public keys one of a user's devices publishes so others can
+// encrypt messages to it. Private keys never leave the device; the server only relays.
+type DeviceKeyBundle struct {
+	ID                    uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID                uuid.UUID      `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_device_key_bundle"`
+	DeviceID              string         `json:"device_id" gorm:"size:100;not null;uniqueIndex:idx_device_key_bundle"`
+	IdentityKey           string         `json:"identity_key" gorm:"type:text;not null"`
+	SignedPreKey          string         `json:"signed_pre_key" gorm:"type:text;not null"`
+	SignedPreKeySignature string         `json:"signed_pre_key_signature" gorm:"type:text;not null"`
+	OneTimePreKeys        pq.StringArray `json:"-" gorm:"type:text[]"` // Handed out once each, then removed
+	CreatedAt             time.Time      `json:"created_at"`
+	UpdatedAt             time.Time      `json:"updated_at"`
+
+	// Relationships
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for DeviceKeyBundle model
+func (DeviceKeyBundle) TableName() string {
+	return "device_key_bundles"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (b *DeviceKeyBundle) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// internal/models/emergency_broadcast.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmergencyAction is what an emergency broadcast does to the meetings checked in in the building
+type EmergencyAction string
+
+const (
+	EmergencyActionCheckOut EmergencyAction = "check_out" // Meetings are checked out and completed
+	EmergencyActionSuspend  EmergencyAction = "suspend"   // Check-ins are paused until the all-clear
+)
+
+// EmergencyBroadcast records an evacuation or other emergency announced to everyone with a
+// booking in a building that day, and what it did to the meetings under way
+type EmergencyBroadcast struct {
+	ID               uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Building         string          `json:"building" gorm:"size:100;not null;index"`
+	Title            string          `json:"title" gorm:"size:200;not null"`
+	Message          string          `json:"message" gorm:"type:text;not null"`
+	Action           EmergencyAction `json:"action" gorm:"type:varchar(20);not null"`
+	CheckInsAffected int             `json:"check_ins_affected" gorm:"not null;default:0"`
+	CreatedByID      uuid.UUID       `json:"created_by_id" gorm:"type:uuid;not null"`
+	EndedAt          *time.Time      `json:"ended_at,omitempty"` // All-clear, resuming suspended check-ins
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+
+	// Relationships
+	CreatedBy *User `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID"`
+}
+
+// EmergencyRecipient is a user told about an emergency broadcast, and when they acknowledged it
+type EmergencyRecipient struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	BroadcastID    uuid.UUID  `json:"broadcast_id" gorm:"type:uuid;not null;uniqueIndex:idx_emergency_recipient_user"`
+	UserID         uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_emergency_recipient_user"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	// Relationships
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for EmergencyBroadcast model
+func (EmergencyBroadcast) TableName() string {
+	return "emergency_broadcasts"
+}
+
+// TableName returns the table name for EmergencyRecipient model
+func (EmergencyRecipient) TableName() string {
+	return "emergency_recipients"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (b *EmergencyBroadcast) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to set ID if not provided
+func (r *EmergencyRecipient) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsValid checks if the emergency action is valid
+func (a EmergencyAction) IsValid() bool {
+	return a == EmergencyActionCheckOut || a == EmergencyActionSuspend
+}
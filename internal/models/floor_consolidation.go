@@ -0,0 +1,86 @@
+// internal/models/floor_consolidation.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// FloorConsolidation keeps only some floors of a building open for a range of days,
+// so new bookings are steered onto them and facilities can close the rest
+type FloorConsolidation struct {
+	ID          uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Building    string        `json:"building" gorm:"size:100;not null;index"`
+	OpenFloors  pq.Int64Array `json:"open_floors" gorm:"type:integer[];not null"`
+	StartDate   time.Time     `json:"start_date" gorm:"type:date;not null"`
+	EndDate     time.Time     `json:"end_date" gorm:"type:date;not null"` // Inclusive
+	Reason      string        `json:"reason" gorm:"type:text"`
+	CreatedByID uuid.UUID     `json:"created_by_id" gorm:"type:uuid;not null"`
+	EndedAt     *time.Time    `json:"ended_at,omitempty"` // Set when ended before the end date
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+
+	// Relationships
+	CreatedBy *User `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID"`
+}
+
+// LowUsageAlert records that facilities were told about a low-usage day in a building,
+// so each building and day is only reported once
+type LowUsageAlert struct {
+	ID        uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Building  string        `json:"building" gorm:"size:100;not null"`
+	Date      time.Time     `json:"date" gorm:"type:date;not null"`
+	LowFloors pq.Int64Array `json:"low_floors" gorm:"type:integer[]"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// TableName returns the table name for FloorConsolidation model
+func (FloorConsolidation) TableName() string {
+	return "floor_consolidations"
+}
+
+// TableName returns the table name for LowUsageAlert model
+func (LowUsageAlert) TableName() string {
+	return "low_usage_alerts"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (c *FloorConsolidation) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to set ID if not provided
+func (a *LowUsageAlert) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// Covers checks if the consolidation applies on the calendar day (UTC) of t
+func (c *FloorConsolidation) Covers(t time.Time) bool {
+	t = t.UTC()
+	if c.EndedAt != nil && !t.Before(*c.EndedAt) {
+		return false
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	start := time.Date(c.StartDate.Year(), c.StartDate.Month(), c.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(c.EndDate.Year(), c.EndDate.Month(), c.EndDate.Day(), 0, 0, 0, 0, time.UTC)
+	return !day.Before(start) && !day.After(end)
+}
+
+// KeepsOpen checks if the floor stays open during the consolidation
+func (c *FloorConsolidation) KeepsOpen(floor int) bool {
+	for _, open := range c.OpenFloors {
+		if int(open) == floor {
+			return true
+		}
+	}
+	return false
+}
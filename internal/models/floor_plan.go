@@ -0,0 +1,107 @@
+// internal/models/floor_plan.go
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// FloorPlan is the drawing of one floor of a building, either inline SVG or an image URL.
+// Seat coordinates use the drawing's own units, from the top-left corner.
+type FloorPlan struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Building    string    `json:"building" gorm:"size:50;not null;uniqueIndex:idx_floor_plan_building_floor"`
+	Floor       int       `json:"floor" gorm:"not null;uniqueIndex:idx_floor_plan_building_floor"`
+	SVG         string    `json:"svg,omitempty" gorm:"type:text"`
+	ImageURL    string    `json:"image_url,omitempty" gorm:"size:500"`
+	Width       int       `json:"width" gorm:"not null"`
+	Height      int       `json:"height" gorm:"not null"`
+	UpdatedByID uuid.UUID `json:"updated_by_id" gorm:"type:uuid;not null"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Relationships
+	Seats         []FloorPlanSeat `json:"seats,omitempty" gorm:"foreignKey:FloorPlanID"`
+	Neighborhoods []Neighborhood  `json:"neighborhoods,omitempty" gorm:"foreignKey:FloorPlanID"`
+}
+
+// Neighborhood is a zone of a floor plan whose desks only some teams may book
+type Neighborhood struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	FloorPlanID uuid.UUID      `json:"floor_plan_id" gorm:"type:uuid;not null;index"`
+	Name        string         `json:"name" gorm:"size:100;not null"`
+	Color       string         `json:"color,omitempty" gorm:"size:7"`  // Hex color the zone is drawn with
+	Departments pq.StringArray `json:"departments" gorm:"type:text[]"` // Teams allowed to book its desks; empty lets everyone
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// FloorPlanSeat places a desk on a floor plan
+type FloorPlanSeat struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	FloorPlanID    uuid.UUID  `json:"floor_plan_id" gorm:"type:uuid;not null;index"`
+	SpaceID        uuid.UUID  `json:"space_id" gorm:"type:uuid;not null;uniqueIndex"`
+	X              float64    `json:"x" gorm:"not null"`
+	Y              float64    `json:"y" gorm:"not null"`
+	NeighborhoodID *uuid.UUID `json:"neighborhood_id,omitempty" gorm:"type:uuid;index"`
+
+	// Relationships
+	Space        *Space        `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
+	Neighborhood *Neighborhood `json:"neighborhood,omitempty" gorm:"foreignKey:NeighborhoodID"`
+}
+
+// TableName returns the table name for FloorPlan model
+func (FloorPlan) TableName() string {
+	return "floor_plans"
+}
+
+// TableName returns the table name for Neighborhood model
+func (Neighborhood) TableName() string {
+	return "neighborhoods"
+}
+
+// TableName returns the table name for FloorPlanSeat model
+func (FloorPlanSeat) TableName() string {
+	return "floor_plan_seats"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (p *FloorPlan) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to set ID if not provided
+func (n *Neighborhood) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to set ID if not provided
+func (s *FloorPlanSeat) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// AllowsDepartment checks if members of the department may book the neighborhood's desks, ignoring case
+func (n *Neighborhood) AllowsDepartment(department string) bool {
+	if len(n.Departments) == 0 {
+		return true
+	}
+	for _, allowed := range n.Departments {
+		if strings.EqualFold(allowed, department) {
+			return true
+		}
+	}
+	return false
+}
@@ -40,16 +40,29 @@ type Message struct {
 	IsEdited       bool        `json:"is_edited" gorm:"not null;default:false"`
 	EditedAt       *time.Time  `json:"edited_at"`
 	Metadata       *string     `json:"metadata" gorm:"type:jsonb"` // JSON string for booking_id, space_id, payment_id, etc.
+	ReplyToID      *uuid.UUID  `json:"reply_to_id" gorm:"type:uuid;index"`
+	ReplyCount     int         `json:"reply_count" gorm:"not null;default:0"` // Direct replies to this message
+	Encrypted      bool        `json:"encrypted" gorm:"not null;default:false"`
+	Ciphertext     *string     `json:"ciphertext,omitempty" gorm:"type:text"`      // Opaque client payload of encrypted messages; Content stays empty
+	SenderDeviceID *string     `json:"sender_device_id,omitempty" gorm:"size:100"` // Device whose keys encrypted the payload
 	CreatedAt      time.Time   `json:"created_at"`
 	UpdatedAt      time.Time   `json:"updated_at"`
 
 	// Relationships
 	Conversation *Conversation        `json:"conversation,omitempty" gorm:"foreignKey:ConversationID"`
 	Sender       *User                `json:"sender,omitempty" gorm:"foreignKey:SenderID"`
+	ReplyTo      *Message             `json:"reply_to,omitempty" gorm:"foreignKey:ReplyToID;constraint:OnDelete:SET NULL"`
 	Attachments  []MessageAttachment  `json:"attachments,omitempty" gorm:"foreignKey:MessageID"`
 	ReadReceipts []MessageReadReceipt `json:"read_receipts,omitempty" gorm:"foreignKey:MessageID"`
 }
 
+// MessageSearchHit is a message matched by full-text search
+type MessageSearchHit struct {
+	Message Message
+	Rank    float64 // ts_rank_cd relevance, higher is better
+	Snippet string  // Content excerpt with matches wrapped in <mark> tags
+}
+
 // TableName returns the table name for Message model
 func (Message) TableName() string {
 	return "messages"
@@ -78,6 +91,11 @@ func (m *Message) IsSystemMessage() bool {
 		m.MessageType == MessageTypePaymentReminder
 }
 
+// IsReply checks if message replies to another message
+func (m *Message) IsReply() bool {
+	return m.ReplyToID != nil
+}
+
 // HasAttachments checks if message has attachments
 func (m *Message) HasAttachments() bool {
 	return len(m.Attachments) > 0
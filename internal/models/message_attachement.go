@@ -7,15 +7,32 @@ import (
 	"gorm.io/gorm"
 )
 
+// AttachmentScanStatus represents the antivirus scan state of an uploaded file
+type AttachmentScanStatus string
+
+const (
+	ScanStatusPending  AttachmentScanStatus = "pending"
+	ScanStatusClean    AttachmentScanStatus = "clean"
+	ScanStatusInfected AttachmentScanStatus = "infected"
+	ScanStatusSkipped  AttachmentScanStatus = "skipped"
+)
+
 type MessageAttachment struct {
-	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	MessageID    uuid.UUID `json:"message_id" gorm:"type:uuid;not null"`
-	FileName     string    `json:"file_name" gorm:"size:255;not null"`
-	FileSize     int64     `json:"file_size" gorm:"not null"`
-	FileType     string    `json:"file_type" gorm:"size:100;not null"`
-	FileURL      string    `json:"file_url" gorm:"type:text;not null"`
-	ThumbnailURL *string   `json:"thumbnail_url" gorm:"type:text"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	MessageID    uuid.UUID  `json:"message_id" gorm:"type:uuid;not null"`
+	FileName     string     `json:"file_name" gorm:"size:255;not null"`
+	FileSize     int64      `json:"file_size" gorm:"not null"`
+	FileType     string     `json:"file_type" gorm:"size:100;not null"`
+	FileURL      string     `json:"file_url" gorm:"type:text;not null"`
+	ThumbnailURL *string    `json:"thumbnail_url" gorm:"type:text"`
+	UploadID     *uuid.UUID `json:"upload_id,omitempty" gorm:"type:uuid;index"` // Stored upload, nil for external links
+
+	// Antivirus scanning
+	ScanStatus    AttachmentScanStatus `json:"scan_status" gorm:"size:20;not null;default:'pending'"`
+	ScanSignature *string              `json:"scan_signature,omitempty" gorm:"size:255"`
+	ScannedAt     *time.Time           `json:"scanned_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
 
 	// Relationships
 	Message *Message `json:"message,omitempty" gorm:"foreignKey:MessageID"`
@@ -65,3 +82,8 @@ func (ma *MessageAttachment) IsAudio() bool {
 func (ma *MessageAttachment) HasThumbnail() bool {
 	return ma.ThumbnailURL != nil && *ma.ThumbnailURL != ""
 }
+
+// IsQuarantined checks if attachment is still waiting for a clean scan result
+func (ma *MessageAttachment) IsQuarantined() bool {
+	return ma.ScanStatus == ScanStatusPending
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Message delivery states, from least to most advanced
+const (
+	MessageDeliverySent      = "sent"
+	MessageDeliveryDelivered = "delivered"
+	MessageDeliveryRead      = "read"
+)
+
+// MessageDeliveryReceipt records that a message reached one of the recipient's clients
+type MessageDeliveryReceipt struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	MessageID   uuid.UUID `json:"message_id" gorm:"type:uuid;not null;uniqueIndex:idx_message_delivery_receipt"`
+	UserID      uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_message_delivery_receipt"`
+	DeliveredAt time.Time `json:"delivered_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+
+	// Relationships
+	Message *Message `json:"message,omitempty" gorm:"foreignKey:MessageID"`
+	User    *User    `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for MessageDeliveryReceipt model
+func (MessageDeliveryReceipt) TableName() string {
+	return "message_delivery_receipts"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (mdr *MessageDeliveryReceipt) BeforeCreate(tx *gorm.DB) error {
+	if mdr.ID == uuid.Nil {
+		mdr.ID = uuid.New()
+	}
+	return nil
+}
+
+// MessageDeliveryCounts aggregates how many recipients received and read a message
+type MessageDeliveryCounts struct {
+	MessageID      uuid.UUID `json:"message_id"`
+	RecipientCount int       `json:"recipient_count"`
+	DeliveredCount int       `json:"delivered_count"`
+	ReadCount      int       `json:"read_count"`
+}
+
+// Status reduces the counts to a single tick state for the sender
+func (c MessageDeliveryCounts) Status() string {
+	switch {
+	case c.RecipientCount > 0 && c.ReadCount >= c.RecipientCount:
+		return MessageDeliveryRead
+	case c.RecipientCount > 0 && c.DeliveredCount >= c.RecipientCount:
+		return MessageDeliveryDelivered
+	default:
+		return MessageDeliverySent
+	}
+}
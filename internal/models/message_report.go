@@ -0,0 +1,109 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReportReason string
+type ReportStatus string
+type ModerationAction string
+
+const (
+	ReportReasonSpam          ReportReason = "spam"
+	ReportReasonHarassment    ReportReason = "harassment"
+	ReportReasonInappropriate ReportReason = "inappropriate"
+	ReportReasonOther         ReportReason = "other"
+)
+
+const (
+	ReportStatusPending   ReportStatus = "pending"
+	ReportStatusActioned  ReportStatus = "actioned"
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+const (
+	ModerationActionDismiss       ModerationAction = "dismiss"
+	ModerationActionDeleteMessage ModerationAction = "delete_message"
+	ModerationActionWarn          ModerationAction = "warn"
+	ModerationActionBan           ModerationAction = "ban"
+)
+
+// MessageReport is a user flag on a chat message awaiting moderation.
+// The message content is copied so the report survives message deletion.
+type MessageReport struct {
+	ID             uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	MessageID      uuid.UUID        `json:"message_id" gorm:"type:uuid;not null;index"`
+	ConversationID uuid.UUID        `json:"conversation_id" gorm:"type:uuid;not null"`
+	ReporterID     uuid.UUID        `json:"reporter_id" gorm:"type:uuid;not null"`
+	ReportedUserID uuid.UUID        `json:"reported_user_id" gorm:"type:uuid;not null;index"`
+	MessageContent string           `json:"message_content" gorm:"type:text;not null"`
+	Reason         ReportReason     `json:"reason" gorm:"type:varchar(30);not null"`
+	Details        string           `json:"details" gorm:"type:text"`
+	Status         ReportStatus     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	Action         ModerationAction `json:"action,omitempty" gorm:"type:varchar(30)"`
+	ModeratorNote  string           `json:"moderator_note,omitempty" gorm:"type:text"`
+	ResolvedByID   *uuid.UUID       `json:"resolved_by_id,omitempty" gorm:"type:uuid"`
+	ResolvedAt     *time.Time       `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+
+	// Relationships
+	Reporter     *User `json:"reporter,omitempty" gorm:"foreignKey:ReporterID"`
+	ReportedUser *User `json:"reported_user,omitempty" gorm:"foreignKey:ReportedUserID"`
+	ResolvedBy   *User `json:"resolved_by,omitempty" gorm:"foreignKey:ResolvedByID"`
+}
+
+// TableName returns the table name for MessageReport model
+func (MessageReport) TableName() string {
+	return "message_reports"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (mr *MessageReport) BeforeCreate(tx *gorm.DB) error {
+	if mr.ID == uuid.Nil {
+		mr.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsPending checks if report still needs a moderator decision
+func (mr *MessageReport) IsPending() bool {
+	return mr.Status == ReportStatusPending
+}
+
+// ChatBan temporarily prevents a user from sending chat messages
+type ChatBan struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	ReportID   *uuid.UUID `json:"report_id,omitempty" gorm:"type:uuid"`
+	Reason     string     `json:"reason" gorm:"type:text"`
+	BannedByID uuid.UUID  `json:"banned_by_id" gorm:"type:uuid;not null"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null;index"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	// Relationships
+	User     *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	BannedBy *User `json:"banned_by,omitempty" gorm:"foreignKey:BannedByID"`
+}
+
+// TableName returns the table name for ChatBan model
+func (ChatBan) TableName() string {
+	return "chat_bans"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (cb *ChatBan) BeforeCreate(tx *gorm.DB) error {
+	if cb.ID == uuid.Nil {
+		cb.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsActive checks if ban is still in force at the given time
+func (cb *ChatBan) IsActive(now time.Time) bool {
+	return cb.RevokedAt == nil && now.Before(cb.ExpiresAt)
+}
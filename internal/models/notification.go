@@ -0,0 +1,92 @@
+// internal/models/notification.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type NotificationType string
+type NotificationStatus string
+
+const (
+	NotificationTypeRoomSwapSuggestion     NotificationType = "room_swap_suggestion"
+	NotificationTypeConversationResolved   NotificationType = "conversation_resolved"
+	NotificationTypeChatWarning            NotificationType = "chat_warning"
+	NotificationTypeChatBan                NotificationType = "chat_ban"
+	NotificationTypeChatAssigned           NotificationType = "chat_assigned"
+	NotificationTypeChatEscalated          NotificationType = "chat_escalated"
+	NotificationTypeReservationCancelled   NotificationType = "reservation_cancelled"
+	NotificationTypeReservationReleased    NotificationType = "reservation_released"
+	NotificationTypeReservationPreempted   NotificationType = "reservation_preempted"
+	NotificationTypeReservationInClosure   NotificationType = "reservation_in_closure"
+	NotificationTypeEmergency              NotificationType = "emergency"
+	NotificationTypeEmergencyAllClear      NotificationType = "emergency_all_clear"
+	NotificationTypeLowUsageForecast       NotificationType = "low_usage_forecast"
+	NotificationTypeChecklistReminder      NotificationType = "checklist_reminder"
+	NotificationTypeCoOrganizerAdded       NotificationType = "co_organizer_added"
+	NotificationTypeCoOrganizerRemoved     NotificationType = "co_organizer_removed"
+	NotificationTypeRoomDowngraded         NotificationType = "room_downgraded"
+	NotificationTypeSavedSearchMatch       NotificationType = "saved_search_match"
+	NotificationTypeServiceRequestRejected NotificationType = "service_request_rejected"
+	NotificationTypeEquipmentOverdue       NotificationType = "equipment_overdue"
+	NotificationTypeTeamDeskAssigned       NotificationType = "team_desk_assigned"
+	NotificationTypeApprovalRequested      NotificationType = "approval_requested"
+	NotificationTypeBroadcastPost          NotificationType = "broadcast_post"
+
+	NotificationStatusPending NotificationStatus = "pending"
+	NotificationStatusSent    NotificationStatus = "sent"
+	NotificationStatusFailed  NotificationStatus = "failed"
+)
+
+type Notification struct {
+	ID          uuid.UUID          `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID      uuid.UUID          `json:"user_id" gorm:"type:uuid;not null;index"`
+	Type        NotificationType   `json:"type" gorm:"type:varchar(50);not null"`
+	Title       string             `json:"title" gorm:"not null;size:200"`
+	Message     string             `json:"message" gorm:"type:text;not null"`
+	Data        datatypes.JSON     `json:"data" gorm:"type:jsonb"`              // Links and entity references
+	DeepLink    string             `json:"deep_link,omitempty" gorm:"size:100"` // Screen the notification opens, e.g. app://reservation/{id}
+	Status      NotificationStatus `json:"status" gorm:"type:varchar(20);default:'pending'"`
+	ScheduledAt time.Time          `json:"scheduled_at" gorm:"not null"`
+	SentAt      *time.Time         `json:"sent_at"`
+	ReadAt      *time.Time         `json:"read_at"`
+	RetryCount  int                `json:"retry_count" gorm:"default:0"`
+	MaxRetries  int                `json:"max_retries" gorm:"default:3"`
+	LastError   string             `json:"-" gorm:"type:text"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt     `json:"-" gorm:"index"`
+
+	// Relationships
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for Notification model
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	if n.ScheduledAt.IsZero() {
+		n.ScheduledAt = time.Now()
+	}
+	return nil
+}
+
+// IsRead checks if the notification has been read
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// CanRetry checks if delivery can be retried
+func (n *Notification) CanRetry() bool {
+	return n.Status == NotificationStatusPending && n.RetryCount < n.MaxRetries
+}
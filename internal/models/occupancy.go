@@ -0,0 +1,68 @@
+// internal/models/occupancy.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OccupancySensor is an IoT headcount sensor installed in a space. Like door displays it
+// authenticates with an API key bound to its own space; only a hash of the key is stored.
+type OccupancySensor struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SpaceID     uuid.UUID  `json:"space_id" gorm:"type:uuid;not null;index"`
+	Name        string     `json:"name" gorm:"size:100;not null"`
+	APIKeyHash  string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	KeyPrefix   string     `json:"key_prefix" gorm:"size:16;not null"` // Shown to tell keys apart
+	CreatedByID uuid.UUID  `json:"created_by_id" gorm:"type:uuid;not null"`
+	LastSeenAt  *time.Time `json:"last_seen_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Relationships
+	Space *Space `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
+}
+
+// TableName returns the table name for OccupancySensor model
+func (OccupancySensor) TableName() string {
+	return "occupancy_sensors"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (o *OccupancySensor) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsRevoked checks if the sensor key has been revoked
+func (o *OccupancySensor) IsRevoked() bool {
+	return o.RevokedAt != nil
+}
+
+// OccupancySample is one headcount reported by a sensor
+type OccupancySample struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SpaceID    uuid.UUID `json:"space_id" gorm:"type:uuid;not null"`
+	SensorID   uuid.UUID `json:"sensor_id" gorm:"type:uuid;not null;index"`
+	Headcount  int       `json:"headcount" gorm:"not null"`
+	RecordedAt time.Time `json:"recorded_at" gorm:"not null"` // When the sensor counted, not when it was received
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for OccupancySample model
+func (OccupancySample) TableName() string {
+	return "occupancy_samples"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (o *OccupancySample) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// internal/models/outbox_event.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type OutboxEventStatus string
+
+const (
+	OutboxEventPending    OutboxEventStatus = "pending"
+	OutboxEventDispatched OutboxEventStatus = "dispatched"
+	OutboxEventFailed     OutboxEventStatus = "failed"
+
+	// Event types, named like the realtime events they become
+	OutboxReservationCreated   = "reservation_created"
+	OutboxReservationUpdated   = "reservation_updated"
+	OutboxReservationCancelled = "reservation_cancelled"
+	OutboxReservationReleased  = "reservation_released"  // The rest of a booking was given back at an early check-out
+	OutboxReservationPreempted = "reservation_preempted" // A pending booking was displaced by a higher-priority one
+	OutboxNotificationCreated  = "notification_created"
+)
+
+// OutboxReservationEvents lists the event types of reservation changes
+var OutboxReservationEvents = []string{OutboxReservationCreated, OutboxReservationUpdated, OutboxReservationCancelled}
+
+// OutboxEvent is a change waiting to be pushed to realtime clients, webhooks and email. It is
+// written in the same transaction as the change, so an event exists exactly when the change
+// was committed, and stays pending until every consumer got it, across restarts.
+type OutboxEvent struct {
+	ID            uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	EventType     string            `json:"event_type" gorm:"size:50;not null;index"`
+	AggregateID   uuid.UUID         `json:"aggregate_id" gorm:"type:uuid;not null;index"` // Reservation or notification the event is about
+	Payload       datatypes.JSON    `json:"payload" gorm:"type:jsonb;not null"`
+	Status        OutboxEventStatus `json:"status" gorm:"type:varchar(20);default:'pending';index:idx_outbox_due,priority:1"`
+	Attempts      int               `json:"attempts" gorm:"default:0"`
+	NextAttemptAt time.Time         `json:"next_attempt_at" gorm:"not null;index:idx_outbox_due,priority:2"`
+	LastError     string            `json:"last_error,omitempty" gorm:"type:text"`
+	DispatchedAt  *time.Time        `json:"dispatched_at"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// TableName returns the table name for OutboxEvent model
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (e *OutboxEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.NextAttemptAt.IsZero() {
+		e.NextAttemptAt = time.Now()
+	}
+	return nil
+}
+
+// ReservationChange is the payload of reservation events: the reservation as committed and
+// who organizes it, so the event can be delivered without reading the reservation again
+type ReservationChange struct {
+	ReservationID uuid.UUID   `json:"reservation_id"`
+	SpaceID       uuid.UUID   `json:"space_id"`
+	UserID        uuid.UUID   `json:"user_id"`
+	Title         string      `json:"title"`
+	Status        string      `json:"status"`
+	StartTime     time.Time   `json:"start_time"`
+	EndTime       time.Time   `json:"end_time"`
+	CheckInTime   *time.Time  `json:"check_in_time,omitempty"`
+	OrganizerIDs  []uuid.UUID `json:"organizer_ids"`
+}
+
+// NewReservationChange captures a reservation for a reservation event
+func NewReservationChange(reservation *Reservation) ReservationChange {
+	return ReservationChange{
+		ReservationID: reservation.ID,
+		SpaceID:       reservation.SpaceID,
+		UserID:        reservation.UserID,
+		Title:         reservation.Title,
+		Status:        string(reservation.Status),
+		StartTime:     reservation.StartTime,
+		EndTime:       reservation.EndTime,
+		CheckInTime:   reservation.CheckInTime,
+		OrganizerIDs:  reservation.OrganizerIDs(),
+	}
+}
+
+// ReservationRelease is the payload of reservation_released events: the time an organizer
+// gave back by checking out early, now free for others
+type ReservationRelease struct {
+	ReservationID uuid.UUID `json:"reservation_id"`
+	SpaceID       uuid.UUID `json:"space_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	StartTime     time.Time `json:"start_time"` // Check-out
+	EndTime       time.Time `json:"end_time"`   // Former end of the booking
+}
@@ -0,0 +1,52 @@
+// internal/models/placement_policy.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PlacementStrategy decides which of several equivalent free spaces gets a booking
+// made for "any suitable room"
+type PlacementStrategy string
+
+const (
+	PlacementSmallestFit PlacementStrategy = "smallest_fit" // Smallest space, on the user's preferred floor first
+	PlacementFillFloors  PlacementStrategy = "fill_floors"  // Lowest floor first, so upper floors stay empty
+	PlacementBalanceWear PlacementStrategy = "balance_wear" // Least used space over the last weeks first
+	PlacementLowEnergy   PlacementStrategy = "low_energy"   // Best energy rating first
+)
+
+// IsValid checks if the strategy is known
+func (s PlacementStrategy) IsValid() bool {
+	switch s {
+	case PlacementSmallestFit, PlacementFillFloors, PlacementBalanceWear, PlacementLowEnergy:
+		return true
+	}
+	return false
+}
+
+// PlacementPolicy sets the placement strategy of a building
+type PlacementPolicy struct {
+	ID          uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Building    string            `json:"building" gorm:"size:50;not null;uniqueIndex"`
+	Strategy    PlacementStrategy `json:"strategy" gorm:"type:varchar(20);not null"`
+	UpdatedByID uuid.UUID         `json:"updated_by_id" gorm:"type:uuid;not null"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// TableName returns the table name for PlacementPolicy model
+func (PlacementPolicy) TableName() string {
+	return "placement_policies"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (p *PlacementPolicy) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+// internal/models/rebooking_suggestion.go
+package models
+
+import "github.com/google/uuid"
+
+// RebookingSuggestion is a space offered to an organizer whose booking was displaced, free for
+// the same slot
+type RebookingSuggestion struct {
+	SpaceID  uuid.UUID `json:"space_id"`
+	Name     string    `json:"name"`
+	Building string    `json:"building"`
+	Floor    int       `json:"floor"`
+	Capacity int       `json:"capacity"`
+}
@@ -0,0 +1,47 @@
+// internal/models/report_run.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type ReportRunStatus string
+
+const (
+	ReportRunQueued    ReportRunStatus = "queued"
+	ReportRunRunning   ReportRunStatus = "running"
+	ReportRunCompleted ReportRunStatus = "completed"
+	ReportRunFailed    ReportRunStatus = "failed"
+)
+
+// ReportRun is a custom report too heavy to answer right away, run by a background job.
+// The result is kept so the admin can fetch it once the run completed.
+type ReportRun struct {
+	ID            uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Definition    datatypes.JSON  `json:"definition" gorm:"type:jsonb;not null"` // dto.ReportDefinition
+	Status        ReportRunStatus `json:"status" gorm:"type:varchar(20);default:'queued';index"`
+	Result        datatypes.JSON  `json:"-" gorm:"type:jsonb"` // dto.ReportResult
+	Error         string          `json:"error,omitempty" gorm:"type:text"`
+	RequestedByID uuid.UUID       `json:"requested_by_id" gorm:"type:uuid;not null;index"`
+	StartedAt     *time.Time      `json:"started_at"`
+	CompletedAt   *time.Time      `json:"completed_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// TableName returns the table name for ReportRun model
+func (ReportRun) TableName() string {
+	return "report_runs"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (r *ReportRun) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
@@ -48,19 +49,58 @@ type Reservation struct {
 	RecurrencePattern  datatypes.JSON    `json:"recurrence_pattern" gorm:"type:jsonb"`
 	ApproverID         *uuid.UUID        `json:"approver_id" gorm:"type:uuid"`
 	ApprovalComments   string            `json:"approval_comments" gorm:"type:text"`
+	ApprovalSteps      int               `json:"approval_steps,omitempty" gorm:"default:0"`    // Approvals needed while pending, 2 under two-step rules
+	FirstApproverID    *uuid.UUID        `json:"first_approver_id,omitempty" gorm:"type:uuid"` // Set by the first of two approvals
+	FirstApprovedAt    *time.Time        `json:"first_approved_at,omitempty"`
+	AutoApproved       bool              `json:"auto_approved,omitempty" gorm:"default:false"` // Confirmed by the space's approval rule
 	CancellationReason string            `json:"cancellation_reason" gorm:"type:text"`
 	CheckInTime        *time.Time        `json:"check_in_time"`
 	CheckOutTime       *time.Time        `json:"check_out_time"`
 	NoShowReported     bool              `json:"no_show_reported" gorm:"default:false"`
+	IsPrivate          bool              `json:"is_private" gorm:"default:false"`        // Details hidden from shared calendars
+	LicensePlate       string            `json:"license_plate,omitempty" gorm:"size:20"` // Car booked onto a parking spot
+	Cost               float64           `json:"cost" gorm:"not null;default:0"`         // Chargeback at the space's hourly cost rate, kept when the rate changes
+	Tags               pq.StringArray    `json:"tags,omitempty" gorm:"type:text[];index:,type:gin"`
+	CostCenter         string            `json:"cost_center,omitempty" gorm:"size:50;index"` // Code of the cost center charged
 	CreatedAt          time.Time         `json:"created_at"`
 	UpdatedAt          time.Time         `json:"updated_at"`
 	DeletedAt          gorm.DeletedAt    `json:"-" gorm:"index"`
 
+	// Set when the user knowingly booked over another of their own reservations
+	DuplicateJustification string `json:"duplicate_justification,omitempty" gorm:"type:text"`
+	// Set on requests sent through an embeddable booking widget by someone without an account;
+	// the reservation belongs to whoever created the widget token
+	WidgetTokenID  *uuid.UUID `json:"widget_token_id,omitempty" gorm:"type:uuid;index"`
+	RequesterName  string     `json:"requester_name,omitempty" gorm:"size:200"`
+	RequesterEmail string     `json:"requester_email,omitempty" gorm:"size:255"`
+	// Set when the retention policy stripped the personal details of a past reservation
+	AnonymizedAt *time.Time `json:"anonymized_at,omitempty" gorm:"index"`
+	// Set while the reservation falls in a building or floor closure, until moved or the closure ends
+	ClosureID *uuid.UUID `json:"closure_id,omitempty" gorm:"type:uuid;index"`
+	// Set while the check-in is paused by an emergency broadcast, until its all-clear
+	SuspendedByEmergencyID *uuid.UUID `json:"suspended_by_emergency_id,omitempty" gorm:"type:uuid;index"`
+	// Set on the desk bookings a team lead made for a member through a team block
+	TeamBlockID *uuid.UUID `json:"team_block_id,omitempty" gorm:"type:uuid;index"`
+	// Non-blocking issues found while saving (not persisted)
+	Warnings []string `json:"warnings,omitempty" gorm:"-"`
+	// Full-text relevance, only filled by search queries that select them
+	SearchRank    float64 `json:"-" gorm:"->;-:migration"`
+	SearchSnippet string  `json:"-" gorm:"->;-:migration"`
+
 	// Relationships
 	User     User  `json:"user" gorm:"foreignKey:UserID"`
 	Space    Space `json:"space" gorm:"foreignKey:SpaceID"`
 	Approver *User `json:"approver,omitempty" gorm:"foreignKey:ApproverID"`
 
+	// Equipment booked together with the space
+	Resources []ReservationResource `json:"resources,omitempty" gorm:"foreignKey:ReservationID"`
+
+	// Users the owner designated to help run the reservation
+	CoOrganizers []ReservationCoOrganizer `json:"co_organizers,omitempty" gorm:"foreignKey:ReservationID"`
+
+	// Organizer's checklist, only shown to the owner and co-organizers
+	Tasks []ReservationTask `json:"tasks,omitempty" gorm:"foreignKey:ReservationID"`
+
 	// Child reservations for recurring bookings
 	ChildReservations []Reservation `json:"child_reservations,omitempty" gorm:"foreignKey:RecurrenceParentID"`
 }
@@ -112,6 +152,35 @@ func (r *Reservation) CanBeModified() bool {
 		time.Now().Before(r.StartTime.Add(-30*time.Minute)) // 30 min before start
 }
 
+// AwaitsSecondApproval checks if a two-step booking passed its first approval
+func (r *Reservation) AwaitsSecondApproval() bool {
+	return r.Status == StatusPending && r.ApprovalSteps >= 2 && r.FirstApproverID != nil
+}
+
+// IsOrganizer checks if the user owns or co-organizes the reservation
+func (r *Reservation) IsOrganizer(userID uuid.UUID) bool {
+	return r.UserID == userID || r.IsCoOrganizer(userID)
+}
+
+// IsCoOrganizer checks if the owner designated the user as a co-organizer
+func (r *Reservation) IsCoOrganizer(userID uuid.UUID) bool {
+	for _, coOrganizer := range r.CoOrganizers {
+		if coOrganizer.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// OrganizerIDs returns the owner followed by the co-organizers
+func (r *Reservation) OrganizerIDs() []uuid.UUID {
+	ids := []uuid.UUID{r.UserID}
+	for _, coOrganizer := range r.CoOrganizers {
+		ids = append(ids, coOrganizer.UserID)
+	}
+	return ids
+}
+
 // GetRecurrencePattern returns the recurrence pattern
 func (r *Reservation) GetRecurrencePattern() *RecurrencePattern {
 	if !r.IsRecurring || r.RecurrencePattern == nil {
@@ -0,0 +1,35 @@
+// internal/models/reservation_co_organizer.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReservationCoOrganizer is a user the owner designated to help run a reservation;
+// co-organizers can edit, cancel and check in as the owner can
+type ReservationCoOrganizer struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ReservationID uuid.UUID `json:"reservation_id" gorm:"type:uuid;not null;uniqueIndex:idx_co_organizer_reservation_user"`
+	UserID        uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_co_organizer_reservation_user;index"`
+	AddedByID     uuid.UUID `json:"added_by_id" gorm:"type:uuid;not null"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// Relationships
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for ReservationCoOrganizer model
+func (ReservationCoOrganizer) TableName() string {
+	return "reservation_co_organizers"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (c *ReservationCoOrganizer) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// internal/models/reservation_feedback.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReservationFeedback is the rating and comment left by the organizer when checking out
+type ReservationFeedback struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ReservationID uuid.UUID `json:"reservation_id" gorm:"type:uuid;not null;uniqueIndex"`
+	SpaceID       uuid.UUID `json:"space_id" gorm:"type:uuid;not null;index"`
+	UserID        uuid.UUID `json:"user_id" gorm:"type:uuid;not null"`
+	Rating        *int      `json:"rating,omitempty"` // 1 to 5
+	Comment       string    `json:"comment,omitempty" gorm:"type:text"`
+	CreatedAt     time.Time `json:"created_at" gorm:"index"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// FeedbackEntry is one feedback of a facilities report, with where it was left
+type FeedbackEntry struct {
+	SpaceID   uuid.UUID `json:"space_id"`
+	SpaceName string    `json:"space_name"`
+	Building  string    `json:"building"`
+	Rating    *int      `json:"rating,omitempty"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for ReservationFeedback model
+func (ReservationFeedback) TableName() string {
+	return "reservation_feedback"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (f *ReservationFeedback) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
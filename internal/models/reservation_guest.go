@@ -0,0 +1,62 @@
+// internal/models/reservation_guest.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GuestRSVPStatus string
+
+const (
+	GuestRSVPPending  GuestRSVPStatus = "pending"
+	GuestRSVPAccepted GuestRSVPStatus = "accepted"
+	GuestRSVPDeclined GuestRSVPStatus = "declined"
+)
+
+// ReservationGuest is an external visitor invited to a reservation. The pass token
+// identifies the guest at reception and is encoded in the guest pass QR code.
+type ReservationGuest struct {
+	ID            uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ReservationID uuid.UUID       `json:"reservation_id" gorm:"type:uuid;not null;index"`
+	InvitedByID   uuid.UUID       `json:"invited_by_id" gorm:"type:uuid;not null"`
+	Name          string          `json:"name" gorm:"size:200;not null"`
+	Email         string          `json:"email" gorm:"size:255;not null"`
+	Company       string          `json:"company,omitempty" gorm:"size:200"`
+	PassToken     string          `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	InviteSentAt  *time.Time      `json:"invite_sent_at"`
+	ArrivedAt     *time.Time      `json:"arrived_at"` // Set when reception checks the guest in
+	RSVPStatus    GuestRSVPStatus `json:"rsvp_status" gorm:"type:varchar(20);default:'pending'"`
+	RespondedAt   *time.Time      `json:"responded_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+
+	// Relationships
+	Reservation *Reservation `json:"reservation,omitempty" gorm:"foreignKey:ReservationID"`
+	InvitedBy   *User        `json:"invited_by,omitempty" gorm:"foreignKey:InvitedByID"`
+}
+
+// TableName returns the table name for ReservationGuest model
+func (ReservationGuest) TableName() string {
+	return "reservation_guests"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (g *ReservationGuest) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
+
+// HasDeclined checks if the guest has said they will not come
+func (g *ReservationGuest) HasDeclined() bool {
+	return g.RSVPStatus == GuestRSVPDeclined
+}
+
+// HasArrived checks if reception has checked the guest in
+func (g *ReservationGuest) HasArrived() bool {
+	return g.ArrivedAt != nil
+}
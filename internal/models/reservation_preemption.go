@@ -0,0 +1,48 @@
+// internal/models/reservation_preemption.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ReservationPreemption records a pending reservation cancelled to make room for the booking
+// of a user with a higher priority, with the spaces suggested to its organizer instead
+type ReservationPreemption struct {
+	ID                      uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SpaceID                 uuid.UUID      `json:"space_id" gorm:"type:uuid;not null;index"`
+	ReservationID           uuid.UUID      `json:"reservation_id" gorm:"type:uuid;not null;uniqueIndex"` // The displaced reservation
+	PreemptingReservationID uuid.UUID      `json:"preempting_reservation_id" gorm:"type:uuid;not null;index"`
+	DisplacedUserID         uuid.UUID      `json:"displaced_user_id" gorm:"type:uuid;not null;index"`
+	PreemptedByID           uuid.UUID      `json:"preempted_by_id" gorm:"type:uuid;not null;index"`
+	DisplacedPriority       int            `json:"displaced_priority" gorm:"not null"`
+	PreemptorPriority       int            `json:"preemptor_priority" gorm:"not null"`
+	Justification           string         `json:"justification" gorm:"type:text;not null"`
+	StartTime               time.Time      `json:"start_time" gorm:"not null"` // Slot of the displaced reservation
+	EndTime                 time.Time      `json:"end_time" gorm:"not null"`
+	Suggestions             datatypes.JSON `json:"suggestions" gorm:"type:jsonb"` // []RebookingSuggestion offered to the displaced organizer
+	CreatedAt               time.Time      `json:"created_at" gorm:"index"`
+
+	// Relationships
+	Space                 *Space       `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
+	Reservation           *Reservation `json:"reservation,omitempty" gorm:"foreignKey:ReservationID"`
+	PreemptingReservation *Reservation `json:"preempting_reservation,omitempty" gorm:"foreignKey:PreemptingReservationID"`
+	DisplacedUser         *User        `json:"displaced_user,omitempty" gorm:"foreignKey:DisplacedUserID"`
+	PreemptedBy           *User        `json:"preempted_by,omitempty" gorm:"foreignKey:PreemptedByID"`
+}
+
+// TableName returns the table name for ReservationPreemption model
+func (ReservationPreemption) TableName() string {
+	return "reservation_preemptions"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (p *ReservationPreemption) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
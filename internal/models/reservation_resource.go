@@ -0,0 +1,53 @@
+// internal/models/reservation_resource.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReservationResource is equipment booked together with a reservation's space,
+// e.g. a projector for a meeting room. The resource is busy for the whole reservation.
+// Portable equipment is checked out: facility staff confirm when it is picked up and
+// returned, and it is overdue when still out after the reservation ends.
+type ReservationResource struct {
+	ID               uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ReservationID    uuid.UUID  `json:"reservation_id" gorm:"type:uuid;not null;uniqueIndex:idx_reservation_resource"`
+	ResourceID       uuid.UUID  `json:"resource_id" gorm:"type:uuid;not null;uniqueIndex:idx_reservation_resource;index"`
+	PickedUpAt       *time.Time `json:"picked_up_at,omitempty"`
+	PickedUpByID     *uuid.UUID `json:"picked_up_by_id,omitempty" gorm:"type:uuid"` // Staff member who handed it out
+	ReturnedAt       *time.Time `json:"returned_at,omitempty"`
+	ReturnedByID     *uuid.UUID `json:"returned_by_id,omitempty" gorm:"type:uuid"` // Staff member who took it back
+	OverdueAlertedAt *time.Time `json:"overdue_alerted_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+
+	// Relationships
+	Resource    *Space       `json:"resource,omitempty" gorm:"foreignKey:ResourceID"`
+	Reservation *Reservation `json:"reservation,omitempty" gorm:"foreignKey:ReservationID"`
+}
+
+// TableName returns the table name for ReservationResource model
+func (ReservationResource) TableName() string {
+	return "reservation_resources"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (r *ReservationResource) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsCheckedOut checks if the equipment was picked up and not returned yet
+func (r *ReservationResource) IsCheckedOut() bool {
+	return r.PickedUpAt != nil && r.ReturnedAt == nil
+}
+
+// IsOverdueAt checks if the equipment is still out after its reservation ended.
+// The reservation must be loaded.
+func (r *ReservationResource) IsOverdueAt(now time.Time) bool {
+	return r.IsCheckedOut() && r.Reservation != nil && now.After(r.Reservation.EndTime)
+}
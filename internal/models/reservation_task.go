@@ -0,0 +1,87 @@
+// internal/models/reservation_task.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ReservationTask is an item of an organizer's checklist for a reservation,
+// e.g. book catering or bring an adapter
+type ReservationTask struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ReservationID   uuid.UUID  `json:"reservation_id" gorm:"type:uuid;not null;index"`
+	Title           string     `json:"title" gorm:"size:200;not null"`
+	Position        int        `json:"position" gorm:"not null;default:0"`
+	DueAt           *time.Time `json:"due_at,omitempty" gorm:"index"`
+	ReminderMinutes *int       `json:"reminder_minutes,omitempty"` // Remind this long before the due time, nil sends no reminder
+	RemindedAt      *time.Time `json:"reminded_at,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+	CompletedByID   *uuid.UUID `json:"completed_by_id,omitempty" gorm:"type:uuid"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Relationships
+	Reservation *Reservation `json:"-" gorm:"foreignKey:ReservationID"`
+}
+
+// ChecklistTemplate is the checklist added to reservations of a space type when the organizer asks for it
+type ChecklistTemplate struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SpaceType   SpaceType      `json:"space_type" gorm:"type:varchar(50);not null;uniqueIndex"`
+	Items       datatypes.JSON `json:"items" gorm:"type:jsonb;not null"`
+	UpdatedByID uuid.UUID      `json:"updated_by_id" gorm:"type:uuid;not null"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// ChecklistTemplateItem is a task of a checklist template, due relative to the reservation start
+type ChecklistTemplateItem struct {
+	Title            string `json:"title"`
+	DueBeforeMinutes *int   `json:"due_before_minutes,omitempty"` // Due this long before the start, nil has no due time
+	ReminderMinutes  *int   `json:"reminder_minutes,omitempty"`
+}
+
+// TableName returns the table name for ReservationTask model
+func (ReservationTask) TableName() string {
+	return "reservation_tasks"
+}
+
+// TableName returns the table name for ChecklistTemplate model
+func (ChecklistTemplate) TableName() string {
+	return "checklist_templates"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (t *ReservationTask) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to set ID if not provided
+func (t *ChecklistTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsCompleted checks if the task was ticked off
+func (t *ReservationTask) IsCompleted() bool {
+	return t.CompletedAt != nil
+}
+
+// GetItems returns the tasks of the template
+func (t *ChecklistTemplate) GetItems() []ChecklistTemplateItem {
+	var items []ChecklistTemplateItem
+	if t.Items != nil {
+		json.Unmarshal(t.Items, &items)
+	}
+	return items
+}
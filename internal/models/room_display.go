@@ -0,0 +1,45 @@
+// internal/models/room_display.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoomDisplay is a door display or kiosk mounted outside a space. It authenticates with
+// an API key that only grants access to its own space; only a hash of the key is stored.
+type RoomDisplay struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SpaceID     uuid.UUID  `json:"space_id" gorm:"type:uuid;not null;index"`
+	Name        string     `json:"name" gorm:"size:100;not null"`
+	APIKeyHash  string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	KeyPrefix   string     `json:"key_prefix" gorm:"size:16;not null"` // Shown to tell keys apart
+	CreatedByID uuid.UUID  `json:"created_by_id" gorm:"type:uuid;not null"`
+	LastSeenAt  *time.Time `json:"last_seen_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Relationships
+	Space *Space `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
+}
+
+// TableName returns the table name for RoomDisplay model
+func (RoomDisplay) TableName() string {
+	return "room_displays"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (d *RoomDisplay) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsRevoked checks if the display key has been revoked
+func (d *RoomDisplay) IsRevoked() bool {
+	return d.RevokedAt != nil
+}
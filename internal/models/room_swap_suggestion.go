@@ -0,0 +1,61 @@
+// internal/models/room_swap_suggestion.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RoomSwapReason string
+type RoomSwapStatus string
+
+const (
+	RoomSwapReasonOversized  RoomSwapReason = "oversized"  // More participants than the room holds
+	RoomSwapReasonUndersized RoomSwapReason = "undersized" // Room far larger than needed
+
+	RoomSwapStatusPending  RoomSwapStatus = "pending"
+	RoomSwapStatusAccepted RoomSwapStatus = "accepted"
+	RoomSwapStatusDeclined RoomSwapStatus = "declined"
+	RoomSwapStatusExpired  RoomSwapStatus = "expired"
+)
+
+// RoomSwapSuggestion proposes a better-fitting room for an upcoming reservation
+type RoomSwapSuggestion struct {
+	ID               uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ReservationID    uuid.UUID      `json:"reservation_id" gorm:"type:uuid;not null;index"`
+	UserID           uuid.UUID      `json:"user_id" gorm:"type:uuid;not null;index"`
+	CurrentSpaceID   uuid.UUID      `json:"current_space_id" gorm:"type:uuid;not null"`
+	SuggestedSpaceID uuid.UUID      `json:"suggested_space_id" gorm:"type:uuid;not null"`
+	Reason           RoomSwapReason `json:"reason" gorm:"type:varchar(20);not null"`
+	Status           RoomSwapStatus `json:"status" gorm:"type:varchar(20);default:'pending';index"`
+	Token            string         `json:"-" gorm:"size:64;not null;uniqueIndex"` // One-click accept/decline token
+	ExpiresAt        time.Time      `json:"expires_at" gorm:"not null"`
+	RespondedAt      *time.Time     `json:"responded_at"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+
+	// Relationships
+	Reservation    *Reservation `json:"reservation,omitempty" gorm:"foreignKey:ReservationID"`
+	CurrentSpace   *Space       `json:"current_space,omitempty" gorm:"foreignKey:CurrentSpaceID"`
+	SuggestedSpace *Space       `json:"suggested_space,omitempty" gorm:"foreignKey:SuggestedSpaceID"`
+}
+
+// TableName returns the table name for RoomSwapSuggestion model
+func (RoomSwapSuggestion) TableName() string {
+	return "room_swap_suggestions"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (s *RoomSwapSuggestion) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsActionable checks if the suggestion can still be accepted or declined
+func (s *RoomSwapSuggestion) IsActionable() bool {
+	return s.Status == RoomSwapStatusPending && time.Now().Before(s.ExpiresAt)
+}
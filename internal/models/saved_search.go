@@ -0,0 +1,86 @@
+// internal/models/saved_search.go
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// SavedSearch is space search criteria a user keeps, optionally alerting them when a
+// cancellation or an early check-out frees a matching space during the time window
+type SavedSearch struct {
+	ID                 uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID             uuid.UUID      `json:"user_id" gorm:"type:uuid;not null;index"`
+	Name               string         `json:"name" gorm:"size:100;not null"`
+	MinCapacity        int            `json:"min_capacity" gorm:"default:0"`
+	Types              pq.StringArray `json:"types" gorm:"type:text[]"`     // Any type when empty
+	Building           string         `json:"building" gorm:"size:50"`      // Any building when empty
+	Amenities          pq.StringArray `json:"amenities" gorm:"type:text[]"` // Equipment names the space must all have
+	WindowStart        *time.Time     `json:"window_start"`                 // Any future time when the window is not set
+	WindowEnd          *time.Time     `json:"window_end"`
+	MinDurationMinutes int            `json:"min_duration_minutes" gorm:"default:30"` // Shortest freed slot worth an alert
+	AlertsEnabled      bool           `json:"alerts_enabled" gorm:"not null;index"`
+	LastAlertedAt      *time.Time     `json:"last_alerted_at"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+}
+
+// TableName returns the table name for SavedSearch model
+func (SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (s *SavedSearch) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsExpired checks whether the time window of the search is over
+func (s *SavedSearch) IsExpired(now time.Time) bool {
+	return s.WindowEnd != nil && !s.WindowEnd.After(now)
+}
+
+// Matches checks whether a space meets the criteria of the search; amenities are compared
+// with the names of the space's equipment, ignoring case
+func (s *SavedSearch) Matches(space *Space) bool {
+	if space.Capacity < s.MinCapacity {
+		return false
+	}
+	if s.Building != "" && !strings.EqualFold(s.Building, space.Building) {
+		return false
+	}
+	if len(s.Types) > 0 {
+		found := false
+		for _, spaceType := range s.Types {
+			if spaceType == string(space.Type) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	equipment := space.GetEquipmentList()
+	for _, amenity := range s.Amenities {
+		found := false
+		for _, item := range equipment {
+			if strings.EqualFold(strings.TrimSpace(item.Name), strings.TrimSpace(amenity)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
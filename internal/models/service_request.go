@@ -0,0 +1,123 @@
+// internal/models/service_request.go
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ServiceCategory string
+type ServiceRequestStatus string
+
+const (
+	ServiceCategoryCatering  ServiceCategory = "catering"
+	ServiceCategoryAVSupport ServiceCategory = "av_support"
+	ServiceCategoryFurniture ServiceCategory = "furniture" // Extra chairs, tables
+	ServiceCategoryOther     ServiceCategory = "other"
+
+	ServiceRequestRequested ServiceRequestStatus = "requested"
+	ServiceRequestAccepted  ServiceRequestStatus = "accepted"  // Facility staff took it on
+	ServiceRequestFulfilled ServiceRequestStatus = "fulfilled" // Delivered; charged back with the reservation
+	ServiceRequestRejected  ServiceRequestStatus = "rejected"
+	ServiceRequestCancelled ServiceRequestStatus = "cancelled" // Withdrawn by the organizer or with the reservation
+)
+
+// serviceRequestTransitions are the statuses facility staff may move a service request to
+var serviceRequestTransitions = map[ServiceRequestStatus][]ServiceRequestStatus{
+	ServiceRequestRequested: {ServiceRequestAccepted, ServiceRequestRejected},
+	ServiceRequestAccepted:  {ServiceRequestFulfilled, ServiceRequestRejected},
+}
+
+// ServiceItem is a service of the facilities catalog that can be ordered with a reservation,
+// e.g. a lunch tray, AV support or extra chairs
+type ServiceItem struct {
+	ID              uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name            string          `json:"name" gorm:"size:100;not null"`
+	Category        ServiceCategory `json:"category" gorm:"type:varchar(20);not null;index"`
+	Description     string          `json:"description,omitempty" gorm:"type:text"`
+	UnitPrice       float64         `json:"unit_price" gorm:"not null;default:0"`        // Charged back per unit ordered
+	LeadTimeMinutes int             `json:"lead_time_minutes" gorm:"not null;default:0"` // Must be ordered at least this long before the reservation starts
+	MaxQuantity     int             `json:"max_quantity" gorm:"not null;default:1"`
+	IsActive        bool            `json:"is_active" gorm:"not null;default:true"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// ServiceRequest is a service ordered with a reservation and tracked by facility staff until it is
+// delivered. The unit price is recorded when the request is placed.
+type ServiceRequest struct {
+	ID              uuid.UUID            `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ReservationID   uuid.UUID            `json:"reservation_id" gorm:"type:uuid;not null;index"`
+	ServiceItemID   uuid.UUID            `json:"service_item_id" gorm:"type:uuid;not null;index"`
+	RequestedByID   uuid.UUID            `json:"requested_by_id" gorm:"type:uuid;not null"`
+	Quantity        int                  `json:"quantity" gorm:"not null;default:1"`
+	Notes           string               `json:"notes,omitempty" gorm:"type:text"` // Dietary needs, setup instructions
+	UnitPrice       float64              `json:"unit_price" gorm:"not null;default:0"`
+	Cost            float64              `json:"cost" gorm:"not null;default:0"`
+	Status          ServiceRequestStatus `json:"status" gorm:"type:varchar(20);not null;default:'requested';index"`
+	DueAt           time.Time            `json:"due_at" gorm:"not null;index"` // Start of the reservation
+	HandledByID     *uuid.UUID           `json:"handled_by_id,omitempty" gorm:"type:uuid"`
+	StaffNotes      string               `json:"staff_notes,omitempty" gorm:"type:text"`
+	StatusChangedAt *time.Time           `json:"status_changed_at,omitempty"`
+	FulfilledAt     *time.Time           `json:"fulfilled_at,omitempty"`
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+
+	// Relationships
+	ServiceItem *ServiceItem `json:"service_item,omitempty" gorm:"foreignKey:ServiceItemID"`
+	Reservation *Reservation `json:"reservation,omitempty" gorm:"foreignKey:ReservationID"`
+}
+
+// TableName returns the table name for ServiceItem model
+func (ServiceItem) TableName() string {
+	return "service_items"
+}
+
+// TableName returns the table name for ServiceRequest model
+func (ServiceRequest) TableName() string {
+	return "service_requests"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (i *ServiceItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to set ID if not provided
+func (r *ServiceRequest) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// LeadTime returns how long before the reservation starts the service must be ordered
+func (i *ServiceItem) LeadTime() time.Duration {
+	return time.Duration(i.LeadTimeMinutes) * time.Minute
+}
+
+// CostFor returns the cost of ordering the quantity, rounded to cents
+func (i *ServiceItem) CostFor(quantity int) float64 {
+	return math.Round(float64(quantity)*i.UnitPrice*100) / 100
+}
+
+// IsOpen checks if the request still awaits delivery
+func (r *ServiceRequest) IsOpen() bool {
+	return r.Status == ServiceRequestRequested || r.Status == ServiceRequestAccepted
+}
+
+// CanMoveTo checks if facility staff may move the request to the status
+func (r *ServiceRequest) CanMoveTo(status ServiceRequestStatus) bool {
+	for _, next := range serviceRequestTransitions[r.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
@@ -2,15 +2,21 @@
 package models
 
 import (
+	"encoding/json"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
 type SpaceType string
 type SpaceStatus string
+type PresenceCheck string
+type ResourceClass string
 
 const (
 	SpaceTypeMeetingRoom SpaceType = "meeting_room"
@@ -19,46 +25,105 @@ const (
 	SpaceTypeOpenSpace   SpaceType = "open_space"
 	SpaceTypeHotDesk     SpaceType = "hot_desk"
 	SpaceTypeConference  SpaceType = "conference_room"
+	SpaceTypeParkingSpot SpaceType = "parking_spot"
+	SpaceTypeEquipment   SpaceType = "equipment"
+
+	ResourceClassRoom      ResourceClass = "room"
+	ResourceClassDesk      ResourceClass = "desk"
+	ResourceClassParking   ResourceClass = "parking"
+	ResourceClassEquipment ResourceClass = "equipment"
 
 	SpaceStatusAvailable    SpaceStatus = "available"
 	SpaceStatusMaintenance  SpaceStatus = "maintenance"
 	SpaceStatusOutOfService SpaceStatus = "out_of_service"
 	SpaceStatusReserved     SpaceStatus = "reserved"
+
+	PresenceCheckNone             PresenceCheck = "none"
+	PresenceCheckBeacon           PresenceCheck = "beacon"
+	PresenceCheckGeofence         PresenceCheck = "geofence"
+	PresenceCheckBeaconOrGeofence PresenceCheck = "beacon_or_geofence"
 )
 
+// Mean Earth radius used for geofence distances
+const earthRadiusMeters = 6371000
+
 type Equipment struct {
 	Name        string `json:"name"`
 	Quantity    int    `json:"quantity"`
 	Description string `json:"description,omitempty"`
 }
 
+// ResourceAttributes holds the settings that only make sense for one resource class
+type ResourceAttributes struct {
+	// Parking
+	SpotNumber           string `json:"spot_number,omitempty"`
+	RequiresLicensePlate bool   `json:"requires_license_plate,omitempty"` // Bookings must give the car's plate, e.g. for the gate camera
+	EVCharging           bool   `json:"ev_charging,omitempty"`
+
+	// Desk
+	MonitorCount   int  `json:"monitor_count,omitempty"`
+	StandingDesk   bool `json:"standing_desk,omitempty"`
+	DockingStation bool `json:"docking_station,omitempty"`
+
+	// Equipment
+	AssetTag string `json:"asset_tag,omitempty"`
+	Category string `json:"category,omitempty"` // e.g. projector, camera, laptop
+}
+
 type Space struct {
-	ID                 uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Name               string         `json:"name" gorm:"not null;size:100;uniqueIndex:idx_space_building_name" validate:"required,min=2,max=100"`
-	Type               SpaceType      `json:"type" gorm:"type:varchar(50);not null" validate:"required"`
-	Capacity           int            `json:"capacity" gorm:"not null;check:capacity > 0" validate:"required,min=1,max=1000"`
-	Building           string         `json:"building" gorm:"not null;size:50;uniqueIndex:idx_space_building_name" validate:"required"`
-	Floor              int            `json:"floor" gorm:"not null" validate:"required"`
-	RoomNumber         string         `json:"room_number" gorm:"not null;size:20" validate:"required"`
-	Equipment          datatypes.JSON `json:"equipment" gorm:"type:jsonb"`
-	Status             SpaceStatus    `json:"status" gorm:"type:varchar(20);default:'available'"`
-	Description        string         `json:"description" gorm:"type:text"`
-	Surface            float64        `json:"surface" validate:"omitempty,min=0"`
-	Photos             datatypes.JSON `json:"photos" gorm:"type:jsonb"`
-	PricePerHour       float64        `json:"price_per_hour" gorm:"default:0" validate:"omitempty,min=0"`
-	PricePerDay        float64        `json:"price_per_day" gorm:"default:0" validate:"omitempty,min=0"`
-	PricePerMonth      float64        `json:"price_per_month" gorm:"default:0" validate:"omitempty,min=0"`
-	ManagerID          *uuid.UUID     `json:"manager_id" gorm:"type:uuid"`
-	RequiresApproval   bool           `json:"requires_approval" gorm:"default:false"`
-	BookingAdvanceTime int            `json:"booking_advance_time" gorm:"default:30"`  // minutes
-	MaxBookingDuration int            `json:"max_booking_duration" gorm:"default:480"` // minutes (8 hours)
-	CreatedAt          time.Time      `json:"created_at"`
-	UpdatedAt          time.Time      `json:"updated_at"`
-	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                  uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name                string         `json:"name" gorm:"not null;size:100;uniqueIndex:idx_space_building_name" validate:"required,min=2,max=100"`
+	Type                SpaceType      `json:"type" gorm:"type:varchar(50);not null" validate:"required"`
+	Capacity            int            `json:"capacity" gorm:"not null;check:capacity > 0" validate:"required,min=1,max=1000"`
+	Building            string         `json:"building" gorm:"not null;size:50;uniqueIndex:idx_space_building_name" validate:"required"`
+	Floor               int            `json:"floor" gorm:"not null" validate:"required"`
+	RoomNumber          string         `json:"room_number" gorm:"not null;size:20" validate:"required"`
+	Equipment           datatypes.JSON `json:"equipment" gorm:"type:jsonb"`
+	Attributes          datatypes.JSON `json:"attributes" gorm:"type:jsonb"` // ResourceAttributes of the space's resource class
+	Status              SpaceStatus    `json:"status" gorm:"type:varchar(20);default:'available'"`
+	Description         string         `json:"description" gorm:"type:text"`
+	Surface             float64        `json:"surface" validate:"omitempty,min=0"`
+	Photos              datatypes.JSON `json:"photos" gorm:"type:jsonb"`
+	PrimaryPhotoURL     string         `json:"primary_photo_url,omitempty" gorm:"size:500"` // Thumbnail of the gallery's primary photo, shown in search results
+	PricePerHour        float64        `json:"price_per_hour" gorm:"default:0" validate:"omitempty,min=0"`
+	PricePerDay         float64        `json:"price_per_day" gorm:"default:0" validate:"omitempty,min=0"`
+	PricePerMonth       float64        `json:"price_per_month" gorm:"default:0" validate:"omitempty,min=0"`
+	CostPerHour         float64        `json:"cost_per_hour" gorm:"not null;default:0" validate:"omitempty,min=0"` // Internal rate charged back to the booker's department
+	ManagerID           *uuid.UUID     `json:"manager_id" gorm:"type:uuid"`
+	RequiresApproval    bool           `json:"requires_approval" gorm:"default:false"`
+	BookingAdvanceTime  int            `json:"booking_advance_time" gorm:"default:30"`          // minutes
+	MaxBookingDuration  int            `json:"max_booking_duration" gorm:"default:480"`         // minutes (8 hours)
+	BufferBefore        int            `json:"buffer_before" gorm:"not null;default:0"`         // minutes kept free before each booking, e.g. for cleaning
+	BufferAfter         int            `json:"buffer_after" gorm:"not null;default:0"`          // minutes kept free after each booking
+	CancellationWindow  int            `json:"cancellation_window" gorm:"not null;default:0"`   // minutes before the start under which cancelling earns a strike, 0 for none
+	EarlyReleaseMinutes int            `json:"early_release_minutes" gorm:"not null;default:0"` // booked minutes left at check-out from which the rest is released, 0 to keep it
+	PreemptionTier      int            `json:"preemption_tier" gorm:"not null;default:0"`       // lowest user priority that may preempt pending bookings, 0 disables preemption
+	IsVIP               bool           `json:"is_vip" gorm:"default:false"`                     // VIP spaces enforce guaranteed-availability blocks
+	IsPremium           bool           `json:"is_premium" gorm:"default:false"`                 // Bookings count against the team's premium-room quota
+	PilotUntil          *time.Time     `json:"pilot_until,omitempty"`                           // Soft launch: until then only the pilot group can see and book the space
+	PilotUserIDs        pq.StringArray `json:"pilot_user_ids,omitempty" gorm:"type:text[]"`
+	PilotDepartments    pq.StringArray `json:"pilot_departments,omitempty" gorm:"type:text[]"`
+	CombinationOf       pq.StringArray `json:"combination_of,omitempty" gorm:"type:text[]"` // Spaces this one joins into a single unit, e.g. rooms opened up by a partition wall
+	EnergyRating        string         `json:"energy_rating,omitempty" gorm:"size:1"`       // A (most efficient) to G, used by the low_energy placement strategy
+	CheckInPresence     PresenceCheck  `json:"check_in_presence" gorm:"type:varchar(20);not null;default:'none'"`
+	BeaconIDs           pq.StringArray `json:"-" gorm:"type:text[]"` // BLE beacons in the room, never returned so they cannot be replayed remotely
+	Latitude            *float64       `json:"latitude"`             // Building location for geofenced check-in
+	Longitude           *float64       `json:"longitude"`
+	GeofenceRadius      int            `json:"geofence_radius" gorm:"default:150"` // meters
+	CurrentOccupancy    *int           `json:"current_occupancy"`                  // Latest headcount from occupancy sensors
+	OccupancyUpdatedAt  *time.Time     `json:"occupancy_updated_at"`
+	EmptySince          *time.Time     `json:"empty_since,omitempty"` // Sensors have counted nobody since
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Derived from Type when loaded (not persisted)
+	ResourceClass ResourceClass `json:"resource_class" gorm:"-"`
 
 	// Relationships
 	Manager      *User         `json:"manager,omitempty" gorm:"foreignKey:ManagerID"`
 	Reservations []Reservation `json:"reservations,omitempty" gorm:"foreignKey:SpaceID"`
+	VIPBlocks    []VIPBlock    `json:"vip_blocks,omitempty" gorm:"foreignKey:SpaceID"`
 }
 
 // TableName returns the table name for Space model
@@ -74,11 +139,146 @@ func (s *Space) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// CostFor returns the chargeback cost of booking the space over the time range, rounded to cents
+func (s *Space) CostFor(start, end time.Time) float64 {
+	if s.CostPerHour <= 0 || !end.After(start) {
+		return 0
+	}
+	return math.Round(end.Sub(start).Hours()*s.CostPerHour*100) / 100
+}
+
+// AfterFind hook to derive the resource class
+func (s *Space) AfterFind(tx *gorm.DB) error {
+	s.ResourceClass = ResourceClassOf(s.Type)
+	return nil
+}
+
+// ResourceClassOf returns the class of resource a space type belongs to
+func ResourceClassOf(spaceType SpaceType) ResourceClass {
+	switch spaceType {
+	case SpaceTypeHotDesk:
+		return ResourceClassDesk
+	case SpaceTypeParkingSpot:
+		return ResourceClassParking
+	case SpaceTypeEquipment:
+		return ResourceClassEquipment
+	default:
+		return ResourceClassRoom
+	}
+}
+
+// SpaceTypesOf returns the space types of a resource class
+func SpaceTypesOf(class ResourceClass) []SpaceType {
+	switch class {
+	case ResourceClassDesk:
+		return []SpaceType{SpaceTypeHotDesk}
+	case ResourceClassParking:
+		return []SpaceType{SpaceTypeParkingSpot}
+	case ResourceClassEquipment:
+		return []SpaceType{SpaceTypeEquipment}
+	case ResourceClassRoom:
+		return []SpaceType{SpaceTypeMeetingRoom, SpaceTypeOffice, SpaceTypeAuditorium, SpaceTypeOpenSpace, SpaceTypeConference}
+	default:
+		return nil
+	}
+}
+
+// GetAttributes returns the resource attributes, empty when none are set
+func (s *Space) GetAttributes() ResourceAttributes {
+	var attributes ResourceAttributes
+	if s.Attributes != nil {
+		json.Unmarshal(s.Attributes, &attributes)
+	}
+	return attributes
+}
+
 // IsAvailable checks if space is available for booking
 func (s *Space) IsAvailable() bool {
 	return s.Status == SpaceStatusAvailable
 }
 
+// HostsPeople checks if people meet or work in the space, as opposed to a parking spot or equipment
+func (s *Space) HostsPeople() bool {
+	class := ResourceClassOf(s.Type)
+	return class == ResourceClassRoom || class == ResourceClassDesk
+}
+
+// IsCombined checks if the space joins other spaces into a single unit. Booking it takes its
+// parts, and booking a part takes it.
+func (s *Space) IsCombined() bool {
+	return len(s.CombinationOf) > 0
+}
+
+// BufferGap returns the free time the space needs between two bookings
+func (s *Space) BufferGap() time.Duration {
+	return time.Duration(s.BufferBefore+s.BufferAfter) * time.Minute
+}
+
+// RequiresPresence checks if check-in needs proof the user is at the space
+func (s *Space) RequiresPresence() bool {
+	return s.CheckInPresence != "" && s.CheckInPresence != PresenceCheckNone
+}
+
+// AcceptsBeacon checks if check-in can be proven with a beacon
+func (s *Space) AcceptsBeacon() bool {
+	return s.CheckInPresence == PresenceCheckBeacon || s.CheckInPresence == PresenceCheckBeaconOrGeofence
+}
+
+// AcceptsGeofence checks if check-in can be proven with GPS coordinates
+func (s *Space) AcceptsGeofence() bool {
+	return s.CheckInPresence == PresenceCheckGeofence || s.CheckInPresence == PresenceCheckBeaconOrGeofence
+}
+
+// HasBeacon checks if the beacon is installed in the space, ignoring case
+func (s *Space) HasBeacon(beaconID string) bool {
+	for _, id := range s.BeaconIDs {
+		if strings.EqualFold(id, beaconID) {
+			return true
+		}
+	}
+	return false
+}
+
+// InPilot checks if the space is in its soft launch, open only to its pilot group
+func (s *Space) InPilot(now time.Time) bool {
+	return s.PilotUntil != nil && now.Before(*s.PilotUntil)
+}
+
+// PilotAllows checks if the user can see and book the space. Outside its soft launch
+// everyone can; during it only the pilot users and departments, managers and admins.
+func (s *Space) PilotAllows(user *User, now time.Time) bool {
+	if !s.InPilot(now) || user.HasStaffAccess() {
+		return true
+	}
+	for _, id := range s.PilotUserIDs {
+		if id == user.ID.String() {
+			return true
+		}
+	}
+	for _, department := range s.PilotDepartments {
+		if user.Department != "" && strings.EqualFold(department, user.Department) {
+			return true
+		}
+	}
+	return false
+}
+
+// DistanceFrom returns the great-circle distance in meters between the space's
+// location and the given coordinates; ok is false when the space has no location
+func (s *Space) DistanceFrom(latitude, longitude float64) (meters float64, ok bool) {
+	if s.Latitude == nil || s.Longitude == nil {
+		return 0, false
+	}
+
+	lat1 := *s.Latitude * math.Pi / 180
+	lat2 := latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (longitude - *s.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return earthRadiusMeters * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a)), true
+}
+
 // GetFullLocation returns the full location string
 func (s *Space) GetFullLocation() string {
 	return s.Building + " - Floor " + string(rune(s.Floor)) + " - Room " + s.RoomNumber
@@ -88,8 +288,7 @@ func (s *Space) GetFullLocation() string {
 func (s *Space) GetEquipmentList() []Equipment {
 	var equipment []Equipment
 	if s.Equipment != nil {
-		// Parse JSON to equipment slice
-		// Implementation depends on your JSON structure
+		_ = json.Unmarshal(s.Equipment, &equipment) // Equipment is written by the API, which encodes this slice
 	}
 	return equipment
 }
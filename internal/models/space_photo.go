@@ -0,0 +1,45 @@
+// internal/models/space_photo.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Bounds of the photo gallery of a space
+const (
+	MaxSpacePhotos    = 20
+	MaxSpacePhotoSize = 10 << 20 // 10MB per uploaded file
+)
+
+// SpacePhoto is one image of the gallery of a space, stored resized to standard dimensions
+// with a thumbnail. The primary photo illustrates the space in search results.
+type SpacePhoto struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SpaceID      uuid.UUID `json:"space_id" gorm:"type:uuid;not null;index"`
+	Position     int       `json:"position" gorm:"not null"` // Gallery order, from 0
+	IsPrimary    bool      `json:"is_primary" gorm:"not null"`
+	URL          string    `json:"url" gorm:"size:500;not null"`
+	ThumbnailURL string    `json:"thumbnail_url" gorm:"size:500;not null"`
+	Width        int       `json:"width" gorm:"not null"` // Of the resized image
+	Height       int       `json:"height" gorm:"not null"`
+	OriginalName string    `json:"original_name" gorm:"size:255"`
+	UploadedByID uuid.UUID `json:"uploaded_by_id" gorm:"type:uuid;not null"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for SpacePhoto model
+func (SpacePhoto) TableName() string {
+	return "space_photos"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (p *SpacePhoto) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
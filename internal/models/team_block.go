@@ -0,0 +1,115 @@
+// internal/models/team_block.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// TeamBlock reserves desks of a neighborhood for a team (department) on chosen weekdays, for
+// hybrid schedules. Members are assigned a desk each day unless they mark themselves remote.
+type TeamBlock struct {
+	ID             uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Department     string         `json:"department" gorm:"size:100;not null;index"`
+	NeighborhoodID uuid.UUID      `json:"neighborhood_id" gorm:"type:uuid;not null;index"`
+	DeskIDs        pq.StringArray `json:"desk_ids" gorm:"type:text[];not null"`    // In assignment order
+	Weekdays       pq.Int64Array  `json:"weekdays" gorm:"type:integer[];not null"` // 0=Sunday, 1=Monday, etc.
+	StartDate      time.Time      `json:"start_date" gorm:"type:date;not null"`
+	EndDate        time.Time      `json:"end_date" gorm:"type:date;not null"` // Included
+	DayStart       string         `json:"day_start" gorm:"size:5;not null"`   // HH:MM
+	DayEnd         string         `json:"day_end" gorm:"size:5;not null"`     // HH:MM
+	Timezone       string         `json:"timezone" gorm:"size:64;default:'UTC'"`
+	CreatedByID    uuid.UUID      `json:"created_by_id" gorm:"type:uuid;not null"`
+	CancelledAt    *time.Time     `json:"cancelled_at,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+
+	// Relationships
+	Neighborhood *Neighborhood `json:"neighborhood,omitempty" gorm:"foreignKey:NeighborhoodID"`
+}
+
+// TeamBlockException records a team member working remotely on a day of a team block,
+// so no desk is assigned to them that day
+type TeamBlockException struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TeamBlockID uuid.UUID `json:"team_block_id" gorm:"type:uuid;not null;uniqueIndex:idx_team_block_exception"`
+	UserID      uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_team_block_exception"`
+	Date        time.Time `json:"date" gorm:"type:date;not null;uniqueIndex:idx_team_block_exception"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for TeamBlock model
+func (TeamBlock) TableName() string {
+	return "team_blocks"
+}
+
+// TableName returns the table name for TeamBlockException model
+func (TeamBlockException) TableName() string {
+	return "team_block_exceptions"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (b *TeamBlock) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to set ID if not provided
+func (e *TeamBlockException) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsCancelled checks if the team lead cancelled the block
+func (b *TeamBlock) IsCancelled() bool {
+	return b.CancelledAt != nil
+}
+
+// Location returns the timezone the days and hours of the block are read in
+func (b *TeamBlock) Location() *time.Location {
+	loc, err := time.LoadLocation(b.Timezone)
+	if err != nil || b.Timezone == "" {
+		return time.UTC
+	}
+	return loc
+}
+
+// CoversDay checks if the date, given as its midnight in UTC, is one of the block's days
+func (b *TeamBlock) CoversDay(date time.Time) bool {
+	if date.Before(b.StartDate) || date.After(b.EndDate) {
+		return false
+	}
+	for _, weekday := range b.Weekdays {
+		if int(date.Weekday()) == int(weekday) {
+			return true
+		}
+	}
+	return false
+}
+
+// Days returns the dates of the block, as their midnight in UTC
+func (b *TeamBlock) Days() []time.Time {
+	var days []time.Time
+	for day := b.StartDate; !day.After(b.EndDate); day = day.AddDate(0, 0, 1) {
+		if b.CoversDay(day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// WindowOn returns when the desks are booked on a date of the block
+func (b *TeamBlock) WindowOn(date time.Time) (time.Time, time.Time) {
+	loc := b.Location()
+	start, _ := time.Parse("15:04", b.DayStart)
+	end, _ := time.Parse("15:04", b.DayEnd)
+	return time.Date(date.Year(), date.Month(), date.Day(), start.Hour(), start.Minute(), 0, 0, loc),
+		time.Date(date.Year(), date.Month(), date.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+}
@@ -14,8 +14,16 @@ const (
 	RoleAdmin        UserRole = "admin"
 	RoleManager      UserRole = "manager"
 	RoleStandardUser UserRole = "user"
+	RoleAuditor      UserRole = "auditor"    // Only runs compliance exports
+	RoleFacilities   UserRole = "facilities" // Fulfills the services ordered with reservations
 )
 
+// rolePriorities are the booking priorities given by roles; users may be raised above theirs
+var rolePriorities = map[UserRole]int{
+	RoleAdmin:   2,
+	RoleManager: 1,
+}
+
 type User struct {
 	ID             uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	FirstName      string         `json:"first_name" gorm:"not null;size:100" validate:"required,min=2,max=100"`
@@ -29,14 +37,16 @@ type User struct {
 	ProfilePicture string         `json:"profile_picture" gorm:"size:255"`
 	Department     string         `json:"department" gorm:"size:100"`
 	Position       string         `json:"position" gorm:"size:100"`
+	PriorityTier   int            `json:"priority_tier" gorm:"not null;default:0"` // Booking priority granted on top of the role's, see Priority
+	AnonymizedAt   *time.Time     `json:"anonymized_at,omitempty"`                 // Set when the user deleted their account; the row stays for statistics
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
-	Reservations  []Reservation `json:"reservations,omitempty" gorm:"foreignKey:UserID"`
-	ManagedSpaces []Space       `json:"managed_spaces,omitempty" gorm:"foreignKey:ManagerID"`
-	// Notifications []Notification `json:"notifications,omitempty" gorm:"foreignKey:UserID"`
+	Reservations  []Reservation  `json:"reservations,omitempty" gorm:"foreignKey:UserID"`
+	ManagedSpaces []Space        `json:"managed_spaces,omitempty" gorm:"foreignKey:ManagerID"`
+	Notifications []Notification `json:"notifications,omitempty" gorm:"foreignKey:UserID"`
 }
 
 // TableName returns the table name for User model
@@ -67,6 +77,17 @@ func (u *User) IsManager() bool {
 	return u.Role == RoleManager
 }
 
+// HasStaffAccess checks if user sees beyond their own data (managers and admins)
+func (u *User) HasStaffAccess() bool {
+	return u.IsAdmin() || u.IsManager()
+}
+
+// Priority returns the booking priority of the user, the higher of its tier and its role's.
+// Higher priorities may preempt the pending bookings of lower ones in spaces that allow it.
+func (u *User) Priority() int {
+	return max(u.PriorityTier, rolePriorities[u.Role])
+}
+
 // CanManageSpace checks if user can manage a specific space
 func (u *User) CanManageSpace(space *Space) bool {
 	if u.IsAdmin() {
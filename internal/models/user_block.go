@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserBlock stops the blocked user from starting or continuing direct conversations with the blocker
+type UserBlock struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	BlockerID uuid.UUID `json:"blocker_id" gorm:"type:uuid;not null;uniqueIndex:idx_user_block"`
+	BlockedID uuid.UUID `json:"blocked_id" gorm:"type:uuid;not null;uniqueIndex:idx_user_block;index"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	Blocker *User `json:"blocker,omitempty" gorm:"foreignKey:BlockerID"`
+	Blocked *User `json:"blocked,omitempty" gorm:"foreignKey:BlockedID"`
+}
+
+// TableName returns the table name for UserBlock model
+func (UserBlock) TableName() string {
+	return "user_blocks"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (b *UserBlock) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
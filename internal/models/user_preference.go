@@ -0,0 +1,37 @@
+// internal/models/user_preference.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// UserPreference holds the defaults applied to a user's new reservations when
+// the request leaves the matching fields out
+type UserPreference struct {
+	ID                     uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID                 uuid.UUID      `json:"user_id" gorm:"type:uuid;not null;uniqueIndex"`
+	PreferredBuilding      string         `json:"preferred_building" gorm:"size:50"`
+	PreferredFloor         *int           `json:"preferred_floor"`
+	DefaultDurationMinutes int            `json:"default_duration_minutes" gorm:"default:0"`  // 0 means no default
+	DefaultParticipantIDs  pq.StringArray `json:"default_participant_ids" gorm:"type:text[]"` // Colleagues usually invited
+	DefaultPrivate         bool           `json:"default_private" gorm:"default:false"`
+	CreatedAt              time.Time      `json:"created_at"`
+	UpdatedAt              time.Time      `json:"updated_at"`
+}
+
+// TableName returns the table name for UserPreference model
+func (UserPreference) TableName() string {
+	return "user_preferences"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (p *UserPreference) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
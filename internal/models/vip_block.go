@@ -0,0 +1,120 @@
+// internal/models/vip_block.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// VIPBlock is a weekly guaranteed-availability window on a VIP space.
+// During the window the space can only be booked by the allowed roles.
+type VIPBlock struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SpaceID      uuid.UUID      `json:"space_id" gorm:"type:uuid;not null;index"`
+	DayOfWeek    int            `json:"day_of_week" gorm:"not null"`           // 0=Sunday, 1=Monday, etc.
+	StartTime    string         `json:"start_time" gorm:"size:5;not null"`     // HH:MM
+	EndTime      string         `json:"end_time" gorm:"size:5;not null"`       // HH:MM
+	Timezone     string         `json:"timezone" gorm:"size:64;default:'UTC'"` // IANA timezone of the window
+	AllowedRoles pq.StringArray `json:"allowed_roles" gorm:"type:text[]"`
+	Reason       string         `json:"reason" gorm:"type:text"`
+	IsActive     bool           `json:"is_active" gorm:"default:true"`
+	CreatedByID  uuid.UUID      `json:"created_by_id" gorm:"type:uuid;not null"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Space     *Space `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
+	CreatedBy *User  `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID"`
+}
+
+// VIPBlockViolation records a booking that was allowed into a VIP block by override
+type VIPBlockViolation struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	BlockID       uuid.UUID `json:"block_id" gorm:"type:uuid;not null;index"`
+	SpaceID       uuid.UUID `json:"space_id" gorm:"type:uuid;not null;index"`
+	ReservationID uuid.UUID `json:"reservation_id" gorm:"type:uuid;not null;index"`
+	UserID        uuid.UUID `json:"user_id" gorm:"type:uuid;not null"` // User who overrode the block
+	Justification string    `json:"justification" gorm:"type:text;not null"`
+	StartTime     time.Time `json:"start_time" gorm:"not null"`
+	EndTime       time.Time `json:"end_time" gorm:"not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"index"`
+
+	// Relationships
+	Block       *VIPBlock    `json:"block,omitempty" gorm:"foreignKey:BlockID"`
+	Space       *Space       `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
+	Reservation *Reservation `json:"reservation,omitempty" gorm:"foreignKey:ReservationID"`
+	User        *User        `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for VIPBlock model
+func (VIPBlock) TableName() string {
+	return "vip_blocks"
+}
+
+// TableName returns the table name for VIPBlockViolation model
+func (VIPBlockViolation) TableName() string {
+	return "vip_block_violations"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (b *VIPBlock) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to set ID if not provided
+func (v *VIPBlockViolation) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+// AllowsRole checks if the role may book inside the block
+func (b *VIPBlock) AllowsRole(role UserRole) bool {
+	for _, allowed := range b.AllowedRoles {
+		if allowed == string(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Overlaps checks if a time range intersects any weekly occurrence of the block
+func (b *VIPBlock) Overlaps(start, end time.Time) bool {
+	loc, err := time.LoadLocation(b.Timezone)
+	if err != nil || b.Timezone == "" {
+		loc = time.UTC
+	}
+
+	windowStart, err := time.Parse("15:04", b.StartTime)
+	if err != nil {
+		return false
+	}
+	windowEnd, err := time.Parse("15:04", b.EndTime)
+	if err != nil {
+		return false
+	}
+
+	// Walk every local day touched by the range
+	localStart := start.In(loc)
+	day := time.Date(localStart.Year(), localStart.Month(), localStart.Day(), 0, 0, 0, 0, loc)
+	for !day.After(end.In(loc)) {
+		if int(day.Weekday()) == b.DayOfWeek {
+			occurrenceStart := time.Date(day.Year(), day.Month(), day.Day(), windowStart.Hour(), windowStart.Minute(), 0, 0, loc)
+			occurrenceEnd := time.Date(day.Year(), day.Month(), day.Day(), windowEnd.Hour(), windowEnd.Minute(), 0, 0, loc)
+			if start.Before(occurrenceEnd) && occurrenceStart.Before(end) {
+				return true
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+// internal/models/visitor_event.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Visitor event types, published to reception systems
+const (
+	VisitorEventExpected  = "visitor_expected"  // A guest was invited
+	VisitorEventUpdated   = "visitor_updated"   // The guest confirmed, or their meeting changed
+	VisitorEventCancelled = "visitor_cancelled" // The guest declined or was removed, or their meeting was cancelled
+	VisitorEventArrived   = "visitor_arrived"   // Reception checked the guest in
+)
+
+// VisitorEvent records a change to an expected visitor, kept for a while so reception systems
+// can catch up on what they missed. It is written within the transaction of the change.
+type VisitorEvent struct {
+	ID         uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Type       string         `json:"type" gorm:"size:50;not null"`
+	GuestID    uuid.UUID      `json:"guest_id" gorm:"type:uuid;not null;index"`
+	Building   string         `json:"building" gorm:"size:100;not null;index"`
+	Visitor    datatypes.JSON `json:"visitor" gorm:"type:jsonb;not null"` // VisitorSnapshot at the time of the change
+	OccurredAt time.Time      `json:"occurred_at" gorm:"not null;index"`
+}
+
+// VisitorSnapshot is what a reception system knows about a visitor and their meeting
+type VisitorSnapshot struct {
+	GuestID           uuid.UUID  `json:"guest_id"`
+	ReservationID     uuid.UUID  `json:"reservation_id"`
+	Name              string     `json:"name"`
+	Email             string     `json:"email"`
+	Company           string     `json:"company,omitempty"`
+	RSVPStatus        string     `json:"rsvp_status"`
+	ArrivedAt         *time.Time `json:"arrived_at,omitempty"`
+	HostName          string     `json:"host_name"`
+	HostEmail         string     `json:"host_email"`
+	ReservationTitle  string     `json:"reservation_title"`
+	ReservationStatus string     `json:"reservation_status"`
+	SpaceName         string     `json:"space_name"`
+	Building          string     `json:"building"`
+	Floor             int        `json:"floor"`
+	RoomNumber        string     `json:"room_number"`
+	StartTime         time.Time  `json:"start_time"`
+	EndTime           time.Time  `json:"end_time"`
+}
+
+// NewVisitorSnapshot captures a guest; its reservation must be loaded with the space and host
+func NewVisitorSnapshot(guest *ReservationGuest) VisitorSnapshot {
+	reservation := guest.Reservation
+	return VisitorSnapshot{
+		GuestID:           guest.ID,
+		ReservationID:     guest.ReservationID,
+		Name:              guest.Name,
+		Email:             guest.Email,
+		Company:           guest.Company,
+		RSVPStatus:        string(guest.RSVPStatus),
+		ArrivedAt:         guest.ArrivedAt,
+		HostName:          reservation.User.GetFullName(),
+		HostEmail:         reservation.User.Email,
+		ReservationTitle:  reservation.Title,
+		ReservationStatus: string(reservation.Status),
+		SpaceName:         reservation.Space.Name,
+		Building:          reservation.Space.Building,
+		Floor:             reservation.Space.Floor,
+		RoomNumber:        reservation.Space.RoomNumber,
+		StartTime:         reservation.StartTime,
+		EndTime:           reservation.EndTime,
+	}
+}
+
+// TableName returns the table name for VisitorEvent model
+func (VisitorEvent) TableName() string {
+	return "visitor_events"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (e *VisitorEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// internal/models/webhook.go
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookSubscription sends events to an external endpoint. Payloads are rendered in the
+// schema version the consumer selected, so schema changes do not break old integrations.
+type WebhookSubscription struct {
+	ID            uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name          string         `json:"name" gorm:"size:100;not null"`
+	URL           string         `json:"url" gorm:"size:500;not null"`
+	Secret        string         `json:"-" gorm:"size:100;not null"` // HMAC key for the signature header
+	Events        pq.StringArray `json:"events" gorm:"type:text[];not null"`
+	SchemaVersion int            `json:"schema_version" gorm:"not null"`
+	IsActive      bool           `json:"is_active" gorm:"default:true"`
+	CreatedByID   uuid.UUID      `json:"created_by_id" gorm:"type:uuid;not null"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName returns the table name for WebhookSubscription model
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (w *WebhookSubscription) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// Wants checks if the subscription receives the event type
+func (w *WebhookSubscription) Wants(eventType string) bool {
+	for _, event := range w.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event queued for a subscription, with the payload as sent
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SubscriptionID uuid.UUID             `json:"subscription_id" gorm:"type:uuid;not null;index"`
+	EventID        string                `json:"event_id" gorm:"size:50;not null"`
+	EventType      string                `json:"event_type" gorm:"size:50;not null"`
+	SchemaVersion  int                   `json:"schema_version" gorm:"not null"`
+	Payload        datatypes.JSON        `json:"payload" gorm:"type:jsonb;not null"`
+	Status         WebhookDeliveryStatus `json:"status" gorm:"type:varchar(20);default:'pending';index"`
+	Attempts       int                   `json:"attempts" gorm:"default:0"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at" gorm:"not null"`
+	ResponseStatus int                   `json:"response_status,omitempty"`
+	LastError      string                `json:"last_error,omitempty" gorm:"type:text"`
+	DeliveredAt    *time.Time            `json:"delivered_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+
+	// Relationships
+	Subscription *WebhookSubscription `json:"-" gorm:"foreignKey:SubscriptionID"`
+}
+
+// TableName returns the table name for WebhookDelivery model
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	if d.NextAttemptAt.IsZero() {
+		d.NextAttemptAt = time.Now()
+	}
+	return nil
+}
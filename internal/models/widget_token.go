@@ -0,0 +1,72 @@
+// internal/models/widget_token.go
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// WidgetToken lets a booking widget embedded in another site show availability and send
+// booking requests for one space, or every space of one building. Widget tokens end up in
+// page sources, so they only grant that; only a hash of the token is stored.
+type WidgetToken struct {
+	ID             uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name           string         `json:"name" gorm:"size:100;not null"`
+	SpaceID        *uuid.UUID     `json:"space_id,omitempty" gorm:"type:uuid;index"` // Set for tokens scoped to one space
+	Building       string         `json:"building,omitempty" gorm:"size:50"`         // Set for tokens scoped to a building
+	AllowedOrigins pq.StringArray `json:"allowed_origins" gorm:"type:text[]"`        // Sites the widget may run on; empty allows any
+	TokenHash      string         `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	TokenPrefix    string         `json:"token_prefix" gorm:"size:16;not null"`    // Shown to tell tokens apart
+	CreatedByID    uuid.UUID      `json:"created_by_id" gorm:"type:uuid;not null"` // Owns the reservations requested through the widget
+	LastUsedAt     *time.Time     `json:"last_used_at"`
+	RevokedAt      *time.Time     `json:"revoked_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+
+	// Relationships
+	Space *Space `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
+}
+
+// TableName returns the table name for WidgetToken model
+func (WidgetToken) TableName() string {
+	return "widget_tokens"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (t *WidgetToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsRevoked checks if the widget token has been revoked
+func (t *WidgetToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// Covers checks if the space is within the token's scope
+func (t *WidgetToken) Covers(space *Space) bool {
+	if t.SpaceID != nil {
+		return *t.SpaceID == space.ID
+	}
+	return t.Building != "" && t.Building == space.Building
+}
+
+// AllowsOrigin checks if a page on the origin may use the token
+func (t *WidgetToken) AllowsOrigin(origin string) bool {
+	if len(t.AllowedOrigins) == 0 {
+		return true
+	}
+	origin = strings.ToLower(strings.TrimRight(origin, "/"))
+	for _, allowed := range t.AllowedOrigins {
+		if strings.ToLower(strings.TrimRight(allowed, "/")) == origin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+// internal/models/working_calendar.go
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+type CalendarDayKind string
+
+const (
+	CalendarDayPublicHoliday  CalendarDayKind = "public_holiday"
+	CalendarDayCompanyClosure CalendarDayKind = "company_closure"
+)
+
+// CalendarRegion groups the buildings sharing a working calendar, e.g. the offices of one
+// country or state
+type CalendarRegion struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Code        string         `json:"code" gorm:"size:20;not null;uniqueIndex"` // e.g. DE-BY
+	Name        string         `json:"name" gorm:"size:100;not null"`
+	Buildings   pq.StringArray `json:"buildings" gorm:"type:text[];not null"`
+	Timezone    string         `json:"timezone" gorm:"size:64;default:'UTC'"` // Days are matched in this timezone
+	UpdatedByID uuid.UUID      `json:"updated_by_id" gorm:"type:uuid;not null"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// CalendarDay is a public holiday or company closure day of a region, or of every region.
+// Blocking days refuse bookings; the others only warn about them.
+type CalendarDay struct {
+	ID          uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Region      string          `json:"region" gorm:"size:20;not null;default:'';uniqueIndex:idx_calendar_day"` // Empty for every region
+	Date        time.Time       `json:"date" gorm:"type:date;not null;uniqueIndex:idx_calendar_day"`
+	Name        string          `json:"name" gorm:"size:200;not null"`
+	Kind        CalendarDayKind `json:"kind" gorm:"type:varchar(20);not null"`
+	Blocking    bool            `json:"blocking" gorm:"not null"`
+	CreatedByID uuid.UUID       `json:"created_by_id" gorm:"type:uuid;not null"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// TableName returns the table name for CalendarRegion model
+func (CalendarRegion) TableName() string {
+	return "calendar_regions"
+}
+
+// TableName returns the table name for CalendarDay model
+func (CalendarDay) TableName() string {
+	return "calendar_days"
+}
+
+// BeforeCreate hook to set ID if not provided
+func (r *CalendarRegion) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to set ID if not provided
+func (d *CalendarDay) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// Location returns the timezone the days of the region are matched in
+func (r *CalendarRegion) Location() *time.Location {
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil || r.Timezone == "" {
+		return time.UTC
+	}
+	return loc
+}
+
+// HasBuilding checks if the building is in the region
+func (r *CalendarRegion) HasBuilding(building string) bool {
+	for _, candidate := range r.Buildings {
+		if strings.EqualFold(candidate, building) {
+			return true
+		}
+	}
+	return false
+}
+
+// Label describes the kind of day for people
+func (d *CalendarDay) Label() string {
+	if d.Kind == CalendarDayCompanyClosure {
+		return "a company closure day"
+	}
+	return "a public holiday"
+}
@@ -0,0 +1,151 @@
+// internal/repositories/account_repository.go
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Name shown in place of an anonymized user
+const anonymizedName = "Deleted user"
+
+// AccountRepository implements the AccountRepositoryInterface
+type AccountRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountRepository creates a new account repository
+func NewAccountRepository(db *gorm.DB) interfaces.AccountRepositoryInterface {
+	return &AccountRepository{db: db}
+}
+
+// Anonymize strips a user's personal data from every table in one transaction. Upcoming
+// bookings are cancelled to free their rooms; past ones keep their space, times, cost and
+// department so occupancy and chargeback statistics do not change.
+func (r *AccountRepository) Anonymize(userID uuid.UUID, at time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ReservationGuest{}).
+			Where("reservation_id IN (?)", tx.Model(&models.Reservation{}).Select("id").Where("user_id = ?", userID)).
+			Updates(map[string]interface{}{
+				"name":    "Guest",
+				"email":   "",
+				"company": "",
+			}).Error; err != nil {
+			return fmt.Errorf("anonymize guests: %w", err)
+		}
+
+		if err := tx.Model(&models.Reservation{}).Where("user_id = ?", userID).
+			Updates(map[string]interface{}{
+				"title":                   "Reservation",
+				"description":             "",
+				"license_plate":           "",
+				"duplicate_justification": "",
+				"cancellation_reason":     "",
+				"approval_comments":       "",
+				"requester_name":          "",
+				"requester_email":         "",
+			}).Error; err != nil {
+			return fmt.Errorf("anonymize reservations: %w", err)
+		}
+
+		// Upcoming bookings are cancelled after the texts were cleared, so their reason stays
+		if err := tx.Model(&models.Reservation{}).
+			Where("user_id = ? AND start_time > ? AND status IN ?", userID, at, []models.ReservationStatus{models.StatusPending, models.StatusConfirmed}).
+			Updates(map[string]interface{}{
+				"status":              models.StatusCancelled,
+				"cancellation_reason": "Organizer account deleted",
+			}).Error; err != nil {
+			return fmt.Errorf("cancel reservations: %w", err)
+		}
+
+		if err := tx.Model(&models.QuestionnaireSubmission{}).Where("user_id = ?", userID).
+			Update("answers", "{}").Error; err != nil {
+			return fmt.Errorf("anonymize questionnaire answers: %w", err)
+		}
+
+		if err := tx.Model(&models.Message{}).Where("sender_id = ?", userID).
+			Update("sender_name", anonymizedName).Error; err != nil {
+			return fmt.Errorf("anonymize messages: %w", err)
+		}
+
+		// Activity about the user's bookings keeps what happened, not the titles or payloads, and
+		// their own actions, whose notes they wrote, are no longer attributed to them
+		if err := tx.Model(&models.ActivityEvent{}).
+			Where("entity_type = ? AND entity_id IN (?)", models.ActivityEntityReservation,
+				tx.Model(&models.Reservation{}).Select("id").Where("user_id = ?", userID)).
+			Updates(map[string]interface{}{
+				"entity_name": "Reservation",
+				"details":     gorm.Expr("NULL"),
+			}).Error; err != nil {
+			return fmt.Errorf("anonymize activity: %w", err)
+		}
+		if err := tx.Model(&models.ActivityEvent{}).
+			Where("details->>'user_id' = ? OR details->>'preempted_by_id' = ? OR details->'organizer_ids' @> jsonb_build_array(?::text)",
+				userID.String(), userID.String(), userID.String()).
+			Update("details", gorm.Expr("NULL")).Error; err != nil {
+			return fmt.Errorf("anonymize activity: %w", err)
+		}
+		if err := tx.Model(&models.ActivityEvent{}).Where("actor_id = ?", userID).
+			Updates(map[string]interface{}{
+				"actor_id": nil,
+				"details":  gorm.Expr("NULL"),
+			}).Error; err != nil {
+			return fmt.Errorf("anonymize activity: %w", err)
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.ReservationCoOrganizer{}).Error; err != nil {
+			return fmt.Errorf("remove co-organizer roles: %w", err)
+		}
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.Notification{}).Error; err != nil {
+			return fmt.Errorf("delete notifications: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserPreference{}).Error; err != nil {
+			return fmt.Errorf("delete preferences: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.QuotaOverride{}).Error; err != nil {
+			return fmt.Errorf("delete quota override: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.FavoriteSpace{}).Error; err != nil {
+			return fmt.Errorf("delete favorite spaces: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.BookingTemplate{}).Error; err != nil {
+			return fmt.Errorf("delete booking templates: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.SavedSearch{}).Error; err != nil {
+			return fmt.Errorf("delete saved searches: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.DeviceKeyBundle{}).Error; err != nil {
+			return fmt.Errorf("delete device keys: %w", err)
+		}
+		if err := tx.Where("blocker_id = ? OR blocked_id = ?", userID, userID).Delete(&models.UserBlock{}).Error; err != nil {
+			return fmt.Errorf("delete user blocks: %w", err)
+		}
+
+		// The password hash is not a valid bcrypt hash, so no password matches it
+		result := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"first_name":      "Deleted",
+			"last_name":       "User",
+			"email":           fmt.Sprintf("deleted-%s@anonymized.invalid", userID),
+			"password_hash":   "!",
+			"phone":           "",
+			"profile_picture": "",
+			"position":        "",
+			"role":            models.RoleStandardUser,
+			"is_active":       false,
+			"anonymized_at":   at,
+		})
+		if result.Error != nil {
+			return fmt.Errorf("anonymize user: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
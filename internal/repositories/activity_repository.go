@@ -0,0 +1,81 @@
+// internal/repositories/activity_repository.go
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ActivityRepository implements the ActivityRepositoryInterface
+type ActivityRepository struct {
+	db *gorm.DB
+}
+
+// NewActivityRepository creates a new activity repository
+func NewActivityRepository(db *gorm.DB) interfaces.ActivityRepositoryInterface {
+	return &ActivityRepository{db: db}
+}
+
+// Record appends an event, skipping it when its source event was recorded already
+func (r *ActivityRepository) Record(ctx context.Context, event *models.ActivityEvent) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "source_id"}}, DoNothing: true}).
+		Create(event).Error
+}
+
+// List returns the events matching the filter after the cursor, oldest first, leaving out
+// those that occurred after until
+func (r *ActivityRepository) List(ctx context.Context, filter interfaces.ActivityFilter, after *time.Time, afterID uuid.UUID, until time.Time, limit int) ([]*models.ActivityEvent, error) {
+	query := r.db.WithContext(ctx).Model(&models.ActivityEvent{}).
+		Where("occurred_at <= ?", until)
+
+	if after != nil {
+		query = query.Where("(occurred_at, id) > (?, ?)", *after, afterID)
+	}
+	if len(filter.Categories) > 0 {
+		query = query.Where("category IN ?", filter.Categories)
+	}
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.From != nil {
+		query = query.Where("occurred_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("occurred_at < ?", *filter.To)
+	}
+
+	var events []*models.ActivityEvent
+	err := query.Preload("Actor").
+		Order("occurred_at ASC, id ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// appendActivityEvent writes an activity event within the transaction of the change it describes
+func appendActivityEvent(tx *gorm.DB, event *models.ActivityEvent, details interface{}) error {
+	if details != nil {
+		body, err := json.Marshal(details)
+		if err != nil {
+			return err
+		}
+		event.Details = datatypes.JSON(body)
+	}
+	return tx.Create(event).Error
+}
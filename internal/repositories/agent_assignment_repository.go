@@ -0,0 +1,83 @@
+// internal/repositories/agent_assignment_repository.go
+package repositories
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AgentAssignmentRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+// NewAgentAssignmentRepository creates a new agent assignment repository instance
+func NewAgentAssignmentRepository(db *gorm.DB, logger *slog.Logger) *AgentAssignmentRepository {
+	return &AgentAssignmentRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Ensure AgentAssignmentRepository implements the interface
+var _ interfaces.AgentAssignmentRepository = (*AgentAssignmentRepository)(nil)
+
+func (r *AgentAssignmentRepository) Create(ctx context.Context, assignment *models.AgentAssignment) error {
+	return r.db.WithContext(ctx).Create(assignment).Error
+}
+
+func (r *AgentAssignmentRepository) Update(ctx context.Context, assignment *models.AgentAssignment) error {
+	return r.db.WithContext(ctx).Save(assignment).Error
+}
+
+func (r *AgentAssignmentRepository) GetPendingByConversation(ctx context.Context, conversationID uuid.UUID) (*models.AgentAssignment, error) {
+	var assignment models.AgentAssignment
+	err := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND status = ?", conversationID, models.AssignmentStatusPending).
+		Order("offered_at DESC").
+		First(&assignment).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *AgentAssignmentRepository) GetExpiredPending(ctx context.Context, offeredBefore time.Time, limit int) ([]models.AgentAssignment, error) {
+	var assignments []models.AgentAssignment
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND offered_at < ?", models.AssignmentStatusPending, offeredBefore).
+		Order("offered_at ASC").
+		Limit(limit).
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// GetRejectedAgentIDs returns agents who declined the conversation or let the offer expire
+func (r *AgentAssignmentRepository) GetRejectedAgentIDs(ctx context.Context, conversationID uuid.UUID) ([]uuid.UUID, error) {
+	var agentIDs []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&models.AgentAssignment{}).
+		Where("conversation_id = ? AND status IN ?", conversationID,
+			[]models.AssignmentStatus{models.AssignmentStatusDeclined, models.AssignmentStatusExpired}).
+		Distinct().
+		Pluck("agent_id", &agentIDs).Error
+	return agentIDs, err
+}
+
+func (r *AgentAssignmentRepository) CancelPending(ctx context.Context, conversationID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&models.AgentAssignment{}).
+		Where("conversation_id = ? AND status = ?", conversationID, models.AssignmentStatusPending).
+		Updates(map[string]interface{}{
+			"status":       models.AssignmentStatusCancelled,
+			"responded_at": time.Now(),
+		}).Error
+}
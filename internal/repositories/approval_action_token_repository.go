@@ -0,0 +1,76 @@
+// internal/repositories/approval_action_token_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApprovalActionTokenRepository implements the ApprovalActionTokenRepositoryInterface
+type ApprovalActionTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewApprovalActionTokenRepository creates a new approval action token repository
+func NewApprovalActionTokenRepository(db *gorm.DB) interfaces.ApprovalActionTokenRepositoryInterface {
+	return &ApprovalActionTokenRepository{db: db}
+}
+
+// CreateBatch saves the tokens of an approval email together
+func (r *ApprovalActionTokenRepository) CreateBatch(tokens []*models.ApprovalActionToken) error {
+	return r.db.Create(&tokens).Error
+}
+
+// GetByID retrieves a token
+func (r *ApprovalActionTokenRepository) GetByID(id uuid.UUID) (*models.ApprovalActionToken, error) {
+	var token models.ApprovalActionToken
+	err := r.db.Where("id = ?", id).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// HasTokens checks if the approver was already sent links for the reservation
+func (r *ApprovalActionTokenRepository) HasTokens(reservationID, approverID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.ApprovalActionToken{}).
+		Where("reservation_id = ? AND approver_id = ?", reservationID, approverID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Use spends the token with the comments, and the approver's other tokens for the reservation,
+// returning gorm.ErrRecordNotFound when it was used already
+func (r *ApprovalActionTokenRepository) Use(token *models.ApprovalActionToken, comments, ip string, usedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.ApprovalActionToken{}).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Updates(map[string]interface{}{
+				"used_at":      usedAt,
+				"used_from_ip": ip,
+				"comments":     comments,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Model(&models.ApprovalActionToken{}).
+			Where("reservation_id = ? AND approver_id = ? AND used_at IS NULL", token.ReservationID, token.ApproverID).
+			Update("used_at", usedAt).Error
+	})
+}
+
+// DeleteExpired removes the tokens that expired before the time
+func (r *ApprovalActionTokenRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&models.ApprovalActionToken{})
+	return result.RowsAffected, result.Error
+}
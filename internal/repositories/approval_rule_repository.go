@@ -0,0 +1,65 @@
+// internal/repositories/approval_rule_repository.go
+package repositories
+
+import (
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ApprovalRuleRepository implements the ApprovalRuleRepositoryInterface
+type ApprovalRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewApprovalRuleRepository creates a new approval rule repository
+func NewApprovalRuleRepository(db *gorm.DB) interfaces.ApprovalRuleRepositoryInterface {
+	return &ApprovalRuleRepository{db: db}
+}
+
+// GetAll retrieves the rules of all spaces
+func (r *ApprovalRuleRepository) GetAll() ([]*models.ApprovalRule, error) {
+	var rules []*models.ApprovalRule
+	err := r.db.Preload("Space").Order("created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+// GetBySpace retrieves the rule of a space
+func (r *ApprovalRuleRepository) GetBySpace(spaceID uuid.UUID) (*models.ApprovalRule, error) {
+	var rule models.ApprovalRule
+	err := r.db.Where("space_id = ?", spaceID).First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Save creates the space's rule or replaces its thresholds
+func (r *ApprovalRuleRepository) Save(rule *models.ApprovalRule) (*models.ApprovalRule, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "space_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"auto_approve_max_minutes", "auto_approve_max_participants", "auto_approve_min_tenure_days",
+			"auto_approve_roles", "two_step", "updated_by_id", "updated_at",
+		}),
+	}).Create(rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.GetBySpace(rule.SpaceID)
+}
+
+// Delete removes the rule of a space
+func (r *ApprovalRuleRepository) Delete(spaceID uuid.UUID) error {
+	result := r.db.Where("space_id = ?", spaceID).Delete(&models.ApprovalRule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// internal/repositories/booking_conflict_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BookingConflictRepository implements the BookingConflictRepositoryInterface
+type BookingConflictRepository struct {
+	db *gorm.DB
+}
+
+// NewBookingConflictRepository creates a new booking conflict repository
+func NewBookingConflictRepository(db *gorm.DB) interfaces.BookingConflictRepositoryInterface {
+	return &BookingConflictRepository{db: db}
+}
+
+// Create records a rejected booking attempt
+func (r *BookingConflictRepository) Create(conflict *models.BookingConflict) error {
+	return r.db.Create(conflict).Error
+}
+
+// GetSummaryBySpace aggregates conflicts recorded within a date range per space
+func (r *BookingConflictRepository) GetSummaryBySpace(startDate, endDate time.Time, managerID *uuid.UUID, spaceID *uuid.UUID) ([]*models.BookingConflictSummary, error) {
+	var summaries []*models.BookingConflictSummary
+
+	query := r.db.Model(&models.BookingConflict{}).
+		Select(`booking_conflicts.space_id,
+			spaces.name AS space_name,
+			COUNT(*) AS total_conflicts,
+			COUNT(CASE WHEN booking_conflicts.reason = ? THEN 1 END) AS slot_taken,
+			COUNT(CASE WHEN booking_conflicts.reason = ? THEN 1 END) AS vip_blocked,
+			COUNT(DISTINCT booking_conflicts.user_id) AS distinct_users,
+			MAX(booking_conflicts.created_at) AS last_conflict_at`,
+			models.ConflictReasonSlotTaken, models.ConflictReasonVIPBlock).
+		Joins("JOIN spaces ON spaces.id = booking_conflicts.space_id AND spaces.deleted_at IS NULL").
+		Where("booking_conflicts.created_at >= ? AND booking_conflicts.created_at < ?", startDate, endDate)
+	if managerID != nil {
+		query = query.Where("spaces.manager_id = ?", *managerID)
+	}
+	if spaceID != nil {
+		query = query.Where("booking_conflicts.space_id = ?", *spaceID)
+	}
+
+	err := query.
+		Group("booking_conflicts.space_id, spaces.name").
+		Order("total_conflicts DESC, last_conflict_at DESC").
+		Scan(&summaries).Error
+
+	return summaries, err
+}
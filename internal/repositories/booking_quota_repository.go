@@ -0,0 +1,120 @@
+// internal/repositories/booking_quota_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Reservations that use up a quota: cancelled and rejected ones give their hours back
+var quotaCountedStatuses = []models.ReservationStatus{models.StatusPending, models.StatusConfirmed, models.StatusCompleted}
+
+// BookingQuotaRepository implements the BookingQuotaRepositoryInterface
+type BookingQuotaRepository struct {
+	db *gorm.DB
+}
+
+// NewBookingQuotaRepository creates a new booking quota repository
+func NewBookingQuotaRepository(db *gorm.DB) interfaces.BookingQuotaRepositoryInterface {
+	return &BookingQuotaRepository{db: db}
+}
+
+// ========================================
+// OVERRIDE OPERATIONS
+// ========================================
+
+// GetOverrides retrieves every override, user quotas first
+func (r *BookingQuotaRepository) GetOverrides() ([]*models.QuotaOverride, error) {
+	var overrides []*models.QuotaOverride
+	err := r.db.Preload("User").Order("scope DESC, created_at ASC").Find(&overrides).Error
+	return overrides, err
+}
+
+// GetUserOverride retrieves the override of a user's weekly quota
+func (r *BookingQuotaRepository) GetUserOverride(userID uuid.UUID) (*models.QuotaOverride, error) {
+	var override models.QuotaOverride
+	err := r.db.Where("user_id = ?", userID).First(&override).Error
+	if err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+// GetTeamOverride retrieves the override of a department's premium-room quota
+func (r *BookingQuotaRepository) GetTeamOverride(department string) (*models.QuotaOverride, error) {
+	var override models.QuotaOverride
+	err := r.db.Where("department = ?", department).First(&override).Error
+	if err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+// SaveOverride creates the user's or team's override or replaces its limit
+func (r *BookingQuotaRepository) SaveOverride(override *models.QuotaOverride) (*models.QuotaOverride, error) {
+	target := "user_id"
+	if override.Scope == models.QuotaScopeTeam {
+		target = "department"
+	}
+
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: target}},
+		DoUpdates: clause.AssignmentColumns([]string{"limit_hours", "reason", "expires_at", "updated_by_id", "updated_at"}),
+	}).Create(override).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if override.Scope == models.QuotaScopeTeam {
+		return r.GetTeamOverride(*override.Department)
+	}
+	return r.GetUserOverride(*override.UserID)
+}
+
+// DeleteOverride removes an override, putting the configured quota back
+func (r *BookingQuotaRepository) DeleteOverride(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.QuotaOverride{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ========================================
+// USAGE OPERATIONS
+// ========================================
+
+// SumUserBookedMinutes sums the minutes of the user's reservations starting within the time range
+func (r *BookingQuotaRepository) SumUserBookedMinutes(userID uuid.UUID, startTime, endTime time.Time) (float64, error) {
+	var minutes float64
+	err := r.db.Model(&models.Reservation{}).
+		Select("COALESCE(SUM(EXTRACT(EPOCH FROM (end_time - start_time)) / 60), 0)").
+		Where("user_id = ? AND status IN ? AND start_time >= ? AND start_time < ?",
+			userID, quotaCountedStatuses, startTime, endTime).
+		Scan(&minutes).Error
+	return minutes, err
+}
+
+// SumTeamPremiumMinutes sums the minutes of premium-room reservations made by members of
+// the department starting within the time range
+func (r *BookingQuotaRepository) SumTeamPremiumMinutes(department string, startTime, endTime time.Time) (float64, error) {
+	var minutes float64
+	err := r.db.Model(&models.Reservation{}).
+		Select("COALESCE(SUM(EXTRACT(EPOCH FROM (reservations.end_time - reservations.start_time)) / 60), 0)").
+		Joins("JOIN users ON users.id = reservations.user_id").
+		Joins("JOIN spaces ON spaces.id = reservations.space_id").
+		Where("users.department = ? AND spaces.is_premium = ?", department, true).
+		Where("reservations.status IN ? AND reservations.start_time >= ? AND reservations.start_time < ?",
+			quotaCountedStatuses, startTime, endTime).
+		Scan(&minutes).Error
+	return minutes, err
+}
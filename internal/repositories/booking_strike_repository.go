@@ -0,0 +1,101 @@
+// internal/repositories/booking_strike_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BookingStrikeRepository implements the BookingStrikeRepositoryInterface
+type BookingStrikeRepository struct {
+	db *gorm.DB
+}
+
+// NewBookingStrikeRepository creates a new booking strike repository
+func NewBookingStrikeRepository(db *gorm.DB) interfaces.BookingStrikeRepositoryInterface {
+	return &BookingStrikeRepository{db: db}
+}
+
+// ========================================
+// STRIKE OPERATIONS
+// ========================================
+
+// Create records a strike; a reservation that already earned one is left as is
+func (r *BookingStrikeRepository) Create(strike *models.BookingStrike) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "reservation_id"}},
+		DoNothing: true,
+	}).Create(strike).Error
+}
+
+// GetActiveByUser retrieves the user's uncleared strikes since the given time, newest first
+func (r *BookingStrikeRepository) GetActiveByUser(userID uuid.UUID, since time.Time) ([]*models.BookingStrike, error) {
+	var strikes []*models.BookingStrike
+	err := r.db.Preload("Reservation").
+		Where("user_id = ? AND cleared_at IS NULL AND created_at >= ?", userID, since).
+		Order("created_at DESC").
+		Find(&strikes).Error
+	return strikes, err
+}
+
+// GetActive retrieves every uncleared strike since the given time, newest first
+func (r *BookingStrikeRepository) GetActive(since time.Time, offset, limit int) ([]*models.BookingStrike, int64, error) {
+	var strikes []*models.BookingStrike
+	var total int64
+
+	query := r.db.Model(&models.BookingStrike{}).Where("cleared_at IS NULL AND created_at >= ?", since)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("User").Preload("Reservation").
+		Order("created_at DESC").
+		Offset(offset).Limit(limit).
+		Find(&strikes).Error
+	return strikes, total, err
+}
+
+// Clear stops an uncleared strike from counting
+func (r *BookingStrikeRepository) Clear(id, clearedByID uuid.UUID, at time.Time) error {
+	result := r.db.Model(&models.BookingStrike{}).
+		Where("id = ? AND cleared_at IS NULL", id).
+		Updates(map[string]interface{}{"cleared_at": at, "cleared_by_id": clearedByID})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ClearByUser stops every uncleared strike of the user from counting and returns how many were cleared
+func (r *BookingStrikeRepository) ClearByUser(userID, clearedByID uuid.UUID, at time.Time) (int64, error) {
+	result := r.db.Model(&models.BookingStrike{}).
+		Where("user_id = ? AND cleared_at IS NULL", userID).
+		Updates(map[string]interface{}{"cleared_at": at, "cleared_by_id": clearedByID})
+	return result.RowsAffected, result.Error
+}
+
+// ========================================
+// NO-SHOW DETECTION
+// ========================================
+
+// GetMissedCheckIns retrieves confirmed reservations started within the time range
+// that were never checked in nor reported as no-shows
+func (r *BookingStrikeRepository) GetMissedCheckIns(startedAfter, startedBefore time.Time, limit int) ([]*models.Reservation, error) {
+	var reservations []*models.Reservation
+	err := r.db.Preload("Space").
+		Where("status = ? AND check_in_time IS NULL AND no_show_reported = ?", models.StatusConfirmed, false).
+		Where("start_time >= ? AND start_time < ?", startedAfter, startedBefore).
+		Order("start_time ASC").
+		Limit(limit).
+		Find(&reservations).Error
+	return reservations, err
+}
@@ -0,0 +1,219 @@
+// internal/repositories/broadcast_channel_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BroadcastChannelRepository implements the BroadcastChannelRepositoryInterface
+type BroadcastChannelRepository struct {
+	db *gorm.DB
+}
+
+// NewBroadcastChannelRepository creates a new broadcast channel repository
+func NewBroadcastChannelRepository(db *gorm.DB) interfaces.BroadcastChannelRepositoryInterface {
+	return &BroadcastChannelRepository{db: db}
+}
+
+// ========================================
+// CHANNEL OPERATIONS
+// ========================================
+
+// CreateChannel creates a broadcast channel
+func (r *BroadcastChannelRepository) CreateChannel(channel *models.BroadcastChannel) error {
+	return r.db.Create(channel).Error
+}
+
+// GetChannelByID retrieves a broadcast channel by ID
+func (r *BroadcastChannelRepository) GetChannelByID(id uuid.UUID) (*models.BroadcastChannel, error) {
+	var channel models.BroadcastChannel
+	if err := r.db.Where("id = ?", id).First(&channel).Error; err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// ListChannels retrieves channels by name, archived ones only when asked
+func (r *BroadcastChannelRepository) ListChannels(includeArchived bool) ([]*models.BroadcastChannel, error) {
+	var channels []*models.BroadcastChannel
+	query := r.db.Model(&models.BroadcastChannel{})
+	if !includeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+	err := query.Order("name ASC").Find(&channels).Error
+	return channels, err
+}
+
+// UpdateChannel updates a broadcast channel
+func (r *BroadcastChannelRepository) UpdateChannel(id uuid.UUID, updates map[string]interface{}) (*models.BroadcastChannel, error) {
+	result := r.db.Model(&models.BroadcastChannel{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.GetChannelByID(id)
+}
+
+// ========================================
+// POST OPERATIONS
+// ========================================
+
+// CreatePost stores the post together with its recipients
+func (r *BroadcastChannelRepository) CreatePost(post *models.BroadcastPost, recipientIDs []uuid.UUID) (*models.BroadcastPost, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(post).Error; err != nil {
+			return err
+		}
+		if len(recipientIDs) == 0 {
+			return nil
+		}
+
+		recipients := make([]*models.BroadcastRecipient, len(recipientIDs))
+		for i, userID := range recipientIDs {
+			recipients[i] = &models.BroadcastRecipient{PostID: post.ID, UserID: userID}
+		}
+		return tx.CreateInBatches(recipients, 500).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetPostByID(post.ID)
+}
+
+// GetPostByID retrieves a broadcast post by ID with its channel and author
+func (r *BroadcastChannelRepository) GetPostByID(id uuid.UUID) (*models.BroadcastPost, error) {
+	var post models.BroadcastPost
+	err := r.db.Preload("Channel").Preload("Author").Where("id = ?", id).First(&post).Error
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// ListPosts retrieves the posts of a channel, most recent first
+func (r *BroadcastChannelRepository) ListPosts(channelID uuid.UUID, offset, limit int) ([]*models.BroadcastPost, int64, error) {
+	var posts []*models.BroadcastPost
+	var total int64
+
+	query := r.db.Model(&models.BroadcastPost{}).Where("channel_id = ?", channelID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Channel").Preload("Author").
+		Order("created_at DESC").Offset(offset).Limit(limit).Find(&posts).Error
+	return posts, total, err
+}
+
+// GetReadStats counts the reads of each of the posts
+func (r *BroadcastChannelRepository) GetReadStats(postIDs []uuid.UUID) (map[uuid.UUID]*models.BroadcastReadStats, error) {
+	stats := make(map[uuid.UUID]*models.BroadcastReadStats, len(postIDs))
+	if len(postIDs) == 0 {
+		return stats, nil
+	}
+
+	var rows []*models.BroadcastReadStats
+	err := r.db.Model(&models.BroadcastRecipient{}).
+		Select("post_id, COUNT(read_at) AS read_count, MAX(read_at) AS last_read_at").
+		Where("post_id IN ?", postIDs).
+		Group("post_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		stats[row.PostID] = row
+	}
+	return stats, nil
+}
+
+// ========================================
+// RECIPIENT OPERATIONS
+// ========================================
+
+// GetInbox retrieves the posts delivered to a user with their posts and channels, most recent
+// first, optionally only the unread ones or those of one channel
+func (r *BroadcastChannelRepository) GetInbox(userID uuid.UUID, channelID *uuid.UUID, unreadOnly bool, offset, limit int) ([]*models.BroadcastRecipient, int64, error) {
+	var recipients []*models.BroadcastRecipient
+	var total int64
+
+	query := r.db.Model(&models.BroadcastRecipient{}).
+		Joins("JOIN broadcast_posts ON broadcast_posts.id = broadcast_recipients.post_id").
+		Where("broadcast_recipients.user_id = ?", userID)
+	if channelID != nil {
+		query = query.Where("broadcast_posts.channel_id = ?", *channelID)
+	}
+	if unreadOnly {
+		query = query.Where("broadcast_recipients.read_at IS NULL")
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Post.Channel").Preload("Post.Author").
+		Order("broadcast_posts.created_at DESC").Offset(offset).Limit(limit).Find(&recipients).Error
+	return recipients, total, err
+}
+
+// GetRecipient retrieves the delivery of a post to a user
+func (r *BroadcastChannelRepository) GetRecipient(postID, userID uuid.UUID) (*models.BroadcastRecipient, error) {
+	var recipient models.BroadcastRecipient
+	err := r.db.Where("post_id = ? AND user_id = ?", postID, userID).First(&recipient).Error
+	if err != nil {
+		return nil, err
+	}
+	return &recipient, nil
+}
+
+// MarkRead records when a recipient read a post, keeping the first read
+func (r *BroadcastChannelRepository) MarkRead(recipientID uuid.UUID, readAt time.Time) error {
+	return r.db.Model(&models.BroadcastRecipient{}).
+		Where("id = ? AND read_at IS NULL", recipientID).
+		Update("read_at", readAt).Error
+}
+
+// ========================================
+// AUDIENCE OPERATIONS
+// ========================================
+
+// GetActiveUserIDs retrieves every active user
+func (r *BroadcastChannelRepository) GetActiveUserIDs() ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Model(&models.User{}).Where("is_active = ? AND anonymized_at IS NULL", true).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// GetBuildingUserIDs retrieves the owners and co-organizers of the reservations of a building
+// ending after the given time, cancelled ones excepted, and the managers of its spaces
+func (r *BroadcastChannelRepository) GetBuildingUserIDs(building string, since time.Time) ([]uuid.UUID, error) {
+	reservations := r.db.Model(&models.Reservation{}).Select("reservations.id").
+		Joins("JOIN spaces ON spaces.id = reservations.space_id").
+		Where("spaces.building = ? AND reservations.status <> ? AND reservations.end_time > ?",
+			building, models.StatusCancelled, since)
+
+	var ids []uuid.UUID
+	err := r.db.Raw(`SELECT id FROM users WHERE is_active AND anonymized_at IS NULL AND deleted_at IS NULL AND id IN (
+			SELECT user_id FROM reservations WHERE id IN (?)
+			UNION SELECT user_id FROM reservation_co_organizers WHERE reservation_id IN (?)
+			UNION SELECT manager_id FROM spaces WHERE building = ? AND manager_id IS NOT NULL AND deleted_at IS NULL)`,
+		reservations, reservations, building).
+		Scan(&ids).Error
+	return ids, err
+}
+
+// ManagesBuilding checks if the user manages a space of the building
+func (r *BroadcastChannelRepository) ManagesBuilding(userID uuid.UUID, building string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Space{}).Where("manager_id = ? AND building = ?", userID, building).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,139 @@
+// internal/repositories/building_closure_repository.go
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BuildingClosureRepository implements the BuildingClosureRepositoryInterface
+type BuildingClosureRepository struct {
+	db *gorm.DB
+}
+
+// NewBuildingClosureRepository creates a new building closure repository
+func NewBuildingClosureRepository(db *gorm.DB) interfaces.BuildingClosureRepositoryInterface {
+	return &BuildingClosureRepository{db: db}
+}
+
+// ========================================
+// CLOSURE OPERATIONS
+// ========================================
+
+// Create creates a new building closure
+func (r *BuildingClosureRepository) Create(closure *models.BuildingClosure) (*models.BuildingClosure, error) {
+	if err := r.db.Create(closure).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(closure.ID)
+}
+
+// GetByID retrieves a building closure by ID
+func (r *BuildingClosureRepository) GetByID(id uuid.UUID) (*models.BuildingClosure, error) {
+	var closure models.BuildingClosure
+	err := r.db.Preload("CreatedBy").Where("id = ?", id).First(&closure).Error
+	if err != nil {
+		return nil, err
+	}
+	return &closure, nil
+}
+
+// GetCurrentAndUpcoming retrieves the closures not ended and not over by the given day
+func (r *BuildingClosureRepository) GetCurrentAndUpcoming(from time.Time) ([]*models.BuildingClosure, error) {
+	var closures []*models.BuildingClosure
+	err := r.db.Where("ended_at IS NULL AND end_date >= ?", from.Format("2006-01-02")).
+		Order("start_date ASC, building ASC").
+		Find(&closures).Error
+	return closures, err
+}
+
+// GetOverlapping retrieves the closures of a building still running during part of the time range
+func (r *BuildingClosureRepository) GetOverlapping(building string, startTime, endTime time.Time) ([]*models.BuildingClosure, error) {
+	var closures []*models.BuildingClosure
+	err := r.db.Where("building = ? AND start_date <= ? AND end_date >= ? AND (ended_at IS NULL OR ended_at > ?)",
+		building, endTime.UTC().Format("2006-01-02"), startTime.UTC().Format("2006-01-02"), startTime).
+		Find(&closures).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Dates only narrow the search down to the day; the window settles bookings on the day a closure ended
+	overlapping := closures[:0]
+	for _, closure := range closures {
+		closedFrom, closedUntil := closure.Window()
+		if startTime.Before(closedUntil) && closedFrom.Before(endTime) {
+			overlapping = append(overlapping, closure)
+		}
+	}
+	return overlapping, nil
+}
+
+// End reopens the closed spaces from the given time and clears the flag of the reservations
+// that are no longer affected
+func (r *BuildingClosureRepository) End(id uuid.UUID, endedAt time.Time) (*models.BuildingClosure, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.BuildingClosure{}).Where("id = ?", id).Update("ended_at", endedAt).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Reservation{}).
+			Where("closure_id = ? AND start_time >= ?", id, endedAt).
+			Update("closure_id", nil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
+// ========================================
+// RESERVATION OPERATIONS
+// ========================================
+
+// FlagReservations marks the pending and confirmed reservations of the closed spaces during the
+// closure, and returns them with their organizers and space
+func (r *BuildingClosureRepository) FlagReservations(closure *models.BuildingClosure) ([]*models.Reservation, error) {
+	closedFrom, closedUntil := closure.Window()
+	query := r.db.Model(&models.Reservation{}).
+		Joins("JOIN spaces ON spaces.id = reservations.space_id").
+		Where("spaces.building = ? AND reservations.status IN ? AND reservations.start_time < ? AND reservations.end_time > ?",
+			closure.Building, []models.ReservationStatus{models.StatusPending, models.StatusConfirmed}, closedUntil, closedFrom)
+	if closure.Floor != nil {
+		query = query.Where("spaces.floor = ?", *closure.Floor)
+	}
+
+	var ids []uuid.UUID
+	if err := query.Pluck("reservations.id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	if err := r.db.Model(&models.Reservation{}).Where("id IN ?", ids).Update("closure_id", closure.ID).Error; err != nil {
+		return nil, err
+	}
+
+	var reservations []*models.Reservation
+	err := r.db.Preload("User").Preload("Space").Preload("CoOrganizers").
+		Where("id IN ?", ids).
+		Order("start_time ASC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// closedSpaceCondition matches the spaces, given by their table alias, closed by a building
+// closure during part of a time range given as two parameters, its end then its start
+func closedSpaceCondition(spaces string) string {
+	return fmt.Sprintf(`EXISTS (SELECT 1 FROM building_closures WHERE building_closures.building = %[1]s.building
+		AND (building_closures.floor IS NULL OR building_closures.floor = %[1]s.floor)
+		AND CAST(building_closures.start_date AS timestamp) AT TIME ZONE 'UTC' < ?
+		AND LEAST(CAST(building_closures.end_date + 1 AS timestamp) AT TIME ZONE 'UTC', COALESCE(building_closures.ended_at, 'infinity')) > ?)`, spaces)
+}
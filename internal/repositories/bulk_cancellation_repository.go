@@ -0,0 +1,43 @@
+// internal/repositories/bulk_cancellation_repository.go
+package repositories
+
+import (
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BulkCancellationRepository implements the BulkCancellationRepositoryInterface
+type BulkCancellationRepository struct {
+	db *gorm.DB
+}
+
+// NewBulkCancellationRepository creates a new bulk cancellation repository
+func NewBulkCancellationRepository(db *gorm.DB) interfaces.BulkCancellationRepositoryInterface {
+	return &BulkCancellationRepository{db: db}
+}
+
+// Create records a new bulk cancellation
+func (r *BulkCancellationRepository) Create(cancellation *models.BulkCancellation) (*models.BulkCancellation, error) {
+	if err := r.db.Create(cancellation).Error; err != nil {
+		return nil, err
+	}
+	return cancellation, nil
+}
+
+// GetByID retrieves a bulk cancellation with its results
+func (r *BulkCancellationRepository) GetByID(id uuid.UUID) (*models.BulkCancellation, error) {
+	var cancellation models.BulkCancellation
+	err := r.db.Preload("RequestedBy").Where("id = ?", id).First(&cancellation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &cancellation, nil
+}
+
+// Update updates the progress of a bulk cancellation
+func (r *BulkCancellationRepository) Update(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Model(&models.BulkCancellation{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,102 @@
+// internal/repositories/canned_response_repository.go
+package repositories
+
+import (
+	"context"
+	"log/slog"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CannedResponseRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+// NewCannedResponseRepository creates a new canned response repository instance
+func NewCannedResponseRepository(db *gorm.DB, logger *slog.Logger) *CannedResponseRepository {
+	return &CannedResponseRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Ensure CannedResponseRepository implements the interface
+var _ interfaces.CannedResponseRepository = (*CannedResponseRepository)(nil)
+
+func (r *CannedResponseRepository) Create(ctx context.Context, response *models.CannedResponse) error {
+	return r.db.WithContext(ctx).Create(response).Error
+}
+
+func (r *CannedResponseRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CannedResponse, error) {
+	var response models.CannedResponse
+	if err := r.db.WithContext(ctx).First(&response, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (r *CannedResponseRepository) GetByShortcut(ctx context.Context, shortcut string) (*models.CannedResponse, error) {
+	var response models.CannedResponse
+	if err := r.db.WithContext(ctx).First(&response, "shortcut = ?", shortcut).Error; err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (r *CannedResponseRepository) List(ctx context.Context, category, search string, activeOnly bool, limit, offset int) ([]models.CannedResponse, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.CannedResponse{})
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+	if search != "" {
+		pattern := "%" + search + "%"
+		query = query.Where("title ILIKE ? OR shortcut ILIKE ? OR content ILIKE ?", pattern, pattern, pattern)
+	}
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Most used first so agents find common answers quickly
+	var responses []models.CannedResponse
+	err := query.
+		Order("usage_count DESC, title ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&responses).Error
+
+	return responses, total, err
+}
+
+func (r *CannedResponseRepository) GetAutoReplies(ctx context.Context) ([]models.CannedResponse, error) {
+	var responses []models.CannedResponse
+	err := r.db.WithContext(ctx).
+		Where("auto_reply = ? AND is_active = ?", true, true).
+		Order("title ASC").
+		Find(&responses).Error
+	return responses, err
+}
+
+func (r *CannedResponseRepository) Update(ctx context.Context, response *models.CannedResponse) error {
+	return r.db.WithContext(ctx).Save(response).Error
+}
+
+func (r *CannedResponseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.CannedResponse{}, "id = ?", id).Error
+}
+
+func (r *CannedResponseRepository) IncrementUsage(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&models.CannedResponse{}).
+		Where("id = ?", id).
+		UpdateColumn("usage_count", gorm.Expr("usage_count + 1")).Error
+}
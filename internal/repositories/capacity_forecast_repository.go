@@ -0,0 +1,66 @@
+// internal/repositories/capacity_forecast_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"gorm.io/gorm"
+)
+
+// CapacityForecastRepository implements the CapacityForecastRepositoryInterface
+type CapacityForecastRepository struct {
+	db *gorm.DB
+}
+
+// NewCapacityForecastRepository creates a new capacity forecast repository
+func NewCapacityForecastRepository(db *gorm.DB) interfaces.CapacityForecastRepositoryInterface {
+	return &CapacityForecastRepository{db: db}
+}
+
+// GetDailyDemand sums, per building, space type and UTC day of the start, the participant
+// minutes of reservations with the statuses starting within the time range. Empty building
+// and space type match every space.
+func (r *CapacityForecastRepository) GetDailyDemand(startTime, endTime time.Time, statuses []models.ReservationStatus, building, spaceType string) ([]interfaces.DailyDemand, error) {
+	var demand []interfaces.DailyDemand
+
+	query := r.db.Model(&models.Reservation{}).
+		Select("spaces.building, spaces.type AS space_type, "+
+			"date_trunc('day', reservations.start_time AT TIME ZONE 'UTC') AS day, "+
+			"SUM(reservations.participant_count * EXTRACT(EPOCH FROM (reservations.end_time - reservations.start_time)) / 60) AS seat_minutes").
+		Joins("JOIN spaces ON spaces.id = reservations.space_id").
+		Where("reservations.status IN ? AND reservations.start_time >= ? AND reservations.start_time < ?", statuses, startTime, endTime)
+	if building != "" {
+		query = query.Where("spaces.building = ?", building)
+	}
+	if spaceType != "" {
+		query = query.Where("spaces.type = ?", spaceType)
+	}
+
+	err := query.Group("spaces.building, spaces.type, day").
+		Order("spaces.building ASC, spaces.type ASC, day ASC").
+		Scan(&demand).Error
+	return demand, err
+}
+
+// GetCapacity sums the capacity of bookable spaces per building and space type
+func (r *CapacityForecastRepository) GetCapacity(building, spaceType string) ([]interfaces.GroupCapacity, error) {
+	var capacity []interfaces.GroupCapacity
+
+	query := r.db.Model(&models.Space{}).
+		Select("building, type AS space_type, COUNT(*) AS spaces, SUM(capacity) AS capacity").
+		Where("status = ?", models.SpaceStatusAvailable)
+	if building != "" {
+		query = query.Where("building = ?", building)
+	}
+	if spaceType != "" {
+		query = query.Where("type = ?", spaceType)
+	}
+
+	err := query.Group("building, type").
+		Order("building ASC, type ASC").
+		Scan(&capacity).Error
+	return capacity, err
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ChatRepository struct {
@@ -31,6 +32,9 @@ func NewChatRepository(db *gorm.DB, logger *slog.Logger) *ChatRepository {
 
 var _ interfaces.ChatRepository = (*ChatRepository)(nil)
 
+// searchHeadlineOptions shapes the ts_headline snippets returned by full-text search
+const searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MinWords=10, MaxWords=30, MaxFragments=2, FragmentDelimiter=\" ... \""
+
 // Conversation operations
 
 func (r *ChatRepository) CreateConversation(ctx context.Context, conversation *models.Conversation) error {
@@ -43,6 +47,9 @@ func (r *ChatRepository) GetConversationByID(ctx context.Context, id uuid.UUID)
 		Preload("Participants").
 		Preload("Participants.User").
 		Preload("AssignedAgent").
+		Preload("Reservation").
+		Preload("Reservation.User").
+		Preload("Space").
 		First(&conversation, "id = ?", id).Error
 
 	if err != nil {
@@ -65,13 +72,16 @@ func (r *ChatRepository) GetConversationsByUserID(ctx context.Context, userID uu
 	}
 	if req.IsArchived != nil {
 		query = query.Where("conversations.is_archived = ?", *req.IsArchived)
+	} else if req.Search == "" {
+		// Archived conversations stay out of the default list but are still found by search
+		query = query.Where("conversations.is_archived = ?", false)
 	}
 	if req.Tag != "" {
 		query = query.Where("? = ANY(conversations.tags)", req.Tag)
 	}
 	if req.Search != "" {
 		search := "%" + req.Search + "%"
-		query = query.Where("conversations.title ILIKE ? OR EXISTS (SELECT 1 FROM messages m WHERE m.conversation_id = conversations.id AND m.content ILIKE ?)", search, search)
+		query = query.Where("conversations.title ILIKE ? OR EXISTS (SELECT 1 FROM messages m WHERE m.conversation_id = conversations.id AND NOT m.encrypted AND m.content ILIKE ?)", search, search)
 	}
 
 	// Count total records
@@ -89,14 +99,26 @@ func (r *ChatRepository) GetConversationsByUserID(ctx context.Context, userID uu
 	if req.SortOrder == "asc" {
 		sortOrder = "ASC"
 	}
-	query = query.Order(fmt.Sprintf("conversations.%s %s", sortBy, sortOrder))
+	query = query.Order(fmt.Sprintf("conversations.%s %s, conversations.id %s", sortBy, sortOrder, sortOrder))
 
 	// Apply pagination
 	limit := 20
 	if req.Limit > 0 {
 		limit = req.Limit
 	}
-	if req.Offset > 0 {
+	if req.Cursor != nil {
+		after, at, err := decodeTimeCursor(*req.Cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		if after != nil {
+			operator := "<"
+			if sortOrder == "ASC" {
+				operator = ">"
+			}
+			query = query.Where(fmt.Sprintf("(conversations.%s, conversations.id) %s (?, ?)", sortBy, operator), at, after.ID)
+		}
+	} else if req.Offset > 0 {
 		query = query.Offset(req.Offset)
 	}
 	query = query.Limit(limit)
@@ -107,25 +129,157 @@ func (r *ChatRepository) GetConversationsByUserID(ctx context.Context, userID uu
 		Preload("Participants").
 		Preload("Participants.User").
 		Preload("AssignedAgent").
+		Preload("Reservation").
+		Preload("Reservation.User").
+		Preload("Space").
 		Select("DISTINCT conversations.*").
 		Find(&conversations).Error
 
 	return conversations, total, err
 }
 
+// GetOpenReservationConversation returns the unresolved conversation the user opened about a
+// reservation, the most recent first
+func (r *ChatRepository) GetOpenReservationConversation(ctx context.Context, userID, reservationID uuid.UUID) (*models.Conversation, error) {
+	var conversation models.Conversation
+	err := r.db.WithContext(ctx).
+		Joins("JOIN conversation_participants cp ON conversations.id = cp.conversation_id").
+		Where("cp.user_id = ? AND cp.user_type = ?", userID, models.ParticipantTypeAdmin).
+		Where("conversations.reservation_id = ? AND conversations.attendee_chat = ? AND conversations.status <> ?", reservationID, false, models.ConversationStatusResolved).
+		Order("conversations.created_at DESC").
+		First(&conversation).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &conversation, nil
+}
+
+// GetAttendeeChat returns the attendee chat of a reservation with its participants
+func (r *ChatRepository) GetAttendeeChat(ctx context.Context, reservationID uuid.UUID) (*models.Conversation, error) {
+	var conversation models.Conversation
+	err := r.db.WithContext(ctx).
+		Preload("Participants").
+		Where("reservation_id = ? AND attendee_chat = ?", reservationID, true).
+		First(&conversation).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &conversation, nil
+}
+
+// ArchiveEndedAttendeeChats archives up to limit attendee chats of reservations that ended
+// before the given time, returning their IDs
+func (r *ChatRepository) ArchiveEndedAttendeeChats(ctx context.Context, endedBefore time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Conversation{}).
+			Joins("JOIN reservations ON reservations.id = conversations.reservation_id").
+			Where("conversations.attendee_chat = ? AND conversations.is_archived = ? AND reservations.end_time < ?", true, false, endedBefore).
+			Order("reservations.end_time ASC").
+			Limit(limit).
+			Pluck("conversations.id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		return tx.Model(&models.Conversation{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"is_archived":   true,
+				"archived_at":   time.Now(),
+				"auto_archived": false,
+			}).Error
+	})
+	return ids, err
+}
+
 func (r *ChatRepository) UpdateConversation(ctx context.Context, conversation *models.Conversation) error {
 	return r.db.WithContext(ctx).Save(conversation).Error
 }
 
+// UpdateConversationIfUnmodified saves a conversation only if its updated_at is still the one
+// given, returning gorm.ErrRecordNotFound when another write got there first
+func (r *ChatRepository) UpdateConversationIfUnmodified(ctx context.Context, conversation *models.Conversation, updatedAt time.Time) error {
+	// Unlike Save, Updates never falls back to an insert when no row matches
+	result := r.db.WithContext(ctx).Model(conversation).
+		Where("updated_at = ?", updatedAt.Truncate(time.Microsecond)).
+		Select("*").
+		Updates(conversation)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 func (r *ChatRepository) DeleteConversation(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&models.Conversation{}, "id = ?", id).Error
 }
 
 func (r *ChatRepository) ArchiveConversation(ctx context.Context, id uuid.UUID, isArchived bool) error {
+	var archivedAt *time.Time
+	if isArchived {
+		now := time.Now()
+		archivedAt = &now
+	}
+
 	return r.db.WithContext(ctx).
 		Model(&models.Conversation{}).
 		Where("id = ?", id).
-		Update("is_archived", isArchived).Error
+		Updates(map[string]interface{}{
+			"is_archived":   isArchived,
+			"archived_at":   archivedAt,
+			"auto_archived": false,
+		}).Error
+}
+
+// ArchiveInactiveConversations archives up to limit conversations with no messages since before
+func (r *ChatRepository) ArchiveInactiveConversations(ctx context.Context, before time.Time, limit int) (int64, error) {
+	batch := r.db.Model(&models.Conversation{}).
+		Select("id").
+		Where("is_archived = ? AND attendee_chat = ? AND last_message_at < ?", false, false, before).
+		Order("last_message_at ASC").
+		Limit(limit)
+
+	result := r.db.WithContext(ctx).
+		Model(&models.Conversation{}).
+		Where("id IN (?)", batch).
+		Updates(map[string]interface{}{
+			"is_archived":   true,
+			"archived_at":   time.Now(),
+			"auto_archived": true,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// UnarchiveConversations restores archived conversations to the default list
+func (r *ChatRepository) UnarchiveConversations(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Conversation{}).
+		Where("id IN ? AND is_archived = ?", ids, true).
+		Updates(map[string]interface{}{
+			"is_archived":   false,
+			"archived_at":   nil,
+			"auto_archived": false,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// GetInactiveConversations returns open conversations with no messages since before
+func (r *ChatRepository) GetInactiveConversations(ctx context.Context, before time.Time, limit int) ([]models.Conversation, error) {
+	var conversations []models.Conversation
+	err := r.db.WithContext(ctx).
+		Preload("Participants").
+		Where("status IN ? AND is_archived = ? AND attendee_chat = ? AND last_message_at < ?",
+			[]string{string(models.ConversationStatusActive), string(models.ConversationStatusPending)}, false, false, before).
+		Order("last_message_at ASC").
+		Limit(limit).
+		Find(&conversations).Error
+	return conversations, err
 }
 
 func (r *ChatRepository) GetConversationWithParticipants(ctx context.Context, id uuid.UUID) (*models.Conversation, error) {
@@ -137,6 +291,9 @@ func (r *ChatRepository) GetConversationWithParticipants(ctx context.Context, id
 			return db.Order("created_at DESC").Limit(1)
 		}).
 		Preload("AssignedAgent").
+		Preload("Reservation").
+		Preload("Reservation.User").
+		Preload("Space").
 		First(&conversation, "id = ?", id).Error
 
 	if err != nil {
@@ -186,6 +343,31 @@ func (r *ChatRepository) UpdateParticipantUnreadCount(ctx context.Context, conve
 		Update("unread_count", count).Error
 }
 
+// SetParticipantMute mutes or unmutes a conversation for one of its participants
+func (r *ChatRepository) SetParticipantMute(ctx context.Context, conversationID, userID uuid.UUID, muted bool, until *time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		Updates(map[string]interface{}{"muted": muted, "muted_until": until})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *ChatRepository) IsConversationMuted(ctx context.Context, userID, conversationID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id = ? AND muted", conversationID, userID).
+		Where("muted_until IS NULL OR muted_until > ?", time.Now()).
+		Count(&count).Error
+	return count > 0, err
+}
+
 func (r *ChatRepository) IsUserParticipant(ctx context.Context, userID, conversationID uuid.UUID) (bool, error) {
 	var count int64
 	err := r.db.WithContext(ctx).
@@ -204,6 +386,15 @@ func (r *ChatRepository) CreateMessage(ctx context.Context, message *models.Mess
 			return err
 		}
 
+		// Keep the parent's reply count in sync for thread previews
+		if message.ReplyToID != nil {
+			if err := tx.Model(&models.Message{}).
+				Where("id = ?", *message.ReplyToID).
+				UpdateColumn("reply_count", gorm.Expr("reply_count + 1")).Error; err != nil {
+				return err
+			}
+		}
+
 		// Update conversation last_message_at (handled by trigger, but we can do it here too for consistency)
 		if err := tx.Model(&models.Conversation{}).
 			Where("id = ?", message.ConversationID).
@@ -211,6 +402,17 @@ func (r *ChatRepository) CreateMessage(ctx context.Context, message *models.Mess
 			return err
 		}
 
+		// New activity brings back conversations archived for inactivity
+		if err := tx.Model(&models.Conversation{}).
+			Where("id = ? AND auto_archived = ?", message.ConversationID, true).
+			Updates(map[string]interface{}{
+				"is_archived":   false,
+				"archived_at":   nil,
+				"auto_archived": false,
+			}).Error; err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
@@ -222,6 +424,7 @@ func (r *ChatRepository) GetMessageByID(ctx context.Context, id uuid.UUID) (*mod
 		Preload("Attachments").
 		Preload("ReadReceipts").
 		Preload("ReadReceipts.User").
+		Preload("ReplyTo").
 		First(&message, "id = ?", id).Error
 
 	if err != nil {
@@ -264,7 +467,15 @@ func (r *ChatRepository) GetMessagesByConversationID(ctx context.Context, conver
 	if req.Limit > 0 {
 		limit = req.Limit
 	}
-	if req.Offset > 0 {
+	if req.Cursor != nil {
+		after, at, err := decodeTimeCursor(*req.Cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		if after != nil {
+			query = query.Where("(created_at, id) < (?, ?)", at, after.ID)
+		}
+	} else if req.Offset > 0 {
 		query = query.Offset(req.Offset)
 	}
 	query = query.Limit(limit)
@@ -274,7 +485,8 @@ func (r *ChatRepository) GetMessagesByConversationID(ctx context.Context, conver
 	err := query.
 		Preload("Sender").
 		Preload("Attachments").
-		Order("created_at DESC").
+		Preload("ReplyTo").
+		Order("created_at DESC, id DESC").
 		Find(&messages).Error
 
 	return messages, total, err
@@ -285,19 +497,34 @@ func (r *ChatRepository) UpdateMessage(ctx context.Context, message *models.Mess
 }
 
 func (r *ChatRepository) DeleteMessage(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.Message{}, "id = ?", id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var message models.Message
+		if err := tx.Select("id", "reply_to_id").First(&message, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&models.Message{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		// Replies to the deleted message are detached by the foreign key
+		if message.ReplyToID != nil {
+			return tx.Model(&models.Message{}).
+				Where("id = ? AND reply_count > 0", *message.ReplyToID).
+				UpdateColumn("reply_count", gorm.Expr("reply_count - 1")).Error
+		}
+
+		return nil
+	})
 }
 
-func (r *ChatRepository) SearchMessages(ctx context.Context, userID uuid.UUID, req *dto.SearchMessagesRequest) ([]models.Message, int64, error) {
+// SearchMessages runs a full-text search over the user's conversations, best matches first
+func (r *ChatRepository) SearchMessages(ctx context.Context, userID uuid.UUID, req *dto.SearchMessagesRequest) ([]models.MessageSearchHit, int64, error) {
 	query := r.db.WithContext(ctx).Model(&models.Message{}).
 		Joins("JOIN conversation_participants cp ON messages.conversation_id = cp.conversation_id").
-		Where("cp.user_id = ?", userID)
-
-	// Apply search query
-	if req.Query != "" {
-		search := "%" + req.Query + "%"
-		query = query.Where("messages.content ILIKE ?", search)
-	}
+		Where("cp.user_id = ?", userID).
+		Where("messages.search_vector @@ websearch_to_tsquery('english', ?)", req.Query).
+		Where("messages.encrypted = ?", false) // The server cannot read encrypted messages, so never indexes them
 
 	// Apply filters
 	if req.ConversationID != nil {
@@ -324,7 +551,7 @@ func (r *ChatRepository) SearchMessages(ctx context.Context, userID uuid.UUID, r
 
 	// Count total records
 	var total int64
-	if err := query.Count(&total).Error; err != nil {
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -333,22 +560,121 @@ func (r *ChatRepository) SearchMessages(ctx context.Context, userID uuid.UUID, r
 	if req.Limit > 0 {
 		limit = req.Limit
 	}
-	if req.Offset > 0 {
-		query = query.Offset(req.Offset)
+
+	// Rank and highlight the page of matches, then load them with their relations
+	var rows []struct {
+		ID      uuid.UUID
+		Rank    float64
+		Snippet string
+	}
+	err := query.
+		Select("messages.id, ts_rank_cd(messages.search_vector, websearch_to_tsquery('english', ?)) AS rank, "+
+			"ts_headline('english', messages.content, websearch_to_tsquery('english', ?), ?) AS snippet",
+			req.Query, req.Query, searchHeadlineOptions).
+		Order("rank DESC, messages.created_at DESC").
+		Offset(req.Offset).
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(rows) == 0 {
+		return []models.MessageSearchHit{}, total, nil
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
 	}
-	query = query.Limit(limit)
 
-	// Execute query with preloads
 	var messages []models.Message
-	err := query.
+	err = r.db.WithContext(ctx).
 		Preload("Sender").
 		Preload("Attachments").
 		Preload("Conversation").
-		Select("DISTINCT messages.*").
-		Order("messages.created_at DESC").
+		Preload("ReplyTo").
+		Where("id IN ?", ids).
+		Find(&messages).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	byID := make(map[uuid.UUID]models.Message, len(messages))
+	for _, message := range messages {
+		byID[message.ID] = message
+	}
+
+	hits := make([]models.MessageSearchHit, 0, len(rows))
+	for _, row := range rows {
+		message, ok := byID[row.ID]
+		if !ok {
+			continue
+		}
+		hits = append(hits, models.MessageSearchHit{
+			Message: message,
+			Rank:    row.Rank,
+			Snippet: row.Snippet,
+		})
+	}
+
+	return hits, total, nil
+}
+
+// GetMessagesForExport returns a conversation's messages with attachments, oldest first
+func (r *ChatRepository) GetMessagesForExport(ctx context.Context, conversationID uuid.UUID, limit int) ([]models.Message, error) {
+	var messages []models.Message
+	err := r.db.WithContext(ctx).
+		Preload("Attachments").
+		Where("conversation_id = ?", conversationID).
+		Order("created_at ASC").
+		Limit(limit).
 		Find(&messages).Error
+	return messages, err
+}
 
-	return messages, total, err
+// GetMessagesForCompliance returns the messages of a conversation or sent by a user, with
+// attachments, oldest first; ties on the timestamp are broken by ID so the order is stable
+func (r *ChatRepository) GetMessagesForCompliance(ctx context.Context, conversationID, senderID *uuid.UUID, from, to *time.Time, limit int) ([]models.Message, error) {
+	query := r.db.WithContext(ctx).Preload("Attachments")
+	if conversationID != nil {
+		query = query.Where("conversation_id = ?", *conversationID)
+	}
+	if senderID != nil {
+		query = query.Where("sender_id = ?", *senderID)
+	}
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	var messages []models.Message
+	err := query.Order("created_at ASC, id ASC").Limit(limit).Find(&messages).Error
+	return messages, err
+}
+
+// GetThreadReplies returns every message replying directly or indirectly to the root, oldest first
+func (r *ChatRepository) GetThreadReplies(ctx context.Context, rootMessageID uuid.UUID, limit int) ([]models.Message, error) {
+	var messages []models.Message
+	err := r.db.WithContext(ctx).
+		Preload("Sender").
+		Preload("Attachments").
+		Preload("ReadReceipts").
+		Preload("ReplyTo").
+		Where(`id IN (
+			WITH RECURSIVE thread AS (
+				SELECT id FROM messages WHERE reply_to_id = ?
+				UNION ALL
+				SELECT m.id FROM messages m JOIN thread t ON m.reply_to_id = t.id
+			)
+			SELECT id FROM thread
+		)`, rootMessageID).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&messages).Error
+
+	return messages, err
 }
 
 // Message attachment operations
@@ -369,6 +695,57 @@ func (r *ChatRepository) DeleteAttachment(ctx context.Context, id uuid.UUID) err
 	return r.db.WithContext(ctx).Delete(&models.MessageAttachment{}, "id = ?", id).Error
 }
 
+// Upload operations
+
+func (r *ChatRepository) CreateChatUpload(ctx context.Context, upload *models.ChatUpload) error {
+	return r.db.WithContext(ctx).Create(upload).Error
+}
+
+// GetChatUpload finds a file stored for the conversation by the name the server gave it
+func (r *ChatRepository) GetChatUpload(ctx context.Context, conversationID uuid.UUID, storedName string) (*models.ChatUpload, error) {
+	var upload models.ChatUpload
+	err := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND stored_name = ?", conversationID, storedName).
+		First(&upload).Error
+	if err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+func (r *ChatRepository) GetChatUploadsByScanStatus(ctx context.Context, status models.AttachmentScanStatus, limit int) ([]models.ChatUpload, error) {
+	var uploads []models.ChatUpload
+	err := r.db.WithContext(ctx).
+		Where("scan_status = ?", status).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&uploads).Error
+	return uploads, err
+}
+
+// ResolveChatUploadScan stores the scan result of an upload and copies it, with the file's
+// new URL, to every attachment of the upload
+func (r *ChatRepository) ResolveChatUploadScan(ctx context.Context, upload *models.ChatUpload) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(upload).Updates(map[string]interface{}{
+			"scan_status":    upload.ScanStatus,
+			"scan_signature": upload.ScanSignature,
+			"scanned_at":     upload.ScannedAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.MessageAttachment{}).
+			Where("upload_id = ?", upload.ID).
+			Updates(map[string]interface{}{
+				"scan_status":    upload.ScanStatus,
+				"scan_signature": upload.ScanSignature,
+				"scanned_at":     upload.ScannedAt,
+				"file_url":       upload.FileURL(),
+			}).Error
+	})
+}
+
 // Read receipt operations
 
 func (r *ChatRepository) MarkMessageAsRead(ctx context.Context, messageID, userID uuid.UUID) error {
@@ -411,6 +788,55 @@ func (r *ChatRepository) GetUnreadMessageIDs(ctx context.Context, conversationID
 	return messageIDs, err
 }
 
+// GetUnreadCountsByUser counts unread messages in every conversation the user takes part in
+func (r *ChatRepository) GetUnreadCountsByUser(ctx context.Context, userID uuid.UUID) ([]dto.ConversationUnreadCount, error) {
+	var counts []dto.ConversationUnreadCount
+	err := r.db.WithContext(ctx).
+		Model(&models.ConversationParticipant{}).
+		Select(`conversation_participants.conversation_id,
+			(SELECT COUNT(*) FROM messages
+				WHERE messages.conversation_id = conversation_participants.conversation_id
+				AND messages.sender_id != conversation_participants.user_id
+				AND NOT EXISTS (SELECT 1 FROM message_read_receipts mrr WHERE mrr.message_id = messages.id AND mrr.user_id = conversation_participants.user_id)
+			) AS unread_count`).
+		Where("conversation_participants.user_id = ?", userID).
+		Order("unread_count DESC, conversation_participants.conversation_id").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// GetMessagesSince returns messages newer than (after, afterID) across the user's
+// conversations, oldest first
+func (r *ChatRepository) GetMessagesSince(ctx context.Context, userID uuid.UUID, after time.Time, afterID uuid.UUID, limit int) ([]models.Message, error) {
+	var messages []models.Message
+	err := r.db.WithContext(ctx).
+		Joins("JOIN conversation_participants cp ON cp.conversation_id = messages.conversation_id AND cp.user_id = ?", userID).
+		Where("(messages.created_at, messages.id) > (?, ?)", after, afterID).
+		Preload("Sender").
+		Preload("Attachments").
+		Preload("ReplyTo").
+		Preload("ReadReceipts", "user_id = ?", userID).
+		Order("messages.created_at ASC, messages.id ASC").
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+// GetLatestMessageForUser returns the newest message in any of the user's conversations,
+// nil when there is none
+func (r *ChatRepository) GetLatestMessageForUser(ctx context.Context, userID uuid.UUID) (*models.Message, error) {
+	var messages []models.Message
+	err := r.db.WithContext(ctx).
+		Joins("JOIN conversation_participants cp ON cp.conversation_id = messages.conversation_id AND cp.user_id = ?", userID).
+		Order("messages.created_at DESC, messages.id DESC").
+		Limit(1).
+		Find(&messages).Error
+	if err != nil || len(messages) == 0 {
+		return nil, err
+	}
+	return &messages[0], nil
+}
+
 func (r *ChatRepository) MarkMultipleMessagesAsRead(ctx context.Context, messageIDs []uuid.UUID, userID uuid.UUID) error {
 	if len(messageIDs) == 0 {
 		return nil
@@ -431,6 +857,200 @@ func (r *ChatRepository) MarkMultipleMessagesAsRead(ctx context.Context, message
 	})
 }
 
+// Delivery receipt operations
+
+// MarkMessagesAsDelivered records delivery for messages the user did not send and
+// returns the IDs that were newly marked
+func (r *ChatRepository) MarkMessagesAsDelivered(ctx context.Context, messageIDs []uuid.UUID, userID uuid.UUID) ([]uuid.UUID, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+
+	var delivered []uuid.UUID
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var recipientMessageIDs []uuid.UUID
+		if err := tx.Model(&models.Message{}).
+			Where("id IN ? AND sender_id != ?", messageIDs, userID).
+			Pluck("id", &recipientMessageIDs).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, messageID := range recipientMessageIDs {
+			receipt := &models.MessageDeliveryReceipt{
+				MessageID:   messageID,
+				UserID:      userID,
+				DeliveredAt: now,
+			}
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(receipt)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				delivered = append(delivered, messageID)
+			}
+		}
+		return nil
+	})
+
+	return delivered, err
+}
+
+func (r *ChatRepository) GetDeliveryReceipts(ctx context.Context, messageID uuid.UUID) ([]models.MessageDeliveryReceipt, error) {
+	var receipts []models.MessageDeliveryReceipt
+	err := r.db.WithContext(ctx).
+		Where("message_id = ?", messageID).
+		Find(&receipts).Error
+	return receipts, err
+}
+
+// GetMessageDeliveryCounts aggregates delivery and read state per message. A read
+// receipt counts as delivery, so messages read before delivery tracking existed stay consistent.
+func (r *ChatRepository) GetMessageDeliveryCounts(ctx context.Context, messageIDs []uuid.UUID) (map[uuid.UUID]models.MessageDeliveryCounts, error) {
+	counts := make(map[uuid.UUID]models.MessageDeliveryCounts, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []models.MessageDeliveryCounts
+	err := r.db.WithContext(ctx).
+		Model(&models.Message{}).
+		Select(`messages.id AS message_id,
+			(SELECT COUNT(*) FROM conversation_participants cp
+				WHERE cp.conversation_id = messages.conversation_id AND cp.user_id != messages.sender_id) AS recipient_count,
+			(SELECT COUNT(*) FROM (
+				SELECT mdr.user_id FROM message_delivery_receipts mdr WHERE mdr.message_id = messages.id
+				UNION
+				SELECT mrr.user_id FROM message_read_receipts mrr WHERE mrr.message_id = messages.id
+			) seen WHERE seen.user_id != messages.sender_id) AS delivered_count,
+			(SELECT COUNT(*) FROM message_read_receipts mrr
+				WHERE mrr.message_id = messages.id AND mrr.user_id != messages.sender_id) AS read_count`).
+		Where("messages.id IN ?", messageIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.MessageID] = row
+	}
+	return counts, nil
+}
+
+// Device key operations
+
+// UpsertDeviceKeyBundle registers a device's keys, replacing the bundle it published before
+func (r *ChatRepository) UpsertDeviceKeyBundle(ctx context.Context, bundle *models.DeviceKeyBundle) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "device_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"identity_key", "signed_pre_key", "signed_pre_key_signature", "one_time_pre_keys", "updated_at",
+		}),
+	}).Create(bundle).Error
+}
+
+func (r *ChatRepository) GetDeviceKeyBundles(ctx context.Context, userID uuid.UUID) ([]models.DeviceKeyBundle, error) {
+	var bundles []models.DeviceKeyBundle
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&bundles).Error
+	return bundles, err
+}
+
+func (r *ChatRepository) HasDeviceKeyBundle(ctx context.Context, userID uuid.UUID, deviceID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.DeviceKeyBundle{}).
+		Where("user_id = ? AND device_id = ?", userID, deviceID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ChatRepository) DeleteDeviceKeyBundle(ctx context.Context, userID uuid.UUID, deviceID string) error {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND device_id = ?", userID, deviceID).
+		Delete(&models.DeviceKeyBundle{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ClaimDeviceKeyBundles returns the bundles of every device of the user, each with at most
+// one one-time pre-key, which is removed so no other session can use it
+func (r *ChatRepository) ClaimDeviceKeyBundles(ctx context.Context, userID uuid.UUID) ([]models.DeviceKeyBundle, error) {
+	var bundles []models.DeviceKeyBundle
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", userID).
+			Order("created_at").
+			Find(&bundles).Error; err != nil {
+			return err
+		}
+
+		for i := range bundles {
+			if len(bundles[i].OneTimePreKeys) == 0 {
+				continue
+			}
+
+			claimed := bundles[i].OneTimePreKeys[0]
+			remaining := bundles[i].OneTimePreKeys[1:]
+			if err := tx.Model(&models.DeviceKeyBundle{}).
+				Where("id = ?", bundles[i].ID).
+				UpdateColumn("one_time_pre_keys", remaining).Error; err != nil {
+				return err
+			}
+			bundles[i].OneTimePreKeys = []string{claimed}
+		}
+		return nil
+	})
+
+	return bundles, err
+}
+
+// User block operations
+
+func (r *ChatRepository) CreateUserBlock(ctx context.Context, block *models.UserBlock) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(block).Error
+}
+
+func (r *ChatRepository) DeleteUserBlock(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).
+		Delete(&models.UserBlock{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *ChatRepository) GetUserBlocks(ctx context.Context, blockerID uuid.UUID) ([]models.UserBlock, error) {
+	var blocks []models.UserBlock
+	err := r.db.WithContext(ctx).
+		Preload("Blocked").
+		Where("blocker_id = ?", blockerID).
+		Order("created_at DESC").
+		Find(&blocks).Error
+	return blocks, err
+}
+
+// IsBlockedBetween reports whether either user blocked the other
+func (r *ChatRepository) IsBlockedBetween(ctx context.Context, userID, otherUserID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.UserBlock{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", userID, otherUserID, otherUserID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
 // Support agent operations
 
 func (r *ChatRepository) CreateSupportAgent(ctx context.Context, agent *models.SupportAgent) error {
@@ -549,11 +1169,15 @@ func (r *ChatRepository) GetConversationStats(ctx context.Context, req *dto.Conv
 			COUNT(*) as total,
 			COUNT(CASE WHEN status = 'active' THEN 1 END) as active,
 			COUNT(CASE WHEN status = 'resolved' THEN 1 END) as resolved,
-			COUNT(CASE WHEN status = 'pending' THEN 1 END) as pending
+			COUNT(CASE WHEN status = 'pending' THEN 1 END) as pending,
+			COUNT(CASE WHEN auto_resolved THEN 1 END) as auto_resolved,
+			COUNT(CASE WHEN reopen_count > 0 THEN 1 END) as reopened,
+			COALESCE(SUM(reopen_count), 0) as total_reopens
 		FROM conversations %s`, whereClause)
 
 	row := r.db.WithContext(ctx).Raw(query, args...).Row()
-	err := row.Scan(&stats.TotalConversations, &stats.ActiveConversations, &stats.ResolvedConversations, &stats.PendingConversations)
+	err := row.Scan(&stats.TotalConversations, &stats.ActiveConversations, &stats.ResolvedConversations, &stats.PendingConversations,
+		&stats.AutoResolved, &stats.ReopenedConversations, &stats.TotalReopens)
 	if err != nil {
 		return nil, err
 	}
@@ -619,11 +1243,43 @@ func (r *ChatRepository) GetDailyMessageCounts(ctx context.Context, startDate, e
 
 // Utility operations
 
-func (r *ChatRepository) CleanupOldMessages(ctx context.Context, retentionDays int) error {
-	cutoffDate := time.Now().AddDate(0, 0, -retentionDays)
-	return r.db.WithContext(ctx).
-		Where("created_at < ?", cutoffDate).
-		Delete(&models.Message{}).Error
+// CleanupOldMessages deletes messages created before the cutoff in conversations of the
+// given priority, returning the URLs of the removed attachments and the message count
+func (r *ChatRepository) CleanupOldMessages(ctx context.Context, priority models.ConversationPriority, before time.Time) ([]string, int64, error) {
+	var fileURLs []string
+	var deleted int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		expired := func() *gorm.DB {
+			return tx.Model(&models.Message{}).
+				Select("messages.id").
+				Joins("JOIN conversations ON conversations.id = messages.conversation_id").
+				Where("conversations.priority = ? AND messages.created_at < ?", priority, before)
+		}
+
+		if err := tx.Model(&models.MessageAttachment{}).
+			Where("message_id IN (?)", expired()).
+			Pluck("file_url", &fileURLs).Error; err != nil {
+			return err
+		}
+
+		// Attachments and receipts reference messages without cascading deletes
+		if err := tx.Where("message_id IN (?)", expired()).Delete(&models.MessageAttachment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("message_id IN (?)", expired()).Delete(&models.MessageReadReceipt{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("message_id IN (?)", expired()).Delete(&models.MessageDeliveryReceipt{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id IN (?)", expired()).Delete(&models.Message{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+
+	return fileURLs, deleted, err
 }
 
 func (r *ChatRepository) GetConversationParticipantIDs(ctx context.Context, conversationID uuid.UUID) ([]uuid.UUID, error) {
@@ -643,3 +1299,16 @@ func (r *ChatRepository) BulkUpdateUnreadCounts(ctx context.Context, conversatio
 		WHERE conversation_id = ? AND user_id != ?`,
 		conversationID, excludeUserID).Error
 }
+
+// decodeTimeCursor reads a cursor over a timestamp column, nil for the first page
+func decodeTimeCursor(value string) (*dto.Cursor, time.Time, error) {
+	cursor, err := dto.DecodeCursor(value)
+	if err != nil || cursor == nil {
+		return nil, time.Time{}, err
+	}
+	at, err := cursor.Time()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return cursor, at, nil
+}
@@ -0,0 +1,79 @@
+// internal/repositories/check_in_policy_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CheckInPolicyRepository implements the CheckInPolicyRepositoryInterface
+type CheckInPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewCheckInPolicyRepository creates a new check-in policy repository
+func NewCheckInPolicyRepository(db *gorm.DB) interfaces.CheckInPolicyRepositoryInterface {
+	return &CheckInPolicyRepository{db: db}
+}
+
+// GetAll retrieves the check-in policy of every space type that has one
+func (r *CheckInPolicyRepository) GetAll() ([]*models.CheckInPolicy, error) {
+	var policies []*models.CheckInPolicy
+	err := r.db.Order("space_type ASC").Find(&policies).Error
+	return policies, err
+}
+
+// GetBySpaceType retrieves the check-in policy of a space type
+func (r *CheckInPolicyRepository) GetBySpaceType(spaceType models.SpaceType) (*models.CheckInPolicy, error) {
+	var policy models.CheckInPolicy
+	err := r.db.Where("space_type = ?", spaceType).First(&policy).Error
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Save creates or replaces the check-in policy of a space type
+func (r *CheckInPolicyRepository) Save(policy *models.CheckInPolicy) (*models.CheckInPolicy, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "space_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"window_minutes", "enabled", "updated_by_id", "updated_at"}),
+	}).Create(policy).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.GetBySpaceType(policy.SpaceType)
+}
+
+// Delete removes the check-in policy of a space type
+func (r *CheckInPolicyRepository) Delete(spaceType models.SpaceType) error {
+	result := r.db.Where("space_type = ?", spaceType).Delete(&models.CheckInPolicy{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetMissedCheckIns retrieves confirmed reservations of spaces of the type started within the
+// time range that were never checked in. Reservations suspended by an emergency are left alone.
+func (r *CheckInPolicyRepository) GetMissedCheckIns(spaceType models.SpaceType, startedAfter, startedBefore time.Time, limit int) ([]*models.Reservation, error) {
+	var reservations []*models.Reservation
+	err := r.db.Preload("Space").
+		Preload("CoOrganizers").
+		Joins("JOIN spaces ON spaces.id = reservations.space_id").
+		Where("spaces.type = ?", spaceType).
+		Where("reservations.status = ? AND reservations.check_in_time IS NULL AND reservations.suspended_by_emergency_id IS NULL", models.StatusConfirmed).
+		Where("reservations.start_time >= ? AND reservations.start_time < ?", startedAfter, startedBefore).
+		Order("reservations.start_time ASC").
+		Limit(limit).
+		Find(&reservations).Error
+	return reservations, err
+}
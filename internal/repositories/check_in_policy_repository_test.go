@@ -0,0 +1,74 @@
+// internal/repositories/check_in_policy_repository_test.go
+package repositories
+
+import (
+	"testing"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestCheckInPolicySaveDisabled(t *testing.T) {
+	db, fake := newFakeDB(t)
+	// Databases migrated before enabled lost its default still carry it
+	fake.setDefault("check_in_policies", "enabled", true)
+	repo := NewCheckInPolicyRepository(db)
+
+	saved, err := repo.Save(&models.CheckInPolicy{
+		SpaceType:     models.SpaceTypeHotDesk,
+		WindowMinutes: 15,
+		Enabled:       false,
+		UpdatedByID:   uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if saved.Enabled {
+		t.Fatal("Save() returned an enabled policy, want disabled")
+	}
+
+	policy, err := repo.GetBySpaceType(models.SpaceTypeHotDesk)
+	if err != nil {
+		t.Fatalf("GetBySpaceType() error = %v", err)
+	}
+	if policy.Enabled {
+		t.Error("GetBySpaceType() returned an enabled policy, want disabled")
+	}
+}
+
+func TestCheckInPolicySaveDisablesExisting(t *testing.T) {
+	db, fake := newFakeDB(t)
+	fake.setDefault("check_in_policies", "enabled", true)
+	repo := NewCheckInPolicyRepository(db)
+
+	policy := models.CheckInPolicy{
+		SpaceType:     models.SpaceTypeHotDesk,
+		WindowMinutes: 15,
+		Enabled:       true,
+		UpdatedByID:   uuid.New(),
+	}
+	if _, err := repo.Save(&policy); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// The admin endpoint replaces the policy with a new record
+	disabled := policy
+	disabled.ID = uuid.Nil
+	disabled.Enabled = false
+	disabled.WindowMinutes = 30
+	if _, err := repo.Save(&disabled); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := repo.GetBySpaceType(models.SpaceTypeHotDesk)
+	if err != nil {
+		t.Fatalf("GetBySpaceType() error = %v", err)
+	}
+	if got.Enabled {
+		t.Error("GetBySpaceType() returned an enabled policy, want disabled")
+	}
+	if got.WindowMinutes != 30 {
+		t.Errorf("GetBySpaceType() window = %d, want 30", got.WindowMinutes)
+	}
+}
@@ -0,0 +1,87 @@
+// internal/repositories/check_in_questionnaire_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CheckInQuestionnaireRepository implements the CheckInQuestionnaireRepositoryInterface
+type CheckInQuestionnaireRepository struct {
+	db *gorm.DB
+}
+
+// NewCheckInQuestionnaireRepository creates a new check-in questionnaire repository
+func NewCheckInQuestionnaireRepository(db *gorm.DB) interfaces.CheckInQuestionnaireRepositoryInterface {
+	return &CheckInQuestionnaireRepository{db: db}
+}
+
+// ========================================
+// QUESTIONNAIRES
+// ========================================
+
+// Create creates a new questionnaire
+func (r *CheckInQuestionnaireRepository) Create(questionnaire *models.CheckInQuestionnaire) (*models.CheckInQuestionnaire, error) {
+	if err := r.db.Create(questionnaire).Error; err != nil {
+		return nil, err
+	}
+	return questionnaire, nil
+}
+
+// GetBySpace retrieves the questionnaire of a space
+func (r *CheckInQuestionnaireRepository) GetBySpace(spaceID uuid.UUID) (*models.CheckInQuestionnaire, error) {
+	var questionnaire models.CheckInQuestionnaire
+	err := r.db.Where("space_id = ?", spaceID).First(&questionnaire).Error
+	if err != nil {
+		return nil, err
+	}
+	return &questionnaire, nil
+}
+
+// Update updates a questionnaire
+func (r *CheckInQuestionnaireRepository) Update(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Model(&models.CheckInQuestionnaire{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// Delete removes a questionnaire; submissions are kept for compliance
+func (r *CheckInQuestionnaireRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.CheckInQuestionnaire{}, "id = ?", id).Error
+}
+
+// ========================================
+// SUBMISSIONS
+// ========================================
+
+// CreateSubmission stores the answers for a reservation
+func (r *CheckInQuestionnaireRepository) CreateSubmission(submission *models.QuestionnaireSubmission) (*models.QuestionnaireSubmission, error) {
+	if err := r.db.Create(submission).Error; err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+// GetSubmissionByReservation retrieves the answers submitted for a reservation
+func (r *CheckInQuestionnaireRepository) GetSubmissionByReservation(reservationID uuid.UUID) (*models.QuestionnaireSubmission, error) {
+	var submission models.QuestionnaireSubmission
+	err := r.db.Where("reservation_id = ?", reservationID).First(&submission).Error
+	if err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// GetSubmissionsBySpace retrieves the submissions for reservations of a space starting in a period
+func (r *CheckInQuestionnaireRepository) GetSubmissionsBySpace(spaceID uuid.UUID, from, to time.Time) ([]*models.QuestionnaireSubmission, error) {
+	var submissions []*models.QuestionnaireSubmission
+	err := r.db.Preload("Reservation").Preload("User").
+		Joins("JOIN reservations ON reservations.id = questionnaire_submissions.reservation_id").
+		Where("reservations.space_id = ? AND reservations.start_time >= ? AND reservations.start_time < ?", spaceID, from, to).
+		Order("questionnaire_submissions.submitted_at ASC").
+		Find(&submissions).Error
+	return submissions, err
+}
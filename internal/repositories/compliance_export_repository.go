@@ -0,0 +1,59 @@
+// internal/repositories/compliance_export_repository.go
+package repositories
+
+import (
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ComplianceExportRepository implements the ComplianceExportRepositoryInterface
+type ComplianceExportRepository struct {
+	db *gorm.DB
+}
+
+// NewComplianceExportRepository creates a new compliance export repository
+func NewComplianceExportRepository(db *gorm.DB) interfaces.ComplianceExportRepositoryInterface {
+	return &ComplianceExportRepository{db: db}
+}
+
+// Create records an issued compliance export
+func (r *ComplianceExportRepository) Create(export *models.ComplianceExport) (*models.ComplianceExport, error) {
+	if err := r.db.Create(export).Error; err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// GetByID retrieves a compliance export record
+func (r *ComplianceExportRepository) GetByID(id uuid.UUID) (*models.ComplianceExport, error) {
+	var export models.ComplianceExport
+	err := r.db.Preload("RequestedBy").Where("id = ?", id).First(&export).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// GetAll lists the exports, newest first, optionally for one case
+func (r *ComplianceExportRepository) GetAll(caseReference string, offset, limit int) ([]*models.ComplianceExport, int64, error) {
+	query := r.db.Model(&models.ComplianceExport{})
+	if caseReference != "" {
+		query = query.Where("case_reference = ?", caseReference)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var exports []*models.ComplianceExport
+	err := query.Preload("RequestedBy").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&exports).Error
+	return exports, total, err
+}
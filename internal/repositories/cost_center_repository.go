@@ -0,0 +1,65 @@
+// internal/repositories/cost_center_repository.go
+package repositories
+
+import (
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CostCenterRepository implements the CostCenterRepositoryInterface
+type CostCenterRepository struct {
+	db *gorm.DB
+}
+
+// NewCostCenterRepository creates a new cost center repository
+func NewCostCenterRepository(db *gorm.DB) interfaces.CostCenterRepositoryInterface {
+	return &CostCenterRepository{db: db}
+}
+
+// GetAll retrieves the cost centers by code, optionally only the active ones
+func (r *CostCenterRepository) GetAll(activeOnly bool) ([]*models.CostCenter, error) {
+	var costCenters []*models.CostCenter
+	query := r.db.Order("code ASC")
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Find(&costCenters).Error
+	return costCenters, err
+}
+
+// GetByCode retrieves a cost center by its code
+func (r *CostCenterRepository) GetByCode(code string) (*models.CostCenter, error) {
+	var costCenter models.CostCenter
+	err := r.db.Where("code = ?", code).First(&costCenter).Error
+	if err != nil {
+		return nil, err
+	}
+	return &costCenter, nil
+}
+
+// Save creates the cost center or replaces its name and status
+func (r *CostCenterRepository) Save(costCenter *models.CostCenter) (*models.CostCenter, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "is_active", "updated_by_id", "updated_at"}),
+	}).Create(costCenter).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByCode(costCenter.Code)
+}
+
+// Delete removes a cost center from the list
+func (r *CostCenterRepository) Delete(code string) error {
+	result := r.db.Where("code = ?", code).Delete(&models.CostCenter{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// internal/repositories/data_quality_repository.go
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DataQualityRepository implements the DataQualityRepositoryInterface
+type DataQualityRepository struct {
+	db *gorm.DB
+}
+
+// NewDataQualityRepository creates a new data quality repository
+func NewDataQualityRepository(db *gorm.DB) interfaces.DataQualityRepositoryInterface {
+	return &DataQualityRepository{db: db}
+}
+
+// ========================================
+// CHECKS
+// ========================================
+
+// FindCheckInsWithoutConfirmation finds reservations checked in while never confirmed
+func (r *DataQualityRepository) FindCheckInsWithoutConfirmation(limit int) ([]*models.Reservation, error) {
+	var reservations []*models.Reservation
+	err := r.db.Where("check_in_time IS NOT NULL AND status NOT IN ?",
+		[]models.ReservationStatus{models.StatusConfirmed, models.StatusCompleted}).
+		Order("check_in_time DESC").
+		Limit(limit).
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// FindOverlappingConfirmed finds confirmed reservations, not yet ended, that share their
+// space and time with an earlier confirmed one. A booking overlapping several others is
+// returned once per overlap.
+func (r *DataQualityRepository) FindOverlappingConfirmed(endedAfter time.Time, limit int) ([]interfaces.ReservationOverlap, error) {
+	var overlaps []interfaces.ReservationOverlap
+	err := r.db.Raw(`
+		SELECT a.id AS reservation_id, b.id AS other_id, a.space_id, a.start_time, a.end_time
+		FROM reservations a
+		JOIN reservations b ON b.space_id = a.space_id AND b.id <> a.id
+			AND b.start_time < a.end_time AND b.end_time > a.start_time
+			AND (b.created_at < a.created_at OR (b.created_at = a.created_at AND b.id < a.id))
+		WHERE a.status = ? AND b.status = ?
+			AND a.deleted_at IS NULL AND b.deleted_at IS NULL
+			AND a.end_time > ?
+		ORDER BY a.start_time
+		LIMIT ?`,
+		models.StatusConfirmed, models.StatusConfirmed, endedAfter, limit).
+		Scan(&overlaps).Error
+	return overlaps, err
+}
+
+// FindOrphanedParticipants finds co-organizers and guests left on deleted reservations, and
+// co-organizers whose user was deleted
+func (r *DataQualityRepository) FindOrphanedParticipants(limit int) ([]interfaces.OrphanedParticipant, error) {
+	var orphans []interfaces.OrphanedParticipant
+	err := r.db.Raw(`
+		SELECT co.id, ? AS kind, co.reservation_id,
+			CASE WHEN res.id IS NULL OR res.deleted_at IS NOT NULL THEN 'reservation deleted' ELSE 'user deleted' END AS reason
+		FROM reservation_co_organizers co
+		LEFT JOIN reservations res ON res.id = co.reservation_id
+		LEFT JOIN users u ON u.id = co.user_id
+		WHERE res.id IS NULL OR res.deleted_at IS NOT NULL OR u.id IS NULL OR u.deleted_at IS NOT NULL
+		UNION ALL
+		SELECT g.id, ? AS kind, g.reservation_id, 'reservation deleted' AS reason
+		FROM reservation_guests g
+		LEFT JOIN reservations res ON res.id = g.reservation_id
+		WHERE res.id IS NULL OR res.deleted_at IS NOT NULL
+		LIMIT ?`,
+		interfaces.ParticipantKindCoOrganizer, interfaces.ParticipantKindGuest, limit).
+		Scan(&orphans).Error
+	return orphans, err
+}
+
+// FindReservationsOnDeletedSpaces finds pending or confirmed reservations, not yet ended,
+// whose space was deleted
+func (r *DataQualityRepository) FindReservationsOnDeletedSpaces(endedAfter time.Time, limit int) ([]*models.Reservation, error) {
+	var reservations []*models.Reservation
+	err := r.db.
+		Joins("LEFT JOIN spaces ON spaces.id = reservations.space_id").
+		Where("reservations.status IN ? AND reservations.end_time > ?",
+			[]models.ReservationStatus{models.StatusPending, models.StatusConfirmed}, endedAfter).
+		Where("spaces.id IS NULL OR spaces.deleted_at IS NOT NULL").
+		Order("reservations.start_time").
+		Limit(limit).
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// ========================================
+// FIXES
+// ========================================
+
+// ClearCheckIn removes the check-in and check-out of a reservation
+func (r *DataQualityRepository) ClearCheckIn(reservationID uuid.UUID) error {
+	result := r.db.Model(&models.Reservation{}).Where("id = ?", reservationID).
+		Updates(map[string]interface{}{
+			"check_in_time":  nil,
+			"check_out_time": nil,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteParticipant deletes a co-organizer or guest row
+func (r *DataQualityRepository) DeleteParticipant(kind string, id uuid.UUID) error {
+	var model interface{}
+	switch kind {
+	case interfaces.ParticipantKindCoOrganizer:
+		model = &models.ReservationCoOrganizer{}
+	case interfaces.ParticipantKindGuest:
+		model = &models.ReservationGuest{}
+	default:
+		return fmt.Errorf("unknown participant kind %s", kind)
+	}
+
+	result := r.db.Where("id = ?", id).Delete(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
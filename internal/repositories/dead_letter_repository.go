@@ -0,0 +1,116 @@
+// internal/repositories/dead_letter_repository.go
+package repositories
+
+import (
+	"errors"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeadLetterRepository implements the DeadLetterRepositoryInterface
+type DeadLetterRepository struct {
+	db *gorm.DB
+}
+
+// NewDeadLetterRepository creates a new dead letter repository
+func NewDeadLetterRepository(db *gorm.DB) interfaces.DeadLetterRepositoryInterface {
+	return &DeadLetterRepository{db: db}
+}
+
+// Record stores failed work, or counts another failure on its open dead letter. Work is the
+// same when it has the same reference or, for jobs, the same name.
+func (r *DeadLetterRepository) Record(letter *models.DeadLetter) (*models.DeadLetter, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("source = ? AND status = ?", letter.Source, models.DeadLetterStatusOpen)
+		if letter.ReferenceID != nil {
+			query = query.Where("reference_id = ?", *letter.ReferenceID)
+		} else {
+			query = query.Where("reference_id IS NULL AND name = ?", letter.Name)
+		}
+
+		var existing models.DeadLetter
+		err := query.First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			letter.Status = models.DeadLetterStatusOpen
+			letter.Failures = 1
+			return tx.Create(letter).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&existing).Updates(map[string]interface{}{
+			"last_error": letter.LastError,
+			"failures":   gorm.Expr("failures + 1"),
+		}).Error; err != nil {
+			return err
+		}
+		*letter = existing
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return letter, nil
+}
+
+// GetByID retrieves a dead letter by ID
+func (r *DeadLetterRepository) GetByID(id uuid.UUID) (*models.DeadLetter, error) {
+	var letter models.DeadLetter
+	err := r.db.Preload("ResolvedBy").Where("id = ?", id).First(&letter).Error
+	if err != nil {
+		return nil, err
+	}
+	return &letter, nil
+}
+
+// GetAll lists dead letters newest first, filtered by source and status when set
+func (r *DeadLetterRepository) GetAll(source models.DeadLetterSource, status models.DeadLetterStatus, offset, limit int) ([]*models.DeadLetter, int64, error) {
+	query := r.db.Model(&models.DeadLetter{})
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var letters []*models.DeadLetter
+	err := query.Order("updated_at DESC").Offset(offset).Limit(limit).Find(&letters).Error
+	return letters, total, err
+}
+
+// GetOpen lists open dead letters, oldest first, limited to the IDs or source when set
+func (r *DeadLetterRepository) GetOpen(ids []uuid.UUID, source models.DeadLetterSource, limit int) ([]*models.DeadLetter, error) {
+	query := r.db.Where("status = ?", models.DeadLetterStatusOpen)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+
+	var letters []*models.DeadLetter
+	err := query.Order("created_at ASC").Limit(limit).Find(&letters).Error
+	return letters, err
+}
+
+// Update updates a dead letter
+func (r *DeadLetterRepository) Update(id uuid.UUID, updates map[string]interface{}) error {
+	result := r.db.Model(&models.DeadLetter{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
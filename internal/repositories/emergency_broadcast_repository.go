@@ -0,0 +1,176 @@
+// internal/repositories/emergency_broadcast_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmergencyBroadcastRepository implements the EmergencyBroadcastRepositoryInterface
+type EmergencyBroadcastRepository struct {
+	db *gorm.DB
+}
+
+// NewEmergencyBroadcastRepository creates a new emergency broadcast repository
+func NewEmergencyBroadcastRepository(db *gorm.DB) interfaces.EmergencyBroadcastRepositoryInterface {
+	return &EmergencyBroadcastRepository{db: db}
+}
+
+// ========================================
+// BROADCAST OPERATIONS
+// ========================================
+
+// Create stores the broadcast together with its recipients
+func (r *EmergencyBroadcastRepository) Create(broadcast *models.EmergencyBroadcast, recipientIDs []uuid.UUID) (*models.EmergencyBroadcast, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(broadcast).Error; err != nil {
+			return err
+		}
+		if len(recipientIDs) == 0 {
+			return nil
+		}
+
+		recipients := make([]*models.EmergencyRecipient, len(recipientIDs))
+		for i, userID := range recipientIDs {
+			recipients[i] = &models.EmergencyRecipient{BroadcastID: broadcast.ID, UserID: userID}
+		}
+		return tx.CreateInBatches(recipients, 500).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(broadcast.ID)
+}
+
+// GetByID retrieves an emergency broadcast by ID
+func (r *EmergencyBroadcastRepository) GetByID(id uuid.UUID) (*models.EmergencyBroadcast, error) {
+	var broadcast models.EmergencyBroadcast
+	err := r.db.Preload("CreatedBy").Where("id = ?", id).First(&broadcast).Error
+	if err != nil {
+		return nil, err
+	}
+	return &broadcast, nil
+}
+
+// List retrieves emergency broadcasts, most recent first
+func (r *EmergencyBroadcastRepository) List(offset, limit int) ([]*models.EmergencyBroadcast, int64, error) {
+	var broadcasts []*models.EmergencyBroadcast
+	var total int64
+
+	query := r.db.Model(&models.EmergencyBroadcast{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&broadcasts).Error
+	return broadcasts, total, err
+}
+
+// End records the all-clear and resumes the check-ins the broadcast suspended
+func (r *EmergencyBroadcastRepository) End(id uuid.UUID, endedAt time.Time) (*models.EmergencyBroadcast, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.EmergencyBroadcast{}).Where("id = ?", id).Update("ended_at", endedAt).Error; err != nil {
+			return err
+		}
+
+		var suspended []uuid.UUID
+		if err := tx.Model(&models.Reservation{}).Where("suspended_by_emergency_id = ?", id).Pluck("id", &suspended).Error; err != nil {
+			return err
+		}
+		return updateSuspension(tx, suspended, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
+// ========================================
+// RECIPIENT OPERATIONS
+// ========================================
+
+// GetRecipients retrieves the users told about a broadcast, with their acknowledgment
+func (r *EmergencyBroadcastRepository) GetRecipients(broadcastID uuid.UUID) ([]*models.EmergencyRecipient, error) {
+	var recipients []*models.EmergencyRecipient
+	err := r.db.Preload("User").
+		Where("broadcast_id = ?", broadcastID).
+		Order("acknowledged_at ASC NULLS FIRST, created_at ASC").
+		Find(&recipients).Error
+	return recipients, err
+}
+
+// GetRecipient retrieves the recipient of a broadcast for a user
+func (r *EmergencyBroadcastRepository) GetRecipient(broadcastID, userID uuid.UUID) (*models.EmergencyRecipient, error) {
+	var recipient models.EmergencyRecipient
+	err := r.db.Where("broadcast_id = ? AND user_id = ?", broadcastID, userID).First(&recipient).Error
+	if err != nil {
+		return nil, err
+	}
+	return &recipient, nil
+}
+
+// Acknowledge records when a recipient acknowledged a broadcast, keeping the first acknowledgment
+func (r *EmergencyBroadcastRepository) Acknowledge(recipientID uuid.UUID, acknowledgedAt time.Time) error {
+	return r.db.Model(&models.EmergencyRecipient{}).
+		Where("id = ? AND acknowledged_at IS NULL", recipientID).
+		Update("acknowledged_at", acknowledgedAt).Error
+}
+
+// ========================================
+// RESERVATION OPERATIONS
+// ========================================
+
+// GetActiveCheckIns retrieves the confirmed reservations of a building checked in and not out,
+// not yet over and not already suspended
+func (r *EmergencyBroadcastRepository) GetActiveCheckIns(building string, at time.Time) ([]*models.Reservation, error) {
+	var reservations []*models.Reservation
+	err := r.db.Joins("JOIN spaces ON spaces.id = reservations.space_id").
+		Where("spaces.building = ? AND reservations.status = ? AND reservations.check_in_time IS NOT NULL AND reservations.check_out_time IS NULL",
+			building, models.StatusConfirmed).
+		Where("reservations.end_time > ? AND reservations.suspended_by_emergency_id IS NULL", at).
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// SuspendCheckIns pauses the check-in of the reservations until the all-clear of the broadcast
+func (r *EmergencyBroadcastRepository) SuspendCheckIns(broadcastID uuid.UUID, reservationIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return updateSuspension(tx, reservationIDs, &broadcastID)
+	})
+}
+
+// GetOrganizerIDs retrieves the owners and co-organizers of the reservations of a building
+// during part of the time range, cancelled ones excepted
+func (r *EmergencyBroadcastRepository) GetOrganizerIDs(building string, startTime, endTime time.Time) ([]uuid.UUID, error) {
+	reservations := r.db.Model(&models.Reservation{}).Select("reservations.id").
+		Joins("JOIN spaces ON spaces.id = reservations.space_id").
+		Where("spaces.building = ? AND reservations.status <> ? AND reservations.start_time < ? AND reservations.end_time > ?",
+			building, models.StatusCancelled, endTime, startTime)
+
+	var ids []uuid.UUID
+	err := r.db.Raw(`SELECT user_id FROM reservations WHERE id IN (?)
+		UNION SELECT user_id FROM reservation_co_organizers WHERE reservation_id IN (?)`, reservations, reservations).
+		Scan(&ids).Error
+	return ids, err
+}
+
+// updateSuspension sets the emergency broadcast suspending the check-in of the reservations,
+// nil resuming it, with an event per reservation
+func updateSuspension(tx *gorm.DB, reservationIDs []uuid.UUID, broadcastID *uuid.UUID) error {
+	for _, id := range reservationIDs {
+		if err := tx.Model(&models.Reservation{}).Where("id = ?", id).Update("suspended_by_emergency_id", broadcastID).Error; err != nil {
+			return err
+		}
+		if err := appendReservationEvent(tx, models.OutboxReservationUpdated, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
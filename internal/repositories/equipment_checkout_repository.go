@@ -0,0 +1,101 @@
+// internal/repositories/equipment_checkout_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EquipmentCheckoutRepository implements the EquipmentCheckoutRepositoryInterface
+type EquipmentCheckoutRepository struct {
+	db *gorm.DB
+}
+
+// NewEquipmentCheckoutRepository creates a new equipment checkout repository
+func NewEquipmentCheckoutRepository(db *gorm.DB) interfaces.EquipmentCheckoutRepositoryInterface {
+	return &EquipmentCheckoutRepository{db: db}
+}
+
+// GetByID retrieves equipment attached to a reservation, with the equipment and the reservation
+func (r *EquipmentCheckoutRepository) GetByID(id uuid.UUID) (*models.ReservationResource, error) {
+	var link models.ReservationResource
+	err := r.db.Preload("Resource").
+		Preload("Reservation").
+		Preload("Reservation.CoOrganizers").
+		Where("id = ?", id).
+		First(&link).Error
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetByReservation retrieves the equipment attached to a reservation
+func (r *EquipmentCheckoutRepository) GetByReservation(reservationID uuid.UUID) ([]*models.ReservationResource, error) {
+	var links []*models.ReservationResource
+	err := r.db.Preload("Resource").
+		Where("reservation_id = ?", reservationID).
+		Order("created_at ASC").
+		Find(&links).Error
+	return links, err
+}
+
+// Attach attaches equipment to a reservation
+func (r *EquipmentCheckoutRepository) Attach(link *models.ReservationResource) error {
+	return r.db.Create(link).Error
+}
+
+// Detach removes equipment from a reservation
+func (r *EquipmentCheckoutRepository) Detach(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.ReservationResource{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Update updates the checkout of attached equipment
+func (r *EquipmentCheckoutRepository) Update(id uuid.UUID, updates map[string]interface{}) (*models.ReservationResource, error) {
+	err := r.db.Model(&models.ReservationResource{}).Where("id = ?", id).Updates(updates).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(id)
+}
+
+// GetCheckedOut retrieves the equipment picked up and not returned, longest out first
+func (r *EquipmentCheckoutRepository) GetCheckedOut(resourceIDs []uuid.UUID) ([]*models.ReservationResource, error) {
+	query := r.db.Preload("Resource").
+		Preload("Reservation").
+		Where("picked_up_at IS NOT NULL AND returned_at IS NULL")
+	if len(resourceIDs) > 0 {
+		query = query.Where("resource_id IN ?", resourceIDs)
+	}
+
+	var links []*models.ReservationResource
+	err := query.Order("picked_up_at ASC").Find(&links).Error
+	return links, err
+}
+
+// GetOverdue retrieves the equipment still out after its reservation ended, not alerted yet
+func (r *EquipmentCheckoutRepository) GetOverdue(endedBefore time.Time, limit int) ([]*models.ReservationResource, error) {
+	var links []*models.ReservationResource
+	err := r.db.Preload("Resource").
+		Preload("Reservation").
+		Preload("Reservation.CoOrganizers").
+		Joins("JOIN reservations ON reservations.id = reservation_resources.reservation_id").
+		Where("reservation_resources.picked_up_at IS NOT NULL AND reservation_resources.returned_at IS NULL").
+		Where("reservation_resources.overdue_alerted_at IS NULL AND reservations.end_time < ?", endedBefore).
+		Order("reservations.end_time ASC").
+		Limit(limit).
+		Find(&links).Error
+	return links, err
+}
@@ -0,0 +1,300 @@
+// internal/repositories/fakedb_test.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeDB is an in-memory stand-in for PostgreSQL that understands just the statements GORM
+// writes for simple inserts, upserts and lookups by column, and logs every statement it runs.
+// Tables can be given column defaults, like those an older schema left in the database.
+type fakeDB struct {
+	mu         sync.Mutex
+	statements []string
+	columns    map[string][]string
+	rows       map[string][]map[string]driver.Value
+	defaults   map[string]map[string]driver.Value
+}
+
+// newFakeDB opens a GORM connection on an empty fake database
+func newFakeDB(t *testing.T) (*gorm.DB, *fakeDB) {
+	t.Helper()
+
+	fake := &fakeDB{
+		columns:  make(map[string][]string),
+		rows:     make(map[string][]map[string]driver.Value),
+		defaults: make(map[string]map[string]driver.Value),
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(fake)}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open fake database: %v", err)
+	}
+	return db, fake
+}
+
+// setDefault gives a column of a table a default value
+func (f *fakeDB) setDefault(table, column string, value driver.Value) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.defaults[table] == nil {
+		f.defaults[table] = make(map[string]driver.Value)
+	}
+	f.defaults[table][column] = value
+	f.addColumns(table, column)
+}
+
+// seed stores rows in a table as they are, without logging a statement
+func (f *fakeDB) seed(table string, rows ...map[string]driver.Value) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, row := range rows {
+		for column := range row {
+			f.addColumns(table, column)
+		}
+		f.rows[table] = append(f.rows[table], row)
+	}
+}
+
+// queries returns the statements run since the log was last reset
+func (f *fakeDB) queries() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.statements...)
+}
+
+// resetQueries clears the statement log
+func (f *fakeDB) resetQueries() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statements = nil
+}
+
+func (f *fakeDB) addColumns(table string, columns ...string) {
+	for _, column := range columns {
+		known := false
+		for _, c := range f.columns[table] {
+			if c == column {
+				known = true
+				break
+			}
+		}
+		if !known {
+			f.columns[table] = append(f.columns[table], column)
+		}
+	}
+}
+
+var (
+	fakeInsertPattern   = regexp.MustCompile(`^INSERT INTO "(\w+)" \(([^)]*)\) VALUES \(([^)]*)\)`)
+	fakeConflictPattern = regexp.MustCompile(`ON CONFLICT \(([^)]*)\) DO UPDATE SET (.*?)(?: RETURNING|$)`)
+	fakeReturnPattern   = regexp.MustCompile(`RETURNING (.*)$`)
+	fakeFromPattern     = regexp.MustCompile(`FROM "?(\w+)"?`)
+	fakeWherePattern    = regexp.MustCompile(`(?:"\w+"\.)?"?(\w+)"? = \$(\d+)`)
+	fakeUpdatePattern   = regexp.MustCompile(`^UPDATE "(\w+)" SET`)
+	fakeQuotePattern    = regexp.MustCompile(`"([^"]*)"`)
+)
+
+func fakeIdentifiers(list string) []string {
+	var names []string
+	for _, part := range strings.Split(list, ",") {
+		name := strings.TrimSpace(part)
+		if m := fakeQuotePattern.FindAllStringSubmatch(name, -1); len(m) > 0 {
+			name = m[len(m)-1][1]
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func fakeArg(args []driver.NamedValue, placeholder string) driver.Value {
+	var n int
+	for _, r := range strings.TrimPrefix(strings.TrimSpace(placeholder), "$") {
+		n = n*10 + int(r-'0')
+	}
+	if n < 1 || n > len(args) {
+		return nil
+	}
+	return args[n-1].Value
+}
+
+// run executes a statement and returns the columns and rows it yields
+func (f *fakeDB) run(query string, args []driver.NamedValue) ([]string, [][]driver.Value, int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statements = append(f.statements, query)
+
+	if m := fakeInsertPattern.FindStringSubmatch(query); m != nil {
+		return f.insert(query, m[1], fakeIdentifiers(m[2]), strings.Split(m[3], ","), args)
+	}
+	if fakeUpdatePattern.MatchString(query) || strings.HasPrefix(query, "DELETE") {
+		return nil, nil, 0, nil
+	}
+	if strings.HasPrefix(query, "SELECT") {
+		return f.selectRows(query, args)
+	}
+	return nil, nil, 0, nil
+}
+
+func (f *fakeDB) insert(query, table string, columns, placeholders []string, args []driver.NamedValue) ([]string, [][]driver.Value, int64, error) {
+	f.addColumns(table, columns...)
+
+	row := make(map[string]driver.Value)
+	for column, value := range f.defaults[table] {
+		row[column] = value
+	}
+	for i, column := range columns {
+		row[column] = fakeArg(args, placeholders[i])
+	}
+
+	stored, conflicted := row, false
+	if m := fakeConflictPattern.FindStringSubmatch(query); m != nil {
+		keys := fakeIdentifiers(m[1])
+		for _, existing := range f.rows[table] {
+			if !fakeRowMatches(existing, keys, row) {
+				continue
+			}
+			// DO UPDATE SET "col"="excluded"."col" copies the proposed row
+			for _, assignment := range strings.Split(m[2], ",") {
+				column := fakeIdentifiers(strings.SplitN(assignment, "=", 2)[0])[0]
+				existing[column] = row[column]
+			}
+			stored, conflicted = existing, true
+			break
+		}
+	}
+	if !conflicted {
+		f.rows[table] = append(f.rows[table], row)
+	}
+
+	var returning []string
+	if m := fakeReturnPattern.FindStringSubmatch(query); m != nil {
+		returning = fakeIdentifiers(m[1])
+	}
+	values := make([]driver.Value, len(returning))
+	for i, column := range returning {
+		values[i] = stored[column]
+	}
+	return returning, [][]driver.Value{values}, 1, nil
+}
+
+func fakeRowMatches(row map[string]driver.Value, keys []string, other map[string]driver.Value) bool {
+	for _, key := range keys {
+		if row[key] != other[key] {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *fakeDB) selectRows(query string, args []driver.NamedValue) ([]string, [][]driver.Value, int64, error) {
+	m := fakeFromPattern.FindStringSubmatch(query)
+	if m == nil {
+		return []string{"?column?"}, [][]driver.Value{{int64(1)}}, 0, nil
+	}
+	table := m[1]
+
+	var matching []map[string]driver.Value
+	where := ""
+	if idx := strings.Index(query, " WHERE "); idx >= 0 {
+		where = query[idx:]
+	}
+	for _, row := range f.rows[table] {
+		ok := true
+		for _, cond := range fakeWherePattern.FindAllStringSubmatch(where, -1) {
+			if row[cond[1]] != fakeArg(args, cond[2]) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matching = append(matching, row)
+		}
+	}
+
+	if strings.HasPrefix(strings.ToLower(query), "select count(") {
+		return []string{"count"}, [][]driver.Value{{int64(len(matching))}}, 0, nil
+	}
+
+	columns := f.columns[table]
+	rows := make([][]driver.Value, len(matching))
+	for i, row := range matching {
+		rows[i] = make([]driver.Value, len(columns))
+		for j, column := range columns {
+			rows[i][j] = row[column]
+		}
+	}
+	return columns, rows, 0, nil
+}
+
+// database/sql driver plumbing
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: f}, nil }
+func (f *fakeDB) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fake database is opened through its connector")
+}
+
+type fakeConn struct{ db *fakeDB }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("fake database does not prepare statements")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	_, _, affected, err := c.db.run(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(affected), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	columns, rows, _, err := c.db.run(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{columns: columns, rows: rows}, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
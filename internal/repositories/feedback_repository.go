@@ -0,0 +1,56 @@
+// internal/repositories/feedback_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FeedbackRepository implements the FeedbackRepositoryInterface
+type FeedbackRepository struct {
+	db      *gorm.DB
+	replica *gorm.DB
+}
+
+// NewFeedbackRepository creates a new feedback repository; reports read from the replica
+func NewFeedbackRepository(db, replica *gorm.DB) interfaces.FeedbackRepositoryInterface {
+	return &FeedbackRepository{db: db, replica: replica}
+}
+
+// Save records the feedback of a reservation, replacing an earlier one
+func (r *FeedbackRepository) Save(feedback *models.ReservationFeedback) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "reservation_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rating", "comment", "updated_at"}),
+	}).Create(feedback).Error
+}
+
+// GetEntries lists the feedback left within a date range with the space it is about
+func (r *FeedbackRepository) GetEntries(startDate, endDate time.Time, building string, spaceID *uuid.UUID) ([]*models.FeedbackEntry, error) {
+	var entries []*models.FeedbackEntry
+
+	query := r.replica.Model(&models.ReservationFeedback{}).
+		Select(`reservation_feedback.space_id,
+			spaces.name AS space_name,
+			spaces.building,
+			reservation_feedback.rating,
+			reservation_feedback.comment,
+			reservation_feedback.created_at`).
+		Joins("JOIN spaces ON spaces.id = reservation_feedback.space_id AND spaces.deleted_at IS NULL").
+		Where("reservation_feedback.created_at >= ? AND reservation_feedback.created_at < ?", startDate, endDate)
+	if building != "" {
+		query = query.Where("spaces.building = ?", building)
+	}
+	if spaceID != nil {
+		query = query.Where("reservation_feedback.space_id = ?", *spaceID)
+	}
+
+	err := query.Order("reservation_feedback.created_at ASC").Scan(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,121 @@
+// internal/repositories/floor_consolidation_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FloorConsolidationRepository implements the FloorConsolidationRepositoryInterface
+type FloorConsolidationRepository struct {
+	db *gorm.DB
+}
+
+// NewFloorConsolidationRepository creates a new floor consolidation repository
+func NewFloorConsolidationRepository(db *gorm.DB) interfaces.FloorConsolidationRepositoryInterface {
+	return &FloorConsolidationRepository{db: db}
+}
+
+// ========================================
+// CONSOLIDATION OPERATIONS
+// ========================================
+
+// Create creates a new floor consolidation
+func (r *FloorConsolidationRepository) Create(consolidation *models.FloorConsolidation) (*models.FloorConsolidation, error) {
+	if err := r.db.Create(consolidation).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(consolidation.ID)
+}
+
+// GetByID retrieves a floor consolidation by ID
+func (r *FloorConsolidationRepository) GetByID(id uuid.UUID) (*models.FloorConsolidation, error) {
+	var consolidation models.FloorConsolidation
+	err := r.db.Preload("CreatedBy").Where("id = ?", id).First(&consolidation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &consolidation, nil
+}
+
+// Update updates a floor consolidation
+func (r *FloorConsolidationRepository) Update(id uuid.UUID, updates map[string]interface{}) (*models.FloorConsolidation, error) {
+	err := r.db.Model(&models.FloorConsolidation{}).Where("id = ?", id).Updates(updates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
+// GetCurrentAndUpcoming retrieves the consolidations not ended and not over by the given day
+func (r *FloorConsolidationRepository) GetCurrentAndUpcoming(from time.Time) ([]*models.FloorConsolidation, error) {
+	var consolidations []*models.FloorConsolidation
+	err := r.db.Where("ended_at IS NULL AND end_date >= ?", from.Format("2006-01-02")).
+		Order("start_date ASC, building ASC").
+		Find(&consolidations).Error
+	return consolidations, err
+}
+
+// GetOverlapping retrieves the consolidations of a building not ended that share a day with the range
+func (r *FloorConsolidationRepository) GetOverlapping(building string, startDate, endDate time.Time) ([]*models.FloorConsolidation, error) {
+	var consolidations []*models.FloorConsolidation
+	err := r.db.Where("building = ? AND ended_at IS NULL AND start_date <= ? AND end_date >= ?",
+		building, endDate.Format("2006-01-02"), startDate.Format("2006-01-02")).
+		Find(&consolidations).Error
+	return consolidations, err
+}
+
+// ========================================
+// USAGE OPERATIONS
+// ========================================
+
+// GetFloorUsage sums, per building and floor, the capacity of bookable spaces and the
+// participant minutes of pending and confirmed reservations within the time range
+func (r *FloorConsolidationRepository) GetFloorUsage(startTime, endTime time.Time) ([]interfaces.FloorUsage, error) {
+	var usage []interfaces.FloorUsage
+
+	booked := r.db.Model(&models.Reservation{}).
+		Select("space_id, SUM(participant_count * EXTRACT(EPOCH FROM (LEAST(end_time, ?) - GREATEST(start_time, ?))) / 60) AS seat_minutes", endTime, startTime).
+		Where("status IN ? AND start_time < ? AND end_time > ?",
+			[]models.ReservationStatus{models.StatusPending, models.StatusConfirmed}, endTime, startTime).
+		Group("space_id")
+
+	err := r.db.Model(&models.Space{}).
+		Select("spaces.building, spaces.floor, SUM(spaces.capacity) AS capacity, COALESCE(SUM(booked.seat_minutes), 0) AS booked_seat_minutes").
+		Joins("LEFT JOIN (?) AS booked ON booked.space_id = spaces.id", booked).
+		Where("spaces.status = ?", models.SpaceStatusAvailable).
+		Group("spaces.building, spaces.floor").
+		Order("spaces.building ASC, spaces.floor ASC").
+		Scan(&usage).Error
+	return usage, err
+}
+
+// CountReservationsOnClosedFloors counts the pending and confirmed reservations in the
+// building outside the open floors that overlap the time range
+func (r *FloorConsolidationRepository) CountReservationsOnClosedFloors(building string, openFloors []int64, startTime, endTime time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Reservation{}).
+		Joins("JOIN spaces ON spaces.id = reservations.space_id").
+		Where("spaces.building = ? AND spaces.floor NOT IN ?", building, openFloors).
+		Where("reservations.status IN ? AND reservations.start_time < ? AND reservations.end_time > ?",
+			[]models.ReservationStatus{models.StatusPending, models.StatusConfirmed}, endTime, startTime).
+		Count(&count).Error
+	return count, err
+}
+
+// CreateAlert records a low-usage alert, returning false if the building and day were already reported
+func (r *FloorConsolidationRepository) CreateAlert(alert *models.LowUsageAlert) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(alert)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
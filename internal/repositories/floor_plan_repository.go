@@ -0,0 +1,168 @@
+// internal/repositories/floor_plan_repository.go
+package repositories
+
+import (
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FloorPlanRepository implements the FloorPlanRepositoryInterface
+type FloorPlanRepository struct {
+	db *gorm.DB
+}
+
+// NewFloorPlanRepository creates a new floor plan repository
+func NewFloorPlanRepository(db *gorm.DB) interfaces.FloorPlanRepositoryInterface {
+	return &FloorPlanRepository{db: db}
+}
+
+// ========================================
+// FLOOR PLAN OPERATIONS
+// ========================================
+
+// Save creates the plan of a floor or replaces its drawing
+func (r *FloorPlanRepository) Save(plan *models.FloorPlan) (*models.FloorPlan, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "building"}, {Name: "floor"}},
+		DoUpdates: clause.AssignmentColumns([]string{"svg", "image_url", "width", "height", "updated_by_id", "updated_at"}),
+	}).Create(plan).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByBuildingFloor(plan.Building, plan.Floor)
+}
+
+// GetByID retrieves a floor plan by ID with its seats and neighborhoods
+func (r *FloorPlanRepository) GetByID(id uuid.UUID) (*models.FloorPlan, error) {
+	var plan models.FloorPlan
+	err := r.db.Preload("Seats.Space").
+		Preload("Neighborhoods", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name ASC")
+		}).
+		Where("id = ?", id).First(&plan).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// GetByBuildingFloor retrieves the plan of a floor with its seats and neighborhoods
+func (r *FloorPlanRepository) GetByBuildingFloor(building string, floor int) (*models.FloorPlan, error) {
+	var plan models.FloorPlan
+	err := r.db.Select("id").Where("building = ? AND floor = ?", building, floor).First(&plan).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(plan.ID)
+}
+
+// GetAll retrieves the floor plans, of one building when given, without their drawings
+func (r *FloorPlanRepository) GetAll(building string) ([]*models.FloorPlan, error) {
+	var plans []*models.FloorPlan
+	query := r.db.Omit("svg")
+	if building != "" {
+		query = query.Where("building = ?", building)
+	}
+	err := query.Order("building ASC, floor ASC").Find(&plans).Error
+	return plans, err
+}
+
+// Delete removes a floor plan with its seats and neighborhoods
+func (r *FloorPlanRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("floor_plan_id = ?", id).Delete(&models.FloorPlanSeat{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("floor_plan_id = ?", id).Delete(&models.Neighborhood{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", id).Delete(&models.FloorPlan{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// ========================================
+// SEAT AND NEIGHBORHOOD OPERATIONS
+// ========================================
+
+// ReplaceSeats replaces every seat of a floor plan
+func (r *FloorPlanRepository) ReplaceSeats(planID uuid.UUID, seats []*models.FloorPlanSeat) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("floor_plan_id = ?", planID).Delete(&models.FloorPlanSeat{}).Error; err != nil {
+			return err
+		}
+		if len(seats) == 0 {
+			return nil
+		}
+		return tx.Create(&seats).Error
+	})
+}
+
+// CreateNeighborhood creates a new neighborhood
+func (r *FloorPlanRepository) CreateNeighborhood(neighborhood *models.Neighborhood) (*models.Neighborhood, error) {
+	if err := r.db.Create(neighborhood).Error; err != nil {
+		return nil, err
+	}
+	return r.GetNeighborhoodByID(neighborhood.ID)
+}
+
+// GetNeighborhoodByID retrieves a neighborhood by ID
+func (r *FloorPlanRepository) GetNeighborhoodByID(id uuid.UUID) (*models.Neighborhood, error) {
+	var neighborhood models.Neighborhood
+	err := r.db.Where("id = ?", id).First(&neighborhood).Error
+	if err != nil {
+		return nil, err
+	}
+	return &neighborhood, nil
+}
+
+// DeleteNeighborhood removes a neighborhood; its seats stay on the plan, open to everyone
+func (r *FloorPlanRepository) DeleteNeighborhood(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.FloorPlanSeat{}).Where("neighborhood_id = ?", id).
+			Update("neighborhood_id", nil).Error
+		if err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", id).Delete(&models.Neighborhood{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// GetNeighborhoodsBySpaces maps each of the spaces seated in a neighborhood to that neighborhood
+func (r *FloorPlanRepository) GetNeighborhoodsBySpaces(spaceIDs []uuid.UUID) (map[uuid.UUID]*models.Neighborhood, error) {
+	neighborhoods := make(map[uuid.UUID]*models.Neighborhood)
+	if len(spaceIDs) == 0 {
+		return neighborhoods, nil
+	}
+
+	var seats []*models.FloorPlanSeat
+	err := r.db.Preload("Neighborhood").
+		Where("space_id IN ? AND neighborhood_id IS NOT NULL", spaceIDs).
+		Find(&seats).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, seat := range seats {
+		if seat.Neighborhood != nil {
+			neighborhoods[seat.SpaceID] = seat.Neighborhood
+		}
+	}
+	return neighborhoods, nil
+}
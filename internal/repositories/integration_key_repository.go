@@ -0,0 +1,60 @@
+// internal/repositories/integration_key_repository.go
+package repositories
+
+import (
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// IntegrationKeyRepository implements the IntegrationKeyRepositoryInterface
+type IntegrationKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewIntegrationKeyRepository creates a new integration key repository
+func NewIntegrationKeyRepository(db *gorm.DB) interfaces.IntegrationKeyRepositoryInterface {
+	return &IntegrationKeyRepository{db: db}
+}
+
+// Create creates a new integration key
+func (r *IntegrationKeyRepository) Create(key *models.IntegrationKey) (*models.IntegrationKey, error) {
+	if err := r.db.Create(key).Error; err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// GetByID retrieves an integration key by ID
+func (r *IntegrationKeyRepository) GetByID(id uuid.UUID) (*models.IntegrationKey, error) {
+	var key models.IntegrationKey
+	err := r.db.Where("id = ?", id).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetByKeyHash retrieves an integration key by the hash of its key
+func (r *IntegrationKeyRepository) GetByKeyHash(keyHash string) (*models.IntegrationKey, error) {
+	var key models.IntegrationKey
+	err := r.db.Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetAll retrieves every integration key, newest first
+func (r *IntegrationKeyRepository) GetAll() ([]*models.IntegrationKey, error) {
+	var keys []*models.IntegrationKey
+	err := r.db.Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+// Update updates an integration key
+func (r *IntegrationKeyRepository) Update(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Model(&models.IntegrationKey{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,15 @@
+// internal/repositories/interfaces/account_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountRepositoryInterface defines the contract for account-wide data operations
+type AccountRepositoryInterface interface {
+	// Anonymize strips a user's personal data from every table in one transaction, keeping
+	// the rows statistics are built from
+	Anonymize(userID uuid.UUID, at time.Time) error
+}
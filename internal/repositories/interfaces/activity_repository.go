@@ -0,0 +1,30 @@
+// internal/repositories/interfaces/activity_repository.go
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ActivityFilter narrows the admin activity feed; zero fields match everything
+type ActivityFilter struct {
+	Categories []models.ActivityCategory
+	ActorID    *uuid.UUID
+	EntityType string
+	EntityID   *uuid.UUID
+	From       *time.Time
+	To         *time.Time // Exclusive
+}
+
+// ActivityRepositoryInterface defines the contract for activity feed data operations. Approvals
+// are appended by the reservation repository in the transaction of the decision.
+type ActivityRepositoryInterface interface {
+	// Record appends an event; an event whose source was recorded already is skipped
+	Record(ctx context.Context, event *models.ActivityEvent) error
+	// List returns events after the cursor in the order they occurred, up to the given time
+	List(ctx context.Context, filter ActivityFilter, after *time.Time, afterID uuid.UUID, until time.Time, limit int) ([]*models.ActivityEvent, error)
+}
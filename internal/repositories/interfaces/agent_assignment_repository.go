@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// AgentAssignmentRepository defines the interface for agent assignment database operations
+type AgentAssignmentRepository interface {
+	Create(ctx context.Context, assignment *models.AgentAssignment) error
+	Update(ctx context.Context, assignment *models.AgentAssignment) error
+	GetPendingByConversation(ctx context.Context, conversationID uuid.UUID) (*models.AgentAssignment, error)
+	GetExpiredPending(ctx context.Context, offeredBefore time.Time, limit int) ([]models.AgentAssignment, error)
+	GetRejectedAgentIDs(ctx context.Context, conversationID uuid.UUID) ([]uuid.UUID, error)
+	CancelPending(ctx context.Context, conversationID uuid.UUID) error
+}
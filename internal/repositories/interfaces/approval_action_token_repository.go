@@ -0,0 +1,21 @@
+// internal/repositories/interfaces/approval_action_token_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ApprovalActionTokenRepositoryInterface defines the contract for the one-time approval links of emails
+type ApprovalActionTokenRepositoryInterface interface {
+	CreateBatch(tokens []*models.ApprovalActionToken) error
+	GetByID(id uuid.UUID) (*models.ApprovalActionToken, error)
+	HasTokens(reservationID, approverID uuid.UUID) (bool, error)
+	// Use spends the token with the comments, and the approver's other tokens for the reservation;
+	// gorm.ErrRecordNotFound when it was used already
+	Use(token *models.ApprovalActionToken, comments, ip string, usedAt time.Time) error
+	DeleteExpired(before time.Time) (int64, error)
+}
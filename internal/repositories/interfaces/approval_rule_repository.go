@@ -0,0 +1,17 @@
+// internal/repositories/interfaces/approval_rule_repository.go
+package interfaces
+
+import (
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ApprovalRuleRepositoryInterface defines the contract for space approval rule data operations
+type ApprovalRuleRepositoryInterface interface {
+	GetAll() ([]*models.ApprovalRule, error)
+	GetBySpace(spaceID uuid.UUID) (*models.ApprovalRule, error)
+	// Save creates the space's rule or replaces its thresholds
+	Save(rule *models.ApprovalRule) (*models.ApprovalRule, error)
+	Delete(spaceID uuid.UUID) error
+}
@@ -0,0 +1,18 @@
+// internal/repositories/interfaces/booking_conflict_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// BookingConflictRepositoryInterface defines the contract for booking conflict data operations
+type BookingConflictRepositoryInterface interface {
+	Create(conflict *models.BookingConflict) error
+	// GetSummaryBySpace aggregates conflicts per space, most contested first.
+	// A non-nil managerID limits the result to the spaces that user manages.
+	GetSummaryBySpace(startDate, endDate time.Time, managerID *uuid.UUID, spaceID *uuid.UUID) ([]*models.BookingConflictSummary, error)
+}
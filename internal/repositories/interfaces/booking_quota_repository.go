@@ -0,0 +1,29 @@
+// internal/repositories/interfaces/booking_quota_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// BookingQuotaRepositoryInterface defines the contract for booking quota data operations
+type BookingQuotaRepositoryInterface interface {
+	// ========================================
+	// OVERRIDE OPERATIONS
+	// ========================================
+	GetOverrides() ([]*models.QuotaOverride, error)
+	GetUserOverride(userID uuid.UUID) (*models.QuotaOverride, error)
+	GetTeamOverride(department string) (*models.QuotaOverride, error)
+	// SaveOverride creates the user's or team's override or replaces its limit
+	SaveOverride(override *models.QuotaOverride) (*models.QuotaOverride, error)
+	DeleteOverride(id uuid.UUID) error
+
+	// ========================================
+	// USAGE OPERATIONS
+	// ========================================
+	SumUserBookedMinutes(userID uuid.UUID, startTime, endTime time.Time) (float64, error)
+	SumTeamPremiumMinutes(department string, startTime, endTime time.Time) (float64, error)
+}
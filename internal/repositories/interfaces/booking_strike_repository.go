@@ -0,0 +1,32 @@
+// internal/repositories/interfaces/booking_strike_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// BookingStrikeRepositoryInterface defines the contract for booking strike data operations
+type BookingStrikeRepositoryInterface interface {
+	// ========================================
+	// STRIKE OPERATIONS
+	// ========================================
+	// Create records a strike; a reservation that already earned one is left as is
+	Create(strike *models.BookingStrike) error
+	// GetActiveByUser retrieves the user's uncleared strikes since the given time, newest first
+	GetActiveByUser(userID uuid.UUID, since time.Time) ([]*models.BookingStrike, error)
+	// GetActive retrieves every uncleared strike since the given time, newest first
+	GetActive(since time.Time, offset, limit int) ([]*models.BookingStrike, int64, error)
+	Clear(id, clearedByID uuid.UUID, at time.Time) error
+	ClearByUser(userID, clearedByID uuid.UUID, at time.Time) (int64, error)
+
+	// ========================================
+	// NO-SHOW DETECTION
+	// ========================================
+	// GetMissedCheckIns retrieves confirmed reservations started within the time range
+	// that were never checked in nor reported as no-shows
+	GetMissedCheckIns(startedAfter, startedBefore time.Time, limit int) ([]*models.Reservation, error)
+}
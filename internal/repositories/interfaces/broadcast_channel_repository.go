@@ -0,0 +1,53 @@
+// internal/repositories/interfaces/broadcast_channel_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// BroadcastChannelRepositoryInterface defines the contract for broadcast channel data operations
+type BroadcastChannelRepositoryInterface interface {
+	// ========================================
+	// CHANNEL OPERATIONS
+	// ========================================
+	CreateChannel(channel *models.BroadcastChannel) error
+	GetChannelByID(id uuid.UUID) (*models.BroadcastChannel, error)
+	// ListChannels retrieves channels by name, archived ones only when asked
+	ListChannels(includeArchived bool) ([]*models.BroadcastChannel, error)
+	UpdateChannel(id uuid.UUID, updates map[string]interface{}) (*models.BroadcastChannel, error)
+
+	// ========================================
+	// POST OPERATIONS
+	// ========================================
+	// CreatePost stores the post together with its recipients
+	CreatePost(post *models.BroadcastPost, recipientIDs []uuid.UUID) (*models.BroadcastPost, error)
+	GetPostByID(id uuid.UUID) (*models.BroadcastPost, error)
+	// ListPosts retrieves the posts of a channel, most recent first
+	ListPosts(channelID uuid.UUID, offset, limit int) ([]*models.BroadcastPost, int64, error)
+	// GetReadStats counts the reads of each of the posts
+	GetReadStats(postIDs []uuid.UUID) (map[uuid.UUID]*models.BroadcastReadStats, error)
+
+	// ========================================
+	// RECIPIENT OPERATIONS
+	// ========================================
+	// GetInbox retrieves the posts delivered to a user with their posts and channels, most
+	// recent first, optionally only the unread ones or those of one channel
+	GetInbox(userID uuid.UUID, channelID *uuid.UUID, unreadOnly bool, offset, limit int) ([]*models.BroadcastRecipient, int64, error)
+	GetRecipient(postID, userID uuid.UUID) (*models.BroadcastRecipient, error)
+	MarkRead(recipientID uuid.UUID, readAt time.Time) error
+
+	// ========================================
+	// AUDIENCE OPERATIONS
+	// ========================================
+	// GetActiveUserIDs retrieves every active user
+	GetActiveUserIDs() ([]uuid.UUID, error)
+	// GetBuildingUserIDs retrieves the owners and co-organizers of the reservations of a building
+	// ending after the given time, cancelled ones excepted, and the managers of its spaces
+	GetBuildingUserIDs(building string, since time.Time) ([]uuid.UUID, error)
+	// ManagesBuilding checks if the user manages a space of the building
+	ManagesBuilding(userID uuid.UUID, building string) (bool, error)
+}
@@ -0,0 +1,27 @@
+// internal/repositories/interfaces/building_closure_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// BuildingClosureRepositoryInterface defines the contract for building closure data operations
+type BuildingClosureRepositoryInterface interface {
+	// ========================================
+	// CLOSURE OPERATIONS
+	// ========================================
+	Create(closure *models.BuildingClosure) (*models.BuildingClosure, error)
+	GetByID(id uuid.UUID) (*models.BuildingClosure, error)
+	GetCurrentAndUpcoming(from time.Time) ([]*models.BuildingClosure, error)
+	GetOverlapping(building string, startTime, endTime time.Time) ([]*models.BuildingClosure, error)
+	End(id uuid.UUID, endedAt time.Time) (*models.BuildingClosure, error)
+
+	// ========================================
+	// RESERVATION OPERATIONS
+	// ========================================
+	FlagReservations(closure *models.BuildingClosure) ([]*models.Reservation, error)
+}
@@ -0,0 +1,15 @@
+// internal/repositories/interfaces/bulk_cancellation_repository.go
+package interfaces
+
+import (
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// BulkCancellationRepositoryInterface defines the contract for bulk cancellation data operations
+type BulkCancellationRepositoryInterface interface {
+	Create(cancellation *models.BulkCancellation) (*models.BulkCancellation, error)
+	GetByID(id uuid.UUID) (*models.BulkCancellation, error)
+	Update(id uuid.UUID, updates map[string]interface{}) error
+}
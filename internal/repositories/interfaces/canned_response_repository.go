@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// CannedResponseRepository defines the interface for canned response database operations
+type CannedResponseRepository interface {
+	Create(ctx context.Context, response *models.CannedResponse) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.CannedResponse, error)
+	GetByShortcut(ctx context.Context, shortcut string) (*models.CannedResponse, error)
+	List(ctx context.Context, category, search string, activeOnly bool, limit, offset int) ([]models.CannedResponse, int64, error)
+	GetAutoReplies(ctx context.Context) ([]models.CannedResponse, error)
+	Update(ctx context.Context, response *models.CannedResponse) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	IncrementUsage(ctx context.Context, id uuid.UUID) error
+}
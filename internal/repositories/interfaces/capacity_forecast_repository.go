@@ -0,0 +1,30 @@
+// internal/repositories/interfaces/capacity_forecast_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+)
+
+// CapacityForecastRepositoryInterface defines the contract for the bookings and capacity that capacity forecasts are built from
+type CapacityForecastRepositoryInterface interface {
+	GetDailyDemand(startTime, endTime time.Time, statuses []models.ReservationStatus, building, spaceType string) ([]DailyDemand, error)
+	GetCapacity(building, spaceType string) ([]GroupCapacity, error)
+}
+
+// DailyDemand is the seat time booked in the spaces of a building and type on one UTC day
+type DailyDemand struct {
+	Building    string
+	SpaceType   string
+	Day         time.Time
+	SeatMinutes float64
+}
+
+// GroupCapacity is the bookable capacity of the spaces of a building and type
+type GroupCapacity struct {
+	Building  string
+	SpaceType string
+	Spaces    int
+	Capacity  int
+}
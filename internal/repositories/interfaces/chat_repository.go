@@ -17,9 +17,16 @@ type ChatRepository interface {
 	GetConversationByID(ctx context.Context, id uuid.UUID) (*models.Conversation, error)
 	GetConversationsByUserID(ctx context.Context, userID uuid.UUID, req *dto.GetConversationsRequest) ([]models.Conversation, int64, error)
 	UpdateConversation(ctx context.Context, conversation *models.Conversation) error
+	UpdateConversationIfUnmodified(ctx context.Context, conversation *models.Conversation, updatedAt time.Time) error
 	DeleteConversation(ctx context.Context, id uuid.UUID) error
 	ArchiveConversation(ctx context.Context, id uuid.UUID, isArchived bool) error
 	GetConversationWithParticipants(ctx context.Context, id uuid.UUID) (*models.Conversation, error)
+	GetOpenReservationConversation(ctx context.Context, userID, reservationID uuid.UUID) (*models.Conversation, error)
+	GetAttendeeChat(ctx context.Context, reservationID uuid.UUID) (*models.Conversation, error)
+	ArchiveEndedAttendeeChats(ctx context.Context, endedBefore time.Time, limit int) ([]uuid.UUID, error)
+	GetInactiveConversations(ctx context.Context, before time.Time, limit int) ([]models.Conversation, error)
+	ArchiveInactiveConversations(ctx context.Context, before time.Time, limit int) (int64, error)
+	UnarchiveConversations(ctx context.Context, ids []uuid.UUID) (int64, error)
 
 	// Participant operations
 	AddParticipant(ctx context.Context, participant *models.ConversationParticipant) error
@@ -28,6 +35,8 @@ type ChatRepository interface {
 	GetParticipantByUserAndConversation(ctx context.Context, userID, conversationID uuid.UUID) (*models.ConversationParticipant, error)
 	UpdateParticipantUnreadCount(ctx context.Context, conversationID, userID uuid.UUID, count int) error
 	IsUserParticipant(ctx context.Context, userID, conversationID uuid.UUID) (bool, error)
+	SetParticipantMute(ctx context.Context, conversationID, userID uuid.UUID, muted bool, until *time.Time) error
+	IsConversationMuted(ctx context.Context, userID, conversationID uuid.UUID) (bool, error)
 
 	// Message operations
 	CreateMessage(ctx context.Context, message *models.Message) error
@@ -35,13 +44,22 @@ type ChatRepository interface {
 	GetMessagesByConversationID(ctx context.Context, conversationID uuid.UUID, req *dto.GetMessagesRequest) ([]models.Message, int64, error)
 	UpdateMessage(ctx context.Context, message *models.Message) error
 	DeleteMessage(ctx context.Context, id uuid.UUID) error
-	SearchMessages(ctx context.Context, userID uuid.UUID, req *dto.SearchMessagesRequest) ([]models.Message, int64, error)
+	SearchMessages(ctx context.Context, userID uuid.UUID, req *dto.SearchMessagesRequest) ([]models.MessageSearchHit, int64, error)
+	GetThreadReplies(ctx context.Context, rootMessageID uuid.UUID, limit int) ([]models.Message, error)
+	GetMessagesForExport(ctx context.Context, conversationID uuid.UUID, limit int) ([]models.Message, error)
+	GetMessagesForCompliance(ctx context.Context, conversationID, senderID *uuid.UUID, from, to *time.Time, limit int) ([]models.Message, error)
 
 	// Message attachment operations
 	CreateMessageAttachment(ctx context.Context, attachment *models.MessageAttachment) error
 	GetAttachmentsByMessageID(ctx context.Context, messageID uuid.UUID) ([]models.MessageAttachment, error)
 	DeleteAttachment(ctx context.Context, id uuid.UUID) error
 
+	// Upload operations
+	CreateChatUpload(ctx context.Context, upload *models.ChatUpload) error
+	GetChatUpload(ctx context.Context, conversationID uuid.UUID, storedName string) (*models.ChatUpload, error)
+	GetChatUploadsByScanStatus(ctx context.Context, status models.AttachmentScanStatus, limit int) ([]models.ChatUpload, error)
+	ResolveChatUploadScan(ctx context.Context, upload *models.ChatUpload) error
+
 	// Read receipt operations
 	MarkMessageAsRead(ctx context.Context, messageID, userID uuid.UUID) error
 	GetReadReceipts(ctx context.Context, messageID uuid.UUID) ([]models.MessageReadReceipt, error)
@@ -49,6 +67,29 @@ type ChatRepository interface {
 	GetUnreadMessageIDs(ctx context.Context, conversationID, userID uuid.UUID) ([]uuid.UUID, error)
 	MarkMultipleMessagesAsRead(ctx context.Context, messageIDs []uuid.UUID, userID uuid.UUID) error
 
+	// Device key operations
+	UpsertDeviceKeyBundle(ctx context.Context, bundle *models.DeviceKeyBundle) error
+	GetDeviceKeyBundles(ctx context.Context, userID uuid.UUID) ([]models.DeviceKeyBundle, error)
+	HasDeviceKeyBundle(ctx context.Context, userID uuid.UUID, deviceID string) (bool, error)
+	DeleteDeviceKeyBundle(ctx context.Context, userID uuid.UUID, deviceID string) error
+	ClaimDeviceKeyBundles(ctx context.Context, userID uuid.UUID) ([]models.DeviceKeyBundle, error)
+
+	// User block operations
+	CreateUserBlock(ctx context.Context, block *models.UserBlock) error
+	DeleteUserBlock(ctx context.Context, blockerID, blockedID uuid.UUID) error
+	GetUserBlocks(ctx context.Context, blockerID uuid.UUID) ([]models.UserBlock, error)
+	IsBlockedBetween(ctx context.Context, userID, otherUserID uuid.UUID) (bool, error)
+
+	// Sync operations
+	GetUnreadCountsByUser(ctx context.Context, userID uuid.UUID) ([]dto.ConversationUnreadCount, error)
+	GetMessagesSince(ctx context.Context, userID uuid.UUID, after time.Time, afterID uuid.UUID, limit int) ([]models.Message, error)
+	GetLatestMessageForUser(ctx context.Context, userID uuid.UUID) (*models.Message, error)
+
+	// Delivery receipt operations
+	MarkMessagesAsDelivered(ctx context.Context, messageIDs []uuid.UUID, userID uuid.UUID) ([]uuid.UUID, error)
+	GetDeliveryReceipts(ctx context.Context, messageID uuid.UUID) ([]models.MessageDeliveryReceipt, error)
+	GetMessageDeliveryCounts(ctx context.Context, messageIDs []uuid.UUID) (map[uuid.UUID]models.MessageDeliveryCounts, error)
+
 	// Support agent operations
 	CreateSupportAgent(ctx context.Context, agent *models.SupportAgent) error
 	GetSupportAgentByID(ctx context.Context, id uuid.UUID) (*models.SupportAgent, error)
@@ -65,7 +106,7 @@ type ChatRepository interface {
 	GetDailyMessageCounts(ctx context.Context, startDate, endDate time.Time) ([]dto.DailyConversationStats, error)
 
 	// Utility operations
-	CleanupOldMessages(ctx context.Context, retentionDays int) error
+	CleanupOldMessages(ctx context.Context, priority models.ConversationPriority, before time.Time) ([]string, int64, error)
 	GetConversationParticipantIDs(ctx context.Context, conversationID uuid.UUID) ([]uuid.UUID, error)
 	BulkUpdateUnreadCounts(ctx context.Context, conversationID uuid.UUID, excludeUserID uuid.UUID) error
 }
@@ -0,0 +1,19 @@
+// internal/repositories/interfaces/check_in_policy_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+)
+
+// CheckInPolicyRepositoryInterface defines the contract for check-in enforcement data operations
+type CheckInPolicyRepositoryInterface interface {
+	GetAll() ([]*models.CheckInPolicy, error)
+	GetBySpaceType(spaceType models.SpaceType) (*models.CheckInPolicy, error)
+	Save(policy *models.CheckInPolicy) (*models.CheckInPolicy, error)
+	Delete(spaceType models.SpaceType) error
+	// GetMissedCheckIns retrieves confirmed reservations of spaces of the type started within the
+	// time range that were never checked in
+	GetMissedCheckIns(spaceType models.SpaceType, startedAfter, startedBefore time.Time, limit int) ([]*models.Reservation, error)
+}
@@ -0,0 +1,24 @@
+// internal/repositories/interfaces/check_in_questionnaire_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// CheckInQuestionnaireRepositoryInterface defines the contract for check-in questionnaire data operations
+type CheckInQuestionnaireRepositoryInterface interface {
+	// Questionnaires
+	Create(questionnaire *models.CheckInQuestionnaire) (*models.CheckInQuestionnaire, error)
+	GetBySpace(spaceID uuid.UUID) (*models.CheckInQuestionnaire, error)
+	Update(id uuid.UUID, updates map[string]interface{}) error
+	Delete(id uuid.UUID) error
+
+	// Submissions
+	CreateSubmission(submission *models.QuestionnaireSubmission) (*models.QuestionnaireSubmission, error)
+	GetSubmissionByReservation(reservationID uuid.UUID) (*models.QuestionnaireSubmission, error)
+	GetSubmissionsBySpace(spaceID uuid.UUID, from, to time.Time) ([]*models.QuestionnaireSubmission, error)
+}
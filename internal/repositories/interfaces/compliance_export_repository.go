@@ -0,0 +1,16 @@
+// internal/repositories/interfaces/compliance_export_repository.go
+package interfaces
+
+import (
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ComplianceExportRepositoryInterface defines the contract for compliance export record operations
+type ComplianceExportRepositoryInterface interface {
+	Create(export *models.ComplianceExport) (*models.ComplianceExport, error)
+	GetByID(id uuid.UUID) (*models.ComplianceExport, error)
+	// GetAll lists the exports, newest first, optionally for one case
+	GetAll(caseReference string, offset, limit int) ([]*models.ComplianceExport, int64, error)
+}
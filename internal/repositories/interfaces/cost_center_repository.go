@@ -0,0 +1,15 @@
+// internal/repositories/interfaces/cost_center_repository.go
+package interfaces
+
+import (
+	"room-reservation-api/internal/models"
+)
+
+// CostCenterRepositoryInterface defines the contract for cost center data operations
+type CostCenterRepositoryInterface interface {
+	GetAll(activeOnly bool) ([]*models.CostCenter, error)
+	GetByCode(code string) (*models.CostCenter, error)
+	// Save creates the cost center or replaces its name and status
+	Save(costCenter *models.CostCenter) (*models.CostCenter, error)
+	Delete(code string) error
+}
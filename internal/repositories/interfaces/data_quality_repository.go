@@ -0,0 +1,50 @@
+// internal/repositories/interfaces/data_quality_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/models"
+)
+
+// Kinds of reservation participants that can be left without a reservation
+const (
+	ParticipantKindCoOrganizer = "co_organizer"
+	ParticipantKindGuest       = "guest"
+)
+
+// DataQualityRepositoryInterface defines the contract for finding and fixing inconsistent reservation data
+type DataQualityRepositoryInterface interface {
+	// ========================================
+	// CHECKS
+	// ========================================
+	FindCheckInsWithoutConfirmation(limit int) ([]*models.Reservation, error)
+	FindOverlappingConfirmed(endedAfter time.Time, limit int) ([]ReservationOverlap, error)
+	FindOrphanedParticipants(limit int) ([]OrphanedParticipant, error)
+	FindReservationsOnDeletedSpaces(endedAfter time.Time, limit int) ([]*models.Reservation, error)
+
+	// ========================================
+	// FIXES
+	// ========================================
+	ClearCheckIn(reservationID uuid.UUID) error
+	DeleteParticipant(kind string, id uuid.UUID) error
+}
+
+// ReservationOverlap is a confirmed reservation booked over an earlier confirmed one in the same space
+type ReservationOverlap struct {
+	ReservationID uuid.UUID // The later booking of the two
+	OtherID       uuid.UUID
+	SpaceID       uuid.UUID
+	StartTime     time.Time
+	EndTime       time.Time
+}
+
+// OrphanedParticipant is a co-organizer or guest whose reservation, or user, no longer exists
+type OrphanedParticipant struct {
+	ID            uuid.UUID
+	Kind          string // ParticipantKindCoOrganizer or ParticipantKindGuest
+	ReservationID uuid.UUID
+	Reason        string
+}
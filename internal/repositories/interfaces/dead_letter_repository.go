@@ -0,0 +1,20 @@
+// internal/repositories/interfaces/dead_letter_repository.go
+package interfaces
+
+import (
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetterRepositoryInterface defines the contract for dead letter data operations
+type DeadLetterRepositoryInterface interface {
+	// Record stores failed work, or counts another failure on its open dead letter
+	Record(letter *models.DeadLetter) (*models.DeadLetter, error)
+	GetByID(id uuid.UUID) (*models.DeadLetter, error)
+	// GetAll lists dead letters newest first, filtered by source and status when set
+	GetAll(source models.DeadLetterSource, status models.DeadLetterStatus, offset, limit int) ([]*models.DeadLetter, int64, error)
+	// GetOpen lists open dead letters, oldest first, limited to the IDs or source when set
+	GetOpen(ids []uuid.UUID, source models.DeadLetterSource, limit int) ([]*models.DeadLetter, error)
+	Update(id uuid.UUID, updates map[string]interface{}) error
+}
@@ -0,0 +1,41 @@
+// internal/repositories/interfaces/emergency_broadcast_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// EmergencyBroadcastRepositoryInterface defines the contract for emergency broadcast data operations
+type EmergencyBroadcastRepositoryInterface interface {
+	// ========================================
+	// BROADCAST OPERATIONS
+	// ========================================
+	// Create stores the broadcast together with its recipients
+	Create(broadcast *models.EmergencyBroadcast, recipientIDs []uuid.UUID) (*models.EmergencyBroadcast, error)
+	GetByID(id uuid.UUID) (*models.EmergencyBroadcast, error)
+	List(offset, limit int) ([]*models.EmergencyBroadcast, int64, error)
+	// End records the all-clear and resumes the check-ins the broadcast suspended
+	End(id uuid.UUID, endedAt time.Time) (*models.EmergencyBroadcast, error)
+
+	// ========================================
+	// RECIPIENT OPERATIONS
+	// ========================================
+	GetRecipients(broadcastID uuid.UUID) ([]*models.EmergencyRecipient, error)
+	GetRecipient(broadcastID, userID uuid.UUID) (*models.EmergencyRecipient, error)
+	Acknowledge(recipientID uuid.UUID, acknowledgedAt time.Time) error
+
+	// ========================================
+	// RESERVATION OPERATIONS
+	// ========================================
+	// GetActiveCheckIns retrieves the confirmed reservations of a building checked in and not out,
+	// not yet over and not already suspended
+	GetActiveCheckIns(building string, at time.Time) ([]*models.Reservation, error)
+	SuspendCheckIns(broadcastID uuid.UUID, reservationIDs []uuid.UUID) error
+	// GetOrganizerIDs retrieves the owners and co-organizers of the reservations of a building
+	// during part of the time range, cancelled ones excepted
+	GetOrganizerIDs(building string, startTime, endTime time.Time) ([]uuid.UUID, error)
+}
@@ -0,0 +1,23 @@
+// internal/repositories/interfaces/equipment_checkout_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// EquipmentCheckoutRepositoryInterface defines the contract for equipment attached to reservations and its checkouts
+type EquipmentCheckoutRepositoryInterface interface {
+	GetByID(id uuid.UUID) (*models.ReservationResource, error)
+	GetByReservation(reservationID uuid.UUID) ([]*models.ReservationResource, error)
+	Attach(link *models.ReservationResource) error
+	Detach(id uuid.UUID) error
+	Update(id uuid.UUID, updates map[string]interface{}) (*models.ReservationResource, error)
+	// GetCheckedOut retrieves the equipment picked up and not returned, of the given resources or all when none are given
+	GetCheckedOut(resourceIDs []uuid.UUID) ([]*models.ReservationResource, error)
+	// GetOverdue retrieves the equipment still out after its reservation ended before the cutoff, not alerted yet
+	GetOverdue(endedBefore time.Time, limit int) ([]*models.ReservationResource, error)
+}
@@ -0,0 +1,19 @@
+// internal/repositories/interfaces/feedback_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// FeedbackRepositoryInterface defines the contract for check-out feedback data operations
+type FeedbackRepositoryInterface interface {
+	// Save records the feedback of a reservation, replacing an earlier one
+	Save(feedback *models.ReservationFeedback) error
+	// GetEntries lists the feedback left within a date range, oldest first, optionally for
+	// one building or space
+	GetEntries(startDate, endDate time.Time, building string, spaceID *uuid.UUID) ([]*models.FeedbackEntry, error)
+}
@@ -0,0 +1,37 @@
+// internal/repositories/interfaces/floor_consolidation_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// FloorConsolidationRepositoryInterface defines the contract for floor consolidation data operations
+type FloorConsolidationRepositoryInterface interface {
+	// ========================================
+	// CONSOLIDATION OPERATIONS
+	// ========================================
+	Create(consolidation *models.FloorConsolidation) (*models.FloorConsolidation, error)
+	GetByID(id uuid.UUID) (*models.FloorConsolidation, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*models.FloorConsolidation, error)
+	GetCurrentAndUpcoming(from time.Time) ([]*models.FloorConsolidation, error)
+	GetOverlapping(building string, startDate, endDate time.Time) ([]*models.FloorConsolidation, error)
+
+	// ========================================
+	// USAGE OPERATIONS
+	// ========================================
+	GetFloorUsage(startTime, endTime time.Time) ([]FloorUsage, error)
+	CountReservationsOnClosedFloors(building string, openFloors []int64, startTime, endTime time.Time) (int64, error)
+	CreateAlert(alert *models.LowUsageAlert) (bool, error)
+}
+
+// FloorUsage is the bookable capacity of a floor and the seat time booked on it
+type FloorUsage struct {
+	Building          string
+	Floor             int
+	Capacity          int
+	BookedSeatMinutes float64
+}
@@ -0,0 +1,29 @@
+// internal/repositories/interfaces/floor_plan_repository.go
+package interfaces
+
+import (
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// FloorPlanRepositoryInterface defines the contract for floor plan data operations
+type FloorPlanRepositoryInterface interface {
+	// ========================================
+	// FLOOR PLAN OPERATIONS
+	// ========================================
+	Save(plan *models.FloorPlan) (*models.FloorPlan, error)
+	GetByID(id uuid.UUID) (*models.FloorPlan, error)
+	GetByBuildingFloor(building string, floor int) (*models.FloorPlan, error)
+	GetAll(building string) ([]*models.FloorPlan, error)
+	Delete(id uuid.UUID) error
+
+	// ========================================
+	// SEAT AND NEIGHBORHOOD OPERATIONS
+	// ========================================
+	ReplaceSeats(planID uuid.UUID, seats []*models.FloorPlanSeat) error
+	CreateNeighborhood(neighborhood *models.Neighborhood) (*models.Neighborhood, error)
+	GetNeighborhoodByID(id uuid.UUID) (*models.Neighborhood, error)
+	DeleteNeighborhood(id uuid.UUID) error
+	GetNeighborhoodsBySpaces(spaceIDs []uuid.UUID) (map[uuid.UUID]*models.Neighborhood, error)
+}
@@ -0,0 +1,17 @@
+// internal/repositories/interfaces/integration_key_repository.go
+package interfaces
+
+import (
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// IntegrationKeyRepositoryInterface defines the contract for integration key data operations
+type IntegrationKeyRepositoryInterface interface {
+	Create(key *models.IntegrationKey) (*models.IntegrationKey, error)
+	GetByID(id uuid.UUID) (*models.IntegrationKey, error)
+	GetByKeyHash(keyHash string) (*models.IntegrationKey, error)
+	GetAll() ([]*models.IntegrationKey, error)
+	Update(id uuid.UUID, updates map[string]interface{}) error
+}
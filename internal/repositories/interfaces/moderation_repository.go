@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ModerationRepository defines the interface for chat moderation database operations
+type ModerationRepository interface {
+	// Report operations
+	CreateReport(ctx context.Context, report *models.MessageReport) error
+	GetReportByID(ctx context.Context, id uuid.UUID) (*models.MessageReport, error)
+	GetReports(ctx context.Context, status string, limit, offset int) ([]models.MessageReport, int64, error)
+	UpdateReport(ctx context.Context, report *models.MessageReport) error
+	HasPendingReport(ctx context.Context, messageID, reporterID uuid.UUID) (bool, error)
+	ResolvePendingReportsForMessage(ctx context.Context, messageID uuid.UUID, action models.ModerationAction, resolvedByID uuid.UUID) error
+
+	// Ban operations
+	CreateBan(ctx context.Context, ban *models.ChatBan) error
+	GetBanByID(ctx context.Context, id uuid.UUID) (*models.ChatBan, error)
+	GetActiveBan(ctx context.Context, userID uuid.UUID, now time.Time) (*models.ChatBan, error)
+	GetActiveBans(ctx context.Context, now time.Time, limit, offset int) ([]models.ChatBan, int64, error)
+	RevokeBan(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+}
@@ -0,0 +1,22 @@
+// internal/repositories/interfaces/notification_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// NotificationRepositoryInterface defines the contract for notification data operations
+type NotificationRepositoryInterface interface {
+	Create(notification *models.Notification) (*models.Notification, error)
+	GetByID(id uuid.UUID) (*models.Notification, error)
+	Update(id uuid.UUID, updates map[string]interface{}) error
+	GetUserNotifications(userID uuid.UUID, unreadOnly bool, offset, limit int) ([]*models.Notification, int64, error)
+	GetPendingDeliveries(before time.Time, limit int) ([]*models.Notification, error)
+	CountPendingDeliveries(before time.Time) (int64, error)
+	MarkAsRead(id uuid.UUID, readAt time.Time) error
+	CountUnread(userID uuid.UUID) (int64, error)
+}
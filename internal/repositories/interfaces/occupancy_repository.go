@@ -0,0 +1,29 @@
+// internal/repositories/interfaces/occupancy_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// OccupancyRepositoryInterface defines the contract for occupancy sensor data operations
+type OccupancyRepositoryInterface interface {
+	// Sensors
+	CreateSensor(sensor *models.OccupancySensor) (*models.OccupancySensor, error)
+	GetSensorByID(id uuid.UUID) (*models.OccupancySensor, error)
+	GetSensorByKeyHash(keyHash string) (*models.OccupancySensor, error)
+	GetSensorsBySpace(spaceID uuid.UUID) ([]*models.OccupancySensor, error)
+	UpdateSensor(id uuid.UUID, updates map[string]interface{}) error
+
+	// Samples
+	CreateSample(sample *models.OccupancySample) error
+	GetSamples(spaceID uuid.UUID, from, to time.Time, limit int) ([]*models.OccupancySample, error)
+	DeleteSamplesBefore(before time.Time) (int64, error)
+
+	// Live occupancy
+	RecordSpaceOccupancy(spaceID uuid.UUID, headcount int, recordedAt time.Time) error
+	GetSpacesEmptySince(before, reportedAfter time.Time) ([]*models.Space, error)
+}
@@ -0,0 +1,19 @@
+// internal/repositories/interfaces/outbox_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// OutboxRepositoryInterface defines the contract for outbox event data operations. Events are
+// written by the repositories whose changes they describe, in the same transaction.
+type OutboxRepositoryInterface interface {
+	ClaimDue(now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error)
+	Update(id uuid.UUID, updates map[string]interface{}) error
+	GetPendingStats() (int64, *time.Time, error)
+	DeleteDispatchedBefore(cutoff time.Time) (int64, error)
+}
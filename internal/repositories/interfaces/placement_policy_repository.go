@@ -0,0 +1,15 @@
+// internal/repositories/interfaces/placement_policy_repository.go
+package interfaces
+
+import (
+	"room-reservation-api/internal/models"
+)
+
+// PlacementPolicyRepositoryInterface defines the contract for building placement policy data operations
+type PlacementPolicyRepositoryInterface interface {
+	GetAll() ([]*models.PlacementPolicy, error)
+	GetByBuilding(building string) (*models.PlacementPolicy, error)
+	// Save creates the building's policy or replaces its strategy
+	Save(policy *models.PlacementPolicy) (*models.PlacementPolicy, error)
+	Delete(building string) error
+}
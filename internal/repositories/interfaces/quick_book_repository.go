@@ -0,0 +1,29 @@
+// internal/repositories/interfaces/quick_book_repository.go
+package interfaces
+
+import (
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// QuickBookRepositoryInterface defines the contract for favorite spaces and booking templates
+type QuickBookRepositoryInterface interface {
+	// ========================================
+	// FAVORITE SPACES
+	// ========================================
+	// AddFavorite returns false when the space was already a favorite
+	AddFavorite(favorite *models.FavoriteSpace) (bool, error)
+	RemoveFavorite(userID, spaceID uuid.UUID) (bool, error)
+	GetFavorites(userID uuid.UUID) ([]*models.FavoriteSpace, error)
+
+	// ========================================
+	// BOOKING TEMPLATES
+	// ========================================
+	CreateTemplate(template *models.BookingTemplate) (*models.BookingTemplate, error)
+	GetTemplateByID(id uuid.UUID) (*models.BookingTemplate, error)
+	GetTemplates(userID uuid.UUID) ([]*models.BookingTemplate, error)
+	CountTemplates(userID uuid.UUID) (int64, error)
+	UpdateTemplate(id uuid.UUID, updates map[string]interface{}) (*models.BookingTemplate, error)
+	DeleteTemplate(id uuid.UUID) error
+}
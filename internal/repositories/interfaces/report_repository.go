@@ -0,0 +1,32 @@
+// internal/repositories/interfaces/report_repository.go
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ReportRepositoryInterface defines the contract for custom reports and their background runs.
+// Definitions only name fields of the report catalog; the SQL behind them never comes from the request.
+type ReportRepositoryInterface interface {
+	// ========================================
+	// REPORTS
+	// ========================================
+	Catalog() dto.ReportCatalog
+	CheckDefinition(definition *dto.ReportDefinition) error
+	Run(ctx context.Context, definition *dto.ReportDefinition, timeout time.Duration) (*dto.ReportResult, error)
+
+	// ========================================
+	// BACKGROUND RUNS
+	// ========================================
+	CreateRun(run *models.ReportRun) (*models.ReportRun, error)
+	GetRunByID(id uuid.UUID) (*models.ReportRun, error)
+	UpdateRun(id uuid.UUID, updates map[string]interface{}) error
+	ClaimNextRun(staleBefore time.Time) (*models.ReportRun, error)
+	DeleteRunsBefore(cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,31 @@
+// internal/repositories/interfaces/reservation_checklist_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ReservationChecklistRepositoryInterface defines the contract for reservation checklist data operations
+type ReservationChecklistRepositoryInterface interface {
+	// ========================================
+	// TASK OPERATIONS
+	// ========================================
+	CreateTasks(tasks []*models.ReservationTask) error
+	GetTaskByID(id uuid.UUID) (*models.ReservationTask, error)
+	GetTasksByReservation(reservationID uuid.UUID) ([]*models.ReservationTask, error)
+	UpdateTask(id uuid.UUID, updates map[string]interface{}) (*models.ReservationTask, error)
+	DeleteTask(id uuid.UUID) error
+	GetDueReminders(now time.Time, limit int) ([]*models.ReservationTask, error)
+
+	// ========================================
+	// TEMPLATE OPERATIONS
+	// ========================================
+	GetTemplates() ([]*models.ChecklistTemplate, error)
+	GetTemplate(spaceType models.SpaceType) (*models.ChecklistTemplate, error)
+	SaveTemplate(template *models.ChecklistTemplate) (*models.ChecklistTemplate, error)
+	DeleteTemplate(spaceType models.SpaceType) error
+}
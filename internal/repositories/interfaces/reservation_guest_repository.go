@@ -0,0 +1,24 @@
+// internal/repositories/interfaces/reservation_guest_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ReservationGuestRepositoryInterface defines the contract for reservation guest data operations
+type ReservationGuestRepositoryInterface interface {
+	Create(guest *models.ReservationGuest) (*models.ReservationGuest, error)
+	GetByID(id uuid.UUID) (*models.ReservationGuest, error)
+	GetByToken(token string) (*models.ReservationGuest, error)
+	GetByReservation(reservationID uuid.UUID) ([]*models.ReservationGuest, error)
+	CountByReservation(reservationID uuid.UUID) (int64, error)
+	Update(id uuid.UUID, updates map[string]interface{}) error
+	Delete(id uuid.UUID) error
+	// GetExpectedVisitors lists guests of active reservations in a building starting within the window,
+	// leaving out those who declined
+	GetExpectedVisitors(building string, windowStart, windowEnd time.Time) ([]*models.ReservationGuest, error)
+}
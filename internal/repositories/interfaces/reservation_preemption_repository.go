@@ -0,0 +1,16 @@
+// internal/repositories/interfaces/reservation_preemption_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ReservationPreemptionRepositoryInterface defines the contract for preemption audit data operations.
+// Preemptions are recorded with the preempting reservation, see ReservationRepositoryInterface.CreatePreempting.
+type ReservationPreemptionRepositoryInterface interface {
+	GetPreemptions(startDate, endDate time.Time, spaceID *uuid.UUID, offset, limit int) ([]*models.ReservationPreemption, int64, error)
+}
@@ -2,31 +2,48 @@
 package interfaces
 
 import (
+	"errors"
 	"time"
 
+	"room-reservation-api/internal/dto"
 	"room-reservation-api/internal/models"
 
 	"github.com/google/uuid"
 )
 
+// ErrNoFreeWindow is returned when a space is not free long enough to book it right away
+var ErrNoFreeWindow = errors.New("no free window")
+
+// ErrSpaceTaken is returned when a reservation cannot move because the new space is not free
+var ErrSpaceTaken = errors.New("space is not free")
+
 // ReservationRepositoryInterface defines the contract for reservation data operations
 type ReservationRepositoryInterface interface {
 	// ========================================
 	// BASIC CRUD OPERATIONS
 	// ========================================
 	Create(reservation *models.Reservation) (*models.Reservation, error)
+	// CreatePreempting cancels the pending reservations of the preemptions and creates the reservation
+	// in their place, recording the preemptions; gorm.ErrRecordNotFound when one is no longer pending
+	CreatePreempting(reservation *models.Reservation, preemptions []*models.ReservationPreemption) (*models.Reservation, error)
 	GetByID(id uuid.UUID) (*models.Reservation, error)
+	// Update and UpdateIfUnmodified move open checklist due times along when start_time changes
 	Update(id uuid.UUID, updates map[string]interface{}) (*models.Reservation, error)
+	UpdateIfUnmodified(id uuid.UUID, updatedAt time.Time, updates map[string]interface{}) (*models.Reservation, error)
 	Delete(id uuid.UUID) error
 	GetAll(offset, limit int) ([]*models.Reservation, int64, error)
 
 	// ========================================
 	// USER-SPECIFIC OPERATIONS
 	// ========================================
-	GetUserReservations(userID uuid.UUID, offset, limit int) ([]*models.Reservation, int64, error)
+	// Lists load the relations in include, or all of them when nil
+	GetUserReservations(userID uuid.UUID, offset, limit int, include dto.ReservationIncludes) ([]*models.Reservation, int64, error)
+	GetUserReservationsAfter(userID uuid.UUID, after *dto.Cursor, limit int, include dto.ReservationIncludes) ([]*models.Reservation, error)
 	GetUserUpcomingReservations(userID uuid.UUID, limit int) ([]*models.Reservation, error)
+	GetUserOverlappingReservations(userID uuid.UUID, startTime, endTime time.Time, excludeReservationID *uuid.UUID) ([]*models.Reservation, error)
 	GetUserPastReservations(userID uuid.UUID, offset, limit int) ([]*models.Reservation, int64, error)
 	GetUserActiveReservation(userID uuid.UUID) (*models.Reservation, error)
+	GetUserReservationsStartingBetween(userID uuid.UUID, startTime, endTime time.Time) ([]*models.Reservation, error)
 	HasActiveReservationsForSpace(spaceID uuid.UUID) (bool, error)
 
 	// ========================================
@@ -36,19 +53,34 @@ type ReservationRepositoryInterface interface {
 	GetSpaceReservationsByDateRange(spaceID uuid.UUID, startDate, endDate time.Time, offset, limit int) ([]*models.Reservation, int64, error)
 	GetConflictingReservations(spaceID uuid.UUID, startTime, endTime time.Time) ([]*models.Reservation, error)
 	CheckTimeSlotAvailability(spaceID uuid.UUID, startTime, endTime time.Time, excludeReservationID *uuid.UUID) (bool, error)
+	// GetBusyWindows lists when the spaces are booked, add-on bookings included, without loading the reservations
+	GetBusyWindows(spaceIDs []uuid.UUID, startTime, endTime time.Time) ([]BusyWindow, error)
+	// CreateInFreeWindow books from StartTime until EndTime or the next booking, whichever comes first,
+	// while holding a lock on the space. EndTime is shortened in place; returns ErrNoFreeWindow when
+	// less than minDuration is free.
+	CreateInFreeWindow(reservation *models.Reservation, minDuration time.Duration) (*models.Reservation, error)
+	// MoveToSpace moves an active reservation from one space to another at the new space's cost,
+	// while holding a lock on it. Returns ErrSpaceTaken when the new space is not free and
+	// gorm.ErrRecordNotFound when the reservation is no longer active in the old space.
+	MoveToSpace(id, fromSpaceID, toSpaceID uuid.UUID) (*models.Reservation, error)
 
 	// ========================================
 	// APPROVAL OPERATIONS
 	// ========================================
 	GetPendingApprovals(managerID uuid.UUID, offset, limit int) ([]*models.Reservation, int64, error)
+	// Decisions only apply to pending reservations; gorm.ErrRecordNotFound when it was decided already
 	ApproveReservation(id uuid.UUID, approverID uuid.UUID, comments string) error
+	RecordFirstApproval(id uuid.UUID, approverID uuid.UUID, comments string) error
 	RejectReservation(id uuid.UUID, approverID uuid.UUID, reason string) error
 
 	// ========================================
 	// CHECK-IN/OUT OPERATIONS
 	// ========================================
 	CheckIn(id uuid.UUID, checkInTime time.Time) error
-	CheckOut(id uuid.UUID, checkOutTime time.Time) error
+	// CheckOut completes the reservation and records its session in one transaction; updates
+	// are further columns changed along with it, e.g. an earlier end time. A released session
+	// also announces the freed time with a reservation_released event.
+	CheckOut(session *models.ReservationSession, updates map[string]interface{}) error
 
 	// ========================================
 	// SEARCH AND FILTER
@@ -56,6 +88,7 @@ type ReservationRepositoryInterface interface {
 	SearchReservations(filters map[string]interface{}, offset, limit int) ([]*models.Reservation, int64, error)
 	GetReservationsByDateRange(startDate, endDate time.Time, offset, limit int) ([]*models.Reservation, int64, error)
 	GetReservationsByStatus(status string, offset, limit int) ([]*models.Reservation, int64, error)
+	GetDepartmentReservations(department string, startDate, endDate time.Time) ([]*models.Reservation, error)
 
 	// ========================================
 	// RECURRING RESERVATIONS
@@ -63,11 +96,24 @@ type ReservationRepositoryInterface interface {
 	CreateBatch(reservations []*models.Reservation) ([]*models.Reservation, error)
 	GetRecurringReservations(parentID uuid.UUID) ([]*models.Reservation, error)
 
+	// ========================================
+	// CO-ORGANIZERS
+	// ========================================
+	SetCoOrganizers(reservationID uuid.UUID, coOrganizers []*models.ReservationCoOrganizer) error
+
 	// ========================================
 	// SIMPLE COUNTS (for basic statistics)
 	// ========================================
 	CountUserReservations(userID uuid.UUID) (int64, error)
+	CountUserReservationsByStatus(userID uuid.UUID) (map[models.ReservationStatus]int64, error)
 	CountSpaceReservations(spaceID uuid.UUID) (int64, error)
+	CountUserReservationsBySpace(userID uuid.UUID, since time.Time) (map[uuid.UUID]int64, error)
+	GetBookedMinutesBySpace(spaceIDs []uuid.UUID, since time.Time) (map[uuid.UUID]float64, error)
+
+	// ========================================
+	// CHARGEBACK
+	// ========================================
+	GetChargebackTotals(startTime, endTime time.Time) ([]ChargebackTotal, error)
 }
 
 // ReservationFilters represents search filters (simplified)
@@ -80,6 +126,25 @@ type ReservationFilters struct {
 	Title     *string    `json:"title,omitempty"`
 }
 
+// BusyWindow is when a space is booked, and nothing else about the booking
+type BusyWindow struct {
+	SpaceID   uuid.UUID
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// ChargebackTotal is what one department was charged for the reservations it made in a month
+// against one cost center
+type ChargebackTotal struct {
+	Month        string // YYYY-MM
+	Department   string // Empty for users without a department
+	CostCenter   string // Empty for reservations without one
+	Reservations int64
+	Minutes      float64
+	Cost         float64 // Spaces only
+	ServicesCost float64
+}
+
 // ReservationSummary represents basic reservation statistics
 type ReservationSummary struct {
 	TotalReservations int            `json:"total_reservations"`
@@ -0,0 +1,21 @@
+// internal/repositories/interfaces/reservation_session_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ReservationSessionRepositoryInterface defines the contract for check-in session data operations.
+// Sessions are created with the check-out, see ReservationRepositoryInterface.CheckOut.
+type ReservationSessionRepositoryInterface interface {
+	GetByReservation(reservationID uuid.UUID) (*models.ReservationSession, error)
+	// GetOverdueCheckOuts retrieves confirmed reservations checked in but not out that ended before the given time
+	GetOverdueCheckOuts(endedBefore time.Time, limit int) ([]*models.Reservation, error)
+	// GetSummaryBySpace aggregates the sessions checked out within a date range per space, least
+	// used first. A non-nil managerID limits the result to the spaces that user manages.
+	GetSummaryBySpace(startDate, endDate time.Time, managerID *uuid.UUID, spaceID *uuid.UUID) ([]*models.ReservationSessionSummary, error)
+}
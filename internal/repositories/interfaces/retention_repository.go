@@ -0,0 +1,21 @@
+// internal/repositories/interfaces/retention_repository.go
+package interfaces
+
+import (
+	"time"
+)
+
+// RetentionRepositoryInterface defines the contract for applying data retention rules
+type RetentionRepositoryInterface interface {
+	// Reservations that ended before the cutoff and still carry personal details
+	CountReservationsToAnonymize(endedBefore time.Time) (int64, error)
+	AnonymizeReservations(endedBefore, at time.Time) (int64, error)
+
+	// Chat messages sent before the cutoff; purging returns the URLs of their attachment files
+	CountMessages(before time.Time) (int64, error)
+	PurgeMessages(before time.Time) ([]string, int64, error)
+
+	// Sent or failed notifications, finished webhook deliveries and resolved dead letters
+	CountAuditLogs(before time.Time) (int64, error)
+	PurgeAuditLogs(before time.Time) (int64, error)
+}
@@ -0,0 +1,17 @@
+// internal/repositories/interfaces/room_display_repository.go
+package interfaces
+
+import (
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// RoomDisplayRepositoryInterface defines the contract for room display data operations
+type RoomDisplayRepositoryInterface interface {
+	Create(display *models.RoomDisplay) (*models.RoomDisplay, error)
+	GetByID(id uuid.UUID) (*models.RoomDisplay, error)
+	GetByKeyHash(keyHash string) (*models.RoomDisplay, error)
+	GetBySpace(spaceID uuid.UUID) ([]*models.RoomDisplay, error)
+	Update(id uuid.UUID, updates map[string]interface{}) error
+}
@@ -0,0 +1,19 @@
+// internal/repositories/interfaces/room_swap_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// RoomSwapRepositoryInterface defines the contract for room swap suggestion data operations
+type RoomSwapRepositoryInterface interface {
+	Create(suggestion *models.RoomSwapSuggestion) (*models.RoomSwapSuggestion, error)
+	GetByToken(token string) (*models.RoomSwapSuggestion, error)
+	Update(id uuid.UUID, updates map[string]interface{}) error
+	HasSuggestionForReservation(reservationID uuid.UUID) (bool, error)
+	ExpireStale(now time.Time) (int64, error)
+}
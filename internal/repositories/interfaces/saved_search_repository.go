@@ -0,0 +1,23 @@
+// internal/repositories/interfaces/saved_search_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// SavedSearchRepositoryInterface defines the contract for saved space search data operations
+type SavedSearchRepositoryInterface interface {
+	Create(search *models.SavedSearch) (*models.SavedSearch, error)
+	GetByID(id uuid.UUID) (*models.SavedSearch, error)
+	GetByUser(userID uuid.UUID) ([]*models.SavedSearch, error)
+	CountByUser(userID uuid.UUID) (int64, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*models.SavedSearch, error)
+	Delete(id uuid.UUID) error
+
+	// GetAlertCandidates lists the searches with alerts on whose window overlaps the time range
+	GetAlertCandidates(startTime, endTime time.Time) ([]*models.SavedSearch, error)
+}
@@ -0,0 +1,41 @@
+// internal/repositories/interfaces/service_request_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ServiceRequestRepositoryInterface defines the contract for service catalog and service request data operations
+type ServiceRequestRepositoryInterface interface {
+	// ========================================
+	// CATALOG OPERATIONS
+	// ========================================
+	CreateItem(item *models.ServiceItem) error
+	GetItemByID(id uuid.UUID) (*models.ServiceItem, error)
+	GetItems(activeOnly bool) ([]*models.ServiceItem, error)
+	UpdateItem(id uuid.UUID, updates map[string]interface{}) (*models.ServiceItem, error)
+
+	// ========================================
+	// REQUEST OPERATIONS
+	// ========================================
+	Create(request *models.ServiceRequest) error
+	GetByID(id uuid.UUID) (*models.ServiceRequest, error)
+	GetByReservation(reservationID uuid.UUID) ([]*models.ServiceRequest, error)
+	GetQueue(filters ServiceQueueFilters, offset, limit int) ([]*models.ServiceRequest, int64, error)
+	Update(id uuid.UUID, updates map[string]interface{}) (*models.ServiceRequest, error)
+	MoveOpenForReservation(reservationID uuid.UUID, dueAt time.Time) error
+	CancelOpenForReservation(reservationID uuid.UUID, cancelledAt time.Time) (int64, error)
+}
+
+// ServiceQueueFilters narrows the fulfillment queue of facility staff
+type ServiceQueueFilters struct {
+	Statuses []models.ServiceRequestStatus // Open requests when empty
+	Category *models.ServiceCategory
+	Building *string
+	From     *time.Time // Due at or after
+	To       *time.Time // Due before
+}
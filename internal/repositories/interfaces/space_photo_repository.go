@@ -0,0 +1,19 @@
+// internal/repositories/interfaces/space_photo_repository.go
+package interfaces
+
+import (
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// SpacePhotoRepositoryInterface defines the contract for space photo gallery data operations
+type SpacePhotoRepositoryInterface interface {
+	Create(photo *models.SpacePhoto) (*models.SpacePhoto, error)
+	GetByID(id uuid.UUID) (*models.SpacePhoto, error)
+	// GetBySpace lists the photos of a space in gallery order
+	GetBySpace(spaceID uuid.UUID) ([]*models.SpacePhoto, error)
+	// SaveOrder sets the position of each photo and flags the primary one, in one transaction
+	SaveOrder(spaceID uuid.UUID, photoIDs []uuid.UUID, primaryID uuid.UUID) error
+	Delete(id uuid.UUID) error
+}
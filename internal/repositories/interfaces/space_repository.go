@@ -4,6 +4,7 @@ package interfaces
 import (
 	"time"
 
+	"room-reservation-api/internal/dto"
 	"room-reservation-api/internal/models"
 
 	"github.com/google/uuid"
@@ -17,22 +18,25 @@ type SpaceRepositoryInterface interface {
 	Create(space *models.Space) (*models.Space, error)
 	GetByID(id uuid.UUID) (*models.Space, error)
 	Update(id uuid.UUID, updates map[string]interface{}) (*models.Space, error)
+	UpdateIfUnmodified(id uuid.UUID, updatedAt time.Time, updates map[string]interface{}) (*models.Space, error)
 	Delete(id uuid.UUID) error
-	GetAll(offset, limit int) ([]*models.Space, int64, error)
+	// The viewer, when set, hides spaces in soft launch they are not piloting
+	GetAll(viewer *PilotViewer, offset, limit int) ([]*models.Space, int64, error)
+	GetAllAfter(viewer *PilotViewer, after *dto.Cursor, limit int) ([]*models.Space, error)
 
 	// ========================================
 	// SEARCH AND FILTER OPERATIONS
 	// ========================================
 	SearchSpaces(filters SpaceFilters, offset, limit int) ([]*models.Space, int64, error)
-	GetSpacesByBuilding(building string, offset, limit int) ([]*models.Space, int64, error)
-	GetSpacesByType(spaceType string, offset, limit int) ([]*models.Space, int64, error)
+	GetSpacesByBuilding(building string, viewer *PilotViewer, offset, limit int) ([]*models.Space, int64, error)
+	GetSpacesByType(spaceType string, viewer *PilotViewer, offset, limit int) ([]*models.Space, int64, error)
 	GetSpacesByStatus(status string, offset, limit int) ([]*models.Space, int64, error)
 	GetSpacesByCapacityRange(minCapacity, maxCapacity int, offset, limit int) ([]*models.Space, int64, error)
 
 	// ========================================
 	// AVAILABILITY OPERATIONS
 	// ========================================
-	GetAvailableSpaces(startTime, endTime time.Time, offset, limit int) ([]*models.Space, int64, error)
+	GetAvailableSpaces(startTime, endTime time.Time, viewer *PilotViewer, offset, limit int) ([]*models.Space, int64, error)
 	CheckSpaceAvailability(spaceID uuid.UUID, startTime, endTime time.Time) (bool, error)
 
 	// ========================================
@@ -47,6 +51,8 @@ type SpaceRepositoryInterface interface {
 	GetDistinctFloors() ([]int, error)
 	ExistsByNameAndBuilding(name, building string) (bool, error)
 	ExistsByNameAndBuildingExcluding(name, building string, excludeID uuid.UUID) (bool, error)
+	// GetCombinedSpacesIncluding retrieves the combined spaces the space is a part of
+	GetCombinedSpacesIncluding(partID uuid.UUID) ([]*models.Space, error)
 
 	// ========================================
 	// SIMPLE COUNTS
@@ -71,4 +77,22 @@ type SpaceFilters struct {
 	AvailableEnd     *time.Time `json:"available_end,omitempty"`
 	SortBy           string     `json:"sort_by,omitempty"`    // name, capacity, created_at
 	SortOrder        string     `json:"sort_order,omitempty"` // asc, desc
+
+	// Resource class and its attributes
+	ResourceClass     string `json:"resource_class,omitempty"` // room, desk, parking, equipment
+	MinMonitors       *int   `json:"min_monitors,omitempty"`
+	StandingDesk      *bool  `json:"standing_desk,omitempty"`
+	DockingStation    *bool  `json:"docking_station,omitempty"`
+	EVCharging        *bool  `json:"ev_charging,omitempty"`
+	EquipmentCategory string `json:"equipment_category,omitempty"`
+
+	// Hide spaces in soft launch from viewers outside their pilot group; nil shows every space
+	PilotViewer *PilotViewer `json:"-"`
+}
+
+// PilotViewer identifies who is browsing or booking spaces, to match them against the
+// pilot groups of spaces in soft launch
+type PilotViewer struct {
+	UserID     uuid.UUID // uuid.Nil for anonymous viewers
+	Department string
 }
@@ -0,0 +1,30 @@
+// internal/repositories/interfaces/team_block_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// TeamBlockRepositoryInterface defines the contract for team block-booking data operations
+type TeamBlockRepositoryInterface interface {
+	Create(block *models.TeamBlock) (*models.TeamBlock, error)
+	GetByID(id uuid.UUID) (*models.TeamBlock, error)
+	// GetAll lists the blocks of a department, or of every department when empty
+	GetAll(department string) ([]*models.TeamBlock, error)
+	// Cancel ends the block and cancels its reservations starting after cancelledAt,
+	// returning how many were cancelled
+	Cancel(id uuid.UUID, cancelledAt time.Time) (int64, error)
+	// GetReservations retrieves the bookings of the block starting within the time range, cancelled ones excepted
+	GetReservations(blockID uuid.UUID, startTime, endTime time.Time) ([]*models.Reservation, error)
+
+	// ========================================
+	// REMOTE DAYS
+	// ========================================
+	AddException(exception *models.TeamBlockException) error
+	RemoveException(blockID, userID uuid.UUID, date time.Time) error
+	GetExceptions(blockID uuid.UUID, startDate, endDate time.Time) ([]*models.TeamBlockException, error)
+}
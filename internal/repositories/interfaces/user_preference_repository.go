@@ -0,0 +1,15 @@
+// internal/repositories/interfaces/user_preference_repository.go
+package interfaces
+
+import (
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// UserPreferenceRepositoryInterface defines the contract for user preference data operations
+type UserPreferenceRepositoryInterface interface {
+	Create(preference *models.UserPreference) (*models.UserPreference, error)
+	GetByUser(userID uuid.UUID) (*models.UserPreference, error)
+	Update(id uuid.UUID, updates map[string]interface{}) error
+}
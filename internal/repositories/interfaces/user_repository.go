@@ -24,6 +24,7 @@ type UserRepositoryInterface interface {
 	Activate(id uuid.UUID) error
 	Deactivate(id uuid.UUID) error
 	UpdateRole(id uuid.UUID, role models.UserRole) error
+	UpdatePriorityTier(id uuid.UUID, tier int) error
 	UpdatePassword(id uuid.UUID, passwordHash string) error
 	UpdateLastLogin(id uuid.UUID) error
 
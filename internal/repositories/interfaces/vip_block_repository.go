@@ -0,0 +1,27 @@
+// internal/repositories/interfaces/vip_block_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// VIPBlockRepositoryInterface defines the contract for VIP block data operations
+type VIPBlockRepositoryInterface interface {
+	// ========================================
+	// BLOCK OPERATIONS
+	// ========================================
+	Create(block *models.VIPBlock) (*models.VIPBlock, error)
+	GetByID(id uuid.UUID) (*models.VIPBlock, error)
+	Delete(id uuid.UUID) error
+	GetActiveBlocksBySpace(spaceID uuid.UUID) ([]*models.VIPBlock, error)
+
+	// ========================================
+	// VIOLATION OPERATIONS
+	// ========================================
+	CreateViolation(violation *models.VIPBlockViolation) error
+	GetViolations(startDate, endDate time.Time, spaceID *uuid.UUID, offset, limit int) ([]*models.VIPBlockViolation, int64, error)
+}
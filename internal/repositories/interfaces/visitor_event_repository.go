@@ -0,0 +1,23 @@
+// internal/repositories/interfaces/visitor_event_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// VisitorEventRepositoryInterface defines the contract for visitor event data operations.
+// Guest changes write their events themselves, see ReservationGuestRepositoryInterface.
+type VisitorEventRepositoryInterface interface {
+	// GetAfter retrieves the events of the buildings, every building if none, that come after
+	// the cursor, oldest first
+	GetAfter(buildings []string, after *dto.Cursor, limit int) ([]*models.VisitorEvent, error)
+	// AppendForReservation writes an event for every guest of the reservation. The event IDs
+	// derive from sourceID, so appending again for the same source writes nothing.
+	AppendForReservation(reservationID uuid.UUID, eventType string, sourceID uuid.UUID) error
+	DeleteBefore(cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,30 @@
+// internal/repositories/interfaces/webhook_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// WebhookRepositoryInterface defines the contract for webhook subscription and delivery data operations
+type WebhookRepositoryInterface interface {
+	// Subscriptions
+	CreateSubscription(subscription *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	GetSubscriptionByID(id uuid.UUID) (*models.WebhookSubscription, error)
+	GetSubscriptions() ([]*models.WebhookSubscription, error)
+	GetActiveSubscriptions() ([]*models.WebhookSubscription, error)
+	UpdateSubscription(id uuid.UUID, updates map[string]interface{}) error
+	DeleteSubscription(id uuid.UUID) error
+
+	// Deliveries
+	CreateDeliveries(deliveries []*models.WebhookDelivery) error
+	GetDueDeliveries(before time.Time, limit int) ([]*models.WebhookDelivery, error)
+	// GetPendingStats counts pending deliveries and returns when the oldest was queued
+	GetPendingStats() (int64, *time.Time, error)
+	GetDeliveriesBySubscription(subscriptionID uuid.UUID, limit int) ([]*models.WebhookDelivery, error)
+	GetDeliveryByID(id uuid.UUID) (*models.WebhookDelivery, error)
+	UpdateDelivery(id uuid.UUID, updates map[string]interface{}) error
+}
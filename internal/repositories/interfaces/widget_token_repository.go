@@ -0,0 +1,17 @@
+// internal/repositories/interfaces/widget_token_repository.go
+package interfaces
+
+import (
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// WidgetTokenRepositoryInterface defines the contract for booking widget token data operations
+type WidgetTokenRepositoryInterface interface {
+	Create(token *models.WidgetToken) (*models.WidgetToken, error)
+	GetByID(id uuid.UUID) (*models.WidgetToken, error)
+	GetByTokenHash(tokenHash string) (*models.WidgetToken, error)
+	GetAll() ([]*models.WidgetToken, error)
+	Update(id uuid.UUID, updates map[string]interface{}) error
+}
@@ -0,0 +1,31 @@
+// internal/repositories/interfaces/working_calendar_repository.go
+package interfaces
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// WorkingCalendarRepositoryInterface defines the contract for the regions' holidays and closure days
+type WorkingCalendarRepositoryInterface interface {
+	// ========================================
+	// REGIONS
+	// ========================================
+	GetRegions() ([]*models.CalendarRegion, error)
+	GetRegionByCode(code string) (*models.CalendarRegion, error)
+	GetRegionByBuilding(building string) (*models.CalendarRegion, error)
+	SaveRegion(region *models.CalendarRegion) (*models.CalendarRegion, error)
+	DeleteRegion(code string) error
+
+	// ========================================
+	// DAYS
+	// ========================================
+	CreateDay(day *models.CalendarDay) (*models.CalendarDay, error)
+	DeleteDay(id uuid.UUID) error
+	// GetDays retrieves the days of the regions within the date range, both included; every
+	// region's when regions is nil. The empty region holds the days of every region.
+	GetDays(regions []string, startDate, endDate time.Time) ([]*models.CalendarDay, error)
+}
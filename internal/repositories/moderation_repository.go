@@ -0,0 +1,166 @@
+// internal/repositories/moderation_repository.go
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ModerationRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+// NewModerationRepository creates a new moderation repository instance
+func NewModerationRepository(db *gorm.DB, logger *slog.Logger) *ModerationRepository {
+	return &ModerationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Ensure ModerationRepository implements the interface
+var _ interfaces.ModerationRepository = (*ModerationRepository)(nil)
+
+// Report operations
+
+func (r *ModerationRepository) CreateReport(ctx context.Context, report *models.MessageReport) error {
+	return r.db.WithContext(ctx).Create(report).Error
+}
+
+func (r *ModerationRepository) GetReportByID(ctx context.Context, id uuid.UUID) (*models.MessageReport, error) {
+	var report models.MessageReport
+	err := r.db.WithContext(ctx).
+		Preload("Reporter").
+		Preload("ReportedUser").
+		Preload("ResolvedBy").
+		First(&report, "id = ?", id).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *ModerationRepository) GetReports(ctx context.Context, status string, limit, offset int) ([]models.MessageReport, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.MessageReport{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Oldest first so the queue is worked in order
+	var reports []models.MessageReport
+	err := query.
+		Preload("Reporter").
+		Preload("ReportedUser").
+		Preload("ResolvedBy").
+		Order("created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&reports).Error
+
+	return reports, total, err
+}
+
+func (r *ModerationRepository) UpdateReport(ctx context.Context, report *models.MessageReport) error {
+	return r.db.WithContext(ctx).Save(report).Error
+}
+
+func (r *ModerationRepository) HasPendingReport(ctx context.Context, messageID, reporterID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.MessageReport{}).
+		Where("message_id = ? AND reporter_id = ? AND status = ?", messageID, reporterID, models.ReportStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ResolvePendingReportsForMessage closes other open reports once a message has been handled
+func (r *ModerationRepository) ResolvePendingReportsForMessage(ctx context.Context, messageID uuid.UUID, action models.ModerationAction, resolvedByID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&models.MessageReport{}).
+		Where("message_id = ? AND status = ?", messageID, models.ReportStatusPending).
+		Updates(map[string]interface{}{
+			"status":         models.ReportStatusActioned,
+			"action":         action,
+			"resolved_by_id": resolvedByID,
+			"resolved_at":    time.Now(),
+		}).Error
+}
+
+// Ban operations
+
+func (r *ModerationRepository) CreateBan(ctx context.Context, ban *models.ChatBan) error {
+	return r.db.WithContext(ctx).Create(ban).Error
+}
+
+func (r *ModerationRepository) GetBanByID(ctx context.Context, id uuid.UUID) (*models.ChatBan, error) {
+	var ban models.ChatBan
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Preload("BannedBy").
+		First(&ban, "id = ?", id).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &ban, nil
+}
+
+// GetActiveBan returns the longest running ban of a user, or nil when the user can chat
+func (r *ModerationRepository) GetActiveBan(ctx context.Context, userID uuid.UUID, now time.Time) (*models.ChatBan, error) {
+	var ban models.ChatBan
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("expires_at DESC").
+		First(&ban).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ban, nil
+}
+
+func (r *ModerationRepository) GetActiveBans(ctx context.Context, now time.Time, limit, offset int) ([]models.ChatBan, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.ChatBan{}).
+		Where("revoked_at IS NULL AND expires_at > ?", now)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var bans []models.ChatBan
+	err := query.
+		Preload("User").
+		Preload("BannedBy").
+		Order("expires_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&bans).Error
+
+	return bans, total, err
+}
+
+func (r *ModerationRepository) RevokeBan(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.ChatBan{}).
+		Where("id = ?", id).
+		Update("revoked_at", revokedAt).Error
+}
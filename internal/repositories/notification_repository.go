@@ -0,0 +1,111 @@
+// internal/repositories/notification_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationRepository implements the NotificationRepositoryInterface
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *gorm.DB) interfaces.NotificationRepositoryInterface {
+	return &NotificationRepository{db: db}
+}
+
+// Create creates a new notification, with the outbox event that delivers it in the same transaction
+func (r *NotificationRepository) Create(notification *models.Notification) (*models.Notification, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(notification).Error; err != nil {
+			return err
+		}
+		return appendOutboxEvent(tx, models.OutboxNotificationCreated, notification.ID, map[string]interface{}{
+			"notification_id": notification.ID,
+			"user_id":         notification.UserID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+// GetByID retrieves a notification by ID
+func (r *NotificationRepository) GetByID(id uuid.UUID) (*models.Notification, error) {
+	var notification models.Notification
+	if err := r.db.Where("id = ?", id).First(&notification).Error; err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// Update updates a notification
+func (r *NotificationRepository) Update(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Model(&models.Notification{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// GetUserNotifications retrieves a user's notifications, newest first
+func (r *NotificationRepository) GetUserNotifications(userID uuid.UUID, unreadOnly bool, offset, limit int) ([]*models.Notification, int64, error) {
+	var notifications []*models.Notification
+	var total int64
+
+	query := r.db.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&notifications).Error
+
+	return notifications, total, err
+}
+
+// GetPendingDeliveries retrieves notifications waiting to be delivered
+func (r *NotificationRepository) GetPendingDeliveries(before time.Time, limit int) ([]*models.Notification, error) {
+	var notifications []*models.Notification
+	err := r.db.Preload("User").
+		Where("status = ? AND scheduled_at <= ? AND retry_count < max_retries", models.NotificationStatusPending, before).
+		Order("scheduled_at ASC").
+		Limit(limit).
+		Find(&notifications).Error
+	return notifications, err
+}
+
+// CountPendingDeliveries counts notifications due for delivery that were not delivered yet
+func (r *NotificationRepository) CountPendingDeliveries(before time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).
+		Where("status = ? AND scheduled_at <= ? AND retry_count < max_retries", models.NotificationStatusPending, before).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkAsRead marks a notification as read
+func (r *NotificationRepository) MarkAsRead(id uuid.UUID, readAt time.Time) error {
+	return r.db.Model(&models.Notification{}).
+		Where("id = ? AND read_at IS NULL", id).
+		Update("read_at", readAt).Error
+}
+
+// CountUnread counts a user's unread notifications
+func (r *NotificationRepository) CountUnread(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,125 @@
+// internal/repositories/occupancy_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OccupancyRepository implements the OccupancyRepositoryInterface
+type OccupancyRepository struct {
+	db *gorm.DB
+}
+
+// NewOccupancyRepository creates a new occupancy repository
+func NewOccupancyRepository(db *gorm.DB) interfaces.OccupancyRepositoryInterface {
+	return &OccupancyRepository{db: db}
+}
+
+// ========================================
+// SENSORS
+// ========================================
+
+// CreateSensor registers a new occupancy sensor
+func (r *OccupancyRepository) CreateSensor(sensor *models.OccupancySensor) (*models.OccupancySensor, error) {
+	if err := r.db.Create(sensor).Error; err != nil {
+		return nil, err
+	}
+	return sensor, nil
+}
+
+// GetSensorByID retrieves a sensor by ID
+func (r *OccupancyRepository) GetSensorByID(id uuid.UUID) (*models.OccupancySensor, error) {
+	var sensor models.OccupancySensor
+	err := r.db.Where("id = ?", id).First(&sensor).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sensor, nil
+}
+
+// GetSensorByKeyHash retrieves a sensor by the hash of its API key
+func (r *OccupancyRepository) GetSensorByKeyHash(keyHash string) (*models.OccupancySensor, error) {
+	var sensor models.OccupancySensor
+	err := r.db.Where("api_key_hash = ?", keyHash).First(&sensor).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sensor, nil
+}
+
+// GetSensorsBySpace retrieves the sensors registered for a space
+func (r *OccupancyRepository) GetSensorsBySpace(spaceID uuid.UUID) ([]*models.OccupancySensor, error) {
+	var sensors []*models.OccupancySensor
+	err := r.db.Where("space_id = ?", spaceID).
+		Order("created_at ASC").
+		Find(&sensors).Error
+	return sensors, err
+}
+
+// UpdateSensor updates a sensor
+func (r *OccupancyRepository) UpdateSensor(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Model(&models.OccupancySensor{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// ========================================
+// SAMPLES
+// ========================================
+
+// CreateSample stores a headcount sample
+func (r *OccupancyRepository) CreateSample(sample *models.OccupancySample) error {
+	return r.db.Create(sample).Error
+}
+
+// GetSamples retrieves the samples of a space recorded in a time range, oldest first
+func (r *OccupancyRepository) GetSamples(spaceID uuid.UUID, from, to time.Time, limit int) ([]*models.OccupancySample, error) {
+	var samples []*models.OccupancySample
+	err := r.db.Where("space_id = ? AND recorded_at >= ? AND recorded_at < ?", spaceID, from, to).
+		Order("recorded_at ASC").
+		Limit(limit).
+		Find(&samples).Error
+	return samples, err
+}
+
+// DeleteSamplesBefore removes samples recorded before a time
+func (r *OccupancyRepository) DeleteSamplesBefore(before time.Time) (int64, error) {
+	result := r.db.Where("recorded_at < ?", before).Delete(&models.OccupancySample{})
+	return result.RowsAffected, result.Error
+}
+
+// ========================================
+// LIVE OCCUPANCY
+// ========================================
+
+// RecordSpaceOccupancy updates the live headcount of a space unless a newer sample was already
+// applied. The time the room became empty is kept while it stays empty.
+func (r *OccupancyRepository) RecordSpaceOccupancy(spaceID uuid.UUID, headcount int, recordedAt time.Time) error {
+	emptySince := gorm.Expr("NULL")
+	if headcount == 0 {
+		emptySince = gorm.Expr("COALESCE(empty_since, ?)", recordedAt)
+	}
+
+	return r.db.Model(&models.Space{}).
+		Where("id = ?", spaceID).
+		Where("occupancy_updated_at IS NULL OR occupancy_updated_at <= ?", recordedAt).
+		Updates(map[string]interface{}{
+			"current_occupancy":    headcount,
+			"occupancy_updated_at": recordedAt,
+			"empty_since":          emptySince,
+		}).Error
+}
+
+// GetSpacesEmptySince retrieves spaces whose sensors have reported them empty since before
+// a time, skipping spaces whose sensors have gone quiet
+func (r *OccupancyRepository) GetSpacesEmptySince(before, reportedAfter time.Time) ([]*models.Space, error) {
+	var spaces []*models.Space
+	err := r.db.Where("empty_since IS NOT NULL AND empty_since <= ?", before).
+		Where("occupancy_updated_at >= ?", reportedAfter).
+		Find(&spaces).Error
+	return spaces, err
+}
@@ -0,0 +1,116 @@
+// internal/repositories/outbox_repository.go
+package repositories
+
+import (
+	"encoding/json"
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxRepository implements the OutboxRepositoryInterface
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db *gorm.DB) interfaces.OutboxRepositoryInterface {
+	return &OutboxRepository{db: db}
+}
+
+// ClaimDue picks the oldest pending events whose attempt is due and pushes their next attempt
+// back by the lease, so other instances skip them while they are dispatched here. Events of a
+// dispatcher that crashed are picked up again once the lease runs out.
+func (r *OutboxRepository) ClaimDue(now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error) {
+	var events []*models.OutboxEvent
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.OutboxEventPending, now).
+			Order("created_at ASC").
+			Limit(limit).
+			Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(events))
+		for i, event := range events {
+			ids[i] = event.ID
+		}
+		return tx.Model(&models.OutboxEvent{}).Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return events, err
+}
+
+// Update updates an outbox event
+func (r *OutboxRepository) Update(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Model(&models.OutboxEvent{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// GetPendingStats counts events not dispatched yet and returns when the oldest was written
+func (r *OutboxRepository) GetPendingStats() (int64, *time.Time, error) {
+	var stats struct {
+		Count  int64
+		Oldest *time.Time
+	}
+	err := r.db.Model(&models.OutboxEvent{}).
+		Select("COUNT(*) AS count, MIN(created_at) AS oldest").
+		Where("status = ?", models.OutboxEventPending).
+		Scan(&stats).Error
+	return stats.Count, stats.Oldest, err
+}
+
+// DeleteDispatchedBefore removes events dispatched before the cutoff
+func (r *OutboxRepository) DeleteDispatchedBefore(cutoff time.Time) (int64, error) {
+	result := r.db.Where("status = ? AND dispatched_at < ?", models.OutboxEventDispatched, cutoff).
+		Delete(&models.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
+
+// ========================================
+// WRITING EVENTS
+// ========================================
+
+// appendOutboxEvent writes an event within the transaction of the change it describes
+func appendOutboxEvent(tx *gorm.DB, eventType string, aggregateID uuid.UUID, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&models.OutboxEvent{
+		EventType:   eventType,
+		AggregateID: aggregateID,
+		Payload:     datatypes.JSON(body),
+		Status:      models.OutboxEventPending,
+	}).Error
+}
+
+// appendReservationEvent writes the reservation as the transaction sees it to the outbox
+func appendReservationEvent(tx *gorm.DB, eventType string, reservationID uuid.UUID) error {
+	var reservation models.Reservation
+	if err := tx.Preload("CoOrganizers").Where("id = ?", reservationID).First(&reservation).Error; err != nil {
+		return err
+	}
+	return appendOutboxEvent(tx, eventType, reservation.ID, models.NewReservationChange(&reservation))
+}
+
+// reservationUpdateEvent names the event of a reservation update
+func reservationUpdateEvent(updates map[string]interface{}) string {
+	if status, ok := updates["status"]; ok {
+		switch status {
+		case models.StatusCancelled, string(models.StatusCancelled):
+			return models.OutboxReservationCancelled
+		}
+	}
+	return models.OutboxReservationUpdated
+}
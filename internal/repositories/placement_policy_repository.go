@@ -0,0 +1,61 @@
+// internal/repositories/placement_policy_repository.go
+package repositories
+
+import (
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PlacementPolicyRepository implements the PlacementPolicyRepositoryInterface
+type PlacementPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewPlacementPolicyRepository creates a new placement policy repository
+func NewPlacementPolicyRepository(db *gorm.DB) interfaces.PlacementPolicyRepositoryInterface {
+	return &PlacementPolicyRepository{db: db}
+}
+
+// GetAll retrieves the policies of all buildings
+func (r *PlacementPolicyRepository) GetAll() ([]*models.PlacementPolicy, error) {
+	var policies []*models.PlacementPolicy
+	err := r.db.Order("building ASC").Find(&policies).Error
+	return policies, err
+}
+
+// GetByBuilding retrieves the policy of a building
+func (r *PlacementPolicyRepository) GetByBuilding(building string) (*models.PlacementPolicy, error) {
+	var policy models.PlacementPolicy
+	err := r.db.Where("building = ?", building).First(&policy).Error
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Save creates the building's policy or replaces its strategy
+func (r *PlacementPolicyRepository) Save(policy *models.PlacementPolicy) (*models.PlacementPolicy, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "building"}},
+		DoUpdates: clause.AssignmentColumns([]string{"strategy", "updated_by_id", "updated_at"}),
+	}).Create(policy).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByBuilding(policy.Building)
+}
+
+// Delete removes the policy of a building
+func (r *PlacementPolicyRepository) Delete(building string) error {
+	result := r.db.Where("building = ?", building).Delete(&models.PlacementPolicy{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// internal/repositories/quick_book_repository.go
+package repositories
+
+import (
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// QuickBookRepository implements the QuickBookRepositoryInterface
+type QuickBookRepository struct {
+	db *gorm.DB
+}
+
+// NewQuickBookRepository creates a new favorite space and booking template repository
+func NewQuickBookRepository(db *gorm.DB) interfaces.QuickBookRepositoryInterface {
+	return &QuickBookRepository{db: db}
+}
+
+// ========================================
+// FAVORITE SPACES
+// ========================================
+
+// AddFavorite marks a space as a favorite of a user, returning false if it already was
+func (r *QuickBookRepository) AddFavorite(favorite *models.FavoriteSpace) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(favorite)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RemoveFavorite unmarks a favorite space, returning false if it was not a favorite
+func (r *QuickBookRepository) RemoveFavorite(userID, spaceID uuid.UUID) (bool, error) {
+	result := r.db.Where("user_id = ? AND space_id = ?", userID, spaceID).Delete(&models.FavoriteSpace{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetFavorites retrieves the favorite spaces of a user that still exist, most recent first
+func (r *QuickBookRepository) GetFavorites(userID uuid.UUID) ([]*models.FavoriteSpace, error) {
+	var favorites []*models.FavoriteSpace
+	err := r.db.Joins("Space").
+		Where("favorite_spaces.user_id = ?", userID).
+		Order("favorite_spaces.created_at DESC").
+		Find(&favorites).Error
+	return favorites, err
+}
+
+// ========================================
+// BOOKING TEMPLATES
+// ========================================
+
+// CreateTemplate creates a new booking template
+func (r *QuickBookRepository) CreateTemplate(template *models.BookingTemplate) (*models.BookingTemplate, error) {
+	if err := r.db.Create(template).Error; err != nil {
+		return nil, err
+	}
+	return r.GetTemplateByID(template.ID)
+}
+
+// GetTemplateByID retrieves a booking template by ID
+func (r *QuickBookRepository) GetTemplateByID(id uuid.UUID) (*models.BookingTemplate, error) {
+	var template models.BookingTemplate
+	err := r.db.Preload("Space").Where("id = ?", id).First(&template).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetTemplates retrieves the booking templates of a user by name
+func (r *QuickBookRepository) GetTemplates(userID uuid.UUID) ([]*models.BookingTemplate, error) {
+	var templates []*models.BookingTemplate
+	err := r.db.Preload("Space").
+		Where("user_id = ?", userID).
+		Order("name ASC").
+		Find(&templates).Error
+	return templates, err
+}
+
+// CountTemplates counts the booking templates of a user
+func (r *QuickBookRepository) CountTemplates(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.BookingTemplate{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// UpdateTemplate updates a booking template
+func (r *QuickBookRepository) UpdateTemplate(id uuid.UUID, updates map[string]interface{}) (*models.BookingTemplate, error) {
+	if err := r.db.Model(&models.BookingTemplate{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+	return r.GetTemplateByID(id)
+}
+
+// DeleteTemplate deletes a booking template
+func (r *QuickBookRepository) DeleteTemplate(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&models.BookingTemplate{}).Error
+}
@@ -0,0 +1,554 @@
+// internal/repositories/report_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Rows a report returns when its definition sets no limit
+const defaultReportLimit = 1000
+
+// Types of the fields reports filter on
+const (
+	reportText    = "text"
+	reportNumber  = "number"
+	reportUUID    = "uuid"
+	reportBoolean = "boolean"
+)
+
+// reportSource maps the field names of one report source to SQL expressions. Only these
+// expressions, the grain and fixed operators are written into queries; values are parameters.
+type reportSource struct {
+	from       string            // Table and joins, with the aliases the expressions use
+	where      string            // Rows always excluded, such as soft-deleted ones
+	dateField  string            // Name of the field from, to and the date grain apply to
+	dateExpr   string            // SQL of the date field
+	dimensions map[string]string // Group by fields
+	measures   map[string]string // Aggregates
+	filters    map[string]reportField
+}
+
+// reportField is a filterable field and the type of its values
+type reportField struct {
+	expr      string
+	valueType string
+}
+
+// reportSources is the catalog of custom reports
+var reportSources = map[string]reportSource{
+	"reservations": {
+		from:      "reservations r JOIN spaces s ON s.id = r.space_id JOIN users u ON u.id = r.user_id",
+		where:     "r.deleted_at IS NULL",
+		dateField: "start_time",
+		dateExpr:  "r.start_time",
+		dimensions: map[string]string{
+			"status":        "r.status",
+			"space":         "s.name",
+			"space_type":    "s.type",
+			"building":      "s.building",
+			"floor":         "s.floor",
+			"department":    "u.department",
+			"cost_center":   "r.cost_center",
+			"user_role":     "u.role",
+			"is_recurring":  "r.is_recurring",
+			"auto_approved": "r.auto_approved",
+		},
+		measures: map[string]string{
+			"count":                "COUNT(*)",
+			"booked_hours":         "SUM(EXTRACT(EPOCH FROM r.end_time - r.start_time)) / 3600",
+			"avg_duration_minutes": "AVG(EXTRACT(EPOCH FROM r.end_time - r.start_time)) / 60",
+			"participants":         "SUM(r.participant_count)",
+			"avg_participants":     "AVG(r.participant_count)",
+			"cost":                 "SUM(r.cost)",
+			"checked_in":           "COUNT(r.check_in_time)",
+			"check_in_rate":        "AVG(CASE WHEN r.check_in_time IS NOT NULL THEN 1 ELSE 0 END)",
+			"no_shows":             "COUNT(*) FILTER (WHERE r.no_show_reported)",
+			"distinct_users":       "COUNT(DISTINCT r.user_id)",
+			"distinct_spaces":      "COUNT(DISTINCT r.space_id)",
+		},
+		filters: map[string]reportField{
+			"status":            {"r.status", reportText},
+			"space_id":          {"r.space_id", reportUUID},
+			"space_type":        {"s.type", reportText},
+			"building":          {"s.building", reportText},
+			"floor":             {"s.floor", reportNumber},
+			"user_id":           {"r.user_id", reportUUID},
+			"department":        {"u.department", reportText},
+			"cost_center":       {"r.cost_center", reportText},
+			"user_role":         {"u.role", reportText},
+			"participant_count": {"r.participant_count", reportNumber},
+			"cost":              {"r.cost", reportNumber},
+			"is_recurring":      {"r.is_recurring", reportBoolean},
+			"no_show_reported":  {"r.no_show_reported", reportBoolean},
+		},
+	},
+	"spaces": {
+		from:      "spaces s",
+		where:     "s.deleted_at IS NULL",
+		dateField: "created_at",
+		dateExpr:  "s.created_at",
+		dimensions: map[string]string{
+			"type":       "s.type",
+			"building":   "s.building",
+			"floor":      "s.floor",
+			"status":     "s.status",
+			"is_vip":     "s.is_vip",
+			"is_premium": "s.is_premium",
+		},
+		measures: map[string]string{
+			"count":          "COUNT(*)",
+			"total_capacity": "SUM(s.capacity)",
+			"avg_capacity":   "AVG(s.capacity)",
+			"total_surface":  "SUM(s.surface)",
+		},
+		filters: map[string]reportField{
+			"type":       {"s.type", reportText},
+			"building":   {"s.building", reportText},
+			"floor":      {"s.floor", reportNumber},
+			"status":     {"s.status", reportText},
+			"capacity":   {"s.capacity", reportNumber},
+			"is_vip":     {"s.is_vip", reportBoolean},
+			"is_premium": {"s.is_premium", reportBoolean},
+		},
+	},
+	"users": {
+		from:      "users u",
+		where:     "u.deleted_at IS NULL AND u.anonymized_at IS NULL",
+		dateField: "created_at",
+		dateExpr:  "u.created_at",
+		dimensions: map[string]string{
+			"role":       "u.role",
+			"department": "u.department",
+			"position":   "u.position",
+			"is_active":  "u.is_active",
+		},
+		measures: map[string]string{
+			"count":           "COUNT(*)",
+			"active_users":    "COUNT(*) FILTER (WHERE u.is_active)",
+			"signed_in_users": "COUNT(u.last_login_at)",
+		},
+		filters: map[string]reportField{
+			"role":       {"u.role", reportText},
+			"department": {"u.department", reportText},
+			"is_active":  {"u.is_active", reportBoolean},
+		},
+	},
+}
+
+// reportGrainFormats formats the periods of each date grain
+var reportGrainFormats = map[string]string{
+	"hour":    `YYYY-MM-DD"T"HH24:00`,
+	"day":     "YYYY-MM-DD",
+	"week":    "YYYY-MM-DD", // Monday of the week
+	"month":   "YYYY-MM",
+	"quarter": `YYYY-"Q"Q`,
+	"year":    "YYYY",
+}
+
+// reportOperators maps filter operators to SQL; ne also keeps rows where the field is empty
+var reportOperators = map[string]string{
+	"eq":       "= ?",
+	"ne":       "IS DISTINCT FROM ?",
+	"in":       "IN ?",
+	"not_in":   "NOT IN ?",
+	"gt":       "> ?",
+	"gte":      ">= ?",
+	"lt":       "< ?",
+	"lte":      "<= ?",
+	"contains": `ILIKE ? ESCAPE '\'`,
+}
+
+// ReportRepository implements the ReportRepositoryInterface
+type ReportRepository struct {
+	db      *gorm.DB
+	replica *gorm.DB // Runs the reports, which read a lot and tolerate replication lag
+}
+
+// NewReportRepository creates a new report repository. Reports read from the replica when
+// one is given; background runs are stored in db.
+func NewReportRepository(db, replica *gorm.DB) interfaces.ReportRepositoryInterface {
+	if replica == nil {
+		replica = db
+	}
+	return &ReportRepository{db: db, replica: replica}
+}
+
+// ========================================
+// REPORTS
+// ========================================
+
+// Catalog lists the sources, fields, grains and operators definitions can use
+func (r *ReportRepository) Catalog() dto.ReportCatalog {
+	catalog := dto.ReportCatalog{
+		Sources:    make([]dto.ReportSourceCatalog, 0, len(reportSources)),
+		DateGrains: []string{"hour", "day", "week", "month", "quarter", "year"},
+		Operators:  sortedKeys(reportOperators),
+	}
+	for _, name := range sortedKeys(reportSources) {
+		source := reportSources[name]
+		filters := make([]dto.ReportFilterField, 0, len(source.filters))
+		for _, field := range sortedKeys(source.filters) {
+			filters = append(filters, dto.ReportFilterField{Name: field, Type: source.filters[field].valueType})
+		}
+		catalog.Sources = append(catalog.Sources, dto.ReportSourceCatalog{
+			Name:       name,
+			DateField:  source.dateField,
+			Dimensions: sortedKeys(source.dimensions),
+			Measures:   sortedKeys(source.measures),
+			Filters:    filters,
+		})
+	}
+	return catalog
+}
+
+// CheckDefinition checks a definition only uses fields of the catalog, with values of the right type
+func (r *ReportRepository) CheckDefinition(definition *dto.ReportDefinition) error {
+	_, _, _, err := buildReportQuery(definition)
+	return err
+}
+
+// Run executes a report in a read-only transaction that the database cancels after the timeout
+func (r *ReportRepository) Run(ctx context.Context, definition *dto.ReportDefinition, timeout time.Duration) (*dto.ReportResult, error) {
+	query, args, columns, err := buildReportQuery(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := definition.Limit
+	if limit <= 0 {
+		limit = defaultReportLimit
+	}
+
+	result := &dto.ReportResult{Columns: columns, Rows: [][]interface{}{}}
+	err = r.replica.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET TRANSACTION READ ONLY").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(fmt.Sprintf("SET LOCAL statement_timeout = %d", timeout.Milliseconds())).Error; err != nil {
+			return err
+		}
+
+		// One extra row tells whether the result was cut at the limit
+		rows, err := tx.Raw(query+" LIMIT ?", append(args, limit+1)...).Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if len(result.Rows) == limit {
+				result.Truncated = true
+				break
+			}
+			row, err := scanReportRow(rows, columns)
+			if err != nil {
+				return err
+			}
+			result.Rows = append(result.Rows, row)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.RowCount = len(result.Rows)
+	result.GeneratedAt = time.Now().UTC()
+	return result, nil
+}
+
+// ========================================
+// BACKGROUND RUNS
+// ========================================
+
+// CreateRun queues a report run
+func (r *ReportRepository) CreateRun(run *models.ReportRun) (*models.ReportRun, error) {
+	if err := r.db.Create(run).Error; err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// GetRunByID retrieves a report run by ID
+func (r *ReportRepository) GetRunByID(id uuid.UUID) (*models.ReportRun, error) {
+	var run models.ReportRun
+	if err := r.db.Where("id = ?", id).First(&run).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// UpdateRun updates a report run
+func (r *ReportRepository) UpdateRun(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Model(&models.ReportRun{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// ClaimNextRun marks the oldest queued run running and returns it, or nil when none waits. Runs
+// started before staleBefore are taken over, since the instance running them must have stopped.
+func (r *ReportRepository) ClaimNextRun(staleBefore time.Time) (*models.ReportRun, error) {
+	var run models.ReportRun
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND started_at < ?)", models.ReportRunQueued, models.ReportRunRunning, staleBefore).
+			Order("created_at ASC").
+			First(&run).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		run.Status = models.ReportRunRunning
+		run.StartedAt = &now
+		return tx.Model(&models.ReportRun{}).Where("id = ?", run.ID).Updates(map[string]interface{}{
+			"status":     run.Status,
+			"started_at": now,
+		}).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// DeleteRunsBefore removes runs created before the cutoff, with their results
+func (r *ReportRepository) DeleteRunsBefore(cutoff time.Time) (int64, error) {
+	result := r.db.Where("created_at < ?", cutoff).Delete(&models.ReportRun{})
+	return result.RowsAffected, result.Error
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// buildReportQuery turns a definition into parameterized SQL, without its LIMIT. Groups are
+// referenced by position so the timezone parameter is only bound once.
+func buildReportQuery(definition *dto.ReportDefinition) (string, []interface{}, []dto.ReportColumn, error) {
+	source, ok := reportSources[definition.Source]
+	if !ok {
+		return "", nil, nil, fmt.Errorf("unknown report source %q", definition.Source)
+	}
+
+	var selects []string
+	var args []interface{}
+	var columns []dto.ReportColumn
+	seen := make(map[string]bool)
+
+	if definition.DateGrain != "" {
+		format, ok := reportGrainFormats[definition.DateGrain]
+		if !ok {
+			return "", nil, nil, fmt.Errorf("unknown date grain %q", definition.DateGrain)
+		}
+		timezone := definition.Timezone
+		if timezone == "" {
+			timezone = "UTC"
+		}
+		selects = append(selects, fmt.Sprintf("to_char(date_trunc('%s', %s AT TIME ZONE ?), '%s')", definition.DateGrain, source.dateExpr, format))
+		args = append(args, timezone)
+		columns = append(columns, dto.ReportColumn{Name: "period", Kind: "period"})
+		seen["period"] = true
+	}
+
+	for _, name := range definition.Dimensions {
+		expr, ok := source.dimensions[name]
+		if !ok {
+			return "", nil, nil, fmt.Errorf("unknown dimension %q for %s", name, definition.Source)
+		}
+		if seen[name] {
+			return "", nil, nil, fmt.Errorf("column %q is listed twice", name)
+		}
+		seen[name] = true
+		selects = append(selects, fmt.Sprintf("(%s)::text", expr))
+		columns = append(columns, dto.ReportColumn{Name: name, Kind: "dimension"})
+	}
+	groups := len(selects)
+
+	for _, name := range definition.Measures {
+		expr, ok := source.measures[name]
+		if !ok {
+			return "", nil, nil, fmt.Errorf("unknown measure %q for %s", name, definition.Source)
+		}
+		if seen[name] {
+			return "", nil, nil, fmt.Errorf("column %q is listed twice", name)
+		}
+		seen[name] = true
+		selects = append(selects, fmt.Sprintf("(%s)::float8", expr))
+		columns = append(columns, dto.ReportColumn{Name: name, Kind: "measure"})
+	}
+
+	conditions := []string{source.where}
+	if definition.From != nil {
+		conditions = append(conditions, source.dateExpr+" >= ?")
+		args = append(args, *definition.From)
+	}
+	if definition.To != nil {
+		conditions = append(conditions, source.dateExpr+" < ?")
+		args = append(args, *definition.To)
+	}
+	for _, filter := range definition.Filters {
+		field, ok := source.filters[filter.Field]
+		if !ok {
+			return "", nil, nil, fmt.Errorf("unknown filter field %q for %s", filter.Field, definition.Source)
+		}
+		operator, ok := reportOperators[filter.Operator]
+		if !ok {
+			return "", nil, nil, fmt.Errorf("unknown operator %q", filter.Operator)
+		}
+		value, err := reportFilterValue(field, filter)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		conditions = append(conditions, field.expr+" "+operator)
+		args = append(args, value)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", strings.Join(selects, ", "), source.from, strings.Join(conditions, " AND "))
+	if groups > 0 {
+		query += " GROUP BY " + reportPositions(1, groups)
+	}
+
+	switch {
+	case definition.SortBy != "":
+		position := -1
+		for i, column := range columns {
+			if column.Name == definition.SortBy {
+				position = i + 1
+			}
+		}
+		if position < 0 {
+			return "", nil, nil, fmt.Errorf("sort_by %q is not a column of the report", definition.SortBy)
+		}
+		direction := "ASC"
+		if definition.SortOrder == "desc" {
+			direction = "DESC"
+		}
+		query += fmt.Sprintf(" ORDER BY %d %s NULLS LAST", position, direction)
+	case groups > 0:
+		query += " ORDER BY " + reportPositions(1, groups)
+	}
+
+	return query, args, columns, nil
+}
+
+// reportFilterValue converts the JSON value of a filter to the type of its field
+func reportFilterValue(field reportField, filter dto.ReportFilter) (interface{}, error) {
+	switch filter.Operator {
+	case "gt", "gte", "lt", "lte":
+		if field.valueType != reportNumber {
+			return nil, fmt.Errorf("operator %s only applies to number fields, not %s", filter.Operator, filter.Field)
+		}
+	case "contains":
+		if field.valueType != reportText {
+			return nil, fmt.Errorf("operator contains only applies to text fields, not %s", filter.Field)
+		}
+		text, ok := filter.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("value of %s must be text", filter.Field)
+		}
+		return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text) + "%", nil
+	case "in", "not_in":
+		items, ok := filter.Value.([]interface{})
+		if !ok || len(items) == 0 {
+			return nil, fmt.Errorf("value of %s must be a non-empty list", filter.Field)
+		}
+		values := make([]interface{}, len(items))
+		for i, item := range items {
+			value, err := reportScalar(field, filter.Field, item)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+	return reportScalar(field, filter.Field, filter.Value)
+}
+
+// reportScalar checks one filter value has the type of its field
+func reportScalar(field reportField, name string, value interface{}) (interface{}, error) {
+	switch field.valueType {
+	case reportNumber:
+		if number, ok := value.(float64); ok {
+			return number, nil
+		}
+	case reportBoolean:
+		if flag, ok := value.(bool); ok {
+			return flag, nil
+		}
+	case reportUUID:
+		if text, ok := value.(string); ok {
+			if id, err := uuid.Parse(text); err == nil {
+				return id, nil
+			}
+		}
+	default:
+		if text, ok := value.(string); ok {
+			return text, nil
+		}
+	}
+	return nil, fmt.Errorf("value of %s must be a %s", name, field.valueType)
+}
+
+// scanReportRow reads a row as text for periods and dimensions and numbers for measures
+func scanReportRow(rows *sql.Rows, columns []dto.ReportColumn) ([]interface{}, error) {
+	targets := make([]interface{}, len(columns))
+	for i, column := range columns {
+		if column.Kind == "measure" {
+			targets[i] = new(sql.NullFloat64)
+		} else {
+			targets[i] = new(sql.NullString)
+		}
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return nil, err
+	}
+
+	row := make([]interface{}, len(columns))
+	for i, target := range targets {
+		switch value := target.(type) {
+		case *sql.NullFloat64:
+			if value.Valid {
+				row[i] = value.Float64
+			}
+		case *sql.NullString:
+			if value.Valid {
+				row[i] = value.String
+			}
+		}
+	}
+	return row, nil
+}
+
+// reportPositions lists the column positions from first to last, e.g. "1, 2, 3"
+func reportPositions(first, last int) string {
+	positions := make([]string, 0, last-first+1)
+	for i := first; i <= last; i++ {
+		positions = append(positions, fmt.Sprint(i))
+	}
+	return strings.Join(positions, ", ")
+}
+
+// sortedKeys lists the keys of a catalog map in order
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,135 @@
+// internal/repositories/reservation_checklist_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReservationChecklistRepository implements the ReservationChecklistRepositoryInterface
+type ReservationChecklistRepository struct {
+	db *gorm.DB
+}
+
+// NewReservationChecklistRepository creates a new reservation checklist repository
+func NewReservationChecklistRepository(db *gorm.DB) interfaces.ReservationChecklistRepositoryInterface {
+	return &ReservationChecklistRepository{db: db}
+}
+
+// ========================================
+// TASK OPERATIONS
+// ========================================
+
+// CreateTasks creates checklist tasks
+func (r *ReservationChecklistRepository) CreateTasks(tasks []*models.ReservationTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	return r.db.Create(&tasks).Error
+}
+
+// GetTaskByID retrieves a checklist task by ID
+func (r *ReservationChecklistRepository) GetTaskByID(id uuid.UUID) (*models.ReservationTask, error) {
+	var task models.ReservationTask
+	err := r.db.Where("id = ?", id).First(&task).Error
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// GetTasksByReservation retrieves the checklist of a reservation in order
+func (r *ReservationChecklistRepository) GetTasksByReservation(reservationID uuid.UUID) ([]*models.ReservationTask, error) {
+	var tasks []*models.ReservationTask
+	err := r.db.Where("reservation_id = ?", reservationID).
+		Order("position ASC, created_at ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// UpdateTask updates a checklist task
+func (r *ReservationChecklistRepository) UpdateTask(id uuid.UUID, updates map[string]interface{}) (*models.ReservationTask, error) {
+	err := r.db.Model(&models.ReservationTask{}).Where("id = ?", id).Updates(updates).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.GetTaskByID(id)
+}
+
+// DeleteTask removes a checklist task
+func (r *ReservationChecklistRepository) DeleteTask(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.ReservationTask{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetDueReminders retrieves open tasks of upcoming reservations whose reminder time has come
+func (r *ReservationChecklistRepository) GetDueReminders(now time.Time, limit int) ([]*models.ReservationTask, error) {
+	var tasks []*models.ReservationTask
+	err := r.db.Preload("Reservation.Space").Preload("Reservation.CoOrganizers").
+		Joins("JOIN reservations ON reservations.id = reservation_tasks.reservation_id AND reservations.deleted_at IS NULL").
+		Where("reservation_tasks.completed_at IS NULL AND reservation_tasks.reminded_at IS NULL").
+		Where("reservation_tasks.reminder_minutes IS NOT NULL AND reservation_tasks.due_at IS NOT NULL").
+		Where("reservation_tasks.due_at - make_interval(mins => reservation_tasks.reminder_minutes) <= ?", now).
+		Where("reservations.status IN ? AND reservations.end_time > ?", []string{"confirmed", "pending"}, now).
+		Order("reservation_tasks.due_at ASC").
+		Limit(limit).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// ========================================
+// TEMPLATE OPERATIONS
+// ========================================
+
+// GetTemplates retrieves the checklist templates of all space types
+func (r *ReservationChecklistRepository) GetTemplates() ([]*models.ChecklistTemplate, error) {
+	var templates []*models.ChecklistTemplate
+	err := r.db.Order("space_type ASC").Find(&templates).Error
+	return templates, err
+}
+
+// GetTemplate retrieves the checklist template of a space type
+func (r *ReservationChecklistRepository) GetTemplate(spaceType models.SpaceType) (*models.ChecklistTemplate, error) {
+	var template models.ChecklistTemplate
+	err := r.db.Where("space_type = ?", spaceType).First(&template).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// SaveTemplate creates the template of a space type or replaces its items
+func (r *ReservationChecklistRepository) SaveTemplate(template *models.ChecklistTemplate) (*models.ChecklistTemplate, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "space_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"items", "updated_by_id", "updated_at"}),
+	}).Create(template).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.GetTemplate(template.SpaceType)
+}
+
+// DeleteTemplate removes the checklist template of a space type
+func (r *ReservationChecklistRepository) DeleteTemplate(spaceType models.SpaceType) error {
+	result := r.db.Where("space_type = ?", spaceType).Delete(&models.ChecklistTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// internal/repositories/reservation_guest_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReservationGuestRepository implements the ReservationGuestRepositoryInterface
+type ReservationGuestRepository struct {
+	db *gorm.DB
+}
+
+// NewReservationGuestRepository creates a new reservation guest repository
+func NewReservationGuestRepository(db *gorm.DB) interfaces.ReservationGuestRepositoryInterface {
+	return &ReservationGuestRepository{db: db}
+}
+
+// Create creates a new reservation guest and tells reception systems to expect them
+func (r *ReservationGuestRepository) Create(guest *models.ReservationGuest) (*models.ReservationGuest, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(guest).Error; err != nil {
+			return err
+		}
+		return appendVisitorEvent(tx, models.VisitorEventExpected, guest.ID, uuid.Nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return guest, nil
+}
+
+// GetByID retrieves a guest by ID
+func (r *ReservationGuestRepository) GetByID(id uuid.UUID) (*models.ReservationGuest, error) {
+	var guest models.ReservationGuest
+	err := r.db.Where("id = ?", id).First(&guest).Error
+	if err != nil {
+		return nil, err
+	}
+	return &guest, nil
+}
+
+// GetByToken retrieves a guest by pass token, with the reservation, its space and host
+func (r *ReservationGuestRepository) GetByToken(token string) (*models.ReservationGuest, error) {
+	var guest models.ReservationGuest
+	err := r.db.Preload("Reservation").Preload("Reservation.Space").Preload("Reservation.User").
+		Where("pass_token = ?", token).First(&guest).Error
+	if err != nil {
+		return nil, err
+	}
+	return &guest, nil
+}
+
+// GetByReservation retrieves the guests of a reservation
+func (r *ReservationGuestRepository) GetByReservation(reservationID uuid.UUID) ([]*models.ReservationGuest, error) {
+	var guests []*models.ReservationGuest
+	err := r.db.Where("reservation_id = ?", reservationID).
+		Order("created_at ASC").
+		Find(&guests).Error
+	return guests, err
+}
+
+// CountByReservation counts the guests of a reservation
+func (r *ReservationGuestRepository) CountByReservation(reservationID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ReservationGuest{}).Where("reservation_id = ?", reservationID).Count(&count).Error
+	return count, err
+}
+
+// Update updates a guest, with a visitor event when the change matters to reception
+func (r *ReservationGuestRepository) Update(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ReservationGuest{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+			return err
+		}
+		if eventType := visitorUpdateEvent(updates); eventType != "" {
+			return appendVisitorEvent(tx, eventType, id, uuid.Nil)
+		}
+		return nil
+	})
+}
+
+// Delete removes a guest and tells reception systems they are no longer expected
+func (r *ReservationGuestRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := appendVisitorEvent(tx, models.VisitorEventCancelled, id, uuid.Nil); err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.ReservationGuest{}).Error
+	})
+}
+
+// GetExpectedVisitors retrieves guests expected in a building within a time window
+func (r *ReservationGuestRepository) GetExpectedVisitors(building string, windowStart, windowEnd time.Time) ([]*models.ReservationGuest, error) {
+	var guests []*models.ReservationGuest
+	err := r.db.Preload("Reservation").Preload("Reservation.Space").Preload("Reservation.User").
+		Joins("JOIN reservations ON reservations.id = reservation_guests.reservation_id AND reservations.deleted_at IS NULL").
+		Joins("JOIN spaces ON spaces.id = reservations.space_id").
+		Where("spaces.building = ?", building).
+		Where("reservation_guests.rsvp_status <> ?", models.GuestRSVPDeclined).
+		Where("reservations.status IN ?", []models.ReservationStatus{models.StatusConfirmed, models.StatusPending}).
+		Where("reservations.start_time >= ? AND reservations.start_time < ?", windowStart, windowEnd).
+		Order("reservations.start_time ASC, reservation_guests.name ASC").
+		Find(&guests).Error
+	return guests, err
+}
+
+// visitorUpdateEvent names the visitor event of a guest update, empty if reception need not know
+func visitorUpdateEvent(updates map[string]interface{}) string {
+	if _, ok := updates["arrived_at"]; ok {
+		return models.VisitorEventArrived
+	}
+	if status, ok := updates["rsvp_status"]; ok {
+		switch status {
+		case models.GuestRSVPDeclined, string(models.GuestRSVPDeclined):
+			return models.VisitorEventCancelled
+		}
+		return models.VisitorEventUpdated
+	}
+	return ""
+}
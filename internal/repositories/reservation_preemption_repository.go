@@ -0,0 +1,46 @@
+// internal/repositories/reservation_preemption_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReservationPreemptionRepository implements the ReservationPreemptionRepositoryInterface
+type ReservationPreemptionRepository struct {
+	db *gorm.DB
+}
+
+// NewReservationPreemptionRepository creates a new reservation preemption repository
+func NewReservationPreemptionRepository(db *gorm.DB) interfaces.ReservationPreemptionRepositoryInterface {
+	return &ReservationPreemptionRepository{db: db}
+}
+
+// GetPreemptions retrieves the preemptions of bookings within a date range
+func (r *ReservationPreemptionRepository) GetPreemptions(startDate, endDate time.Time, spaceID *uuid.UUID, offset, limit int) ([]*models.ReservationPreemption, int64, error) {
+	var preemptions []*models.ReservationPreemption
+	var total int64
+
+	query := r.db.Model(&models.ReservationPreemption{}).
+		Where("start_time < ? AND end_time > ?", endDate, startDate)
+	if spaceID != nil {
+		query = query.Where("space_id = ?", *spaceID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Space").Preload("DisplacedUser").Preload("PreemptedBy").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&preemptions).Error
+
+	return preemptions, total, err
+}
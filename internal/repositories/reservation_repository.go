@@ -2,23 +2,33 @@
 package repositories
 
 import (
+	"errors"
+	"maps"
 	"time"
 
+	"room-reservation-api/internal/dto"
 	"room-reservation-api/internal/models"
 	"room-reservation-api/internal/repositories/interfaces"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ReservationRepository implements the ReservationRepositoryInterface
 type ReservationRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	replica *gorm.DB // Serves lists and counts, which tolerate replication lag
 }
 
-// NewReservationRepository creates a new reservation repository
-func NewReservationRepository(db *gorm.DB) interfaces.ReservationRepositoryInterface {
-	return &ReservationRepository{db: db}
+// NewReservationRepository creates a new reservation repository. Lists and counts read from the
+// replica when one is given; everything else, availability checks included, uses db.
+func NewReservationRepository(db, replica *gorm.DB) interfaces.ReservationRepositoryInterface {
+	if replica == nil {
+		replica = db
+	}
+	return &ReservationRepository{db: db, replica: replica}
 }
 
 // ========================================
@@ -27,7 +37,13 @@ func NewReservationRepository(db *gorm.DB) interfaces.ReservationRepositoryInter
 
 // Create creates a new reservation
 func (r *ReservationRepository) Create(reservation *models.Reservation) (*models.Reservation, error) {
-	if err := r.db.Create(reservation).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(reservation).Error; err != nil {
+			return err
+		}
+		return appendReservationEvent(tx, models.OutboxReservationCreated, reservation.ID)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -35,10 +51,62 @@ func (r *ReservationRepository) Create(reservation *models.Reservation) (*models
 	return r.GetByID(reservation.ID)
 }
 
+// CreatePreempting cancels the displaced pending reservations, creates the reservation and records
+// the preemptions in one transaction, each with an outbox event for the displaced organizer
+func (r *ReservationRepository) CreatePreempting(reservation *models.Reservation, preemptions []*models.ReservationPreemption) (*models.Reservation, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, preemption := range preemptions {
+			updates := map[string]interface{}{
+				"status":              models.StatusCancelled,
+				"cancellation_reason": "Preempted by a higher-priority booking: " + preemption.Justification,
+			}
+			result := tx.Model(&models.Reservation{}).
+				Where("id = ? AND status = ?", preemption.ReservationID, models.StatusPending).
+				Updates(updates)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+			if err := appendReservationEvent(tx, reservationUpdateEvent(updates), preemption.ReservationID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(reservation).Error; err != nil {
+			return err
+		}
+		if err := appendReservationEvent(tx, models.OutboxReservationCreated, reservation.ID); err != nil {
+			return err
+		}
+
+		for _, preemption := range preemptions {
+			preemption.PreemptingReservationID = reservation.ID
+			if err := tx.Create(preemption).Error; err != nil {
+				return err
+			}
+			if err := appendOutboxEvent(tx, models.OutboxReservationPreempted, preemption.ReservationID, preemption); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(reservation.ID)
+}
+
 // GetByID retrieves a reservation by ID with relationships
 func (r *ReservationRepository) GetByID(id uuid.UUID) (*models.Reservation, error) {
 	var reservation models.Reservation
-	err := r.db.Preload("User").Preload("Space").Preload("Approver").
+	err := r.db.Preload("User").Preload("Space").Preload("Approver").Preload("Resources.Resource").
+		Preload("CoOrganizers.User").
+		Preload("Tasks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC, created_at ASC")
+		}).
 		Where("id = ?", id).First(&reservation).Error
 	if err != nil {
 		return nil, err
@@ -48,7 +116,7 @@ func (r *ReservationRepository) GetByID(id uuid.UUID) (*models.Reservation, erro
 
 // Update updates a reservation
 func (r *ReservationRepository) Update(id uuid.UUID, updates map[string]interface{}) (*models.Reservation, error) {
-	if err := r.db.Model(&models.Reservation{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+	if err := r.updateWithEvent(id, updates); err != nil {
 		return nil, err
 	}
 
@@ -56,6 +124,63 @@ func (r *ReservationRepository) Update(id uuid.UUID, updates map[string]interfac
 	return r.GetByID(id)
 }
 
+// UpdateIfUnmodified updates a reservation only if its updated_at is still the one given,
+// returning gorm.ErrRecordNotFound when another write got there first
+func (r *ReservationRepository) UpdateIfUnmodified(id uuid.UUID, updatedAt time.Time, updates map[string]interface{}) (*models.Reservation, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := shiftTasksWithStart(tx, id, updates); err != nil {
+			return err
+		}
+		result := tx.Model(&models.Reservation{}).
+			Where("id = ? AND updated_at = ?", id, updatedAt.Truncate(time.Microsecond)).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return appendReservationEvent(tx, reservationUpdateEvent(updates), id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
+// updateWithEvent updates a reservation and writes the change to the outbox in one transaction
+func (r *ReservationRepository) updateWithEvent(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := shiftTasksWithStart(tx, id, updates); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Reservation{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+			return err
+		}
+		return appendReservationEvent(tx, reservationUpdateEvent(updates), id)
+	})
+}
+
+// shiftTasksWithStart moves the due times of the reservation's open checklist tasks by as much
+// as the update moves its start, so they stay as far ahead of it and their reminders are sent
+// again. It runs in the update's transaction before the reservation changes, so a failed or
+// stale update leaves the tasks where they were.
+func shiftTasksWithStart(tx *gorm.DB, id uuid.UUID, updates map[string]interface{}) error {
+	startTime, ok := updates["start_time"]
+	if !ok {
+		return nil
+	}
+	previousStart := tx.Model(&models.Reservation{}).Select("start_time").Where("id = ?", id)
+	return tx.Model(&models.ReservationTask{}).
+		Where("reservation_id = ? AND due_at IS NOT NULL AND completed_at IS NULL", id).
+		Where("(?) <> ?", previousStart, startTime).
+		Updates(map[string]interface{}{
+			"due_at":      gorm.Expr("due_at + (? - (?))", startTime, previousStart),
+			"reminded_at": nil,
+		}).Error
+}
+
 // Delete soft deletes a reservation
 func (r *ReservationRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.Reservation{}, "id = ?", id).Error
@@ -67,13 +192,13 @@ func (r *ReservationRepository) GetAll(offset, limit int) ([]*models.Reservation
 	var total int64
 
 	// Count total
-	if err := r.db.Model(&models.Reservation{}).Count(&total).Error; err != nil {
+	if err := r.replica.Model(&models.Reservation{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get reservations with pagination
-	err := r.db.Preload("User").Preload("Space").Preload("Approver").
-		Order("start_time DESC").
+	err := r.replica.Preload("User").Preload("Space").Preload("Approver").
+		Order("start_time DESC, id DESC").
 		Offset(offset).Limit(limit).
 		Find(&reservations).Error
 
@@ -84,33 +209,53 @@ func (r *ReservationRepository) GetAll(offset, limit int) ([]*models.Reservation
 // USER-SPECIFIC OPERATIONS
 // ========================================
 
-// GetUserReservations retrieves all reservations for a user
-func (r *ReservationRepository) GetUserReservations(userID uuid.UUID, offset, limit int) ([]*models.Reservation, int64, error) {
+// GetUserReservations retrieves all reservations a user owns or co-organizes
+func (r *ReservationRepository) GetUserReservations(userID uuid.UUID, offset, limit int, include dto.ReservationIncludes) ([]*models.Reservation, int64, error) {
 	var reservations []*models.Reservation
 	var total int64
 
 	// Count total
-	if err := r.db.Model(&models.Reservation{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+	if err := r.replica.Model(&models.Reservation{}).Where("user_id = ? OR id IN (?)", userID, r.coOrganizedBy(userID)).
+		Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get reservations
-	err := r.db.Preload("User").Preload("Space").Preload("Approver").
-		Where("user_id = ?", userID).
-		Order("start_time DESC").
+	err := r.preloadList(r.replica, include).
+		Where("user_id = ? OR id IN (?)", userID, r.coOrganizedBy(userID)).
+		Order("start_time DESC, id DESC").
 		Offset(offset).Limit(limit).
 		Find(&reservations).Error
 
 	return reservations, total, err
 }
 
-// GetUserUpcomingReservations retrieves upcoming reservations for a user
+// GetUserReservationsAfter retrieves the reservations a user owns or co-organizes that come
+// after the cursor, latest start first, without counting them
+func (r *ReservationRepository) GetUserReservationsAfter(userID uuid.UUID, after *dto.Cursor, limit int, include dto.ReservationIncludes) ([]*models.Reservation, error) {
+	var reservations []*models.Reservation
+
+	query := r.preloadList(r.replica, include).
+		Where("user_id = ? OR id IN (?)", userID, r.coOrganizedBy(userID))
+	if after != nil {
+		startTime, err := after.Time()
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("(start_time, id) < (?, ?)", startTime, after.ID)
+	}
+
+	err := query.Order("start_time DESC, id DESC").Limit(limit).Find(&reservations).Error
+	return reservations, err
+}
+
+// GetUserUpcomingReservations retrieves upcoming reservations a user owns or co-organizes
 func (r *ReservationRepository) GetUserUpcomingReservations(userID uuid.UUID, limit int) ([]*models.Reservation, error) {
 	var reservations []*models.Reservation
 
-	err := r.db.Preload("User").Preload("Space").
-		Where("user_id = ? AND start_time > ? AND status IN ?",
-			userID, time.Now(), []string{"confirmed", "pending"}).
+	err := r.db.Preload("User").Preload("Space").Preload("CoOrganizers.User").
+		Where("(user_id = ? OR id IN (?)) AND start_time > ? AND status IN ?",
+			userID, r.coOrganizedBy(userID), time.Now(), []string{"confirmed", "pending"}).
 		Order("start_time ASC").
 		Limit(limit).
 		Find(&reservations).Error
@@ -118,20 +263,37 @@ func (r *ReservationRepository) GetUserUpcomingReservations(userID uuid.UUID, li
 	return reservations, err
 }
 
+// GetUserOverlappingReservations retrieves a user's active reservations overlapping a time range
+func (r *ReservationRepository) GetUserOverlappingReservations(userID uuid.UUID, startTime, endTime time.Time, excludeReservationID *uuid.UUID) ([]*models.Reservation, error) {
+	var reservations []*models.Reservation
+
+	query := r.db.Preload("Space").
+		Where("user_id = ? AND status IN ? AND start_time < ? AND end_time > ?",
+			userID, []string{"confirmed", "pending"}, endTime, startTime)
+
+	// Exclude specific reservation if provided
+	if excludeReservationID != nil {
+		query = query.Where("id != ?", *excludeReservationID)
+	}
+
+	err := query.Order("start_time ASC").Find(&reservations).Error
+	return reservations, err
+}
+
 // GetUserPastReservations retrieves past reservations for a user
 func (r *ReservationRepository) GetUserPastReservations(userID uuid.UUID, offset, limit int) ([]*models.Reservation, int64, error) {
 	var reservations []*models.Reservation
 	var total int64
 
 	// Count total
-	if err := r.db.Model(&models.Reservation{}).
+	if err := r.replica.Model(&models.Reservation{}).
 		Where("user_id = ? AND end_time < ?", userID, time.Now()).
 		Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get reservations
-	err := r.db.Preload("User").Preload("Space").
+	err := r.replica.Preload("User").Preload("Space").
 		Where("user_id = ? AND end_time < ?", userID, time.Now()).
 		Order("start_time DESC").
 		Offset(offset).Limit(limit).
@@ -140,6 +302,17 @@ func (r *ReservationRepository) GetUserPastReservations(userID uuid.UUID, offset
 	return reservations, total, err
 }
 
+// GetUserReservationsStartingBetween retrieves the reservations a user owns starting within the
+// time range, whatever their status, with their space
+func (r *ReservationRepository) GetUserReservationsStartingBetween(userID uuid.UUID, startTime, endTime time.Time) ([]*models.Reservation, error) {
+	var reservations []*models.Reservation
+	err := r.replica.Preload("Space").
+		Where("user_id = ? AND start_time >= ? AND start_time < ?", userID, startTime, endTime).
+		Order("start_time ASC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
 // GetUserActiveReservation retrieves the currently active reservation for a user
 func (r *ReservationRepository) GetUserActiveReservation(userID uuid.UUID) (*models.Reservation, error) {
 	var reservation models.Reservation
@@ -170,12 +343,12 @@ func (r *ReservationRepository) GetSpaceReservations(spaceID uuid.UUID, offset,
 	var total int64
 
 	// Count total
-	if err := r.db.Model(&models.Reservation{}).Where("space_id = ?", spaceID).Count(&total).Error; err != nil {
+	if err := r.replica.Model(&models.Reservation{}).Where("space_id = ?", spaceID).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get reservations
-	err := r.db.Preload("User").Preload("Space").
+	err := r.replica.Preload("User").Preload("Space").
 		Where("space_id = ?", spaceID).
 		Order("start_time DESC").
 		Offset(offset).Limit(limit).
@@ -189,7 +362,7 @@ func (r *ReservationRepository) GetSpaceReservationsByDateRange(spaceID uuid.UUI
 	var reservations []*models.Reservation
 	var total int64
 
-	query := r.db.Model(&models.Reservation{}).
+	query := r.replica.Model(&models.Reservation{}).
 		Where("space_id = ? AND start_time >= ? AND end_time <= ?", spaceID, startDate, endDate)
 
 	// Count total
@@ -198,7 +371,7 @@ func (r *ReservationRepository) GetSpaceReservationsByDateRange(spaceID uuid.UUI
 	}
 
 	// Get reservations
-	err := r.db.Preload("User").Preload("Space").
+	err := r.replica.Preload("User").Preload("Space").
 		Where("space_id = ? AND start_time >= ? AND end_time <= ?", spaceID, startDate, endDate).
 		Order("start_time ASC").
 		Offset(offset).Limit(limit).
@@ -207,25 +380,38 @@ func (r *ReservationRepository) GetSpaceReservationsByDateRange(spaceID uuid.UUI
 	return reservations, total, err
 }
 
-// GetConflictingReservations finds reservations that conflict with a given time range
+// GetConflictingReservations finds reservations that conflict with a given time range,
+// including those too close to it to leave the space's buffer time in between and those of
+// the spaces sharing its floor area (combined spaces and their parts)
 func (r *ReservationRepository) GetConflictingReservations(spaceID uuid.UUID, startTime, endTime time.Time) ([]*models.Reservation, error) {
 	var reservations []*models.Reservation
 
-	err := r.db.Preload("User").Preload("Space").
-		Where("space_id = ? AND status IN ? AND start_time < ? AND end_time > ?",
-			spaceID, []string{"confirmed", "pending"}, endTime, startTime).
+	gap, err := r.spaceBufferGap(spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Preload("User").Preload("Space").Preload("CoOrganizers").
+		Where("(space_id IN (?) OR id IN (?)) AND status IN ? AND start_time < ? AND end_time > ?",
+			sharingAreaWith(r.db, spaceID), r.addOnBookings(spaceID), []string{"confirmed", "pending"}, endTime.Add(gap), startTime.Add(-gap)).
 		Find(&reservations).Error
 
 	return reservations, err
 }
 
-// CheckTimeSlotAvailability checks if a time slot is available
+// CheckTimeSlotAvailability checks if a time slot is available, keeping the space's buffer time free.
+// Booking a combined space takes its parts, and booking a part takes the combined spaces including it.
 func (r *ReservationRepository) CheckTimeSlotAvailability(spaceID uuid.UUID, startTime, endTime time.Time, excludeReservationID *uuid.UUID) (bool, error) {
 	var count int64
 
+	gap, err := r.spaceBufferGap(spaceID)
+	if err != nil {
+		return false, err
+	}
+
 	query := r.db.Model(&models.Reservation{}).
-		Where("space_id = ? AND status IN ? AND start_time < ? AND end_time > ?",
-			spaceID, []string{"confirmed", "pending"}, endTime, startTime)
+		Where("(space_id IN (?) OR id IN (?)) AND status IN ? AND start_time < ? AND end_time > ?",
+			sharingAreaWith(r.db, spaceID), r.addOnBookings(spaceID), []string{"confirmed", "pending"}, endTime.Add(gap), startTime.Add(-gap))
 
 	// Exclude specific reservation if provided
 	if excludeReservationID != nil {
@@ -239,6 +425,136 @@ func (r *ReservationRepository) CheckTimeSlotAvailability(spaceID uuid.UUID, sta
 	return count == 0, nil
 }
 
+// GetBusyWindows lists the confirmed and pending bookings of the spaces overlapping a time
+// range, as bare windows; equipment booked as an add-on is busy for its reservation's window,
+// and a space is busy for the bookings of the spaces sharing its floor area
+func (r *ReservationRepository) GetBusyWindows(spaceIDs []uuid.UUID, startTime, endTime time.Time) ([]interfaces.BusyWindow, error) {
+	var windows []interfaces.BusyWindow
+	if len(spaceIDs) == 0 {
+		return windows, nil
+	}
+
+	err := r.replica.Model(&models.Reservation{}).
+		Select("spaces.id AS space_id, reservations.start_time, reservations.end_time").
+		Joins("JOIN spaces AS booked ON booked.id = reservations.space_id").
+		Joins("JOIN spaces ON "+sharedAreaCondition("spaces", "booked")).
+		Where("spaces.id IN ? AND reservations.status IN ? AND reservations.start_time < ? AND reservations.end_time > ?",
+			spaceIDs, []string{"confirmed", "pending"}, endTime, startTime).
+		Scan(&windows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var addOns []interfaces.BusyWindow
+	err = r.replica.Model(&models.Reservation{}).
+		Select("reservation_resources.resource_id AS space_id, reservations.start_time, reservations.end_time").
+		Joins("JOIN reservation_resources ON reservation_resources.reservation_id = reservations.id").
+		Where("reservation_resources.resource_id IN ? AND reservations.status IN ? AND reservations.start_time < ? AND reservations.end_time > ?",
+			spaceIDs, []string{"confirmed", "pending"}, endTime, startTime).
+		Scan(&addOns).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return append(windows, addOns...), nil
+}
+
+// CreateInFreeWindow creates a reservation in the free window starting at its start time.
+// The space row is locked so concurrent requests cannot book the same window.
+func (r *ReservationRepository) CreateInFreeWindow(reservation *models.Reservation, minDuration time.Duration) (*models.Reservation, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var space models.Space
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", reservation.SpaceID).First(&space).Error; err != nil {
+			return err
+		}
+
+		gap := space.BufferGap()
+		var blocking models.Reservation
+		err := tx.Where("(space_id IN (?) OR id IN (?)) AND status IN ? AND start_time < ? AND end_time > ?",
+			sharingAreaWith(tx, reservation.SpaceID), r.addOnBookings(reservation.SpaceID), []string{"confirmed", "pending"},
+			reservation.EndTime.Add(gap), reservation.StartTime.Add(-gap)).
+			Order("start_time ASC").
+			First(&blocking).Error
+		switch {
+		case err == nil:
+			// The window closes where the next booking's buffer begins
+			windowEnd := blocking.StartTime.Add(-gap)
+			if !windowEnd.After(reservation.StartTime) {
+				return interfaces.ErrNoFreeWindow
+			}
+			reservation.EndTime = windowEnd
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		if reservation.EndTime.Sub(reservation.StartTime) < minDuration {
+			return interfaces.ErrNoFreeWindow
+		}
+
+		if err := tx.Create(reservation).Error; err != nil {
+			return err
+		}
+		return appendReservationEvent(tx, models.OutboxReservationCreated, reservation.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(reservation.ID)
+}
+
+// MoveToSpace moves an active reservation into another space. The reservation and the new
+// space are locked, so the availability check and the move cannot interleave with a booking.
+func (r *ReservationRepository) MoveToSpace(id, fromSpaceID, toSpaceID uuid.UUID) (*models.Reservation, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var reservation models.Reservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND space_id = ? AND status IN ?", id, fromSpaceID, []models.ReservationStatus{models.StatusPending, models.StatusConfirmed}).
+			First(&reservation).Error; err != nil {
+			return err
+		}
+
+		var space models.Space
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ?", toSpaceID, "available").
+			Where("NOT "+closedSpaceCondition("spaces"), reservation.EndTime, reservation.StartTime).
+			First(&space).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return interfaces.ErrSpaceTaken
+		}
+		if err != nil {
+			return err
+		}
+
+		gap := space.BufferGap()
+		var conflicts int64
+		if err := tx.Model(&models.Reservation{}).
+			Where("(space_id IN (?) OR id IN (?)) AND id <> ? AND status IN ? AND start_time < ? AND end_time > ?",
+				sharingAreaWith(tx, toSpaceID), r.addOnBookings(toSpaceID), id, []string{"confirmed", "pending"},
+				reservation.EndTime.Add(gap), reservation.StartTime.Add(-gap)).
+			Count(&conflicts).Error; err != nil {
+			return err
+		}
+		if conflicts > 0 {
+			return interfaces.ErrSpaceTaken
+		}
+
+		if err := tx.Model(&models.Reservation{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"space_id": toSpaceID,
+			"cost":     space.CostFor(reservation.StartTime, reservation.EndTime),
+		}).Error; err != nil {
+			return err
+		}
+		return appendReservationEvent(tx, models.OutboxReservationUpdated, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
 // ========================================
 // APPROVAL OPERATIONS
 // ========================================
@@ -251,16 +567,19 @@ func (r *ReservationRepository) GetPendingApprovals(managerID uuid.UUID, offset,
 	// Subquery to get space IDs managed by the manager
 	subQuery := r.db.Model(&models.Space{}).Select("id").Where("manager_id = ?", managerID)
 
+	// A manager who gave the first of two approvals cannot give the second
+	notFirstApprover := r.db.Where("first_approver_id IS NULL OR first_approver_id <> ?", managerID)
+
 	// Count total
 	if err := r.db.Model(&models.Reservation{}).
-		Where("status = ? AND space_id IN (?)", "pending", subQuery).
+		Where("status = ? AND space_id IN (?)", "pending", subQuery).Where(notFirstApprover).
 		Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get reservations
 	err := r.db.Preload("User").Preload("Space").
-		Where("status = ? AND space_id IN (?)", "pending", subQuery).
+		Where("status = ? AND space_id IN (?)", "pending", subQuery).Where(notFirstApprover).
 		Order("created_at ASC").
 		Offset(offset).Limit(limit).
 		Find(&reservations).Error
@@ -276,7 +595,18 @@ func (r *ReservationRepository) ApproveReservation(id uuid.UUID, approverID uuid
 		"approval_comments": comments,
 	}
 
-	return r.db.Model(&models.Reservation{}).Where("id = ?", id).Updates(updates).Error
+	return r.updatePending(id, "", updates, models.ActivityApproved, approverID, comments)
+}
+
+// RecordFirstApproval records the first of two approvals, leaving the reservation pending
+func (r *ReservationRepository) RecordFirstApproval(id uuid.UUID, approverID uuid.UUID, comments string) error {
+	updates := map[string]interface{}{
+		"first_approver_id": approverID,
+		"first_approved_at": time.Now(),
+		"approval_comments": comments,
+	}
+
+	return r.updatePending(id, "first_approver_id IS NULL", updates, models.ActivityFirstApproval, approverID, comments)
 }
 
 // RejectReservation rejects a reservation
@@ -287,7 +617,50 @@ func (r *ReservationRepository) RejectReservation(id uuid.UUID, approverID uuid.
 		"cancellation_reason": reason,
 	}
 
-	return r.db.Model(&models.Reservation{}).Where("id = ?", id).Updates(updates).Error
+	return r.updatePending(id, "", updates, models.ActivityRejected, approverID, reason)
+}
+
+// updatePending applies an approval decision only while the reservation is still pending and
+// matches the extra condition, if any, returning gorm.ErrRecordNotFound when another approver
+// decided first. The decision is added to the activity feed with the approver's note.
+func (r *ReservationRepository) updatePending(id uuid.UUID, condition string, updates map[string]interface{}, action string, approverID uuid.UUID, note string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.Reservation{}).Where("id = ? AND status = ?", id, models.StatusPending)
+		if condition != "" {
+			query = query.Where(condition)
+		}
+
+		result := query.Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := appendReservationEvent(tx, models.OutboxReservationUpdated, id); err != nil {
+			return err
+		}
+
+		var titles []string
+		if err := tx.Model(&models.Reservation{}).Where("id = ?", id).Pluck("title", &titles).Error; err != nil {
+			return err
+		}
+		activity := &models.ActivityEvent{
+			Category:   models.ActivityCategoryApproval,
+			Action:     action,
+			ActorID:    &approverID,
+			EntityType: models.ActivityEntityReservation,
+			EntityID:   &id,
+		}
+		if len(titles) > 0 {
+			activity.EntityName = titles[0]
+		}
+		var details interface{}
+		if note != "" {
+			details = map[string]string{"note": note}
+		}
+		return appendActivityEvent(tx, activity, details)
+	})
 }
 
 // ========================================
@@ -296,16 +669,39 @@ func (r *ReservationRepository) RejectReservation(id uuid.UUID, approverID uuid.
 
 // CheckIn records check-in time for a reservation
 func (r *ReservationRepository) CheckIn(id uuid.UUID, checkInTime time.Time) error {
-	return r.db.Model(&models.Reservation{}).
-		Where("id = ?", id).
-		Update("check_in_time", checkInTime).Error
+	return r.updateWithEvent(id, map[string]interface{}{"check_in_time": checkInTime})
 }
 
-// CheckOut records check-out time for a reservation
-func (r *ReservationRepository) CheckOut(id uuid.UUID, checkOutTime time.Time) error {
-	return r.db.Model(&models.Reservation{}).
-		Where("id = ?", id).
-		Update("check_out_time", checkOutTime).Error
+// CheckOut records the check-out time and session of a reservation and marks it completed
+func (r *ReservationRepository) CheckOut(session *models.ReservationSession, updates map[string]interface{}) error {
+	updates = maps.Clone(updates)
+	if updates == nil {
+		updates = make(map[string]interface{})
+	}
+	updates["check_out_time"] = session.ActualEnd
+	updates["status"] = models.StatusCompleted
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Reservation{}).Where("id = ?", session.ReservationID).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		if err := appendReservationEvent(tx, reservationUpdateEvent(updates), session.ReservationID); err != nil {
+			return err
+		}
+		if !session.Released || session.ReleasedMinutes <= 0 {
+			return nil
+		}
+		return appendOutboxEvent(tx, models.OutboxReservationReleased, session.ReservationID, models.ReservationRelease{
+			ReservationID: session.ReservationID,
+			SpaceID:       session.SpaceID,
+			UserID:        session.UserID,
+			StartTime:     session.ScheduledEnd.Add(-time.Duration(session.ReleasedMinutes) * time.Minute),
+			EndTime:       session.ScheduledEnd,
+		})
+	})
 }
 
 // ========================================
@@ -317,7 +713,9 @@ func (r *ReservationRepository) SearchReservations(filters map[string]interface{
 	var reservations []*models.Reservation
 	var total int64
 
-	query := r.db.Model(&models.Reservation{})
+	query := r.replica.Model(&models.Reservation{})
+	searchQuery := ""
+	var include dto.ReservationIncludes
 
 	// Apply filters
 	for key, value := range filters {
@@ -346,6 +744,28 @@ func (r *ReservationRepository) SearchReservations(filters map[string]interface{
 			if title, ok := value.(string); ok {
 				query = query.Where("title ILIKE ?", "%"+title+"%")
 			}
+		case "tags":
+			if tags, ok := value.([]string); ok && len(tags) > 0 {
+				query = query.Where("tags @> ?", pq.StringArray(tags))
+			}
+		case "cost_center":
+			if costCenter, ok := value.(string); ok {
+				query = query.Where("cost_center = ?", costCenter)
+			}
+		case "include":
+			include, _ = value.(dto.ReservationIncludes)
+		case "after":
+			// Keyset pagination, not combined with full-text ranking
+			if cursor, ok := value.(dto.Cursor); ok {
+				if startTime, err := cursor.Time(); err == nil {
+					query = query.Where("(start_time, id) < (?, ?)", startTime, cursor.ID)
+				}
+			}
+		case "query":
+			if text, ok := value.(string); ok && text != "" {
+				searchQuery = text
+				query = query.Where("search_vector @@ websearch_to_tsquery('english', ?)", text)
+			}
 		}
 	}
 
@@ -354,21 +774,45 @@ func (r *ReservationRepository) SearchReservations(filters map[string]interface{
 		return nil, 0, err
 	}
 
+	// Full-text searches are ranked by relevance and carry a highlighted snippet
+	if searchQuery != "" {
+		query = query.
+			Select("reservations.*, ts_rank_cd(search_vector, websearch_to_tsquery('english', ?)) AS search_rank, "+
+				"ts_headline('english', coalesce(title, '') || ' ' || coalesce(description, ''), websearch_to_tsquery('english', ?), ?) AS search_snippet",
+				searchQuery, searchQuery, searchHeadlineOptions).
+			Order("search_rank DESC")
+	}
+
 	// Get reservations
-	err := query.Preload("User").Preload("Space").Preload("Approver").
-		Order("start_time DESC").
+	err := r.preloadList(query, include).
+		Order("start_time DESC, id DESC").
 		Offset(offset).Limit(limit).
 		Find(&reservations).Error
 
 	return reservations, total, err
 }
 
+// GetDepartmentReservations retrieves the active reservations of a department's members overlapping a date range
+func (r *ReservationRepository) GetDepartmentReservations(department string, startDate, endDate time.Time) ([]*models.Reservation, error) {
+	var reservations []*models.Reservation
+
+	err := r.replica.Preload("User").Preload("Space").
+		Joins("JOIN users ON users.id = reservations.user_id AND users.deleted_at IS NULL").
+		Where("users.department = ?", department).
+		Where("reservations.status IN ?", []models.ReservationStatus{models.StatusConfirmed, models.StatusPending}).
+		Where("reservations.start_time < ? AND reservations.end_time > ?", endDate, startDate).
+		Order("reservations.start_time ASC").
+		Find(&reservations).Error
+
+	return reservations, err
+}
+
 // GetReservationsByDateRange retrieves reservations within a date range
 func (r *ReservationRepository) GetReservationsByDateRange(startDate, endDate time.Time, offset, limit int) ([]*models.Reservation, int64, error) {
 	var reservations []*models.Reservation
 	var total int64
 
-	query := r.db.Model(&models.Reservation{}).
+	query := r.replica.Model(&models.Reservation{}).
 		Where("start_time >= ? AND end_time <= ?", startDate, endDate)
 
 	// Count total
@@ -377,7 +821,7 @@ func (r *ReservationRepository) GetReservationsByDateRange(startDate, endDate ti
 	}
 
 	// Get reservations
-	err := r.db.Preload("User").Preload("Space").
+	err := r.replica.Preload("User").Preload("Space").
 		Where("start_time >= ? AND end_time <= ?", startDate, endDate).
 		Order("start_time ASC").
 		Offset(offset).Limit(limit).
@@ -392,12 +836,12 @@ func (r *ReservationRepository) GetReservationsByStatus(status string, offset, l
 	var total int64
 
 	// Count total
-	if err := r.db.Model(&models.Reservation{}).Where("status = ?", status).Count(&total).Error; err != nil {
+	if err := r.replica.Model(&models.Reservation{}).Where("status = ?", status).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get reservations
-	err := r.db.Preload("User").Preload("Space").
+	err := r.replica.Preload("User").Preload("Space").
 		Where("status = ?", status).
 		Order("start_time DESC").
 		Offset(offset).Limit(limit).
@@ -421,6 +865,9 @@ func (r *ReservationRepository) CreateBatch(reservations []*models.Reservation)
 			if err := tx.Create(reservation).Error; err != nil {
 				return err
 			}
+			if err := appendReservationEvent(tx, models.OutboxReservationCreated, reservation.ID); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -444,6 +891,25 @@ func (r *ReservationRepository) GetRecurringReservations(parentID uuid.UUID) ([]
 	return reservations, err
 }
 
+// ========================================
+// CO-ORGANIZERS
+// ========================================
+
+// SetCoOrganizers replaces the co-organizers of a reservation, recording a reservation_updated event
+func (r *ReservationRepository) SetCoOrganizers(reservationID uuid.UUID, coOrganizers []*models.ReservationCoOrganizer) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("reservation_id = ?", reservationID).Delete(&models.ReservationCoOrganizer{}).Error; err != nil {
+			return err
+		}
+		if len(coOrganizers) > 0 {
+			if err := tx.Create(&coOrganizers).Error; err != nil {
+				return err
+			}
+		}
+		return appendReservationEvent(tx, models.OutboxReservationUpdated, reservationID)
+	})
+}
+
 // ========================================
 // SIMPLE COUNTS (for basic statistics)
 // ========================================
@@ -451,17 +917,120 @@ func (r *ReservationRepository) GetRecurringReservations(parentID uuid.UUID) ([]
 // CountUserReservations counts total reservations for a user
 func (r *ReservationRepository) CountUserReservations(userID uuid.UUID) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.Reservation{}).Where("user_id = ?", userID).Count(&count).Error
+	err := r.replica.Model(&models.Reservation{}).Where("user_id = ?", userID).Count(&count).Error
 	return count, err
 }
 
+// CountUserReservationsByStatus counts the reservations a user owns in each status, in one query
+func (r *ReservationRepository) CountUserReservationsByStatus(userID uuid.UUID) (map[models.ReservationStatus]int64, error) {
+	var rows []struct {
+		Status models.ReservationStatus
+		Count  int64
+	}
+
+	err := r.replica.Model(&models.Reservation{}).
+		Select("status, COUNT(*) AS count").
+		Where("user_id = ?", userID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[models.ReservationStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
 // CountSpaceReservations counts total reservations for a space
 func (r *ReservationRepository) CountSpaceReservations(spaceID uuid.UUID) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.Reservation{}).Where("space_id = ?", spaceID).Count(&count).Error
+	err := r.replica.Model(&models.Reservation{}).Where("space_id = ?", spaceID).Count(&count).Error
 	return count, err
 }
 
+// CountUserReservationsBySpace counts the user's confirmed and completed reservations per space since a date
+func (r *ReservationRepository) CountUserReservationsBySpace(userID uuid.UUID, since time.Time) (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		SpaceID uuid.UUID
+		Count   int64
+	}
+
+	err := r.db.Model(&models.Reservation{}).
+		Select("space_id, COUNT(*) AS count").
+		Where("user_id = ? AND start_time >= ? AND status IN ?", userID, since,
+			[]models.ReservationStatus{models.StatusConfirmed, models.StatusCompleted}).
+		Group("space_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.SpaceID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetBookedMinutesBySpace sums the minutes the spaces were booked since a date, skipping cancelled and rejected reservations
+func (r *ReservationRepository) GetBookedMinutesBySpace(spaceIDs []uuid.UUID, since time.Time) (map[uuid.UUID]float64, error) {
+	var rows []struct {
+		SpaceID uuid.UUID
+		Minutes float64
+	}
+
+	err := r.replica.Model(&models.Reservation{}).
+		Select("space_id, SUM(EXTRACT(EPOCH FROM (end_time - start_time)) / 60) AS minutes").
+		Where("space_id IN ? AND start_time >= ? AND status IN ?", spaceIDs, since,
+			[]models.ReservationStatus{models.StatusConfirmed, models.StatusCompleted}).
+		Group("space_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	minutes := make(map[uuid.UUID]float64, len(rows))
+	for _, row := range rows {
+		minutes[row.SpaceID] = row.Minutes
+	}
+	return minutes, nil
+}
+
+// ========================================
+// CHARGEBACK
+// ========================================
+
+// GetChargebackTotals sums, per month, department of the booker and cost center, the confirmed and
+// completed reservations starting within the time range, with the services delivered with them.
+// No-shows are charged, as the room was held for them.
+func (r *ReservationRepository) GetChargebackTotals(startTime, endTime time.Time) ([]interfaces.ChargebackTotal, error) {
+	var totals []interfaces.ChargebackTotal
+
+	err := r.replica.Model(&models.Reservation{}).
+		Select(`TO_CHAR(reservations.start_time AT TIME ZONE 'UTC', 'YYYY-MM') AS month,
+			COALESCE(users.department, '') AS department,
+			COALESCE(reservations.cost_center, '') AS cost_center,
+			COUNT(*) AS reservations,
+			SUM(EXTRACT(EPOCH FROM (reservations.end_time - reservations.start_time)) / 60) AS minutes,
+			SUM(reservations.cost) AS cost,
+			COALESCE(SUM(services.cost), 0) AS services_cost`).
+		Joins("JOIN users ON users.id = reservations.user_id").
+		Joins("LEFT JOIN (?) AS services ON services.reservation_id = reservations.id",
+			r.replica.Model(&models.ServiceRequest{}).
+				Select("reservation_id, SUM(cost) AS cost").
+				Where("status = ?", models.ServiceRequestFulfilled).
+				Group("reservation_id")).
+		Where("reservations.status IN ? AND reservations.start_time >= ? AND reservations.start_time < ?",
+			[]models.ReservationStatus{models.StatusConfirmed, models.StatusCompleted}, startTime, endTime).
+		Group("month, COALESCE(users.department, ''), COALESCE(reservations.cost_center, '')").
+		Order("month ASC, department ASC, cost_center ASC").
+		Scan(&totals).Error
+	return totals, err
+}
+
 func (r *ReservationRepository) HasActiveReservationsForSpace(spaceID uuid.UUID) (bool, error) {
 	var count int64
 	now := time.Now()
@@ -477,3 +1046,44 @@ func (r *ReservationRepository) HasActiveReservationsForSpace(spaceID uuid.UUID)
 
 	return count > 0, nil
 }
+
+// spaceBufferGap returns the free time a space needs between two bookings
+func (r *ReservationRepository) spaceBufferGap(spaceID uuid.UUID) (time.Duration, error) {
+	var space models.Space
+	err := r.db.Unscoped().Select("buffer_before", "buffer_after").Where("id = ?", spaceID).First(&space).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return space.BufferGap(), nil
+}
+
+// coOrganizedBy selects the reservations the user co-organizes
+func (r *ReservationRepository) coOrganizedBy(userID uuid.UUID) *gorm.DB {
+	return r.db.Model(&models.ReservationCoOrganizer{}).Select("reservation_id").Where("user_id = ?", userID)
+}
+
+// addOnBookings selects the reservations that booked the resource as an add-on to their space
+func (r *ReservationRepository) addOnBookings(resourceID uuid.UUID) *gorm.DB {
+	return r.db.Model(&models.ReservationResource{}).Select("reservation_id").Where("resource_id = ?", resourceID)
+}
+
+// preloadList loads the relations a reservation list asked for. Each relation costs one
+// batched query (WHERE id IN the page's keys) whatever the page size, never one per row.
+func (r *ReservationRepository) preloadList(query *gorm.DB, include dto.ReservationIncludes) *gorm.DB {
+	if include.Has(dto.IncludeUser) {
+		query = query.Preload("User")
+	}
+	if include.Has(dto.IncludeSpace) {
+		query = query.Preload("Space")
+	}
+	if include.Has(dto.IncludeApprover) {
+		query = query.Preload("Approver")
+	}
+	if include.Has(dto.IncludeCoOrganizers) {
+		query = query.Preload("CoOrganizers.User")
+	}
+	return query
+}
@@ -0,0 +1,123 @@
+// internal/repositories/reservation_repository_test.go
+package repositories
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"room-reservation-api/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// seedUserReservations stores count reservations of a new user, each in its own space, approved
+// and with a co-organizer, and returns the user
+func seedUserReservations(fake *fakeDB, count int) uuid.UUID {
+	userID := uuid.New()
+	approverID := uuid.New()
+	coOrganizerID := uuid.New()
+	fake.seed("users",
+		map[string]driver.Value{"id": userID.String()},
+		map[string]driver.Value{"id": approverID.String()},
+		map[string]driver.Value{"id": coOrganizerID.String()},
+	)
+
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < count; i++ {
+		reservationID, spaceID := uuid.New(), uuid.New()
+		fake.seed("spaces", map[string]driver.Value{"id": spaceID.String()})
+		fake.seed("reservations", map[string]driver.Value{
+			"id":          reservationID.String(),
+			"user_id":     userID.String(),
+			"space_id":    spaceID.String(),
+			"approver_id": approverID.String(),
+			"status":      "confirmed",
+			"start_time":  start.Add(time.Duration(i) * 24 * time.Hour),
+			"end_time":    start.Add(time.Duration(i)*24*time.Hour + time.Hour),
+		})
+		fake.seed("reservation_co_organizers", map[string]driver.Value{
+			"id":             uuid.New().String(),
+			"reservation_id": reservationID.String(),
+			"user_id":        coOrganizerID.String(),
+		})
+	}
+	return userID
+}
+
+func TestGetUserReservationsQueryCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		include string // Empty with all set loads every relation
+		all     bool
+		want    int
+	}{
+		// Count, page, users, spaces, approvers, co-organizers and their users
+		{name: "all relations", all: true, want: 7},
+		{name: "no relation", include: "", want: 2},
+		{name: "space", include: "space", want: 3},
+		{name: "space and user", include: "space,user", want: 4},
+		{name: "co-organizers", include: "co_organizers", want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var include dto.ReservationIncludes
+			if !tt.all {
+				var err error
+				if include, err = dto.ParseReservationIncludes(tt.include); err != nil {
+					t.Fatalf("ParseReservationIncludes() error = %v", err)
+				}
+			}
+
+			// The number of statements must not grow with the page
+			for _, rows := range []int{1, 10} {
+				db, fake := newFakeDB(t)
+				userID := seedUserReservations(fake, rows)
+				repo := NewReservationRepository(db, nil)
+
+				reservations, total, err := repo.GetUserReservations(userID, 0, 20, include)
+				if err != nil {
+					t.Fatalf("GetUserReservations() error = %v", err)
+				}
+				if len(reservations) != rows || total != int64(rows) {
+					t.Fatalf("GetUserReservations() = %d reservations of %d, want %d", len(reservations), total, rows)
+				}
+				if got := len(fake.queries()); got != tt.want {
+					t.Errorf("GetUserReservations() with %d rows ran %d statements, want %d:\n%v", rows, got, tt.want, fake.queries())
+				}
+			}
+		})
+	}
+}
+
+func TestGetUserReservationsAfterQueryCount(t *testing.T) {
+	db, fake := newFakeDB(t)
+	userID := seedUserReservations(fake, 10)
+	repo := NewReservationRepository(db, nil)
+
+	include, err := dto.ParseReservationIncludes("space")
+	if err != nil {
+		t.Fatalf("ParseReservationIncludes() error = %v", err)
+	}
+	if _, err := repo.GetUserReservationsAfter(userID, nil, 20, include); err != nil {
+		t.Fatalf("GetUserReservationsAfter() error = %v", err)
+	}
+	// Page and spaces, no count
+	if got := len(fake.queries()); got != 2 {
+		t.Errorf("GetUserReservationsAfter() ran %d statements, want 2:\n%v", got, fake.queries())
+	}
+}
+
+func TestCountUserReservationsByStatusQueryCount(t *testing.T) {
+	db, fake := newFakeDB(t)
+	userID := seedUserReservations(fake, 10)
+	repo := NewReservationRepository(db, nil)
+
+	if _, err := repo.CountUserReservationsByStatus(userID); err != nil {
+		t.Fatalf("CountUserReservationsByStatus() error = %v", err)
+	}
+	if got := len(fake.queries()); got != 1 {
+		t.Errorf("CountUserReservationsByStatus() ran %d statements, want 1:\n%v", got, fake.queries())
+	}
+}
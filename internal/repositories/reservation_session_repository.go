@@ -0,0 +1,73 @@
+// internal/repositories/reservation_session_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReservationSessionRepository implements the ReservationSessionRepositoryInterface
+type ReservationSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewReservationSessionRepository creates a new reservation session repository
+func NewReservationSessionRepository(db *gorm.DB) interfaces.ReservationSessionRepositoryInterface {
+	return &ReservationSessionRepository{db: db}
+}
+
+// GetByReservation retrieves the session of a checked-out reservation
+func (r *ReservationSessionRepository) GetByReservation(reservationID uuid.UUID) (*models.ReservationSession, error) {
+	var session models.ReservationSession
+	err := r.db.Where("reservation_id = ?", reservationID).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetOverdueCheckOuts retrieves confirmed reservations checked in but not out that ended before the given time
+func (r *ReservationSessionRepository) GetOverdueCheckOuts(endedBefore time.Time, limit int) ([]*models.Reservation, error) {
+	var reservations []*models.Reservation
+	err := r.db.
+		Where("status = ? AND check_in_time IS NOT NULL AND check_out_time IS NULL", models.StatusConfirmed).
+		Where("end_time <= ?", endedBefore).
+		Order("end_time ASC").
+		Limit(limit).
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// GetSummaryBySpace aggregates the sessions checked out within a date range per space
+func (r *ReservationSessionRepository) GetSummaryBySpace(startDate, endDate time.Time, managerID *uuid.UUID, spaceID *uuid.UUID) ([]*models.ReservationSessionSummary, error) {
+	var summaries []*models.ReservationSessionSummary
+
+	query := r.db.Model(&models.ReservationSession{}).
+		Select(`reservation_sessions.space_id,
+			spaces.name AS space_name,
+			COUNT(*) AS sessions,
+			SUM(reservation_sessions.scheduled_minutes) AS scheduled_minutes,
+			SUM(reservation_sessions.used_minutes) AS used_minutes,
+			COUNT(CASE WHEN reservation_sessions.early_checkout THEN 1 END) AS early_checkouts,
+			SUM(reservation_sessions.released_minutes) AS released_minutes`).
+		Joins("JOIN spaces ON spaces.id = reservation_sessions.space_id AND spaces.deleted_at IS NULL").
+		Where("reservation_sessions.actual_end >= ? AND reservation_sessions.actual_end < ?", startDate, endDate)
+	if managerID != nil {
+		query = query.Where("spaces.manager_id = ?", *managerID)
+	}
+	if spaceID != nil {
+		query = query.Where("reservation_sessions.space_id = ?", *spaceID)
+	}
+
+	err := query.
+		Group("reservation_sessions.space_id, spaces.name").
+		Order("SUM(reservation_sessions.used_minutes)::float / NULLIF(SUM(reservation_sessions.scheduled_minutes), 0) ASC, spaces.name").
+		Scan(&summaries).Error
+
+	return summaries, err
+}
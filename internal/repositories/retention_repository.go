@@ -0,0 +1,200 @@
+// internal/repositories/retention_repository.go
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"gorm.io/gorm"
+)
+
+// RetentionRepository implements the RetentionRepositoryInterface
+type RetentionRepository struct {
+	db *gorm.DB
+}
+
+// NewRetentionRepository creates a new retention repository
+func NewRetentionRepository(db *gorm.DB) interfaces.RetentionRepositoryInterface {
+	return &RetentionRepository{db: db}
+}
+
+// ========================================
+// RESERVATIONS
+// ========================================
+
+// expiredReservations selects reservations, deleted ones included, that ended before the
+// cutoff and were not anonymized yet
+func (r *RetentionRepository) expiredReservations(tx *gorm.DB, endedBefore time.Time) *gorm.DB {
+	return tx.Unscoped().Model(&models.Reservation{}).
+		Where("end_time < ? AND anonymized_at IS NULL", endedBefore)
+}
+
+// CountReservationsToAnonymize counts the reservations the next anonymization would change
+func (r *RetentionRepository) CountReservationsToAnonymize(endedBefore time.Time) (int64, error) {
+	var count int64
+	err := r.expiredReservations(r.db, endedBefore).Count(&count).Error
+	return count, err
+}
+
+// AnonymizeReservations strips free text, plates, questionnaire answers and guest details
+// from past reservations, and their titles and payloads from the activity feed. Space,
+// times, organizer, status and cost stay for statistics.
+func (r *RetentionRepository) AnonymizeReservations(endedBefore, at time.Time) (int64, error) {
+	var anonymized int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		expired := func() *gorm.DB {
+			return r.expiredReservations(tx, endedBefore).Select("id")
+		}
+
+		if err := tx.Model(&models.ReservationGuest{}).Where("reservation_id IN (?)", expired()).
+			Updates(map[string]interface{}{
+				"name":    "Guest",
+				"email":   "",
+				"company": "",
+			}).Error; err != nil {
+			return fmt.Errorf("anonymize guests: %w", err)
+		}
+
+		if err := tx.Model(&models.QuestionnaireSubmission{}).Where("reservation_id IN (?)", expired()).
+			Update("answers", "{}").Error; err != nil {
+			return fmt.Errorf("anonymize questionnaire answers: %w", err)
+		}
+
+		if err := tx.Model(&models.ActivityEvent{}).
+			Where("entity_type = ? AND entity_id IN (?)", models.ActivityEntityReservation, expired()).
+			Updates(map[string]interface{}{
+				"entity_name": "Reservation",
+				"details":     gorm.Expr("NULL"),
+			}).Error; err != nil {
+			return fmt.Errorf("anonymize activity: %w", err)
+		}
+
+		result := r.expiredReservations(tx, endedBefore).Updates(map[string]interface{}{
+			"title":                   "Reservation",
+			"description":             "",
+			"license_plate":           "",
+			"duplicate_justification": "",
+			"cancellation_reason":     "",
+			"requester_name":          "",
+			"requester_email":         "",
+			"approval_comments":       "",
+			"anonymized_at":           at,
+		})
+		if result.Error != nil {
+			return fmt.Errorf("anonymize reservations: %w", result.Error)
+		}
+		anonymized = result.RowsAffected
+		return nil
+	})
+
+	return anonymized, err
+}
+
+// ========================================
+// CHAT MESSAGES
+// ========================================
+
+// CountMessages counts the chat messages sent before the cutoff
+func (r *RetentionRepository) CountMessages(before time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Message{}).Where("created_at < ?", before).Count(&count).Error
+	return count, err
+}
+
+// PurgeMessages deletes the chat messages sent before the cutoff with their attachments and
+// read receipts, and returns the attachment file URLs
+func (r *RetentionRepository) PurgeMessages(before time.Time) ([]string, int64, error) {
+	var fileURLs []string
+	var deleted int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		expired := func() *gorm.DB {
+			return tx.Model(&models.Message{}).Select("id").Where("created_at < ?", before)
+		}
+
+		if err := tx.Model(&models.MessageAttachment{}).
+			Where("message_id IN (?)", expired()).
+			Pluck("file_url", &fileURLs).Error; err != nil {
+			return err
+		}
+
+		// Attachments and receipts reference messages without cascading deletes
+		if err := tx.Where("message_id IN (?)", expired()).Delete(&models.MessageAttachment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("message_id IN (?)", expired()).Delete(&models.MessageReadReceipt{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("message_id IN (?)", expired()).Delete(&models.MessageDeliveryReceipt{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id IN (?)", expired()).Delete(&models.Message{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+
+	return fileURLs, deleted, err
+}
+
+// ========================================
+// AUDIT LOGS
+// ========================================
+
+// openDeadLetterReferences selects what open dead letters still point to, so it survives
+// until they are replayed or discarded
+func (r *RetentionRepository) openDeadLetterReferences(tx *gorm.DB) *gorm.DB {
+	return tx.Model(&models.DeadLetter{}).Select("reference_id").
+		Where("status = ? AND reference_id IS NOT NULL", models.DeadLetterStatusOpen)
+}
+
+// expiredAuditLogs returns the queries selecting each kind of log created before the cutoff
+func (r *RetentionRepository) expiredAuditLogs(tx *gorm.DB, before time.Time) []*gorm.DB {
+	return []*gorm.DB{
+		tx.Unscoped().Model(&models.Notification{}).
+			Where("created_at < ? AND status <> ?", before, models.NotificationStatusPending).
+			Where("id NOT IN (?)", r.openDeadLetterReferences(tx)),
+		tx.Model(&models.WebhookDelivery{}).
+			Where("created_at < ? AND status <> ?", before, models.WebhookDeliveryPending).
+			Where("id NOT IN (?)", r.openDeadLetterReferences(tx)),
+		tx.Model(&models.DeadLetter{}).
+			Where("created_at < ? AND status <> ?", before, models.DeadLetterStatusOpen),
+		tx.Model(&models.ActivityEvent{}).
+			Where("occurred_at < ?", before),
+	}
+}
+
+// CountAuditLogs counts the logs the next purge would delete
+func (r *RetentionRepository) CountAuditLogs(before time.Time) (int64, error) {
+	var total int64
+	for _, query := range r.expiredAuditLogs(r.db, before) {
+		var count int64
+		if err := query.Count(&count).Error; err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// PurgeAuditLogs deletes notifications, webhook deliveries and dead letters created before
+// the cutoff once they are finished, and the activity feed up to the cutoff
+func (r *RetentionRepository) PurgeAuditLogs(before time.Time) (int64, error) {
+	var deleted int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		targets := []interface{}{&models.Notification{}, &models.WebhookDelivery{}, &models.DeadLetter{}, &models.ActivityEvent{}}
+		for i, query := range r.expiredAuditLogs(tx, before) {
+			result := query.Delete(targets[i])
+			if result.Error != nil {
+				return result.Error
+			}
+			deleted += result.RowsAffected
+		}
+		return nil
+	})
+
+	return deleted, err
+}
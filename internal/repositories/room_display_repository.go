@@ -0,0 +1,62 @@
+// internal/repositories/room_display_repository.go
+package repositories
+
+import (
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoomDisplayRepository implements the RoomDisplayRepositoryInterface
+type RoomDisplayRepository struct {
+	db *gorm.DB
+}
+
+// NewRoomDisplayRepository creates a new room display repository
+func NewRoomDisplayRepository(db *gorm.DB) interfaces.RoomDisplayRepositoryInterface {
+	return &RoomDisplayRepository{db: db}
+}
+
+// Create creates a new room display
+func (r *RoomDisplayRepository) Create(display *models.RoomDisplay) (*models.RoomDisplay, error) {
+	if err := r.db.Create(display).Error; err != nil {
+		return nil, err
+	}
+	return display, nil
+}
+
+// GetByID retrieves a display by ID
+func (r *RoomDisplayRepository) GetByID(id uuid.UUID) (*models.RoomDisplay, error) {
+	var display models.RoomDisplay
+	err := r.db.Where("id = ?", id).First(&display).Error
+	if err != nil {
+		return nil, err
+	}
+	return &display, nil
+}
+
+// GetByKeyHash retrieves a display by the hash of its API key, with its space
+func (r *RoomDisplayRepository) GetByKeyHash(keyHash string) (*models.RoomDisplay, error) {
+	var display models.RoomDisplay
+	err := r.db.Preload("Space").Where("api_key_hash = ?", keyHash).First(&display).Error
+	if err != nil {
+		return nil, err
+	}
+	return &display, nil
+}
+
+// GetBySpace retrieves the displays registered for a space
+func (r *RoomDisplayRepository) GetBySpace(spaceID uuid.UUID) ([]*models.RoomDisplay, error) {
+	var displays []*models.RoomDisplay
+	err := r.db.Where("space_id = ?", spaceID).
+		Order("created_at ASC").
+		Find(&displays).Error
+	return displays, err
+}
+
+// Update updates a display
+func (r *RoomDisplayRepository) Update(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Model(&models.RoomDisplay{}).Where("id = ?", id).Updates(updates).Error
+}
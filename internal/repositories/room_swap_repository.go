@@ -0,0 +1,63 @@
+// internal/repositories/room_swap_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoomSwapRepository implements the RoomSwapRepositoryInterface
+type RoomSwapRepository struct {
+	db *gorm.DB
+}
+
+// NewRoomSwapRepository creates a new room swap repository
+func NewRoomSwapRepository(db *gorm.DB) interfaces.RoomSwapRepositoryInterface {
+	return &RoomSwapRepository{db: db}
+}
+
+// Create creates a new room swap suggestion
+func (r *RoomSwapRepository) Create(suggestion *models.RoomSwapSuggestion) (*models.RoomSwapSuggestion, error) {
+	if err := r.db.Create(suggestion).Error; err != nil {
+		return nil, err
+	}
+	return suggestion, nil
+}
+
+// GetByToken retrieves a suggestion by its one-click token
+func (r *RoomSwapRepository) GetByToken(token string) (*models.RoomSwapSuggestion, error) {
+	var suggestion models.RoomSwapSuggestion
+	err := r.db.Preload("Reservation").Preload("CurrentSpace").Preload("SuggestedSpace").
+		Where("token = ?", token).First(&suggestion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &suggestion, nil
+}
+
+// Update updates a suggestion
+func (r *RoomSwapRepository) Update(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Model(&models.RoomSwapSuggestion{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// HasSuggestionForReservation checks if a reservation was already offered a swap
+func (r *RoomSwapRepository) HasSuggestionForReservation(reservationID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.RoomSwapSuggestion{}).
+		Where("reservation_id = ?", reservationID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ExpireStale marks pending suggestions past their expiry as expired
+func (r *RoomSwapRepository) ExpireStale(now time.Time) (int64, error) {
+	result := r.db.Model(&models.RoomSwapSuggestion{}).
+		Where("status = ? AND expires_at <= ?", models.RoomSwapStatusPending, now).
+		Update("status", models.RoomSwapStatusExpired)
+	return result.RowsAffected, result.Error
+}
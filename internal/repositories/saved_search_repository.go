@@ -0,0 +1,77 @@
+// internal/repositories/saved_search_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SavedSearchRepository implements the SavedSearchRepositoryInterface
+type SavedSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewSavedSearchRepository creates a new saved search repository
+func NewSavedSearchRepository(db *gorm.DB) interfaces.SavedSearchRepositoryInterface {
+	return &SavedSearchRepository{db: db}
+}
+
+// Create creates a new saved search
+func (r *SavedSearchRepository) Create(search *models.SavedSearch) (*models.SavedSearch, error) {
+	if err := r.db.Create(search).Error; err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+// GetByID retrieves a saved search by ID
+func (r *SavedSearchRepository) GetByID(id uuid.UUID) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	if err := r.db.Where("id = ?", id).First(&search).Error; err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+// GetByUser retrieves the saved searches of a user by name
+func (r *SavedSearchRepository) GetByUser(userID uuid.UUID) ([]*models.SavedSearch, error) {
+	var searches []*models.SavedSearch
+	err := r.db.Where("user_id = ?", userID).Order("name ASC").Find(&searches).Error
+	return searches, err
+}
+
+// CountByUser counts the saved searches of a user
+func (r *SavedSearchRepository) CountByUser(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.SavedSearch{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Update updates a saved search
+func (r *SavedSearchRepository) Update(id uuid.UUID, updates map[string]interface{}) (*models.SavedSearch, error) {
+	if err := r.db.Model(&models.SavedSearch{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(id)
+}
+
+// Delete deletes a saved search
+func (r *SavedSearchRepository) Delete(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&models.SavedSearch{}).Error
+}
+
+// GetAlertCandidates lists the searches with alerts on whose window overlaps the time range;
+// searches without a window cover any time
+func (r *SavedSearchRepository) GetAlertCandidates(startTime, endTime time.Time) ([]*models.SavedSearch, error) {
+	var searches []*models.SavedSearch
+	err := r.db.Where("alerts_enabled = ?", true).
+		Where("window_start IS NULL OR window_start < ?", endTime).
+		Where("window_end IS NULL OR window_end > ?", startTime).
+		Find(&searches).Error
+	return searches, err
+}
@@ -0,0 +1,167 @@
+// internal/repositories/service_request_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ServiceRequestRepository implements the ServiceRequestRepositoryInterface
+type ServiceRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewServiceRequestRepository creates a new service request repository
+func NewServiceRequestRepository(db *gorm.DB) interfaces.ServiceRequestRepositoryInterface {
+	return &ServiceRequestRepository{db: db}
+}
+
+// ========================================
+// CATALOG OPERATIONS
+// ========================================
+
+// CreateItem adds a service to the catalog
+func (r *ServiceRequestRepository) CreateItem(item *models.ServiceItem) error {
+	return r.db.Create(item).Error
+}
+
+// GetItemByID retrieves a catalog service by ID
+func (r *ServiceRequestRepository) GetItemByID(id uuid.UUID) (*models.ServiceItem, error) {
+	var item models.ServiceItem
+	err := r.db.Where("id = ?", id).First(&item).Error
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetItems retrieves the catalog by category and name
+func (r *ServiceRequestRepository) GetItems(activeOnly bool) ([]*models.ServiceItem, error) {
+	var items []*models.ServiceItem
+	query := r.db.Model(&models.ServiceItem{})
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("category ASC, name ASC").Find(&items).Error
+	return items, err
+}
+
+// UpdateItem updates a catalog service
+func (r *ServiceRequestRepository) UpdateItem(id uuid.UUID, updates map[string]interface{}) (*models.ServiceItem, error) {
+	result := r.db.Model(&models.ServiceItem{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.GetItemByID(id)
+}
+
+// ========================================
+// REQUEST OPERATIONS
+// ========================================
+
+// Create places a service request
+func (r *ServiceRequestRepository) Create(request *models.ServiceRequest) error {
+	return r.db.Create(request).Error
+}
+
+// GetByID retrieves a service request with its service and reservation
+func (r *ServiceRequestRepository) GetByID(id uuid.UUID) (*models.ServiceRequest, error) {
+	var request models.ServiceRequest
+	err := r.db.Preload("ServiceItem").
+		Preload("Reservation").
+		Preload("Reservation.Space").
+		Where("id = ?", id).
+		First(&request).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// GetByReservation retrieves the services ordered with a reservation
+func (r *ServiceRequestRepository) GetByReservation(reservationID uuid.UUID) ([]*models.ServiceRequest, error) {
+	var requests []*models.ServiceRequest
+	err := r.db.Preload("ServiceItem").
+		Where("reservation_id = ?", reservationID).
+		Order("created_at ASC").
+		Find(&requests).Error
+	return requests, err
+}
+
+// GetQueue retrieves the requests facility staff work on, soonest due first
+func (r *ServiceRequestRepository) GetQueue(filters interfaces.ServiceQueueFilters, offset, limit int) ([]*models.ServiceRequest, int64, error) {
+	statuses := filters.Statuses
+	if len(statuses) == 0 {
+		statuses = []models.ServiceRequestStatus{models.ServiceRequestRequested, models.ServiceRequestAccepted}
+	}
+
+	query := r.db.Model(&models.ServiceRequest{}).Where("service_requests.status IN ?", statuses)
+	if filters.Category != nil {
+		query = query.Where("service_requests.service_item_id IN (?)",
+			r.db.Model(&models.ServiceItem{}).Select("id").Where("category = ?", *filters.Category))
+	}
+	if filters.Building != nil {
+		query = query.Where("service_requests.reservation_id IN (?)",
+			r.db.Model(&models.Reservation{}).Select("reservations.id").
+				Joins("JOIN spaces ON spaces.id = reservations.space_id").
+				Where("spaces.building = ?", *filters.Building))
+	}
+	if filters.From != nil {
+		query = query.Where("service_requests.due_at >= ?", *filters.From)
+	}
+	if filters.To != nil {
+		query = query.Where("service_requests.due_at < ?", *filters.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var requests []*models.ServiceRequest
+	err := query.Preload("ServiceItem").
+		Preload("Reservation").
+		Preload("Reservation.Space").
+		Order("service_requests.due_at ASC, service_requests.created_at ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&requests).Error
+	return requests, total, err
+}
+
+// Update updates a service request
+func (r *ServiceRequestRepository) Update(id uuid.UUID, updates map[string]interface{}) (*models.ServiceRequest, error) {
+	err := r.db.Model(&models.ServiceRequest{}).Where("id = ?", id).Updates(updates).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(id)
+}
+
+// MoveOpenForReservation moves the services of a reservation not delivered yet to its new start
+func (r *ServiceRequestRepository) MoveOpenForReservation(reservationID uuid.UUID, dueAt time.Time) error {
+	return r.db.Model(&models.ServiceRequest{}).
+		Where("reservation_id = ? AND status IN ? AND due_at <> ?", reservationID,
+			[]models.ServiceRequestStatus{models.ServiceRequestRequested, models.ServiceRequestAccepted}, dueAt).
+		Update("due_at", dueAt).Error
+}
+
+// CancelOpenForReservation cancels the services of a reservation that were not delivered yet
+func (r *ServiceRequestRepository) CancelOpenForReservation(reservationID uuid.UUID, cancelledAt time.Time) (int64, error) {
+	result := r.db.Model(&models.ServiceRequest{}).
+		Where("reservation_id = ? AND status IN ?", reservationID,
+			[]models.ServiceRequestStatus{models.ServiceRequestRequested, models.ServiceRequestAccepted}).
+		Updates(map[string]interface{}{
+			"status":            models.ServiceRequestCancelled,
+			"status_changed_at": cancelledAt,
+		})
+	return result.RowsAffected, result.Error
+}
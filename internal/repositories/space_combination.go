@@ -0,0 +1,23 @@
+// internal/repositories/space_combination.go
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// sharedAreaCondition matches two spaces, given by their table aliases, that take up the same
+// floor area: the same space, a combined space and one of its parts, or two combined spaces
+// with a part in common. A booking of either blocks the other.
+func sharedAreaCondition(a, b string) string {
+	return fmt.Sprintf("(%[1]s.id = %[2]s.id OR %[1]s.id::text = ANY(%[2]s.combination_of) OR %[2]s.id::text = ANY(%[1]s.combination_of) OR %[1]s.combination_of && %[2]s.combination_of)", a, b)
+}
+
+// sharingAreaWith selects the IDs of the spaces sharing floor area with the space, itself included
+func sharingAreaWith(db *gorm.DB, spaceID uuid.UUID) *gorm.DB {
+	return db.Table("spaces AS sharing").Select("sharing.id").
+		Joins("JOIN spaces AS target ON "+sharedAreaCondition("sharing", "target")).
+		Where("target.id = ? AND (sharing.id = target.id OR sharing.deleted_at IS NULL)", spaceID)
+}
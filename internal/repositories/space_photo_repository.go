@@ -0,0 +1,77 @@
+// internal/repositories/space_photo_repository.go
+package repositories
+
+import (
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SpacePhotoRepository implements the SpacePhotoRepositoryInterface
+type SpacePhotoRepository struct {
+	db *gorm.DB
+}
+
+// NewSpacePhotoRepository creates a new space photo repository
+func NewSpacePhotoRepository(db *gorm.DB) interfaces.SpacePhotoRepositoryInterface {
+	return &SpacePhotoRepository{db: db}
+}
+
+// Create adds a photo to the gallery of a space
+func (r *SpacePhotoRepository) Create(photo *models.SpacePhoto) (*models.SpacePhoto, error) {
+	if err := r.db.Create(photo).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(photo.ID)
+}
+
+// GetByID retrieves a photo by ID
+func (r *SpacePhotoRepository) GetByID(id uuid.UUID) (*models.SpacePhoto, error) {
+	var photo models.SpacePhoto
+	err := r.db.Where("id = ?", id).First(&photo).Error
+	if err != nil {
+		return nil, err
+	}
+	return &photo, nil
+}
+
+// GetBySpace lists the photos of a space in gallery order
+func (r *SpacePhotoRepository) GetBySpace(spaceID uuid.UUID) ([]*models.SpacePhoto, error) {
+	var photos []*models.SpacePhoto
+	err := r.db.Where("space_id = ?", spaceID).
+		Order("position ASC, created_at ASC").
+		Find(&photos).Error
+	return photos, err
+}
+
+// SaveOrder sets the position of each photo and flags the primary one, in one transaction
+func (r *SpacePhotoRepository) SaveOrder(spaceID uuid.UUID, photoIDs []uuid.UUID, primaryID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for position, id := range photoIDs {
+			err := tx.Model(&models.SpacePhoto{}).
+				Where("id = ? AND space_id = ?", id, spaceID).
+				Updates(map[string]interface{}{
+					"position":   position,
+					"is_primary": id == primaryID,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete removes a photo from its gallery
+func (r *SpacePhotoRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.SpacePhoto{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"room-reservation-api/internal/dto"
 	"room-reservation-api/internal/models"
 	"room-reservation-api/internal/repositories/interfaces"
 
@@ -15,12 +16,17 @@ import (
 
 // SpaceRepository implements the SpaceRepositoryInterface
 type SpaceRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	replica *gorm.DB // Serves lists and counts, which tolerate replication lag
 }
 
-// NewSpaceRepository creates a new space repository
-func NewSpaceRepository(db *gorm.DB) interfaces.SpaceRepositoryInterface {
-	return &SpaceRepository{db: db}
+// NewSpaceRepository creates a new space repository. Lists and counts read from the
+// replica when one is given; everything else, availability checks included, uses db.
+func NewSpaceRepository(db, replica *gorm.DB) interfaces.SpaceRepositoryInterface {
+	if replica == nil {
+		replica = db
+	}
+	return &SpaceRepository{db: db, replica: replica}
 }
 
 // ========================================
@@ -57,30 +63,59 @@ func (r *SpaceRepository) Update(id uuid.UUID, updates map[string]interface{}) (
 	return r.GetByID(id)
 }
 
+// UpdateIfUnmodified updates a space only if its updated_at is still the one given,
+// returning gorm.ErrRecordNotFound when another write got there first
+func (r *SpaceRepository) UpdateIfUnmodified(id uuid.UUID, updatedAt time.Time, updates map[string]interface{}) (*models.Space, error) {
+	result := r.db.Model(&models.Space{}).
+		Where("id = ? AND updated_at = ?", id, updatedAt.Truncate(time.Microsecond)).
+		Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return r.GetByID(id)
+}
+
 // Delete soft deletes a space
 func (r *SpaceRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.Space{}, "id = ?", id).Error
 }
 
 // GetAll retrieves all spaces with pagination
-func (r *SpaceRepository) GetAll(offset, limit int) ([]*models.Space, int64, error) {
+func (r *SpaceRepository) GetAll(viewer *interfaces.PilotViewer, offset, limit int) ([]*models.Space, int64, error) {
 	var spaces []*models.Space
 	var total int64
 
 	// Count total
-	if err := r.db.Model(&models.Space{}).Count(&total).Error; err != nil {
+	if err := r.scopePilot(r.replica.Model(&models.Space{}), viewer).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get spaces with pagination
-	err := r.db.Preload("Manager").
-		Order("name ASC").
+	err := r.scopePilot(r.replica, viewer).Preload("Manager").
+		Order("name ASC, id ASC").
 		Offset(offset).Limit(limit).
 		Find(&spaces).Error
 
 	return spaces, total, err
 }
 
+// GetAllAfter retrieves the spaces that come after the cursor by name, without counting them
+func (r *SpaceRepository) GetAllAfter(viewer *interfaces.PilotViewer, after *dto.Cursor, limit int) ([]*models.Space, error) {
+	var spaces []*models.Space
+
+	query := r.scopePilot(r.replica, viewer).Preload("Manager")
+	if after != nil {
+		query = query.Where("(name, id) > (?, ?)", after.Key, after.ID)
+	}
+
+	err := query.Order("name ASC, id ASC").Limit(limit).Find(&spaces).Error
+	return spaces, err
+}
+
 // ========================================
 // SEARCH AND FILTER OPERATIONS
 // ========================================
@@ -91,7 +126,7 @@ func (r *SpaceRepository) SearchSpaces(filters interfaces.SpaceFilters, offset,
 	var total int64
 
 	// Build the base query
-	query := r.db.Model(&models.Space{})
+	query := r.replica.Model(&models.Space{})
 
 	// Apply filters
 	query = r.applyFilters(query, filters)
@@ -113,17 +148,17 @@ func (r *SpaceRepository) SearchSpaces(filters interfaces.SpaceFilters, offset,
 }
 
 // GetSpacesByBuilding retrieves spaces in a specific building
-func (r *SpaceRepository) GetSpacesByBuilding(building string, offset, limit int) ([]*models.Space, int64, error) {
+func (r *SpaceRepository) GetSpacesByBuilding(building string, viewer *interfaces.PilotViewer, offset, limit int) ([]*models.Space, int64, error) {
 	var spaces []*models.Space
 	var total int64
 
 	// Count total
-	if err := r.db.Model(&models.Space{}).Where("building = ?", building).Count(&total).Error; err != nil {
+	if err := r.scopePilot(r.replica.Model(&models.Space{}), viewer).Where("building = ?", building).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get spaces
-	err := r.db.Preload("Manager").
+	err := r.scopePilot(r.replica, viewer).Preload("Manager").
 		Where("building = ?", building).
 		Order("floor ASC, room_number ASC").
 		Offset(offset).Limit(limit).
@@ -133,17 +168,17 @@ func (r *SpaceRepository) GetSpacesByBuilding(building string, offset, limit int
 }
 
 // GetSpacesByType retrieves spaces of a specific type
-func (r *SpaceRepository) GetSpacesByType(spaceType string, offset, limit int) ([]*models.Space, int64, error) {
+func (r *SpaceRepository) GetSpacesByType(spaceType string, viewer *interfaces.PilotViewer, offset, limit int) ([]*models.Space, int64, error) {
 	var spaces []*models.Space
 	var total int64
 
 	// Count total
-	if err := r.db.Model(&models.Space{}).Where("type = ?", spaceType).Count(&total).Error; err != nil {
+	if err := r.scopePilot(r.replica.Model(&models.Space{}), viewer).Where("type = ?", spaceType).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get spaces
-	err := r.db.Preload("Manager").
+	err := r.scopePilot(r.replica, viewer).Preload("Manager").
 		Where("type = ?", spaceType).
 		Order("name ASC").
 		Offset(offset).Limit(limit).
@@ -158,12 +193,12 @@ func (r *SpaceRepository) GetSpacesByStatus(status string, offset, limit int) ([
 	var total int64
 
 	// Count total
-	if err := r.db.Model(&models.Space{}).Where("status = ?", status).Count(&total).Error; err != nil {
+	if err := r.replica.Model(&models.Space{}).Where("status = ?", status).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get spaces
-	err := r.db.Preload("Manager").
+	err := r.replica.Preload("Manager").
 		Where("status = ?", status).
 		Order("name ASC").
 		Offset(offset).Limit(limit).
@@ -177,7 +212,7 @@ func (r *SpaceRepository) GetSpacesByCapacityRange(minCapacity, maxCapacity int,
 	var spaces []*models.Space
 	var total int64
 
-	query := r.db.Model(&models.Space{})
+	query := r.replica.Model(&models.Space{})
 
 	// Apply capacity filters
 	if minCapacity > 0 {
@@ -206,19 +241,21 @@ func (r *SpaceRepository) GetSpacesByCapacityRange(minCapacity, maxCapacity int,
 // ========================================
 
 // GetAvailableSpaces retrieves spaces available during a specific time period
-func (r *SpaceRepository) GetAvailableSpaces(startTime, endTime time.Time, offset, limit int) ([]*models.Space, int64, error) {
+func (r *SpaceRepository) GetAvailableSpaces(startTime, endTime time.Time, viewer *interfaces.PilotViewer, offset, limit int) ([]*models.Space, int64, error) {
 	var spaces []*models.Space
 	var total int64
 
-	// Subquery to find spaces that have conflicting reservations
-	conflictingSpaces := r.db.Table("reservations").
-		Select("DISTINCT space_id").
-		Where("status IN ? AND start_time < ? AND end_time > ?",
-			[]string{"confirmed", "pending"}, endTime, startTime)
-
-	// Query for available spaces (not in conflicting list and status = available)
+	// Query for available spaces: status = available and no reservation within the space's buffer time,
+	// neither of the space nor of the spaces sharing its floor area
 	query := r.db.Model(&models.Space{}).
-		Where("status = ? AND id NOT IN (?)", "available", conflictingSpaces)
+		Where("status = ?", "available").
+		Where(`NOT EXISTS (SELECT 1 FROM reservations JOIN spaces AS booked ON booked.id = reservations.space_id
+			WHERE `+sharedAreaCondition("booked", "spaces")+` AND reservations.status IN ? AND reservations.deleted_at IS NULL
+			AND reservations.start_time < CAST(? AS timestamptz) + (spaces.buffer_before + spaces.buffer_after) * interval '1 minute'
+			AND reservations.end_time > CAST(? AS timestamptz) - (spaces.buffer_before + spaces.buffer_after) * interval '1 minute')`,
+			[]string{"confirmed", "pending"}, endTime, startTime).
+		Where("NOT "+closedSpaceCondition("spaces"), endTime, startTime)
+	query = r.scopePilot(query, viewer)
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -236,20 +273,24 @@ func (r *SpaceRepository) GetAvailableSpaces(startTime, endTime time.Time, offse
 
 // CheckSpaceAvailability checks if a specific space is available during a time period
 func (r *SpaceRepository) CheckSpaceAvailability(spaceID uuid.UUID, startTime, endTime time.Time) (bool, error) {
-	// First check if space exists and is available status
+	// First check if space exists, is available status and not closed
 	var space models.Space
-	if err := r.db.Where("id = ? AND status = ?", spaceID, "available").First(&space).Error; err != nil {
+	err := r.db.Where("id = ? AND status = ?", spaceID, "available").
+		Where("NOT "+closedSpaceCondition("spaces"), endTime, startTime).
+		First(&space).Error
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return false, nil // Space doesn't exist or not available
+			return false, nil // Space doesn't exist, not available or closed
 		}
 		return false, err
 	}
 
-	// Check for conflicting reservations
+	// Check for conflicting reservations, keeping the buffer time free
 	var count int64
-	err := r.db.Model(&models.Reservation{}).
-		Where("space_id = ? AND status IN ? AND start_time < ? AND end_time > ?",
-			spaceID, []string{"confirmed", "pending"}, endTime, startTime).
+	gap := space.BufferGap()
+	err = r.db.Model(&models.Reservation{}).
+		Where("space_id IN (?) AND status IN ? AND start_time < ? AND end_time > ?",
+			sharingAreaWith(r.db, spaceID), []string{"confirmed", "pending"}, endTime.Add(gap), startTime.Add(-gap)).
 		Count(&count).Error
 
 	if err != nil {
@@ -269,12 +310,12 @@ func (r *SpaceRepository) GetSpacesByManager(managerID uuid.UUID, offset, limit
 	var total int64
 
 	// Count total
-	if err := r.db.Model(&models.Space{}).Where("manager_id = ?", managerID).Count(&total).Error; err != nil {
+	if err := r.replica.Model(&models.Space{}).Where("manager_id = ?", managerID).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get spaces
-	err := r.db.Preload("Manager").
+	err := r.replica.Preload("Manager").
 		Where("manager_id = ?", managerID).
 		Order("building ASC, floor ASC, room_number ASC").
 		Offset(offset).Limit(limit).
@@ -290,7 +331,7 @@ func (r *SpaceRepository) GetSpacesByManager(managerID uuid.UUID, offset, limit
 // GetDistinctBuildings retrieves all unique building names
 func (r *SpaceRepository) GetDistinctBuildings() ([]string, error) {
 	var buildings []string
-	err := r.db.Model(&models.Space{}).
+	err := r.replica.Model(&models.Space{}).
 		Distinct("building").
 		Order("building ASC").
 		Pluck("building", &buildings).Error
@@ -301,7 +342,7 @@ func (r *SpaceRepository) GetDistinctBuildings() ([]string, error) {
 // GetDistinctFloors retrieves all unique floor numbers
 func (r *SpaceRepository) GetDistinctFloors() ([]int, error) {
 	var floors []int
-	err := r.db.Model(&models.Space{}).
+	err := r.replica.Model(&models.Space{}).
 		Distinct("floor").
 		Order("floor ASC").
 		Pluck("floor", &floors).Error
@@ -329,6 +370,13 @@ func (r *SpaceRepository) ExistsByNameAndBuildingExcluding(name, building string
 	return count > 0, err
 }
 
+// GetCombinedSpacesIncluding retrieves the combined spaces the space is a part of
+func (r *SpaceRepository) GetCombinedSpacesIncluding(partID uuid.UUID) ([]*models.Space, error) {
+	var spaces []*models.Space
+	err := r.db.Where("? = ANY(combination_of)", partID.String()).Order("name ASC").Find(&spaces).Error
+	return spaces, err
+}
+
 // ========================================
 // SIMPLE COUNTS
 // ========================================
@@ -336,21 +384,21 @@ func (r *SpaceRepository) ExistsByNameAndBuildingExcluding(name, building string
 // CountSpaces counts total number of spaces
 func (r *SpaceRepository) CountSpaces() (int64, error) {
 	var count int64
-	err := r.db.Model(&models.Space{}).Count(&count).Error
+	err := r.replica.Model(&models.Space{}).Count(&count).Error
 	return count, err
 }
 
 // CountSpacesByStatus counts spaces by status
 func (r *SpaceRepository) CountSpacesByStatus(status string) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.Space{}).Where("status = ?", status).Count(&count).Error
+	err := r.replica.Model(&models.Space{}).Where("status = ?", status).Count(&count).Error
 	return count, err
 }
 
 // CountSpacesByBuilding counts spaces in a specific building
 func (r *SpaceRepository) CountSpacesByBuilding(building string) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.Space{}).Where("building = ?", building).Count(&count).Error
+	err := r.replica.Model(&models.Space{}).Where("building = ?", building).Count(&count).Error
 	return count, err
 }
 
@@ -365,6 +413,28 @@ func (r *SpaceRepository) applyFilters(query *gorm.DB, filters interfaces.SpaceF
 		query = query.Where("type IN ?", filters.Types)
 	}
 
+	// Filter by resource class
+	if filters.ResourceClass != "" {
+		query = query.Where("type IN ?", models.SpaceTypesOf(models.ResourceClass(filters.ResourceClass)))
+	}
+
+	// Filter by resource attributes
+	if filters.MinMonitors != nil && *filters.MinMonitors > 0 {
+		query = query.Where("COALESCE((attributes->>'monitor_count')::int, 0) >= ?", *filters.MinMonitors)
+	}
+	if filters.StandingDesk != nil {
+		query = query.Where("COALESCE((attributes->>'standing_desk')::boolean, false) = ?", *filters.StandingDesk)
+	}
+	if filters.DockingStation != nil {
+		query = query.Where("COALESCE((attributes->>'docking_station')::boolean, false) = ?", *filters.DockingStation)
+	}
+	if filters.EVCharging != nil {
+		query = query.Where("COALESCE((attributes->>'ev_charging')::boolean, false) = ?", *filters.EVCharging)
+	}
+	if filters.EquipmentCategory != "" {
+		query = query.Where("LOWER(attributes->>'category') = ?", strings.ToLower(filters.EquipmentCategory))
+	}
+
 	// Filter by buildings
 	if len(filters.Buildings) > 0 {
 		query = query.Where("building IN ?", filters.Buildings)
@@ -407,20 +477,44 @@ func (r *SpaceRepository) applyFilters(query *gorm.DB, filters interfaces.SpaceF
 		)
 	}
 
+	// Hide spaces in soft launch unless the viewer belongs to their pilot group
+	query = r.scopePilot(query, filters.PilotViewer)
+
 	// Filter by availability (if both start and end times are provided)
 	if filters.AvailableStart != nil && filters.AvailableEnd != nil {
-		// Exclude spaces that have conflicting reservations
+		// Exclude spaces that have conflicting reservations, or share floor area with a space that has
 		conflictingSpaces := r.db.Table("reservations").
-			Select("DISTINCT space_id").
-			Where("status IN ? AND start_time < ? AND end_time > ?",
+			Select("DISTINCT sharing.id").
+			Joins("JOIN spaces AS booked ON booked.id = reservations.space_id").
+			Joins("JOIN spaces AS sharing ON "+sharedAreaCondition("sharing", "booked")).
+			Where("reservations.status IN ? AND reservations.start_time < ? AND reservations.end_time > ? AND reservations.deleted_at IS NULL",
+				[]string{"confirmed", "pending"}, *filters.AvailableEnd, *filters.AvailableStart)
+
+		// and resources booked as add-ons to another space
+		bookedAddOns := r.db.Table("reservation_resources").
+			Select("DISTINCT reservation_resources.resource_id").
+			Joins("JOIN reservations ON reservations.id = reservation_resources.reservation_id").
+			Where("reservations.status IN ? AND reservations.start_time < ? AND reservations.end_time > ? AND reservations.deleted_at IS NULL",
 				[]string{"confirmed", "pending"}, *filters.AvailableEnd, *filters.AvailableStart)
 
-		query = query.Where("id NOT IN (?)", conflictingSpaces)
+		query = query.Where("id NOT IN (?) AND id NOT IN (?)", conflictingSpaces, bookedAddOns).
+			Where("NOT "+closedSpaceCondition("spaces"), *filters.AvailableEnd, *filters.AvailableStart)
 	}
 
 	return query
 }
 
+// scopePilot hides spaces in soft launch from a viewer outside their pilot group
+func (r *SpaceRepository) scopePilot(query *gorm.DB, viewer *interfaces.PilotViewer) *gorm.DB {
+	if viewer == nil {
+		return query
+	}
+	return query.Where(
+		"pilot_until IS NULL OR pilot_until <= ? OR ? = ANY(pilot_user_ids) OR EXISTS (SELECT 1 FROM unnest(pilot_departments) AS d WHERE LOWER(d) = LOWER(?))",
+		time.Now(), viewer.UserID.String(), viewer.Department,
+	)
+}
+
 // applySorting applies sorting to the query
 func (r *SpaceRepository) applySorting(query *gorm.DB, sortBy, sortOrder string) *gorm.DB {
 	// Default sorting
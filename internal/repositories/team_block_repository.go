@@ -0,0 +1,136 @@
+// internal/repositories/team_block_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TeamBlockRepository implements the TeamBlockRepositoryInterface
+type TeamBlockRepository struct {
+	db *gorm.DB
+}
+
+// NewTeamBlockRepository creates a new team block repository
+func NewTeamBlockRepository(db *gorm.DB) interfaces.TeamBlockRepositoryInterface {
+	return &TeamBlockRepository{db: db}
+}
+
+// Create saves a new team block
+func (r *TeamBlockRepository) Create(block *models.TeamBlock) (*models.TeamBlock, error) {
+	if err := r.db.Create(block).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(block.ID)
+}
+
+// GetByID retrieves a team block with its neighborhood
+func (r *TeamBlockRepository) GetByID(id uuid.UUID) (*models.TeamBlock, error) {
+	var block models.TeamBlock
+	err := r.db.Preload("Neighborhood").Where("id = ?", id).First(&block).Error
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// GetAll lists the blocks of a department, or of every department when empty, latest first
+func (r *TeamBlockRepository) GetAll(department string) ([]*models.TeamBlock, error) {
+	query := r.db.Preload("Neighborhood")
+	if department != "" {
+		query = query.Where("LOWER(department) = LOWER(?)", department)
+	}
+
+	var blocks []*models.TeamBlock
+	err := query.Order("start_date DESC").Find(&blocks).Error
+	return blocks, err
+}
+
+// Cancel ends the block and cancels its reservations starting after cancelledAt, with an
+// event per reservation, returning how many were cancelled
+func (r *TeamBlockRepository) Cancel(id uuid.UUID, cancelledAt time.Time) (int64, error) {
+	var cancelled int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.TeamBlock{}).
+			Where("id = ? AND cancelled_at IS NULL", id).
+			Update("cancelled_at", cancelledAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var ids []uuid.UUID
+		err := tx.Model(&models.Reservation{}).
+			Where("team_block_id = ? AND status IN ? AND start_time > ?", id,
+				[]models.ReservationStatus{models.StatusPending, models.StatusConfirmed}, cancelledAt).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+
+		for _, reservationID := range ids {
+			err := tx.Model(&models.Reservation{}).Where("id = ?", reservationID).Updates(map[string]interface{}{
+				"status":              models.StatusCancelled,
+				"cancellation_reason": "Team block cancelled",
+			}).Error
+			if err != nil {
+				return err
+			}
+			if err := appendReservationEvent(tx, models.OutboxReservationCancelled, reservationID); err != nil {
+				return err
+			}
+		}
+		cancelled = int64(len(ids))
+		return nil
+	})
+	return cancelled, err
+}
+
+// GetReservations retrieves the bookings of the block starting within the time range, cancelled ones excepted
+func (r *TeamBlockRepository) GetReservations(blockID uuid.UUID, startTime, endTime time.Time) ([]*models.Reservation, error) {
+	var reservations []*models.Reservation
+	err := r.db.Where("team_block_id = ? AND status <> ?", blockID, models.StatusCancelled).
+		Where("start_time >= ? AND start_time < ?", startTime, endTime).
+		Order("start_time ASC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// ========================================
+// REMOTE DAYS
+// ========================================
+
+// AddException records a member working remotely on a day; recording it twice is a no-op
+func (r *TeamBlockRepository) AddException(exception *models.TeamBlockException) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(exception).Error
+}
+
+// RemoveException removes a remote day of a member
+func (r *TeamBlockRepository) RemoveException(blockID, userID uuid.UUID, date time.Time) error {
+	result := r.db.Where("team_block_id = ? AND user_id = ? AND date = ?", blockID, userID, date).
+		Delete(&models.TeamBlockException{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetExceptions retrieves the remote days of the block's members within the date range, both included
+func (r *TeamBlockRepository) GetExceptions(blockID uuid.UUID, startDate, endDate time.Time) ([]*models.TeamBlockException, error) {
+	var exceptions []*models.TeamBlockException
+	err := r.db.Where("team_block_id = ? AND date >= ? AND date <= ?", blockID, startDate, endDate).
+		Order("date ASC").
+		Find(&exceptions).Error
+	return exceptions, err
+}
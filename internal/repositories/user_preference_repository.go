@@ -0,0 +1,43 @@
+// internal/repositories/user_preference_repository.go
+package repositories
+
+import (
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserPreferenceRepository implements the UserPreferenceRepositoryInterface
+type UserPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewUserPreferenceRepository creates a new user preference repository
+func NewUserPreferenceRepository(db *gorm.DB) interfaces.UserPreferenceRepositoryInterface {
+	return &UserPreferenceRepository{db: db}
+}
+
+// Create stores the preferences of a user
+func (r *UserPreferenceRepository) Create(preference *models.UserPreference) (*models.UserPreference, error) {
+	if err := r.db.Create(preference).Error; err != nil {
+		return nil, err
+	}
+	return preference, nil
+}
+
+// GetByUser retrieves the preferences of a user
+func (r *UserPreferenceRepository) GetByUser(userID uuid.UUID) (*models.UserPreference, error) {
+	var preference models.UserPreference
+	err := r.db.Where("user_id = ?", userID).First(&preference).Error
+	if err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+// Update updates the preferences of a user
+func (r *UserPreferenceRepository) Update(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Model(&models.UserPreference{}).Where("id = ?", id).Updates(updates).Error
+}
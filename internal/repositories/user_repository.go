@@ -141,6 +141,11 @@ func (r *UserRepository) UpdateRole(id uuid.UUID, role models.UserRole) error {
 	return r.db.Model(&models.User{}).Where("id = ?", id).Update("role", role).Error
 }
 
+// UpdatePriorityTier updates a user's booking priority tier
+func (r *UserRepository) UpdatePriorityTier(id uuid.UUID, tier int) error {
+	return r.db.Model(&models.User{}).Where("id = ?", id).Update("priority_tier", tier).Error
+}
+
 // UpdatePassword updates a user's password hash
 func (r *UserRepository) UpdatePassword(id uuid.UUID, passwordHash string) error {
 	return r.db.Model(&models.User{}).Where("id = ?", id).Update("password_hash", passwordHash).Error
@@ -0,0 +1,92 @@
+// internal/repositories/vip_block_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VIPBlockRepository implements the VIPBlockRepositoryInterface
+type VIPBlockRepository struct {
+	db *gorm.DB
+}
+
+// NewVIPBlockRepository creates a new VIP block repository
+func NewVIPBlockRepository(db *gorm.DB) interfaces.VIPBlockRepositoryInterface {
+	return &VIPBlockRepository{db: db}
+}
+
+// ========================================
+// BLOCK OPERATIONS
+// ========================================
+
+// Create creates a new VIP block
+func (r *VIPBlockRepository) Create(block *models.VIPBlock) (*models.VIPBlock, error) {
+	if err := r.db.Create(block).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(block.ID)
+}
+
+// GetByID retrieves a VIP block by ID
+func (r *VIPBlockRepository) GetByID(id uuid.UUID) (*models.VIPBlock, error) {
+	var block models.VIPBlock
+	err := r.db.Preload("Space").Where("id = ?", id).First(&block).Error
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// Delete soft deletes a VIP block
+func (r *VIPBlockRepository) Delete(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&models.VIPBlock{}).Error
+}
+
+// GetActiveBlocksBySpace retrieves the active VIP blocks of a space
+func (r *VIPBlockRepository) GetActiveBlocksBySpace(spaceID uuid.UUID) ([]*models.VIPBlock, error) {
+	var blocks []*models.VIPBlock
+	err := r.db.Where("space_id = ? AND is_active = ?", spaceID, true).
+		Order("day_of_week ASC, start_time ASC").
+		Find(&blocks).Error
+	return blocks, err
+}
+
+// ========================================
+// VIOLATION OPERATIONS
+// ========================================
+
+// CreateViolation records a VIP block override
+func (r *VIPBlockRepository) CreateViolation(violation *models.VIPBlockViolation) error {
+	return r.db.Create(violation).Error
+}
+
+// GetViolations retrieves VIP block overrides for bookings within a date range
+func (r *VIPBlockRepository) GetViolations(startDate, endDate time.Time, spaceID *uuid.UUID, offset, limit int) ([]*models.VIPBlockViolation, int64, error) {
+	var violations []*models.VIPBlockViolation
+	var total int64
+
+	query := r.db.Model(&models.VIPBlockViolation{}).
+		Where("start_time < ? AND end_time > ?", endDate, startDate)
+	if spaceID != nil {
+		query = query.Where("space_id = ?", *spaceID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Block").Preload("Space").Preload("User").Preload("Reservation").
+		Order("start_time DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&violations).Error
+
+	return violations, total, err
+}
@@ -0,0 +1,101 @@
+// internal/repositories/visitor_event_repository.go
+package repositories
+
+import (
+	"encoding/json"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// VisitorEventRepository implements the VisitorEventRepositoryInterface
+type VisitorEventRepository struct {
+	db *gorm.DB
+}
+
+// NewVisitorEventRepository creates a new visitor event repository
+func NewVisitorEventRepository(db *gorm.DB) interfaces.VisitorEventRepositoryInterface {
+	return &VisitorEventRepository{db: db}
+}
+
+// GetAfter retrieves the events of the buildings, every building if none, that come after
+// the cursor, oldest first
+func (r *VisitorEventRepository) GetAfter(buildings []string, after *dto.Cursor, limit int) ([]*models.VisitorEvent, error) {
+	var events []*models.VisitorEvent
+
+	query := r.db.Model(&models.VisitorEvent{})
+	if len(buildings) > 0 {
+		query = query.Where("building IN ?", buildings)
+	}
+	if after != nil {
+		occurredAt, err := after.Time()
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("(occurred_at, id) > (?, ?)", occurredAt, after.ID)
+	}
+
+	err := query.Order("occurred_at ASC, id ASC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// AppendForReservation writes an event for every guest of the reservation. The event IDs
+// derive from sourceID, so appending again for the same source writes nothing.
+func (r *VisitorEventRepository) AppendForReservation(reservationID uuid.UUID, eventType string, sourceID uuid.UUID) error {
+	var guestIDs []uuid.UUID
+	if err := r.db.Model(&models.ReservationGuest{}).Where("reservation_id = ?", reservationID).Pluck("id", &guestIDs).Error; err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, guestID := range guestIDs {
+			if err := appendVisitorEvent(tx, eventType, guestID, uuid.NewSHA1(sourceID, guestID[:])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteBefore deletes the events that occurred before the cutoff
+func (r *VisitorEventRepository) DeleteBefore(cutoff time.Time) (int64, error) {
+	result := r.db.Where("occurred_at < ?", cutoff).Delete(&models.VisitorEvent{})
+	return result.RowsAffected, result.Error
+
+}
+
+// appendVisitorEvent writes an event about the guest as the transaction sees it. A nil ID is
+// generated; an ID already written is skipped. Guests of deleted reservations have no event.
+func appendVisitorEvent(tx *gorm.DB, eventType string, guestID uuid.UUID, id uuid.UUID) error {
+	var guest models.ReservationGuest
+	err := tx.Preload("Reservation").Preload("Reservation.Space").Preload("Reservation.User").
+		Where("id = ?", guestID).First(&guest).Error
+	if err != nil {
+		return err
+	}
+	if guest.Reservation == nil {
+		return nil
+	}
+
+	snapshot := models.NewVisitorSnapshot(&guest)
+	body, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.VisitorEvent{
+		ID:         id,
+		Type:       eventType,
+		GuestID:    guest.ID,
+		Building:   snapshot.Building,
+		Visitor:    datatypes.JSON(body),
+		OccurredAt: time.Now(),
+	}).Error
+}
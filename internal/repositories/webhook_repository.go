@@ -0,0 +1,138 @@
+// internal/repositories/webhook_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookRepository implements the WebhookRepositoryInterface
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *gorm.DB) interfaces.WebhookRepositoryInterface {
+	return &WebhookRepository{db: db}
+}
+
+// ========================================
+// SUBSCRIPTIONS
+// ========================================
+
+// CreateSubscription creates a new webhook subscription
+func (r *WebhookRepository) CreateSubscription(subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	if err := r.db.Create(subscription).Error; err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// GetSubscriptionByID retrieves a subscription by ID
+func (r *WebhookRepository) GetSubscriptionByID(id uuid.UUID) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := r.db.Where("id = ?", id).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// GetSubscriptions retrieves all subscriptions
+func (r *WebhookRepository) GetSubscriptions() ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	err := r.db.Order("created_at ASC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// GetActiveSubscriptions retrieves the subscriptions that receive events
+func (r *WebhookRepository) GetActiveSubscriptions() ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	err := r.db.Where("is_active = ?", true).Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// UpdateSubscription updates a subscription
+func (r *WebhookRepository) UpdateSubscription(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Model(&models.WebhookSubscription{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// DeleteSubscription soft deletes a subscription and drops its undelivered events
+func (r *WebhookRepository) DeleteSubscription(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ? AND status = ?", id, models.WebhookDeliveryPending).
+			Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.WebhookSubscription{}, "id = ?", id).Error
+	})
+}
+
+// ========================================
+// DELIVERIES
+// ========================================
+
+// CreateDeliveries queues deliveries
+func (r *WebhookRepository) CreateDeliveries(deliveries []*models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.Create(&deliveries).Error
+}
+
+// GetDueDeliveries retrieves pending deliveries of active subscriptions whose next attempt is due.
+// Deliveries of paused subscriptions wait until the subscription is resumed.
+func (r *WebhookRepository) GetDueDeliveries(before time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := r.db.Preload("Subscription").
+		Joins("JOIN webhook_subscriptions ON webhook_subscriptions.id = webhook_deliveries.subscription_id").
+		Where("webhook_subscriptions.is_active = ? AND webhook_subscriptions.deleted_at IS NULL", true).
+		Where("webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ?", models.WebhookDeliveryPending, before).
+		Order("webhook_deliveries.created_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// GetPendingStats counts pending deliveries and returns when the oldest was queued
+func (r *WebhookRepository) GetPendingStats() (int64, *time.Time, error) {
+	var stats struct {
+		Count  int64
+		Oldest *time.Time
+	}
+	err := r.db.Model(&models.WebhookDelivery{}).
+		Select("COUNT(*) AS count, MIN(created_at) AS oldest").
+		Where("status = ?", models.WebhookDeliveryPending).
+		Scan(&stats).Error
+	return stats.Count, stats.Oldest, err
+}
+
+// GetDeliveriesBySubscription retrieves the most recent deliveries of a subscription
+func (r *WebhookRepository) GetDeliveriesBySubscription(subscriptionID uuid.UUID, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := r.db.Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// GetDeliveryByID retrieves a delivery by ID
+func (r *WebhookRepository) GetDeliveryByID(id uuid.UUID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.Where("id = ?", id).First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// UpdateDelivery updates a delivery
+func (r *WebhookRepository) UpdateDelivery(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Model(&models.WebhookDelivery{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,62 @@
+// internal/repositories/widget_token_repository.go
+package repositories
+
+import (
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WidgetTokenRepository implements the WidgetTokenRepositoryInterface
+type WidgetTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewWidgetTokenRepository creates a new widget token repository
+func NewWidgetTokenRepository(db *gorm.DB) interfaces.WidgetTokenRepositoryInterface {
+	return &WidgetTokenRepository{db: db}
+}
+
+// Create creates a new widget token
+func (r *WidgetTokenRepository) Create(token *models.WidgetToken) (*models.WidgetToken, error) {
+	if err := r.db.Create(token).Error; err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// GetByID retrieves a widget token by ID
+func (r *WidgetTokenRepository) GetByID(id uuid.UUID) (*models.WidgetToken, error) {
+	var token models.WidgetToken
+	err := r.db.Where("id = ?", id).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetByTokenHash retrieves a widget token by the hash of its token
+func (r *WidgetTokenRepository) GetByTokenHash(tokenHash string) (*models.WidgetToken, error) {
+	var token models.WidgetToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetAll retrieves every widget token with its space, newest first
+func (r *WidgetTokenRepository) GetAll() ([]*models.WidgetToken, error) {
+	var tokens []*models.WidgetToken
+	err := r.db.Preload("Space").
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// Update updates a widget token
+func (r *WidgetTokenRepository) Update(id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Model(&models.WidgetToken{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,118 @@
+// internal/repositories/working_calendar_repository.go
+package repositories
+
+import (
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WorkingCalendarRepository implements the WorkingCalendarRepositoryInterface
+type WorkingCalendarRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkingCalendarRepository creates a new working calendar repository
+func NewWorkingCalendarRepository(db *gorm.DB) interfaces.WorkingCalendarRepositoryInterface {
+	return &WorkingCalendarRepository{db: db}
+}
+
+// ========================================
+// REGIONS
+// ========================================
+
+// GetRegions retrieves every calendar region
+func (r *WorkingCalendarRepository) GetRegions() ([]*models.CalendarRegion, error) {
+	var regions []*models.CalendarRegion
+	err := r.db.Order("code ASC").Find(&regions).Error
+	return regions, err
+}
+
+// GetRegionByCode retrieves a calendar region by its code
+func (r *WorkingCalendarRepository) GetRegionByCode(code string) (*models.CalendarRegion, error) {
+	var region models.CalendarRegion
+	err := r.db.Where("UPPER(code) = UPPER(?)", code).First(&region).Error
+	if err != nil {
+		return nil, err
+	}
+	return &region, nil
+}
+
+// GetRegionByBuilding retrieves the calendar region a building is in
+func (r *WorkingCalendarRepository) GetRegionByBuilding(building string) (*models.CalendarRegion, error) {
+	var region models.CalendarRegion
+	err := r.db.Where("EXISTS (SELECT 1 FROM unnest(buildings) AS b WHERE LOWER(b) = LOWER(?))", building).
+		First(&region).Error
+	if err != nil {
+		return nil, err
+	}
+	return &region, nil
+}
+
+// SaveRegion creates or replaces the calendar region with the code
+func (r *WorkingCalendarRepository) SaveRegion(region *models.CalendarRegion) (*models.CalendarRegion, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "buildings", "timezone", "updated_by_id", "updated_at"}),
+	}).Create(region).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.GetRegionByCode(region.Code)
+}
+
+// DeleteRegion removes a calendar region and its days
+func (r *WorkingCalendarRepository) DeleteRegion(code string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("UPPER(code) = UPPER(?)", code).Delete(&models.CalendarRegion{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("UPPER(region) = UPPER(?)", code).Delete(&models.CalendarDay{}).Error
+	})
+}
+
+// ========================================
+// DAYS
+// ========================================
+
+// CreateDay saves a new calendar day
+func (r *WorkingCalendarRepository) CreateDay(day *models.CalendarDay) (*models.CalendarDay, error) {
+	if err := r.db.Create(day).Error; err != nil {
+		return nil, err
+	}
+	return day, nil
+}
+
+// DeleteDay removes a calendar day
+func (r *WorkingCalendarRepository) DeleteDay(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.CalendarDay{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetDays retrieves the days of the regions within the date range, both included; every
+// region's when regions is nil
+func (r *WorkingCalendarRepository) GetDays(regions []string, startDate, endDate time.Time) ([]*models.CalendarDay, error) {
+	query := r.db.Where("date >= ? AND date <= ?", startDate, endDate)
+	if regions != nil {
+		query = query.Where("region IN ?", regions)
+	}
+
+	var days []*models.CalendarDay
+	err := query.Order("date ASC, region ASC").Find(&days).Error
+	return days, err
+}
@@ -2,184 +2,1085 @@
 package routes
 
 import (
+	"context"
+	"log/slog"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"gorm.io/gorm"
 
+	"room-reservation-api/internal/breaker"
 	"room-reservation-api/internal/config"
+	"room-reservation-api/internal/database"
+	"room-reservation-api/internal/dto"
 	"room-reservation-api/internal/handlers"
+	"room-reservation-api/internal/jobs"
 	"room-reservation-api/internal/middlewares"
+	"room-reservation-api/internal/models"
 	"room-reservation-api/internal/repositories"
 	"room-reservation-api/internal/services"
+	"room-reservation-api/internal/version"
+	"room-reservation-api/internal/websocket"
 )
 
-func Setup(router *gin.Engine, db *gorm.DB, cfg *config.Config) {
+func Setup(router *gin.Engine, db, replica *gorm.DB, cfg *config.Config, scheduler *jobs.Scheduler, wsManager *websocket.Manager) {
 	// CORS middleware
 	router.Use(middlewares.CustomCORS())
 
+	// API v2 responses are wrapped in the envelope once their error codes are filled in
+	router.Use(middlewares.ResponseEnvelope())
+
+	// Every error response carries a machine-readable code
+	router.Use(middlewares.ErrorCodes())
+
+	// Binding errors name fields the way clients send them
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		dto.UseJSONFieldNames(v)
+	}
+
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(db)
-	spaceRepo := repositories.NewSpaceRepository(db)
-	reservationRepo := repositories.NewReservationRepository(db)
+	spaceRepo := repositories.NewSpaceRepository(db, replica)
+	reservationRepo := repositories.NewReservationRepository(db, replica)
+	vipBlockRepo := repositories.NewVIPBlockRepository(db)
+	bookingConflictRepo := repositories.NewBookingConflictRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	roomSwapRepo := repositories.NewRoomSwapRepository(db)
+	chatRepo := repositories.NewChatRepository(db, slog.Default())
+	moderationRepo := repositories.NewModerationRepository(db, slog.Default())
+	cannedResponseRepo := repositories.NewCannedResponseRepository(db, slog.Default())
+	agentAssignmentRepo := repositories.NewAgentAssignmentRepository(db, slog.Default())
+	reservationGuestRepo := repositories.NewReservationGuestRepository(db)
+	roomDisplayRepo := repositories.NewRoomDisplayRepository(db)
+	widgetTokenRepo := repositories.NewWidgetTokenRepository(db)
+	questionnaireRepo := repositories.NewCheckInQuestionnaireRepository(db)
+	userPreferenceRepo := repositories.NewUserPreferenceRepository(db)
+	bulkCancellationRepo := repositories.NewBulkCancellationRepository(db)
+	occupancyRepo := repositories.NewOccupancyRepository(db)
+	webhookRepo := repositories.NewWebhookRepository(db)
+	deadLetterRepo := repositories.NewDeadLetterRepository(db)
+	accountRepo := repositories.NewAccountRepository(db)
+	retentionRepo := repositories.NewRetentionRepository(db)
+	dataQualityRepo := repositories.NewDataQualityRepository(db)
+	floorConsolidationRepo := repositories.NewFloorConsolidationRepository(db)
+	placementPolicyRepo := repositories.NewPlacementPolicyRepository(db)
+	floorPlanRepo := repositories.NewFloorPlanRepository(db)
+	checklistRepo := repositories.NewReservationChecklistRepository(db)
+	approvalRuleRepo := repositories.NewApprovalRuleRepository(db)
+	complianceExportRepo := repositories.NewComplianceExportRepository(db)
+	bookingQuotaRepo := repositories.NewBookingQuotaRepository(db)
+	bookingStrikeRepo := repositories.NewBookingStrikeRepository(db)
+	outboxRepo := repositories.NewOutboxRepository(db)
+	activityRepo := repositories.NewActivityRepository(db)
+	reportRepo := repositories.NewReportRepository(db, replica)
+	capacityForecastRepo := repositories.NewCapacityForecastRepository(db)
+	quickBookRepo := repositories.NewQuickBookRepository(db)
+	savedSearchRepo := repositories.NewSavedSearchRepository(db)
+	costCenterRepo := repositories.NewCostCenterRepository(db)
+	spacePhotoRepo := repositories.NewSpacePhotoRepository(db)
+	feedbackRepo := repositories.NewFeedbackRepository(db, replica)
+	reservationSessionRepo := repositories.NewReservationSessionRepository(db)
+	reservationPreemptionRepo := repositories.NewReservationPreemptionRepository(db)
+	buildingClosureRepo := repositories.NewBuildingClosureRepository(db)
+	emergencyBroadcastRepo := repositories.NewEmergencyBroadcastRepository(db)
+	broadcastChannelRepo := repositories.NewBroadcastChannelRepository(db)
+	integrationKeyRepo := repositories.NewIntegrationKeyRepository(db)
+	visitorEventRepo := repositories.NewVisitorEventRepository(db)
+	serviceRequestRepo := repositories.NewServiceRequestRepository(db)
+	equipmentCheckoutRepo := repositories.NewEquipmentCheckoutRepository(db)
+	checkInPolicyRepo := repositories.NewCheckInPolicyRepository(db)
+	teamBlockRepo := repositories.NewTeamBlockRepository(db)
+	workingCalendarRepo := repositories.NewWorkingCalendarRepository(db)
+	approvalActionTokenRepo := repositories.NewApprovalActionTokenRepository(db)
+
+	// External integrations are called through circuit breakers so a slow or failing
+	// third party cannot hold up bookings; their state is reported by /health/ready
+	breakers := breaker.NewRegistry(breaker.Settings{
+		FailureThreshold: cfg.BreakerFailureThreshold,
+		OpenTimeout:      cfg.BreakerOpenTimeout,
+	}, slog.Default())
+
+	// Email delivery is enabled once SMTP credentials are configured
+	var mailer services.Mailer
+	if cfg.SMTPUser != "" {
+		mailer = services.NewBreakerMailer(
+			services.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPassword, cfg.SMTPFrom),
+			breakers.Get("smtp", cfg.SMTPTimeout))
+	}
+
+	// Chat uploads are scanned once a ClamAV daemon is configured
+	var fileScanner services.FileScanner
+	if cfg.ClamAVAddress != "" {
+		fileScanner = services.NewBreakerScanner(
+			services.NewClamAVScanner(cfg.ClamAVAddress, cfg.ClamAVTimeout),
+			breakers.Get("clamav", cfg.ClamAVTimeout))
+	}
+
+	// Changes are committed with outbox events, which the dispatcher pushes to realtime
+	// clients, webhooks and email; consumers are registered below once they exist
+	outboxDispatcher := services.NewOutboxDispatcher(outboxRepo, wsManager, slog.Default())
 
 	// Initialize services
 	authService := services.NewAuthService(userRepo, cfg.JWTSecret, time.Hour*24*7)
 	spaceService := services.NewSpaceService(spaceRepo, reservationRepo, userRepo)
-	reservationService := services.NewReservationService(reservationRepo, spaceRepo, userRepo)
+	holidayCalendar := services.NewHolidayCalendar(cfg.HolidayCountry, cfg.HolidayRegion, cfg.HolidayTimezone, cfg.HolidayAPIURL, breakers, slog.Default())
+	workingCalendarService := services.NewWorkingCalendarService(workingCalendarRepo)
+	bookingQuotaService := services.NewBookingQuotaService(bookingQuotaRepo, userRepo, cfg.UserWeeklyQuotaHours, cfg.TeamPremiumQuotaHours)
+	bookingStrikeService := services.NewBookingStrikeService(bookingStrikeRepo, reservationRepo, userRepo, cfg.StrikeLimit, cfg.StrikeWindowDays, cfg.StrikeRestrictionDays, cfg.NoShowGracePeriod, slog.Default())
+	reservationService := services.NewReservationService(reservationRepo, spaceRepo, userRepo, vipBlockRepo, bookingConflictRepo, questionnaireRepo, userPreferenceRepo, floorConsolidationRepo, placementPolicyRepo, floorPlanRepo, checklistRepo, approvalRuleRepo, costCenterRepo, feedbackRepo, reservationSessionRepo, buildingClosureRepo, cfg.DuplicateBookingPolicy, cfg.PlacementStrategy, holidayCalendar, workingCalendarService, bookingQuotaService, bookingStrikeService, outboxDispatcher)
+	vipSpaceService := services.NewVIPSpaceService(vipBlockRepo, spaceRepo)
+	spaceCatalogService := services.NewSpaceCatalogService(spaceRepo, userRepo, approvalRuleRepo, vipBlockRepo)
+	// Space photos are stored with the other uploads and served under the same prefix
+	feedbackReportService := services.NewFeedbackReportService(feedbackRepo)
+	spacePhotoService := services.NewSpacePhotoService(spacePhotoRepo, spaceRepo, services.NewLocalFileStorage(cfg.UploadPath, "/uploads"), slog.Default())
+	spaceRecommendationService := services.NewSpaceRecommendationService(spaceRepo, reservationRepo, userRepo, vipBlockRepo, floorConsolidationRepo)
+	roomDisplayService := services.NewRoomDisplayService(roomDisplayRepo, spaceRepo, reservationRepo, reservationService, wsManager, slog.Default())
+	questionnaireService := services.NewCheckInQuestionnaireService(questionnaireRepo, reservationRepo, spaceRepo)
+	userPreferenceService := services.NewUserPreferenceService(userPreferenceRepo, userRepo, spaceRepo)
+	reservationImportService := services.NewReservationImportService(reservationService, reservationRepo, spaceRepo, userRepo, breakers)
+	notificationService := services.NewNotificationService(notificationRepo, userRepo, deadLetterRepo, chatRepo, mailer, cfg.NotificationRetryCount, cfg.AppBaseURL, slog.Default(), wsManager, outboxDispatcher)
+	reservationBulkCancelService := services.NewReservationBulkCancelService(reservationService, reservationRepo, bulkCancellationRepo, notificationService, slog.Default())
+	occupancyService := services.NewOccupancyService(occupancyRepo, spaceRepo, reservationRepo, reservationService, notificationService, cfg.OccupancyReleaseAfter, cfg.OccupancyRetentionDays, slog.Default())
+	approvalRuleService := services.NewApprovalRuleService(approvalRuleRepo, spaceRepo)
+	placementPolicyService := services.NewPlacementPolicyService(placementPolicyRepo, spaceRepo, cfg.PlacementStrategy)
+	floorPlanService := services.NewFloorPlanService(floorPlanRepo, spaceRepo, reservationRepo, userRepo, floorConsolidationRepo, reservationService)
+	floorConsolidationService := services.NewFloorConsolidationService(floorConsolidationRepo, userRepo, notificationService, cfg.LowUsageThreshold, cfg.LowUsageLookaheadDays, slog.Default())
+	buildingClosureService := services.NewBuildingClosureService(buildingClosureRepo, spaceRepo, notificationService, slog.Default())
+	emergencyBroadcastService := services.NewEmergencyBroadcastService(emergencyBroadcastRepo, reservationRepo, spaceRepo, notificationService, outboxDispatcher, slog.Default())
+	broadcastChannelService := services.NewBroadcastChannelService(broadcastChannelRepo, userRepo, spaceRepo, notificationService, slog.Default())
+	chargebackService := services.NewChargebackService(reservationRepo)
+	costCenterService := services.NewCostCenterService(costCenterRepo)
+	reportService := services.NewReportService(reportRepo, slog.Default())
+	capacityForecastService := services.NewCapacityForecastService(capacityForecastRepo, holidayCalendar)
+	quickBookService := services.NewQuickBookService(quickBookRepo, spaceRepo, reservationService, slog.Default())
+	savedSearchService := services.NewSavedSearchService(savedSearchRepo, spaceRepo, reservationRepo, notificationService, slog.Default())
+	roomSwapService := services.NewRoomSwapService(roomSwapRepo, reservationRepo, spaceRepo, notificationService, cfg.AppBaseURL, cfg.RSVPDowngradePolicy, cfg.RSVPDowngradeRatio, slog.Default())
+	approvalLinkService := services.NewApprovalLinkService(approvalActionTokenRepo, reservationRepo, userRepo, reservationService, notificationService, cfg.JWTSecret, cfg.AppBaseURL, slog.Default())
+	reservationGuestService := services.NewReservationGuestService(reservationGuestRepo, reservationRepo, userRepo, mailer, roomSwapService, cfg.AppBaseURL, slog.Default())
+	visitorIntegrationService := services.NewVisitorIntegrationService(integrationKeyRepo, visitorEventRepo, reservationGuestRepo, reservationRepo, spaceRepo, reservationGuestService, slog.Default())
+	reservationSessionService := services.NewReservationSessionService(reservationSessionRepo, reservationRepo, outboxDispatcher, slog.Default())
+	reservationPreemptionService := services.NewReservationPreemptionService(reservationPreemptionRepo, reservationRepo, notificationService)
+	checklistService := services.NewReservationChecklistService(checklistRepo, reservationRepo, notificationService, slog.Default())
+	serviceRequestService := services.NewServiceRequestService(serviceRequestRepo, reservationRepo, notificationService, slog.Default())
+	checkInPolicyService := services.NewCheckInPolicyService(checkInPolicyRepo, reservationRepo, bookingStrikeService, notificationService, slog.Default())
+	teamBlockService := services.NewTeamBlockService(teamBlockRepo, floorPlanRepo, reservationRepo, userRepo, notificationService, slog.Default())
+	equipmentCheckoutService := services.NewEquipmentCheckoutService(equipmentCheckoutRepo, reservationRepo, spaceRepo, notificationService, slog.Default())
+	coOrganizerService := services.NewReservationCoOrganizerService(reservationRepo, userRepo, notificationService, slog.Default())
+	reservationCloneService := services.NewReservationCloneService(reservationService, coOrganizerService, reservationGuestService, reservationRepo, spaceRepo, userRepo, reservationGuestRepo, slog.Default())
+	activityService := services.NewActivityService(activityRepo, slog.Default())
+	agentAssignmentService := services.NewAgentAssignmentService(agentAssignmentRepo, chatRepo, userRepo, notificationService, activityService, cfg.ChatAssignmentTimeout, slog.Default())
+
+	// The support bot answers common questions until an agent picks up the conversation
+	var supportBot *services.SupportBot
+	if cfg.ChatBotEnabled {
+		supportBot = services.NewSupportBot(cannedResponseRepo, chatRepo, agentAssignmentService, wsManager, slog.Default())
+	}
+	chatService := services.NewChatService(chatRepo, moderationRepo, userRepo, reservationRepo, spaceRepo, slog.Default(), wsManager, fileScanner, cfg.UploadPath,
+		notificationService, time.Duration(cfg.ChatAutoResolveDays)*24*time.Hour, time.Duration(cfg.ChatArchiveAfterDays)*24*time.Hour,
+		time.Duration(cfg.ChatReopenWindowDays)*24*time.Hour,
+		cfg.ChatRetentionDays, supportBot, agentAssignmentService)
+	if wsManager != nil {
+		wsManager.SetDeliveryHandler(chatService)
+	}
+	moderationService := services.NewModerationService(moderationRepo, chatRepo, notificationService, wsManager, slog.Default())
+	cannedResponseService := services.NewCannedResponseService(cannedResponseRepo, chatRepo, userRepo, chatService, slog.Default())
+	eventPollService := services.NewEventPollService(wsManager, chatRepo, slog.Default())
+	webhookService := services.NewWebhookService(webhookRepo, deadLetterRepo, breakers, slog.Default())
+	diagnosticsService := services.NewDiagnosticsService(db, scheduler, wsManager, notificationRepo, outboxDispatcher, webhookService, holidayCalendar, breakers)
+	deadLetterService := services.NewDeadLetterService(deadLetterRepo, notificationRepo, webhookRepo, scheduler, slog.Default())
+	// Compliance exports are signed with their own key; without one they are off
+	complianceExportService, complianceErr := services.NewComplianceExportService(complianceExportRepo, chatRepo, cfg.ComplianceSigningKey, slog.Default())
+	if complianceErr != nil {
+		slog.Warn("Compliance exports disabled", "reason", complianceErr)
+	}
+	accountService := services.NewAccountService(accountRepo, userRepo, reservationRepo, notificationRepo, userPreferenceRepo, chatRepo, chatService, slog.Default())
+	retentionService := services.NewRetentionService(retentionRepo, chatService, cfg.RetentionReservationDays, cfg.RetentionChatMessageDays, cfg.RetentionAuditLogDays, slog.Default())
+	dataQualityService := services.NewDataQualityService(dataQualityRepo, reservationService, slog.Default())
+	publicAvailabilityService := services.NewPublicAvailabilityService(spaceRepo, reservationRepo, cfg.PublicAvailabilityTTL)
+	widgetService := services.NewWidgetService(widgetTokenRepo, spaceRepo, reservationService, publicAvailabilityService, slog.Default())
+
+	outboxDispatcher.Handle(models.OutboxNotificationCreated, notificationService.DeliverOutboxEvent)
+	for _, eventType := range models.OutboxReservationEvents {
+		outboxDispatcher.Handle(eventType, webhookService.QueueOutboxEvent)
+	}
+	outboxDispatcher.Handle(models.OutboxReservationCancelled, savedSearchService.AlertCancellation)
+	outboxDispatcher.Handle(models.OutboxReservationReleased, savedSearchService.AlertRelease)
+	outboxDispatcher.Handle(models.OutboxReservationReleased, publicAvailabilityService.DropCachedViews)
+	outboxDispatcher.Handle(models.OutboxReservationPreempted, reservationPreemptionService.NotifyDisplaced)
+	outboxDispatcher.Handle(models.OutboxReservationUpdated, visitorIntegrationService.RecordReservationChange)
+	outboxDispatcher.Handle(models.OutboxReservationCancelled, visitorIntegrationService.RecordReservationChange)
+	outboxDispatcher.Handle(models.OutboxReservationUpdated, serviceRequestService.SyncReservationChange)
+	outboxDispatcher.Handle(models.OutboxReservationCreated, approvalLinkService.NotifyApprovers)
+	outboxDispatcher.Handle(models.OutboxReservationUpdated, approvalLinkService.NotifyApprovers)
+	for _, eventType := range models.OutboxReservationEvents {
+		outboxDispatcher.Handle(eventType, chatService.SyncAttendeeChat)
+	}
+	for _, eventType := range models.OutboxReservationEvents {
+		outboxDispatcher.Handle(eventType, activityService.RecordReservationEvent)
+	}
+	outboxDispatcher.Handle(models.OutboxReservationReleased, activityService.RecordReservationEvent)
+	outboxDispatcher.Handle(models.OutboxReservationPreempted, activityService.RecordReservationEvent)
+	outboxDispatcher.Handle(models.OutboxReservationCancelled, serviceRequestService.SyncReservationChange)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(db, cfg)
 	statsHandler := handlers.NewStatsHandler(authService)
 	spaceHandler := handlers.NewSpaceHandler(spaceService)
+	resourceHandler := handlers.NewResourceHandler(spaceService)
+	spaceRecommendationHandler := handlers.NewSpaceRecommendationHandler(spaceRecommendationService)
 	reservationHandler := handlers.NewReservationHandler(reservationService)
+	reservationImportHandler := handlers.NewReservationImportHandler(reservationImportService)
+	vipSpaceHandler := handlers.NewVIPSpaceHandler(vipSpaceService)
+	spaceCatalogHandler := handlers.NewSpaceCatalogHandler(spaceCatalogService)
+	spacePhotoHandler := handlers.NewSpacePhotoHandler(spacePhotoService)
+	feedbackReportHandler := handlers.NewFeedbackReportHandler(feedbackReportService)
+	reservationPreemptionHandler := handlers.NewReservationPreemptionHandler(reservationPreemptionService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	roomSwapHandler := handlers.NewRoomSwapHandler(roomSwapService)
+	approvalActionHandler := handlers.NewApprovalActionHandler(approvalLinkService)
+	reservationGuestHandler := handlers.NewReservationGuestHandler(reservationGuestService)
+	roomDisplayHandler := handlers.NewRoomDisplayHandler(roomDisplayService)
+	questionnaireHandler := handlers.NewCheckInQuestionnaireHandler(questionnaireService)
+	userPreferenceHandler := handlers.NewUserPreferenceHandler(userPreferenceService)
+	reservationBulkCancelHandler := handlers.NewReservationBulkCancelHandler(reservationBulkCancelService)
+	reservationCloneHandler := handlers.NewReservationCloneHandler(reservationCloneService)
+	occupancyHandler := handlers.NewOccupancyHandler(occupancyService)
+	floorConsolidationHandler := handlers.NewFloorConsolidationHandler(floorConsolidationService)
+	buildingClosureHandler := handlers.NewBuildingClosureHandler(buildingClosureService)
+	emergencyBroadcastHandler := handlers.NewEmergencyBroadcastHandler(emergencyBroadcastService)
+	broadcastChannelHandler := handlers.NewBroadcastChannelHandler(broadcastChannelService)
+	chargebackHandler := handlers.NewChargebackHandler(chargebackService)
+	costCenterHandler := handlers.NewCostCenterHandler(costCenterService)
+	reportHandler := handlers.NewReportHandler(reportService)
+	capacityForecastHandler := handlers.NewCapacityForecastHandler(capacityForecastService)
+	quickBookHandler := handlers.NewQuickBookHandler(quickBookService)
+	savedSearchHandler := handlers.NewSavedSearchHandler(savedSearchService)
+	bookingQuotaHandler := handlers.NewBookingQuotaHandler(bookingQuotaService)
+	bookingStrikeHandler := handlers.NewBookingStrikeHandler(bookingStrikeService)
+	placementPolicyHandler := handlers.NewPlacementPolicyHandler(placementPolicyService)
+	approvalRuleHandler := handlers.NewApprovalRuleHandler(approvalRuleService)
+	floorPlanHandler := handlers.NewFloorPlanHandler(floorPlanService)
+	holidayHandler := handlers.NewHolidayHandler(holidayCalendar)
+	checklistHandler := handlers.NewReservationChecklistHandler(checklistService)
+	serviceRequestHandler := handlers.NewServiceRequestHandler(serviceRequestService)
+	equipmentCheckoutHandler := handlers.NewEquipmentCheckoutHandler(equipmentCheckoutService)
+	checkInPolicyHandler := handlers.NewCheckInPolicyHandler(checkInPolicyService)
+	teamBlockHandler := handlers.NewTeamBlockHandler(teamBlockService)
+	workingCalendarHandler := handlers.NewWorkingCalendarHandler(workingCalendarService)
+	coOrganizerHandler := handlers.NewReservationCoOrganizerHandler(coOrganizerService)
+	jobHandler := handlers.NewJobHandler(scheduler)
+	deadLetterHandler := handlers.NewDeadLetterHandler(deadLetterService)
+	activityHandler := handlers.NewActivityHandler(activityService)
+	diagnosticsHandler := handlers.NewDiagnosticsHandler(diagnosticsService)
+	healthHandler := handlers.NewHealthHandler(services.NewHealthService(db, replica, wsManager, breakers, cfg.RedisURL, cfg.UploadPath))
+	versionHandler := handlers.NewVersionHandler(cfg.MinClientVersions)
+	deepLinkHandler := handlers.NewDeepLinkHandler(cfg.WebAppURL)
+	chatHandler := handlers.NewChatHandler(chatService, moderationService, cannedResponseService, slog.Default())
+	eventHandler := handlers.NewEventHandler(eventPollService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	complianceExportHandler := handlers.NewComplianceExportHandler(complianceExportService)
+	accountHandler := handlers.NewAccountHandler(accountService)
+	retentionHandler := handlers.NewRetentionHandler(retentionService)
+	dataQualityHandler := handlers.NewDataQualityHandler(dataQualityService)
+	publicAvailabilityHandler := handlers.NewPublicAvailabilityHandler(publicAvailabilityService)
+	widgetHandler := handlers.NewWidgetHandler(widgetService)
+	visitorIntegrationHandler := handlers.NewVisitorIntegrationHandler(visitorIntegrationService)
 
-	// API base group
-	api := router.Group("/api/v1")
+	// Register background jobs; failed runs are kept as dead letters and runs go to the activity feed
+	scheduler.OnFailure(deadLetterService.RecordJobFailure)
+	scheduler.OnRun(activityService.RecordJobRun)
+	scheduler.Daily("room-swap-suggestions", cfg.RoomSwapJobHour, 0, roomSwapService.RunNightlySuggestions)
+	scheduler.Every("notification-retry", cfg.NotificationRetryDelay, func(ctx context.Context) error {
+		_, err := notificationService.RetryPendingDeliveries(100)
+		return err
+	})
+	scheduler.Every("chat-auto-resolve", time.Hour, chatService.AutoResolveInactiveConversations)
+	scheduler.Daily("chat-retention", 3, 0, chatService.ApplyRetentionPolicy)
+	scheduler.Daily("chat-auto-archive", 4, 0, chatService.AutoArchiveInactiveConversations)
+	scheduler.Every("attendee-chat-archive", 15*time.Minute, chatService.ArchiveEndedAttendeeChats)
+	scheduler.Every("chat-assignment-timeout", time.Minute, agentAssignmentService.ExpirePendingAssignments)
+	scheduler.Every("outbox-dispatch", 5*time.Second, outboxDispatcher.Dispatch)
+	scheduler.Daily("outbox-prune", 3, 45, outboxDispatcher.PruneDispatched)
+	scheduler.Daily("approval-links-prune", 3, 55, approvalLinkService.PruneExpired)
+	scheduler.Daily("visitor-events-prune", 3, 50, visitorIntegrationService.PruneEvents)
+	scheduler.Every("webhook-delivery", 10*time.Second, webhookService.ProcessEvents)
+	scheduler.Every("occupancy-release", time.Minute, occupancyService.ReleaseEmptyRooms)
+	scheduler.Daily("occupancy-retention", 3, 30, occupancyService.PruneSamples)
+	scheduler.Daily(services.RetentionJobReservations, 2, 0, retentionService.AnonymizeReservations)
+	scheduler.Daily(services.RetentionJobChatMessages, 2, 15, retentionService.PurgeChatMessages)
+	scheduler.Daily(services.RetentionJobAuditLogs, 2, 30, retentionService.PurgeAuditLogs)
+	scheduler.Daily("low-usage-forecast", 7, 0, floorConsolidationService.NotifyLowUsage)
+	scheduler.Every("report-runs", 15*time.Second, reportService.RunQueued)
+	scheduler.Daily("report-runs-prune", 4, 0, reportService.PruneRuns)
+	scheduler.Every("checklist-reminders", time.Minute, checklistService.SendReminders)
+	scheduler.Every("equipment-overdue-alerts", 5*time.Minute, equipmentCheckoutService.SendOverdueAlerts)
+	scheduler.Every("no-show-detection", 5*time.Minute, bookingStrikeService.DetectNoShows)
+	scheduler.Every("check-in-enforcement", time.Minute, checkInPolicyService.ReleaseMissedCheckIns)
+	scheduler.Every("auto-checkout", time.Minute, reservationSessionService.AutoCheckOut)
+	if fileScanner != nil {
+		scheduler.Every("attachment-rescan", 10*time.Minute, func(ctx context.Context) error {
+			_, err := chatService.RescanQuarantinedAttachments(ctx, 100)
+			return err
+		})
+	}
 
-	// ========================================
-	// PUBLIC ROUTES (No authentication required)
-	// ========================================
-	{
-		// Authentication endpoints
-		auth := api.Group("/auth")
-		{
-			auth.POST("/register", authHandler.Register)
-			auth.POST("/login", authHandler.Login)
-			auth.POST("/refresh", authHandler.RefreshToken)
-			auth.POST("/forgot-password", authHandler.ForgotPassword)
-			auth.POST("/reset-password", authHandler.ResetPassword)
-		}
+	// The same routes are served by every API version; v1 answers with the DTO of each
+	// handler and v2 with the envelope, and Accept can ask either for the other shape
+	apiVersions := []*gin.RouterGroup{
+		router.Group("/api/v1", middlewares.APIVersion("v1", false)),
+		router.Group("/api/v2", middlewares.APIVersion("v2", true)),
+	}
+	for _, api := range apiVersions {
 
-		// Public space information (for browsing/discovery)
-		spaces := api.Group("/spaces")
+		// ========================================
+		// PUBLIC ROUTES (No authentication required)
+		// ========================================
 		{
-			spaces.GET("", spaceHandler.GetSpaces)                                // List all spaces
-			spaces.GET("/:id", spaceHandler.GetSpace)                             // Space details
-			spaces.GET("/search", spaceHandler.SearchSpaces)                      // Search with filters
-			spaces.GET("/buildings", spaceHandler.GetBuildings)                   // Available buildings
-			spaces.GET("/building/:building", spaceHandler.GetSpacesByBuilding)   // Spaces by building
-			spaces.GET("/type/:type", spaceHandler.GetSpacesByType)               // Spaces by type
-			spaces.GET("/available", spaceHandler.GetAvailableSpaces)             // Available spaces
-			spaces.POST("/:id/availability", spaceHandler.CheckSpaceAvailability) // Check availability
+			// Authentication endpoints
+			auth := api.Group("/auth")
+			{
+				auth.POST("/register", authHandler.Register)
+				auth.POST("/login", authHandler.Login)
+				auth.POST("/refresh", authHandler.RefreshToken)
+				auth.POST("/forgot-password", authHandler.ForgotPassword)
+				auth.POST("/reset-password", authHandler.ResetPassword)
+			}
+
+			// Public space information (for browsing/discovery); signed-in pilot groups also see spaces in soft launch
+			spaces := api.Group("/spaces")
+			spaces.Use(middlewares.OptionalAuth(cfg.JWTSecret))
+			{
+				spaces.GET("", spaceHandler.GetSpaces)                                // List all spaces
+				spaces.GET("/:id", spaceHandler.GetSpace)                             // Space details
+				spaces.GET("/search", spaceHandler.SearchSpaces)                      // Search with filters
+				spaces.GET("/buildings", spaceHandler.GetBuildings)                   // Available buildings
+				spaces.GET("/building/:building", spaceHandler.GetSpacesByBuilding)   // Spaces by building
+				spaces.GET("/type/:type", spaceHandler.GetSpacesByType)               // Spaces by type
+				spaces.GET("/available", spaceHandler.GetAvailableSpaces)             // Available spaces
+				spaces.POST("/:id/availability", spaceHandler.CheckSpaceAvailability) // Check availability
+			}
+
+			// Anonymous free/busy view for lobby screens and public booking portals, off unless enabled
+			if cfg.PublicAvailability {
+				public := api.Group("/public")
+				{
+					public.GET("/spaces/availability", publicAvailabilityHandler.GetAvailability)
+				}
+			}
+
+			// Bookable resources by class
+			resources := api.Group("/resources")
+			resources.Use(middlewares.OptionalAuth(cfg.JWTSecret))
+			{
+				resources.GET("/rooms", resourceHandler.SearchRooms)         // Meeting rooms, offices...
+				resources.GET("/desks", resourceHandler.SearchDesks)         // Hot desks
+				resources.GET("/parking", resourceHandler.SearchParking)     // Parking spots
+				resources.GET("/equipment", resourceHandler.SearchEquipment) // Equipment, alone or as add-ons
+			}
+
+			// Room swap suggestion links (the token authorizes the action)
+			roomSwaps := api.Group("/room-swaps")
+			{
+				roomSwaps.GET("/:token", roomSwapHandler.GetSuggestion)              // Show the suggestion
+				roomSwaps.POST("/:token/accept", roomSwapHandler.AcceptSuggestion)   // Move to suggested room
+				roomSwaps.POST("/:token/decline", roomSwapHandler.DeclineSuggestion) // Keep current room
+			}
+
+			// Approval email links (the token authorizes the action)
+			approvalActions := api.Group("/approval-actions")
+			{
+				approvalActions.GET("/:token", approvalActionHandler.GetApprovalAction)      // Show the decision
+				approvalActions.POST("/:token", approvalActionHandler.ConfirmApprovalAction) // Approve or reject
+			}
+
+			// Guest passes from invite emails (the token authorizes access)
+			guestPasses := api.Group("/guest-passes")
+			{
+				guestPasses.GET("/:token", reservationGuestHandler.GetGuestPass)          // Visit details
+				guestPasses.GET("/:token/qr", reservationGuestHandler.GetGuestPassQRCode) // QR code image
+				guestPasses.POST("/:token/rsvp", reservationGuestHandler.RespondToInvite) // Accept or decline
+			}
+
+			// Notification deep links opened in a browser; the web client asks for sign-in
+			api.GET("/links", deepLinkHandler.ResolveLink)            // app://<kind>/<id> given as ?to=
+			api.GET("/links/:kind/:id", deepLinkHandler.RedirectLink) // HTTPS form sent in emails
+
+			// Webhook payload documentation for integrators
+			api.GET("/webhooks/schema-changelog", webhookHandler.GetSchemaChangelog)
 		}
-	}
 
-	// ========================================
-	// PROTECTED ROUTES (Authentication required)
-	// ========================================
-	protected := api.Group("")
-	protected.Use(middlewares.AuthMiddleware(cfg.JWTSecret))
-	{
-		// User profile management
-		protected.GET("/profile", authHandler.GetProfile)
-		protected.PUT("/profile", authHandler.UpdateProfile)
-		protected.PUT("/password", authHandler.ChangePassword)
-
-		// Core reservation functionality
-		reservations := protected.Group("/reservations")
+		// ========================================
+		// DOOR DISPLAY ROUTES (Display API key required)
+		// ========================================
+		display := api.Group("/display")
+		display.Use(middlewares.DisplayKeyMiddleware(roomDisplayService))
 		{
-			// Basic CRUD operations
-			reservations.POST("", reservationHandler.CreateReservation)            // Create reservation
-			reservations.GET("/:id", reservationHandler.GetReservation)            // Get reservation details
-			reservations.PUT("/:id", reservationHandler.UpdateReservation)         // Update reservation
-			reservations.POST("/:id/cancel", reservationHandler.CancelReservation) // Cancel reservation
-
-			// User's personal reservations
-			reservations.GET("/my", reservationHandler.GetUserReservations)                  // My reservations
-			reservations.GET("/my/upcoming", reservationHandler.GetUserUpcomingReservations) // Upcoming reservations
-			reservations.GET("/my/active", reservationHandler.GetUserActiveReservation)      // Current active reservation
-
-			// Check-in/Check-out functionality
-			reservations.POST("/:id/checkin", reservationHandler.CheckIn)        // Check into space
-			reservations.POST("/:id/checkout", reservationHandler.CheckOut)      // Check out of space
-			reservations.GET("/:id/status", reservationHandler.GetCheckInStatus) // Check-in status
-
-			// Search and filtering
-			reservations.GET("/search", reservationHandler.SearchReservations)       // Advanced search
-			reservations.GET("/calendar", reservationHandler.GetReservationCalendar) // Calendar view
+			display.GET("/status", roomDisplayHandler.GetStatus)      // Current and next meeting
+			display.POST("/extend", roomDisplayHandler.ExtendMeeting) // Extend current meeting by 15 min
+			display.POST("/end", roomDisplayHandler.EndMeeting)       // End current meeting early
+			display.GET("/stream", roomDisplayHandler.Stream)         // SSE status updates
 		}
 
-		// Space management for authenticated users
-		userSpaces := protected.Group("/spaces")
+		// ========================================
+		// BOOKING WIDGET ROUTES (Widget token required)
+		// ========================================
+		widget := api.Group("/widget")
+		widget.Use(middlewares.WidgetTokenMiddleware(widgetService))
 		{
-			userSpaces.POST("/batch-availability", spaceHandler.BatchCheckAvailability) // Batch availability check
+			widget.GET("/availability", widgetHandler.GetAvailability)           // Free/busy of the covered spaces
+			widget.POST("/booking-requests", widgetHandler.SubmitBookingRequest) // Request a booking, pending approval
 		}
-	}
 
-	// ========================================
-	// MANAGER ROUTES (Manager & Admin roles)
-	// ========================================
-	manager := protected.Group("/manager")
-	manager.Use(middlewares.ManagerMiddleware())
-	{
-		// Space management for managers
-		spaces := manager.Group("/spaces")
+		// ========================================
+		// SENSOR INTEGRATION ROUTES (Sensor API key required)
+		// ========================================
+		integrations := api.Group("/integrations")
+		integrations.Use(middlewares.SensorKeyMiddleware(occupancyService))
 		{
-			spaces.GET("/managed", spaceHandler.GetMyManagedSpaces)                  // Spaces I manage
-			spaces.GET("/:id/reservations", reservationHandler.GetSpaceReservations) // Reservations for my space
-			spaces.PUT("/:id/status", spaceHandler.UpdateSpaceStatus)                // Update space status
+			integrations.POST("/occupancy", occupancyHandler.RecordOccupancy) // Report headcount
 		}
 
-		// Reservation approval workflow
-		approvals := manager.Group("/approvals")
+		// ========================================
+		// RECEPTION INTEGRATION ROUTES (Integration key required)
+		// ========================================
+		reception := api.Group("/integrations/reception")
+		reception.Use(middlewares.IntegrationKeyMiddleware(visitorIntegrationService))
 		{
-			approvals.GET("", reservationHandler.GetPendingApprovals)             // Pending approvals
-			approvals.POST("/:id/approve", reservationHandler.ApproveReservation) // Approve reservation
-			approvals.POST("/:id/reject", reservationHandler.RejectReservation)   // Reject reservation
+			reception.GET("/visitors", middlewares.RequireIntegrationScope(models.IntegrationScopeVisitorsRead), visitorIntegrationHandler.GetExpectedVisitors)  // Visitors expected in a building on a day
+			reception.POST("/visitors/check-in", middlewares.RequireIntegrationScope(models.IntegrationScopeVisitorsCheckIn), visitorIntegrationHandler.CheckIn) // Check a visitor in, returns the badge
+			reception.GET("/visitors/:id/badge", middlewares.RequireIntegrationScope(models.IntegrationScopeBadgesRead), visitorIntegrationHandler.GetBadge)     // Badge-printing payload
+			reception.GET("/events", middlewares.RequireIntegrationScope(models.IntegrationScopeEventsRead), visitorIntegrationHandler.GetEvents)                // Visitor changes since a cursor
 		}
 
-		// Manager dashboard and statistics
-		stats := manager.Group("/stats")
+		// ========================================
+		// PROTECTED ROUTES (Authentication required)
+		// ========================================
+		protected := api.Group("")
+		protected.Use(middlewares.AuthMiddleware(cfg.JWTSecret))
 		{
-			stats.GET("/dashboard", spaceHandler.GetDashboardStatistics) // Manager dashboard
-			stats.GET("/spaces/:id", spaceHandler.GetSpaceStatistics)    // Individual space stats
+			// User profile management
+			protected.GET("/profile", authHandler.GetProfile)
+			protected.PUT("/profile", authHandler.UpdateProfile)
+
+			// Booking defaults for the current user
+			me := protected.Group("/users/me")
+			{
+				me.GET("/preferences", userPreferenceHandler.GetPreferences)    // My booking preferences
+				me.PUT("/preferences", userPreferenceHandler.UpdatePreferences) // Replace booking preferences
+				me.GET("/quota", bookingQuotaHandler.GetMyUsage)                // Booking quotas used and left
+				me.GET("/strikes", bookingStrikeHandler.GetMyStrikes)           // Late cancellation and no-show strikes
+				me.GET("/export", accountHandler.ExportData)                    // Download my data as a ZIP archive
+				me.DELETE("", accountHandler.DeleteAccount)                     // Delete and anonymize my account
+			}
+			protected.PUT("/password", authHandler.ChangePassword)
+
+			// Core reservation functionality
+			reservations := protected.Group("/reservations")
+			{
+				// Basic CRUD operations
+				reservations.POST("", reservationHandler.CreateReservation)                    // Create reservation
+				reservations.POST("/find-time", reservationHandler.FindTime)                   // Propose slots for participants
+				reservations.GET("/:id", reservationHandler.GetReservation)                    // Get reservation details
+				reservations.PUT("/:id", reservationHandler.UpdateReservation)                 // Update reservation
+				reservations.POST("/:id/cancel", reservationHandler.CancelReservation)         // Cancel reservation
+				reservations.POST("/:id/clone", reservationCloneHandler.CloneReservation)      // Copy to another time
+				reservations.POST("/:id/report-problem", chatHandler.ReportReservationProblem) // Open a support conversation
+				reservations.GET("/:id/chat", chatHandler.GetAttendeeChat)                     // Attendee group chat
+
+				// User's personal reservations
+				reservations.GET("/my", reservationHandler.GetUserReservations)                  // My reservations
+				reservations.GET("/my/upcoming", reservationHandler.GetUserUpcomingReservations) // Upcoming reservations
+				reservations.GET("/my/active", reservationHandler.GetUserActiveReservation)      // Current active reservation
+				reservations.GET("/my/insights", reservationHandler.GetUserBookingInsights)      // Personal booking habits
+
+				// Quick-book templates
+				reservations.GET("/templates", quickBookHandler.GetTemplates)               // My templates
+				reservations.POST("/templates", quickBookHandler.CreateTemplate)            // Save template
+				reservations.PUT("/templates/:id", quickBookHandler.UpdateTemplate)         // Replace template
+				reservations.DELETE("/templates/:id", quickBookHandler.DeleteTemplate)      // Delete template
+				reservations.POST("/templates/:id/book", quickBookHandler.BookFromTemplate) // Book with only a start time
+
+				// Check-in/Check-out functionality
+				reservations.POST("/:id/checkin", reservationHandler.CheckIn)        // Check into space
+				reservations.POST("/:id/checkout", reservationHandler.CheckOut)      // Check out of space
+				reservations.POST("/:id/extend", reservationHandler.ExtendMeeting)   // Extend ongoing meeting if the room stays free
+				reservations.GET("/:id/status", reservationHandler.GetCheckInStatus) // Check-in status
+
+				// Pre-check-in questionnaire
+				reservations.GET("/:id/questionnaire", questionnaireHandler.GetReservationQuestionnaire) // Questions to answer
+				reservations.POST("/:id/questionnaire", questionnaireHandler.SubmitAnswers)              // Submit answers
+
+				// External guests
+				reservations.POST("/:id/guests", reservationGuestHandler.AddGuests)                    // Invite guests
+				reservations.GET("/:id/guests", reservationGuestHandler.GetGuests)                     // List guests
+				reservations.POST("/:id/guests/:guestId/resend", reservationGuestHandler.ResendInvite) // Resend invite
+				reservations.DELETE("/:id/guests/:guestId", reservationGuestHandler.RemoveGuest)       // Remove guest
+
+				// Co-organizers
+				reservations.GET("/:id/co-organizers", coOrganizerHandler.GetCoOrganizers) // List co-organizers
+				reservations.PUT("/:id/co-organizers", coOrganizerHandler.SetCoOrganizers) // Designate co-organizers
+
+				// Organizer checklist
+				reservations.GET("/:id/tasks", checklistHandler.GetTasks)                      // Checklist
+				reservations.POST("/:id/tasks", checklistHandler.AddTask)                      // Add task
+				reservations.POST("/:id/tasks/apply-template", checklistHandler.ApplyTemplate) // Add the space type's tasks
+				reservations.PUT("/:id/tasks/:taskId", checklistHandler.UpdateTask)            // Edit or tick off
+				reservations.DELETE("/:id/tasks/:taskId", checklistHandler.DeleteTask)         // Remove task
+
+				// Catering and other services ordered with the reservation
+				reservations.GET("/:id/services", serviceRequestHandler.GetReservationServices)             // Ordered services and their cost
+				reservations.POST("/:id/services", serviceRequestHandler.OrderService)                      // Order from the catalog
+				reservations.DELETE("/:id/services/:requestId", serviceRequestHandler.CancelServiceRequest) // Withdraw an undelivered service
+
+				// Portable equipment booked for the reservation
+				reservations.GET("/:id/equipment", equipmentCheckoutHandler.GetReservationEquipment)        // Attached equipment and its checkout
+				reservations.POST("/:id/equipment", equipmentCheckoutHandler.AttachEquipment)               // Attach equipment
+				reservations.DELETE("/:id/equipment/:checkoutId", equipmentCheckoutHandler.DetachEquipment) // Detach equipment not picked up
+
+				// Search and filtering
+				reservations.GET("/search", reservationHandler.SearchReservations)       // Advanced search
+				reservations.GET("/calendar", reservationHandler.GetReservationCalendar) // Calendar view
+
+				// Import from shared Google Sheets
+				reservations.POST("/import/sheets/preview", reservationImportHandler.PreviewSheetImport) // Preview rows and conflicts
+				reservations.POST("/import/sheets/commit", reservationImportHandler.CommitSheetImport)   // Book approved rows
+			}
+
+			// Team calendars (department name as the ID)
+			protected.GET("/departments/:id/calendar", reservationHandler.GetDepartmentCalendar)
+
+			// Notification inbox
+			notifications := protected.Group("/notifications")
+			{
+				notifications.GET("", notificationHandler.GetMyNotifications)          // My notifications
+				notifications.GET("/unread-count", notificationHandler.GetUnreadCount) // Unread badge count
+				notifications.POST("/:id/read", notificationHandler.MarkAsRead)        // Mark as read
+			}
+
+			// Emergency broadcasts received
+			protected.POST("/emergencies/:id/acknowledge", emergencyBroadcastHandler.Acknowledge)
+
+			// Announcements received from broadcast channels
+			announcements := protected.Group("/announcements")
+			{
+				announcements.GET("", broadcastChannelHandler.GetInbox)           // Most recent first
+				announcements.POST("/:id/read", broadcastChannelHandler.MarkRead) // Record the read
+			}
+
+			// Realtime events for clients without a WebSocket connection
+			protected.GET("/events/poll", eventHandler.Poll)     // Long-poll fallback
+			protected.GET("/events/stream", eventHandler.Stream) // SSE for dashboards
+
+			// Space management for authenticated users
+			userSpaces := protected.Group("/spaces")
+			{
+				userSpaces.POST("/batch-availability", spaceHandler.BatchCheckAvailability)       // Batch availability check
+				userSpaces.GET("/recommendations", spaceRecommendationHandler.GetRecommendations) // Ranked suggestions for a time slot
+				userSpaces.POST("/:id/book-now", reservationHandler.BookNow)                      // Walk-up booking starting now
+				userSpaces.GET("/:id/occupancy", occupancyHandler.GetSpaceOccupancy)              // Live headcount and samples
+				userSpaces.GET("/:id/timeline", reservationHandler.GetSpaceTimeline)              // Day split into free and busy segments
+				userSpaces.GET("/favorites", quickBookHandler.GetFavorites)                       // My favorite spaces
+				userSpaces.PUT("/favorites/:id", quickBookHandler.AddFavorite)                    // Mark as favorite
+				userSpaces.DELETE("/favorites/:id", quickBookHandler.RemoveFavorite)              // Unmark favorite
+				userSpaces.GET("/saved-searches", savedSearchHandler.GetSearches)                 // My saved searches
+				userSpaces.POST("/saved-searches", savedSearchHandler.CreateSearch)               // Save search, alerted on cancellations
+				userSpaces.PUT("/saved-searches/:id", savedSearchHandler.UpdateSearch)            // Replace search
+				userSpaces.DELETE("/saved-searches/:id", savedSearchHandler.DeleteSearch)         // Delete search
+			}
+
+			// Public holidays, when bookings are closed
+			protected.GET("/holidays", holidayHandler.GetHolidays)
+
+			// Regional holidays and company closure days of a building
+			protected.GET("/calendar", workingCalendarHandler.GetCalendar)
+
+			// Cost centers a reservation can be charged to
+			protected.GET("/cost-centers", costCenterHandler.GetActiveCostCenters)
+
+			// Services that can be ordered with a reservation
+			protected.GET("/services", serviceRequestHandler.GetCatalog)
+
+			// Portable equipment inventory
+			protected.GET("/equipment", equipmentCheckoutHandler.GetInventory)
+
+			// Floor maps and the desk seat picker
+			floorPlans := protected.Group("/floor-plans")
+			{
+				floorPlans.GET("", floorPlanHandler.GetPlans)            // Plans, optionally of one building
+				floorPlans.GET("/:id/map", floorPlanHandler.GetFloorMap) // Desks with live availability
+				floorPlans.POST("/:id/pick", floorPlanHandler.PickSeat)  // Book the desk at the clicked point
+			}
+
+			// Desks block-booked for teams on their office days
+			teamBlocks := protected.Group("/team-blocks")
+			{
+				teamBlocks.GET("", teamBlockHandler.GetBlocks)                  // Blocks of the user's team
+				teamBlocks.POST("", teamBlockHandler.CreateBlock)               // Team leads: block-book desks
+				teamBlocks.GET("/:id", teamBlockHandler.GetBlock)               // Block with upcoming assignments
+				teamBlocks.DELETE("/:id", teamBlockHandler.CancelBlock)         // Team leads: cancel the block
+				teamBlocks.POST("/:id/remote", teamBlockHandler.MarkRemote)     // Work remotely on a day
+				teamBlocks.DELETE("/:id/remote", teamBlockHandler.UnmarkRemote) // Back in the office on a day
+			}
+
+			// Support chat
+			chat := protected.Group("/chat")
+			{
+				// Conversations
+				chat.POST("/conversations", chatHandler.CreateConversation)                   // Start conversation
+				chat.GET("/conversations", chatHandler.GetConversations)                      // My conversations
+				chat.GET("/conversations/:id", chatHandler.GetConversation)                   // Conversation details
+				chat.PUT("/conversations/:id", chatHandler.UpdateConversation)                // Update status, priority, tags
+				chat.DELETE("/conversations/:id", chatHandler.DeleteConversation)             // Delete conversation
+				chat.POST("/conversations/:id/archive", chatHandler.ArchiveConversation)      // Archive conversation
+				chat.POST("/conversations/:id/reopen", chatHandler.ReopenConversation)        // Reopen resolved conversation
+				chat.GET("/conversations/:id/summary", chatHandler.GetConversationSummary)    // Conversation summary
+				chat.GET("/conversations/:id/export", chatHandler.ExportConversation)         // JSON or PDF transcript
+				chat.GET("/conversations/:id/online", chatHandler.GetOnlineUsers)             // Online participants
+				chat.POST("/conversations/:id/participants", chatHandler.AddParticipant)      // Add participant
+				chat.DELETE("/conversations/:id/participants", chatHandler.RemoveParticipant) // Remove participant
+				chat.POST("/conversations/:id/leave", chatHandler.LeaveConversation)          // Leave conversation
+				chat.PUT("/conversations/:id/mute", chatHandler.MuteConversation)             // Suppress notifications
+				chat.DELETE("/conversations/:id/mute", chatHandler.UnmuteConversation)        // Restore notifications
+				chat.POST("/conversations/:id/assign-agent", chatHandler.AssignAgent)         // Assign support agent
+				chat.GET("/conversations/:id/messages", chatHandler.GetMessages)              // Message history
+				chat.POST("/conversations/:id/messages", chatHandler.SendMessage)             // Send message
+
+				// Messages
+				chat.PUT("/messages/read", chatHandler.MarkMessagesAsRead)           // Mark as read
+				chat.PUT("/messages/delivered", chatHandler.MarkMessagesAsDelivered) // Mark as delivered
+				chat.GET("/messages/search", chatHandler.SearchMessages)             // Search messages
+				chat.PUT("/messages/:id", chatHandler.UpdateMessage)                 // Edit message
+				chat.DELETE("/messages/:id", chatHandler.DeleteMessage)              // Delete message
+				chat.GET("/messages/:id/receipts", chatHandler.GetReadReceipts)      // Read receipts
+				chat.GET("/messages/:id/delivery", chatHandler.GetMessageDelivery)   // Sent/delivered/read ticks
+				chat.GET("/messages/:id/thread", chatHandler.GetThread)              // Reply chain
+				chat.POST("/messages/:id/report", chatHandler.ReportMessage)         // Report abuse
+				chat.POST("/upload", chatHandler.UploadFile)                         // Upload attachment
+				chat.POST("/typing", chatHandler.SetTypingStatus)                    // Typing indicator
+				chat.GET("/stats", chatHandler.GetConversationStats)                 // Chat statistics
+				chat.GET("/sync", chatHandler.SyncChat)                              // Unread badges and messages since cursor
+				chat.GET("/blocks", chatHandler.GetBlockedUsers)                     // Users I blocked
+				chat.POST("/blocks", chatHandler.BlockUser)                          // Block direct messages from a user
+				chat.DELETE("/blocks/:userId", chatHandler.UnblockUser)              // Lift a block
+
+				// End-to-end encryption keys
+				chat.GET("/keys/devices", chatHandler.GetDeviceKeys)                 // My registered devices
+				chat.PUT("/keys/devices/:deviceId", chatHandler.RegisterDeviceKeys)  // Publish device keys
+				chat.DELETE("/keys/devices/:deviceId", chatHandler.DeleteDeviceKeys) // Remove device keys
+				chat.POST("/keys/users/:userId/claim", chatHandler.ClaimKeyBundles)  // Key bundles to encrypt to a user
+
+				// Canned responses (agents)
+				chat.GET("/canned-responses", chatHandler.GetCannedResponses)                      // Quick replies
+				chat.POST("/conversations/:id/canned-responses", chatHandler.InsertCannedResponse) // Send a quick reply
+
+				// Support agents
+				chat.GET("/agents", chatHandler.GetSupportAgents)                                      // List agents
+				chat.GET("/agents/available", chatHandler.GetAvailableAgents)                          // Available agents
+				chat.PUT("/agents/status", chatHandler.UpdateAgentStatus)                              // Set my agent status
+				chat.POST("/agents", chatHandler.CreateSupportAgent)                                   // Register agent (admin)
+				chat.PUT("/agents/:id", middlewares.AdminMiddleware(), chatHandler.UpdateSupportAgent) // Update agent (admin)
+
+				// Auto-assignment offers
+				chat.POST("/conversations/:id/assignment/accept", chatHandler.AcceptAssignment)   // Take the conversation
+				chat.POST("/conversations/:id/assignment/decline", chatHandler.DeclineAssignment) // Pass to the next agent
+			}
 		}
-	}
 
-	// ========================================
-	// ADMIN ROUTES (Admin role only)
-	// ========================================
-	admin := protected.Group("/admin")
-	admin.Use(middlewares.AdminMiddleware())
-	{
-		// User management
-		users := admin.Group("/users")
+		// ========================================
+		// MANAGER ROUTES (Manager & Admin roles)
+		// ========================================
+		manager := protected.Group("/manager")
+		manager.Use(middlewares.ManagerMiddleware())
 		{
-			users.GET("", authHandler.GetAllUsers)                   // List all users
-			users.GET("/search", authHandler.SearchUsers)            // Search users
-			users.PUT("/:id/role", authHandler.UpdateUserRole)       // Change user role
-			users.PUT("/:id/activate", authHandler.ActivateUser)     // Activate user
-			users.PUT("/:id/deactivate", authHandler.DeactivateUser) // Deactivate user
-			users.DELETE("/:id", authHandler.DeleteUser)             // Delete user (soft delete)
+			// Space management for managers
+			spaces := manager.Group("/spaces")
+			{
+				spaces.GET("/managed", spaceHandler.GetMyManagedSpaces)                  // Spaces I manage
+				spaces.GET("/:id/reservations", reservationHandler.GetSpaceReservations) // Reservations for my space
+				spaces.PUT("/:id/status", spaceHandler.UpdateSpaceStatus)                // Update space status
+
+				// Pre-check-in questionnaire answers
+				spaces.GET("/:id/questionnaire/submissions", questionnaireHandler.GetSpaceSubmissions) // Questionnaire compliance report
+			}
+
+			// Reservation approval workflow
+			approvals := manager.Group("/approvals")
+			{
+				approvals.GET("", reservationHandler.GetPendingApprovals)             // Pending approvals
+				approvals.POST("/bulk", reservationHandler.BulkApproveReservations)   // Approve or reject many at once
+				approvals.POST("/:id/approve", reservationHandler.ApproveReservation) // Approve reservation
+				approvals.POST("/:id/reject", reservationHandler.RejectReservation)   // Reject reservation
+			}
+
+			// Manager dashboard and statistics
+			stats := manager.Group("/stats")
+			{
+				stats.GET("/dashboard", spaceHandler.GetDashboardStatistics) // Manager dashboard
+				stats.GET("/spaces/:id", spaceHandler.GetSpaceStatistics)    // Individual space stats
+			}
+
+			// Booking analytics
+			analytics := manager.Group("/analytics")
+			{
+				analytics.GET("/conflicts", reservationHandler.GetConflictAnalytics)      // Attempts on already-taken slots
+				analytics.GET("/utilization", reservationHandler.GetUtilizationAnalytics) // Booked time actually used, from check-in sessions
+				analytics.GET("/capacity-forecast", capacityForecastHandler.GetForecast)  // Expected demand per building and space type
+			}
+
+			// Reception desk
+			reception := manager.Group("/reception")
+			{
+				reception.GET("/visitors", reservationGuestHandler.GetExpectedVisitors)          // Expected visitors per building and day
+				reception.POST("/visitors/:id/arrive", reservationGuestHandler.MarkGuestArrived) // Check visitor in
+			}
+
+			// Announcement channels, building ones for managers of a space there
+			broadcastChannels := manager.Group("/broadcast-channels")
+			{
+				broadcastChannels.GET("", broadcastChannelHandler.GetChannels)               // Channels I can post to
+				broadcastChannels.POST("/:id/posts", broadcastChannelHandler.CreatePost)     // Deliver an announcement
+				broadcastChannels.GET("/:id/posts", broadcastChannelHandler.GetPosts)        // With read statistics
+				broadcastChannels.GET("/:id/posts/:postId", broadcastChannelHandler.GetPost) // Read statistics of one post
+			}
 		}
 
-		// Space management (full CRUD)
-		spaces := admin.Group("/spaces")
+		// ========================================
+		// ADMIN ROUTES (Admin role only)
+		// ========================================
+		admin := protected.Group("/admin")
+		admin.Use(middlewares.AdminMiddleware())
 		{
-			spaces.POST("", spaceHandler.CreateSpace)                            // Create new space
-			spaces.PUT("/:id", spaceHandler.UpdateSpace)                         // Update space
-			spaces.DELETE("/:id", spaceHandler.DeleteSpace)                      // Delete space
-			spaces.POST("/:id/assign-manager", spaceHandler.AssignManager)       // Assign manager
-			spaces.DELETE("/:id/unassign-manager", spaceHandler.UnassignManager) // Remove manager
-			spaces.GET("/status/:status", spaceHandler.GetSpacesByStatus)        // Filter by status
+			// User management
+			users := admin.Group("/users")
+			{
+				users.GET("", authHandler.GetAllUsers)                     // List all users
+				users.GET("/search", authHandler.SearchUsers)              // Search users
+				users.PUT("/:id/role", authHandler.UpdateUserRole)         // Change user role
+				users.PUT("/:id/priority", authHandler.UpdateUserPriority) // Set booking priority tier
+				users.PUT("/:id/activate", authHandler.ActivateUser)       // Activate user
+				users.PUT("/:id/deactivate", authHandler.DeactivateUser)   // Deactivate user
+				users.DELETE("/:id", authHandler.DeleteUser)               // Delete user (soft delete)
+			}
+
+			// Facilities analytics
+			adminAnalytics := admin.Group("/analytics")
+			{
+				adminAnalytics.GET("/feedback", feedbackReportHandler.GetReport) // Check-out ratings and complaints per building and space
+			}
+
+			// Space management (full CRUD)
+			spaces := admin.Group("/spaces")
+			{
+				spaces.POST("", spaceHandler.CreateSpace)                            // Create new space
+				spaces.PUT("/:id", spaceHandler.UpdateSpace)                         // Update space
+				spaces.DELETE("/:id", spaceHandler.DeleteSpace)                      // Delete space
+				spaces.POST("/:id/assign-manager", spaceHandler.AssignManager)       // Assign manager
+				spaces.DELETE("/:id/unassign-manager", spaceHandler.UnassignManager) // Remove manager
+				spaces.GET("/status/:status", spaceHandler.GetSpacesByStatus)        // Filter by status
+
+				// Whole catalog, to replicate environments or bulk edit in spreadsheets
+				spaces.GET("/catalog", spaceCatalogHandler.ExportCatalog)         // Download as JSON or CSV
+				spaces.POST("/catalog/import", spaceCatalogHandler.ImportCatalog) // Upsert by building and name, with dry run
+
+				// VIP guaranteed-availability blocks
+				spaces.POST("/:id/vip-blocks", vipSpaceHandler.CreateBlock)   // Add VIP block
+				spaces.GET("/:id/vip-blocks", vipSpaceHandler.GetSpaceBlocks) // List VIP blocks
+
+				// Photo gallery
+				spaces.GET("/:id/photos", spacePhotoHandler.GetPhotos)                        // List photos in order
+				spaces.POST("/:id/photos", spacePhotoHandler.UploadPhotos)                    // Upload and resize photos
+				spaces.PUT("/:id/photos/order", spacePhotoHandler.ReorderPhotos)              // Reorder gallery
+				spaces.PUT("/:id/photos/:photoId/primary", spacePhotoHandler.SetPrimaryPhoto) // Photo shown in search results
+				spaces.DELETE("/:id/photos/:photoId", spacePhotoHandler.DeletePhoto)          // Delete photo
+
+				// Door displays
+				spaces.POST("/:id/displays", roomDisplayHandler.CreateDisplay)   // Register display, returns API key
+				spaces.GET("/:id/displays", roomDisplayHandler.GetSpaceDisplays) // List displays
+
+				// Occupancy sensors
+				spaces.POST("/:id/sensors", occupancyHandler.CreateSensor)   // Register sensor, returns API key
+				spaces.GET("/:id/sensors", occupancyHandler.GetSpaceSensors) // List sensors
+
+				// Pre-check-in questionnaires
+				spaces.PUT("/:id/questionnaire", questionnaireHandler.SetSpaceQuestionnaire)       // Create or replace questionnaire
+				spaces.GET("/:id/questionnaire", questionnaireHandler.GetSpaceQuestionnaire)       // Get questionnaire
+				spaces.DELETE("/:id/questionnaire", questionnaireHandler.DeleteSpaceQuestionnaire) // Remove questionnaire
+			}
+
+			admin.DELETE("/vip-blocks/:id", vipSpaceHandler.DeleteBlock)    // Remove VIP block
+			admin.DELETE("/displays/:id", roomDisplayHandler.RevokeDisplay) // Revoke display key
+			admin.DELETE("/sensors/:id", occupancyHandler.RevokeSensor)     // Revoke sensor key
+
+			// Embeddable booking widgets
+			widgetTokens := admin.Group("/widget-tokens")
+			{
+				widgetTokens.POST("", widgetHandler.CreateToken)       // Create token for a space or building, returns it once
+				widgetTokens.GET("", widgetHandler.GetTokens)          // List tokens
+				widgetTokens.DELETE("/:id", widgetHandler.RevokeToken) // Revoke token
+			}
+
+			// Reception system integrations
+			integrationKeys := admin.Group("/integration-keys")
+			{
+				integrationKeys.POST("", visitorIntegrationHandler.CreateKey)       // Create scoped key, returns it once
+				integrationKeys.GET("", visitorIntegrationHandler.GetKeys)          // List keys
+				integrationKeys.DELETE("/:id", visitorIntegrationHandler.RevokeKey) // Revoke key
+			}
+
+			// System-wide reservation management
+			reservations := admin.Group("/reservations")
+			{
+				reservations.GET("", reservationHandler.GetAllReservations)                     // All reservations
+				reservations.GET("/status/:status", reservationHandler.GetReservationsByStatus) // Filter by status
+				reservations.DELETE("/:id", reservationHandler.DeleteReservation)               // Force delete
+				reservations.POST("/:id/no-show", reservationHandler.MarkNoShow)                // Mark as no-show
+
+				// Bulk cancellation
+				reservations.POST("/import", reservationImportHandler.ImportCSV)                    // Migrate bookings from a CSV file
+				reservations.POST("/bulk-cancel", reservationBulkCancelHandler.BulkCancel)          // Dry run or cancel matches
+				reservations.GET("/bulk-cancel/:id/report", reservationBulkCancelHandler.GetReport) // Download CSV report
+			}
+
+			// System statistics and monitoring
+			stats := admin.Group("/stats")
+			{
+				stats.GET("", statsHandler.GetSystemStats)              // System overview
+				stats.GET("/users", statsHandler.GetUserStats)          // User statistics
+				stats.GET("/recent-users", statsHandler.GetRecentUsers) // Recent registrations
+			}
+
+			// Incident diagnostics
+			admin.GET("/diagnostics", diagnosticsHandler.GetDiagnostics) // Build, queues, clients, caches and jobs
+
+			// Background jobs
+			jobsGroup := admin.Group("/jobs")
+			{
+				jobsGroup.GET("", jobHandler.GetJobs)           // Job status
+				jobsGroup.POST("/:name/run", jobHandler.RunJob) // Run a job now
+			}
+
+			// Failed notifications, webhook deliveries and job runs
+			deadLetters := admin.Group("/dead-letters")
+			{
+				deadLetters.GET("", deadLetterHandler.GetDeadLetters)               // List, by source and status
+				deadLetters.POST("/replay", deadLetterHandler.ReplayDeadLetters)    // Replay selected or all open
+				deadLetters.GET("/:id", deadLetterHandler.GetDeadLetter)            // Inspect payload
+				deadLetters.PUT("/:id", deadLetterHandler.UpdateDeadLetter)         // Edit payload
+				deadLetters.POST("/:id/replay", deadLetterHandler.ReplayDeadLetter) // Replay one
+				deadLetters.DELETE("/:id", deadLetterHandler.DiscardDeadLetter)     // Discard without replaying
+			}
+
+			// Bookings, approvals, chat escalations and job runs in one feed
+			admin.GET("/events", activityHandler.GetEvents) // Page by cursor, or stream=true to follow as SSE
+
+			// Reservation data consistency
+			admin.GET("/data-quality", dataQualityHandler.GetReport)      // Anomalies with their fix-up action
+			admin.POST("/data-quality/fix", dataQualityHandler.FixIssues) // Apply the fix-up of one issue type
+
+			// Personal data retention
+			admin.GET("/retention/report", retentionHandler.GetReport) // Dry run of the retention jobs
+
+			// Executive reports
+			reports := admin.Group("/reports")
+			{
+				reports.POST("", reportHandler.CreateReport)                                  // Custom report, queued when heavy
+				reports.GET("/catalog", reportHandler.GetCatalog)                             // Fields custom reports can use
+				reports.GET("/runs/:id", reportHandler.GetRun)                                // Queued custom report and its result
+				reports.GET("/vip-violations", vipSpaceHandler.GetViolationReport)            // VIP block overrides
+				reports.GET("/preemptions", reservationPreemptionHandler.GetPreemptionReport) // Pending bookings displaced by higher priorities
+				reports.GET("/low-usage", floorConsolidationHandler.GetLowUsageForecast)      // Booked usage per floor and day
+				reports.GET("/chargeback", chargebackHandler.GetChargebackReport)             // Monthly cost per department and cost center, JSON or CSV
+			}
+
+			// Floor consolidations steering bookings onto fewer floors
+			consolidations := admin.Group("/floor-consolidations")
+			{
+				consolidations.POST("", floorConsolidationHandler.CreateConsolidation)      // Keep only some floors open
+				consolidations.GET("", floorConsolidationHandler.GetConsolidations)         // Running and planned
+				consolidations.POST("/:id/end", floorConsolidationHandler.EndConsolidation) // Reopen every floor
+			}
+
+			// Buildings and floors closed for holidays or renovation
+			closures := admin.Group("/closures")
+			{
+				closures.POST("", buildingClosureHandler.CreateClosure)      // Close a building or floor, flagging existing bookings
+				closures.GET("", buildingClosureHandler.GetClosures)         // Running and planned
+				closures.POST("/:id/end", buildingClosureHandler.EndClosure) // Reopen early
+			}
+
+			// Evacuations and other emergencies announced in a building
+			emergencies := admin.Group("/emergencies")
+			{
+				emergencies.POST("", emergencyBroadcastHandler.Broadcast)            // Notify everyone booked today, stop check-ins
+				emergencies.GET("", emergencyBroadcastHandler.GetBroadcasts)         // Most recent first
+				emergencies.GET("/:id", emergencyBroadcastHandler.GetBroadcast)      // With acknowledgments
+				emergencies.POST("/:id/end", emergencyBroadcastHandler.EndBroadcast) // All-clear
+			}
+
+			// Read-only announcement channels
+			broadcastChannels := admin.Group("/broadcast-channels")
+			{
+				broadcastChannels.POST("", broadcastChannelHandler.CreateChannel)    // For a building or every user
+				broadcastChannels.PUT("/:id", broadcastChannelHandler.UpdateChannel) // Rename or archive
+			}
+
+			// Cost centers reservations are charged to
+			costCenters := admin.Group("/cost-centers")
+			{
+				costCenters.GET("", costCenterHandler.GetCostCenters)            // All cost centers
+				costCenters.PUT("/:code", costCenterHandler.SetCostCenter)       // Add or change
+				costCenters.DELETE("/:code", costCenterHandler.DeleteCostCenter) // Remove from the list
+			}
+
+			// Where "any suitable room" bookings are placed in each building
+			placementPolicies := admin.Group("/placement-policies")
+			{
+				placementPolicies.GET("", placementPolicyHandler.GetPolicies)               // Strategy per building
+				placementPolicies.PUT("/:building", placementPolicyHandler.SetPolicy)       // Set a building's strategy
+				placementPolicies.DELETE("/:building", placementPolicyHandler.DeletePolicy) // Back to the default
+			}
+
+			// Conditional and two-step approval per space
+			approvalRules := admin.Group("/approval-rules")
+			{
+				approvalRules.GET("", approvalRuleHandler.GetRules)               // All rules
+				approvalRules.GET("/:spaceId", approvalRuleHandler.GetRule)       // Rule of a space
+				approvalRules.PUT("/:spaceId", approvalRuleHandler.SetRule)       // Create or replace
+				approvalRules.DELETE("/:spaceId", approvalRuleHandler.DeleteRule) // Back to single approval
+			}
+
+			// Booking quota overrides per user and team
+			quotas := admin.Group("/quotas")
+			{
+				quotas.GET("/overrides", bookingQuotaHandler.GetOverrides)            // All overrides
+				quotas.DELETE("/overrides/:id", bookingQuotaHandler.DeleteOverride)   // Back to the configured quota
+				quotas.PUT("/users/:id", bookingQuotaHandler.SetUserOverride)         // Weekly hours of a user
+				quotas.GET("/users/:id/usage", bookingQuotaHandler.GetUserUsage)      // A user's quota usage
+				quotas.PUT("/teams/:department", bookingQuotaHandler.SetTeamOverride) // Premium-room hours of a department
+			}
+
+			// Strikes for late cancellations and no-shows
+			strikes := admin.Group("/strikes")
+			{
+				strikes.GET("", bookingStrikeHandler.GetActiveStrikes)              // Strikes still counting
+				strikes.DELETE("/:id", bookingStrikeHandler.ClearStrike)            // Clear one strike
+				strikes.GET("/users/:id", bookingStrikeHandler.GetUserStrikes)      // A user's strikes and restriction
+				strikes.DELETE("/users/:id", bookingStrikeHandler.ClearUserStrikes) // Clear all of a user's strikes
+			}
+
+			// Floor plans, desk positions and team neighborhoods
+			adminFloorPlans := admin.Group("/floor-plans")
+			{
+				adminFloorPlans.POST("", floorPlanHandler.SavePlan)                                               // Create or replace a floor's plan
+				adminFloorPlans.GET("/:id", floorPlanHandler.GetPlan)                                             // Plan with seats and neighborhoods
+				adminFloorPlans.DELETE("/:id", floorPlanHandler.DeletePlan)                                       // Remove a plan
+				adminFloorPlans.PUT("/:id/seats", floorPlanHandler.SetSeats)                                      // Place the floor's desks
+				adminFloorPlans.POST("/:id/neighborhoods", floorPlanHandler.CreateNeighborhood)                   // Add a team zone
+				adminFloorPlans.DELETE("/:id/neighborhoods/:neighborhoodId", floorPlanHandler.DeleteNeighborhood) // Remove a team zone
+			}
+
+			// Checklist templates per space type
+			checklistTemplates := admin.Group("/checklist-templates")
+			{
+				checklistTemplates.GET("", checklistHandler.GetTemplates)                 // All templates
+				checklistTemplates.PUT("/:spaceType", checklistHandler.SetTemplate)       // Create or replace
+				checklistTemplates.DELETE("/:spaceType", checklistHandler.DeleteTemplate) // Remove
+			}
+
+			// Regional holidays and company closure days
+			calendar := admin.Group("/calendar")
+			{
+				calendar.GET("/regions", workingCalendarHandler.GetRegions)            // Regions and their buildings
+				calendar.PUT("/regions/:code", workingCalendarHandler.SetRegion)       // Create or replace
+				calendar.DELETE("/regions/:code", workingCalendarHandler.DeleteRegion) // Remove with its days
+				calendar.GET("/days", workingCalendarHandler.GetDays)                  // Days of a year
+				calendar.POST("/days", workingCalendarHandler.CreateDay)               // Add a holiday or closure day
+				calendar.DELETE("/days/:id", workingCalendarHandler.DeleteDay)         // Open the day again
+			}
+
+			// Check-in enforcement per space type
+			checkInPolicies := admin.Group("/check-in-policies")
+			{
+				checkInPolicies.GET("", checkInPolicyHandler.GetPolicies)                // All policies
+				checkInPolicies.PUT("/:spaceType", checkInPolicyHandler.SetPolicy)       // Create or replace
+				checkInPolicies.DELETE("/:spaceType", checkInPolicyHandler.DeletePolicy) // Stop enforcing
+			}
+
+			// Services catalog
+			serviceItems := admin.Group("/services")
+			{
+				serviceItems.GET("", serviceRequestHandler.GetAllItems)    // Whole catalog
+				serviceItems.POST("", serviceRequestHandler.CreateItem)    // Offer a service
+				serviceItems.PUT("/:id", serviceRequestHandler.UpdateItem) // Change price, lead time or withdraw
+			}
+
+			// Chat moderation
+			moderation := admin.Group("/moderation")
+			{
+				moderation.GET("/reports", chatHandler.GetReports)                       // Moderation queue
+				moderation.POST("/reports/:id/action", chatHandler.TakeModerationAction) // Act on a report
+				moderation.GET("/bans", chatHandler.GetActiveBans)                       // Active chat bans
+				moderation.DELETE("/bans/:id", chatHandler.LiftBan)                      // Lift a ban
+			}
+
+			// Support conversations
+			adminChat := admin.Group("/chat")
+			{
+				adminChat.POST("/conversations/unarchive", chatHandler.BatchUnarchiveConversations) // Restore archived conversations
+			}
+
+			// Webhook subscriptions
+			webhooks := admin.Group("/webhooks")
+			{
+				webhooks.POST("", webhookHandler.CreateWebhook)               // Subscribe endpoint, returns secret
+				webhooks.GET("", webhookHandler.GetWebhooks)                  // List subscriptions
+				webhooks.PUT("/:id", webhookHandler.UpdateWebhook)            // Change events, schema version, pause
+				webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)         // Remove subscription
+				webhooks.GET("/:id/deliveries", webhookHandler.GetDeliveries) // Recent deliveries
+			}
+
+			// Canned responses and support bot answers
+			canned := admin.Group("/canned-responses")
+			{
+				canned.GET("", chatHandler.GetAllCannedResponses)       // All canned responses
+				canned.POST("", chatHandler.CreateCannedResponse)       // Add canned response
+				canned.PUT("/:id", chatHandler.UpdateCannedResponse)    // Update canned response
+				canned.DELETE("/:id", chatHandler.DeleteCannedResponse) // Delete canned response
+			}
 		}
 
-		// System-wide reservation management
-		reservations := admin.Group("/reservations")
+		// ========================================
+		// FACILITIES ROUTES (Facility staff or admin)
+		// ========================================
+		facilities := protected.Group("/facilities")
+		facilities.Use(middlewares.RequireFacilities())
 		{
-			reservations.GET("", reservationHandler.GetAllReservations)                     // All reservations
-			reservations.GET("/status/:status", reservationHandler.GetReservationsByStatus) // Filter by status
-			reservations.DELETE("/:id", reservationHandler.DeleteReservation)               // Force delete
-			reservations.POST("/:id/no-show", reservationHandler.MarkNoShow)                // Mark as no-show
+			facilities.GET("/service-requests", serviceRequestHandler.GetQueue)                        // Fulfillment queue
+			facilities.PUT("/service-requests/:id/status", serviceRequestHandler.UpdateStatus)         // Accept, fulfill or reject
+			facilities.GET("/equipment/checkouts", equipmentCheckoutHandler.GetCheckedOut)             // Equipment out, or overdue
+			facilities.POST("/equipment/checkouts/:id/pickup", equipmentCheckoutHandler.ConfirmPickup) // Hand equipment out
+			facilities.POST("/equipment/checkouts/:id/return", equipmentCheckoutHandler.ConfirmReturn) // Take equipment back
 		}
 
-		// System statistics and monitoring
-		stats := admin.Group("/stats")
+		// ========================================
+		// AUDIT ROUTES (Auditor role only)
+		// ========================================
+		audit := protected.Group("/audit")
+		audit.Use(middlewares.RequireAuditor())
 		{
-			stats.GET("", statsHandler.GetSystemStats)              // System overview
-			stats.GET("/users", statsHandler.GetUserStats)          // User statistics
-			stats.GET("/recent-users", statsHandler.GetRecentUsers) // Recent registrations
+			// Tamper-evident message exports for legal requests, off without a signing key
+			if complianceExportService != nil {
+				complianceExports := audit.Group("/compliance-exports")
+				{
+					complianceExports.GET("", complianceExportHandler.GetExports)                            // Export history
+					complianceExports.GET("/public-key", complianceExportHandler.GetPublicKey)               // Key verifying manifests
+					complianceExports.POST("/verify", complianceExportHandler.VerifyExport)                  // Check a bundle
+					complianceExports.POST("/conversations/:id", complianceExportHandler.ExportConversation) // Export a conversation
+					complianceExports.POST("/users/:id", complianceExportHandler.ExportUserMessages)         // Export a user's messages
+				}
+			}
 		}
 	}
 
@@ -192,16 +1093,53 @@ func Setup(router *gin.Engine, db *gorm.DB, cfg *config.Config) {
 		c.JSON(200, gin.H{
 			"status":    "healthy",
 			"timestamp": time.Now().UTC(),
-			"version":   "1.0.0",
+			"version":   version.Version,
 			"service":   "Room Reservation API",
 		})
 	})
 
+	// Build details and client compatibility, used by mobile apps to prompt for upgrades
+	router.GET("/version", versionHandler.GetVersion)
+
+	// Probes: liveness checks nothing; readiness needs the database, other dependencies and open
+	// breakers only degrade it
+	router.GET("/health/live", healthHandler.Live)
+	router.GET("/health/ready", healthHandler.Ready)
+
+	// Connection pool usage of the primary and the read replica; 503 once every connection is in use
+	router.GET("/health/pool", func(c *gin.Context) {
+		status, code := "ok", 200
+		pools := gin.H{}
+
+		targets := map[string]*gorm.DB{"primary": db}
+		if replica != nil {
+			targets["replica"] = replica
+		}
+		for name, target := range targets {
+			pool, err := database.Pool(target)
+			if err != nil {
+				pools[name] = gin.H{"error": err.Error()}
+				status, code = "unavailable", 503
+				continue
+			}
+			pools[name] = pool
+			if pool.Saturated && code == 200 {
+				status, code = "saturated", 503
+			}
+		}
+
+		c.JSON(code, gin.H{
+			"status":    status,
+			"timestamp": time.Now().UTC(),
+			"pools":     pools,
+		})
+	})
+
 	// API documentation
 	router.GET("/api/docs", func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"service":     "Room Reservation API",
-			"version":     "1.0.0",
+			"version":     version.Version,
 			"description": "Coworking Space Reservation System - PFE Project",
 			"author":      "Your Name",
 			"endpoints": gin.H{
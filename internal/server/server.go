@@ -2,12 +2,18 @@ package server
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"net"
 	"net/http"
 	"time"
 
 	"room-reservation-api/internal/config"
+	"room-reservation-api/internal/handlers"
+	"room-reservation-api/internal/jobs"
+	"room-reservation-api/internal/middlewares"
 	"room-reservation-api/internal/server/routes"
+	"room-reservation-api/internal/websocket"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -19,11 +25,14 @@ type Server struct {
 	logger     *slog.Logger
 	config     *config.Config
 	db         *gorm.DB
+	replica    *gorm.DB // Read replica, nil when not configured
 	httpServer *http.Server
+	scheduler  *jobs.Scheduler
+	wsManager  *websocket.Manager
 }
 
 // New creates a new server instance with all dependencies
-func New(cfg *config.Config, logger *slog.Logger, db *gorm.DB) *Server {
+func New(cfg *config.Config, logger *slog.Logger, db, replica *gorm.DB) *Server {
 	// Configure Gin mode based on environment
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -36,10 +45,14 @@ func New(cfg *config.Config, logger *slog.Logger, db *gorm.DB) *Server {
 
 	// Create server instance
 	server := &Server{
-		config: cfg,
-		logger: logger,
-		db:     db,
-		router: router,
+		config:    cfg,
+		logger:    logger,
+		db:        db,
+		replica:   replica,
+		router:    router,
+		scheduler: jobs.NewScheduler(logger),
+		// Realtime events are published on a bus shared by WebSocket clients and long-poll requests
+		wsManager: websocket.NewManager(nil, nil, nil, nil, websocket.NewEventBus(websocket.DefaultEventBusCapacity)),
 		httpServer: &http.Server{
 			Addr:         ":" + cfg.Port,
 			Handler:      router,
@@ -49,6 +62,13 @@ func New(cfg *config.Config, logger *slog.Logger, db *gorm.DB) *Server {
 		},
 	}
 
+	// Event streams watch the shutdown context so the drain does not wait for them
+	shutdownCtx, shutdown := context.WithCancel(context.Background())
+	server.httpServer.BaseContext = func(net.Listener) context.Context {
+		return handlers.WithShutdown(context.Background(), shutdownCtx)
+	}
+	server.httpServer.RegisterOnShutdown(shutdown)
+
 	// Setup middleware and routes
 	server.setupMiddleware()
 	server.setupRoutes()
@@ -60,66 +80,22 @@ func New(cfg *config.Config, logger *slog.Logger, db *gorm.DB) *Server {
 func (s *Server) setupMiddleware() {
 	// Recovery middleware - recovers from panics
 	s.router.Use(gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		s.logger.Error("Panic recovered", "error", recovered)
+		s.logger.ErrorContext(c.Request.Context(), "Panic recovered", "error", recovered)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "internal_server_error",
 			"message": "An unexpected error occurred",
 		})
 	}))
 
-	// Request logging middleware for PFE demonstration
-	s.router.Use(gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		return ""
-	}))
-
-	// Custom request logger for structured logging
-	s.router.Use(func(c *gin.Context) {
-		start := time.Now()
-		path := c.Request.URL.Path
-		raw := c.Request.URL.RawQuery
-
-		// Process request
-		c.Next()
+	// Correlation ID first, so every later log line and error response carries it
+	s.router.Use(middlewares.RequestID())
 
-		// Log request details
-		latency := time.Since(start)
-
-		if raw != "" {
-			path = path + "?" + raw
-		}
-
-		// Log different levels based on status code
-		statusCode := c.Writer.Status()
-		switch {
-		case statusCode >= 500:
-			s.logger.Error("HTTP Request",
-				"method", c.Request.Method,
-				"path", path,
-				"status", statusCode,
-				"latency", latency,
-				"ip", c.ClientIP(),
-			)
-		case statusCode >= 400:
-			s.logger.Warn("HTTP Request",
-				"method", c.Request.Method,
-				"path", path,
-				"status", statusCode,
-				"latency", latency,
-				"ip", c.ClientIP(),
-			)
-		default:
-			// Only log API calls, skip health checks in production
-			if s.config.Environment != "production" || (path != "/health" && path != "/") {
-				s.logger.Info("HTTP Request",
-					"method", c.Request.Method,
-					"path", path,
-					"status", statusCode,
-					"latency", latency,
-					"ip", c.ClientIP(),
-				)
-			}
-		}
-	})
+	// Structured request logging; health probes are only logged when they fail in production
+	var skipPaths []string
+	if s.config.Environment == "production" {
+		skipPaths = []string{"/", "/health", "/health/live", "/health/ready"}
+	}
+	s.router.Use(middlewares.RequestLogger(s.logger, skipPaths...))
 
 	// Security headers middleware
 	s.router.Use(func(c *gin.Context) {
@@ -141,7 +117,7 @@ func (s *Server) setupMiddleware() {
 // setupRoutes initializes all application routes
 func (s *Server) setupRoutes() {
 	// Setup all routes using the routes package
-	routes.Setup(s.router, s.db, s.config)
+	routes.Setup(s.router, s.db, s.replica, s.config, s.scheduler, s.wsManager)
 
 	// Add root endpoint for PFE demonstration
 	s.router.GET("/", func(c *gin.Context) {
@@ -189,6 +165,11 @@ func (s *Server) Start() error {
 		"environment", s.config.Environment,
 	)
 
+	// Start background jobs
+	if s.config.EnableScheduler {
+		s.scheduler.Start()
+	}
+
 	// Start server
 	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		s.logger.Error("❌ Failed to start server", "error", err)
@@ -198,14 +179,40 @@ func (s *Server) Start() error {
 	return nil
 }
 
-// Shutdown gracefully shuts down the server
+// Shutdown gracefully shuts down the server within ctx: it stops accepting requests, ends
+// event streams and drains the other requests in flight, closes WebSocket connections after
+// notifying clients, and meanwhile waits for running background jobs. Every step runs even
+// when an earlier one fails.
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("🛑 Shutting down HTTP server...")
 
-	// Shutdown server with context timeout
+	// Let running jobs finish alongside the drain; the ones still going at the deadline are cancelled
+	schedulerDone := make(chan error, 1)
+	go func() {
+		schedulerDone <- s.scheduler.Stop(ctx)
+	}()
+
+	var shutdownErr error
+
+	// Drain requests in flight; the shutdown hook ends event streams and hijacked WebSocket
+	// connections are closed by the manager
 	if err := s.httpServer.Shutdown(ctx); err != nil {
 		s.logger.Error("❌ Server shutdown error", "error", err)
-		return err
+		shutdownErr = err
+	}
+
+	if err := s.wsManager.Shutdown(ctx); err != nil {
+		s.logger.Error("❌ WebSocket shutdown error", "error", err)
+		shutdownErr = errors.Join(shutdownErr, err)
+	}
+
+	if err := <-schedulerDone; err != nil {
+		s.logger.Error("❌ Scheduler shutdown error", "error", err)
+		shutdownErr = errors.Join(shutdownErr, err)
+	}
+
+	if shutdownErr != nil {
+		return shutdownErr
 	}
 
 	s.logger.Info("✅ HTTP server shutdown complete")
@@ -227,6 +234,16 @@ func (s *Server) GetRouter() *gin.Engine {
 	return s.router
 }
 
+// GetWebSocketManager returns the realtime event manager
+func (s *Server) GetWebSocketManager() *websocket.Manager {
+	return s.wsManager
+}
+
+// GetScheduler returns the background job scheduler
+func (s *Server) GetScheduler() *jobs.Scheduler {
+	return s.scheduler
+}
+
 // GetConfig returns the server configuration
 func (s *Server) GetConfig() *config.Config {
 	return s.config
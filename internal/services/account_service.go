@@ -0,0 +1,255 @@
+// internal/services/account_service.go
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// Version of the export archive layout, raised when files change shape
+	accountExportFormatVersion = 1
+	// Records read per query while building an export
+	accountExportPageSize = 200
+)
+
+// AccountService lets users take their data with them and delete their account. Deleting
+// anonymizes the user instead of removing rows, so statistics built from past bookings stay intact.
+type AccountService struct {
+	accountRepo        interfaces.AccountRepositoryInterface
+	userRepo           interfaces.UserRepositoryInterface
+	reservationRepo    interfaces.ReservationRepositoryInterface
+	notificationRepo   interfaces.NotificationRepositoryInterface
+	userPreferenceRepo interfaces.UserPreferenceRepositoryInterface
+	chatRepo           interfaces.ChatRepository
+	chatService        *ChatService
+	logger             *slog.Logger
+}
+
+// NewAccountService creates a new account service
+func NewAccountService(
+	accountRepo interfaces.AccountRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	notificationRepo interfaces.NotificationRepositoryInterface,
+	userPreferenceRepo interfaces.UserPreferenceRepositoryInterface,
+	chatRepo interfaces.ChatRepository,
+	chatService *ChatService,
+	logger *slog.Logger,
+) *AccountService {
+	return &AccountService{
+		accountRepo:        accountRepo,
+		userRepo:           userRepo,
+		reservationRepo:    reservationRepo,
+		notificationRepo:   notificationRepo,
+		userPreferenceRepo: userPreferenceRepo,
+		chatRepo:           chatRepo,
+		chatService:        chatService,
+		logger:             logger,
+	}
+}
+
+// ========================================
+// DATA EXPORT
+// ========================================
+
+// ExportData builds a ZIP archive of the user's data as JSON files: profile and booking
+// preferences, reservations they own or co-organize, notifications and chat transcripts
+func (s *AccountService) ExportData(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	preference, err := s.userPreferenceRepo.GetByUser(userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	reservations, err := s.exportReservations(userID)
+	if err != nil {
+		return nil, err
+	}
+	notifications, err := s.exportNotifications(userID)
+	if err != nil {
+		return nil, err
+	}
+	conversations, err := s.exportConversations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := dto.AccountExportManifest{
+		UserID:        user.ID,
+		GeneratedAt:   time.Now().UTC(),
+		FormatVersion: accountExportFormatVersion,
+		Files: map[string]int{
+			"profile.json":       1,
+			"reservations.json":  len(reservations),
+			"notifications.json": len(notifications),
+			"conversations.json": len(conversations),
+		},
+	}
+
+	profile := map[string]interface{}{
+		"user":        dto.ToUserResponse(user),
+		"preferences": preference,
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	files := []struct {
+		name    string
+		content interface{}
+	}{
+		{"manifest.json", manifest},
+		{"profile.json", profile},
+		{"reservations.json", reservations},
+		{"notifications.json", notifications},
+		{"conversations.json", conversations},
+	}
+	for _, file := range files {
+		if err := writeJSONFile(archive, file.name, file.content); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "Account data exported", "userID", userID, "reservations", len(reservations), "conversations", len(conversations))
+	return buf.Bytes(), nil
+}
+
+// ========================================
+// ACCOUNT DELETION
+// ========================================
+
+// DeleteAccount anonymizes the user once they confirm with their password. Upcoming
+// bookings are cancelled and the account can no longer sign in. The last admin cannot
+// delete their account.
+func (s *AccountService) DeleteAccount(userID uuid.UUID, req *dto.DeleteAccountRequest) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		return errors.New("password is incorrect")
+	}
+
+	if user.IsAdmin() {
+		admins, err := s.userRepo.CountByRole(models.RoleAdmin)
+		if err != nil {
+			return fmt.Errorf("failed to count admins: %w", err)
+		}
+		if admins <= 1 {
+			return errors.New("the last admin cannot delete their account")
+		}
+	}
+
+	if err := s.accountRepo.Anonymize(userID, time.Now()); err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+
+	s.logger.Info("Account deleted and anonymized", "userID", userID)
+	return nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// exportReservations reads every reservation the user owns or co-organizes
+func (s *AccountService) exportReservations(userID uuid.UUID) ([]*dto.ReservationResponse, error) {
+	var responses []*dto.ReservationResponse
+	for offset := 0; ; offset += accountExportPageSize {
+		reservations, total, err := s.reservationRepo.GetUserReservations(userID, offset, accountExportPageSize, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reservations: %w", err)
+		}
+		for _, reservation := range reservations {
+			responses = append(responses, dto.ToReservationResponse(reservation))
+		}
+		if len(reservations) < accountExportPageSize || int64(offset+len(reservations)) >= total {
+			return responses, nil
+		}
+	}
+}
+
+// exportNotifications reads every notification sent to the user
+func (s *AccountService) exportNotifications(userID uuid.UUID) ([]*models.Notification, error) {
+	var all []*models.Notification
+	for offset := 0; ; offset += accountExportPageSize {
+		notifications, total, err := s.notificationRepo.GetUserNotifications(userID, false, offset, accountExportPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get notifications: %w", err)
+		}
+		all = append(all, notifications...)
+		if len(notifications) < accountExportPageSize || int64(offset+len(notifications)) >= total {
+			return all, nil
+		}
+	}
+}
+
+// exportConversations builds the transcript of every conversation the user takes part in,
+// archived ones included
+func (s *AccountService) exportConversations(ctx context.Context, userID uuid.UUID) ([]*dto.ConversationTranscript, error) {
+	var transcripts []*dto.ConversationTranscript
+	for _, archived := range []bool{false, true} {
+		archived := archived
+		for offset := 0; ; offset += accountExportPageSize {
+			conversations, total, err := s.chatRepo.GetConversationsByUserID(ctx, userID, &dto.GetConversationsRequest{
+				IsArchived: &archived,
+				Limit:      accountExportPageSize,
+				Offset:     offset,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get conversations: %w", err)
+			}
+
+			for _, conversation := range conversations {
+				transcript, err := s.chatService.ExportConversation(ctx, userID, conversation.ID)
+				if err != nil {
+					return nil, err
+				}
+				transcripts = append(transcripts, transcript)
+			}
+			if len(conversations) < accountExportPageSize || int64(offset+len(conversations)) >= total {
+				break
+			}
+		}
+	}
+	return transcripts, nil
+}
+
+// writeJSONFile adds an indented JSON file to the archive
+func writeJSONFile(archive *zip.Writer, name string, content interface{}) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(content)
+}
@@ -0,0 +1,266 @@
+// internal/services/activity_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/jobs"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	defaultActivityPageSize = 50
+	// Events are served once they are this old, so a change still committing cannot land
+	// behind a cursor that was already handed out
+	activitySettleDelay = 2 * time.Second
+	// Jobs running at most this often have every run in the feed; more frequent ones only
+	// appear when they start failing and when they recover
+	activityJobMinInterval = time.Hour
+)
+
+// ActivityService keeps the admin activity feed: bookings from the outbox, approval decisions,
+// chat escalations and scheduled job runs, in one stream that can be paged and replayed
+type ActivityService struct {
+	activityRepo interfaces.ActivityRepositoryInterface
+	logger       *slog.Logger
+}
+
+// NewActivityService creates a new activity service
+func NewActivityService(activityRepo interfaces.ActivityRepositoryInterface, logger *slog.Logger) *ActivityService {
+	return &ActivityService{
+		activityRepo: activityRepo,
+		logger:       logger,
+	}
+}
+
+// ========================================
+// RECORDING
+// ========================================
+
+// RecordReservationEvent adds a booking change from the outbox to the feed, attributed to the
+// organizer, or to whoever displaced the booking for preemptions. Like every entry it is dated
+// when it enters the feed, here at dispatch, so a retried event cannot land behind a cursor
+// that was already handed out.
+func (s *ActivityService) RecordReservationEvent(ctx context.Context, event *models.OutboxEvent) error {
+	var payload struct {
+		UserID        uuid.UUID `json:"user_id"`
+		Title         string    `json:"title"`
+		PreemptedByID uuid.UUID `json:"preempted_by_id"`
+	}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("failed to decode reservation event: %w", err)
+	}
+
+	actorID := payload.UserID
+	if payload.PreemptedByID != uuid.Nil {
+		actorID = payload.PreemptedByID
+	}
+
+	activity := &models.ActivityEvent{
+		Category:   models.ActivityCategoryBooking,
+		Action:     event.EventType,
+		EntityType: models.ActivityEntityReservation,
+		EntityID:   &event.AggregateID,
+		EntityName: payload.Title,
+		Details:    event.Payload,
+		SourceID:   &event.ID,
+	}
+	if actorID != uuid.Nil {
+		activity.ActorID = &actorID
+	}
+
+	if err := s.activityRepo.Record(ctx, activity); err != nil {
+		return fmt.Errorf("failed to record activity: %w", err)
+	}
+	return nil
+}
+
+// RecordEscalation adds a support conversation nobody accepted to the feed
+func (s *ActivityService) RecordEscalation(ctx context.Context, conversation *models.Conversation) {
+	details, _ := json.Marshal(map[string]interface{}{"department": conversation.Department})
+	activity := &models.ActivityEvent{
+		Category:   models.ActivityCategoryChat,
+		Action:     models.ActivityChatEscalated,
+		EntityType: models.ActivityEntityConversation,
+		EntityID:   &conversation.ID,
+		Details:    datatypes.JSON(details),
+	}
+	if conversation.Title != nil {
+		activity.EntityName = *conversation.Title
+	}
+
+	if err := s.activityRepo.Record(ctx, activity); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to record escalation activity", "conversationID", conversation.ID, "error", err)
+	}
+}
+
+// RecordJobRun adds a scheduled job run to the feed; it is registered as the scheduler's run hook
+func (s *ActivityService) RecordJobRun(run jobs.JobRun) {
+	frequent := run.Interval > 0 && run.Interval < activityJobMinInterval
+
+	var action string
+	switch {
+	case run.Err != nil && (!frequent || !run.PrevFailed):
+		action = models.ActivityJobFailed
+	case run.Err == nil && frequent && run.PrevFailed:
+		action = models.ActivityJobRecovered
+	case run.Err == nil && !frequent:
+		action = models.ActivityJobCompleted
+	default:
+		return
+	}
+
+	details := map[string]interface{}{
+		"started_at":  run.StartedAt,
+		"duration_ms": run.Duration.Milliseconds(),
+	}
+	if run.Err != nil {
+		details["error"] = run.Err.Error()
+	}
+	body, _ := json.Marshal(details)
+
+	if err := s.activityRepo.Record(context.Background(), &models.ActivityEvent{
+		Category:   models.ActivityCategoryJob,
+		Action:     action,
+		EntityType: models.ActivityEntityJob,
+		EntityName: run.Name,
+		Details:    datatypes.JSON(body),
+	}); err != nil {
+		s.logger.Error("Failed to record job activity", "job", run.Name, "error", err)
+	}
+}
+
+// ========================================
+// READING
+// ========================================
+
+// GetEvents returns a page of the feed in the order events occurred, starting after the cursor.
+// Each event carries its own cursor, so a client that read the last page resumes from its last event.
+func (s *ActivityService) GetEvents(ctx context.Context, req *dto.ActivityEventsRequest) (*dto.CursorPaginatedResponse, error) {
+	events, more, err := s.NextEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var next *dto.Cursor
+	if more {
+		last := events[len(events)-1]
+		cursor := dto.NewTimeCursor(last.OccurredAt, last.ID)
+		next = &cursor
+	}
+
+	response := dto.NewCursorPaginatedResponse(events, activityPageSize(req), next)
+	return &response, nil
+}
+
+// NextEvents returns the events after the request's cursor and whether more are waiting
+func (s *ActivityService) NextEvents(ctx context.Context, req *dto.ActivityEventsRequest) ([]dto.ActivityEventResponse, bool, error) {
+	limit := activityPageSize(req)
+	events, err := s.listEvents(ctx, req, limit+1)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(events) > limit {
+		return events[:limit], true, nil
+	}
+	return events, false, nil
+}
+
+func (s *ActivityService) listEvents(ctx context.Context, req *dto.ActivityEventsRequest, limit int) ([]dto.ActivityEventResponse, error) {
+	filter, err := activityFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := dto.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	var after *time.Time
+	var afterID uuid.UUID
+	if cursor != nil {
+		t, err := cursor.Time()
+		if err != nil {
+			return nil, err
+		}
+		after, afterID = &t, cursor.ID
+	}
+
+	events, err := s.activityRepo.List(ctx, filter, after, afterID, time.Now().Add(-activitySettleDelay), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity: %w", err)
+	}
+
+	response := make([]dto.ActivityEventResponse, len(events))
+	for i, event := range events {
+		response[i] = mapActivityEvent(event)
+	}
+	return response, nil
+}
+
+func activityPageSize(req *dto.ActivityEventsRequest) int {
+	if req.Limit <= 0 {
+		return defaultActivityPageSize
+	}
+	return req.Limit
+}
+
+// activityFilter reads the filters of a feed request
+func activityFilter(req *dto.ActivityEventsRequest) (interfaces.ActivityFilter, error) {
+	filter := interfaces.ActivityFilter{
+		EntityType: req.EntityType,
+		From:       req.From,
+		To:         req.To,
+	}
+	if req.From != nil && req.To != nil && !req.From.Before(*req.To) {
+		return filter, errors.New("from must be before to")
+	}
+
+	for _, category := range req.Categories {
+		filter.Categories = append(filter.Categories, models.ActivityCategory(category))
+	}
+	if req.ActorID != "" {
+		actorID, err := uuid.Parse(req.ActorID)
+		if err != nil {
+			return filter, fmt.Errorf("invalid actor_id: %w", err)
+		}
+		filter.ActorID = &actorID
+	}
+	if req.EntityID != "" {
+		entityID, err := uuid.Parse(req.EntityID)
+		if err != nil {
+			return filter, fmt.Errorf("invalid entity_id: %w", err)
+		}
+		filter.EntityID = &entityID
+	}
+	return filter, nil
+}
+
+func mapActivityEvent(event *models.ActivityEvent) dto.ActivityEventResponse {
+	response := dto.ActivityEventResponse{
+		ID:         event.ID,
+		Category:   string(event.Category),
+		Action:     event.Action,
+		ActorID:    event.ActorID,
+		EntityType: event.EntityType,
+		EntityID:   event.EntityID,
+		EntityName: event.EntityName,
+		Details:    json.RawMessage(event.Details),
+		OccurredAt: event.OccurredAt,
+		Cursor:     dto.NewTimeCursor(event.OccurredAt, event.ID).Encode(),
+	}
+	if event.Actor != nil {
+		response.ActorName = event.Actor.GetFullName()
+	}
+	return response
+}
@@ -0,0 +1,341 @@
+// internal/services/agent_assignment_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Default time an agent has to accept a conversation before it moves on
+const defaultAssignmentTimeout = 5 * time.Minute
+
+// AgentAssignmentService routes support conversations to available agents by
+// department, current load and rating. Agents accept or decline each offer;
+// unanswered offers move to the next agent, and admins are alerted once no agent is left.
+type AgentAssignmentService struct {
+	assignmentRepo      interfaces.AgentAssignmentRepository
+	chatRepo            interfaces.ChatRepository
+	userRepo            interfaces.UserRepositoryInterface
+	notificationService *NotificationService
+	activityService     *ActivityService
+	acceptTimeout       time.Duration
+	logger              *slog.Logger
+}
+
+// NewAgentAssignmentService creates a new agent assignment service instance
+func NewAgentAssignmentService(
+	assignmentRepo interfaces.AgentAssignmentRepository,
+	chatRepo interfaces.ChatRepository,
+	userRepo interfaces.UserRepositoryInterface,
+	notificationService *NotificationService,
+	activityService *ActivityService,
+	acceptTimeout time.Duration,
+	logger *slog.Logger,
+) *AgentAssignmentService {
+	if acceptTimeout <= 0 {
+		acceptTimeout = defaultAssignmentTimeout
+	}
+
+	return &AgentAssignmentService{
+		assignmentRepo:      assignmentRepo,
+		chatRepo:            chatRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		activityService:     activityService,
+		acceptTimeout:       acceptTimeout,
+		logger:              logger,
+	}
+}
+
+// AutoAssign offers the conversation to the best available agent. It returns
+// nil when nobody is available, in which case the conversation is escalated.
+func (s *AgentAssignmentService) AutoAssign(ctx context.Context, conversation *models.Conversation) (*models.SupportAgent, error) {
+	agent, err := s.pickAgent(ctx, conversation)
+	if err != nil {
+		return nil, err
+	}
+	if agent == nil {
+		return nil, s.escalate(ctx, conversation)
+	}
+
+	if err := s.offer(ctx, conversation, agent); err != nil {
+		return nil, err
+	}
+	return agent, nil
+}
+
+// AssignManually assigns an agent chosen by an admin, replacing any pending offer
+func (s *AgentAssignmentService) AssignManually(ctx context.Context, conversation *models.Conversation, agentID uuid.UUID) error {
+	if err := s.assignmentRepo.CancelPending(ctx, conversation.ID); err != nil {
+		return fmt.Errorf("failed to cancel pending assignment: %w", err)
+	}
+
+	now := time.Now()
+	assignment := &models.AgentAssignment{
+		ConversationID: conversation.ID,
+		AgentID:        agentID,
+		Status:         models.AssignmentStatusAccepted,
+		OfferedAt:      now,
+		RespondedAt:    &now,
+	}
+	if err := s.assignmentRepo.Create(ctx, assignment); err != nil {
+		return fmt.Errorf("failed to record assignment: %w", err)
+	}
+
+	conversation.AssignedAgentID = &agentID
+	if err := s.chatRepo.UpdateConversation(ctx, conversation); err != nil {
+		return fmt.Errorf("failed to assign agent: %w", err)
+	}
+
+	s.ensureParticipant(ctx, conversation.ID, agentID)
+	return nil
+}
+
+// AcceptAssignment confirms the pending offer made to the agent
+func (s *AgentAssignmentService) AcceptAssignment(ctx context.Context, agentID uuid.UUID, conversationID uuid.UUID) error {
+	assignment, err := s.getPendingFor(ctx, agentID, conversationID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	assignment.Status = models.AssignmentStatusAccepted
+	assignment.RespondedAt = &now
+	if err := s.assignmentRepo.Update(ctx, assignment); err != nil {
+		return fmt.Errorf("failed to accept assignment: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "Agent accepted conversation", "conversationID", conversationID, "agentID", agentID)
+	return nil
+}
+
+// DeclineAssignment rejects the pending offer and routes the conversation to the next agent
+func (s *AgentAssignmentService) DeclineAssignment(ctx context.Context, agentID uuid.UUID, conversationID uuid.UUID) error {
+	assignment, err := s.getPendingFor(ctx, agentID, conversationID)
+	if err != nil {
+		return err
+	}
+
+	return s.release(ctx, assignment, models.AssignmentStatusDeclined)
+}
+
+// ExpirePendingAssignments moves offers nobody answered in time on to the next agent
+func (s *AgentAssignmentService) ExpirePendingAssignments(ctx context.Context) error {
+	assignments, err := s.assignmentRepo.GetExpiredPending(ctx, time.Now().Add(-s.acceptTimeout), 100)
+	if err != nil {
+		return fmt.Errorf("failed to get expired assignments: %w", err)
+	}
+
+	for i := range assignments {
+		if err := s.release(ctx, &assignments[i], models.AssignmentStatusExpired); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to reassign expired conversation",
+				"conversationID", assignments[i].ConversationID,
+				"agentID", assignments[i].AgentID,
+				"error", err)
+		}
+	}
+
+	if len(assignments) > 0 {
+		s.logger.InfoContext(ctx, "Expired unanswered agent assignments", "count", len(assignments))
+	}
+	return nil
+}
+
+// release closes an offer, removes the agent from the conversation and tries the next agent
+func (s *AgentAssignmentService) release(ctx context.Context, assignment *models.AgentAssignment, status models.AssignmentStatus) error {
+	now := time.Now()
+	assignment.Status = status
+	assignment.RespondedAt = &now
+	if err := s.assignmentRepo.Update(ctx, assignment); err != nil {
+		return fmt.Errorf("failed to update assignment: %w", err)
+	}
+
+	conversation, err := s.chatRepo.GetConversationByID(ctx, assignment.ConversationID)
+	if err != nil {
+		return fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	if conversation.AssignedAgentID != nil && *conversation.AssignedAgentID == assignment.AgentID {
+		conversation.AssignedAgentID = nil
+		if err := s.chatRepo.RemoveParticipant(ctx, conversation.ID, assignment.AgentID); err != nil {
+			s.logger.WarnContext(ctx, "Failed to remove agent from conversation", "conversationID", conversation.ID, "agentID", assignment.AgentID, "error", err)
+		}
+	}
+
+	// Resolved conversations no longer need an agent
+	if !conversation.IsActive() {
+		return s.chatRepo.UpdateConversation(ctx, conversation)
+	}
+
+	_, err = s.AutoAssign(ctx, conversation)
+	return err
+}
+
+// pickAgent ranks available agents of the conversation's department (or any
+// department when none is free) by open conversations, then rating
+func (s *AgentAssignmentService) pickAgent(ctx context.Context, conversation *models.Conversation) (*models.SupportAgent, error) {
+	rejected, err := s.assignmentRepo.GetRejectedAgentIDs(ctx, conversation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous assignments: %w", err)
+	}
+	skip := make(map[uuid.UUID]bool, len(rejected))
+	for _, id := range rejected {
+		skip[id] = true
+	}
+
+	departments := []string{conversation.Department}
+	if conversation.Department != "" {
+		departments = append(departments, "")
+	}
+
+	for _, department := range departments {
+		agents, err := s.chatRepo.GetAvailableAgents(ctx, department)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get available agents: %w", err)
+		}
+
+		type candidate struct {
+			agent    *models.SupportAgent
+			workload int64
+		}
+		var candidates []candidate
+		for i := range agents {
+			if skip[agents[i].ID] {
+				continue
+			}
+			workload, err := s.chatRepo.GetAgentWorkload(ctx, agents[i].ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get agent workload: %w", err)
+			}
+			candidates = append(candidates, candidate{agent: &agents[i], workload: workload})
+		}
+
+		if len(candidates) > 0 {
+			// Agents arrive sorted by rating, so a stable sort keeps the best rated first on ties
+			sort.SliceStable(candidates, func(i, j int) bool {
+				return candidates[i].workload < candidates[j].workload
+			})
+			return candidates[0].agent, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// offer assigns the conversation to the agent pending their acceptance
+func (s *AgentAssignmentService) offer(ctx context.Context, conversation *models.Conversation, agent *models.SupportAgent) error {
+	assignment := &models.AgentAssignment{
+		ConversationID: conversation.ID,
+		AgentID:        agent.ID,
+		Status:         models.AssignmentStatusPending,
+		OfferedAt:      time.Now(),
+	}
+	if err := s.assignmentRepo.Create(ctx, assignment); err != nil {
+		return fmt.Errorf("failed to record assignment: %w", err)
+	}
+
+	conversation.AssignedAgentID = &agent.ID
+	if err := s.chatRepo.UpdateConversation(ctx, conversation); err != nil {
+		return fmt.Errorf("failed to assign agent: %w", err)
+	}
+
+	s.ensureParticipant(ctx, conversation.ID, agent.ID)
+
+	title := "New support conversation"
+	if conversation.Title != nil {
+		title = fmt.Sprintf("New support conversation: %s", *conversation.Title)
+	}
+	s.notify(agent.ID, models.NotificationTypeChatAssigned, title,
+		fmt.Sprintf("A customer is waiting for your reply. Accept within %s or the conversation moves to another agent.", s.acceptTimeout),
+		map[string]interface{}{"conversation_id": conversation.ID, "assignment_id": assignment.ID})
+
+	s.logger.InfoContext(ctx, "Offered conversation to agent",
+		"conversationID", conversation.ID,
+		"agentID", agent.ID,
+		"department", conversation.Department)
+	return nil
+}
+
+// escalate alerts admins that no agent took the conversation. Admins are alerted once per conversation.
+func (s *AgentAssignmentService) escalate(ctx context.Context, conversation *models.Conversation) error {
+	alreadyEscalated := conversation.EscalatedAt != nil
+	if !alreadyEscalated {
+		now := time.Now()
+		conversation.EscalatedAt = &now
+	}
+	if err := s.chatRepo.UpdateConversation(ctx, conversation); err != nil {
+		return fmt.Errorf("failed to escalate conversation: %w", err)
+	}
+	if alreadyEscalated {
+		return nil
+	}
+	s.activityService.RecordEscalation(ctx, conversation)
+
+	admins, err := s.userRepo.GetByRole(models.RoleAdmin)
+	if err != nil {
+		return fmt.Errorf("failed to get admins: %w", err)
+	}
+
+	title := "Support conversation needs an agent"
+	if conversation.Title != nil {
+		title = fmt.Sprintf("Support conversation needs an agent: %s", *conversation.Title)
+	}
+	for _, admin := range admins {
+		s.notify(admin.ID, models.NotificationTypeChatEscalated, title,
+			"No available agent accepted this conversation. Please assign one manually.",
+			map[string]interface{}{"conversation_id": conversation.ID})
+	}
+
+	s.logger.WarnContext(ctx, "Escalated unassigned conversation", "conversationID", conversation.ID, "department", conversation.Department)
+	return nil
+}
+
+// Helper functions
+
+func (s *AgentAssignmentService) getPendingFor(ctx context.Context, agentID, conversationID uuid.UUID) (*models.AgentAssignment, error) {
+	assignment, err := s.assignmentRepo.GetPendingByConversation(ctx, conversationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("no pending assignment for this agent")
+		}
+		return nil, fmt.Errorf("failed to get assignment: %w", err)
+	}
+	if assignment.AgentID != agentID {
+		return nil, errors.New("no pending assignment for this agent")
+	}
+	return assignment, nil
+}
+
+func (s *AgentAssignmentService) ensureParticipant(ctx context.Context, conversationID, agentID uuid.UUID) {
+	isParticipant, err := s.chatRepo.IsUserParticipant(ctx, agentID, conversationID)
+	if err == nil && !isParticipant {
+		err = s.chatRepo.AddParticipant(ctx, &models.ConversationParticipant{
+			ConversationID: conversationID,
+			UserID:         agentID,
+			UserType:       models.ParticipantTypeAgent,
+		})
+	}
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to add agent to conversation", "conversationID", conversationID, "agentID", agentID, "error", err)
+	}
+}
+
+func (s *AgentAssignmentService) notify(userID uuid.UUID, notificationType models.NotificationType, title, message string, data map[string]interface{}) {
+	if s.notificationService == nil {
+		return
+	}
+
+	if _, err := s.notificationService.Notify(userID, notificationType, title, message, data); err != nil {
+		s.logger.Warn("Failed to send assignment notification", "userID", userID, "type", notificationType, "error", err)
+	}
+}
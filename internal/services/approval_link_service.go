@@ -0,0 +1,305 @@
+// internal/services/approval_link_service.go
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// Approval links expire after this long, or when the reservation starts if sooner
+	approvalLinkTTL = 72 * time.Hour
+	// Expired approval links are kept this long before being pruned
+	approvalLinkRetention = 30 * 24 * time.Hour
+)
+
+// errInvalidApprovalLink hides why a link is refused, so links cannot be probed
+var errInvalidApprovalLink = errors.New("invalid or expired approval link")
+
+// ApprovalLinkService emails approvers signed approve and reject links for pending reservations,
+// so they can decide without logging in. A link only shows the decision it makes; the decision
+// is taken by posting to it, so mail scanners opening links do not decide for the approver.
+type ApprovalLinkService struct {
+	tokenRepo           interfaces.ApprovalActionTokenRepositoryInterface
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	userRepo            interfaces.UserRepositoryInterface
+	reservationService  *ReservationService
+	notificationService *NotificationService
+	secret              []byte
+	baseURL             string
+	logger              *slog.Logger
+}
+
+// NewApprovalLinkService creates a new approval link service; links are signed with the secret
+func NewApprovalLinkService(
+	tokenRepo interfaces.ApprovalActionTokenRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	reservationService *ReservationService,
+	notificationService *NotificationService,
+	secret string,
+	baseURL string,
+	logger *slog.Logger,
+) *ApprovalLinkService {
+	return &ApprovalLinkService{
+		tokenRepo:           tokenRepo,
+		reservationRepo:     reservationRepo,
+		userRepo:            userRepo,
+		reservationService:  reservationService,
+		notificationService: notificationService,
+		secret:              []byte(secret),
+		baseURL:             baseURL,
+		logger:              logger,
+	}
+}
+
+// NotifyApprovers sends the approvers of the current step of a pending reservation an approval
+// request with its links. Approvers already sent links for the reservation are skipped, so a
+// redispatched event or a later update does not email them twice. It handles reservation_created
+// and reservation_updated events of the outbox.
+func (s *ApprovalLinkService) NotifyApprovers(ctx context.Context, event *models.OutboxEvent) error {
+	var change models.ReservationChange
+	if err := json.Unmarshal(event.Payload, &change); err != nil {
+		return fmt.Errorf("failed to decode reservation change: %w", err)
+	}
+	if models.ReservationStatus(change.Status) != models.StatusPending {
+		return nil
+	}
+
+	reservation, err := s.reservationRepo.GetByID(change.ReservationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if reservation.Status != models.StatusPending || !reservation.StartTime.After(time.Now()) {
+		return nil
+	}
+
+	approvers, err := s.currentApprovers(reservation)
+	if err != nil {
+		return err
+	}
+	for _, approverID := range approvers {
+		sent, err := s.tokenRepo.HasTokens(reservation.ID, approverID)
+		if err != nil {
+			return fmt.Errorf("failed to check approval links: %w", err)
+		}
+		if sent {
+			continue
+		}
+		if err := s.sendLinks(reservation, approverID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAction shows the decision a link makes, without making it
+func (s *ApprovalLinkService) GetAction(signed string) (*dto.ApprovalActionResponse, error) {
+	token, err := s.resolve(signed)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := s.reservationRepo.GetByID(token.ReservationID)
+	if err != nil {
+		return nil, errInvalidApprovalLink
+	}
+	return toApprovalActionResponse(token, reservation), nil
+}
+
+// Act makes the decision of a link as its approver. The link is spent even when the decision
+// is refused, e.g. because another approver decided first.
+func (s *ApprovalLinkService) Act(signed string, req *dto.ApprovalActionRequest, ip string) (*dto.ApprovalActionResponse, error) {
+	token, err := s.resolve(signed)
+	if err != nil {
+		return nil, err
+	}
+	if !token.IsUsable(time.Now()) {
+		return nil, errInvalidApprovalLink
+	}
+
+	comments := strings.TrimSpace(req.Comments)
+	if token.Action == models.ApprovalActionReject && comments == "" {
+		return nil, errors.New("rejection reason is required")
+	}
+
+	if err := s.tokenRepo.Use(token, comments, ip, time.Now()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errInvalidApprovalLink
+		}
+		return nil, fmt.Errorf("failed to use approval link: %w", err)
+	}
+
+	if token.Action == models.ApprovalActionReject {
+		err = s.reservationService.RejectReservation(token.ReservationID, token.ApproverID, comments)
+	} else {
+		err = s.reservationService.ApproveReservation(token.ReservationID, token.ApproverID, comments)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := s.tokenRepo.GetByID(token.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval link: %w", err)
+	}
+	reservation, err := s.reservationRepo.GetByID(token.ReservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	return toApprovalActionResponse(used, reservation), nil
+}
+
+// PruneExpired deletes approval links expired for longer than the retention
+func (s *ApprovalLinkService) PruneExpired(ctx context.Context) error {
+	deleted, err := s.tokenRepo.DeleteExpired(time.Now().Add(-approvalLinkRetention))
+	if err != nil {
+		return fmt.Errorf("failed to prune approval links: %w", err)
+	}
+	if deleted > 0 {
+		s.logger.Info("Pruned expired approval links", "count", deleted)
+	}
+	return nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// currentApprovers lists who may decide the reservation at its current step: the space
+// manager, or every admin for spaces without one, then for a second approval every admin
+// but the first approver
+func (s *ApprovalLinkService) currentApprovers(reservation *models.Reservation) ([]uuid.UUID, error) {
+	if !reservation.AwaitsSecondApproval() && reservation.Space.ManagerID != nil {
+		return []uuid.UUID{*reservation.Space.ManagerID}, nil
+	}
+
+	admins, err := s.userRepo.GetByRole(models.RoleAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admins: %w", err)
+	}
+	var approvers []uuid.UUID
+	for _, admin := range admins {
+		if !admin.IsActive || (reservation.FirstApproverID != nil && *reservation.FirstApproverID == admin.ID) {
+			continue
+		}
+		approvers = append(approvers, admin.ID)
+	}
+	return approvers, nil
+}
+
+// sendLinks creates an approver's links for the reservation and notifies them
+func (s *ApprovalLinkService) sendLinks(reservation *models.Reservation, approverID uuid.UUID) error {
+	expiresAt := time.Now().Add(approvalLinkTTL)
+	if reservation.StartTime.Before(expiresAt) {
+		expiresAt = reservation.StartTime
+	}
+
+	approve := &models.ApprovalActionToken{ID: uuid.New(), ReservationID: reservation.ID, ApproverID: approverID, Action: models.ApprovalActionApprove, ExpiresAt: expiresAt}
+	reject := &models.ApprovalActionToken{ID: uuid.New(), ReservationID: reservation.ID, ApproverID: approverID, Action: models.ApprovalActionReject, ExpiresAt: expiresAt}
+	if err := s.tokenRepo.CreateBatch([]*models.ApprovalActionToken{approve, reject}); err != nil {
+		return fmt.Errorf("failed to create approval links: %w", err)
+	}
+
+	requester := "Someone"
+	if reservation.User.ID != uuid.Nil {
+		requester = reservation.User.GetFullName()
+	}
+	title := fmt.Sprintf("Approval needed: %s", reservation.Title)
+	message := fmt.Sprintf("%s asks to book %s on %s, %s to %s, for %d participant(s). Open a link below to approve or reject without signing in; rejecting asks for a reason. The links work once and expire on %s.",
+		requester, reservation.Space.Name,
+		reservation.StartTime.Format("Mon Jan 2"), reservation.StartTime.Format("15:04"), reservation.EndTime.Format("15:04"),
+		reservation.ParticipantCount, expiresAt.Format("Mon Jan 2 15:04"))
+	data := map[string]interface{}{
+		"reservation_id": reservation.ID,
+		"space_id":       reservation.SpaceID,
+		"links": map[string]string{
+			"approve": s.linkURL(approve),
+			"reject":  s.linkURL(reject),
+		},
+	}
+	if _, err := s.notificationService.Notify(approverID, models.NotificationTypeApprovalRequested, title, message, data); err != nil {
+		return fmt.Errorf("failed to notify approver: %w", err)
+	}
+	return nil
+}
+
+// linkURL returns the email link of a token
+func (s *ApprovalLinkService) linkURL(token *models.ApprovalActionToken) string {
+	return fmt.Sprintf("%s/api/v1/approval-actions/%s", s.baseURL, s.sign(token.ID, token.Action))
+}
+
+// sign joins a token ID and the signature of it with its action
+func (s *ApprovalLinkService) sign(id uuid.UUID, action models.ApprovalAction) string {
+	return id.String() + "." + hex.EncodeToString(s.mac(id, action))
+}
+
+// mac computes the signature of a token ID with its action
+func (s *ApprovalLinkService) mac(id uuid.UUID, action models.ApprovalAction) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("approval-action:" + id.String() + ":" + string(action)))
+	return mac.Sum(nil)
+}
+
+// resolve checks the signature of a link and loads its token. Forged links are refused
+// before the database is queried.
+func (s *ApprovalLinkService) resolve(signed string) (*models.ApprovalActionToken, error) {
+	rawID, rawMAC, ok := strings.Cut(signed, ".")
+	if !ok {
+		return nil, errInvalidApprovalLink
+	}
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, errInvalidApprovalLink
+	}
+	signature, err := hex.DecodeString(rawMAC)
+	if err != nil {
+		return nil, errInvalidApprovalLink
+	}
+	if !hmac.Equal(signature, s.mac(id, models.ApprovalActionApprove)) && !hmac.Equal(signature, s.mac(id, models.ApprovalActionReject)) {
+		return nil, errInvalidApprovalLink
+	}
+
+	token, err := s.tokenRepo.GetByID(id)
+	if err != nil {
+		return nil, errInvalidApprovalLink
+	}
+	if !hmac.Equal(signature, s.mac(id, token.Action)) {
+		return nil, errInvalidApprovalLink
+	}
+	return token, nil
+}
+
+// toApprovalActionResponse converts a link and its reservation to the response
+func toApprovalActionResponse(token *models.ApprovalActionToken, reservation *models.Reservation) *dto.ApprovalActionResponse {
+	return &dto.ApprovalActionResponse{
+		Action:        string(token.Action),
+		ReservationID: reservation.ID,
+		Title:         reservation.Title,
+		SpaceName:     reservation.Space.Name,
+		RequestedBy:   reservation.User.GetFullName(),
+		StartTime:     reservation.StartTime,
+		EndTime:       reservation.EndTime,
+		Status:        string(reservation.Status),
+		ExpiresAt:     token.ExpiresAt,
+		UsedAt:        token.UsedAt,
+	}
+}
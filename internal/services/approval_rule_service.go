@@ -0,0 +1,96 @@
+// internal/services/approval_rule_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// ApprovalRuleService manages the per-space rules that decide which bookings of
+// approval spaces are confirmed straight away and which need one or two approvals
+type ApprovalRuleService struct {
+	ruleRepo  interfaces.ApprovalRuleRepositoryInterface
+	spaceRepo interfaces.SpaceRepositoryInterface
+}
+
+// NewApprovalRuleService creates a new approval rule service
+func NewApprovalRuleService(ruleRepo interfaces.ApprovalRuleRepositoryInterface, spaceRepo interfaces.SpaceRepositoryInterface) *ApprovalRuleService {
+	return &ApprovalRuleService{
+		ruleRepo:  ruleRepo,
+		spaceRepo: spaceRepo,
+	}
+}
+
+// GetRules lists the approval rules of all spaces
+func (s *ApprovalRuleService) GetRules() ([]*models.ApprovalRule, error) {
+	rules, err := s.ruleRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetRule returns the approval rule of a space
+func (s *ApprovalRuleService) GetRule(spaceID uuid.UUID) (*models.ApprovalRule, error) {
+	rule, err := s.ruleRepo.GetBySpace(spaceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get approval rule: %w", err)
+	}
+	return rule, nil
+}
+
+// SetRule creates or replaces the approval rule of a space. Pending bookings keep
+// the number of approvals they were created with.
+func (s *ApprovalRuleService) SetRule(spaceID uuid.UUID, req *dto.SetApprovalRuleRequest, userID uuid.UUID) (*models.ApprovalRule, error) {
+	space, err := s.spaceRepo.GetByID(spaceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+	if !space.RequiresApproval {
+		return nil, errors.New("space does not require approval, so approval rules do not apply")
+	}
+
+	twoStep := space.Type == models.SpaceTypeAuditorium
+	if req.TwoStep != nil {
+		twoStep = *req.TwoStep
+	}
+
+	rule, err := s.ruleRepo.Save(&models.ApprovalRule{
+		SpaceID:                    spaceID,
+		AutoApproveMaxMinutes:      req.AutoApproveMaxMinutes,
+		AutoApproveMaxParticipants: req.AutoApproveMaxParticipants,
+		AutoApproveMinTenureDays:   req.AutoApproveMinTenureDays,
+		AutoApproveRoles:           pq.StringArray(req.AutoApproveRoles),
+		TwoStep:                    twoStep,
+		UpdatedByID:                userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save approval rule: %w", err)
+	}
+	return rule, nil
+}
+
+// DeleteRule makes every booking of the space need a single approval again
+func (s *ApprovalRuleService) DeleteRule(spaceID uuid.UUID) error {
+	if err := s.ruleRepo.Delete(spaceID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete approval rule: %w", err)
+	}
+	return nil
+}
@@ -272,6 +272,14 @@ func (s *AuthService) UpdateUserRole(userID uuid.UUID, role models.UserRole) err
 	return nil
 }
 
+// UpdateUserPriority updates the booking priority tier of a user (admin only)
+func (s *AuthService) UpdateUserPriority(userID uuid.UUID, tier int) error {
+	if err := s.userRepo.UpdatePriorityTier(userID, tier); err != nil {
+		return fmt.Errorf("failed to update user priority: %w", err)
+	}
+	return nil
+}
+
 // DeleteUser soft deletes a user
 func (s *AuthService) DeleteUser(userID uuid.UUID) error {
 	if err := s.userRepo.Delete(userID); err != nil {
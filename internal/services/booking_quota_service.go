@@ -0,0 +1,312 @@
+// internal/services/booking_quota_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// BookingQuotaService enforces the hours users can book per week and the premium-room
+// hours departments (teams) can book per month. Quotas are checked when reservations
+// are created, against the week or month the booking starts in (UTC); admins are not
+// subject to them and can override the configured limits per user or team.
+type BookingQuotaService struct {
+	quotaRepo        interfaces.BookingQuotaRepositoryInterface
+	userRepo         interfaces.UserRepositoryInterface
+	userWeeklyHours  int // 0 disables the user quota
+	teamPremiumHours int // 0 disables the team quota
+}
+
+// NewBookingQuotaService creates a new booking quota service
+func NewBookingQuotaService(
+	quotaRepo interfaces.BookingQuotaRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	userWeeklyHours int,
+	teamPremiumHours int,
+) *BookingQuotaService {
+	return &BookingQuotaService{
+		quotaRepo:        quotaRepo,
+		userRepo:         userRepo,
+		userWeeklyHours:  userWeeklyHours,
+		teamPremiumHours: teamPremiumHours,
+	}
+}
+
+// quotaLimit is the limit in force for a user or team
+type quotaLimit struct {
+	hours    int // 0 when no quota applies
+	override *models.QuotaOverride
+}
+
+// ========================================
+// ENFORCEMENT
+// ========================================
+
+// CheckBooking checks the user can book the space over the time range without going past
+// their weekly quota or their team's premium-room quota. planned holds reservations of
+// the same booking not saved yet, such as the earlier occurrences of a recurring series.
+func (s *BookingQuotaService) CheckBooking(userID uuid.UUID, space *models.Space, start, end time.Time, planned []*models.Reservation) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsAdmin() {
+		return nil
+	}
+
+	now := time.Now()
+	minutes := end.Sub(start).Minutes()
+
+	limit, err := s.userLimit(user.ID, now)
+	if err != nil {
+		return err
+	}
+	if limit.hours > 0 {
+		weekStart, weekEnd := quotaWeek(start)
+		used, err := s.quotaRepo.SumUserBookedMinutes(user.ID, weekStart, weekEnd)
+		if err != nil {
+			return fmt.Errorf("failed to get booked hours: %w", err)
+		}
+		used += plannedMinutes(planned, weekStart, weekEnd)
+		if used+minutes > float64(limit.hours*60) {
+			return dto.NewUserQuotaExceededError(limit.hours, remainingHours(limit.hours, used))
+		}
+	}
+
+	if !space.IsPremium || user.Department == "" {
+		return nil
+	}
+	limit, err = s.teamLimit(user.Department, now)
+	if err != nil {
+		return err
+	}
+	if limit.hours > 0 {
+		monthStart, monthEnd := quotaMonth(start)
+		used, err := s.quotaRepo.SumTeamPremiumMinutes(user.Department, monthStart, monthEnd)
+		if err != nil {
+			return fmt.Errorf("failed to get premium-room hours: %w", err)
+		}
+		used += plannedMinutes(planned, monthStart, monthEnd)
+		if used+minutes > float64(limit.hours*60) {
+			return dto.NewTeamQuotaExceededError(user.Department, limit.hours, remainingHours(limit.hours, used))
+		}
+	}
+
+	return nil
+}
+
+// ========================================
+// USAGE
+// ========================================
+
+// GetUsage reports how much of their quotas a user has used in the week and month of the given time
+func (s *BookingQuotaService) GetUsage(userID uuid.UUID, at time.Time) (*dto.QuotaUsageResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	now := time.Now()
+	response := &dto.QuotaUsageResponse{UserID: user.ID}
+
+	limit, err := s.userLimit(user.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	weekStart, weekEnd := quotaWeek(at)
+	used, err := s.quotaRepo.SumUserBookedMinutes(user.ID, weekStart, weekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booked hours: %w", err)
+	}
+	response.Weekly = quotaUsage(limit, used, weekStart, weekEnd, user.IsAdmin())
+
+	if user.Department != "" {
+		limit, err := s.teamLimit(user.Department, now)
+		if err != nil {
+			return nil, err
+		}
+		monthStart, monthEnd := quotaMonth(at)
+		used, err := s.quotaRepo.SumTeamPremiumMinutes(user.Department, monthStart, monthEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get premium-room hours: %w", err)
+		}
+		response.TeamPremium = quotaUsage(limit, used, monthStart, monthEnd, user.IsAdmin())
+		response.TeamPremium.Department = user.Department
+	}
+
+	return response, nil
+}
+
+// ========================================
+// ADMIN OVERRIDES
+// ========================================
+
+// GetOverrides lists the quota overrides of every user and team, expired ones included
+func (s *BookingQuotaService) GetOverrides() ([]*models.QuotaOverride, error) {
+	overrides, err := s.quotaRepo.GetOverrides()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quota overrides: %w", err)
+	}
+	return overrides, nil
+}
+
+// SetUserOverride replaces the weekly quota of a user
+func (s *BookingQuotaService) SetUserOverride(userID uuid.UUID, req *dto.SetQuotaOverrideRequest, adminID uuid.UUID) (*models.QuotaOverride, error) {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := validateOverrideExpiry(req); err != nil {
+		return nil, err
+	}
+
+	override, err := s.quotaRepo.SaveOverride(&models.QuotaOverride{
+		Scope:       models.QuotaScopeUser,
+		UserID:      &userID,
+		LimitHours:  req.LimitHours,
+		Reason:      req.Reason,
+		ExpiresAt:   req.ExpiresAt,
+		UpdatedByID: adminID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save quota override: %w", err)
+	}
+	return override, nil
+}
+
+// SetTeamOverride replaces the monthly premium-room quota of a department
+func (s *BookingQuotaService) SetTeamOverride(department string, req *dto.SetQuotaOverrideRequest, adminID uuid.UUID) (*models.QuotaOverride, error) {
+	department = strings.TrimSpace(department)
+	if department == "" {
+		return nil, errors.New("department is required")
+	}
+	if err := validateOverrideExpiry(req); err != nil {
+		return nil, err
+	}
+
+	override, err := s.quotaRepo.SaveOverride(&models.QuotaOverride{
+		Scope:       models.QuotaScopeTeam,
+		Department:  &department,
+		LimitHours:  req.LimitHours,
+		Reason:      req.Reason,
+		ExpiresAt:   req.ExpiresAt,
+		UpdatedByID: adminID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save quota override: %w", err)
+	}
+	return override, nil
+}
+
+// DeleteOverride puts the configured quota back for the override's user or team
+func (s *BookingQuotaService) DeleteOverride(id uuid.UUID) error {
+	if err := s.quotaRepo.DeleteOverride(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete quota override: %w", err)
+	}
+	return nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// userLimit returns the weekly hours of a user, from their override if one applies
+func (s *BookingQuotaService) userLimit(userID uuid.UUID, now time.Time) (quotaLimit, error) {
+	override, err := s.quotaRepo.GetUserOverride(userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return quotaLimit{}, fmt.Errorf("failed to get quota override: %w", err)
+	}
+	if override != nil && override.AppliesAt(now) {
+		return quotaLimit{hours: override.LimitHours, override: override}, nil
+	}
+	return quotaLimit{hours: s.userWeeklyHours}, nil
+}
+
+// teamLimit returns the monthly premium-room hours of a department, from its override if one applies
+func (s *BookingQuotaService) teamLimit(department string, now time.Time) (quotaLimit, error) {
+	override, err := s.quotaRepo.GetTeamOverride(department)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return quotaLimit{}, fmt.Errorf("failed to get quota override: %w", err)
+	}
+	if override != nil && override.AppliesAt(now) {
+		return quotaLimit{hours: override.LimitHours, override: override}, nil
+	}
+	return quotaLimit{hours: s.teamPremiumHours}, nil
+}
+
+// validateOverrideExpiry rejects overrides that would already be expired
+func validateOverrideExpiry(req *dto.SetQuotaOverrideRequest) error {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return errors.New("expires_at must be in the future")
+	}
+	return nil
+}
+
+// quotaUsage describes a quota over its period; exempt users and disabled quotas have no limit
+func quotaUsage(limit quotaLimit, usedMinutes float64, periodStart, periodEnd time.Time, exempt bool) *dto.QuotaUsage {
+	usage := &dto.QuotaUsage{
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		UsedHours:   roundCents(usedMinutes / 60),
+		Overridden:  limit.override != nil,
+	}
+	if limit.override != nil {
+		usage.OverrideExpiresAt = limit.override.ExpiresAt
+	}
+	if limit.hours > 0 && !exempt {
+		hours := limit.hours
+		remaining := remainingHours(limit.hours, usedMinutes)
+		usage.LimitHours = &hours
+		usage.RemainingHours = &remaining
+	}
+	return usage
+}
+
+// remainingHours returns the hours left under a limit, never below zero
+func remainingHours(limitHours int, usedMinutes float64) float64 {
+	return roundCents(math.Max(0, float64(limitHours)-usedMinutes/60))
+}
+
+// plannedMinutes sums the minutes of planned reservations starting within the period
+func plannedMinutes(planned []*models.Reservation, periodStart, periodEnd time.Time) float64 {
+	var minutes float64
+	for _, reservation := range planned {
+		if !reservation.StartTime.Before(periodStart) && reservation.StartTime.Before(periodEnd) {
+			minutes += reservation.Duration().Minutes()
+		}
+	}
+	return minutes
+}
+
+// quotaWeek returns the week, Monday to Monday in UTC, containing the time
+func quotaWeek(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	start := time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 0, 7)
+}
+
+// quotaMonth returns the calendar month in UTC containing the time
+func quotaMonth(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
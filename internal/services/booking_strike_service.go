@@ -0,0 +1,264 @@
+// internal/services/booking_strike_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// Upper bound of reservations checked for no-shows per run
+const noShowBatchSize = 200
+
+// How far back the no-show job looks for reservations that were never checked in
+const noShowLookback = 24 * time.Hour
+
+// BookingStrikeService tracks strikes users earn by cancelling within a space's cancellation
+// window or not showing up. Users with too many strikes within the window are kept from
+// booking premium spaces for a while after their latest strike; admins are not subject to
+// it and can clear strikes.
+type BookingStrikeService struct {
+	strikeRepo      interfaces.BookingStrikeRepositoryInterface
+	reservationRepo interfaces.ReservationRepositoryInterface
+	userRepo        interfaces.UserRepositoryInterface
+	limit           int           // Strikes that restrict premium bookings, 0 disables restrictions
+	window          time.Duration // How long strikes count
+	restriction     time.Duration // How long a restriction lasts after the latest strike
+	noShowGrace     time.Duration // Time after the start without check-in that makes a no-show, 0 disables detection
+	logger          *slog.Logger
+}
+
+// NewBookingStrikeService creates a new booking strike service
+func NewBookingStrikeService(
+	strikeRepo interfaces.BookingStrikeRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	limit int,
+	windowDays int,
+	restrictionDays int,
+	noShowGrace time.Duration,
+	logger *slog.Logger,
+) *BookingStrikeService {
+	return &BookingStrikeService{
+		strikeRepo:      strikeRepo,
+		reservationRepo: reservationRepo,
+		userRepo:        userRepo,
+		limit:           limit,
+		window:          time.Duration(windowDays) * 24 * time.Hour,
+		restriction:     time.Duration(restrictionDays) * 24 * time.Hour,
+		noShowGrace:     noShowGrace,
+		logger:          logger,
+	}
+}
+
+// ========================================
+// RECORDING STRIKES
+// ========================================
+
+// RecordLateCancellation gives the booker a strike when the reservation was cancelled
+// within its space's cancellation window
+func (s *BookingStrikeService) RecordLateCancellation(reservation *models.Reservation, cancelledAt time.Time) error {
+	window := time.Duration(reservation.Space.CancellationWindow) * time.Minute
+	if window <= 0 || reservation.StartTime.Sub(cancelledAt) >= window {
+		return nil
+	}
+
+	minutes := int(reservation.StartTime.Sub(cancelledAt).Minutes())
+	if minutes < 0 {
+		minutes = 0
+	}
+	return s.record(reservation, models.StrikeReasonLateCancellation,
+		fmt.Sprintf("Cancelled %d minutes before the start, within the %d-minute window", minutes, reservation.Space.CancellationWindow))
+}
+
+// RecordNoShow gives the booker a strike for a reservation they never checked in to
+func (s *BookingStrikeService) RecordNoShow(reservation *models.Reservation, note string) error {
+	return s.record(reservation, models.StrikeReasonNoShow, note)
+}
+
+// DetectNoShows reports confirmed reservations not checked in within the grace period
+// after their start as no-shows. It runs as a scheduled job.
+func (s *BookingStrikeService) DetectNoShows(ctx context.Context) error {
+	if s.noShowGrace <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	reservations, err := s.strikeRepo.GetMissedCheckIns(now.Add(-noShowLookback), now.Add(-s.noShowGrace), noShowBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get missed check-ins: %w", err)
+	}
+
+	detected := 0
+	for _, reservation := range reservations {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if _, err := s.reservationRepo.Update(reservation.ID, map[string]interface{}{"no_show_reported": true}); err != nil {
+			s.logger.Error("Failed to report no-show", "reservationID", reservation.ID, "error", err)
+			continue
+		}
+		note := fmt.Sprintf("Not checked in %d minutes after the start", int(s.noShowGrace.Minutes()))
+		if err := s.RecordNoShow(reservation, note); err != nil {
+			s.logger.Error("Failed to record no-show strike", "reservationID", reservation.ID, "error", err)
+			continue
+		}
+		detected++
+	}
+
+	if detected > 0 {
+		s.logger.Info("No-shows detected", "count", detected)
+	}
+	return nil
+}
+
+// ========================================
+// ENFORCEMENT
+// ========================================
+
+// CheckBooking rejects a premium-space booking by a user restricted for too many strikes
+func (s *BookingStrikeService) CheckBooking(userID uuid.UUID, space *models.Space) error {
+	if !space.IsPremium || s.limit <= 0 {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsAdmin() {
+		return nil
+	}
+
+	strikes, until, err := s.restrictedUntil(userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if until != nil {
+		return dto.NewStrikeRestrictedError(len(strikes), until.UTC().Format("2006-01-02"))
+	}
+	return nil
+}
+
+// ========================================
+// STRIKE QUERIES
+// ========================================
+
+// GetSummary reports a user's active strikes and whether they are restricted
+func (s *BookingStrikeService) GetSummary(userID uuid.UUID) (*dto.StrikeSummaryResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	strikes, until, err := s.restrictedUntil(user.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if user.IsAdmin() {
+		until = nil
+	}
+
+	return &dto.StrikeSummaryResponse{
+		UserID:          user.ID,
+		ActiveStrikes:   len(strikes),
+		Limit:           s.limit,
+		WindowDays:      int(s.window.Hours() / 24),
+		Restricted:      until != nil,
+		RestrictedUntil: until,
+		Strikes:         strikes,
+	}, nil
+}
+
+// GetActiveStrikes lists the strikes of every user still counting
+func (s *BookingStrikeService) GetActiveStrikes(offset, limit int) ([]*models.BookingStrike, int64, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	strikes, total, err := s.strikeRepo.GetActive(time.Now().Add(-s.window), offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get strikes: %w", err)
+	}
+	return strikes, total, nil
+}
+
+// ========================================
+// ADMIN OPERATIONS
+// ========================================
+
+// ClearStrike stops a strike from counting against its user
+func (s *BookingStrikeService) ClearStrike(id, adminID uuid.UUID) error {
+	if err := s.strikeRepo.Clear(id, adminID, time.Now()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to clear strike: %w", err)
+	}
+	return nil
+}
+
+// ClearUserStrikes stops every strike of a user from counting, lifting any restriction
+func (s *BookingStrikeService) ClearUserStrikes(userID, adminID uuid.UUID) (int64, error) {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	cleared, err := s.strikeRepo.ClearByUser(userID, adminID, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear strikes: %w", err)
+	}
+	return cleared, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// record saves a strike against the reservation's booker
+func (s *BookingStrikeService) record(reservation *models.Reservation, reason models.StrikeReason, note string) error {
+	err := s.strikeRepo.Create(&models.BookingStrike{
+		UserID:        reservation.UserID,
+		ReservationID: reservation.ID,
+		Reason:        reason,
+		Note:          note,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record strike: %w", err)
+	}
+	return nil
+}
+
+// restrictedUntil returns the user's active strikes and, when they reach the limit, the end
+// of the restriction that follows the latest one; nil once it has ended
+func (s *BookingStrikeService) restrictedUntil(userID uuid.UUID, now time.Time) ([]*models.BookingStrike, *time.Time, error) {
+	strikes, err := s.strikeRepo.GetActiveByUser(userID, now.Add(-s.window))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get strikes: %w", err)
+	}
+
+	if s.limit <= 0 || len(strikes) < s.limit {
+		return strikes, nil, nil
+	}
+	until := strikes[0].CreatedAt.Add(s.restriction)
+	if !now.Before(until) {
+		return strikes, nil, nil
+	}
+	return strikes, &until, nil
+}
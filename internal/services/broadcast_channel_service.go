@@ -0,0 +1,393 @@
+// internal/services/broadcast_channel_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// People who booked in a building this recently get the posts of its channel
+const broadcastAudienceLookback = 90 * 24 * time.Hour
+
+// BroadcastChannelService runs read-only announcement channels: admins post to any channel and
+// managers to the channels of buildings where they manage a space. Each post is delivered to its
+// audience as a notification and kept in their inbox, with who read it.
+type BroadcastChannelService struct {
+	channelRepo         interfaces.BroadcastChannelRepositoryInterface
+	userRepo            interfaces.UserRepositoryInterface
+	spaceRepo           interfaces.SpaceRepositoryInterface
+	notificationService *NotificationService
+	logger              *slog.Logger
+}
+
+// NewBroadcastChannelService creates a new broadcast channel service
+func NewBroadcastChannelService(
+	channelRepo interfaces.BroadcastChannelRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	notificationService *NotificationService,
+	logger *slog.Logger,
+) *BroadcastChannelService {
+	return &BroadcastChannelService{
+		channelRepo:         channelRepo,
+		userRepo:            userRepo,
+		spaceRepo:           spaceRepo,
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// ========================================
+// CHANNELS
+// ========================================
+
+// CreateChannel creates an announcement channel, for a building or every user
+func (s *BroadcastChannelService) CreateChannel(req *dto.CreateBroadcastChannelRequest, userID uuid.UUID) (*dto.BroadcastChannelResponse, error) {
+	building := strings.TrimSpace(req.Building)
+	if building != "" {
+		count, err := s.spaceRepo.CountSpacesByBuilding(building)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check building: %w", err)
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("unknown building %q", building)
+		}
+	}
+	if err := s.ensureNameFree(req.Name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	channel := &models.BroadcastChannel{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Building:    building,
+		CreatedByID: userID,
+	}
+	if err := s.channelRepo.CreateChannel(channel); err != nil {
+		return nil, fmt.Errorf("failed to create broadcast channel: %w", err)
+	}
+	return toBroadcastChannelResponse(channel), nil
+}
+
+// UpdateChannel renames, describes, archives or restores an announcement channel
+func (s *BroadcastChannelService) UpdateChannel(id uuid.UUID, req *dto.UpdateBroadcastChannelRequest) (*dto.BroadcastChannelResponse, error) {
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		if err := s.ensureNameFree(*req.Name, id); err != nil {
+			return nil, err
+		}
+		updates["name"] = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Archived != nil {
+		if *req.Archived {
+			updates["archived_at"] = time.Now()
+		} else {
+			updates["archived_at"] = nil
+		}
+	}
+	if len(updates) == 0 {
+		return nil, errors.New("no changes given")
+	}
+
+	channel, err := s.channelRepo.UpdateChannel(id, updates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update broadcast channel: %w", err)
+	}
+	return toBroadcastChannelResponse(channel), nil
+}
+
+// GetChannels lists the channels the user can post to, archived ones only when asked
+func (s *BroadcastChannelService) GetChannels(userID uuid.UUID, includeArchived bool) ([]*dto.BroadcastChannelResponse, error) {
+	channels, err := s.channelRepo.ListChannels(includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broadcast channels: %w", err)
+	}
+
+	responses := make([]*dto.BroadcastChannelResponse, 0, len(channels))
+	for _, channel := range channels {
+		canPost, err := s.canPost(channel, userID)
+		if err != nil {
+			return nil, err
+		}
+		if canPost {
+			responses = append(responses, toBroadcastChannelResponse(channel))
+		}
+	}
+	return responses, nil
+}
+
+// ========================================
+// POSTS
+// ========================================
+
+// Post delivers an announcement to the audience of a channel: every active user, or the people
+// who booked in the channel's building in the last 90 days or later, and its space managers
+func (s *BroadcastChannelService) Post(channelID uuid.UUID, req *dto.CreateBroadcastPostRequest, userID uuid.UUID) (*dto.BroadcastPostResponse, error) {
+	channel, err := s.getPublishableChannel(channelID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if channel.IsArchived() {
+		return nil, errors.New("broadcast channel is archived")
+	}
+
+	kind := models.BroadcastKindAnnouncement
+	if req.Kind != "" {
+		kind = models.BroadcastKind(req.Kind)
+	}
+	if !kind.IsValid() {
+		return nil, fmt.Errorf("invalid kind %q", req.Kind)
+	}
+
+	var audience []uuid.UUID
+	if channel.Building == "" {
+		audience, err = s.channelRepo.GetActiveUserIDs()
+	} else {
+		audience, err = s.channelRepo.GetBuildingUserIDs(channel.Building, time.Now().Add(-broadcastAudienceLookback))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel audience: %w", err)
+	}
+	recipientIDs := make([]uuid.UUID, 0, len(audience))
+	for _, id := range audience {
+		if id != userID {
+			recipientIDs = append(recipientIDs, id)
+		}
+	}
+
+	post, err := s.channelRepo.CreatePost(&models.BroadcastPost{
+		ChannelID:      channel.ID,
+		AuthorID:       userID,
+		Kind:           kind,
+		Title:          req.Title,
+		Body:           req.Body,
+		RecipientCount: len(recipientIDs),
+	}, recipientIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create broadcast post: %w", err)
+	}
+
+	title := fmt.Sprintf("%s: %s", channel.Name, post.Title)
+	data := map[string]interface{}{
+		"broadcast_post_id": post.ID,
+		"channel_id":        channel.ID,
+		"kind":              post.Kind,
+	}
+	if channel.Building != "" {
+		data["building"] = channel.Building
+	}
+	for _, recipientID := range recipientIDs {
+		if _, err := s.notificationService.Notify(recipientID, models.NotificationTypeBroadcastPost, title, post.Body, data); err != nil {
+			s.logger.Error("Failed to deliver broadcast post", "postID", post.ID, "userID", recipientID, "error", err)
+		}
+	}
+
+	s.logger.Info("Broadcast post sent", "postID", post.ID, "channelID", channel.ID, "recipients", len(recipientIDs))
+
+	response := toBroadcastPostResponse(post)
+	response.Stats = toBroadcastPostStats(post, nil)
+	return response, nil
+}
+
+// GetPosts lists the posts of a channel with their read statistics, most recent first
+func (s *BroadcastChannelService) GetPosts(channelID, userID uuid.UUID, offset, limit int) ([]*dto.BroadcastPostResponse, int64, error) {
+	if _, err := s.getPublishableChannel(channelID, userID); err != nil {
+		return nil, 0, err
+	}
+
+	posts, total, err := s.channelRepo.ListPosts(channelID, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get broadcast posts: %w", err)
+	}
+	postIDs := make([]uuid.UUID, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+	stats, err := s.channelRepo.GetReadStats(postIDs)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get read statistics: %w", err)
+	}
+
+	responses := make([]*dto.BroadcastPostResponse, len(posts))
+	for i, post := range posts {
+		responses[i] = toBroadcastPostResponse(post)
+		responses[i].Stats = toBroadcastPostStats(post, stats[post.ID])
+	}
+	return responses, total, nil
+}
+
+// GetPost shows a post of a channel with its read statistics
+func (s *BroadcastChannelService) GetPost(channelID, postID, userID uuid.UUID) (*dto.BroadcastPostResponse, error) {
+	if _, err := s.getPublishableChannel(channelID, userID); err != nil {
+		return nil, err
+	}
+
+	post, err := s.channelRepo.GetPostByID(postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broadcast post: %w", err)
+	}
+	if post.ChannelID != channelID {
+		return nil, fmt.Errorf("failed to get broadcast post: %w", gorm.ErrRecordNotFound)
+	}
+	stats, err := s.channelRepo.GetReadStats([]uuid.UUID{post.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get read statistics: %w", err)
+	}
+
+	response := toBroadcastPostResponse(post)
+	response.Stats = toBroadcastPostStats(post, stats[post.ID])
+	return response, nil
+}
+
+// ========================================
+// INBOX
+// ========================================
+
+// GetInbox lists the announcements delivered to a user, most recent first
+func (s *BroadcastChannelService) GetInbox(userID uuid.UUID, channelID *uuid.UUID, unreadOnly bool, offset, limit int) ([]*dto.BroadcastPostResponse, int64, error) {
+	recipients, total, err := s.channelRepo.GetInbox(userID, channelID, unreadOnly, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get announcements: %w", err)
+	}
+
+	responses := make([]*dto.BroadcastPostResponse, len(recipients))
+	for i, recipient := range recipients {
+		responses[i] = toBroadcastPostResponse(recipient.Post)
+		responses[i].ReadAt = recipient.ReadAt
+	}
+	return responses, total, nil
+}
+
+// MarkRead records that a user read an announcement delivered to them
+func (s *BroadcastChannelService) MarkRead(postID, userID uuid.UUID) error {
+	recipient, err := s.channelRepo.GetRecipient(postID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("this announcement was not sent to you")
+		}
+		return fmt.Errorf("failed to get announcement recipient: %w", err)
+	}
+	if recipient.ReadAt != nil {
+		return nil
+	}
+
+	if err := s.channelRepo.MarkRead(recipient.ID, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark announcement read: %w", err)
+	}
+	return nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// getPublishableChannel loads a channel the user can post to
+func (s *BroadcastChannelService) getPublishableChannel(channelID, userID uuid.UUID) (*models.BroadcastChannel, error) {
+	channel, err := s.channelRepo.GetChannelByID(channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broadcast channel: %w", err)
+	}
+	canPost, err := s.canPost(channel, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canPost {
+		return nil, dto.ErrAccessDenied
+	}
+	return channel, nil
+}
+
+// canPost checks if the user is an admin, or a manager of a space in the channel's building
+func (s *BroadcastChannelService) canPost(channel *models.BroadcastChannel, userID uuid.UUID) (bool, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsAdmin() {
+		return true, nil
+	}
+	if !user.IsManager() || channel.Building == "" {
+		return false, nil
+	}
+
+	manages, err := s.channelRepo.ManagesBuilding(userID, channel.Building)
+	if err != nil {
+		return false, fmt.Errorf("failed to check managed spaces: %w", err)
+	}
+	return manages, nil
+}
+
+// ensureNameFree checks that no other channel has the name, case aside
+func (s *BroadcastChannelService) ensureNameFree(name string, exceptID uuid.UUID) error {
+	channels, err := s.channelRepo.ListChannels(true)
+	if err != nil {
+		return fmt.Errorf("failed to get broadcast channels: %w", err)
+	}
+	for _, channel := range channels {
+		if channel.ID != exceptID && strings.EqualFold(channel.Name, strings.TrimSpace(name)) {
+			return fmt.Errorf("broadcast channel %q already exists", channel.Name)
+		}
+	}
+	return nil
+}
+
+// toBroadcastChannelResponse converts a broadcast channel to its response
+func toBroadcastChannelResponse(channel *models.BroadcastChannel) *dto.BroadcastChannelResponse {
+	return &dto.BroadcastChannelResponse{
+		ID:          channel.ID,
+		Name:        channel.Name,
+		Description: channel.Description,
+		Building:    channel.Building,
+		CreatedByID: channel.CreatedByID,
+		ArchivedAt:  channel.ArchivedAt,
+		CreatedAt:   channel.CreatedAt,
+	}
+}
+
+// toBroadcastPostResponse converts a broadcast post to its response
+func toBroadcastPostResponse(post *models.BroadcastPost) *dto.BroadcastPostResponse {
+	response := &dto.BroadcastPostResponse{
+		ID:        post.ID,
+		ChannelID: post.ChannelID,
+		Kind:      string(post.Kind),
+		Title:     post.Title,
+		Body:      post.Body,
+		AuthorID:  post.AuthorID,
+		CreatedAt: post.CreatedAt,
+	}
+	if post.Channel != nil {
+		response.ChannelName = post.Channel.Name
+		response.Building = post.Channel.Building
+	}
+	if post.Author != nil {
+		response.AuthorName = post.Author.GetFullName()
+	}
+	return response
+}
+
+// toBroadcastPostStats computes the read statistics of a post
+func toBroadcastPostStats(post *models.BroadcastPost, reads *models.BroadcastReadStats) *dto.BroadcastPostStats {
+	stats := &dto.BroadcastPostStats{RecipientCount: post.RecipientCount}
+	if reads != nil {
+		stats.ReadCount = reads.ReadCount
+		stats.LastReadAt = reads.LastReadAt
+	}
+	if post.RecipientCount > 0 {
+		stats.ReadRate = math.Round(float64(stats.ReadCount)/float64(post.RecipientCount)*1000) / 10
+	}
+	return stats
+}
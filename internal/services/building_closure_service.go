@@ -0,0 +1,188 @@
+// internal/services/building_closure_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// BuildingClosureService manages the closures that take a building, or one of its floors,
+// out of use for a range of days, and tells organizers of the bookings caught in them
+type BuildingClosureService struct {
+	closureRepo         interfaces.BuildingClosureRepositoryInterface
+	spaceRepo           interfaces.SpaceRepositoryInterface
+	notificationService *NotificationService
+	logger              *slog.Logger
+}
+
+// NewBuildingClosureService creates a new building closure service
+func NewBuildingClosureService(
+	closureRepo interfaces.BuildingClosureRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	notificationService *NotificationService,
+	logger *slog.Logger,
+) *BuildingClosureService {
+	return &BuildingClosureService{
+		closureRepo:         closureRepo,
+		spaceRepo:           spaceRepo,
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// CreateClosure closes a building, or one of its floors, for a range of days. The bookings
+// already made there are flagged and their organizers told where they could rebook.
+func (s *BuildingClosureService) CreateClosure(req *dto.CreateBuildingClosureRequest, userID uuid.UUID) (*dto.BuildingClosureResponse, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, errors.New("start_date must use the YYYY-MM-DD format")
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, errors.New("end_date must use the YYYY-MM-DD format")
+	}
+	if endDate.Before(startDate) {
+		return nil, errors.New("end date must not be before start date")
+	}
+	if startDate.Before(startOfDayUTC(time.Now())) {
+		return nil, errors.New("cannot close a building in the past")
+	}
+
+	count, err := s.spaceRepo.CountSpacesByBuilding(req.Building)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check building: %w", err)
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("unknown building %q", req.Building)
+	}
+
+	overlapping, err := s.closureRepo.GetOverlapping(req.Building, startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check building closures: %w", err)
+	}
+	for _, other := range overlapping {
+		if req.Floor == nil || other.Floor == nil || *other.Floor == *req.Floor {
+			return nil, errors.New("building is already closed on some of these days")
+		}
+	}
+
+	closure, err := s.closureRepo.Create(&models.BuildingClosure{
+		Building:    req.Building,
+		Floor:       req.Floor,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Reason:      req.Reason,
+		CreatedByID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create building closure: %w", err)
+	}
+
+	// Existing bookings are kept; their organizers decide where to move them
+	affected, err := s.closureRepo.FlagReservations(closure)
+	if err != nil {
+		return nil, fmt.Errorf("failed to flag affected reservations: %w", err)
+	}
+	for _, reservation := range affected {
+		s.notifyOrganizers(closure, reservation)
+	}
+
+	s.logger.Info("Building closure created", "closureID", closure.ID, "building", closure.Building,
+		"floor", closure.Floor, "affectedReservations", len(affected))
+
+	response := toBuildingClosureResponse(closure)
+	affectedCount := int64(len(affected))
+	response.AffectedReservations = &affectedCount
+	return response, nil
+}
+
+// GetClosures lists the closures that are running or planned
+func (s *BuildingClosureService) GetClosures() ([]*dto.BuildingClosureResponse, error) {
+	closures, err := s.closureRepo.GetCurrentAndUpcoming(startOfDayUTC(time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get building closures: %w", err)
+	}
+
+	responses := make([]*dto.BuildingClosureResponse, len(closures))
+	for i, closure := range closures {
+		responses[i] = toBuildingClosureResponse(closure)
+	}
+	return responses, nil
+}
+
+// EndClosure reopens the closed spaces from now on
+func (s *BuildingClosureService) EndClosure(id uuid.UUID) (*dto.BuildingClosureResponse, error) {
+	closure, err := s.closureRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get building closure: %w", err)
+	}
+	if closure.EndedAt != nil {
+		return nil, errors.New("building closure already ended")
+	}
+
+	closure, err = s.closureRepo.End(id, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to end building closure: %w", err)
+	}
+
+	s.logger.Info("Building closure ended", "closureID", id, "building", closure.Building)
+	return toBuildingClosureResponse(closure), nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// notifyOrganizers tells the organizers of a booking caught in the closure where they could rebook
+func (s *BuildingClosureService) notifyOrganizers(closure *models.BuildingClosure, reservation *models.Reservation) {
+	suggestions, err := suggestRebooking(s.spaceRepo, reservation, func(candidate *models.Space) bool {
+		return !closure.Closes(candidate, reservation.StartTime, reservation.EndTime)
+	})
+	if err != nil {
+		s.logger.Warn("Failed to suggest rebooking", "reservationID", reservation.ID, "error", err)
+	}
+
+	closed := closure.Building
+	if closure.Floor != nil {
+		closed = fmt.Sprintf("floor %d of %s", *closure.Floor, closure.Building)
+	}
+	title := fmt.Sprintf("%s is closed during your booking", reservation.Space.Name)
+	message := fmt.Sprintf("%s is closed from %s to %s, so your booking \"%s\" on %s cannot take place there. ",
+		closed, closure.StartDate.Format("Mon 2 Jan"), closure.EndDate.Format("Mon 2 Jan"),
+		reservation.Title, reservation.StartTime.Format("Mon 2 Jan 15:04"))
+	if closure.Reason != "" {
+		message += "Reason: " + closure.Reason + ". "
+	}
+	message += rebookingSentence(suggestions)
+	data := map[string]interface{}{
+		"reservation_id": reservation.ID,
+		"closure_id":     closure.ID,
+		"suggestions":    suggestions,
+	}
+
+	if err := s.notificationService.NotifyOrganizers(reservation, models.NotificationTypeReservationInClosure, title, message, data); err != nil {
+		s.logger.Warn("Failed to notify organizers of building closure", "reservationID", reservation.ID, "error", err)
+	}
+}
+
+// toBuildingClosureResponse converts a closure to its response
+func toBuildingClosureResponse(closure *models.BuildingClosure) *dto.BuildingClosureResponse {
+	return &dto.BuildingClosureResponse{
+		ID:          closure.ID,
+		Building:    closure.Building,
+		Floor:       closure.Floor,
+		StartDate:   closure.StartDate.Format("2006-01-02"),
+		EndDate:     closure.EndDate.Format("2006-01-02"),
+		Reason:      closure.Reason,
+		CreatedByID: closure.CreatedByID,
+		EndedAt:     closure.EndedAt,
+	}
+}
@@ -0,0 +1,308 @@
+// internal/services/canned_response_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CannedResponseService manages reusable support answers and lets agents send them
+type CannedResponseService struct {
+	cannedRepo  interfaces.CannedResponseRepository
+	chatRepo    interfaces.ChatRepository
+	userRepo    interfaces.UserRepositoryInterface
+	chatService *ChatService
+	logger      *slog.Logger
+}
+
+// NewCannedResponseService creates a new canned response service instance
+func NewCannedResponseService(
+	cannedRepo interfaces.CannedResponseRepository,
+	chatRepo interfaces.ChatRepository,
+	userRepo interfaces.UserRepositoryInterface,
+	chatService *ChatService,
+	logger *slog.Logger,
+) *CannedResponseService {
+	return &CannedResponseService{
+		cannedRepo:  cannedRepo,
+		chatRepo:    chatRepo,
+		userRepo:    userRepo,
+		chatService: chatService,
+		logger:      logger,
+	}
+}
+
+// Admin operations
+
+// CreateCannedResponse adds a canned response
+func (s *CannedResponseService) CreateCannedResponse(ctx context.Context, adminUserID uuid.UUID, req *dto.CreateCannedResponseRequest) (*dto.CannedResponseResponse, error) {
+	shortcut := normalizeShortcut(req.Shortcut)
+	if err := s.ensureShortcutAvailable(ctx, shortcut, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	response := &models.CannedResponse{
+		Title:       req.Title,
+		Shortcut:    shortcut,
+		Content:     req.Content,
+		Category:    req.Category,
+		Keywords:    req.Keywords,
+		AutoReply:   req.AutoReply,
+		IsActive:    true,
+		CreatedByID: adminUserID,
+	}
+
+	if response.AutoReply && len(response.Keywords) == 0 {
+		return nil, errors.New("auto replies need at least one keyword")
+	}
+
+	if err := s.cannedRepo.Create(ctx, response); err != nil {
+		return nil, fmt.Errorf("failed to create canned response: %w", err)
+	}
+
+	return s.mapCannedResponseToResponse(response), nil
+}
+
+// GetCannedResponses lists canned responses. Inactive ones are only listed for admins.
+func (s *CannedResponseService) GetCannedResponses(ctx context.Context, req *dto.GetCannedResponsesRequest, includeInactive bool) (*dto.CannedResponseListResponse, error) {
+	limit := 20
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+
+	responses, total, err := s.cannedRepo.List(ctx, req.Category, req.Search, !includeInactive, limit, req.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get canned responses: %w", err)
+	}
+
+	list := &dto.CannedResponseListResponse{
+		CannedResponses: make([]dto.CannedResponseResponse, len(responses)),
+		TotalCount:      total,
+		HasMore:         int64(req.Offset+len(responses)) < total,
+	}
+	for i := range responses {
+		list.CannedResponses[i] = *s.mapCannedResponseToResponse(&responses[i])
+	}
+
+	return list, nil
+}
+
+// UpdateCannedResponse changes a canned response
+func (s *CannedResponseService) UpdateCannedResponse(ctx context.Context, id uuid.UUID, req *dto.UpdateCannedResponseRequest) (*dto.CannedResponseResponse, error) {
+	response, err := s.getCannedResponse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Shortcut != nil {
+		shortcut := normalizeShortcut(*req.Shortcut)
+		if err := s.ensureShortcutAvailable(ctx, shortcut, response.ID); err != nil {
+			return nil, err
+		}
+		response.Shortcut = shortcut
+	}
+	if req.Title != nil {
+		response.Title = *req.Title
+	}
+	if req.Content != nil {
+		response.Content = *req.Content
+	}
+	if req.Category != nil {
+		response.Category = *req.Category
+	}
+	if req.Keywords != nil {
+		response.Keywords = *req.Keywords
+	}
+	if req.AutoReply != nil {
+		response.AutoReply = *req.AutoReply
+	}
+	if req.IsActive != nil {
+		response.IsActive = *req.IsActive
+	}
+
+	if response.AutoReply && len(response.Keywords) == 0 {
+		return nil, errors.New("auto replies need at least one keyword")
+	}
+
+	if err := s.cannedRepo.Update(ctx, response); err != nil {
+		return nil, fmt.Errorf("failed to update canned response: %w", err)
+	}
+
+	return s.mapCannedResponseToResponse(response), nil
+}
+
+// DeleteCannedResponse removes a canned response
+func (s *CannedResponseService) DeleteCannedResponse(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getCannedResponse(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.cannedRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete canned response: %w", err)
+	}
+	return nil
+}
+
+// Agent operations
+
+// EnsureCanUseCannedResponses checks that the user is a support agent or an admin
+func (s *CannedResponseService) EnsureCanUseCannedResponses(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsAdmin() {
+		return nil
+	}
+
+	isAgent, err := s.chatService.IsUserAgent(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !isAgent {
+		return errors.New("only support agents can use canned responses")
+	}
+	return nil
+}
+
+// InsertCannedResponse sends a canned response as the agent, filling in the
+// {{customer_name}} and {{agent_name}} placeholders
+func (s *CannedResponseService) InsertCannedResponse(ctx context.Context, agentID uuid.UUID, conversationID uuid.UUID, req *dto.InsertCannedResponseRequest) (*dto.ChatMessageResponse, error) {
+	if err := s.EnsureCanUseCannedResponses(ctx, agentID); err != nil {
+		return nil, err
+	}
+
+	var response *models.CannedResponse
+	var err error
+	switch {
+	case req.CannedResponseID != nil:
+		response, err = s.getCannedResponse(ctx, *req.CannedResponseID)
+	case req.Shortcut != "":
+		response, err = s.cannedRepo.GetByShortcut(ctx, normalizeShortcut(req.Shortcut))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = errors.New("canned response not found")
+		}
+	default:
+		return nil, errors.New("canned_response_id or shortcut is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !response.IsActive {
+		return nil, errors.New("canned response not found")
+	}
+
+	content, err := s.renderContent(ctx, response.Content, agentID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.chatService.SendMessage(ctx, agentID, &dto.SendMessageRequest{
+		ConversationID: conversationID,
+		Content:        content,
+		Type:           string(models.MessageTypeText),
+		ReplyToID:      req.ReplyToID,
+		Metadata:       map[string]interface{}{"canned_response_id": response.ID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cannedRepo.IncrementUsage(ctx, response.ID); err != nil {
+		s.logger.WarnContext(ctx, "Failed to record canned response usage", "cannedResponseID", response.ID, "error", err)
+	}
+
+	return message, nil
+}
+
+// Helper functions
+
+func (s *CannedResponseService) getCannedResponse(ctx context.Context, id uuid.UUID) (*models.CannedResponse, error) {
+	response, err := s.cannedRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("canned response not found")
+		}
+		return nil, fmt.Errorf("failed to get canned response: %w", err)
+	}
+	return response, nil
+}
+
+func (s *CannedResponseService) ensureShortcutAvailable(ctx context.Context, shortcut string, currentID uuid.UUID) error {
+	existing, err := s.cannedRepo.GetByShortcut(ctx, shortcut)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to check shortcut: %w", err)
+	}
+	if existing.ID != currentID {
+		return errors.New("shortcut already in use")
+	}
+	return nil
+}
+
+// renderContent replaces placeholders with the agent's and the customer's first names
+func (s *CannedResponseService) renderContent(ctx context.Context, content string, agentID, conversationID uuid.UUID) (string, error) {
+	participants, err := s.chatRepo.GetParticipants(ctx, conversationID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	agentName, customerName := "", "there"
+	for _, p := range participants {
+		if p.User == nil {
+			continue
+		}
+		if p.UserID == agentID {
+			agentName = p.User.FirstName
+		} else if p.UserType != models.ParticipantTypeAgent && customerName == "there" {
+			customerName = p.User.FirstName
+		}
+	}
+
+	return strings.NewReplacer(
+		"{{customer_name}}", customerName,
+		"{{agent_name}}", agentName,
+	).Replace(content), nil
+}
+
+func (s *CannedResponseService) mapCannedResponseToResponse(response *models.CannedResponse) *dto.CannedResponseResponse {
+	keywords := []string(response.Keywords)
+	if keywords == nil {
+		keywords = []string{}
+	}
+
+	return &dto.CannedResponseResponse{
+		ID:         response.ID,
+		Title:      response.Title,
+		Shortcut:   response.Shortcut,
+		Content:    response.Content,
+		Category:   response.Category,
+		Keywords:   keywords,
+		AutoReply:  response.AutoReply,
+		IsActive:   response.IsActive,
+		UsageCount: response.UsageCount,
+		CreatedAt:  response.CreatedAt,
+		UpdatedAt:  response.UpdatedAt,
+	}
+}
+
+// normalizeShortcut lowercases a shortcut and makes sure it starts with a slash
+func normalizeShortcut(shortcut string) string {
+	shortcut = strings.ToLower(strings.TrimSpace(shortcut))
+	if !strings.HasPrefix(shortcut, "/") {
+		shortcut = "/" + shortcut
+	}
+	return shortcut
+}
@@ -0,0 +1,197 @@
+// internal/services/capacity_forecast_service.go
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	defaultCapacityForecastDays = 28
+	defaultCapacityHistoryWeeks = 12
+	// Forecast usage from which a day is at risk of running out of room
+	defaultCapacityRiskPercent = 85
+	// Days of history the trend compares with the whole history
+	capacityTrendDays = 28
+	// Bounds of the trend, so a quiet or busy month does not swamp the weekly pattern
+	minCapacityTrend = 0.5
+	maxCapacityTrend = 2.0
+)
+
+// CapacityForecastService forecasts the demand for each building and space type from past
+// bookings, to show space planners the days likely to exceed capacity. The model is seasonal
+// by weekday: the average seat time booked on that weekday over the history, scaled by how
+// recent weeks compare with the whole history, and never below what is already booked.
+type CapacityForecastService struct {
+	forecastRepo    interfaces.CapacityForecastRepositoryInterface
+	holidayCalendar *HolidayCalendar // Holidays are left out of the history and only keep their bookings
+}
+
+// NewCapacityForecastService creates a new capacity forecast service
+func NewCapacityForecastService(forecastRepo interfaces.CapacityForecastRepositoryInterface, holidayCalendar *HolidayCalendar) *CapacityForecastService {
+	return &CapacityForecastService{
+		forecastRepo:    forecastRepo,
+		holidayCalendar: holidayCalendar,
+	}
+}
+
+// GetForecast forecasts the demand of every building and space type from tomorrow on
+func (s *CapacityForecastService) GetForecast(req *dto.CapacityForecastRequest) (*dto.CapacityForecastResponse, error) {
+	days := req.Days
+	if days == 0 {
+		days = defaultCapacityForecastDays
+	}
+	historyWeeks := req.HistoryWeeks
+	if historyWeeks == 0 {
+		historyWeeks = defaultCapacityHistoryWeeks
+	}
+	threshold := req.ThresholdPercent
+	if threshold == 0 {
+		threshold = defaultCapacityRiskPercent
+	}
+
+	today := startOfDayUTC(time.Now())
+	from := today.AddDate(0, 0, 1)
+	end := from.AddDate(0, 0, days)
+	historyFrom := today.AddDate(0, 0, -7*historyWeeks)
+
+	capacities, err := s.forecastRepo.GetCapacity(req.Building, req.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space capacity: %w", err)
+	}
+	history, err := s.forecastRepo.GetDailyDemand(historyFrom, today,
+		[]models.ReservationStatus{models.StatusConfirmed, models.StatusCompleted}, req.Building, req.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking history: %w", err)
+	}
+	booked, err := s.forecastRepo.GetDailyDemand(from, end,
+		[]models.ReservationStatus{models.StatusPending, models.StatusConfirmed}, req.Building, req.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upcoming bookings: %w", err)
+	}
+
+	historyByGroup := groupDailyDemand(history)
+	bookedByGroup := groupDailyDemand(booked)
+
+	response := &dto.CapacityForecastResponse{
+		From:             from.Format("2006-01-02"),
+		To:               end.AddDate(0, 0, -1).Format("2006-01-02"),
+		HistoryFrom:      historyFrom.Format("2006-01-02"),
+		HistoryTo:        today.AddDate(0, 0, -1).Format("2006-01-02"),
+		ThresholdPercent: threshold,
+		Groups:           []dto.CapacityForecastGroup{},
+		AtRiskDays:       []dto.CapacityRiskDay{},
+		GeneratedAt:      time.Now().UTC(),
+	}
+
+	for _, capacity := range capacities {
+		key := capacity.Building + "\x00" + capacity.SpaceType
+		group := s.forecastGroup(capacity, historyByGroup[key], bookedByGroup[key], historyFrom, today, from, end, threshold)
+
+		for _, day := range group.Days {
+			if day.AtRisk {
+				response.AtRiskDays = append(response.AtRiskDays, dto.CapacityRiskDay{
+					Date:                 day.Date,
+					Building:             group.Building,
+					SpaceType:            group.SpaceType,
+					ForecastUsagePercent: day.ForecastUsagePercent,
+				})
+			}
+		}
+		response.Groups = append(response.Groups, group)
+	}
+
+	sort.SliceStable(response.AtRiskDays, func(i, j int) bool {
+		return response.AtRiskDays[i].ForecastUsagePercent > response.AtRiskDays[j].ForecastUsagePercent
+	})
+
+	return response, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// forecastGroup forecasts each day of one building and space type from its history
+func (s *CapacityForecastService) forecastGroup(
+	capacity interfaces.GroupCapacity,
+	history, booked map[string]float64,
+	historyFrom, historyTo, from, end time.Time,
+	threshold int,
+) dto.CapacityForecastGroup {
+	var weekdayTotals, weekdayDays [7]float64
+	var total, totalDays, recent, recentDays float64
+	recentFrom := historyTo.AddDate(0, 0, -capacityTrendDays)
+
+	for day := historyFrom; day.Before(historyTo); day = day.AddDate(0, 0, 1) {
+		if s.holidayCalendar.IsHoliday(day) {
+			continue
+		}
+		minutes := history[day.Format("2006-01-02")]
+		weekdayTotals[day.Weekday()] += minutes
+		weekdayDays[day.Weekday()]++
+		total += minutes
+		totalDays++
+		if !day.Before(recentFrom) {
+			recent += minutes
+			recentDays++
+		}
+	}
+
+	trend := 1.0
+	if total > 0 && recentDays > 0 {
+		trend = math.Min(math.Max((recent/recentDays)/(total/totalDays), minCapacityTrend), maxCapacityTrend)
+	}
+
+	group := dto.CapacityForecastGroup{
+		Building:  capacity.Building,
+		SpaceType: capacity.SpaceType,
+		Spaces:    capacity.Spaces,
+		Capacity:  capacity.Capacity,
+		Trend:     math.Round(trend*100) / 100,
+	}
+
+	for day := from; day.Before(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		bookedMinutes := booked[date]
+		holiday := s.holidayCalendar.IsHoliday(day)
+
+		forecast := bookedMinutes
+		if !holiday && weekdayDays[day.Weekday()] > 0 {
+			forecast = math.Max(forecast, weekdayTotals[day.Weekday()]/weekdayDays[day.Weekday()]*trend)
+		}
+
+		percent := usagePercent(forecast, capacity.Capacity)
+		group.Days = append(group.Days, dto.CapacityForecastDay{
+			Date:                 date,
+			Weekday:              day.Weekday().String(),
+			BookedSeatHours:      math.Round(bookedMinutes/60*10) / 10,
+			ForecastSeatHours:    math.Round(forecast/60*10) / 10,
+			AvailableSeatHours:   float64(capacity.Capacity * usageWorkdayMinutes / 60),
+			ForecastUsagePercent: percent,
+			Holiday:              holiday,
+			AtRisk:               percent >= threshold,
+		})
+	}
+
+	return group
+}
+
+// groupDailyDemand indexes seat minutes by building and space type, then by date
+func groupDailyDemand(demand []interfaces.DailyDemand) map[string]map[string]float64 {
+	grouped := make(map[string]map[string]float64)
+	for _, row := range demand {
+		key := row.Building + "\x00" + row.SpaceType
+		if grouped[key] == nil {
+			grouped[key] = make(map[string]float64)
+		}
+		grouped[key][row.Day.Format("2006-01-02")] += row.SeatMinutes
+	}
+	return grouped
+}
@@ -0,0 +1,111 @@
+// internal/services/chargeback_service.go
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// Widest range of months in one chargeback report
+	maxChargebackMonths = 24
+	// Department shown for bookers who have none
+	chargebackUnassigned = "Unassigned"
+)
+
+// ChargebackService reports what reservations cost each department, at the cost
+// rate of the space recorded on each reservation when it was booked, plus the
+// services delivered with them at the price recorded when they were ordered
+type ChargebackService struct {
+	reservationRepo interfaces.ReservationRepositoryInterface
+}
+
+// NewChargebackService creates a new chargeback service
+func NewChargebackService(reservationRepo interfaces.ReservationRepositoryInterface) *ChargebackService {
+	return &ChargebackService{
+		reservationRepo: reservationRepo,
+	}
+}
+
+// GetChargebackReport totals the confirmed and completed reservations per department and cost center
+// for each month from the first to the last month, both given as their first day in UTC
+func (s *ChargebackService) GetChargebackReport(from, to time.Time) (*dto.ChargebackReportResponse, error) {
+	if to.Before(from) {
+		return nil, errors.New("to must not be before from")
+	}
+	if !to.Before(from.AddDate(0, maxChargebackMonths, 0)) {
+		return nil, fmt.Errorf("report cannot exceed %d months", maxChargebackMonths)
+	}
+
+	totals, err := s.reservationRepo.GetChargebackTotals(from, to.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chargeback totals: %w", err)
+	}
+
+	report := &dto.ChargebackReportResponse{
+		From: from.Format("2006-01"),
+		To:   to.Format("2006-01"),
+		Rows: []dto.ChargebackRow{},
+	}
+	for _, total := range totals {
+		department := total.Department
+		if department == "" {
+			department = chargebackUnassigned
+		}
+		row := dto.ChargebackRow{
+			Month:        total.Month,
+			Department:   department,
+			CostCenter:   total.CostCenter,
+			Reservations: total.Reservations,
+			Hours:        roundCents(total.Minutes / 60),
+			ServicesCost: roundCents(total.ServicesCost),
+			Cost:         roundCents(total.Cost + total.ServicesCost),
+		}
+		report.Rows = append(report.Rows, row)
+		report.TotalReservations += row.Reservations
+		report.TotalHours += row.Hours
+		report.TotalServicesCost += row.ServicesCost
+		report.TotalCost += row.Cost
+	}
+	report.TotalHours = roundCents(report.TotalHours)
+	report.TotalServicesCost = roundCents(report.TotalServicesCost)
+	report.TotalCost = roundCents(report.TotalCost)
+
+	return report, nil
+}
+
+// RenderChargebackCSV renders a chargeback report as CSV for finance, one line per month, department and cost center
+func (s *ChargebackService) RenderChargebackCSV(report *dto.ChargebackReportResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"month", "department", "cost_center", "reservations", "hours", "services_cost", "cost"})
+	for _, row := range report.Rows {
+		writer.Write([]string{
+			row.Month,
+			row.Department,
+			row.CostCenter,
+			strconv.FormatInt(row.Reservations, 10),
+			strconv.FormatFloat(row.Hours, 'f', 2, 64),
+			strconv.FormatFloat(row.ServicesCost, 'f', 2, 64),
+			strconv.FormatFloat(row.Cost, 'f', 2, 64),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to render report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// roundCents rounds an amount to two decimals
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
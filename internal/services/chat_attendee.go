@@ -0,0 +1,132 @@
+// internal/services/chat_attendee.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+)
+
+const (
+	// Attendee chats stay open this long after their reservation ends, for follow-ups
+	attendeeChatArchiveDelay = 24 * time.Hour
+	// Attendee chats archived per run of the archive job
+	attendeeChatArchiveBatchSize = 500
+)
+
+// SyncAttendeeChat keeps the attendee chat of a reservation in step with it: the chat is opened
+// when the reservation is confirmed, its organizers are added as they are designated, and it is
+// archived when the reservation is cancelled or rejected. Organizers add the other attendees.
+// It handles reservation_created, reservation_updated and reservation_cancelled events of the outbox.
+func (s *ChatService) SyncAttendeeChat(ctx context.Context, event *models.OutboxEvent) error {
+	var change models.ReservationChange
+	if err := json.Unmarshal(event.Payload, &change); err != nil {
+		return fmt.Errorf("failed to decode reservation change: %w", err)
+	}
+
+	chat, err := s.chatRepo.GetAttendeeChat(ctx, change.ReservationID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get attendee chat: %w", err)
+	}
+
+	switch models.ReservationStatus(change.Status) {
+	case models.StatusCancelled, models.StatusRejected:
+		if chat == nil || chat.IsArchived {
+			return nil
+		}
+		if err := s.CreateSystemMessage(ctx, chat.ID, string(models.MessageTypeSystemNotification),
+			fmt.Sprintf("%s was %s. This chat is now archived.", change.Title, change.Status), nil); err != nil {
+			return fmt.Errorf("failed to post attendee chat message: %w", err)
+		}
+		if err := s.chatRepo.ArchiveConversation(ctx, chat.ID, true); err != nil {
+			return fmt.Errorf("failed to archive attendee chat: %w", err)
+		}
+		return nil
+	case models.StatusConfirmed:
+		if time.Now().After(change.EndTime.Add(attendeeChatArchiveDelay)) {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if chat == nil {
+		title := change.Title
+		chat = &models.Conversation{
+			Title:         &title,
+			Priority:      models.ConversationPriorityNormal,
+			Status:        models.ConversationStatusActive,
+			ReservationID: &change.ReservationID,
+			SpaceID:       &change.SpaceID,
+			AttendeeChat:  true,
+		}
+		if err := s.chatRepo.CreateConversation(ctx, chat); err != nil {
+			return fmt.Errorf("failed to create attendee chat: %w", err)
+		}
+		s.logger.InfoContext(ctx, "Attendee chat created", "conversationID", chat.ID, "reservationID", change.ReservationID)
+	}
+
+	// Co-organizers who are removed stay in the chat as attendees
+	joined := make(map[uuid.UUID]bool, len(chat.Participants))
+	for _, participant := range chat.Participants {
+		joined[participant.UserID] = true
+	}
+	for _, organizerID := range change.OrganizerIDs {
+		if joined[organizerID] {
+			continue
+		}
+		participant := &models.ConversationParticipant{
+			ConversationID: chat.ID,
+			UserID:         organizerID,
+			UserType:       models.ParticipantTypeAdmin, // Organizers add the attendees
+		}
+		if err := s.chatRepo.AddParticipant(ctx, participant); err != nil {
+			return fmt.Errorf("failed to add organizer to attendee chat: %w", err)
+		}
+		joined[organizerID] = true
+	}
+	return nil
+}
+
+// ArchiveEndedAttendeeChats archives the attendee chats of reservations that ended more than
+// a day ago
+func (s *ChatService) ArchiveEndedAttendeeChats(ctx context.Context) error {
+	endedBefore := time.Now().Add(-attendeeChatArchiveDelay)
+	archived := 0
+	for {
+		ids, err := s.chatRepo.ArchiveEndedAttendeeChats(ctx, endedBefore, attendeeChatArchiveBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to archive attendee chats: %w", err)
+		}
+		archived += len(ids)
+		if len(ids) < attendeeChatArchiveBatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if archived > 0 {
+		s.logger.InfoContext(ctx, "Attendee chats of ended reservations archived", "count", archived)
+	}
+	return nil
+}
+
+// GetAttendeeChat returns the attendee chat of a reservation to one of its members
+func (s *ChatService) GetAttendeeChat(ctx context.Context, userID uuid.UUID, reservationID uuid.UUID) (*dto.ConversationResponse, error) {
+	chat, err := s.chatRepo.GetAttendeeChat(ctx, reservationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("attendee chat not found")
+		}
+		return nil, fmt.Errorf("failed to get attendee chat: %w", err)
+	}
+
+	return s.GetConversation(ctx, userID, chat.ID)
+}
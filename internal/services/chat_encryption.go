@@ -0,0 +1,190 @@
+// internal/services/chat_encryption.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+)
+
+// Encrypted conversations are direct conversations whose messages are encrypted on the
+// devices of their two participants. The server stores and relays the ciphertext and the
+// public key bundles the devices publish, but never sees plaintext, so encrypted messages
+// are left out of search, bots, moderation previews and transcripts.
+
+// Stands in for the content of encrypted messages wherever the server renders messages itself
+const encryptedMessagePlaceholder = "[end-to-end encrypted message]"
+
+var (
+	errEncryptedDirectOnly     = errors.New("encrypted conversations are limited to one other participant")
+	errEncryptedNoDepartment   = errors.New("encrypted conversations cannot be routed to a support department")
+	errEncryptedNoLinks        = errors.New("encrypted conversations cannot be linked to a reservation or space")
+	errCiphertextRequired      = errors.New("messages in encrypted conversations must carry ciphertext instead of content")
+	errCiphertextNotAccepted   = errors.New("ciphertext is only accepted in encrypted conversations")
+	errSenderDeviceUnknown     = errors.New("sender device has no registered encryption keys")
+	errEncryptedMessageEdit    = errors.New("encrypted messages cannot be edited")
+	errEncryptedParticipants   = errors.New("participants of encrypted conversations cannot change")
+	errDeviceKeysNotRegistered = errors.New("device keys not found")
+)
+
+// RegisterDeviceKeys publishes or replaces the public key bundle of one of the user's devices
+func (s *ChatService) RegisterDeviceKeys(ctx context.Context, userID uuid.UUID, deviceID string, req *dto.RegisterDeviceKeysRequest) (*dto.DeviceKeysResponse, error) {
+	bundle := &models.DeviceKeyBundle{
+		UserID:                userID,
+		DeviceID:              deviceID,
+		IdentityKey:           req.IdentityKey,
+		SignedPreKey:          req.SignedPreKey,
+		SignedPreKeySignature: req.SignedPreKeySignature,
+		OneTimePreKeys:        req.OneTimePreKeys,
+	}
+
+	if err := s.chatRepo.UpsertDeviceKeyBundle(ctx, bundle); err != nil {
+		return nil, fmt.Errorf("failed to register device keys: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "Registered device keys",
+		"userID", userID,
+		"deviceID", deviceID,
+		"oneTimePreKeys", len(req.OneTimePreKeys))
+
+	response := mapDeviceKeys(bundle)
+	return &response, nil
+}
+
+// GetDeviceKeys lists the key bundles the user registered, to see which devices need new one-time keys
+func (s *ChatService) GetDeviceKeys(ctx context.Context, userID uuid.UUID) ([]dto.DeviceKeysResponse, error) {
+	bundles, err := s.chatRepo.GetDeviceKeyBundles(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device keys: %w", err)
+	}
+
+	response := make([]dto.DeviceKeysResponse, len(bundles))
+	for i := range bundles {
+		response[i] = mapDeviceKeys(&bundles[i])
+	}
+	return response, nil
+}
+
+// DeleteDeviceKeys removes a device's keys, for devices that are lost or signed out
+func (s *ChatService) DeleteDeviceKeys(ctx context.Context, userID uuid.UUID, deviceID string) error {
+	if err := s.chatRepo.DeleteDeviceKeyBundle(ctx, userID, deviceID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errDeviceKeysNotRegistered
+		}
+		return fmt.Errorf("failed to delete device keys: %w", err)
+	}
+	return nil
+}
+
+// ClaimKeyBundles hands out the key bundles of every device of a user so the caller can
+// encrypt to them. Each claim consumes one one-time pre-key per device.
+func (s *ChatService) ClaimKeyBundles(ctx context.Context, userID uuid.UUID, targetUserID uuid.UUID) ([]dto.ClaimedKeyBundleResponse, error) {
+	if _, err := s.userRepo.GetByID(targetUserID); err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	bundles, err := s.chatRepo.ClaimDeviceKeyBundles(ctx, targetUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim key bundles: %w", err)
+	}
+
+	response := make([]dto.ClaimedKeyBundleResponse, len(bundles))
+	for i, bundle := range bundles {
+		response[i] = dto.ClaimedKeyBundleResponse{
+			UserID:                bundle.UserID,
+			DeviceID:              bundle.DeviceID,
+			IdentityKey:           bundle.IdentityKey,
+			SignedPreKey:          bundle.SignedPreKey,
+			SignedPreKeySignature: bundle.SignedPreKeySignature,
+		}
+		if len(bundle.OneTimePreKeys) > 0 {
+			response[i].OneTimePreKey = bundle.OneTimePreKeys[0]
+		}
+	}
+
+	s.logger.DebugContext(ctx, "Claimed key bundles",
+		"userID", userID,
+		"targetUserID", targetUserID,
+		"devices", len(bundles))
+
+	return response, nil
+}
+
+// validateEncryptedConversation checks that a conversation can be encrypted end to end:
+// a direct conversation nobody else joins later, whose participants both have device keys
+func (s *ChatService) validateEncryptedConversation(ctx context.Context, userID uuid.UUID, req *dto.CreateConversationRequest) error {
+	if len(req.ParticipantIDs) != 1 || req.ParticipantIDs[0] == userID {
+		return errEncryptedDirectOnly
+	}
+	if req.Department != "" {
+		return errEncryptedNoDepartment
+	}
+	if req.ReservationID != nil || req.SpaceID != nil {
+		return errEncryptedNoLinks
+	}
+
+	for _, participantID := range []uuid.UUID{userID, req.ParticipantIDs[0]} {
+		bundles, err := s.chatRepo.GetDeviceKeyBundles(ctx, participantID)
+		if err != nil {
+			return fmt.Errorf("failed to get device keys: %w", err)
+		}
+		if len(bundles) == 0 {
+			return fmt.Errorf("participant %s has no registered encryption keys", participantID)
+		}
+	}
+	return nil
+}
+
+// applyMessageEncryption stores the ciphertext of a message sent to an encrypted conversation
+// and rejects plaintext there, or rejects ciphertext sent to a regular conversation
+func (s *ChatService) applyMessageEncryption(ctx context.Context, userID uuid.UUID, conversation *models.Conversation, req *dto.SendMessageRequest, message *models.Message) error {
+	if !conversation.Encrypted {
+		if req.Ciphertext != "" {
+			return errCiphertextNotAccepted
+		}
+		return nil
+	}
+
+	if req.Ciphertext == "" || req.Content != "" {
+		return errCiphertextRequired
+	}
+
+	registered, err := s.chatRepo.HasDeviceKeyBundle(ctx, userID, req.SenderDeviceID)
+	if err != nil {
+		return fmt.Errorf("failed to check device keys: %w", err)
+	}
+	if !registered {
+		return errSenderDeviceUnknown
+	}
+
+	message.Encrypted = true
+	message.Content = ""
+	message.Ciphertext = &req.Ciphertext
+	message.SenderDeviceID = &req.SenderDeviceID
+	return nil
+}
+
+// displayContent returns the content the server can show for a message
+func displayContent(message *models.Message) string {
+	if message.Encrypted {
+		return encryptedMessagePlaceholder
+	}
+	return message.Content
+}
+
+func mapDeviceKeys(bundle *models.DeviceKeyBundle) dto.DeviceKeysResponse {
+	return dto.DeviceKeysResponse{
+		DeviceID:              bundle.DeviceID,
+		IdentityKey:           bundle.IdentityKey,
+		SignedPreKey:          bundle.SignedPreKey,
+		SignedPreKeySignature: bundle.SignedPreKeySignature,
+		OneTimePreKeyCount:    len(bundle.OneTimePreKeys),
+		UpdatedAt:             bundle.UpdatedAt,
+	}
+}
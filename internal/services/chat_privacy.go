@@ -0,0 +1,125 @@
+// internal/services/chat_privacy.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+)
+
+var errUserBlocked = errors.New("you cannot exchange direct messages with this user")
+
+// MuteConversation suppresses notifications about a conversation for the user, for a number
+// of minutes or, without a duration, until they unmute it. Messages still arrive in realtime.
+func (s *ChatService) MuteConversation(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID, req *dto.MuteConversationRequest) error {
+	var until *time.Time
+	if req.DurationMinutes > 0 {
+		at := time.Now().Add(time.Duration(req.DurationMinutes) * time.Minute)
+		until = &at
+	}
+
+	if err := s.chatRepo.SetParticipantMute(ctx, conversationID, userID, true, until); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ErrAccessDenied
+		}
+		return fmt.Errorf("failed to mute conversation: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "Conversation muted", "conversationID", conversationID, "userID", userID, "until", until)
+	return nil
+}
+
+// UnmuteConversation restores notifications about a conversation for the user
+func (s *ChatService) UnmuteConversation(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID) error {
+	if err := s.chatRepo.SetParticipantMute(ctx, conversationID, userID, false, nil); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ErrAccessDenied
+		}
+		return fmt.Errorf("failed to unmute conversation: %w", err)
+	}
+	return nil
+}
+
+// BlockUser stops another user from starting direct conversations with the user or
+// messaging them in existing ones. Group conversations are not affected.
+func (s *ChatService) BlockUser(ctx context.Context, userID uuid.UUID, req *dto.BlockUserRequest) error {
+	if req.UserID == userID {
+		return errors.New("you cannot block yourself")
+	}
+	if _, err := s.userRepo.GetByID(req.UserID); err != nil {
+		return errors.New("user not found")
+	}
+
+	block := &models.UserBlock{
+		BlockerID: userID,
+		BlockedID: req.UserID,
+	}
+	if err := s.chatRepo.CreateUserBlock(ctx, block); err != nil {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "User blocked", "userID", userID, "blockedUserID", req.UserID)
+	return nil
+}
+
+// UnblockUser lifts a block the user placed
+func (s *ChatService) UnblockUser(ctx context.Context, userID uuid.UUID, blockedUserID uuid.UUID) error {
+	if err := s.chatRepo.DeleteUserBlock(ctx, userID, blockedUserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user is not blocked")
+		}
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+	return nil
+}
+
+// GetBlockedUsers lists the users the user blocked, most recent first
+func (s *ChatService) GetBlockedUsers(ctx context.Context, userID uuid.UUID) ([]dto.UserBlockResponse, error) {
+	blocks, err := s.chatRepo.GetUserBlocks(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+
+	response := make([]dto.UserBlockResponse, len(blocks))
+	for i, block := range blocks {
+		response[i] = dto.UserBlockResponse{
+			UserID:    block.BlockedID,
+			User:      s.mapUserToInfo(block.Blocked),
+			BlockedAt: block.CreatedAt,
+		}
+	}
+	return response, nil
+}
+
+// ensureNotBlocked rejects direct messages between two users when either blocked the other
+func (s *ChatService) ensureNotBlocked(ctx context.Context, userID, otherUserID uuid.UUID) error {
+	blocked, err := s.chatRepo.IsBlockedBetween(ctx, userID, otherUserID)
+	if err != nil {
+		return fmt.Errorf("failed to check blocks: %w", err)
+	}
+	if blocked {
+		return errUserBlocked
+	}
+	return nil
+}
+
+// directRecipient returns the other participant of a direct conversation between two users,
+// and false for group and support conversations
+func directRecipient(conversation *models.Conversation, userID uuid.UUID) (uuid.UUID, bool) {
+	if len(conversation.Participants) != 2 || conversation.AssignedAgentID != nil {
+		return uuid.Nil, false
+	}
+	for _, participant := range conversation.Participants {
+		if participant.UserID != userID {
+			return participant.UserID, true
+		}
+	}
+	return uuid.Nil, false
+}
@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"mime/multipart"
+	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -15,32 +18,84 @@ import (
 	"room-reservation-api/internal/dto"
 	"room-reservation-api/internal/models"
 	"room-reservation-api/internal/repositories/interfaces"
+	"room-reservation-api/internal/utils"
 	"room-reservation-api/internal/websocket"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// quarantineDir holds uploads that have not passed antivirus scanning yet
+const quarantineDir = models.ChatUploadQuarantineDir
+
+const (
+	// Guards against reply loops when walking up to a thread root
+	maxThreadDepth = 50
+	// Upper bound of replies returned for a single thread
+	maxThreadReplies = 500
+	// Characters of the original message shown in reply previews
+	replyPreviewLength = 120
+	// Upper bound of messages included in a conversation export
+	maxTranscriptMessages = 10000
+	// Conversations archived per update by the inactivity job
+	autoArchiveBatchSize = 1000
+)
+
 type ChatService struct {
-	chatRepo  interfaces.ChatRepository
-	userRepo  interfaces.UserRepositoryInterface
-	logger    *slog.Logger
-	wsManager *websocket.Manager // We'll add this later
-	// fileService FileService      // For file upload handling
+	chatRepo            interfaces.ChatRepository
+	moderationRepo      interfaces.ModerationRepository
+	userRepo            interfaces.UserRepositoryInterface
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	spaceRepo           interfaces.SpaceRepositoryInterface
+	logger              *slog.Logger
+	wsManager           *websocket.Manager // We'll add this later
+	fileScanner         FileScanner        // Optional, nil disables scanning
+	uploadPath          string
+	notificationService *NotificationService
+	autoResolveAfter    time.Duration  // Inactivity before auto-resolve, 0 disables it
+	autoArchiveAfter    time.Duration  // Time without messages before archival, 0 disables it
+	reopenWindow        time.Duration  // How long a resolved conversation can be reopened
+	retentionDays       map[string]int // Days messages are kept per conversation priority, 0 keeps forever
+	supportBot          *SupportBot    // Optional, nil disables bot auto-replies
+	agentAssignment     *AgentAssignmentService
 }
 
 // NewChatService creates a new chat service instance
 func NewChatService(
 	chatRepo interfaces.ChatRepository,
+	moderationRepo interfaces.ModerationRepository,
 	userRepo interfaces.UserRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
 	logger *slog.Logger,
 	wsManager *websocket.Manager, // Add this parameter
+	fileScanner FileScanner,
+	uploadPath string,
+	notificationService *NotificationService,
+	autoResolveAfter time.Duration,
+	autoArchiveAfter time.Duration,
+	reopenWindow time.Duration,
+	retentionDays map[string]int,
+	supportBot *SupportBot,
+	agentAssignment *AgentAssignmentService,
 ) *ChatService {
 	return &ChatService{
-		chatRepo:  chatRepo,
-		userRepo:  userRepo,
-		logger:    logger,
-		wsManager: wsManager, // Set the field
+		chatRepo:            chatRepo,
+		moderationRepo:      moderationRepo,
+		userRepo:            userRepo,
+		reservationRepo:     reservationRepo,
+		spaceRepo:           spaceRepo,
+		logger:              logger,
+		wsManager:           wsManager, // Set the field
+		fileScanner:         fileScanner,
+		uploadPath:          uploadPath,
+		notificationService: notificationService,
+		autoResolveAfter:    autoResolveAfter,
+		autoArchiveAfter:    autoArchiveAfter,
+		reopenWindow:        reopenWindow,
+		retentionDays:       retentionDays,
+		supportBot:          supportBot,
+		agentAssignment:     agentAssignment,
 	}
 }
 
@@ -54,16 +109,38 @@ func (s *ChatService) CreateConversation(ctx context.Context, userID uuid.UUID,
 		}
 	}
 
+	// Blocks only cover direct conversations
+	if len(req.ParticipantIDs) == 1 && req.ParticipantIDs[0] != userID {
+		if err := s.ensureNotBlocked(ctx, userID, req.ParticipantIDs[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Encrypted {
+		if err := s.validateEncryptedConversation(ctx, userID, req); err != nil {
+			return nil, err
+		}
+	}
+
+	reservationID, spaceID, err := s.resolveConversationLinks(ctx, userID, req.ReservationID, req.SpaceID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create conversation
 	conversation := &models.Conversation{
-		Title:    req.Subject,
-		Priority: models.ConversationPriority(req.Priority),
-		Status:   models.ConversationStatusActive,
-		Tags:     req.Tags,
+		Title:         req.Subject,
+		Priority:      models.ConversationPriority(req.Priority),
+		Status:        models.ConversationStatusActive,
+		Tags:          req.Tags,
+		Department:    req.Department,
+		ReservationID: reservationID,
+		SpaceID:       spaceID,
+		Encrypted:     req.Encrypted,
 	}
 
 	if err := s.chatRepo.CreateConversation(ctx, conversation); err != nil {
-		s.logger.Error("Failed to create conversation", "error", err)
+		s.logger.ErrorContext(ctx, "Failed to create conversation", "error", err)
 		return nil, fmt.Errorf("failed to create conversation: %w", err)
 	}
 
@@ -82,7 +159,7 @@ func (s *ChatService) CreateConversation(ctx context.Context, userID uuid.UUID,
 		}
 
 		if err := s.chatRepo.AddParticipant(ctx, participant); err != nil {
-			s.logger.Error("Failed to add participant", "participantID", participantID, "error", err)
+			s.logger.ErrorContext(ctx, "Failed to add participant", "participantID", participantID, "error", err)
 			return nil, fmt.Errorf("failed to add participant: %w", err)
 		}
 	}
@@ -91,10 +168,15 @@ func (s *ChatService) CreateConversation(ctx context.Context, userID uuid.UUID,
 	if req.InitialMessage != nil {
 		req.InitialMessage.ConversationID = conversation.ID
 		if _, err := s.SendMessage(ctx, userID, req.InitialMessage); err != nil {
-			s.logger.Warn("Failed to send initial message", "error", err)
+			s.logger.WarnContext(ctx, "Failed to send initial message", "error", err)
 		}
 	}
 
+	// Route support requests straight to an agent, unless the bot answers first
+	if s.supportBot == nil {
+		s.autoAssignAgent(ctx, userID, conversation.ID)
+	}
+
 	// Get the complete conversation with participants
 	createdConversation, err := s.chatRepo.GetConversationWithParticipants(ctx, conversation.ID)
 	if err != nil {
@@ -104,7 +186,7 @@ func (s *ChatService) CreateConversation(ctx context.Context, userID uuid.UUID,
 	response := s.mapConversationToResponse(createdConversation, userID)
 
 	// Broadcast conversation creation via WebSocket
-	if s.wsManager != nil && s.wsManager.IsRunning() {
+	if s.wsManager != nil {
 		conversationData := websocket.ConversationEventData{
 			ConversationID: createdConversation.ID,
 			Title:          *createdConversation.Title,
@@ -140,7 +222,7 @@ func (s *ChatService) CreateConversation(ctx context.Context, userID uuid.UUID,
 
 		s.wsManager.BroadcastConversationCreated(conversationData)
 
-		s.logger.Info("Broadcasted conversation creation",
+		s.logger.InfoContext(ctx, "Broadcasted conversation creation",
 			"conversationID", createdConversation.ID,
 			"participantCount", len(participants))
 	}
@@ -148,11 +230,74 @@ func (s *ChatService) CreateConversation(ctx context.Context, userID uuid.UUID,
 	return response, nil
 }
 
+// ReportReservationProblem opens a support conversation about a reservation with the problem
+// as its first message. A problem reported while the user's previous report on the reservation
+// is still open is added to that conversation instead.
+func (s *ChatService) ReportReservationProblem(ctx context.Context, userID uuid.UUID, reservationID uuid.UUID, req *dto.ReportReservationProblemRequest) (*dto.ConversationResponse, error) {
+	reservation, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("reservation not found")
+		}
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if !reservation.IsOrganizer(userID) {
+		return nil, dto.ErrAccessDenied
+	}
+
+	existing, err := s.chatRepo.GetOpenReservationConversation(ctx, userID, reservationID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get open conversation: %w", err)
+	}
+	if existing != nil {
+		message := &dto.SendMessageRequest{ConversationID: existing.ID, Content: req.Description, Type: "text"}
+		if _, err := s.SendMessage(ctx, userID, message); err != nil {
+			return nil, err
+		}
+		return s.GetConversation(ctx, userID, existing.ID)
+	}
+
+	priority := req.Priority
+	if priority == "" {
+		priority = string(models.ConversationPriorityNormal)
+	}
+	subject := fmt.Sprintf("Problem with %s", reservation.Title)
+	if reservation.Space.Name != "" {
+		subject = fmt.Sprintf("Problem with %s in %s", reservation.Title, reservation.Space.Name)
+	}
+	if runes := []rune(subject); len(runes) > 255 {
+		subject = string(runes[:255])
+	}
+
+	return s.CreateConversation(ctx, userID, &dto.CreateConversationRequest{
+		Subject:        &subject,
+		Priority:       priority,
+		Tags:           []string{"reservation-problem"},
+		ReservationID:  &reservationID,
+		InitialMessage: &dto.SendMessageRequest{Content: req.Description, Type: "text"},
+	})
+}
+
 func (s *ChatService) GetConversations(ctx context.Context, userID uuid.UUID, req *dto.GetConversationsRequest) (*dto.ConversationListResponse, error) {
-	conversations, total, err := s.chatRepo.GetConversationsByUserID(ctx, userID, req)
+	query := req
+	if req.Cursor != nil {
+		// One conversation beyond the page shows whether another page follows
+		paged := *req
+		paged.Limit++
+		query = &paged
+	}
+
+	conversations, total, err := s.chatRepo.GetConversationsByUserID(ctx, userID, query)
 	if err != nil {
+		if errors.Is(err, dto.ErrInvalidCursor) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to get conversations: %w", err)
 	}
+	hasNextPage := req.Cursor != nil && len(conversations) > req.Limit
+	if hasNextPage {
+		conversations = conversations[:req.Limit]
+	}
 
 	// Calculate total unread count for user
 	var totalUnread int64
@@ -172,7 +317,14 @@ func (s *ChatService) GetConversations(ctx context.Context, userID uuid.UUID, re
 		HasMore:       int64(req.Offset+req.Limit) < total,
 	}
 
-	if response.HasMore {
+	if req.Cursor != nil {
+		response.HasMore = hasNextPage
+		if hasNextPage {
+			last := conversations[len(conversations)-1]
+			nextCursor := dto.NewTimeCursor(conversationSortTime(&last, req.SortBy), last.ID).Encode()
+			response.NextCursor = &nextCursor
+		}
+	} else if response.HasMore {
 		nextOffset := req.Offset + req.Limit
 		response.NextOffset = &nextOffset
 	}
@@ -191,7 +343,7 @@ func (s *ChatService) GetConversation(ctx context.Context, userID uuid.UUID, con
 		return nil, err
 	}
 	if !canAccess {
-		return nil, errors.New("access denied")
+		return nil, dto.ErrAccessDenied
 	}
 
 	conversation, err := s.chatRepo.GetConversationByID(ctx, conversationID)
@@ -212,7 +364,7 @@ func (s *ChatService) UpdateConversation(ctx context.Context, userID uuid.UUID,
 		return nil, err
 	}
 	if !canAccess {
-		return nil, errors.New("access denied")
+		return nil, dto.ErrAccessDenied
 	}
 
 	conversation, err := s.chatRepo.GetConversationByID(ctx, conversationID)
@@ -222,6 +374,9 @@ func (s *ChatService) UpdateConversation(ctx context.Context, userID uuid.UUID,
 		}
 		return nil, fmt.Errorf("failed to get conversation: %w", err)
 	}
+	if err := checkUnmodified(conversation.UpdatedAt, req.UpdatedAt); err != nil {
+		return nil, err
+	}
 
 	// Store original values for change detection
 	originalStatus := conversation.Status
@@ -236,7 +391,19 @@ func (s *ChatService) UpdateConversation(ctx context.Context, userID uuid.UUID,
 
 	// Update fields
 	if req.Status != nil && string(conversation.Status) != *req.Status {
-		conversation.Status = models.ConversationStatus(*req.Status)
+		newStatus := models.ConversationStatus(*req.Status)
+		switch {
+		case newStatus == models.ConversationStatusResolved:
+			now := time.Now()
+			conversation.ResolvedAt = &now
+			conversation.AutoResolved = false
+		case conversation.IsResolved():
+			// Leaving the resolved state follows the same rules as the reopen endpoint
+			if err := s.reopen(ctx, conversation, userID); err != nil {
+				return nil, err
+			}
+		}
+		conversation.Status = newStatus
 		changes["status"] = map[string]string{"from": string(originalStatus), "to": *req.Status}
 	}
 	if req.Priority != nil && string(conversation.Priority) != *req.Priority {
@@ -259,6 +426,12 @@ func (s *ChatService) UpdateConversation(ctx context.Context, userID uuid.UUID,
 	}
 	if req.IsArchived != nil && conversation.IsArchived != *req.IsArchived {
 		conversation.IsArchived = *req.IsArchived
+		conversation.AutoArchived = false
+		conversation.ArchivedAt = nil
+		if conversation.IsArchived {
+			now := time.Now()
+			conversation.ArchivedAt = &now
+		}
 		changes["archived"] = map[string]bool{"from": originalArchived, "to": *req.IsArchived}
 	}
 	if req.Title != nil && (conversation.Title == nil || *conversation.Title != *req.Title) {
@@ -271,16 +444,24 @@ func (s *ChatService) UpdateConversation(ctx context.Context, userID uuid.UUID,
 
 	// Only update if there are actual changes
 	if len(changes) == 0 {
-		s.logger.Debug("No changes detected for conversation update", "conversationID", conversationID)
+		s.logger.DebugContext(ctx, "No changes detected for conversation update", "conversationID", conversationID)
 		return s.mapConversationToResponse(conversation, userID), nil
 	}
 
-	if err := s.chatRepo.UpdateConversation(ctx, conversation); err != nil {
+	if req.UpdatedAt != nil {
+		err = staleUpdateError(s.chatRepo.UpdateConversationIfUnmodified(ctx, conversation, *req.UpdatedAt))
+	} else {
+		err = s.chatRepo.UpdateConversation(ctx, conversation)
+	}
+	if errors.Is(err, dto.ErrStaleUpdate) {
+		return nil, err
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to update conversation: %w", err)
 	}
 
 	// Broadcast conversation update via WebSocket
-	if s.wsManager != nil && s.wsManager.IsRunning() {
+	if s.wsManager != nil {
 		conversationData := websocket.ConversationEventData{
 			ConversationID: conversation.ID,
 			Title: func() string {
@@ -338,13 +519,13 @@ func (s *ChatService) UpdateConversation(ctx context.Context, userID uuid.UUID,
 
 		s.wsManager.BroadcastConversationUpdated(conversationID, conversationData, &userID)
 
-		s.logger.Info("Broadcasted conversation update",
+		s.logger.InfoContext(ctx, "Broadcasted conversation update",
 			"conversationID", conversationID,
 			"updatedBy", userID,
 			"changeCount", len(changes))
 	}
 
-	s.logger.Info("Conversation updated successfully",
+	s.logger.InfoContext(ctx, "Conversation updated successfully",
 		"conversationID", conversationID,
 		"updatedBy", userID,
 		"changes", changes)
@@ -352,13 +533,217 @@ func (s *ChatService) UpdateConversation(ctx context.Context, userID uuid.UUID,
 	return s.mapConversationToResponse(conversation, userID), nil
 }
 
+// ReopenConversation reopens a resolved conversation so follow-ups stay in the same thread
+func (s *ChatService) ReopenConversation(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID) (*dto.ConversationResponse, error) {
+	canAccess, err := s.CanUserAccessConversation(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, dto.ErrAccessDenied
+	}
+
+	conversation, err := s.chatRepo.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("conversation not found")
+		}
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	if !conversation.IsResolved() {
+		return nil, errors.New("conversation is not resolved")
+	}
+
+	if err := s.reopen(ctx, conversation, userID); err != nil {
+		return nil, err
+	}
+	conversation.Status = models.ConversationStatusActive
+
+	if err := s.chatRepo.UpdateConversation(ctx, conversation); err != nil {
+		return nil, fmt.Errorf("failed to reopen conversation: %w", err)
+	}
+
+	if s.wsManager != nil {
+		s.wsManager.BroadcastConversationUpdated(conversationID, websocket.ConversationEventData{
+			ConversationID: conversation.ID,
+			Status:         string(conversation.Status),
+			Priority:       string(conversation.Priority),
+			Metadata: map[string]interface{}{
+				"reopenedBy":  userID,
+				"reopenCount": conversation.ReopenCount,
+			},
+			UpdatedAt: time.Now(),
+		}, &userID)
+	}
+
+	s.logger.InfoContext(ctx, "Conversation reopened",
+		"conversationID", conversationID,
+		"reopenedBy", userID,
+		"reopenCount", conversation.ReopenCount)
+
+	return s.mapConversationToResponse(conversation, userID), nil
+}
+
+// AutoResolveInactiveConversations resolves open conversations without recent
+// activity and lets the members know they can still reopen them
+func (s *ChatService) AutoResolveInactiveConversations(ctx context.Context) error {
+	if s.autoResolveAfter <= 0 {
+		return nil
+	}
+
+	conversations, err := s.chatRepo.GetInactiveConversations(ctx, time.Now().Add(-s.autoResolveAfter), 200)
+	if err != nil {
+		return fmt.Errorf("failed to get inactive conversations: %w", err)
+	}
+
+	resolved := 0
+	for i := range conversations {
+		conversation := &conversations[i]
+		now := time.Now()
+		conversation.Status = models.ConversationStatusResolved
+		conversation.ResolvedAt = &now
+		conversation.AutoResolved = true
+
+		if err := s.chatRepo.UpdateConversation(ctx, conversation); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to auto-resolve conversation", "conversationID", conversation.ID, "error", err)
+			continue
+		}
+		resolved++
+
+		s.notifyAutoResolved(conversation)
+	}
+
+	s.logger.InfoContext(ctx, "Inactive conversations auto-resolved", "count", resolved)
+	return nil
+}
+
+// AutoArchiveInactiveConversations archives conversations without messages for the
+// configured period so they drop out of the default conversation list
+func (s *ChatService) AutoArchiveInactiveConversations(ctx context.Context) error {
+	if s.autoArchiveAfter <= 0 {
+		return nil
+	}
+
+	before := time.Now().Add(-s.autoArchiveAfter)
+	var archived int64
+	for {
+		count, err := s.chatRepo.ArchiveInactiveConversations(ctx, before, autoArchiveBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to archive inactive conversations: %w", err)
+		}
+		archived += count
+		if count < autoArchiveBatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	s.logger.InfoContext(ctx, "Inactive conversations archived", "count", archived)
+	return nil
+}
+
+// BatchUnarchiveConversations restores archived conversations to their members' lists (admin only)
+func (s *ChatService) BatchUnarchiveConversations(ctx context.Context, adminUserID uuid.UUID, req *dto.BatchUnarchiveRequest) (*dto.BatchUnarchiveResponse, error) {
+	admin, err := s.userRepo.GetByID(adminUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !admin.IsAdmin() {
+		return nil, dto.ErrAccessDenied
+	}
+
+	unarchived, err := s.chatRepo.UnarchiveConversations(ctx, req.ConversationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unarchive conversations: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "Conversations unarchived",
+		"adminID", adminUserID,
+		"requested", len(req.ConversationIDs),
+		"unarchived", unarchived)
+
+	return &dto.BatchUnarchiveResponse{
+		Requested:  len(req.ConversationIDs),
+		Unarchived: unarchived,
+	}, nil
+}
+
+// notifyAutoResolved tells the members of a conversation that it was closed for inactivity
+func (s *ChatService) notifyAutoResolved(conversation *models.Conversation) {
+	if s.notificationService == nil {
+		return
+	}
+
+	subject := "your support conversation"
+	if conversation.Title != nil && *conversation.Title != "" {
+		subject = fmt.Sprintf("\"%s\"", *conversation.Title)
+	}
+	reopenUntil := s.reopenDeadline(conversation)
+	days := int(s.autoResolveAfter.Hours() / 24)
+
+	for _, participant := range conversation.Participants {
+		if participant.UserType != models.ParticipantTypeMember {
+			continue
+		}
+
+		message := fmt.Sprintf("We resolved %s after %d days without activity.", subject, days)
+		data := map[string]interface{}{
+			"conversation_id": conversation.ID,
+		}
+		if reopenUntil != nil {
+			message += fmt.Sprintf(" You can reopen it until %s if you still need help.", reopenUntil.Format("Mon Jan 2 15:04"))
+			data["reopen_until"] = reopenUntil
+		}
+
+		if _, err := s.notificationService.Notify(participant.UserID, models.NotificationTypeConversationResolved,
+			"Support conversation resolved", message, data); err != nil {
+			s.logger.Warn("Failed to notify participant of auto-resolve",
+				"conversationID", conversation.ID,
+				"userID", participant.UserID,
+				"error", err)
+		}
+	}
+}
+
+// reopen moves a resolved conversation back to active; members must be within the reopen window
+func (s *ChatService) reopen(ctx context.Context, conversation *models.Conversation, userID uuid.UUID) error {
+	participant, err := s.chatRepo.GetParticipantByUserAndConversation(ctx, userID, conversation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get participant: %w", err)
+	}
+
+	// Agents and conversation admins can always reopen
+	if participant.UserType == models.ParticipantTypeMember {
+		deadline := s.reopenDeadline(conversation)
+		if deadline != nil && time.Now().After(*deadline) {
+			return errors.New("reopen window has expired")
+		}
+	}
+
+	now := time.Now()
+	conversation.ResolvedAt = nil
+	conversation.AutoResolved = false
+	conversation.ReopenCount++
+	conversation.LastReopenedAt = &now
+	return nil
+}
+
+// reopenDeadline returns until when a resolved conversation can be reopened by its members
+func (s *ChatService) reopenDeadline(conversation *models.Conversation) *time.Time {
+	if !conversation.IsResolved() || conversation.ResolvedAt == nil || s.reopenWindow <= 0 {
+		return nil
+	}
+	deadline := conversation.ResolvedAt.Add(s.reopenWindow)
+	return &deadline
+}
+
 func (s *ChatService) ArchiveConversation(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID) error {
 	canAccess, err := s.CanUserAccessConversation(ctx, userID, conversationID)
 	if err != nil {
 		return err
 	}
 	if !canAccess {
-		return errors.New("access denied")
+		return dto.ErrAccessDenied
 	}
 
 	if err := s.chatRepo.ArchiveConversation(ctx, conversationID, true); err != nil {
@@ -366,7 +751,7 @@ func (s *ChatService) ArchiveConversation(ctx context.Context, userID uuid.UUID,
 	}
 
 	// NEW: Broadcast conversation archived via WebSocket
-	if s.wsManager != nil && s.wsManager.IsRunning() {
+	if s.wsManager != nil {
 		s.wsManager.BroadcastConversationArchived(conversationID, userID)
 	}
 
@@ -380,7 +765,7 @@ func (s *ChatService) DeleteConversation(ctx context.Context, userID uuid.UUID,
 		return err
 	}
 	if !canAccess {
-		return errors.New("access denied")
+		return dto.ErrAccessDenied
 	}
 
 	// Check if user is admin of the conversation
@@ -404,7 +789,21 @@ func (s *ChatService) SendMessage(ctx context.Context, userID uuid.UUID, req *dt
 		return nil, err
 	}
 	if !canAccess {
-		return nil, errors.New("access denied")
+		return nil, dto.ErrAccessDenied
+	}
+
+	if err := s.ensureNotBanned(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	conversation, err := s.chatRepo.GetConversationByID(ctx, req.ConversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+	if recipientID, ok := directRecipient(conversation, userID); ok {
+		if err := s.ensureNotBlocked(ctx, userID, recipientID); err != nil {
+			return nil, err
+		}
 	}
 
 	// Get user info
@@ -429,16 +828,22 @@ func (s *ChatService) SendMessage(ctx context.Context, userID uuid.UUID, req *dt
 		MessageType:    models.MessageType(req.Type),
 	}
 
+	if err := s.applyMessageEncryption(ctx, userID, conversation, req, message); err != nil {
+		return nil, err
+	}
+
 	// Set reply information if provided
+	var replyToMessage *models.Message
 	if req.ReplyToID != nil {
 		// Validate that the reply-to message exists and is in the same conversation
-		replyToMessage, err := s.chatRepo.GetMessageByID(ctx, *req.ReplyToID)
+		replyToMessage, err = s.chatRepo.GetMessageByID(ctx, *req.ReplyToID)
 		if err != nil {
 			return nil, fmt.Errorf("reply-to message not found: %w", err)
 		}
 		if replyToMessage.ConversationID != req.ConversationID {
 			return nil, errors.New("reply-to message must be in the same conversation")
 		}
+		message.ReplyToID = req.ReplyToID
 	}
 
 	// Add metadata if provided
@@ -450,26 +855,27 @@ func (s *ChatService) SendMessage(ctx context.Context, userID uuid.UUID, req *dt
 		}
 	}
 
+	attachments := make([]*models.MessageAttachment, 0, len(req.Attachments))
+	for _, attachmentReq := range req.Attachments {
+		attachment, err := s.newMessageAttachment(ctx, req.ConversationID, attachmentReq)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
 	if err := s.chatRepo.CreateMessage(ctx, message); err != nil {
-		s.logger.Error("Failed to create message", "error", err)
+		s.logger.ErrorContext(ctx, "Failed to create message", "error", err)
 		return nil, fmt.Errorf("failed to create message: %w", err)
 	}
 
 	// Create attachments if provided
-	for _, attachmentReq := range req.Attachments {
-		attachment := &models.MessageAttachment{
-			MessageID:    message.ID,
-			FileName:     attachmentReq.FileName,
-			FileSize:     attachmentReq.FileSize,
-			FileType:     attachmentReq.FileType,
-			FileURL:      attachmentReq.FileURL,
-			ThumbnailURL: &attachmentReq.ThumbnailURL,
-		}
-
+	for _, attachment := range attachments {
+		attachment.MessageID = message.ID
 		if err := s.chatRepo.CreateMessageAttachment(ctx, attachment); err != nil {
-			s.logger.Warn("Failed to create attachment",
+			s.logger.WarnContext(ctx, "Failed to create attachment",
 				"messageID", message.ID,
-				"fileName", attachmentReq.FileName,
+				"fileName", attachment.FileName,
 				"error", err)
 		}
 	}
@@ -481,15 +887,21 @@ func (s *ChatService) SendMessage(ctx context.Context, userID uuid.UUID, req *dt
 	}
 
 	// Broadcast message via WebSocket
-	if s.wsManager != nil && s.wsManager.IsRunning() {
+	if s.wsManager != nil {
 		messageData := websocket.MessageEventData{
 			MessageID:      completeMessage.ID,
 			ConversationID: completeMessage.ConversationID,
 			UserID:         completeMessage.SenderID,
 			Content:        completeMessage.Content,
 			MessageType:    string(completeMessage.MessageType),
+			ReplyToID:      completeMessage.ReplyToID,
+			Encrypted:      completeMessage.Encrypted,
 			CreatedAt:      completeMessage.CreatedAt,
 		}
+		if completeMessage.Encrypted {
+			messageData.Ciphertext = *completeMessage.Ciphertext
+			messageData.SenderDeviceID = *completeMessage.SenderDeviceID
+		}
 
 		// Add attachments if any
 		if len(completeMessage.Attachments) > 0 {
@@ -523,20 +935,37 @@ func (s *ChatService) SendMessage(ctx context.Context, userID uuid.UUID, req *dt
 
 		s.wsManager.BroadcastMessageSent(req.ConversationID, messageData, &userID)
 
-		s.logger.Info("Broadcasted new message",
+		// Let clients update the reply counter shown under the parent message
+		if replyToMessage != nil {
+			s.wsManager.BroadcastThreadUpdated(req.ConversationID, websocket.ThreadEventData{
+				ConversationID:  req.ConversationID,
+				ParentMessageID: replyToMessage.ID,
+				ReplyID:         completeMessage.ID,
+				ReplyCount:      replyToMessage.ReplyCount + 1,
+				RepliedBy:       userID,
+				LastReplyAt:     completeMessage.CreatedAt,
+			})
+		}
+
+		s.logger.InfoContext(ctx, "Broadcasted new message",
 			"messageID", completeMessage.ID,
 			"conversationID", req.ConversationID,
 			"senderID", userID,
 			"hasAttachments", len(completeMessage.Attachments) > 0)
 	}
 
-	s.logger.Info("Message sent successfully",
+	s.logger.InfoContext(ctx, "Message sent successfully",
 		"messageID", completeMessage.ID,
 		"conversationID", req.ConversationID,
 		"senderID", userID,
 		"contentLength", len(completeMessage.Content),
 		"attachmentCount", len(completeMessage.Attachments))
 
+	// Answer common questions until an agent picks up the conversation
+	if s.supportBot != nil {
+		s.supportBot.HandleCustomerMessage(ctx, completeMessage)
+	}
+
 	return s.mapMessageToResponse(completeMessage, userID), nil
 }
 
@@ -547,13 +976,28 @@ func (s *ChatService) GetMessages(ctx context.Context, userID uuid.UUID, convers
 		return nil, err
 	}
 	if !canAccess {
-		return nil, errors.New("access denied")
+		return nil, dto.ErrAccessDenied
+	}
+
+	query := req
+	if req.Cursor != nil {
+		// One message beyond the page shows whether another page follows
+		paged := *req
+		paged.Limit++
+		query = &paged
 	}
 
-	messages, total, err := s.chatRepo.GetMessagesByConversationID(ctx, conversationID, req)
+	messages, total, err := s.chatRepo.GetMessagesByConversationID(ctx, conversationID, query)
 	if err != nil {
+		if errors.Is(err, dto.ErrInvalidCursor) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to get messages: %w", err)
 	}
+	hasNextPage := req.Cursor != nil && len(messages) > req.Limit
+	if hasNextPage {
+		messages = messages[:req.Limit]
+	}
 
 	response := &dto.MessageListResponse{
 		Messages:   make([]dto.ChatMessageResponse, len(messages)),
@@ -562,7 +1006,14 @@ func (s *ChatService) GetMessages(ctx context.Context, userID uuid.UUID, convers
 	}
 
 	// Set cursors for pagination
-	if len(messages) > 0 {
+	if req.Cursor != nil {
+		response.HasMore = hasNextPage
+		if hasNextPage {
+			last := messages[len(messages)-1]
+			nextCursor := dto.NewTimeCursor(last.CreatedAt, last.ID).Encode()
+			response.NextCursor = &nextCursor
+		}
+	} else if len(messages) > 0 {
 		firstMessage := messages[0]
 		lastMessage := messages[len(messages)-1]
 
@@ -577,6 +1028,8 @@ func (s *ChatService) GetMessages(ctx context.Context, userID uuid.UUID, convers
 		response.Messages[i] = *s.mapMessageToResponse(&message, userID)
 	}
 
+	s.applyDeliveryStatus(ctx, userID, response.Messages)
+
 	return response, nil
 }
 
@@ -587,7 +1040,7 @@ func (s *ChatService) UpdateMessage(ctx context.Context, userID uuid.UUID, messa
 		return nil, err
 	}
 	if !canModify {
-		return nil, errors.New("access denied")
+		return nil, dto.ErrAccessDenied
 	}
 
 	message, err := s.chatRepo.GetMessageByID(ctx, messageID)
@@ -597,6 +1050,9 @@ func (s *ChatService) UpdateMessage(ctx context.Context, userID uuid.UUID, messa
 		}
 		return nil, fmt.Errorf("failed to get message: %w", err)
 	}
+	if message.Encrypted {
+		return nil, errEncryptedMessageEdit
+	}
 
 	// Store original content for logging
 	originalContent := message.Content
@@ -612,7 +1068,7 @@ func (s *ChatService) UpdateMessage(ctx context.Context, userID uuid.UUID, messa
 	}
 
 	// Broadcast message update via WebSocket
-	if s.wsManager != nil && s.wsManager.IsRunning() {
+	if s.wsManager != nil {
 		messageData := websocket.MessageEventData{
 			MessageID:      message.ID,
 			ConversationID: message.ConversationID,
@@ -655,13 +1111,13 @@ func (s *ChatService) UpdateMessage(ctx context.Context, userID uuid.UUID, messa
 
 		s.wsManager.BroadcastMessageUpdated(message.ConversationID, messageData, &userID)
 
-		s.logger.Info("Broadcasted message update",
+		s.logger.InfoContext(ctx, "Broadcasted message update",
 			"messageID", messageID,
 			"conversationID", message.ConversationID,
 			"userID", userID)
 	}
 
-	s.logger.Info("Message updated",
+	s.logger.InfoContext(ctx, "Message updated",
 		"messageID", messageID,
 		"userID", userID,
 		"originalLength", len(originalContent),
@@ -682,7 +1138,7 @@ func (s *ChatService) DeleteMessage(ctx context.Context, userID uuid.UUID, messa
 		return err
 	}
 	if !canModify {
-		return errors.New("access denied")
+		return dto.ErrAccessDenied
 	}
 
 	if err := s.chatRepo.DeleteMessage(ctx, messageID); err != nil {
@@ -690,37 +1146,85 @@ func (s *ChatService) DeleteMessage(ctx context.Context, userID uuid.UUID, messa
 	}
 
 	// NEW: Broadcast message deletion via WebSocket
-	if s.wsManager != nil && s.wsManager.IsRunning() {
+	if s.wsManager != nil {
 		s.wsManager.BroadcastMessageDeleted(message.ConversationID, messageID, userID)
 	}
 
 	return nil
 }
+
+// GetThread returns the root of the thread containing a message and every reply under it
+func (s *ChatService) GetThread(ctx context.Context, userID uuid.UUID, messageID uuid.UUID) (*dto.ThreadResponse, error) {
+	message, err := s.chatRepo.GetMessageByID(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("message not found: %w", err)
+	}
+
+	canAccess, err := s.CanUserAccessConversation(ctx, userID, message.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, dto.ErrAccessDenied
+	}
+
+	// Walk up the reply links to the message that started the thread
+	root := message
+	for depth := 0; root.ReplyToID != nil && depth < maxThreadDepth; depth++ {
+		parent, err := s.chatRepo.GetMessageByID(ctx, *root.ReplyToID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				break
+			}
+			return nil, fmt.Errorf("failed to get parent message: %w", err)
+		}
+		root = parent
+	}
+
+	replies, err := s.chatRepo.GetThreadReplies(ctx, root.ID, maxThreadReplies)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thread replies: %w", err)
+	}
+
+	response := &dto.ThreadResponse{
+		RootMessage:  *s.mapMessageToResponse(root, userID),
+		Replies:      make([]dto.ChatMessageResponse, len(replies)),
+		TotalReplies: len(replies),
+	}
+	for i := range replies {
+		response.Replies[i] = *s.mapMessageToResponse(&replies[i], userID)
+	}
+
+	threadMessages := append([]dto.ChatMessageResponse{response.RootMessage}, response.Replies...)
+	s.applyDeliveryStatus(ctx, userID, threadMessages)
+	response.RootMessage = threadMessages[0]
+	copy(response.Replies, threadMessages[1:])
+
+	return response, nil
+}
+
 func (s *ChatService) SearchMessages(ctx context.Context, userID uuid.UUID, req *dto.SearchMessagesRequest) (*dto.MessageSearchResponse, error) {
-	messages, total, err := s.chatRepo.SearchMessages(ctx, userID, req)
+	hits, total, err := s.chatRepo.SearchMessages(ctx, userID, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search messages: %w", err)
 	}
 
 	response := &dto.MessageSearchResponse{
-		Messages:   make([]dto.MessageSearchResult, len(messages)),
+		Messages:   make([]dto.MessageSearchResult, len(hits)),
 		TotalCount: total,
-		HasMore:    int64(req.Offset+req.Limit) < total,
+		HasMore:    int64(req.Offset+len(hits)) < total,
 	}
 
-	for i, message := range messages {
-		messageResp := s.mapMessageToResponse(&message, userID)
-		conversationResp := s.mapConversationToResponse(message.Conversation, userID)
-
+	for i := range hits {
+		message := &hits[i].Message
 		result := dto.MessageSearchResult{
-			Message:      *messageResp,
-			Conversation: *conversationResp,
-			Score:        1.0, // TODO: Implement proper scoring
+			Message:      *s.mapMessageToResponse(message, userID),
+			Conversation: *s.mapConversationToResponse(message.Conversation, userID),
+			Score:        hits[i].Rank,
 		}
 
-		// Add highlights for search terms
-		if strings.Contains(strings.ToLower(message.Content), strings.ToLower(req.Query)) {
-			result.Highlights = []string{req.Query}
+		if hits[i].Snippet != "" {
+			result.Highlights = strings.Split(hits[i].Snippet, " ... ")
 		}
 
 		response.Messages[i] = result
@@ -746,6 +1250,14 @@ func (s *ChatService) AddParticipant(ctx context.Context, userID uuid.UUID, conv
 		return fmt.Errorf("user not found: %w", err)
 	}
 
+	conversation, err := s.chatRepo.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		return fmt.Errorf("conversation not found: %w", err)
+	}
+	if conversation.Encrypted {
+		return errEncryptedParticipants
+	}
+
 	// Check if user is already a participant
 	if exists, _ := s.chatRepo.IsUserParticipant(ctx, req.UserID, conversationID); exists {
 		return errors.New("user is already a participant")
@@ -814,7 +1326,7 @@ func (s *ChatService) MarkMessagesAsRead(ctx context.Context, userID uuid.UUID,
 	}
 
 	// Broadcast read receipts via WebSocket
-	if s.wsManager != nil && s.wsManager.IsRunning() {
+	if s.wsManager != nil {
 		readTime := time.Now()
 
 		// Broadcast read receipts for each conversation
@@ -828,14 +1340,14 @@ func (s *ChatService) MarkMessagesAsRead(ctx context.Context, userID uuid.UUID,
 
 			s.wsManager.BroadcastMessageRead(conversationID, readData, &userID)
 
-			s.logger.Debug("Broadcasted read receipts",
+			s.logger.DebugContext(ctx, "Broadcasted read receipts",
 				"conversationID", conversationID,
 				"userID", userID,
 				"messageCount", len(messageIDs))
 		}
 	}
 
-	s.logger.Info("Marked messages as read",
+	s.logger.InfoContext(ctx, "Marked messages as read",
 		"userID", userID,
 		"messageCount", len(req.MessageIDs),
 		"conversationCount", len(conversationMessages))
@@ -854,7 +1366,7 @@ func (s *ChatService) GetReadReceipts(ctx context.Context, userID uuid.UUID, mes
 		return nil, err
 	}
 	if !canAccess {
-		return nil, errors.New("access denied")
+		return nil, dto.ErrAccessDenied
 	}
 
 	receipts, err := s.chatRepo.GetReadReceipts(ctx, messageID)
@@ -876,56 +1388,491 @@ func (s *ChatService) GetReadReceipts(ctx context.Context, userID uuid.UUID, mes
 	return response, nil
 }
 
-// File operations
-
-func (s *ChatService) UploadFile(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID, file multipart.File, header *multipart.FileHeader) (*dto.FileUploadResponse, error) {
-	// Check permissions
-	canAccess, err := s.CanUserAccessConversation(ctx, userID, conversationID)
+// SyncChat returns unread badges for every conversation and the messages posted after the
+// client's cursor. The first sync (no cursor) returns no messages, only a cursor at the
+// newest message so later syncs receive deltas.
+func (s *ChatService) SyncChat(ctx context.Context, userID uuid.UUID, req *dto.ChatSyncRequest) (*dto.ChatSyncResponse, error) {
+	cursor, err := dto.DecodeCursor(req.Cursor)
 	if err != nil {
 		return nil, err
 	}
-	if !canAccess {
-		return nil, errors.New("access denied")
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 100
 	}
 
-	// Validate file size (50MB max)
-	const maxFileSize = 50 * 1024 * 1024
-	if header.Size > maxFileSize {
-		return nil, errors.New("file size exceeds maximum allowed size")
+	syncedAt := time.Now()
+
+	counts, err := s.chatRepo.GetUnreadCountsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unread counts: %w", err)
 	}
 
-	// Validate file type
-	allowedTypes := map[string]bool{
-		".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true,
-		".pdf": true, ".doc": true, ".docx": true, ".txt": true,
-		".mp4": true, ".webm": true, ".ogg": true,
-		".mp3": true, ".wav": true,
+	response := &dto.ChatSyncResponse{
+		Conversations: counts,
+		Messages:      []dto.ChatMessageResponse{},
+		NextCursor:    req.Cursor,
+		SyncedAt:      syncedAt,
+	}
+	for _, count := range counts {
+		response.TotalUnread += count.UnreadCount
 	}
 
-	ext := strings.ToLower(filepath.Ext(header.Filename))
-	if !allowedTypes[ext] {
-		return nil, errors.New("file type not allowed")
+	if cursor == nil {
+		latest, err := s.chatRepo.GetLatestMessageForUser(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest message: %w", err)
+		}
+		if latest != nil {
+			response.NextCursor = dto.NewTimeCursor(latest.CreatedAt, latest.ID).Encode()
+		}
+		return response, nil
 	}
 
-	// TODO: Implement actual file upload to S3/MinIO
-	// For now, return a mock response
-	fileURL := fmt.Sprintf("/uploads/%s/%s", conversationID, header.Filename)
+	after, err := cursor.Time()
+	if err != nil {
+		return nil, err
+	}
 
-	response := &dto.FileUploadResponse{
-		FileURL:  fileURL,
-		FileName: header.Filename,
-		FileSize: header.Size,
-		FileType: header.Header.Get("Content-Type"),
+	messages, err := s.chatRepo.GetMessagesSince(ctx, userID, after, cursor.ID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages since cursor: %w", err)
 	}
 
-	// Generate thumbnail URL for images
-	if strings.HasPrefix(response.FileType, "image/") {
-		response.ThumbnailURL = fileURL + "_thumb"
+	if len(messages) > limit {
+		messages = messages[:limit]
+		response.HasMore = true
+	}
+
+	response.Messages = make([]dto.ChatMessageResponse, len(messages))
+	for i := range messages {
+		response.Messages[i] = *s.mapMessageToResponse(&messages[i], userID)
+	}
+	s.applyDeliveryStatus(ctx, userID, response.Messages)
+
+	if len(messages) > 0 {
+		last := messages[len(messages)-1]
+		response.NextCursor = dto.NewTimeCursor(last.CreatedAt, last.ID).Encode()
+	}
+
+	return response, nil
+}
+
+// Delivery receipt operations
+
+func (s *ChatService) MarkMessagesAsDelivered(ctx context.Context, userID uuid.UUID, req *dto.MarkMessagesDeliveredRequest) error {
+	conversationIDs := make(map[uuid.UUID]uuid.UUID, len(req.MessageIDs))
+
+	for _, messageID := range req.MessageIDs {
+		message, err := s.chatRepo.GetMessageByID(ctx, messageID)
+		if err != nil {
+			return fmt.Errorf("message %s not found: %w", messageID, err)
+		}
+
+		canAccess, err := s.CanUserAccessConversation(ctx, userID, message.ConversationID)
+		if err != nil {
+			return err
+		}
+		if !canAccess {
+			return fmt.Errorf("access denied to message %s", messageID)
+		}
+
+		conversationIDs[messageID] = message.ConversationID
+	}
+
+	delivered, err := s.chatRepo.MarkMessagesAsDelivered(ctx, req.MessageIDs, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark messages as delivered: %w", err)
+	}
+
+	// Only newly delivered messages are broadcast, so repeated acks stay quiet
+	if s.wsManager != nil && len(delivered) > 0 {
+		deliveredAt := time.Now()
+
+		conversationMessages := make(map[uuid.UUID][]uuid.UUID)
+		for _, messageID := range delivered {
+			conversationID := conversationIDs[messageID]
+			conversationMessages[conversationID] = append(conversationMessages[conversationID], messageID)
+		}
+
+		for conversationID, messageIDs := range conversationMessages {
+			s.wsManager.BroadcastMessageDelivered(conversationID, websocket.DeliveryEventData{
+				ConversationID: conversationID,
+				UserID:         userID,
+				MessageIDs:     messageIDs,
+				DeliveredAt:    deliveredAt,
+			}, &userID)
+		}
+	}
+
+	s.logger.DebugContext(ctx, "Marked messages as delivered",
+		"userID", userID,
+		"requested", len(req.MessageIDs),
+		"delivered", len(delivered))
+
+	return nil
+}
+
+// MarkDelivered records delivery acks sent over WebSocket connections
+func (s *ChatService) MarkDelivered(userID uuid.UUID, messageIDs []uuid.UUID) error {
+	return s.MarkMessagesAsDelivered(context.Background(), userID, &dto.MarkMessagesDeliveredRequest{MessageIDs: messageIDs})
+}
+
+// GetMessageDelivery returns the aggregate and per-recipient delivery state of a message
+func (s *ChatService) GetMessageDelivery(ctx context.Context, userID uuid.UUID, messageID uuid.UUID) (*dto.MessageDeliveryResponse, error) {
+	message, err := s.chatRepo.GetMessageByID(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("message not found: %w", err)
+	}
+
+	canAccess, err := s.CanUserAccessConversation(ctx, userID, message.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, dto.ErrAccessDenied
+	}
+
+	participants, err := s.chatRepo.GetParticipants(ctx, message.ConversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	deliveries, err := s.chatRepo.GetDeliveryReceipts(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delivery receipts: %w", err)
+	}
+
+	reads, err := s.chatRepo.GetReadReceipts(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get read receipts: %w", err)
+	}
+
+	deliveredAt := make(map[uuid.UUID]time.Time, len(deliveries))
+	for _, receipt := range deliveries {
+		deliveredAt[receipt.UserID] = receipt.DeliveredAt
+	}
+	readAt := make(map[uuid.UUID]time.Time, len(reads))
+	for _, receipt := range reads {
+		readAt[receipt.UserID] = receipt.ReadAt
+	}
+
+	counts := models.MessageDeliveryCounts{MessageID: messageID}
+	response := &dto.MessageDeliveryResponse{
+		MessageID:  messageID,
+		Recipients: make([]dto.RecipientDeliveryResponse, 0, len(participants)),
+	}
+
+	for _, participant := range participants {
+		if participant.UserID == message.SenderID {
+			continue
+		}
+
+		recipient := dto.RecipientDeliveryResponse{
+			UserID: participant.UserID,
+			User:   s.mapUserToInfo(participant.User),
+			Status: models.MessageDeliverySent,
+		}
+
+		if at, ok := deliveredAt[participant.UserID]; ok {
+			recipient.DeliveredAt = &at
+			recipient.Status = models.MessageDeliveryDelivered
+		}
+		if at, ok := readAt[participant.UserID]; ok {
+			// A read receipt implies delivery even if the client never acked it
+			if recipient.DeliveredAt == nil {
+				recipient.DeliveredAt = &at
+			}
+			recipient.ReadAt = &at
+			recipient.Status = models.MessageDeliveryRead
+		}
+
+		counts.RecipientCount++
+		if recipient.DeliveredAt != nil {
+			counts.DeliveredCount++
+		}
+		if recipient.ReadAt != nil {
+			counts.ReadCount++
+		}
+
+		response.Recipients = append(response.Recipients, recipient)
+	}
+
+	response.Status = counts.Status()
+	response.RecipientCount = counts.RecipientCount
+	response.DeliveredCount = counts.DeliveredCount
+	response.ReadCount = counts.ReadCount
+
+	return response, nil
+}
+
+// applyDeliveryStatus sets the tick state on the caller's own messages
+func (s *ChatService) applyDeliveryStatus(ctx context.Context, userID uuid.UUID, messages []dto.ChatMessageResponse) {
+	var ownMessageIDs []uuid.UUID
+	for _, message := range messages {
+		if message.SenderID == userID {
+			ownMessageIDs = append(ownMessageIDs, message.ID)
+		}
+	}
+	if len(ownMessageIDs) == 0 {
+		return
+	}
+
+	counts, err := s.chatRepo.GetMessageDeliveryCounts(ctx, ownMessageIDs)
+	if err != nil {
+		// Messages are still usable without ticks
+		s.logger.WarnContext(ctx, "Failed to load message delivery counts", "error", err)
+		return
+	}
+
+	for i := range messages {
+		if c, ok := counts[messages[i].ID]; ok && messages[i].SenderID == userID {
+			messages[i].DeliveryStatus = c.Status()
+		}
+	}
+}
+
+// File operations
+
+func (s *ChatService) UploadFile(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID, file multipart.File, header *multipart.FileHeader) (*dto.FileUploadResponse, error) {
+	// Check permissions
+	canAccess, err := s.CanUserAccessConversation(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, dto.ErrAccessDenied
+	}
+
+	if err := s.ensureNotBanned(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// Validate file size (50MB max)
+	const maxFileSize = 50 * 1024 * 1024
+	if header.Size > maxFileSize {
+		return nil, errors.New("file size exceeds maximum allowed size")
+	}
+
+	// Validate file type
+	allowedTypes := map[string]bool{
+		".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true,
+		".pdf": true, ".doc": true, ".docx": true, ".txt": true,
+		".mp4": true, ".webm": true, ".ogg": true,
+		".mp3": true, ".wav": true,
+	}
+
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	if !allowedTypes[ext] {
+		return nil, errors.New("file type not allowed")
+	}
+
+	// Store the file in quarantine until it has been scanned
+	storedName := uuid.New().String() + ext
+	quarantinePath := filepath.Join(s.uploadPath, quarantineDir, conversationID.String(), storedName)
+	if err := saveUploadedFile(file, quarantinePath); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to store upload", "conversationID", conversationID, "error", err)
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+
+	response := &dto.FileUploadResponse{
+		FileName: header.Filename,
+		FileSize: header.Size,
+		FileType: header.Header.Get("Content-Type"),
+	}
+
+	upload := &models.ChatUpload{
+		ConversationID: conversationID,
+		UploaderID:     userID,
+		StoredName:     storedName,
+	}
+
+	result, scanStatus := s.scanStoredFile(ctx, quarantinePath)
+	upload.ScanStatus = scanStatus
+	switch scanStatus {
+	case models.ScanStatusInfected:
+		os.Remove(quarantinePath)
+		s.logger.WarnContext(ctx, "Rejected infected upload",
+			"userID", userID,
+			"conversationID", conversationID,
+			"fileName", header.Filename,
+			"signature", result.Signature)
+		return nil, errors.New("file rejected: malware detected")
+	case models.ScanStatusPending:
+		// Scanner unavailable - keep the file quarantined for a later rescan
+	default:
+		now := time.Now()
+		upload.ScannedAt = &now
+		publicPath := filepath.Join(s.uploadPath, conversationID.String(), storedName)
+		if err := moveFile(quarantinePath, publicPath); err != nil {
+			return nil, fmt.Errorf("failed to release file from quarantine: %w", err)
+		}
+	}
+
+	if err := s.chatRepo.CreateChatUpload(ctx, upload); err != nil {
+		return nil, fmt.Errorf("failed to record upload: %w", err)
+	}
+
+	response.FileURL = upload.FileURL()
+	response.ScanStatus = string(scanStatus)
+	// Generate thumbnail URL for released images
+	if scanStatus != models.ScanStatusPending && strings.HasPrefix(response.FileType, "image/") {
+		response.ThumbnailURL = response.FileURL + "_thumb"
 	}
 
 	return response, nil
 }
 
+// RescanQuarantinedAttachments retries scanning for uploads still waiting in quarantine and
+// releases or drops the attachments using them. Paths are built from the names the server
+// gave the files on upload only.
+func (s *ChatService) RescanQuarantinedAttachments(ctx context.Context, limit int) (int, error) {
+	if s.fileScanner == nil {
+		return 0, nil
+	}
+
+	uploads, err := s.chatRepo.GetChatUploadsByScanStatus(ctx, models.ScanStatusPending, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get quarantined uploads: %w", err)
+	}
+
+	processed := 0
+	for i := range uploads {
+		upload := &uploads[i]
+
+		quarantinePath, ok := s.uploadFilePath(upload.RelativePath())
+		if !ok {
+			s.logger.ErrorContext(ctx, "Quarantined upload is outside the upload directory", "uploadID", upload.ID)
+			continue
+		}
+
+		result, scanStatus := s.scanStoredFile(ctx, quarantinePath)
+		if scanStatus == models.ScanStatusPending {
+			continue
+		}
+
+		now := time.Now()
+		upload.ScanStatus = scanStatus
+		upload.ScannedAt = &now
+
+		if scanStatus == models.ScanStatusInfected {
+			os.Remove(quarantinePath)
+			upload.ScanSignature = &result.Signature
+		} else {
+			publicPath, ok := s.uploadFilePath(upload.RelativePath())
+			if !ok {
+				continue
+			}
+			if err := moveFile(quarantinePath, publicPath); err != nil {
+				s.logger.ErrorContext(ctx, "Failed to release upload from quarantine", "uploadID", upload.ID, "error", err)
+				continue
+			}
+		}
+
+		if err := s.chatRepo.ResolveChatUploadScan(ctx, upload); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to update upload scan status", "uploadID", upload.ID, "error", err)
+			continue
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+// scanStoredFile scans a stored upload, returning pending when the scanner is unreachable
+func (s *ChatService) scanStoredFile(ctx context.Context, path string) (*ScanResult, models.AttachmentScanStatus) {
+	if s.fileScanner == nil {
+		return nil, models.ScanStatusSkipped
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to open file for scanning", "path", path, "error", err)
+		return nil, models.ScanStatusPending
+	}
+	defer file.Close()
+
+	result, err := s.fileScanner.Scan(ctx, file)
+	if err != nil {
+		s.logger.WarnContext(ctx, "File scan failed, keeping file quarantined", "path", path, "error", err)
+		return nil, models.ScanStatusPending
+	}
+
+	if !result.Clean {
+		return result, models.ScanStatusInfected
+	}
+	return result, models.ScanStatusClean
+}
+
+// newMessageAttachment builds the attachment of a message. Files under /uploads/ must have been
+// uploaded to the conversation: their URL and scan status come from the upload record, the
+// client's URL only names the file. Other URLs are external links nobody scanned.
+func (s *ChatService) newMessageAttachment(ctx context.Context, conversationID uuid.UUID, req dto.AttachmentRequest) (*models.MessageAttachment, error) {
+	attachment := &models.MessageAttachment{
+		FileName:     req.FileName,
+		FileSize:     req.FileSize,
+		FileType:     req.FileType,
+		FileURL:      req.FileURL,
+		ThumbnailURL: &req.ThumbnailURL,
+		ScanStatus:   models.ScanStatusSkipped,
+	}
+
+	idx := strings.Index(req.FileURL, "/uploads/")
+	if idx < 0 {
+		return attachment, nil
+	}
+
+	upload, err := s.chatRepo.GetChatUpload(ctx, conversationID, path.Base(req.FileURL[idx:]))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("attachment was not uploaded to this conversation")
+		}
+		return nil, fmt.Errorf("failed to get upload: %w", err)
+	}
+	if upload.ScanStatus == models.ScanStatusInfected {
+		return nil, errors.New("attachment was not uploaded to this conversation")
+	}
+
+	attachment.UploadID = &upload.ID
+	attachment.FileURL = upload.FileURL()
+	attachment.ScanStatus = upload.ScanStatus
+	attachment.ScannedAt = upload.ScannedAt
+	return attachment, nil
+}
+
+// uploadFilePath resolves a slash-separated path under the upload directory, refusing paths
+// that would leave it
+func (s *ChatService) uploadFilePath(relativePath string) (string, bool) {
+	base := filepath.Clean(s.uploadPath)
+	path := filepath.Join(base, filepath.FromSlash(relativePath))
+	return path, strings.HasPrefix(path, base+string(filepath.Separator))
+}
+
+// saveUploadedFile writes an uploaded file to disk
+func saveUploadedFile(file multipart.File, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o640)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, file)
+	return err
+}
+
+// moveFile moves a file, creating the destination directory if needed
+func moveFile(from, to string) error {
+	if err := os.MkdirAll(filepath.Dir(to), 0o750); err != nil {
+		return err
+	}
+	return os.Rename(from, to)
+}
+
 func (s *ChatService) DeleteAttachment(ctx context.Context, userID uuid.UUID, attachmentID uuid.UUID) error {
 	// TODO: Implement attachment deletion with permission checks
 	return s.chatRepo.DeleteAttachment(ctx, attachmentID)
@@ -1058,11 +2005,63 @@ func (s *ChatService) AssignAgent(ctx context.Context, adminUserID uuid.UUID, co
 	if err != nil {
 		return fmt.Errorf("conversation not found: %w", err)
 	}
+	if conversation.Encrypted {
+		return errEncryptedParticipants
+	}
+
+	// Manual assignment overrides any offer still waiting for an answer
+	if s.agentAssignment != nil {
+		return s.agentAssignment.AssignManually(ctx, conversation, req.AgentID)
+	}
 
 	conversation.AssignedAgentID = &req.AgentID
 	return s.chatRepo.UpdateConversation(ctx, conversation)
 }
 
+// AcceptAssignment confirms a conversation offered to the agent by auto-assignment
+func (s *ChatService) AcceptAssignment(ctx context.Context, agentID uuid.UUID, conversationID uuid.UUID) error {
+	if s.agentAssignment == nil {
+		return errors.New("no pending assignment for this agent")
+	}
+	return s.agentAssignment.AcceptAssignment(ctx, agentID, conversationID)
+}
+
+// DeclineAssignment hands a conversation offered to the agent on to the next agent
+func (s *ChatService) DeclineAssignment(ctx context.Context, agentID uuid.UUID, conversationID uuid.UUID) error {
+	if s.agentAssignment == nil {
+		return errors.New("no pending assignment for this agent")
+	}
+	return s.agentAssignment.DeclineAssignment(ctx, agentID, conversationID)
+}
+
+// autoAssignAgent offers a new conversation to an agent when a customer started it
+func (s *ChatService) autoAssignAgent(ctx context.Context, creatorID uuid.UUID, conversationID uuid.UUID) {
+	if s.agentAssignment == nil {
+		return
+	}
+
+	creator, err := s.userRepo.GetByID(creatorID)
+	if err != nil || creator.IsAdmin() {
+		return
+	}
+	if isAgent, err := s.IsUserAgent(ctx, creatorID); err != nil || isAgent {
+		return
+	}
+
+	conversation, err := s.chatRepo.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to load conversation for auto-assignment", "conversationID", conversationID, "error", err)
+		return
+	}
+	if conversation.AssignedAgentID != nil || conversation.Encrypted {
+		return
+	}
+
+	if _, err := s.agentAssignment.AutoAssign(ctx, conversation); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to auto-assign agent", "conversationID", conversationID, "error", err)
+	}
+}
+
 func (s *ChatService) UpdateAgentStatus(ctx context.Context, agentID uuid.UUID, status string) error {
 	return s.chatRepo.UpdateAgentStatus(ctx, agentID, status)
 }
@@ -1099,11 +2098,11 @@ func (s *ChatService) SetTypingStatus(ctx context.Context, userID uuid.UUID, req
 		return err
 	}
 	if !canAccess {
-		return errors.New("access denied")
+		return dto.ErrAccessDenied
 	}
 
 	// NEW: Broadcast typing status via WebSocket
-	if s.wsManager != nil && s.wsManager.IsRunning() {
+	if s.wsManager != nil {
 		s.wsManager.BroadcastUserTyping(req.ConversationID, userID, req.IsTyping)
 	}
 
@@ -1174,7 +2173,7 @@ func (s *ChatService) GetConversationSummary(ctx context.Context, userID uuid.UU
 		return nil, err
 	}
 	if !canAccess {
-		return nil, errors.New("access denied")
+		return nil, dto.ErrAccessDenied
 	}
 
 	conversation, err := s.chatRepo.GetConversationByID(ctx, conversationID)
@@ -1225,8 +2224,196 @@ func (s *ChatService) CreateSystemMessage(ctx context.Context, conversationID uu
 	return s.chatRepo.CreateMessage(ctx, message)
 }
 
-func (s *ChatService) CleanupOldMessages(ctx context.Context, retentionDays int) error {
-	return s.chatRepo.CleanupOldMessages(ctx, retentionDays)
+// ApplyRetentionPolicy deletes messages older than the retention period of their conversation's priority
+func (s *ChatService) ApplyRetentionPolicy(ctx context.Context) error {
+	now := time.Now()
+	priorities := []models.ConversationPriority{
+		models.ConversationPriorityLow,
+		models.ConversationPriorityNormal,
+		models.ConversationPriorityHigh,
+		models.ConversationPriorityUrgent,
+	}
+
+	for _, priority := range priorities {
+		days := s.retentionDays[string(priority)]
+		if days <= 0 {
+			continue
+		}
+
+		fileURLs, deleted, err := s.chatRepo.CleanupOldMessages(ctx, priority, now.AddDate(0, 0, -days))
+		if err != nil {
+			return fmt.Errorf("failed to clean up %s priority messages: %w", priority, err)
+		}
+
+		for _, fileURL := range fileURLs {
+			s.removeUploadedFile(fileURL)
+		}
+
+		if deleted > 0 {
+			s.logger.InfoContext(ctx, "Chat retention applied",
+				"priority", priority,
+				"retentionDays", days,
+				"deletedMessages", deleted,
+				"deletedFiles", len(fileURLs))
+		}
+	}
+
+	return nil
+}
+
+// removeUploadedFile deletes the file behind an /uploads/ URL, ignoring URLs outside the upload directory
+func (s *ChatService) removeUploadedFile(fileURL string) {
+	idx := strings.Index(fileURL, "/uploads/")
+	if idx < 0 {
+		return
+	}
+
+	path, ok := s.uploadFilePath(strings.TrimPrefix(fileURL[idx:], "/uploads/"))
+	if !ok {
+		return
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		s.logger.Warn("Failed to remove attachment file", "path", path, "error", err)
+	}
+}
+
+// Export operations
+
+// ExportConversation builds a full transcript for participants and admins
+func (s *ChatService) ExportConversation(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID) (*dto.ConversationTranscript, error) {
+	canAccess, err := s.CanUserAccessConversation(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil || !user.IsAdmin() {
+			return nil, dto.ErrAccessDenied
+		}
+	}
+
+	conversation, err := s.chatRepo.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("conversation not found")
+		}
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	// Fetch one extra message to detect truncation
+	messages, err := s.chatRepo.GetMessagesForExport(ctx, conversationID, maxTranscriptMessages+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+
+	transcript := &dto.ConversationTranscript{
+		ConversationID: conversation.ID,
+		Status:         string(conversation.Status),
+		Priority:       string(conversation.Priority),
+		Tags:           []string(conversation.Tags),
+		CreatedAt:      conversation.CreatedAt,
+		ResolvedAt:     conversation.ResolvedAt,
+		Participants:   make([]dto.TranscriptParticipant, len(conversation.Participants)),
+		ExportedAt:     time.Now(),
+		ExportedBy:     userID,
+	}
+	if conversation.Title != nil {
+		transcript.Title = *conversation.Title
+	}
+
+	for i, participant := range conversation.Participants {
+		transcript.Participants[i] = dto.TranscriptParticipant{
+			UserID:   participant.UserID,
+			UserType: string(participant.UserType),
+			JoinedAt: participant.JoinedAt,
+		}
+		if participant.User != nil {
+			transcript.Participants[i].Name = participant.User.GetFullName()
+			transcript.Participants[i].Email = participant.User.Email
+		}
+	}
+
+	if len(messages) > maxTranscriptMessages {
+		messages = messages[:maxTranscriptMessages]
+		transcript.Truncated = true
+	}
+
+	transcript.Messages = make([]dto.TranscriptMessage, len(messages))
+	for i, message := range messages {
+		entry := dto.TranscriptMessage{
+			ID:          message.ID,
+			SenderID:    message.SenderID,
+			SenderName:  message.SenderName,
+			SenderType:  string(message.SenderType),
+			MessageType: string(message.MessageType),
+			Content:     displayContent(&message),
+			ReplyToID:   message.ReplyToID,
+			IsEdited:    message.IsEdited,
+			EditedAt:    message.EditedAt,
+			CreatedAt:   message.CreatedAt,
+		}
+		for _, attachment := range message.Attachments {
+			entry.Attachments = append(entry.Attachments, attachment.FileName)
+		}
+		transcript.Messages[i] = entry
+	}
+	transcript.MessageCount = len(transcript.Messages)
+
+	s.logger.InfoContext(ctx, "Conversation exported",
+		"conversationID", conversationID,
+		"userID", userID,
+		"messages", transcript.MessageCount)
+
+	return transcript, nil
+}
+
+// RenderTranscriptPDF lays out a transcript as a printable PDF
+func (s *ChatService) RenderTranscriptPDF(transcript *dto.ConversationTranscript) []byte {
+	const timeLayout = "2006-01-02 15:04:05 MST"
+
+	doc := utils.NewPDFDocument()
+
+	title := transcript.Title
+	if title == "" {
+		title = "Untitled conversation"
+	}
+	doc.AddLine("Conversation transcript: " + title)
+	doc.AddLine("Conversation ID: " + transcript.ConversationID.String())
+	doc.AddLine(fmt.Sprintf("Status: %s    Priority: %s", transcript.Status, transcript.Priority))
+	doc.AddLine("Created: " + transcript.CreatedAt.UTC().Format(timeLayout))
+	if transcript.ResolvedAt != nil {
+		doc.AddLine("Resolved: " + transcript.ResolvedAt.UTC().Format(timeLayout))
+	}
+	doc.AddLine(fmt.Sprintf("Exported: %s by %s", transcript.ExportedAt.UTC().Format(timeLayout), transcript.ExportedBy))
+	doc.AddBlankLine()
+
+	doc.AddLine("Participants:")
+	for _, participant := range transcript.Participants {
+		doc.AddLine(fmt.Sprintf("  - %s <%s> (%s), joined %s",
+			participant.Name, participant.Email, participant.UserType, participant.JoinedAt.UTC().Format(timeLayout)))
+	}
+	doc.AddBlankLine()
+
+	doc.AddLine(fmt.Sprintf("Messages (%d):", transcript.MessageCount))
+	for _, message := range transcript.Messages {
+		header := fmt.Sprintf("[%s] %s", message.CreatedAt.UTC().Format(timeLayout), message.SenderName)
+		if message.IsEdited {
+			header += " (edited)"
+		}
+		doc.AddLine(header)
+		doc.AddLine(message.Content)
+		for _, attachment := range message.Attachments {
+			doc.AddLine("  Attachment: " + attachment)
+		}
+		doc.AddBlankLine()
+	}
+
+	if transcript.Truncated {
+		doc.AddLine(fmt.Sprintf("Transcript truncated after %d messages.", transcript.MessageCount))
+	}
+
+	return doc.Bytes()
 }
 
 // Permission helpers
@@ -1254,6 +2441,23 @@ func (s *ChatService) CanUserModifyMessage(ctx context.Context, userID uuid.UUID
 	return user.IsAdmin(), nil
 }
 
+// ensureNotBanned rejects users under an active chat ban
+func (s *ChatService) ensureNotBanned(ctx context.Context, userID uuid.UUID) error {
+	if s.moderationRepo == nil {
+		return nil
+	}
+
+	ban, err := s.moderationRepo.GetActiveBan(ctx, userID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to check chat ban: %w", err)
+	}
+	if ban != nil {
+		return fmt.Errorf("you are banned from chat until %s", ban.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
 func (s *ChatService) IsUserAgent(ctx context.Context, userID uuid.UUID) (bool, error) {
 	_, err := s.chatRepo.GetSupportAgentByID(ctx, userID)
 	if err != nil {
@@ -1265,8 +2469,62 @@ func (s *ChatService) IsUserAgent(ctx context.Context, userID uuid.UUID) (bool,
 	return true, nil
 }
 
+// resolveConversationLinks checks the reservation and space a new conversation is about.
+// Reservations can be linked by their organizers, admins and support agents; a linked
+// reservation also links its space.
+func (s *ChatService) resolveConversationLinks(ctx context.Context, userID uuid.UUID, reservationID, spaceID *uuid.UUID) (*uuid.UUID, *uuid.UUID, error) {
+	if reservationID != nil {
+		reservation, err := s.reservationRepo.GetByID(*reservationID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil, errors.New("reservation not found")
+			}
+			return nil, nil, fmt.Errorf("failed to get reservation: %w", err)
+		}
+		if !reservation.IsOrganizer(userID) {
+			user, err := s.userRepo.GetByID(userID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get user: %w", err)
+			}
+			isAgent, err := s.IsUserAgent(ctx, userID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to check support agent: %w", err)
+			}
+			if !user.IsAdmin() && !isAgent {
+				return nil, nil, dto.ErrAccessDenied
+			}
+		}
+		if spaceID != nil && *spaceID != reservation.SpaceID {
+			return nil, nil, errors.New("space does not match the reservation")
+		}
+		return &reservation.ID, &reservation.SpaceID, nil
+	}
+
+	if spaceID != nil {
+		if _, err := s.spaceRepo.GetByID(*spaceID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil, errors.New("space not found")
+			}
+			return nil, nil, fmt.Errorf("failed to get space: %w", err)
+		}
+	}
+	return nil, spaceID, nil
+}
+
 // Mapping helper functions
 
+// conversationSortTime is the value of the column a conversation list is sorted by
+func conversationSortTime(conversation *models.Conversation, sortBy string) time.Time {
+	switch sortBy {
+	case "created_at":
+		return conversation.CreatedAt
+	case "updated_at":
+		return conversation.UpdatedAt
+	default:
+		return conversation.LastMessageAt
+	}
+}
+
 func (s *ChatService) mapConversationToResponse(conversation *models.Conversation, userID uuid.UUID) *dto.ConversationResponse {
 	response := &dto.ConversationResponse{
 		ID:              conversation.ID,
@@ -1274,14 +2532,50 @@ func (s *ChatService) mapConversationToResponse(conversation *models.Conversatio
 		Participants:    make([]dto.ConversationParticipantResponse, len(conversation.Participants)),
 		LastMessageAt:   conversation.LastMessageAt,
 		IsArchived:      conversation.IsArchived,
+		ArchivedAt:      conversation.ArchivedAt,
+		AutoArchived:    conversation.AutoArchived,
 		Tags:            conversation.Tags,
 		Priority:        string(conversation.Priority),
 		Status:          string(conversation.Status),
 		AssignedAgentID: conversation.AssignedAgentID,
+		Department:      conversation.Department,
+		EscalatedAt:     conversation.EscalatedAt,
+		ResolvedAt:      conversation.ResolvedAt,
+		AutoResolved:    conversation.AutoResolved,
+		ReopenCount:     conversation.ReopenCount,
+		ReopenUntil:     s.reopenDeadline(conversation),
+		ReservationID:   conversation.ReservationID,
+		AttendeeChat:    conversation.AttendeeChat,
+		Encrypted:       conversation.Encrypted,
+		SpaceID:         conversation.SpaceID,
 		CreatedAt:       conversation.CreatedAt,
 		UpdatedAt:       conversation.UpdatedAt,
 	}
 
+	// Map the booking context agents need to help
+	if conversation.Reservation != nil {
+		response.Reservation = &dto.LinkedReservationInfo{
+			ID:               conversation.Reservation.ID,
+			Title:            conversation.Reservation.Title,
+			Status:           string(conversation.Reservation.Status),
+			StartTime:        conversation.Reservation.StartTime,
+			EndTime:          conversation.Reservation.EndTime,
+			ParticipantCount: conversation.Reservation.ParticipantCount,
+			CheckInTime:      conversation.Reservation.CheckInTime,
+			Organizer:        s.mapUserToInfo(&conversation.Reservation.User),
+		}
+	}
+	if conversation.Space != nil {
+		response.Space = &dto.LinkedSpaceInfo{
+			ID:       conversation.Space.ID,
+			Name:     conversation.Space.Name,
+			Type:     string(conversation.Space.Type),
+			Building: conversation.Space.Building,
+			Floor:    conversation.Space.Floor,
+			Status:   string(conversation.Space.Status),
+		}
+	}
+
 	// Map participants
 	for i, participant := range conversation.Participants {
 		response.Participants[i] = dto.ConversationParticipantResponse{
@@ -1293,9 +2587,13 @@ func (s *ChatService) mapConversationToResponse(conversation *models.Conversatio
 			User:        s.mapUserToInfo(participant.User),
 		}
 
-		// Set unread count for current user
+		// Set unread count and mute state for current user
 		if participant.UserID == userID {
 			response.UnreadCount = participant.UnreadCount
+			if participant.IsMuted(time.Now()) {
+				response.IsMuted = true
+				response.MutedUntil = participant.MutedUntil
+			}
 		}
 	}
 
@@ -1332,11 +2630,29 @@ func (s *ChatService) mapMessageToResponse(message *models.Message, userID uuid.
 		IsEdited:       message.IsEdited,
 		EditedAt:       message.EditedAt,
 		Attachments:    make([]dto.AttachmentResponse, len(message.Attachments)),
+		ReplyToID:      message.ReplyToID,
+		ReplyTo:        s.mapReplyPreview(message.ReplyTo),
+		ReplyCount:     message.ReplyCount,
 		Timestamp:      message.CreatedAt,
 		CreatedAt:      message.CreatedAt,
 		UpdatedAt:      message.UpdatedAt,
 	}
 
+	if message.Encrypted {
+		response.Encrypted = true
+		if message.Ciphertext != nil {
+			response.Ciphertext = *message.Ciphertext
+		}
+		if message.SenderDeviceID != nil {
+			response.SenderDeviceID = *message.SenderDeviceID
+		}
+	}
+
+	// Own messages start as sent; list endpoints refine this from receipts
+	if message.SenderID == userID {
+		response.DeliveryStatus = models.MessageDeliverySent
+	}
+
 	// Check if message is read by current user
 	for _, receipt := range message.ReadReceipts {
 		if receipt.UserID == userID {
@@ -1355,8 +2671,14 @@ func (s *ChatService) mapMessageToResponse(message *models.Message, userID uuid.
 			FileType:     attachment.FileType,
 			FileURL:      attachment.FileURL,
 			ThumbnailURL: attachment.ThumbnailURL,
+			ScanStatus:   string(attachment.ScanStatus),
 			CreatedAt:    attachment.CreatedAt,
 		}
+
+		// Quarantined files are not downloadable until they pass scanning
+		if attachment.IsQuarantined() {
+			response.Attachments[i].FileURL = ""
+		}
 	}
 
 	// Parse metadata
@@ -1370,6 +2692,26 @@ func (s *ChatService) mapMessageToResponse(message *models.Message, userID uuid.
 	return response
 }
 
+func (s *ChatService) mapReplyPreview(message *models.Message) *dto.ReplyPreview {
+	if message == nil {
+		return nil
+	}
+
+	content := displayContent(message)
+	if runes := []rune(content); len(runes) > replyPreviewLength {
+		content = string(runes[:replyPreviewLength]) + "…"
+	}
+
+	return &dto.ReplyPreview{
+		ID:         message.ID,
+		SenderID:   message.SenderID,
+		SenderName: message.SenderName,
+		Content:    content,
+		Type:       string(message.MessageType),
+		CreatedAt:  message.CreatedAt,
+	}
+}
+
 func (s *ChatService) mapUserToInfo(user *models.User) dto.UserInfo {
 	if user == nil {
 		return dto.UserInfo{}
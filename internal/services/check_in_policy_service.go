@@ -0,0 +1,177 @@
+// internal/services/check_in_policy_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// Reservations started longer ago than this are no longer released
+	checkInEnforcementLookback = 12 * time.Hour
+	// Upper bound of reservations released per space type and run
+	checkInEnforcementBatch = 200
+)
+
+// CheckInPolicyService enforces check-in per space type. Reservations not checked in within
+// the window of their space type are released: cancelled, so the outbox tells saved searches
+// waiting for the space, and reported as no-shows.
+type CheckInPolicyService struct {
+	policyRepo          interfaces.CheckInPolicyRepositoryInterface
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	strikes             *BookingStrikeService
+	notificationService *NotificationService
+	logger              *slog.Logger
+}
+
+// NewCheckInPolicyService creates a new check-in policy service
+func NewCheckInPolicyService(
+	policyRepo interfaces.CheckInPolicyRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	strikes *BookingStrikeService,
+	notificationService *NotificationService,
+	logger *slog.Logger,
+) *CheckInPolicyService {
+	return &CheckInPolicyService{
+		policyRepo:          policyRepo,
+		reservationRepo:     reservationRepo,
+		strikes:             strikes,
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// ========================================
+// POLICIES
+// ========================================
+
+// GetPolicies lists the check-in policy of every space type that has one
+func (s *CheckInPolicyService) GetPolicies() ([]*models.CheckInPolicy, error) {
+	policies, err := s.policyRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check-in policies: %w", err)
+	}
+	return policies, nil
+}
+
+// SetPolicy creates or replaces the check-in policy of a space type
+func (s *CheckInPolicyService) SetPolicy(spaceType string, req *dto.SetCheckInPolicyRequest, userID uuid.UUID) (*models.CheckInPolicy, error) {
+	if !isSpaceType(spaceType) {
+		return nil, fmt.Errorf("invalid space type %q", spaceType)
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	policy, err := s.policyRepo.Save(&models.CheckInPolicy{
+		SpaceType:     models.SpaceType(spaceType),
+		WindowMinutes: req.WindowMinutes,
+		Enabled:       enabled,
+		UpdatedByID:   userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save check-in policy: %w", err)
+	}
+	return policy, nil
+}
+
+// DeletePolicy stops enforcing check-in for a space type
+func (s *CheckInPolicyService) DeletePolicy(spaceType string) error {
+	if err := s.policyRepo.Delete(models.SpaceType(spaceType)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete check-in policy: %w", err)
+	}
+	return nil
+}
+
+// ========================================
+// ENFORCEMENT
+// ========================================
+
+// ReleaseMissedCheckIns releases the confirmed reservations not checked in within the window
+// of their space type's policy and tells their organizers. It runs as a scheduled job.
+func (s *CheckInPolicyService) ReleaseMissedCheckIns(ctx context.Context) error {
+	policies, err := s.policyRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to get check-in policies: %w", err)
+	}
+
+	now := time.Now()
+	released := 0
+	for _, policy := range policies {
+		if !policy.Enabled {
+			continue
+		}
+
+		reservations, err := s.policyRepo.GetMissedCheckIns(policy.SpaceType, now.Add(-checkInEnforcementLookback), now.Add(-policy.Window()), checkInEnforcementBatch)
+		if err != nil {
+			return fmt.Errorf("failed to get missed check-ins: %w", err)
+		}
+
+		for _, reservation := range reservations {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if s.release(reservation, policy) {
+				released++
+			}
+		}
+	}
+
+	if released > 0 {
+		s.logger.Info("Reservations released for missed check-in", "count", released)
+	}
+	return nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// release cancels a reservation that missed its check-in, unless it changed since it was read
+// (e.g. it was checked in meanwhile), and reports whether it was released
+func (s *CheckInPolicyService) release(reservation *models.Reservation, policy *models.CheckInPolicy) bool {
+	reason := fmt.Sprintf("Released: not checked in within %d minutes of the start", policy.WindowMinutes)
+	_, err := s.reservationRepo.UpdateIfUnmodified(reservation.ID, reservation.UpdatedAt, map[string]interface{}{
+		"status":              models.StatusCancelled,
+		"cancellation_reason": reason,
+		"no_show_reported":    true,
+	})
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Error("Failed to release reservation", "reservationID", reservation.ID, "error", err)
+		}
+		return false
+	}
+
+	if s.strikes != nil && !reservation.NoShowReported {
+		if err := s.strikes.RecordNoShow(reservation, reason); err != nil {
+			s.logger.Error("Failed to record no-show strike", "reservationID", reservation.ID, "error", err)
+		}
+	}
+
+	title := "Booking released"
+	message := fmt.Sprintf("Your booking of %s at %s was released because it was not checked in within %d minutes.",
+		reservation.Space.Name, reservation.StartTime.Format("15:04"), policy.WindowMinutes)
+	data := map[string]interface{}{
+		"reservation_id": reservation.ID,
+		"space_id":       reservation.SpaceID,
+	}
+	if err := s.notificationService.NotifyOrganizers(reservation, models.NotificationTypeReservationReleased, title, message, data); err != nil {
+		s.logger.Error("Failed to notify released reservation", "reservationID", reservation.ID, "error", err)
+	}
+	return true
+}
@@ -0,0 +1,381 @@
+// internal/services/check_in_questionnaire_service.go
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// CheckInQuestionnaireService manages the safety attestations users submit before checking in
+type CheckInQuestionnaireService struct {
+	questionnaireRepo interfaces.CheckInQuestionnaireRepositoryInterface
+	reservationRepo   interfaces.ReservationRepositoryInterface
+	spaceRepo         interfaces.SpaceRepositoryInterface
+}
+
+// NewCheckInQuestionnaireService creates a new check-in questionnaire service
+func NewCheckInQuestionnaireService(
+	questionnaireRepo interfaces.CheckInQuestionnaireRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+) *CheckInQuestionnaireService {
+	return &CheckInQuestionnaireService{
+		questionnaireRepo: questionnaireRepo,
+		reservationRepo:   reservationRepo,
+		spaceRepo:         spaceRepo,
+	}
+}
+
+// ========================================
+// QUESTIONNAIRE CONFIGURATION
+// ========================================
+
+// SetSpaceQuestionnaire creates or replaces the check-in questionnaire of a space
+func (s *CheckInQuestionnaireService) SetSpaceQuestionnaire(spaceID uuid.UUID, req *dto.SetQuestionnaireRequest, userID uuid.UUID) (*models.CheckInQuestionnaire, error) {
+	if _, err := s.spaceRepo.GetByID(spaceID); err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	questions, err := validateQuestions(req.Questions)
+	if err != nil {
+		return nil, err
+	}
+
+	questionsJSON, err := json.Marshal(questions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize questions: %w", err)
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	existing, err := s.questionnaireRepo.GetBySpace(spaceID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get questionnaire: %w", err)
+	}
+
+	if existing == nil {
+		questionnaire, err := s.questionnaireRepo.Create(&models.CheckInQuestionnaire{
+			SpaceID:     spaceID,
+			Title:       req.Title,
+			Questions:   datatypes.JSON(questionsJSON),
+			IsActive:    isActive,
+			UpdatedByID: userID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create questionnaire: %w", err)
+		}
+		return questionnaire, nil
+	}
+
+	updates := map[string]interface{}{
+		"title":         req.Title,
+		"questions":     datatypes.JSON(questionsJSON),
+		"is_active":     isActive,
+		"updated_by_id": userID,
+	}
+	if err := s.questionnaireRepo.Update(existing.ID, updates); err != nil {
+		return nil, fmt.Errorf("failed to update questionnaire: %w", err)
+	}
+
+	return s.questionnaireRepo.GetBySpace(spaceID)
+}
+
+// GetSpaceQuestionnaire retrieves the check-in questionnaire of a space
+func (s *CheckInQuestionnaireService) GetSpaceQuestionnaire(spaceID uuid.UUID) (*models.CheckInQuestionnaire, error) {
+	questionnaire, err := s.questionnaireRepo.GetBySpace(spaceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("space has no check-in questionnaire")
+		}
+		return nil, fmt.Errorf("failed to get questionnaire: %w", err)
+	}
+	return questionnaire, nil
+}
+
+// DeleteSpaceQuestionnaire removes the check-in questionnaire of a space. Stored answers are kept.
+func (s *CheckInQuestionnaireService) DeleteSpaceQuestionnaire(spaceID uuid.UUID) error {
+	questionnaire, err := s.GetSpaceQuestionnaire(spaceID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.questionnaireRepo.Delete(questionnaire.ID); err != nil {
+		return fmt.Errorf("failed to delete questionnaire: %w", err)
+	}
+	return nil
+}
+
+// ========================================
+// USER ANSWERS
+// ========================================
+
+// GetReservationQuestionnaire returns the questionnaire a user must answer before checking in
+func (s *CheckInQuestionnaireService) GetReservationQuestionnaire(reservationID, userID uuid.UUID) (*dto.ReservationQuestionnaireResponse, error) {
+	reservation, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	if !reservation.IsOrganizer(userID) {
+		return nil, dto.ErrAccessDenied
+	}
+
+	// A submitted questionnaire is shown as answered, even if the space changed it since
+	submission, err := s.questionnaireRepo.GetSubmissionByReservation(reservationID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get submission: %w", err)
+	}
+	questionnaire, err := s.GetSpaceQuestionnaire(reservation.SpaceID)
+	if submission != nil {
+		title := ""
+		if err == nil {
+			title = questionnaire.Title
+		}
+		return toReservationQuestionnaireResponse(reservationID, submission.QuestionnaireID, title, submission)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !questionnaire.IsActive {
+		return nil, errors.New("space has no check-in questionnaire")
+	}
+
+	questions, err := questionnaire.GetQuestions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read questions: %w", err)
+	}
+
+	return &dto.ReservationQuestionnaireResponse{
+		ReservationID:   reservationID,
+		QuestionnaireID: questionnaire.ID,
+		Title:           questionnaire.Title,
+		Questions:       questions,
+	}, nil
+}
+
+// SubmitAnswers validates and stores a user's answers for a reservation
+func (s *CheckInQuestionnaireService) SubmitAnswers(reservationID uuid.UUID, req *dto.SubmitQuestionnaireRequest, userID uuid.UUID) (*dto.ReservationQuestionnaireResponse, error) {
+	reservation, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	if !reservation.IsOrganizer(userID) {
+		return nil, dto.ErrAccessDenied
+	}
+	if reservation.Status != models.StatusConfirmed || reservation.CheckInTime != nil || time.Now().After(reservation.EndTime) {
+		return nil, errors.New("reservation does not accept answers")
+	}
+
+	if _, err := s.questionnaireRepo.GetSubmissionByReservation(reservationID); err == nil {
+		return nil, errors.New("questionnaire already submitted")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get submission: %w", err)
+	}
+
+	questionnaire, err := s.GetSpaceQuestionnaire(reservation.SpaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !questionnaire.IsActive {
+		return nil, errors.New("space has no check-in questionnaire")
+	}
+
+	questions, err := questionnaire.GetQuestions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read questions: %w", err)
+	}
+
+	answers, err := validateAnswers(questions, req.Answers)
+	if err != nil {
+		return nil, err
+	}
+
+	answersJSON, err := json.Marshal(answers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize answers: %w", err)
+	}
+
+	submission, err := s.questionnaireRepo.CreateSubmission(&models.QuestionnaireSubmission{
+		ReservationID:   reservationID,
+		QuestionnaireID: questionnaire.ID,
+		UserID:          userID,
+		Questions:       questionnaire.Questions,
+		Answers:         datatypes.JSON(answersJSON),
+		SubmittedAt:     time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store answers: %w", err)
+	}
+
+	return toReservationQuestionnaireResponse(reservationID, questionnaire.ID, questionnaire.Title, submission)
+}
+
+// ========================================
+// COMPLIANCE
+// ========================================
+
+// GetSpaceSubmissions lists the answers stored for reservations of a space in a period
+func (s *CheckInQuestionnaireService) GetSpaceSubmissions(spaceID uuid.UUID, from, to time.Time) ([]dto.QuestionnaireSubmissionResponse, error) {
+	if _, err := s.spaceRepo.GetByID(spaceID); err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	submissions, err := s.questionnaireRepo.GetSubmissionsBySpace(spaceID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions: %w", err)
+	}
+
+	responses := make([]dto.QuestionnaireSubmissionResponse, 0, len(submissions))
+	for _, submission := range submissions {
+		response := dto.QuestionnaireSubmissionResponse{
+			ID:            submission.ID,
+			ReservationID: submission.ReservationID,
+			UserID:        submission.UserID,
+			SubmittedAt:   submission.SubmittedAt,
+		}
+		if submission.Reservation != nil {
+			response.ReservationTitle = submission.Reservation.Title
+			response.StartTime = submission.Reservation.StartTime
+		}
+		if submission.User != nil {
+			response.UserName = submission.User.GetFullName()
+		}
+		if err := json.Unmarshal(submission.Questions, &response.Questions); err != nil {
+			return nil, fmt.Errorf("failed to read submission: %w", err)
+		}
+		if err := json.Unmarshal(submission.Answers, &response.Answers); err != nil {
+			return nil, fmt.Errorf("failed to read submission: %w", err)
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// validateQuestions checks a questionnaire definition is answerable
+func validateQuestions(requested []dto.QuestionnaireQuestion) ([]models.QuestionnaireQuestion, error) {
+	seen := make(map[string]bool, len(requested))
+	questions := make([]models.QuestionnaireQuestion, len(requested))
+
+	for i, q := range requested {
+		if seen[q.ID] {
+			return nil, fmt.Errorf("duplicate question id %q", q.ID)
+		}
+		seen[q.ID] = true
+
+		switch q.Type {
+		case models.QuestionTypeYesNo:
+			if q.ExpectedAnswer != "" && q.ExpectedAnswer != "yes" && q.ExpectedAnswer != "no" {
+				return nil, fmt.Errorf("expected answer of question %q must be yes or no", q.ID)
+			}
+		case models.QuestionTypeChoice:
+			if len(q.Options) < 2 {
+				return nil, fmt.Errorf("choice question %q needs at least two options", q.ID)
+			}
+			if q.ExpectedAnswer != "" && !containsString(q.Options, q.ExpectedAnswer) {
+				return nil, fmt.Errorf("expected answer of question %q is not one of its options", q.ID)
+			}
+		case models.QuestionTypeText:
+			if q.ExpectedAnswer != "" {
+				return nil, fmt.Errorf("text question %q cannot have an expected answer", q.ID)
+			}
+		}
+
+		questions[i] = models.QuestionnaireQuestion{
+			ID:             q.ID,
+			Text:           q.Text,
+			Type:           q.Type,
+			Required:       q.Required || q.ExpectedAnswer != "",
+			Options:        q.Options,
+			ExpectedAnswer: q.ExpectedAnswer,
+		}
+	}
+
+	return questions, nil
+}
+
+// validateAnswers checks the answers against the questions and drops answers to unknown questions
+func validateAnswers(questions []models.QuestionnaireQuestion, submitted map[string]string) (map[string]string, error) {
+	answers := make(map[string]string, len(questions))
+
+	for _, q := range questions {
+		answer := strings.TrimSpace(submitted[q.ID])
+		if answer == "" {
+			if q.Required {
+				return nil, fmt.Errorf("answer required for question %q", q.ID)
+			}
+			continue
+		}
+
+		switch q.Type {
+		case models.QuestionTypeYesNo:
+			answer = strings.ToLower(answer)
+			if answer != "yes" && answer != "no" {
+				return nil, fmt.Errorf("answer to question %q must be yes or no", q.ID)
+			}
+		case models.QuestionTypeChoice:
+			if !containsString(q.Options, answer) {
+				return nil, fmt.Errorf("answer to question %q is not one of its options", q.ID)
+			}
+		case models.QuestionTypeText:
+			if len(answer) > 1000 {
+				return nil, fmt.Errorf("answer to question %q is too long", q.ID)
+			}
+		}
+
+		if q.ExpectedAnswer != "" && answer != q.ExpectedAnswer {
+			return nil, fmt.Errorf("answer to question %q does not permit entry", q.ID)
+		}
+
+		answers[q.ID] = answer
+	}
+
+	return answers, nil
+}
+
+// toReservationQuestionnaireResponse converts a stored submission for the user
+func toReservationQuestionnaireResponse(reservationID, questionnaireID uuid.UUID, title string, submission *models.QuestionnaireSubmission) (*dto.ReservationQuestionnaireResponse, error) {
+	response := &dto.ReservationQuestionnaireResponse{
+		ReservationID:   reservationID,
+		QuestionnaireID: questionnaireID,
+		Title:           title,
+		Submitted:       true,
+		SubmittedAt:     &submission.SubmittedAt,
+	}
+	if err := json.Unmarshal(submission.Questions, &response.Questions); err != nil {
+		return nil, fmt.Errorf("failed to read submission: %w", err)
+	}
+	if err := json.Unmarshal(submission.Answers, &response.Answers); err != nil {
+		return nil, fmt.Errorf("failed to read submission: %w", err)
+	}
+	return response, nil
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,316 @@
+// internal/services/compliance_export_service.go
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// A compliance export is complete or not produced; larger requests must narrow the dates
+	complianceMaxMessages        = 100000
+	complianceHashAlgorithm      = "sha256-chain"
+	complianceSignatureAlgorithm = "ed25519"
+)
+
+// ComplianceExportService produces tamper-evident message exports for legal requests.
+// Unlike the transcript export it is never truncated, hash-chains every message,
+// signs the manifest with the server key and keeps a record of who exported what.
+type ComplianceExportService struct {
+	exportRepo interfaces.ComplianceExportRepositoryInterface
+	chatRepo   interfaces.ChatRepository
+	signingKey ed25519.PrivateKey
+	keyID      string
+	logger     *slog.Logger
+}
+
+// NewComplianceExportService creates a new compliance export service. signingSeed is a
+// base64 Ed25519 seed; exports are only signed by a dedicated key, so without a valid one
+// the service is not created.
+func NewComplianceExportService(
+	exportRepo interfaces.ComplianceExportRepositoryInterface,
+	chatRepo interfaces.ChatRepository,
+	signingSeed string,
+	logger *slog.Logger,
+) (*ComplianceExportService, error) {
+	if signingSeed == "" {
+		return nil, errors.New("no compliance signing key configured")
+	}
+	seed, err := base64.StdEncoding.DecodeString(signingSeed)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("compliance signing key must be a base64 %d-byte Ed25519 seed", ed25519.SeedSize)
+	}
+
+	key := ed25519.NewKeyFromSeed(seed)
+	fingerprint := sha256.Sum256(key.Public().(ed25519.PublicKey))
+
+	return &ComplianceExportService{
+		exportRepo: exportRepo,
+		chatRepo:   chatRepo,
+		signingKey: key,
+		keyID:      hex.EncodeToString(fingerprint[:8]),
+		logger:     logger,
+	}, nil
+}
+
+// ExportConversation exports every message of a conversation within the dates
+func (s *ComplianceExportService) ExportConversation(ctx context.Context, conversationID uuid.UUID, req *dto.ComplianceExportRequest, auditorID uuid.UUID) (*dto.ComplianceExportBundle, error) {
+	if _, err := s.chatRepo.GetConversationByID(ctx, conversationID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	record := &models.ComplianceExport{
+		Scope:          models.ComplianceScopeConversation,
+		ConversationID: &conversationID,
+	}
+	return s.export(ctx, record, req, auditorID)
+}
+
+// ExportUserMessages exports every message a user sent within the dates, across all
+// conversations. The user may have been deleted since; their messages are still exported.
+func (s *ComplianceExportService) ExportUserMessages(ctx context.Context, subjectUserID uuid.UUID, req *dto.ComplianceExportRequest, auditorID uuid.UUID) (*dto.ComplianceExportBundle, error) {
+	record := &models.ComplianceExport{
+		Scope:         models.ComplianceScopeUser,
+		SubjectUserID: &subjectUserID,
+	}
+	return s.export(ctx, record, req, auditorID)
+}
+
+// Verify checks that a bundle's hash chain is intact, its manifest is signed by this
+// server and the export is on record with the same head hash
+func (s *ComplianceExportService) Verify(bundle *dto.ComplianceExportBundle) (*dto.ComplianceVerification, error) {
+	result := &dto.ComplianceVerification{ChainIntact: true}
+	manifest := bundle.Manifest
+
+	if manifest.HashAlgorithm != complianceHashAlgorithm {
+		return nil, fmt.Errorf("unsupported hash algorithm %q", manifest.HashAlgorithm)
+	}
+
+	if genesis := complianceGenesisHash(manifest.ExportID); manifest.GenesisHash != genesis {
+		result.ChainIntact = false
+		result.Problems = append(result.Problems, "genesis hash does not match the export ID")
+	}
+
+	prevHash := manifest.GenesisHash
+	for i, entry := range bundle.Entries {
+		if entry.Sequence != i+1 {
+			result.ChainIntact = false
+			result.Problems = append(result.Problems, fmt.Sprintf("entry %d is out of sequence", i+1))
+		}
+		if entry.PrevHash != prevHash {
+			result.ChainIntact = false
+			result.Problems = append(result.Problems, fmt.Sprintf("entry %d does not link to the previous entry", i+1))
+		}
+		hash, err := complianceEntryHash(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash entry: %w", err)
+		}
+		if entry.Hash != hash {
+			result.ChainIntact = false
+			result.Problems = append(result.Problems, fmt.Sprintf("entry %d was modified", i+1))
+		}
+		prevHash = entry.Hash
+	}
+	if manifest.HeadHash != prevHash {
+		result.ChainIntact = false
+		result.Problems = append(result.Problems, "head hash does not match the last entry")
+	}
+	if manifest.MessageCount != len(bundle.Entries) {
+		result.ChainIntact = false
+		result.Problems = append(result.Problems, "message count does not match the entries")
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(bundle.Signature)
+	if err == nil && manifest.KeyID == s.keyID {
+		payload, err := json.Marshal(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode manifest: %w", err)
+		}
+		result.SignatureValid = ed25519.Verify(s.signingKey.Public().(ed25519.PublicKey), payload, signature)
+	}
+	if !result.SignatureValid {
+		result.Problems = append(result.Problems, "manifest signature is not valid for this server's key")
+	}
+
+	record, err := s.exportRepo.GetByID(manifest.ExportID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get compliance export: %w", err)
+	}
+	result.IssuedByServer = record != nil && record.HeadHash == manifest.HeadHash && record.Signature == bundle.Signature
+	if !result.IssuedByServer {
+		result.Problems = append(result.Problems, "no export with this ID and head hash was issued")
+	}
+
+	result.Valid = result.ChainIntact && result.SignatureValid && result.IssuedByServer
+	return result, nil
+}
+
+// GetExports lists the compliance exports issued, newest first, optionally for one case
+func (s *ComplianceExportService) GetExports(caseReference string, offset, limit int) ([]*models.ComplianceExport, int64, error) {
+	exports, total, err := s.exportRepo.GetAll(caseReference, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get compliance exports: %w", err)
+	}
+	return exports, total, nil
+}
+
+// PublicKey returns the key third parties use to check manifest signatures
+func (s *ComplianceExportService) PublicKey() dto.ComplianceSigningKey {
+	return dto.ComplianceSigningKey{
+		Algorithm: complianceSignatureAlgorithm,
+		KeyID:     s.keyID,
+		PublicKey: base64.StdEncoding.EncodeToString(s.signingKey.Public().(ed25519.PublicKey)),
+	}
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// export builds, signs and records the bundle for the conversation or subject set on the record
+func (s *ComplianceExportService) export(ctx context.Context, record *models.ComplianceExport, req *dto.ComplianceExportRequest, auditorID uuid.UUID) (*dto.ComplianceExportBundle, error) {
+	from, to := utcTime(req.From), utcTime(req.To)
+	if from != nil && to != nil && !to.After(*from) {
+		return nil, errors.New("to must be after from")
+	}
+
+	// Fetch one extra message to detect an export that would be incomplete
+	messages, err := s.chatRepo.GetMessagesForCompliance(ctx, record.ConversationID, record.SubjectUserID, from, to, complianceMaxMessages+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+	if len(messages) > complianceMaxMessages {
+		return nil, fmt.Errorf("more than %d messages match, narrow the date range", complianceMaxMessages)
+	}
+
+	record.ID = uuid.New()
+	manifest := dto.ComplianceManifest{
+		ExportID:           record.ID,
+		Scope:              string(record.Scope),
+		ConversationID:     record.ConversationID,
+		SubjectUserID:      record.SubjectUserID,
+		CaseReference:      req.CaseReference,
+		Reason:             req.Reason,
+		From:               from,
+		To:                 to,
+		GeneratedAt:        time.Now().UTC(),
+		GeneratedBy:        auditorID,
+		MessageCount:       len(messages),
+		HashAlgorithm:      complianceHashAlgorithm,
+		GenesisHash:        complianceGenesisHash(record.ID),
+		SignatureAlgorithm: complianceSignatureAlgorithm,
+		KeyID:              s.keyID,
+	}
+
+	entries := make([]dto.ComplianceEntry, len(messages))
+	prevHash := manifest.GenesisHash
+	for i, message := range messages {
+		entry := dto.ComplianceEntry{
+			Sequence:       i + 1,
+			MessageID:      message.ID,
+			ConversationID: message.ConversationID,
+			SenderID:       message.SenderID,
+			SenderName:     message.SenderName,
+			SenderType:     string(message.SenderType),
+			MessageType:    string(message.MessageType),
+			Content:        displayContent(&message),
+			ReplyToID:      message.ReplyToID,
+			IsEdited:       message.IsEdited,
+			EditedAt:       utcTime(message.EditedAt),
+			CreatedAt:      message.CreatedAt.UTC(),
+			PrevHash:       prevHash,
+		}
+		for _, attachment := range message.Attachments {
+			entry.Attachments = append(entry.Attachments, dto.ComplianceAttachment{
+				FileName:   attachment.FileName,
+				FileType:   attachment.FileType,
+				FileSize:   attachment.FileSize,
+				ScanStatus: string(attachment.ScanStatus),
+			})
+		}
+
+		entry.Hash, err = complianceEntryHash(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash message: %w", err)
+		}
+		prevHash = entry.Hash
+		entries[i] = entry
+	}
+	manifest.HeadHash = prevHash
+	if len(entries) > 0 {
+		manifest.FirstMessageAt = &entries[0].CreatedAt
+		manifest.LastMessageAt = &entries[len(entries)-1].CreatedAt
+	}
+
+	payload, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(s.signingKey, payload))
+
+	record.CaseReference = req.CaseReference
+	record.Reason = req.Reason
+	record.From = from
+	record.To = to
+	record.MessageCount = len(entries)
+	record.HeadHash = manifest.HeadHash
+	record.Signature = signature
+	record.KeyID = s.keyID
+	record.RequestedByID = auditorID
+	if _, err := s.exportRepo.Create(record); err != nil {
+		return nil, fmt.Errorf("failed to record compliance export: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "Compliance export issued", "exportID", record.ID, "scope", record.Scope,
+		"caseReference", record.CaseReference, "messages", record.MessageCount, "auditorID", auditorID)
+
+	return &dto.ComplianceExportBundle{
+		Manifest:  manifest,
+		Entries:   entries,
+		Signature: signature,
+	}, nil
+}
+
+// complianceGenesisHash is the previous hash of an export's first entry, tying the chain to the export
+func complianceGenesisHash(exportID uuid.UUID) string {
+	sum := sha256.Sum256([]byte("compliance-export:" + exportID.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// complianceEntryHash hashes the previous hash followed by the entry's JSON encoding without its own hash
+func complianceEntryHash(entry dto.ComplianceEntry) (string, error) {
+	entry.Hash = ""
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(entry.PrevHash), payload...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// utcTime converts an optional time to UTC so encodings stay the same after a round trip
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
@@ -0,0 +1,57 @@
+// internal/services/cost_center_service.go
+package services
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// CostCenterService manages the list of cost centers reservations can be charged to
+type CostCenterService struct {
+	costCenterRepo interfaces.CostCenterRepositoryInterface
+}
+
+// NewCostCenterService creates a new cost center service
+func NewCostCenterService(costCenterRepo interfaces.CostCenterRepositoryInterface) *CostCenterService {
+	return &CostCenterService{
+		costCenterRepo: costCenterRepo,
+	}
+}
+
+// GetCostCenters lists the cost centers by code, optionally only the ones new bookings can use
+func (s *CostCenterService) GetCostCenters(activeOnly bool) ([]*models.CostCenter, error) {
+	costCenters, err := s.costCenterRepo.GetAll(activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cost centers: %w", err)
+	}
+	return costCenters, nil
+}
+
+// SetCostCenter adds a cost center to the list or changes its name and status
+func (s *CostCenterService) SetCostCenter(code string, req *dto.SetCostCenterRequest, userID uuid.UUID) (*models.CostCenter, error) {
+	code = models.NormalizeCostCenterCode(code)
+	if code == "" || len(code) > 50 {
+		return nil, fmt.Errorf("cost center code must be 1 to 50 characters")
+	}
+
+	costCenter, err := s.costCenterRepo.Save(&models.CostCenter{
+		Code:        code,
+		Name:        req.Name,
+		IsActive:    req.IsActive == nil || *req.IsActive,
+		UpdatedByID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save cost center: %w", err)
+	}
+	return costCenter, nil
+}
+
+// DeleteCostCenter removes a cost center from the list; reservations charged to it keep the code
+func (s *CostCenterService) DeleteCostCenter(code string) error {
+	return s.costCenterRepo.Delete(models.NormalizeCostCenterCode(code))
+}
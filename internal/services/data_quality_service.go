@@ -0,0 +1,232 @@
+// internal/services/data_quality_service.go
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// Upper bound of issues of each type found by one scan
+const dataQualityScanLimit = 500
+
+// DataQualityService scans reservation data for inconsistencies that validation should have
+// prevented and applies the fix-up action of each one on request
+type DataQualityService struct {
+	dataQualityRepo    interfaces.DataQualityRepositoryInterface
+	reservationService *ReservationService
+	logger             *slog.Logger
+}
+
+// NewDataQualityService creates a new data quality service
+func NewDataQualityService(
+	dataQualityRepo interfaces.DataQualityRepositoryInterface,
+	reservationService *ReservationService,
+	logger *slog.Logger,
+) *DataQualityService {
+	return &DataQualityService{
+		dataQualityRepo:    dataQualityRepo,
+		reservationService: reservationService,
+		logger:             logger,
+	}
+}
+
+// dataQualityIssue is a reported issue with what its fix needs to know
+type dataQualityIssue struct {
+	dto.DataQualityIssue
+	participantKind string // Set for orphaned participants
+}
+
+// ========================================
+// REPORTING
+// ========================================
+
+// GetReport runs every check and lists the issues found, up to a limit per type
+func (s *DataQualityService) GetReport() (*dto.DataQualityReport, error) {
+	report := &dto.DataQualityReport{
+		GeneratedAt: time.Now(),
+		Counts:      make(map[string]int),
+		Issues:      []dto.DataQualityIssue{},
+	}
+
+	for _, issueType := range []string{
+		dto.DataQualityCheckInWithoutConfirmation,
+		dto.DataQualityOverlappingConfirmed,
+		dto.DataQualityOrphanedParticipant,
+		dto.DataQualityDeletedSpace,
+	} {
+		issues, err := s.findIssues(issueType)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Counts[issueType] = len(issues)
+		for _, issue := range issues {
+			report.Issues = append(report.Issues, issue.DataQualityIssue)
+		}
+	}
+	report.Total = len(report.Issues)
+
+	return report, nil
+}
+
+// ========================================
+// FIXES
+// ========================================
+
+// Fix applies the fix-up action to the issues of a type that are still found, carrying on
+// past failures. Issues are checked again first, so only current inconsistencies change.
+func (s *DataQualityService) Fix(req *dto.FixDataQualityRequest, adminID uuid.UUID) (*dto.FixDataQualityResponse, error) {
+	issues, err := s.findIssues(req.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.IDs) > 0 {
+		selected := make(map[uuid.UUID]bool, len(req.IDs))
+		for _, id := range req.IDs {
+			selected[id] = true
+		}
+
+		var matched []dataQualityIssue
+		for _, issue := range issues {
+			if selected[issue.RecordID] {
+				matched = append(matched, issue)
+			}
+		}
+		issues = matched
+	}
+
+	result := &dto.FixDataQualityResponse{Type: req.Type, Matched: len(issues)}
+	for _, issue := range issues {
+		if err := s.fix(issue, adminID); err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, dto.DataQualityFixError{RecordID: issue.RecordID, Error: err.Error()})
+			continue
+		}
+		result.Fixed++
+	}
+
+	s.logger.Info("Data quality issues fixed", "type", req.Type, "matched", result.Matched, "fixed", result.Fixed, "failed", result.Failed, "adminID", adminID)
+	return result, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// findIssues runs the check of one issue type
+func (s *DataQualityService) findIssues(issueType string) ([]dataQualityIssue, error) {
+	now := time.Now()
+	var issues []dataQualityIssue
+
+	switch issueType {
+	case dto.DataQualityCheckInWithoutConfirmation:
+		reservations, err := s.dataQualityRepo.FindCheckInsWithoutConfirmation(dataQualityScanLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find check-ins without confirmation: %w", err)
+		}
+		for _, reservation := range reservations {
+			issues = append(issues, dataQualityIssue{DataQualityIssue: dto.DataQualityIssue{
+				Type:          issueType,
+				RecordID:      reservation.ID,
+				ReservationID: &reservation.ID,
+				Description:   fmt.Sprintf("Checked in at %s while %s", reservation.CheckInTime.Format(time.RFC3339), reservation.Status),
+				FixAction:     dto.DataQualityFixClearCheckIn,
+			}})
+		}
+
+	case dto.DataQualityOverlappingConfirmed:
+		overlaps, err := s.dataQualityRepo.FindOverlappingConfirmed(now, dataQualityScanLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find overlapping reservations: %w", err)
+		}
+		// A booking over several others is reported, and cancelled, once
+		seen := make(map[uuid.UUID]bool)
+		for _, overlap := range overlaps {
+			if seen[overlap.ReservationID] {
+				continue
+			}
+			seen[overlap.ReservationID] = true
+
+			overlap := overlap
+			issues = append(issues, dataQualityIssue{DataQualityIssue: dto.DataQualityIssue{
+				Type:          issueType,
+				RecordID:      overlap.ReservationID,
+				ReservationID: &overlap.ReservationID,
+				RelatedID:     &overlap.OtherID,
+				Description: fmt.Sprintf("Confirmed for %s to %s over an earlier confirmed booking of the same space",
+					overlap.StartTime.Format(time.RFC3339), overlap.EndTime.Format(time.RFC3339)),
+				FixAction: dto.DataQualityFixCancel,
+			}})
+		}
+
+	case dto.DataQualityOrphanedParticipant:
+		orphans, err := s.dataQualityRepo.FindOrphanedParticipants(dataQualityScanLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find orphaned participants: %w", err)
+		}
+		for _, orphan := range orphans {
+			orphan := orphan
+			issues = append(issues, dataQualityIssue{
+				DataQualityIssue: dto.DataQualityIssue{
+					Type:          issueType,
+					RecordID:      orphan.ID,
+					ReservationID: &orphan.ReservationID,
+					Description:   fmt.Sprintf("Reservation %s left behind: %s", orphan.Kind, orphan.Reason),
+					FixAction:     dto.DataQualityFixDelete,
+				},
+				participantKind: orphan.Kind,
+			})
+		}
+
+	case dto.DataQualityDeletedSpace:
+		reservations, err := s.dataQualityRepo.FindReservationsOnDeletedSpaces(now, dataQualityScanLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find reservations on deleted spaces: %w", err)
+		}
+		for _, reservation := range reservations {
+			issues = append(issues, dataQualityIssue{DataQualityIssue: dto.DataQualityIssue{
+				Type:          issueType,
+				RecordID:      reservation.ID,
+				ReservationID: &reservation.ID,
+				Description:   fmt.Sprintf("Still %s for %s on a deleted space", reservation.Status, reservation.StartTime.Format(time.RFC3339)),
+				FixAction:     dto.DataQualityFixCancel,
+			}})
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown issue type: %s", issueType)
+	}
+
+	return issues, nil
+}
+
+// fix applies the fix-up action of an issue
+func (s *DataQualityService) fix(issue dataQualityIssue, adminID uuid.UUID) error {
+	switch issue.FixAction {
+	case dto.DataQualityFixClearCheckIn:
+		if err := s.dataQualityRepo.ClearCheckIn(issue.RecordID); err != nil {
+			return fmt.Errorf("failed to clear check-in: %w", err)
+		}
+		return nil
+	case dto.DataQualityFixCancel:
+		reason := "Cancelled by data quality check: booked over another confirmed reservation"
+		if issue.Type == dto.DataQualityDeletedSpace {
+			reason = "Cancelled by data quality check: the space was removed"
+		}
+		return s.reservationService.CancelReservation(issue.RecordID, reason, adminID)
+	case dto.DataQualityFixDelete:
+		if err := s.dataQualityRepo.DeleteParticipant(issue.participantKind, issue.RecordID); err != nil {
+			return fmt.Errorf("failed to delete participant: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown fix action %s", issue.FixAction)
+	}
+}
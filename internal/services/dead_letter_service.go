@@ -0,0 +1,333 @@
+// internal/services/dead_letter_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/jobs"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// Upper bound of dead letters replayed by one bulk request
+const deadLetterReplayBatch = 500
+
+// DeadLetterService lets admins inspect, edit and replay async work that gave up: emails out
+// of retries, webhook deliveries out of attempts and failed job runs. Replayed notifications
+// and deliveries go back to their queue with fresh retries; jobs run again right away.
+type DeadLetterService struct {
+	deadLetterRepo   interfaces.DeadLetterRepositoryInterface
+	notificationRepo interfaces.NotificationRepositoryInterface
+	webhookRepo      interfaces.WebhookRepositoryInterface
+	scheduler        *jobs.Scheduler
+	logger           *slog.Logger
+}
+
+// NewDeadLetterService creates a new dead letter service
+func NewDeadLetterService(
+	deadLetterRepo interfaces.DeadLetterRepositoryInterface,
+	notificationRepo interfaces.NotificationRepositoryInterface,
+	webhookRepo interfaces.WebhookRepositoryInterface,
+	scheduler *jobs.Scheduler,
+	logger *slog.Logger,
+) *DeadLetterService {
+	return &DeadLetterService{
+		deadLetterRepo:   deadLetterRepo,
+		notificationRepo: notificationRepo,
+		webhookRepo:      webhookRepo,
+		scheduler:        scheduler,
+		logger:           logger,
+	}
+}
+
+// ========================================
+// RECORDING
+// ========================================
+
+// RecordJobFailure keeps a failed job run; it is registered as the scheduler's failure hook
+func (s *DeadLetterService) RecordJobFailure(name string, jobErr error) {
+	payload, _ := json.Marshal(map[string]string{"job": name})
+	if _, err := s.deadLetterRepo.Record(&models.DeadLetter{
+		Source:    models.DeadLetterSourceJob,
+		Name:      name,
+		Payload:   datatypes.JSON(payload),
+		LastError: jobErr.Error(),
+	}); err != nil {
+		s.logger.Error("Failed to record dead letter", "job", name, "error", err)
+	}
+}
+
+// ========================================
+// INSPECTION
+// ========================================
+
+// GetDeadLetters lists dead letters, filtered by source and status when set
+func (s *DeadLetterService) GetDeadLetters(source, status string, offset, limit int) ([]*models.DeadLetter, int64, error) {
+	switch models.DeadLetterSource(source) {
+	case "", models.DeadLetterSourceNotification, models.DeadLetterSourceWebhook, models.DeadLetterSourceJob:
+	default:
+		return nil, 0, fmt.Errorf("invalid source: %s", source)
+	}
+	switch models.DeadLetterStatus(status) {
+	case "", models.DeadLetterStatusOpen, models.DeadLetterStatusReplayed, models.DeadLetterStatusDiscarded:
+	default:
+		return nil, 0, fmt.Errorf("invalid status: %s", status)
+	}
+
+	letters, total, err := s.deadLetterRepo.GetAll(models.DeadLetterSource(source), models.DeadLetterStatus(status), offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get dead letters: %w", err)
+	}
+	return letters, total, nil
+}
+
+// GetDeadLetter retrieves a dead letter with its payload
+func (s *DeadLetterService) GetDeadLetter(id uuid.UUID) (*models.DeadLetter, error) {
+	letter, err := s.deadLetterRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+	return letter, nil
+}
+
+// ========================================
+// ADMIN OPERATIONS
+// ========================================
+
+// UpdatePayload replaces the payload an open dead letter is replayed with
+func (s *DeadLetterService) UpdatePayload(id uuid.UUID, req *dto.UpdateDeadLetterRequest) (*models.DeadLetter, error) {
+	letter, err := s.getOpen(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload []byte
+	switch letter.Source {
+	case models.DeadLetterSourceNotification:
+		payload, err = s.editNotificationPayload(letter, req.Payload)
+		if err != nil {
+			return nil, err
+		}
+	case models.DeadLetterSourceWebhook:
+		var body map[string]interface{}
+		if err := json.Unmarshal(req.Payload, &body); err != nil {
+			return nil, errors.New("webhook payload must be a JSON object")
+		}
+		payload = req.Payload
+	default:
+		return nil, errors.New("job runs have no payload to edit")
+	}
+
+	if err := s.deadLetterRepo.Update(letter.ID, map[string]interface{}{
+		"payload":   datatypes.JSON(payload),
+		"edited_at": time.Now(),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update dead letter: %w", err)
+	}
+	return s.deadLetterRepo.GetByID(letter.ID)
+}
+
+// Replay sends an open dead letter again and marks it replayed. A job that fails again
+// stays open with its failure counted.
+func (s *DeadLetterService) Replay(ctx context.Context, id, adminID uuid.UUID) (*models.DeadLetter, error) {
+	letter, err := s.getOpen(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.replay(ctx, letter, adminID); err != nil {
+		return nil, err
+	}
+	return s.deadLetterRepo.GetByID(letter.ID)
+}
+
+// ReplayBulk replays the selected open dead letters, carrying on past failures
+func (s *DeadLetterService) ReplayBulk(ctx context.Context, req *dto.ReplayDeadLettersRequest, adminID uuid.UUID) (*dto.ReplayDeadLettersResponse, error) {
+	letters, err := s.deadLetterRepo.GetOpen(req.IDs, models.DeadLetterSource(req.Source), deadLetterReplayBatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead letters: %w", err)
+	}
+
+	result := &dto.ReplayDeadLettersResponse{Matched: len(letters)}
+	for _, letter := range letters {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if err := s.replay(ctx, letter, adminID); err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, dto.DeadLetterReplayError{ID: letter.ID, Error: err.Error()})
+			continue
+		}
+		result.Replayed++
+	}
+
+	s.logger.InfoContext(ctx, "Dead letters replayed", "matched", result.Matched, "replayed", result.Replayed, "failed", result.Failed)
+	return result, nil
+}
+
+// Discard closes an open dead letter without replaying it
+func (s *DeadLetterService) Discard(id, adminID uuid.UUID) error {
+	letter, err := s.getOpen(id)
+	if err != nil {
+		return err
+	}
+
+	return s.resolve(letter, models.DeadLetterStatusDiscarded, adminID)
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// getOpen loads a dead letter that can still be edited, replayed or discarded
+func (s *DeadLetterService) getOpen(id uuid.UUID) (*models.DeadLetter, error) {
+	letter, err := s.GetDeadLetter(id)
+	if err != nil {
+		return nil, err
+	}
+	if !letter.IsOpen() {
+		return nil, fmt.Errorf("dead letter is already %s", letter.Status)
+	}
+	return letter, nil
+}
+
+// replay puts the work of a dead letter back in motion and resolves it
+func (s *DeadLetterService) replay(ctx context.Context, letter *models.DeadLetter, adminID uuid.UUID) error {
+	var err error
+	switch letter.Source {
+	case models.DeadLetterSourceNotification:
+		err = s.requeueNotification(letter)
+	case models.DeadLetterSourceWebhook:
+		err = s.requeueDelivery(letter)
+	case models.DeadLetterSourceJob:
+		err = s.rerunJob(ctx, letter)
+	default:
+		err = fmt.Errorf("unknown dead letter source %s", letter.Source)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.resolve(letter, models.DeadLetterStatusReplayed, adminID)
+}
+
+// requeueNotification puts the notification back in the delivery queue with fresh retries,
+// using the edited title, message and data
+func (s *DeadLetterService) requeueNotification(letter *models.DeadLetter) error {
+	if letter.ReferenceID == nil {
+		return errors.New("dead letter has no notification")
+	}
+
+	var payload dto.NotificationDeadLetterPayload
+	if err := json.Unmarshal(letter.Payload, &payload); err != nil {
+		return fmt.Errorf("invalid notification payload: %v", err)
+	}
+
+	updates := map[string]interface{}{
+		"status":       models.NotificationStatusPending,
+		"retry_count":  0,
+		"last_error":   "",
+		"scheduled_at": time.Now(),
+		"title":        payload.Title,
+		"message":      payload.Message,
+	}
+	if len(payload.Data) > 0 {
+		updates["data"] = datatypes.JSON(payload.Data)
+	}
+
+	if err := s.notificationRepo.Update(*letter.ReferenceID, updates); err != nil {
+		return fmt.Errorf("failed to requeue notification: %w", err)
+	}
+	return nil
+}
+
+// requeueDelivery puts the webhook delivery back in the queue with fresh attempts and the
+// edited payload, signed again when sent
+func (s *DeadLetterService) requeueDelivery(letter *models.DeadLetter) error {
+	if letter.ReferenceID == nil {
+		return errors.New("dead letter has no webhook delivery")
+	}
+
+	if _, err := s.webhookRepo.GetDeliveryByID(*letter.ReferenceID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("webhook delivery no longer exists")
+		}
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	if err := s.webhookRepo.UpdateDelivery(*letter.ReferenceID, map[string]interface{}{
+		"status":          models.WebhookDeliveryPending,
+		"attempts":        0,
+		"last_error":      "",
+		"next_attempt_at": time.Now(),
+		"payload":         letter.Payload,
+	}); err != nil {
+		return fmt.Errorf("failed to requeue webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// rerunJob runs the job now. A failure is counted on the dead letter by the scheduler hook.
+func (s *DeadLetterService) rerunJob(ctx context.Context, letter *models.DeadLetter) error {
+	found, err := s.scheduler.RunNow(ctx, letter.Name)
+	if !found {
+		return fmt.Errorf("job %s is not registered", letter.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("job failed again: %v", err)
+	}
+	return nil
+}
+
+// resolve closes a dead letter
+func (s *DeadLetterService) resolve(letter *models.DeadLetter, status models.DeadLetterStatus, adminID uuid.UUID) error {
+	if err := s.deadLetterRepo.Update(letter.ID, map[string]interface{}{
+		"status":         status,
+		"resolved_at":    time.Now(),
+		"resolved_by_id": adminID,
+	}); err != nil {
+		return fmt.Errorf("failed to update dead letter: %w", err)
+	}
+	return nil
+}
+
+// editNotificationPayload applies the editable fields of a notification payload, keeping
+// the recipient of the original
+func (s *DeadLetterService) editNotificationPayload(letter *models.DeadLetter, raw json.RawMessage) ([]byte, error) {
+	var current, edited dto.NotificationDeadLetterPayload
+	if err := json.Unmarshal(letter.Payload, &current); err != nil {
+		return nil, fmt.Errorf("invalid notification payload: %v", err)
+	}
+	if err := json.Unmarshal(raw, &edited); err != nil {
+		return nil, errors.New("notification payload must be a JSON object")
+	}
+
+	if strings.TrimSpace(edited.Title) == "" || strings.TrimSpace(edited.Message) == "" {
+		return nil, errors.New("notification title and message are required")
+	}
+	if len(edited.Title) > 200 {
+		return nil, errors.New("notification title must be at most 200 characters")
+	}
+
+	current.Title = edited.Title
+	current.Message = edited.Message
+	if len(edited.Data) > 0 {
+		current.Data = edited.Data
+	}
+	return json.Marshal(current)
+}
@@ -0,0 +1,137 @@
+// internal/services/diagnostics_service.go
+package services
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/breaker"
+	"room-reservation-api/internal/database"
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/jobs"
+	"room-reservation-api/internal/repositories/interfaces"
+	"room-reservation-api/internal/version"
+	"room-reservation-api/internal/websocket"
+)
+
+// Time allowed for the database checks of a diagnostics report
+const diagnosticsDatabaseTimeout = 5 * time.Second
+
+// DiagnosticsService gathers the state of the running instance in one report: build,
+// schema, queues, realtime clients, caches, background jobs and integrations
+type DiagnosticsService struct {
+	db               *gorm.DB
+	scheduler        *jobs.Scheduler
+	wsManager        *websocket.Manager
+	notificationRepo interfaces.NotificationRepositoryInterface
+	outbox           *OutboxDispatcher
+	webhookService   *WebhookService
+	holidays         *HolidayCalendar
+	breakers         *breaker.Registry
+	startedAt        time.Time
+}
+
+// NewDiagnosticsService creates a new diagnostics service; uptime is counted from its creation
+func NewDiagnosticsService(
+	db *gorm.DB,
+	scheduler *jobs.Scheduler,
+	wsManager *websocket.Manager,
+	notificationRepo interfaces.NotificationRepositoryInterface,
+	outbox *OutboxDispatcher,
+	webhookService *WebhookService,
+	holidays *HolidayCalendar,
+	breakers *breaker.Registry,
+) *DiagnosticsService {
+	return &DiagnosticsService{
+		db:               db,
+		scheduler:        scheduler,
+		wsManager:        wsManager,
+		notificationRepo: notificationRepo,
+		outbox:           outbox,
+		webhookService:   webhookService,
+		holidays:         holidays,
+		breakers:         breakers,
+		startedAt:        time.Now(),
+	}
+}
+
+// GetDiagnostics builds the report. A section that cannot be gathered is listed under
+// errors instead of failing the report, since it is read while things are broken.
+func (s *DiagnosticsService) GetDiagnostics(ctx context.Context) *dto.DiagnosticsResponse {
+	now := time.Now()
+	report := &dto.DiagnosticsResponse{
+		GeneratedAt: now.UTC(),
+		Build:       version.Get(),
+		Caches:      []dto.CacheStats{s.holidays.CacheStats()},
+		Jobs:        s.scheduler.Status(),
+		Breakers:    s.breakers.Statuses(),
+	}
+
+	var memory runtime.MemStats
+	runtime.ReadMemStats(&memory)
+	report.Runtime = dto.RuntimeDiagnostics{
+		StartedAt:      s.startedAt.UTC(),
+		Uptime:         now.Sub(s.startedAt).Round(time.Second).String(),
+		Goroutines:     runtime.NumGoroutine(),
+		HeapAllocMB:    float64(memory.HeapAlloc) / (1 << 20),
+		GCPauseTotalMs: float64(memory.PauseTotalNs) / float64(time.Millisecond),
+	}
+
+	report.Database = s.databaseDiagnostics(ctx, report)
+
+	notificationsDue, err := s.notificationRepo.CountPendingDeliveries(now)
+	if err != nil {
+		report.Errors = append(report.Errors, "notification queue: "+err.Error())
+	}
+	report.Queues.NotificationsDue = notificationsDue
+
+	outbox, err := s.outbox.Backlog()
+	if err != nil {
+		report.Errors = append(report.Errors, "outbox: "+err.Error())
+	}
+	report.Queues.Outbox = outbox
+
+	webhooks, err := s.webhookService.Backlog()
+	if err != nil {
+		report.Errors = append(report.Errors, "webhook deliveries: "+err.Error())
+	}
+	report.Queues.Webhooks = webhooks
+
+	report.Realtime = dto.RealtimeDiagnostics{
+		Connections:      s.wsManager.GetConnectionStats(),
+		EventBusSequence: s.wsManager.EventBus().Latest(),
+	}
+
+	return report
+}
+
+// databaseDiagnostics checks the database answers, the schema is migrated and the pool is not exhausted
+func (s *DiagnosticsService) databaseDiagnostics(ctx context.Context, report *dto.DiagnosticsResponse) dto.DatabaseDiagnostics {
+	var diagnostics dto.DatabaseDiagnostics
+
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		report.Errors = append(report.Errors, "database: "+err.Error())
+		return diagnostics
+	}
+
+	stats := sqlDB.Stats()
+	diagnostics.OpenConnections = stats.OpenConnections
+	diagnostics.InUse = stats.InUse
+	diagnostics.WaitCount = stats.WaitCount
+	diagnostics.WaitDuration = stats.WaitDuration.String()
+
+	ctx, cancel := context.WithTimeout(ctx, diagnosticsDatabaseTimeout)
+	defer cancel()
+	if err := sqlDB.PingContext(ctx); err != nil {
+		report.Errors = append(report.Errors, "database: "+err.Error())
+		return diagnostics
+	}
+	diagnostics.Reachable = true
+	diagnostics.SchemaTables, diagnostics.MissingTables = database.SchemaStatus(s.db.WithContext(ctx))
+
+	return diagnostics
+}
@@ -0,0 +1,258 @@
+// internal/services/emergency_broadcast_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// EmergencyBroadcastService announces evacuations and other emergencies to everyone booked in a
+// building that day, stops the meetings under way there and tracks who acknowledged
+type EmergencyBroadcastService struct {
+	broadcastRepo       interfaces.EmergencyBroadcastRepositoryInterface
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	spaceRepo           interfaces.SpaceRepositoryInterface
+	notificationService *NotificationService
+	events              *OutboxDispatcher // Optional, nil leaves committed events to the dispatch job
+	logger              *slog.Logger
+}
+
+// NewEmergencyBroadcastService creates a new emergency broadcast service
+func NewEmergencyBroadcastService(
+	broadcastRepo interfaces.EmergencyBroadcastRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	notificationService *NotificationService,
+	events *OutboxDispatcher,
+	logger *slog.Logger,
+) *EmergencyBroadcastService {
+	return &EmergencyBroadcastService{
+		broadcastRepo:       broadcastRepo,
+		reservationRepo:     reservationRepo,
+		spaceRepo:           spaceRepo,
+		notificationService: notificationService,
+		events:              events,
+		logger:              logger,
+	}
+}
+
+// Broadcast announces an emergency in a building. Everyone with a booking there today is
+// notified in-app, over their realtime streams and by email, and the meetings checked in are
+// checked out or suspended until the all-clear.
+func (s *EmergencyBroadcastService) Broadcast(req *dto.CreateEmergencyBroadcastRequest, userID uuid.UUID) (*dto.EmergencyBroadcastResponse, error) {
+	action := models.EmergencyAction(req.Action)
+	if !action.IsValid() {
+		return nil, fmt.Errorf("invalid action %q", req.Action)
+	}
+
+	count, err := s.spaceRepo.CountSpacesByBuilding(req.Building)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check building: %w", err)
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("unknown building %q", req.Building)
+	}
+
+	now := time.Now()
+	checkIns, err := s.broadcastRepo.GetActiveCheckIns(req.Building, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active check-ins: %w", err)
+	}
+	today := startOfDayUTC(now)
+	recipientIDs, err := s.broadcastRepo.GetOrganizerIDs(req.Building, today, today.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get people booked in the building: %w", err)
+	}
+
+	broadcast, err := s.broadcastRepo.Create(&models.EmergencyBroadcast{
+		Building:         req.Building,
+		Title:            req.Title,
+		Message:          req.Message,
+		Action:           action,
+		CheckInsAffected: len(checkIns),
+		CreatedByID:      userID,
+	}, recipientIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create emergency broadcast: %w", err)
+	}
+
+	// People are told first; the meetings are stopped after
+	data := map[string]interface{}{
+		"emergency_id": broadcast.ID,
+		"building":     broadcast.Building,
+		"action":       broadcast.Action,
+	}
+	for _, recipientID := range recipientIDs {
+		if _, err := s.notificationService.Notify(recipientID, models.NotificationTypeEmergency, broadcast.Title, broadcast.Message, data); err != nil {
+			s.logger.Error("Failed to send emergency notification", "emergencyID", broadcast.ID, "userID", recipientID, "error", err)
+		}
+	}
+
+	s.stopCheckIns(broadcast, checkIns, now)
+	s.events.Wake()
+
+	s.logger.Warn("Emergency broadcast sent", "emergencyID", broadcast.ID, "building", broadcast.Building,
+		"action", broadcast.Action, "recipients", len(recipientIDs), "checkIns", len(checkIns))
+
+	response := toEmergencyBroadcastResponse(broadcast)
+	recipientCount, acknowledged := len(recipientIDs), 0
+	response.RecipientCount = &recipientCount
+	response.AcknowledgedCount = &acknowledged
+	return response, nil
+}
+
+// GetBroadcasts lists emergency broadcasts, most recent first
+func (s *EmergencyBroadcastService) GetBroadcasts(offset, limit int) ([]*dto.EmergencyBroadcastResponse, int64, error) {
+	broadcasts, total, err := s.broadcastRepo.List(offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get emergency broadcasts: %w", err)
+	}
+
+	responses := make([]*dto.EmergencyBroadcastResponse, len(broadcasts))
+	for i, broadcast := range broadcasts {
+		responses[i] = toEmergencyBroadcastResponse(broadcast)
+	}
+	return responses, total, nil
+}
+
+// GetBroadcast shows an emergency broadcast with who acknowledged it
+func (s *EmergencyBroadcastService) GetBroadcast(id uuid.UUID) (*dto.EmergencyBroadcastResponse, error) {
+	broadcast, err := s.broadcastRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get emergency broadcast: %w", err)
+	}
+	recipients, err := s.broadcastRepo.GetRecipients(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get emergency recipients: %w", err)
+	}
+
+	response := toEmergencyBroadcastResponse(broadcast)
+	acknowledged := 0
+	response.Recipients = make([]dto.EmergencyRecipientResponse, len(recipients))
+	for i, recipient := range recipients {
+		response.Recipients[i] = dto.EmergencyRecipientResponse{
+			UserID:         recipient.UserID,
+			AcknowledgedAt: recipient.AcknowledgedAt,
+		}
+		if recipient.User != nil {
+			response.Recipients[i].Name = recipient.User.GetFullName()
+			response.Recipients[i].Email = recipient.User.Email
+		}
+		if recipient.AcknowledgedAt != nil {
+			acknowledged++
+		}
+	}
+	recipientCount := len(recipients)
+	response.RecipientCount = &recipientCount
+	response.AcknowledgedCount = &acknowledged
+	return response, nil
+}
+
+// Acknowledge records that a user read an emergency broadcast sent to them
+func (s *EmergencyBroadcastService) Acknowledge(id, userID uuid.UUID) error {
+	recipient, err := s.broadcastRepo.GetRecipient(id, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("this emergency broadcast was not sent to you")
+		}
+		return fmt.Errorf("failed to get emergency recipient: %w", err)
+	}
+	if recipient.AcknowledgedAt != nil {
+		return nil
+	}
+
+	if err := s.broadcastRepo.Acknowledge(recipient.ID, time.Now()); err != nil {
+		return fmt.Errorf("failed to acknowledge emergency broadcast: %w", err)
+	}
+	return nil
+}
+
+// EndBroadcast gives the all-clear: suspended check-ins resume and recipients are told
+func (s *EmergencyBroadcastService) EndBroadcast(id uuid.UUID) (*dto.EmergencyBroadcastResponse, error) {
+	broadcast, err := s.broadcastRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get emergency broadcast: %w", err)
+	}
+	if broadcast.EndedAt != nil {
+		return nil, errors.New("emergency broadcast already ended")
+	}
+
+	broadcast, err = s.broadcastRepo.End(id, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to end emergency broadcast: %w", err)
+	}
+
+	recipients, err := s.broadcastRepo.GetRecipients(id)
+	if err != nil {
+		s.logger.Error("Failed to get emergency recipients for the all-clear", "emergencyID", id, "error", err)
+	}
+	title := "All clear: " + broadcast.Title
+	message := fmt.Sprintf("The emergency in %s is over.", broadcast.Building)
+	if broadcast.Action == models.EmergencyActionSuspend {
+		message += " Meetings that were checked in have resumed."
+	}
+	data := map[string]interface{}{"emergency_id": broadcast.ID, "building": broadcast.Building}
+	for _, recipient := range recipients {
+		if _, err := s.notificationService.Notify(recipient.UserID, models.NotificationTypeEmergencyAllClear, title, message, data); err != nil {
+			s.logger.Warn("Failed to send all-clear notification", "emergencyID", id, "userID", recipient.UserID, "error", err)
+		}
+	}
+	s.events.Wake()
+
+	s.logger.Info("Emergency broadcast ended", "emergencyID", id, "building", broadcast.Building)
+	return toEmergencyBroadcastResponse(broadcast), nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// stopCheckIns checks out or suspends the meetings checked in when the broadcast was sent.
+// Checked-out meetings give nothing back to be rebooked, as the building is being evacuated.
+func (s *EmergencyBroadcastService) stopCheckIns(broadcast *models.EmergencyBroadcast, checkIns []*models.Reservation, now time.Time) {
+	if len(checkIns) == 0 {
+		return
+	}
+
+	if broadcast.Action == models.EmergencyActionSuspend {
+		ids := make([]uuid.UUID, len(checkIns))
+		for i, reservation := range checkIns {
+			ids[i] = reservation.ID
+		}
+		if err := s.broadcastRepo.SuspendCheckIns(broadcast.ID, ids); err != nil {
+			s.logger.Error("Failed to suspend check-ins", "emergencyID", broadcast.ID, "error", err)
+		}
+		return
+	}
+
+	for _, reservation := range checkIns {
+		session := models.NewReservationSession(reservation, now)
+		if err := s.reservationRepo.CheckOut(session, nil); err != nil {
+			s.logger.Error("Failed to check out reservation for emergency", "emergencyID", broadcast.ID, "reservationID", reservation.ID, "error", err)
+		}
+	}
+}
+
+// toEmergencyBroadcastResponse converts a broadcast to its response
+func toEmergencyBroadcastResponse(broadcast *models.EmergencyBroadcast) *dto.EmergencyBroadcastResponse {
+	return &dto.EmergencyBroadcastResponse{
+		ID:               broadcast.ID,
+		Building:         broadcast.Building,
+		Title:            broadcast.Title,
+		Message:          broadcast.Message,
+		Action:           string(broadcast.Action),
+		CheckInsAffected: broadcast.CheckInsAffected,
+		CreatedByID:      broadcast.CreatedByID,
+		CreatedAt:        broadcast.CreatedAt,
+		EndedAt:          broadcast.EndedAt,
+	}
+}
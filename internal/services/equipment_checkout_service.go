@@ -0,0 +1,384 @@
+// internal/services/equipment_checkout_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// How early before its reservation starts equipment can be picked up
+	equipmentPickupLead = 30 * time.Minute
+	// How long after its reservation ends equipment still out is reported overdue
+	equipmentOverdueGrace = 15 * time.Minute
+	// Upper bound of overdue alerts sent per run
+	equipmentOverdueBatch = 100
+	// Largest inventory listed at once
+	equipmentInventoryLimit = 200
+)
+
+// EquipmentCheckoutService manages portable equipment (projectors, conference phones) attached to
+// reservations: the inventory and where each item is, attaching it for a reservation, pickup and
+// return confirmed by facility staff, and alerts when it is not brought back
+type EquipmentCheckoutService struct {
+	checkoutRepo        interfaces.EquipmentCheckoutRepositoryInterface
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	spaceRepo           interfaces.SpaceRepositoryInterface
+	notificationService *NotificationService
+	logger              *slog.Logger
+}
+
+// NewEquipmentCheckoutService creates a new equipment checkout service
+func NewEquipmentCheckoutService(
+	checkoutRepo interfaces.EquipmentCheckoutRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	notificationService *NotificationService,
+	logger *slog.Logger,
+) *EquipmentCheckoutService {
+	return &EquipmentCheckoutService{
+		checkoutRepo:        checkoutRepo,
+		reservationRepo:     reservationRepo,
+		spaceRepo:           spaceRepo,
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// ========================================
+// INVENTORY
+// ========================================
+
+// GetInventory lists the portable equipment, of a building when given, with whether each item is
+// checked out or overdue. With a time window it also tells whether each item can be booked in it.
+func (s *EquipmentCheckoutService) GetInventory(building string, startTime, endTime *time.Time) ([]dto.EquipmentStatusResponse, error) {
+	filters := interfaces.SpaceFilters{Types: []string{string(models.SpaceTypeEquipment)}, SortBy: "name"}
+	if building != "" {
+		filters.Buildings = []string{building}
+	}
+	equipment, _, err := s.spaceRepo.SearchSpaces(filters, 0, equipmentInventoryLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get equipment: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(equipment))
+	for _, item := range equipment {
+		ids = append(ids, item.ID)
+	}
+	checkouts := map[uuid.UUID]*models.ReservationResource{}
+	if len(ids) > 0 {
+		out, err := s.checkoutRepo.GetCheckedOut(ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get checked out equipment: %w", err)
+		}
+		for _, checkout := range out {
+			checkouts[checkout.ResourceID] = checkout
+		}
+	}
+
+	now := time.Now()
+	inventory := make([]dto.EquipmentStatusResponse, 0, len(equipment))
+	for _, item := range equipment {
+		status := dto.EquipmentStatusResponse{Equipment: dto.ToSpaceResponse(item)}
+		checkout, out := checkouts[item.ID]
+		if out {
+			status.CheckedOut = true
+			status.Overdue = checkout.IsOverdueAt(now)
+			status.ReservationID = &checkout.ReservationID
+			if checkout.Reservation != nil {
+				status.DueBackAt = &checkout.Reservation.EndTime
+			}
+		}
+		if startTime != nil && endTime != nil {
+			free := item.IsAvailable() && !status.Overdue
+			if free {
+				if free, err = s.reservationRepo.CheckTimeSlotAvailability(item.ID, *startTime, *endTime, nil); err != nil {
+					return nil, fmt.Errorf("failed to check equipment availability: %w", err)
+				}
+			}
+			status.Free = &free
+		}
+		inventory = append(inventory, status)
+	}
+	return inventory, nil
+}
+
+// ========================================
+// ORGANIZER
+// ========================================
+
+// GetReservationEquipment lists the equipment attached to a reservation and its checkout
+func (s *EquipmentCheckoutService) GetReservationEquipment(reservationID, userID uuid.UUID) ([]*models.ReservationResource, error) {
+	if _, err := s.organizedReservation(reservationID, userID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.checkoutRepo.GetByReservation(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation equipment: %w", err)
+	}
+	return links, nil
+}
+
+// AttachEquipment reserves portable equipment for the whole of an upcoming reservation. The
+// equipment must be in the reservation's building and free during it.
+func (s *EquipmentCheckoutService) AttachEquipment(reservationID uuid.UUID, req *dto.AttachEquipmentRequest, userID uuid.UUID) (*models.ReservationResource, error) {
+	reservation, err := s.organizedReservation(reservationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Status != models.StatusConfirmed && reservation.Status != models.StatusPending {
+		return nil, fmt.Errorf("equipment cannot be attached to a %s reservation", reservation.Status)
+	}
+	if !reservation.EndTime.After(time.Now()) {
+		return nil, errors.New("equipment cannot be attached to a reservation that has ended")
+	}
+	if req.ResourceID == reservation.SpaceID {
+		return nil, errors.New("the booked space cannot also be booked as an add-on")
+	}
+
+	equipment, err := s.spaceRepo.GetByID(req.ResourceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get equipment: %w", err)
+	}
+	if models.ResourceClassOf(equipment.Type) != models.ResourceClassEquipment || equipment.Building != reservation.Space.Building {
+		return nil, dto.NewResourceNotAddOnError(equipment.Name, reservation.Space.Building)
+	}
+	if !equipment.IsAvailable() {
+		return nil, fmt.Errorf("%s is not available for booking", equipment.Name)
+	}
+	for _, booked := range reservation.Resources {
+		if booked.ResourceID == equipment.ID {
+			return nil, fmt.Errorf("%s is already attached to this reservation", equipment.Name)
+		}
+	}
+
+	available, err := s.reservationRepo.CheckTimeSlotAvailability(equipment.ID, reservation.StartTime, reservation.EndTime, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check equipment availability: %w", err)
+	}
+	if !available {
+		return nil, dto.NewResourceBookedError(equipment.Name)
+	}
+	if err := s.checkNotOverdue(equipment, reservationID); err != nil {
+		return nil, err
+	}
+
+	link := &models.ReservationResource{ReservationID: reservationID, ResourceID: equipment.ID}
+	if err := s.checkoutRepo.Attach(link); err != nil {
+		return nil, fmt.Errorf("failed to attach equipment: %w", err)
+	}
+	link.Resource = equipment
+	return link, nil
+}
+
+// DetachEquipment gives back equipment attached to a reservation that was not picked up
+func (s *EquipmentCheckoutService) DetachEquipment(reservationID, checkoutID, userID uuid.UUID) error {
+	if _, err := s.organizedReservation(reservationID, userID); err != nil {
+		return err
+	}
+
+	link, err := s.getCheckout(checkoutID)
+	if err != nil {
+		return err
+	}
+	if link.ReservationID != reservationID {
+		return gorm.ErrRecordNotFound
+	}
+	if link.PickedUpAt != nil {
+		return errors.New("equipment was already picked up and must be returned")
+	}
+
+	if err := s.checkoutRepo.Detach(checkoutID); err != nil {
+		return fmt.Errorf("failed to detach equipment: %w", err)
+	}
+	return nil
+}
+
+// ========================================
+// FACILITIES
+// ========================================
+
+// GetCheckedOut lists the equipment out now, longest out first, or only the overdue items
+func (s *EquipmentCheckoutService) GetCheckedOut(overdueOnly bool) ([]*models.ReservationResource, error) {
+	links, err := s.checkoutRepo.GetCheckedOut(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checked out equipment: %w", err)
+	}
+	if !overdueOnly {
+		return links, nil
+	}
+
+	now := time.Now()
+	overdue := []*models.ReservationResource{}
+	for _, link := range links {
+		if link.IsOverdueAt(now) {
+			overdue = append(overdue, link)
+		}
+	}
+	return overdue, nil
+}
+
+// ConfirmPickup records facility staff handing the equipment out for its reservation, from
+// shortly before the reservation starts until it ends
+func (s *EquipmentCheckoutService) ConfirmPickup(checkoutID, staffID uuid.UUID) (*models.ReservationResource, error) {
+	link, err := s.getCheckout(checkoutID)
+	if err != nil {
+		return nil, err
+	}
+	if link.PickedUpAt != nil {
+		return nil, errors.New("equipment was already picked up")
+	}
+
+	reservation := link.Reservation
+	now := time.Now()
+	if reservation.Status != models.StatusConfirmed {
+		return nil, fmt.Errorf("equipment cannot be picked up for a %s reservation", reservation.Status)
+	}
+	if now.Before(reservation.StartTime.Add(-equipmentPickupLead)) {
+		return nil, fmt.Errorf("equipment can be picked up from %s", reservation.StartTime.Add(-equipmentPickupLead).Format(time.RFC3339))
+	}
+	if !now.Before(reservation.EndTime) {
+		return nil, errors.New("the reservation has ended")
+	}
+
+	out, err := s.checkoutRepo.GetCheckedOut([]uuid.UUID{link.ResourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checked out equipment: %w", err)
+	}
+	if len(out) > 0 {
+		return nil, fmt.Errorf("%s has not been returned by the previous borrower", link.Resource.Name)
+	}
+
+	updated, err := s.checkoutRepo.Update(checkoutID, map[string]interface{}{
+		"picked_up_at":    now,
+		"picked_up_by_id": staffID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record pickup: %w", err)
+	}
+
+	s.logger.Info("Equipment picked up", "checkoutID", checkoutID, "resourceID", link.ResourceID, "reservationID", link.ReservationID)
+	return updated, nil
+}
+
+// ConfirmReturn records facility staff taking the equipment back
+func (s *EquipmentCheckoutService) ConfirmReturn(checkoutID, staffID uuid.UUID) (*models.ReservationResource, error) {
+	link, err := s.getCheckout(checkoutID)
+	if err != nil {
+		return nil, err
+	}
+	if link.PickedUpAt == nil {
+		return nil, errors.New("equipment was not picked up")
+	}
+	if link.ReturnedAt != nil {
+		return nil, errors.New("equipment was already returned")
+	}
+
+	now := time.Now()
+	updated, err := s.checkoutRepo.Update(checkoutID, map[string]interface{}{
+		"returned_at":    now,
+		"returned_by_id": staffID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record return: %w", err)
+	}
+
+	s.logger.Info("Equipment returned", "checkoutID", checkoutID, "resourceID", link.ResourceID, "overdue", link.IsOverdueAt(now))
+	return updated, nil
+}
+
+// SendOverdueAlerts tells the organizers of reservations whose equipment is still out after they
+// ended to bring it back, once per checkout. It runs as a scheduled job.
+func (s *EquipmentCheckoutService) SendOverdueAlerts(ctx context.Context) error {
+	overdue, err := s.checkoutRepo.GetOverdue(time.Now().Add(-equipmentOverdueGrace), equipmentOverdueBatch)
+	if err != nil {
+		return fmt.Errorf("failed to get overdue equipment: %w", err)
+	}
+
+	for _, link := range overdue {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		reservation := link.Reservation
+		title := "Equipment overdue"
+		message := fmt.Sprintf("%s borrowed for \"%s\" was due back at %s. Please return it to facilities.",
+			link.Resource.Name, reservation.Title, reservation.EndTime.Format("15:04"))
+		data := map[string]interface{}{
+			"reservation_id": reservation.ID,
+			"resource_id":    link.ResourceID,
+			"checkout_id":    link.ID,
+		}
+		if err := s.notificationService.NotifyOrganizers(reservation, models.NotificationTypeEquipmentOverdue, title, message, data); err != nil {
+			s.logger.Error("Failed to send overdue equipment alert", "checkoutID", link.ID, "error", err)
+			continue
+		}
+
+		if _, err := s.checkoutRepo.Update(link.ID, map[string]interface{}{"overdue_alerted_at": time.Now()}); err != nil {
+			return fmt.Errorf("failed to record overdue alert: %w", err)
+		}
+		s.logger.Warn("Equipment overdue", "checkoutID", link.ID, "resourceID", link.ResourceID, "reservationID", reservation.ID)
+	}
+	return nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// organizedReservation loads a reservation and checks the user organizes it
+func (s *EquipmentCheckoutService) organizedReservation(reservationID, userID uuid.UUID) (*models.Reservation, error) {
+	reservation, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if !reservation.IsOrganizer(userID) {
+		return nil, dto.ErrAccessDenied
+	}
+	return reservation, nil
+}
+
+// getCheckout loads equipment attached to a reservation with the equipment and the reservation
+func (s *EquipmentCheckoutService) getCheckout(checkoutID uuid.UUID) (*models.ReservationResource, error) {
+	link, err := s.checkoutRepo.GetByID(checkoutID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get equipment checkout: %w", err)
+	}
+	return link, nil
+}
+
+// checkNotOverdue refuses equipment still out after an earlier reservation, since nobody knows
+// when it will be back
+func (s *EquipmentCheckoutService) checkNotOverdue(equipment *models.Space, reservationID uuid.UUID) error {
+	out, err := s.checkoutRepo.GetCheckedOut([]uuid.UUID{equipment.ID})
+	if err != nil {
+		return fmt.Errorf("failed to get checked out equipment: %w", err)
+	}
+	now := time.Now()
+	for _, link := range out {
+		if link.ReservationID != reservationID && link.IsOverdueAt(now) {
+			return fmt.Errorf("%s is overdue and cannot be booked until it is returned", equipment.Name)
+		}
+	}
+	return nil
+}
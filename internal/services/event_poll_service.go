@@ -0,0 +1,132 @@
+// internal/services/event_poll_service.go
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/repositories/interfaces"
+	"room-reservation-api/internal/websocket"
+)
+
+// EventFilter narrows the events delivered to a client
+type EventFilter struct {
+	Topics   []string // Empty means every topic
+	AllUsers bool     // Include events addressed to other users, for admin activity feeds
+}
+
+// Matches reports whether the event's topic was requested
+func (f EventFilter) Matches(event websocket.BusEvent) bool {
+	if len(f.Topics) == 0 {
+		return true
+	}
+	topic := event.Topic()
+	for _, t := range f.Topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// EventPollService serves the realtime event stream to clients that cannot keep a WebSocket open
+type EventPollService struct {
+	eventBus *websocket.EventBus
+	chatRepo interfaces.ChatRepository
+	logger   *slog.Logger
+}
+
+// NewEventPollService creates a new event poll service reading from the manager's event bus
+func NewEventPollService(wsManager *websocket.Manager, chatRepo interfaces.ChatRepository, logger *slog.Logger) *EventPollService {
+	return &EventPollService{
+		eventBus: wsManager.EventBus(),
+		chatRepo: chatRepo,
+		logger:   logger,
+	}
+}
+
+// Poll returns the user's events published after since, waiting up to hold for new ones.
+// A nil since starts from the current position without returning history.
+func (s *EventPollService) Poll(ctx context.Context, userID uuid.UUID, since *uint64, hold time.Duration, filter EventFilter) (*dto.EventPollResponse, error) {
+	cursor := s.eventBus.Latest()
+	if since != nil {
+		cursor = *since
+	}
+
+	timer := time.NewTimer(hold)
+	defer timer.Stop()
+
+	// Participation rarely changes within a request, so checks are cached per conversation
+	participation := make(map[uuid.UUID]bool)
+
+	for {
+		changed := s.eventBus.Changed()
+		events, latest, complete := s.eventBus.Since(cursor)
+		if !complete {
+			return &dto.EventPollResponse{
+				Events: []websocket.BusEvent{},
+				Cursor: latest,
+				Reset:  true,
+			}, nil
+		}
+
+		visible := make([]websocket.BusEvent, 0, len(events))
+		for _, event := range events {
+			cursor = event.Sequence
+			if !filter.Matches(event) {
+				continue
+			}
+			if filter.AllUsers {
+				visible = append(visible, event)
+				continue
+			}
+
+			ok, err := s.isVisibleTo(ctx, event, userID, participation)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				visible = append(visible, event)
+			}
+		}
+
+		if len(visible) > 0 {
+			return &dto.EventPollResponse{Events: visible, Cursor: cursor}, nil
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return &dto.EventPollResponse{Events: visible, Cursor: cursor}, nil
+		case <-ctx.Done():
+			return &dto.EventPollResponse{Events: visible, Cursor: cursor}, nil
+		}
+	}
+}
+
+// isVisibleTo reports whether the event belongs in the user's stream
+func (s *EventPollService) isVisibleTo(ctx context.Context, event websocket.BusEvent, userID uuid.UUID, participation map[uuid.UUID]bool) (bool, error) {
+	if event.IsAddressedTo(userID) {
+		return true, nil
+	}
+	if event.ConversationID == nil || (event.ExcludeUserID != nil && *event.ExcludeUserID == userID) {
+		return false, nil
+	}
+
+	isParticipant, cached := participation[*event.ConversationID]
+	if !cached {
+		var err error
+		isParticipant, err = s.chatRepo.IsUserParticipant(ctx, userID, *event.ConversationID)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to check participation for event poll", "userID", userID, "conversationID", *event.ConversationID, "error", err)
+			return false, err
+		}
+		participation[*event.ConversationID] = isParticipant
+	}
+
+	return isParticipant, nil
+}
@@ -0,0 +1,298 @@
+// internal/services/feedback_report_service.go
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	defaultFeedbackReportDays = 90
+	// Highest rating whose feedback counts as a complaint
+	lowFeedbackRating = 3
+	// Keywords listed per report, building and space
+	feedbackTopComplaints = 10
+	// Shortest word kept as a complaint keyword
+	minFeedbackKeywordLength = 3
+)
+
+// feedbackStopwords are the English and French words too common to tell what a complaint is about
+var feedbackStopwords = func() map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range []string{
+		"the", "and", "for", "was", "were", "are", "but", "not", "too", "very", "with", "this", "that",
+		"there", "they", "their", "had", "has", "have", "been", "our", "you", "all", "any", "can",
+		"could", "would", "should", "from", "into", "out", "off", "again", "just", "than", "then",
+		"some", "more", "much", "many", "also", "only", "when", "which", "what", "who", "how", "its",
+		"it's", "room", "space", "meeting", "les", "des", "une", "est", "pas", "trop", "tres", "très",
+		"dans", "pour", "avec", "sur", "par", "qui", "que", "quoi", "mais", "plus", "nous", "vous",
+		"ils", "elle", "elles", "était", "etait", "été", "ete", "salle", "réunion", "reunion", "aux",
+		"ces", "cette", "son", "ses", "leur", "leurs", "encore", "peu", "bien", "fait",
+	} {
+		set[word] = true
+	}
+	return set
+}()
+
+// FeedbackReportService reports the quality of spaces as rated by organizers when checking out,
+// for facilities teams to spot the buildings and rooms that need attention
+type FeedbackReportService struct {
+	feedbackRepo interfaces.FeedbackRepositoryInterface
+}
+
+// NewFeedbackReportService creates a new feedback report service
+func NewFeedbackReportService(feedbackRepo interfaces.FeedbackRepositoryInterface) *FeedbackReportService {
+	return &FeedbackReportService{
+		feedbackRepo: feedbackRepo,
+	}
+}
+
+// GetReport aggregates the feedback of the past days per building and space, with the trend of
+// ratings and the words most often found in the comments of low ratings
+func (s *FeedbackReportService) GetReport(req *dto.FeedbackReportRequest) (*dto.FeedbackReportResponse, error) {
+	days := req.Days
+	if days == 0 {
+		days = defaultFeedbackReportDays
+	}
+	interval := req.Interval
+	if interval == "" {
+		interval = "week"
+	}
+	var spaceID *uuid.UUID
+	if req.SpaceID != "" {
+		id, err := uuid.Parse(req.SpaceID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid space ID: %w", err)
+		}
+		spaceID = &id
+	}
+
+	end := startOfDayUTC(time.Now()).AddDate(0, 0, 1)
+	from := end.AddDate(0, 0, -days)
+
+	entries, err := s.feedbackRepo.GetEntries(from, end, req.Building, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feedback: %w", err)
+	}
+
+	total := newFeedbackTally()
+	buildings := make(map[string]*feedbackTally)
+	spaces := make(map[uuid.UUID]*feedbackTally)
+	spaceNames := make(map[uuid.UUID]string)
+	spacesByBuilding := make(map[string][]uuid.UUID)
+	for _, entry := range entries {
+		building, ok := buildings[entry.Building]
+		if !ok {
+			building = newFeedbackTally()
+			buildings[entry.Building] = building
+		}
+		space, ok := spaces[entry.SpaceID]
+		if !ok {
+			space = newFeedbackTally()
+			spaces[entry.SpaceID] = space
+			spaceNames[entry.SpaceID] = entry.SpaceName
+			spacesByBuilding[entry.Building] = append(spacesByBuilding[entry.Building], entry.SpaceID)
+		}
+
+		period := feedbackPeriodStart(entry.CreatedAt, interval)
+		keywords := complaintKeywords(entry)
+		for _, tally := range []*feedbackTally{total, building, space} {
+			tally.add(entry, period, keywords)
+		}
+	}
+
+	response := &dto.FeedbackReportResponse{
+		From:          from.Format("2006-01-02"),
+		To:            end.AddDate(0, 0, -1).Format("2006-01-02"),
+		Interval:      interval,
+		FeedbackCount: total.count,
+		RatedCount:    total.rated,
+		AverageRating: total.average(),
+		LowRatings:    total.low,
+		Trend:         total.trend(),
+		TopComplaints: total.topComplaints(),
+		Buildings:     make([]dto.FeedbackBuildingSummary, 0, len(buildings)),
+		GeneratedAt:   time.Now(),
+	}
+	for name, tally := range buildings {
+		summary := dto.FeedbackBuildingSummary{
+			Building:      name,
+			FeedbackCount: tally.count,
+			RatedCount:    tally.rated,
+			AverageRating: tally.average(),
+			LowRatings:    tally.low,
+			Trend:         tally.trend(),
+			TopComplaints: tally.topComplaints(),
+		}
+		for _, id := range spacesByBuilding[name] {
+			space := spaces[id]
+			summary.Spaces = append(summary.Spaces, dto.FeedbackSpaceSummary{
+				SpaceID:       id,
+				SpaceName:     spaceNames[id],
+				FeedbackCount: space.count,
+				RatedCount:    space.rated,
+				AverageRating: space.average(),
+				LowRatings:    space.low,
+				TopComplaints: space.topComplaints(),
+			})
+		}
+		sort.Slice(summary.Spaces, func(i, j int) bool {
+			a, b := summary.Spaces[i], summary.Spaces[j]
+			if a.AverageRating != b.AverageRating {
+				return lowestRatingFirst(a.AverageRating, a.RatedCount, b.AverageRating, b.RatedCount)
+			}
+			return a.SpaceName < b.SpaceName
+		})
+		response.Buildings = append(response.Buildings, summary)
+	}
+	sort.Slice(response.Buildings, func(i, j int) bool {
+		a, b := response.Buildings[i], response.Buildings[j]
+		if a.AverageRating != b.AverageRating {
+			return lowestRatingFirst(a.AverageRating, a.RatedCount, b.AverageRating, b.RatedCount)
+		}
+		return a.Building < b.Building
+	})
+
+	return response, nil
+}
+
+// lowestRatingFirst orders by average rating, leaving what was never rated last
+func lowestRatingFirst(averageA float64, ratedA int, averageB float64, ratedB int) bool {
+	if (ratedA == 0) != (ratedB == 0) {
+		return ratedB == 0
+	}
+	return averageA < averageB
+}
+
+// feedbackTally accumulates the feedback of the whole report, a building or a space
+type feedbackTally struct {
+	count     int
+	rated     int
+	ratingSum int
+	low       int
+	periods   map[string]*dto.FeedbackTrendPoint
+	sums      map[string]int // Rating sum per period
+	keywords  map[string]int
+}
+
+// newFeedbackTally creates an empty tally
+func newFeedbackTally() *feedbackTally {
+	return &feedbackTally{
+		periods:  make(map[string]*dto.FeedbackTrendPoint),
+		sums:     make(map[string]int),
+		keywords: make(map[string]int),
+	}
+}
+
+// add counts one feedback in the tally
+func (t *feedbackTally) add(entry *models.FeedbackEntry, period string, keywords []string) {
+	point, ok := t.periods[period]
+	if !ok {
+		point = &dto.FeedbackTrendPoint{PeriodStart: period}
+		t.periods[period] = point
+	}
+
+	t.count++
+	point.FeedbackCount++
+	if entry.Rating != nil {
+		t.rated++
+		t.ratingSum += *entry.Rating
+		point.RatedCount++
+		t.sums[period] += *entry.Rating
+		if *entry.Rating <= lowFeedbackRating {
+			t.low++
+		}
+	}
+	for _, keyword := range keywords {
+		t.keywords[keyword]++
+	}
+}
+
+// average returns the average rating to two decimals, 0 when nothing was rated
+func (t *feedbackTally) average() float64 {
+	return averageRating(t.ratingSum, t.rated)
+}
+
+// trend returns the ratings of each period with feedback, oldest first
+func (t *feedbackTally) trend() []dto.FeedbackTrendPoint {
+	trend := make([]dto.FeedbackTrendPoint, 0, len(t.periods))
+	for period, point := range t.periods {
+		point.AverageRating = averageRating(t.sums[period], point.RatedCount)
+		trend = append(trend, *point)
+	}
+	sort.Slice(trend, func(i, j int) bool { return trend[i].PeriodStart < trend[j].PeriodStart })
+	return trend
+}
+
+// topComplaints returns the keywords found in the most low-rated comments
+func (t *feedbackTally) topComplaints() []dto.FeedbackKeyword {
+	complaints := make([]dto.FeedbackKeyword, 0, len(t.keywords))
+	for keyword, comments := range t.keywords {
+		complaints = append(complaints, dto.FeedbackKeyword{Keyword: keyword, Comments: comments})
+	}
+	sort.Slice(complaints, func(i, j int) bool {
+		if complaints[i].Comments != complaints[j].Comments {
+			return complaints[i].Comments > complaints[j].Comments
+		}
+		return complaints[i].Keyword < complaints[j].Keyword
+	})
+	if len(complaints) > feedbackTopComplaints {
+		complaints = complaints[:feedbackTopComplaints]
+	}
+	return complaints
+}
+
+// averageRating divides a rating sum, rounded to two decimals
+func averageRating(sum, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return math.Round(float64(sum)/float64(count)*100) / 100
+}
+
+// feedbackPeriodStart returns the first day of the week (Monday) or month of a feedback
+func feedbackPeriodStart(t time.Time, interval string) string {
+	day := startOfDayUTC(t)
+	if interval == "month" {
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+	}
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset).Format("2006-01-02")
+}
+
+// complaintKeywords extracts the distinct meaningful words of the comment of a low rating
+func complaintKeywords(entry *models.FeedbackEntry) []string {
+	if entry.Rating == nil || *entry.Rating > lowFeedbackRating || entry.Comment == "" {
+		return nil
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(entry.Comment), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+	seen := make(map[string]bool, len(words))
+	var keywords []string
+	for _, word := range words {
+		word = strings.Trim(word, "'")
+		if len([]rune(word)) < minFeedbackKeywordLength || feedbackStopwords[word] || seen[word] || isNumber(word) {
+			continue
+		}
+		seen[word] = true
+		keywords = append(keywords, word)
+	}
+	return keywords
+}
+
+// isNumber reports whether a word is only digits
+func isNumber(word string) bool {
+	return strings.IndexFunc(word, func(r rune) bool { return !unicode.IsDigit(r) }) < 0
+}
@@ -0,0 +1,145 @@
+// internal/services/file_scanner.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/breaker"
+)
+
+// ScanResult represents the verdict of an antivirus scan
+type ScanResult struct {
+	Clean     bool
+	Signature string // Name of the detected threat when not clean
+}
+
+// FileScanner scans uploaded content for malware
+type FileScanner interface {
+	Scan(ctx context.Context, content io.Reader) (*ScanResult, error)
+}
+
+// ClamAVScanner scans files through a clamd daemon using the INSTREAM command
+type ClamAVScanner struct {
+	address string
+	timeout time.Duration
+}
+
+// NewClamAVScanner creates a scanner for the clamd daemon at address ("host:port" or a unix socket path)
+func NewClamAVScanner(address string, timeout time.Duration) *ClamAVScanner {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	return &ClamAVScanner{
+		address: address,
+		timeout: timeout,
+	}
+}
+
+// Scan streams content to clamd and parses its verdict
+func (s *ClamAVScanner) Scan(ctx context.Context, content io.Reader) (*ScanResult, error) {
+	network := "tcp"
+	if strings.HasPrefix(s.address, "/") {
+		network = "unix"
+	}
+
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, network, s.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(s.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, fmt.Errorf("failed to set clamd deadline: %w", err)
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return nil, fmt.Errorf("failed to start clamd stream: %w", err)
+	}
+
+	// Each chunk is prefixed with its length as a 4-byte big-endian integer
+	buf := make([]byte, 32*1024)
+	size := make([]byte, 4)
+	for {
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return nil, fmt.Errorf("failed to stream to clamd: %w", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return nil, fmt.Errorf("failed to stream to clamd: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read file for scanning: %w", readErr)
+		}
+	}
+
+	// A zero-length chunk terminates the stream
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return nil, fmt.Errorf("failed to finish clamd stream: %w", err)
+	}
+
+	reply, err := io.ReadAll(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+
+	return parseClamdReply(string(bytes.TrimRight(reply, "\x00\n")))
+}
+
+// parseClamdReply interprets replies such as "stream: OK" or "stream: Eicar-Signature FOUND"
+func parseClamdReply(reply string) (*ScanResult, error) {
+	verdict := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+
+	switch {
+	case verdict == "OK":
+		return &ScanResult{Clean: true}, nil
+	case strings.HasSuffix(verdict, "FOUND"):
+		return &ScanResult{
+			Clean:     false,
+			Signature: strings.TrimSpace(strings.TrimSuffix(verdict, "FOUND")),
+		}, nil
+	case strings.HasSuffix(verdict, "ERROR"):
+		return nil, fmt.Errorf("clamd error: %s", strings.TrimSpace(strings.TrimSuffix(verdict, "ERROR")))
+	default:
+		return nil, errors.New("unexpected clamd reply: " + reply)
+	}
+}
+
+// breakerScanner scans through another scanner behind a circuit breaker, so uploads go
+// straight to quarantine while the daemon is down instead of each waiting for it to time out
+type breakerScanner struct {
+	scanner FileScanner
+	breaker *breaker.Breaker
+}
+
+// NewBreakerScanner wraps a scanner with a circuit breaker
+func NewBreakerScanner(scanner FileScanner, b *breaker.Breaker) FileScanner {
+	return &breakerScanner{scanner: scanner, breaker: b}
+}
+
+// Scan scans content unless the daemon's breaker is open
+func (s *breakerScanner) Scan(ctx context.Context, content io.Reader) (*ScanResult, error) {
+	return breaker.Do(ctx, s.breaker, func(ctx context.Context) (*ScanResult, error) {
+		return s.scanner.Scan(ctx, content)
+	})
+}
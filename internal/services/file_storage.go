@@ -0,0 +1,87 @@
+// internal/services/file_storage.go
+package services
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FileStorage stores uploaded files under slash-separated keys and serves them by URL
+type FileStorage interface {
+	// Save stores the content under the key, replacing any previous file, and returns its URL
+	Save(key string, content io.Reader) (string, error)
+	// Delete removes the file stored under the key; a missing file is not an error
+	Delete(key string) error
+}
+
+// LocalFileStorage stores files in a directory served under a URL prefix, like chat uploads
+type LocalFileStorage struct {
+	root      string
+	urlPrefix string
+}
+
+// NewLocalFileStorage creates a storage writing to root, with file URLs starting with urlPrefix
+func NewLocalFileStorage(root, urlPrefix string) *LocalFileStorage {
+	return &LocalFileStorage{
+		root:      root,
+		urlPrefix: strings.TrimSuffix(urlPrefix, "/"),
+	}
+}
+
+// Save writes the content to a temporary file first, so readers never see a partial file
+func (s *LocalFileStorage) Save(key string, content io.Reader) (string, error) {
+	target, err := s.pathOf(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), 0o640); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return "", err
+	}
+
+	return s.urlPrefix + "/" + path.Clean(key), nil
+}
+
+// Delete removes the file stored under the key
+func (s *LocalFileStorage) Delete(key string) error {
+	target, err := s.pathOf(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// pathOf maps a key to its file, refusing keys that would leave the storage directory
+func (s *LocalFileStorage) pathOf(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" || cleaned != "/"+key {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
@@ -0,0 +1,438 @@
+// internal/services/floor_consolidation_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// Seat time a floor offers per day, the denominator of its usage
+	usageWorkdayMinutes = 9 * 60
+	// Open floors are suggested so bookings fill at most this share of their seat time
+	consolidationTargetPercent = 60
+	// Widest range of days in one forecast
+	maxForecastDays = 62
+)
+
+// FloorConsolidationService forecasts low-usage days so facilities can close floors,
+// and manages the consolidations that steer new bookings onto the floors left open
+type FloorConsolidationService struct {
+	consolidationRepo   interfaces.FloorConsolidationRepositoryInterface
+	userRepo            interfaces.UserRepositoryInterface
+	notificationService *NotificationService
+	thresholdPercent    int // 0 disables low-usage notifications
+	lookaheadDays       int
+	logger              *slog.Logger
+}
+
+// NewFloorConsolidationService creates a new floor consolidation service
+func NewFloorConsolidationService(
+	consolidationRepo interfaces.FloorConsolidationRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	notificationService *NotificationService,
+	thresholdPercent int,
+	lookaheadDays int,
+	logger *slog.Logger,
+) *FloorConsolidationService {
+	return &FloorConsolidationService{
+		consolidationRepo:   consolidationRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		thresholdPercent:    thresholdPercent,
+		lookaheadDays:       lookaheadDays,
+		logger:              logger,
+	}
+}
+
+// ========================================
+// LOW-USAGE FORECAST
+// ========================================
+
+// GetLowUsageForecast reports the booked usage of every building and floor for each day from
+// the first to the last date, with the floors that could host everything on low-usage days
+func (s *FloorConsolidationService) GetLowUsageForecast(from, to time.Time) (*dto.LowUsageForecastResponse, error) {
+	if to.Before(from) {
+		return nil, errors.New("to must not be before from")
+	}
+	if to.Sub(from) >= maxForecastDays*24*time.Hour {
+		return nil, fmt.Errorf("forecast cannot exceed %d days", maxForecastDays)
+	}
+
+	consolidations, err := s.consolidationRepo.GetCurrentAndUpcoming(from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get floor consolidations: %w", err)
+	}
+
+	response := &dto.LowUsageForecastResponse{
+		From:             from.Format("2006-01-02"),
+		To:               to.Format("2006-01-02"),
+		ThresholdPercent: s.thresholdPercent,
+		Days:             []dto.DailyUsageReport{},
+	}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		report, err := s.forecastDay(day, consolidations)
+		if err != nil {
+			return nil, err
+		}
+		response.Days = append(response.Days, report)
+	}
+
+	return response, nil
+}
+
+// NotifyLowUsage tells admins about upcoming low-usage days in buildings not yet
+// consolidated, once per building and day
+func (s *FloorConsolidationService) NotifyLowUsage(ctx context.Context) error {
+	if s.thresholdPercent <= 0 || s.lookaheadDays <= 0 {
+		return nil
+	}
+
+	today := startOfDayUTC(time.Now())
+	forecast, err := s.GetLowUsageForecast(today.AddDate(0, 0, 1), today.AddDate(0, 0, s.lookaheadDays))
+	if err != nil {
+		return err
+	}
+
+	var admins []models.User
+	reported := 0
+	for _, day := range forecast.Days {
+		for _, building := range day.Buildings {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !building.LowUsage || building.Consolidation != nil || len(building.SuggestedOpenFloors) == 0 {
+				continue
+			}
+
+			date, _ := time.Parse("2006-01-02", day.Date)
+			var lowFloors pq.Int64Array
+			for _, floor := range building.Floors {
+				if floor.LowUsage {
+					lowFloors = append(lowFloors, int64(floor.Floor))
+				}
+			}
+			created, err := s.consolidationRepo.CreateAlert(&models.LowUsageAlert{
+				Building:  building.Building,
+				Date:      date,
+				LowFloors: lowFloors,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record low-usage alert: %w", err)
+			}
+			if !created {
+				continue
+			}
+
+			if admins == nil {
+				admins, err = s.userRepo.GetByRole(models.RoleAdmin)
+				if err != nil {
+					return fmt.Errorf("failed to get admins: %w", err)
+				}
+			}
+			s.notifyAdmins(admins, date, building)
+			reported++
+		}
+	}
+
+	if reported > 0 {
+		s.logger.Info("Reported low-usage days", "count", reported)
+	}
+	return nil
+}
+
+// ========================================
+// CONSOLIDATIONS
+// ========================================
+
+// CreateConsolidation keeps only the given floors of a building open for a range of days
+func (s *FloorConsolidationService) CreateConsolidation(req *dto.CreateFloorConsolidationRequest, userID uuid.UUID) (*dto.FloorConsolidationResponse, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, errors.New("start_date must use the YYYY-MM-DD format")
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, errors.New("end_date must use the YYYY-MM-DD format")
+	}
+	if endDate.Before(startDate) {
+		return nil, errors.New("end date must not be before start date")
+	}
+	if startDate.Before(startOfDayUTC(time.Now())) {
+		return nil, errors.New("cannot consolidate floors in the past")
+	}
+
+	// The building's floors with bookable spaces, with their capacity
+	usage, err := s.consolidationRepo.GetFloorUsage(startDate, startDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get building floors: %w", err)
+	}
+	floors := make(map[int]bool)
+	for _, floor := range usage {
+		if floor.Building == req.Building {
+			floors[floor.Floor] = true
+		}
+	}
+	if len(floors) == 0 {
+		return nil, fmt.Errorf("unknown building %q", req.Building)
+	}
+
+	var openFloors pq.Int64Array
+	seen := make(map[int]bool)
+	for _, floor := range req.OpenFloors {
+		if seen[floor] {
+			continue
+		}
+		if !floors[floor] {
+			return nil, fmt.Errorf("%s has no bookable spaces on floor %d", req.Building, floor)
+		}
+		seen[floor] = true
+		openFloors = append(openFloors, int64(floor))
+	}
+	if len(openFloors) == len(floors) {
+		return nil, errors.New("open floors must leave at least one floor closed")
+	}
+	sort.Slice(openFloors, func(i, j int) bool { return openFloors[i] < openFloors[j] })
+
+	overlapping, err := s.consolidationRepo.GetOverlapping(req.Building, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check floor consolidations: %w", err)
+	}
+	if len(overlapping) > 0 {
+		return nil, errors.New("building already has a floor consolidation on these days")
+	}
+
+	consolidation, err := s.consolidationRepo.Create(&models.FloorConsolidation{
+		Building:    req.Building,
+		OpenFloors:  openFloors,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Reason:      req.Reason,
+		CreatedByID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create floor consolidation: %w", err)
+	}
+
+	// Existing bookings are kept; facilities decide whether to move them
+	affected, err := s.consolidationRepo.CountReservationsOnClosedFloors(req.Building, openFloors, startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count affected reservations: %w", err)
+	}
+
+	s.logger.Info("Floor consolidation created", "consolidationID", consolidation.ID, "building", req.Building,
+		"openFloors", []int64(openFloors), "affectedReservations", affected)
+
+	response := toFloorConsolidationResponse(consolidation)
+	response.AffectedReservations = &affected
+	return response, nil
+}
+
+// GetConsolidations lists the consolidations that are running or planned
+func (s *FloorConsolidationService) GetConsolidations() ([]*dto.FloorConsolidationResponse, error) {
+	consolidations, err := s.consolidationRepo.GetCurrentAndUpcoming(startOfDayUTC(time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get floor consolidations: %w", err)
+	}
+
+	responses := make([]*dto.FloorConsolidationResponse, len(consolidations))
+	for i, consolidation := range consolidations {
+		responses[i] = toFloorConsolidationResponse(consolidation)
+	}
+	return responses, nil
+}
+
+// EndConsolidation reopens every floor of the building from now on
+func (s *FloorConsolidationService) EndConsolidation(id uuid.UUID) (*dto.FloorConsolidationResponse, error) {
+	consolidation, err := s.consolidationRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get floor consolidation: %w", err)
+	}
+	if consolidation.EndedAt != nil {
+		return nil, errors.New("floor consolidation already ended")
+	}
+
+	consolidation, err = s.consolidationRepo.Update(id, map[string]interface{}{"ended_at": time.Now()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to end floor consolidation: %w", err)
+	}
+
+	s.logger.Info("Floor consolidation ended", "consolidationID", id, "building", consolidation.Building)
+	return toFloorConsolidationResponse(consolidation), nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// forecastDay reports the usage of each building on the day
+func (s *FloorConsolidationService) forecastDay(day time.Time, consolidations []*models.FloorConsolidation) (dto.DailyUsageReport, error) {
+	report := dto.DailyUsageReport{
+		Date:      day.Format("2006-01-02"),
+		Buildings: []dto.BuildingUsageReport{},
+	}
+
+	usage, err := s.consolidationRepo.GetFloorUsage(day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return report, fmt.Errorf("failed to get floor usage: %w", err)
+	}
+
+	// Rows come ordered by building, then floor
+	for start := 0; start < len(usage); {
+		end := start
+		for end < len(usage) && usage[end].Building == usage[start].Building {
+			end++
+		}
+		report.Buildings = append(report.Buildings, s.buildingReport(day, usage[start:end], consolidations))
+		start = end
+	}
+
+	return report, nil
+}
+
+// buildingReport rates the usage of one building's floors on the day
+func (s *FloorConsolidationService) buildingReport(day time.Time, floors []interfaces.FloorUsage, consolidations []*models.FloorConsolidation) dto.BuildingUsageReport {
+	report := dto.BuildingUsageReport{Building: floors[0].Building}
+
+	var capacity int
+	var booked float64
+	for _, floor := range floors {
+		capacity += floor.Capacity
+		booked += floor.BookedSeatMinutes
+		percent := usagePercent(floor.BookedSeatMinutes, floor.Capacity)
+		report.Floors = append(report.Floors, dto.FloorUsageReport{
+			Floor:        floor.Floor,
+			Capacity:     floor.Capacity,
+			BookedHours:  math.Round(floor.BookedSeatMinutes/60*10) / 10,
+			UsagePercent: percent,
+			LowUsage:     percent < s.thresholdPercent,
+		})
+	}
+
+	report.UsagePercent = usagePercent(booked, capacity)
+	// A single floor leaves nothing to consolidate
+	report.LowUsage = len(floors) > 1 && report.UsagePercent < s.thresholdPercent
+	if report.LowUsage {
+		report.SuggestedOpenFloors = suggestOpenFloors(floors, booked)
+	}
+
+	for _, consolidation := range consolidations {
+		if consolidation.Building == report.Building && consolidation.Covers(day) {
+			report.Consolidation = toFloorConsolidationResponse(consolidation)
+			break
+		}
+	}
+
+	return report
+}
+
+// notifyAdmins sends the low-usage report of a building to every admin
+func (s *FloorConsolidationService) notifyAdmins(admins []models.User, date time.Time, building dto.BuildingUsageReport) {
+	open := make([]string, len(building.SuggestedOpenFloors))
+	for i, floor := range building.SuggestedOpenFloors {
+		open[i] = fmt.Sprint(floor)
+	}
+
+	title := fmt.Sprintf("Low usage expected in %s on %s", building.Building, date.Format("Mon 2 Jan"))
+	message := fmt.Sprintf("Only %d%% of %s's seat time is booked on %s. Floor(s) %s could host every booking; "+
+		"start a floor consolidation to steer new bookings there and close the other floors.",
+		building.UsagePercent, building.Building, date.Format("Monday 2 January"), strings.Join(open, ", "))
+	data := map[string]interface{}{
+		"building":              building.Building,
+		"date":                  date.Format("2006-01-02"),
+		"usage_percent":         building.UsagePercent,
+		"suggested_open_floors": building.SuggestedOpenFloors,
+	}
+
+	for _, admin := range admins {
+		if _, err := s.notificationService.Notify(admin.ID, models.NotificationTypeLowUsageForecast, title, message, data); err != nil {
+			s.logger.Warn("Failed to notify admin of low usage", "userID", admin.ID, "building", building.Building, "error", err)
+		}
+	}
+}
+
+// usagePercent is the share of a floor's daily seat time that is booked
+func usagePercent(bookedSeatMinutes float64, capacity int) int {
+	if capacity == 0 {
+		return 0
+	}
+	return int(math.Round(100 * bookedSeatMinutes / float64(capacity*usageWorkdayMinutes)))
+}
+
+// suggestOpenFloors picks the busiest floors until they can host every booking of the
+// day, or nil if no floor could be closed
+func suggestOpenFloors(floors []interfaces.FloorUsage, booked float64) []int {
+	ranked := make([]interfaces.FloorUsage, len(floors))
+	copy(ranked, floors)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].BookedSeatMinutes != ranked[j].BookedSeatMinutes {
+			return ranked[i].BookedSeatMinutes > ranked[j].BookedSeatMinutes
+		}
+		return ranked[i].Capacity > ranked[j].Capacity
+	})
+
+	var open []int
+	capacity := 0
+	for _, floor := range ranked {
+		open = append(open, floor.Floor)
+		capacity += floor.Capacity
+		if float64(capacity*usageWorkdayMinutes*consolidationTargetPercent)/100 >= booked {
+			break
+		}
+	}
+	if len(open) == len(floors) {
+		return nil
+	}
+
+	sort.Ints(open)
+	return open
+}
+
+// closingConsolidation returns the consolidation that closes the floor of the space
+// on the day of t, or nil if the floor is open
+func closingConsolidation(consolidations []*models.FloorConsolidation, space *models.Space, t time.Time) *models.FloorConsolidation {
+	for _, consolidation := range consolidations {
+		if consolidation.Building == space.Building && consolidation.Covers(t) && !consolidation.KeepsOpen(space.Floor) {
+			return consolidation
+		}
+	}
+	return nil
+}
+
+// startOfDayUTC truncates a time to midnight UTC
+func startOfDayUTC(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// toFloorConsolidationResponse converts a consolidation to its response
+func toFloorConsolidationResponse(consolidation *models.FloorConsolidation) *dto.FloorConsolidationResponse {
+	openFloors := make([]int, len(consolidation.OpenFloors))
+	for i, floor := range consolidation.OpenFloors {
+		openFloors[i] = int(floor)
+	}
+
+	return &dto.FloorConsolidationResponse{
+		ID:          consolidation.ID,
+		Building:    consolidation.Building,
+		OpenFloors:  openFloors,
+		StartDate:   consolidation.StartDate.Format("2006-01-02"),
+		EndDate:     consolidation.EndDate.Format("2006-01-02"),
+		Reason:      consolidation.Reason,
+		CreatedByID: consolidation.CreatedByID,
+		EndedAt:     consolidation.EndedAt,
+	}
+}
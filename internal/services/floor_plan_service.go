@@ -0,0 +1,331 @@
+// internal/services/floor_plan_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// Statuses of a desk on a floor map
+const (
+	SeatStatusFree        = "free"
+	SeatStatusBooked      = "booked"
+	SeatStatusUnavailable = "unavailable"
+	SeatStatusRestricted  = "restricted"
+)
+
+// A click picks the nearest desk within this share of the plan's longest side
+const seatPickRadiusShare = 0.03
+
+// FloorPlanService manages floor plans, the desks placed on them and team neighborhoods
+type FloorPlanService struct {
+	floorPlanRepo      interfaces.FloorPlanRepositoryInterface
+	spaceRepo          interfaces.SpaceRepositoryInterface
+	reservationRepo    interfaces.ReservationRepositoryInterface
+	userRepo           interfaces.UserRepositoryInterface
+	consolidationRepo  interfaces.FloorConsolidationRepositoryInterface
+	reservationService *ReservationService
+}
+
+// NewFloorPlanService creates a new floor plan service
+func NewFloorPlanService(
+	floorPlanRepo interfaces.FloorPlanRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	consolidationRepo interfaces.FloorConsolidationRepositoryInterface,
+	reservationService *ReservationService,
+) *FloorPlanService {
+	return &FloorPlanService{
+		floorPlanRepo:      floorPlanRepo,
+		spaceRepo:          spaceRepo,
+		reservationRepo:    reservationRepo,
+		userRepo:           userRepo,
+		consolidationRepo:  consolidationRepo,
+		reservationService: reservationService,
+	}
+}
+
+// ========================================
+// FLOOR PLAN OPERATIONS
+// ========================================
+
+// SavePlan creates the plan of a floor, or replaces the drawing of an existing one while keeping its seats
+func (s *FloorPlanService) SavePlan(req *dto.SaveFloorPlanRequest, userID uuid.UUID) (*models.FloorPlan, error) {
+	if (req.SVG == "") == (req.ImageURL == "") {
+		return nil, errors.New("exactly one of svg and image_url is required")
+	}
+
+	buildings, err := s.spaceRepo.GetDistinctBuildings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get buildings: %w", err)
+	}
+	known := false
+	for _, building := range buildings {
+		if building == req.Building {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("building %q has no spaces", req.Building)
+	}
+
+	plan, err := s.floorPlanRepo.Save(&models.FloorPlan{
+		Building:    req.Building,
+		Floor:       req.Floor,
+		SVG:         req.SVG,
+		ImageURL:    req.ImageURL,
+		Width:       req.Width,
+		Height:      req.Height,
+		UpdatedByID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save floor plan: %w", err)
+	}
+	return plan, nil
+}
+
+// GetPlans lists the floor plans, of one building when given, without their drawings
+func (s *FloorPlanService) GetPlans(building string) ([]*models.FloorPlan, error) {
+	plans, err := s.floorPlanRepo.GetAll(building)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get floor plans: %w", err)
+	}
+	return plans, nil
+}
+
+// GetPlan retrieves a floor plan with its seats and neighborhoods
+func (s *FloorPlanService) GetPlan(planID uuid.UUID) (*models.FloorPlan, error) {
+	return s.floorPlanRepo.GetByID(planID)
+}
+
+// DeletePlan removes a floor plan with its seats and neighborhoods; the desks themselves stay bookable
+func (s *FloorPlanService) DeletePlan(planID uuid.UUID) error {
+	return s.floorPlanRepo.Delete(planID)
+}
+
+// SetSeats replaces the desks placed on a floor plan. Each desk must be on the plan's
+// floor, and its neighborhood, when given, one of the plan's.
+func (s *FloorPlanService) SetSeats(planID uuid.UUID, req *dto.SetFloorPlanSeatsRequest) (*models.FloorPlan, error) {
+	plan, err := s.floorPlanRepo.GetByID(planID)
+	if err != nil {
+		return nil, err
+	}
+
+	neighborhoods := make(map[uuid.UUID]bool, len(plan.Neighborhoods))
+	for _, neighborhood := range plan.Neighborhoods {
+		neighborhoods[neighborhood.ID] = true
+	}
+
+	seats := make([]*models.FloorPlanSeat, 0, len(req.Seats))
+	placed := make(map[uuid.UUID]bool, len(req.Seats))
+	for _, input := range req.Seats {
+		if placed[input.SpaceID] {
+			return nil, fmt.Errorf("space %s is placed more than once", input.SpaceID)
+		}
+		placed[input.SpaceID] = true
+
+		if input.X > float64(plan.Width) || input.Y > float64(plan.Height) {
+			return nil, fmt.Errorf("seat of space %s is outside the %dx%d plan", input.SpaceID, plan.Width, plan.Height)
+		}
+		if input.NeighborhoodID != nil && !neighborhoods[*input.NeighborhoodID] {
+			return nil, fmt.Errorf("neighborhood %s is not on this floor plan", *input.NeighborhoodID)
+		}
+
+		space, err := s.spaceRepo.GetByID(input.SpaceID)
+		if err != nil {
+			return nil, fmt.Errorf("space %s not found", input.SpaceID)
+		}
+		if models.ResourceClassOf(space.Type) != models.ResourceClassDesk {
+			return nil, fmt.Errorf("space %s is not a desk", space.Name)
+		}
+		if space.Building != plan.Building || space.Floor != plan.Floor {
+			return nil, fmt.Errorf("desk %s is not on floor %d of %s", space.Name, plan.Floor, plan.Building)
+		}
+
+		seats = append(seats, &models.FloorPlanSeat{
+			FloorPlanID:    plan.ID,
+			SpaceID:        space.ID,
+			X:              input.X,
+			Y:              input.Y,
+			NeighborhoodID: input.NeighborhoodID,
+		})
+	}
+
+	if err := s.floorPlanRepo.ReplaceSeats(plan.ID, seats); err != nil {
+		return nil, fmt.Errorf("failed to save seats: %w", err)
+	}
+	return s.floorPlanRepo.GetByID(plan.ID)
+}
+
+// ========================================
+// NEIGHBORHOOD OPERATIONS
+// ========================================
+
+// CreateNeighborhood adds a team zone to a floor plan
+func (s *FloorPlanService) CreateNeighborhood(planID uuid.UUID, req *dto.CreateNeighborhoodRequest) (*models.Neighborhood, error) {
+	if _, err := s.floorPlanRepo.GetByID(planID); err != nil {
+		return nil, err
+	}
+
+	neighborhood, err := s.floorPlanRepo.CreateNeighborhood(&models.Neighborhood{
+		FloorPlanID: planID,
+		Name:        req.Name,
+		Color:       req.Color,
+		Departments: req.Departments,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create neighborhood: %w", err)
+	}
+	return neighborhood, nil
+}
+
+// DeleteNeighborhood removes a team zone; its desks become open to everyone
+func (s *FloorPlanService) DeleteNeighborhood(planID, neighborhoodID uuid.UUID) error {
+	neighborhood, err := s.floorPlanRepo.GetNeighborhoodByID(neighborhoodID)
+	if err != nil {
+		return err
+	}
+	if neighborhood.FloorPlanID != planID {
+		return gorm.ErrRecordNotFound
+	}
+	return s.floorPlanRepo.DeleteNeighborhood(neighborhoodID)
+}
+
+// ========================================
+// FLOOR MAP OPERATIONS
+// ========================================
+
+// GetFloorMap returns the plan with the status of each desk for the user over the time range
+func (s *FloorPlanService) GetFloorMap(planID uuid.UUID, startTime, endTime time.Time, userID uuid.UUID) (*dto.FloorMapResponse, error) {
+	if !endTime.After(startTime) {
+		return nil, errors.New("end time must be after start time")
+	}
+
+	plan, err := s.floorPlanRepo.GetByID(planID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	consolidations, err := s.consolidationRepo.GetOverlapping(plan.Building, startTime.UTC(), startTime.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get floor consolidations: %w", err)
+	}
+
+	response := &dto.FloorMapResponse{
+		ID:            plan.ID,
+		Building:      plan.Building,
+		Floor:         plan.Floor,
+		SVG:           plan.SVG,
+		ImageURL:      plan.ImageURL,
+		Width:         plan.Width,
+		Height:        plan.Height,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		Neighborhoods: make([]dto.NeighborhoodResponse, 0, len(plan.Neighborhoods)),
+		Seats:         make([]dto.FloorMapSeat, 0, len(plan.Seats)),
+	}
+
+	restricted := make(map[uuid.UUID]bool)
+	for _, neighborhood := range plan.Neighborhoods {
+		response.Neighborhoods = append(response.Neighborhoods, dto.NeighborhoodResponse{
+			ID:          neighborhood.ID,
+			Name:        neighborhood.Name,
+			Color:       neighborhood.Color,
+			Departments: append([]string{}, neighborhood.Departments...),
+		})
+		if !user.IsAdmin() && !neighborhood.AllowsDepartment(user.Department) {
+			restricted[neighborhood.ID] = true
+		}
+	}
+
+	for _, seat := range plan.Seats {
+		if seat.Space == nil {
+			continue
+		}
+
+		status := SeatStatusFree
+		switch {
+		case !seat.Space.IsAvailable() || closingConsolidation(consolidations, seat.Space, startTime) != nil:
+			status = SeatStatusUnavailable
+		case seat.NeighborhoodID != nil && restricted[*seat.NeighborhoodID]:
+			status = SeatStatusRestricted
+		default:
+			available, err := s.reservationRepo.CheckTimeSlotAvailability(seat.SpaceID, startTime, endTime, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check availability: %w", err)
+			}
+			if !available {
+				status = SeatStatusBooked
+			}
+		}
+		if status == SeatStatusFree {
+			response.FreeSeats++
+		}
+
+		response.Seats = append(response.Seats, dto.FloorMapSeat{
+			SpaceID:        seat.SpaceID,
+			Name:           seat.Space.Name,
+			X:              seat.X,
+			Y:              seat.Y,
+			NeighborhoodID: seat.NeighborhoodID,
+			Status:         status,
+		})
+	}
+
+	return response, nil
+}
+
+// PickSeat books the desk nearest to the clicked point of the plan, like any other booking of that desk
+func (s *FloorPlanService) PickSeat(planID uuid.UUID, req *dto.PickSeatRequest, userID uuid.UUID) (*models.Reservation, error) {
+	plan, err := s.floorPlanRepo.GetByID(planID)
+	if err != nil {
+		return nil, err
+	}
+
+	seat := nearestSeat(plan, req.X, req.Y)
+	if seat == nil {
+		return nil, errors.New("no desk at the picked position")
+	}
+
+	title := req.Title
+	if title == "" {
+		title = "Desk booking"
+	}
+	return s.reservationService.CreateReservation(&dto.CreateReservationRequest{
+		SpaceID:          seat.SpaceID,
+		StartTime:        req.StartTime,
+		EndTime:          req.EndTime,
+		ParticipantCount: 1,
+		Title:            title,
+	}, userID)
+}
+
+// nearestSeat finds the seat closest to the point within the pick radius of the plan
+func nearestSeat(plan *models.FloorPlan, x, y float64) *models.FloorPlanSeat {
+	radius := seatPickRadiusShare * math.Max(float64(plan.Width), float64(plan.Height))
+
+	var nearest *models.FloorPlanSeat
+	nearestDistance := radius
+	for i := range plan.Seats {
+		seat := &plan.Seats[i]
+		if distance := math.Hypot(seat.X-x, seat.Y-y); distance <= nearestDistance {
+			nearest = seat
+			nearestDistance = distance
+		}
+	}
+	return nearest
+}
@@ -0,0 +1,275 @@
+// internal/services/health_service.go
+package services
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/breaker"
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/version"
+	"room-reservation-api/internal/websocket"
+)
+
+// Time allowed for each dependency check of a readiness probe
+const healthCheckTimeout = 2 * time.Second
+
+// HealthService answers liveness and readiness probes. Only critical dependencies, the
+// database and its replica, make the instance not ready; the others and open breakers
+// degrade it, since it still serves bookings without them.
+type HealthService struct {
+	db         *gorm.DB
+	replica    *gorm.DB
+	wsManager  *websocket.Manager
+	breakers   *breaker.Registry
+	redisURL   string
+	uploadPath string
+	startedAt  time.Time
+}
+
+// NewHealthService creates a new health service; replica may be nil and redisURL empty
+func NewHealthService(
+	db, replica *gorm.DB,
+	wsManager *websocket.Manager,
+	breakers *breaker.Registry,
+	redisURL string,
+	uploadPath string,
+) *HealthService {
+	return &HealthService{
+		db:         db,
+		replica:    replica,
+		wsManager:  wsManager,
+		breakers:   breakers,
+		redisURL:   redisURL,
+		uploadPath: uploadPath,
+		startedAt:  time.Now(),
+	}
+}
+
+// healthCheck checks one dependency, returning details to report or an error
+type healthCheck struct {
+	name     string
+	critical bool
+	run      func(ctx context.Context) (interface{}, error)
+}
+
+// Live reports the process is up. It checks no dependency, so that an outage elsewhere
+// does not get the instance restarted.
+func (s *HealthService) Live() *dto.HealthReport {
+	return s.report("alive")
+}
+
+// Ready checks every dependency concurrently and tells whether the instance can take traffic
+func (s *HealthService) Ready(ctx context.Context) (*dto.HealthReport, bool) {
+	checks := []healthCheck{
+		{name: "database", critical: true, run: s.pingDatabase(s.db)},
+		{name: "redis", run: s.pingRedis},
+		{name: "storage", run: s.checkStorage},
+		{name: "websocket", run: s.checkWebSocket},
+	}
+	if s.replica != nil {
+		checks = append(checks, healthCheck{name: "database_replica", critical: true, run: s.pingDatabase(s.replica)})
+	}
+
+	results := make(map[string]dto.DependencyCheck, len(checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check healthCheck) {
+			defer wg.Done()
+			result := s.runCheck(ctx, check)
+			mu.Lock()
+			results[check.name] = result
+			mu.Unlock()
+		}(check)
+	}
+	wg.Wait()
+
+	ready, degraded := true, false
+	for _, result := range results {
+		if result.Status != dto.HealthDown {
+			continue
+		}
+		if result.Critical {
+			ready = false
+		} else {
+			degraded = true
+		}
+	}
+
+	statuses := s.breakers.Statuses()
+	for _, breakerStatus := range statuses {
+		if breakerStatus.State != breaker.StateClosed {
+			degraded = true
+		}
+	}
+
+	status := "ready"
+	switch {
+	case !ready:
+		status = "not_ready"
+	case degraded:
+		status = "degraded"
+	}
+
+	report := s.report(status)
+	report.Checks = results
+	report.Breakers = statuses
+	return report, ready
+}
+
+// ========================================
+// DEPENDENCY CHECKS
+// ========================================
+
+// runCheck runs a check within its timeout
+func (s *HealthService) runCheck(ctx context.Context, check healthCheck) dto.DependencyCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	started := time.Now()
+	details, err := check.run(ctx)
+	result := dto.DependencyCheck{
+		Status:   dto.HealthUp,
+		Critical: check.critical,
+		Latency:  time.Since(started).Round(time.Millisecond).String(),
+		Details:  details,
+	}
+	switch {
+	case errors.Is(err, errHealthCheckSkipped):
+		result.Status = dto.HealthSkipped
+		result.Latency = ""
+	case err != nil:
+		result.Status = dto.HealthDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// errHealthCheckSkipped marks a dependency that is not configured
+var errHealthCheckSkipped = errors.New("not configured")
+
+// pingDatabase checks a database answers
+func (s *HealthService) pingDatabase(db *gorm.DB) func(ctx context.Context) (interface{}, error) {
+	return func(ctx context.Context) (interface{}, error) {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		return nil, sqlDB.PingContext(ctx)
+	}
+}
+
+// pingRedis sends PING to the Redis server, authenticating first when the URL has a password
+func (s *HealthService) pingRedis(ctx context.Context) (interface{}, error) {
+	if s.redisURL == "" {
+		return nil, errHealthCheckSkipped
+	}
+
+	redisURL, err := url.Parse(s.redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REDIS_URL: %w", err)
+	}
+	address := redisURL.Host
+	if redisURL.Port() == "" {
+		address = net.JoinHostPort(redisURL.Hostname(), "6379")
+	}
+
+	var conn net.Conn
+	dialer := &net.Dialer{}
+	if redisURL.Scheme == "rediss" {
+		conn, err = (&tls.Dialer{NetDialer: dialer}).DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	reader := bufio.NewReader(conn)
+	if password, ok := redisURL.User.Password(); ok {
+		args := []string{"AUTH", password}
+		if username := redisURL.User.Username(); username != "" {
+			args = []string{"AUTH", username, password}
+		}
+		if err := redisCommand(conn, reader, "+OK", args...); err != nil {
+			return nil, err
+		}
+	}
+	return nil, redisCommand(conn, reader, "+PONG", "PING")
+}
+
+// redisCommand sends a command in the Redis protocol and checks the reply
+func redisCommand(conn net.Conn, reader *bufio.Reader, expected string, args ...string) error {
+	var command strings.Builder
+	fmt.Fprintf(&command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := conn.Write([]byte(command.String())); err != nil {
+		return err
+	}
+
+	reply, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	reply = strings.TrimSpace(reply)
+	if reply != expected {
+		return fmt.Errorf("unexpected reply to %s: %s", args[0], reply)
+	}
+	return nil
+}
+
+// checkStorage checks a file can be written to the upload directory
+func (s *HealthService) checkStorage(ctx context.Context) (interface{}, error) {
+	if s.uploadPath == "" {
+		return nil, errHealthCheckSkipped
+	}
+	if err := os.MkdirAll(s.uploadPath, 0o750); err != nil {
+		return nil, err
+	}
+
+	probe, err := os.CreateTemp(s.uploadPath, ".health-*")
+	if err != nil {
+		return nil, err
+	}
+	probe.Close()
+	return map[string]string{"path": s.uploadPath}, os.Remove(probe.Name())
+}
+
+// checkWebSocket checks the WebSocket manager is delivering events
+func (s *HealthService) checkWebSocket(ctx context.Context) (interface{}, error) {
+	stats := s.wsManager.GetConnectionStats()
+	if !s.wsManager.IsRunning() {
+		return stats, fmt.Errorf("websocket manager is not running")
+	}
+	return stats, nil
+}
+
+// report starts a health report
+func (s *HealthService) report(status string) *dto.HealthReport {
+	now := time.Now()
+	return &dto.HealthReport{
+		Status:    status,
+		Timestamp: now.UTC(),
+		Version:   version.Version,
+		Uptime:    now.Sub(s.startedAt).Round(time.Second).String(),
+	}
+}
@@ -0,0 +1,268 @@
+// internal/services/holiday_calendar.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"room-reservation-api/internal/breaker"
+	"room-reservation-api/internal/dto"
+)
+
+const (
+	holidayFetchTimeout = 10 * time.Second
+	// Holidays from the API are fetched again after this long
+	holidayCacheTTL = 24 * time.Hour
+	// After the API failed, the embedded dataset is used this long before the API is tried again
+	holidayRetryAfter = time.Hour
+	// Largest API response read
+	maxHolidayResponseSize = 1 << 20
+)
+
+// Sources of a holiday calendar
+const (
+	HolidaySourceAPI      = "api"
+	HolidaySourceEmbedded = "embedded"
+)
+
+// holidayYear is the cached calendar of one year
+type holidayYear struct {
+	holidays  map[string]dto.Holiday // By date, YYYY-MM-DD
+	source    string
+	expiresAt time.Time // Zero when it never expires
+}
+
+// HolidayCalendar knows the public holidays of the country and region the organization is in.
+// Holidays come from a public holiday API when one is configured, falling back to the embedded
+// dataset when the API fails or does not know the country. Bookings are closed on holidays and
+// scheduling skips them.
+type HolidayCalendar struct {
+	country    string // ISO 3166-1 alpha-2, empty disables holidays
+	region     string // ISO 3166-2, empty keeps nationwide holidays only
+	location   *time.Location
+	apiURL     string // Empty uses only the embedded dataset
+	httpClient *http.Client
+	breaker    *breaker.Breaker
+	logger     *slog.Logger
+
+	mu     sync.Mutex
+	years  map[int]*holidayYear
+	hits   int64 // Lookups answered from the cache
+	misses int64 // Lookups that loaded the year
+}
+
+// NewHolidayCalendar creates the holiday calendar of a country and optional region.
+// Days are matched in the given timezone.
+func NewHolidayCalendar(country, region, timezone, apiURL string, breakers *breaker.Registry, logger *slog.Logger) *HolidayCalendar {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		logger.Warn("invalid holiday timezone, using UTC", "timezone", timezone, "error", err)
+		location = time.UTC
+	}
+
+	return &HolidayCalendar{
+		country:    strings.ToUpper(strings.TrimSpace(country)),
+		region:     strings.ToUpper(strings.TrimSpace(region)),
+		location:   location,
+		apiURL:     strings.TrimRight(strings.TrimSpace(apiURL), "/"),
+		httpClient: &http.Client{Timeout: holidayFetchTimeout},
+		breaker:    breakers.Get("holiday-api", holidayFetchTimeout),
+		logger:     logger,
+		years:      make(map[int]*holidayYear),
+	}
+}
+
+// Enabled reports whether a country is configured
+func (c *HolidayCalendar) Enabled() bool {
+	return c != nil && c.country != ""
+}
+
+// HolidayOn returns the holiday falling on the day of t in the calendar's timezone, or nil
+func (c *HolidayCalendar) HolidayOn(t time.Time) *dto.Holiday {
+	if !c.Enabled() {
+		return nil
+	}
+
+	local := t.In(c.location)
+	year := c.year(local.Year())
+	if holiday, ok := year.holidays[local.Format("2006-01-02")]; ok {
+		return &holiday
+	}
+	return nil
+}
+
+// IsHoliday checks whether the calendar day is a holiday; the day's own location is ignored
+func (c *HolidayCalendar) IsHoliday(day time.Time) bool {
+	if !c.Enabled() {
+		return false
+	}
+	_, ok := c.year(day.Year()).holidays[day.Format("2006-01-02")]
+	return ok
+}
+
+// Calendar lists the holidays of a year
+func (c *HolidayCalendar) Calendar(year int) *dto.HolidayCalendarResponse {
+	response := &dto.HolidayCalendarResponse{
+		Country:  c.country,
+		Region:   c.region,
+		Timezone: c.location.String(),
+		Year:     year,
+		Holidays: []dto.Holiday{},
+	}
+	if !c.Enabled() {
+		return response
+	}
+
+	cached := c.year(year)
+	response.Source = cached.source
+	for _, holiday := range cached.holidays {
+		response.Holidays = append(response.Holidays, holiday)
+	}
+	sort.Slice(response.Holidays, func(i, j int) bool {
+		return response.Holidays[i].Date < response.Holidays[j].Date
+	})
+	return response
+}
+
+// CacheStats reports how often holiday lookups were answered from the cache
+func (c *HolidayCalendar) CacheStats() dto.CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := dto.CacheStats{
+		Name:    "holidays",
+		Entries: len(c.years),
+		Hits:    c.hits,
+		Misses:  c.misses,
+	}
+	if lookups := c.hits + c.misses; lookups > 0 {
+		stats.HitRate = float64(c.hits) / float64(lookups)
+	}
+	return stats
+}
+
+// year returns the cached holidays of a year, loading them when missing or expired
+func (c *HolidayCalendar) year(year int) *holidayYear {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.years[year]; ok && (cached.expiresAt.IsZero() || time.Now().Before(cached.expiresAt)) {
+		c.hits++
+		return cached
+	}
+
+	c.misses++
+	loaded := c.load(year)
+	c.years[year] = loaded
+	return loaded
+}
+
+// load gets the holidays of a year from the API, or from the embedded dataset
+func (c *HolidayCalendar) load(year int) *holidayYear {
+	if c.apiURL != "" {
+		holidays, err := breaker.Do(context.Background(), c.breaker, func(ctx context.Context) ([]dto.Holiday, error) {
+			return c.fetchHolidays(ctx, year)
+		})
+		if err == nil {
+			return &holidayYear{
+				holidays:  indexHolidays(holidays),
+				source:    HolidaySourceAPI,
+				expiresAt: time.Now().Add(holidayCacheTTL),
+			}
+		}
+		c.logger.Warn("failed to fetch public holidays, using embedded dataset",
+			"country", c.country, "year", year, "error", err)
+	}
+
+	loaded := &holidayYear{
+		holidays: indexHolidays(embeddedHolidays(c.country, c.region, year)),
+		source:   HolidaySourceEmbedded,
+	}
+	if c.apiURL != "" {
+		loaded.expiresAt = time.Now().Add(holidayRetryAfter)
+	}
+	return loaded
+}
+
+// apiHoliday is a holiday as returned by the Nager.Date public holiday API
+type apiHoliday struct {
+	Date      string   `json:"date"`
+	LocalName string   `json:"localName"`
+	Name      string   `json:"name"`
+	Global    bool     `json:"global"`   // Observed in the whole country
+	Counties  []string `json:"counties"` // ISO 3166-2 regions observing it when not global
+}
+
+// fetchHolidays requests the holidays of a year and keeps those observed in the region
+func (c *HolidayCalendar) fetchHolidays(ctx context.Context, year int) ([]dto.Holiday, error) {
+	url := fmt.Sprintf("%s/PublicHolidays/%d/%s", c.apiURL, year, c.country)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var entries []apiHoliday
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxHolidayResponseSize)).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode holidays: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("no holidays returned")
+	}
+
+	holidays := make([]dto.Holiday, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Global && !containsFold(entry.Counties, c.region) {
+			continue
+		}
+		holidays = append(holidays, dto.Holiday{
+			Date:      entry.Date,
+			Name:      entry.Name,
+			LocalName: entry.LocalName,
+		})
+	}
+	return holidays, nil
+}
+
+// indexHolidays indexes holidays by date; the first of two on the same day is kept
+func indexHolidays(holidays []dto.Holiday) map[string]dto.Holiday {
+	indexed := make(map[string]dto.Holiday, len(holidays))
+	for _, holiday := range holidays {
+		if _, ok := indexed[holiday.Date]; !ok {
+			indexed[holiday.Date] = holiday
+		}
+	}
+	return indexed
+}
+
+// containsFold checks if the list holds the value, ignoring case
+func containsFold(values []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+// internal/services/holiday_dataset.go
+package services
+
+import (
+	"time"
+
+	"room-reservation-api/internal/dto"
+)
+
+// holidayRule describes how to find the date of a public holiday in a year
+type holidayRule struct {
+	name    string
+	date    func(year int) time.Time
+	regions []string // ISO 3166-2 regions observing it, empty when nationwide
+	since   int      // First year observed, 0 when always
+}
+
+// embeddedHolidayRules is the offline dataset used without a holiday API. Observed-day
+// substitutions for holidays falling on a weekend are not applied, and holidays that follow
+// the lunar calendar (such as Eid in Morocco) are only known from the API.
+var embeddedHolidayRules = map[string][]holidayRule{
+	"BE": {
+		{name: "New Year's Day", date: fixedDate(time.January, 1)},
+		{name: "Easter Monday", date: easterOffset(1)},
+		{name: "Labour Day", date: fixedDate(time.May, 1)},
+		{name: "Ascension Day", date: easterOffset(39)},
+		{name: "Whit Monday", date: easterOffset(50)},
+		{name: "National Day", date: fixedDate(time.July, 21)},
+		{name: "Assumption Day", date: fixedDate(time.August, 15)},
+		{name: "All Saints' Day", date: fixedDate(time.November, 1)},
+		{name: "Armistice Day", date: fixedDate(time.November, 11)},
+		{name: "Christmas Day", date: fixedDate(time.December, 25)},
+	},
+	"DE": {
+		{name: "New Year's Day", date: fixedDate(time.January, 1)},
+		{name: "Epiphany", date: fixedDate(time.January, 6), regions: []string{"DE-BW", "DE-BY", "DE-ST"}},
+		{name: "International Women's Day", date: fixedDate(time.March, 8), regions: []string{"DE-BE"}, since: 2019},
+		{name: "Good Friday", date: easterOffset(-2)},
+		{name: "Easter Monday", date: easterOffset(1)},
+		{name: "Labour Day", date: fixedDate(time.May, 1)},
+		{name: "Ascension Day", date: easterOffset(39)},
+		{name: "Whit Monday", date: easterOffset(50)},
+		{name: "Corpus Christi", date: easterOffset(60), regions: []string{"DE-BW", "DE-BY", "DE-HE", "DE-NW", "DE-RP", "DE-SL"}},
+		{name: "German Unity Day", date: fixedDate(time.October, 3)},
+		{name: "Reformation Day", date: fixedDate(time.October, 31), regions: []string{"DE-BB", "DE-HB", "DE-HH", "DE-MV", "DE-NI", "DE-SH", "DE-SN", "DE-ST", "DE-TH"}},
+		{name: "All Saints' Day", date: fixedDate(time.November, 1), regions: []string{"DE-BW", "DE-BY", "DE-NW", "DE-RP", "DE-SL"}},
+		{name: "Christmas Day", date: fixedDate(time.December, 25)},
+		{name: "St. Stephen's Day", date: fixedDate(time.December, 26)},
+	},
+	"FR": {
+		{name: "New Year's Day", date: fixedDate(time.January, 1)},
+		{name: "Good Friday", date: easterOffset(-2), regions: []string{"FR-57", "FR-67", "FR-68"}},
+		{name: "Easter Monday", date: easterOffset(1)},
+		{name: "Labour Day", date: fixedDate(time.May, 1)},
+		{name: "Victory in Europe Day", date: fixedDate(time.May, 8)},
+		{name: "Ascension Day", date: easterOffset(39)},
+		{name: "Whit Monday", date: easterOffset(50)},
+		{name: "Bastille Day", date: fixedDate(time.July, 14)},
+		{name: "Assumption Day", date: fixedDate(time.August, 15)},
+		{name: "All Saints' Day", date: fixedDate(time.November, 1)},
+		{name: "Armistice Day", date: fixedDate(time.November, 11)},
+		{name: "Christmas Day", date: fixedDate(time.December, 25)},
+		{name: "St. Stephen's Day", date: fixedDate(time.December, 26), regions: []string{"FR-57", "FR-67", "FR-68"}},
+	},
+	"GB": {
+		{name: "New Year's Day", date: fixedDate(time.January, 1)},
+		{name: "2nd January", date: fixedDate(time.January, 2), regions: []string{"GB-SCT"}},
+		{name: "Saint Patrick's Day", date: fixedDate(time.March, 17), regions: []string{"GB-NIR"}},
+		{name: "Good Friday", date: easterOffset(-2)},
+		{name: "Easter Monday", date: easterOffset(1), regions: []string{"GB-ENG", "GB-WLS", "GB-NIR"}},
+		{name: "Early May Bank Holiday", date: nthWeekday(time.May, time.Monday, 1)},
+		{name: "Spring Bank Holiday", date: nthWeekday(time.May, time.Monday, -1)},
+		{name: "Battle of the Boyne", date: fixedDate(time.July, 12), regions: []string{"GB-NIR"}},
+		{name: "Summer Bank Holiday", date: nthWeekday(time.August, time.Monday, 1), regions: []string{"GB-SCT"}},
+		{name: "Summer Bank Holiday", date: nthWeekday(time.August, time.Monday, -1), regions: []string{"GB-ENG", "GB-WLS", "GB-NIR"}},
+		{name: "Saint Andrew's Day", date: fixedDate(time.November, 30), regions: []string{"GB-SCT"}},
+		{name: "Christmas Day", date: fixedDate(time.December, 25)},
+		{name: "Boxing Day", date: fixedDate(time.December, 26)},
+	},
+	"MA": {
+		{name: "New Year's Day", date: fixedDate(time.January, 1)},
+		{name: "Proclamation of Independence", date: fixedDate(time.January, 11)},
+		{name: "Amazigh New Year", date: fixedDate(time.January, 14), since: 2024},
+		{name: "Labour Day", date: fixedDate(time.May, 1)},
+		{name: "Throne Day", date: fixedDate(time.July, 30)},
+		{name: "Oued Ed-Dahab Day", date: fixedDate(time.August, 14)},
+		{name: "Revolution Day", date: fixedDate(time.August, 20)},
+		{name: "Youth Day", date: fixedDate(time.August, 21)},
+		{name: "Green March", date: fixedDate(time.November, 6)},
+		{name: "Independence Day", date: fixedDate(time.November, 18)},
+	},
+	"US": {
+		{name: "New Year's Day", date: fixedDate(time.January, 1)},
+		{name: "Martin Luther King, Jr. Day", date: nthWeekday(time.January, time.Monday, 3)},
+		{name: "Presidents Day", date: nthWeekday(time.February, time.Monday, 3)},
+		{name: "Memorial Day", date: nthWeekday(time.May, time.Monday, -1)},
+		{name: "Juneteenth", date: fixedDate(time.June, 19), since: 2021},
+		{name: "Independence Day", date: fixedDate(time.July, 4)},
+		{name: "Labor Day", date: nthWeekday(time.September, time.Monday, 1)},
+		{name: "Columbus Day", date: nthWeekday(time.October, time.Monday, 2)},
+		{name: "Veterans Day", date: fixedDate(time.November, 11)},
+		{name: "Thanksgiving Day", date: nthWeekday(time.November, time.Thursday, 4)},
+		{name: "Christmas Day", date: fixedDate(time.December, 25)},
+	},
+}
+
+// embeddedHolidays lists the holidays of the dataset observed in the country and region during the year
+func embeddedHolidays(country, region string, year int) []dto.Holiday {
+	var holidays []dto.Holiday
+	for _, rule := range embeddedHolidayRules[country] {
+		if year < rule.since {
+			continue
+		}
+		if len(rule.regions) > 0 && !containsFold(rule.regions, region) {
+			continue
+		}
+		holidays = append(holidays, dto.Holiday{
+			Date: rule.date(year).Format("2006-01-02"),
+			Name: rule.name,
+		})
+	}
+	return holidays
+}
+
+// fixedDate is a holiday on the same day every year
+func fixedDate(month time.Month, day int) func(year int) time.Time {
+	return func(year int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// easterOffset is a holiday the given number of days after Easter Sunday
+func easterOffset(days int) func(year int) time.Time {
+	return func(year int) time.Time {
+		return easterSunday(year).AddDate(0, 0, days)
+	}
+}
+
+// nthWeekday is a holiday on the nth weekday of the month; a negative n counts from the end
+func nthWeekday(month time.Month, weekday time.Weekday, n int) func(year int) time.Time {
+	return func(year int) time.Time {
+		if n < 0 {
+			last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+			back := (int(last.Weekday()) - int(weekday) + 7) % 7
+			return last.AddDate(0, 0, -back+7*(n+1))
+		}
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		ahead := (int(weekday) - int(first.Weekday()) + 7) % 7
+		return first.AddDate(0, 0, ahead+7*(n-1))
+	}
+}
+
+// easterSunday computes the date of Easter Sunday in the Gregorian calendar
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,74 @@
+// internal/services/image_resize.go
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// Decoders of the accepted upload formats
+	_ "image/gif"
+	_ "image/png"
+)
+
+// Quality of the JPEG images stored for uploaded photos
+const resizedJPEGQuality = 85
+
+// fitWithin scales width and height down to fit in a box, keeping the aspect ratio.
+// Images already within the box keep their size.
+func fitWithin(width, height, maxWidth, maxHeight int) (int, int) {
+	if width <= maxWidth && height <= maxHeight {
+		return width, height
+	}
+	if width*maxHeight > height*maxWidth {
+		return maxWidth, max(1, height*maxWidth/width)
+	}
+	return max(1, width*maxHeight/height), maxHeight
+}
+
+// resizeImage scales an image down to fit within a box, averaging the source pixels each
+// target pixel covers. Transparent areas are flattened onto white since JPEG has no alpha.
+func resizeImage(src image.Image, maxWidth, maxHeight int) *image.RGBA {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	width, height := fitWithin(srcWidth, srcHeight, maxWidth, maxHeight)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcHeight/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcHeight/height)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcWidth/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcWidth/width)
+
+			var r, g, b, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					// Premultiplied channels over a white background
+					r += uint64(cr + 0xffff - ca)
+					g += uint64(cg + 0xffff - ca)
+					b += uint64(cb + 0xffff - ca)
+					count++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / count >> 8),
+				G: uint8(g / count >> 8),
+				B: uint8(b / count >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
+
+// encodeJPEG encodes an image as JPEG for storage
+func encodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: resizedJPEGQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -36,6 +36,20 @@ type ChatService interface {
 	MarkMessagesAsRead(ctx context.Context, userID uuid.UUID, req *dto.MarkMessagesReadRequest) error
 	GetReadReceipts(ctx context.Context, userID uuid.UUID, messageID uuid.UUID) ([]dto.ReadReceiptResponse, error)
 
+	// Mute and block operations
+	MuteConversation(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID, req *dto.MuteConversationRequest) error
+	UnmuteConversation(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID) error
+	BlockUser(ctx context.Context, userID uuid.UUID, req *dto.BlockUserRequest) error
+	UnblockUser(ctx context.Context, userID uuid.UUID, blockedUserID uuid.UUID) error
+	GetBlockedUsers(ctx context.Context, userID uuid.UUID) ([]dto.UserBlockResponse, error)
+
+	// Sync operations
+	SyncChat(ctx context.Context, userID uuid.UUID, req *dto.ChatSyncRequest) (*dto.ChatSyncResponse, error)
+
+	// Delivery receipt operations
+	MarkMessagesAsDelivered(ctx context.Context, userID uuid.UUID, req *dto.MarkMessagesDeliveredRequest) error
+	GetMessageDelivery(ctx context.Context, userID uuid.UUID, messageID uuid.UUID) (*dto.MessageDeliveryResponse, error)
+
 	// File operations
 	UploadFile(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID, file multipart.File, header *multipart.FileHeader) (*dto.FileUploadResponse, error)
 	DeleteAttachment(ctx context.Context, userID uuid.UUID, attachmentID uuid.UUID) error
@@ -59,7 +73,8 @@ type ChatService interface {
 	// System operations
 	CreateSystemMessage(ctx context.Context, conversationID uuid.UUID, messageType string, content string, metadata map[string]interface{}) error
 	NotifyReservationUpdate(ctx context.Context, userID uuid.UUID, reservationID uuid.UUID, messageType string) error
-	CleanupOldMessages(ctx context.Context, retentionDays int) error
+	ApplyRetentionPolicy(ctx context.Context) error
+	ExportConversation(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID) (*dto.ConversationTranscript, error)
 
 	// Permission helpers
 	CanUserAccessConversation(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID) (bool, error)
@@ -0,0 +1,141 @@
+// internal/services/mailer.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+
+	"room-reservation-api/internal/breaker"
+)
+
+// Mailer sends plain-text emails
+type Mailer interface {
+	Send(to, subject, body string) error
+	SendWithAttachments(to, subject, body string, attachments []MailAttachment) error
+}
+
+// MailAttachment is a file attached to an email
+type MailAttachment struct {
+	Filename    string
+	ContentType string // e.g. "text/calendar; charset=UTF-8; method=REQUEST"
+	Data        []byte
+}
+
+// SMTPMailer sends emails through an SMTP relay
+type SMTPMailer struct {
+	host     string
+	port     string
+	user     string
+	password string
+	from     string
+}
+
+// NewSMTPMailer creates a mailer for the given SMTP relay
+func NewSMTPMailer(host, port, user, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		host:     host,
+		port:     port,
+		user:     user,
+		password: password,
+		from:     from,
+	}
+}
+
+// Send sends a plain-text email
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	return m.send(to, subject, "text/plain; charset=UTF-8", []byte(body))
+}
+
+// SendWithAttachments sends a plain-text email with files attached
+func (m *SMTPMailer) SendWithAttachments(to, subject, body string, attachments []MailAttachment) error {
+	if len(attachments) == 0 {
+		return m.Send(to, subject, body)
+	}
+
+	var content bytes.Buffer
+	writer := multipart.NewWriter(&content)
+
+	textPart, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=UTF-8"}})
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+	textPart.Write([]byte(body))
+
+	for _, attachment := range attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", attachment.Filename)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to build email: %w", err)
+		}
+		encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+		for len(encoded) > 76 {
+			part.Write([]byte(encoded[:76] + "\r\n"))
+			encoded = encoded[76:]
+		}
+		part.Write([]byte(encoded))
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+
+	return m.send(to, subject, "multipart/mixed; boundary="+writer.Boundary(), content.Bytes())
+}
+
+// send delivers a message with the given body content type
+func (m *SMTPMailer) send(to, subject, contentType string, body []byte) error {
+	var auth smtp.Auth
+	if m.user != "" {
+		auth = smtp.PlainAuth("", m.user, m.password, m.host)
+	}
+
+	headers := []string{
+		"From: " + m.from,
+		"To: " + to,
+		"Subject: " + strings.ReplaceAll(subject, "\n", " "),
+		"MIME-Version: 1.0",
+		"Content-Type: " + contentType,
+	}
+	msg := append([]byte(strings.Join(headers, "\r\n")+"\r\n\r\n"), body...)
+
+	if err := smtp.SendMail(m.host+":"+m.port, auth, m.from, []string{to}, msg); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// breakerMailer sends through another mailer behind a circuit breaker, so a slow or
+// unreachable relay fails fast instead of holding up the request that sends the email
+type breakerMailer struct {
+	mailer  Mailer
+	breaker *breaker.Breaker
+}
+
+// NewBreakerMailer wraps a mailer with a circuit breaker
+func NewBreakerMailer(mailer Mailer, b *breaker.Breaker) Mailer {
+	return &breakerMailer{mailer: mailer, breaker: b}
+}
+
+// Send sends a plain-text email unless the relay's breaker is open
+func (m *breakerMailer) Send(to, subject, body string) error {
+	return m.breaker.Execute(context.Background(), func(ctx context.Context) error {
+		return m.mailer.Send(to, subject, body)
+	})
+}
+
+// SendWithAttachments sends an email with files attached unless the relay's breaker is open
+func (m *breakerMailer) SendWithAttachments(to, subject, body string, attachments []MailAttachment) error {
+	return m.breaker.Execute(context.Background(), func(ctx context.Context) error {
+		return m.mailer.SendWithAttachments(to, subject, body, attachments)
+	})
+}
@@ -0,0 +1,337 @@
+// internal/services/moderation_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+	"room-reservation-api/internal/websocket"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Default length of a chat ban when the moderator does not choose one
+const defaultChatBanDuration = 24 * time.Hour
+
+// ModerationService handles abuse reports and chat bans
+type ModerationService struct {
+	moderationRepo      interfaces.ModerationRepository
+	chatRepo            interfaces.ChatRepository
+	notificationService *NotificationService
+	wsManager           *websocket.Manager
+	logger              *slog.Logger
+}
+
+// NewModerationService creates a new moderation service instance
+func NewModerationService(
+	moderationRepo interfaces.ModerationRepository,
+	chatRepo interfaces.ChatRepository,
+	notificationService *NotificationService,
+	wsManager *websocket.Manager,
+	logger *slog.Logger,
+) *ModerationService {
+	return &ModerationService{
+		moderationRepo:      moderationRepo,
+		chatRepo:            chatRepo,
+		notificationService: notificationService,
+		wsManager:           wsManager,
+		logger:              logger,
+	}
+}
+
+// Report operations
+
+// ReportMessage flags a message in one of the user's conversations for review
+func (s *ModerationService) ReportMessage(ctx context.Context, userID uuid.UUID, messageID uuid.UUID, req *dto.ReportMessageRequest) (*dto.MessageReportResponse, error) {
+	message, err := s.chatRepo.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("message not found")
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	isParticipant, err := s.chatRepo.IsUserParticipant(ctx, userID, message.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	if !isParticipant {
+		return nil, dto.ErrAccessDenied
+	}
+
+	if message.SenderID == userID {
+		return nil, errors.New("cannot report your own message")
+	}
+
+	alreadyReported, err := s.moderationRepo.HasPendingReport(ctx, messageID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing reports: %w", err)
+	}
+	if alreadyReported {
+		return nil, errors.New("message already reported")
+	}
+
+	report := &models.MessageReport{
+		MessageID:      message.ID,
+		ConversationID: message.ConversationID,
+		ReporterID:     userID,
+		ReportedUserID: message.SenderID,
+		MessageContent: displayContent(message),
+		Reason:         models.ReportReason(req.Reason),
+		Details:        req.Details,
+		Status:         models.ReportStatusPending,
+	}
+
+	if err := s.moderationRepo.CreateReport(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "Message reported",
+		"reportID", report.ID,
+		"messageID", messageID,
+		"reporterID", userID,
+		"reason", req.Reason)
+
+	created, err := s.moderationRepo.GetReportByID(ctx, report.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+
+	return s.mapReportToResponse(created), nil
+}
+
+// GetReports returns the moderation queue, oldest reports first
+func (s *ModerationService) GetReports(ctx context.Context, req *dto.GetReportsRequest) (*dto.MessageReportListResponse, error) {
+	limit := 20
+	if req.Limit > 0 {
+		limit = req.Limit
+	}
+
+	reports, total, err := s.moderationRepo.GetReports(ctx, req.Status, limit, req.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reports: %w", err)
+	}
+
+	response := &dto.MessageReportListResponse{
+		Reports:    make([]dto.MessageReportResponse, len(reports)),
+		TotalCount: total,
+		HasMore:    int64(req.Offset+len(reports)) < total,
+	}
+	for i := range reports {
+		response.Reports[i] = *s.mapReportToResponse(&reports[i])
+	}
+
+	return response, nil
+}
+
+// TakeAction applies a moderator decision to a pending report
+func (s *ModerationService) TakeAction(ctx context.Context, moderatorID uuid.UUID, reportID uuid.UUID, req *dto.ModerationActionRequest) (*dto.MessageReportResponse, error) {
+	report, err := s.moderationRepo.GetReportByID(ctx, reportID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("report not found")
+		}
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+
+	if !report.IsPending() {
+		return nil, errors.New("report has already been resolved")
+	}
+
+	action := models.ModerationAction(req.Action)
+	switch action {
+	case models.ModerationActionDeleteMessage:
+		if err := s.deleteReportedMessage(ctx, report, moderatorID); err != nil {
+			return nil, err
+		}
+	case models.ModerationActionWarn:
+		message := "One of your messages was reported and reviewed by a moderator. Please keep conversations respectful."
+		if req.Note != "" {
+			message += " Moderator note: " + req.Note
+		}
+		s.notify(report.ReportedUserID, models.NotificationTypeChatWarning, "Chat warning", message)
+	case models.ModerationActionBan:
+		if err := s.banUser(ctx, report, moderatorID, req); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	report.Status = models.ReportStatusActioned
+	if action == models.ModerationActionDismiss {
+		report.Status = models.ReportStatusDismissed
+	}
+	report.Action = action
+	report.ModeratorNote = req.Note
+	report.ResolvedByID = &moderatorID
+	report.ResolvedAt = &now
+
+	if err := s.moderationRepo.UpdateReport(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to update report: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "Moderation action taken",
+		"reportID", reportID,
+		"action", action,
+		"moderatorID", moderatorID,
+		"reportedUserID", report.ReportedUserID)
+
+	updated, err := s.moderationRepo.GetReportByID(ctx, reportID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+
+	return s.mapReportToResponse(updated), nil
+}
+
+// deleteReportedMessage removes the message and closes other reports about it
+func (s *ModerationService) deleteReportedMessage(ctx context.Context, report *models.MessageReport, moderatorID uuid.UUID) error {
+	if err := s.chatRepo.DeleteMessage(ctx, report.MessageID); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+
+	if err := s.moderationRepo.ResolvePendingReportsForMessage(ctx, report.MessageID, models.ModerationActionDeleteMessage, moderatorID); err != nil {
+		s.logger.WarnContext(ctx, "Failed to close related reports", "messageID", report.MessageID, "error", err)
+	}
+
+	if s.wsManager != nil {
+		s.wsManager.BroadcastMessageDeleted(report.ConversationID, report.MessageID, moderatorID)
+	}
+
+	return nil
+}
+
+// banUser blocks the reported user from sending chat messages for a while
+func (s *ModerationService) banUser(ctx context.Context, report *models.MessageReport, moderatorID uuid.UUID, req *dto.ModerationActionRequest) error {
+	duration := defaultChatBanDuration
+	if req.BanDurationHours > 0 {
+		duration = time.Duration(req.BanDurationHours) * time.Hour
+	}
+
+	reason := req.Note
+	if reason == "" {
+		reason = fmt.Sprintf("Reported for %s", report.Reason)
+	}
+
+	ban := &models.ChatBan{
+		UserID:     report.ReportedUserID,
+		ReportID:   &report.ID,
+		Reason:     reason,
+		BannedByID: moderatorID,
+		ExpiresAt:  time.Now().Add(duration),
+	}
+	if err := s.moderationRepo.CreateBan(ctx, ban); err != nil {
+		return fmt.Errorf("failed to create ban: %w", err)
+	}
+
+	s.notify(report.ReportedUserID, models.NotificationTypeChatBan, "Chat access suspended",
+		fmt.Sprintf("You cannot send chat messages until %s. Reason: %s", ban.ExpiresAt.Format("Mon Jan 2 15:04"), reason))
+
+	return nil
+}
+
+// Ban operations
+
+// GetActiveBans lists bans currently in force
+func (s *ModerationService) GetActiveBans(ctx context.Context, limit, offset int) (*dto.ChatBanListResponse, error) {
+	bans, total, err := s.moderationRepo.GetActiveBans(ctx, time.Now(), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bans: %w", err)
+	}
+
+	response := &dto.ChatBanListResponse{
+		Bans:       make([]dto.ChatBanResponse, len(bans)),
+		TotalCount: total,
+		HasMore:    int64(offset+len(bans)) < total,
+	}
+	for i, ban := range bans {
+		response.Bans[i] = dto.ChatBanResponse{
+			ID:         ban.ID,
+			UserID:     ban.UserID,
+			User:       mapModerationUser(ban.User),
+			ReportID:   ban.ReportID,
+			Reason:     ban.Reason,
+			BannedByID: ban.BannedByID,
+			ExpiresAt:  ban.ExpiresAt,
+			CreatedAt:  ban.CreatedAt,
+		}
+	}
+
+	return response, nil
+}
+
+// LiftBan ends a ban before it expires
+func (s *ModerationService) LiftBan(ctx context.Context, banID uuid.UUID) error {
+	ban, err := s.moderationRepo.GetBanByID(ctx, banID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("ban not found")
+		}
+		return fmt.Errorf("failed to get ban: %w", err)
+	}
+
+	if !ban.IsActive(time.Now()) {
+		return errors.New("ban is no longer active")
+	}
+
+	return s.moderationRepo.RevokeBan(ctx, banID, time.Now())
+}
+
+// Helper functions
+
+func (s *ModerationService) notify(userID uuid.UUID, notificationType models.NotificationType, title, message string) {
+	if s.notificationService == nil {
+		return
+	}
+
+	if _, err := s.notificationService.Notify(userID, notificationType, title, message, nil); err != nil {
+		s.logger.Warn("Failed to send moderation notification", "userID", userID, "type", notificationType, "error", err)
+	}
+}
+
+func (s *ModerationService) mapReportToResponse(report *models.MessageReport) *dto.MessageReportResponse {
+	response := &dto.MessageReportResponse{
+		ID:             report.ID,
+		MessageID:      report.MessageID,
+		ConversationID: report.ConversationID,
+		MessageContent: report.MessageContent,
+		Reason:         string(report.Reason),
+		Details:        report.Details,
+		Status:         string(report.Status),
+		Action:         string(report.Action),
+		ModeratorNote:  report.ModeratorNote,
+		Reporter:       mapModerationUser(report.Reporter),
+		ReportedUser:   mapModerationUser(report.ReportedUser),
+		ResolvedAt:     report.ResolvedAt,
+		CreatedAt:      report.CreatedAt,
+	}
+
+	if report.ResolvedBy != nil {
+		resolvedBy := mapModerationUser(report.ResolvedBy)
+		response.ResolvedBy = &resolvedBy
+	}
+
+	return response
+}
+
+func mapModerationUser(user *models.User) dto.UserInfo {
+	if user == nil {
+		return dto.UserInfo{}
+	}
+
+	return dto.UserInfo{
+		ID:        user.ID,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		Role:      string(user.Role),
+		IsActive:  user.IsActive,
+	}
+}
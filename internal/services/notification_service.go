@@ -0,0 +1,374 @@
+// internal/services/notification_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/breaker"
+	"room-reservation-api/internal/deeplink"
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+	"room-reservation-api/internal/websocket"
+)
+
+// NotificationService stores in-app notifications and delivers them by email
+type NotificationService struct {
+	notificationRepo interfaces.NotificationRepositoryInterface
+	userRepo         interfaces.UserRepositoryInterface
+	deadLetterRepo   interfaces.DeadLetterRepositoryInterface
+	chatRepo         interfaces.ChatRepository // Optional, nil ignores conversation mutes
+	mailer           Mailer                    // Optional, nil keeps notifications in-app only
+	maxRetries       int
+	baseURL          string // API base URL of the HTTPS form of deep links in emails
+	logger           *slog.Logger
+	wsManager        *websocket.Manager // Optional, nil disables realtime events
+	events           *OutboxDispatcher  // Optional, nil leaves new notifications to the dispatch job
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(
+	notificationRepo interfaces.NotificationRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	deadLetterRepo interfaces.DeadLetterRepositoryInterface,
+	chatRepo interfaces.ChatRepository,
+	mailer Mailer,
+	maxRetries int,
+	baseURL string,
+	logger *slog.Logger,
+	wsManager *websocket.Manager,
+	events *OutboxDispatcher,
+) *NotificationService {
+	if maxRetries <= 0 {
+		maxRetries = 3
+	}
+
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		deadLetterRepo:   deadLetterRepo,
+		chatRepo:         chatRepo,
+		mailer:           mailer,
+		maxRetries:       maxRetries,
+		baseURL:          baseURL,
+		logger:           logger,
+		wsManager:        wsManager,
+		events:           events,
+	}
+}
+
+// ========================================
+// SENDING
+// ========================================
+
+// Notify stores a notification for a user; the outbox dispatcher emails it and pushes it to the
+// user's realtime streams. A conversation_id or reservation_id in the data gives the notification
+// a deep link to that screen. Notifications about a conversation the user muted are dropped and
+// nil is returned.
+func (s *NotificationService) Notify(userID uuid.UUID, notificationType models.NotificationType, title, message string, data map[string]interface{}) (*models.Notification, error) {
+	if s.isMutedFor(userID, data) {
+		s.logger.Debug("Notification suppressed by conversation mute", "userID", userID, "type", notificationType)
+		return nil, nil
+	}
+
+	notification := &models.Notification{
+		UserID:      userID,
+		Type:        notificationType,
+		Title:       title,
+		Message:     message,
+		Status:      models.NotificationStatusPending,
+		ScheduledAt: time.Now(),
+		MaxRetries:  s.maxRetries,
+	}
+
+	if data != nil {
+		dataBytes, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize notification data: %w", err)
+		}
+		notification.Data = datatypes.JSON(dataBytes)
+	}
+	if link, ok := linkFromData(data); ok {
+		notification.DeepLink = link.String()
+	}
+
+	created, err := s.notificationRepo.Create(notification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	s.events.Wake()
+	return created, nil
+}
+
+// NotifyOrganizers sends the same notification to the owner and every co-organizer of a reservation,
+// adding both parties to the data. Delivery to each is attempted even if another fails.
+func (s *NotificationService) NotifyOrganizers(reservation *models.Reservation, notificationType models.NotificationType, title, message string, data map[string]interface{}) error {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	data["owner_id"] = reservation.UserID
+	if len(reservation.CoOrganizers) > 0 {
+		data["co_organizer_ids"] = reservation.OrganizerIDs()[1:]
+	}
+
+	var failed error
+	for _, userID := range reservation.OrganizerIDs() {
+		if _, err := s.Notify(userID, notificationType, title, message, data); err != nil {
+			failed = err
+		}
+	}
+	return failed
+}
+
+// DeliverOutboxEvent emails a new notification and pushes it to the realtime streams of its user.
+// It handles notification_created events of the outbox.
+func (s *NotificationService) DeliverOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	notification, err := s.notificationRepo.GetByID(event.AggregateID)
+	if err != nil {
+		// Deleted since, e.g. with the account of its user
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get notification: %w", err)
+	}
+
+	// Only the first attempt is made here; failed emails are left to the retry job, which has
+	// its own retry limit and dead letters, and a redispatched event does not email twice
+	if notification.Status == models.NotificationStatusPending && notification.RetryCount == 0 && notification.LastError == "" {
+		s.deliver(notification)
+	}
+
+	if s.wsManager != nil {
+		wsEvent := websocket.NewNotificationCreatedEvent(notification.UserID, websocket.NotificationEventData{
+			NotificationID: notification.ID,
+			Type:           string(notification.Type),
+			Title:          notification.Title,
+			Message:        notification.Message,
+			DeepLink:       notification.DeepLink,
+			CreatedAt:      notification.CreatedAt,
+		})
+		wsEvent.Timestamp = event.CreatedAt
+		s.wsManager.BroadcastToUser(notification.UserID, wsEvent)
+	}
+	return nil
+}
+
+// RetryPendingDeliveries retries email delivery for notifications that failed earlier
+func (s *NotificationService) RetryPendingDeliveries(limit int) (int, error) {
+	notifications, err := s.notificationRepo.GetPendingDeliveries(time.Now(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending notifications: %w", err)
+	}
+
+	delivered := 0
+	for _, notification := range notifications {
+		if s.deliver(notification) {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+// deliver emails a notification and records the outcome, returning true when sent
+func (s *NotificationService) deliver(notification *models.Notification) bool {
+	now := time.Now()
+
+	// Without a mailer the in-app copy is the delivery
+	if s.mailer == nil {
+		s.notificationRepo.Update(notification.ID, map[string]interface{}{
+			"status":  models.NotificationStatusSent,
+			"sent_at": now,
+		})
+		return true
+	}
+
+	email := notification.User.Email
+	if email == "" {
+		user, err := s.userRepo.GetByID(notification.UserID)
+		if err != nil {
+			s.logger.Error("Failed to load notification recipient", "notificationID", notification.ID, "error", err)
+			return false
+		}
+		email = user.Email
+	}
+
+	body := notification.Message + s.formatLinks(notification)
+	if err := s.mailer.Send(email, notification.Title, body); err != nil {
+		// The retry job sends it once the relay is back, without using up a retry
+		if errors.Is(err, breaker.ErrOpen) {
+			s.notificationRepo.Update(notification.ID, map[string]interface{}{"last_error": err.Error()})
+			return false
+		}
+
+		retryCount := notification.RetryCount + 1
+		status := models.NotificationStatusPending
+		if retryCount >= notification.MaxRetries {
+			status = models.NotificationStatusFailed
+		}
+
+		s.logger.Warn("Notification delivery failed",
+			"notificationID", notification.ID,
+			"attempt", retryCount,
+			"error", err)
+
+		s.notificationRepo.Update(notification.ID, map[string]interface{}{
+			"status":      status,
+			"retry_count": retryCount,
+			"last_error":  err.Error(),
+		})
+		if status == models.NotificationStatusFailed {
+			s.deadLetter(notification, email, err)
+		}
+		return false
+	}
+
+	s.notificationRepo.Update(notification.ID, map[string]interface{}{
+		"status":  models.NotificationStatusSent,
+		"sent_at": now,
+	})
+	return true
+}
+
+// deadLetter keeps a notification out of retries so an admin can replay it
+func (s *NotificationService) deadLetter(notification *models.Notification, email string, deliveryErr error) {
+	payload, err := json.Marshal(dto.NotificationDeadLetterPayload{
+		NotificationID: notification.ID,
+		UserID:         notification.UserID,
+		Email:          email,
+		Title:          notification.Title,
+		Message:        notification.Message,
+		Data:           json.RawMessage(notification.Data),
+	})
+	if err != nil {
+		s.logger.Error("Failed to serialize dead letter", "notificationID", notification.ID, "error", err)
+		return
+	}
+
+	if _, err := s.deadLetterRepo.Record(&models.DeadLetter{
+		Source:      models.DeadLetterSourceNotification,
+		ReferenceID: &notification.ID,
+		Name:        string(notification.Type),
+		Payload:     datatypes.JSON(payload),
+		LastError:   deliveryErr.Error(),
+	}); err != nil {
+		s.logger.Error("Failed to record dead letter", "notificationID", notification.ID, "error", err)
+	}
+}
+
+// formatLinks renders the deep link, as its HTTPS form, and the "links" entry of the
+// notification data as plain text
+func (s *NotificationService) formatLinks(notification *models.Notification) string {
+	links := map[string]string{}
+	if notification.Data != nil {
+		var payload struct {
+			Links map[string]string `json:"links"`
+		}
+		if err := json.Unmarshal(notification.Data, &payload); err == nil {
+			links = payload.Links
+		}
+	}
+
+	var text string
+	if link, err := deeplink.Parse(notification.DeepLink); err == nil {
+		text += "\n\nOpen: " + link.RedirectURL(s.baseURL)
+	}
+	if len(links) > 0 && text == "" {
+		text = "\n"
+	}
+	for label, url := range links {
+		text += fmt.Sprintf("\n%s: %s", label, url)
+	}
+	return text
+}
+
+// isMutedFor reports whether the data points at a conversation the user muted
+func (s *NotificationService) isMutedFor(userID uuid.UUID, data map[string]interface{}) bool {
+	value, ok := data["conversation_id"]
+	if !ok || s.chatRepo == nil {
+		return false
+	}
+	conversationID, err := uuid.Parse(fmt.Sprint(value))
+	if err != nil {
+		return false
+	}
+
+	muted, err := s.chatRepo.IsConversationMuted(context.Background(), userID, conversationID)
+	if err != nil {
+		// Better an unwanted notification than a lost one
+		s.logger.Warn("Failed to check conversation mute", "userID", userID, "conversationID", conversationID, "error", err)
+		return false
+	}
+	return muted
+}
+
+// linkFromData picks the screen a notification opens from the entity IDs in its data,
+// preferring the conversation of chat notifications that also mention a reservation
+func linkFromData(data map[string]interface{}) (deeplink.Link, bool) {
+	for _, candidate := range []struct {
+		key  string
+		link func(uuid.UUID) deeplink.Link
+	}{
+		{"conversation_id", deeplink.Conversation},
+		{"reservation_id", deeplink.Reservation},
+	} {
+		value, ok := data[candidate.key]
+		if !ok {
+			continue
+		}
+		if id, err := uuid.Parse(fmt.Sprint(value)); err == nil {
+			return candidate.link(id), true
+		}
+	}
+	return deeplink.Link{}, false
+}
+
+// ========================================
+// INBOX
+// ========================================
+
+// GetUserNotifications lists a user's notifications
+func (s *NotificationService) GetUserNotifications(userID uuid.UUID, unreadOnly bool, offset, limit int) ([]*models.Notification, int64, error) {
+	notifications, total, err := s.notificationRepo.GetUserNotifications(userID, unreadOnly, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	return notifications, total, nil
+}
+
+// MarkAsRead marks one of the user's notifications as read
+func (s *NotificationService) MarkAsRead(notificationID, userID uuid.UUID) error {
+	notification, err := s.notificationRepo.GetByID(notificationID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification: %w", err)
+	}
+
+	if notification.UserID != userID {
+		return dto.ErrAccessDenied
+	}
+
+	if err := s.notificationRepo.MarkAsRead(notificationID, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	return nil
+}
+
+// CountUnread counts a user's unread notifications
+func (s *NotificationService) CountUnread(userID uuid.UUID) (int64, error) {
+	count, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	return count, nil
+}
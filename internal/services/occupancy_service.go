@@ -0,0 +1,347 @@
+// internal/services/occupancy_service.go
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// Prefix that makes sensor keys recognizable in logs and device config
+	sensorKeyPrefix = "os_"
+	// Minimum interval between last-seen updates for a sensor
+	sensorSeenInterval = time.Minute
+	// Sensors that have not reported for this long are not trusted to release rooms
+	occupancyStaleAfter = 5 * time.Minute
+	// Allowed clock drift of a sensor reporting a sample time
+	occupancyClockSkew = time.Minute
+	// Oldest sample a sensor may send, e.g. when flushing a buffer after an outage
+	occupancyMaxSampleAge = 24 * time.Hour
+	// Widest history window and most samples returned per request
+	occupancyMaxHistory = 7 * 24 * time.Hour
+	occupancyMaxSamples = 5000
+)
+
+// OccupancyService ingests headcounts from room sensors and releases meetings in rooms left empty
+type OccupancyService struct {
+	occupancyRepo       interfaces.OccupancyRepositoryInterface
+	spaceRepo           interfaces.SpaceRepositoryInterface
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	reservationService  *ReservationService
+	notificationService *NotificationService
+	releaseAfter        time.Duration // 0 disables auto-release
+	retentionDays       int           // 0 keeps samples forever
+	logger              *slog.Logger
+}
+
+// NewOccupancyService creates a new occupancy service
+func NewOccupancyService(
+	occupancyRepo interfaces.OccupancyRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	reservationService *ReservationService,
+	notificationService *NotificationService,
+	releaseAfter time.Duration,
+	retentionDays int,
+	logger *slog.Logger,
+) *OccupancyService {
+	return &OccupancyService{
+		occupancyRepo:       occupancyRepo,
+		spaceRepo:           spaceRepo,
+		reservationRepo:     reservationRepo,
+		reservationService:  reservationService,
+		notificationService: notificationService,
+		releaseAfter:        releaseAfter,
+		retentionDays:       retentionDays,
+		logger:              logger,
+	}
+}
+
+// ========================================
+// SENSOR REGISTRATION
+// ========================================
+
+// CreateSensor registers a sensor for a space and returns its API key, which is not stored
+func (s *OccupancyService) CreateSensor(spaceID uuid.UUID, req *dto.CreateOccupancySensorRequest, userID uuid.UUID) (*dto.OccupancySensorKeyResponse, error) {
+	if _, err := s.spaceRepo.GetByID(spaceID); err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	apiKey, err := generateSensorKey()
+	if err != nil {
+		return nil, err
+	}
+
+	sensor, err := s.occupancyRepo.CreateSensor(&models.OccupancySensor{
+		SpaceID:     spaceID,
+		Name:        req.Name,
+		APIKeyHash:  hashSensorKey(apiKey),
+		KeyPrefix:   apiKey[:len(sensorKeyPrefix)+8],
+		CreatedByID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sensor: %w", err)
+	}
+
+	return &dto.OccupancySensorKeyResponse{
+		OccupancySensorResponse: toOccupancySensorResponse(sensor),
+		APIKey:                  apiKey,
+	}, nil
+}
+
+// GetSpaceSensors lists the sensors registered for a space
+func (s *OccupancyService) GetSpaceSensors(spaceID uuid.UUID) ([]dto.OccupancySensorResponse, error) {
+	if _, err := s.spaceRepo.GetByID(spaceID); err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	sensors, err := s.occupancyRepo.GetSensorsBySpace(spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sensors: %w", err)
+	}
+
+	responses := make([]dto.OccupancySensorResponse, len(sensors))
+	for i, sensor := range sensors {
+		responses[i] = toOccupancySensorResponse(sensor)
+	}
+	return responses, nil
+}
+
+// RevokeSensor disables a sensor key
+func (s *OccupancyService) RevokeSensor(sensorID uuid.UUID) error {
+	sensor, err := s.occupancyRepo.GetSensorByID(sensorID)
+	if err != nil {
+		return fmt.Errorf("failed to get sensor: %w", err)
+	}
+
+	if sensor.IsRevoked() {
+		return errors.New("sensor is already revoked")
+	}
+
+	if err := s.occupancyRepo.UpdateSensor(sensor.ID, map[string]interface{}{"revoked_at": time.Now()}); err != nil {
+		return fmt.Errorf("failed to revoke sensor: %w", err)
+	}
+	return nil
+}
+
+// Authenticate resolves an API key to an active sensor
+func (s *OccupancyService) Authenticate(apiKey string) (*models.OccupancySensor, error) {
+	if apiKey == "" {
+		return nil, errors.New("invalid sensor key")
+	}
+
+	sensor, err := s.occupancyRepo.GetSensorByKeyHash(hashSensorKey(apiKey))
+	if err != nil || sensor.IsRevoked() {
+		return nil, errors.New("invalid sensor key")
+	}
+
+	now := time.Now()
+	if sensor.LastSeenAt == nil || now.Sub(*sensor.LastSeenAt) > sensorSeenInterval {
+		if err := s.occupancyRepo.UpdateSensor(sensor.ID, map[string]interface{}{"last_seen_at": now}); err != nil {
+			s.logger.Warn("Failed to record sensor activity", "sensorID", sensor.ID, "error", err)
+		}
+		sensor.LastSeenAt = &now
+	}
+
+	return sensor, nil
+}
+
+// ========================================
+// INGESTION AND LIVE OCCUPANCY
+// ========================================
+
+// RecordSample stores a headcount from a sensor and updates the live occupancy of its space
+func (s *OccupancyService) RecordSample(sensor *models.OccupancySensor, req *dto.OccupancySampleRequest) error {
+	now := time.Now()
+	recordedAt := now
+	if req.RecordedAt != nil {
+		recordedAt = *req.RecordedAt
+		if recordedAt.After(now.Add(occupancyClockSkew)) {
+			return errors.New("recorded_at cannot be in the future")
+		}
+		if recordedAt.Before(now.Add(-occupancyMaxSampleAge)) {
+			return errors.New("recorded_at cannot be more than 24 hours ago")
+		}
+	}
+
+	if err := s.occupancyRepo.CreateSample(&models.OccupancySample{
+		SpaceID:    sensor.SpaceID,
+		SensorID:   sensor.ID,
+		Headcount:  *req.Headcount,
+		RecordedAt: recordedAt,
+	}); err != nil {
+		return fmt.Errorf("failed to store sample: %w", err)
+	}
+
+	if err := s.occupancyRepo.RecordSpaceOccupancy(sensor.SpaceID, *req.Headcount, recordedAt); err != nil {
+		return fmt.Errorf("failed to update live occupancy: %w", err)
+	}
+	return nil
+}
+
+// GetSpaceOccupancy returns the live headcount of a space and its samples in a time range
+func (s *OccupancyService) GetSpaceOccupancy(spaceID uuid.UUID, from, to time.Time) (*dto.SpaceOccupancyResponse, error) {
+	if !to.After(from) {
+		return nil, errors.New("to must be after from")
+	}
+	if to.Sub(from) > occupancyMaxHistory {
+		return nil, errors.New("occupancy history cannot exceed 7 days")
+	}
+
+	space, err := s.spaceRepo.GetByID(spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	samples, err := s.occupancyRepo.GetSamples(spaceID, from, to, occupancyMaxSamples)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get samples: %w", err)
+	}
+
+	response := &dto.SpaceOccupancyResponse{
+		SpaceID:            space.ID,
+		Capacity:           space.Capacity,
+		CurrentOccupancy:   space.CurrentOccupancy,
+		OccupancyUpdatedAt: space.OccupancyUpdatedAt,
+		EmptySince:         space.EmptySince,
+		Samples:            make([]dto.OccupancyPoint, len(samples)),
+	}
+	for i, sample := range samples {
+		response.Samples[i] = dto.OccupancyPoint{Headcount: sample.Headcount, RecordedAt: sample.RecordedAt}
+	}
+	return response, nil
+}
+
+// ========================================
+// SCHEDULED JOBS
+// ========================================
+
+// ReleaseEmptyRooms ends meetings in progress whose room sensors have counted nobody for
+// the release threshold and tells the organizer. It runs as a scheduled job.
+func (s *OccupancyService) ReleaseEmptyRooms(ctx context.Context) error {
+	if s.releaseAfter <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	spaces, err := s.occupancyRepo.GetSpacesEmptySince(now.Add(-s.releaseAfter), now.Add(-occupancyStaleAfter))
+	if err != nil {
+		return fmt.Errorf("failed to get empty spaces: %w", err)
+	}
+
+	released := 0
+	for _, space := range spaces {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		reservations, err := s.reservationRepo.GetConflictingReservations(space.ID, now, now.Add(time.Second))
+		if err != nil {
+			s.logger.Error("Failed to get reservations of empty space", "spaceID", space.ID, "error", err)
+			continue
+		}
+
+		for _, reservation := range reservations {
+			// Only the space's own meetings: a booking of a combined room or of an add-on
+			// continues elsewhere, where this sensor cannot see
+			if reservation.SpaceID != space.ID {
+				continue
+			}
+			// Rooms evacuated during an emergency are empty on purpose
+			if !reservation.IsActive() || reservation.SuspendedByEmergencyID != nil {
+				continue
+			}
+			// The grace period starts with the meeting, not when the room emptied before it
+			emptySince := *space.EmptySince
+			if reservation.StartTime.After(emptySince) {
+				emptySince = reservation.StartTime
+			}
+			if now.Sub(emptySince) < s.releaseAfter {
+				continue
+			}
+
+			if _, err := s.reservationService.EndReservationEarly(reservation.ID); err != nil {
+				s.logger.Error("Failed to release empty room", "reservationID", reservation.ID, "error", err)
+				continue
+			}
+			released++
+			s.notifyReleased(reservation, space)
+		}
+	}
+
+	if released > 0 {
+		s.logger.Info("Empty rooms released", "count", released)
+	}
+	return nil
+}
+
+// PruneSamples deletes samples older than the retention period. It runs as a scheduled job.
+func (s *OccupancyService) PruneSamples(ctx context.Context) error {
+	if s.retentionDays <= 0 {
+		return nil
+	}
+
+	deleted, err := s.occupancyRepo.DeleteSamplesBefore(time.Now().AddDate(0, 0, -s.retentionDays))
+	if err != nil {
+		return fmt.Errorf("failed to delete old occupancy samples: %w", err)
+	}
+
+	s.logger.Info("Old occupancy samples deleted", "count", deleted)
+	return nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// notifyReleased tells the organizers their meeting was ended because nobody was in the room
+func (s *OccupancyService) notifyReleased(reservation *models.Reservation, space *models.Space) {
+	err := s.notificationService.NotifyOrganizers(reservation, models.NotificationTypeReservationReleased,
+		"Room released",
+		fmt.Sprintf("Nobody was detected in %s for %d minutes, so your reservation \"%s\" was ended and the room released.",
+			space.Name, int(s.releaseAfter.Minutes()), reservation.Title),
+		map[string]interface{}{"reservation_id": reservation.ID, "space_id": space.ID})
+	if err != nil {
+		s.logger.Warn("Failed to notify organizers of released room", "reservationID", reservation.ID, "error", err)
+	}
+}
+
+// toOccupancySensorResponse converts a sensor to its response
+func toOccupancySensorResponse(sensor *models.OccupancySensor) dto.OccupancySensorResponse {
+	return dto.OccupancySensorResponse{
+		ID:         sensor.ID,
+		SpaceID:    sensor.SpaceID,
+		Name:       sensor.Name,
+		KeyPrefix:  sensor.KeyPrefix,
+		LastSeenAt: sensor.LastSeenAt,
+		RevokedAt:  sensor.RevokedAt,
+		CreatedAt:  sensor.CreatedAt,
+	}
+}
+
+// generateSensorKey creates a random API key for a sensor
+func generateSensorKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate sensor key: %w", err)
+	}
+	return sensorKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+// hashSensorKey hashes an API key for storage and lookup
+func hashSensorKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,35 @@
+// internal/services/optimistic_lock.go
+package services
+
+import (
+	"errors"
+	"time"
+
+	"room-reservation-api/internal/dto"
+
+	"gorm.io/gorm"
+)
+
+// Updates can carry the updated_at the client last read. The record is checked against it
+// before anything changes, and the write itself is conditional on it, so of two editors
+// working from the same copy the second gets dto.ErrStaleUpdate instead of overwriting.
+
+// checkUnmodified fails when the record changed after the client read it. The database keeps
+// microseconds, so finer digits sent by the client are ignored.
+func checkUnmodified(updatedAt time.Time, expected *time.Time) error {
+	if expected == nil {
+		return nil
+	}
+	if !updatedAt.Truncate(time.Microsecond).Equal(expected.Truncate(time.Microsecond)) {
+		return dto.ErrStaleUpdate
+	}
+	return nil
+}
+
+// staleUpdateError reports a conditional write that matched no row as a stale update
+func staleUpdateError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.ErrStaleUpdate
+	}
+	return err
+}
@@ -0,0 +1,237 @@
+// internal/services/outbox_dispatcher.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+	"room-reservation-api/internal/websocket"
+)
+
+const (
+	// Events dispatched per run
+	outboxBatchSize = 100
+	// Dispatch attempts before an event is marked failed
+	outboxMaxAttempts = 8
+	// Delay before the first retry, doubled on every further attempt
+	outboxRetryDelay = 5 * time.Second
+	// How long a claimed event is hidden from other instances while it is dispatched
+	outboxLease = time.Minute
+	// How long dispatched events are kept for troubleshooting
+	outboxRetention = 7 * 24 * time.Hour
+)
+
+// OutboxHandler delivers an outbox event to one consumer. An event whose delivery failed or
+// was interrupted is dispatched again to every handler, so handlers must tolerate duplicates.
+type OutboxHandler func(ctx context.Context, event *models.OutboxEvent) error
+
+// OutboxDispatcher delivers the events repositories write to the outbox along with their
+// changes: to WebSocket clients itself, and to webhooks and email through registered handlers.
+// An event is only marked dispatched once every handler succeeded, so a crash between a commit
+// and its delivery delays the event instead of losing it.
+type OutboxDispatcher struct {
+	outboxRepo interfaces.OutboxRepositoryInterface
+	wsManager  *websocket.Manager // Optional, nil disables realtime events
+	handlers   map[string][]OutboxHandler
+	logger     *slog.Logger
+
+	mu    sync.Mutex  // One dispatch at a time per instance; instances share work through claims
+	woken atomic.Bool // A dispatch triggered by Wake is waiting to run
+}
+
+// NewOutboxDispatcher creates a new outbox dispatcher that pushes reservation events to the
+// realtime streams of their organizers
+func NewOutboxDispatcher(outboxRepo interfaces.OutboxRepositoryInterface, wsManager *websocket.Manager, logger *slog.Logger) *OutboxDispatcher {
+	dispatcher := &OutboxDispatcher{
+		outboxRepo: outboxRepo,
+		wsManager:  wsManager,
+		handlers:   make(map[string][]OutboxHandler),
+		logger:     logger,
+	}
+	for _, eventType := range models.OutboxReservationEvents {
+		dispatcher.Handle(eventType, dispatcher.broadcastReservation)
+	}
+	return dispatcher
+}
+
+// Handle adds a consumer of an event type. Handlers are registered at startup, before dispatching begins.
+func (d *OutboxDispatcher) Handle(eventType string, handler OutboxHandler) {
+	d.handlers[eventType] = append(d.handlers[eventType], handler)
+}
+
+// Wake dispatches pending events right away instead of waiting for the next scheduled run.
+// Services call it after committing a change; it never blocks them.
+func (d *OutboxDispatcher) Wake() {
+	if d == nil || d.woken.Swap(true) {
+		return
+	}
+
+	go func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.woken.Store(false)
+
+		if err := d.dispatchDue(context.Background()); err != nil {
+			d.logger.Error("Failed to dispatch outbox events", "error", err)
+		}
+	}()
+}
+
+// Dispatch delivers the events that are due. It runs as a scheduled job, which picks up events
+// whose Wake was lost in a crash and retries failed ones.
+func (d *OutboxDispatcher) Dispatch(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dispatchDue(ctx)
+}
+
+// PruneDispatched removes dispatched events past the retention period. It runs as a scheduled job.
+func (d *OutboxDispatcher) PruneDispatched(ctx context.Context) error {
+	deleted, err := d.outboxRepo.DeleteDispatchedBefore(time.Now().Add(-outboxRetention))
+	if err != nil {
+		return fmt.Errorf("failed to prune outbox events: %w", err)
+	}
+	if deleted > 0 {
+		d.logger.Info("Pruned dispatched outbox events", "count", deleted)
+	}
+	return nil
+}
+
+// Backlog reports the events not dispatched yet
+func (d *OutboxDispatcher) Backlog() (*dto.OutboxBacklog, error) {
+	pending, oldest, err := d.outboxRepo.GetPendingStats()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending outbox events: %w", err)
+	}
+
+	backlog := &dto.OutboxBacklog{
+		PendingEvents:   pending,
+		OldestPendingAt: oldest,
+	}
+	if oldest != nil {
+		backlog.OldestPendingAge = time.Since(*oldest).Round(time.Second).String()
+	}
+	return backlog, nil
+}
+
+// ========================================
+// DISPATCH
+// ========================================
+
+// dispatchDue claims batches of due events until none is left, in the order they were written
+func (d *OutboxDispatcher) dispatchDue(ctx context.Context) error {
+	for {
+		events, err := d.outboxRepo.ClaimDue(time.Now(), outboxLease, outboxBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to claim outbox events: %w", err)
+		}
+
+		for _, event := range events {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			d.dispatch(ctx, event)
+		}
+
+		if len(events) < outboxBatchSize {
+			return nil
+		}
+	}
+}
+
+// dispatch runs every handler of an event and records the outcome
+func (d *OutboxDispatcher) dispatch(ctx context.Context, event *models.OutboxEvent) {
+	var dispatchErr error
+	for _, handler := range d.handlers[event.EventType] {
+		if err := handler(ctx, event); err != nil {
+			dispatchErr = err
+			break
+		}
+	}
+
+	now := time.Now()
+	if dispatchErr == nil {
+		if err := d.outboxRepo.Update(event.ID, map[string]interface{}{
+			"status":        models.OutboxEventDispatched,
+			"dispatched_at": now,
+			"last_error":    "",
+		}); err != nil {
+			d.logger.Error("Failed to mark outbox event dispatched", "eventID", event.ID, "error", err)
+		}
+		return
+	}
+
+	attempts := event.Attempts + 1
+	status := models.OutboxEventPending
+	if attempts >= outboxMaxAttempts {
+		status = models.OutboxEventFailed
+		d.logger.Error("Outbox event gave up after repeated failures",
+			"eventID", event.ID, "event", event.EventType, "aggregateID", event.AggregateID, "error", dispatchErr)
+	} else {
+		d.logger.Warn("Outbox event dispatch failed",
+			"eventID", event.ID, "event", event.EventType, "attempt", attempts, "error", dispatchErr)
+	}
+
+	if err := d.outboxRepo.Update(event.ID, map[string]interface{}{
+		"status":          status,
+		"attempts":        attempts,
+		"last_error":      dispatchErr.Error(),
+		"next_attempt_at": now.Add(outboxRetryDelay << (attempts - 1)),
+	}); err != nil {
+		d.logger.Error("Failed to reschedule outbox event", "eventID", event.ID, "error", err)
+	}
+}
+
+// broadcastReservation pushes a reservation event to the realtime streams of the owner and co-organizers
+func (d *OutboxDispatcher) broadcastReservation(ctx context.Context, event *models.OutboxEvent) error {
+	if d.wsManager == nil {
+		return nil
+	}
+
+	change, err := decodeReservationChange(event)
+	if err != nil {
+		return err
+	}
+
+	wsEvent := websocket.NewReservationEvent(event.EventType, reservationEventData(change))
+	wsEvent.Timestamp = event.CreatedAt
+	for _, organizerID := range change.OrganizerIDs {
+		d.wsManager.BroadcastToUser(organizerID, wsEvent)
+	}
+	return nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// decodeReservationChange reads the payload of a reservation event
+func decodeReservationChange(event *models.OutboxEvent) (*models.ReservationChange, error) {
+	var change models.ReservationChange
+	if err := json.Unmarshal(event.Payload, &change); err != nil {
+		return nil, fmt.Errorf("invalid reservation event payload: %w", err)
+	}
+	return &change, nil
+}
+
+// reservationEventData converts a reservation change to its realtime event data
+func reservationEventData(change *models.ReservationChange) websocket.ReservationEventData {
+	return websocket.ReservationEventData{
+		ReservationID: change.ReservationID,
+		SpaceID:       change.SpaceID,
+		UserID:        change.UserID,
+		Title:         change.Title,
+		Status:        change.Status,
+		StartTime:     change.StartTime,
+		EndTime:       change.EndTime,
+		CheckInTime:   change.CheckInTime,
+	}
+}
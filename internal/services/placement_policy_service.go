@@ -0,0 +1,118 @@
+// internal/services/placement_policy_service.go
+package services
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// PlacementPolicyService manages the per-building strategies that place bookings made for "any suitable room"
+type PlacementPolicyService struct {
+	policyRepo      interfaces.PlacementPolicyRepositoryInterface
+	spaceRepo       interfaces.SpaceRepositoryInterface
+	defaultStrategy models.PlacementStrategy
+}
+
+// NewPlacementPolicyService creates a new placement policy service
+func NewPlacementPolicyService(
+	policyRepo interfaces.PlacementPolicyRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	defaultStrategy string,
+) *PlacementPolicyService {
+	return &PlacementPolicyService{
+		policyRepo:      policyRepo,
+		spaceRepo:       spaceRepo,
+		defaultStrategy: normalizePlacementStrategy(defaultStrategy),
+	}
+}
+
+// GetPolicies lists the strategy applied in every building with spaces
+func (s *PlacementPolicyService) GetPolicies() (*dto.PlacementPoliciesResponse, error) {
+	buildings, err := s.spaceRepo.GetDistinctBuildings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get buildings: %w", err)
+	}
+	policies, err := s.policyRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get placement policies: %w", err)
+	}
+
+	byBuilding := make(map[string]*models.PlacementPolicy, len(policies))
+	for _, policy := range policies {
+		byBuilding[policy.Building] = policy
+	}
+
+	response := &dto.PlacementPoliciesResponse{
+		DefaultStrategy: string(s.defaultStrategy),
+		Buildings:       []dto.BuildingPlacementPolicy{},
+	}
+	for _, building := range buildings {
+		policy, ok := byBuilding[building]
+		if !ok {
+			response.Buildings = append(response.Buildings, dto.BuildingPlacementPolicy{
+				Building:  building,
+				Strategy:  string(s.defaultStrategy),
+				IsDefault: true,
+			})
+			continue
+		}
+		response.Buildings = append(response.Buildings, toBuildingPlacementPolicy(policy))
+	}
+
+	return response, nil
+}
+
+// SetPolicy sets the strategy of a building
+func (s *PlacementPolicyService) SetPolicy(building string, req *dto.SetPlacementPolicyRequest, userID uuid.UUID) (*dto.BuildingPlacementPolicy, error) {
+	strategy := models.PlacementStrategy(req.Strategy)
+	if !strategy.IsValid() {
+		return nil, fmt.Errorf("unknown placement strategy %q", req.Strategy)
+	}
+
+	buildings, err := s.spaceRepo.GetDistinctBuildings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get buildings: %w", err)
+	}
+	known := false
+	for _, existing := range buildings {
+		if existing == building {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("building %s has no spaces", building)
+	}
+
+	policy, err := s.policyRepo.Save(&models.PlacementPolicy{
+		Building:    building,
+		Strategy:    strategy,
+		UpdatedByID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save placement policy: %w", err)
+	}
+
+	response := toBuildingPlacementPolicy(policy)
+	return &response, nil
+}
+
+// DeletePolicy makes the building use the default strategy again
+func (s *PlacementPolicyService) DeletePolicy(building string) error {
+	return s.policyRepo.Delete(building)
+}
+
+// toBuildingPlacementPolicy converts a stored policy to its response
+func toBuildingPlacementPolicy(policy *models.PlacementPolicy) dto.BuildingPlacementPolicy {
+	return dto.BuildingPlacementPolicy{
+		Building:    policy.Building,
+		Strategy:    string(policy.Strategy),
+		UpdatedByID: &policy.UpdatedByID,
+		UpdatedAt:   &policy.UpdatedAt,
+	}
+}
@@ -0,0 +1,315 @@
+// internal/services/public_availability_service.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Most spaces listed in one public availability view
+	maxPublicAvailabilitySpaces = 200
+	// How many days ahead the public view can look
+	maxPublicAvailabilityDays = 14
+	// Most views kept in the cache; expired ones are dropped first
+	maxPublicAvailabilityEntries = 1000
+)
+
+// availabilityScope selects the spaces of a view: one space, or the spaces matching a building and type
+type availabilityScope struct {
+	spaceID   *uuid.UUID
+	building  string
+	spaceType string
+}
+
+// key identifies the scope in the cache
+func (sc availabilityScope) key() string {
+	spaceID := ""
+	if sc.spaceID != nil {
+		spaceID = sc.spaceID.String()
+	}
+	return strings.Join([]string{spaceID, sc.building, sc.spaceType}, "|")
+}
+
+// publicAvailabilityEntry is a cached view and when it stops being served
+type publicAvailabilityEntry struct {
+	view      *dto.PublicAvailabilityResponse
+	expiresAt time.Time
+}
+
+// PublicAvailabilityService answers the anonymous free/busy view shown on lobby screens and
+// public booking portals. Only spaces open to everyone are listed, pilot spaces never, and
+// only when they are busy: not who booked them or what for. Lobby screens poll, so views
+// are cached for a while and read from the replica.
+type PublicAvailabilityService struct {
+	spaceRepo       interfaces.SpaceRepositoryInterface
+	reservationRepo interfaces.ReservationRepositoryInterface
+	cacheTTL        time.Duration // 0 disables the cache
+
+	mu    sync.Mutex
+	cache map[string]publicAvailabilityEntry
+}
+
+// NewPublicAvailabilityService creates a new public availability service
+func NewPublicAvailabilityService(spaceRepo interfaces.SpaceRepositoryInterface, reservationRepo interfaces.ReservationRepositoryInterface, cacheTTL time.Duration) *PublicAvailabilityService {
+	return &PublicAvailabilityService{
+		spaceRepo:       spaceRepo,
+		reservationRepo: reservationRepo,
+		cacheTTL:        cacheTTL,
+		cache:           make(map[string]publicAvailabilityEntry),
+	}
+}
+
+// CacheTTL is how long a view is served before it is computed again
+func (s *PublicAvailabilityService) CacheTTL() time.Duration {
+	return s.cacheTTL
+}
+
+// GetAvailability returns the free/busy view of a day, from the cache when it is fresh
+func (s *PublicAvailabilityService) GetAvailability(req *dto.PublicAvailabilityRequest) (*dto.PublicAvailabilityResponse, error) {
+	return s.availability(req, availabilityScope{building: req.Building, spaceType: req.Type})
+}
+
+// GetWidgetAvailability returns the free/busy view of the spaces a booking widget token covers;
+// building tokens can narrow it down by type
+func (s *PublicAvailabilityService) GetWidgetAvailability(token *models.WidgetToken, req *dto.PublicAvailabilityRequest) (*dto.PublicAvailabilityResponse, error) {
+	scope := availabilityScope{spaceID: token.SpaceID}
+	if token.SpaceID == nil {
+		scope.building = token.Building
+		scope.spaceType = req.Type
+	}
+	return s.availability(req, scope)
+}
+
+// availability resolves the day of the request and serves the view of the scope
+func (s *PublicAvailabilityService) availability(req *dto.PublicAvailabilityRequest, scope availabilityScope) (*dto.PublicAvailabilityResponse, error) {
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s", timezone)
+	}
+
+	now := time.Now().In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	day := today
+	if req.Date != "" {
+		day, err = time.ParseInLocation("2006-01-02", req.Date, location)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date: %s", req.Date)
+		}
+	}
+	if day.Before(today) || day.After(today.AddDate(0, 0, maxPublicAvailabilityDays)) {
+		return nil, fmt.Errorf("date must be between today and %d days ahead", maxPublicAvailabilityDays)
+	}
+
+	key := strings.Join([]string{day.Format("2006-01-02"), timezone, scope.key()}, "|")
+	if view := s.cached(key, now); view != nil {
+		return view, nil
+	}
+
+	view, err := s.buildView(day, location, scope)
+	if err != nil {
+		return nil, err
+	}
+	s.store(key, view)
+	return view, nil
+}
+
+// buildView lists the busy windows of every public space of the scope on the day
+func (s *PublicAvailabilityService) buildView(day time.Time, location *time.Location, scope availabilityScope) (*dto.PublicAvailabilityResponse, error) {
+	spaces, err := s.listSpaces(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	dayStart := day
+	dayEnd := day.AddDate(0, 0, 1)
+	spaceIDs := make([]uuid.UUID, len(spaces))
+	for i, space := range spaces {
+		spaceIDs[i] = space.ID
+	}
+
+	// Buffers are busy too, so windows are loaded wide enough to include them
+	var widest time.Duration
+	for _, space := range spaces {
+		widest = max(widest, time.Duration(max(space.BufferBefore, space.BufferAfter))*time.Minute)
+	}
+	windows, err := s.reservationRepo.GetBusyWindows(spaceIDs, dayStart.Add(-widest), dayEnd.Add(widest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get busy windows: %w", err)
+	}
+
+	bySpace := make(map[uuid.UUID][]dto.TimeSlot)
+	for _, window := range windows {
+		bySpace[window.SpaceID] = append(bySpace[window.SpaceID], dto.TimeSlot{StartTime: window.StartTime, EndTime: window.EndTime})
+	}
+
+	now := time.Now()
+	view := &dto.PublicAvailabilityResponse{
+		Date:        day.Format("2006-01-02"),
+		Timezone:    location.String(),
+		GeneratedAt: now.UTC(),
+		Spaces:      make([]dto.PublicSpaceAvailability, 0, len(spaces)),
+	}
+	for _, space := range spaces {
+		busy := mergeBusySlots(bySpace[space.ID], space, dayStart, dayEnd, location)
+		availability := dto.PublicSpaceAvailability{
+			SpaceID:    space.ID,
+			Name:       space.Name,
+			Type:       string(space.Type),
+			Building:   space.Building,
+			Floor:      space.Floor,
+			RoomNumber: space.RoomNumber,
+			Capacity:   space.Capacity,
+			FreeNow:    true,
+			Busy:       busy,
+		}
+		for _, slot := range busy {
+			if !now.Before(slot.StartTime) && now.Before(slot.EndTime) {
+				availability.FreeNow = false
+				break
+			}
+		}
+		view.Spaces = append(view.Spaces, availability)
+	}
+
+	return view, nil
+}
+
+// listSpaces lists the spaces of the scope open to everyone: available, and not in soft launch
+func (s *PublicAvailabilityService) listSpaces(scope availabilityScope) ([]*models.Space, error) {
+	if scope.spaceID != nil {
+		space, err := s.spaceRepo.GetByID(*scope.spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get space: %w", err)
+		}
+		if !space.IsAvailable() || space.InPilot(time.Now()) {
+			return []*models.Space{}, nil
+		}
+		return []*models.Space{space}, nil
+	}
+
+	filters := interfaces.SpaceFilters{
+		Status:      []string{string(models.SpaceStatusAvailable)},
+		PilotViewer: &interfaces.PilotViewer{}, // Anonymous, so spaces in soft launch stay hidden
+		SortBy:      "name",
+		SortOrder:   "asc",
+	}
+	if scope.building != "" {
+		filters.Buildings = []string{scope.building}
+	}
+	if scope.spaceType != "" {
+		filters.Types = []string{scope.spaceType}
+	}
+
+	spaces, _, err := s.spaceRepo.SearchSpaces(filters, 0, maxPublicAvailabilitySpaces)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list spaces: %w", err)
+	}
+	return spaces, nil
+}
+
+// mergeBusySlots widens bookings by the space's buffer times, joins the ones that touch or
+// overlap, and clips them to the day. Merging also hides how many bookings there were.
+func mergeBusySlots(slots []dto.TimeSlot, space *models.Space, dayStart, dayEnd time.Time, location *time.Location) []dto.TimeSlot {
+	before := time.Duration(space.BufferBefore) * time.Minute
+	after := time.Duration(space.BufferAfter) * time.Minute
+	for i := range slots {
+		slots[i].StartTime = slots[i].StartTime.Add(-before)
+		slots[i].EndTime = slots[i].EndTime.Add(after)
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].StartTime.Before(slots[j].StartTime)
+	})
+
+	merged := []dto.TimeSlot{}
+	for _, slot := range slots {
+		if slot.StartTime.Before(dayStart) {
+			slot.StartTime = dayStart
+		}
+		if slot.EndTime.After(dayEnd) {
+			slot.EndTime = dayEnd
+		}
+		if !slot.StartTime.Before(slot.EndTime) {
+			continue
+		}
+
+		last := len(merged) - 1
+		if last >= 0 && !slot.StartTime.After(merged[last].EndTime) {
+			if slot.EndTime.After(merged[last].EndTime) {
+				merged[last].EndTime = slot.EndTime
+			}
+			continue
+		}
+		merged = append(merged, slot)
+	}
+
+	for i := range merged {
+		merged[i].StartTime = merged[i].StartTime.In(location)
+		merged[i].EndTime = merged[i].EndTime.In(location)
+	}
+	return merged
+}
+
+// ========================================
+// CACHE
+// ========================================
+
+// DropCachedViews is the outbox handler of released reservations: views cached before the
+// release would show the freed time as busy until they expire
+func (s *PublicAvailabilityService) DropCachedViews(ctx context.Context, event *models.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = make(map[string]publicAvailabilityEntry)
+	return nil
+}
+
+// cached returns the view stored under the key while it is fresh
+func (s *PublicAvailabilityService) cached(key string, now time.Time) *dto.PublicAvailabilityResponse {
+	if s.cacheTTL <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.cache[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		return nil
+	}
+	return entry.view
+}
+
+// store caches a view, dropping expired views first and the whole cache when it is still full
+func (s *PublicAvailabilityService) store(key string, view *dto.PublicAvailabilityResponse) {
+	if s.cacheTTL <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if len(s.cache) >= maxPublicAvailabilityEntries {
+		for cachedKey, entry := range s.cache {
+			if !now.Before(entry.expiresAt) {
+				delete(s.cache, cachedKey)
+			}
+		}
+		if len(s.cache) >= maxPublicAvailabilityEntries {
+			s.cache = make(map[string]publicAvailabilityEntry)
+		}
+	}
+	s.cache[key] = publicAvailabilityEntry{view: view, expiresAt: now.Add(s.cacheTTL)}
+}
@@ -0,0 +1,304 @@
+// internal/services/quick_book_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// Favorite spaces a user can keep
+	maxUserFavorites = 50
+	// Booking templates a user can keep
+	maxBookingTemplates = 20
+)
+
+// QuickBookService manages the favorite spaces and booking templates users book again
+// in one call, without filling in the whole reservation each time
+type QuickBookService struct {
+	quickBookRepo      interfaces.QuickBookRepositoryInterface
+	spaceRepo          interfaces.SpaceRepositoryInterface
+	reservationService *ReservationService
+	logger             *slog.Logger
+}
+
+// NewQuickBookService creates a new favorite space and booking template service
+func NewQuickBookService(
+	quickBookRepo interfaces.QuickBookRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	reservationService *ReservationService,
+	logger *slog.Logger,
+) *QuickBookService {
+	return &QuickBookService{
+		quickBookRepo:      quickBookRepo,
+		spaceRepo:          spaceRepo,
+		reservationService: reservationService,
+		logger:             logger,
+	}
+}
+
+// ========================================
+// FAVORITE SPACES
+// ========================================
+
+// GetFavorites lists the favorite spaces of the user, most recently added first
+func (s *QuickBookService) GetFavorites(userID uuid.UUID) ([]dto.FavoriteSpaceResponse, error) {
+	favorites, err := s.quickBookRepo.GetFavorites(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get favorite spaces: %w", err)
+	}
+
+	responses := make([]dto.FavoriteSpaceResponse, len(favorites))
+	for i, favorite := range favorites {
+		responses[i] = dto.FavoriteSpaceResponse{
+			SpaceID:     favorite.SpaceID,
+			Name:        favorite.Space.Name,
+			Type:        string(favorite.Space.Type),
+			Building:    favorite.Space.Building,
+			Floor:       favorite.Space.Floor,
+			Capacity:    favorite.Space.Capacity,
+			Status:      string(favorite.Space.Status),
+			FavoritedAt: favorite.CreatedAt,
+		}
+	}
+	return responses, nil
+}
+
+// AddFavorite marks a space as a favorite of the user; marking it again changes nothing
+func (s *QuickBookService) AddFavorite(userID, spaceID uuid.UUID) error {
+	if _, err := s.spaceRepo.GetByID(spaceID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to get space: %w", err)
+	}
+
+	favorites, err := s.quickBookRepo.GetFavorites(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get favorite spaces: %w", err)
+	}
+	for _, favorite := range favorites {
+		if favorite.SpaceID == spaceID {
+			return nil
+		}
+	}
+	if len(favorites) >= maxUserFavorites {
+		return fmt.Errorf("cannot have more than %d favorite spaces", maxUserFavorites)
+	}
+
+	if _, err := s.quickBookRepo.AddFavorite(&models.FavoriteSpace{UserID: userID, SpaceID: spaceID}); err != nil {
+		return fmt.Errorf("failed to add favorite space: %w", err)
+	}
+	return nil
+}
+
+// RemoveFavorite unmarks a favorite space of the user
+func (s *QuickBookService) RemoveFavorite(userID, spaceID uuid.UUID) error {
+	removed, err := s.quickBookRepo.RemoveFavorite(userID, spaceID)
+	if err != nil {
+		return fmt.Errorf("failed to remove favorite space: %w", err)
+	}
+	if !removed {
+		return errors.New("space is not a favorite")
+	}
+	return nil
+}
+
+// ========================================
+// BOOKING TEMPLATES
+// ========================================
+
+// GetTemplates lists the booking templates of the user by name
+func (s *QuickBookService) GetTemplates(userID uuid.UUID) ([]dto.BookingTemplateResponse, error) {
+	templates, err := s.quickBookRepo.GetTemplates(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking templates: %w", err)
+	}
+
+	responses := make([]dto.BookingTemplateResponse, len(templates))
+	for i, template := range templates {
+		responses[i] = toBookingTemplateResponse(template)
+	}
+	return responses, nil
+}
+
+// CreateTemplate saves a booking template for the user
+func (s *QuickBookService) CreateTemplate(req *dto.BookingTemplateRequest, userID uuid.UUID) (*dto.BookingTemplateResponse, error) {
+	count, err := s.quickBookRepo.CountTemplates(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count booking templates: %w", err)
+	}
+	if count >= maxBookingTemplates {
+		return nil, fmt.Errorf("cannot have more than %d booking templates", maxBookingTemplates)
+	}
+
+	template := &models.BookingTemplate{UserID: userID}
+	if err := s.applyTemplateRequest(template, req); err != nil {
+		return nil, err
+	}
+
+	created, err := s.quickBookRepo.CreateTemplate(template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create booking template: %w", err)
+	}
+
+	response := toBookingTemplateResponse(created)
+	return &response, nil
+}
+
+// UpdateTemplate replaces a booking template of the user
+func (s *QuickBookService) UpdateTemplate(id uuid.UUID, req *dto.BookingTemplateRequest, userID uuid.UUID) (*dto.BookingTemplateResponse, error) {
+	template, err := s.ownTemplate(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyTemplateRequest(template, req); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.quickBookRepo.UpdateTemplate(id, map[string]interface{}{
+		"name":              template.Name,
+		"title":             template.Title,
+		"description":       template.Description,
+		"duration_minutes":  template.DurationMinutes,
+		"participant_count": template.ParticipantCount,
+		"space_id":          template.SpaceID,
+		"building":          template.Building,
+		"is_private":        template.IsPrivate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update booking template: %w", err)
+	}
+
+	response := toBookingTemplateResponse(updated)
+	return &response, nil
+}
+
+// DeleteTemplate removes a booking template of the user
+func (s *QuickBookService) DeleteTemplate(id, userID uuid.UUID) error {
+	if _, err := s.ownTemplate(id, userID); err != nil {
+		return err
+	}
+
+	if err := s.quickBookRepo.DeleteTemplate(id); err != nil {
+		return fmt.Errorf("failed to delete booking template: %w", err)
+	}
+	return nil
+}
+
+// BookFromTemplate books the template at the start time, like a reservation filled in by hand
+func (s *QuickBookService) BookFromTemplate(id uuid.UUID, req *dto.BookFromTemplateRequest, userID uuid.UUID) (*models.Reservation, error) {
+	template, err := s.ownTemplate(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	isPrivate := template.IsPrivate
+	createReq := &dto.CreateReservationRequest{
+		Building:         template.Building,
+		StartTime:        req.StartTime,
+		EndTime:          req.StartTime.Add(time.Duration(template.DurationMinutes) * time.Minute),
+		ParticipantCount: template.ParticipantCount,
+		Title:            template.Title,
+		Description:      template.Description,
+		IsPrivate:        &isPrivate,
+	}
+	if template.SpaceID != nil {
+		createReq.SpaceID = *template.SpaceID
+	}
+	if req.SpaceID != nil {
+		createReq.SpaceID = *req.SpaceID
+	}
+	if req.Title != "" {
+		createReq.Title = req.Title
+	}
+	if req.ParticipantCount > 0 {
+		createReq.ParticipantCount = req.ParticipantCount
+	}
+
+	reservation, err := s.reservationService.CreateReservation(createReq, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Reservation booked from template", "templateID", template.ID, "reservationID", reservation.ID, "userID", userID)
+	return reservation, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// ownTemplate retrieves a booking template, hiding the templates of other users
+func (s *QuickBookService) ownTemplate(id, userID uuid.UUID) (*models.BookingTemplate, error) {
+	template, err := s.quickBookRepo.GetTemplateByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get booking template: %w", err)
+	}
+	if template.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return template, nil
+}
+
+// applyTemplateRequest copies a template request onto a template, checking its preferred space
+func (s *QuickBookService) applyTemplateRequest(template *models.BookingTemplate, req *dto.BookingTemplateRequest) error {
+	template.Building = req.Building
+	template.Space = nil
+	if req.SpaceID != nil {
+		space, err := s.spaceRepo.GetByID(*req.SpaceID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			return fmt.Errorf("failed to get space: %w", err)
+		}
+		template.Building = space.Building
+	}
+
+	template.Name = req.Name
+	template.Title = req.Title
+	template.Description = req.Description
+	template.DurationMinutes = req.DurationMinutes
+	template.ParticipantCount = req.ParticipantCount
+	if template.ParticipantCount == 0 {
+		template.ParticipantCount = 1
+	}
+	template.SpaceID = req.SpaceID
+	template.IsPrivate = req.IsPrivate
+	return nil
+}
+
+// toBookingTemplateResponse converts a booking template for the user
+func toBookingTemplateResponse(template *models.BookingTemplate) dto.BookingTemplateResponse {
+	response := dto.BookingTemplateResponse{
+		ID:               template.ID,
+		Name:             template.Name,
+		Title:            template.Title,
+		Description:      template.Description,
+		DurationMinutes:  template.DurationMinutes,
+		ParticipantCount: template.ParticipantCount,
+		SpaceID:          template.SpaceID,
+		Building:         template.Building,
+		IsPrivate:        template.IsPrivate,
+		CreatedAt:        template.CreatedAt,
+		UpdatedAt:        template.UpdatedAt,
+	}
+	if template.Space != nil {
+		response.SpaceName = template.Space.Name
+	}
+	return response
+}
@@ -0,0 +1,73 @@
+// internal/services/rebooking.go
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// Spaces suggested to an organizer whose booking was displaced
+const maxRebookingSuggestions = 3
+
+// suggestRebooking lists spaces an organizer could move a displaced reservation to: free for its
+// slot, of the type of its space, large enough and accepted by keep. Spaces of the same building
+// come first, then those on the same floor, then the smallest. The reservation's user and space
+// must be loaded.
+func suggestRebooking(spaceRepo interfaces.SpaceRepositoryInterface, displaced *models.Reservation, keep func(*models.Space) bool) ([]models.RebookingSuggestion, error) {
+	spaces, _, err := spaceRepo.GetAvailableSpaces(displaced.StartTime, displaced.EndTime, pilotViewerOf(&displaced.User), 0, suitableSpaceCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available spaces: %w", err)
+	}
+
+	var candidates []*models.Space
+	for _, candidate := range spaces {
+		if candidate.ID == displaced.SpaceID || candidate.Type != displaced.Space.Type || !keep(candidate) {
+			continue
+		}
+		// VIP and approval-only spaces are only booked on purpose
+		if !candidate.IsAvailable() || candidate.IsVIP || candidate.RequiresApproval || candidate.Capacity < displaced.ParticipantCount {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	building, floor := displaced.Space.Building, displaced.Space.Floor
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if (a.Building == building) != (b.Building == building) {
+			return a.Building == building
+		}
+		return preferredSpaceLess(a, b, &floor)
+	})
+	if len(candidates) > maxRebookingSuggestions {
+		candidates = candidates[:maxRebookingSuggestions]
+	}
+
+	suggestions := make([]models.RebookingSuggestion, len(candidates))
+	for i, candidate := range candidates {
+		suggestions[i] = models.RebookingSuggestion{
+			SpaceID:  candidate.ID,
+			Name:     candidate.Name,
+			Building: candidate.Building,
+			Floor:    candidate.Floor,
+			Capacity: candidate.Capacity,
+		}
+	}
+	return suggestions, nil
+}
+
+// rebookingSentence tells an organizer where they could rebook
+func rebookingSentence(suggestions []models.RebookingSuggestion) string {
+	if len(suggestions) == 0 {
+		return "No other suitable space is free at the same time."
+	}
+	names := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		names[i] = fmt.Sprintf("%s (%s, floor %d, %d seats)", suggestion.Name, suggestion.Building, suggestion.Floor, suggestion.Capacity)
+	}
+	return "These spaces are free at the same time: " + strings.Join(names, ", ") + "."
+}
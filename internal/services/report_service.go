@@ -0,0 +1,222 @@
+// internal/services/report_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// How long a report answered right away may run in the database
+	reportSyncTimeout = 30 * time.Second
+	// How long a background run may run in the database
+	reportAsyncTimeout = 5 * time.Minute
+	// Reservation reports spanning more than this are run in the background
+	reportSyncMaxSpan = 92 * 24 * time.Hour
+	// Hourly reports spanning more than this are run in the background
+	reportSyncMaxHourlySpan = 31 * 24 * time.Hour
+	// How long finished runs and their results are kept
+	reportRunRetention = 30 * 24 * time.Hour
+)
+
+// ReportService runs the custom reports admins define over reservations, spaces and users.
+// Light reports are answered right away; heavy ones are queued and run by a background job.
+type ReportService struct {
+	reportRepo interfaces.ReportRepositoryInterface
+	logger     *slog.Logger
+}
+
+// NewReportService creates a new custom report service
+func NewReportService(reportRepo interfaces.ReportRepositoryInterface, logger *slog.Logger) *ReportService {
+	return &ReportService{
+		reportRepo: reportRepo,
+		logger:     logger,
+	}
+}
+
+// ========================================
+// REPORTS
+// ========================================
+
+// GetCatalog lists the sources, fields, grains and operators reports can use
+func (s *ReportService) GetCatalog() dto.ReportCatalog {
+	return s.reportRepo.Catalog()
+}
+
+// CreateReport runs a report right away, or queues it when it is heavy. Exactly one of the
+// result and the run is returned.
+func (s *ReportService) CreateReport(ctx context.Context, definition *dto.ReportDefinition, userID uuid.UUID) (*dto.ReportResult, *dto.ReportRunResponse, error) {
+	if err := s.reportRepo.CheckDefinition(definition); err != nil {
+		return nil, nil, err
+	}
+
+	if !isHeavyReport(definition) {
+		result, err := s.reportRepo.Run(ctx, definition, reportSyncTimeout)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to run report: %w", err)
+		}
+		return result, nil, nil
+	}
+
+	encoded, err := json.Marshal(definition)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode report definition: %w", err)
+	}
+
+	run, err := s.reportRepo.CreateRun(&models.ReportRun{
+		Definition:    encoded,
+		Status:        models.ReportRunQueued,
+		RequestedByID: userID,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to queue report: %w", err)
+	}
+
+	s.logger.Info("Custom report queued", "runID", run.ID, "source", definition.Source, "userID", userID)
+
+	response, err := toReportRunResponse(run)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, response, nil
+}
+
+// GetRun returns a background run, with its result once completed
+func (s *ReportService) GetRun(id uuid.UUID) (*dto.ReportRunResponse, error) {
+	run, err := s.reportRepo.GetRunByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get report run: %w", err)
+	}
+	return toReportRunResponse(run)
+}
+
+// ========================================
+// BACKGROUND RUNS
+// ========================================
+
+// RunQueued runs the queued reports one after the other. It runs as a scheduled job; runs
+// left running by a stopped instance are taken over once their timeout has passed.
+func (s *ReportService) RunQueued(ctx context.Context) error {
+	for ctx.Err() == nil {
+		run, err := s.reportRepo.ClaimNextRun(time.Now().Add(-2 * reportAsyncTimeout))
+		if err != nil {
+			return fmt.Errorf("failed to claim report run: %w", err)
+		}
+		if run == nil {
+			return nil
+		}
+		s.execute(ctx, run)
+	}
+	return ctx.Err()
+}
+
+// PruneRuns removes runs past the retention period. It runs as a scheduled job.
+func (s *ReportService) PruneRuns(ctx context.Context) error {
+	deleted, err := s.reportRepo.DeleteRunsBefore(time.Now().Add(-reportRunRetention))
+	if err != nil {
+		return fmt.Errorf("failed to prune report runs: %w", err)
+	}
+	if deleted > 0 {
+		s.logger.Info("Pruned report runs", "count", deleted)
+	}
+	return nil
+}
+
+// execute runs a claimed report and stores its result or error
+func (s *ReportService) execute(ctx context.Context, run *models.ReportRun) {
+	var encoded []byte
+	result, err := s.runDefinition(ctx, run)
+	if err == nil {
+		encoded, err = json.Marshal(result)
+	}
+
+	updates := map[string]interface{}{"completed_at": time.Now()}
+	if err != nil {
+		s.logger.Warn("Custom report failed", "runID", run.ID, "error", err)
+		updates["status"] = models.ReportRunFailed
+		updates["error"] = err.Error()
+	} else {
+		s.logger.Info("Custom report completed", "runID", run.ID, "rows", result.RowCount)
+		updates["status"] = models.ReportRunCompleted
+		updates["result"] = encoded
+	}
+
+	if err := s.reportRepo.UpdateRun(run.ID, updates); err != nil {
+		s.logger.Error("Failed to store report run outcome", "runID", run.ID, "error", err)
+	}
+}
+
+// runDefinition decodes and runs the definition of a background run
+func (s *ReportService) runDefinition(ctx context.Context, run *models.ReportRun) (*dto.ReportResult, error) {
+	var definition dto.ReportDefinition
+	if err := json.Unmarshal(run.Definition, &definition); err != nil {
+		return nil, fmt.Errorf("invalid report definition: %w", err)
+	}
+	return s.reportRepo.Run(ctx, &definition, reportAsyncTimeout)
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// isHeavyReport tells whether a report should run in the background: when asked to, and when
+// it covers a long or open-ended period of reservations or many hourly periods
+func isHeavyReport(definition *dto.ReportDefinition) bool {
+	if definition.Async {
+		return true
+	}
+	if definition.Source != "reservations" && definition.DateGrain != "hour" {
+		return false
+	}
+	if definition.From == nil || definition.To == nil {
+		return true
+	}
+
+	span := definition.To.Sub(*definition.From)
+	if definition.DateGrain == "hour" && span > reportSyncMaxHourlySpan {
+		return true
+	}
+	return definition.Source == "reservations" && span > reportSyncMaxSpan
+}
+
+// toReportRunResponse converts a report run, decoding its definition and result
+func toReportRunResponse(run *models.ReportRun) (*dto.ReportRunResponse, error) {
+	response := &dto.ReportRunResponse{
+		ID:            run.ID,
+		Status:        string(run.Status),
+		Error:         run.Error,
+		RequestedByID: run.RequestedByID,
+		StartedAt:     run.StartedAt,
+		CompletedAt:   run.CompletedAt,
+		CreatedAt:     run.CreatedAt,
+	}
+
+	var definition dto.ReportDefinition
+	if err := json.Unmarshal(run.Definition, &definition); err != nil {
+		return nil, fmt.Errorf("failed to decode report definition: %w", err)
+	}
+	response.Definition = &definition
+
+	if len(run.Result) > 0 {
+		var result dto.ReportResult
+		if err := json.Unmarshal(run.Result, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode report result: %w", err)
+		}
+		response.Result = &result
+	}
+	return response, nil
+}
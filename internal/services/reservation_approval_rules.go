@@ -0,0 +1,64 @@
+// internal/services/reservation_approval_rules.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/models"
+)
+
+// approvalDecision is what a space's approval settings require of a booking
+type approvalDecision struct {
+	required     bool
+	steps        int  // Approvals needed when required
+	autoApproved bool // Approval space, but the rule let the booking through
+}
+
+// decideApproval applies the space's approval rule to a booking. Spaces that do not require
+// approval are never held; without a rule every booking of an approval space needs one approval.
+func (s *ReservationService) decideApproval(space *models.Space, duration time.Duration, participants int, userID uuid.UUID) (approvalDecision, error) {
+	if !space.RequiresApproval {
+		return approvalDecision{}, nil
+	}
+
+	rule, err := s.approvalRuleRepo.GetBySpace(space.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return approvalDecision{required: true, steps: 1}, nil
+		}
+		return approvalDecision{}, fmt.Errorf("failed to get approval rule: %w", err)
+	}
+
+	if rule.HasThresholds() {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return approvalDecision{}, fmt.Errorf("failed to get user: %w", err)
+		}
+		if rule.AutoApproves(duration, participants, user, time.Now()) {
+			return approvalDecision{autoApproved: true}, nil
+		}
+	}
+
+	steps := 1
+	if rule.TwoStep {
+		steps = 2
+	}
+	return approvalDecision{required: true, steps: steps}, nil
+}
+
+// canGiveSecondApproval checks the user is an admin other than the first approver
+func (s *ReservationService) canGiveSecondApproval(reservation *models.Reservation, userID uuid.UUID) bool {
+	if reservation.FirstApproverID != nil && *reservation.FirstApproverID == userID {
+		return false
+	}
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return false
+	}
+	return user.IsAdmin()
+}
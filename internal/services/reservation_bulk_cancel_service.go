@@ -0,0 +1,299 @@
+// internal/services/reservation_bulk_cancel_service.go
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// Most reservations one bulk cancellation may touch
+	bulkCancelMaxMatches = 5000
+	// Reservations cancelled between progress saves
+	bulkCancelBatchSize = 100
+	// Matches listed in a dry run
+	bulkCancelSampleSize = 20
+)
+
+// ReservationBulkCancelService cancels every reservation matching admin filters and keeps a report
+type ReservationBulkCancelService struct {
+	reservationService  *ReservationService
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	bulkCancelRepo      interfaces.BulkCancellationRepositoryInterface
+	notificationService *NotificationService
+	logger              *slog.Logger
+}
+
+// NewReservationBulkCancelService creates a new bulk cancellation service
+func NewReservationBulkCancelService(
+	reservationService *ReservationService,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	bulkCancelRepo interfaces.BulkCancellationRepositoryInterface,
+	notificationService *NotificationService,
+	logger *slog.Logger,
+) *ReservationBulkCancelService {
+	return &ReservationBulkCancelService{
+		reservationService:  reservationService,
+		reservationRepo:     reservationRepo,
+		bulkCancelRepo:      bulkCancelRepo,
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// BulkCancel counts the reservations matching the filters and, unless it is a dry run,
+// cancels them in batches and notifies their owners. The admin must pass back the count
+// from the dry run so nothing is cancelled that they did not see coming.
+func (s *ReservationBulkCancelService) BulkCancel(req *dto.BulkCancelRequest, adminID uuid.UUID) (*dto.BulkCancelResponse, error) {
+	filters, err := bulkCancelFilters(req)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := s.findTargets(filters, req.Status)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) > bulkCancelMaxMatches {
+		return nil, fmt.Errorf("filters match more than %d reservations, narrow them down", bulkCancelMaxMatches)
+	}
+
+	if req.DryRun {
+		response := &dto.BulkCancelResponse{DryRun: true, Matched: len(targets)}
+		for i, reservation := range targets {
+			if i == bulkCancelSampleSize {
+				break
+			}
+			response.Sample = append(response.Sample, toBulkCancelReservation(reservation))
+		}
+		return response, nil
+	}
+
+	if req.ExpectedCount == nil {
+		return nil, errors.New("expected_count is required, run a dry run first")
+	}
+	if *req.ExpectedCount != len(targets) {
+		return nil, fmt.Errorf("matching reservations changed since the dry run: expected %d, now %d", *req.ExpectedCount, len(targets))
+	}
+
+	filterJSON, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize filters: %w", err)
+	}
+	record, err := s.bulkCancelRepo.Create(&models.BulkCancellation{
+		RequestedByID: adminID,
+		Reason:        req.Reason,
+		Filters:       datatypes.JSON(filterJSON),
+		Matched:       len(targets),
+		Results:       datatypes.JSON("[]"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record bulk cancellation: %w", err)
+	}
+
+	results := make([]models.BulkCancellationResult, 0, len(targets))
+	cancelled, failed := 0, 0
+	for start := 0; start < len(targets); start += bulkCancelBatchSize {
+		end := start + bulkCancelBatchSize
+		if end > len(targets) {
+			end = len(targets)
+		}
+		for _, reservation := range targets[start:end] {
+			result := s.cancelOne(reservation, req.Reason, adminID)
+			if result.Outcome == models.BulkCancelOutcomeCancelled {
+				cancelled++
+			} else {
+				failed++
+			}
+			results = append(results, result)
+		}
+
+		// Save progress after each batch so an interrupted run still has a report
+		if err := s.saveProgress(record.ID, results, cancelled, failed, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	if err := s.saveProgress(record.ID, results, cancelled, failed, &now); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Bulk cancellation completed",
+		"bulkCancellationID", record.ID,
+		"adminID", adminID,
+		"matched", len(targets),
+		"cancelled", cancelled,
+		"failed", failed)
+
+	return &dto.BulkCancelResponse{
+		ID:        &record.ID,
+		Matched:   len(targets),
+		Cancelled: cancelled,
+		Failed:    failed,
+		ReportURL: fmt.Sprintf("/api/v1/admin/reservations/bulk-cancel/%s/report", record.ID),
+	}, nil
+}
+
+// GetReport renders the outcome of a bulk cancellation as CSV
+func (s *ReservationBulkCancelService) GetReport(id uuid.UUID) ([]byte, error) {
+	record, err := s.bulkCancelRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	results, err := record.GetResults()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bulk cancellation results: %w", err)
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"reservation_id", "title", "space", "user_email", "start_time", "end_time", "outcome", "error"})
+	for _, result := range results {
+		writer.Write([]string{
+			result.ReservationID.String(),
+			result.Title,
+			result.SpaceName,
+			result.UserEmail,
+			result.StartTime.UTC().Format(time.RFC3339),
+			result.EndTime.UTC().Format(time.RFC3339),
+			result.Outcome,
+			result.Error,
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to render report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// findTargets loads the cancellable reservations matching the filters, earliest first.
+// One more than the limit is loaded so callers can tell the filters are too broad.
+func (s *ReservationBulkCancelService) findTargets(filters map[string]interface{}, status string) ([]*models.Reservation, error) {
+	statuses := []string{string(models.StatusPending), string(models.StatusConfirmed)}
+	if status != "" {
+		statuses = []string{status}
+	}
+
+	var targets []*models.Reservation
+	for _, status := range statuses {
+		filters["status"] = status
+		reservations, _, err := s.reservationRepo.SearchReservations(filters, 0, bulkCancelMaxMatches+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get matching reservations: %w", err)
+		}
+		targets = append(targets, reservations...)
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].StartTime.Before(targets[j].StartTime)
+	})
+	return targets, nil
+}
+
+// cancelOne cancels a reservation and tells its organizers why
+func (s *ReservationBulkCancelService) cancelOne(reservation *models.Reservation, reason string, adminID uuid.UUID) models.BulkCancellationResult {
+	result := models.BulkCancellationResult{
+		ReservationID: reservation.ID,
+		Title:         reservation.Title,
+		SpaceName:     reservation.Space.Name,
+		UserEmail:     reservation.User.Email,
+		StartTime:     reservation.StartTime,
+		EndTime:       reservation.EndTime,
+		Outcome:       models.BulkCancelOutcomeCancelled,
+	}
+
+	if err := s.reservationService.CancelReservation(reservation.ID, reason, adminID); err != nil {
+		result.Outcome = models.BulkCancelOutcomeFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	err := s.notificationService.NotifyOrganizers(reservation, models.NotificationTypeReservationCancelled,
+		"Reservation cancelled",
+		fmt.Sprintf("Your reservation \"%s\" in %s on %s was cancelled by an administrator: %s",
+			reservation.Title, reservation.Space.Name, reservation.StartTime.Format("Jan 2, 2006 15:04"), reason),
+		map[string]interface{}{"reservation_id": reservation.ID})
+	if err != nil {
+		// The cancellation stands; the report still lists it as cancelled
+		s.logger.Warn("Failed to notify user of bulk cancellation", "reservationID", reservation.ID, "error", err)
+	}
+	return result
+}
+
+// saveProgress stores the results so far
+func (s *ReservationBulkCancelService) saveProgress(id uuid.UUID, results []models.BulkCancellationResult, cancelled, failed int, completedAt *time.Time) error {
+	resultJSON, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("failed to serialize bulk cancellation results: %w", err)
+	}
+
+	updates := map[string]interface{}{
+		"results":   datatypes.JSON(resultJSON),
+		"cancelled": cancelled,
+		"failed":    failed,
+	}
+	if completedAt != nil {
+		updates["completed_at"] = *completedAt
+	}
+	if err := s.bulkCancelRepo.Update(id, updates); err != nil {
+		return fmt.Errorf("failed to save bulk cancellation progress: %w", err)
+	}
+	return nil
+}
+
+// bulkCancelFilters turns the request into reservation search filters.
+// At least one filter is required so a bare reason cannot cancel everything.
+func bulkCancelFilters(req *dto.BulkCancelRequest) (map[string]interface{}, error) {
+	filters := make(map[string]interface{})
+	if req.SpaceID != nil {
+		filters["space_id"] = *req.SpaceID
+	}
+	if req.UserID != nil {
+		filters["user_id"] = *req.UserID
+	}
+	if req.StartDate != nil {
+		filters["start_date"] = *req.StartDate
+	}
+	if req.EndDate != nil {
+		filters["end_date"] = *req.EndDate
+	}
+	if len(filters) == 0 {
+		return nil, errors.New("at least one of space_id, user_id, start_date or end_date is required")
+	}
+	if req.StartDate != nil && req.EndDate != nil && !req.EndDate.After(*req.StartDate) {
+		return nil, errors.New("end date must be after start date")
+	}
+	return filters, nil
+}
+
+// toBulkCancelReservation summarizes a matched reservation
+func toBulkCancelReservation(reservation *models.Reservation) dto.BulkCancelReservation {
+	return dto.BulkCancelReservation{
+		ID:        reservation.ID,
+		Title:     reservation.Title,
+		SpaceName: reservation.Space.Name,
+		UserEmail: reservation.User.Email,
+		StartTime: reservation.StartTime,
+		EndTime:   reservation.EndTime,
+		Status:    string(reservation.Status),
+	}
+}
@@ -0,0 +1,407 @@
+// internal/services/reservation_checklist_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// Upper bound of checklist reminders sent per run
+const checklistReminderBatch = 200
+
+// ReservationChecklistService manages the organizer's checklist of a reservation
+// (book catering, invite guests, bring an adapter), its reminders and the
+// per-space-type templates it can be started from
+type ReservationChecklistService struct {
+	checklistRepo       interfaces.ReservationChecklistRepositoryInterface
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	notificationService *NotificationService
+	logger              *slog.Logger
+}
+
+// NewReservationChecklistService creates a new reservation checklist service
+func NewReservationChecklistService(
+	checklistRepo interfaces.ReservationChecklistRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	notificationService *NotificationService,
+	logger *slog.Logger,
+) *ReservationChecklistService {
+	return &ReservationChecklistService{
+		checklistRepo:       checklistRepo,
+		reservationRepo:     reservationRepo,
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// ========================================
+// TASKS
+// ========================================
+
+// GetTasks lists the checklist of a reservation
+func (s *ReservationChecklistService) GetTasks(reservationID, userID uuid.UUID) ([]*models.ReservationTask, error) {
+	if _, err := s.organizedReservation(reservationID, userID); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.checklistRepo.GetTasksByReservation(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist: %w", err)
+	}
+	return tasks, nil
+}
+
+// AddTask adds a task at the end of the checklist
+func (s *ReservationChecklistService) AddTask(reservationID uuid.UUID, req *dto.CreateReservationTaskRequest, userID uuid.UUID) (*models.ReservationTask, error) {
+	reservation, err := s.organizedReservation(reservationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTaskDue(reservation, req.DueAt, req.ReminderMinutes); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.checklistRepo.GetTasksByReservation(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist: %w", err)
+	}
+
+	task := &models.ReservationTask{
+		ReservationID:   reservationID,
+		Title:           req.Title,
+		Position:        nextTaskPosition(tasks),
+		DueAt:           req.DueAt,
+		ReminderMinutes: req.ReminderMinutes,
+	}
+	if err := s.checklistRepo.CreateTasks([]*models.ReservationTask{task}); err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+	return task, nil
+}
+
+// ApplyTemplate appends the tasks of the space type's template to the checklist
+func (s *ReservationChecklistService) ApplyTemplate(reservationID, userID uuid.UUID) ([]*models.ReservationTask, error) {
+	reservation, err := s.organizedReservation(reservationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.checklistRepo.GetTemplate(reservation.Space.Type)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("no checklist template for %s spaces", reservation.Space.Type)
+		}
+		return nil, fmt.Errorf("failed to get checklist template: %w", err)
+	}
+
+	existing, err := s.checklistRepo.GetTasksByReservation(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist: %w", err)
+	}
+
+	position := nextTaskPosition(existing)
+	var tasks []*models.ReservationTask
+	for _, task := range templateTasks(template, reservation.StartTime) {
+		task := task
+		task.ReservationID = reservationID
+		task.Position += position
+		tasks = append(tasks, &task)
+	}
+	if len(tasks) > 0 {
+		if err := s.checklistRepo.CreateTasks(tasks); err != nil {
+			return nil, fmt.Errorf("failed to create tasks: %w", err)
+		}
+	}
+
+	checklist, err := s.checklistRepo.GetTasksByReservation(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist: %w", err)
+	}
+	return checklist, nil
+}
+
+// UpdateTask edits a task or ticks it off
+func (s *ReservationChecklistService) UpdateTask(reservationID, taskID uuid.UUID, req *dto.UpdateReservationTaskRequest, userID uuid.UUID) (*models.ReservationTask, error) {
+	reservation, err := s.organizedReservation(reservationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := s.reservationTask(reservationID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	dueAt, reminder := task.DueAt, task.ReminderMinutes
+	updates := make(map[string]interface{})
+	if req.Title != nil {
+		updates["title"] = *req.Title
+	}
+	if req.Position != nil {
+		updates["position"] = *req.Position
+	}
+	if req.DueAt != nil {
+		dueAt = req.DueAt
+		updates["due_at"] = *req.DueAt
+	}
+	if req.ReminderMinutes != nil {
+		reminder = req.ReminderMinutes
+		updates["reminder_minutes"] = *req.ReminderMinutes
+	}
+	if req.DueAt != nil || req.ReminderMinutes != nil {
+		if err := validateTaskDue(reservation, dueAt, reminder); err != nil {
+			return nil, err
+		}
+		// A new due time gets a new reminder
+		updates["reminded_at"] = nil
+	}
+	if req.Completed != nil {
+		if *req.Completed && !task.IsCompleted() {
+			updates["completed_at"] = time.Now()
+			updates["completed_by_id"] = userID
+		} else if !*req.Completed {
+			updates["completed_at"] = nil
+			updates["completed_by_id"] = nil
+		}
+	}
+	if len(updates) == 0 {
+		return task, nil
+	}
+
+	updated, err := s.checklistRepo.UpdateTask(taskID, updates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+	return updated, nil
+}
+
+// DeleteTask removes a task from the checklist
+func (s *ReservationChecklistService) DeleteTask(reservationID, taskID, userID uuid.UUID) error {
+	if _, err := s.organizedReservation(reservationID, userID); err != nil {
+		return err
+	}
+	if _, err := s.reservationTask(reservationID, taskID); err != nil {
+		return err
+	}
+
+	if err := s.checklistRepo.DeleteTask(taskID); err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+	return nil
+}
+
+// SendReminders reminds the owner and co-organizers of open tasks whose reminder time has come, once per task
+func (s *ReservationChecklistService) SendReminders(ctx context.Context) error {
+	now := time.Now()
+	tasks, err := s.checklistRepo.GetDueReminders(now, checklistReminderBatch)
+	if err != nil {
+		return fmt.Errorf("failed to get due checklist reminders: %w", err)
+	}
+
+	for _, task := range tasks {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		reservation := task.Reservation
+		if reservation == nil {
+			continue
+		}
+
+		title := fmt.Sprintf("To do for %s: %s", reservation.Title, task.Title)
+		message := fmt.Sprintf("\"%s\" is due %s for your reservation \"%s\" in %s starting %s.",
+			task.Title, task.DueAt.Format("Mon 2 Jan 15:04"), reservation.Title, reservation.Space.Name,
+			reservation.StartTime.Format("Mon 2 Jan 15:04"))
+		data := map[string]interface{}{
+			"reservation_id": reservation.ID,
+			"task_id":        task.ID,
+			"due_at":         task.DueAt,
+		}
+
+		// Marked as sent even if one organizer missed it, so the others are not reminded twice
+		if err := s.notificationService.NotifyOrganizers(reservation, models.NotificationTypeChecklistReminder, title, message, data); err != nil {
+			s.logger.WarnContext(ctx, "Failed to send checklist reminder", "taskID", task.ID, "reservationID", reservation.ID, "error", err)
+		}
+
+		if _, err := s.checklistRepo.UpdateTask(task.ID, map[string]interface{}{"reminded_at": now}); err != nil {
+			return fmt.Errorf("failed to mark checklist reminder sent: %w", err)
+		}
+	}
+
+	if len(tasks) > 0 {
+		s.logger.InfoContext(ctx, "Sent checklist reminders", "count", len(tasks))
+	}
+	return nil
+}
+
+// ========================================
+// TEMPLATES
+// ========================================
+
+// GetTemplates lists the checklist templates of every space type (admin only)
+func (s *ReservationChecklistService) GetTemplates() ([]*models.ChecklistTemplate, error) {
+	templates, err := s.checklistRepo.GetTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist templates: %w", err)
+	}
+	return templates, nil
+}
+
+// SetTemplate replaces the checklist template of a space type (admin only)
+func (s *ReservationChecklistService) SetTemplate(spaceType string, req *dto.SetChecklistTemplateRequest, userID uuid.UUID) (*models.ChecklistTemplate, error) {
+	if !isSpaceType(spaceType) {
+		return nil, fmt.Errorf("invalid space type %q", spaceType)
+	}
+
+	items := make([]models.ChecklistTemplateItem, len(req.Items))
+	for i, item := range req.Items {
+		if item.ReminderMinutes != nil && item.DueBeforeMinutes == nil {
+			return nil, fmt.Errorf("item %q needs due_before_minutes to have a reminder", item.Title)
+		}
+		items[i] = models.ChecklistTemplateItem{
+			Title:            item.Title,
+			DueBeforeMinutes: item.DueBeforeMinutes,
+			ReminderMinutes:  item.ReminderMinutes,
+		}
+	}
+	itemsJSON, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize checklist items: %w", err)
+	}
+
+	template, err := s.checklistRepo.SaveTemplate(&models.ChecklistTemplate{
+		SpaceType:   models.SpaceType(spaceType),
+		Items:       datatypes.JSON(itemsJSON),
+		UpdatedByID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save checklist template: %w", err)
+	}
+	return template, nil
+}
+
+// DeleteTemplate removes the checklist template of a space type (admin only)
+func (s *ReservationChecklistService) DeleteTemplate(spaceType string) error {
+	if err := s.checklistRepo.DeleteTemplate(models.SpaceType(spaceType)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete checklist template: %w", err)
+	}
+	return nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// organizedReservation loads a reservation whose checklist the user may see and edit
+func (s *ReservationChecklistService) organizedReservation(reservationID, userID uuid.UUID) (*models.Reservation, error) {
+	reservation, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if !reservation.IsOrganizer(userID) {
+		return nil, dto.ErrAccessDenied
+	}
+	return reservation, nil
+}
+
+// reservationTask loads a task and checks it belongs to the reservation
+func (s *ReservationChecklistService) reservationTask(reservationID, taskID uuid.UUID) (*models.ReservationTask, error) {
+	task, err := s.checklistRepo.GetTaskByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	if task.ReservationID != reservationID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return task, nil
+}
+
+// validateTaskDue checks a task is due before the reservation ends and has a due time to be reminded of
+func validateTaskDue(reservation *models.Reservation, dueAt *time.Time, reminderMinutes *int) error {
+	if reminderMinutes != nil && dueAt == nil {
+		return errors.New("a reminder needs a due time")
+	}
+	if dueAt != nil && dueAt.After(reservation.EndTime) {
+		return errors.New("due time must not be after the reservation ends")
+	}
+	return nil
+}
+
+// nextTaskPosition is the position after the last task of the checklist
+func nextTaskPosition(tasks []*models.ReservationTask) int {
+	position := 0
+	for _, task := range tasks {
+		if task.Position >= position {
+			position = task.Position + 1
+		}
+	}
+	return position
+}
+
+// templateTasks builds the checklist of a reservation starting at start from a template
+func templateTasks(template *models.ChecklistTemplate, start time.Time) []models.ReservationTask {
+	items := template.GetItems()
+	tasks := make([]models.ReservationTask, 0, len(items))
+	for i, item := range items {
+		task := models.ReservationTask{
+			Title:           item.Title,
+			Position:        i,
+			ReminderMinutes: item.ReminderMinutes,
+		}
+		if item.DueBeforeMinutes != nil {
+			due := start.Add(-time.Duration(*item.DueBeforeMinutes) * time.Minute)
+			task.DueAt = &due
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// shiftedTasks copies a checklist for another occurrence, moving due times by delta
+func shiftedTasks(tasks []models.ReservationTask, delta time.Duration) []models.ReservationTask {
+	if len(tasks) == 0 {
+		return nil
+	}
+	copies := make([]models.ReservationTask, len(tasks))
+	for i, task := range tasks {
+		copies[i] = models.ReservationTask{
+			Title:           task.Title,
+			Position:        task.Position,
+			ReminderMinutes: task.ReminderMinutes,
+		}
+		if task.DueAt != nil {
+			due := task.DueAt.Add(delta)
+			copies[i].DueAt = &due
+		}
+	}
+	return copies
+}
+
+// isSpaceType checks the value is a known space type
+func isSpaceType(value string) bool {
+	switch models.SpaceType(value) {
+	case models.SpaceTypeMeetingRoom, models.SpaceTypeOffice, models.SpaceTypeAuditorium, models.SpaceTypeOpenSpace,
+		models.SpaceTypeHotDesk, models.SpaceTypeConference, models.SpaceTypeParkingSpot, models.SpaceTypeEquipment:
+		return true
+	}
+	return false
+}
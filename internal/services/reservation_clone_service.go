@@ -0,0 +1,284 @@
+// internal/services/reservation_clone_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// Nearest free slots are searched this far before and after the requested start
+	cloneSearchSpan = 3 * 24 * time.Hour
+	// Candidate start times move away from the requested start by this step
+	cloneSearchStep = 15 * time.Minute
+)
+
+// ReservationCloneService copies reservations to another date or time. Copies are created
+// like new bookings, so conflict and policy checks run again.
+type ReservationCloneService struct {
+	reservationService *ReservationService
+	coOrganizerService *ReservationCoOrganizerService
+	guestService       *ReservationGuestService
+	reservationRepo    interfaces.ReservationRepositoryInterface
+	spaceRepo          interfaces.SpaceRepositoryInterface
+	userRepo           interfaces.UserRepositoryInterface
+	guestRepo          interfaces.ReservationGuestRepositoryInterface
+	logger             *slog.Logger
+}
+
+// NewReservationCloneService creates a new reservation clone service
+func NewReservationCloneService(
+	reservationService *ReservationService,
+	coOrganizerService *ReservationCoOrganizerService,
+	guestService *ReservationGuestService,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	guestRepo interfaces.ReservationGuestRepositoryInterface,
+	logger *slog.Logger,
+) *ReservationCloneService {
+	return &ReservationCloneService{
+		reservationService: reservationService,
+		coOrganizerService: coOrganizerService,
+		guestService:       guestService,
+		reservationRepo:    reservationRepo,
+		spaceRepo:          spaceRepo,
+		userRepo:           userRepo,
+		guestRepo:          guestRepo,
+		logger:             logger,
+	}
+}
+
+// CloneReservation books a copy of the reservation at the requested time, owned by the
+// user cloning it. Participants, equipment, co-organizers and guests are carried over;
+// the original owner becomes a co-organizer when someone else clones. When the exact copy
+// conflicts, the nearest free slot is booked instead if the request accepts it, otherwise
+// it is returned in the error.
+func (s *ReservationCloneService) CloneReservation(reservationID uuid.UUID, req *dto.CloneReservationRequest, userID uuid.UUID) (*dto.CloneReservationResponse, error) {
+	source, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if !s.reservationService.canUserModifyReservation(source, userID) {
+		return nil, dto.ErrAccessDenied
+	}
+
+	endTime := req.StartTime.Add(source.Duration())
+	if req.EndTime != nil {
+		endTime = *req.EndTime
+	}
+	if !endTime.After(req.StartTime) {
+		return nil, dto.ErrInvalidTimeRange
+	}
+
+	createReq, warnings, err := s.copyRequest(source, req, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.CloneReservationResponse{
+		SourceID:           source.ID,
+		RequestedStartTime: req.StartTime,
+		RequestedEndTime:   endTime,
+	}
+
+	reservation, err := s.reservationService.CreateReservation(createReq, userID)
+	if err != nil {
+		if !isSlotConflict(err) {
+			return nil, err
+		}
+
+		start, end, found, searchErr := s.nearestFreeSlot(createReq, userID)
+		if searchErr != nil {
+			return nil, searchErr
+		}
+		if !found {
+			return nil, err
+		}
+		if !req.AcceptNearest {
+			return nil, dto.NewCloneSlotTakenError(start, end)
+		}
+
+		createReq.StartTime, createReq.EndTime = start, end
+		reservation, err = s.reservationService.CreateReservation(createReq, userID)
+		if err != nil {
+			return nil, err
+		}
+		response.Adjusted = true
+	}
+
+	warnings = append(warnings, reservation.Warnings...)
+	warnings = append(warnings, s.copyCoOrganizers(source, reservation.ID, userID)...)
+	warnings = append(warnings, s.copyGuests(source, reservation.ID, userID)...)
+
+	reservation, err = s.reservationRepo.GetByID(reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	response.Reservation = dto.ToReservationResponse(reservation)
+	response.Warnings = warnings
+
+	return response, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// copyRequest builds the booking request of the copy. Equipment stays with the copy unless
+// it moves to a space in another building, where the equipment cannot follow.
+func (s *ReservationCloneService) copyRequest(source *models.Reservation, req *dto.CloneReservationRequest, endTime time.Time) (*dto.CreateReservationRequest, []string, error) {
+	isPrivate := source.IsPrivate
+	createReq := &dto.CreateReservationRequest{
+		SpaceID:          source.SpaceID,
+		StartTime:        req.StartTime,
+		EndTime:          endTime,
+		ParticipantCount: source.ParticipantCount,
+		Title:            source.Title,
+		Description:      source.Description,
+		IsPrivate:        &isPrivate,
+		LicensePlate:     source.LicensePlate,
+	}
+	if req.Title != "" {
+		createReq.Title = req.Title
+	}
+
+	building := source.Space.Building
+	if req.SpaceID != nil && *req.SpaceID != source.SpaceID {
+		space, err := s.spaceRepo.GetByID(*req.SpaceID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get space: %w", err)
+		}
+		createReq.SpaceID = space.ID
+		building = space.Building
+	}
+
+	var warnings []string
+	for _, resource := range source.Resources {
+		if resource.Resource != nil && resource.Resource.Building != building {
+			warnings = append(warnings, fmt.Sprintf("%s was not copied, it is in another building", resource.Resource.Name))
+			continue
+		}
+		createReq.ResourceIDs = append(createReq.ResourceIDs, resource.ResourceID)
+	}
+
+	return createReq, warnings, nil
+}
+
+// nearestFreeSlot finds the free slot of the same length closest to the requested one,
+// later slots first on ties, where the space's booking rules allow it and the user has
+// no other booking
+func (s *ReservationCloneService) nearestFreeSlot(createReq *dto.CreateReservationRequest, userID uuid.UUID) (time.Time, time.Time, bool, error) {
+	requester, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	duration := createReq.EndTime.Sub(createReq.StartTime)
+	windowStart := createReq.StartTime.Add(-cloneSearchSpan)
+	windowEnd := createReq.EndTime.Add(cloneSearchSpan)
+
+	spaces, err := s.reservationService.findTimeSpaces(&createReq.SpaceID, createReq.ParticipantCount, requester, windowStart, windowEnd)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, err
+	}
+	if len(spaces) == 0 {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	candidate := spaces[0]
+
+	own, err := s.reservationRepo.GetUserOverlappingReservations(userID, windowStart, windowEnd, nil)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("failed to get user reservations: %w", err)
+	}
+	var userBusy []busyPeriod
+	for _, reservation := range own {
+		userBusy = append(userBusy, busyPeriod{start: reservation.StartTime, end: reservation.EndTime})
+	}
+
+	now := time.Now()
+	for shift := cloneSearchStep; shift <= cloneSearchSpan; shift += cloneSearchStep {
+		for _, start := range []time.Time{createReq.StartTime.Add(shift), createReq.StartTime.Add(-shift)} {
+			end := start.Add(duration)
+			if start.Before(now) || overlapsAny(userBusy, start, end) {
+				continue
+			}
+			if candidate.canHost(start, end, now) {
+				return start, end, true, nil
+			}
+		}
+	}
+	return time.Time{}, time.Time{}, false, nil
+}
+
+// copyCoOrganizers gives the copy the co-organizers of the original, plus its owner when
+// someone else cloned it, and returns warnings for what could not be copied
+func (s *ReservationCloneService) copyCoOrganizers(source *models.Reservation, reservationID, userID uuid.UUID) []string {
+	var userIDs []uuid.UUID
+	if source.UserID != userID {
+		userIDs = append(userIDs, source.UserID)
+	}
+	for _, coOrganizer := range source.CoOrganizers {
+		if coOrganizer.UserID != userID {
+			userIDs = append(userIDs, coOrganizer.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	if _, err := s.coOrganizerService.SetCoOrganizers(reservationID, &dto.SetCoOrganizersRequest{UserIDs: userIDs}, userID); err != nil {
+		s.logger.Warn("Failed to copy co-organizers", "reservationID", reservationID, "error", err)
+		return []string{fmt.Sprintf("co-organizers were not copied: %v", err)}
+	}
+	return nil
+}
+
+// copyGuests invites the guests of the original to the copy and returns warnings for what could not be copied
+func (s *ReservationCloneService) copyGuests(source *models.Reservation, reservationID, userID uuid.UUID) []string {
+	guests, err := s.guestRepo.GetByReservation(source.ID)
+	if err != nil {
+		s.logger.Warn("Failed to get guests to copy", "reservationID", source.ID, "error", err)
+		return []string{"guests were not copied"}
+	}
+	if len(guests) == 0 {
+		return nil
+	}
+
+	req := &dto.AddGuestsRequest{}
+	for _, guest := range guests {
+		req.Guests = append(req.Guests, dto.GuestRequest{Name: guest.Name, Email: guest.Email, Company: guest.Company})
+	}
+	if _, err := s.guestService.AddGuests(reservationID, req, userID); err != nil {
+		s.logger.Warn("Failed to copy guests", "reservationID", reservationID, "error", err)
+		return []string{fmt.Sprintf("guests were not copied: %v", err)}
+	}
+	return nil
+}
+
+// isSlotConflict checks if a booking failed because the slot is taken, rather than for a
+// reason another time would not fix
+func isSlotConflict(err error) bool {
+	var coded *dto.CodedError
+	if !errors.As(err, &coded) {
+		return false
+	}
+	switch coded.Code {
+	case dto.ErrCodeResTimeConflict, dto.ErrCodeResVIPReserved, dto.ErrCodeResUserOverlap,
+		dto.ErrCodeResourceBooked, dto.ErrCodeSpaceFloorClosed, dto.ErrCodeSpaceClosed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+// internal/services/reservation_co_organizer_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// ReservationCoOrganizerService lets the owner of a reservation designate co-organizers,
+// who can then edit, cancel and check in to it as the owner can
+type ReservationCoOrganizerService struct {
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	userRepo            interfaces.UserRepositoryInterface
+	notificationService *NotificationService
+	logger              *slog.Logger
+}
+
+// NewReservationCoOrganizerService creates a new reservation co-organizer service
+func NewReservationCoOrganizerService(
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	notificationService *NotificationService,
+	logger *slog.Logger,
+) *ReservationCoOrganizerService {
+	return &ReservationCoOrganizerService{
+		reservationRepo:     reservationRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// GetCoOrganizers lists the co-organizers of a reservation
+func (s *ReservationCoOrganizerService) GetCoOrganizers(reservationID, userID uuid.UUID) ([]models.ReservationCoOrganizer, error) {
+	reservation, err := s.getReservation(reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reservation.IsOrganizer(userID) {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if !user.IsAdmin() {
+			return nil, dto.ErrAccessDenied
+		}
+	}
+
+	return reservation.CoOrganizers, nil
+}
+
+// SetCoOrganizers replaces the co-organizers of a reservation and tells the users added
+// and removed. Only the owner or an admin can change them; co-organizers cannot.
+func (s *ReservationCoOrganizerService) SetCoOrganizers(reservationID uuid.UUID, req *dto.SetCoOrganizersRequest, userID uuid.UUID) (*models.Reservation, error) {
+	reservation, err := s.getReservation(reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	actor, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if reservation.UserID != userID && !actor.IsAdmin() {
+		return nil, dto.ErrAccessDenied
+	}
+
+	if !reservation.CanBeCancelled() || !time.Now().Before(reservation.EndTime) {
+		return nil, errors.New("co-organizers can only be changed on upcoming or ongoing reservations")
+	}
+
+	var coOrganizers []*models.ReservationCoOrganizer
+	designated := make(map[uuid.UUID]*models.User)
+	for _, id := range req.UserIDs {
+		if id == reservation.UserID {
+			return nil, errors.New("the owner cannot be a co-organizer")
+		}
+		if designated[id] != nil {
+			continue
+		}
+		user, err := s.userRepo.GetByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("user %s not found", id)
+			}
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if !user.IsActive {
+			return nil, fmt.Errorf("user %s is not active", id)
+		}
+		designated[id] = user
+		coOrganizers = append(coOrganizers, &models.ReservationCoOrganizer{
+			ReservationID: reservationID,
+			UserID:        id,
+			AddedByID:     userID,
+		})
+	}
+
+	if err := s.reservationRepo.SetCoOrganizers(reservationID, coOrganizers); err != nil {
+		return nil, fmt.Errorf("failed to save co-organizers: %w", err)
+	}
+
+	previous := reservation.CoOrganizers
+	updated, err := s.getReservation(reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, coOrganizer := range previous {
+		if designated[coOrganizer.UserID] == nil {
+			s.notifyChange(updated, coOrganizer.UserID, actor, false)
+		}
+	}
+	for id := range designated {
+		if !reservation.IsCoOrganizer(id) {
+			s.notifyChange(updated, id, actor, true)
+		}
+	}
+
+	return updated, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// getReservation loads a reservation with its owner and co-organizers
+func (s *ReservationCoOrganizerService) getReservation(reservationID uuid.UUID) (*models.Reservation, error) {
+	reservation, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	return reservation, nil
+}
+
+// notifyChange tells a user they were made or are no longer a co-organizer, naming the owner
+func (s *ReservationCoOrganizerService) notifyChange(reservation *models.Reservation, userID uuid.UUID, actor *models.User, added bool) {
+	when := reservation.StartTime.Format("Jan 2, 2006 15:04")
+	notificationType := models.NotificationTypeCoOrganizerRemoved
+	title := "No longer co-organizing"
+	message := fmt.Sprintf("%s removed you as co-organizer of \"%s\" in %s on %s, organized by %s.",
+		actor.GetFullName(), reservation.Title, reservation.Space.Name, when, reservation.User.GetFullName())
+	if added {
+		notificationType = models.NotificationTypeCoOrganizerAdded
+		title = "You are co-organizing a reservation"
+		message = fmt.Sprintf("%s made you co-organizer of \"%s\" in %s on %s, organized by %s. You can now edit, cancel and check in to it.",
+			actor.GetFullName(), reservation.Title, reservation.Space.Name, when, reservation.User.GetFullName())
+	}
+
+	data := map[string]interface{}{
+		"reservation_id": reservation.ID,
+		"owner_id":       reservation.UserID,
+	}
+	if _, err := s.notificationService.Notify(userID, notificationType, title, message, data); err != nil {
+		s.logger.Warn("Failed to notify co-organizer change", "reservationID", reservation.ID, "userID", userID, "error", err)
+	}
+}
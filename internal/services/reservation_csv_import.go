@@ -0,0 +1,213 @@
+// internal/services/reservation_csv_import.go
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+)
+
+const (
+	// Rows of one CSV import, larger than a sheet since it migrates whole calendars
+	maxCSVImportRows = 2000
+	// Column naming the booker by email; rows without it are booked for the importer
+	csvImportUserColumn = "user_email"
+)
+
+// ========================================
+// CSV IMPORT
+// ========================================
+
+// ImportCSV books the rows of a CSV file exported from a legacy system. Rows use the default
+// sheet columns plus an optional user_email column for the booker. A dry run only validates
+// the rows and reports conflicts. Otherwise valid rows are booked as they are, bypassing
+// booking rules like advance notice, and rows clashing with a booking or an earlier row are
+// skipped, booked anyway (force) or booked pending approval (queue_approval).
+func (s *ReservationImportService) ImportCSV(file io.Reader, req *dto.CSVImportRequest, userID uuid.UUID) (*dto.CSVImportResponse, error) {
+	onConflict := req.OnConflict
+	if onConflict == "" {
+		onConflict = dto.ImportConflictSkip
+	}
+	location := time.Local
+	if req.Timezone != "" {
+		location, _ = time.LoadLocation(req.Timezone) // Checked by the request validation
+	}
+
+	reader := csv.NewReader(io.LimitReader(file, maxImportSheetSize))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV file: %w", err)
+	}
+
+	if len(records) < 2 {
+		return nil, errors.New("file has no data rows")
+	}
+	if len(records)-1 > maxCSVImportRows {
+		return nil, fmt.Errorf("file exceeds the maximum of %d rows", maxCSVImportRows)
+	}
+
+	// Spreadsheet tools often start UTF-8 exports with a byte order mark
+	header := records[0]
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	var mapping dto.SheetColumnMapping
+	mapping.SetDefaults()
+	columns, err := resolveImportColumns(header, &mapping)
+	if err != nil {
+		return nil, err
+	}
+	userColumn := -1
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), csvImportUserColumn) {
+			userColumn = i
+		}
+	}
+
+	spaces, err := s.loadSpaceLookup()
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[string]*models.User)
+	var rows []*importRow
+	for i, record := range records[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+
+		// Row numbers match the file opened in a spreadsheet, where the header is row 1
+		row := s.parseRow(i+2, record, columns, spaces, location)
+		row.ownerID = userID
+		if userColumn >= 0 && userColumn < len(record) {
+			if err := s.resolveRowOwner(row, strings.TrimSpace(record[userColumn]), owners); err != nil {
+				return nil, err
+			}
+		}
+		if row.result.Status == "ready" {
+			s.checkRowConflicts(row, rows)
+		}
+		rows = append(rows, row)
+	}
+
+	response := &dto.CSVImportResponse{
+		DryRun:     req.DryRun,
+		OnConflict: onConflict,
+		TotalRows:  len(rows),
+		Rows:       []dto.SheetImportRowResult{},
+	}
+	for _, row := range rows {
+		result := row.result
+		if !req.DryRun {
+			s.commitCSVRow(row, &result, onConflict)
+		}
+		tallyCSVRow(response, result.Status)
+		response.Rows = append(response.Rows, result)
+	}
+
+	return response, nil
+}
+
+// resolveRowOwner books a row for the user named in its user_email column
+func (s *ReservationImportService) resolveRowOwner(row *importRow, email string, owners map[string]*models.User) error {
+	if email == "" {
+		return nil
+	}
+	row.result.UserEmail = email
+
+	key := strings.ToLower(email)
+	owner, known := owners[key]
+	if !known {
+		user, err := s.userRepo.GetByEmail(email)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		owner = user
+		owners[key] = owner
+	}
+
+	if owner == nil || !owner.IsActive {
+		row.result.Errors = append(row.result.Errors, fmt.Sprintf("unknown or inactive user %q", email))
+		row.result.Status = "invalid"
+		return nil
+	}
+	row.ownerID = owner.ID
+	return nil
+}
+
+// commitCSVRow books one row following the conflict strategy and records the outcome
+func (s *ReservationImportService) commitCSVRow(row *importRow, result *dto.SheetImportRowResult, onConflict string) {
+	status := models.StatusConfirmed
+	switch result.Status {
+	case "ready":
+	case "conflict":
+		switch onConflict {
+		case dto.ImportConflictForce:
+		case dto.ImportConflictQueueApproval:
+			status = models.StatusPending
+		default:
+			result.Status = "skipped"
+			return
+		}
+	default:
+		return
+	}
+
+	reservation := &models.Reservation{
+		UserID:           row.ownerID,
+		SpaceID:          row.space.ID,
+		StartTime:        row.request.StartTime,
+		EndTime:          row.request.EndTime,
+		ParticipantCount: row.request.ParticipantCount,
+		Title:            row.request.Title,
+		Description:      row.request.Description,
+		Status:           status,
+		Cost:             row.space.CostFor(row.request.StartTime, row.request.EndTime),
+	}
+	if status == models.StatusPending {
+		reservation.ApprovalSteps = 1
+	}
+
+	created, err := s.reservationRepo.Create(reservation)
+	if err != nil {
+		result.Status = "failed"
+		result.Errors = append(result.Errors, "failed to create reservation: "+err.Error())
+		return
+	}
+
+	result.ReservationID = &created.ID
+	result.Status = "created"
+	if status == models.StatusPending {
+		result.Status = "queued"
+	}
+}
+
+// tallyCSVRow updates the import counters for a row status
+func tallyCSVRow(response *dto.CSVImportResponse, status string) {
+	switch status {
+	case "ready":
+		response.ReadyCount++
+	case "conflict":
+		response.ConflictCount++
+	case "invalid":
+		response.InvalidCount++
+	case "created":
+		response.CreatedCount++
+	case "queued":
+		response.QueuedCount++
+	case "skipped":
+		response.SkippedCount++
+	case "failed":
+		response.FailedCount++
+	}
+}
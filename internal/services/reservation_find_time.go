@@ -0,0 +1,306 @@
+// internal/services/reservation_find_time.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+)
+
+const (
+	// Widest window searched in one request
+	findTimeMaxWindow = 14 * 24 * time.Hour
+	// Candidate start times are aligned to this step
+	findTimeStep = 30 * time.Minute
+	// Upper bound of spaces checked when no space is given
+	findTimeCandidateSpaces = 100
+	// Spaces listed per suggestion
+	findTimeSpacesPerSlot = 3
+	// Suggestions returned when the request does not say
+	findTimeDefaultResults = 10
+)
+
+// busyPeriod is a time someone or something is booked
+type busyPeriod struct {
+	start time.Time
+	end   time.Time
+}
+
+// findTimeSpace is a candidate space with its bookings in the search window
+type findTimeSpace struct {
+	space    *models.Space
+	busy     []busyPeriod
+	blocks   []*models.VIPBlock           // VIP blocks the requester's role cannot book through
+	closures []*models.FloorConsolidation // Consolidations closing the space's floor
+}
+
+// FindTime proposes slots within the window when every participant is free and a
+// space that fits them all can be booked, similar to a scheduling assistant.
+// Only free/busy is considered; no details of other reservations are returned.
+func (s *ReservationService) FindTime(req *dto.FindTimeRequest, userID uuid.UUID) (*dto.FindTimeResponse, error) {
+	if !req.WindowEnd.After(req.WindowStart) {
+		return nil, errors.New("window end must be after window start")
+	}
+	if req.WindowEnd.Sub(req.WindowStart) > findTimeMaxWindow {
+		return nil, errors.New("search window cannot exceed 14 days")
+	}
+
+	location := time.UTC
+	if req.Timezone != "" {
+		loc, err := time.LoadLocation(req.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q", req.Timezone)
+		}
+		location = loc
+	}
+
+	dayStart, err := parseClock(req.WorkdayStart, 9*time.Hour)
+	if err != nil {
+		return nil, errors.New("invalid workday_start, expected HH:MM")
+	}
+	dayEnd, err := parseClock(req.WorkdayEnd, 18*time.Hour)
+	if err != nil {
+		return nil, errors.New("invalid workday_end, expected HH:MM")
+	}
+	duration := time.Duration(req.DurationMinutes) * time.Minute
+	if dayEnd-dayStart < duration {
+		return nil, errors.New("meeting does not fit within the working hours")
+	}
+
+	maxResults := req.MaxResults
+	if maxResults == 0 {
+		maxResults = findTimeDefaultResults
+	}
+
+	requester, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// The requester always attends
+	participants := []uuid.UUID{userID}
+	seen := map[uuid.UUID]bool{userID: true}
+	for _, id := range req.ParticipantIDs {
+		if seen[id] {
+			continue
+		}
+		if _, err := s.userRepo.GetByID(id); err != nil {
+			return nil, fmt.Errorf("participant %s not found", id)
+		}
+		seen[id] = true
+		participants = append(participants, id)
+	}
+
+	now := time.Now()
+	windowStart := req.WindowStart
+	if windowStart.Before(now) {
+		windowStart = now
+	}
+	windowEnd := req.WindowEnd
+
+	response := &dto.FindTimeResponse{
+		DurationMinutes: req.DurationMinutes,
+		Participants:    participants,
+		Suggestions:     []dto.TimeSuggestion{},
+	}
+	if !windowEnd.After(windowStart) {
+		return response, nil
+	}
+
+	var attendeesBusy []busyPeriod
+	for _, participantID := range participants {
+		reservations, err := s.reservationRepo.GetUserOverlappingReservations(participantID, windowStart, windowEnd, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get participant reservations: %w", err)
+		}
+		for _, reservation := range reservations {
+			attendeesBusy = append(attendeesBusy, busyPeriod{start: reservation.StartTime, end: reservation.EndTime})
+		}
+	}
+
+	spaces, err := s.findTimeSpaces(req.SpaceID, len(participants), requester, windowStart, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+	if len(spaces) == 0 {
+		return response, nil
+	}
+
+	// Walk the working hours of each day in the requested timezone
+	local := windowStart.In(location)
+	for day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location); day.Before(windowEnd); day = day.AddDate(0, 0, 1) {
+		if !req.IncludeWeekends && (day.Weekday() == time.Saturday || day.Weekday() == time.Sunday) {
+			continue
+		}
+		if !req.IncludeHolidays && s.holidays.IsHoliday(day) {
+			continue
+		}
+
+		// time.Date normalizes the minutes, which keeps working hours right across DST changes
+		open := time.Date(day.Year(), day.Month(), day.Day(), 0, int(dayStart.Minutes()), 0, 0, location)
+		closing := time.Date(day.Year(), day.Month(), day.Day(), 0, int(dayEnd.Minutes()), 0, 0, location)
+		for start := open; !start.Add(duration).After(closing); start = start.Add(findTimeStep) {
+			end := start.Add(duration)
+			if start.Before(windowStart) || end.After(windowEnd) {
+				continue
+			}
+			if overlapsAny(attendeesBusy, start, end) {
+				continue
+			}
+
+			var free []dto.SuggestedSpaceRef
+			for _, candidate := range spaces {
+				if !candidate.canHost(start, end, now) {
+					continue
+				}
+				free = append(free, dto.SuggestedSpaceRef{
+					ID:               candidate.space.ID,
+					Name:             candidate.space.Name,
+					Building:         candidate.space.Building,
+					Floor:            candidate.space.Floor,
+					Capacity:         candidate.space.Capacity,
+					RequiresApproval: candidate.space.RequiresApproval,
+				})
+				if len(free) == findTimeSpacesPerSlot {
+					break
+				}
+			}
+			if len(free) == 0 {
+				continue
+			}
+
+			response.Suggestions = append(response.Suggestions, dto.TimeSuggestion{
+				StartTime: start,
+				EndTime:   end,
+				Spaces:    free,
+			})
+			if len(response.Suggestions) == maxResults {
+				return response, nil
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// findTimeSpaces loads the candidate spaces, smallest first, with their bookings and VIP blocks in the window
+func (s *ReservationService) findTimeSpaces(spaceID *uuid.UUID, participants int, requester *models.User, windowStart, windowEnd time.Time) ([]*findTimeSpace, error) {
+	var spaces []*models.Space
+	if spaceID != nil {
+		space, err := s.spaceRepo.GetByID(*spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get space: %w", err)
+		}
+		if space.Capacity < participants {
+			return nil, dto.NewCapacityExceededError(participants, space.Capacity)
+		}
+		spaces = []*models.Space{space}
+	} else {
+		var err error
+		spaces, _, err = s.spaceRepo.GetSpacesByCapacityRange(participants, 0, 0, findTimeCandidateSpaces)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get spaces: %w", err)
+		}
+	}
+
+	restricted, err := s.restrictedDesks(spaces, requester.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	consolidations, err := s.consolidationRepo.GetCurrentAndUpcoming(windowStart.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get floor consolidations: %w", err)
+	}
+
+	var candidates []*findTimeSpace
+	for _, space := range spaces {
+		// Parking spots and equipment are only proposed when asked for by ID
+		if !space.IsAvailable() || (spaceID == nil && !space.HostsPeople()) || restricted[space.ID] != nil {
+			continue
+		}
+
+		reservations, err := s.reservationRepo.GetConflictingReservations(space.ID, windowStart, windowEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get space reservations: %w", err)
+		}
+		candidate := &findTimeSpace{space: space}
+		for _, reservation := range reservations {
+			candidate.busy = append(candidate.busy, busyPeriod{start: reservation.StartTime, end: reservation.EndTime})
+		}
+
+		if space.IsVIP {
+			blocks, err := s.vipBlockRepo.GetActiveBlocksBySpace(space.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get VIP blocks: %w", err)
+			}
+			for _, block := range blocks {
+				if !block.AllowsRole(requester.Role) {
+					candidate.blocks = append(candidate.blocks, block)
+				}
+			}
+		}
+
+		for _, consolidation := range consolidations {
+			if consolidation.Building == space.Building && !consolidation.KeepsOpen(space.Floor) {
+				candidate.closures = append(candidate.closures, consolidation)
+			}
+		}
+
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+// canHost checks the space is free and its booking rules allow the slot
+func (c *findTimeSpace) canHost(start, end, now time.Time) bool {
+	space := c.space
+	if space.MaxBookingDuration > 0 && end.Sub(start) > time.Duration(space.MaxBookingDuration)*time.Minute {
+		return false
+	}
+	if space.BookingAdvanceTime > 0 && start.Before(now.Add(time.Duration(space.BookingAdvanceTime)*time.Minute)) {
+		return false
+	}
+	gap := space.BufferGap()
+	if overlapsAny(c.busy, start.Add(-gap), end.Add(gap)) {
+		return false
+	}
+	for _, block := range c.blocks {
+		if block.Overlaps(start, end) {
+			return false
+		}
+	}
+	for _, consolidation := range c.closures {
+		if consolidation.Covers(start) {
+			return false
+		}
+	}
+	return true
+}
+
+// overlapsAny checks whether the slot overlaps any busy period
+func overlapsAny(busy []busyPeriod, start, end time.Time) bool {
+	for _, period := range busy {
+		if period.start.Before(end) && period.end.After(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock parses an HH:MM time of day into an offset from midnight
+func parseClock(value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
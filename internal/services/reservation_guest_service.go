@@ -0,0 +1,497 @@
+// internal/services/reservation_guest_service.go
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	qrcode "github.com/skip2/go-qrcode"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+	"room-reservation-api/internal/utils"
+)
+
+const (
+	// Upper bound of external guests on a single reservation
+	maxGuestsPerReservation = 20
+	// Pixel size of the guest pass QR code image
+	guestPassQRSize = 256
+)
+
+// ReservationGuestService manages external visitors invited to reservations
+type ReservationGuestService struct {
+	guestRepo       interfaces.ReservationGuestRepositoryInterface
+	reservationRepo interfaces.ReservationRepositoryInterface
+	userRepo        interfaces.UserRepositoryInterface
+	mailer          Mailer           // Optional, nil skips invite emails
+	roomSwapService *RoomSwapService // Optional, nil keeps meetings in their room when guests decline
+	baseURL         string
+	logger          *slog.Logger
+}
+
+// NewReservationGuestService creates a new reservation guest service
+func NewReservationGuestService(
+	guestRepo interfaces.ReservationGuestRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	mailer Mailer,
+	roomSwapService *RoomSwapService,
+	baseURL string,
+	logger *slog.Logger,
+) *ReservationGuestService {
+	return &ReservationGuestService{
+		guestRepo:       guestRepo,
+		reservationRepo: reservationRepo,
+		userRepo:        userRepo,
+		mailer:          mailer,
+		roomSwapService: roomSwapService,
+		baseURL:         strings.TrimRight(baseURL, "/"),
+		logger:          logger,
+	}
+}
+
+// ========================================
+// HOST OPERATIONS
+// ========================================
+
+// AddGuests invites external guests to a reservation and emails each of them a calendar invite
+func (s *ReservationGuestService) AddGuests(reservationID uuid.UUID, req *dto.AddGuestsRequest, userID uuid.UUID) ([]dto.ReservationGuestResponse, error) {
+	reservation, err := s.getHostedReservation(reservationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reservation.CanBeCancelled() {
+		return nil, errors.New("guests can only be invited to upcoming reservations")
+	}
+
+	existing, err := s.guestRepo.GetByReservation(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guests: %w", err)
+	}
+	if len(existing)+len(req.Guests) > maxGuestsPerReservation {
+		return nil, fmt.Errorf("a reservation can have at most %d guests", maxGuestsPerReservation)
+	}
+
+	invited := make(map[string]bool, len(existing)+len(req.Guests))
+	for _, guest := range existing {
+		invited[strings.ToLower(guest.Email)] = true
+	}
+	for _, guest := range req.Guests {
+		email := strings.ToLower(strings.TrimSpace(guest.Email))
+		if invited[email] {
+			return nil, fmt.Errorf("guest already invited: %s", guest.Email)
+		}
+		invited[email] = true
+	}
+
+	responses := make([]dto.ReservationGuestResponse, 0, len(req.Guests))
+	for _, guestReq := range req.Guests {
+		token, err := generateGuestPassToken()
+		if err != nil {
+			return nil, err
+		}
+
+		guest, err := s.guestRepo.Create(&models.ReservationGuest{
+			ReservationID: reservationID,
+			InvitedByID:   userID,
+			Name:          strings.TrimSpace(guestReq.Name),
+			Email:         strings.TrimSpace(guestReq.Email),
+			Company:       strings.TrimSpace(guestReq.Company),
+			PassToken:     token,
+			RSVPStatus:    models.GuestRSVPPending,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create guest: %w", err)
+		}
+
+		s.sendInvite(reservation, guest, utils.CalendarMethodRequest)
+		responses = append(responses, s.toGuestResponse(guest))
+	}
+
+	return responses, nil
+}
+
+// GetGuests lists the guests of a reservation
+func (s *ReservationGuestService) GetGuests(reservationID, userID uuid.UUID) ([]dto.ReservationGuestResponse, error) {
+	if _, err := s.getHostedReservation(reservationID, userID); err != nil {
+		return nil, err
+	}
+
+	guests, err := s.guestRepo.GetByReservation(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guests: %w", err)
+	}
+
+	responses := make([]dto.ReservationGuestResponse, len(guests))
+	for i, guest := range guests {
+		responses[i] = s.toGuestResponse(guest)
+	}
+	return responses, nil
+}
+
+// ResendInvite emails the calendar invite to a guest again
+func (s *ReservationGuestService) ResendInvite(reservationID, guestID, userID uuid.UUID) (*dto.ReservationGuestResponse, error) {
+	reservation, err := s.getHostedReservation(reservationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	guest, err := s.getReservationGuest(reservationID, guestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reservation.CanBeCancelled() {
+		return nil, errors.New("guests can only be invited to upcoming reservations")
+	}
+	if s.mailer == nil {
+		return nil, errors.New("email delivery is not configured")
+	}
+
+	if !s.sendInvite(reservation, guest, utils.CalendarMethodRequest) {
+		return nil, errors.New("failed to send invite")
+	}
+
+	response := s.toGuestResponse(guest)
+	return &response, nil
+}
+
+// RemoveGuest uninvites a guest and emails them a cancellation
+func (s *ReservationGuestService) RemoveGuest(reservationID, guestID, userID uuid.UUID) error {
+	reservation, err := s.getHostedReservation(reservationID, userID)
+	if err != nil {
+		return err
+	}
+
+	guest, err := s.getReservationGuest(reservationID, guestID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.guestRepo.Delete(guest.ID); err != nil {
+		return fmt.Errorf("failed to remove guest: %w", err)
+	}
+
+	if guest.InviteSentAt != nil && reservation.EndTime.After(time.Now()) {
+		s.sendInvite(reservation, guest, utils.CalendarMethodCancel)
+	}
+
+	return nil
+}
+
+// ========================================
+// GUEST PASSES
+// ========================================
+
+// GetGuestPass resolves a pass token to the details a guest shows at reception
+func (s *ReservationGuestService) GetGuestPass(token string) (*dto.GuestPassResponse, error) {
+	guest, err := s.getGuestByToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toPassResponse(guest), nil
+}
+
+// RespondToInvite records whether a guest will come. Declines take the guest off the
+// participant count, which may get the meeting a smaller room; accepting again after
+// declining puts them back.
+func (s *ReservationGuestService) RespondToInvite(token string, req *dto.GuestRSVPRequest) (*dto.GuestPassResponse, error) {
+	guest, err := s.getGuestByToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation := guest.Reservation
+	if !reservation.CanBeCancelled() || !reservation.StartTime.After(time.Now()) {
+		return nil, errors.New("invitation can no longer be answered")
+	}
+
+	status := models.GuestRSVPStatus(req.Response)
+	if guest.RSVPStatus == status {
+		return s.toPassResponse(guest), nil
+	}
+
+	delta := 0
+	if status == models.GuestRSVPDeclined {
+		delta = -1
+	} else if guest.HasDeclined() {
+		delta = 1
+	}
+
+	now := time.Now()
+	if err := s.guestRepo.Update(guest.ID, map[string]interface{}{"rsvp_status": status, "responded_at": now}); err != nil {
+		return nil, fmt.Errorf("failed to save response: %w", err)
+	}
+	guest.RSVPStatus = status
+	guest.RespondedAt = &now
+
+	// The organizer always counts, so the count does not drop below one
+	count := reservation.ParticipantCount + delta
+	if count < 1 {
+		count = 1
+	}
+	if count != reservation.ParticipantCount {
+		if _, err := s.reservationRepo.Update(reservation.ID, map[string]interface{}{"participant_count": count}); err != nil {
+			return nil, fmt.Errorf("failed to update participant count: %w", err)
+		}
+		reservation.ParticipantCount = count
+	}
+
+	if delta < 0 && s.roomSwapService != nil {
+		if err := s.roomSwapService.CheckAttendance(reservation); err != nil {
+			s.logger.Warn("Failed to check room size after decline", "reservationID", reservation.ID, "error", err)
+		}
+	}
+
+	return s.toPassResponse(guest), nil
+}
+
+// GetGuestPassQRCode renders the pass link as a PNG QR code
+func (s *ReservationGuestService) GetGuestPassQRCode(token string) ([]byte, error) {
+	guest, err := s.getGuestByToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	png, err := qrcode.Encode(s.passURL(guest.PassToken), qrcode.Medium, guestPassQRSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate QR code: %w", err)
+	}
+	return png, nil
+}
+
+// toPassResponse converts a guest with its reservation to the pass they show at reception
+func (s *ReservationGuestService) toPassResponse(guest *models.ReservationGuest) *dto.GuestPassResponse {
+	reservation := guest.Reservation
+	return &dto.GuestPassResponse{
+		GuestName:  guest.Name,
+		Company:    guest.Company,
+		HostName:   reservation.User.GetFullName(),
+		Title:      reservation.Title,
+		SpaceName:  reservation.Space.Name,
+		Building:   reservation.Space.Building,
+		Floor:      reservation.Space.Floor,
+		RoomNumber: reservation.Space.RoomNumber,
+		StartTime:  reservation.StartTime,
+		EndTime:    reservation.EndTime,
+		Status:     string(reservation.Status),
+		QRCodeURL:  s.qrCodeURL(guest.PassToken),
+		RSVPStatus: string(guest.RSVPStatus),
+		ArrivedAt:  guest.ArrivedAt,
+	}
+}
+
+// ========================================
+// RECEPTION
+// ========================================
+
+// GetExpectedVisitors lists the guests expected in a building on a given day
+func (s *ReservationGuestService) GetExpectedVisitors(building string, date time.Time) (*dto.ExpectedVisitorsResponse, error) {
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	guests, err := s.guestRepo.GetExpectedVisitors(building, dayStart, dayStart.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expected visitors: %w", err)
+	}
+
+	response := &dto.ExpectedVisitorsResponse{
+		Building:      building,
+		Date:          dayStart.Format("2006-01-02"),
+		TotalVisitors: len(guests),
+		Visitors:      make([]dto.ExpectedVisitor, len(guests)),
+	}
+	for i, guest := range guests {
+		reservation := guest.Reservation
+		if guest.HasArrived() {
+			response.ArrivedCount++
+		}
+		response.Visitors[i] = dto.ExpectedVisitor{
+			GuestID:          guest.ID,
+			Name:             guest.Name,
+			Company:          guest.Company,
+			HostName:         reservation.User.GetFullName(),
+			HostEmail:        reservation.User.Email,
+			ReservationTitle: reservation.Title,
+			SpaceName:        reservation.Space.Name,
+			Floor:            reservation.Space.Floor,
+			RoomNumber:       reservation.Space.RoomNumber,
+			StartTime:        reservation.StartTime,
+			EndTime:          reservation.EndTime,
+			ArrivedAt:        guest.ArrivedAt,
+		}
+	}
+
+	return response, nil
+}
+
+// MarkGuestArrived records that reception has checked a guest in
+func (s *ReservationGuestService) MarkGuestArrived(guestID uuid.UUID) (*dto.ReservationGuestResponse, error) {
+	guest, err := s.guestRepo.GetByID(guestID)
+	if err != nil {
+		return nil, errors.New("guest not found")
+	}
+
+	if guest.HasArrived() {
+		return nil, errors.New("guest has already arrived")
+	}
+
+	now := time.Now()
+	if err := s.guestRepo.Update(guest.ID, map[string]interface{}{"arrived_at": now}); err != nil {
+		return nil, fmt.Errorf("failed to check guest in: %w", err)
+	}
+	guest.ArrivedAt = &now
+
+	response := s.toGuestResponse(guest)
+	return &response, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// getHostedReservation loads a reservation the user may manage guests for
+func (s *ReservationGuestService) getHostedReservation(reservationID, userID uuid.UUID) (*models.Reservation, error) {
+	reservation, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	if !reservation.IsOrganizer(userID) {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if !user.IsAdmin() && !user.IsManager() {
+			return nil, dto.ErrAccessDenied
+		}
+	}
+
+	return reservation, nil
+}
+
+// getReservationGuest loads a guest and checks it belongs to the reservation
+func (s *ReservationGuestService) getReservationGuest(reservationID, guestID uuid.UUID) (*models.ReservationGuest, error) {
+	guest, err := s.guestRepo.GetByID(guestID)
+	if err != nil || guest.ReservationID != reservationID {
+		return nil, errors.New("guest not found")
+	}
+	return guest, nil
+}
+
+// getGuestByToken resolves a pass token to a guest with its reservation loaded
+func (s *ReservationGuestService) getGuestByToken(token string) (*models.ReservationGuest, error) {
+	if token == "" {
+		return nil, errors.New("invalid guest pass")
+	}
+
+	guest, err := s.guestRepo.GetByToken(token)
+	if err != nil || guest.Reservation == nil {
+		return nil, errors.New("invalid guest pass")
+	}
+	return guest, nil
+}
+
+// sendInvite emails a guest the calendar invite (or cancellation), returning true when sent
+func (s *ReservationGuestService) sendInvite(reservation *models.Reservation, guest *models.ReservationGuest, method string) bool {
+	if s.mailer == nil {
+		return false
+	}
+
+	space := reservation.Space
+	location := fmt.Sprintf("%s, %s - Floor %d - Room %s", space.Name, space.Building, space.Floor, space.RoomNumber)
+	invite := &utils.CalendarInvite{
+		UID:            guest.ID.String() + "@room-reservation",
+		Method:         method,
+		Summary:        reservation.Title,
+		Description:    fmt.Sprintf("Hosted by %s. Show your guest pass at reception: %s", reservation.User.GetFullName(), s.passURL(guest.PassToken)),
+		Location:       location,
+		Start:          reservation.StartTime,
+		End:            reservation.EndTime,
+		OrganizerName:  reservation.User.GetFullName(),
+		OrganizerEmail: reservation.User.Email,
+		AttendeeName:   guest.Name,
+		AttendeeEmail:  guest.Email,
+	}
+
+	var subject, body string
+	attachments := []MailAttachment{}
+	if method == utils.CalendarMethodCancel {
+		invite.Sequence = 1
+		subject = fmt.Sprintf("Cancelled: %s", reservation.Title)
+		body = fmt.Sprintf("Hello %s,\n\n%s has removed you from \"%s\" on %s. Your guest pass is no longer valid.",
+			guest.Name, reservation.User.GetFullName(), reservation.Title, reservation.StartTime.Format("Mon Jan 2 15:04"))
+	} else {
+		subject = fmt.Sprintf("Invitation: %s", reservation.Title)
+		body = fmt.Sprintf(
+			"Hello %s,\n\n%s has invited you to \"%s\" on %s at %s.\n\nPlease show your guest pass at reception when you arrive, and let your host know there if you cannot make it:\n%s\n\nQR code: %s",
+			guest.Name, reservation.User.GetFullName(), reservation.Title,
+			reservation.StartTime.Format("Mon Jan 2 15:04"), location,
+			s.passURL(guest.PassToken), s.qrCodeURL(guest.PassToken),
+		)
+
+		if png, err := qrcode.Encode(s.passURL(guest.PassToken), qrcode.Medium, guestPassQRSize); err == nil {
+			attachments = append(attachments, MailAttachment{Filename: "guest-pass.png", ContentType: "image/png", Data: png})
+		}
+	}
+	attachments = append(attachments, MailAttachment{
+		Filename:    "invite.ics",
+		ContentType: "text/calendar; charset=UTF-8; method=" + method,
+		Data:        invite.Bytes(),
+	})
+
+	if err := s.mailer.SendWithAttachments(guest.Email, subject, body, attachments); err != nil {
+		s.logger.Warn("Guest invite delivery failed", "guestID", guest.ID, "method", method, "error", err)
+		return false
+	}
+
+	if method == utils.CalendarMethodRequest {
+		now := time.Now()
+		s.guestRepo.Update(guest.ID, map[string]interface{}{"invite_sent_at": now})
+		guest.InviteSentAt = &now
+	}
+	return true
+}
+
+// toGuestResponse converts a guest to the host-facing response
+func (s *ReservationGuestService) toGuestResponse(guest *models.ReservationGuest) dto.ReservationGuestResponse {
+	return dto.ReservationGuestResponse{
+		ID:            guest.ID,
+		ReservationID: guest.ReservationID,
+		Name:          guest.Name,
+		Email:         guest.Email,
+		Company:       guest.Company,
+		PassURL:       s.passURL(guest.PassToken),
+		QRCodeURL:     s.qrCodeURL(guest.PassToken),
+		InviteSentAt:  guest.InviteSentAt,
+		RSVPStatus:    string(guest.RSVPStatus),
+		RespondedAt:   guest.RespondedAt,
+		ArrivedAt:     guest.ArrivedAt,
+		CreatedAt:     guest.CreatedAt,
+	}
+}
+
+func (s *ReservationGuestService) passURL(token string) string {
+	return fmt.Sprintf("%s/api/v1/guest-passes/%s", s.baseURL, token)
+}
+
+func (s *ReservationGuestService) qrCodeURL(token string) string {
+	return s.passURL(token) + "/qr"
+}
+
+// generateGuestPassToken creates a random URL-safe token
+func generateGuestPassToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
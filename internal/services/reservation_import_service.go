@@ -0,0 +1,501 @@
+// internal/services/reservation_import_service.go
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/breaker"
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	maxImportRows      = 500
+	maxImportSheetSize = 5 << 20 // 5MB
+	sheetFetchTimeout  = 15 * time.Second
+)
+
+var (
+	sheetIDPattern  = regexp.MustCompile(`/spreadsheets/d/([a-zA-Z0-9-_]+)`)
+	sheetGIDPattern = regexp.MustCompile(`[#&?]gid=([0-9]+)`)
+)
+
+// importTimeLayouts lists the date formats accepted in imported sheets
+var importTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"02/01/2006 15:04",
+}
+
+// ReservationImportService handles bulk reservation imports from external sources
+type ReservationImportService struct {
+	reservationService *ReservationService
+	reservationRepo    interfaces.ReservationRepositoryInterface
+	spaceRepo          interfaces.SpaceRepositoryInterface
+	userRepo           interfaces.UserRepositoryInterface
+	httpClient         *http.Client
+	sheetsBreaker      *breaker.Breaker
+}
+
+// NewReservationImportService creates a new reservation import service
+func NewReservationImportService(
+	reservationService *ReservationService,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	breakers *breaker.Registry,
+) *ReservationImportService {
+	return &ReservationImportService{
+		reservationService: reservationService,
+		reservationRepo:    reservationRepo,
+		spaceRepo:          spaceRepo,
+		userRepo:           userRepo,
+		httpClient:         &http.Client{Timeout: sheetFetchTimeout},
+		sheetsBreaker:      breakers.Get("google-sheets", sheetFetchTimeout),
+	}
+}
+
+// importRow is a parsed sheet row ready to be turned into a reservation
+type importRow struct {
+	result  dto.SheetImportRowResult
+	request *dto.CreateReservationRequest
+	space   *models.Space
+	ownerID uuid.UUID // Booker of a CSV row
+}
+
+// ========================================
+// GOOGLE SHEETS IMPORT
+// ========================================
+
+// PreviewSheetImport fetches the sheet and reports which rows can be booked
+func (s *ReservationImportService) PreviewSheetImport(req *dto.SheetImportRequest) (*dto.SheetImportResponse, error) {
+	sheetID, rows, err := s.loadSheetRows(req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.SheetImportResponse{
+		SheetID:   sheetID,
+		TotalRows: len(rows),
+	}
+
+	for _, row := range rows {
+		s.tallyRow(response, row.result.Status)
+		response.Rows = append(response.Rows, row.result)
+	}
+
+	return response, nil
+}
+
+// CommitSheetImport creates reservations for the approved rows of the sheet
+func (s *ReservationImportService) CommitSheetImport(req *dto.SheetImportRequest, userID uuid.UUID) (*dto.SheetImportResponse, error) {
+	if len(req.ApprovedRows) == 0 {
+		return nil, errors.New("at least one approved row is required")
+	}
+
+	// Re-read the sheet so we commit exactly what is currently shared
+	sheetID, rows, err := s.loadSheetRows(req)
+	if err != nil {
+		return nil, err
+	}
+
+	approved := make(map[int]bool, len(req.ApprovedRows))
+	for _, rowNumber := range req.ApprovedRows {
+		approved[rowNumber] = true
+	}
+
+	response := &dto.SheetImportResponse{
+		SheetID:   sheetID,
+		TotalRows: len(rows),
+	}
+
+	for _, row := range rows {
+		result := row.result
+
+		switch {
+		case !approved[result.RowNumber]:
+			result.Status = "skipped"
+		case result.Status != "ready":
+			result.Status = "failed"
+		default:
+			reservation, err := s.reservationService.CreateReservation(row.request, userID)
+			if err != nil {
+				result.Status = "failed"
+				result.Errors = append(result.Errors, err.Error())
+			} else {
+				result.Status = "created"
+				result.ReservationID = &reservation.ID
+				response.CreatedCount++
+			}
+		}
+
+		s.tallyRow(response, row.result.Status)
+		response.Rows = append(response.Rows, result)
+	}
+
+	return response, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// loadSheetRows downloads the sheet as CSV and validates every row
+func (s *ReservationImportService) loadSheetRows(req *dto.SheetImportRequest) (string, []*importRow, error) {
+	if err := req.Validate(); err != nil {
+		return "", nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	location := time.Local
+	if req.Timezone != "" {
+		location, _ = time.LoadLocation(req.Timezone)
+	}
+
+	mapping := req.ColumnMapping
+	mapping.SetDefaults()
+
+	sheetID, exportURL, err := buildSheetExportURL(req.SheetURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	records, err := s.fetchSheetCSV(exportURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(records) < 2 {
+		return "", nil, errors.New("sheet has no data rows")
+	}
+	if len(records)-1 > maxImportRows {
+		return "", nil, fmt.Errorf("sheet exceeds the maximum of %d rows", maxImportRows)
+	}
+
+	columns, err := resolveImportColumns(records[0], &mapping)
+	if err != nil {
+		return "", nil, err
+	}
+
+	spaces, err := s.loadSpaceLookup()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var rows []*importRow
+	for i, record := range records[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+
+		// Row numbers match the sheet UI, where the header is row 1
+		row := s.parseRow(i+2, record, columns, spaces, location)
+		if row.result.Status == "ready" {
+			s.checkRowConflicts(row, rows)
+		}
+		rows = append(rows, row)
+	}
+
+	return sheetID, rows, nil
+}
+
+// sheetDownload is the outcome of a sheet export request that reached Google.
+// Problems with the sheet itself are kept apart so they do not trip the breaker.
+type sheetDownload struct {
+	records [][]string
+	err     error
+}
+
+// fetchSheetCSV downloads a sheet export using the link-sharing (read-only) URL
+func (s *ReservationImportService) fetchSheetCSV(exportURL string) ([][]string, error) {
+	download, err := breaker.Do(context.Background(), s.sheetsBreaker, func(ctx context.Context) (*sheetDownload, error) {
+		return s.downloadSheet(ctx, exportURL)
+	})
+	if errors.Is(err, breaker.ErrOpen) {
+		return nil, errors.New("failed to fetch sheet: Google Sheets is not responding, try again in a few minutes")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sheet: %w", err)
+	}
+	if download.err != nil {
+		return nil, download.err
+	}
+	return download.records, nil
+}
+
+// downloadSheet requests the export, returning an error only when Google could not serve it
+func (s *ReservationImportService) downloadSheet(ctx context.Context, exportURL string) (*sheetDownload, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, exportURL, nil)
+	if err != nil {
+		return &sheetDownload{err: fmt.Errorf("failed to fetch sheet: %w", err)}, nil
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &sheetDownload{err: fmt.Errorf("failed to fetch sheet: unexpected status %d (is the sheet shared with anyone who has the link?)", resp.StatusCode)}, nil
+	}
+
+	// A private sheet redirects to the Google login page instead of returning CSV
+	if contentType := resp.Header.Get("Content-Type"); strings.Contains(contentType, "text/html") {
+		return &sheetDownload{err: errors.New("sheet is not publicly readable; share it with anyone who has the link")}, nil
+	}
+
+	reader := csv.NewReader(io.LimitReader(resp.Body, maxImportSheetSize))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return &sheetDownload{err: fmt.Errorf("failed to parse sheet: %w", err)}, nil
+	}
+
+	return &sheetDownload{records: records}, nil
+}
+
+// loadSpaceLookup indexes spaces by ID and lowercased name
+func (s *ReservationImportService) loadSpaceLookup() (map[string]*models.Space, error) {
+	spaces, _, err := s.spaceRepo.GetAll(nil, 0, 1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spaces: %w", err)
+	}
+
+	lookup := make(map[string]*models.Space, len(spaces)*2)
+	for _, space := range spaces {
+		lookup[space.ID.String()] = space
+		lookup[strings.ToLower(strings.TrimSpace(space.Name))] = space
+	}
+
+	return lookup, nil
+}
+
+// parseRow converts a CSV record into a reservation request
+func (s *ReservationImportService) parseRow(rowNumber int, record []string, columns map[string]int, spaces map[string]*models.Space, location *time.Location) *importRow {
+	row := &importRow{
+		result: dto.SheetImportRowResult{RowNumber: rowNumber},
+	}
+	result := &row.result
+
+	value := func(field string) string {
+		index, ok := columns[field]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	spaceValue := value("space")
+	space, ok := spaces[strings.ToLower(spaceValue)]
+	if !ok {
+		result.Errors = append(result.Errors, fmt.Sprintf("unknown space %q", spaceValue))
+	} else {
+		row.space = space
+		result.SpaceID = &space.ID
+		result.SpaceName = space.Name
+	}
+
+	startTime, err := parseImportTime(value("start_time"), location)
+	if err != nil {
+		result.Errors = append(result.Errors, "invalid start time: "+err.Error())
+	} else {
+		result.StartTime = &startTime
+	}
+
+	endTime, err := parseImportTime(value("end_time"), location)
+	if err != nil {
+		result.Errors = append(result.Errors, "invalid end time: "+err.Error())
+	} else {
+		result.EndTime = &endTime
+	}
+
+	result.Title = value("title")
+	if len(result.Title) < 2 {
+		result.Errors = append(result.Errors, "title must be at least 2 characters")
+	}
+
+	participants := value("participant_count")
+	if participants == "" {
+		result.ParticipantCount = 1
+	} else if count, err := strconv.Atoi(participants); err != nil || count < 1 {
+		result.Errors = append(result.Errors, "participant count must be a positive number")
+	} else {
+		result.ParticipantCount = count
+	}
+
+	if len(result.Errors) > 0 {
+		result.Status = "invalid"
+		return row
+	}
+
+	row.request = &dto.CreateReservationRequest{
+		SpaceID:          space.ID,
+		StartTime:        startTime,
+		EndTime:          endTime,
+		ParticipantCount: result.ParticipantCount,
+		Title:            result.Title,
+		Description:      value("description"),
+	}
+
+	if err := row.request.Validate(); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+	if !space.IsAvailable() {
+		result.Errors = append(result.Errors, "space is not available for booking")
+	}
+	if result.ParticipantCount > space.Capacity {
+		result.Errors = append(result.Errors, fmt.Sprintf("participant count (%d) exceeds space capacity (%d)", result.ParticipantCount, space.Capacity))
+	}
+
+	result.Status = "ready"
+	if len(result.Errors) > 0 {
+		result.Status = "invalid"
+	}
+
+	return row
+}
+
+// checkRowConflicts flags rows that clash with existing bookings or earlier rows in the sheet
+func (s *ReservationImportService) checkRowConflicts(row *importRow, previous []*importRow) {
+	req := row.request
+
+	conflicts, err := s.reservationRepo.GetConflictingReservations(req.SpaceID, req.StartTime, req.EndTime)
+	if err != nil {
+		row.result.Errors = append(row.result.Errors, "failed to check availability: "+err.Error())
+		row.result.Status = "invalid"
+		return
+	}
+
+	for _, conflict := range conflicts {
+		userName := conflict.User.FirstName + " " + conflict.User.LastName
+		if userName == " " {
+			userName = conflict.User.Email
+		}
+		row.result.Conflicts = append(row.result.Conflicts, dto.ReservationConflict{
+			ReservationID: conflict.ID,
+			Title:         conflict.Title,
+			StartTime:     conflict.StartTime,
+			EndTime:       conflict.EndTime,
+			UserName:      userName,
+			Status:        string(conflict.Status),
+		})
+	}
+
+	for _, other := range previous {
+		if other.result.Status != "ready" || other.request.SpaceID != req.SpaceID {
+			continue
+		}
+		if req.StartTime.Before(other.request.EndTime) && req.EndTime.After(other.request.StartTime) {
+			row.result.Errors = append(row.result.Errors, fmt.Sprintf("overlaps with row %d", other.result.RowNumber))
+		}
+	}
+
+	if len(row.result.Conflicts) > 0 || len(row.result.Errors) > 0 {
+		row.result.Status = "conflict"
+	}
+}
+
+// tallyRow updates the preview counters for a row status
+func (s *ReservationImportService) tallyRow(response *dto.SheetImportResponse, status string) {
+	switch status {
+	case "ready":
+		response.ReadyCount++
+	case "conflict":
+		response.ConflictCount++
+	case "invalid":
+		response.InvalidCount++
+	}
+}
+
+// buildSheetExportURL turns a shared sheet link into its CSV export URL
+func buildSheetExportURL(sheetURL string) (string, string, error) {
+	match := sheetIDPattern.FindStringSubmatch(sheetURL)
+	if match == nil {
+		return "", "", errors.New("could not find a spreadsheet ID in the sheet URL")
+	}
+
+	sheetID := match[1]
+	exportURL := fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s/export?format=csv", sheetID)
+	if gid := sheetGIDPattern.FindStringSubmatch(sheetURL); gid != nil {
+		exportURL += "&gid=" + gid[1]
+	}
+
+	return sheetID, exportURL, nil
+}
+
+// resolveImportColumns maps each reservation field to its column index
+func resolveImportColumns(header []string, mapping *dto.SheetColumnMapping) (map[string]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	fields := map[string]string{
+		"space":             mapping.Space,
+		"start_time":        mapping.StartTime,
+		"end_time":          mapping.EndTime,
+		"title":             mapping.Title,
+		"participant_count": mapping.ParticipantCount,
+		"description":       mapping.Description,
+	}
+	required := []string{"space", "start_time", "end_time", "title"}
+
+	columns := make(map[string]int, len(fields))
+	for field, headerName := range fields {
+		if index, ok := positions[strings.ToLower(strings.TrimSpace(headerName))]; ok {
+			columns[field] = index
+		}
+	}
+
+	for _, field := range required {
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("sheet is missing the %q column (mapped to %s)", fields[field], field)
+		}
+	}
+
+	return columns, nil
+}
+
+// parseImportTime parses a sheet cell using the accepted layouts
+func parseImportTime(value string, location *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("value is empty")
+	}
+
+	for _, layout := range importTimeLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, location); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized date format %q", value)
+}
+
+// isBlankRecord reports whether every cell of a record is empty
+func isBlankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,224 @@
+// internal/services/reservation_insights.go
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+)
+
+// Codes of booking suggestions
+const (
+	SuggestionOversizedRooms        = "OVERSIZED_ROOMS"
+	SuggestionLateCheckIns          = "LATE_CHECK_INS"
+	SuggestionNoShows               = "NO_SHOWS"
+	SuggestionFrequentCancellations = "FREQUENT_CANCELLATIONS"
+)
+
+const (
+	defaultInsightDays = 90
+	// Favorite spaces listed
+	maxFavoriteSpaces = 5
+	// A check-in this late after the start still counts as on time
+	onTimeCheckInGrace = 5 * time.Minute
+	// Reservations a habit must show up in before it is worth a suggestion
+	minSuggestionSample = 3
+)
+
+// GetBookingInsights describes how the user booked over the past days: favorite spaces, meeting
+// length, punctuality, busiest weekdays and suggestions drawn from these habits
+func (s *ReservationService) GetBookingInsights(userID uuid.UUID, req *dto.BookingInsightsRequest) (*dto.BookingInsightsResponse, error) {
+	days := req.Days
+	if days == 0 {
+		days = defaultInsightDays
+	}
+	location := time.UTC
+	if req.Timezone != "" {
+		location, _ = time.LoadLocation(req.Timezone) // Checked by the request validation
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+	reservations, err := s.reservationRepo.GetUserReservationsStartingBetween(userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservations: %w", err)
+	}
+
+	insights := &dto.BookingInsightsResponse{
+		From:           from.UTC(),
+		To:             to.UTC(),
+		Timezone:       location.String(),
+		FavoriteSpaces: []dto.FavoriteSpace{},
+		Weekdays:       []dto.WeekdayBookings{},
+		Suggestions:    []dto.BookingSuggestion{},
+	}
+
+	var held []*models.Reservation
+	for _, reservation := range reservations {
+		switch reservation.Status {
+		case models.StatusCancelled:
+			insights.Cancelled++
+		case models.StatusRejected:
+		default:
+			held = append(held, reservation)
+		}
+	}
+	insights.Reservations = len(held)
+	if booked := insights.Reservations + insights.Cancelled; booked > 0 {
+		insights.CancellationPercent = int(math.Round(100 * float64(insights.Cancelled) / float64(booked)))
+	}
+	if len(held) == 0 {
+		return insights, nil
+	}
+
+	var minutes, participants float64
+	var weekdays [7]int
+	var hours [24]int
+	bySpace := make(map[uuid.UUID]*dto.FavoriteSpace)
+	for _, reservation := range held {
+		minutes += reservation.EndTime.Sub(reservation.StartTime).Minutes()
+		participants += float64(reservation.ParticipantCount)
+
+		local := reservation.StartTime.In(location)
+		weekdays[local.Weekday()]++
+		hours[local.Hour()]++
+
+		favorite, ok := bySpace[reservation.SpaceID]
+		if !ok {
+			favorite = &dto.FavoriteSpace{
+				SpaceID:  reservation.SpaceID,
+				Name:     reservation.Space.Name,
+				Building: reservation.Space.Building,
+			}
+			bySpace[reservation.SpaceID] = favorite
+		}
+		favorite.Reservations++
+	}
+
+	insights.AverageDurationMinutes = int(math.Round(minutes / float64(len(held))))
+	insights.AverageParticipants = math.Round(participants/float64(len(held))*10) / 10
+	insights.FavoriteSpaces = favoriteSpaces(bySpace, len(held))
+	insights.Punctuality = punctuality(held)
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if weekdays[weekday] > 0 {
+			insights.Weekdays = append(insights.Weekdays, dto.WeekdayBookings{Weekday: weekday.String(), Reservations: weekdays[weekday]})
+		}
+	}
+	sort.SliceStable(insights.Weekdays, func(i, j int) bool {
+		return insights.Weekdays[i].Reservations > insights.Weekdays[j].Reservations
+	})
+
+	busiest := 0
+	for hour := range hours {
+		if hours[hour] > hours[busiest] {
+			busiest = hour
+		}
+	}
+	insights.BusiestHour = &busiest
+
+	insights.Suggestions = bookingSuggestions(held, insights)
+	return insights, nil
+}
+
+// favoriteSpaces ranks the spaces by reservations, keeping the most booked ones
+func favoriteSpaces(bySpace map[uuid.UUID]*dto.FavoriteSpace, total int) []dto.FavoriteSpace {
+	favorites := make([]dto.FavoriteSpace, 0, len(bySpace))
+	for _, favorite := range bySpace {
+		favorite.SharePercent = int(math.Round(100 * float64(favorite.Reservations) / float64(total)))
+		favorites = append(favorites, *favorite)
+	}
+	sort.Slice(favorites, func(i, j int) bool {
+		if favorites[i].Reservations != favorites[j].Reservations {
+			return favorites[i].Reservations > favorites[j].Reservations
+		}
+		return favorites[i].Name < favorites[j].Name
+	})
+	if len(favorites) > maxFavoriteSpaces {
+		favorites = favorites[:maxFavoriteSpaces]
+	}
+	return favorites
+}
+
+// punctuality measures check-in delays; checking in early counts as no delay
+func punctuality(reservations []*models.Reservation) dto.PunctualityInsight {
+	var insight dto.PunctualityInsight
+	var delay time.Duration
+	onTime := 0
+	for _, reservation := range reservations {
+		if reservation.NoShowReported {
+			insight.NoShows++
+		}
+		if reservation.CheckInTime == nil {
+			continue
+		}
+
+		insight.CheckedIn++
+		late := reservation.CheckInTime.Sub(reservation.StartTime)
+		if late <= onTimeCheckInGrace {
+			onTime++
+		}
+		if late > 0 {
+			delay += late
+		}
+	}
+
+	if insight.CheckedIn > 0 {
+		insight.OnTimePercent = int(math.Round(100 * float64(onTime) / float64(insight.CheckedIn)))
+		insight.AverageCheckInDelayMinutes = math.Round(delay.Minutes()/float64(insight.CheckedIn)*10) / 10
+	}
+	return insight
+}
+
+// bookingSuggestions turns the habits that waste space or time into advice
+func bookingSuggestions(reservations []*models.Reservation, insights *dto.BookingInsightsResponse) []dto.BookingSuggestion {
+	suggestions := []dto.BookingSuggestion{}
+
+	// Rooms at least twice the size of the meeting, with several seats to spare
+	oversized, capacity, attendees := 0, 0, 0
+	for _, reservation := range reservations {
+		if reservation.Space.Capacity >= 2*reservation.ParticipantCount && reservation.Space.Capacity-reservation.ParticipantCount >= 4 {
+			oversized++
+			capacity += reservation.Space.Capacity
+			attendees += reservation.ParticipantCount
+		}
+	}
+	if oversized >= minSuggestionSample && oversized*3 >= len(reservations) {
+		suggestions = append(suggestions, dto.BookingSuggestion{
+			Code: SuggestionOversizedRooms,
+			Message: fmt.Sprintf("You often book %d-person rooms for %d attendees (%d of %d reservations); a smaller room leaves the larger ones to bigger meetings.",
+				int(math.Round(float64(capacity)/float64(oversized))), int(math.Round(float64(attendees)/float64(oversized))), oversized, len(reservations)),
+		})
+	}
+
+	punctuality := insights.Punctuality
+	if punctuality.CheckedIn >= minSuggestionSample && punctuality.AverageCheckInDelayMinutes >= 10 {
+		suggestions = append(suggestions, dto.BookingSuggestion{
+			Code: SuggestionLateCheckIns,
+			Message: fmt.Sprintf("You check in %.0f minutes after the start on average; booking a later start would free the room for others in the meantime.",
+				punctuality.AverageCheckInDelayMinutes),
+		})
+	}
+
+	if punctuality.NoShows >= 2 {
+		suggestions = append(suggestions, dto.BookingSuggestion{
+			Code:    SuggestionNoShows,
+			Message: fmt.Sprintf("%d of your reservations were no-shows; cancel the ones you will not use so others can book the room.", punctuality.NoShows),
+		})
+	}
+
+	if insights.Cancelled >= minSuggestionSample && insights.CancellationPercent >= 30 {
+		suggestions = append(suggestions, dto.BookingSuggestion{
+			Code:    SuggestionFrequentCancellations,
+			Message: fmt.Sprintf("You cancel %d%% of your reservations; booking once plans are settled keeps rooms available for colleagues.", insights.CancellationPercent),
+		})
+	}
+
+	return suggestions
+}
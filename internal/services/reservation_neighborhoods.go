@@ -0,0 +1,64 @@
+// internal/services/reservation_neighborhoods.go
+package services
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+)
+
+// checkNeighborhood rejects booking a desk in a neighborhood the user's team may not book.
+// Admins may book any desk.
+func (s *ReservationService) checkNeighborhood(space *models.Space, userID uuid.UUID) error {
+	if models.ResourceClassOf(space.Type) != models.ResourceClassDesk {
+		return nil
+	}
+
+	restricted, err := s.restrictedDesks([]*models.Space{space}, userID)
+	if err != nil {
+		return err
+	}
+	if neighborhood := restricted[space.ID]; neighborhood != nil {
+		return dto.NewNeighborhoodRestrictedError(space.Name, neighborhood.Name, neighborhood.Departments)
+	}
+	return nil
+}
+
+// restrictedDesks maps the desks among the spaces that the user may not book to their neighborhood
+func (s *ReservationService) restrictedDesks(spaces []*models.Space, userID uuid.UUID) (map[uuid.UUID]*models.Neighborhood, error) {
+	var deskIDs []uuid.UUID
+	for _, space := range spaces {
+		if models.ResourceClassOf(space.Type) == models.ResourceClassDesk {
+			deskIDs = append(deskIDs, space.ID)
+		}
+	}
+	restricted := make(map[uuid.UUID]*models.Neighborhood)
+	if len(deskIDs) == 0 {
+		return restricted, nil
+	}
+
+	neighborhoods, err := s.floorPlanRepo.GetNeighborhoodsBySpaces(deskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get neighborhoods: %w", err)
+	}
+	if len(neighborhoods) == 0 {
+		return restricted, nil
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsAdmin() {
+		return restricted, nil
+	}
+	for spaceID, neighborhood := range neighborhoods {
+		if !neighborhood.AllowsDepartment(user.Department) {
+			restricted[spaceID] = neighborhood
+		}
+	}
+	return restricted, nil
+}
@@ -0,0 +1,124 @@
+// internal/services/reservation_placement.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/models"
+)
+
+const (
+	// Spaces up to this many times the capacity of the smallest fitting one are
+	// interchangeable; bigger ones are kept for bigger meetings
+	placementSizeSlack = 2
+	// Period over which balance_wear compares how much spaces were used
+	placementWearWindow = 30 * 24 * time.Hour
+)
+
+// normalizePlacementStrategy falls back to smallest_fit for unknown strategies
+func normalizePlacementStrategy(value string) models.PlacementStrategy {
+	strategy := models.PlacementStrategy(value)
+	if !strategy.IsValid() {
+		return models.PlacementSmallestFit
+	}
+	return strategy
+}
+
+// placementStrategy returns the strategy of the building, or the default when it has no policy
+func (s *ReservationService) placementStrategy(building string) (models.PlacementStrategy, error) {
+	policy, err := s.placementRepo.GetByBuilding(building)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return s.defaultPlacement, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get placement policy: %w", err)
+	}
+	return policy.Strategy, nil
+}
+
+// placeSpace chooses the space of a booking made for "any suitable room" among free candidates
+// that all fit it. The preferred floor only matters to smallest_fit; the other strategies
+// place bookings for the building's sake.
+func (s *ReservationService) placeSpace(building string, candidates []*models.Space, preferredFloor *int) (*models.Space, error) {
+	strategy, err := s.placementStrategy(building)
+	if err != nil {
+		return nil, err
+	}
+
+	if strategy == models.PlacementSmallestFit {
+		best := candidates[0]
+		for _, space := range candidates[1:] {
+			if preferredSpaceLess(space, best, preferredFloor) {
+				best = space
+			}
+		}
+		return best, nil
+	}
+
+	smallest := candidates[0].Capacity
+	for _, space := range candidates {
+		if space.Capacity < smallest {
+			smallest = space.Capacity
+		}
+	}
+	var equivalent []*models.Space
+	for _, space := range candidates {
+		if space.Capacity <= smallest*placementSizeSlack {
+			equivalent = append(equivalent, space)
+		}
+	}
+
+	var wear map[uuid.UUID]float64
+	if strategy == models.PlacementBalanceWear {
+		spaceIDs := make([]uuid.UUID, len(equivalent))
+		for i, space := range equivalent {
+			spaceIDs[i] = space.ID
+		}
+		wear, err = s.reservationRepo.GetBookedMinutesBySpace(spaceIDs, time.Now().Add(-placementWearWindow))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get space usage: %w", err)
+		}
+	}
+
+	sort.SliceStable(equivalent, func(i, j int) bool {
+		return placementLess(strategy, equivalent[i], equivalent[j], wear)
+	})
+	return equivalent[0], nil
+}
+
+// placementLess orders spaces by the strategy, then smallest and by name so placement is stable
+func placementLess(strategy models.PlacementStrategy, a, b *models.Space, wear map[uuid.UUID]float64) bool {
+	switch strategy {
+	case models.PlacementFillFloors:
+		if a.Floor != b.Floor {
+			return a.Floor < b.Floor
+		}
+	case models.PlacementBalanceWear:
+		if wear[a.ID] != wear[b.ID] {
+			return wear[a.ID] < wear[b.ID]
+		}
+	case models.PlacementLowEnergy:
+		if energyRank(a) != energyRank(b) {
+			return energyRank(a) < energyRank(b)
+		}
+	}
+
+	if a.Capacity != b.Capacity {
+		return a.Capacity < b.Capacity
+	}
+	return a.Name < b.Name
+}
+
+// energyRank ranks energy ratings from A = 0; unrated spaces come last
+func energyRank(space *models.Space) int {
+	if space.EnergyRating == "" {
+		return 7
+	}
+	return int(space.EnergyRating[0] - 'A')
+}
@@ -0,0 +1,96 @@
+// internal/services/reservation_preemption_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// ReservationPreemptionService tells organizers when their pending booking was displaced by a
+// higher-priority one, and reports preemptions for auditing. Preemptions themselves happen when
+// booking, see ReservationService.CreateReservation.
+type ReservationPreemptionService struct {
+	preemptionRepo      interfaces.ReservationPreemptionRepositoryInterface
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	notificationService *NotificationService
+}
+
+// NewReservationPreemptionService creates a new reservation preemption service
+func NewReservationPreemptionService(
+	preemptionRepo interfaces.ReservationPreemptionRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	notificationService *NotificationService,
+) *ReservationPreemptionService {
+	return &ReservationPreemptionService{
+		preemptionRepo:      preemptionRepo,
+		reservationRepo:     reservationRepo,
+		notificationService: notificationService,
+	}
+}
+
+// NotifyDisplaced tells the organizers of a preempted reservation it was cancelled, with the
+// spaces free for the same slot to rebook. It handles reservation_preempted events of the outbox.
+func (s *ReservationPreemptionService) NotifyDisplaced(ctx context.Context, event *models.OutboxEvent) error {
+	var preemption models.ReservationPreemption
+	if err := json.Unmarshal(event.Payload, &preemption); err != nil {
+		return fmt.Errorf("invalid reservation preemption payload: %w", err)
+	}
+	var suggestions []models.RebookingSuggestion
+	if len(preemption.Suggestions) > 0 {
+		if err := json.Unmarshal(preemption.Suggestions, &suggestions); err != nil {
+			return fmt.Errorf("invalid preemption suggestions: %w", err)
+		}
+	}
+
+	reservation, err := s.reservationRepo.GetByID(preemption.ReservationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	message := fmt.Sprintf("Your pending reservation \"%s\" in %s on %s was cancelled to make room for a higher-priority booking. %s",
+		reservation.Title, reservation.Space.Name, reservation.StartTime.Format("Jan 2, 2006 15:04"), rebookingSentence(suggestions))
+
+	return s.notificationService.NotifyOrganizers(reservation, models.NotificationTypeReservationPreempted,
+		"Reservation preempted", message,
+		map[string]interface{}{
+			"reservation_id": reservation.ID,
+			"space_id":       reservation.SpaceID,
+			"start_time":     reservation.StartTime,
+			"end_time":       reservation.EndTime,
+			"suggestions":    suggestions,
+		})
+}
+
+// GetReport lists the reservations preempted in a period, for auditing
+func (s *ReservationPreemptionService) GetReport(startDate, endDate time.Time, spaceID *uuid.UUID, page, limit int) (*dto.PreemptionReport, error) {
+	if !endDate.After(startDate) {
+		return nil, errors.New("end date must be after start date")
+	}
+
+	preemptions, total, err := s.preemptionRepo.GetPreemptions(startDate, endDate, spaceID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preemptions: %w", err)
+	}
+
+	return &dto.PreemptionReport{
+		StartDate:        startDate,
+		EndDate:          endDate,
+		TotalPreemptions: total,
+		Preemptions:      preemptions,
+		Page:             page,
+		Limit:            limit,
+	}, nil
+}
@@ -0,0 +1,87 @@
+// internal/services/reservation_resources.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+)
+
+// loadAddOnResources loads the equipment to book together with the space. Add-ons must be
+// equipment in the same building, since they are brought to the space for the reservation.
+func (s *ReservationService) loadAddOnResources(space *models.Space, resourceIDs []uuid.UUID) ([]*models.Space, error) {
+	var resources []*models.Space
+	for _, resourceID := range resourceIDs {
+		if resourceID == space.ID {
+			return nil, errors.New("the booked space cannot also be booked as an add-on")
+		}
+
+		resource, err := s.spaceRepo.GetByID(resourceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resource: %w", err)
+		}
+		if models.ResourceClassOf(resource.Type) != models.ResourceClassEquipment || resource.Building != space.Building {
+			return nil, dto.NewResourceNotAddOnError(resource.Name, space.Building)
+		}
+		if !resource.IsAvailable() {
+			return nil, fmt.Errorf("%s is not available for booking", resource.Name)
+		}
+
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// checkResourcesFree checks no add-on is booked during the slot, on its own or with another space
+func (s *ReservationService) checkResourcesFree(resources []*models.Space, startTime, endTime time.Time, excludeReservationID *uuid.UUID) error {
+	for _, resource := range resources {
+		available, err := s.reservationRepo.CheckTimeSlotAvailability(resource.ID, startTime, endTime, excludeReservationID)
+		if err != nil {
+			return fmt.Errorf("failed to check resource availability: %w", err)
+		}
+		if !available {
+			return dto.NewResourceBookedError(resource.Name)
+		}
+	}
+	return nil
+}
+
+// bookedResources returns the add-ons already booked with the reservation
+func bookedResources(reservation *models.Reservation) []*models.Space {
+	var resources []*models.Space
+	for _, booked := range reservation.Resources {
+		if booked.Resource != nil {
+			resources = append(resources, booked.Resource)
+		}
+	}
+	return resources
+}
+
+// reservationResources links the add-ons to a reservation that is about to be created
+func reservationResources(resources []*models.Space) []models.ReservationResource {
+	var links []models.ReservationResource
+	for _, resource := range resources {
+		links = append(links, models.ReservationResource{ResourceID: resource.ID})
+	}
+	return links
+}
+
+// licensePlateFor normalizes the license plate kept with a parking booking; spaces
+// other than parking spots never keep one
+func licensePlateFor(space *models.Space, licensePlate string) (string, error) {
+	if models.ResourceClassOf(space.Type) != models.ResourceClassParking {
+		return "", nil
+	}
+
+	licensePlate = strings.ToUpper(strings.Join(strings.Fields(licensePlate), " "))
+	if licensePlate == "" && space.GetAttributes().RequiresLicensePlate {
+		return "", dto.ErrLicensePlateRequired
+	}
+	return licensePlate, nil
+}
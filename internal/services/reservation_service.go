@@ -5,21 +5,60 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/datatypes"
+	"gorm.io/gorm"
 
 	"room-reservation-api/internal/dto"
 	"room-reservation-api/internal/models"
 	"room-reservation-api/internal/repositories/interfaces"
 )
 
+// Policies for users booking overlapping reservations in different rooms
+const (
+	DuplicateBookingPolicyWarn  = "warn"
+	DuplicateBookingPolicyBlock = "block"
+)
+
+// Shortest walk-up booking worth creating
+const bookNowMinDuration = 15 * time.Minute
+
+// Upper bound of spaces considered when picking one for "any suitable room"
+const suitableSpaceCandidates = 500
+
+// Least precise GPS fix accepted as proof of presence, in meters
+const maxCheckInLocationAccuracy = 100
+
 // ReservationService handles all reservation business logic
 type ReservationService struct {
-	reservationRepo interfaces.ReservationRepositoryInterface
-	spaceRepo       interfaces.SpaceRepositoryInterface
-	userRepo        interfaces.UserRepositoryInterface
+	reservationRepo        interfaces.ReservationRepositoryInterface
+	spaceRepo              interfaces.SpaceRepositoryInterface
+	userRepo               interfaces.UserRepositoryInterface
+	vipBlockRepo           interfaces.VIPBlockRepositoryInterface
+	conflictRepo           interfaces.BookingConflictRepositoryInterface
+	questionnaireRepo      interfaces.CheckInQuestionnaireRepositoryInterface
+	preferenceRepo         interfaces.UserPreferenceRepositoryInterface
+	consolidationRepo      interfaces.FloorConsolidationRepositoryInterface
+	placementRepo          interfaces.PlacementPolicyRepositoryInterface
+	floorPlanRepo          interfaces.FloorPlanRepositoryInterface
+	checklistRepo          interfaces.ReservationChecklistRepositoryInterface
+	approvalRuleRepo       interfaces.ApprovalRuleRepositoryInterface
+	costCenterRepo         interfaces.CostCenterRepositoryInterface
+	feedbackRepo           interfaces.FeedbackRepositoryInterface
+	sessionRepo            interfaces.ReservationSessionRepositoryInterface
+	closureRepo            interfaces.BuildingClosureRepositoryInterface
+	duplicateBookingPolicy string
+	defaultPlacement       models.PlacementStrategy // For buildings without a placement policy
+	holidays               *HolidayCalendar         // Optional, nil keeps bookings open on public holidays
+	calendar               *WorkingCalendarService  // Optional, nil ignores regional holidays and closure days
+	quotas                 *BookingQuotaService     // Optional, nil disables booking quotas
+	strikes                *BookingStrikeService    // Optional, nil disables strikes for late cancellations and no-shows
+	events                 *OutboxDispatcher        // Optional, nil leaves committed events to the dispatch job
 }
 
 // NewReservationService creates a new reservation service
@@ -27,11 +66,55 @@ func NewReservationService(
 	reservationRepo interfaces.ReservationRepositoryInterface,
 	spaceRepo interfaces.SpaceRepositoryInterface,
 	userRepo interfaces.UserRepositoryInterface,
+	vipBlockRepo interfaces.VIPBlockRepositoryInterface,
+	conflictRepo interfaces.BookingConflictRepositoryInterface,
+	questionnaireRepo interfaces.CheckInQuestionnaireRepositoryInterface,
+	preferenceRepo interfaces.UserPreferenceRepositoryInterface,
+	consolidationRepo interfaces.FloorConsolidationRepositoryInterface,
+	placementRepo interfaces.PlacementPolicyRepositoryInterface,
+	floorPlanRepo interfaces.FloorPlanRepositoryInterface,
+	checklistRepo interfaces.ReservationChecklistRepositoryInterface,
+	approvalRuleRepo interfaces.ApprovalRuleRepositoryInterface,
+	costCenterRepo interfaces.CostCenterRepositoryInterface,
+	feedbackRepo interfaces.FeedbackRepositoryInterface,
+	sessionRepo interfaces.ReservationSessionRepositoryInterface,
+	closureRepo interfaces.BuildingClosureRepositoryInterface,
+	duplicateBookingPolicy string,
+	defaultPlacement string,
+	holidays *HolidayCalendar,
+	calendar *WorkingCalendarService,
+	quotas *BookingQuotaService,
+	strikes *BookingStrikeService,
+	events *OutboxDispatcher,
 ) *ReservationService {
+	if duplicateBookingPolicy != DuplicateBookingPolicyBlock {
+		duplicateBookingPolicy = DuplicateBookingPolicyWarn
+	}
+
 	return &ReservationService{
-		reservationRepo: reservationRepo,
-		spaceRepo:       spaceRepo,
-		userRepo:        userRepo,
+		reservationRepo:        reservationRepo,
+		spaceRepo:              spaceRepo,
+		userRepo:               userRepo,
+		vipBlockRepo:           vipBlockRepo,
+		conflictRepo:           conflictRepo,
+		questionnaireRepo:      questionnaireRepo,
+		preferenceRepo:         preferenceRepo,
+		consolidationRepo:      consolidationRepo,
+		placementRepo:          placementRepo,
+		floorPlanRepo:          floorPlanRepo,
+		checklistRepo:          checklistRepo,
+		approvalRuleRepo:       approvalRuleRepo,
+		costCenterRepo:         costCenterRepo,
+		feedbackRepo:           feedbackRepo,
+		sessionRepo:            sessionRepo,
+		closureRepo:            closureRepo,
+		duplicateBookingPolicy: duplicateBookingPolicy,
+		defaultPlacement:       normalizePlacementStrategy(defaultPlacement),
+		holidays:               holidays,
+		calendar:               calendar,
+		quotas:                 quotas,
+		strikes:                strikes,
+		events:                 events,
 	}
 }
 
@@ -41,6 +124,11 @@ func NewReservationService(
 
 // CreateReservation creates a new reservation
 func (s *ReservationService) CreateReservation(req *dto.CreateReservationRequest, userID uuid.UUID) (*models.Reservation, error) {
+	// Fill omitted fields from the user's booking preferences
+	if err := s.applyBookingPreferences(req, userID); err != nil {
+		return nil, err
+	}
+
 	// Validate the request
 	if err := req.Validate(); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
@@ -54,11 +142,65 @@ func (s *ReservationService) CreateReservation(req *dto.CreateReservationRequest
 
 	// Basic validations
 	if !space.IsAvailable() {
-		return nil, errors.New("space is not available for booking")
+		return nil, dto.ErrSpaceUnavailable
+	}
+
+	if err := s.checkFloorOpen(space, req.StartTime); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotClosed(space, req.StartTime, req.EndTime); err != nil {
+		return nil, err
+	}
+	if err := s.checkHoliday(req.StartTime, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkNeighborhood(space, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkPilot(space, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkStrikes(space, userID); err != nil {
+		return nil, err
 	}
 
 	if req.ParticipantCount > space.Capacity {
-		return nil, fmt.Errorf("participant count (%d) exceeds space capacity (%d)", req.ParticipantCount, space.Capacity)
+		return nil, dto.NewCapacityExceededError(req.ParticipantCount, space.Capacity)
+	}
+
+	licensePlate, err := licensePlateFor(space, req.LicensePlate)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := s.loadAddOnResources(space, req.ResourceIDs)
+	if err != nil {
+		return nil, err
+	}
+	costCenter, err := s.chargeableCostCenter(req.CostCenter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Enforce VIP guaranteed-availability blocks
+	var overriddenBlock *models.VIPBlock
+	if space.IsVIP {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+
+		block, err := s.findVIPBlockConflict(space.ID, user.Role, req.StartTime, req.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		if block != nil {
+			canOverride := user.Role == models.RoleAdmin || (space.ManagerID != nil && *space.ManagerID == userID)
+			if !req.OverrideVIPBlock || !canOverride {
+				s.recordConflict(req, userID, models.ConflictReasonVIPBlock)
+				return nil, dto.ErrTimeSlotVIPReserved
+			}
+			overriddenBlock = block
+		}
 	}
 
 	// Check for time conflicts
@@ -66,24 +208,53 @@ func (s *ReservationService) CreateReservation(req *dto.CreateReservationRequest
 	if err != nil {
 		return nil, fmt.Errorf("failed to check availability: %w", err)
 	}
-	if !available {
-		return nil, errors.New("time slot is not available")
+	var preemptions []*models.ReservationPreemption
+	if !available && req.Preempt {
+		preemptions, err = s.planPreemptions(space, req, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !available && len(preemptions) == 0 {
+		s.recordConflict(req, userID, models.ConflictReasonSlotTaken)
+		return nil, dto.ErrTimeSlotUnavailable
+	}
+	if err := s.checkResourcesFree(resources, req.StartTime, req.EndTime, nil); err != nil {
+		return nil, err
+	}
+
+	// Check the user is not already booked elsewhere at the same time
+	duplicates, err := s.reservationRepo.GetUserOverlappingReservations(userID, req.StartTime, req.EndTime, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check overlapping reservations: %w", err)
 	}
+	var warnings []string
+	if len(duplicates) > 0 && !req.OverrideDuplicate {
+		if s.duplicateBookingPolicy == DuplicateBookingPolicyBlock {
+			return nil, dto.ErrUserOverlap
+		}
+		warnings = duplicateBookingWarnings(duplicates)
+	}
+	dayWarnings, err := s.checkWorkingCalendar(space, req.StartTime, userID)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, dayWarnings...)
 
 	// Validate booking time
 	if req.StartTime.Before(time.Now()) {
-		return nil, errors.New("cannot book in the past")
+		return nil, dto.ErrBookingInPast
 	}
 
 	if req.EndTime.Before(req.StartTime) {
-		return nil, errors.New("end time must be after start time")
+		return nil, dto.ErrInvalidTimeRange
 	}
 
 	// Check advance booking time
 	if space.BookingAdvanceTime > 0 {
 		minBookingTime := time.Now().Add(time.Duration(space.BookingAdvanceTime) * time.Minute)
 		if req.StartTime.Before(minBookingTime) {
-			return nil, fmt.Errorf("reservations must be made at least %d minutes in advance", space.BookingAdvanceTime)
+			return nil, dto.NewAdvanceNoticeError(space.BookingAdvanceTime)
 		}
 	}
 
@@ -91,13 +262,21 @@ func (s *ReservationService) CreateReservation(req *dto.CreateReservationRequest
 	if space.MaxBookingDuration > 0 {
 		maxDuration := time.Duration(space.MaxBookingDuration) * time.Minute
 		if req.EndTime.Sub(req.StartTime) > maxDuration {
-			return nil, fmt.Errorf("booking duration cannot exceed %d minutes", space.MaxBookingDuration)
+			return nil, dto.NewDurationExceededError(space.MaxBookingDuration)
 		}
 	}
 
+	if err := s.checkQuota(space, req.StartTime, req.EndTime, userID); err != nil {
+		return nil, err
+	}
+
 	// Determine status
+	approval, err := s.decideApproval(space, req.EndTime.Sub(req.StartTime), req.ParticipantCount, userID)
+	if err != nil {
+		return nil, err
+	}
 	status := models.StatusConfirmed
-	if space.RequiresApproval {
+	if approval.required {
 		status = models.StatusPending
 	}
 
@@ -112,6 +291,17 @@ func (s *ReservationService) CreateReservation(req *dto.CreateReservationRequest
 		Description:      req.Description,
 		Status:           status,
 		IsRecurring:      req.IsRecurring,
+		IsPrivate:        req.IsPrivate != nil && *req.IsPrivate,
+		LicensePlate:     licensePlate,
+		Resources:        reservationResources(resources),
+		ApprovalSteps:    approval.steps,
+		AutoApproved:     approval.autoApproved,
+		Cost:             space.CostFor(req.StartTime, req.EndTime),
+		Tags:             pq.StringArray(models.NormalizeTags(req.Tags)),
+		CostCenter:       costCenter,
+	}
+	if len(duplicates) > 0 && req.OverrideDuplicate {
+		reservation.DuplicateJustification = req.DuplicateJustification
 	}
 
 	// Handle recurrence if needed
@@ -123,19 +313,196 @@ func (s *ReservationService) CreateReservation(req *dto.CreateReservationRequest
 		reservation.RecurrencePattern = datatypes.JSON(patternBytes)
 	}
 
-	createdReservation, err := s.reservationRepo.Create(reservation)
+	// Start the checklist from the space type's template, if there is one
+	if req.WithChecklist {
+		template, err := s.checklistRepo.GetTemplate(space.Type)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to get checklist template: %w", err)
+		}
+		if template != nil {
+			reservation.Tasks = templateTasks(template, req.StartTime)
+		}
+	}
+
+	var createdReservation *models.Reservation
+	if len(preemptions) > 0 {
+		createdReservation, err = s.reservationRepo.CreatePreempting(reservation, preemptions)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// A displaced reservation was approved or cancelled meanwhile
+			return nil, dto.ErrTimeSlotUnavailable
+		}
+	} else {
+		createdReservation, err = s.reservationRepo.Create(reservation)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create reservation: %w", err)
 	}
 
+	// Record the override so it shows up in the violation report
+	if overriddenBlock != nil {
+		violation := &models.VIPBlockViolation{
+			BlockID:       overriddenBlock.ID,
+			SpaceID:       space.ID,
+			ReservationID: createdReservation.ID,
+			UserID:        userID,
+			Justification: req.OverrideJustification,
+			StartTime:     createdReservation.StartTime,
+			EndTime:       createdReservation.EndTime,
+		}
+		if err := s.vipBlockRepo.CreateViolation(violation); err != nil {
+			s.reservationRepo.Delete(createdReservation.ID)
+			return nil, fmt.Errorf("failed to record VIP block override: %w", err)
+		}
+	}
+
 	// Create recurring instances if needed
 	if req.IsRecurring && req.RecurrencePattern != nil {
 		s.createRecurringInstances(createdReservation, req.RecurrencePattern)
 	}
 
+	s.events.Wake()
+
+	createdReservation.Warnings = warnings
 	return createdReservation, nil
 }
 
+// BookNow books a space for walk-up use starting immediately. The booking runs for the
+// requested duration or until the next reservation of the space, whichever comes first.
+func (s *ReservationService) BookNow(spaceID uuid.UUID, req *dto.BookNowRequest, userID uuid.UUID) (*dto.BookNowResponse, error) {
+	space, err := s.spaceRepo.GetByID(spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	if !space.IsAvailable() {
+		return nil, dto.ErrSpaceUnavailable
+	}
+	if err := s.checkFloorOpen(space, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotClosed(space, time.Now(), time.Now().Add(time.Duration(req.DurationMinutes)*time.Minute)); err != nil {
+		return nil, err
+	}
+	if err := s.checkHoliday(time.Now(), userID); err != nil {
+		return nil, err
+	}
+	dayWarnings, err := s.checkWorkingCalendar(space, time.Now(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNeighborhood(space, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkPilot(space, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkStrikes(space, userID); err != nil {
+		return nil, err
+	}
+
+	participants := req.ParticipantCount
+	if participants == 0 {
+		participants = 1
+	}
+	if participants > space.Capacity {
+		return nil, dto.NewCapacityExceededError(participants, space.Capacity)
+	}
+
+	// Walk-up bookings cannot wait for a manager, so only those the space's rule auto-approves go through
+	approval, err := s.decideApproval(space, time.Duration(req.DurationMinutes)*time.Minute, participants, userID)
+	if err != nil {
+		return nil, err
+	}
+	if approval.required {
+		return nil, dto.ErrSpaceRequiresApproval
+	}
+	licensePlate, err := licensePlateFor(space, req.LicensePlate)
+	if err != nil {
+		return nil, err
+	}
+
+	title := req.Title
+	if title == "" {
+		title = "Ad-hoc booking"
+	}
+
+	now := time.Now()
+	requestedEnd := now.Add(time.Duration(req.DurationMinutes) * time.Minute)
+	end := requestedEnd
+	if space.MaxBookingDuration > 0 {
+		if maxEnd := now.Add(time.Duration(space.MaxBookingDuration) * time.Minute); maxEnd.Before(end) {
+			end = maxEnd
+		}
+	}
+
+	if space.IsVIP {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		block, err := s.findVIPBlockConflict(space.ID, user.Role, now, end)
+		if err != nil {
+			return nil, err
+		}
+		if block != nil {
+			return nil, dto.ErrTimeSlotVIPReserved
+		}
+	}
+
+	duplicates, err := s.reservationRepo.GetUserOverlappingReservations(userID, now, end, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check overlapping reservations: %w", err)
+	}
+	if len(duplicates) > 0 && s.duplicateBookingPolicy == DuplicateBookingPolicyBlock {
+		return nil, dto.ErrUserOverlap
+	}
+	if err := s.checkQuota(space, now, end, userID); err != nil {
+		return nil, err
+	}
+
+	reservation := &models.Reservation{
+		UserID:           userID,
+		SpaceID:          spaceID,
+		StartTime:        now,
+		EndTime:          end,
+		ParticipantCount: participants,
+		Title:            title,
+		Status:           models.StatusConfirmed,
+		LicensePlate:     licensePlate,
+		AutoApproved:     approval.autoApproved,
+		Cost:             space.CostFor(now, end),
+	}
+
+	// The user is standing at the room, so the booking starts checked in unless
+	// the space asks for a questionnaire or proof of presence first
+	questionnaire, err := s.activeQuestionnaire(spaceID)
+	if err != nil {
+		return nil, err
+	}
+	if questionnaire == nil && !space.RequiresPresence() {
+		reservation.CheckInTime = &now
+	}
+
+	reservation, err = s.reservationRepo.CreateInFreeWindow(reservation, bookNowMinDuration)
+	if err != nil {
+		if errors.Is(err, interfaces.ErrNoFreeWindow) {
+			return nil, dto.ErrSpaceNotFree
+		}
+		return nil, fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	s.events.Wake()
+
+	return &dto.BookNowResponse{
+		Reservation:      dto.ToReservationResponse(reservation),
+		RequestedEndTime: requestedEnd,
+		GrantedEndTime:   reservation.EndTime,
+		GrantedMinutes:   int(reservation.EndTime.Sub(reservation.StartTime).Minutes()),
+		Shortened:        reservation.EndTime.Before(requestedEnd),
+		Warnings:         append(duplicateBookingWarnings(duplicates), dayWarnings...),
+	}, nil
+}
+
 // GetReservationByID retrieves a reservation by ID
 func (s *ReservationService) GetReservationByID(reservationID, userID uuid.UUID) (*models.Reservation, error) {
 	reservation, err := s.reservationRepo.GetByID(reservationID)
@@ -145,7 +512,12 @@ func (s *ReservationService) GetReservationByID(reservationID, userID uuid.UUID)
 
 	// Check permissions
 	if !s.canUserAccessReservation(reservation, userID) {
-		return nil, errors.New("access denied")
+		return nil, dto.ErrAccessDenied
+	}
+
+	// The checklist is the organizers' own to-do list
+	if !reservation.IsOrganizer(userID) {
+		reservation.Tasks = nil
 	}
 
 	return reservation, nil
@@ -161,12 +533,15 @@ func (s *ReservationService) UpdateReservation(reservationID uuid.UUID, req *dto
 
 	// Check permissions
 	if !s.canUserModifyReservation(reservation, userID) {
-		return nil, errors.New("access denied")
+		return nil, dto.ErrAccessDenied
 	}
 
 	// Check if can be modified
 	if !reservation.CanBeModified() {
-		return nil, errors.New("reservation cannot be modified")
+		return nil, dto.ErrReservationNotModifiable
+	}
+	if err := checkUnmodified(reservation.UpdatedAt, req.UpdatedAt); err != nil {
+		return nil, err
 	}
 
 	// Build updates
@@ -186,8 +561,23 @@ func (s *ReservationService) UpdateReservation(reservationID uuid.UUID, req *dto
 	if req.Description != nil {
 		updates["description"] = *req.Description
 	}
+	if req.IsPrivate != nil {
+		updates["is_private"] = *req.IsPrivate
+	}
+	if req.Tags != nil {
+		updates["tags"] = pq.StringArray(models.NormalizeTags(req.Tags))
+	}
+	if req.CostCenter != nil && models.NormalizeCostCenterCode(*req.CostCenter) != reservation.CostCenter {
+		// Keeping the current code is allowed even if it was deactivated since
+		costCenter, err := s.chargeableCostCenter(*req.CostCenter)
+		if err != nil {
+			return nil, err
+		}
+		updates["cost_center"] = costCenter
+	}
 
 	// Validate time changes
+	var warnings []string
 	if req.StartTime != nil || req.EndTime != nil {
 		startTime := reservation.StartTime
 		endTime := reservation.EndTime
@@ -198,13 +588,56 @@ func (s *ReservationService) UpdateReservation(reservationID uuid.UUID, req *dto
 			endTime = *req.EndTime
 		}
 
+		var dayWarnings []string
+		if req.StartTime != nil {
+			if err := s.checkHoliday(startTime, userID); err != nil {
+				return nil, err
+			}
+			if dayWarnings, err = s.checkWorkingCalendar(&reservation.Space, startTime, userID); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.checkNotClosed(&reservation.Space, startTime, endTime); err != nil {
+			return nil, err
+		}
+
 		available, err := s.reservationRepo.CheckTimeSlotAvailability(reservation.SpaceID, startTime, endTime, &reservationID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check availability: %w", err)
 		}
 		if !available {
-			return nil, errors.New("time slot is not available")
+			return nil, dto.ErrTimeSlotUnavailable
+		}
+		if err := s.checkResourcesFree(bookedResources(reservation), startTime, endTime, &reservationID); err != nil {
+			return nil, err
+		}
+
+		if reservation.Space.IsVIP {
+			block, err := s.findVIPBlockConflict(reservation.SpaceID, reservation.User.Role, startTime, endTime)
+			if err != nil {
+				return nil, err
+			}
+			if block != nil {
+				return nil, dto.ErrTimeSlotVIPReserved
+			}
+		}
+
+		// Reservations already justified as duplicates keep their override
+		duplicates, err := s.reservationRepo.GetUserOverlappingReservations(reservation.UserID, startTime, endTime, &reservationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check overlapping reservations: %w", err)
 		}
+		if len(duplicates) > 0 && reservation.DuplicateJustification == "" {
+			if s.duplicateBookingPolicy == DuplicateBookingPolicyBlock {
+				return nil, dto.ErrUserOverlap
+			}
+			warnings = duplicateBookingWarnings(duplicates)
+		}
+		warnings = append(warnings, dayWarnings...)
+
+		updates["cost"] = reservation.Space.CostFor(startTime, endTime)
+		// Moved out of the closure it was flagged for
+		updates["closure_id"] = nil
 	}
 
 	// Validate capacity changes
@@ -214,15 +647,52 @@ func (s *ReservationService) UpdateReservation(reservationID uuid.UUID, req *dto
 			return nil, fmt.Errorf("failed to get space: %w", err)
 		}
 		if *req.ParticipantCount > space.Capacity {
-			return nil, fmt.Errorf("participant count (%d) exceeds space capacity (%d)", *req.ParticipantCount, space.Capacity)
+			return nil, dto.NewCapacityExceededError(*req.ParticipantCount, space.Capacity)
+		}
+	}
+
+	// Auto-approved bookings that grow past the rule's thresholds go back for approval
+	if reservation.AutoApproved && (req.StartTime != nil || req.EndTime != nil || req.ParticipantCount != nil) {
+		startTime, endTime, participants := reservation.StartTime, reservation.EndTime, reservation.ParticipantCount
+		if req.StartTime != nil {
+			startTime = *req.StartTime
+		}
+		if req.EndTime != nil {
+			endTime = *req.EndTime
+		}
+		if req.ParticipantCount != nil {
+			participants = *req.ParticipantCount
+		}
+		approval, err := s.decideApproval(&reservation.Space, endTime.Sub(startTime), participants, reservation.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if approval.required {
+			updates["status"] = models.StatusPending
+			updates["auto_approved"] = false
+			updates["approval_steps"] = approval.steps
+			warnings = append(warnings, "The changes exceed the space's auto-approval limits, so the reservation is pending approval again")
 		}
 	}
 
-	updatedReservation, err := s.reservationRepo.Update(reservationID, updates)
+	// Moving the start also moves open checklist due times, in the same transaction
+	var updatedReservation *models.Reservation
+	if req.UpdatedAt != nil {
+		updatedReservation, err = s.reservationRepo.UpdateIfUnmodified(reservationID, *req.UpdatedAt, updates)
+		err = staleUpdateError(err)
+	} else {
+		updatedReservation, err = s.reservationRepo.Update(reservationID, updates)
+	}
+	if errors.Is(err, dto.ErrStaleUpdate) {
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to update reservation: %w", err)
 	}
 
+	s.events.Wake()
+
+	updatedReservation.Warnings = warnings
 	return updatedReservation, nil
 }
 
@@ -234,11 +704,11 @@ func (s *ReservationService) CancelReservation(reservationID uuid.UUID, reason s
 	}
 
 	if !s.canUserModifyReservation(reservation, userID) {
-		return errors.New("access denied")
+		return dto.ErrAccessDenied
 	}
 
 	if !reservation.CanBeCancelled() {
-		return errors.New("reservation cannot be cancelled")
+		return dto.ErrReservationNotCancellable
 	}
 
 	updates := map[string]interface{}{
@@ -251,6 +721,46 @@ func (s *ReservationService) CancelReservation(reservationID uuid.UUID, reason s
 		return fmt.Errorf("failed to cancel reservation: %w", err)
 	}
 
+	// Organizers cancelling a confirmed booking late earn a strike, admins cancelling for them do not
+	if s.strikes != nil && reservation.Status == models.StatusConfirmed && reservation.IsOrganizer(userID) {
+		_ = s.strikes.RecordLateCancellation(reservation, time.Now())
+	}
+
+	s.events.Wake()
+
+	return nil
+}
+
+// MarkNoShow reports a confirmed reservation that was never checked in as a no-show,
+// giving its booker a strike (space managers and admins only)
+func (s *ReservationService) MarkNoShow(reservationID uuid.UUID, reason string, reporterID uuid.UUID) error {
+	reservation, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		return fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	if !s.canUserApproveReservation(reservation, reporterID) {
+		return dto.ErrAccessDenied
+	}
+	if reservation.Status != models.StatusConfirmed || reservation.StartTime.After(time.Now()) {
+		return errors.New("only confirmed reservations that have started can be reported as no-shows")
+	}
+	if reservation.CheckInTime != nil {
+		return errors.New("reservation was checked in")
+	}
+	if reservation.NoShowReported {
+		return errors.New("reservation is already reported as a no-show")
+	}
+
+	if _, err := s.reservationRepo.Update(reservationID, map[string]interface{}{"no_show_reported": true}); err != nil {
+		return fmt.Errorf("failed to report no-show: %w", err)
+	}
+
+	if s.strikes != nil {
+		if err := s.strikes.RecordNoShow(reservation, reason); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -273,13 +783,14 @@ func (s *ReservationService) DeleteReservation(reservationID, userID uuid.UUID)
 // USER OPERATIONS
 // ========================================
 
-// GetUserReservations gets all reservations for a user
-func (s *ReservationService) GetUserReservations(userID uuid.UUID, offset, limit int) ([]*models.Reservation, int64, error) {
+// GetUserReservations gets all reservations for a user, with the relations in include or all
+// of them when nil
+func (s *ReservationService) GetUserReservations(userID uuid.UUID, offset, limit int, include dto.ReservationIncludes) ([]*models.Reservation, int64, error) {
 	if limit <= 0 {
 		limit = 20
 	}
 
-	reservations, total, err := s.reservationRepo.GetUserReservations(userID, offset, limit)
+	reservations, total, err := s.reservationRepo.GetUserReservations(userID, offset, limit, include)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get user reservations: %w", err)
 	}
@@ -287,6 +798,25 @@ func (s *ReservationService) GetUserReservations(userID uuid.UUID, offset, limit
 	return reservations, total, nil
 }
 
+// GetUserReservationsPage gets a page of a user's reservations after the cursor, and the
+// cursor of the next page when there is one
+func (s *ReservationService) GetUserReservationsPage(userID uuid.UUID, after *dto.Cursor, limit int, include dto.ReservationIncludes) ([]*models.Reservation, *dto.Cursor, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	reservations, err := s.reservationRepo.GetUserReservationsAfter(userID, after, limit+1, include)
+	if err != nil {
+		if errors.Is(err, dto.ErrInvalidCursor) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("failed to get user reservations: %w", err)
+	}
+
+	reservations, next := reservationPage(reservations, limit)
+	return reservations, next, nil
+}
+
 // GetUserUpcomingReservations gets upcoming reservations for a user
 func (s *ReservationService) GetUserUpcomingReservations(userID uuid.UUID, limit int) ([]*models.Reservation, error) {
 	if limit <= 0 {
@@ -364,6 +894,18 @@ func (s *ReservationService) CheckSpaceAvailability(spaceID uuid.UUID, startTime
 		},
 	}
 
+	// VIP blocks are closed to general booking
+	if space.IsVIP {
+		block, err := s.findVIPBlockConflict(spaceID, models.RoleStandardUser, startTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+		if block != nil {
+			response.IsAvailable = false
+			response.VIPBlocked = true
+		}
+	}
+
 	// Get conflicts if not available
 	if !available {
 		conflictReservations, err := s.reservationRepo.GetConflictingReservations(spaceID, startTime, endTime)
@@ -416,27 +958,56 @@ func (s *ReservationService) GetPendingApprovals(managerID uuid.UUID, offset, li
 	return reservations, total, nil
 }
 
-// ApproveReservation approves a reservation
+// ApproveReservation approves a reservation. Under a two-step rule the first approval,
+// by the space manager or an admin, leaves it pending until a different admin approves it.
 func (s *ReservationService) ApproveReservation(reservationID uuid.UUID, approverID uuid.UUID, comments string) error {
+	_, err := s.approveReservation(reservationID, approverID, comments)
+	return err
+}
+
+// approveReservation approves a reservation and reports whether the approval was final
+func (s *ReservationService) approveReservation(reservationID uuid.UUID, approverID uuid.UUID, comments string) (bool, error) {
 	reservation, err := s.reservationRepo.GetByID(reservationID)
 	if err != nil {
-		return fmt.Errorf("failed to get reservation: %w", err)
+		return false, fmt.Errorf("failed to get reservation: %w", err)
 	}
 
 	if reservation.Status != models.StatusPending {
-		return errors.New("reservation is not pending approval")
+		return false, dto.ErrReservationNotPending
 	}
 
-	if !s.canUserApproveReservation(reservation, approverID) {
-		return errors.New("access denied")
+	if reservation.AwaitsSecondApproval() {
+		if !s.canGiveSecondApproval(reservation, approverID) {
+			return false, errors.New("second approval must come from another admin")
+		}
+	} else {
+		if !s.canUserApproveReservation(reservation, approverID) {
+			return false, dto.ErrAccessDenied
+		}
+
+		if reservation.ApprovalSteps >= 2 {
+			if err := s.reservationRepo.RecordFirstApproval(reservationID, approverID, comments); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return false, dto.ErrReservationNotPending
+				}
+				return false, fmt.Errorf("failed to record first approval: %w", err)
+			}
+			s.events.Wake()
+			return false, nil
+		}
 	}
 
 	err = s.reservationRepo.ApproveReservation(reservationID, approverID, comments)
 	if err != nil {
-		return fmt.Errorf("failed to approve reservation: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, dto.ErrReservationNotPending
+		}
+		return false, fmt.Errorf("failed to approve reservation: %w", err)
 	}
 
-	return nil
+	s.events.Wake()
+
+	return true, nil
 }
 
 // RejectReservation rejects a reservation
@@ -451,49 +1022,121 @@ func (s *ReservationService) RejectReservation(reservationID uuid.UUID, approver
 	}
 
 	if reservation.Status != models.StatusPending {
-		return errors.New("reservation is not pending approval")
+		return dto.ErrReservationNotPending
 	}
 
-	if !s.canUserApproveReservation(reservation, approverID) {
-		return errors.New("access denied")
+	// Either approver of a two-step booking can turn it down
+	if !s.canUserApproveReservation(reservation, approverID) && !(reservation.AwaitsSecondApproval() && s.canGiveSecondApproval(reservation, approverID)) {
+		return dto.ErrAccessDenied
 	}
 
 	err = s.reservationRepo.RejectReservation(reservationID, approverID, reason)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.ErrReservationNotPending
+		}
 		return fmt.Errorf("failed to reject reservation: %w", err)
 	}
 
+	s.events.Wake()
+
 	return nil
 }
 
+// BulkApproveReservations approves or rejects each reservation on its own, so one that
+// fails does not hold up the rest, and reports every outcome in request order
+func (s *ReservationService) BulkApproveReservations(req *dto.BulkApprovalRequest, approverID uuid.UUID) (*dto.BulkApprovalResponse, error) {
+	if req.Action == "reject" && req.Reason == "" {
+		return nil, errors.New("rejection reason is required")
+	}
+
+	response := &dto.BulkApprovalResponse{Results: []dto.ApprovalResult{}}
+	seen := make(map[uuid.UUID]bool)
+	for _, reservationID := range req.ReservationIDs {
+		if seen[reservationID] {
+			continue
+		}
+		seen[reservationID] = true
+
+		result := dto.ApprovalResult{ReservationID: reservationID}
+		if req.Action == "reject" {
+			if err := s.RejectReservation(reservationID, approverID, req.Reason); err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Action, result.Status = "rejected", string(models.StatusRejected)
+			}
+		} else {
+			final, err := s.approveReservation(reservationID, approverID, req.Comments)
+			switch {
+			case err != nil:
+				result.Error = err.Error()
+			case final:
+				result.Action, result.Status = "approved", string(models.StatusConfirmed)
+			default:
+				result.Action, result.Status = "first_approval", string(models.StatusPending)
+			}
+		}
+
+		result.Success = result.Error == ""
+		if result.Success {
+			response.SuccessCount++
+		} else {
+			response.FailedCount++
+		}
+		response.Results = append(response.Results, result)
+	}
+	response.TotalCount = len(response.Results)
+
+	return response, nil
+}
+
 // ========================================
 // CHECK-IN/OUT OPERATIONS
 // ========================================
 
-// CheckIn checks in to a reservation
-func (s *ReservationService) CheckIn(reservationID uuid.UUID, userID uuid.UUID) error {
+// CheckIn checks in to a reservation. Spaces with a presence check need a beacon
+// ID or GPS position in the request proving the user is there.
+func (s *ReservationService) CheckIn(reservationID uuid.UUID, req *dto.CheckInRequest, userID uuid.UUID) error {
 	reservation, err := s.reservationRepo.GetByID(reservationID)
 	if err != nil {
 		return fmt.Errorf("failed to get reservation: %w", err)
 	}
 
 	// Basic validations
-	if reservation.UserID != userID {
-		return errors.New("can only check in to your own reservation")
+	if !reservation.IsOrganizer(userID) {
+		return errors.New("can only check in to reservations you organize")
 	}
 
 	if reservation.Status != models.StatusConfirmed {
-		return errors.New("reservation must be confirmed to check in")
+		return dto.ErrReservationNotConfirmed
 	}
 
 	if reservation.CheckInTime != nil {
-		return errors.New("already checked in")
+		return dto.ErrAlreadyCheckedIn
 	}
 
 	// Check if it's time to check in (within 15 minutes of start time)
 	now := time.Now()
 	if now.Before(reservation.StartTime.Add(-15*time.Minute)) || now.After(reservation.EndTime) {
-		return errors.New("can only check in within 15 minutes of start time")
+		return dto.ErrCheckInWindow
+	}
+
+	if err := s.verifyPresence(&reservation.Space, req); err != nil {
+		return err
+	}
+
+	// Spaces with a questionnaire need the answers on record before entry
+	questionnaire, err := s.activeQuestionnaire(reservation.SpaceID)
+	if err != nil {
+		return err
+	}
+	if questionnaire != nil {
+		if _, err := s.questionnaireRepo.GetSubmissionByReservation(reservationID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("check-in questionnaire must be submitted first")
+			}
+			return fmt.Errorf("failed to get questionnaire answers: %w", err)
+		}
 	}
 
 	err = s.reservationRepo.CheckIn(reservationID, now)
@@ -501,63 +1144,190 @@ func (s *ReservationService) CheckIn(reservationID uuid.UUID, userID uuid.UUID)
 		return fmt.Errorf("failed to check in: %w", err)
 	}
 
+	s.events.Wake()
+
 	return nil
 }
 
-// CheckOut checks out of a reservation
-func (s *ReservationService) CheckOut(reservationID uuid.UUID, userID uuid.UUID) error {
+// CheckOut checks out of a reservation, recording how much of the booked time was used
+func (s *ReservationService) CheckOut(reservationID uuid.UUID, userID uuid.UUID, feedback *dto.CheckOutRequest) (*models.ReservationSession, error) {
 	reservation, err := s.reservationRepo.GetByID(reservationID)
 	if err != nil {
-		return fmt.Errorf("failed to get reservation: %w", err)
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
 	}
 
-	if reservation.UserID != userID {
-		return errors.New("can only check out of your own reservation")
+	if !reservation.IsOrganizer(userID) {
+		return nil, errors.New("can only check out of reservations you organize")
 	}
 
 	if reservation.CheckInTime == nil {
-		return errors.New("must check in before checking out")
+		return nil, dto.ErrNotCheckedIn
 	}
 
 	if reservation.CheckOutTime != nil {
-		return errors.New("already checked out")
+		return nil, dto.ErrAlreadyCheckedOut
+	}
+
+	// Ratings and comments feed the facilities quality report
+	if feedback != nil && (feedback.Rating != nil || strings.TrimSpace(feedback.Feedback) != "") {
+		err = s.feedbackRepo.Save(&models.ReservationFeedback{
+			ReservationID: reservationID,
+			SpaceID:       reservation.SpaceID,
+			UserID:        userID,
+			Rating:        feedback.Rating,
+			Comment:       strings.TrimSpace(feedback.Feedback),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to save feedback: %w", err)
+		}
 	}
 
+	// Checking out completes the reservation
 	now := time.Now()
-	err = s.reservationRepo.CheckOut(reservationID, now)
-	if err != nil {
-		return fmt.Errorf("failed to check out: %w", err)
+	session := models.NewReservationSession(reservation, now)
+	var updates map[string]interface{}
+	if threshold := reservation.Space.EarlyReleaseMinutes; threshold > 0 && session.ReleasedMinutes >= threshold {
+		// Enough time is left to be worth giving back: the booking ends and is billed at check-out
+		end := now
+		if end.Before(reservation.StartTime) {
+			end = reservation.StartTime
+		}
+		updates = map[string]interface{}{
+			"end_time": end,
+			"cost":     reservation.Space.CostFor(reservation.StartTime, end),
+		}
+		session.Released = true
 	}
-
-	// Mark as completed if checked out
-	_, err = s.reservationRepo.Update(reservationID, map[string]interface{}{
-		"status": models.StatusCompleted,
-	})
+	err = s.reservationRepo.CheckOut(session, updates)
 	if err != nil {
-		return fmt.Errorf("failed to mark as completed: %w", err)
+		return nil, fmt.Errorf("failed to check out: %w", err)
 	}
 
-	return nil
-}
+	s.events.Wake()
 
-// ========================================
-// SEARCH AND FILTER
-// ========================================
+	return session, nil
+}
 
-// SearchReservations searches reservations with filters
-func (s *ReservationService) SearchReservations(filters map[string]interface{}, offset, limit int, userID uuid.UUID) ([]*models.Reservation, int64, error) {
-	// Check permissions
-	user, err := s.userRepo.GetByID(userID)
+// ExtendReservation pushes back the end of an ongoing reservation when the room stays free.
+// Callers are responsible for authorization (e.g. the room's door display).
+func (s *ReservationService) ExtendReservation(reservationID uuid.UUID, extension time.Duration) (*models.Reservation, error) {
+	reservation, err := s.reservationRepo.GetByID(reservationID)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get user: %w", err)
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
 	}
 
-	// Regular users can only search their own reservations
-	if user.Role == models.RoleStandardUser {
-		filters["user_id"] = userID
+	if !reservation.IsActive() {
+		return nil, errors.New("only an ongoing reservation can be extended")
 	}
 
-	if limit <= 0 {
+	newEnd := reservation.EndTime.Add(extension)
+	if maxDuration := reservation.Space.MaxBookingDuration; maxDuration > 0 && newEnd.Sub(reservation.StartTime) > time.Duration(maxDuration)*time.Minute {
+		return nil, errors.New("extension exceeds the maximum booking duration for this space")
+	}
+
+	available, err := s.reservationRepo.CheckTimeSlotAvailability(reservation.SpaceID, reservation.EndTime, newEnd, &reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check availability: %w", err)
+	}
+	if !available {
+		return nil, errors.New("space is booked right after this reservation")
+	}
+	if err := s.checkResourcesFree(bookedResources(reservation), reservation.EndTime, newEnd, &reservation.ID); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.reservationRepo.Update(reservationID, map[string]interface{}{
+		"end_time": newEnd,
+		"cost":     reservation.Space.CostFor(reservation.StartTime, newEnd),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extend reservation: %w", err)
+	}
+
+	s.events.Wake()
+	return updated, nil
+}
+
+// ExtendMeeting pushes back the end of an ongoing reservation the user organizes to the
+// requested time, when the room stays free until then
+func (s *ReservationService) ExtendMeeting(reservationID, userID uuid.UUID, req *dto.ExtendReservationRequest) (*models.Reservation, error) {
+	reservation, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	if !reservation.IsOrganizer(userID) {
+		return nil, errors.New("can only extend reservations you organize")
+	}
+	if !req.NewEndTime.After(reservation.EndTime) {
+		return nil, errors.New("new end time must be after the current end time")
+	}
+
+	return s.ExtendReservation(reservationID, req.NewEndTime.Sub(reservation.EndTime))
+}
+
+// EndReservationEarly ends an ongoing reservation now, releasing the room.
+// Callers are responsible for authorization (e.g. the room's door display).
+func (s *ReservationService) EndReservationEarly(reservationID uuid.UUID) (*models.Reservation, error) {
+	reservation, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	if !reservation.IsActive() {
+		return nil, errors.New("only an ongoing reservation can be ended")
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"end_time": now,
+		"status":   models.StatusCompleted,
+		"cost":     reservation.Space.CostFor(reservation.StartTime, now),
+	}
+
+	// A meeting checked in is checked out with it, so its session records the released time
+	if reservation.CheckInTime != nil && reservation.CheckOutTime == nil {
+		session := models.NewReservationSession(reservation, now)
+		session.Released = true
+		if err := s.reservationRepo.CheckOut(session, updates); err != nil {
+			return nil, fmt.Errorf("failed to end reservation: %w", err)
+		}
+		s.events.Wake()
+
+		updated, err := s.reservationRepo.GetByID(reservationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reservation: %w", err)
+		}
+		return updated, nil
+	}
+
+	updated, err := s.reservationRepo.Update(reservationID, updates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to end reservation: %w", err)
+	}
+
+	s.events.Wake()
+	return updated, nil
+}
+
+// ========================================
+// SEARCH AND FILTER
+// ========================================
+
+// SearchReservations searches reservations with filters
+func (s *ReservationService) SearchReservations(filters map[string]interface{}, offset, limit int, userID uuid.UUID) ([]*models.Reservation, int64, error) {
+	// Check permissions
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Regular users can only search their own reservations
+	if !user.HasStaffAccess() {
+		filters["user_id"] = userID
+	}
+
+	if limit <= 0 {
 		limit = 20
 	}
 
@@ -569,6 +1339,33 @@ func (s *ReservationService) SearchReservations(filters map[string]interface{},
 	return reservations, total, nil
 }
 
+// SearchReservationsPage searches a page of reservations after the cursor, latest start
+// first, and returns the cursor of the next page when there is one. Full-text searches are
+// ranked by relevance and only paginate by page number.
+func (s *ReservationService) SearchReservationsPage(filters map[string]interface{}, after *dto.Cursor, limit int, userID uuid.UUID) ([]*models.Reservation, *dto.Cursor, error) {
+	if _, ok := filters["query"]; ok {
+		return nil, nil, errors.New("cursor pagination is not available with full-text search")
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+
+	if after != nil {
+		if _, err := after.Time(); err != nil {
+			return nil, nil, err
+		}
+		filters["after"] = *after
+	}
+
+	reservations, _, err := s.SearchReservations(filters, 0, limit+1, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reservations, next := reservationPage(reservations, limit)
+	return reservations, next, nil
+}
+
 // GetReservationsByDateRange gets reservations in a date range
 func (s *ReservationService) GetReservationsByDateRange(startDate, endDate time.Time, offset, limit int, userID uuid.UUID) ([]*models.Reservation, int64, error) {
 	// Check permissions
@@ -582,8 +1379,8 @@ func (s *ReservationService) GetReservationsByDateRange(startDate, endDate time.
 	}
 
 	// For regular users, only show their reservations
-	if user.Role == models.RoleStandardUser {
-		return s.reservationRepo.GetUserReservations(userID, offset, limit)
+	if !user.HasStaffAccess() {
+		return s.reservationRepo.GetUserReservations(userID, offset, limit, nil)
 	}
 
 	reservations, total, err := s.reservationRepo.GetReservationsByDateRange(startDate, endDate, offset, limit)
@@ -594,6 +1391,62 @@ func (s *ReservationService) GetReservationsByDateRange(startDate, endDate time.
 	return reservations, total, nil
 }
 
+// GetDepartmentCalendar gets the reservations of a department's members. Members of the
+// department, managers and admins can view it; private reservations only show as busy
+// time to anyone but their owner.
+func (s *ReservationService) GetDepartmentCalendar(department string, startDate, endDate time.Time, userID uuid.UUID) (*dto.DepartmentCalendarResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if !user.HasStaffAccess() && user.Department != department {
+		return nil, dto.ErrAccessDenied
+	}
+
+	members, err := s.userRepo.GetUsersByDepartment(department)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get department members: %w", err)
+	}
+	if len(members) == 0 {
+		return nil, errors.New("department not found")
+	}
+
+	reservations, err := s.reservationRepo.GetDepartmentReservations(department, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get department reservations: %w", err)
+	}
+
+	calendar := &dto.DepartmentCalendarResponse{
+		Department:  department,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		MemberCount: len(members),
+		Entries:     make([]dto.DepartmentCalendarEntry, len(reservations)),
+	}
+	for i, reservation := range reservations {
+		entry := dto.DepartmentCalendarEntry{
+			ReservationID: reservation.ID,
+			UserID:        reservation.UserID,
+			UserName:      reservation.User.GetFullName(),
+			Title:         "Busy",
+			StartTime:     reservation.StartTime,
+			EndTime:       reservation.EndTime,
+			Status:        string(reservation.Status),
+			IsPrivate:     reservation.IsPrivate,
+		}
+		if !reservation.IsPrivate || reservation.IsOrganizer(userID) {
+			entry.SpaceID = &reservation.SpaceID
+			entry.SpaceName = reservation.Space.Name
+			entry.Title = reservation.Title
+			entry.ParticipantCount = reservation.ParticipantCount
+		}
+		calendar.Entries[i] = entry
+	}
+
+	return calendar, nil
+}
+
 // GetReservationsByStatus gets reservations by status
 func (s *ReservationService) GetReservationsByStatus(status string, offset, limit int, userID uuid.UUID) ([]*models.Reservation, int64, error) {
 	// Check permissions - only admins and managers can filter by status
@@ -602,8 +1455,8 @@ func (s *ReservationService) GetReservationsByStatus(status string, offset, limi
 		return nil, 0, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	if user.Role == models.RoleStandardUser {
-		return nil, 0, errors.New("access denied")
+	if !user.HasStaffAccess() {
+		return nil, 0, dto.ErrAccessDenied
 	}
 
 	if limit <= 0 {
@@ -631,7 +1484,7 @@ func (s *ReservationService) GetRecurringReservations(parentID uuid.UUID, userID
 	}
 
 	if !s.canUserAccessReservation(parent, userID) {
-		return nil, errors.New("access denied")
+		return nil, dto.ErrAccessDenied
 	}
 
 	reservations, err := s.reservationRepo.GetRecurringReservations(parentID)
@@ -656,11 +1509,21 @@ func (s *ReservationService) GetUserReservationCount(userID uuid.UUID) (int64, e
 	return count, nil
 }
 
+// GetUserStatusCounts counts the reservations a user owns in each status
+func (s *ReservationService) GetUserStatusCounts(userID uuid.UUID) (map[models.ReservationStatus]int64, error) {
+	counts, err := s.reservationRepo.CountUserReservationsByStatus(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count user reservations by status: %w", err)
+	}
+
+	return counts, nil
+}
+
 // GetSpaceReservationCount gets total reservation count for a space
 func (s *ReservationService) GetSpaceReservationCount(spaceID uuid.UUID, userID uuid.UUID) (int64, error) {
 	// Check if user can view space stats
 	if !s.canUserViewSpaceStats(spaceID, userID) {
-		return 0, errors.New("access denied")
+		return 0, dto.ErrAccessDenied
 	}
 
 	count, err := s.reservationRepo.CountSpaceReservations(spaceID)
@@ -671,14 +1534,147 @@ func (s *ReservationService) GetSpaceReservationCount(spaceID uuid.UUID, userID
 	return count, nil
 }
 
+// GetConflictReport aggregates rejected booking attempts per space. Managers only see
+// the spaces they manage; spaces with at least chronicThreshold conflicts are flagged.
+func (s *ReservationService) GetConflictReport(userID uuid.UUID, startDate, endDate time.Time, spaceID *uuid.UUID, chronicThreshold int64) (*dto.BookingConflictReport, error) {
+	if !endDate.After(startDate) {
+		return nil, errors.New("end date must be after start date")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var managerID *uuid.UUID
+	switch user.Role {
+	case models.RoleAdmin:
+	case models.RoleManager:
+		managerID = &userID
+	default:
+		return nil, errors.New("only managers and admins can view conflict analytics")
+	}
+
+	summaries, err := s.conflictRepo.GetSummaryBySpace(startDate, endDate, managerID, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking conflicts: %w", err)
+	}
+
+	days := endDate.Sub(startDate).Hours() / 24
+	report := &dto.BookingConflictReport{
+		StartDate:        startDate,
+		EndDate:          endDate,
+		ChronicThreshold: chronicThreshold,
+		Spaces:           make([]dto.BookingConflictSpaceSummary, len(summaries)),
+	}
+	for i, summary := range summaries {
+		chronic := summary.TotalConflicts >= chronicThreshold
+		report.Spaces[i] = dto.BookingConflictSpaceSummary{
+			BookingConflictSummary: summary,
+			ConflictsPerDay:        math.Round(float64(summary.TotalConflicts)/days*100) / 100,
+			Chronic:                chronic,
+		}
+		report.TotalConflicts += summary.TotalConflicts
+		if chronic {
+			report.ChronicSpaceCount++
+		}
+	}
+
+	return report, nil
+}
+
+// GetUtilizationReport reports how much of the booked time of checked-out reservations was
+// actually used, per space. Managers see the spaces they manage, admins see all spaces.
+func (s *ReservationService) GetUtilizationReport(userID uuid.UUID, startDate, endDate time.Time, spaceID *uuid.UUID) (*dto.SessionUtilizationReport, error) {
+	if !endDate.After(startDate) {
+		return nil, errors.New("end date must be after start date")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var managerID *uuid.UUID
+	switch user.Role {
+	case models.RoleAdmin:
+	case models.RoleManager:
+		managerID = &userID
+	default:
+		return nil, errors.New("only managers and admins can view utilization analytics")
+	}
+
+	summaries, err := s.sessionRepo.GetSummaryBySpace(startDate, endDate, managerID, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation sessions: %w", err)
+	}
+
+	report := &dto.SessionUtilizationReport{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Spaces:    make([]dto.SessionUtilizationSummary, len(summaries)),
+	}
+	var scheduledMinutes, usedMinutes, releasedMinutes int64
+	for i, summary := range summaries {
+		report.Spaces[i] = dto.SessionUtilizationSummary{
+			SpaceID:            summary.SpaceID,
+			SpaceName:          summary.SpaceName,
+			Sessions:           summary.Sessions,
+			ScheduledHours:     minutesToHours(summary.ScheduledMinutes),
+			UsedHours:          minutesToHours(summary.UsedMinutes),
+			UtilizationPercent: percentOf(summary.UsedMinutes, summary.ScheduledMinutes),
+			EarlyCheckouts:     summary.EarlyCheckouts,
+			EarlyCheckoutRate:  percentOf(summary.EarlyCheckouts, summary.Sessions),
+			ReleasedHours:      minutesToHours(summary.ReleasedMinutes),
+		}
+		report.Sessions += summary.Sessions
+		report.EarlyCheckouts += summary.EarlyCheckouts
+		scheduledMinutes += summary.ScheduledMinutes
+		usedMinutes += summary.UsedMinutes
+		releasedMinutes += summary.ReleasedMinutes
+	}
+	report.ScheduledHours = minutesToHours(scheduledMinutes)
+	report.UsedHours = minutesToHours(usedMinutes)
+	report.UtilizationPercent = percentOf(usedMinutes, scheduledMinutes)
+	report.ReleasedHours = minutesToHours(releasedMinutes)
+
+	return report, nil
+}
+
 // ========================================
 // HELPER METHODS
 // ========================================
 
+// minutesToHours converts minutes to hours rounded to two decimals
+func minutesToHours(minutes int64) float64 {
+	return math.Round(float64(minutes)/60*100) / 100
+}
+
+// percentOf returns part as a percentage of total to one decimal, 0 when total is 0
+func percentOf(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*1000) / 10
+}
+
+// reservationPage trims a result fetched one item beyond the limit to the page, and returns
+// the cursor of its last reservation when the extra item shows more follow
+func reservationPage(reservations []*models.Reservation, limit int) ([]*models.Reservation, *dto.Cursor) {
+	if len(reservations) <= limit {
+		return reservations, nil
+	}
+
+	reservations = reservations[:limit]
+	last := reservations[limit-1]
+	next := dto.NewTimeCursor(last.StartTime, last.ID)
+	return reservations, &next
+}
+
 // canUserAccessReservation checks if user can access reservation
 func (s *ReservationService) canUserAccessReservation(reservation *models.Reservation, userID uuid.UUID) bool {
-	// Own reservation
-	if reservation.UserID == userID {
+	// Own or co-organized reservation
+	if reservation.IsOrganizer(userID) {
 		return true
 	}
 
@@ -705,8 +1701,8 @@ func (s *ReservationService) canUserAccessReservation(reservation *models.Reserv
 
 // canUserModifyReservation checks if user can modify reservation
 func (s *ReservationService) canUserModifyReservation(reservation *models.Reservation, userID uuid.UUID) bool {
-	// Own reservation
-	if reservation.UserID == userID {
+	// Own or co-organized reservation
+	if reservation.IsOrganizer(userID) {
 		return true
 	}
 
@@ -763,6 +1759,444 @@ func (s *ReservationService) canUserViewSpaceStats(spaceID uuid.UUID, userID uui
 	return false
 }
 
+// recordConflict stores a booking attempt rejected for a taken slot. The attempt
+// already failed, so a storage error is ignored rather than masking the real reason.
+func (s *ReservationService) recordConflict(req *dto.CreateReservationRequest, userID uuid.UUID, reason models.BookingConflictReason) {
+	if s.conflictRepo == nil {
+		return
+	}
+
+	_ = s.conflictRepo.Create(&models.BookingConflict{
+		SpaceID:   req.SpaceID,
+		UserID:    userID,
+		Reason:    reason,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	})
+}
+
+// findVIPBlockConflict returns the first active VIP block overlapping the slot that the role cannot book
+func (s *ReservationService) findVIPBlockConflict(spaceID uuid.UUID, role models.UserRole, startTime, endTime time.Time) (*models.VIPBlock, error) {
+	blocks, err := s.vipBlockRepo.GetActiveBlocksBySpace(spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VIP blocks: %w", err)
+	}
+
+	for _, block := range blocks {
+		if !block.AllowsRole(role) && block.Overlaps(startTime, endTime) {
+			return block, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// applyBookingPreferences fills the fields a reservation request left out from the user's preferences
+func (s *ReservationService) applyBookingPreferences(req *dto.CreateReservationRequest, userID uuid.UUID) error {
+	preference, err := s.preferenceRepo.GetByUser(userID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to get booking preferences: %w", err)
+		}
+		preference = &models.UserPreference{}
+	}
+
+	if req.EndTime.IsZero() {
+		if preference.DefaultDurationMinutes == 0 {
+			return errors.New("end time is required without a default meeting duration")
+		}
+		req.EndTime = req.StartTime.Add(time.Duration(preference.DefaultDurationMinutes) * time.Minute)
+	}
+	if req.ParticipantCount == 0 {
+		req.ParticipantCount = len(preference.DefaultParticipantIDs) + 1
+	}
+	if req.IsPrivate == nil {
+		req.IsPrivate = &preference.DefaultPrivate
+	}
+
+	// Without a space the user asks for any suitable room in the building
+	if req.SpaceID == uuid.Nil {
+		building := req.Building
+		if building == "" {
+			building = preference.PreferredBuilding
+		}
+		if building == "" {
+			return errors.New("space is required without a building or a preferred building")
+		}
+
+		var preferredFloor *int
+		if building == preference.PreferredBuilding {
+			preferredFloor = preference.PreferredFloor
+		}
+		space, err := s.pickSuitableSpace(building, preferredFloor, req, userID)
+		if err != nil {
+			return err
+		}
+		req.SpaceID = space.ID
+	}
+
+	return nil
+}
+
+// checkFloorOpen rejects booking a space on a floor closed by a floor consolidation on the day
+func (s *ReservationService) checkFloorOpen(space *models.Space, startTime time.Time) error {
+	consolidations, err := s.consolidationRepo.GetOverlapping(space.Building, startTime.UTC(), startTime.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to get floor consolidations: %w", err)
+	}
+	if consolidation := closingConsolidation(consolidations, space, startTime); consolidation != nil {
+		return dto.NewFloorClosedError(space.Building, space.Floor, consolidation.OpenFloors)
+	}
+	return nil
+}
+
+// checkNotClosed rejects booking a space closed by a building or floor closure during part of the time range
+func (s *ReservationService) checkNotClosed(space *models.Space, startTime, endTime time.Time) error {
+	closures, err := s.closureRepo.GetOverlapping(space.Building, startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("failed to get building closures: %w", err)
+	}
+	for _, closure := range closures {
+		if closure.Closes(space, startTime, endTime) {
+			return dto.NewSpaceClosedError(space.Name, closure.StartDate, closure.EndDate)
+		}
+	}
+	return nil
+}
+
+// checkHoliday rejects a booking starting on a public holiday; admins may still book
+func (s *ReservationService) checkHoliday(startTime time.Time, userID uuid.UUID) error {
+	holiday := s.holidays.HolidayOn(startTime)
+	if holiday == nil {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsAdmin() {
+		return nil
+	}
+	return dto.NewPublicHolidayError(holiday.Date, holiday.Name)
+}
+
+// checkWorkingCalendar rejects a booking starting on a blocking holiday or closure day of the
+// space's region, admins excepted, and describes a day that only warns
+func (s *ReservationService) checkWorkingCalendar(space *models.Space, startTime time.Time, userID uuid.UUID) ([]string, error) {
+	if s.calendar == nil {
+		return nil, nil
+	}
+
+	day, err := s.calendar.DayOn(space, startTime)
+	if err != nil || day == nil {
+		return nil, err
+	}
+	date := day.Date.Format("2006-01-02")
+	if !day.Blocking {
+		return []string{fmt.Sprintf("%s is %s in %s (%s)", date, day.Label(), space.Building, day.Name)}, nil
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsAdmin() {
+		return nil, nil
+	}
+	return nil, dto.NewNonWorkingDayError(space.Building, date, day.Name)
+}
+
+// checkPilot rejects booking a space in soft launch by a user outside its pilot group
+func (s *ReservationService) checkPilot(space *models.Space, userID uuid.UUID) error {
+	now := time.Now()
+	if !space.InPilot(now) {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !space.PilotAllows(user, now) {
+		return dto.NewPilotRestrictedError(space.Name, space.PilotUntil.UTC().Format("2006-01-02"))
+	}
+	return nil
+}
+
+// checkStrikes rejects premium-space bookings by users restricted for late cancellations and no-shows
+func (s *ReservationService) checkStrikes(space *models.Space, userID uuid.UUID) error {
+	if s.strikes == nil {
+		return nil
+	}
+	return s.strikes.CheckBooking(userID, space)
+}
+
+// chargeableCostCenter normalizes the cost center of a booking, which must be an active
+// code of the list; an empty code charges no cost center
+func (s *ReservationService) chargeableCostCenter(code string) (string, error) {
+	code = models.NormalizeCostCenterCode(code)
+	if code == "" {
+		return "", nil
+	}
+
+	costCenter, err := s.costCenterRepo.GetByCode(code)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", fmt.Errorf("failed to get cost center: %w", err)
+	}
+	if costCenter == nil || !costCenter.IsActive {
+		var fields dto.ValidationErrors
+		fields.Add("cost_center", dto.ValidationNotListed, nil)
+		return "", fields.Err()
+	}
+	return code, nil
+}
+
+// checkQuota checks the booking fits the user's weekly quota and their team's premium-room quota
+func (s *ReservationService) checkQuota(space *models.Space, startTime, endTime time.Time, userID uuid.UUID) error {
+	if s.quotas == nil {
+		return nil
+	}
+	return s.quotas.CheckBooking(userID, space, startTime, endTime, nil)
+}
+
+// pickSuitableSpace finds the free spaces in the building that fit the request and
+// lets the building's placement strategy choose among them
+func (s *ReservationService) pickSuitableSpace(building string, preferredFloor *int, req *dto.CreateReservationRequest, userID uuid.UUID) (*models.Space, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	spaces, _, err := s.spaceRepo.GetAvailableSpaces(req.StartTime, req.EndTime, pilotViewerOf(user), 0, suitableSpaceCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available spaces: %w", err)
+	}
+	consolidations, err := s.consolidationRepo.GetOverlapping(building, req.StartTime.UTC(), req.StartTime.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get floor consolidations: %w", err)
+	}
+
+	var candidates []*models.Space
+	for _, space := range spaces {
+		// VIP and approval-only spaces are only booked on purpose
+		if space.Building != building || !space.IsAvailable() || !space.HostsPeople() || space.IsVIP || space.RequiresApproval {
+			continue
+		}
+		if space.Capacity < req.ParticipantCount || closingConsolidation(consolidations, space, req.StartTime) != nil {
+			continue
+		}
+		if space.MaxBookingDuration > 0 && req.EndTime.Sub(req.StartTime) > time.Duration(space.MaxBookingDuration)*time.Minute {
+			continue
+		}
+		if space.BookingAdvanceTime > 0 && req.StartTime.Before(time.Now().Add(time.Duration(space.BookingAdvanceTime)*time.Minute)) {
+			continue
+		}
+
+		candidates = append(candidates, space)
+	}
+
+	// Desks in another team's neighborhood are left out
+	restricted, err := s.restrictedDesks(candidates, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(restricted) > 0 {
+		allowed := candidates[:0]
+		for _, space := range candidates {
+			if restricted[space.ID] == nil {
+				allowed = append(allowed, space)
+			}
+		}
+		candidates = allowed
+	}
+
+	if len(candidates) == 0 {
+		return nil, errors.New("no suitable space is free in the building for this time")
+	}
+	return s.placeSpace(building, candidates, preferredFloor)
+}
+
+// planPreemptions plans taking the slot of the request from the pending reservations holding it,
+// with the spaces their organizers could rebook. It returns none when a booking that cannot be
+// preempted holds the slot: a confirmed one, one of a user with the same or a higher priority, or
+// the add-on booking of another space.
+func (s *ReservationService) planPreemptions(space *models.Space, req *dto.CreateReservationRequest, userID uuid.UUID) ([]*models.ReservationPreemption, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	priority := user.Priority()
+	if space.PreemptionTier == 0 || priority < space.PreemptionTier {
+		return nil, dto.NewPreemptionDeniedError(priority, space.PreemptionTier)
+	}
+
+	conflicts, err := s.reservationRepo.GetConflictingReservations(space.ID, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conflicting reservations: %w", err)
+	}
+
+	preemptions := make([]*models.ReservationPreemption, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		displacedPriority := conflict.User.Priority()
+		if conflict.Status != models.StatusPending || conflict.SpaceID != space.ID || displacedPriority >= priority {
+			return nil, nil
+		}
+
+		// Other rooms of the building, as the preemptor may need the organizer nearby
+		suggestions, err := suggestRebooking(s.spaceRepo, conflict, func(candidate *models.Space) bool {
+			return candidate.Building == space.Building
+		})
+		if err != nil {
+			return nil, err
+		}
+		suggestionsJSON, err := json.Marshal(suggestions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize rebooking suggestions: %w", err)
+		}
+
+		preemptions = append(preemptions, &models.ReservationPreemption{
+			SpaceID:           space.ID,
+			ReservationID:     conflict.ID,
+			DisplacedUserID:   conflict.UserID,
+			PreemptedByID:     userID,
+			DisplacedPriority: displacedPriority,
+			PreemptorPriority: priority,
+			Justification:     req.PreemptJustification,
+			StartTime:         conflict.StartTime,
+			EndTime:           conflict.EndTime,
+			Suggestions:       datatypes.JSON(suggestionsJSON),
+		})
+	}
+	return preemptions, nil
+}
+
+// preferredSpaceLess orders spaces on the preferred floor first, then by capacity
+func preferredSpaceLess(a, b *models.Space, floor *int) bool {
+	if floor != nil {
+		aOnFloor, bOnFloor := a.Floor == *floor, b.Floor == *floor
+		if aOnFloor != bOnFloor {
+			return aOnFloor
+		}
+	}
+	return a.Capacity < b.Capacity
+}
+
+// verifyPresence checks the proof that the user is at the space, when the space asks for one
+func (s *ReservationService) verifyPresence(space *models.Space, proof *dto.CheckInRequest) error {
+	if !space.RequiresPresence() {
+		return nil
+	}
+	if proof == nil {
+		proof = &dto.CheckInRequest{}
+	}
+
+	if proof.BeaconID != "" && space.AcceptsBeacon() {
+		if !space.HasBeacon(proof.BeaconID) {
+			return errors.New("beacon does not belong to this space")
+		}
+		return nil
+	}
+
+	if proof.Latitude != nil && proof.Longitude != nil && space.AcceptsGeofence() {
+		if proof.Accuracy > maxCheckInLocationAccuracy {
+			return errors.New("location is not precise enough to check in")
+		}
+		distance, ok := space.DistanceFrom(*proof.Latitude, *proof.Longitude)
+		if !ok {
+			return errors.New("space has no location for geofenced check-in")
+		}
+		if distance > float64(space.GeofenceRadius)+proof.Accuracy {
+			return errors.New("location is outside the space's check-in area")
+		}
+		return nil
+	}
+
+	switch space.CheckInPresence {
+	case models.PresenceCheckBeacon:
+		return errors.New("proof of presence required: scan the room's beacon")
+	case models.PresenceCheckGeofence:
+		return errors.New("proof of presence required: share your location")
+	default:
+		return errors.New("proof of presence required: scan the room's beacon or share your location")
+	}
+}
+
+// activeQuestionnaire returns the check-in questionnaire of a space, or nil when it has no active one
+func (s *ReservationService) activeQuestionnaire(spaceID uuid.UUID) (*models.CheckInQuestionnaire, error) {
+	questionnaire, err := s.questionnaireRepo.GetBySpace(spaceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get check-in questionnaire: %w", err)
+	}
+	if !questionnaire.IsActive {
+		return nil, nil
+	}
+	return questionnaire, nil
+}
+
+// duplicateBookingWarnings describes the user's other reservations overlapping a booking
+func duplicateBookingWarnings(duplicates []*models.Reservation) []string {
+	warnings := make([]string, 0, len(duplicates))
+	for _, duplicate := range duplicates {
+		warnings = append(warnings, fmt.Sprintf(
+			"you already have \"%s\" in %s from %s to %s",
+			duplicate.Title, duplicate.Space.Name,
+			duplicate.StartTime.Format("2006-01-02 15:04"), duplicate.EndTime.Format("15:04"),
+		))
+	}
+	return warnings
+}
+
+// nextOccurrence returns the start of the occurrence of the series following the one at start
+func nextOccurrence(pattern *dto.RecurrencePattern, start time.Time) (time.Time, error) {
+	switch pattern.Type {
+	case "daily":
+		return start.AddDate(0, 0, pattern.Interval), nil
+	case "weekly":
+		return start.AddDate(0, 0, 7*pattern.Interval), nil
+	case "monthly":
+		return start.AddDate(0, pattern.Interval, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("unsupported recurrence type: %s", pattern.Type)
+	}
+}
+
+// closedOn checks if the space's offices are closed on the day of t: a public holiday, or a
+// blocking holiday or closure day of its region
+func (s *ReservationService) closedOn(space *models.Space, t time.Time) (bool, error) {
+	if s.holidays.HolidayOn(t) != nil {
+		return true, nil
+	}
+	if s.calendar == nil {
+		return false, nil
+	}
+	day, err := s.calendar.DayOn(space, t)
+	if err != nil {
+		return false, err
+	}
+	return day != nil && day.Blocking, nil
+}
+
+// nextOpenDay moves an occurrence on a closed day to the same time of the next open weekday,
+// which must come before the following occurrence of the series
+func (s *ReservationService) nextOpenDay(space *models.Space, start, following time.Time) (time.Time, error) {
+	for candidate := start.AddDate(0, 0, 1); candidate.Before(following); candidate = candidate.AddDate(0, 0, 1) {
+		if candidate.Weekday() == time.Saturday || candidate.Weekday() == time.Sunday {
+			continue
+		}
+		closed, err := s.closedOn(space, candidate)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !closed {
+			return candidate, nil
+		}
+	}
+	return time.Time{}, errors.New("no open day before the next occurrence")
+}
+
 // createRecurringInstances creates recurring reservation instances (simplified for PFE)
 func (s *ReservationService) createRecurringInstances(parentReservation *models.Reservation, pattern *dto.RecurrencePattern) error {
 	var instances []*models.Reservation
@@ -775,22 +2209,35 @@ func (s *ReservationService) createRecurringInstances(parentReservation *models.
 	}
 
 	for i := 0; i < maxOccurrences; i++ {
-		var nextStart time.Time
-		switch pattern.Type {
-		case "daily":
-			nextStart = currentStart.AddDate(0, 0, pattern.Interval)
-		case "weekly":
-			nextStart = currentStart.AddDate(0, 0, 7*pattern.Interval)
-		case "monthly":
-			nextStart = currentStart.AddDate(0, pattern.Interval, 0)
-		default:
-			return fmt.Errorf("unsupported recurrence type: %s", pattern.Type)
+		nextStart, err := nextOccurrence(pattern, currentStart)
+		if err != nil {
+			return err
 		}
 
 		if pattern.EndDate != nil && nextStart.After(*pattern.EndDate) {
 			break
 		}
 
+		// Skipped and shifted occurrences still move the series forward
+		currentStart = nextStart
+
+		// Offices are closed on public holidays and blocking closure days
+		closed, err := s.closedOn(&parentReservation.Space, nextStart)
+		if err != nil {
+			continue
+		}
+		if closed {
+			if pattern.OnClosedDay != "shift" {
+				continue
+			}
+			following, _ := nextOccurrence(pattern, nextStart)
+			if nextStart, err = s.nextOpenDay(&parentReservation.Space, nextStart, following); err != nil {
+				continue
+			}
+			if pattern.EndDate != nil && nextStart.After(*pattern.EndDate) {
+				continue
+			}
+		}
 		nextEnd := nextStart.Add(duration)
 
 		// Check availability
@@ -799,6 +2246,24 @@ func (s *ReservationService) createRecurringInstances(parentReservation *models.
 			continue
 		}
 
+		// Occurrences are booked with the same add-ons, so skip those where one is taken
+		if err := s.checkResourcesFree(bookedResources(parentReservation), nextStart, nextEnd, nil); err != nil {
+			continue
+		}
+
+		// Overrides apply to a single booking, so skip occurrences inside VIP blocks
+		if parentReservation.Space.IsVIP {
+			block, err := s.findVIPBlockConflict(parentReservation.SpaceID, parentReservation.User.Role, nextStart, nextEnd)
+			if err != nil || block != nil {
+				continue
+			}
+		}
+
+		// Occurrences past the user's or team's quota are skipped like taken slots
+		if s.quotas != nil && s.quotas.CheckBooking(parentReservation.UserID, &parentReservation.Space, nextStart, nextEnd, instances) != nil {
+			continue
+		}
+
 		instance := &models.Reservation{
 			UserID:             parentReservation.UserID,
 			SpaceID:            parentReservation.SpaceID,
@@ -810,10 +2275,17 @@ func (s *ReservationService) createRecurringInstances(parentReservation *models.
 			Status:             parentReservation.Status,
 			IsRecurring:        false,
 			RecurrenceParentID: &parentReservation.ID,
+			LicensePlate:       parentReservation.LicensePlate,
+			Tags:               parentReservation.Tags,
+			CostCenter:         parentReservation.CostCenter,
+			ApprovalSteps:      parentReservation.ApprovalSteps,
+			AutoApproved:       parentReservation.AutoApproved,
+			Cost:               parentReservation.Space.CostFor(nextStart, nextEnd),
+			Resources:          reservationResources(bookedResources(parentReservation)),
+			Tasks:              shiftedTasks(parentReservation.Tasks, nextStart.Sub(parentReservation.StartTime)),
 		}
 
 		instances = append(instances, instance)
-		currentStart = nextStart
 	}
 
 	if len(instances) > 0 {
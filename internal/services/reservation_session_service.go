@@ -0,0 +1,69 @@
+// internal/services/reservation_session_service.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// Upper bound of reservations checked out per run
+const autoCheckOutBatch = 200
+
+// ReservationSessionService closes the check-in sessions organizers leave open. Rooms a sensor
+// finds empty are released earlier by OccupancyService.ReleaseEmptyRooms, which checks their
+// meetings out too.
+type ReservationSessionService struct {
+	sessionRepo     interfaces.ReservationSessionRepositoryInterface
+	reservationRepo interfaces.ReservationRepositoryInterface
+	events          *OutboxDispatcher // Optional, nil leaves committed events to the dispatch job
+	logger          *slog.Logger
+}
+
+// NewReservationSessionService creates a new reservation session service
+func NewReservationSessionService(
+	sessionRepo interfaces.ReservationSessionRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	events *OutboxDispatcher,
+	logger *slog.Logger,
+) *ReservationSessionService {
+	return &ReservationSessionService{
+		sessionRepo:     sessionRepo,
+		reservationRepo: reservationRepo,
+		events:          events,
+		logger:          logger,
+	}
+}
+
+// AutoCheckOut checks out the meetings still checked in at their scheduled end, as if the
+// organizer had checked out on time. It runs as a scheduled job.
+func (s *ReservationSessionService) AutoCheckOut(ctx context.Context) error {
+	reservations, err := s.sessionRepo.GetOverdueCheckOuts(time.Now(), autoCheckOutBatch)
+	if err != nil {
+		return fmt.Errorf("failed to get overdue check-outs: %w", err)
+	}
+
+	checkedOut := 0
+	for _, reservation := range reservations {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		session := models.NewReservationSession(reservation, reservation.EndTime)
+		if err := s.reservationRepo.CheckOut(session, nil); err != nil {
+			s.logger.Error("Failed to check out overdue reservation", "reservationID", reservation.ID, "error", err)
+			continue
+		}
+		checkedOut++
+	}
+
+	if checkedOut > 0 {
+		s.events.Wake()
+		s.logger.Info("Overdue reservations checked out", "count", checkedOut)
+	}
+	return nil
+}
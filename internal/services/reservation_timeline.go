@@ -0,0 +1,156 @@
+// internal/services/reservation_timeline.go
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+)
+
+// Statuses of a timeline segment
+const (
+	TimelineFree   = "free"
+	TimelineBusy   = "busy"
+	TimelineBuffer = "buffer"
+)
+
+// GetSpaceTimeline splits the day (midnight to midnight in the day's location) into free, busy
+// and buffer segments. Busy segments list their bookings; private bookings show only their time
+// unless the caller owns them, manages the space or is an admin.
+func (s *ReservationService) GetSpaceTimeline(spaceID uuid.UUID, day time.Time, userID uuid.UUID) (*dto.SpaceTimelineResponse, error) {
+	space, err := s.spaceRepo.GetByID(spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	reservations, err := s.reservationRepo.GetConflictingReservations(spaceID, dayStart, dayEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space reservations: %w", err)
+	}
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].StartTime.Before(reservations[j].StartTime)
+	})
+
+	seesDetails := user.IsAdmin() || (space.ManagerID != nil && *space.ManagerID == userID)
+	before := time.Duration(space.BufferBefore) * time.Minute
+	after := time.Duration(space.BufferAfter) * time.Minute
+
+	// Every booking and buffer edge inside the day starts a new segment
+	boundaries := []time.Time{dayStart, dayEnd}
+	for _, reservation := range reservations {
+		for _, edge := range []time.Time{reservation.StartTime.Add(-before), reservation.StartTime, reservation.EndTime, reservation.EndTime.Add(after)} {
+			if edge.After(dayStart) && edge.Before(dayEnd) {
+				boundaries = append(boundaries, edge)
+			}
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+
+	response := &dto.SpaceTimelineResponse{
+		SpaceID:   space.ID,
+		SpaceName: space.Name,
+		Date:      dayStart.Format("2006-01-02"),
+		Timezone:  dayStart.Location().String(),
+		DayStart:  dayStart,
+		DayEnd:    dayEnd,
+		Available: space.IsAvailable(),
+		Holiday:   s.holidays.HolidayOn(dayStart),
+		Segments:  []dto.TimelineSegment{},
+	}
+
+	var current *dto.TimelineSegment
+	var currentIDs []uuid.UUID
+	for i := 0; i+1 < len(boundaries); i++ {
+		start, end := boundaries[i], boundaries[i+1]
+		if !start.Before(end) {
+			continue
+		}
+
+		status := TimelineFree
+		var busy []*models.Reservation
+		for _, reservation := range reservations {
+			switch {
+			case reservation.StartTime.Before(end) && reservation.EndTime.After(start):
+				status = TimelineBusy
+				busy = append(busy, reservation)
+			case status == TimelineFree && reservation.StartTime.Add(-before).Before(end) && reservation.EndTime.Add(after).After(start):
+				status = TimelineBuffer
+			}
+		}
+		ids := make([]uuid.UUID, len(busy))
+		for j, reservation := range busy {
+			ids[j] = reservation.ID
+		}
+
+		// Neighbouring stretches with the same status and bookings form one segment
+		if current != nil && current.Status == status && sameIDs(currentIDs, ids) {
+			current.EndTime = end
+			continue
+		}
+
+		segment := dto.TimelineSegment{Status: status, StartTime: start, EndTime: end}
+		for _, reservation := range busy {
+			segment.Reservations = append(segment.Reservations, timelineReservation(reservation, userID, seesDetails))
+		}
+		response.Segments = append(response.Segments, segment)
+		current = &response.Segments[len(response.Segments)-1]
+		currentIDs = ids
+	}
+
+	for _, segment := range response.Segments {
+		minutes := int(segment.EndTime.Sub(segment.StartTime).Minutes())
+		switch segment.Status {
+		case TimelineFree:
+			response.FreeMinutes += minutes
+		case TimelineBusy:
+			response.BusyMinutes += minutes
+		}
+	}
+
+	return response, nil
+}
+
+// timelineReservation summarizes a booking, hiding the details of private bookings of others
+func timelineReservation(reservation *models.Reservation, userID uuid.UUID, seesDetails bool) dto.TimelineReservation {
+	summary := dto.TimelineReservation{
+		Title:     "Busy",
+		StartTime: reservation.StartTime,
+		EndTime:   reservation.EndTime,
+		Status:    string(reservation.Status),
+		IsPrivate: reservation.IsPrivate,
+	}
+	if reservation.IsPrivate && !seesDetails && !reservation.IsOrganizer(userID) {
+		return summary
+	}
+
+	summary.ReservationID = &reservation.ID
+	summary.Title = reservation.Title
+	summary.ParticipantCount = reservation.ParticipantCount
+	summary.OrganizerName = reservation.User.GetFullName()
+	return summary
+}
+
+// sameIDs checks if two ID lists hold the same IDs in the same order
+func sameIDs(a, b []uuid.UUID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+// internal/services/reservation_widget_requests.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+)
+
+// CreateWidgetRequest books a space for someone without an account, from a booking widget
+// embedded in another site. The reservation always waits in the approval queue of the space,
+// whatever the approval settings of the space, and belongs to whoever created the widget token.
+// Visitors book like standard users: no admin exemptions, and no spaces in soft launch.
+func (s *ReservationService) CreateWidgetRequest(space *models.Space, req *dto.WidgetBookingRequest, token *models.WidgetToken) (*models.Reservation, error) {
+	if !space.IsAvailable() {
+		return nil, dto.ErrSpaceUnavailable
+	}
+	if space.InPilot(time.Now()) {
+		return nil, dto.NewPilotRestrictedError(space.Name, space.PilotUntil.UTC().Format("2006-01-02"))
+	}
+	if err := s.checkFloorOpen(space, req.StartTime); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotClosed(space, req.StartTime, req.EndTime); err != nil {
+		return nil, err
+	}
+	if holiday := s.holidays.HolidayOn(req.StartTime); holiday != nil {
+		return nil, dto.NewPublicHolidayError(holiday.Date, holiday.Name)
+	}
+	if s.calendar != nil {
+		day, err := s.calendar.DayOn(space, req.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		if day != nil && day.Blocking {
+			return nil, dto.NewNonWorkingDayError(space.Building, day.Date.Format("2006-01-02"), day.Name)
+		}
+	}
+
+	if req.StartTime.Before(time.Now()) {
+		return nil, dto.ErrBookingInPast
+	}
+	if space.BookingAdvanceTime > 0 && req.StartTime.Before(time.Now().Add(time.Duration(space.BookingAdvanceTime)*time.Minute)) {
+		return nil, dto.NewAdvanceNoticeError(space.BookingAdvanceTime)
+	}
+	if space.MaxBookingDuration > 0 && req.EndTime.Sub(req.StartTime) > time.Duration(space.MaxBookingDuration)*time.Minute {
+		return nil, dto.NewDurationExceededError(space.MaxBookingDuration)
+	}
+	if req.ParticipantCount > space.Capacity {
+		return nil, dto.NewCapacityExceededError(req.ParticipantCount, space.Capacity)
+	}
+
+	if space.IsVIP {
+		block, err := s.findVIPBlockConflict(space.ID, models.RoleStandardUser, req.StartTime, req.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		if block != nil {
+			return nil, dto.ErrTimeSlotVIPReserved
+		}
+	}
+
+	available, err := s.reservationRepo.CheckTimeSlotAvailability(space.ID, req.StartTime, req.EndTime, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check availability: %w", err)
+	}
+	if !available {
+		return nil, dto.ErrTimeSlotUnavailable
+	}
+
+	// Two-step rules still apply; thresholds that auto-approve never do
+	steps := 1
+	rule, err := s.approvalRuleRepo.GetBySpace(space.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get approval rule: %w", err)
+	}
+	if rule != nil && rule.TwoStep {
+		steps = 2
+	}
+
+	tokenID := token.ID
+	reservation, err := s.reservationRepo.Create(&models.Reservation{
+		UserID:           token.CreatedByID,
+		SpaceID:          space.ID,
+		StartTime:        req.StartTime,
+		EndTime:          req.EndTime,
+		ParticipantCount: req.ParticipantCount,
+		Title:            req.Title,
+		Description:      req.Notes,
+		Status:           models.StatusPending,
+		ApprovalSteps:    steps,
+		Cost:             space.CostFor(req.StartTime, req.EndTime),
+		WidgetTokenID:    &tokenID,
+		RequesterName:    strings.TrimSpace(req.Name),
+		RequesterEmail:   strings.ToLower(strings.TrimSpace(req.Email)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	s.events.Wake()
+	return reservation, nil
+}
@@ -0,0 +1,169 @@
+// internal/services/retention_service.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// Names the retention jobs are registered under
+const (
+	RetentionJobReservations = "reservation-anonymization"
+	RetentionJobChatMessages = "chat-message-purge"
+	RetentionJobAuditLogs    = "audit-log-purge"
+)
+
+// RetentionService applies the data retention policy: past reservations lose their personal
+// details, and old chat messages and delivery logs are deleted. A period of 0 days keeps the
+// data forever.
+type RetentionService struct {
+	retentionRepo   interfaces.RetentionRepositoryInterface
+	chatService     *ChatService
+	reservationDays int // Days after its end a reservation is anonymized
+	chatMessageDays int // Days a chat message is kept, whatever its conversation's priority
+	auditLogDays    int // Days finished notifications, webhook deliveries, dead letters and activity are kept
+	logger          *slog.Logger
+}
+
+// NewRetentionService creates a new retention service
+func NewRetentionService(
+	retentionRepo interfaces.RetentionRepositoryInterface,
+	chatService *ChatService,
+	reservationDays int,
+	chatMessageDays int,
+	auditLogDays int,
+	logger *slog.Logger,
+) *RetentionService {
+	return &RetentionService{
+		retentionRepo:   retentionRepo,
+		chatService:     chatService,
+		reservationDays: reservationDays,
+		chatMessageDays: chatMessageDays,
+		auditLogDays:    auditLogDays,
+		logger:          logger,
+	}
+}
+
+// ========================================
+// SCHEDULED JOBS
+// ========================================
+
+// AnonymizeReservations strips personal details from reservations past their retention period
+func (s *RetentionService) AnonymizeReservations(ctx context.Context) error {
+	cutoff, ok := retentionCutoff(s.reservationDays, time.Now())
+	if !ok {
+		return nil
+	}
+
+	anonymized, err := s.retentionRepo.AnonymizeReservations(cutoff, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to anonymize reservations: %w", err)
+	}
+
+	if anonymized > 0 {
+		s.logger.Info("Past reservations anonymized", "count", anonymized, "retentionDays", s.reservationDays)
+	}
+	return nil
+}
+
+// PurgeChatMessages deletes chat messages past their retention period with their attachment files
+func (s *RetentionService) PurgeChatMessages(ctx context.Context) error {
+	cutoff, ok := retentionCutoff(s.chatMessageDays, time.Now())
+	if !ok {
+		return nil
+	}
+
+	fileURLs, deleted, err := s.retentionRepo.PurgeMessages(cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to purge chat messages: %w", err)
+	}
+
+	for _, fileURL := range fileURLs {
+		s.chatService.removeUploadedFile(fileURL)
+	}
+
+	if deleted > 0 {
+		s.logger.Info("Old chat messages purged", "count", deleted, "files", len(fileURLs), "retentionDays", s.chatMessageDays)
+	}
+	return nil
+}
+
+// PurgeAuditLogs deletes finished notifications, webhook deliveries, dead letters and activity
+// feed entries past their retention period
+func (s *RetentionService) PurgeAuditLogs(ctx context.Context) error {
+	cutoff, ok := retentionCutoff(s.auditLogDays, time.Now())
+	if !ok {
+		return nil
+	}
+
+	deleted, err := s.retentionRepo.PurgeAuditLogs(cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to purge audit logs: %w", err)
+	}
+
+	if deleted > 0 {
+		s.logger.Info("Old audit logs purged", "count", deleted, "retentionDays", s.auditLogDays)
+	}
+	return nil
+}
+
+// ========================================
+// REPORTING
+// ========================================
+
+// GetReport counts what each retention job would change if it ran now, without changing anything
+func (s *RetentionService) GetReport() (*dto.RetentionReport, error) {
+	now := time.Now()
+	categories := []struct {
+		name   string
+		action string
+		job    string
+		days   int
+		count  func(time.Time) (int64, error)
+	}{
+		{"reservations", "anonymize", RetentionJobReservations, s.reservationDays, s.retentionRepo.CountReservationsToAnonymize},
+		{"chat_messages", "purge", RetentionJobChatMessages, s.chatMessageDays, s.retentionRepo.CountMessages},
+		{"audit_logs", "purge", RetentionJobAuditLogs, s.auditLogDays, s.retentionRepo.CountAuditLogs},
+	}
+
+	report := &dto.RetentionReport{GeneratedAt: now}
+	for _, category := range categories {
+		entry := dto.RetentionCategoryReport{
+			Category:      category.name,
+			Action:        category.action,
+			Job:           category.job,
+			RetentionDays: category.days,
+		}
+
+		if cutoff, ok := retentionCutoff(category.days, now); ok {
+			affected, err := category.count(cutoff)
+			if err != nil {
+				return nil, fmt.Errorf("failed to count %s: %w", category.name, err)
+			}
+			entry.Cutoff = &cutoff
+			entry.Affected = affected
+		}
+
+		report.Categories = append(report.Categories, entry)
+	}
+
+	return report, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// retentionCutoff returns the time before which data is past a retention period of the given
+// days, and false when the period keeps data forever
+func retentionCutoff(days int, now time.Time) (time.Time, bool) {
+	if days <= 0 {
+		return time.Time{}, false
+	}
+	return now.AddDate(0, 0, -days), true
+}
@@ -0,0 +1,359 @@
+// internal/services/room_display_service.go
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+	"room-reservation-api/internal/websocket"
+)
+
+const (
+	// Prefix that makes display keys recognizable in logs and config files
+	displayKeyPrefix = "rd_"
+	// Time added by the display's one-tap extend action
+	displayExtension = 15 * time.Minute
+	// How far ahead the display looks for the next meeting
+	displayLookahead = 24 * time.Hour
+	// Minimum interval between last-seen updates for a display
+	displaySeenInterval = time.Minute
+)
+
+// RoomDisplayService serves door displays and kiosks mounted outside spaces
+type RoomDisplayService struct {
+	displayRepo        interfaces.RoomDisplayRepositoryInterface
+	spaceRepo          interfaces.SpaceRepositoryInterface
+	reservationRepo    interfaces.ReservationRepositoryInterface
+	reservationService *ReservationService
+	eventBus           *websocket.EventBus
+	logger             *slog.Logger
+}
+
+// NewRoomDisplayService creates a new room display service reading reservation changes from the manager's event bus
+func NewRoomDisplayService(
+	displayRepo interfaces.RoomDisplayRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	reservationService *ReservationService,
+	wsManager *websocket.Manager,
+	logger *slog.Logger,
+) *RoomDisplayService {
+	return &RoomDisplayService{
+		displayRepo:        displayRepo,
+		spaceRepo:          spaceRepo,
+		reservationRepo:    reservationRepo,
+		reservationService: reservationService,
+		eventBus:           wsManager.EventBus(),
+		logger:             logger,
+	}
+}
+
+// ========================================
+// DISPLAY REGISTRATION
+// ========================================
+
+// CreateDisplay registers a display for a space and returns its API key, which is not stored
+func (s *RoomDisplayService) CreateDisplay(spaceID uuid.UUID, req *dto.CreateRoomDisplayRequest, userID uuid.UUID) (*dto.RoomDisplayKeyResponse, error) {
+	if _, err := s.spaceRepo.GetByID(spaceID); err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	apiKey, err := generateDisplayKey()
+	if err != nil {
+		return nil, err
+	}
+
+	display, err := s.displayRepo.Create(&models.RoomDisplay{
+		SpaceID:     spaceID,
+		Name:        req.Name,
+		APIKeyHash:  hashDisplayKey(apiKey),
+		KeyPrefix:   apiKey[:len(displayKeyPrefix)+8],
+		CreatedByID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create display: %w", err)
+	}
+
+	return &dto.RoomDisplayKeyResponse{
+		RoomDisplayResponse: toRoomDisplayResponse(display),
+		APIKey:              apiKey,
+	}, nil
+}
+
+// GetSpaceDisplays lists the displays registered for a space
+func (s *RoomDisplayService) GetSpaceDisplays(spaceID uuid.UUID) ([]dto.RoomDisplayResponse, error) {
+	if _, err := s.spaceRepo.GetByID(spaceID); err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	displays, err := s.displayRepo.GetBySpace(spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get displays: %w", err)
+	}
+
+	responses := make([]dto.RoomDisplayResponse, len(displays))
+	for i, display := range displays {
+		responses[i] = toRoomDisplayResponse(display)
+	}
+	return responses, nil
+}
+
+// RevokeDisplay disables a display key
+func (s *RoomDisplayService) RevokeDisplay(displayID uuid.UUID) error {
+	display, err := s.displayRepo.GetByID(displayID)
+	if err != nil {
+		return fmt.Errorf("failed to get display: %w", err)
+	}
+
+	if display.IsRevoked() {
+		return errors.New("display is already revoked")
+	}
+
+	if err := s.displayRepo.Update(display.ID, map[string]interface{}{"revoked_at": time.Now()}); err != nil {
+		return fmt.Errorf("failed to revoke display: %w", err)
+	}
+	return nil
+}
+
+// Authenticate resolves an API key to an active display
+func (s *RoomDisplayService) Authenticate(apiKey string) (*models.RoomDisplay, error) {
+	if apiKey == "" {
+		return nil, errors.New("invalid display key")
+	}
+
+	display, err := s.displayRepo.GetByKeyHash(hashDisplayKey(apiKey))
+	if err != nil || display.IsRevoked() {
+		return nil, errors.New("invalid display key")
+	}
+
+	now := time.Now()
+	if display.LastSeenAt == nil || now.Sub(*display.LastSeenAt) > displaySeenInterval {
+		if err := s.displayRepo.Update(display.ID, map[string]interface{}{"last_seen_at": now}); err != nil {
+			s.logger.Warn("Failed to record display activity", "displayID", display.ID, "error", err)
+		}
+		display.LastSeenAt = &now
+	}
+
+	return display, nil
+}
+
+// ========================================
+// DISPLAY CONTENT
+// ========================================
+
+// GetStatus builds what the display shows: the meeting in progress and the next one
+func (s *RoomDisplayService) GetStatus(spaceID uuid.UUID) (*dto.RoomDisplayStatusResponse, error) {
+	space, err := s.spaceRepo.GetByID(spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	now := time.Now()
+	reservations, err := s.reservationRepo.GetConflictingReservations(spaceID, now, now.Add(displayLookahead))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservations: %w", err)
+	}
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].StartTime.Before(reservations[j].StartTime)
+	})
+
+	status := &dto.RoomDisplayStatusResponse{
+		SpaceID:     space.ID,
+		SpaceName:   space.Name,
+		Building:    space.Building,
+		Floor:       space.Floor,
+		RoomNumber:  space.RoomNumber,
+		Capacity:    space.Capacity,
+		State:       "available",
+		GeneratedAt: now,
+	}
+
+	var current *models.Reservation
+	for _, reservation := range reservations {
+		if reservation.Status != models.StatusConfirmed {
+			continue
+		}
+		if current == nil && !reservation.StartTime.After(now) && reservation.EndTime.After(now) {
+			current = reservation
+			status.Current = toRoomDisplayMeeting(reservation)
+			continue
+		}
+		if reservation.StartTime.After(now) {
+			status.Next = toRoomDisplayMeeting(reservation)
+			break
+		}
+	}
+
+	switch {
+	case current != nil:
+		status.State = "occupied"
+		status.CanExtend = s.canExtend(current, space, reservations)
+	case !space.IsAvailable():
+		status.State = "out_of_service"
+	case status.Next != nil:
+		status.FreeUntil = &status.Next.StartTime
+	}
+
+	return status, nil
+}
+
+// ExtendCurrentMeeting adds fifteen minutes to the meeting in progress
+func (s *RoomDisplayService) ExtendCurrentMeeting(spaceID uuid.UUID) (*dto.RoomDisplayStatusResponse, error) {
+	current, err := s.getCurrentReservation(spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.reservationService.ExtendReservation(current.ID, displayExtension); err != nil {
+		return nil, err
+	}
+	return s.GetStatus(spaceID)
+}
+
+// EndCurrentMeeting ends the meeting in progress and frees the room
+func (s *RoomDisplayService) EndCurrentMeeting(spaceID uuid.UUID) (*dto.RoomDisplayStatusResponse, error) {
+	current, err := s.getCurrentReservation(spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.reservationService.EndReservationEarly(current.ID); err != nil {
+		return nil, err
+	}
+	return s.GetStatus(spaceID)
+}
+
+// WaitForChange waits up to hold for a reservation event on the space after cursor.
+// It returns the new cursor and whether the display should refresh.
+func (s *RoomDisplayService) WaitForChange(ctx context.Context, spaceID uuid.UUID, cursor uint64, hold time.Duration) (uint64, bool) {
+	timer := time.NewTimer(hold)
+	defer timer.Stop()
+
+	for {
+		changed := s.eventBus.Changed()
+		events, latest, complete := s.eventBus.Since(cursor)
+		if !complete {
+			// Events were missed, refresh to be safe
+			return latest, true
+		}
+
+		affected := false
+		for _, event := range events {
+			cursor = event.Sequence
+			if data, ok := event.Data.(websocket.ReservationEventData); ok && data.SpaceID == spaceID {
+				affected = true
+			}
+		}
+		if affected {
+			return cursor, true
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return cursor, false
+		case <-ctx.Done():
+			return cursor, false
+		}
+	}
+}
+
+// LatestEvent returns the current position of the event bus
+func (s *RoomDisplayService) LatestEvent() uint64 {
+	return s.eventBus.Latest()
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// getCurrentReservation finds the confirmed reservation in progress in the space itself. Bookings
+// of a combined room or add-on bookings block the space but are not its display's to end or extend.
+func (s *RoomDisplayService) getCurrentReservation(spaceID uuid.UUID) (*models.Reservation, error) {
+	now := time.Now()
+	reservations, err := s.reservationRepo.GetConflictingReservations(spaceID, now, now.Add(time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservations: %w", err)
+	}
+
+	for _, reservation := range reservations {
+		if reservation.SpaceID == spaceID && reservation.IsActive() {
+			return reservation, nil
+		}
+	}
+	return nil, errors.New("no meeting in progress")
+}
+
+// canExtend checks the room stays free long enough after the current meeting
+func (s *RoomDisplayService) canExtend(current *models.Reservation, space *models.Space, reservations []*models.Reservation) bool {
+	newEnd := current.EndTime.Add(displayExtension)
+	if space.MaxBookingDuration > 0 && newEnd.Sub(current.StartTime) > time.Duration(space.MaxBookingDuration)*time.Minute {
+		return false
+	}
+
+	// The next booking's buffer time must stay free too
+	gap := space.BufferGap()
+	for _, reservation := range reservations {
+		if reservation.ID != current.ID && reservation.StartTime.Before(newEnd.Add(gap)) && reservation.EndTime.After(current.EndTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// toRoomDisplayMeeting converts a reservation for display, hiding private details
+func toRoomDisplayMeeting(reservation *models.Reservation) *dto.RoomDisplayMeeting {
+	meeting := &dto.RoomDisplayMeeting{
+		ReservationID: reservation.ID,
+		Title:         "Private meeting",
+		StartTime:     reservation.StartTime,
+		EndTime:       reservation.EndTime,
+		CheckedIn:     reservation.CheckInTime != nil,
+		IsPrivate:     reservation.IsPrivate,
+	}
+	if !reservation.IsPrivate {
+		meeting.Title = reservation.Title
+		meeting.OrganizerName = reservation.User.GetFullName()
+	}
+	return meeting
+}
+
+// toRoomDisplayResponse converts a display for admin listings
+func toRoomDisplayResponse(display *models.RoomDisplay) dto.RoomDisplayResponse {
+	return dto.RoomDisplayResponse{
+		ID:         display.ID,
+		SpaceID:    display.SpaceID,
+		Name:       display.Name,
+		KeyPrefix:  display.KeyPrefix,
+		LastSeenAt: display.LastSeenAt,
+		RevokedAt:  display.RevokedAt,
+		CreatedAt:  display.CreatedAt,
+	}
+}
+
+// generateDisplayKey creates a random API key for a display
+func generateDisplayKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate display key: %w", err)
+	}
+	return displayKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+// hashDisplayKey hashes an API key for storage and lookup
+func hashDisplayKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
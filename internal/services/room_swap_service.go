@@ -0,0 +1,455 @@
+// internal/services/room_swap_service.go
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// Rooms filled below this ratio are considered oversized for the meeting
+	undersizedOccupancyRatio = 0.3
+	// Ignore small absolute differences (e.g. 2 people in a 6-seat room)
+	minWastedSeats = 4
+	// Upper bound of reservations scanned per nightly run
+	maxSwapCandidates = 1000
+	// How far ahead the nightly run looks for meetings
+	swapLookahead = 36 * time.Hour
+)
+
+// Policies for meetings left in a far too large room after guests decline
+const (
+	DowngradePolicyOff     = "off"
+	DowngradePolicySuggest = "suggest" // Offer the organizer a smaller room
+	DowngradePolicyAuto    = "auto"    // Move the meeting and tell the organizer
+)
+
+// RoomSwapService proposes better-fitting rooms for upcoming reservations
+type RoomSwapService struct {
+	swapRepo            interfaces.RoomSwapRepositoryInterface
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	spaceRepo           interfaces.SpaceRepositoryInterface
+	notificationService *NotificationService
+	baseURL             string
+	downgradePolicy     string
+	downgradeRatio      float64 // Share of the room's capacity attendance must drop below for a downgrade
+	logger              *slog.Logger
+}
+
+// NewRoomSwapService creates a new room swap service
+func NewRoomSwapService(
+	swapRepo interfaces.RoomSwapRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	notificationService *NotificationService,
+	baseURL string,
+	downgradePolicy string,
+	downgradeRatio float64,
+	logger *slog.Logger,
+) *RoomSwapService {
+	if downgradePolicy != DowngradePolicyOff && downgradePolicy != DowngradePolicyAuto {
+		downgradePolicy = DowngradePolicySuggest
+	}
+
+	return &RoomSwapService{
+		swapRepo:            swapRepo,
+		reservationRepo:     reservationRepo,
+		spaceRepo:           spaceRepo,
+		notificationService: notificationService,
+		baseURL:             strings.TrimRight(baseURL, "/"),
+		downgradePolicy:     downgradePolicy,
+		downgradeRatio:      downgradeRatio,
+		logger:              logger,
+	}
+}
+
+// ========================================
+// SUGGESTION GENERATION
+// ========================================
+
+// RunNightlySuggestions is the scheduled entry point covering the upcoming meetings
+func (s *RoomSwapService) RunNightlySuggestions(ctx context.Context) error {
+	now := time.Now()
+	created, err := s.GenerateSuggestions(now, now.Add(swapLookahead))
+	if err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "Room swap suggestions generated", "count", created)
+	return nil
+}
+
+// GenerateSuggestions scans reservations starting within the window and
+// notifies organizers when a better-fitting room is free at the same time
+func (s *RoomSwapService) GenerateSuggestions(windowStart, windowEnd time.Time) (int, error) {
+	if _, err := s.swapRepo.ExpireStale(time.Now()); err != nil {
+		return 0, fmt.Errorf("failed to expire suggestions: %w", err)
+	}
+
+	// Reservations can run up to 12 hours, so widen the end bound for the range query
+	reservations, _, err := s.reservationRepo.GetReservationsByDateRange(windowStart, windowEnd.Add(12*time.Hour), 0, maxSwapCandidates)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reservations: %w", err)
+	}
+
+	created := 0
+	for _, reservation := range reservations {
+		if reservation.Status != models.StatusConfirmed || !reservation.StartTime.Before(windowEnd) {
+			continue
+		}
+
+		reason, ok := s.detectMismatch(reservation)
+		if !ok {
+			continue
+		}
+
+		// Only suggest once per reservation so declined offers are not repeated
+		suggested, err := s.swapRepo.HasSuggestionForReservation(reservation.ID)
+		if err != nil || suggested {
+			continue
+		}
+
+		candidate, err := s.findBetterRoom(reservation, reason)
+		if err != nil {
+			s.logger.Warn("Failed to look up swap candidates", "reservationID", reservation.ID, "error", err)
+			continue
+		}
+		if candidate == nil {
+			continue
+		}
+
+		if err := s.proposeSwap(reservation, candidate, reason); err != nil {
+			s.logger.Error("Failed to create room swap suggestion", "reservationID", reservation.ID, "error", err)
+			continue
+		}
+		created++
+	}
+
+	return created, nil
+}
+
+// detectMismatch checks if the participant count fits the booked room poorly
+func (s *RoomSwapService) detectMismatch(reservation *models.Reservation) (models.RoomSwapReason, bool) {
+	capacity := reservation.Space.Capacity
+	if capacity <= 0 {
+		return "", false
+	}
+
+	if reservation.ParticipantCount > capacity {
+		return models.RoomSwapReasonOversized, true
+	}
+
+	occupancy := float64(reservation.ParticipantCount) / float64(capacity)
+	if occupancy < undersizedOccupancyRatio && capacity-reservation.ParticipantCount >= minWastedSeats {
+		return models.RoomSwapReasonUndersized, true
+	}
+
+	return "", false
+}
+
+// findBetterRoom picks the smallest free room that fits, preferring the same building and type
+func (s *RoomSwapService) findBetterRoom(reservation *models.Reservation, reason models.RoomSwapReason) (*models.Space, error) {
+	// Spaces in soft launch are only offered to their pilot group
+	spaces, _, err := s.spaceRepo.GetAvailableSpaces(reservation.StartTime, reservation.EndTime, pilotViewerOf(&reservation.User), 0, 1000)
+	if err != nil {
+		return nil, err
+	}
+
+	current := reservation.Space
+	duration := int(reservation.EndTime.Sub(reservation.StartTime).Minutes())
+
+	var best *models.Space
+	for _, space := range spaces {
+		// Skip rooms that would need approval or fall under VIP rules
+		if space.ID == current.ID || space.RequiresApproval || space.IsVIP {
+			continue
+		}
+		if space.Capacity < reservation.ParticipantCount {
+			continue
+		}
+		if space.MaxBookingDuration > 0 && duration > space.MaxBookingDuration {
+			continue
+		}
+		if reason == models.RoomSwapReasonUndersized && space.Capacity >= current.Capacity {
+			continue
+		}
+
+		if best == nil || s.betterFit(space, best, &current) {
+			best = space
+		}
+	}
+
+	return best, nil
+}
+
+// betterFit compares two candidate rooms for a reservation currently in current
+func (s *RoomSwapService) betterFit(candidate, best, current *models.Space) bool {
+	if candidate.Capacity != best.Capacity {
+		return candidate.Capacity < best.Capacity
+	}
+	if (candidate.Building == current.Building) != (best.Building == current.Building) {
+		return candidate.Building == current.Building
+	}
+	return candidate.Type == current.Type && best.Type != current.Type
+}
+
+// proposeSwap stores the suggestion and notifies the organizer with a link to review it
+func (s *RoomSwapService) proposeSwap(reservation *models.Reservation, space *models.Space, reason models.RoomSwapReason) error {
+	token, err := generateSwapToken()
+	if err != nil {
+		return err
+	}
+
+	suggestion, err := s.swapRepo.Create(&models.RoomSwapSuggestion{
+		ReservationID:    reservation.ID,
+		UserID:           reservation.UserID,
+		CurrentSpaceID:   reservation.SpaceID,
+		SuggestedSpaceID: space.ID,
+		Reason:           reason,
+		Status:           models.RoomSwapStatusPending,
+		Token:            token,
+		ExpiresAt:        reservation.StartTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	fit := "more than the room holds"
+	if reason == models.RoomSwapReasonUndersized {
+		fit = "far fewer than the room holds"
+	}
+
+	title := fmt.Sprintf("A better room is available for \"%s\"", reservation.Title)
+	message := fmt.Sprintf(
+		"Your meeting on %s in %s (capacity %d) has %d participants, %s. %s in %s (capacity %d) is free at the same time.",
+		reservation.StartTime.Format("Mon Jan 2 15:04"),
+		reservation.Space.Name, reservation.Space.Capacity,
+		reservation.ParticipantCount, fit,
+		space.Name, space.Building, space.Capacity,
+	)
+
+	_, err = s.notificationService.Notify(reservation.UserID, models.NotificationTypeRoomSwapSuggestion, title, message, map[string]interface{}{
+		"reservation_id":     reservation.ID,
+		"suggestion_id":      suggestion.ID,
+		"suggested_space_id": space.ID,
+		"links": map[string]string{
+			"review": fmt.Sprintf("%s/api/v1/room-swaps/%s", s.baseURL, token),
+		},
+	})
+	return err
+}
+
+// ========================================
+// ATTENDANCE DROPS
+// ========================================
+
+// CheckAttendance looks for a smaller room once declines leave an upcoming meeting's room
+// filled below the downgrade ratio. Depending on the policy the room is offered to the
+// organizer or the meeting is moved there right away.
+func (s *RoomSwapService) CheckAttendance(reservation *models.Reservation) error {
+	if s.downgradePolicy == DowngradePolicyOff || reservation.Status != models.StatusConfirmed || !reservation.StartTime.After(time.Now()) {
+		return nil
+	}
+
+	capacity := reservation.Space.Capacity
+	if capacity <= 0 || float64(reservation.ParticipantCount)/float64(capacity) >= s.downgradeRatio {
+		return nil
+	}
+
+	// Like the nightly run, a reservation gets one offer so a declined one is not repeated
+	suggested, err := s.swapRepo.HasSuggestionForReservation(reservation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check suggestions: %w", err)
+	}
+	if suggested {
+		return nil
+	}
+
+	candidate, err := s.findBetterRoom(reservation, models.RoomSwapReasonUndersized)
+	if err != nil {
+		return fmt.Errorf("failed to look up smaller rooms: %w", err)
+	}
+	if candidate == nil {
+		return nil
+	}
+
+	if s.downgradePolicy == DowngradePolicyAuto {
+		return s.downgrade(reservation, candidate)
+	}
+	return s.proposeSwap(reservation, candidate, models.RoomSwapReasonUndersized)
+}
+
+// downgrade moves the reservation into the smaller room, records the move as an accepted
+// suggestion and tells the organizer
+func (s *RoomSwapService) downgrade(reservation *models.Reservation, space *models.Space) error {
+	token, err := generateSwapToken()
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.reservationRepo.MoveToSpace(reservation.ID, reservation.SpaceID, space.ID); err != nil {
+		if errors.Is(err, interfaces.ErrSpaceTaken) || errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to move reservation: %w", err)
+	}
+
+	now := time.Now()
+	if _, err := s.swapRepo.Create(&models.RoomSwapSuggestion{
+		ReservationID:    reservation.ID,
+		UserID:           reservation.UserID,
+		CurrentSpaceID:   reservation.SpaceID,
+		SuggestedSpaceID: space.ID,
+		Reason:           models.RoomSwapReasonUndersized,
+		Status:           models.RoomSwapStatusAccepted,
+		Token:            token,
+		ExpiresAt:        reservation.StartTime,
+		RespondedAt:      &now,
+	}); err != nil {
+		s.logger.Warn("Failed to record room downgrade", "reservationID", reservation.ID, "error", err)
+	}
+
+	title := fmt.Sprintf("\"%s\" moved to a smaller room", reservation.Title)
+	message := fmt.Sprintf(
+		"After guests declined, your meeting on %s has %d participants, far fewer than %s holds (capacity %d). It now takes place in %s in %s (capacity %d).",
+		reservation.StartTime.Format("Mon Jan 2 15:04"),
+		reservation.ParticipantCount, reservation.Space.Name, reservation.Space.Capacity,
+		space.Name, space.Building, space.Capacity,
+	)
+
+	_, err = s.notificationService.Notify(reservation.UserID, models.NotificationTypeRoomDowngraded, title, message, map[string]interface{}{
+		"reservation_id":    reservation.ID,
+		"previous_space_id": reservation.SpaceID,
+		"space_id":          space.ID,
+	})
+	return err
+}
+
+// ========================================
+// ONE-CLICK RESPONSES
+// ========================================
+
+// GetSuggestion shows a suggestion from its link, without responding to it
+func (s *RoomSwapService) GetSuggestion(token string) (*dto.RoomSwapSuggestionResponse, error) {
+	if token == "" {
+		return nil, errors.New("invalid or expired suggestion link")
+	}
+
+	suggestion, err := s.swapRepo.GetByToken(token)
+	if err != nil || suggestion.Reservation == nil || suggestion.CurrentSpace == nil || suggestion.SuggestedSpace == nil {
+		return nil, errors.New("invalid or expired suggestion link")
+	}
+
+	status := suggestion.Status
+	if status == models.RoomSwapStatusPending && !suggestion.IsActionable() {
+		status = models.RoomSwapStatusExpired
+	}
+
+	return &dto.RoomSwapSuggestionResponse{
+		ReservationID:  suggestion.ReservationID,
+		Title:          suggestion.Reservation.Title,
+		StartTime:      suggestion.Reservation.StartTime,
+		EndTime:        suggestion.Reservation.EndTime,
+		Participants:   suggestion.Reservation.ParticipantCount,
+		Reason:         string(suggestion.Reason),
+		CurrentSpace:   toSwapSpaceResponse(suggestion.CurrentSpace),
+		SuggestedSpace: toSwapSpaceResponse(suggestion.SuggestedSpace),
+		Status:         string(status),
+		ExpiresAt:      suggestion.ExpiresAt,
+		RespondedAt:    suggestion.RespondedAt,
+	}, nil
+}
+
+// AcceptSuggestion moves the reservation into the suggested room. The room is checked again
+// when moving, it may have been booked since the suggestion was made.
+func (s *RoomSwapService) AcceptSuggestion(token string) (*models.Reservation, error) {
+	suggestion, err := s.getActionableSuggestion(token)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.reservationRepo.MoveToSpace(suggestion.ReservationID, suggestion.CurrentSpaceID, suggestion.SuggestedSpaceID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		s.swapRepo.Update(suggestion.ID, map[string]interface{}{"status": models.RoomSwapStatusExpired})
+		return nil, errors.New("reservation has changed since the suggestion was made")
+	case errors.Is(err, interfaces.ErrSpaceTaken):
+		s.swapRepo.Update(suggestion.ID, map[string]interface{}{"status": models.RoomSwapStatusExpired})
+		return nil, errors.New("suggested room is no longer available")
+	case err != nil:
+		return nil, fmt.Errorf("failed to move reservation: %w", err)
+	}
+
+	s.swapRepo.Update(suggestion.ID, map[string]interface{}{
+		"status":       models.RoomSwapStatusAccepted,
+		"responded_at": time.Now(),
+	})
+
+	return updated, nil
+}
+
+// DeclineSuggestion keeps the reservation in its current room
+func (s *RoomSwapService) DeclineSuggestion(token string) error {
+	suggestion, err := s.getActionableSuggestion(token)
+	if err != nil {
+		return err
+	}
+
+	if err := s.swapRepo.Update(suggestion.ID, map[string]interface{}{
+		"status":       models.RoomSwapStatusDeclined,
+		"responded_at": time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to decline suggestion: %w", err)
+	}
+
+	return nil
+}
+
+// toSwapSpaceResponse converts a room of a suggestion to the response
+func toSwapSpaceResponse(space *models.Space) dto.RoomSwapSpaceResponse {
+	return dto.RoomSwapSpaceResponse{
+		ID:       space.ID,
+		Name:     space.Name,
+		Building: space.Building,
+		Capacity: space.Capacity,
+	}
+}
+
+// getActionableSuggestion resolves a token to a pending, unexpired suggestion
+func (s *RoomSwapService) getActionableSuggestion(token string) (*models.RoomSwapSuggestion, error) {
+	if token == "" {
+		return nil, errors.New("invalid or expired suggestion link")
+	}
+
+	suggestion, err := s.swapRepo.GetByToken(token)
+	if err != nil {
+		return nil, errors.New("invalid or expired suggestion link")
+	}
+
+	if !suggestion.IsActionable() {
+		return nil, errors.New("invalid or expired suggestion link")
+	}
+
+	return suggestion, nil
+}
+
+// generateSwapToken creates a random URL-safe token
+func generateSwapToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,305 @@
+// internal/services/saved_search_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// Saved searches a user can keep
+	maxSavedSearches = 20
+	// Shortest freed slot worth an alert when the search does not say
+	defaultSavedSearchMinMinutes = 30
+	// Minimum interval between two alerts of the same search
+	savedSearchAlertInterval = 10 * time.Minute
+)
+
+// SavedSearchService manages the space searches users save and alerts them when a
+// cancellation or an early check-out frees a space matching one of them
+type SavedSearchService struct {
+	savedSearchRepo     interfaces.SavedSearchRepositoryInterface
+	spaceRepo           interfaces.SpaceRepositoryInterface
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	notificationService *NotificationService
+	logger              *slog.Logger
+}
+
+// NewSavedSearchService creates a new saved search service
+func NewSavedSearchService(
+	savedSearchRepo interfaces.SavedSearchRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	notificationService *NotificationService,
+	logger *slog.Logger,
+) *SavedSearchService {
+	return &SavedSearchService{
+		savedSearchRepo:     savedSearchRepo,
+		spaceRepo:           spaceRepo,
+		reservationRepo:     reservationRepo,
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// ========================================
+// SAVED SEARCHES
+// ========================================
+
+// GetSearches lists the saved searches of the user by name
+func (s *SavedSearchService) GetSearches(userID uuid.UUID) ([]dto.SavedSearchResponse, error) {
+	searches, err := s.savedSearchRepo.GetByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved searches: %w", err)
+	}
+
+	now := time.Now()
+	responses := make([]dto.SavedSearchResponse, len(searches))
+	for i, search := range searches {
+		responses[i] = toSavedSearchResponse(search, now)
+	}
+	return responses, nil
+}
+
+// CreateSearch saves space search criteria for the user
+func (s *SavedSearchService) CreateSearch(req *dto.SavedSearchRequest, userID uuid.UUID) (*dto.SavedSearchResponse, error) {
+	count, err := s.savedSearchRepo.CountByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count saved searches: %w", err)
+	}
+	if count >= maxSavedSearches {
+		return nil, fmt.Errorf("cannot have more than %d saved searches", maxSavedSearches)
+	}
+
+	search := &models.SavedSearch{UserID: userID}
+	applySavedSearchRequest(search, req)
+
+	created, err := s.savedSearchRepo.Create(search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create saved search: %w", err)
+	}
+
+	response := toSavedSearchResponse(created, time.Now())
+	return &response, nil
+}
+
+// UpdateSearch replaces a saved search of the user
+func (s *SavedSearchService) UpdateSearch(id uuid.UUID, req *dto.SavedSearchRequest, userID uuid.UUID) (*dto.SavedSearchResponse, error) {
+	search, err := s.ownSearch(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	applySavedSearchRequest(search, req)
+	updated, err := s.savedSearchRepo.Update(id, map[string]interface{}{
+		"name":                 search.Name,
+		"min_capacity":         search.MinCapacity,
+		"types":                search.Types,
+		"building":             search.Building,
+		"amenities":            search.Amenities,
+		"window_start":         search.WindowStart,
+		"window_end":           search.WindowEnd,
+		"min_duration_minutes": search.MinDurationMinutes,
+		"alerts_enabled":       search.AlertsEnabled,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update saved search: %w", err)
+	}
+
+	response := toSavedSearchResponse(updated, time.Now())
+	return &response, nil
+}
+
+// DeleteSearch removes a saved search of the user
+func (s *SavedSearchService) DeleteSearch(id, userID uuid.UUID) error {
+	if _, err := s.ownSearch(id, userID); err != nil {
+		return err
+	}
+
+	if err := s.savedSearchRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	return nil
+}
+
+// ========================================
+// ALERTS
+// ========================================
+
+// AlertCancellation is the outbox handler of cancelled reservations: it notifies the users whose
+// saved search matches the space and the freed time, when the space is still free then. The
+// user who cancelled is not alerted, and a search alerts at most once per interval.
+func (s *SavedSearchService) AlertCancellation(ctx context.Context, event *models.OutboxEvent) error {
+	change, err := decodeReservationChange(event)
+	if err != nil {
+		return err
+	}
+	return s.alertFreedTime(ctx, change.SpaceID, change.UserID, change.StartTime, change.EndTime, "A cancellation")
+}
+
+// AlertRelease is the outbox handler of released reservations, alerting saved searches of the
+// time an organizer gave back by checking out early like AlertCancellation does
+func (s *SavedSearchService) AlertRelease(ctx context.Context, event *models.OutboxEvent) error {
+	var release models.ReservationRelease
+	if err := json.Unmarshal(event.Payload, &release); err != nil {
+		return fmt.Errorf("invalid reservation release payload: %w", err)
+	}
+	return s.alertFreedTime(ctx, release.SpaceID, release.UserID, release.StartTime, release.EndTime, "An early check-out")
+}
+
+// alertFreedTime notifies the users whose saved search matches the space and the freed time;
+// cause tells them how it was freed
+func (s *SavedSearchService) alertFreedTime(ctx context.Context, spaceID, freedBy uuid.UUID, freedStart, freedEnd time.Time, cause string) error {
+	now := time.Now()
+	if freedStart.Before(now) {
+		freedStart = now
+	}
+	if !freedEnd.After(freedStart) {
+		return nil
+	}
+
+	space, err := s.spaceRepo.GetByID(spaceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get space: %w", err)
+	}
+	if !space.IsAvailable() {
+		return nil
+	}
+
+	searches, err := s.savedSearchRepo.GetAlertCandidates(freedStart, freedEnd)
+	if err != nil {
+		return fmt.Errorf("failed to get saved searches: %w", err)
+	}
+
+	for _, search := range searches {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if search.UserID == freedBy || !search.Matches(space) {
+			continue
+		}
+		if search.LastAlertedAt != nil && now.Sub(*search.LastAlertedAt) < savedSearchAlertInterval {
+			continue
+		}
+
+		start, end := freedStart, freedEnd
+		if search.WindowStart != nil && search.WindowStart.After(start) {
+			start = *search.WindowStart
+		}
+		if search.WindowEnd != nil && search.WindowEnd.Before(end) {
+			end = *search.WindowEnd
+		}
+		if end.Sub(start) < time.Duration(search.MinDurationMinutes)*time.Minute {
+			continue
+		}
+
+		free, err := s.reservationRepo.CheckTimeSlotAvailability(space.ID, start, end, nil)
+		if err != nil {
+			return fmt.Errorf("failed to check availability: %w", err)
+		}
+		if !free {
+			continue
+		}
+
+		s.alert(search, space, start, end, now, cause)
+	}
+	return nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// alert notifies the owner of a search that a matching space is free
+func (s *SavedSearchService) alert(search *models.SavedSearch, space *models.Space, start, end, now time.Time, cause string) {
+	title := fmt.Sprintf("%s is now free", space.Name)
+	message := fmt.Sprintf("%s freed %s in %s from %s to %s UTC, matching your saved search \"%s\".",
+		cause, space.Name, space.Building, start.UTC().Format("Mon 2 Jan 15:04"), end.UTC().Format("15:04"), search.Name)
+	data := map[string]interface{}{
+		"saved_search_id": search.ID,
+		"space_id":        space.ID,
+		"start_time":      start,
+		"end_time":        end,
+	}
+
+	if _, err := s.notificationService.Notify(search.UserID, models.NotificationTypeSavedSearchMatch, title, message, data); err != nil {
+		s.logger.Warn("Failed to send saved search alert", "searchID", search.ID, "userID", search.UserID, "error", err)
+		return
+	}
+	if _, err := s.savedSearchRepo.Update(search.ID, map[string]interface{}{"last_alerted_at": now}); err != nil {
+		s.logger.Warn("Failed to record saved search alert", "searchID", search.ID, "error", err)
+	}
+}
+
+// ownSearch retrieves a saved search, hiding the searches of other users
+func (s *SavedSearchService) ownSearch(id, userID uuid.UUID) (*models.SavedSearch, error) {
+	search, err := s.savedSearchRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get saved search: %w", err)
+	}
+	if search.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return search, nil
+}
+
+// applySavedSearchRequest copies a saved search request onto a saved search
+func applySavedSearchRequest(search *models.SavedSearch, req *dto.SavedSearchRequest) {
+	search.Name = req.Name
+	search.MinCapacity = req.MinCapacity
+	search.Types = pq.StringArray(req.Types)
+	search.Building = req.Building
+	search.Amenities = pq.StringArray(req.Amenities)
+	search.WindowStart = req.WindowStart
+	search.WindowEnd = req.WindowEnd
+	search.MinDurationMinutes = req.MinDurationMinutes
+	if search.MinDurationMinutes == 0 {
+		search.MinDurationMinutes = defaultSavedSearchMinMinutes
+	}
+	search.AlertsEnabled = req.AlertsEnabled == nil || *req.AlertsEnabled
+}
+
+// toSavedSearchResponse converts a saved search for the user
+func toSavedSearchResponse(search *models.SavedSearch, now time.Time) dto.SavedSearchResponse {
+	response := dto.SavedSearchResponse{
+		ID:                 search.ID,
+		Name:               search.Name,
+		MinCapacity:        search.MinCapacity,
+		Types:              []string(search.Types),
+		Building:           search.Building,
+		Amenities:          []string(search.Amenities),
+		WindowStart:        search.WindowStart,
+		WindowEnd:          search.WindowEnd,
+		MinDurationMinutes: search.MinDurationMinutes,
+		AlertsEnabled:      search.AlertsEnabled,
+		Expired:            search.IsExpired(now),
+		LastAlertedAt:      search.LastAlertedAt,
+		CreatedAt:          search.CreatedAt,
+		UpdatedAt:          search.UpdatedAt,
+	}
+	if response.Types == nil {
+		response.Types = []string{}
+	}
+	if response.Amenities == nil {
+		response.Amenities = []string{}
+	}
+	return response
+}
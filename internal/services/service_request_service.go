@@ -0,0 +1,337 @@
+// internal/services/service_request_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// ServiceRequestService manages the facilities catalog, the services organizers order with their
+// reservations (catering, AV support, extra chairs) and the queue facility staff fulfill them from
+type ServiceRequestService struct {
+	serviceRepo         interfaces.ServiceRequestRepositoryInterface
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	notificationService *NotificationService
+	logger              *slog.Logger
+}
+
+// NewServiceRequestService creates a new service request service
+func NewServiceRequestService(
+	serviceRepo interfaces.ServiceRequestRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	notificationService *NotificationService,
+	logger *slog.Logger,
+) *ServiceRequestService {
+	return &ServiceRequestService{
+		serviceRepo:         serviceRepo,
+		reservationRepo:     reservationRepo,
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// ========================================
+// CATALOG
+// ========================================
+
+// GetCatalog lists the services of the catalog; inactive ones are only listed for admins
+func (s *ServiceRequestService) GetCatalog(activeOnly bool) ([]*models.ServiceItem, error) {
+	items, err := s.serviceRepo.GetItems(activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service catalog: %w", err)
+	}
+	return items, nil
+}
+
+// CreateItem adds a service to the catalog
+func (s *ServiceRequestService) CreateItem(req *dto.CreateServiceItemRequest) (*models.ServiceItem, error) {
+	item := &models.ServiceItem{
+		Name:            req.Name,
+		Category:        models.ServiceCategory(req.Category),
+		Description:     req.Description,
+		UnitPrice:       req.UnitPrice,
+		LeadTimeMinutes: req.LeadTimeMinutes,
+		MaxQuantity:     req.MaxQuantity,
+		IsActive:        true,
+	}
+	if err := s.serviceRepo.CreateItem(item); err != nil {
+		return nil, fmt.Errorf("failed to create service: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateItem edits a catalog service. Requests already placed keep the price they were ordered at.
+func (s *ServiceRequestService) UpdateItem(itemID uuid.UUID, req *dto.UpdateServiceItemRequest) (*models.ServiceItem, error) {
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.UnitPrice != nil {
+		updates["unit_price"] = *req.UnitPrice
+	}
+	if req.LeadTimeMinutes != nil {
+		updates["lead_time_minutes"] = *req.LeadTimeMinutes
+	}
+	if req.MaxQuantity != nil {
+		updates["max_quantity"] = *req.MaxQuantity
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+	if len(updates) == 0 {
+		return nil, errors.New("no fields to update")
+	}
+
+	item, err := s.serviceRepo.UpdateItem(itemID, updates)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update service: %w", err)
+	}
+	return item, nil
+}
+
+// ========================================
+// ORGANIZER REQUESTS
+// ========================================
+
+// GetReservationServices lists the services ordered with a reservation and their total cost
+func (s *ServiceRequestService) GetReservationServices(reservationID, userID uuid.UUID) (*dto.ReservationServicesResponse, error) {
+	if _, err := s.organizedReservation(reservationID, userID); err != nil {
+		return nil, err
+	}
+
+	requests, err := s.serviceRepo.GetByReservation(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service requests: %w", err)
+	}
+
+	response := &dto.ReservationServicesResponse{
+		ReservationID: reservationID,
+		Requests:      requests,
+	}
+	for _, request := range requests {
+		if request.Status != models.ServiceRequestRejected && request.Status != models.ServiceRequestCancelled {
+			response.TotalCost += request.Cost
+		}
+	}
+	response.TotalCost = roundCents(response.TotalCost)
+	return response, nil
+}
+
+// OrderService orders a catalog service with a reservation. The service must be ordered at least
+// its lead time before the reservation starts; its price is recorded with the request.
+func (s *ServiceRequestService) OrderService(reservationID uuid.UUID, req *dto.CreateServiceRequestRequest, userID uuid.UUID) (*models.ServiceRequest, error) {
+	reservation, err := s.organizedReservation(reservationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Status != models.StatusConfirmed && reservation.Status != models.StatusPending {
+		return nil, fmt.Errorf("services cannot be ordered for a %s reservation", reservation.Status)
+	}
+
+	item, err := s.serviceRepo.GetItemByID(req.ServiceItemID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("service not found")
+		}
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+	if !item.IsActive {
+		return nil, fmt.Errorf("%s is no longer offered", item.Name)
+	}
+	if req.Quantity > item.MaxQuantity {
+		return nil, fmt.Errorf("at most %d of %s can be ordered", item.MaxQuantity, item.Name)
+	}
+	if time.Until(reservation.StartTime) < item.LeadTime() {
+		return nil, fmt.Errorf("%s must be ordered at least %s before the reservation starts", item.Name, item.LeadTime())
+	}
+
+	request := &models.ServiceRequest{
+		ReservationID: reservationID,
+		ServiceItemID: item.ID,
+		RequestedByID: userID,
+		Quantity:      req.Quantity,
+		Notes:         req.Notes,
+		UnitPrice:     item.UnitPrice,
+		Cost:          item.CostFor(req.Quantity),
+		Status:        models.ServiceRequestRequested,
+		DueAt:         reservation.StartTime,
+	}
+	if err := s.serviceRepo.Create(request); err != nil {
+		return nil, fmt.Errorf("failed to create service request: %w", err)
+	}
+	request.ServiceItem = item
+
+	s.logger.Info("Service ordered", "reservationID", reservationID, "serviceItemID", item.ID, "quantity", req.Quantity)
+	return request, nil
+}
+
+// CancelServiceRequest withdraws a service that was not delivered yet
+func (s *ServiceRequestService) CancelServiceRequest(reservationID, requestID, userID uuid.UUID) error {
+	if _, err := s.organizedReservation(reservationID, userID); err != nil {
+		return err
+	}
+
+	request, err := s.serviceRepo.GetByID(requestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to get service request: %w", err)
+	}
+	if request.ReservationID != reservationID {
+		return gorm.ErrRecordNotFound
+	}
+	if !request.IsOpen() {
+		return fmt.Errorf("service request is already %s", request.Status)
+	}
+
+	now := time.Now()
+	if _, err := s.serviceRepo.Update(requestID, map[string]interface{}{
+		"status":            models.ServiceRequestCancelled,
+		"status_changed_at": now,
+	}); err != nil {
+		return fmt.Errorf("failed to cancel service request: %w", err)
+	}
+	return nil
+}
+
+// ========================================
+// FULFILLMENT QUEUE
+// ========================================
+
+// GetQueue lists the service requests for facility staff, soonest due first
+func (s *ServiceRequestService) GetQueue(filters interfaces.ServiceQueueFilters, offset, limit int) ([]*models.ServiceRequest, int64, error) {
+	requests, total, err := s.serviceRepo.GetQueue(filters, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get service queue: %w", err)
+	}
+	return requests, total, nil
+}
+
+// UpdateStatus moves a service request along as facility staff work on it. The organizer is
+// notified when it is rejected.
+func (s *ServiceRequestService) UpdateStatus(requestID uuid.UUID, req *dto.UpdateServiceRequestStatusRequest, staffID uuid.UUID) (*models.ServiceRequest, error) {
+	request, err := s.serviceRepo.GetByID(requestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get service request: %w", err)
+	}
+
+	status := models.ServiceRequestStatus(req.Status)
+	if !request.CanMoveTo(status) {
+		return nil, fmt.Errorf("a %s service request cannot be %s", request.Status, status)
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":            status,
+		"handled_by_id":     staffID,
+		"status_changed_at": now,
+	}
+	if req.StaffNotes != "" {
+		updates["staff_notes"] = req.StaffNotes
+	}
+	if status == models.ServiceRequestFulfilled {
+		updates["fulfilled_at"] = now
+	}
+
+	updated, err := s.serviceRepo.Update(requestID, updates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update service request: %w", err)
+	}
+
+	if status == models.ServiceRequestRejected {
+		s.notifyRejected(updated)
+	}
+	return updated, nil
+}
+
+// SyncReservationChange keeps the services of a reservation in step with it: they move with the
+// reservation and are cancelled with it. It handles reservation_updated and reservation_cancelled
+// events of the outbox.
+func (s *ServiceRequestService) SyncReservationChange(ctx context.Context, event *models.OutboxEvent) error {
+	var change models.ReservationChange
+	if err := json.Unmarshal(event.Payload, &change); err != nil {
+		return fmt.Errorf("failed to decode reservation change: %w", err)
+	}
+
+	switch models.ReservationStatus(change.Status) {
+	case models.StatusCancelled, models.StatusRejected:
+		cancelled, err := s.serviceRepo.CancelOpenForReservation(change.ReservationID, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to cancel service requests: %w", err)
+		}
+		if cancelled > 0 {
+			s.logger.Info("Service requests cancelled with reservation", "reservationID", change.ReservationID, "count", cancelled)
+		}
+	default:
+		if err := s.serviceRepo.MoveOpenForReservation(change.ReservationID, change.StartTime); err != nil {
+			return fmt.Errorf("failed to move service requests: %w", err)
+		}
+	}
+	return nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// organizedReservation loads a reservation and checks the user organizes it
+func (s *ServiceRequestService) organizedReservation(reservationID, userID uuid.UUID) (*models.Reservation, error) {
+	reservation, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if !reservation.IsOrganizer(userID) {
+		return nil, dto.ErrAccessDenied
+	}
+	return reservation, nil
+}
+
+// notifyRejected tells the organizers of the reservation a service they ordered will not be delivered
+func (s *ServiceRequestService) notifyRejected(request *models.ServiceRequest) {
+	reservation, err := s.reservationRepo.GetByID(request.ReservationID)
+	if err != nil {
+		s.logger.Error("Failed to load reservation of rejected service request", "requestID", request.ID, "error", err)
+		return
+	}
+
+	name := "A service"
+	if request.ServiceItem != nil {
+		name = request.ServiceItem.Name
+	}
+	message := fmt.Sprintf("%s ordered for \"%s\" on %s cannot be provided.", name, reservation.Title, reservation.StartTime.Format("Jan 2 15:04"))
+	if request.StaffNotes != "" {
+		message += " " + request.StaffNotes
+	}
+
+	data := map[string]interface{}{
+		"reservation_id":     reservation.ID,
+		"service_request_id": request.ID,
+	}
+	if err := s.notificationService.NotifyOrganizers(reservation, models.NotificationTypeServiceRequestRejected, "Service request rejected", message, data); err != nil {
+		s.logger.Error("Failed to notify rejected service request", "requestID", request.ID, "error", err)
+	}
+}
@@ -0,0 +1,306 @@
+// internal/services/space_catalog_csv.go
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+)
+
+// catalogCSVColumns are the columns of a space catalog CSV. Lists and nested settings are
+// JSON cells; pilot departments are separated by semicolons.
+var catalogCSVColumns = []string{
+	"building", "name", "type", "capacity", "floor", "room_number", "status", "description", "surface",
+	"price_per_hour", "price_per_day", "price_per_month", "cost_per_hour", "manager_email",
+	"requires_approval", "booking_advance_time", "max_booking_duration", "buffer_before", "buffer_after",
+	"cancellation_window", "early_release_minutes", "preemption_tier", "is_vip", "is_premium", "energy_rating", "check_in_presence",
+	"latitude", "longitude", "geofence_radius", "pilot_until", "pilot_departments",
+	"equipment", "attributes", "photos", "approval_rule", "vip_blocks",
+}
+
+// ========================================
+// CSV EXPORT
+// ========================================
+
+// RenderCatalogCSV renders a space catalog as CSV, one line per space
+func (s *SpaceCatalogService) RenderCatalogCSV(catalog *dto.SpaceCatalog) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write(catalogCSVColumns)
+	for _, entry := range catalog.Spaces {
+		var latitude, longitude, pilotUntil string
+		if entry.Latitude != nil && entry.Longitude != nil {
+			latitude = formatCatalogFloat(*entry.Latitude)
+			longitude = formatCatalogFloat(*entry.Longitude)
+		}
+		if entry.PilotUntil != nil {
+			pilotUntil = entry.PilotUntil.UTC().Format(time.RFC3339)
+		}
+
+		record := []string{
+			entry.Building,
+			entry.Name,
+			entry.Type,
+			strconv.Itoa(entry.Capacity),
+			strconv.Itoa(entry.Floor),
+			entry.RoomNumber,
+			entry.Status,
+			entry.Description,
+			formatCatalogFloat(entry.Surface),
+			formatCatalogFloat(entry.PricePerHour),
+			formatCatalogFloat(entry.PricePerDay),
+			formatCatalogFloat(entry.PricePerMonth),
+			formatCatalogFloat(entry.CostPerHour),
+			entry.ManagerEmail,
+			strconv.FormatBool(entry.RequiresApproval),
+			strconv.Itoa(entry.BookingAdvanceTime),
+			strconv.Itoa(entry.MaxBookingDuration),
+			strconv.Itoa(entry.BufferBefore),
+			strconv.Itoa(entry.BufferAfter),
+			strconv.Itoa(entry.CancellationWindow),
+			strconv.Itoa(entry.EarlyReleaseMinutes),
+			strconv.Itoa(entry.PreemptionTier),
+			strconv.FormatBool(entry.IsVIP),
+			strconv.FormatBool(entry.IsPremium),
+			entry.EnergyRating,
+			entry.CheckInPresence,
+			latitude,
+			longitude,
+			strconv.Itoa(entry.GeofenceRadius),
+			pilotUntil,
+			strings.Join(entry.PilotDepartments, ";"),
+		}
+
+		for _, value := range []interface{}{entry.Equipment, entry.Attributes, entry.Photos, entry.ApprovalRule, entry.VIPBlocks} {
+			cell, err := catalogJSONCell(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render catalog: %w", err)
+			}
+			record = append(record, cell)
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to render catalog: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// formatCatalogFloat formats a number without trailing zeros
+func formatCatalogFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// catalogJSONCell renders a list or nested setting as a JSON cell, empty when it is not set
+func catalogJSONCell(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	if string(data) == "null" {
+		return "", nil
+	}
+	return string(data), nil
+}
+
+// ========================================
+// CSV IMPORT
+// ========================================
+
+// ImportCatalogCSV adds or replaces the spaces of a catalog CSV like ImportCatalog. The building
+// and name columns are required; missing columns leave the fields at their defaults, and empty
+// approval_rule and vip_blocks cells leave the space's rule and blocks unchanged.
+func (s *SpaceCatalogService) ImportCatalogCSV(file io.Reader, dryRun bool, userID uuid.UUID) (*dto.SpaceCatalogImportResponse, error) {
+	reader := csv.NewReader(io.LimitReader(file, maxImportSheetSize))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV file: %w", err)
+	}
+
+	if len(records) < 2 {
+		return nil, errors.New("file has no data rows")
+	}
+	if len(records)-1 > maxCatalogSpaces {
+		return nil, fmt.Errorf("file exceeds the maximum of %d spaces", maxCatalogSpaces)
+	}
+
+	// Spreadsheet tools often start UTF-8 exports with a byte order mark
+	header := records[0]
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"building", "name"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing column %q", required)
+		}
+	}
+
+	var rows []*catalogRow
+	for i, record := range records[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+		// Row numbers match the file opened in a spreadsheet, where the header is row 1
+		rows = append(rows, parseCatalogRecord(i+2, record, columns))
+	}
+
+	return s.importRows(rows, dryRun, userID)
+}
+
+// catalogRecord reads the cells of one CSV row, collecting the cells it cannot parse
+type catalogRecord struct {
+	record  []string
+	columns map[string]int
+	errors  []string
+}
+
+// parseCatalogRecord converts one CSV row to a catalog entry
+func parseCatalogRecord(number int, record []string, columns map[string]int) *catalogRow {
+	r := &catalogRecord{record: record, columns: columns}
+	entry := dto.SpaceCatalogEntry{
+		Building:            r.text("building"),
+		Name:                r.text("name"),
+		Type:                r.text("type"),
+		Capacity:            r.int("capacity"),
+		Floor:               r.int("floor"),
+		RoomNumber:          r.text("room_number"),
+		Status:              r.text("status"),
+		Description:         r.text("description"),
+		Surface:             r.float("surface"),
+		PricePerHour:        r.float("price_per_hour"),
+		PricePerDay:         r.float("price_per_day"),
+		PricePerMonth:       r.float("price_per_month"),
+		CostPerHour:         r.float("cost_per_hour"),
+		ManagerEmail:        r.text("manager_email"),
+		RequiresApproval:    r.bool("requires_approval"),
+		BookingAdvanceTime:  r.int("booking_advance_time"),
+		MaxBookingDuration:  r.int("max_booking_duration"),
+		BufferBefore:        r.int("buffer_before"),
+		BufferAfter:         r.int("buffer_after"),
+		CancellationWindow:  r.int("cancellation_window"),
+		EarlyReleaseMinutes: r.int("early_release_minutes"),
+		PreemptionTier:      r.int("preemption_tier"),
+		IsVIP:               r.bool("is_vip"),
+		IsPremium:           r.bool("is_premium"),
+		EnergyRating:        strings.ToUpper(r.text("energy_rating")),
+		CheckInPresence:     r.text("check_in_presence"),
+		Latitude:            r.optionalFloat("latitude"),
+		Longitude:           r.optionalFloat("longitude"),
+		GeofenceRadius:      r.int("geofence_radius"),
+		PilotUntil:          r.optionalTime("pilot_until"),
+	}
+	for _, department := range strings.Split(r.text("pilot_departments"), ";") {
+		if department = strings.TrimSpace(department); department != "" {
+			entry.PilotDepartments = append(entry.PilotDepartments, department)
+		}
+	}
+	r.json("equipment", &entry.Equipment)
+	r.json("attributes", &entry.Attributes)
+	r.json("photos", &entry.Photos)
+	r.json("approval_rule", &entry.ApprovalRule)
+	r.json("vip_blocks", &entry.VIPBlocks)
+
+	return &catalogRow{number: number, entry: entry, errors: r.errors}
+}
+
+// text returns the trimmed cell of a column, empty when the row or the file lacks it
+func (r *catalogRecord) text(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+	return strings.TrimSpace(r.record[i])
+}
+
+// invalid records a cell that could not be parsed
+func (r *catalogRecord) invalid(column, expected string) {
+	r.errors = append(r.errors, fmt.Sprintf("%s must be %s", column, expected))
+}
+
+// int parses a whole number cell, 0 when empty
+func (r *catalogRecord) int(column string) int {
+	cell := r.text(column)
+	if cell == "" {
+		return 0
+	}
+	value, err := strconv.Atoi(cell)
+	if err != nil {
+		r.invalid(column, "a whole number")
+	}
+	return value
+}
+
+// float parses a number cell, 0 when empty
+func (r *catalogRecord) float(column string) float64 {
+	value := r.optionalFloat(column)
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+// optionalFloat parses a number cell, nil when empty
+func (r *catalogRecord) optionalFloat(column string) *float64 {
+	cell := r.text(column)
+	if cell == "" {
+		return nil
+	}
+	value, err := strconv.ParseFloat(cell, 64)
+	if err != nil {
+		r.invalid(column, "a number")
+		return nil
+	}
+	return &value
+}
+
+// bool parses a true/false cell, false when empty
+func (r *catalogRecord) bool(column string) bool {
+	cell := r.text(column)
+	if cell == "" {
+		return false
+	}
+	value, err := strconv.ParseBool(cell)
+	if err != nil {
+		r.invalid(column, "true or false")
+	}
+	return value
+}
+
+// optionalTime parses an RFC 3339 cell, nil when empty
+func (r *catalogRecord) optionalTime(column string) *time.Time {
+	cell := r.text(column)
+	if cell == "" {
+		return nil
+	}
+	value, err := time.Parse(time.RFC3339, cell)
+	if err != nil {
+		r.invalid(column, "an RFC 3339 date and time")
+		return nil
+	}
+	return &value
+}
+
+// json decodes a JSON cell into target, leaving it unset when the cell is empty
+func (r *catalogRecord) json(column string, target interface{}) {
+	cell := r.text(column)
+	if cell == "" {
+		return
+	}
+	if err := json.Unmarshal([]byte(cell), target); err != nil {
+		r.invalid(column, "valid JSON")
+	}
+}
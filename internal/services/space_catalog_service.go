@@ -0,0 +1,530 @@
+// internal/services/space_catalog_service.go
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// Spaces of one catalog export or import
+const maxCatalogSpaces = 2000
+
+// SpaceCatalogService exports every space with its approval rule and VIP blocks, and imports
+// such a catalog back to replicate an environment or apply bulk edits made in a spreadsheet
+type SpaceCatalogService struct {
+	spaceRepo        interfaces.SpaceRepositoryInterface
+	userRepo         interfaces.UserRepositoryInterface
+	approvalRuleRepo interfaces.ApprovalRuleRepositoryInterface
+	vipBlockRepo     interfaces.VIPBlockRepositoryInterface
+}
+
+// NewSpaceCatalogService creates a new space catalog service
+func NewSpaceCatalogService(
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	approvalRuleRepo interfaces.ApprovalRuleRepositoryInterface,
+	vipBlockRepo interfaces.VIPBlockRepositoryInterface,
+) *SpaceCatalogService {
+	return &SpaceCatalogService{
+		spaceRepo:        spaceRepo,
+		userRepo:         userRepo,
+		approvalRuleRepo: approvalRuleRepo,
+		vipBlockRepo:     vipBlockRepo,
+	}
+}
+
+// catalogRow is one space of an import, with the errors found while reading it
+type catalogRow struct {
+	number int
+	entry  dto.SpaceCatalogEntry
+	errors []string
+}
+
+// ========================================
+// EXPORT
+// ========================================
+
+// ExportCatalog lists every space by building and name with its approval rule and VIP blocks
+func (s *SpaceCatalogService) ExportCatalog() (*dto.SpaceCatalog, error) {
+	spaces, _, err := s.spaceRepo.GetAll(nil, 0, maxCatalogSpaces)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spaces: %w", err)
+	}
+	sort.SliceStable(spaces, func(i, j int) bool {
+		if spaces[i].Building != spaces[j].Building {
+			return spaces[i].Building < spaces[j].Building
+		}
+		return spaces[i].Name < spaces[j].Name
+	})
+
+	rules, err := s.approvalRuleRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval rules: %w", err)
+	}
+	rulesBySpace := make(map[uuid.UUID]*models.ApprovalRule, len(rules))
+	for _, rule := range rules {
+		rulesBySpace[rule.SpaceID] = rule
+	}
+
+	entries := make([]dto.SpaceCatalogEntry, 0, len(spaces))
+	for _, space := range spaces {
+		entry := catalogEntryOf(space, rulesBySpace[space.ID])
+		if space.IsVIP {
+			blocks, err := s.vipBlockRepo.GetActiveBlocksBySpace(space.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get VIP blocks: %w", err)
+			}
+			entry.VIPBlocks = make([]dto.CreateVIPBlockRequest, 0, len(blocks))
+			for _, block := range blocks {
+				entry.VIPBlocks = append(entry.VIPBlocks, dto.CreateVIPBlockRequest{
+					DayOfWeek:    block.DayOfWeek,
+					StartTime:    block.StartTime,
+					EndTime:      block.EndTime,
+					Timezone:     block.Timezone,
+					AllowedRoles: block.AllowedRoles,
+					Reason:       block.Reason,
+				})
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	exportedAt := time.Now().UTC()
+	return &dto.SpaceCatalog{ExportedAt: &exportedAt, Spaces: entries}, nil
+}
+
+// catalogEntryOf converts a space and its approval rule to their portable form
+func catalogEntryOf(space *models.Space, rule *models.ApprovalRule) dto.SpaceCatalogEntry {
+	entry := dto.SpaceCatalogEntry{
+		Building:            space.Building,
+		Name:                space.Name,
+		Type:                string(space.Type),
+		Capacity:            space.Capacity,
+		Floor:               space.Floor,
+		RoomNumber:          space.RoomNumber,
+		Status:              string(space.Status),
+		Description:         space.Description,
+		Surface:             space.Surface,
+		PricePerHour:        space.PricePerHour,
+		PricePerDay:         space.PricePerDay,
+		PricePerMonth:       space.PricePerMonth,
+		CostPerHour:         space.CostPerHour,
+		RequiresApproval:    space.RequiresApproval,
+		BookingAdvanceTime:  space.BookingAdvanceTime,
+		MaxBookingDuration:  space.MaxBookingDuration,
+		BufferBefore:        space.BufferBefore,
+		BufferAfter:         space.BufferAfter,
+		CancellationWindow:  space.CancellationWindow,
+		EarlyReleaseMinutes: space.EarlyReleaseMinutes,
+		PreemptionTier:      space.PreemptionTier,
+		IsVIP:               space.IsVIP,
+		IsPremium:           space.IsPremium,
+		EnergyRating:        space.EnergyRating,
+		CheckInPresence:     string(space.CheckInPresence),
+		Latitude:            space.Latitude,
+		Longitude:           space.Longitude,
+		GeofenceRadius:      space.GeofenceRadius,
+		PilotUntil:          space.PilotUntil,
+		PilotDepartments:    space.PilotDepartments,
+	}
+	if space.Manager != nil {
+		entry.ManagerEmail = space.Manager.Email
+	}
+
+	// Stored JSON the API wrote itself; unreadable values are left out of the export
+	if len(space.Equipment) > 0 {
+		json.Unmarshal(space.Equipment, &entry.Equipment)
+	}
+	if len(space.Photos) > 0 {
+		json.Unmarshal(space.Photos, &entry.Photos)
+	}
+	if len(space.Attributes) > 0 {
+		var attributes dto.ResourceAttributes
+		if json.Unmarshal(space.Attributes, &attributes) == nil && attributes != (dto.ResourceAttributes{}) {
+			entry.Attributes = &attributes
+		}
+	}
+
+	if rule != nil {
+		twoStep := rule.TwoStep
+		entry.ApprovalRule = &dto.SetApprovalRuleRequest{
+			AutoApproveMaxMinutes:      rule.AutoApproveMaxMinutes,
+			AutoApproveMaxParticipants: rule.AutoApproveMaxParticipants,
+			AutoApproveMinTenureDays:   rule.AutoApproveMinTenureDays,
+			AutoApproveRoles:           rule.AutoApproveRoles,
+			TwoStep:                    &twoStep,
+		}
+	}
+	return entry
+}
+
+// ========================================
+// IMPORT
+// ========================================
+
+// ImportCatalog adds or replaces the spaces of a catalog, matched by building and name.
+// A dry run only validates the spaces and reports which would be added or replaced.
+func (s *SpaceCatalogService) ImportCatalog(catalog *dto.SpaceCatalog, dryRun bool, userID uuid.UUID) (*dto.SpaceCatalogImportResponse, error) {
+	if len(catalog.Spaces) > maxCatalogSpaces {
+		return nil, fmt.Errorf("catalog exceeds the maximum of %d spaces", maxCatalogSpaces)
+	}
+
+	rows := make([]*catalogRow, len(catalog.Spaces))
+	for i, entry := range catalog.Spaces {
+		rows[i] = &catalogRow{number: i + 1, entry: entry}
+	}
+	return s.importRows(rows, dryRun, userID)
+}
+
+// importRows validates every row against the current spaces, then applies the valid ones
+// unless it is a dry run. Rows are applied one by one, so a failed row does not undo the others.
+func (s *SpaceCatalogService) importRows(rows []*catalogRow, dryRun bool, userID uuid.UUID) (*dto.SpaceCatalogImportResponse, error) {
+	spaces, _, err := s.spaceRepo.GetAll(nil, 0, maxCatalogSpaces)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spaces: %w", err)
+	}
+	existing := make(map[string]*models.Space, len(spaces))
+	for _, space := range spaces {
+		existing[catalogKey(space.Building, space.Name)] = space
+	}
+
+	managers := make(map[string]*models.User)
+	seen := make(map[string]int)
+	response := &dto.SpaceCatalogImportResponse{
+		DryRun:    dryRun,
+		TotalRows: len(rows),
+		Rows:      []dto.SpaceCatalogRowResult{},
+	}
+
+	for _, row := range rows {
+		entry := &row.entry
+		entry.Building = strings.TrimSpace(entry.Building)
+		entry.Name = strings.TrimSpace(entry.Name)
+		result := dto.SpaceCatalogRowResult{
+			RowNumber: row.number,
+			Building:  entry.Building,
+			Name:      entry.Name,
+			Errors:    row.errors,
+		}
+
+		key := catalogKey(entry.Building, entry.Name)
+		if first, duplicate := seen[key]; duplicate {
+			result.Errors = append(result.Errors, fmt.Sprintf("same building and name as row %d", first))
+		} else {
+			seen[key] = row.number
+		}
+
+		space := existing[key]
+		managerID, err := s.checkCatalogEntry(entry, space, managers, &result)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case len(result.Errors) > 0:
+			result.Status = "invalid"
+			response.InvalidCount++
+		case dryRun && space != nil:
+			result.Status = "update"
+			result.SpaceID = &space.ID
+			response.UpdateCount++
+		case dryRun:
+			result.Status = "create"
+			response.CreateCount++
+		default:
+			s.applyCatalogEntry(entry, space, managerID, userID, &result)
+			switch result.Status {
+			case "created":
+				response.CreatedCount++
+			case "updated":
+				response.UpdatedCount++
+			default:
+				response.FailedCount++
+			}
+		}
+		response.Rows = append(response.Rows, result)
+	}
+
+	return response, nil
+}
+
+// checkCatalogEntry records what stops the entry from being applied over the space, which is nil
+// for new spaces, and resolves its manager. Only lookup failures are returned as errors.
+func (s *SpaceCatalogService) checkCatalogEntry(entry *dto.SpaceCatalogEntry, space *models.Space, managers map[string]*models.User, result *dto.SpaceCatalogRowResult) (*uuid.UUID, error) {
+	if err := dto.Validate(entry); err != nil {
+		if fields, ok := dto.ValidationErrorsFrom(err); ok {
+			for _, field := range fields {
+				result.Errors = append(result.Errors, field.Message)
+			}
+		} else {
+			result.Errors = append(result.Errors, err.Error())
+		}
+	}
+
+	if _, err := resourceAttributesJSON(models.SpaceType(entry.Type), entry.Attributes); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	// Beacons are not part of the catalog, so a replaced space keeps its own
+	beaconCount := 0
+	if space != nil {
+		beaconCount = len(space.BeaconIDs)
+	}
+	presence := models.PresenceCheck(entry.CheckInPresence)
+	if err := validatePresenceSettings(presence, beaconCount, entry.Latitude != nil && entry.Longitude != nil); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	if entry.ManagerEmail == "" {
+		return nil, nil
+	}
+	key := strings.ToLower(entry.ManagerEmail)
+	manager, known := managers[key]
+	if !known {
+		user, err := s.userRepo.GetByEmail(entry.ManagerEmail)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to get manager: %w", err)
+		}
+		manager = user
+		managers[key] = manager
+	}
+	if manager == nil || !manager.IsActive || (!manager.IsManager() && !manager.IsAdmin()) {
+		result.Errors = append(result.Errors, fmt.Sprintf("manager %q is not an active manager or admin", entry.ManagerEmail))
+		return nil, nil
+	}
+	return &manager.ID, nil
+}
+
+// applyCatalogEntry adds the space or replaces the existing one, then its approval rule and
+// VIP blocks when the entry has them, and records the outcome
+func (s *SpaceCatalogService) applyCatalogEntry(entry *dto.SpaceCatalogEntry, space *models.Space, managerID *uuid.UUID, userID uuid.UUID, result *dto.SpaceCatalogRowResult) {
+	fields, err := catalogSpaceFields(entry, managerID)
+	if err != nil {
+		result.Status = "failed"
+		result.Errors = append(result.Errors, err.Error())
+		return
+	}
+
+	status := "updated"
+	if space == nil {
+		status = "created"
+		fields.Building = entry.Building
+		fields.Name = entry.Name
+		space, err = s.spaceRepo.Create(fields)
+		if err != nil {
+			result.Status = "failed"
+			result.Errors = append(result.Errors, "failed to create space: "+err.Error())
+			return
+		}
+		// The column default replaces a zero advance time on insert
+		if fields.BookingAdvanceTime == 0 && space.BookingAdvanceTime != 0 {
+			if _, err := s.spaceRepo.Update(space.ID, map[string]interface{}{"booking_advance_time": 0}); err != nil {
+				result.Status = "failed"
+				result.Errors = append(result.Errors, "failed to update space: "+err.Error())
+				return
+			}
+		}
+	} else if _, err := s.spaceRepo.Update(space.ID, catalogSpaceUpdates(fields)); err != nil {
+		result.Status = "failed"
+		result.Errors = append(result.Errors, "failed to update space: "+err.Error())
+		return
+	}
+	result.SpaceID = &space.ID
+
+	if rule := entry.ApprovalRule; rule != nil {
+		twoStep := models.SpaceType(entry.Type) == models.SpaceTypeAuditorium
+		if rule.TwoStep != nil {
+			twoStep = *rule.TwoStep
+		}
+		_, err := s.approvalRuleRepo.Save(&models.ApprovalRule{
+			SpaceID:                    space.ID,
+			AutoApproveMaxMinutes:      rule.AutoApproveMaxMinutes,
+			AutoApproveMaxParticipants: rule.AutoApproveMaxParticipants,
+			AutoApproveMinTenureDays:   rule.AutoApproveMinTenureDays,
+			AutoApproveRoles:           pq.StringArray(rule.AutoApproveRoles),
+			TwoStep:                    twoStep,
+			UpdatedByID:                userID,
+		})
+		if err != nil {
+			result.Status = "failed"
+			result.Errors = append(result.Errors, "failed to save approval rule: "+err.Error())
+			return
+		}
+	}
+
+	if entry.VIPBlocks != nil {
+		if err := s.replaceVIPBlocks(space.ID, entry.VIPBlocks, userID); err != nil {
+			result.Status = "failed"
+			result.Errors = append(result.Errors, err.Error())
+			return
+		}
+	}
+
+	result.Status = status
+}
+
+// replaceVIPBlocks swaps the active VIP blocks of a space for the given ones
+func (s *SpaceCatalogService) replaceVIPBlocks(spaceID uuid.UUID, blocks []dto.CreateVIPBlockRequest, userID uuid.UUID) error {
+	current, err := s.vipBlockRepo.GetActiveBlocksBySpace(spaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get VIP blocks: %w", err)
+	}
+	for _, block := range current {
+		if err := s.vipBlockRepo.Delete(block.ID); err != nil {
+			return fmt.Errorf("failed to delete VIP block: %w", err)
+		}
+	}
+
+	for _, block := range blocks {
+		timezone := block.Timezone
+		if timezone == "" {
+			timezone = "UTC"
+		}
+		_, err := s.vipBlockRepo.Create(&models.VIPBlock{
+			SpaceID:      spaceID,
+			DayOfWeek:    block.DayOfWeek,
+			StartTime:    block.StartTime,
+			EndTime:      block.EndTime,
+			Timezone:     timezone,
+			AllowedRoles: pq.StringArray(block.AllowedRoles),
+			Reason:       block.Reason,
+			IsActive:     true,
+			CreatedByID:  userID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create VIP block: %w", err)
+		}
+	}
+	return nil
+}
+
+// catalogSpaceFields converts an entry to the space fields it sets, with the defaults of new spaces
+func catalogSpaceFields(entry *dto.SpaceCatalogEntry, managerID *uuid.UUID) (*models.Space, error) {
+	var equipmentJSON, photosJSON datatypes.JSON
+	if len(entry.Equipment) > 0 {
+		equipmentBytes, err := json.Marshal(entry.Equipment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize equipment: %w", err)
+		}
+		equipmentJSON = datatypes.JSON(equipmentBytes)
+	}
+	if len(entry.Photos) > 0 {
+		photosBytes, err := json.Marshal(entry.Photos)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize photos: %w", err)
+		}
+		photosJSON = datatypes.JSON(photosBytes)
+	}
+	attributesJSON, err := resourceAttributesJSON(models.SpaceType(entry.Type), entry.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.SpaceStatus(entry.Status)
+	if status == "" {
+		status = models.SpaceStatusAvailable
+	}
+	maxBookingDuration := entry.MaxBookingDuration
+	if maxBookingDuration == 0 {
+		maxBookingDuration = 480
+	}
+	presence := models.PresenceCheck(entry.CheckInPresence)
+	if presence == "" {
+		presence = models.PresenceCheckNone
+	}
+	geofenceRadius := entry.GeofenceRadius
+	if geofenceRadius == 0 {
+		geofenceRadius = 150
+	}
+
+	return &models.Space{
+		Type:                models.SpaceType(entry.Type),
+		Capacity:            entry.Capacity,
+		Floor:               entry.Floor,
+		RoomNumber:          entry.RoomNumber,
+		Equipment:           equipmentJSON,
+		Attributes:          attributesJSON,
+		Status:              status,
+		Description:         entry.Description,
+		Surface:             entry.Surface,
+		Photos:              photosJSON,
+		PricePerHour:        entry.PricePerHour,
+		PricePerDay:         entry.PricePerDay,
+		PricePerMonth:       entry.PricePerMonth,
+		CostPerHour:         entry.CostPerHour,
+		ManagerID:           managerID,
+		RequiresApproval:    entry.RequiresApproval,
+		BookingAdvanceTime:  entry.BookingAdvanceTime,
+		MaxBookingDuration:  maxBookingDuration,
+		BufferBefore:        entry.BufferBefore,
+		BufferAfter:         entry.BufferAfter,
+		CancellationWindow:  entry.CancellationWindow,
+		EarlyReleaseMinutes: entry.EarlyReleaseMinutes,
+		PreemptionTier:      entry.PreemptionTier,
+		IsVIP:               entry.IsVIP,
+		IsPremium:           entry.IsPremium,
+		EnergyRating:        entry.EnergyRating,
+		CheckInPresence:     presence,
+		Latitude:            entry.Latitude,
+		Longitude:           entry.Longitude,
+		GeofenceRadius:      geofenceRadius,
+		PilotUntil:          entry.PilotUntil,
+		PilotDepartments:    pq.StringArray(entry.PilotDepartments),
+	}, nil
+}
+
+// catalogSpaceUpdates lists every catalog column of the space fields, so zero values are written too
+func catalogSpaceUpdates(fields *models.Space) map[string]interface{} {
+	return map[string]interface{}{
+		"type":                  fields.Type,
+		"capacity":              fields.Capacity,
+		"floor":                 fields.Floor,
+		"room_number":           fields.RoomNumber,
+		"equipment":             fields.Equipment,
+		"attributes":            fields.Attributes,
+		"status":                fields.Status,
+		"description":           fields.Description,
+		"surface":               fields.Surface,
+		"photos":                fields.Photos,
+		"price_per_hour":        fields.PricePerHour,
+		"price_per_day":         fields.PricePerDay,
+		"price_per_month":       fields.PricePerMonth,
+		"cost_per_hour":         fields.CostPerHour,
+		"manager_id":            fields.ManagerID,
+		"requires_approval":     fields.RequiresApproval,
+		"booking_advance_time":  fields.BookingAdvanceTime,
+		"max_booking_duration":  fields.MaxBookingDuration,
+		"buffer_before":         fields.BufferBefore,
+		"buffer_after":          fields.BufferAfter,
+		"cancellation_window":   fields.CancellationWindow,
+		"early_release_minutes": fields.EarlyReleaseMinutes,
+		"preemption_tier":       fields.PreemptionTier,
+		"is_vip":                fields.IsVIP,
+		"is_premium":            fields.IsPremium,
+		"energy_rating":         fields.EnergyRating,
+		"check_in_presence":     fields.CheckInPresence,
+		"latitude":              fields.Latitude,
+		"longitude":             fields.Longitude,
+		"geofence_radius":       fields.GeofenceRadius,
+		"pilot_until":           fields.PilotUntil,
+		"pilot_departments":     fields.PilotDepartments,
+	}
+}
+
+// catalogKey identifies a space across environments by its building and name
+func catalogKey(building, name string) string {
+	return building + "\x00" + name
+}
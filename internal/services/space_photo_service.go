@@ -0,0 +1,362 @@
+// internal/services/space_photo_service.go
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"log/slog"
+	"mime/multipart"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// Standard dimensions of stored space photos; larger uploads are scaled down to fit
+const (
+	spacePhotoMaxWidth      = 1600
+	spacePhotoMaxHeight     = 1200
+	spaceThumbnailMaxWidth  = 400
+	spaceThumbnailMaxHeight = 300
+	// Uploads beyond this many pixels are refused before decoding
+	maxSpacePhotoPixels = 40_000_000
+)
+
+// SpacePhotoService manages the photo galleries of spaces. The gallery is mirrored on the
+// space: its photos list follows the gallery order and its primary photo URL is the
+// thumbnail of the primary photo.
+type SpacePhotoService struct {
+	photoRepo interfaces.SpacePhotoRepositoryInterface
+	spaceRepo interfaces.SpaceRepositoryInterface
+	storage   FileStorage
+	logger    *slog.Logger
+}
+
+// NewSpacePhotoService creates a new space photo service
+func NewSpacePhotoService(
+	photoRepo interfaces.SpacePhotoRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	storage FileStorage,
+	logger *slog.Logger,
+) *SpacePhotoService {
+	return &SpacePhotoService{
+		photoRepo: photoRepo,
+		spaceRepo: spaceRepo,
+		storage:   storage,
+		logger:    logger,
+	}
+}
+
+// GetPhotos lists the gallery of a space in order
+func (s *SpacePhotoService) GetPhotos(spaceID uuid.UUID) ([]*models.SpacePhoto, error) {
+	if err := s.checkSpace(spaceID); err != nil {
+		return nil, err
+	}
+	photos, err := s.photoRepo.GetBySpace(spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+	return photos, nil
+}
+
+// UploadPhotos resizes the images to the standard dimensions and adds them at the end of the
+// gallery. Every file is checked before any is stored. The first photo of an empty gallery
+// becomes its primary photo.
+func (s *SpacePhotoService) UploadPhotos(spaceID uuid.UUID, files []*multipart.FileHeader, userID uuid.UUID) ([]*models.SpacePhoto, error) {
+	if len(files) == 0 {
+		return nil, errors.New("no photo provided")
+	}
+	if err := s.checkSpace(spaceID); err != nil {
+		return nil, err
+	}
+
+	photos, err := s.photoRepo.GetBySpace(spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+	if len(photos)+len(files) > models.MaxSpacePhotos {
+		return nil, fmt.Errorf("a space can have at most %d photos", models.MaxSpacePhotos)
+	}
+
+	for _, file := range files {
+		if _, err := readSpacePhoto(file, false); err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Filename, err)
+		}
+	}
+
+	// Images are decoded one at a time to bound memory use
+	created := make([]*models.SpacePhoto, 0, len(files))
+	for i, file := range files {
+		photo, err := s.uploadPhoto(spaceID, file, len(photos)+i, len(photos) == 0 && i == 0, userID)
+		if err != nil {
+			// Keep the space in line with the photos stored so far
+			s.syncSpace(spaceID)
+			return nil, err
+		}
+		created = append(created, photo)
+	}
+
+	if err := s.syncSpace(spaceID); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ReorderPhotos puts the gallery in the given order, which must list every photo once
+func (s *SpacePhotoService) ReorderPhotos(spaceID uuid.UUID, req *dto.ReorderSpacePhotosRequest) ([]*models.SpacePhoto, error) {
+	photos, err := s.GetPhotos(spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	primaryID := uuid.Nil
+	remaining := make(map[uuid.UUID]bool, len(photos))
+	for _, photo := range photos {
+		remaining[photo.ID] = true
+		if photo.IsPrimary {
+			primaryID = photo.ID
+		}
+	}
+	for _, id := range req.PhotoIDs {
+		if !remaining[id] {
+			return nil, fmt.Errorf("photo %s is not in the gallery or is listed twice", id)
+		}
+		delete(remaining, id)
+	}
+	if len(remaining) > 0 {
+		return nil, errors.New("photo_ids must list every photo of the gallery")
+	}
+
+	if err := s.photoRepo.SaveOrder(spaceID, req.PhotoIDs, primaryID); err != nil {
+		return nil, fmt.Errorf("failed to reorder photos: %w", err)
+	}
+	if err := s.syncSpace(spaceID); err != nil {
+		return nil, err
+	}
+	return s.GetPhotos(spaceID)
+}
+
+// SetPrimaryPhoto makes a photo the one shown for the space in search results
+func (s *SpacePhotoService) SetPrimaryPhoto(spaceID, photoID uuid.UUID) ([]*models.SpacePhoto, error) {
+	photos, err := s.GetPhotos(spaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsPhoto(photos, photoID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if err := s.photoRepo.SaveOrder(spaceID, photoIDsOf(photos), photoID); err != nil {
+		return nil, fmt.Errorf("failed to set primary photo: %w", err)
+	}
+	if err := s.syncSpace(spaceID); err != nil {
+		return nil, err
+	}
+	return s.GetPhotos(spaceID)
+}
+
+// DeletePhoto removes a photo and its files. When it was the primary photo, the first
+// remaining photo takes its place.
+func (s *SpacePhotoService) DeletePhoto(spaceID, photoID uuid.UUID) error {
+	photos, err := s.GetPhotos(spaceID)
+	if err != nil {
+		return err
+	}
+	if !containsPhoto(photos, photoID) {
+		return gorm.ErrRecordNotFound
+	}
+
+	if err := s.photoRepo.Delete(photoID); err != nil {
+		return fmt.Errorf("failed to delete photo: %w", err)
+	}
+	for _, key := range spacePhotoKeys(spaceID, photoID) {
+		// The record is gone, so a leftover file is only wasted space
+		if err := s.storage.Delete(key); err != nil {
+			s.logger.Warn("Failed to delete space photo file", "spaceID", spaceID, "key", key, "error", err)
+		}
+	}
+
+	remaining := make([]*models.SpacePhoto, 0, len(photos)-1)
+	primaryID := uuid.Nil
+	for _, photo := range photos {
+		if photo.ID == photoID {
+			continue
+		}
+		remaining = append(remaining, photo)
+		if photo.IsPrimary {
+			primaryID = photo.ID
+		}
+	}
+	if len(remaining) > 0 {
+		if primaryID == uuid.Nil {
+			primaryID = remaining[0].ID
+		}
+		if err := s.photoRepo.SaveOrder(spaceID, photoIDsOf(remaining), primaryID); err != nil {
+			return fmt.Errorf("failed to reorder photos: %w", err)
+		}
+	}
+
+	return s.syncSpace(spaceID)
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// checkSpace returns gorm.ErrRecordNotFound when the space does not exist
+func (s *SpacePhotoService) checkSpace(spaceID uuid.UUID) error {
+	if _, err := s.spaceRepo.GetByID(spaceID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to get space: %w", err)
+	}
+	return nil
+}
+
+// uploadPhoto stores the resized image and its thumbnail, then records the photo
+func (s *SpacePhotoService) uploadPhoto(spaceID uuid.UUID, file *multipart.FileHeader, position int, primary bool, userID uuid.UUID) (*models.SpacePhoto, error) {
+	img, err := readSpacePhoto(file, true)
+	if err != nil {
+		return nil, err
+	}
+
+	photoID := uuid.New()
+	keys := spacePhotoKeys(spaceID, photoID)
+
+	resized := resizeImage(img, spacePhotoMaxWidth, spacePhotoMaxHeight)
+	urls := make([]string, len(keys))
+	for i, version := range []image.Image{resized, resizeImage(img, spaceThumbnailMaxWidth, spaceThumbnailMaxHeight)} {
+		data, err := encodeJPEG(version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode photo: %w", err)
+		}
+		urls[i], err = s.storage.Save(keys[i], bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to store photo: %w", err)
+		}
+	}
+
+	photo, err := s.photoRepo.Create(&models.SpacePhoto{
+		ID:           photoID,
+		SpaceID:      spaceID,
+		Position:     position,
+		IsPrimary:    primary,
+		URL:          urls[0],
+		ThumbnailURL: urls[1],
+		Width:        resized.Bounds().Dx(),
+		Height:       resized.Bounds().Dy(),
+		OriginalName: file.Filename,
+		UploadedByID: userID,
+	})
+	if err != nil {
+		for _, key := range keys {
+			s.storage.Delete(key)
+		}
+		return nil, fmt.Errorf("failed to create photo: %w", err)
+	}
+	return photo, nil
+}
+
+// syncSpace mirrors the gallery on the space's photos list and primary photo URL
+func (s *SpacePhotoService) syncSpace(spaceID uuid.UUID) error {
+	photos, err := s.photoRepo.GetBySpace(spaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get photos: %w", err)
+	}
+
+	urls := make([]string, len(photos))
+	primaryURL := ""
+	for i, photo := range photos {
+		urls[i] = photo.URL
+		if photo.IsPrimary {
+			primaryURL = photo.ThumbnailURL
+		}
+	}
+	photosJSON, err := json.Marshal(urls)
+	if err != nil {
+		return fmt.Errorf("failed to serialize photos: %w", err)
+	}
+
+	updates := map[string]interface{}{
+		"photos":            datatypes.JSON(photosJSON),
+		"primary_photo_url": primaryURL,
+	}
+	if _, err := s.spaceRepo.Update(spaceID, updates); err != nil {
+		return fmt.Errorf("failed to update space photos: %w", err)
+	}
+	return nil
+}
+
+// readSpacePhoto checks an upload is a JPEG, PNG or GIF image of reasonable size and,
+// when decode is set, decodes it
+func readSpacePhoto(file *multipart.FileHeader, decode bool) (image.Image, error) {
+	if file.Size > models.MaxSpacePhotoSize {
+		return nil, fmt.Errorf("photo exceeds the maximum size of %d MB", models.MaxSpacePhotoSize>>20)
+	}
+	content, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read photo: %w", err)
+	}
+	defer content.Close()
+
+	data, err := io.ReadAll(io.LimitReader(content, models.MaxSpacePhotoSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read photo: %w", err)
+	}
+	if len(data) > models.MaxSpacePhotoSize {
+		return nil, fmt.Errorf("photo exceeds the maximum size of %d MB", models.MaxSpacePhotoSize>>20)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("photo must be a JPEG, PNG or GIF image")
+	}
+	if config.Width*config.Height > maxSpacePhotoPixels {
+		return nil, errors.New("photo has too many pixels")
+	}
+	if !decode {
+		return nil, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("photo must be a JPEG, PNG or GIF image")
+	}
+	return img, nil
+}
+
+// spacePhotoKeys returns the storage keys of a photo and of its thumbnail
+func spacePhotoKeys(spaceID, photoID uuid.UUID) []string {
+	return []string{
+		fmt.Sprintf("spaces/%s/%s.jpg", spaceID, photoID),
+		fmt.Sprintf("spaces/%s/%s_thumb.jpg", spaceID, photoID),
+	}
+}
+
+// photoIDsOf lists the IDs of photos in their order
+func photoIDsOf(photos []*models.SpacePhoto) []uuid.UUID {
+	ids := make([]uuid.UUID, len(photos))
+	for i, photo := range photos {
+		ids[i] = photo.ID
+	}
+	return ids
+}
+
+// containsPhoto checks if a photo is part of a gallery
+func containsPhoto(photos []*models.SpacePhoto, photoID uuid.UUID) bool {
+	for _, photo := range photos {
+		if photo.ID == photoID {
+			return true
+		}
+	}
+	return false
+}
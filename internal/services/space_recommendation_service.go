@@ -0,0 +1,317 @@
+// internal/services/space_recommendation_service.go
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// How far back bookings count towards preferences and department location
+	recommendationHistory = 90 * 24 * time.Hour
+	// Upper bound of candidate spaces scored per request
+	recommendationCandidates = 500
+
+	// Score weights; factors that do not apply to a request are left out of the total
+	capacityWeight  = 30.0
+	amenityWeight   = 25.0
+	historyWeight   = 25.0
+	proximityWeight = 20.0
+)
+
+// RecommendationQuery describes what the user needs
+type RecommendationQuery struct {
+	StartTime    time.Time
+	EndTime      time.Time
+	Participants int
+	Amenities    []string
+	SpaceType    string
+	Limit        int
+}
+
+// SpaceRecommendationService ranks free spaces for a user and explains the ranking
+type SpaceRecommendationService struct {
+	spaceRepo         interfaces.SpaceRepositoryInterface
+	reservationRepo   interfaces.ReservationRepositoryInterface
+	userRepo          interfaces.UserRepositoryInterface
+	vipBlockRepo      interfaces.VIPBlockRepositoryInterface
+	consolidationRepo interfaces.FloorConsolidationRepositoryInterface
+}
+
+// NewSpaceRecommendationService creates a new space recommendation service
+func NewSpaceRecommendationService(
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	vipBlockRepo interfaces.VIPBlockRepositoryInterface,
+	consolidationRepo interfaces.FloorConsolidationRepositoryInterface,
+) *SpaceRecommendationService {
+	return &SpaceRecommendationService{
+		spaceRepo:         spaceRepo,
+		reservationRepo:   reservationRepo,
+		userRepo:          userRepo,
+		vipBlockRepo:      vipBlockRepo,
+		consolidationRepo: consolidationRepo,
+	}
+}
+
+// homeLocation is the building and floor a department books most
+type homeLocation struct {
+	building string
+	floor    int
+}
+
+// RecommendSpaces ranks the spaces the user can book for the requested time
+func (s *SpaceRecommendationService) RecommendSpaces(query RecommendationQuery, userID uuid.UUID) (*dto.SpaceRecommendationsResponse, error) {
+	if !query.EndTime.After(query.StartTime) {
+		return nil, dto.ErrInvalidTimeRange
+	}
+	if query.StartTime.Before(time.Now()) {
+		return nil, errors.New("cannot recommend spaces in the past")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	spaces, _, err := s.spaceRepo.GetAvailableSpaces(query.StartTime, query.EndTime, pilotViewerOf(user), 0, recommendationCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available spaces: %w", err)
+	}
+
+	// Floors closed by a consolidation are left out so bookings go to the floors kept open
+	consolidations, err := s.consolidationRepo.GetCurrentAndUpcoming(query.StartTime.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get floor consolidations: %w", err)
+	}
+
+	since := time.Now().Add(-recommendationHistory)
+	bookingCounts, err := s.reservationRepo.CountUserReservationsBySpace(userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking history: %w", err)
+	}
+	var maxBookings int64
+	for _, count := range bookingCounts {
+		if count > maxBookings {
+			maxBookings = count
+		}
+	}
+
+	home, err := s.departmentHome(user.Department, since)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.SpaceRecommendationsResponse{
+		StartTime:       query.StartTime,
+		EndTime:         query.EndTime,
+		Participants:    query.Participants,
+		Amenities:       query.Amenities,
+		Recommendations: []dto.SpaceRecommendation{},
+	}
+	if home != nil {
+		response.HomeLocation = fmt.Sprintf("%s, floor %d", home.building, home.floor)
+	}
+
+	for _, space := range spaces {
+		if !space.HostsPeople() || closingConsolidation(consolidations, space, query.StartTime) != nil {
+			continue
+		}
+		bookable, err := s.isBookable(space, user, query)
+		if err != nil {
+			return nil, err
+		}
+		if !bookable {
+			continue
+		}
+
+		response.Recommendations = append(response.Recommendations,
+			s.score(space, user, query, bookingCounts[space.ID], maxBookings, home))
+	}
+
+	sort.SliceStable(response.Recommendations, func(i, j int) bool {
+		a, b := response.Recommendations[i], response.Recommendations[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		// Prefer the smaller room on ties to leave large rooms for large groups
+		return a.Space.Capacity < b.Space.Capacity
+	})
+
+	if len(response.Recommendations) > query.Limit {
+		response.Recommendations = response.Recommendations[:query.Limit]
+	}
+
+	return response, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// isBookable filters out spaces the user could not book for the request
+func (s *SpaceRecommendationService) isBookable(space *models.Space, user *models.User, query RecommendationQuery) (bool, error) {
+	if space.Capacity < query.Participants {
+		return false, nil
+	}
+	if query.SpaceType != "" && string(space.Type) != query.SpaceType {
+		return false, nil
+	}
+	if space.MaxBookingDuration > 0 && query.EndTime.Sub(query.StartTime) > time.Duration(space.MaxBookingDuration)*time.Minute {
+		return false, nil
+	}
+	if space.BookingAdvanceTime > 0 && query.StartTime.Before(time.Now().Add(time.Duration(space.BookingAdvanceTime)*time.Minute)) {
+		return false, nil
+	}
+
+	if space.IsVIP {
+		blocks, err := s.vipBlockRepo.GetActiveBlocksBySpace(space.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get VIP blocks: %w", err)
+		}
+		for _, block := range blocks {
+			if !block.AllowsRole(user.Role) && block.Overlaps(query.StartTime, query.EndTime) {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// score rates a space from 0 to 100 and lists the reasons behind the rating
+func (s *SpaceRecommendationService) score(space *models.Space, user *models.User, query RecommendationQuery, bookings, maxBookings int64, home *homeLocation) dto.SpaceRecommendation {
+	var earned, possible float64
+	var reasons []string
+
+	// Capacity: a snug fit beats an oversized room
+	fit := float64(query.Participants) / float64(space.Capacity)
+	earned += capacityWeight * fit
+	possible += capacityWeight
+	if fit >= 0.5 {
+		reasons = append(reasons, fmt.Sprintf("Seats %d, a good fit for %d participants", space.Capacity, query.Participants))
+	} else {
+		reasons = append(reasons, fmt.Sprintf("Seats %d, larger than needed for %d participants", space.Capacity, query.Participants))
+	}
+
+	// Amenities
+	equipment := spaceEquipment(space)
+	var missing []string
+	if len(query.Amenities) > 0 {
+		var matched []string
+		for _, amenity := range query.Amenities {
+			if hasEquipment(equipment, amenity) {
+				matched = append(matched, amenity)
+			} else {
+				missing = append(missing, amenity)
+			}
+		}
+		earned += amenityWeight * float64(len(matched)) / float64(len(query.Amenities))
+		possible += amenityWeight
+		if len(missing) == 0 {
+			reasons = append(reasons, "Has everything you asked for: "+strings.Join(matched, ", "))
+		} else if len(matched) > 0 {
+			reasons = append(reasons, "Has "+strings.Join(matched, ", ")+" but no "+strings.Join(missing, ", "))
+		} else {
+			reasons = append(reasons, "Has none of the requested amenities")
+		}
+	}
+
+	// Booking history
+	if maxBookings > 0 {
+		earned += historyWeight * float64(bookings) / float64(maxBookings)
+		possible += historyWeight
+		if bookings > 0 {
+			reasons = append(reasons, fmt.Sprintf("You booked it %d time(s) in the last 90 days", bookings))
+		}
+	}
+
+	// Proximity to where the department usually meets
+	if home != nil {
+		possible += proximityWeight
+		switch {
+		case space.Building != home.building:
+			reasons = append(reasons, fmt.Sprintf("In %s, away from %s's usual building %s", space.Building, user.Department, home.building))
+		case space.Floor == home.floor:
+			earned += proximityWeight
+			reasons = append(reasons, fmt.Sprintf("On %s's usual floor", user.Department))
+		default:
+			floors := int(math.Abs(float64(space.Floor - home.floor)))
+			earned += math.Max(0, proximityWeight-5*float64(floors))
+			reasons = append(reasons, fmt.Sprintf("%d floor(s) from %s's usual floor", floors, user.Department))
+		}
+	}
+
+	if space.RequiresApproval {
+		reasons = append(reasons, "Requires manager approval")
+	}
+
+	response := dto.ToSpaceResponse(space)
+	response.Equipment = equipment
+	response.RequiresApproval = space.RequiresApproval
+	response.IsAvailable = true
+
+	return dto.SpaceRecommendation{
+		Space:            response,
+		Score:            int(math.Round(100 * earned / possible)),
+		Reasons:          reasons,
+		MissingAmenities: missing,
+	}
+}
+
+// departmentHome finds the building and floor the department booked most recently, or nil if unknown
+func (s *SpaceRecommendationService) departmentHome(department string, since time.Time) (*homeLocation, error) {
+	if department == "" {
+		return nil, nil
+	}
+
+	reservations, err := s.reservationRepo.GetDepartmentReservations(department, since, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get department bookings: %w", err)
+	}
+
+	counts := make(map[homeLocation]int)
+	var best *homeLocation
+	for _, reservation := range reservations {
+		if reservation.Space.ID == uuid.Nil {
+			continue
+		}
+		location := homeLocation{building: reservation.Space.Building, floor: reservation.Space.Floor}
+		counts[location]++
+		if best == nil || counts[location] > counts[*best] {
+			best = &location
+		}
+	}
+	return best, nil
+}
+
+// spaceEquipment decodes the equipment list of a space
+func spaceEquipment(space *models.Space) []dto.Equipment {
+	var equipment []dto.Equipment
+	if len(space.Equipment) > 0 {
+		_ = json.Unmarshal(space.Equipment, &equipment)
+	}
+	return equipment
+}
+
+// hasEquipment checks for an amenity by name, ignoring case
+func hasEquipment(equipment []dto.Equipment, amenity string) bool {
+	for _, item := range equipment {
+		if strings.EqualFold(item.Name, amenity) && item.Quantity != 0 {
+			return true
+		}
+	}
+	return false
+}
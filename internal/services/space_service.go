@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/datatypes"
+	"gorm.io/gorm"
 
 	"room-reservation-api/internal/dto"
 	"room-reservation-api/internal/models"
@@ -58,7 +60,7 @@ func (s *SpaceService) CreateSpace(req *dto.CreateSpaceRequest, userID uuid.UUID
 		return nil, errors.New("building is required")
 	}
 	if req.Capacity <= 0 {
-		return nil, errors.New("capacity must be greater than 0")
+		return nil, dto.ErrSpaceInvalidCapacity
 	}
 
 	// Check if space name already exists in the same building
@@ -67,7 +69,7 @@ func (s *SpaceService) CreateSpace(req *dto.CreateSpaceRequest, userID uuid.UUID
 		return nil, fmt.Errorf("failed to check space existence: %w", err)
 	}
 	if exists {
-		return nil, errors.New("space with this name already exists in the building")
+		return nil, dto.ErrSpaceNameTaken
 	}
 
 	// Validate manager if provided
@@ -117,26 +119,75 @@ func (s *SpaceService) CreateSpace(req *dto.CreateSpaceRequest, userID uuid.UUID
 		maxBookingDuration = 480 // 8 hours default
 	}
 
+	// Proof of presence at check-in
+	presence := models.PresenceCheck(req.CheckInPresence)
+	if presence == "" {
+		presence = models.PresenceCheckNone
+	}
+	geofenceRadius := req.GeofenceRadius
+	if geofenceRadius == 0 {
+		geofenceRadius = 150
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return nil, errors.New("latitude and longitude must be set together")
+	}
+	if err := validatePresenceSettings(presence, len(req.BeaconIDs), req.Latitude != nil && req.Longitude != nil); err != nil {
+		return nil, err
+	}
+
+	// Soft launch before general availability
+	if req.PilotUntil != nil && !req.PilotUntil.After(time.Now()) {
+		return nil, errors.New("pilot_until must be in the future")
+	}
+
+	attributesJSON, err := resourceAttributesJSON(models.SpaceType(req.Type), req.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	combinationOf, err := s.combinationParts(uuid.Nil, req.Building, req.CombinationOf)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create space
 	space := &models.Space{
-		Name:               req.Name,
-		Type:               models.SpaceType(req.Type),
-		Capacity:           req.Capacity,
-		Building:           req.Building,
-		Floor:              req.Floor,
-		RoomNumber:         req.RoomNumber,
-		Equipment:          equipmentJSON,
-		Status:             models.SpaceStatus(status),
-		Description:        req.Description,
-		Surface:            req.Surface,
-		Photos:             photosJSON,
-		PricePerHour:       req.PricePerHour,
-		PricePerDay:        req.PricePerDay,
-		PricePerMonth:      req.PricePerMonth,
-		ManagerID:          managerID,
-		RequiresApproval:   req.RequiresApproval,
-		BookingAdvanceTime: bookingAdvanceTime,
-		MaxBookingDuration: maxBookingDuration,
+		Name:                req.Name,
+		Type:                models.SpaceType(req.Type),
+		Capacity:            req.Capacity,
+		Building:            req.Building,
+		Floor:               req.Floor,
+		RoomNumber:          req.RoomNumber,
+		Equipment:           equipmentJSON,
+		Attributes:          attributesJSON,
+		Status:              models.SpaceStatus(status),
+		Description:         req.Description,
+		Surface:             req.Surface,
+		Photos:              photosJSON,
+		PricePerHour:        req.PricePerHour,
+		PricePerDay:         req.PricePerDay,
+		PricePerMonth:       req.PricePerMonth,
+		CostPerHour:         req.CostPerHour,
+		ManagerID:           managerID,
+		RequiresApproval:    req.RequiresApproval,
+		IsVIP:               req.IsVIP,
+		IsPremium:           req.IsPremium,
+		EnergyRating:        req.EnergyRating,
+		BookingAdvanceTime:  bookingAdvanceTime,
+		MaxBookingDuration:  maxBookingDuration,
+		BufferBefore:        req.BufferBefore,
+		BufferAfter:         req.BufferAfter,
+		CancellationWindow:  req.CancellationWindow,
+		EarlyReleaseMinutes: req.EarlyReleaseMinutes,
+		PreemptionTier:      req.PreemptionTier,
+		CheckInPresence:     presence,
+		BeaconIDs:           pq.StringArray(req.BeaconIDs),
+		PilotUntil:          req.PilotUntil,
+		PilotUserIDs:        pilotUserIDs(req.PilotUserIDs),
+		PilotDepartments:    pq.StringArray(req.PilotDepartments),
+		CombinationOf:       combinationOf,
+		Latitude:            req.Latitude,
+		Longitude:           req.Longitude,
+		GeofenceRadius:      geofenceRadius,
 	}
 
 	createdSpace, err := s.spaceRepo.Create(space)
@@ -147,13 +198,27 @@ func (s *SpaceService) CreateSpace(req *dto.CreateSpaceRequest, userID uuid.UUID
 	return createdSpace, nil
 }
 
-// GetSpaceByID retrieves a space by ID
-func (s *SpaceService) GetSpaceByID(spaceID uuid.UUID) (*models.Space, error) {
+// GetSpaceByID retrieves a space by ID. A space in soft launch is not found by viewers
+// outside its pilot group; viewerID is nil for anonymous viewers.
+func (s *SpaceService) GetSpaceByID(spaceID uuid.UUID, viewerID *uuid.UUID) (*models.Space, error) {
 	space, err := s.spaceRepo.GetByID(spaceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get space: %w", err)
 	}
 
+	if space.InPilot(time.Now()) {
+		if viewerID == nil {
+			return nil, gorm.ErrRecordNotFound
+		}
+		viewer, err := s.userRepo.GetByID(*viewerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if !space.PilotAllows(viewer, time.Now()) {
+			return nil, gorm.ErrRecordNotFound
+		}
+	}
+
 	return space, nil
 }
 
@@ -167,7 +232,10 @@ func (s *SpaceService) UpdateSpace(spaceID uuid.UUID, req *dto.UpdateSpaceReques
 
 	// Check permissions
 	if !s.canUserModifySpace(space, userID) {
-		return nil, errors.New("access denied")
+		return nil, dto.ErrAccessDenied
+	}
+	if err := checkUnmodified(space.UpdatedAt, req.UpdatedAt); err != nil {
+		return nil, err
 	}
 
 	// Build updates map
@@ -191,7 +259,7 @@ func (s *SpaceService) UpdateSpace(spaceID uuid.UUID, req *dto.UpdateSpaceReques
 				return nil, fmt.Errorf("failed to check space existence: %w", err)
 			}
 			if exists {
-				return nil, errors.New("space with this name already exists in the building")
+				return nil, dto.ErrSpaceNameTaken
 			}
 		}
 		updates["name"] = *req.Name
@@ -202,7 +270,7 @@ func (s *SpaceService) UpdateSpace(spaceID uuid.UUID, req *dto.UpdateSpaceReques
 	}
 	if req.Capacity != nil {
 		if *req.Capacity <= 0 {
-			return nil, errors.New("capacity must be greater than 0")
+			return nil, dto.ErrSpaceInvalidCapacity
 		}
 		updates["capacity"] = *req.Capacity
 	}
@@ -239,9 +307,21 @@ func (s *SpaceService) UpdateSpace(spaceID uuid.UUID, req *dto.UpdateSpaceReques
 	if req.PricePerMonth != nil {
 		updates["price_per_month"] = *req.PricePerMonth
 	}
+	if req.CostPerHour != nil {
+		updates["cost_per_hour"] = *req.CostPerHour
+	}
 	if req.RequiresApproval != nil {
 		updates["requires_approval"] = *req.RequiresApproval
 	}
+	if req.IsVIP != nil {
+		updates["is_vip"] = *req.IsVIP
+	}
+	if req.IsPremium != nil {
+		updates["is_premium"] = *req.IsPremium
+	}
+	if req.EnergyRating != nil {
+		updates["energy_rating"] = *req.EnergyRating
+	}
 	if req.BookingAdvanceTime != nil {
 		updates["booking_advance_time"] = *req.BookingAdvanceTime
 	}
@@ -252,6 +332,104 @@ func (s *SpaceService) UpdateSpace(spaceID uuid.UUID, req *dto.UpdateSpaceReques
 		updates["max_booking_duration"] = *req.MaxBookingDuration
 	}
 
+	// Proof of presence settings are validated as they will be after the update
+	presence := space.CheckInPresence
+	if req.CheckInPresence != nil {
+		presence = models.PresenceCheck(*req.CheckInPresence)
+		updates["check_in_presence"] = presence
+	}
+	beaconCount := len(space.BeaconIDs)
+	if req.BeaconIDs != nil {
+		beaconCount = len(req.BeaconIDs)
+		updates["beacon_ids"] = pq.StringArray(req.BeaconIDs)
+	}
+	hasLocation := space.Latitude != nil && space.Longitude != nil
+	if req.Latitude != nil || req.Longitude != nil {
+		if req.Latitude == nil || req.Longitude == nil {
+			return nil, errors.New("latitude and longitude must be set together")
+		}
+		hasLocation = true
+		updates["latitude"] = *req.Latitude
+		updates["longitude"] = *req.Longitude
+	}
+	if req.GeofenceRadius != nil {
+		updates["geofence_radius"] = *req.GeofenceRadius
+	}
+	if req.BufferBefore != nil {
+		updates["buffer_before"] = *req.BufferBefore
+	}
+	if req.BufferAfter != nil {
+		updates["buffer_after"] = *req.BufferAfter
+	}
+	if req.CancellationWindow != nil {
+		updates["cancellation_window"] = *req.CancellationWindow
+	}
+	if req.EarlyReleaseMinutes != nil {
+		updates["early_release_minutes"] = *req.EarlyReleaseMinutes
+	}
+	if req.PreemptionTier != nil {
+		updates["preemption_tier"] = *req.PreemptionTier
+	}
+	if req.PilotUntil != nil {
+		updates["pilot_until"] = *req.PilotUntil
+	}
+	if req.PilotUserIDs != nil {
+		updates["pilot_user_ids"] = pilotUserIDs(req.PilotUserIDs)
+	}
+	if req.PilotDepartments != nil {
+		updates["pilot_departments"] = pq.StringArray(req.PilotDepartments)
+	}
+
+	// Combined spaces and their parts stay in one building
+	building := space.Building
+	if req.Building != nil {
+		building = *req.Building
+	}
+	if req.CombinationOf != nil || (building != space.Building && space.IsCombined()) {
+		partIDs := req.CombinationOf
+		if partIDs == nil {
+			for _, id := range space.CombinationOf {
+				partIDs = append(partIDs, uuid.MustParse(id))
+			}
+		}
+		combinationOf, err := s.combinationParts(spaceID, building, partIDs)
+		if err != nil {
+			return nil, err
+		}
+		updates["combination_of"] = combinationOf
+	}
+	if building != space.Building {
+		combined, err := s.spaceRepo.GetCombinedSpacesIncluding(spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get combined spaces: %w", err)
+		}
+		if len(combined) > 0 {
+			return nil, fmt.Errorf("the space is part of %s and cannot move to another building", combined[0].Name)
+		}
+	}
+
+	if err := validatePresenceSettings(presence, beaconCount, hasLocation); err != nil {
+		return nil, err
+	}
+
+	// Attributes are checked against the type they will have after the update
+	if req.Type != nil || req.Attributes != nil {
+		spaceType := space.Type
+		if req.Type != nil {
+			spaceType = models.SpaceType(*req.Type)
+		}
+		attributes := req.Attributes
+		if attributes == nil {
+			current := dto.ResourceAttributes(space.GetAttributes())
+			attributes = &current
+		}
+		attributesJSON, err := resourceAttributesJSON(spaceType, attributes)
+		if err != nil {
+			return nil, err
+		}
+		updates["attributes"] = attributesJSON
+	}
+
 	// Handle manager assignment
 	if req.ManagerID != nil {
 		if *req.ManagerID != uuid.Nil {
@@ -292,7 +470,16 @@ func (s *SpaceService) UpdateSpace(spaceID uuid.UUID, req *dto.UpdateSpaceReques
 		}
 	}
 
-	updatedSpace, err := s.spaceRepo.Update(spaceID, updates)
+	var updatedSpace *models.Space
+	if req.UpdatedAt != nil {
+		updatedSpace, err = s.spaceRepo.UpdateIfUnmodified(spaceID, *req.UpdatedAt, updates)
+		err = staleUpdateError(err)
+	} else {
+		updatedSpace, err = s.spaceRepo.Update(spaceID, updates)
+	}
+	if errors.Is(err, dto.ErrStaleUpdate) {
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to update space: %w", err)
 	}
@@ -333,6 +520,14 @@ func (s *SpaceService) DeleteSpace(spaceID, userID uuid.UUID) error {
 		return errors.New("cannot delete space with active reservations")
 	}
 
+	combined, err := s.spaceRepo.GetCombinedSpacesIncluding(spaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get combined spaces: %w", err)
+	}
+	if len(combined) > 0 {
+		return fmt.Errorf("cannot delete a space that is part of %s", combined[0].Name)
+	}
+
 	// Delete the space
 	err = s.spaceRepo.Delete(spaceID)
 	if err != nil {
@@ -346,13 +541,18 @@ func (s *SpaceService) DeleteSpace(spaceID, userID uuid.UUID) error {
 // LISTING AND SEARCH OPERATIONS
 // ========================================
 
-// GetAllSpaces retrieves all spaces with pagination
-func (s *SpaceService) GetAllSpaces(offset, limit int) ([]*models.Space, int64, error) {
+// GetAllSpaces retrieves all spaces the viewer can see with pagination
+func (s *SpaceService) GetAllSpaces(offset, limit int, viewerID *uuid.UUID) ([]*models.Space, int64, error) {
 	if limit <= 0 {
 		limit = 20
 	}
 
-	spaces, total, err := s.spaceRepo.GetAll(offset, limit)
+	viewer, err := s.pilotViewer(viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	spaces, total, err := s.spaceRepo.GetAll(viewer, offset, limit)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get spaces: %w", err)
 	}
@@ -360,12 +560,43 @@ func (s *SpaceService) GetAllSpaces(offset, limit int) ([]*models.Space, int64,
 	return spaces, total, nil
 }
 
-// SearchSpaces searches spaces with filters
-func (s *SpaceService) SearchSpaces(filters interfaces.SpaceFilters, offset, limit int) ([]*models.Space, int64, error) {
+// GetSpacesPage gets a page of the spaces the viewer can see after the cursor, by name, and
+// the cursor of the next page when there is one
+func (s *SpaceService) GetSpacesPage(after *dto.Cursor, limit int, viewerID *uuid.UUID) ([]*models.Space, *dto.Cursor, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	viewer, err := s.pilotViewer(viewerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	spaces, err := s.spaceRepo.GetAllAfter(viewer, after, limit+1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get spaces: %w", err)
+	}
+
+	if len(spaces) <= limit {
+		return spaces, nil, nil
+	}
+	spaces = spaces[:limit]
+	last := spaces[limit-1]
+	return spaces, &dto.Cursor{Key: last.Name, ID: last.ID}, nil
+}
+
+// SearchSpaces searches the spaces the viewer can see with filters
+func (s *SpaceService) SearchSpaces(filters interfaces.SpaceFilters, offset, limit int, viewerID *uuid.UUID) ([]*models.Space, int64, error) {
 	if limit <= 0 {
 		limit = 20
 	}
 
+	viewer, err := s.pilotViewer(viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	filters.PilotViewer = viewer
+
 	spaces, total, err := s.spaceRepo.SearchSpaces(filters, offset, limit)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to search spaces: %w", err)
@@ -374,13 +605,77 @@ func (s *SpaceService) SearchSpaces(filters interfaces.SpaceFilters, offset, lim
 	return spaces, total, nil
 }
 
+// SearchResources searches the bookable resources of one class, e.g. parking spots with EV charging.
+// Attribute filters of other classes are ignored.
+func (s *SpaceService) SearchResources(class models.ResourceClass, req *dto.ResourceSearchRequest, viewerID *uuid.UUID) ([]*models.Space, int64, error) {
+	if (req.AvailableStart == nil) != (req.AvailableEnd == nil) {
+		return nil, 0, errors.New("available_start and available_end must be set together")
+	}
+	if req.AvailableStart != nil && !req.AvailableEnd.After(*req.AvailableStart) {
+		return nil, 0, errors.New("available_end must be after available_start")
+	}
+
+	filters := interfaces.SpaceFilters{
+		ResourceClass:  string(class),
+		Status:         []string{string(models.SpaceStatusAvailable)},
+		MinCapacity:    req.MinCapacity,
+		AvailableStart: req.AvailableStart,
+		AvailableEnd:   req.AvailableEnd,
+		SortBy:         "name",
+	}
+	if req.Building != "" {
+		filters.Buildings = []string{req.Building}
+	}
+	if req.Floor != nil {
+		filters.Floors = []int{*req.Floor}
+	}
+
+	viewer, err := s.pilotViewer(viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	filters.PilotViewer = viewer
+
+	switch class {
+	case models.ResourceClassDesk:
+		filters.MinMonitors = req.MinMonitors
+		filters.StandingDesk = req.StandingDesk
+		filters.DockingStation = req.DockingStation
+	case models.ResourceClassParking:
+		filters.EVCharging = req.EVCharging
+	case models.ResourceClassEquipment:
+		filters.EquipmentCategory = req.Category
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	offset := 0
+	if req.Page > 1 {
+		offset = (req.Page - 1) * limit
+	}
+
+	spaces, total, err := s.spaceRepo.SearchSpaces(filters, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search resources: %w", err)
+	}
+
+	return spaces, total, nil
+}
+
 // GetSpacesByBuilding retrieves spaces in a specific building
-func (s *SpaceService) GetSpacesByBuilding(building string, offset, limit int) ([]*models.Space, int64, error) {
+func (s *SpaceService) GetSpacesByBuilding(building string, offset, limit int, viewerID *uuid.UUID) ([]*models.Space, int64, error) {
 	if limit <= 0 {
 		limit = 20
 	}
 
-	spaces, total, err := s.spaceRepo.GetSpacesByBuilding(building, offset, limit)
+	viewer, err := s.pilotViewer(viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	spaces, total, err := s.spaceRepo.GetSpacesByBuilding(building, viewer, offset, limit)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get spaces by building: %w", err)
 	}
@@ -389,12 +684,17 @@ func (s *SpaceService) GetSpacesByBuilding(building string, offset, limit int) (
 }
 
 // GetSpacesByType retrieves spaces of a specific type
-func (s *SpaceService) GetSpacesByType(spaceType string, offset, limit int) ([]*models.Space, int64, error) {
+func (s *SpaceService) GetSpacesByType(spaceType string, offset, limit int, viewerID *uuid.UUID) ([]*models.Space, int64, error) {
 	if limit <= 0 {
 		limit = 20
 	}
 
-	spaces, total, err := s.spaceRepo.GetSpacesByType(spaceType, offset, limit)
+	viewer, err := s.pilotViewer(viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	spaces, total, err := s.spaceRepo.GetSpacesByType(spaceType, viewer, offset, limit)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get spaces by type: %w", err)
 	}
@@ -411,7 +711,7 @@ func (s *SpaceService) GetSpacesByStatus(status string, offset, limit int, userI
 	}
 
 	if !user.IsAdmin() && !user.IsManager() {
-		return nil, 0, errors.New("access denied")
+		return nil, 0, dto.ErrAccessDenied
 	}
 
 	if limit <= 0 {
@@ -445,7 +745,7 @@ func (s *SpaceService) GetSpacesByCapacityRange(minCapacity, maxCapacity int, of
 // ========================================
 
 // GetAvailableSpaces retrieves spaces available during a specific time period
-func (s *SpaceService) GetAvailableSpaces(startTime, endTime time.Time, offset, limit int) ([]*models.Space, int64, error) {
+func (s *SpaceService) GetAvailableSpaces(startTime, endTime time.Time, offset, limit int, viewerID *uuid.UUID) ([]*models.Space, int64, error) {
 	// Validate time range
 	if startTime.After(endTime) {
 		return nil, 0, errors.New("start time must be before end time")
@@ -459,11 +759,16 @@ func (s *SpaceService) GetAvailableSpaces(startTime, endTime time.Time, offset,
 		limit = 20
 	}
 
+	viewer, err := s.pilotViewer(viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Use space repo method if available, otherwise implement logic here
-	spaces, total, err := s.spaceRepo.GetAvailableSpaces(startTime, endTime, offset, limit)
+	spaces, total, err := s.spaceRepo.GetAvailableSpaces(startTime, endTime, viewer, offset, limit)
 	if err != nil {
 		// Fallback: get all spaces and filter manually
-		allSpaces, _, err := s.spaceRepo.GetAll(0, 1000) // Get reasonable amount
+		allSpaces, _, err := s.spaceRepo.GetAll(viewer, 0, 1000) // Get reasonable amount
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to get spaces: %w", err)
 		}
@@ -568,7 +873,7 @@ func (s *SpaceService) GetSpacesByManager(managerID uuid.UUID, offset, limit int
 
 	// Users can only view their own managed spaces (if they're managers) or admins can view any
 	if !user.IsAdmin() && userID != managerID {
-		return nil, 0, errors.New("access denied")
+		return nil, 0, dto.ErrAccessDenied
 	}
 
 	if limit <= 0 {
@@ -688,6 +993,8 @@ func (s *SpaceService) GetSpaceOptions() (*dto.SpaceOptionsResponse, error) {
 		{Value: "auditorium", Label: "Auditorium", Description: "Large presentation spaces"},
 		{Value: "open_space", Label: "Open Space", Description: "Collaborative open areas"},
 		{Value: "hot_desk", Label: "Hot Desk", Description: "Flexible workstations"},
+		{Value: "parking_spot", Label: "Parking Spot", Description: "Car parking spaces"},
+		{Value: "equipment", Label: "Equipment", Description: "Bookable equipment, alone or with a room"},
 	}
 
 	// Define statuses
@@ -729,7 +1036,7 @@ func (s *SpaceService) GetSpaceCountByStatus(status string, userID uuid.UUID) (i
 	}
 
 	if !user.IsAdmin() && !user.IsManager() {
-		return 0, errors.New("access denied")
+		return 0, dto.ErrAccessDenied
 	}
 
 	count, err := s.spaceRepo.CountSpacesByStatus(status)
@@ -754,7 +1061,7 @@ func (s *SpaceService) GetSpaceCountByBuilding(building string) (int64, error) {
 func (s *SpaceService) GetSpaceReservationCount(spaceID uuid.UUID, userID uuid.UUID) (int64, error) {
 	// Check if user can view space stats
 	if !s.canUserViewSpaceStats(spaceID, userID) {
-		return 0, errors.New("access denied")
+		return 0, dto.ErrAccessDenied
 	}
 
 	count, err := s.reservationRepo.CountSpaceReservations(spaceID)
@@ -832,3 +1139,129 @@ func (s *SpaceService) findNextAvailableSlot(spaceID uuid.UUID, startSearchTime
 
 	return nil
 }
+
+// validatePresenceSettings checks a space can verify the proof of presence it asks for
+func validatePresenceSettings(presence models.PresenceCheck, beaconCount int, hasLocation bool) error {
+	switch presence {
+	case models.PresenceCheckBeacon:
+		if beaconCount == 0 {
+			return errors.New("beacon check-in requires at least one beacon ID")
+		}
+	case models.PresenceCheckGeofence:
+		if !hasLocation {
+			return errors.New("geofenced check-in requires the space's latitude and longitude")
+		}
+	case models.PresenceCheckBeaconOrGeofence:
+		if beaconCount == 0 && !hasLocation {
+			return errors.New("presence check requires beacon IDs or the space's latitude and longitude")
+		}
+	}
+	return nil
+}
+
+// pilotViewer resolves who is browsing spaces: nil for staff, who see every space, and an
+// empty viewer for anonymous visitors, who see no space in soft launch
+func (s *SpaceService) pilotViewer(viewerID *uuid.UUID) (*interfaces.PilotViewer, error) {
+	if viewerID == nil {
+		return &interfaces.PilotViewer{}, nil
+	}
+	user, err := s.userRepo.GetByID(*viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return pilotViewerOf(user), nil
+}
+
+// pilotViewerOf matches a user against the pilot groups of spaces in soft launch;
+// managers and admins are not restricted
+func pilotViewerOf(user *models.User) *interfaces.PilotViewer {
+	if user.HasStaffAccess() {
+		return nil
+	}
+	return &interfaces.PilotViewer{UserID: user.ID, Department: user.Department}
+}
+
+// combinationParts checks the parts of a combined space and returns them as stored: at least
+// two plain spaces of its building other than itself. Combined spaces do not nest, so a part of
+// a combined space cannot combine others; spaceID is uuid.Nil for a new space. No parts make a
+// plain space.
+func (s *SpaceService) combinationParts(spaceID uuid.UUID, building string, partIDs []uuid.UUID) (pq.StringArray, error) {
+	parts := make(pq.StringArray, 0, len(partIDs))
+	if len(partIDs) == 0 {
+		return parts, nil
+	}
+	if len(partIDs) < 2 {
+		return nil, errors.New("a combined space joins at least two spaces")
+	}
+
+	if spaceID != uuid.Nil {
+		combined, err := s.spaceRepo.GetCombinedSpacesIncluding(spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get combined spaces: %w", err)
+		}
+		if len(combined) > 0 {
+			return nil, fmt.Errorf("the space is part of %s and cannot combine other spaces", combined[0].Name)
+		}
+	}
+
+	for _, partID := range partIDs {
+		if partID == spaceID {
+			return nil, errors.New("a combined space cannot include itself")
+		}
+		part, err := s.spaceRepo.GetByID(partID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("combined part %s does not exist", partID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get space: %w", err)
+		}
+		if part.Building != building {
+			return nil, fmt.Errorf("%s is not in building %s", part.Name, building)
+		}
+		if part.IsCombined() {
+			return nil, fmt.Errorf("%s is itself a combined space", part.Name)
+		}
+		parts = append(parts, partID.String())
+	}
+	return parts, nil
+}
+
+// pilotUserIDs converts the pilot group's user IDs to the stored array
+func pilotUserIDs(ids []uuid.UUID) pq.StringArray {
+	values := make(pq.StringArray, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return values
+}
+
+// resourceAttributesJSON checks the attributes belong to the space type's resource class
+// and serializes them; parking spots must have a spot number
+func resourceAttributesJSON(spaceType models.SpaceType, attributes *dto.ResourceAttributes) (datatypes.JSON, error) {
+	class := models.ResourceClassOf(spaceType)
+	if attributes == nil {
+		attributes = &dto.ResourceAttributes{}
+	}
+
+	if class != models.ResourceClassParking && (attributes.SpotNumber != "" || attributes.RequiresLicensePlate || attributes.EVCharging) {
+		return nil, errors.New("spot_number, requires_license_plate and ev_charging only apply to parking spots")
+	}
+	if class != models.ResourceClassDesk && (attributes.MonitorCount > 0 || attributes.StandingDesk || attributes.DockingStation) {
+		return nil, errors.New("monitor_count, standing_desk and docking_station only apply to desks")
+	}
+	if class != models.ResourceClassEquipment && (attributes.AssetTag != "" || attributes.Category != "") {
+		return nil, errors.New("asset_tag and category only apply to equipment")
+	}
+	if class == models.ResourceClassParking && attributes.SpotNumber == "" {
+		return nil, errors.New("parking spots require a spot number")
+	}
+
+	if *attributes == (dto.ResourceAttributes{}) {
+		return nil, nil
+	}
+	attributesBytes, err := json.Marshal(models.ResourceAttributes(*attributes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize attributes: %w", err)
+	}
+	return datatypes.JSON(attributesBytes), nil
+}
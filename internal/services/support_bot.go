@@ -0,0 +1,189 @@
+// internal/services/support_bot.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+	"room-reservation-api/internal/websocket"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Sender name shown on bot messages
+	supportBotName = "Support Bot"
+	// Tag marking conversations the bot has passed on to a human agent
+	botHandoffTag = "bot_handoff"
+)
+
+// Phrases customers use to skip the bot and ask for a person
+var agentRequestKeywords = []string{"agent", "human", "real person"}
+
+// SupportBot answers common questions in support conversations with auto-reply
+// canned responses, and routes everything else to an available agent.
+type SupportBot struct {
+	cannedRepo      interfaces.CannedResponseRepository
+	chatRepo        interfaces.ChatRepository
+	agentAssignment *AgentAssignmentService
+	wsManager       *websocket.Manager
+	logger          *slog.Logger
+}
+
+// NewSupportBot creates a new support bot instance
+func NewSupportBot(
+	cannedRepo interfaces.CannedResponseRepository,
+	chatRepo interfaces.ChatRepository,
+	agentAssignment *AgentAssignmentService,
+	wsManager *websocket.Manager,
+	logger *slog.Logger,
+) *SupportBot {
+	return &SupportBot{
+		cannedRepo:      cannedRepo,
+		chatRepo:        chatRepo,
+		agentAssignment: agentAssignment,
+		wsManager:       wsManager,
+		logger:          logger,
+	}
+}
+
+// HandleCustomerMessage reacts to a customer's text message while no agent has
+// picked up the conversation. Messages from admins and agents are ignored.
+func (b *SupportBot) HandleCustomerMessage(ctx context.Context, message *models.Message) {
+	if message.SenderType != models.SenderTypeUser || message.MessageType != models.MessageTypeText {
+		return
+	}
+
+	if _, err := b.chatRepo.GetSupportAgentByID(ctx, message.SenderID); err == nil {
+		return
+	}
+
+	conversation, err := b.chatRepo.GetConversationByID(ctx, message.ConversationID)
+	if err != nil {
+		b.logger.Warn("Support bot failed to load conversation", "conversationID", message.ConversationID, "error", err)
+		return
+	}
+	if conversation.AttendeeChat || conversation.Encrypted || conversation.AssignedAgentID != nil || hasTag(conversation.Tags, botHandoffTag) {
+		return
+	}
+
+	if !containsAny(message.Content, agentRequestKeywords) {
+		response, err := b.bestAutoReply(ctx, message.Content)
+		if err != nil {
+			b.logger.Warn("Support bot failed to load auto replies", "error", err)
+		}
+		if response != nil {
+			b.reply(ctx, conversation.ID, response.Content+"\n\nReply \"agent\" if you still need help from a person.",
+				map[string]interface{}{"canned_response_id": response.ID})
+
+			if err := b.cannedRepo.IncrementUsage(ctx, response.ID); err != nil {
+				b.logger.Warn("Failed to record canned response usage", "cannedResponseID", response.ID, "error", err)
+			}
+			return
+		}
+	}
+
+	b.handOff(ctx, conversation)
+}
+
+// bestAutoReply returns the auto reply with the most keyword matches, if any
+func (b *SupportBot) bestAutoReply(ctx context.Context, content string) (*models.CannedResponse, error) {
+	responses, err := b.cannedRepo.GetAutoReplies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *models.CannedResponse
+	bestScore := 0
+	for i := range responses {
+		if score := responses[i].MatchScore(content); score > bestScore {
+			best = &responses[i]
+			bestScore = score
+		}
+	}
+	return best, nil
+}
+
+// handOff passes the conversation to the assignment engine, which offers it to
+// the best available agent or escalates it to admins. Either way the bot stops answering.
+func (b *SupportBot) handOff(ctx context.Context, conversation *models.Conversation) {
+	conversation.Tags = append(conversation.Tags, botHandoffTag)
+	if err := b.chatRepo.UpdateConversation(ctx, conversation); err != nil {
+		b.logger.Error("Support bot failed to hand off conversation", "conversationID", conversation.ID, "error", err)
+		return
+	}
+
+	agent, err := b.agentAssignment.AutoAssign(ctx, conversation)
+	if err != nil {
+		b.logger.Error("Support bot failed to assign an agent", "conversationID", conversation.ID, "error", err)
+	}
+
+	if agent == nil {
+		b.reply(ctx, conversation.ID, "All our agents are busy right now. Your question is in the queue and an agent will reply here as soon as possible.", nil)
+		return
+	}
+
+	b.reply(ctx, conversation.ID, fmt.Sprintf("I've passed your question to %s, who will reply here shortly.", agent.GetFullName()), nil)
+	b.logger.Info("Support bot handed off conversation", "conversationID", conversation.ID, "agentID", agent.ID)
+}
+
+// reply posts a bot message to the conversation and pushes it to participants
+func (b *SupportBot) reply(ctx context.Context, conversationID uuid.UUID, content string, metadata map[string]interface{}) {
+	message := &models.Message{
+		ConversationID: conversationID,
+		SenderID:       uuid.Nil, // System message
+		SenderName:     supportBotName,
+		SenderType:     models.SenderTypeBot,
+		Content:        content,
+		MessageType:    models.MessageTypeText,
+	}
+
+	if metadata != nil {
+		metadataJSON, err := json.Marshal(metadata)
+		if err == nil {
+			metadataStr := string(metadataJSON)
+			message.Metadata = &metadataStr
+		}
+	}
+
+	if err := b.chatRepo.CreateMessage(ctx, message); err != nil {
+		b.logger.Error("Support bot failed to send reply", "conversationID", conversationID, "error", err)
+		return
+	}
+
+	if b.wsManager != nil {
+		b.wsManager.BroadcastMessageSent(conversationID, websocket.MessageEventData{
+			MessageID:      message.ID,
+			ConversationID: conversationID,
+			UserID:         message.SenderID,
+			Content:        message.Content,
+			MessageType:    string(message.MessageType),
+			Metadata:       metadata,
+			CreatedAt:      message.CreatedAt,
+		}, nil)
+	}
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(text string, phrases []string) bool {
+	text = strings.ToLower(text)
+	for _, phrase := range phrases {
+		if strings.Contains(text, phrase) {
+			return true
+		}
+	}
+	return false
+}
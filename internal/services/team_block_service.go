@@ -0,0 +1,572 @@
+// internal/services/team_block_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// Longest range of days a team block covers
+	teamBlockMaxDays = 92
+	// Upcoming days of a block shown with it
+	teamBlockUpcomingDays = 10
+)
+
+// TeamBlockService lets team leads block-book desks of a neighborhood for their team on chosen
+// weekdays. Each day, members are assigned a desk of the block, in the order of its desks,
+// unless they work remotely that day or already booked elsewhere. A desk freed by a member
+// going remote goes to a member left without one.
+type TeamBlockService struct {
+	blockRepo           interfaces.TeamBlockRepositoryInterface
+	floorPlanRepo       interfaces.FloorPlanRepositoryInterface
+	reservationRepo     interfaces.ReservationRepositoryInterface
+	userRepo            interfaces.UserRepositoryInterface
+	notificationService *NotificationService
+	logger              *slog.Logger
+}
+
+// NewTeamBlockService creates a new team block service
+func NewTeamBlockService(
+	blockRepo interfaces.TeamBlockRepositoryInterface,
+	floorPlanRepo interfaces.FloorPlanRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	notificationService *NotificationService,
+	logger *slog.Logger,
+) *TeamBlockService {
+	return &TeamBlockService{
+		blockRepo:           blockRepo,
+		floorPlanRepo:       floorPlanRepo,
+		reservationRepo:     reservationRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// CreateBlock block-books desks of a neighborhood for a team and assigns its members a desk
+// on every day of the block
+func (s *TeamBlockService) CreateBlock(req *dto.CreateTeamBlockRequest, userID uuid.UUID) (*dto.TeamBlockResponse, error) {
+	lead, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	department := strings.TrimSpace(req.Department)
+	if department == "" {
+		department = lead.Department
+	}
+	if department == "" {
+		return nil, errors.New("department is required")
+	}
+	if !canLeadTeam(lead, department) {
+		return nil, dto.ErrAccessDenied
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, errors.New("start_date must use the YYYY-MM-DD format")
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return nil, errors.New("end_date must use the YYYY-MM-DD format")
+	}
+	if endDate.Before(startDate) {
+		return nil, errors.New("end date must not be before start date")
+	}
+	if startDate.Before(startOfDayUTC(time.Now())) {
+		return nil, errors.New("cannot block-book desks in the past")
+	}
+	if endDate.Sub(startDate) >= teamBlockMaxDays*24*time.Hour {
+		return nil, fmt.Errorf("a team block covers at most %d days", teamBlockMaxDays)
+	}
+
+	neighborhood, err := s.floorPlanRepo.GetNeighborhoodByID(req.NeighborhoodID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get neighborhood: %w", err)
+	}
+	if !neighborhood.AllowsDepartment(department) {
+		return nil, fmt.Errorf("neighborhood %s is not open to %s", neighborhood.Name, department)
+	}
+
+	desks, err := s.neighborhoodDesks(neighborhood)
+	if err != nil {
+		return nil, err
+	}
+	deskIDs, err := blockDeskIDs(req.DeskIDs, desks)
+	if err != nil {
+		return nil, err
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	block, err := s.blockRepo.Create(&models.TeamBlock{
+		Department:     department,
+		NeighborhoodID: neighborhood.ID,
+		DeskIDs:        deskIDs,
+		Weekdays:       blockWeekdays(req.Weekdays),
+		StartDate:      startDate,
+		EndDate:        endDate,
+		DayStart:       req.DayStart,
+		DayEnd:         req.DayEnd,
+		Timezone:       timezone,
+		CreatedByID:    userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create team block: %w", err)
+	}
+
+	members, err := s.teamMembers(department)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toTeamBlockResponse(block)
+	for _, day := range block.Days() {
+		state, _, err := s.assignDay(block, day, desks, members, true)
+		if err != nil {
+			return nil, err
+		}
+		response.Days = append(response.Days, *state)
+	}
+	return response, nil
+}
+
+// GetBlocks lists the team blocks of the user's department, or of every department for admins
+func (s *TeamBlockService) GetBlocks(userID uuid.UUID) ([]*dto.TeamBlockResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	responses := []*dto.TeamBlockResponse{}
+	department := user.Department
+	if user.IsAdmin() {
+		department = ""
+	} else if department == "" {
+		return responses, nil
+	}
+
+	blocks, err := s.blockRepo.GetAll(department)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team blocks: %w", err)
+	}
+	for _, block := range blocks {
+		responses = append(responses, toTeamBlockResponse(block))
+	}
+	return responses, nil
+}
+
+// GetBlock retrieves a team block with who sits where on its upcoming days
+func (s *TeamBlockService) GetBlock(id, userID uuid.UUID) (*dto.TeamBlockResponse, error) {
+	block, _, err := s.getTeamBlock(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toTeamBlockResponse(block)
+	if block.IsCancelled() {
+		return response, nil
+	}
+
+	members, err := s.teamMembers(block.Department)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().In(block.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for _, day := range block.Days() {
+		if day.Before(today) {
+			continue
+		}
+		if len(response.Days) == teamBlockUpcomingDays {
+			break
+		}
+		state, _, err := s.assignDay(block, day, nil, members, false)
+		if err != nil {
+			return nil, err
+		}
+		response.Days = append(response.Days, *state)
+	}
+	return response, nil
+}
+
+// SetRemote records whether a member works remotely on a day of the block. Going remote
+// cancels the member's desk, which goes to a member left without one; coming back assigns
+// them a free desk, if any. Leads may change the days of their team's members.
+func (s *TeamBlockService) SetRemote(id, userID, memberID uuid.UUID, date string, remote bool) (*dto.TeamBlockDayResponse, error) {
+	block, caller, err := s.getTeamBlock(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if block.IsCancelled() {
+		return nil, errors.New("team block is cancelled")
+	}
+	if memberID != userID && !canLeadTeam(caller, block.Department) {
+		return nil, dto.ErrAccessDenied
+	}
+
+	member, err := s.userRepo.GetByID(memberID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !strings.EqualFold(member.Department, block.Department) {
+		return nil, errors.New("user is not a member of the team")
+	}
+
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, errors.New("date must use the YYYY-MM-DD format")
+	}
+	if !block.CoversDay(day) {
+		return nil, errors.New("date is not a day of the team block")
+	}
+	start, end := block.WindowOn(day)
+	if !start.After(time.Now()) {
+		return nil, errors.New("cannot change a day that has started")
+	}
+
+	if remote {
+		if err := s.blockRepo.AddException(&models.TeamBlockException{TeamBlockID: block.ID, UserID: member.ID, Date: day}); err != nil {
+			return nil, fmt.Errorf("failed to record remote day: %w", err)
+		}
+
+		reservations, err := s.blockRepo.GetReservations(block.ID, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team bookings: %w", err)
+		}
+		for _, reservation := range reservations {
+			if reservation.UserID != member.ID {
+				continue
+			}
+			_, err := s.reservationRepo.Update(reservation.ID, map[string]interface{}{
+				"status":              models.StatusCancelled,
+				"cancellation_reason": "Working remotely",
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to cancel desk booking: %w", err)
+			}
+		}
+	} else if err := s.blockRepo.RemoveException(block.ID, member.ID, day); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user is not working remotely that day")
+		}
+		return nil, fmt.Errorf("failed to remove remote day: %w", err)
+	}
+
+	members, err := s.teamMembers(block.Department)
+	if err != nil {
+		return nil, err
+	}
+	neighborhood, err := s.floorPlanRepo.GetNeighborhoodByID(block.NeighborhoodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get neighborhood: %w", err)
+	}
+	desks, err := s.neighborhoodDesks(neighborhood)
+	if err != nil {
+		return nil, err
+	}
+
+	state, assigned, err := s.assignDay(block, day, desks, members, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, reservation := range assigned {
+		s.notifyAssigned(block, reservation)
+	}
+	return state, nil
+}
+
+// CancelBlock ends a team block and cancels its upcoming desk bookings
+func (s *TeamBlockService) CancelBlock(id, userID uuid.UUID) (int64, error) {
+	block, caller, err := s.getTeamBlock(id, userID)
+	if err != nil {
+		return 0, err
+	}
+	if !canLeadTeam(caller, block.Department) {
+		return 0, dto.ErrAccessDenied
+	}
+	if block.IsCancelled() {
+		return 0, errors.New("team block is already cancelled")
+	}
+
+	cancelled, err := s.blockRepo.Cancel(block.ID, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, errors.New("team block is already cancelled")
+		}
+		return 0, fmt.Errorf("failed to cancel team block: %w", err)
+	}
+	return cancelled, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// getTeamBlock retrieves a team block the user may see: one of their department's, or any for admins
+func (s *TeamBlockService) getTeamBlock(id, userID uuid.UUID) (*models.TeamBlock, *models.User, error) {
+	block, err := s.blockRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("failed to get team block: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsAdmin() && !strings.EqualFold(user.Department, block.Department) {
+		return nil, nil, dto.ErrAccessDenied
+	}
+	return block, user, nil
+}
+
+// assignDay works out who sits where on a day of the block. When book is set, members without
+// a desk get the first free one, unless they work remotely or already booked elsewhere at the
+// time, and the bookings made are returned.
+func (s *TeamBlockService) assignDay(block *models.TeamBlock, day time.Time, desks map[uuid.UUID]*models.Space, members []models.User, book bool) (*dto.TeamBlockDayResponse, []*models.Reservation, error) {
+	start, end := block.WindowOn(day)
+	state := &dto.TeamBlockDayResponse{
+		Date:        day.Format("2006-01-02"),
+		Assignments: []dto.TeamDeskAssignment{},
+		Remote:      []uuid.UUID{},
+		Unassigned:  []uuid.UUID{},
+	}
+
+	reservations, err := s.blockRepo.GetReservations(block.ID, start, end)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get team bookings: %w", err)
+	}
+	exceptions, err := s.blockRepo.GetExceptions(block.ID, day, day)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get remote days: %w", err)
+	}
+
+	seated := make(map[uuid.UUID]bool)
+	taken := make(map[uuid.UUID]bool)
+	for _, reservation := range reservations {
+		seated[reservation.UserID] = true
+		taken[reservation.SpaceID] = true
+		state.Assignments = append(state.Assignments, dto.TeamDeskAssignment{
+			UserID:        reservation.UserID,
+			SpaceID:       reservation.SpaceID,
+			ReservationID: reservation.ID,
+		})
+	}
+	remote := make(map[uuid.UUID]bool)
+	for _, exception := range exceptions {
+		remote[exception.UserID] = true
+		state.Remote = append(state.Remote, exception.UserID)
+	}
+
+	var booked []*models.Reservation
+	for i := range members {
+		member := &members[i]
+		if seated[member.ID] || remote[member.ID] {
+			continue
+		}
+		if !book || !start.After(time.Now()) {
+			state.Unassigned = append(state.Unassigned, member.ID)
+			continue
+		}
+
+		own, err := s.reservationRepo.GetUserOverlappingReservations(member.ID, start, end, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check member bookings: %w", err)
+		}
+		if len(own) > 0 {
+			state.Unassigned = append(state.Unassigned, member.ID)
+			continue
+		}
+
+		reservation, err := s.seatMember(block, member, desks, taken, start, end)
+		if err != nil {
+			return nil, nil, err
+		}
+		if reservation == nil {
+			state.Unassigned = append(state.Unassigned, member.ID)
+			continue
+		}
+		booked = append(booked, reservation)
+		state.Assignments = append(state.Assignments, dto.TeamDeskAssignment{
+			UserID:        member.ID,
+			SpaceID:       reservation.SpaceID,
+			ReservationID: reservation.ID,
+		})
+	}
+	return state, booked, nil
+}
+
+// seatMember books the first desk of the block that is free for the time range, nil when
+// none is. Desks found booked are marked taken.
+func (s *TeamBlockService) seatMember(block *models.TeamBlock, member *models.User, desks map[uuid.UUID]*models.Space, taken map[uuid.UUID]bool, start, end time.Time) (*models.Reservation, error) {
+	for _, rawID := range block.DeskIDs {
+		deskID, err := uuid.Parse(rawID)
+		if err != nil || taken[deskID] {
+			continue
+		}
+		desk := desks[deskID]
+		if desk == nil || !desk.IsAvailable() {
+			continue
+		}
+
+		available, err := s.reservationRepo.CheckTimeSlotAvailability(desk.ID, start, end, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check availability: %w", err)
+		}
+		taken[deskID] = true
+		if !available {
+			continue
+		}
+
+		reservation, err := s.reservationRepo.Create(&models.Reservation{
+			UserID:           member.ID,
+			SpaceID:          desk.ID,
+			StartTime:        start,
+			EndTime:          end,
+			ParticipantCount: 1,
+			Title:            fmt.Sprintf("%s team day", block.Department),
+			Status:           models.StatusConfirmed,
+			Cost:             desk.CostFor(start, end),
+			TeamBlockID:      &block.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to book desk: %w", err)
+		}
+		return reservation, nil
+	}
+	return nil, nil
+}
+
+// neighborhoodDesks retrieves the spaces of the seats of a neighborhood
+func (s *TeamBlockService) neighborhoodDesks(neighborhood *models.Neighborhood) (map[uuid.UUID]*models.Space, error) {
+	plan, err := s.floorPlanRepo.GetByID(neighborhood.FloorPlanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get floor plan: %w", err)
+	}
+
+	desks := make(map[uuid.UUID]*models.Space)
+	for _, seat := range plan.Seats {
+		if seat.NeighborhoodID != nil && *seat.NeighborhoodID == neighborhood.ID && seat.Space != nil {
+			desks[seat.SpaceID] = seat.Space
+		}
+	}
+	return desks, nil
+}
+
+// teamMembers lists the active members of a department, in a stable order so desks are
+// assigned the same way every day
+func (s *TeamBlockService) teamMembers(department string) ([]models.User, error) {
+	members, err := s.userRepo.GetUsersByDepartment(department)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].LastName != members[j].LastName {
+			return members[i].LastName < members[j].LastName
+		}
+		return members[i].FirstName < members[j].FirstName
+	})
+	return members, nil
+}
+
+// notifyAssigned tells a member a desk was booked for them on a day of the block
+func (s *TeamBlockService) notifyAssigned(block *models.TeamBlock, reservation *models.Reservation) {
+	title := "Desk assigned"
+	message := fmt.Sprintf("A desk was booked for you on %s for the %s team day.",
+		reservation.StartTime.In(block.Location()).Format("Monday, January 2"), block.Department)
+	data := map[string]interface{}{
+		"team_block_id":  block.ID,
+		"reservation_id": reservation.ID,
+		"space_id":       reservation.SpaceID,
+	}
+	if _, err := s.notificationService.Notify(reservation.UserID, models.NotificationTypeTeamDeskAssigned, title, message, data); err != nil {
+		s.logger.Warn("Failed to notify team desk assignment", "reservationID", reservation.ID, "error", err)
+	}
+}
+
+// canLeadTeam checks if the user leads the department: admins, and managers of the department
+func canLeadTeam(user *models.User, department string) bool {
+	return user.IsAdmin() || (user.IsManager() && strings.EqualFold(user.Department, department))
+}
+
+// blockDeskIDs checks the requested desks belong to the neighborhood, every desk of it when
+// none are requested
+func blockDeskIDs(requested []uuid.UUID, desks map[uuid.UUID]*models.Space) ([]string, error) {
+	if len(requested) == 0 {
+		for id := range desks {
+			requested = append(requested, id)
+		}
+		sort.Slice(requested, func(i, j int) bool {
+			return desks[requested[i]].Name < desks[requested[j]].Name
+		})
+	}
+	if len(requested) == 0 {
+		return nil, errors.New("neighborhood has no desks")
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var ids []string
+	for _, id := range requested {
+		if desks[id] == nil {
+			return nil, fmt.Errorf("desk %s is not in the neighborhood", id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id.String())
+		}
+	}
+	return ids, nil
+}
+
+// blockWeekdays removes duplicate weekdays and sorts them
+func blockWeekdays(weekdays []int) []int64 {
+	seen := make(map[int]bool)
+	var days []int64
+	for _, day := range weekdays {
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, int64(day))
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+	return days
+}
+
+// toTeamBlockResponse converts a team block to its response
+func toTeamBlockResponse(block *models.TeamBlock) *dto.TeamBlockResponse {
+	return &dto.TeamBlockResponse{
+		ID:             block.ID,
+		Department:     block.Department,
+		NeighborhoodID: block.NeighborhoodID,
+		DeskIDs:        block.DeskIDs,
+		Weekdays:       block.Weekdays,
+		StartDate:      block.StartDate.Format("2006-01-02"),
+		EndDate:        block.EndDate.Format("2006-01-02"),
+		DayStart:       block.DayStart,
+		DayEnd:         block.DayEnd,
+		Timezone:       block.Timezone,
+		CreatedByID:    block.CreatedByID,
+		CancelledAt:    block.CancelledAt,
+	}
+}
@@ -0,0 +1,127 @@
+// internal/services/user_preference_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// UserPreferenceService manages the booking defaults of users
+type UserPreferenceService struct {
+	preferenceRepo interfaces.UserPreferenceRepositoryInterface
+	userRepo       interfaces.UserRepositoryInterface
+	spaceRepo      interfaces.SpaceRepositoryInterface
+}
+
+// NewUserPreferenceService creates a new user preference service
+func NewUserPreferenceService(
+	preferenceRepo interfaces.UserPreferenceRepositoryInterface,
+	userRepo interfaces.UserRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+) *UserPreferenceService {
+	return &UserPreferenceService{
+		preferenceRepo: preferenceRepo,
+		userRepo:       userRepo,
+		spaceRepo:      spaceRepo,
+	}
+}
+
+// GetPreferences returns the user's booking defaults, empty if none were saved
+func (s *UserPreferenceService) GetPreferences(userID uuid.UUID) (*dto.UserPreferencesResponse, error) {
+	preference, err := s.preferenceRepo.GetByUser(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &dto.UserPreferencesResponse{DefaultParticipantIDs: []uuid.UUID{}}, nil
+		}
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	return toUserPreferencesResponse(preference), nil
+}
+
+// UpdatePreferences replaces the user's booking defaults
+func (s *UserPreferenceService) UpdatePreferences(userID uuid.UUID, req *dto.UpdateUserPreferencesRequest) (*dto.UserPreferencesResponse, error) {
+	if req.PreferredFloor != nil && req.PreferredBuilding == "" {
+		return nil, errors.New("preferred floor requires a preferred building")
+	}
+	if req.PreferredBuilding != "" {
+		buildings, err := s.spaceRepo.GetDistinctBuildings()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get buildings: %w", err)
+		}
+		if !containsString(buildings, req.PreferredBuilding) {
+			return nil, fmt.Errorf("unknown building %q", req.PreferredBuilding)
+		}
+	}
+
+	// The user always attends, so only colleagues are stored
+	participantIDs := pq.StringArray{}
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range req.DefaultParticipantIDs {
+		if id == userID || seen[id] {
+			continue
+		}
+		if _, err := s.userRepo.GetByID(id); err != nil {
+			return nil, fmt.Errorf("participant %s not found", id)
+		}
+		seen[id] = true
+		participantIDs = append(participantIDs, id.String())
+	}
+
+	existing, err := s.preferenceRepo.GetByUser(userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	if existing == nil {
+		preference, err := s.preferenceRepo.Create(&models.UserPreference{
+			UserID:                 userID,
+			PreferredBuilding:      req.PreferredBuilding,
+			PreferredFloor:         req.PreferredFloor,
+			DefaultDurationMinutes: req.DefaultDurationMinutes,
+			DefaultParticipantIDs:  participantIDs,
+			DefaultPrivate:         req.DefaultPrivate,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to save preferences: %w", err)
+		}
+		return toUserPreferencesResponse(preference), nil
+	}
+
+	if err := s.preferenceRepo.Update(existing.ID, map[string]interface{}{
+		"preferred_building":       req.PreferredBuilding,
+		"preferred_floor":          req.PreferredFloor,
+		"default_duration_minutes": req.DefaultDurationMinutes,
+		"default_participant_ids":  participantIDs,
+		"default_private":          req.DefaultPrivate,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save preferences: %w", err)
+	}
+
+	return s.GetPreferences(userID)
+}
+
+// toUserPreferencesResponse converts stored preferences to the API shape
+func toUserPreferencesResponse(preference *models.UserPreference) *dto.UserPreferencesResponse {
+	response := &dto.UserPreferencesResponse{
+		PreferredBuilding:      preference.PreferredBuilding,
+		PreferredFloor:         preference.PreferredFloor,
+		DefaultDurationMinutes: preference.DefaultDurationMinutes,
+		DefaultParticipantIDs:  make([]uuid.UUID, 0, len(preference.DefaultParticipantIDs)),
+		DefaultPrivate:         preference.DefaultPrivate,
+		UpdatedAt:              &preference.UpdatedAt,
+	}
+	for _, id := range preference.DefaultParticipantIDs {
+		if parsed, err := uuid.Parse(id); err == nil {
+			response.DefaultParticipantIDs = append(response.DefaultParticipantIDs, parsed)
+		}
+	}
+	return response
+}
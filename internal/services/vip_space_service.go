@@ -0,0 +1,128 @@
+// internal/services/vip_space_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// VIPSpaceService manages guaranteed-availability blocks on VIP spaces
+type VIPSpaceService struct {
+	vipBlockRepo interfaces.VIPBlockRepositoryInterface
+	spaceRepo    interfaces.SpaceRepositoryInterface
+}
+
+// NewVIPSpaceService creates a new VIP space service
+func NewVIPSpaceService(
+	vipBlockRepo interfaces.VIPBlockRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+) *VIPSpaceService {
+	return &VIPSpaceService{
+		vipBlockRepo: vipBlockRepo,
+		spaceRepo:    spaceRepo,
+	}
+}
+
+// ========================================
+// BLOCK MANAGEMENT
+// ========================================
+
+// CreateBlock adds a guaranteed-availability block to a VIP space
+func (s *VIPSpaceService) CreateBlock(spaceID uuid.UUID, req *dto.CreateVIPBlockRequest, userID uuid.UUID) (*models.VIPBlock, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	space, err := s.spaceRepo.GetByID(spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	if !space.IsVIP {
+		return nil, errors.New("space is not marked as VIP")
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	block := &models.VIPBlock{
+		SpaceID:      spaceID,
+		DayOfWeek:    req.DayOfWeek,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		Timezone:     timezone,
+		AllowedRoles: pq.StringArray(req.AllowedRoles),
+		Reason:       req.Reason,
+		IsActive:     true,
+		CreatedByID:  userID,
+	}
+
+	createdBlock, err := s.vipBlockRepo.Create(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create VIP block: %w", err)
+	}
+
+	return createdBlock, nil
+}
+
+// GetSpaceBlocks lists the active blocks of a space
+func (s *VIPSpaceService) GetSpaceBlocks(spaceID uuid.UUID) ([]*models.VIPBlock, error) {
+	if _, err := s.spaceRepo.GetByID(spaceID); err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	blocks, err := s.vipBlockRepo.GetActiveBlocksBySpace(spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VIP blocks: %w", err)
+	}
+
+	return blocks, nil
+}
+
+// DeleteBlock removes a block
+func (s *VIPSpaceService) DeleteBlock(blockID uuid.UUID) error {
+	if _, err := s.vipBlockRepo.GetByID(blockID); err != nil {
+		return fmt.Errorf("failed to get VIP block: %w", err)
+	}
+
+	if err := s.vipBlockRepo.Delete(blockID); err != nil {
+		return fmt.Errorf("failed to delete VIP block: %w", err)
+	}
+
+	return nil
+}
+
+// ========================================
+// VIOLATION REPORTING
+// ========================================
+
+// GetViolationReport lists bookings that overrode VIP blocks in a period
+func (s *VIPSpaceService) GetViolationReport(startDate, endDate time.Time, spaceID *uuid.UUID, page, limit int) (*dto.VIPViolationReport, error) {
+	if !endDate.After(startDate) {
+		return nil, errors.New("end date must be after start date")
+	}
+
+	violations, total, err := s.vipBlockRepo.GetViolations(startDate, endDate, spaceID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VIP violations: %w", err)
+	}
+
+	return &dto.VIPViolationReport{
+		StartDate:       startDate,
+		EndDate:         endDate,
+		TotalViolations: total,
+		Violations:      violations,
+		Page:            page,
+		Limit:           limit,
+	}, nil
+}
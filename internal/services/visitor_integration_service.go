@@ -0,0 +1,336 @@
+// internal/services/visitor_integration_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// Prefix that makes integration keys recognizable in configs and logs
+	integrationKeyPrefix = "ik_"
+	// Minimum interval between last-used updates for a key
+	integrationKeyUsedInterval = time.Minute
+	// How early before their meeting a visitor badge is valid
+	visitorBadgeLeadTime = 30 * time.Minute
+	// How long visitor events are kept for reception systems to catch up
+	visitorEventRetention = 30 * 24 * time.Hour
+)
+
+// VisitorIntegrationService serves reception and visitor management systems: the visitors
+// expected per building and day, their check-in at reception, badge-printing payloads and a
+// feed of visitor changes. Systems call it with integration keys limited by scopes and buildings.
+type VisitorIntegrationService struct {
+	keyRepo         interfaces.IntegrationKeyRepositoryInterface
+	eventRepo       interfaces.VisitorEventRepositoryInterface
+	guestRepo       interfaces.ReservationGuestRepositoryInterface
+	reservationRepo interfaces.ReservationRepositoryInterface
+	spaceRepo       interfaces.SpaceRepositoryInterface
+	guestService    *ReservationGuestService
+	logger          *slog.Logger
+}
+
+// NewVisitorIntegrationService creates a new visitor integration service
+func NewVisitorIntegrationService(
+	keyRepo interfaces.IntegrationKeyRepositoryInterface,
+	eventRepo interfaces.VisitorEventRepositoryInterface,
+	guestRepo interfaces.ReservationGuestRepositoryInterface,
+	reservationRepo interfaces.ReservationRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	guestService *ReservationGuestService,
+	logger *slog.Logger,
+) *VisitorIntegrationService {
+	return &VisitorIntegrationService{
+		keyRepo:         keyRepo,
+		eventRepo:       eventRepo,
+		guestRepo:       guestRepo,
+		reservationRepo: reservationRepo,
+		spaceRepo:       spaceRepo,
+		guestService:    guestService,
+		logger:          logger,
+	}
+}
+
+// ========================================
+// KEY MANAGEMENT
+// ========================================
+
+// CreateKey creates an integration key with the given scopes and returns it; only its hash is stored
+func (s *VisitorIntegrationService) CreateKey(req *dto.CreateIntegrationKeyRequest, userID uuid.UUID) (*dto.IntegrationKeyCreatedResponse, error) {
+	if len(req.Buildings) > 0 {
+		buildings, err := s.spaceRepo.GetDistinctBuildings()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get buildings: %w", err)
+		}
+		for _, building := range req.Buildings {
+			if !containsString(buildings, building) {
+				return nil, fmt.Errorf("unknown building: %s", building)
+			}
+		}
+	}
+
+	rawKey, err := generateIntegrationKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.keyRepo.Create(&models.IntegrationKey{
+		Name:        req.Name,
+		Scopes:      pq.StringArray(req.Scopes),
+		Buildings:   pq.StringArray(req.Buildings),
+		KeyHash:     hashDisplayKey(rawKey),
+		KeyPrefix:   rawKey[:len(integrationKeyPrefix)+8],
+		CreatedByID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create integration key: %w", err)
+	}
+
+	return &dto.IntegrationKeyCreatedResponse{
+		IntegrationKeyResponse: toIntegrationKeyResponse(key),
+		Key:                    rawKey,
+	}, nil
+}
+
+// GetKeys lists every integration key, revoked ones included
+func (s *VisitorIntegrationService) GetKeys() ([]dto.IntegrationKeyResponse, error) {
+	keys, err := s.keyRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get integration keys: %w", err)
+	}
+
+	responses := make([]dto.IntegrationKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = toIntegrationKeyResponse(key)
+	}
+	return responses, nil
+}
+
+// RevokeKey disables an integration key
+func (s *VisitorIntegrationService) RevokeKey(keyID uuid.UUID) error {
+	key, err := s.keyRepo.GetByID(keyID)
+	if err != nil {
+		return fmt.Errorf("failed to get integration key: %w", err)
+	}
+
+	if key.IsRevoked() {
+		return errors.New("integration key is already revoked")
+	}
+
+	if err := s.keyRepo.Update(key.ID, map[string]interface{}{"revoked_at": time.Now()}); err != nil {
+		return fmt.Errorf("failed to revoke integration key: %w", err)
+	}
+	return nil
+}
+
+// Authenticate resolves a raw key to an active integration key
+func (s *VisitorIntegrationService) Authenticate(rawKey string) (*models.IntegrationKey, error) {
+	if rawKey == "" {
+		return nil, errors.New("invalid integration key")
+	}
+
+	key, err := s.keyRepo.GetByKeyHash(hashDisplayKey(rawKey))
+	if err != nil || key.IsRevoked() {
+		return nil, errors.New("invalid integration key")
+	}
+
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > integrationKeyUsedInterval {
+		if err := s.keyRepo.Update(key.ID, map[string]interface{}{"last_used_at": now}); err != nil {
+			s.logger.Warn("Failed to record integration key use", "keyID", key.ID, "error", err)
+		}
+		key.LastUsedAt = &now
+	}
+
+	return key, nil
+}
+
+// ========================================
+// RECEPTION
+// ========================================
+
+// GetExpectedVisitors lists the visitors expected in a building the key covers on a given day
+func (s *VisitorIntegrationService) GetExpectedVisitors(key *models.IntegrationKey, building string, date time.Time) (*dto.ExpectedVisitorsResponse, error) {
+	if !key.CoversBuilding(building) {
+		return nil, errors.New("building is not covered by this integration key")
+	}
+	return s.guestService.GetExpectedVisitors(building, date)
+}
+
+// CheckIn checks a visitor in at reception and returns the badge to print. The visitor must be
+// expected today in a building the key covers.
+func (s *VisitorIntegrationService) CheckIn(key *models.IntegrationKey, req *dto.VisitorCheckInRequest) (*dto.VisitorBadgeResponse, error) {
+	guest, err := s.getCoveredGuest(key, req.GuestID, req.PassToken)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation := guest.Reservation
+	if guest.HasDeclined() {
+		return nil, errors.New("visitor declined the invitation")
+	}
+	if reservation.Status != models.StatusConfirmed && reservation.Status != models.StatusPending {
+		return nil, errors.New("visitor's meeting is no longer taking place")
+	}
+	if today := startOfDayUTC(time.Now()); !startOfDayUTC(reservation.StartTime).Equal(today) {
+		return nil, errors.New("visitor is not expected today")
+	}
+
+	arrived, err := s.guestService.MarkGuestArrived(guest.ID)
+	if err != nil {
+		return nil, err
+	}
+	guest.ArrivedAt = arrived.ArrivedAt
+
+	s.logger.Info("Visitor checked in by integration", "keyID", key.ID, "guestID", guest.ID, "reservationID", reservation.ID)
+	return s.toBadge(guest), nil
+}
+
+// GetBadge returns the badge-printing payload of a visitor in a building the key covers
+func (s *VisitorIntegrationService) GetBadge(key *models.IntegrationKey, guestID uuid.UUID) (*dto.VisitorBadgeResponse, error) {
+	guest, err := s.getCoveredGuest(key, &guestID, "")
+	if err != nil {
+		return nil, err
+	}
+	return s.toBadge(guest), nil
+}
+
+// ========================================
+// EVENTS
+// ========================================
+
+// GetEvents returns the visitor changes in the buildings the key covers after the cursor, oldest
+// first, with the cursor of the next page if more follow
+func (s *VisitorIntegrationService) GetEvents(key *models.IntegrationKey, after *dto.Cursor, limit int) ([]*models.VisitorEvent, *dto.Cursor, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	events, err := s.eventRepo.GetAfter(key.Buildings, after, limit+1)
+	if err != nil {
+		if errors.Is(err, dto.ErrInvalidCursor) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("failed to get visitor events: %w", err)
+	}
+
+	if len(events) <= limit {
+		return events, nil, nil
+	}
+	events = events[:limit]
+	last := events[limit-1]
+	next := dto.NewTimeCursor(last.OccurredAt, last.ID)
+	return events, &next, nil
+}
+
+// RecordReservationChange tells reception systems that the meeting of the reservation's guests
+// changed or was cancelled. It handles reservation_updated and reservation_cancelled events of the outbox.
+func (s *VisitorIntegrationService) RecordReservationChange(ctx context.Context, event *models.OutboxEvent) error {
+	eventType := models.VisitorEventUpdated
+	if event.EventType == models.OutboxReservationCancelled {
+		eventType = models.VisitorEventCancelled
+	}
+
+	if err := s.eventRepo.AppendForReservation(event.AggregateID, eventType, event.ID); err != nil {
+		return fmt.Errorf("failed to record visitor events: %w", err)
+	}
+	return nil
+}
+
+// PruneEvents deletes visitor events past their retention. It runs as a scheduled job.
+func (s *VisitorIntegrationService) PruneEvents(ctx context.Context) error {
+	deleted, err := s.eventRepo.DeleteBefore(time.Now().Add(-visitorEventRetention))
+	if err != nil {
+		return fmt.Errorf("failed to delete old visitor events: %w", err)
+	}
+	if deleted > 0 {
+		s.logger.Info("Old visitor events deleted", "count", deleted)
+	}
+	return nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// getCoveredGuest loads a guest by ID or pass token, with the reservation, its space and host,
+// and checks the key covers the building
+func (s *VisitorIntegrationService) getCoveredGuest(key *models.IntegrationKey, guestID *uuid.UUID, passToken string) (*models.ReservationGuest, error) {
+	var guest *models.ReservationGuest
+	var err error
+	if guestID != nil {
+		if guest, err = s.guestRepo.GetByID(*guestID); err != nil {
+			return nil, errors.New("visitor not found")
+		}
+		reservation, err := s.reservationRepo.GetByID(guest.ReservationID)
+		if err != nil {
+			return nil, errors.New("visitor not found")
+		}
+		guest.Reservation = reservation
+	} else if guest, err = s.guestService.getGuestByToken(passToken); err != nil {
+		return nil, errors.New("visitor not found")
+	}
+
+	// Visitors of other buildings are not revealed
+	if !key.CoversBuilding(guest.Reservation.Space.Building) {
+		return nil, errors.New("visitor not found")
+	}
+	return guest, nil
+}
+
+// toBadge builds the badge-printing payload of a guest with its reservation loaded
+func (s *VisitorIntegrationService) toBadge(guest *models.ReservationGuest) *dto.VisitorBadgeResponse {
+	reservation := guest.Reservation
+	return &dto.VisitorBadgeResponse{
+		GuestID:       guest.ID,
+		ReservationID: reservation.ID,
+		Name:          guest.Name,
+		Company:       guest.Company,
+		HostName:      reservation.User.GetFullName(),
+		Building:      reservation.Space.Building,
+		Floor:         reservation.Space.Floor,
+		SpaceName:     reservation.Space.Name,
+		RoomNumber:    reservation.Space.RoomNumber,
+		ValidFrom:     reservation.StartTime.Add(-visitorBadgeLeadTime),
+		ValidUntil:    reservation.EndTime,
+		QRCodeData:    s.guestService.passURL(guest.PassToken),
+		ArrivedAt:     guest.ArrivedAt,
+	}
+}
+
+// toIntegrationKeyResponse converts an integration key for admin listings
+func toIntegrationKeyResponse(key *models.IntegrationKey) dto.IntegrationKeyResponse {
+	response := dto.IntegrationKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Scopes:     []string(key.Scopes),
+		Buildings:  []string(key.Buildings),
+		KeyPrefix:  key.KeyPrefix,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+	if response.Buildings == nil {
+		response.Buildings = []string{}
+	}
+	return response
+}
+
+// generateIntegrationKey creates a random key for a third-party system
+func generateIntegrationKey() (string, error) {
+	key, err := generateDisplayKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate integration key: %w", err)
+	}
+	return integrationKeyPrefix + key[len(displayKeyPrefix):], nil
+}
@@ -0,0 +1,187 @@
+// internal/services/webhook_schema.go
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/websocket"
+)
+
+// CurrentWebhookSchemaVersion is the payload version built for new events. Older
+// subscriptions get the payload converted down to the version they selected.
+const CurrentWebhookSchemaVersion = 2
+
+// webhookSchemaChangelog documents every payload version, oldest first. Adding a
+// version means adding an entry here and a downgrade converter to the previous one.
+var webhookSchemaChangelog = []dto.WebhookSchemaVersion{
+	{
+		Version: 1,
+		Summary: "Initial payload format",
+		Changes: []string{
+			"Envelope with event, timestamp and data",
+			"data holds the reservation fields at the top level, identified by reservation_id",
+		},
+	},
+	{
+		Version: 2,
+		Summary: "Event envelope with IDs and nested resources",
+		Changes: []string{
+			"Added id, a unique event ID to deduplicate retried deliveries",
+			"Added schema_version",
+			"Renamed event to type and timestamp to occurred_at",
+			"Moved the reservation fields under data.reservation and renamed reservation_id to id",
+			"Added data.reservation.check_in_time",
+		},
+	},
+}
+
+// webhookDowngrades converts a payload of the key version to the version before it
+var webhookDowngrades = map[int]func(payload map[string]interface{}) (map[string]interface{}, error){
+	2: downgradeWebhookPayloadV2,
+}
+
+// webhookPayload is the current (version 2) event envelope
+type webhookPayload struct {
+	ID            string      `json:"id"`
+	Type          string      `json:"type"`
+	SchemaVersion int         `json:"schema_version"`
+	OccurredAt    time.Time   `json:"occurred_at"`
+	Data          interface{} `json:"data"`
+}
+
+// webhookReservation is the reservation resource of the current payload version
+type webhookReservation struct {
+	ID          uuid.UUID  `json:"id"`
+	SpaceID     uuid.UUID  `json:"space_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Title       string     `json:"title"`
+	Status      string     `json:"status"`
+	StartTime   time.Time  `json:"start_time"`
+	EndTime     time.Time  `json:"end_time"`
+	CheckInTime *time.Time `json:"check_in_time"`
+}
+
+// buildWebhookPayload renders a bus event in the current schema version.
+// ok is false for events that are not published to webhooks.
+func buildWebhookPayload(eventID string, event websocket.BusEvent) (payload map[string]interface{}, ok bool, err error) {
+	data, isReservation := event.Data.(websocket.ReservationEventData)
+	if !isReservation {
+		return nil, false, nil
+	}
+
+	envelope := webhookPayload{
+		ID:            eventID,
+		Type:          event.Event,
+		SchemaVersion: CurrentWebhookSchemaVersion,
+		OccurredAt:    event.Timestamp,
+		Data: map[string]interface{}{
+			"reservation": webhookReservation{
+				ID:          data.ReservationID,
+				SpaceID:     data.SpaceID,
+				UserID:      data.UserID,
+				Title:       data.Title,
+				Status:      data.Status,
+				StartTime:   data.StartTime,
+				EndTime:     data.EndTime,
+				CheckInTime: data.CheckInTime,
+			},
+		},
+	}
+
+	// Converters work on the generic JSON form
+	raw, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, false, err
+	}
+	return payload, true, nil
+}
+
+// convertWebhookPayload downgrades a current payload step by step to the requested version
+func convertWebhookPayload(payload map[string]interface{}, version int) (map[string]interface{}, error) {
+	for v := CurrentWebhookSchemaVersion; v > version; v-- {
+		downgrade, ok := webhookDowngrades[v]
+		if !ok {
+			return nil, fmt.Errorf("no converter from schema version %d to %d", v, v-1)
+		}
+
+		var err error
+		if payload, err = downgrade(payload); err != nil {
+			return nil, fmt.Errorf("failed to convert payload to schema version %d: %w", v-1, err)
+		}
+	}
+	return payload, nil
+}
+
+// isSupportedWebhookSchemaVersion checks a subscription can select the version
+func isSupportedWebhookSchemaVersion(version int) bool {
+	return version >= 1 && version <= CurrentWebhookSchemaVersion
+}
+
+// webhookSchemaExamples renders a sample reservation_created payload in every version
+func webhookSchemaExamples() (map[int]map[string]interface{}, error) {
+	start := time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)
+	sample := websocket.BusEvent{
+		Event:     websocket.WSEventReservationCreated,
+		Timestamp: start.Add(-24 * time.Hour),
+		Data: websocket.ReservationEventData{
+			ReservationID: uuid.MustParse("6f1c1f0e-8a4b-4a57-9a8e-2d3c4b5a6e7f"),
+			SpaceID:       uuid.MustParse("0b8e4c2a-1d3f-4e5a-8b7c-9d0e1f2a3b4c"),
+			UserID:        uuid.MustParse("3a2b1c0d-9e8f-4a7b-8c6d-5e4f3a2b1c0d"),
+			Title:         "Sprint planning",
+			Status:        "confirmed",
+			StartTime:     start,
+			EndTime:       start.Add(time.Hour),
+		},
+	}
+
+	current, _, err := buildWebhookPayload("0d6f5e4c-3b2a-4190-8e7d-6c5b4a392817", sample)
+	if err != nil {
+		return nil, err
+	}
+
+	examples := make(map[int]map[string]interface{}, CurrentWebhookSchemaVersion)
+	for version := 1; version <= CurrentWebhookSchemaVersion; version++ {
+		if examples[version], err = convertWebhookPayload(current, version); err != nil {
+			return nil, err
+		}
+	}
+	return examples, nil
+}
+
+// ========================================
+// CONVERTERS
+// ========================================
+
+// downgradeWebhookPayloadV2 converts a version 2 payload to version 1
+func downgradeWebhookPayloadV2(payload map[string]interface{}) (map[string]interface{}, error) {
+	data, ok := payload["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("missing data")
+	}
+	reservation, ok := data["reservation"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("missing data.reservation")
+	}
+
+	return map[string]interface{}{
+		"event":     payload["type"],
+		"timestamp": payload["occurred_at"],
+		"data": map[string]interface{}{
+			"reservation_id": reservation["id"],
+			"space_id":       reservation["space_id"],
+			"user_id":        reservation["user_id"],
+			"title":          reservation["title"],
+			"status":         reservation["status"],
+			"start_time":     reservation["start_time"],
+			"end_time":       reservation["end_time"],
+		},
+	}, nil
+}
@@ -0,0 +1,400 @@
+// internal/services/webhook_service.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+
+	"room-reservation-api/internal/breaker"
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+	"room-reservation-api/internal/websocket"
+)
+
+const (
+	// Delivery attempts before a delivery is marked failed
+	webhookMaxAttempts = 6
+	// Deliveries sent per run of the delivery job
+	webhookBatchSize = 100
+	// Timeout for a consumer endpoint to answer
+	webhookTimeout = 10 * time.Second
+	// Deliveries listed per subscription
+	webhookDeliveryHistory = 50
+	// Delay of deliveries held back while their endpoint's breaker is open
+	webhookBreakerDelay = time.Minute
+)
+
+// WebhookService manages webhook subscriptions and pushes reservation events to them
+type WebhookService struct {
+	webhookRepo    interfaces.WebhookRepositoryInterface
+	deadLetterRepo interfaces.DeadLetterRepositoryInterface
+	httpClient     *http.Client
+	breakers       *breaker.Registry // One breaker per subscription so a failing endpoint does not slow the others
+	logger         *slog.Logger
+}
+
+// NewWebhookService creates a new webhook service. Events reach it from the outbox dispatcher,
+// through QueueOutboxEvent.
+func NewWebhookService(webhookRepo interfaces.WebhookRepositoryInterface, deadLetterRepo interfaces.DeadLetterRepositoryInterface, breakers *breaker.Registry, logger *slog.Logger) *WebhookService {
+	return &WebhookService{
+		webhookRepo:    webhookRepo,
+		deadLetterRepo: deadLetterRepo,
+		httpClient:     &http.Client{Timeout: webhookTimeout},
+		breakers:       breakers,
+		logger:         logger,
+	}
+}
+
+// ========================================
+// SUBSCRIPTIONS
+// ========================================
+
+// CreateSubscription subscribes an endpoint and returns its signing secret, which is only shown once
+func (s *WebhookService) CreateSubscription(req *dto.CreateWebhookRequest, userID uuid.UUID) (*dto.WebhookSecretResponse, error) {
+	version := req.SchemaVersion
+	if version == 0 {
+		version = CurrentWebhookSchemaVersion
+	}
+	if !isSupportedWebhookSchemaVersion(version) {
+		return nil, fmt.Errorf("unsupported schema version %d", version)
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.webhookRepo.CreateSubscription(&models.WebhookSubscription{
+		Name:          req.Name,
+		URL:           req.URL,
+		Secret:        secret,
+		Events:        pq.StringArray(req.Events),
+		SchemaVersion: version,
+		IsActive:      true,
+		CreatedByID:   userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return &dto.WebhookSecretResponse{
+		WebhookSubscription: subscription,
+		Secret:              secret,
+	}, nil
+}
+
+// GetSubscriptions lists all webhook subscriptions
+func (s *WebhookService) GetSubscriptions() ([]*models.WebhookSubscription, error) {
+	subscriptions, err := s.webhookRepo.GetSubscriptions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// UpdateSubscription changes a subscription. A new schema version applies to events queued afterwards.
+func (s *WebhookService) UpdateSubscription(id uuid.UUID, req *dto.UpdateWebhookRequest) (*models.WebhookSubscription, error) {
+	if _, err := s.webhookRepo.GetSubscriptionByID(id); err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.URL != nil {
+		updates["url"] = *req.URL
+	}
+	if len(req.Events) > 0 {
+		updates["events"] = pq.StringArray(req.Events)
+	}
+	if req.SchemaVersion != nil {
+		if !isSupportedWebhookSchemaVersion(*req.SchemaVersion) {
+			return nil, fmt.Errorf("unsupported schema version %d", *req.SchemaVersion)
+		}
+		updates["schema_version"] = *req.SchemaVersion
+	}
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+	}
+
+	if len(updates) > 0 {
+		if err := s.webhookRepo.UpdateSubscription(id, updates); err != nil {
+			return nil, fmt.Errorf("failed to update webhook: %w", err)
+		}
+	}
+
+	return s.webhookRepo.GetSubscriptionByID(id)
+}
+
+// DeleteSubscription removes a subscription and its undelivered events
+func (s *WebhookService) DeleteSubscription(id uuid.UUID) error {
+	if _, err := s.webhookRepo.GetSubscriptionByID(id); err != nil {
+		return fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	if err := s.webhookRepo.DeleteSubscription(id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// GetDeliveries lists the recent deliveries of a subscription
+func (s *WebhookService) GetDeliveries(id uuid.UUID) ([]*models.WebhookDelivery, error) {
+	if _, err := s.webhookRepo.GetSubscriptionByID(id); err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	deliveries, err := s.webhookRepo.GetDeliveriesBySubscription(id, webhookDeliveryHistory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// GetSchemaChangelog lists the payload schema versions with an example of each
+func (s *WebhookService) GetSchemaChangelog() (*dto.WebhookSchemaChangelogResponse, error) {
+	examples, err := webhookSchemaExamples()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render examples: %w", err)
+	}
+
+	versions := make([]dto.WebhookSchemaVersion, len(webhookSchemaChangelog))
+	for i, version := range webhookSchemaChangelog {
+		versions[i] = version
+		versions[i].Example = examples[version.Version]
+	}
+
+	return &dto.WebhookSchemaChangelogResponse{
+		CurrentVersion: CurrentWebhookSchemaVersion,
+		Versions:       versions,
+	}, nil
+}
+
+// ========================================
+// DELIVERY
+// ========================================
+
+// ProcessEvents sends due deliveries. It runs as a scheduled job.
+func (s *WebhookService) ProcessEvents(ctx context.Context) error {
+	deliveries, err := s.webhookRepo.GetDueDeliveries(time.Now(), webhookBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get due deliveries: %w", err)
+	}
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s.deliver(ctx, delivery)
+	}
+	return nil
+}
+
+// QueueOutboxEvent renders a reservation event from the outbox for each matching subscription.
+// The event ID of the deliveries is the outbox event's, so a redispatched event carries the same
+// ID and consumers can drop the duplicate.
+func (s *WebhookService) QueueOutboxEvent(ctx context.Context, outboxEvent *models.OutboxEvent) error {
+	change, err := decodeReservationChange(outboxEvent)
+	if err != nil {
+		return err
+	}
+	event := websocket.BusEvent{
+		Event:     outboxEvent.EventType,
+		Data:      reservationEventData(change),
+		Timestamp: outboxEvent.CreatedAt,
+	}
+
+	subscriptions, err := s.webhookRepo.GetActiveSubscriptions()
+	if err != nil {
+		return fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	eventID := outboxEvent.ID.String()
+	current, ok, err := buildWebhookPayload(eventID, event)
+	if err != nil {
+		return fmt.Errorf("failed to build webhook payload: %w", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	var deliveries []*models.WebhookDelivery
+	for _, subscription := range subscriptions {
+		if !subscription.Wants(event.Event) {
+			continue
+		}
+
+		payload, err := convertWebhookPayload(current, subscription.SchemaVersion)
+		if err != nil {
+			s.logger.Error("Failed to convert webhook payload", "subscriptionID", subscription.ID, "version", subscription.SchemaVersion, "error", err)
+			continue
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			s.logger.Error("Failed to serialize webhook payload", "subscriptionID", subscription.ID, "error", err)
+			continue
+		}
+
+		deliveries = append(deliveries, &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      event.Event,
+			SchemaVersion:  subscription.SchemaVersion,
+			Payload:        datatypes.JSON(body),
+			Status:         models.WebhookDeliveryPending,
+		})
+	}
+
+	if err := s.webhookRepo.CreateDeliveries(deliveries); err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// Backlog reports the deliveries not yet sent
+func (s *WebhookService) Backlog() (*dto.WebhookBacklog, error) {
+	pending, oldest, err := s.webhookRepo.GetPendingStats()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending deliveries: %w", err)
+	}
+
+	backlog := &dto.WebhookBacklog{
+		PendingDeliveries: pending,
+		OldestPendingAt:   oldest,
+	}
+	if oldest != nil {
+		backlog.OldestPendingAge = time.Since(*oldest).Round(time.Second).String()
+	}
+	return backlog, nil
+}
+
+// deliver posts a delivery to its endpoint and records the outcome
+func (s *WebhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	subscription := delivery.Subscription
+	if subscription == nil {
+		return
+	}
+
+	endpointBreaker := s.breakers.Get("webhook:"+subscription.ID.String(), webhookTimeout)
+	statusCode, err := breaker.Do(ctx, endpointBreaker, func(ctx context.Context) (int, error) {
+		return s.post(ctx, subscription, delivery)
+	})
+	now := time.Now()
+
+	// Hold the delivery back without using up an attempt until the endpoint is tried again
+	if errors.Is(err, breaker.ErrOpen) {
+		s.webhookRepo.UpdateDelivery(delivery.ID, map[string]interface{}{
+			"next_attempt_at": now.Add(webhookBreakerDelay),
+		})
+		return
+	}
+
+	attempts := delivery.Attempts + 1
+
+	if err == nil {
+		s.webhookRepo.UpdateDelivery(delivery.ID, map[string]interface{}{
+			"status":          models.WebhookDeliveryDelivered,
+			"attempts":        attempts,
+			"response_status": statusCode,
+			"last_error":      "",
+			"delivered_at":    now,
+		})
+		return
+	}
+
+	status := models.WebhookDeliveryPending
+	if attempts >= webhookMaxAttempts {
+		status = models.WebhookDeliveryFailed
+	}
+
+	s.logger.Warn("Webhook delivery failed",
+		"deliveryID", delivery.ID,
+		"subscriptionID", subscription.ID,
+		"attempt", attempts,
+		"error", err)
+
+	// Back off exponentially: 1, 2, 4, 8... minutes
+	s.webhookRepo.UpdateDelivery(delivery.ID, map[string]interface{}{
+		"status":          status,
+		"attempts":        attempts,
+		"response_status": statusCode,
+		"last_error":      err.Error(),
+		"next_attempt_at": now.Add(time.Minute << (attempts - 1)),
+	})
+
+	if status == models.WebhookDeliveryFailed {
+		if _, recordErr := s.deadLetterRepo.Record(&models.DeadLetter{
+			Source:      models.DeadLetterSourceWebhook,
+			ReferenceID: &delivery.ID,
+			Name:        delivery.EventType,
+			Payload:     delivery.Payload,
+			LastError:   err.Error(),
+		}); recordErr != nil {
+			s.logger.Error("Failed to record dead letter", "deliveryID", delivery.ID, "error", recordErr)
+		}
+	}
+}
+
+// post sends the signed payload and returns the response status
+func (s *WebhookService) post(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Event-ID", delivery.EventID)
+	req.Header.Set("X-Webhook-Schema-Version", strconv.Itoa(delivery.SchemaVersion))
+	req.Header.Set("X-Webhook-Signature", "sha256="+signWebhookPayload(subscription.Secret, delivery.Payload))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, errors.New("endpoint responded " + resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// signWebhookPayload computes the HMAC-SHA256 consumers use to verify a payload
+func signWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// generateWebhookSecret creates a random signing secret
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
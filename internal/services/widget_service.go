@@ -0,0 +1,232 @@
+// internal/services/widget_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+const (
+	// Prefix that makes widget tokens recognizable in page sources and logs
+	widgetTokenPrefix = "wt_"
+	// Minimum interval between last-used updates for a token
+	widgetUsedInterval = time.Minute
+)
+
+// WidgetService manages the tokens of booking widgets embedded in other sites, such as a
+// building's website, and serves what the widgets show and send
+type WidgetService struct {
+	tokenRepo           interfaces.WidgetTokenRepositoryInterface
+	spaceRepo           interfaces.SpaceRepositoryInterface
+	reservationService  *ReservationService
+	availabilityService *PublicAvailabilityService
+	logger              *slog.Logger
+}
+
+// NewWidgetService creates a new booking widget service
+func NewWidgetService(
+	tokenRepo interfaces.WidgetTokenRepositoryInterface,
+	spaceRepo interfaces.SpaceRepositoryInterface,
+	reservationService *ReservationService,
+	availabilityService *PublicAvailabilityService,
+	logger *slog.Logger,
+) *WidgetService {
+	return &WidgetService{
+		tokenRepo:           tokenRepo,
+		spaceRepo:           spaceRepo,
+		reservationService:  reservationService,
+		availabilityService: availabilityService,
+		logger:              logger,
+	}
+}
+
+// ========================================
+// TOKEN MANAGEMENT
+// ========================================
+
+// CreateToken creates a widget token for a space or a building and returns it; only its hash is stored
+func (s *WidgetService) CreateToken(req *dto.CreateWidgetTokenRequest, userID uuid.UUID) (*dto.WidgetTokenKeyResponse, error) {
+	var space *models.Space
+	if req.SpaceID != nil {
+		var err error
+		if space, err = s.spaceRepo.GetByID(*req.SpaceID); err != nil {
+			return nil, fmt.Errorf("failed to get space: %w", err)
+		}
+	} else {
+		buildings, err := s.spaceRepo.GetDistinctBuildings()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get buildings: %w", err)
+		}
+		if !containsString(buildings, req.Building) {
+			return nil, fmt.Errorf("unknown building: %s", req.Building)
+		}
+	}
+
+	rawToken, err := generateWidgetToken()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.tokenRepo.Create(&models.WidgetToken{
+		Name:           req.Name,
+		SpaceID:        req.SpaceID,
+		Building:       req.Building,
+		AllowedOrigins: req.AllowedOrigins,
+		TokenHash:      hashDisplayKey(rawToken),
+		TokenPrefix:    rawToken[:len(widgetTokenPrefix)+8],
+		CreatedByID:    userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create widget token: %w", err)
+	}
+	token.Space = space
+
+	return &dto.WidgetTokenKeyResponse{
+		WidgetTokenResponse: toWidgetTokenResponse(token),
+		Token:               rawToken,
+	}, nil
+}
+
+// GetTokens lists every widget token, revoked ones included
+func (s *WidgetService) GetTokens() ([]dto.WidgetTokenResponse, error) {
+	tokens, err := s.tokenRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get widget tokens: %w", err)
+	}
+
+	responses := make([]dto.WidgetTokenResponse, len(tokens))
+	for i, token := range tokens {
+		responses[i] = toWidgetTokenResponse(token)
+	}
+	return responses, nil
+}
+
+// RevokeToken disables a widget token
+func (s *WidgetService) RevokeToken(tokenID uuid.UUID) error {
+	token, err := s.tokenRepo.GetByID(tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to get widget token: %w", err)
+	}
+
+	if token.IsRevoked() {
+		return errors.New("widget token is already revoked")
+	}
+
+	if err := s.tokenRepo.Update(token.ID, map[string]interface{}{"revoked_at": time.Now()}); err != nil {
+		return fmt.Errorf("failed to revoke widget token: %w", err)
+	}
+	return nil
+}
+
+// Authenticate resolves a token to an active widget token
+func (s *WidgetService) Authenticate(rawToken string) (*models.WidgetToken, error) {
+	if rawToken == "" {
+		return nil, errors.New("invalid widget token")
+	}
+
+	token, err := s.tokenRepo.GetByTokenHash(hashDisplayKey(rawToken))
+	if err != nil || token.IsRevoked() {
+		return nil, errors.New("invalid widget token")
+	}
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > widgetUsedInterval {
+		if err := s.tokenRepo.Update(token.ID, map[string]interface{}{"last_used_at": now}); err != nil {
+			s.logger.Warn("Failed to record widget token use", "tokenID", token.ID, "error", err)
+		}
+		token.LastUsedAt = &now
+	}
+
+	return token, nil
+}
+
+// ========================================
+// WIDGET CONTENT
+// ========================================
+
+// GetAvailability returns the free/busy view of the spaces the token covers
+func (s *WidgetService) GetAvailability(token *models.WidgetToken, req *dto.PublicAvailabilityRequest) (*dto.PublicAvailabilityResponse, error) {
+	return s.availabilityService.GetWidgetAvailability(token, req)
+}
+
+// SubmitBookingRequest books a space the token covers, pending approval
+func (s *WidgetService) SubmitBookingRequest(token *models.WidgetToken, req *dto.WidgetBookingRequest) (*dto.WidgetBookingResponse, error) {
+	spaceID := req.SpaceID
+	if token.SpaceID != nil {
+		if spaceID != nil && *spaceID != *token.SpaceID {
+			return nil, errors.New("space is not covered by this widget")
+		}
+		spaceID = token.SpaceID
+	}
+	if spaceID == nil {
+		return nil, errors.New("space_id is required for building widgets")
+	}
+
+	space, err := s.spaceRepo.GetByID(*spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+	if !token.Covers(space) {
+		return nil, errors.New("space is not covered by this widget")
+	}
+
+	reservation, err := s.reservationService.CreateWidgetRequest(space, req, token)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Widget booking request received",
+		"tokenID", token.ID, "reservationID", reservation.ID, "spaceID", space.ID)
+
+	return &dto.WidgetBookingResponse{
+		ID:        reservation.ID,
+		SpaceID:   space.ID,
+		SpaceName: space.Name,
+		StartTime: reservation.StartTime,
+		EndTime:   reservation.EndTime,
+		Status:    string(reservation.Status),
+	}, nil
+}
+
+// ========================================
+// HELPER METHODS
+// ========================================
+
+// toWidgetTokenResponse converts a widget token for admin listings
+func toWidgetTokenResponse(token *models.WidgetToken) dto.WidgetTokenResponse {
+	response := dto.WidgetTokenResponse{
+		ID:             token.ID,
+		Name:           token.Name,
+		SpaceID:        token.SpaceID,
+		Building:       token.Building,
+		AllowedOrigins: []string(token.AllowedOrigins),
+		TokenPrefix:    token.TokenPrefix,
+		LastUsedAt:     token.LastUsedAt,
+		RevokedAt:      token.RevokedAt,
+		CreatedAt:      token.CreatedAt,
+	}
+	if response.AllowedOrigins == nil {
+		response.AllowedOrigins = []string{}
+	}
+	if token.Space != nil {
+		response.SpaceName = token.Space.Name
+	}
+	return response
+}
+
+// generateWidgetToken creates a random token for a booking widget
+func generateWidgetToken() (string, error) {
+	token, err := generateDisplayKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate widget token: %w", err)
+	}
+	return widgetTokenPrefix + token[len(displayKeyPrefix):], nil
+}
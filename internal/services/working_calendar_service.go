@@ -0,0 +1,243 @@
+// internal/services/working_calendar_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"room-reservation-api/internal/dto"
+	"room-reservation-api/internal/models"
+	"room-reservation-api/internal/repositories/interfaces"
+)
+
+// WorkingCalendarService manages the public holidays and company closure days of each region,
+// on top of the country's holiday calendar. Buildings are grouped into regions; days without a
+// region apply to every building.
+type WorkingCalendarService struct {
+	calendarRepo interfaces.WorkingCalendarRepositoryInterface
+}
+
+// NewWorkingCalendarService creates a new working calendar service
+func NewWorkingCalendarService(calendarRepo interfaces.WorkingCalendarRepositoryInterface) *WorkingCalendarService {
+	return &WorkingCalendarService{
+		calendarRepo: calendarRepo,
+	}
+}
+
+// ========================================
+// REGIONS
+// ========================================
+
+// GetRegions lists the calendar regions
+func (s *WorkingCalendarService) GetRegions() ([]*models.CalendarRegion, error) {
+	regions, err := s.calendarRepo.GetRegions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar regions: %w", err)
+	}
+	return regions, nil
+}
+
+// SetRegion creates or replaces a calendar region. A building is in one region at most.
+func (s *WorkingCalendarService) SetRegion(code string, req *dto.SetCalendarRegionRequest, userID uuid.UUID) (*models.CalendarRegion, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" || len(code) > 20 {
+		return nil, errors.New("region code must be 1 to 20 characters")
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, fmt.Errorf("invalid timezone %q", timezone)
+	}
+
+	for _, building := range req.Buildings {
+		other, err := s.calendarRepo.GetRegionByBuilding(building)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to check building region: %w", err)
+		}
+		if other != nil && other.Code != code {
+			return nil, fmt.Errorf("building %s is already in region %s", building, other.Code)
+		}
+	}
+
+	region, err := s.calendarRepo.SaveRegion(&models.CalendarRegion{
+		Code:        code,
+		Name:        req.Name,
+		Buildings:   req.Buildings,
+		Timezone:    timezone,
+		UpdatedByID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save calendar region: %w", err)
+	}
+	return region, nil
+}
+
+// DeleteRegion removes a calendar region and its days
+func (s *WorkingCalendarService) DeleteRegion(code string) error {
+	if err := s.calendarRepo.DeleteRegion(code); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete calendar region: %w", err)
+	}
+	return nil
+}
+
+// ========================================
+// DAYS
+// ========================================
+
+// GetDays lists the holidays and closure days of a year, of one region when region is set
+func (s *WorkingCalendarService) GetDays(region *string, year int) ([]*models.CalendarDay, error) {
+	var regions []string
+	if region != nil {
+		regions = []string{strings.ToUpper(strings.TrimSpace(*region))}
+	}
+
+	days, err := s.calendarRepo.GetDays(regions, yearStart(year), yearStart(year+1).AddDate(0, 0, -1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar days: %w", err)
+	}
+	return days, nil
+}
+
+// CreateDay adds a public holiday or company closure day to a region, or to every region
+func (s *WorkingCalendarService) CreateDay(req *dto.CreateCalendarDayRequest, userID uuid.UUID) (*models.CalendarDay, error) {
+	region := strings.ToUpper(strings.TrimSpace(req.Region))
+	if region != "" {
+		if _, err := s.calendarRepo.GetRegionByCode(region); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("unknown region %q", region)
+			}
+			return nil, fmt.Errorf("failed to get calendar region: %w", err)
+		}
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, errors.New("date must use the YYYY-MM-DD format")
+	}
+
+	existing, err := s.calendarRepo.GetDays([]string{region}, date, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check calendar days: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("%s is already in the calendar as %s", req.Date, existing[0].Name)
+	}
+
+	blocking := true
+	if req.Blocking != nil {
+		blocking = *req.Blocking
+	}
+	day, err := s.calendarRepo.CreateDay(&models.CalendarDay{
+		Region:      region,
+		Date:        date,
+		Name:        req.Name,
+		Kind:        models.CalendarDayKind(req.Kind),
+		Blocking:    blocking,
+		CreatedByID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create calendar day: %w", err)
+	}
+	return day, nil
+}
+
+// DeleteDay removes a holiday or closure day
+func (s *WorkingCalendarService) DeleteDay(id uuid.UUID) error {
+	if err := s.calendarRepo.DeleteDay(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete calendar day: %w", err)
+	}
+	return nil
+}
+
+// Calendar lists the holidays and closure days of a year that apply to a building
+func (s *WorkingCalendarService) Calendar(building string, year int) (*dto.WorkingCalendarResponse, error) {
+	region, err := s.buildingRegion(building)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.WorkingCalendarResponse{
+		Building: building,
+		Timezone: time.UTC.String(),
+		Year:     year,
+		Days:     []models.CalendarDay{},
+	}
+	regions := []string{""}
+	if region != nil {
+		response.Region = region.Code
+		response.Timezone = region.Location().String()
+		regions = append(regions, region.Code)
+	}
+
+	days, err := s.calendarRepo.GetDays(regions, yearStart(year), yearStart(year+1).AddDate(0, 0, -1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar days: %w", err)
+	}
+	for _, day := range days {
+		response.Days = append(response.Days, *day)
+	}
+	return response, nil
+}
+
+// DayOn returns the holiday or closure day of the space's building on the day of t, or nil.
+// A blocking day wins over one that only warns, and a regional day over one of every region.
+func (s *WorkingCalendarService) DayOn(space *models.Space, t time.Time) (*models.CalendarDay, error) {
+	region, err := s.buildingRegion(space.Building)
+	if err != nil {
+		return nil, err
+	}
+
+	location := time.UTC
+	regions := []string{""}
+	if region != nil {
+		location = region.Location()
+		regions = append(regions, region.Code)
+	}
+	local := t.In(location)
+	date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+	days, err := s.calendarRepo.GetDays(regions, date, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar days: %w", err)
+	}
+
+	var found *models.CalendarDay
+	for _, day := range days {
+		if found == nil || (day.Blocking && !found.Blocking) ||
+			(day.Blocking == found.Blocking && day.Region != "" && found.Region == "") {
+			found = day
+		}
+	}
+	return found, nil
+}
+
+// buildingRegion retrieves the region of a building, nil when it is in none
+func (s *WorkingCalendarService) buildingRegion(building string) (*models.CalendarRegion, error) {
+	region, err := s.calendarRepo.GetRegionByBuilding(building)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get calendar region: %w", err)
+	}
+	return region, nil
+}
+
+// yearStart returns January 1st of the year
+func yearStart(year int) time.Time {
+	return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,99 @@
+// internal/utils/ics.go
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Calendar invite methods (RFC 5546)
+const (
+	CalendarMethodRequest = "REQUEST"
+	CalendarMethodCancel  = "CANCEL"
+)
+
+// CalendarInvite describes a single event sent as an iCalendar invitation
+type CalendarInvite struct {
+	UID            string // Stable across updates so clients replace the event
+	Method         string // CalendarMethodRequest or CalendarMethodCancel
+	Summary        string
+	Description    string
+	Location       string
+	Start          time.Time
+	End            time.Time
+	OrganizerName  string
+	OrganizerEmail string
+	AttendeeName   string
+	AttendeeEmail  string
+	Sequence       int // Bump when the event changes
+}
+
+// Bytes renders the invite as an iCalendar file
+func (i *CalendarInvite) Bytes() []byte {
+	status := "CONFIRMED"
+	if i.Method == CalendarMethodCancel {
+		status = "CANCELLED"
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Room Reservation//Guest Invites//EN",
+		"METHOD:" + i.Method,
+		"BEGIN:VEVENT",
+		"UID:" + i.UID,
+		"DTSTAMP:" + formatICSTime(time.Now()),
+		"DTSTART:" + formatICSTime(i.Start),
+		"DTEND:" + formatICSTime(i.End),
+		fmt.Sprintf("SEQUENCE:%d", i.Sequence),
+		"STATUS:" + status,
+		"SUMMARY:" + escapeICSText(i.Summary),
+		"DESCRIPTION:" + escapeICSText(i.Description),
+		"LOCATION:" + escapeICSText(i.Location),
+		fmt.Sprintf("ORGANIZER;CN=%s:mailto:%s", escapeICSParam(i.OrganizerName), i.OrganizerEmail),
+		fmt.Sprintf("ATTENDEE;CN=%s;ROLE=REQ-PARTICIPANT;RSVP=FALSE:mailto:%s", escapeICSParam(i.AttendeeName), i.AttendeeEmail),
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICSLine(line))
+		b.WriteString("\r\n")
+	}
+	return []byte(b.String())
+}
+
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func escapeICSText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+func escapeICSParam(text string) string {
+	return `"` + strings.NewReplacer(`"`, "'", "\n", " ").Replace(text) + `"`
+}
+
+// foldICSLine splits lines longer than 75 octets as required by RFC 5545
+func foldICSLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
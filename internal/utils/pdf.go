@@ -0,0 +1,151 @@
+// internal/utils/pdf.go
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page layout in points, using the built-in Courier font so text width is predictable
+const (
+	pdfPageWidth   = 595
+	pdfPageHeight  = 842
+	pdfMargin      = 50
+	pdfFontSize    = 9
+	pdfLineHeight  = 12
+	pdfLinesOnPage = (pdfPageHeight - 2*pdfMargin) / pdfLineHeight
+	// Courier glyphs are 600/1000 em wide
+	pdfCharsOnLine = (pdfPageWidth - 2*pdfMargin) * 1000 / (600 * pdfFontSize)
+)
+
+// PDFDocument builds a plain text PDF with automatic line wrapping and pagination
+type PDFDocument struct {
+	pages [][]string
+}
+
+// NewPDFDocument creates an empty document
+func NewPDFDocument() *PDFDocument {
+	return &PDFDocument{}
+}
+
+// AddLine appends text, wrapping it to the page width. Embedded newlines start new lines.
+func (d *PDFDocument) AddLine(text string) {
+	for _, line := range strings.Split(text, "\n") {
+		for _, wrapped := range wrapText(line, pdfCharsOnLine) {
+			d.appendLine(wrapped)
+		}
+	}
+}
+
+// AddBlankLine appends an empty line
+func (d *PDFDocument) AddBlankLine() {
+	d.appendLine("")
+}
+
+func (d *PDFDocument) appendLine(line string) {
+	if len(d.pages) == 0 || len(d.pages[len(d.pages)-1]) >= pdfLinesOnPage {
+		d.pages = append(d.pages, nil)
+	}
+	d.pages[len(d.pages)-1] = append(d.pages[len(d.pages)-1], line)
+}
+
+// Bytes renders the document
+func (d *PDFDocument) Bytes() []byte {
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = [][]string{{""}}
+	}
+
+	// Objects: 1 catalog, 2 page tree, 3 font, then a page and a content stream per page
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+	)
+
+	for i, lines := range pages {
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT /F1 %d Tf %d TL %d %d Td\n", pdfFontSize, pdfLineHeight, pdfMargin, pdfPageHeight-pdfMargin)
+		for _, line := range lines {
+			fmt.Fprintf(&content, "(%s) '\n", escapePDFText(line))
+		}
+		content.WriteString("ET")
+
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, 5+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xrefOffset := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+
+	return out.Bytes()
+}
+
+// wrapText splits a line on word boundaries so no piece exceeds maxChars
+func wrapText(line string, maxChars int) []string {
+	runes := []rune(line)
+	if len(runes) <= maxChars {
+		return []string{line}
+	}
+
+	var wrapped []string
+	for len(runes) > maxChars {
+		cut := maxChars
+		for i := maxChars; i > maxChars/2; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		wrapped = append(wrapped, string(runes[:cut]))
+		runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+	}
+	return append(wrapped, string(runes))
+}
+
+// escapePDFText converts text to WinAnsi bytes and escapes PDF string delimiters.
+// Characters outside Latin-1 are replaced with '?'.
+func escapePDFText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\t':
+			b.WriteString("    ")
+		case r < 0x20:
+			// Skip control characters
+		case r < 0x80:
+			b.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,119 @@
+// internal/version/version.go
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// Build details, injected at build time:
+//
+//	go build -ldflags "-X room-reservation-api/internal/version.Version=1.4.0 \
+//	  -X room-reservation-api/internal/version.GitSHA=$(git rev-parse HEAD) \
+//	  -X room-reservation-api/internal/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = "0.0.0-dev"
+	GitSHA    = ""
+	BuildTime = ""
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	GitSHA    string `json:"git_sha,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	Modified  bool   `json:"modified"` // Built from a tree with uncommitted changes, when known
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build details. Without ldflags the commit embedded by the Go toolchain is used.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		GitSHA:    GitSHA,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.GitSHA == "" {
+				info.GitSHA = setting.Value
+			}
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+	return info
+}
+
+// Compare compares two semantic versions, returning -1, 0 or 1. A leading "v" and build
+// metadata are ignored; a pre-release sorts before its release.
+func Compare(a, b string) (int, error) {
+	coreA, preA, err := parse(a)
+	if err != nil {
+		return 0, err
+	}
+	coreB, preB, err := parse(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range coreA {
+		if coreA[i] != coreB[i] {
+			if coreA[i] < coreB[i] {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+
+	switch {
+	case preA == preB:
+		return 0, nil
+	case preA == "":
+		return 1, nil
+	case preB == "":
+		return -1, nil
+	case preA < preB:
+		return -1, nil
+	default:
+		return 1, nil
+	}
+}
+
+// Valid checks that the value is a semantic version
+func Valid(value string) bool {
+	_, _, err := parse(value)
+	return err == nil
+}
+
+// parse splits a version into major, minor and patch and its pre-release; minor and patch may be omitted
+func parse(value string) ([3]int, string, error) {
+	var core [3]int
+
+	trimmed := strings.TrimPrefix(strings.TrimSpace(value), "v")
+	trimmed, _, _ = strings.Cut(trimmed, "+")
+	trimmed, prerelease, _ := strings.Cut(trimmed, "-")
+
+	parts := strings.Split(trimmed, ".")
+	if len(parts) > 3 {
+		return core, "", fmt.Errorf("invalid version %q", value)
+	}
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return core, "", fmt.Errorf("invalid version %q", value)
+		}
+		core[i] = number
+	}
+	return core, prerelease, nil
+}
@@ -254,6 +254,15 @@ func (c *Client) Close() {
 	log.Printf("Client %s (user %s) disconnected", c.id, c.userID)
 }
 
+// closeGoingAway sends a close frame telling the client the server is going away. Control
+// frames may be written alongside the write pump.
+func (c *Client) closeGoingAway(reason string) {
+	message := websocket.FormatCloseMessage(websocket.CloseGoingAway, reason)
+	if err := c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(WriteTimeout)); err != nil {
+		log.Printf("Failed to send close frame to client %s: %v", c.id, err)
+	}
+}
+
 // readPump pumps messages from the WebSocket connection
 func (c *Client) readPump() {
 	defer func() {
@@ -388,6 +397,8 @@ func (c *Client) handleMessage(message WSMessage) {
 		c.handleHeartbeat(message)
 	case MessageTypeChat:
 		c.handleChat(message)
+	case MessageTypeDelivered:
+		c.handleDelivered(message)
 	default:
 		c.sendError("Unknown message type", http.StatusBadRequest)
 	}
@@ -449,6 +460,17 @@ func (c *Client) handleHeartbeat(message WSMessage) {
 	c.SendMessage(response)
 }
 
+// handleDelivered handles delivery acks for received messages
+func (c *Client) handleDelivered(message WSMessage) {
+	var deliveredMsg WSDeliveredMessage
+	if err := mapToStruct(message.Data, &deliveredMsg); err != nil || len(deliveredMsg.MessageIDs) == 0 {
+		c.sendError("Invalid delivered message", http.StatusBadRequest)
+		return
+	}
+
+	c.hub.handleDelivered(c, message, deliveredMsg.MessageIDs)
+}
+
 // handleChat handles chat messages
 func (c *Client) handleChat(message WSMessage) {
 	// Chat messages are handled by the hub and forwarded to the service layer
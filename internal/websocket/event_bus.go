@@ -0,0 +1,147 @@
+package websocket
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultEventBusCapacity is the number of recent events kept for long-poll clients
+const DefaultEventBusCapacity = 1000
+
+// Event topics used to filter streams
+const (
+	TopicReservations  = "reservations"
+	TopicChat          = "chat"
+	TopicNotifications = "notifications"
+)
+
+// BusEvent is a realtime event shared by WebSocket clients and long-poll requests
+type BusEvent struct {
+	Sequence       uint64      `json:"sequence"`
+	Event          string      `json:"event"`
+	ConversationID *uuid.UUID  `json:"conversation_id,omitempty"`
+	Data           interface{} `json:"data"`
+	Timestamp      time.Time   `json:"timestamp"`
+
+	// Audience: explicit recipients, or the participants of ConversationID
+	UserIDs       []uuid.UUID `json:"-"`
+	ExcludeUserID *uuid.UUID  `json:"-"`
+}
+
+// IsAddressedTo reports whether the event targets the user directly.
+// Conversation events also need a participant check by the caller.
+func (e BusEvent) IsAddressedTo(userID uuid.UUID) bool {
+	if e.ExcludeUserID != nil && *e.ExcludeUserID == userID {
+		return false
+	}
+	for _, id := range e.UserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Topic groups the event by the feature that produced it
+func (e BusEvent) Topic() string {
+	switch {
+	case strings.HasPrefix(e.Event, "reservation_"):
+		return TopicReservations
+	case strings.HasPrefix(e.Event, "notification_"):
+		return TopicNotifications
+	default:
+		return TopicChat
+	}
+}
+
+// EventBus keeps an in-memory ring of recent events with increasing sequence numbers.
+// Publishers never block; readers catch up with Since and wait on Changed.
+type EventBus struct {
+	mu       sync.RWMutex
+	events   []BusEvent
+	capacity int
+	sequence uint64
+	changed  chan struct{}
+}
+
+// NewEventBus creates an event bus retaining up to capacity events
+func NewEventBus(capacity int) *EventBus {
+	if capacity <= 0 {
+		capacity = DefaultEventBusCapacity
+	}
+
+	return &EventBus{
+		events:   make([]BusEvent, 0, capacity),
+		capacity: capacity,
+		changed:  make(chan struct{}),
+	}
+}
+
+// Publish assigns the next sequence number to the event and wakes up waiting readers
+func (b *EventBus) Publish(event BusEvent) BusEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sequence++
+	event.Sequence = b.sequence
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	if len(b.events) == b.capacity {
+		copy(b.events, b.events[1:])
+		b.events = b.events[:len(b.events)-1]
+	}
+	b.events = append(b.events, event)
+
+	close(b.changed)
+	b.changed = make(chan struct{})
+
+	return event
+}
+
+// Since returns events published after the given sequence number, and the sequence number of
+// the last published event read under the same lock, to resync from without skipping events.
+// complete is false when older events were already evicted, or the sequence is unknown (e.g.
+// after a restart).
+func (b *EventBus) Since(sequence uint64) (events []BusEvent, latest uint64, complete bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if sequence > b.sequence {
+		return nil, b.sequence, false
+	}
+	if len(b.events) == 0 || sequence >= b.sequence {
+		return nil, b.sequence, true
+	}
+
+	oldest := b.events[0].Sequence
+	complete = sequence+1 >= oldest
+
+	start := 0
+	if sequence >= oldest {
+		start = int(sequence - oldest + 1)
+	}
+
+	events = make([]BusEvent, len(b.events)-start)
+	copy(events, b.events[start:])
+	return events, b.sequence, complete
+}
+
+// Latest returns the sequence number of the last published event
+func (b *EventBus) Latest() uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.sequence
+}
+
+// Changed returns a channel that is closed on the next publish.
+// Grab it before calling Since to avoid missing events published in between.
+func (b *EventBus) Changed() <-chan struct{} {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.changed
+}
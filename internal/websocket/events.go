@@ -38,9 +38,22 @@ type MessageEventData struct {
 	ReplyToID      *uuid.UUID             `json:"reply_to_id,omitempty"`
 	EditedAt       *time.Time             `json:"edited_at,omitempty"`
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	Encrypted      bool                   `json:"encrypted,omitempty"`
+	Ciphertext     string                 `json:"ciphertext,omitempty"`
+	SenderDeviceID string                 `json:"sender_device_id,omitempty"`
 	CreatedAt      time.Time              `json:"created_at"`
 }
 
+// ThreadEventData represents reply activity on a message thread
+type ThreadEventData struct {
+	ConversationID  uuid.UUID `json:"conversation_id"`
+	ParentMessageID uuid.UUID `json:"parent_message_id"`
+	ReplyID         uuid.UUID `json:"reply_id"`
+	ReplyCount      int       `json:"reply_count"`
+	RepliedBy       uuid.UUID `json:"replied_by"`
+	LastReplyAt     time.Time `json:"last_reply_at"`
+}
+
 // ConversationEventData represents conversation-related event data
 type ConversationEventData struct {
 	ConversationID uuid.UUID              `json:"conversation_id"`
@@ -94,6 +107,36 @@ type ReadReceiptEventData struct {
 	ReadAt         time.Time   `json:"read_at"`
 }
 
+// DeliveryEventData represents messages reaching a recipient's client
+type DeliveryEventData struct {
+	ConversationID uuid.UUID   `json:"conversation_id"`
+	UserID         uuid.UUID   `json:"user_id"`
+	MessageIDs     []uuid.UUID `json:"message_ids"`
+	DeliveredAt    time.Time   `json:"delivered_at"`
+}
+
+// ReservationEventData represents reservation changes sent to the reservation owner
+type ReservationEventData struct {
+	ReservationID uuid.UUID  `json:"reservation_id"`
+	SpaceID       uuid.UUID  `json:"space_id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	Title         string     `json:"title"`
+	Status        string     `json:"status"`
+	StartTime     time.Time  `json:"start_time"`
+	EndTime       time.Time  `json:"end_time"`
+	CheckInTime   *time.Time `json:"check_in_time,omitempty"`
+}
+
+// NotificationEventData represents a new in-app notification
+type NotificationEventData struct {
+	NotificationID uuid.UUID `json:"notification_id"`
+	Type           string    `json:"type"`
+	Title          string    `json:"title"`
+	Message        string    `json:"message"`
+	DeepLink       string    `json:"deep_link,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
 // SystemEventData represents system-level event data
 type SystemEventData struct {
 	EventType   string                 `json:"event_type"`
@@ -199,6 +242,32 @@ func NewMessageReadEvent(readData ReadReceiptEventData) WSEvent {
 	}
 }
 
+// NewMessageDeliveredEvent creates a message delivered event
+func NewMessageDeliveredEvent(deliveryData DeliveryEventData) WSEvent {
+	return WSEvent{
+		ID:             generateEventID(),
+		Type:           MessageTypeEvent,
+		Event:          WSEventMessageDelivered,
+		ConversationID: &deliveryData.ConversationID,
+		UserID:         &deliveryData.UserID,
+		Data:           deliveryData,
+		Timestamp:      time.Now(),
+	}
+}
+
+// NewThreadUpdatedEvent creates a thread activity event
+func NewThreadUpdatedEvent(threadData ThreadEventData) WSEvent {
+	return WSEvent{
+		ID:             generateEventID(),
+		Type:           MessageTypeEvent,
+		Event:          WSEventThreadUpdated,
+		ConversationID: &threadData.ConversationID,
+		UserID:         &threadData.RepliedBy,
+		Data:           threadData,
+		Timestamp:      time.Now(),
+	}
+}
+
 // NewConversationCreatedEvent creates a conversation created event
 func NewConversationCreatedEvent(conversationData ConversationEventData) WSEvent {
 	return WSEvent{
@@ -240,6 +309,30 @@ func NewConversationArchivedEvent(conversationID uuid.UUID, archivedBy uuid.UUID
 	}
 }
 
+// NewReservationEvent creates a reservation created, updated or cancelled event
+func NewReservationEvent(eventType string, reservationData ReservationEventData) WSEvent {
+	return WSEvent{
+		ID:        generateEventID(),
+		Type:      MessageTypeEvent,
+		Event:     eventType,
+		UserID:    &reservationData.UserID,
+		Data:      reservationData,
+		Timestamp: time.Now(),
+	}
+}
+
+// NewNotificationCreatedEvent creates a notification created event
+func NewNotificationCreatedEvent(userID uuid.UUID, notificationData NotificationEventData) WSEvent {
+	return WSEvent{
+		ID:        generateEventID(),
+		Type:      MessageTypeEvent,
+		Event:     WSEventNotificationCreated,
+		UserID:    &userID,
+		Data:      notificationData,
+		Timestamp: time.Now(),
+	}
+}
+
 // NewUserTypingEvent creates a user typing event
 func NewUserTypingEvent(userID, conversationID uuid.UUID, isTyping bool) WSEvent {
 	return WSEvent{
@@ -366,7 +459,7 @@ func ValidateEvent(event WSEvent) error {
 
 	// Validate event-specific requirements
 	switch event.Event {
-	case WSEventMessageSent, WSEventMessageUpdated, WSEventMessageDeleted, WSEventMessageRead:
+	case WSEventMessageSent, WSEventMessageUpdated, WSEventMessageDeleted, WSEventMessageRead, WSEventMessageDelivered:
 		if event.ConversationID == nil {
 			return fmt.Errorf("conversation_id is required for message events")
 		}
@@ -563,6 +656,8 @@ var (
 		WSEventMessageUpdated,
 		WSEventMessageDeleted,
 		WSEventMessageRead,
+		WSEventMessageDelivered,
+		WSEventThreadUpdated,
 	}
 
 	// Conversation events
@@ -41,6 +41,9 @@ type Hub struct {
 	// Permission checker
 	permissionChecker PermissionChecker
 
+	// Delivery handler, set once the chat service exists
+	deliveryHandler DeliveryHandler
+
 	// Context for cancellation
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -75,6 +78,11 @@ type MessageQueue interface {
 	ClearQueuedMessages(userID uuid.UUID) error
 }
 
+// DeliveryHandler interface for recording that a user's client received messages
+type DeliveryHandler interface {
+	MarkDelivered(userID uuid.UUID, messageIDs []uuid.UUID) error
+}
+
 // NewHub creates a new WebSocket hub
 func NewHub(authHandler AuthHandler, permissionChecker PermissionChecker, messageQueue MessageQueue) *Hub {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -142,6 +150,63 @@ func (h *Hub) Stop() {
 	h.clientsMutex.Unlock()
 }
 
+// Shutdown tells every client the server is going away, then stops the hub. Clients get a
+// server_shutdown event and a going-away close frame, so they reconnect to another instance
+// instead of treating the drop as an error.
+func (h *Hub) Shutdown(ctx context.Context) {
+	h.clientsMutex.RLock()
+	clients := make([]*Client, 0)
+	for _, userClients := range h.clients {
+		for _, client := range userClients {
+			clients = append(clients, client)
+		}
+	}
+	h.clientsMutex.RUnlock()
+
+	notice := WSMessage{
+		ID:        uuid.New().String(),
+		Type:      MessageTypeEvent,
+		Event:     WSEventServerShutdown,
+		Timestamp: time.Now(),
+	}
+	for _, client := range clients {
+		if err := client.SendMessage(notice); err != nil {
+			log.Printf("Failed to send shutdown notice to client %s: %v", client.GetID(), err)
+		}
+	}
+
+	// Let write pumps flush the notice before the connections close
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for pending := len(clients); pending > 0; {
+		pending = 0
+		for _, client := range clients {
+			if len(client.send) > 0 {
+				pending++
+			}
+		}
+		if pending == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			pending = 0
+		case <-ticker.C:
+		}
+	}
+
+	for _, client := range clients {
+		client.closeGoingAway("server shutting down")
+	}
+
+	h.Stop()
+}
+
+// SetDeliveryHandler sets the handler for client delivery acks
+func (h *Hub) SetDeliveryHandler(handler DeliveryHandler) {
+	h.deliveryHandler = handler
+}
+
 // RegisterClient registers a new client
 func (h *Hub) RegisterClient(client *Client) {
 	h.register <- client
@@ -726,6 +791,22 @@ func (h *Hub) handleTyping(client *Client, conversationID uuid.UUID, isTyping bo
 	}, &userID)
 }
 
+// handleDelivered records that the client received messages
+func (h *Hub) handleDelivered(client *Client, message WSMessage, messageIDs []uuid.UUID) {
+	if h.deliveryHandler == nil {
+		client.sendAck(message.ID, true)
+		return
+	}
+
+	if err := h.deliveryHandler.MarkDelivered(client.GetUserID(), messageIDs); err != nil {
+		log.Printf("Failed to record delivery for user %s: %v", client.GetUserID(), err)
+		client.sendAck(message.ID, false)
+		return
+	}
+
+	client.sendAck(message.ID, true)
+}
+
 // handleChatMessage handles chat messages (forwards to service layer)
 func (h *Hub) handleChatMessage(client *Client, message WSMessage) {
 	// This will be implemented when integrating with the service layer
@@ -29,6 +29,9 @@ type Manager struct {
 	permissionChecker PermissionChecker
 	messageQueue      MessageQueue
 
+	// Shared with long-poll requests; every broadcast goes through it
+	eventBus *EventBus
+
 	// Event handlers for business logic integration
 	businessHandlers map[string]BusinessEventHandler
 	handlersMutex    sync.RWMutex
@@ -157,10 +160,14 @@ func NewManager(
 	permissionChecker PermissionChecker,
 	messageQueue MessageQueue,
 	config *Config,
+	eventBus *EventBus,
 ) *Manager {
 	if config == nil {
 		config = DefaultConfig()
 	}
+	if eventBus == nil {
+		eventBus = NewEventBus(DefaultEventBusCapacity)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -207,6 +214,7 @@ func NewManager(
 		authHandler:       authHandler,
 		permissionChecker: permissionChecker,
 		messageQueue:      messageQueue,
+		eventBus:          eventBus,
 		businessHandlers:  make(map[string]BusinessEventHandler),
 		metrics:           metrics,
 		ctx:               ctx,
@@ -240,6 +248,9 @@ func (m *Manager) Start() error {
 	// Start cleanup routine
 	go m.cleanup()
 
+	// Deliver bus events to connected clients
+	go m.forwardBusEvents(m.eventBus.Latest())
+
 	m.running = true
 	log.Println("WebSocket manager started successfully")
 
@@ -269,6 +280,27 @@ func (m *Manager) Stop() error {
 	return nil
 }
 
+// Shutdown notifies connected clients that the server is going away, closes their
+// connections and stops the manager. A manager that is not running has nothing to close.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.runningMutex.Lock()
+	defer m.runningMutex.Unlock()
+
+	if !m.running {
+		return nil
+	}
+
+	log.Println("Shutting down WebSocket manager...")
+
+	m.hub.Shutdown(ctx)
+	m.cancel()
+
+	m.running = false
+	log.Println("WebSocket manager shut down successfully")
+
+	return ctx.Err()
+}
+
 // IsRunning returns whether the manager is running
 func (m *Manager) IsRunning() bool {
 	m.runningMutex.RLock()
@@ -325,6 +357,12 @@ func (m *Manager) RegisterBusinessHandler(eventType string, handler BusinessEven
 	log.Printf("Registered business handler for event type: %s", eventType)
 }
 
+// SetDeliveryHandler sets the handler that records delivery acks from clients.
+// The chat service is built after the manager, so it is attached here rather than in NewManager.
+func (m *Manager) SetDeliveryHandler(handler DeliveryHandler) {
+	m.hub.SetDeliveryHandler(handler)
+}
+
 // UnregisterBusinessHandler unregisters a business event handler
 func (m *Manager) UnregisterBusinessHandler(eventType string) {
 	m.handlersMutex.Lock()
@@ -339,62 +377,130 @@ func (m *Manager) UnregisterBusinessHandler(eventType string) {
 // BroadcastMessageSent broadcasts a message sent event
 func (m *Manager) BroadcastMessageSent(conversationID uuid.UUID, messageData MessageEventData, excludeUserID *uuid.UUID) {
 	event := NewMessageSentEvent(messageData)
-	m.hub.BroadcastToConversation(conversationID, event.Event, event.Data, excludeUserID)
-	m.updateEventMetrics(event.Event)
+	m.publishToConversation(conversationID, event, excludeUserID)
 }
 
 // BroadcastMessageUpdated broadcasts a message updated event
 func (m *Manager) BroadcastMessageUpdated(conversationID uuid.UUID, messageData MessageEventData, excludeUserID *uuid.UUID) {
 	event := NewMessageUpdatedEvent(messageData)
-	m.hub.BroadcastToConversation(conversationID, event.Event, event.Data, excludeUserID)
-	m.updateEventMetrics(event.Event)
+	m.publishToConversation(conversationID, event, excludeUserID)
 }
 
 // BroadcastMessageDeleted broadcasts a message deleted event
 func (m *Manager) BroadcastMessageDeleted(conversationID, messageID, userID uuid.UUID) {
 	event := NewMessageDeletedEvent(conversationID, messageID, userID)
-	m.hub.BroadcastToConversation(conversationID, event.Event, event.Data, &userID)
-	m.updateEventMetrics(event.Event)
+	m.publishToConversation(conversationID, event, &userID)
 }
 
 // BroadcastMessageRead broadcasts message read receipts
 func (m *Manager) BroadcastMessageRead(conversationID uuid.UUID, readData ReadReceiptEventData, excludeUserID *uuid.UUID) {
 	event := NewMessageReadEvent(readData)
-	m.hub.BroadcastToConversation(conversationID, event.Event, event.Data, excludeUserID)
-	m.updateEventMetrics(event.Event)
+	m.publishToConversation(conversationID, event, excludeUserID)
+}
+
+// BroadcastMessageDelivered broadcasts that messages reached a recipient
+func (m *Manager) BroadcastMessageDelivered(conversationID uuid.UUID, deliveryData DeliveryEventData, excludeUserID *uuid.UUID) {
+	event := NewMessageDeliveredEvent(deliveryData)
+	m.publishToConversation(conversationID, event, excludeUserID)
+}
+
+// BroadcastThreadUpdated broadcasts reply count changes on a thread
+func (m *Manager) BroadcastThreadUpdated(conversationID uuid.UUID, threadData ThreadEventData) {
+	event := NewThreadUpdatedEvent(threadData)
+	m.publishToConversation(conversationID, event, nil)
 }
 
 // BroadcastConversationCreated broadcasts a conversation created event
 func (m *Manager) BroadcastConversationCreated(conversationData ConversationEventData) {
 	event := NewConversationCreatedEvent(conversationData)
 	// Broadcast to all participants
-	for _, participant := range conversationData.Participants {
-		m.hub.BroadcastToUser(participant.UserID, event.Event, event.Data)
+	userIDs := make([]uuid.UUID, len(conversationData.Participants))
+	for i, participant := range conversationData.Participants {
+		userIDs[i] = participant.UserID
 	}
-	m.updateEventMetrics(event.Event)
+	m.publishToUsers(userIDs, event)
 }
 
 // BroadcastConversationUpdated broadcasts a conversation updated event
 func (m *Manager) BroadcastConversationUpdated(conversationID uuid.UUID, conversationData ConversationEventData, excludeUserID *uuid.UUID) {
 	event := NewConversationUpdatedEvent(conversationData)
-	m.hub.BroadcastToConversation(conversationID, event.Event, event.Data, excludeUserID)
-	m.updateEventMetrics(event.Event)
+	m.publishToConversation(conversationID, event, excludeUserID)
 }
 
 // BroadcastConversationArchived broadcasts a conversation archived event
 func (m *Manager) BroadcastConversationArchived(conversationID uuid.UUID, archivedBy uuid.UUID) {
 	event := NewConversationArchivedEvent(conversationID, archivedBy)
-	m.hub.BroadcastToConversation(conversationID, event.Event, event.Data, &archivedBy)
-	m.updateEventMetrics(event.Event)
+	m.publishToConversation(conversationID, event, &archivedBy)
 }
 
 // BroadcastUserTyping broadcasts typing indicator updates
 func (m *Manager) BroadcastUserTyping(conversationID, userID uuid.UUID, isTyping bool) {
 	event := NewUserTypingEvent(userID, conversationID, isTyping)
-	m.hub.BroadcastToConversation(conversationID, event.Event, event.Data, &userID)
+	m.publishToConversation(conversationID, event, &userID)
+}
+
+// BroadcastToUser sends an event to every connection of a user
+func (m *Manager) BroadcastToUser(userID uuid.UUID, event WSEvent) {
+	m.publishToUsers([]uuid.UUID{userID}, event)
+}
+
+// EventBus returns the bus shared with long-poll requests
+func (m *Manager) EventBus() *EventBus {
+	return m.eventBus
+}
+
+// publishToConversation records a conversation event on the bus
+func (m *Manager) publishToConversation(conversationID uuid.UUID, event WSEvent, excludeUserID *uuid.UUID) {
+	m.eventBus.Publish(BusEvent{
+		Event:          event.Event,
+		ConversationID: &conversationID,
+		Data:           event.Data,
+		Timestamp:      event.Timestamp,
+		ExcludeUserID:  excludeUserID,
+	})
+	m.updateEventMetrics(event.Event)
+}
+
+// publishToUsers records an event addressed to specific users on the bus
+func (m *Manager) publishToUsers(userIDs []uuid.UUID, event WSEvent) {
+	m.eventBus.Publish(BusEvent{
+		Event:     event.Event,
+		Data:      event.Data,
+		Timestamp: event.Timestamp,
+		UserIDs:   userIDs,
+	})
 	m.updateEventMetrics(event.Event)
 }
 
+// forwardBusEvents pushes bus events to connected clients until the manager stops
+func (m *Manager) forwardBusEvents(since uint64) {
+	for {
+		changed := m.eventBus.Changed()
+		events, latest, complete := m.eventBus.Since(since)
+
+		for _, event := range events {
+			if event.ConversationID != nil {
+				m.hub.BroadcastToConversation(*event.ConversationID, event.Event, event.Data, event.ExcludeUserID)
+			}
+			for _, userID := range event.UserIDs {
+				m.hub.BroadcastToUser(userID, event.Event, event.Data)
+			}
+			since = event.Sequence
+		}
+
+		// A cursor ahead of the bus (e.g. after a restart) resyncs to where the bus is
+		if !complete && len(events) == 0 {
+			since = latest
+		}
+
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-changed:
+		}
+	}
+}
+
 // Query methods
 
 // GetOnlineUsers returns online users in a conversation
@@ -9,10 +9,12 @@ import (
 // WebSocket event types
 const (
 	// Message events
-	WSEventMessageSent    = "message_sent"
-	WSEventMessageUpdated = "message_updated"
-	WSEventMessageDeleted = "message_deleted"
-	WSEventMessageRead    = "message_read"
+	WSEventMessageSent      = "message_sent"
+	WSEventMessageUpdated   = "message_updated"
+	WSEventMessageDeleted   = "message_deleted"
+	WSEventMessageRead      = "message_read"
+	WSEventMessageDelivered = "message_delivered"
+	WSEventThreadUpdated    = "thread_updated"
 
 	// Conversation events
 	WSEventConversationCreated  = "conversation_created"
@@ -26,10 +28,21 @@ const (
 	WSEventUserJoined  = "user_joined"
 	WSEventUserLeft    = "user_left"
 
+	// Reservation events
+	WSEventReservationCreated   = "reservation_created"
+	WSEventReservationUpdated   = "reservation_updated"
+	WSEventReservationCancelled = "reservation_cancelled"
+
+	// Notification events
+	WSEventNotificationCreated = "notification_created"
+
 	// System events
 	WSEventError     = "error"
 	WSEventHeartbeat = "heartbeat"
 	WSEventAck       = "ack"
+
+	// Sent before the server closes connections on shutdown; clients should reconnect
+	WSEventServerShutdown = "server_shutdown"
 )
 
 // WebSocket message types
@@ -41,6 +54,9 @@ const (
 	MessageTypeLeave     = "leave"
 	MessageTypeTyping    = "typing"
 
+	// Sent by clients once they have received messages, to advance them to delivered
+	MessageTypeDelivered = "delivered"
+
 	// Data messages
 	MessageTypeChat  = "chat"
 	MessageTypeEvent = "event"
@@ -117,6 +133,11 @@ type WSTypingMessage struct {
 	IsTyping       bool      `json:"is_typing"`
 }
 
+// WSDeliveredMessage acknowledges receipt of chat messages
+type WSDeliveredMessage struct {
+	MessageIDs []uuid.UUID `json:"message_ids"`
+}
+
 // WSHeartbeatMessage represents heartbeat message
 type WSHeartbeatMessage struct {
 	Timestamp time.Time `json:"timestamp"`